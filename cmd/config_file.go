@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/spf13/pflag"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileKeySuffix is the suffix of a config file key whose value is the
+// path to a file from which to read the value of a flag.
+const ConfigFileKeySuffix = "_file"
+
+// SetFlagsFromConfigFile sets flag values from a config file, in YAML or, if
+// its extension is .hcl, HCL. Each key is the name of a flag, e.g. site-token,
+// or the name of a flag suffixed with _file, e.g. site-token_file, in which
+// case the value is the path to a file from which to read the flag's value.
+// Relative paths are relative to the directory containing the config file.
+//
+// Flags that have already been set, either on the command line or from
+// environment variables, are left unchanged. Every unknown key and invalid
+// value is reported.
+func SetFlagsFromConfigFile(fs *pflag.FlagSet, path string) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	var values map[string]any
+	if filepath.Ext(path) == ".hcl" {
+		values, err = parseHCLConfigFile(src, path)
+	} else {
+		values, err = parseYAMLConfigFile(src)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if err := setFlagFromConfigFile(fs, filepath.Dir(path), values, key); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s: %w", path, key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func setFlagFromConfigFile(fs *pflag.FlagSet, dir string, values map[string]any, key string) error {
+	value := values[key]
+	f := fs.Lookup(key)
+	if f == nil {
+		name, ok := strings.CutSuffix(key, ConfigFileKeySuffix)
+		if !ok {
+			return errors.New("unknown key")
+		}
+		if f = fs.Lookup(name); f == nil {
+			return errors.New("unknown key")
+		}
+		if _, ok := values[name]; ok {
+			return fmt.Errorf("cannot be set alongside %s", name)
+		}
+		path, ok := value.(string)
+		if !ok {
+			return errors.New("must be the path to a file")
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		value = string(contents)
+	}
+	if f.Changed {
+		// flags and env vars take precedence
+		return nil
+	}
+	s, err := configFileValueToFlag(value)
+	if err != nil {
+		return err
+	}
+	return fs.Set(f.Name, s)
+}
+
+// configFileValueToFlag converts a config file value into the string form
+// expected by a flag: lists become comma-separated values, and maps,
+// comma-separated key=value pairs.
+func configFileValueToFlag(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", errors.New("missing value")
+	case []any:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			s, err := configFileScalarToFlag(elem)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = s
+		}
+		return strings.Join(elems, ","), nil
+	case map[string]any:
+		pairs := make([]string, 0, len(v))
+		for k, elem := range v {
+			s, err := configFileScalarToFlag(elem)
+			if err != nil {
+				return "", err
+			}
+			pairs = append(pairs, k+"="+s)
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ","), nil
+	default:
+		return configFileScalarToFlag(value)
+	}
+}
+
+func configFileScalarToFlag(value any) (string, error) {
+	switch value.(type) {
+	case nil:
+		return "", errors.New("missing value")
+	case []any, map[string]any:
+		return "", errors.New("nested lists and maps are not supported")
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+func parseYAMLConfigFile(src []byte) (map[string]any, error) {
+	values := make(map[string]any)
+	if err := yaml.Unmarshal(src, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func parseHCLConfigFile(src []byte, filename string) (map[string]any, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	values := make(map[string]any, len(attrs))
+	for name, attr := range attrs {
+		v, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		values[name] = ctyToConfigFileValue(v)
+	}
+	return values, nil
+}
+
+func ctyToConfigFileValue(v cty.Value) any {
+	if v.IsNull() {
+		return nil
+	}
+	ty := v.Type()
+	switch {
+	case ty == cty.String:
+		return v.AsString()
+	case ty == cty.Number:
+		return v.AsBigFloat().Text('f', -1)
+	case ty == cty.Bool:
+		return v.True()
+	case ty.IsListType() || ty.IsTupleType() || ty.IsSetType():
+		var elems []any
+		for it := v.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			elems = append(elems, ctyToConfigFileValue(elem))
+		}
+		return elems
+	case ty.IsMapType() || ty.IsObjectType():
+		elems := make(map[string]any)
+		for it := v.ElementIterator(); it.Next(); {
+			k, elem := it.Element()
+			elems[k.AsString()] = ctyToConfigFileValue(elem)
+		}
+		return elems
+	default:
+		return v.GoString()
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetFlagsFromConfigFile(t *testing.T) {
+	writeConfig := func(t *testing.T, filename, contents string) string {
+		path := filepath.Join(t.TempDir(), filename)
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+		return path
+	}
+	newFlagSet := func() *pflag.FlagSet {
+		fs := pflag.NewFlagSet("testing", pflag.ContinueOnError)
+		fs.String("foo", "default", "")
+		fs.Int("count", 0, "")
+		fs.Duration("timeout", 0, "")
+		fs.StringSlice("names", nil, "")
+		fs.StringToString("labels", nil, "")
+		return fs
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		fs := newFlagSet()
+		path := writeConfig(t, "config.yaml", `
+foo: bar
+count: 3
+timeout: 5m
+names: [alice, bob]
+labels:
+  team: platform
+  env: prod
+`)
+		require.NoError(t, SetFlagsFromConfigFile(fs, path))
+
+		foo, _ := fs.GetString("foo")
+		assert.Equal(t, "bar", foo)
+		count, _ := fs.GetInt("count")
+		assert.Equal(t, 3, count)
+		timeout, _ := fs.GetDuration("timeout")
+		assert.Equal(t, 5*time.Minute, timeout)
+		names, _ := fs.GetStringSlice("names")
+		assert.Equal(t, []string{"alice", "bob"}, names)
+		labels, _ := fs.GetStringToString("labels")
+		assert.Equal(t, map[string]string{"team": "platform", "env": "prod"}, labels)
+	})
+	t.Run("hcl", func(t *testing.T) {
+		fs := newFlagSet()
+		path := writeConfig(t, "config.hcl", `
+foo   = "bar"
+count = 3
+names = ["alice", "bob"]
+`)
+		require.NoError(t, SetFlagsFromConfigFile(fs, path))
+
+		foo, _ := fs.GetString("foo")
+		assert.Equal(t, "bar", foo)
+		count, _ := fs.GetInt("count")
+		assert.Equal(t, 3, count)
+		names, _ := fs.GetStringSlice("names")
+		assert.Equal(t, []string{"alice", "bob"}, names)
+	})
+	t.Run("read value from file", func(t *testing.T) {
+		fs := newFlagSet()
+		testdata, err := filepath.Abs("./testdata/tofutf_foo_file")
+		require.NoError(t, err)
+		path := writeConfig(t, "config.yaml", "foo_file: "+testdata+"\n")
+		require.NoError(t, SetFlagsFromConfigFile(fs, path))
+
+		foo, _ := fs.GetString("foo")
+		assert.Equal(t, "big\nmultiline\nsecret\n", foo)
+	})
+	t.Run("read value from file relative to config file", func(t *testing.T) {
+		fs := newFlagSet()
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "foo"), []byte("secret"), 0o600))
+		path := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("foo_file: foo\n"), 0o600))
+		require.NoError(t, SetFlagsFromConfigFile(fs, path))
+
+		foo, _ := fs.GetString("foo")
+		assert.Equal(t, "secret", foo)
+	})
+	t.Run("flags take precedence", func(t *testing.T) {
+		fs := newFlagSet()
+		require.NoError(t, fs.Parse([]string{"--foo", "from-flag"}))
+		path := writeConfig(t, "config.yaml", "foo: from-file\ncount: 3\n")
+		require.NoError(t, SetFlagsFromConfigFile(fs, path))
+
+		foo, _ := fs.GetString("foo")
+		assert.Equal(t, "from-flag", foo)
+		count, _ := fs.GetInt("count")
+		assert.Equal(t, 3, count)
+	})
+	t.Run("env vars take precedence", func(t *testing.T) {
+		fs := newFlagSet()
+		t.Setenv("OTF_FOO", "from-env")
+		require.NoError(t, SetFlagsFromEnvVariables(fs))
+		path := writeConfig(t, "config.yaml", "foo: from-file\n")
+		require.NoError(t, SetFlagsFromConfigFile(fs, path))
+
+		foo, _ := fs.GetString("foo")
+		assert.Equal(t, "from-env", foo)
+	})
+	t.Run("report every problem", func(t *testing.T) {
+		fs := newFlagSet()
+		path := writeConfig(t, "config.yaml", `
+bogus: 1
+count: not-a-number
+foo: bar
+foo_file: /does-not-matter
+names: [[nested]]
+`)
+		err := SetFlagsFromConfigFile(fs, path)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "bogus: unknown key")
+		assert.ErrorContains(t, err, `count: invalid argument "not-a-number"`)
+		assert.ErrorContains(t, err, "foo_file: cannot be set alongside foo")
+		assert.ErrorContains(t, err, "names: nested lists and maps are not supported")
+	})
+	t.Run("non-existent value file", func(t *testing.T) {
+		fs := newFlagSet()
+		path := writeConfig(t, "config.yaml", "foo_file: ./does-not-exist\n")
+		assert.Error(t, SetFlagsFromConfigFile(fs, path))
+	})
+	t.Run("non-existent config file", func(t *testing.T) {
+		assert.Error(t, SetFlagsFromConfigFile(newFlagSet(), "./does-not-exist.yaml"))
+	})
+}
@@ -2,13 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/signal"
 	"syscall"
 
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	cmdutil "github.com/tofutf/tofutf/cmd"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/agent"
@@ -16,7 +18,14 @@ import (
 	"github.com/tofutf/tofutf/internal/daemon"
 	"github.com/tofutf/tofutf/internal/github"
 	"github.com/tofutf/tofutf/internal/gitlab"
+	"github.com/tofutf/tofutf/internal/lockout"
+	"github.com/tofutf/tofutf/internal/logs"
+	"github.com/tofutf/tofutf/internal/module"
 	"github.com/tofutf/tofutf/internal/otel"
+	"github.com/tofutf/tofutf/internal/repohooks"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/secret/vault"
+	"github.com/tofutf/tofutf/internal/vcs"
 	"github.com/tofutf/tofutf/internal/xslog"
 )
 
@@ -44,7 +53,10 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cfg := daemon.Config{}
 	daemon.ApplyDefaults(&cfg)
 
-	var loggerConfig *xslog.Config
+	var (
+		loggerConfig *xslog.Config
+		configFile   string
+	)
 
 	cmd := &cobra.Command{
 		Use:           "tofutfd",
@@ -54,6 +66,10 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 		SilenceErrors: true,
 		Version:       internal.Version,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := loadConfig(cmd.Flags(), configFile, &cfg); err != nil {
+				return err
+			}
+
 			logger, err := xslog.New(loggerConfig)
 			if err != nil {
 				return err
@@ -84,15 +100,57 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	}
 	cmd.SetOut(out)
 
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate configuration",
+		Long:  "Validate the configuration from flags, environment variables, and the config file, reporting every problem found.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := loadConfig(cmd.Flags(), configFile, &cfg); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "configuration is valid")
+			return nil
+		},
+	}
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Configuration management",
+	}
+	configCmd.AddCommand(validateCmd)
+	cmd.AddCommand(configCmd)
+
+	cmd.Flags().StringVar(&configFile, "config", "", "Path to a YAML or HCL config file. Flags and environment variables take precedence over the file.")
+
 	// TODO: rename --address to --listen
 	cmd.Flags().StringVar(&cfg.Address, "address", defaultAddress, "Listening address")
 	cmd.Flags().StringVar(&cfg.Database, "database", defaultDatabase, "Postgres connection string")
 	cmd.Flags().StringVar(&cfg.Host, "hostname", "", "User-facing hostname for otf")
 	cmd.Flags().StringVar(&cfg.SiteToken, "site-token", "", "API token with site-wide unlimited permissions. Use with care.")
 	cmd.Flags().StringSliceVar(&cfg.SiteAdmins, "site-admins", nil, "Promote a list of users to site admin.")
+	cmd.Flags().IntVar(&cfg.AuthLockoutThreshold, "auth-lockout-threshold", lockout.DefaultThreshold, "Number of consecutive failed authentication attempts after which a client is locked out. 0 disables lockouts.")
+	cmd.Flags().DurationVar(&cfg.AuthLockoutDuration, "auth-lockout-duration", lockout.DefaultDuration, "Length of time for which a client is locked out after too many failed authentication attempts.")
+	cmd.Flags().StringSliceVar(&cfg.TrustedProxies, "trusted-proxies", nil, "List of CIDRs of reverse proxies whose X-Forwarded-For header is trusted to determine a client's IP address.")
 	cmd.Flags().BytesHexVar(&cfg.Secret, "secret", nil, "Hex-encoded 16 byte secret for cryptographic work. Required.")
 	cmd.Flags().Int64Var(&cfg.MaxConfigSize, "max-config-size", cfg.MaxConfigSize, "Maximum permitted configuration size in bytes.")
+	cmd.Flags().IntVar(&cfg.MaxArtifactSize, "max-artifact-size", run.DefaultMaxArtifactSize, "Maximum permitted run artifact size in bytes.")
+	cmd.Flags().IntVar(&cfg.MaxArtifacts, "max-artifacts", run.DefaultMaxArtifacts, "Maximum number of artifacts per run.")
+	cmd.Flags().DurationVar(&cfg.ForceCancelCoolOff, "force-cancel-cool-off", run.DefaultForceCancelCoolOff, "Length of time after a run is canceled before it can be force canceled.")
+	cmd.Flags().BoolVar(&cfg.RejectPausedWorkspaceRuns, "reject-paused-workspace-runs", false, "Reject runs enqueued on a paused workspace rather than queuing them until it is resumed.")
+	cmd.Flags().DurationVar(&cfg.PriorityAging, "priority-aging", run.DefaultPriorityAging, "Length of time after which a normal priority run or job still waiting to be scheduled or allocated is promoted to high priority. 0 disables aging.")
+	cmd.Flags().IntVar(&cfg.AgentJobDispatchers, "agent-job-dispatchers", agent.DefaultJobDispatchers, "Number of dispatchers fanning out job events to agents waiting for jobs.")
+	cmd.Flags().IntVar(&cfg.AgentMaxPools, "agent-max-pools", 0, "Default maximum number of agent pools per organization. Site admins can override it for individual organizations. 0 means no limit.")
+	cmd.Flags().DurationVar(&cfg.AgentPoolRecoveryWindow, "agent-pool-recovery-window", agent.DefaultPoolRecoveryWindow, "Length of time for which a deleted agent pool can be recovered before it is permanently deleted. 0 deletes pools immediately.")
+	cmd.Flags().DurationVar(&cfg.AgentStatusHistoryRetention, "agent-status-history-retention", agent.DefaultStatusHistoryRetention, "Length of time for which agent status changes are retained. 0 disables purging.")
+	cmd.Flags().Int64Var(&cfg.AgentJobDiskEstimate, "agent-job-disk-estimate", 0, "Estimated disk space in bytes required by a job; agents reporting less free disk space are not allocated jobs. 0 disables the check.")
+	cmd.Flags().IntVar(&cfg.MaxDebugRunLogSize, "max-debug-run-log-size", logs.DefaultMaxDebugRunLogSize, "Maximum total size in bytes of the logs for a run with debug logging enabled, used in place of --max-run-log-size if larger. 0 means no limit.")
+	cmd.Flags().IntVar(&cfg.MaxConcurrentLogWrites, "max-concurrent-log-writes", logs.DefaultMaxConcurrentWrites, "Maximum number of chunks of logs written concurrently, beyond which agents are asked to slow down uploading logs. 0 means no limit.")
+	cmd.Flags().IntVar(&cfg.MaxRunLogSize, "max-run-log-size", logs.DefaultMaxRunLogSize, "Maximum total size in bytes of the logs for a run, beyond which they are truncated. 0 means no limit.")
 	cmd.Flags().StringVar(&cfg.WebhookHost, "webhook-hostname", "", "External hostname for otf webhooks")
+	cmd.Flags().DurationVar(&cfg.WebhookReplayMaxAge, "webhook-replay-max-age", repohooks.DefaultReplayMaxAge, "Maximum age of a webhook delivery that may be redelivered.")
+	cmd.Flags().StringSliceVar(&cfg.EventSinks, "event-sinks", nil, "Deliver audit and run events to a list of sinks, each of the form <name>=<url>.")
+	cmd.Flags().StringVar(&cfg.EventSinkHMACSecret, "event-sink-hmac-secret", "", "Secret for signing events delivered to HTTP event sinks.")
+	cmd.Flags().BoolVar(&cfg.TraceJobs, "trace-jobs", false, "Emit OpenTelemetry spans for each step in the lifecycle of a job.")
+	cmd.Flags().BoolVar(&cfg.CheckForUpgrades, "check-for-upgrades", false, "Periodically check for newer releases of tofutf.")
 
 	cmd.Flags().IntVar(&cfg.CacheConfig.Size, "cache-size", 0, "Maximum cache size in MB. 0 means unlimited size.")
 	cmd.Flags().DurationVar(&cfg.CacheConfig.TTL, "cache-expiry", internal.DefaultCacheTTL, "Cache entry TTL.")
@@ -102,6 +160,7 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cmd.Flags().StringVar(&cfg.KeyFile, "key-file", "", "Path to SSL key (required if enabling SSL)")
 	cmd.Flags().BoolVar(&cfg.EnableRequestLogging, "log-http-requests", false, "Log HTTP requests")
 	cmd.Flags().BoolVar(&cfg.DevMode, "dev-mode", false, "Enable developer mode.")
+	cmd.Flags().BoolVar(&cfg.DisableWorkingDirectoryTriggers, "disable-working-directory-triggers", false, "Trigger runs in a workspace regardless of whether files in its working directory have changed.")
 	cmd.Flags().BoolVar(&cfg.SkipTLSVerification, "skip-tls-verification", false, "Enable/Disable verification of client's SSL certificates.")
 
 	cmd.Flags().StringVar(&cfg.GithubHostname, "github-hostname", github.DefaultHostname, "github hostname")
@@ -114,6 +173,10 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 
 	cmd.Flags().StringVar(&cfg.BitbucketServerHostname, "bitbucketserver-hostname", cfg.BitbucketServerHostname, "bitbucket server hostname")
 
+	cmd.Flags().StringVar((*string)(&cfg.GithubUnknownEvents), "github-unknown-events", string(vcs.IgnoreUnknownEvents), "How github events with an unknown type are handled: ignore or reject.")
+	cmd.Flags().StringVar((*string)(&cfg.GitlabUnknownEvents), "gitlab-unknown-events", string(vcs.IgnoreUnknownEvents), "How gitlab events with an unknown type are handled: ignore or reject.")
+	cmd.Flags().StringVar((*string)(&cfg.BitbucketServerUnknownEvents), "bitbucketserver-unknown-events", string(vcs.IgnoreUnknownEvents), "How bitbucket server events with an unknown type are handled: ignore or reject.")
+
 	cmd.Flags().StringVar(&cfg.OIDC.Name, "oidc-name", "", "User friendly OIDC name")
 	cmd.Flags().StringVar(&cfg.OIDC.IssuerURL, "oidc-issuer-url", "", "OIDC issuer URL")
 	cmd.Flags().StringVar(&cfg.OIDC.ClientID, "oidc-client-id", "", "OIDC client ID")
@@ -121,20 +184,50 @@ func parseFlags(ctx context.Context, args []string, out io.Writer) error {
 	cmd.Flags().StringSliceVar(&cfg.OIDC.Scopes, "oidc-scopes", authenticator.DefaultOIDCScopes, "OIDC scopes")
 	cmd.Flags().StringVar(&cfg.OIDC.UsernameClaim, "oidc-username-claim", string(authenticator.DefaultUsernameClaim), "OIDC claim to be used for username (name, email, or sub)")
 
+	cmd.Flags().StringVar(&cfg.Vault.Address, "vault-address", "", "Address of the Vault server from which variables may reference secrets, e.g. https://vault.example.com:8200")
+	cmd.Flags().StringVar(&cfg.Vault.Namespace, "vault-namespace", "", "Vault enterprise namespace")
+	cmd.Flags().StringVar(&cfg.Vault.AuthMethod, "vault-auth-method", vault.AppRoleAuthMethod, "Method with which to authenticate to Vault: approle or kubernetes")
+	cmd.Flags().StringVar(&cfg.Vault.AuthMount, "vault-auth-mount", "", "Path at which the Vault auth method is mounted. Defaults to the name of the auth method")
+	cmd.Flags().StringVar(&cfg.Vault.RoleID, "vault-role-id", "", "Role ID for the Vault approle auth method")
+	cmd.Flags().StringVar(&cfg.Vault.SecretID, "vault-secret-id", "", "Secret ID for the Vault approle auth method")
+	cmd.Flags().StringVar(&cfg.Vault.KubernetesRole, "vault-kubernetes-role", "", "Role for the Vault kubernetes auth method")
+	cmd.Flags().StringVar(&cfg.Vault.KubernetesTokenPath, "vault-kubernetes-token-path", vault.DefaultKubernetesTokenPath, "Path to the service account token for the Vault kubernetes auth method")
+
 	cmd.Flags().BoolVar(&cfg.RestrictOrganizationCreation, "restrict-org-creation", false, "Restrict organization creation capability to site admin role")
 
 	cmd.Flags().StringVar(&cfg.GoogleIAPConfig.Audience, "google-jwt-audience", "", "The Google JWT audience claim for validation. If unspecified then validation is skipped")
 
 	cmd.Flags().StringVar(&cfg.ProviderProxy.URL, "provider-proxy-url", "", "The URL of the provider registry to proxy provider registry requests to")
+	cmd.Flags().BoolVar(&cfg.RequireModuleSignatures, "require-module-signatures", false, "Require module versions uploaded via the API to be accompanied by a detached signature made by one of the organization's signing keys")
+	cmd.Flags().DurationVar(&cfg.ModuleShareGracePeriod, "module-share-grace-period", module.DefaultShareGracePeriod, "Length of time after a module share is revoked for which organizations can continue to download the versions they downloaded before it was revoked.")
+
 	cmd.Flags().BoolVar(&cfg.ProviderProxy.IsArtifactory, "provider-proxy-is-artifactory", false, "Set to true if using artifactory as the backing provider registry")
 
 	loggerConfig = xslog.NewConfigFromFlags(cmd.Flags())
 	cfg.AgentConfig = agent.NewConfigFromFlags(cmd.Flags())
 
+	// the validate command accepts the same flags as the daemon
+	validateCmd.Flags().AddFlagSet(cmd.Flags())
+
 	if err := cmdutil.SetFlagsFromEnvVariables(cmd.Flags()); err != nil {
-		return errors.Wrap(err, "failed to populate config from environment vars")
+		return fmt.Errorf("failed to populate config from environment vars: %w", err)
 	}
 
 	cmd.SetArgs(args)
 	return cmd.ExecuteContext(ctx)
 }
+
+// loadConfig populates the config from the config file, if any, and validates
+// it, reporting every problem found.
+func loadConfig(flags *pflag.FlagSet, configFile string, cfg *daemon.Config) error {
+	var errs []error
+	if configFile != "" {
+		if err := cmdutil.SetFlagsFromConfigFile(flags, configFile); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := cfg.Valid(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
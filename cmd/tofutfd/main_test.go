@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -61,3 +63,37 @@ func TestInvalidSecret(t *testing.T) {
 	want := "invalid argument \"not-hex\" for \"--secret\" flag: encoding/hex: invalid byte: U+006E 'n'"
 	assert.Equal(t, want, err.Error())
 }
+
+func TestConfigValidate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		err := os.WriteFile(path, []byte(`secret: "6b07b57377755b07cf61709780ee7484"`), 0o600)
+		require.NoError(t, err)
+
+		got := new(bytes.Buffer)
+		err = parseFlags(ctx, []string{"config", "validate", "--config", path}, got)
+		require.NoError(t, err)
+		assert.Equal(t, "configuration is valid\n", got.String())
+	})
+	t.Run("report every problem", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		err := os.WriteFile(path, []byte("bogus: true\nssl: true\n"), 0o600)
+		require.NoError(t, err)
+
+		err = parseFlags(ctx, []string{"config", "validate", "--config", path}, io.Discard)
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "bogus: unknown key")
+		assert.ErrorContains(t, err, "required parameter missing: secret")
+		assert.ErrorContains(t, err, "cert-file is required when ssl is enabled")
+	})
+	t.Run("flags take precedence over config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		err := os.WriteFile(path, []byte(`secret: "not-hex"`), 0o600)
+		require.NoError(t, err)
+
+		err = parseFlags(ctx, []string{"config", "validate", "--config", path, "--secret", "6b07b57377755b07cf61709780ee7484"}, io.Discard)
+		require.NoError(t, err)
+	})
+}
@@ -26,6 +26,20 @@ const (
 	AgentUnknown AgentStatus = "unknown"
 )
 
+// Reasons for an agent changing status, other than those with details only
+// known at the time of the change.
+const (
+	reasonRegistered   = "registered"
+	reasonNoJobs       = "no jobs running"
+	reasonRunningJobs  = "running jobs"
+	reasonReported     = "reported by agent"
+	reasonDeregistered = "agent exited"
+)
+
+// maxStatusHistory is the maximum number of status changes retained for each
+// agent. Older changes are discarded.
+const maxStatusHistory = 100
+
 // Agent describes an agent. (The agent *process* is Daemon).
 type Agent struct {
 	// Unique system-wide ID
@@ -38,17 +52,45 @@ type Agent struct {
 	Status AgentStatus `jsonapi:"attribute" json:"status"`
 	// Max number of jobs agent can execute
 	MaxJobs int `jsonapi:"attribute" json:"max_jobs"`
-	// Current number of jobs allocated to agent.
+	// Current number of jobs allocated to agent. It is not stored but derived
+	// from the number of the agent's jobs that are allocated or running, so a
+	// job frees up its slot the moment its status changes from either,
+	// and no slot can be left in use by a job that no longer exists.
 	CurrentJobs int `jsonapi:"attribute" json:"current_jobs"`
 	// Last time a ping was received from the agent
 	LastPingAt time.Time `jsonapi:"attribute" json:"last-ping-at"`
 	// Last time the status was updated
 	LastStatusAt time.Time `jsonapi:"attribute" json:"last-status-at"`
+	// Reason the agent last changed status, e.g. a heartbeat timeout.
+	StatusReason string `jsonapi:"attribute" json:"status-reason"`
 	// IP address of agent
 	IPAddress net.IP `jsonapi:"attribute" json:"ip-address"`
 	// ID of agent' pool. If nil then the agent is assumed to be a server agent
 	// (otfd).
 	AgentPoolID *string `jsonapi:"attribute" json:"agent-pool-id"`
+	// Token issued to an agent that registered using a bootstrap token. Only
+	// populated in the response to registration; it is never persisted.
+	Token string `jsonapi:"attribute" json:"token,omitempty"`
+	// DiskCapacity is the number of bytes free in the agent's working
+	// directory when it registered. Nil if the agent did not report its
+	// capacity.
+	DiskCapacity *int64 `jsonapi:"attribute" json:"disk_capacity"`
+	// Labels restrict the jobs allocated to the agent to those jobs with all
+	// of the labels. If empty then the agent may be allocated any job.
+	Labels map[string]string `jsonapi:"attribute" json:"labels,omitempty"`
+}
+
+// StatusChange is a change in an agent's status, recorded in the agent's status
+// history.
+type StatusChange struct {
+	// Unique ID of the change. IDs increase with each change.
+	ID string `jsonapi:"primary,agent-status-changes"`
+	// Status to which the agent changed.
+	Status AgentStatus `jsonapi:"attribute" json:"status"`
+	// Time at which the change occurred.
+	ChangedAt time.Time `jsonapi:"attribute" json:"changed-at"`
+	// Reason for the change.
+	Reason string `jsonapi:"attribute" json:"reason"`
 }
 
 type registerAgentOptions struct {
@@ -64,6 +106,12 @@ type registerAgentOptions struct {
 	// ID of agent's pool. If unset then the agent is assumed to be a server
 	// agent (which does not belong to a pool).
 	AgentPoolID *string `json:"-"`
+	// DiskCapacity is the number of bytes free in the agent's working
+	// directory. Optional.
+	DiskCapacity *int64 `json:"disk-capacity,omitempty"`
+	// Labels restrict the jobs allocated to the agent to those with all of
+	// the labels. Optional.
+	Labels map[string]string `json:"labels,omitempty"`
 	// CurrentJobs are those jobs the agent has discovered leftover from a
 	// previous agent. Not currently used but may be made use of in later
 	// versions.
@@ -77,13 +125,15 @@ type registrar struct {
 
 func (f *registrar) register(ctx context.Context, opts registerAgentOptions) (*Agent, error) {
 	agent := &Agent{
-		ID:          internal.NewID("agent"),
-		Name:        opts.Name,
-		Version:     opts.Version,
-		MaxJobs:     opts.Concurrency,
-		AgentPoolID: opts.AgentPoolID,
+		ID:           internal.NewID("agent"),
+		Name:         opts.Name,
+		Version:      opts.Version,
+		MaxJobs:      opts.Concurrency,
+		AgentPoolID:  opts.AgentPoolID,
+		DiskCapacity: opts.DiskCapacity,
+		Labels:       opts.Labels,
 	}
-	if err := agent.setStatus(AgentIdle, true); err != nil {
+	if err := agent.setStatus(AgentIdle, true, reasonRegistered); err != nil {
 		return nil, err
 	}
 	if opts.IPAddress != nil {
@@ -101,7 +151,24 @@ func (f *registrar) register(ctx context.Context, opts registerAgentOptions) (*A
 	return agent, nil
 }
 
-func (a *Agent) setStatus(status AgentStatus, ping bool) error {
+// accepts determines whether the agent accepts the job by virtue of the job
+// having all of the agent's labels.
+func (a *Agent) accepts(job *Job) bool {
+	return job.HasLabels(a.Labels)
+}
+
+// hasDiskFor determines whether the agent has sufficient disk for another job,
+// assuming each of its jobs, including the new job, needs estimate bytes. An
+// agent that did not report its capacity, or a zero estimate, is given the
+// benefit of the doubt.
+func (a *Agent) hasDiskFor(estimate int64) bool {
+	if a.DiskCapacity == nil || estimate <= 0 {
+		return true
+	}
+	return *a.DiskCapacity >= estimate*int64(a.CurrentJobs+1)
+}
+
+func (a *Agent) setStatus(status AgentStatus, ping bool, reason string) error {
 	// the agent fsm is as follows:
 	//
 	// idle -> any
@@ -114,6 +181,7 @@ func (a *Agent) setStatus(status AgentStatus, ping bool) error {
 		return internal.ErrConflict
 	}
 	a.Status = status
+	a.StatusReason = reason
 	now := internal.CurrentTimestamp(nil)
 	a.LastStatusAt = now
 	// also update ping time if requested
@@ -2,17 +2,29 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
+	"strings"
+	"time"
 
+	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/releases"
+	otfrun "github.com/tofutf/tofutf/internal/run"
+	"golang.org/x/exp/maps"
 )
 
 // AllocatorLockID guarantees only one allocator on a cluster is running at any
 // time.
 const AllocatorLockID int64 = 5577006791947779412
 
+// defaultQueueSLAInterval is the default frequency with which the allocator
+// checks whether unallocated jobs have breached their organization's queue
+// time SLA.
+var defaultQueueSLAInterval = 30 * time.Second
+
 // allocator allocates jobs to agents. Only one allocator must be active on
 // an OTF cluster at any one time.
 type allocator struct {
@@ -26,6 +38,22 @@ type allocator struct {
 	agents map[string]*Agent
 	// jobs awaiting allocation to an agent, keyed by job ID
 	jobs map[JobSpec]*Job
+	// service for determining whether maintenance mode is active
+	maintenance maintenanceClient
+	// service for checking whether the terraform version a job requires can
+	// be installed
+	releases releasesClient
+	// paused is true whilst maintenance mode is active, during which jobs are
+	// not allocated to agents.
+	paused bool
+	// frequency with which the allocator checks jobs against queue time SLAs.
+	slaInterval time.Duration
+	// estimated disk space in bytes required by a job; agents reporting less
+	// free disk space are not allocated jobs. Zero disables the check.
+	jobDiskEstimate int64
+	// duration after which a normal priority job awaiting allocation is
+	// allocated as if it were high priority. Zero disables aging.
+	priorityAging time.Duration
 }
 
 type allocatorClient interface {
@@ -35,10 +63,24 @@ type allocatorClient interface {
 
 	listAllAgentPools(ctx context.Context) ([]*Pool, error)
 	listAgents(ctx context.Context) ([]*Agent, error)
-	listJobs(ctx context.Context) ([]*Job, error)
+	listJobs(ctx context.Context, opts ListJobsOptions) ([]*Job, error)
 
 	allocateJob(ctx context.Context, spec JobSpec, agentID string) (*Job, error)
 	reallocateJob(ctx context.Context, spec JobSpec, agentID string) (*Job, error)
+	rejectJob(ctx context.Context, spec JobSpec, reason string) (*Job, error)
+
+	recordAllocatorStatus(ctx context.Context, status *AllocatorStatus) error
+	markQueueSLABreaches(ctx context.Context, now time.Time) ([]*Job, error)
+}
+
+type maintenanceClient interface {
+	WatchActive(ctx context.Context) (<-chan bool, error)
+}
+
+type releasesClient interface {
+	CheckVersion(ctx context.Context, version string) error
+	IsInstalled(ctx context.Context, version string) (bool, error)
+	Prewarm(ctx context.Context, version string)
 }
 
 // Start the allocator. Should be invoked in a go routine.
@@ -50,6 +92,19 @@ func (a *allocator) Start(ctx context.Context) error {
 	defer agentsUnsub()
 	jobsSub, jobsUnsub := a.client.WatchJobs(ctx)
 	defer jobsUnsub()
+	maintenanceSub, err := a.maintenance.WatchActive(ctx)
+	if err != nil {
+		return err
+	}
+	select {
+	case paused, open := <-maintenanceSub:
+		if !open {
+			return pubsub.ErrSubscriptionTerminated
+		}
+		a.paused = paused
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 
 	// seed allocator with pools, agents, and jobs
 	pools, err := a.client.listAllAgentPools(ctx)
@@ -60,7 +115,7 @@ func (a *allocator) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	jobs, err := a.client.listJobs(ctx)
+	jobs, err := a.client.listJobs(ctx, ListJobsOptions{})
 	if err != nil {
 		return err
 	}
@@ -69,15 +124,25 @@ func (a *allocator) Start(ctx context.Context) error {
 	// allocate jobs to agents
 	a.allocate(ctx) //nolint:errcheck
 
+	interval := a.slaInterval
+	if interval == 0 {
+		interval = defaultQueueSLAInterval
+	}
+	slaTicker := time.NewTicker(interval)
+	defer slaTicker.Stop()
+
 	// consume events until a subscriber is closed, and allocate jobs.
 	for {
 		select {
+		case <-slaTicker.C:
+			a.checkQueueSLAs(ctx)
+			continue
 		case event, open := <-poolsSub:
 			if !open {
 				return pubsub.ErrSubscriptionTerminated
 			}
-			switch event.Type {
-			case pubsub.DeletedEvent:
+			switch {
+			case event.Type == pubsub.DeletedEvent, event.Payload.DeletedAt != nil:
 				delete(a.pools, event.Payload.ID)
 			default:
 				a.pools[event.Payload.ID] = event.Payload
@@ -102,6 +167,16 @@ func (a *allocator) Start(ctx context.Context) error {
 			default:
 				a.jobs[event.Payload.Spec] = event.Payload
 			}
+		case paused, open := <-maintenanceSub:
+			if !open {
+				return pubsub.ErrSubscriptionTerminated
+			}
+			if paused {
+				a.logger.Info("maintenance mode activated; pausing job allocation")
+			} else {
+				a.logger.Info("maintenance mode deactivated; resuming job allocation")
+			}
+			a.paused = paused
 		}
 		if err := a.allocate(ctx); err != nil {
 			return err
@@ -109,6 +184,25 @@ func (a *allocator) Start(ctx context.Context) error {
 	}
 }
 
+// checkQueueSLAs marks jobs that have waited longer than their organization's
+// queue time SLA to be allocated, logging a warning for each newly breached
+// job. Marking a job publishes a job event, which notifies subscribers of the
+// breach. Failure to check is not deemed fatal to allocation.
+func (a *allocator) checkQueueSLAs(ctx context.Context) {
+	breached, err := a.client.markQueueSLABreaches(ctx, internal.CurrentTimestamp(nil))
+	if err != nil {
+		a.logger.Error("checking queue time SLAs", "err", err)
+		return
+	}
+	for _, job := range breached {
+		a.logger.Warn("job has breached queue time SLA",
+			"job", job,
+			"organization", job.Organization,
+			"queue_time", job.QueueTime(*job.SLABreachedAt),
+		)
+	}
+}
+
 func (a *allocator) seed(pools []*Pool, agents []*Agent, jobs []*Job) {
 	a.pools = make(map[string]*Pool, len(pools))
 	for _, pool := range pools {
@@ -124,9 +218,32 @@ func (a *allocator) seed(pools []*Pool, agents []*Agent, jobs []*Job) {
 	}
 }
 
+// prioritizedJobs returns the jobs in the order in which they should be
+// allocated: jobs that are effectively high priority first, and then the
+// oldest jobs first.
+func (a *allocator) prioritizedJobs() []*Job {
+	now := internal.CurrentTimestamp(nil)
+	jobs := maps.Values(a.jobs)
+	slices.SortFunc(jobs, func(x, y *Job) int {
+		xhigh := otfrun.EffectivePriority(x.Priority, x.CreatedAt, now, a.priorityAging) == otfrun.PriorityHigh
+		yhigh := otfrun.EffectivePriority(y.Priority, y.CreatedAt, now, a.priorityAging) == otfrun.PriorityHigh
+		if xhigh != yhigh {
+			if xhigh {
+				return -1
+			}
+			return 1
+		}
+		return x.CreatedAt.Compare(y.CreatedAt)
+	})
+	return jobs
+}
+
 // allocate jobs to agents.
 func (a *allocator) allocate(ctx context.Context) error {
-	for _, job := range a.jobs {
+	a.countCurrentJobs()
+	// jobs that could not be allocated in this pass
+	var pending []PendingJob
+	for _, job := range a.prioritizedJobs() {
 		var reallocate bool
 		switch job.Status {
 		case JobUnallocated:
@@ -145,26 +262,50 @@ func (a *allocator) allocate(ctx context.Context) error {
 			// another no longer healthy, try reallocating job to another another
 			reallocate = true
 		case JobFinished, JobCanceled, JobErrored:
-			// job has completed: remove and adjust number of current jobs
-			// agents has
+			// job has completed: remove, which frees up its agent's capacity
+			// on the next pass
 			delete(a.jobs, job.Spec)
-			a.agents[*job.AgentID].CurrentJobs--
 			continue
 		default:
 			// job running; ignore
 			continue
 		}
+		if a.paused {
+			// maintenance mode is active; leave job where it is until
+			// maintenance mode ends.
+			pending = append(pending, newPendingJob(job, ReasonMaintenanceMode))
+			continue
+		}
+		if !reallocate {
+			// reject job before it is allocated if its terraform version
+			// is not allowed by its pool or cannot be installed, rather than
+			// leave the agent to fail the job after it has started.
+			rejected, err := a.checkVersion(ctx, job)
+			if err != nil {
+				return err
+			}
+			if rejected {
+				continue
+			}
+		}
+		if a.belowMinAgents(job) {
+			// hold job until enough of its pool's agents are ready
+			pending = append(pending, newPendingJob(job, ReasonBelowMinAgents))
+			continue
+		}
 		// allocate job to available agent
-		var available []*Agent
+		var (
+			available []*Agent
+			// number of agents ready for the job, regardless of capacity
+			ready int
+			// number of those agents lacking disk space for the job
+			lackingDisk int
+		)
 		for _, agent := range a.agents {
 			if agent.Status != AgentIdle && agent.Status != AgentBusy {
 				// skip agents that are not ready for jobs
 				continue
 			}
-			// skip agents with insufficient capacity
-			if agent.CurrentJobs == agent.MaxJobs {
-				continue
-			}
 			if agent.AgentPoolID == nil {
 				// if agent has a nil agent pool ID then it is a server
 				// agent and it only handles jobs with a nil pool ID.
@@ -178,10 +319,31 @@ func (a *allocator) allocate(ctx context.Context) error {
 					continue
 				}
 			}
+			// an agent with labels only handles jobs with all of its labels
+			if !agent.accepts(job) {
+				continue
+			}
+			ready++
+			// skip agents with insufficient capacity
+			if agent.CurrentJobs >= agent.MaxJobs {
+				continue
+			}
+			// skip agents with insufficient disk space
+			if !agent.hasDiskFor(a.jobDiskEstimate) {
+				lackingDisk++
+				continue
+			}
 			available = append(available, agent)
 		}
 		if len(available) == 0 {
-			a.logger.Error("no available agents found for job", "job", job)
+			reason := ReasonNoAgents
+			if lackingDisk > 0 && lackingDisk == ready {
+				reason = ReasonInsufficientDisk
+			} else if ready > 0 {
+				reason = ReasonAgentsAtCapacity
+			}
+			a.logger.Error("no available agents found for job", "job", job, "reason", reason)
+			pending = append(pending, newPendingJob(job, reason))
 			continue
 		}
 		// select agent that has most recently sent a ping
@@ -207,12 +369,166 @@ func (a *allocator) allocate(ctx context.Context) error {
 			a.agents[from].CurrentJobs--
 		} else {
 			updatedJob, err = a.client.allocateJob(ctx, job.Spec, agent.ID)
-			if err != nil {
+			if errors.Is(err, ErrInvalidJobStateTransition) {
+				// job has since been claimed by an agent; its event will
+				// update the cache.
+				a.logger.Debug("job already claimed", "job", job)
+				continue
+			} else if err != nil {
 				return err
 			}
 		}
 		a.jobs[job.Spec] = updatedJob
 		a.agents[agent.ID].CurrentJobs++
 	}
+	a.recordStatus(ctx, pending)
+	return nil
+}
+
+// countCurrentJobs sets the number of current jobs of each agent to the number
+// of jobs in the cache that are allocated to, or running on, the agent,
+// mirroring how the database counts an agent's jobs. The count is derived
+// from jobs rather than maintained incrementally because jobs are also
+// allocated by agents claiming them, unbeknownst to the allocator until it
+// receives their events.
+func (a *allocator) countCurrentJobs() {
+	for _, agent := range a.agents {
+		agent.CurrentJobs = 0
+	}
+	for _, job := range a.jobs {
+		if job.AgentID == nil || (job.Status != JobAllocated && job.Status != JobRunning) {
+			continue
+		}
+		if agent, ok := a.agents[*job.AgentID]; ok {
+			agent.CurrentJobs++
+		}
+	}
+}
+
+// recordStatus records the outcome of an allocation pass, for the benefit of
+// those wondering why jobs are yet to be allocated. Failure to record the
+// status is not deemed fatal to allocation.
+func (a *allocator) recordStatus(ctx context.Context, pending []PendingJob) {
+	slices.SortFunc(pending, func(a, b PendingJob) int {
+		return strings.Compare(a.Spec.String(), b.Spec.String())
+	})
+	status := &AllocatorStatus{
+		LastAllocatedAt: internal.CurrentTimestamp(nil),
+		Paused:          a.paused,
+		PendingJobs:     pending,
+		Pools:           a.poolCapacities(),
+	}
+	if err := a.client.recordAllocatorStatus(ctx, status); err != nil {
+		a.logger.Error("recording allocator status", "err", err)
+	}
+}
+
+// poolCapacities counts the agents available to each pool, with the server
+// agents listed first.
+func (a *allocator) poolCapacities() []PoolCapacity {
+	// server agents are keyed by an empty string
+	capacities := map[string]*PoolCapacity{"": {}}
+	for id, pool := range a.pools {
+		capacities[id] = &PoolCapacity{AgentPoolID: &pool.ID, Name: pool.Name}
+	}
+	for _, agent := range a.agents {
+		if agent.Status != AgentIdle && agent.Status != AgentBusy {
+			continue
+		}
+		var id string
+		if agent.AgentPoolID != nil {
+			id = *agent.AgentPoolID
+		}
+		capacity, ok := capacities[id]
+		if !ok {
+			// agent belongs to a pool not yet in the cache
+			capacity = &PoolCapacity{AgentPoolID: agent.AgentPoolID}
+			capacities[id] = capacity
+		}
+		capacity.Agents++
+		if agent.CurrentJobs < agent.MaxJobs && agent.hasDiskFor(a.jobDiskEstimate) {
+			capacity.Candidates++
+		}
+	}
+	ids := maps.Keys(capacities)
+	slices.Sort(ids)
+	to := make([]PoolCapacity, len(ids))
+	for i, id := range ids {
+		to[i] = *capacities[id]
+	}
+	return to
+}
+
+// checkVersion checks the terraform version required by the job is allowed by
+// the job's agent pool, and is either installed or available for download. If
+// not, the job is rejected. Returns true if the job was rejected. If the
+// version is available but not yet installed then it is prewarmed, so that a
+// server agent need not download it mid-run.
+func (a *allocator) checkVersion(ctx context.Context, job *Job) (bool, error) {
+	if job.TerraformVersion == "" {
+		return false, nil
+	}
+	if job.AgentPoolID != nil {
+		if pool, ok := a.pools[*job.AgentPoolID]; ok {
+			if err := pool.CheckTerraformVersion(job.TerraformVersion); err != nil {
+				return true, a.reject(ctx, job, err.Error())
+			}
+		}
+	}
+	if a.releases == nil {
+		return false, nil
+	}
+	installed, err := a.releases.IsInstalled(ctx, job.TerraformVersion)
+	if err != nil {
+		a.logger.Warn("checking terraform version is installed", "job", job, "version", job.TerraformVersion, "err", err)
+	} else if installed {
+		// proceed without further ado
+		return false, nil
+	}
+	err = a.releases.CheckVersion(ctx, job.TerraformVersion)
+	if errors.Is(err, releases.ErrVersionUnavailable) {
+		return true, a.reject(ctx, job, err.Error())
+	} else if err != nil {
+		// unable to determine whether the version is available, so give the
+		// agent the benefit of the doubt.
+		a.logger.Warn("checking terraform version", "job", job, "version", job.TerraformVersion, "err", err)
+		return false, nil
+	}
+	if job.AgentPoolID == nil {
+		// the job is for a server agent, which shares the server's terraform
+		// bin directory, so download the version ahead of the job starting.
+		a.releases.Prewarm(ctx, job.TerraformVersion)
+	}
+	return false, nil
+}
+
+// belowMinAgents determines whether fewer than the minimum number of agents
+// in the job's pool are ready to accept jobs.
+func (a *allocator) belowMinAgents(job *Job) bool {
+	if job.AgentPoolID == nil {
+		return false
+	}
+	pool, ok := a.pools[*job.AgentPoolID]
+	if !ok || pool.MinAgents == 0 {
+		return false
+	}
+	var ready int
+	for _, agent := range a.agents {
+		if agent.Status != AgentIdle && agent.Status != AgentBusy {
+			continue
+		}
+		if agent.AgentPoolID != nil && *agent.AgentPoolID == pool.ID {
+			ready++
+		}
+	}
+	return ready < pool.MinAgents
+}
+
+func (a *allocator) reject(ctx context.Context, job *Job, reason string) error {
+	rejected, err := a.client.rejectJob(ctx, job.Spec, reason)
+	if err != nil {
+		return err
+	}
+	a.jobs[job.Spec] = rejected
 	return nil
 }
@@ -0,0 +1,110 @@
+package agent
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// ReasonMaintenanceMode is the reason given for a job not being allocated
+	// whilst maintenance mode is active.
+	ReasonMaintenanceMode UnallocatedReason = "maintenance_mode"
+	// ReasonNoAgents is the reason given for a job not being allocated
+	// because there are no agents ready to accept its jobs.
+	ReasonNoAgents UnallocatedReason = "no_agents"
+	// ReasonAgentsAtCapacity is the reason given for a job not being allocated
+	// because every agent ready to accept the job is already running as many
+	// jobs as it is permitted to run.
+	ReasonAgentsAtCapacity UnallocatedReason = "agents_at_capacity"
+	// ReasonInsufficientDisk is the reason given for a job not being
+	// allocated because every agent ready to accept the job has reported less
+	// free disk space than a job is estimated to need.
+	ReasonInsufficientDisk UnallocatedReason = "insufficient_disk"
+	// ReasonBelowMinAgents is the reason given for a job not being allocated
+	// because fewer than its pool's minimum number of agents are ready to
+	// accept jobs.
+	ReasonBelowMinAgents UnallocatedReason = "below_min_agents"
+)
+
+type (
+	// AllocatorStatus is the allocator's view of jobs and agents, recorded at
+	// the end of each allocation pass.
+	AllocatorStatus struct {
+		// LastAllocatedAt is when the allocator last completed an allocation
+		// pass.
+		LastAllocatedAt time.Time `json:"last_allocated_at"`
+		// Paused is true if maintenance mode was active, during which jobs are
+		// not allocated.
+		Paused bool `json:"paused"`
+		// PendingJobs are jobs the allocator was unable to allocate to an
+		// agent.
+		PendingJobs []PendingJob `json:"pending_jobs"`
+		// Pools summarises the agents available to each pool, including the
+		// server agents, which are represented by a nil pool ID.
+		Pools []PoolCapacity `json:"pools"`
+	}
+
+	// PendingJob is a job that the allocator was unable to allocate to an
+	// agent, along with the reason why.
+	PendingJob struct {
+		Spec         JobSpec           `json:"spec"`
+		Status       JobStatus         `json:"status"`
+		AgentPoolID  *string           `json:"agent_pool_id"`
+		Organization string            `json:"organization"`
+		WorkspaceID  string            `json:"workspace_id"`
+		Reason       UnallocatedReason `json:"reason"`
+	}
+
+	// PoolCapacity is the number of agents available to a pool.
+	PoolCapacity struct {
+		// ID of the pool; nil denotes the server agents.
+		AgentPoolID *string `json:"agent_pool_id"`
+		// Name of the pool; empty for the server agents.
+		Name string `json:"name"`
+		// Number of agents that are ready to accept jobs, i.e. either idle or
+		// busy.
+		Agents int `json:"agents"`
+		// Number of those agents that are candidates for allocation, i.e.
+		// with spare capacity, and disk space, for another job.
+		Candidates int `json:"candidates"`
+	}
+
+	// UnallocatedReason is the reason why a job could not be allocated to an
+	// agent.
+	UnallocatedReason string
+)
+
+// Explain the reason to a user wondering why their run is queued.
+func (j PendingJob) Explain() string {
+	agents := "server agents"
+	if j.AgentPoolID != nil {
+		agents = fmt.Sprintf("agents in pool %s", *j.AgentPoolID)
+	}
+	switch j.Reason {
+	case ReasonMaintenanceMode:
+		return "Maintenance mode is active: jobs are not being allocated to agents until it ends."
+	case ReasonNoAgents:
+		return fmt.Sprintf("There are no %s ready to accept jobs.", agents)
+	case ReasonAgentsAtCapacity:
+		return fmt.Sprintf("All %s are at capacity; the job will be allocated once an agent finishes a job.", agents)
+	case ReasonInsufficientDisk:
+		return fmt.Sprintf("All %s lack sufficient free disk space for the job.", agents)
+	case ReasonBelowMinAgents:
+		return fmt.Sprintf("Fewer than the minimum number of %s are ready to accept jobs; the job will be allocated once enough agents are ready.", agents)
+	default:
+		return fmt.Sprintf("The job has not been allocated to an agent: %s.", j.Reason)
+	}
+}
+
+// newPendingJob constructs a pending job from a job and the reason it was not
+// allocated.
+func newPendingJob(job *Job, reason UnallocatedReason) PendingJob {
+	return PendingJob{
+		Spec:         job.Spec,
+		Status:       job.Status,
+		AgentPoolID:  job.AgentPoolID,
+		Organization: job.Organization,
+		WorkspaceID:  job.WorkspaceID,
+		Reason:       reason,
+	}
+}
@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"bytes"
 	"context"
 	"log/slog"
 	"testing"
@@ -9,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal"
+	otfrun "github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
 	"github.com/tofutf/tofutf/internal/xslog"
 )
 
@@ -53,10 +56,14 @@ func TestAllocator_allocate(t *testing.T) {
 		agents []*Agent
 		// seed allocator with job
 		job *Job
+		// seed allocator with jobs already running on agents
+		running []*Job
 		// want this job after allocation
 		wantJob *Job
 		// want these agents after allocation
 		wantAgents map[string]*Agent
+		// whether maintenance mode is active
+		paused bool
 	}{
 		{
 			name: "allocate job to server agent",
@@ -117,12 +124,97 @@ func TestAllocator_allocate(t *testing.T) {
 				"agent-1": {ID: "agent-1", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1, AgentPoolID: internal.String("pool-1")},
 			},
 		},
+		{
+			name: "allocate labelled job to agent with matching labels",
+			agents: []*Agent{
+				{ID: "agent-prod", Status: AgentIdle, MaxJobs: 1, Labels: map[string]string{"env": "prod"}},
+				{ID: "agent-dev", Status: AgentIdle, MaxJobs: 1, Labels: map[string]string{"env": "dev"}, LastPingAt: now},
+			},
+			job: &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+				Labels: map[string]string{"env": "prod", "team": "platform"},
+			},
+			wantJob: &Job{
+				Spec:    JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:  JobAllocated,
+				AgentID: internal.String("agent-prod"),
+				Labels:  map[string]string{"env": "prod", "team": "platform"},
+			},
+			wantAgents: map[string]*Agent{
+				"agent-prod": {ID: "agent-prod", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1, Labels: map[string]string{"env": "prod"}},
+				"agent-dev":  {ID: "agent-dev", Status: AgentIdle, MaxJobs: 1, Labels: map[string]string{"env": "dev"}, LastPingAt: now},
+			},
+		},
+		{
+			name: "do not allocate job lacking labels of agent",
+			agents: []*Agent{
+				{ID: "agent-prod", Status: AgentIdle, MaxJobs: 1, Labels: map[string]string{"env": "prod"}},
+			},
+			job: &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+			},
+			wantJob: &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+			},
+			wantAgents: map[string]*Agent{
+				"agent-prod": {ID: "agent-prod", Status: AgentIdle, MaxJobs: 1, Labels: map[string]string{"env": "prod"}},
+			},
+		},
+		{
+			name:  "allocate job to pool agent with terraform version allowed by pool",
+			pools: []*Pool{{ID: "pool-1", AllowedTerraformVersions: internal.String(">= 1.5, < 1.8")}},
+			agents: []*Agent{
+				{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-1")},
+			},
+			job: &Job{
+				Spec:             JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:           JobUnallocated,
+				AgentPoolID:      internal.String("pool-1"),
+				TerraformVersion: "1.6.0",
+			},
+			wantJob: &Job{
+				Spec:             JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:           JobAllocated,
+				AgentPoolID:      internal.String("pool-1"),
+				AgentID:          internal.String("agent-1"),
+				TerraformVersion: "1.6.0",
+			},
+			wantAgents: map[string]*Agent{
+				"agent-1": {ID: "agent-1", Status: AgentIdle, MaxJobs: 1, CurrentJobs: 1, AgentPoolID: internal.String("pool-1")},
+			},
+		},
+		{
+			name:  "reject job requiring terraform version not allowed by pool",
+			pools: []*Pool{{ID: "pool-1", AllowedTerraformVersions: internal.String(">= 1.5, < 1.8")}},
+			agents: []*Agent{
+				{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-1")},
+			},
+			job: &Job{
+				Spec:             JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:           JobUnallocated,
+				AgentPoolID:      internal.String("pool-1"),
+				TerraformVersion: "1.8.0",
+			},
+			wantJob: &Job{
+				Spec:             JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:           JobErrored,
+				AgentPoolID:      internal.String("pool-1"),
+				TerraformVersion: "1.8.0",
+			},
+			wantAgents: map[string]*Agent{
+				"agent-1": {ID: "agent-1", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-1")},
+			},
+		},
 		{
 			name:  "do not allocate job to agent with insufficient capacity",
 			pools: []*Pool{{ID: "pool-1"}},
 			agents: []*Agent{
-				{ID: "agent-1", Status: AgentIdle, CurrentJobs: 1, MaxJobs: 1},
+				{ID: "agent-1", Status: AgentIdle, MaxJobs: 1},
 			},
+			running: []*Job{runningJob("agent-1")},
 			job: &Job{
 				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
 				Status: JobUnallocated,
@@ -175,6 +267,43 @@ func TestAllocator_allocate(t *testing.T) {
 				AgentID: internal.String("agent-1"),
 			},
 		},
+		{
+			name: "do not allocate job whilst maintenance mode is active",
+			agents: []*Agent{
+				{ID: "agent-idle", Status: AgentIdle, MaxJobs: 1},
+			},
+			job: &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+			},
+			wantJob: &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+			},
+			wantAgents: map[string]*Agent{
+				"agent-idle": {ID: "agent-idle", Status: AgentIdle, MaxJobs: 1},
+			},
+			paused: true,
+		},
+		{
+			name: "reject job requiring unavailable terraform version",
+			agents: []*Agent{
+				{ID: "agent-idle", Status: AgentIdle, MaxJobs: 1},
+			},
+			job: &Job{
+				Spec:             JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:           JobUnallocated,
+				TerraformVersion: "0.0.1",
+			},
+			wantJob: &Job{
+				Spec:             JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:           JobErrored,
+				TerraformVersion: "0.0.1",
+			},
+			wantAgents: map[string]*Agent{
+				"agent-idle": {ID: "agent-idle", Status: AgentIdle, MaxJobs: 1},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -183,8 +312,10 @@ func TestAllocator_allocate(t *testing.T) {
 				client: &fakeService{
 					job: tt.job,
 				},
+				releases: &fakeReleasesService{unavailable: []string{"0.0.1"}},
+				paused:   tt.paused,
 			}
-			a.seed(tt.pools, tt.agents, []*Job{tt.job})
+			a.seed(tt.pools, tt.agents, append(tt.running, tt.job))
 			err := a.allocate(context.Background())
 			require.NoError(t, err)
 			// check agents
@@ -200,3 +331,496 @@ func TestAllocator_allocate(t *testing.T) {
 		})
 	}
 }
+
+func TestAllocator_allocateClaimedJob(t *testing.T) {
+	spec := JobSpec{RunID: "run-123", Phase: internal.PlanPhase}
+	// the job has since been claimed by another agent, unbeknownst to the
+	// allocator
+	a := &allocator{
+		logger: slog.New(&xslog.NoopHandler{}),
+		client: &fakeService{
+			job: &Job{Spec: spec, Status: JobAllocated, AgentID: internal.String("agent-claimant")},
+		},
+	}
+	a.seed(nil, []*Agent{{ID: "agent-idle", Status: AgentIdle, MaxJobs: 1}}, []*Job{{Spec: spec, Status: JobUnallocated}})
+
+	err := a.allocate(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, a.agents["agent-idle"].CurrentJobs)
+}
+
+func TestAllocator_countCurrentJobs(t *testing.T) {
+	// agent-1 has claimed a job, unbeknownst to the allocator until it
+	// receives the job's event, and has since finished another job.
+	claimed := runningJob("agent-1")
+	claimed.Status = JobAllocated
+	finished := runningJob("agent-1")
+	finished.Spec.Phase = internal.ApplyPhase
+	finished.Status = JobFinished
+
+	job := &Job{Spec: JobSpec{RunID: "run-123", Phase: internal.PlanPhase}, Status: JobUnallocated}
+	svc := &fakeService{job: job}
+	a := &allocator{
+		logger: slog.New(&xslog.NoopHandler{}),
+		client: svc,
+	}
+	a.seed(nil, []*Agent{{ID: "agent-1", Status: AgentBusy, MaxJobs: 1}}, []*Job{claimed, finished, job})
+
+	err := a.allocate(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, a.agents["agent-1"].CurrentJobs)
+	assert.Equal(t, JobUnallocated, a.jobs[job.Spec].Status)
+	require.Len(t, svc.allocatorStatus.PendingJobs, 1)
+	assert.Equal(t, ReasonAgentsAtCapacity, svc.allocatorStatus.PendingJobs[0].Reason)
+}
+
+// runningJob constructs a job running on an agent.
+func runningJob(agentID string) *Job {
+	return &Job{
+		Spec:    JobSpec{RunID: "run-" + agentID, Phase: internal.PlanPhase},
+		Status:  JobRunning,
+		AgentID: internal.String(agentID),
+	}
+}
+
+func TestAllocator_diskCapacity(t *testing.T) {
+	const estimate = 1000
+
+	tests := []struct {
+		name            string
+		agents          []*Agent
+		running         []*Job
+		jobDiskEstimate int64
+		// want job allocated to this agent, or pending if empty
+		wantAgentID string
+		// want this reason if pending
+		wantReason UnallocatedReason
+	}{
+		{
+			name:            "allocate job to agent that has not reported disk capacity",
+			agents:          []*Agent{{ID: "agent-1", Status: AgentIdle, MaxJobs: 1}},
+			jobDiskEstimate: estimate,
+			wantAgentID:     "agent-1",
+		},
+		{
+			name:            "allocate job to agent with sufficient disk capacity",
+			agents:          []*Agent{{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, DiskCapacity: internal.Int64(estimate)}},
+			jobDiskEstimate: estimate,
+			wantAgentID:     "agent-1",
+		},
+		{
+			name: "skip agent with insufficient disk capacity",
+			agents: []*Agent{
+				{ID: "agent-small", Status: AgentIdle, MaxJobs: 1, DiskCapacity: internal.Int64(estimate - 1)},
+				{ID: "agent-large", Status: AgentIdle, MaxJobs: 1, DiskCapacity: internal.Int64(estimate)},
+			},
+			jobDiskEstimate: estimate,
+			wantAgentID:     "agent-large",
+		},
+		{
+			name:            "account for jobs already running on agent",
+			agents:          []*Agent{{ID: "agent-1", Status: AgentBusy, MaxJobs: 2, DiskCapacity: internal.Int64(estimate)}},
+			running:         []*Job{runningJob("agent-1")},
+			jobDiskEstimate: estimate,
+			wantReason:      ReasonInsufficientDisk,
+		},
+		{
+			name:            "do not allocate job when all agents lack disk capacity",
+			agents:          []*Agent{{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, DiskCapacity: internal.Int64(estimate - 1)}},
+			jobDiskEstimate: estimate,
+			wantReason:      ReasonInsufficientDisk,
+		},
+		{
+			name: "agents at capacity take precedence over lack of disk capacity",
+			agents: []*Agent{
+				{ID: "agent-busy", Status: AgentBusy, MaxJobs: 1},
+				{ID: "agent-small", Status: AgentIdle, MaxJobs: 1, DiskCapacity: internal.Int64(estimate - 1)},
+			},
+			running:         []*Job{runningJob("agent-busy")},
+			jobDiskEstimate: estimate,
+			wantReason:      ReasonAgentsAtCapacity,
+		},
+		{
+			name:        "disk capacity is disregarded without an estimate",
+			agents:      []*Agent{{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, DiskCapacity: internal.Int64(0)}},
+			wantAgentID: "agent-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+			}
+			svc := &fakeService{job: job}
+			a := &allocator{
+				logger:          slog.New(&xslog.NoopHandler{}),
+				client:          svc,
+				jobDiskEstimate: tt.jobDiskEstimate,
+			}
+			a.seed(nil, tt.agents, append(tt.running, job))
+			err := a.allocate(context.Background())
+			require.NoError(t, err)
+
+			if tt.wantAgentID != "" {
+				got := a.jobs[job.Spec]
+				assert.Equal(t, JobAllocated, got.Status)
+				assert.Equal(t, tt.wantAgentID, *got.AgentID)
+				assert.Empty(t, svc.allocatorStatus.PendingJobs)
+			} else {
+				assert.Equal(t, JobUnallocated, a.jobs[job.Spec].Status)
+				require.Len(t, svc.allocatorStatus.PendingJobs, 1)
+				assert.Equal(t, tt.wantReason, svc.allocatorStatus.PendingJobs[0].Reason)
+			}
+		})
+	}
+}
+
+func TestAllocator_minAgents(t *testing.T) {
+	newAllocator := func(pool *Pool, agents []*Agent) (*allocator, *fakeService, *Job) {
+		job := &Job{
+			Spec:        JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+			Status:      JobUnallocated,
+			AgentPoolID: internal.String(pool.ID),
+		}
+		svc := &fakeService{job: job}
+		a := &allocator{
+			logger: slog.New(&xslog.NoopHandler{}),
+			client: svc,
+		}
+		a.seed([]*Pool{pool}, agents, []*Job{job})
+		return a, svc, job
+	}
+
+	t.Run("hold job until minimum number of agents are ready", func(t *testing.T) {
+		pool := &Pool{ID: "pool-1", MinAgents: 2}
+		a, svc, job := newAllocator(pool, []*Agent{
+			{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-1")},
+			// agents that are not ready, or in another pool, do not count
+			{ID: "agent-2", Status: AgentExited, MaxJobs: 1, AgentPoolID: internal.String("pool-1")},
+			{ID: "agent-3", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-2")},
+		})
+		err := a.allocate(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, JobUnallocated, a.jobs[job.Spec].Status)
+		require.Len(t, svc.allocatorStatus.PendingJobs, 1)
+		assert.Equal(t, ReasonBelowMinAgents, svc.allocatorStatus.PendingJobs[0].Reason)
+
+		// another agent becomes ready, releasing the job
+		a.agents["agent-4"] = &Agent{ID: "agent-4", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-1")}
+		err = a.allocate(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, JobAllocated, a.jobs[job.Spec].Status)
+		assert.Empty(t, svc.allocatorStatus.PendingJobs)
+	})
+
+	t.Run("allocate job when minimum is disabled", func(t *testing.T) {
+		pool := &Pool{ID: "pool-1"}
+		a, svc, job := newAllocator(pool, []*Agent{
+			{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-1")},
+		})
+		err := a.allocate(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, JobAllocated, a.jobs[job.Spec].Status)
+		assert.Empty(t, svc.allocatorStatus.PendingJobs)
+	})
+
+	t.Run("count busy agents towards minimum", func(t *testing.T) {
+		pool := &Pool{ID: "pool-1", MinAgents: 2}
+		a, _, job := newAllocator(pool, []*Agent{
+			{ID: "agent-1", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-1")},
+			{ID: "agent-2", Status: AgentBusy, MaxJobs: 1, CurrentJobs: 1, AgentPoolID: internal.String("pool-1")},
+		})
+		err := a.allocate(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, JobAllocated, a.jobs[job.Spec].Status)
+		assert.Equal(t, "agent-1", *a.jobs[job.Spec].AgentID)
+	})
+}
+
+func TestAllocator_phasePools(t *testing.T) {
+	// plans run on spot agents and applies on stable agents
+	ws := &workspace.Workspace{
+		ExecutionMode:    workspace.AgentExecutionMode,
+		AgentPoolID:      internal.String("pool-spot"),
+		ApplyAgentPoolID: internal.String("pool-stable"),
+	}
+	tests := []struct {
+		status    otfrun.Status
+		wantAgent string
+	}{
+		{otfrun.RunPlanQueued, "agent-spot"},
+		{otfrun.RunApplyQueued, "agent-stable"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			job := newJob(&otfrun.Run{ID: "run-123", Status: tt.status}, ws)
+			a := &allocator{
+				logger: slog.New(&xslog.NoopHandler{}),
+				client: &fakeService{job: job},
+			}
+			a.seed(
+				[]*Pool{{ID: "pool-spot"}, {ID: "pool-stable"}},
+				[]*Agent{
+					{ID: "agent-spot", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-spot")},
+					{ID: "agent-stable", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-stable")},
+				},
+				[]*Job{job},
+			)
+			err := a.allocate(context.Background())
+			require.NoError(t, err)
+
+			got := a.jobs[job.Spec]
+			assert.Equal(t, JobAllocated, got.Status)
+			assert.Equal(t, tt.wantAgent, *got.AgentID)
+		})
+	}
+}
+
+func TestAllocator_prewarm(t *testing.T) {
+	tests := []struct {
+		name          string
+		agentPoolID   *string
+		installed     []string
+		wantPrewarmed []string
+	}{
+		{"proceed with installed version", nil, []string{"1.6.0"}, nil},
+		{"prewarm missing version", nil, nil, []string{"1.6.0"}},
+		{"do not prewarm for pool agent", internal.String("pool-1"), nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{
+				Spec:             JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:           JobUnallocated,
+				TerraformVersion: "1.6.0",
+				AgentPoolID:      tt.agentPoolID,
+			}
+			releases := &fakeReleasesService{installed: tt.installed}
+			a := &allocator{
+				logger:   slog.New(&xslog.NoopHandler{}),
+				client:   &fakeService{job: job},
+				releases: releases,
+			}
+			a.seed([]*Pool{{ID: "pool-1"}}, []*Agent{
+				{ID: "agent-server", Status: AgentIdle, MaxJobs: 1},
+				{ID: "agent-pool", Status: AgentIdle, MaxJobs: 1, AgentPoolID: internal.String("pool-1")},
+			}, []*Job{job})
+			err := a.allocate(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, JobAllocated, a.jobs[job.Spec].Status)
+			assert.Equal(t, tt.wantPrewarmed, releases.prewarmed)
+		})
+	}
+}
+
+func TestAllocator_status(t *testing.T) {
+	tests := []struct {
+		name    string
+		agents  []*Agent
+		running []*Job
+		job     *Job
+		paused  bool
+		// want this pending job recorded, or none if nil
+		wantPending *PendingJob
+		// want these pool capacities recorded
+		wantPools []PoolCapacity
+	}{
+		{
+			name:   "allocated job is not pending",
+			agents: []*Agent{{ID: "agent-idle", Status: AgentIdle, MaxJobs: 1}},
+			job: &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+			},
+			wantPools: []PoolCapacity{
+				{Agents: 1, Candidates: 0},
+				{AgentPoolID: internal.String("pool-1"), Name: "pool-1"},
+			},
+		},
+		{
+			name: "no agents",
+			job: &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+			},
+			wantPending: &PendingJob{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+				Reason: ReasonNoAgents,
+			},
+			wantPools: []PoolCapacity{
+				{},
+				{AgentPoolID: internal.String("pool-1"), Name: "pool-1"},
+			},
+		},
+		{
+			name: "no agents in pool",
+			agents: []*Agent{
+				{ID: "agent-idle", Status: AgentIdle, MaxJobs: 1},
+				{ID: "agent-unknown", Status: AgentUnknown, MaxJobs: 1, AgentPoolID: internal.String("pool-1")},
+			},
+			job: &Job{
+				Spec:        JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:      JobUnallocated,
+				AgentPoolID: internal.String("pool-1"),
+			},
+			wantPending: &PendingJob{
+				Spec:        JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status:      JobUnallocated,
+				AgentPoolID: internal.String("pool-1"),
+				Reason:      ReasonNoAgents,
+			},
+			wantPools: []PoolCapacity{
+				{Agents: 1, Candidates: 1},
+				{AgentPoolID: internal.String("pool-1"), Name: "pool-1"},
+			},
+		},
+		{
+			name:    "agents at capacity",
+			agents:  []*Agent{{ID: "agent-busy", Status: AgentBusy, MaxJobs: 1}},
+			running: []*Job{runningJob("agent-busy")},
+			job: &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+			},
+			wantPending: &PendingJob{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+				Reason: ReasonAgentsAtCapacity,
+			},
+			wantPools: []PoolCapacity{
+				{Agents: 1, Candidates: 0},
+				{AgentPoolID: internal.String("pool-1"), Name: "pool-1"},
+			},
+		},
+		{
+			name:   "maintenance mode",
+			agents: []*Agent{{ID: "agent-idle", Status: AgentIdle, MaxJobs: 1}},
+			job: &Job{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+			},
+			paused: true,
+			wantPending: &PendingJob{
+				Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+				Status: JobUnallocated,
+				Reason: ReasonMaintenanceMode,
+			},
+			wantPools: []PoolCapacity{
+				{Agents: 1, Candidates: 1},
+				{AgentPoolID: internal.String("pool-1"), Name: "pool-1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeService{job: tt.job}
+			a := &allocator{
+				logger: slog.New(&xslog.NoopHandler{}),
+				client: svc,
+				paused: tt.paused,
+			}
+			a.seed([]*Pool{{ID: "pool-1", Name: "pool-1"}}, tt.agents, append(tt.running, tt.job))
+			err := a.allocate(context.Background())
+			require.NoError(t, err)
+
+			require.NotNil(t, svc.allocatorStatus)
+			assert.False(t, svc.allocatorStatus.LastAllocatedAt.IsZero())
+			assert.Equal(t, tt.paused, svc.allocatorStatus.Paused)
+			if tt.wantPending != nil {
+				assert.Equal(t, []PendingJob{*tt.wantPending}, svc.allocatorStatus.PendingJobs)
+			} else {
+				assert.Empty(t, svc.allocatorStatus.PendingJobs)
+			}
+			assert.Equal(t, tt.wantPools, svc.allocatorStatus.Pools)
+		})
+	}
+}
+
+func TestAllocator_checkQueueSLAs(t *testing.T) {
+	created := internal.CurrentTimestamp(nil).Add(-10 * time.Minute)
+
+	tests := []struct {
+		name string
+		// jobs newly marked as breaching their queue time SLA
+		breached []*Job
+		// want a warning logged
+		wantWarning bool
+	}{
+		{
+			name: "breach",
+			breached: []*Job{
+				{
+					Spec:          JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+					Status:        JobUnallocated,
+					Organization:  "acme-corp",
+					CreatedAt:     created,
+					SLABreachedAt: internal.Time(created.Add(5 * time.Minute)),
+				},
+			},
+			wantWarning: true,
+		},
+		{
+			name:        "no breach",
+			wantWarning: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			svc := &fakeService{breachedJobs: tt.breached}
+			a := &allocator{
+				logger: slog.New(slog.NewTextHandler(&buf, nil)),
+				client: svc,
+			}
+			a.checkQueueSLAs(context.Background())
+
+			assert.True(t, svc.checkedQueueSLAs)
+			if tt.wantWarning {
+				assert.Contains(t, buf.String(), "job has breached queue time SLA")
+				assert.Contains(t, buf.String(), "organization=acme-corp")
+				assert.Contains(t, buf.String(), "queue_time=5m0s")
+			} else {
+				assert.Empty(t, buf.String())
+			}
+		})
+	}
+}
+
+func TestAllocator_prioritizedJobs(t *testing.T) {
+	now := time.Now()
+	newJob := func(runID string, priority otfrun.Priority, createdAt time.Time) *Job {
+		return &Job{
+			Spec:      JobSpec{RunID: runID, Phase: internal.PlanPhase},
+			Status:    JobUnallocated,
+			Priority:  priority,
+			CreatedAt: createdAt,
+		}
+	}
+	aged := newJob("run-aged", otfrun.PriorityNormal, now.Add(-2*time.Hour))
+	older := newJob("run-older", otfrun.PriorityNormal, now.Add(-time.Minute))
+	newer := newJob("run-newer", otfrun.PriorityNormal, now)
+	high := newJob("run-high", otfrun.PriorityHigh, now)
+
+	t.Run("with aging", func(t *testing.T) {
+		a := &allocator{priorityAging: time.Hour}
+		a.seed(nil, nil, []*Job{newer, high, older, aged})
+
+		assert.Equal(t, []*Job{aged, high, older, newer}, a.prioritizedJobs())
+	})
+
+	t.Run("without aging", func(t *testing.T) {
+		a := &allocator{}
+		a.seed(nil, nil, []*Job{newer, high, older, aged})
+
+		assert.Equal(t, []*Job{high, aged, older, newer}, a.prioritizedJobs())
+	})
+}
@@ -20,6 +20,9 @@ type (
 
 	updateAgentStatusParams struct {
 		Status AgentStatus `json:"status"`
+		// Reason for the status, if any. Agents predating the reporting
+		// of reasons omit it.
+		Reason string `json:"reason,omitempty"`
 	}
 
 	finishJobParams struct {
@@ -34,9 +37,13 @@ func (a *api) addHandlers(r *mux.Router) {
 	// agents
 	r.HandleFunc("/agents/register", a.registerAgent).Methods("POST")
 	r.HandleFunc("/agents/jobs", a.getJobs).Methods("GET")
+	r.HandleFunc("/agents/claim", a.claimJob).Methods("POST")
 	r.HandleFunc("/agents/status", a.updateStatus).Methods("POST")
+	r.HandleFunc("/agents/deregister", a.deregisterAgent).Methods("POST")
+	r.HandleFunc("/agents/diagnostics/{bundle_id}", a.uploadDiagnostics).Methods("POST")
 	r.HandleFunc("/agents/start", a.startJob).Methods("POST")
 	r.HandleFunc("/agents/finish", a.finishJob).Methods("POST")
+	r.HandleFunc("/agents/{agent_id}/status-history", a.getStatusHistory).Methods("GET")
 
 	// agent tokens
 	r.HandleFunc("/agent-tokens/{pool_id}/create", a.createAgentToken).Methods("POST")
@@ -53,7 +60,10 @@ func (a *api) registerAgent(w http.ResponseWriter, r *http.Request) {
 	opts.IPAddress = net.ParseIP(r.RemoteAddr)
 
 	agent, err := a.service.registerAgent(r.Context(), opts)
-	if err != nil {
+	if errors.Is(err, ErrBootstrapTokenUsedUp) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	} else if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -78,6 +88,30 @@ func (a *api) getJobs(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, jobs, http.StatusOK)
 }
 
+// claimJob claims the next job for the calling agent, responding with a list
+// containing the claimed job, or with an empty list if no job was claimed
+// before the request was canceled.
+func (a *api) claimJob(w http.ResponseWriter, r *http.Request) {
+	// retrieve subject, which contains ID of calling agent
+	subject, err := poolAgentFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	job, err := a.service.ClaimNextJob(r.Context(), subject.agent.ID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	jobs := []*Job{}
+	if job != nil {
+		jobs = append(jobs, job)
+	}
+	a.Respond(w, r, jobs, http.StatusOK)
+}
+
 // updateStatus receives a status update from an agent
 func (a *api) updateStatus(w http.ResponseWriter, r *http.Request) {
 	// retrieve subject, which contains ID of calling agent
@@ -93,7 +127,7 @@ func (a *api) updateStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = a.service.updateAgentStatus(r.Context(), subject.agent.ID, params.Status)
+	response, err := a.service.updateAgentStatus(r.Context(), subject.agent.ID, params.Status, params.Reason)
 	if err != nil {
 		if errors.Is(err, ErrInvalidAgentStateTransition) {
 			tfeapi.Error(w, err)
@@ -102,6 +136,73 @@ func (a *api) updateStatus(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response) //nolint:errcheck
+}
+
+// deregisterAgent receives notice from an agent that it is shutting down
+func (a *api) deregisterAgent(w http.ResponseWriter, r *http.Request) {
+	// retrieve subject, which contains ID of calling agent
+	subject, err := poolAgentFromContext(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := a.service.deregisterAgent(r.Context(), subject.agent.ID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// uploadDiagnostics receives a diagnostics bundle from an agent
+func (a *api) uploadDiagnostics(w http.ResponseWriter, r *http.Request) {
+	bundleID, err := decode.Param("bundle_id", r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// permit a little more than the maximum size of a bundle to allow for the
+	// encoding of the request, leaving the service to enforce the maximum.
+	r.Body = http.MaxBytesReader(w, r.Body, 2*maxDiagnosticsSize)
+	var opts uploadDiagnosticsOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, ErrDiagnosticsTooLarge.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = a.service.uploadDiagnostics(r.Context(), bundleID, opts)
+	if errors.Is(err, ErrDiagnosticsTooLarge) {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) getStatusHistory(w http.ResponseWriter, r *http.Request) {
+	agentID, err := decode.Param("agent_id", r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	history, err := a.service.GetAgentStatusHistory(r.Context(), agentID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, history, http.StatusOK)
 }
 
 func (a *api) createAgentToken(w http.ResponseWriter, r *http.Request) {
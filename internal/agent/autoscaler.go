@@ -0,0 +1,410 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+)
+
+// AutoscalerLockID guarantees only one autoscaler on a cluster is running at
+// any time.
+const AutoscalerLockID int64 = 5577006791947779417
+
+const (
+	// DefaultScaleUpThreshold is the default number of unallocated jobs for a
+	// pool at or above which more agents are desired.
+	DefaultScaleUpThreshold = 1
+	// DefaultScaleDownIdle is the default duration for which an agent must be
+	// idle before fewer agents are desired.
+	DefaultScaleDownIdle = 10 * time.Minute
+	// DefaultScalingCooldown is the default minimum duration between scaling
+	// decisions for a pool.
+	DefaultScalingCooldown = 5 * time.Minute
+
+	// ScalingSignatureHeader is the header containing the HMAC-SHA256
+	// signature of the body of a request to a scaling webhook, prefixed with
+	// "sha256=".
+	ScalingSignatureHeader = "X-OTF-Signature"
+
+	// maxScalingDecisions is the maximum number of scaling decisions retained
+	// for each pool. Older decisions are discarded.
+	maxScalingDecisions = 50
+
+	defaultAutoscalerInterval = 30 * time.Second
+	scalingWebhookTimeout     = 10 * time.Second
+)
+
+var (
+	ErrInvalidScalingWebhookURL = errors.New("webhook URL must be an absolute http or https URL")
+	ErrScalingSecretRequired    = errors.New("HMAC secret is required")
+	ErrInvalidScaleUpThreshold  = errors.New("scale up threshold must be at least 1")
+	ErrInvalidScalingDuration   = errors.New("scale down idle duration and cooldown cannot be negative")
+)
+
+type (
+	// PoolAutoscaler configures the autoscaling of the agents in a pool. The
+	// pool's queue depth and idle agents are evaluated periodically, and
+	// whenever more or fewer agents are desired a webhook is called with the
+	// desired change in the number of agents. tofutf does not itself start or
+	// stop agents: that is left to the receiver of the webhook.
+	PoolAutoscaler struct {
+		AgentPoolID string
+		// WebhookURL is the URL to which scaling decisions are POSTed.
+		WebhookURL string
+		// HMACSecret is the secret with which requests to the webhook are
+		// signed.
+		HMACSecret string
+		// ScaleUpThreshold is the number of unallocated jobs at or above
+		// which more agents are desired.
+		ScaleUpThreshold int
+		// ScaleDownIdle is the duration for which an agent must be idle,
+		// with no jobs awaiting allocation, before fewer agents are desired.
+		ScaleDownIdle time.Duration
+		// Cooldown is the minimum duration between scaling decisions.
+		Cooldown time.Duration
+		// DryRun is true if scaling decisions are only recorded and logged,
+		// and the webhook is not called.
+		DryRun    bool
+		CreatedAt time.Time
+		UpdatedAt time.Time
+
+		// populated only when listing autoscalers for evaluation.
+		poolName      string
+		organization  string
+		lastDecidedAt *time.Time
+	}
+
+	SetPoolAutoscalerOptions struct {
+		WebhookURL string
+		// HMACSecret is required when the autoscaler is first configured.
+		// If empty when updating the autoscaler then the existing secret is
+		// retained.
+		HMACSecret string
+		// Defaults to DefaultScaleUpThreshold
+		ScaleUpThreshold *int
+		// Defaults to DefaultScaleDownIdle
+		ScaleDownIdle *time.Duration
+		// Defaults to DefaultScalingCooldown
+		Cooldown *time.Duration
+		DryRun   bool
+	}
+
+	// ScalingDecision is a decision by the autoscaler that a pool needs more
+	// or fewer agents.
+	ScalingDecision struct {
+		ID          string
+		AgentPoolID string
+		// CurrentAgents is the number of agents in the pool ready to accept
+		// jobs.
+		CurrentAgents int
+		// UnallocatedJobs is the number of jobs awaiting allocation to an
+		// agent in the pool.
+		UnallocatedJobs int
+		// IdleAgents is the number of agents that have been idle for at
+		// least the scale down idle duration.
+		IdleAgents int
+		// DesiredDelta is the desired change in the number of agents:
+		// positive to scale up, negative to scale down.
+		DesiredDelta int
+		// DryRun is true if the webhook was not called.
+		DryRun bool
+		// Error is non-nil if calling the webhook failed.
+		Error     *string
+		DecidedAt time.Time
+	}
+
+	// scalingWebhookPayload is the body of a request to a scaling webhook.
+	scalingWebhookPayload struct {
+		AgentPoolID     string    `json:"agent_pool_id"`
+		AgentPoolName   string    `json:"agent_pool_name"`
+		Organization    string    `json:"organization"`
+		CurrentAgents   int       `json:"current_agents"`
+		UnallocatedJobs int       `json:"unallocated_jobs"`
+		IdleAgents      int       `json:"idle_agents"`
+		DesiredDelta    int       `json:"desired_delta"`
+		Timestamp       time.Time `json:"timestamp"`
+	}
+)
+
+func newPoolAutoscaler(poolID string, existing *PoolAutoscaler, opts SetPoolAutoscalerOptions) (*PoolAutoscaler, error) {
+	u, err := url.Parse(opts.WebhookURL)
+	if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, ErrInvalidScalingWebhookURL
+	}
+	now := internal.CurrentTimestamp(nil)
+	as := &PoolAutoscaler{
+		AgentPoolID:      poolID,
+		WebhookURL:       opts.WebhookURL,
+		HMACSecret:       opts.HMACSecret,
+		ScaleUpThreshold: DefaultScaleUpThreshold,
+		ScaleDownIdle:    DefaultScaleDownIdle,
+		Cooldown:         DefaultScalingCooldown,
+		DryRun:           opts.DryRun,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if existing != nil {
+		as.CreatedAt = existing.CreatedAt
+		if as.HMACSecret == "" {
+			as.HMACSecret = existing.HMACSecret
+		}
+	}
+	if as.HMACSecret == "" {
+		return nil, ErrScalingSecretRequired
+	}
+	if opts.ScaleUpThreshold != nil {
+		if *opts.ScaleUpThreshold < 1 {
+			return nil, ErrInvalidScaleUpThreshold
+		}
+		as.ScaleUpThreshold = *opts.ScaleUpThreshold
+	}
+	if opts.ScaleDownIdle != nil {
+		as.ScaleDownIdle = *opts.ScaleDownIdle
+	}
+	if opts.Cooldown != nil {
+		as.Cooldown = *opts.Cooldown
+	}
+	if as.ScaleDownIdle < 0 || as.Cooldown < 0 {
+		return nil, ErrInvalidScalingDuration
+	}
+	return as, nil
+}
+
+// LogValue omits the HMAC secret.
+func (as *PoolAutoscaler) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("agent_pool_id", as.AgentPoolID),
+		slog.String("webhook_url", as.WebhookURL),
+		slog.Int("scale_up_threshold", as.ScaleUpThreshold),
+		slog.Duration("scale_down_idle", as.ScaleDownIdle),
+		slog.Duration("cooldown", as.Cooldown),
+		slog.Bool("dry_run", as.DryRun),
+	)
+}
+
+// desiredDelta determines the desired change in the number of a pool's
+// agents, given the number of jobs awaiting allocation, the number of agents
+// idle for at least the scale down idle duration, and the number of jobs each
+// agent can run.
+//
+// Flapping is avoided by leaving a dead band between scaling up and down: more
+// agents are desired only once the unallocated jobs reach the threshold, and
+// fewer agents only once there are no unallocated jobs at all.
+func (as *PoolAutoscaler) desiredDelta(unallocated, idle, concurrency int) int {
+	switch {
+	case unallocated >= as.ScaleUpThreshold:
+		// enough agents to run every unallocated job
+		return (unallocated + concurrency - 1) / concurrency
+	case unallocated == 0:
+		return -idle
+	default:
+		return 0
+	}
+}
+
+// coolingDown determines whether the pool's last scaling decision was made
+// too recently for another to be made.
+func (as *PoolAutoscaler) coolingDown(now time.Time) bool {
+	return as.lastDecidedAt != nil && now.Sub(*as.lastDecidedAt) < as.Cooldown
+}
+
+// autoscaler periodically evaluates the queue depth and idle agents of each
+// pool with an autoscaler, and calls the autoscaler's webhook whenever more or
+// fewer agents are desired.
+//
+// Only one autoscaler should be running on an OTF cluster at any one time.
+type autoscaler struct {
+	logger *slog.Logger
+	client autoscalerClient
+	http   *http.Client
+	// frequency with which pools are evaluated.
+	interval time.Duration
+	// idleSince is when each idle agent was first seen to be idle. It is held
+	// in memory only, so should another autoscaler take over then the idle
+	// durations start afresh, delaying rather than hastening scaling down.
+	idleSince map[string]time.Time
+}
+
+type autoscalerClient interface {
+	listPoolAutoscalers(ctx context.Context) ([]*PoolAutoscaler, error)
+	getAllocatorStatus(ctx context.Context) (*AllocatorStatus, error)
+	listAgentsByPool(ctx context.Context, poolID string) ([]*Agent, error)
+	recordScalingDecision(ctx context.Context, decision *ScalingDecision) error
+}
+
+func newAutoscaler(logger *slog.Logger, client autoscalerClient) *autoscaler {
+	return &autoscaler{
+		logger:    logger.With("component", "autoscaler"),
+		client:    client,
+		http:      &http.Client{Timeout: scalingWebhookTimeout},
+		interval:  defaultAutoscalerInterval,
+		idleSince: make(map[string]time.Time),
+	}
+}
+
+// Start the autoscaler. Should be invoked in a go routine.
+func (a *autoscaler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.evaluate(ctx, internal.CurrentTimestamp(nil)); err != nil {
+				a.logger.Error("evaluating pools for autoscaling", "err", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// evaluate each pool with an autoscaler, making a scaling decision for those
+// that need more or fewer agents.
+func (a *autoscaler) evaluate(ctx context.Context, now time.Time) error {
+	autoscalers, err := a.client.listPoolAutoscalers(ctx)
+	if err != nil {
+		return err
+	}
+	if len(autoscalers) == 0 {
+		return nil
+	}
+	// queue depth is taken from the allocator's last allocation pass
+	status, err := a.client.getAllocatorStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if status.LastAllocatedAt.IsZero() || status.Paused {
+		// allocator has yet to run, or jobs are not being allocated whilst
+		// maintenance mode is active, in which case queue depth is no
+		// indication of the agents needed.
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, as := range autoscalers {
+		agents, err := a.client.listAgentsByPool(ctx, as.AgentPoolID)
+		if err != nil {
+			return err
+		}
+		decision := a.decide(as, status, agents, now)
+		for _, agent := range agents {
+			seen[agent.ID] = true
+		}
+		if decision == nil {
+			continue
+		}
+		if as.coolingDown(now) {
+			a.logger.Debug("skipping scaling decision during cooldown", "agent_pool_id", as.AgentPoolID, "desired_delta", decision.DesiredDelta)
+			continue
+		}
+		if as.DryRun {
+			a.logger.Info("scaling decision (dry run)", "agent_pool_id", as.AgentPoolID, "current_agents", decision.CurrentAgents, "unallocated_jobs", decision.UnallocatedJobs, "desired_delta", decision.DesiredDelta)
+		} else if err := a.callWebhook(ctx, as, decision); err != nil {
+			a.logger.Error("calling scaling webhook", "agent_pool_id", as.AgentPoolID, "desired_delta", decision.DesiredDelta, "err", err)
+			decision.Error = internal.String(err.Error())
+		} else {
+			a.logger.Info("scaling decision", "agent_pool_id", as.AgentPoolID, "current_agents", decision.CurrentAgents, "unallocated_jobs", decision.UnallocatedJobs, "desired_delta", decision.DesiredDelta)
+		}
+		if err := a.client.recordScalingDecision(ctx, decision); err != nil {
+			return err
+		}
+	}
+	// forget agents that no longer belong to a pool with an autoscaler
+	for id := range a.idleSince {
+		if !seen[id] {
+			delete(a.idleSince, id)
+		}
+	}
+	return nil
+}
+
+// decide whether a pool needs more or fewer agents, returning nil if it does
+// not. The idle durations of the pool's agents are updated as a side-effect.
+func (a *autoscaler) decide(as *PoolAutoscaler, status *AllocatorStatus, agents []*Agent, now time.Time) *ScalingDecision {
+	var unallocated int
+	for _, job := range status.PendingJobs {
+		if job.AgentPoolID != nil && *job.AgentPoolID == as.AgentPoolID {
+			unallocated++
+		}
+	}
+	var current, idle int
+	concurrency := 1
+	for _, agent := range agents {
+		if agent.Status != AgentIdle && agent.Status != AgentBusy {
+			delete(a.idleSince, agent.ID)
+			continue
+		}
+		current++
+		concurrency = max(concurrency, agent.MaxJobs)
+		if agent.Status != AgentIdle || agent.CurrentJobs > 0 {
+			delete(a.idleSince, agent.ID)
+			continue
+		}
+		since, ok := a.idleSince[agent.ID]
+		if !ok {
+			since = now
+			a.idleSince[agent.ID] = since
+		}
+		if now.Sub(since) >= as.ScaleDownIdle {
+			idle++
+		}
+	}
+	delta := as.desiredDelta(unallocated, idle, concurrency)
+	if delta == 0 {
+		return nil
+	}
+	return &ScalingDecision{
+		AgentPoolID:     as.AgentPoolID,
+		CurrentAgents:   current,
+		UnallocatedJobs: unallocated,
+		IdleAgents:      idle,
+		DesiredDelta:    delta,
+		DryRun:          as.DryRun,
+		DecidedAt:       now,
+	}
+}
+
+// callWebhook POSTs a scaling decision to an autoscaler's webhook, signing the
+// request with the autoscaler's secret.
+func (a *autoscaler) callWebhook(ctx context.Context, as *PoolAutoscaler, decision *ScalingDecision) error {
+	body, err := json.Marshal(scalingWebhookPayload{
+		AgentPoolID:     as.AgentPoolID,
+		AgentPoolName:   as.poolName,
+		Organization:    as.organization,
+		CurrentAgents:   decision.CurrentAgents,
+		UnallocatedJobs: decision.UnallocatedJobs,
+		IdleAgents:      decision.IdleAgents,
+		DesiredDelta:    decision.DesiredDelta,
+		Timestamp:       decision.DecidedAt,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", as.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, []byte(as.HMACSecret))
+	mac.Write(body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ScalingSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	return nil
+}
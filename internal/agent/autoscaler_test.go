@@ -0,0 +1,219 @@
+package agent
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestNewPoolAutoscaler(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		got, err := newPoolAutoscaler("pool-123", nil, SetPoolAutoscalerOptions{
+			WebhookURL: "https://scaler.example.com/hook",
+			HMACSecret: "secret",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, DefaultScaleUpThreshold, got.ScaleUpThreshold)
+		assert.Equal(t, DefaultScaleDownIdle, got.ScaleDownIdle)
+		assert.Equal(t, DefaultScalingCooldown, got.Cooldown)
+	})
+
+	t.Run("retain existing secret", func(t *testing.T) {
+		existing := &PoolAutoscaler{HMACSecret: "secret", CreatedAt: time.Unix(0, 0)}
+		got, err := newPoolAutoscaler("pool-123", existing, SetPoolAutoscalerOptions{
+			WebhookURL: "https://scaler.example.com/hook",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "secret", got.HMACSecret)
+		assert.Equal(t, existing.CreatedAt, got.CreatedAt)
+	})
+
+	tests := []struct {
+		name string
+		opts SetPoolAutoscalerOptions
+		want error
+	}{
+		{"relative url", SetPoolAutoscalerOptions{WebhookURL: "/hook", HMACSecret: "secret"}, ErrInvalidScalingWebhookURL},
+		{"non-http url", SetPoolAutoscalerOptions{WebhookURL: "ftp://scaler.example.com", HMACSecret: "secret"}, ErrInvalidScalingWebhookURL},
+		{"missing secret", SetPoolAutoscalerOptions{WebhookURL: "https://scaler.example.com/hook"}, ErrScalingSecretRequired},
+		{"zero threshold", SetPoolAutoscalerOptions{WebhookURL: "https://scaler.example.com/hook", HMACSecret: "secret", ScaleUpThreshold: internal.Int(0)}, ErrInvalidScaleUpThreshold},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newPoolAutoscaler("pool-123", nil, tt.opts)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestPoolAutoscaler_desiredDelta(t *testing.T) {
+	as := &PoolAutoscaler{ScaleUpThreshold: 3}
+
+	tests := []struct {
+		name        string
+		unallocated int
+		idle        int
+		concurrency int
+		want        int
+	}{
+		{"below threshold", 2, 0, 1, 0},
+		{"below threshold with idle agents", 2, 1, 1, 0},
+		{"at threshold", 3, 0, 1, 3},
+		{"at threshold with concurrent agents", 3, 0, 2, 2},
+		{"no jobs and idle agents", 0, 2, 1, -2},
+		{"no jobs and no idle agents", 0, 0, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, as.desiredDelta(tt.unallocated, tt.idle, tt.concurrency))
+		})
+	}
+}
+
+func TestAutoscaler(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	// webhook that records the body of each request and verifies its
+	// signature
+	var bodies [][]byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		if r.Header.Get(ScalingSignatureHeader) != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		bodies = append(bodies, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	newClient := func(as *PoolAutoscaler, pending int, agents ...*Agent) *fakeAutoscalerClient {
+		status := &AllocatorStatus{LastAllocatedAt: now}
+		for i := 0; i < pending; i++ {
+			status.PendingJobs = append(status.PendingJobs, PendingJob{AgentPoolID: &as.AgentPoolID})
+		}
+		return &fakeAutoscalerClient{autoscalers: []*PoolAutoscaler{as}, status: status, agents: agents}
+	}
+	newScaler := func(client autoscalerClient) *autoscaler {
+		return newAutoscaler(slog.New(&xslog.NoopHandler{}), client)
+	}
+
+	t.Run("scale up", func(t *testing.T) {
+		bodies = nil
+		as := &PoolAutoscaler{AgentPoolID: "pool-1", WebhookURL: srv.URL, HMACSecret: "secret", ScaleUpThreshold: 2}
+		client := newClient(as, 3, &Agent{ID: "agent-1", Status: AgentBusy, MaxJobs: 1, CurrentJobs: 1})
+
+		err := newScaler(client).evaluate(ctx, now)
+		require.NoError(t, err)
+
+		require.Len(t, bodies, 1)
+		var payload scalingWebhookPayload
+		require.NoError(t, json.Unmarshal(bodies[0], &payload))
+		assert.Equal(t, "pool-1", payload.AgentPoolID)
+		assert.Equal(t, 1, payload.CurrentAgents)
+		assert.Equal(t, 3, payload.UnallocatedJobs)
+		assert.Equal(t, 3, payload.DesiredDelta)
+
+		require.Len(t, client.decisions, 1)
+		assert.Nil(t, client.decisions[0].Error)
+	})
+
+	t.Run("scale down once idle for long enough", func(t *testing.T) {
+		bodies = nil
+		as := &PoolAutoscaler{AgentPoolID: "pool-1", WebhookURL: srv.URL, HMACSecret: "secret", ScaleUpThreshold: 1, ScaleDownIdle: time.Minute}
+		client := newClient(as, 0, &Agent{ID: "agent-1", Status: AgentIdle, MaxJobs: 1})
+		scaler := newScaler(client)
+
+		// agent is first seen idle
+		require.NoError(t, scaler.evaluate(ctx, now))
+		assert.Len(t, bodies, 0)
+
+		require.NoError(t, scaler.evaluate(ctx, now.Add(time.Minute)))
+		require.Len(t, client.decisions, 1)
+		assert.Equal(t, -1, client.decisions[0].DesiredDelta)
+		assert.Equal(t, 1, client.decisions[0].IdleAgents)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		bodies = nil
+		as := &PoolAutoscaler{AgentPoolID: "pool-1", WebhookURL: srv.URL, HMACSecret: "secret", ScaleUpThreshold: 1, DryRun: true}
+		client := newClient(as, 1)
+
+		require.NoError(t, newScaler(client).evaluate(ctx, now))
+
+		assert.Len(t, bodies, 0)
+		require.Len(t, client.decisions, 1)
+		assert.True(t, client.decisions[0].DryRun)
+	})
+
+	t.Run("cooldown", func(t *testing.T) {
+		bodies = nil
+		as := &PoolAutoscaler{AgentPoolID: "pool-1", WebhookURL: srv.URL, HMACSecret: "secret", ScaleUpThreshold: 1, Cooldown: time.Minute, lastDecidedAt: internal.Time(now.Add(-30 * time.Second))}
+		client := newClient(as, 1)
+
+		require.NoError(t, newScaler(client).evaluate(ctx, now))
+
+		assert.Len(t, bodies, 0)
+		assert.Len(t, client.decisions, 0)
+	})
+
+	t.Run("webhook failure", func(t *testing.T) {
+		as := &PoolAutoscaler{AgentPoolID: "pool-1", WebhookURL: srv.URL, HMACSecret: "wrong", ScaleUpThreshold: 1}
+		client := newClient(as, 1)
+
+		require.NoError(t, newScaler(client).evaluate(ctx, now))
+
+		require.Len(t, client.decisions, 1)
+		require.NotNil(t, client.decisions[0].Error)
+		assert.Contains(t, *client.decisions[0].Error, "401")
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		as := &PoolAutoscaler{AgentPoolID: "pool-1", WebhookURL: srv.URL, HMACSecret: "secret", ScaleUpThreshold: 1}
+		client := newClient(as, 1)
+		client.status.Paused = true
+
+		require.NoError(t, newScaler(client).evaluate(ctx, now))
+
+		assert.Len(t, client.decisions, 0)
+	})
+}
+
+type fakeAutoscalerClient struct {
+	autoscalers []*PoolAutoscaler
+	status      *AllocatorStatus
+	agents      []*Agent
+	decisions   []*ScalingDecision
+}
+
+func (f *fakeAutoscalerClient) listPoolAutoscalers(context.Context) ([]*PoolAutoscaler, error) {
+	return f.autoscalers, nil
+}
+
+func (f *fakeAutoscalerClient) getAllocatorStatus(context.Context) (*AllocatorStatus, error) {
+	return f.status, nil
+}
+
+func (f *fakeAutoscalerClient) listAgentsByPool(context.Context, string) ([]*Agent, error) {
+	return f.agents, nil
+}
+
+func (f *fakeAutoscalerClient) recordScalingDecision(_ context.Context, decision *ScalingDecision) error {
+	f.decisions = append(f.decisions, decision)
+	return nil
+}
@@ -0,0 +1,144 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/tokens"
+)
+
+const (
+	// defaultBootstrapTokenExpiry is the default lifetime of a bootstrap
+	// token.
+	defaultBootstrapTokenExpiry = time.Hour
+	// maxBootstrapTokenExpiry is the maximum lifetime of a bootstrap token,
+	// which is only intended for provisioning agents.
+	maxBootstrapTokenExpiry = 24 * time.Hour
+)
+
+var (
+	ErrBootstrapTokenUsedUp = errors.New("bootstrap token has expired or has been used the maximum number of times")
+	ErrAgentTokenRevoked    = errors.New("agent token has been revoked")
+)
+
+type (
+	// bootstrapToken is a short-lived token that agents exchange for their own
+	// token upon registration.
+	// NOTE: the cryptographic token itself is not retained.
+	bootstrapToken struct {
+		ID          string
+		CreatedAt   time.Time
+		AgentPoolID string
+		Description string
+		ExpiresAt   time.Time
+		// MaxUses is the maximum number of agents that can register using the
+		// token. Nil if there is no maximum.
+		MaxUses *int
+		// Uses is the number of agents that have registered using the token.
+		Uses int
+	}
+
+	CreateBootstrapTokenOptions struct {
+		Description string `schema:"description,required"`
+		// ExpiresAt optionally sets the time at which the token expires.
+		// Defaults to an hour from now, and it cannot be more than a day from
+		// now.
+		ExpiresAt *time.Time `schema:"-"`
+		// MaxUses optionally sets the maximum number of agents that can
+		// register using the token.
+		MaxUses *int `schema:"max_uses"`
+	}
+
+	// agentCredential is the record of the token issued to an agent that
+	// registered using a bootstrap token. Only a hash of the token is
+	// retained.
+	agentCredential struct {
+		AgentID          string
+		BootstrapTokenID string
+		TokenHash        string
+		CreatedAt        time.Time
+	}
+)
+
+func (t *bootstrapToken) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", t.ID),
+		slog.String("agent_pool_id", t.AgentPoolID),
+		slog.String("description", t.Description),
+		slog.Time("expires_at", t.ExpiresAt),
+	}
+	if t.MaxUses != nil {
+		attrs = append(attrs, slog.Int("max_uses", *t.MaxUses))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// newBootstrapToken constructs a bootstrap token for agents in a pool,
+// returning both the representation of the token, and the cryptographic token
+// itself.
+func (f *tokenFactory) newBootstrapToken(poolID string, opts CreateBootstrapTokenOptions) (*bootstrapToken, []byte, error) {
+	if opts.Description == "" {
+		return nil, nil, fmt.Errorf("description cannot be an empty string")
+	}
+	bt := bootstrapToken{
+		ID:          internal.NewID("abt"),
+		CreatedAt:   internal.CurrentTimestamp(nil),
+		Description: opts.Description,
+		AgentPoolID: poolID,
+		MaxUses:     opts.MaxUses,
+	}
+	bt.ExpiresAt = bt.CreatedAt.Add(defaultBootstrapTokenExpiry)
+	if opts.ExpiresAt != nil {
+		bt.ExpiresAt = opts.ExpiresAt.UTC()
+		if !bt.ExpiresAt.After(bt.CreatedAt) {
+			return nil, nil, fmt.Errorf("expiry must be in the future")
+		}
+		if bt.ExpiresAt.After(bt.CreatedAt.Add(maxBootstrapTokenExpiry)) {
+			return nil, nil, fmt.Errorf("expiry cannot be more than %s from now", maxBootstrapTokenExpiry)
+		}
+	}
+	if opts.MaxUses != nil && *opts.MaxUses < 1 {
+		return nil, nil, fmt.Errorf("maximum uses must be at least one")
+	}
+	token, err := f.tokens.NewToken(tokens.NewTokenOptions{
+		Subject: bt.ID,
+		Kind:    AgentBootstrapTokenKind,
+		Claims: map[string]string{
+			"agent_pool_id": poolID,
+		},
+		Expiry: &bt.ExpiresAt,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &bt, token, nil
+}
+
+// newAgentCredential constructs a token for an individual agent that has
+// registered using a bootstrap token, returning both the record of the token
+// and the cryptographic token itself.
+func (f *tokenFactory) newAgentCredential(agentID, bootstrapTokenID string) (*agentCredential, []byte, error) {
+	token, err := f.tokens.NewToken(tokens.NewTokenOptions{
+		Subject: agentID,
+		Kind:    PerAgentTokenKind,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &agentCredential{
+		AgentID:          agentID,
+		BootstrapTokenID: bootstrapTokenID,
+		TokenHash:        hashAgentToken(token),
+		CreatedAt:        internal.CurrentTimestamp(nil),
+	}, token, nil
+}
+
+func hashAgentToken(token []byte) string {
+	sum := sha256.Sum256(token)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/tokens"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestNewBootstrapToken(t *testing.T) {
+	svc, err := tokens.NewService(tokens.Options{
+		Logger: slog.New(&xslog.NoopHandler{}),
+		Secret: []byte("abcdefghijklmnopqrstuvwxyz123456"),
+	})
+	require.NoError(t, err)
+	f := &tokenFactory{tokens: svc}
+
+	t.Run("defaults", func(t *testing.T) {
+		bt, token, err := f.newBootstrapToken("pool-123", CreateBootstrapTokenOptions{
+			Description: "ci agents",
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, "pool-123", bt.AgentPoolID)
+		assert.Equal(t, bt.CreatedAt.Add(defaultBootstrapTokenExpiry), bt.ExpiresAt)
+		assert.Nil(t, bt.MaxUses)
+	})
+
+	tests := []struct {
+		name string
+		opts CreateBootstrapTokenOptions
+	}{
+		{"missing description", CreateBootstrapTokenOptions{}},
+		{"expiry in past", CreateBootstrapTokenOptions{
+			Description: "ci agents",
+			ExpiresAt:   internal.Time(time.Now().Add(-time.Minute)),
+		}},
+		{"expiry too far in future", CreateBootstrapTokenOptions{
+			Description: "ci agents",
+			ExpiresAt:   internal.Time(time.Now().Add(maxBootstrapTokenExpiry + time.Hour)),
+		}},
+		{"zero maximum uses", CreateBootstrapTokenOptions{
+			Description: "ci agents",
+			MaxUses:     internal.Int(0),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := f.newBootstrapToken("pool-123", tt.opts)
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("agent credential", func(t *testing.T) {
+		cred, token, err := f.newAgentCredential("agent-123", "abt-123")
+		require.NoError(t, err)
+		assert.Equal(t, "agent-123", cred.AgentID)
+		assert.Equal(t, "abt-123", cred.BootstrapTokenID)
+		// only the hash of the token is retained
+		assert.NotContains(t, cred.TokenHash, string(token))
+		assert.Equal(t, hashAgentToken(token), cred.TokenHash)
+	})
+}
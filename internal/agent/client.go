@@ -3,6 +3,7 @@ package agent
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -43,6 +44,12 @@ func (c *client) registerAgent(ctx context.Context, opts registerAgentOptions) (
 	// add agent ID to future requests
 	agentID := agent.ID
 	c.agentID = &agentID
+	// an agent that registered using a bootstrap token is issued its own
+	// token, which it uses to authenticate all future requests.
+	if agent.Token != "" {
+		c.SetToken(agent.Token)
+		agent.Token = ""
+	}
 	return &agent, nil
 }
 
@@ -67,10 +74,60 @@ func (c *client) getAgentJobs(ctx context.Context, agentID string) ([]*Job, erro
 	return jobs, nil
 }
 
-func (c *client) updateAgentStatus(ctx context.Context, agentID string, status AgentStatus) error {
+// ClaimNextJob claims the next job for the agent, blocking until a job is
+// claimed or a timeout is reached, in which case nil is returned.
+func (c *client) ClaimNextJob(ctx context.Context, agentID string) (*Job, error) {
+	req, err := c.NewRequest("POST", "agents/claim", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*Job
+	if err := c.Do(ctx, req, &jobs); err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs[0], nil
+}
+
+func (c *client) updateAgentStatus(ctx context.Context, agentID string, status AgentStatus, reason string) (*statusUpdateResponse, error) {
 	req, err := c.NewRequest("POST", "agents/status", &updateAgentStatusParams{
 		Status: status,
+		Reason: reason,
 	})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := c.Do(ctx, req, &buf); err != nil {
+		return nil, err
+	}
+	var response statusUpdateResponse
+	if buf.Len() > 0 {
+		// servers predating the delivery of requests for diagnostics respond
+		// without a body.
+		if err := json.Unmarshal(buf.Bytes(), &response); err != nil {
+			return nil, err
+		}
+	}
+	return &response, nil
+}
+
+func (c *client) deregisterAgent(ctx context.Context, agentID string) error {
+	req, err := c.NewRequest("POST", "agents/deregister", nil)
+	if err != nil {
+		return err
+	}
+	if err := c.Do(ctx, req, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *client) uploadDiagnostics(ctx context.Context, bundleID string, opts uploadDiagnosticsOptions) error {
+	req, err := c.NewRequest("POST", fmt.Sprintf("agents/diagnostics/%s", bundleID), &opts)
 	if err != nil {
 		return err
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/tofutf/tofutf/internal"
 	otfapi "github.com/tofutf/tofutf/internal/api"
 	"github.com/tofutf/tofutf/internal/configversion"
+	otfhttp "github.com/tofutf/tofutf/internal/http"
 	"github.com/tofutf/tofutf/internal/logs"
 	"github.com/tofutf/tofutf/internal/releases"
 	"github.com/tofutf/tofutf/internal/run"
@@ -45,6 +46,22 @@ type (
 		Debug           bool   // toggle debug mode
 		PluginCache     bool   // toggle use of terraform's shared plugin cache
 		TerraformBinDir string // destination directory for terraform binaries
+		Product         string // name of product whose binaries are executed
+		// maximum number of distinct terraform versions downloaded at any one
+		// time
+		MaxConcurrentDownloads int
+		// directory in which downloaded terraform archives are cached, so
+		// that binaries can be extracted again without downloading them
+		// again; caching is disabled if empty.
+		ArchiveCacheDir string
+		// refuse requests to collect diagnostics
+		DisableDiagnostics bool
+		// directory in which jobs' working directories are created; defaults
+		// to the system's temporary directory.
+		WorkDir string
+		// labels restricting the jobs allocated to the agent to those with
+		// all of the labels
+		Labels map[string]string
 	}
 )
 
@@ -55,6 +72,12 @@ func NewConfigFromFlags(flags *pflag.FlagSet) *Config {
 	flags.BoolVar(&cfg.Debug, "debug", false, "Enable agent debug mode which dumps additional info to terraform runs.")
 	flags.BoolVar(&cfg.PluginCache, "plugin-cache", false, "Enable shared plugin cache for terraform providers.")
 	flags.StringVar(&cfg.Name, "name", "", "Give agent a descriptive name. Optional.")
+	flags.StringVar(&cfg.Product, "product", releases.TerraformProduct.Name, "Product whose binaries are downloaded and executed: terraform or opentofu.")
+	flags.IntVar(&cfg.MaxConcurrentDownloads, "max-concurrent-downloads", releases.DefaultMaxConcurrentDownloads, "Maximum number of terraform versions that can be downloaded concurrently.")
+	flags.StringVar(&cfg.ArchiveCacheDir, "archive-cache-dir", "", "Directory in which to cache downloaded terraform archives. Caching is disabled if unset.")
+	flags.BoolVar(&cfg.DisableDiagnostics, "disable-diagnostics", false, "Refuse requests from the server to collect and upload diagnostics.")
+	flags.StringToStringVar(&cfg.Labels, "labels", nil, "Only run jobs with all of these labels, e.g. environment=prod,team=platform. Optional.")
+	flags.StringVar(&cfg.WorkDir, "work-dir", "", "Directory in which to create the working directories of jobs. Defaults to the system's temporary directory.")
 	return &cfg
 }
 
@@ -68,6 +91,9 @@ type daemon struct {
 	registered chan *Agent
 	logger     *slog.Logger // logger that logs messages regardless of whether agent is a pool agent or not.
 	poolLogger *slog.Logger // logger that only logs messages if the agent is a pool agent.
+	// collects diagnostics upon request; nil if the agent is not a pool
+	// agent or diagnostics are disabled.
+	diagnostics *diagnosticsCollector
 
 	isPoolAgent bool
 }
@@ -76,6 +102,9 @@ type DaemonOptions struct {
 	Logger *slog.Logger
 	Config Config
 	client *daemonClient
+	// downloader for terraform binaries; if nil then a downloader is
+	// constructed from the config.
+	downloader downloader
 
 	// whether daemon is for a pool agent (true) or for a server agent (false).
 	isPoolAgent bool
@@ -84,10 +113,18 @@ type DaemonOptions struct {
 // downloader downloads terraform versions
 type downloader interface {
 	Download(ctx context.Context, version string, w io.Writer) (string, error)
+	// Subscribe subscribes to events for downloads of a version.
+	Subscribe(ctx context.Context, version string) (<-chan releases.DownloadEvent, func())
 }
 
 // newDaemon constructs an agent daemon.
 func newDaemon(opts DaemonOptions) (*daemon, error) {
+	var logs *logRecorder
+	if opts.isPoolAgent && !opts.Config.DisableDiagnostics {
+		// retain recent logs for inclusion in diagnostics bundles
+		logs = newLogRecorder(opts.Logger.Handler(), maxDiagnosticsLogLines)
+		opts.Logger = slog.New(logs)
+	}
 	poolLogger := opts.Logger
 	if !opts.isPoolAgent {
 		// disable logging for server agents otherwise the server logs are
@@ -108,16 +145,37 @@ func newDaemon(opts DaemonOptions) (*daemon, error) {
 		}
 		opts.Logger.Debug("enabled sandbox mode")
 	}
+	if opts.Config.WorkDir != "" {
+		if info, err := os.Stat(opts.Config.WorkDir); err != nil {
+			return nil, fmt.Errorf("checking work directory: %w", err)
+		} else if !info.IsDir() {
+			return nil, fmt.Errorf("work directory is not a directory: %s", opts.Config.WorkDir)
+		}
+	}
+	product, err := releases.LookupProduct(opts.Config.Product)
+	if err != nil {
+		return nil, err
+	}
+	if opts.downloader == nil {
+		opts.downloader = releases.NewDownloader(product, opts.Config.TerraformBinDir, opts.Config.MaxConcurrentDownloads, opts.Config.ArchiveCacheDir)
+	}
 	d := &daemon{
 		daemonClient: opts.client,
 		envs:         DefaultEnvs,
-		downloader:   releases.NewDownloader(opts.Config.TerraformBinDir),
+		downloader:   opts.downloader,
 		registered:   make(chan *Agent),
 		config:       opts.Config,
 		poolLogger:   poolLogger,
 		logger:       opts.Logger,
 		isPoolAgent:  opts.isPoolAgent,
 	}
+	if logs != nil {
+		targets := []string{"https://" + product.Host}
+		if server, err := otfhttp.SanitizeAddress(opts.client.address); err == nil {
+			targets = append([]string{server + "/healthz"}, targets...)
+		}
+		d.diagnostics = newDiagnosticsCollector(opts.Config, logs, targets...)
+	}
 	if opts.Config.PluginCache {
 		if err := os.MkdirAll(PluginCacheDir, 0o755); err != nil {
 			return nil, fmt.Errorf("creating plugin cache directory: %w", err)
@@ -139,6 +197,9 @@ type ServerDaemonOptions struct {
 	LogsService                 *logs.Service
 	AgentService                Service
 	HostnameService             *internal.HostnameService
+	// Downloader is shared with the server so that the limit on concurrent
+	// downloads applies server-wide.
+	Downloader downloader
 }
 
 // NewServerDaemon constructs a server agent daemon that is part of the otfd
@@ -157,6 +218,7 @@ func NewServerDaemon(logger *slog.Logger, cfg Config, opts ServerDaemonOptions)
 			agents:     opts.AgentService,
 			server:     opts.HostnameService,
 		},
+		downloader: opts.Downloader,
 	})
 }
 
@@ -191,9 +253,11 @@ func (d *daemon) Start(ctx context.Context) error {
 
 	// register agent with server
 	agent, err := d.agents.registerAgent(ctx, registerAgentOptions{
-		Name:        d.config.Name,
-		Version:     internal.Version,
-		Concurrency: d.config.Concurrency,
+		Name:         d.config.Name,
+		Version:      internal.Version,
+		Concurrency:  d.config.Concurrency,
+		DiskCapacity: d.diskCapacity(),
+		Labels:       d.config.Labels,
 	})
 	if err != nil {
 		return err
@@ -213,31 +277,20 @@ func (d *daemon) Start(ctx context.Context) error {
 	}
 
 	g, ctx := errgroup.WithContext(ctx)
-	g.Go(func() (err error) {
-		defer func() {
-			// send final status update using a context that is still valid
-			// for a further 10 seconds unless daemon is forcefully shutdown.
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
-
-			if updateErr := d.agents.updateAgentStatus(ctx, agent.ID, AgentExited); updateErr != nil {
-				err = fmt.Errorf("sending final status update: %w", updateErr)
-			} else {
-				d.logger.Info("sent final status update", "status", "exited")
-			}
-		}()
-
+	g.Go(func() error {
 		// every 10 seconds update the agent status
 		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ticker.C:
 				// send agent status update
-				status := AgentIdle
+				status, reason := AgentIdle, reasonNoJobs
 				if terminator.totalJobs() > 0 {
-					status = AgentBusy
+					status, reason = AgentBusy, reasonRunningJobs
 				}
-				if err := d.agents.updateAgentStatus(ctx, agent.ID, status); err != nil {
+				response, err := d.agents.updateAgentStatus(ctx, agent.ID, status, reason)
+				if err != nil {
 					if ctx.Err() != nil {
 						// context canceled
 						return nil
@@ -254,6 +307,13 @@ func (d *daemon) Start(ctx context.Context) error {
 					}
 				} else {
 					d.poolLogger.Debug("sent agent status update", "status", status)
+					if response.DiagnosticsBundleID != "" {
+						bundleID := response.DiagnosticsBundleID
+						g.Go(func() error {
+							d.uploadDiagnostics(ctx, agent.ID, bundleID)
+							return nil
+						})
+					}
 				}
 			case <-ctx.Done():
 				// context canceled
@@ -262,6 +322,33 @@ func (d *daemon) Start(ctx context.Context) error {
 		}
 	})
 
+	g.Go(func() error {
+		// claim jobs for this agent, which atomically allocates them to the
+		// agent, rather than relying solely upon the allocator to allocate
+		// them. Claimed jobs are received and started below, along with jobs
+		// allocated by the allocator.
+		for {
+			claimJob := func() error {
+				job, err := d.agents.ClaimNextJob(ctx, agent.ID)
+				if err != nil {
+					return err
+				}
+				if job != nil {
+					d.poolLogger.Debug("claimed job", "job", job)
+				}
+				return nil
+			}
+			policy := backoff.WithContext(backoff.NewExponentialBackOff(), ctx)
+			_ = backoff.RetryNotify(claimJob, policy, func(err error, next time.Duration) {
+				d.poolLogger.Error("claiming job", "backoff", next, "err", err)
+			})
+			// only stop claiming if context is canceled
+			if ctx.Err() != nil {
+				return nil
+			}
+		}
+	})
+
 	g.Go(func() (err error) {
 		defer func() {
 			if terminator.totalJobs() > 0 {
@@ -313,8 +400,11 @@ func (d *daemon) Start(ctx context.Context) error {
 						terminator.checkIn(j.Spec, op)
 						op.logger.Debug("started job")
 						g.Go(func() error {
-							op.doAndFinish()
+							outcome := op.doAndFinish()
 							terminator.checkOut(op.job.Spec)
+							if d.diagnostics != nil {
+								d.diagnostics.recordJob(op.job.Spec, outcome)
+							}
 							return nil
 						})
 					} else if j.Signaled != nil {
@@ -334,7 +424,56 @@ func (d *daemon) Start(ctx context.Context) error {
 			}
 		}
 	})
-	return g.Wait()
+	err = g.Wait()
+
+	// deregister once in-progress jobs have finished, using a context that is
+	// still valid for a further 10 seconds unless daemon is forcefully
+	// shutdown.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	if deregisterErr := d.agents.deregisterAgent(ctx, agent.ID); deregisterErr != nil {
+		return errors.Join(err, fmt.Errorf("deregistering agent: %w", deregisterErr))
+	}
+	d.logger.Info("deregistered agent")
+	return err
+}
+
+// uploadDiagnostics collects a bundle of diagnostics and uploads it to the
+// server, or, if diagnostics are disabled or cannot be collected, informs the
+// server why not.
+func (d *daemon) uploadDiagnostics(ctx context.Context, agentID, bundleID string) {
+	d.poolLogger.Info("received request to collect diagnostics", "bundle_id", bundleID)
+	var opts uploadDiagnosticsOptions
+	if d.diagnostics == nil {
+		opts.Error = diagnosticsDisabledReason
+	} else if bundle, err := d.diagnostics.collect(ctx, agentID); err != nil {
+		opts.Error = fmt.Sprintf("collecting diagnostics: %s", err.Error())
+	} else {
+		opts.Bundle = bundle
+	}
+	if err := d.agents.uploadDiagnostics(ctx, bundleID, opts); err != nil {
+		d.poolLogger.Error("uploading diagnostics", "bundle_id", bundleID, "err", err)
+		return
+	}
+	d.poolLogger.Info("uploaded diagnostics", "bundle_id", bundleID, "size", len(opts.Bundle))
+}
+
+// diskCapacity determines the number of bytes free in the work directory, for
+// the agent to report upon registration. Returns nil if it cannot be
+// determined, in which case the allocator assumes the agent has sufficient
+// disk for its jobs.
+func (d *daemon) diskCapacity() *int64 {
+	dir := d.config.WorkDir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	free, err := freeDisk(dir)
+	if err != nil {
+		d.poolLogger.Warn("unable to determine disk capacity", "work_dir", dir, "err", err)
+		return nil
+	}
+	return &free
 }
 
 // Registered returns the daemon's corresponding agent on a channel once it has
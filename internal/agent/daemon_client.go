@@ -40,6 +40,7 @@ type (
 		UploadPlanFile(ctx context.Context, id string, plan []byte, format run.PlanFormat) error
 		GetLockFile(ctx context.Context, id string) ([]byte, error)
 		UploadLockFile(ctx context.Context, id string, lockFile []byte) error
+		UploadModuleManifest(ctx context.Context, id string, manifest []byte) error
 	}
 
 	workspaceClient interface {
@@ -53,7 +54,10 @@ type (
 	agentClient interface {
 		registerAgent(ctx context.Context, opts registerAgentOptions) (*Agent, error)
 		getAgentJobs(ctx context.Context, agentID string) ([]*Job, error)
-		updateAgentStatus(ctx context.Context, agentID string, status AgentStatus) error
+		ClaimNextJob(ctx context.Context, agentID string) (*Job, error)
+		updateAgentStatus(ctx context.Context, agentID string, status AgentStatus, reason string) (*statusUpdateResponse, error)
+		deregisterAgent(ctx context.Context, agentID string) error
+		uploadDiagnostics(ctx context.Context, bundleID string, opts uploadDiagnosticsOptions) error
 
 		startJob(ctx context.Context, spec JobSpec) ([]byte, error)
 		finishJob(ctx context.Context, spec JobSpec, opts finishJobOptions) error
@@ -70,6 +74,7 @@ type (
 
 	logsClient interface {
 		PutChunk(ctx context.Context, opts internal.PutChunkOptions) error
+		MarkLogsIncomplete(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream) error
 	}
 
 	hostnameClient interface {
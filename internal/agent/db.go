@@ -2,35 +2,54 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/tofutf/tofutf/internal"
+	otfrun "github.com/tofutf/tofutf/internal/run"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
 )
 
 // poolresult is the result of a database query for an agent pool
 type poolresult struct {
-	AgentPoolID         pgtype.Text        `json:"agent_pool_id"`
-	Name                pgtype.Text        `json:"name"`
-	CreatedAt           pgtype.Timestamptz `json:"created_at"`
-	OrganizationName    pgtype.Text        `json:"organization_name"`
-	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
-	WorkspaceIds        []string           `json:"workspace_ids"`
-	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	DeletedAt                pgtype.Timestamptz `json:"deleted_at"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
+	WorkspaceIds             []string           `json:"workspace_ids"`
+	AllowedWorkspaceIds      []string           `json:"allowed_workspace_ids"`
 }
 
 func (r poolresult) toPool() *Pool {
-	return &Pool{
+	pool := &Pool{
 		ID:                 r.AgentPoolID.String,
 		Name:               r.Name.String,
 		CreatedAt:          r.CreatedAt.Time.UTC(),
 		Organization:       r.OrganizationName.String,
 		OrganizationScoped: r.OrganizationScoped.Bool,
+		MinAgents:          int(r.MinAgents.Int32),
 		AssignedWorkspaces: r.WorkspaceIds,
 		AllowedWorkspaces:  r.AllowedWorkspaceIds,
 	}
+	if r.DeletedAt.Valid {
+		pool.DeletedAt = internal.Time(r.DeletedAt.Time.UTC())
+	}
+	if r.AllowedTerraformVersions.Valid {
+		pool.AllowedTerraformVersions = &r.AllowedTerraformVersions.String
+	}
+	return pool
 }
 
 // agentresult is the result of a database query for an agent
@@ -44,6 +63,9 @@ type agentresult struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 	CurrentJobs  pgtype.Int8        `json:"current_jobs"`
 }
 
@@ -58,35 +80,68 @@ func (r agentresult) toAgent() *Agent {
 		LastPingAt:   r.LastPingAt.Time.UTC(),
 		LastStatusAt: r.LastStatusAt.Time.UTC(),
 		Status:       AgentStatus(r.Status.String),
+		StatusReason: r.StatusReason.String,
 	}
 
 	if r.AgentPoolID.Valid {
 		agent.AgentPoolID = &r.AgentPoolID.String
 	}
+	if r.DiskCapacity.Valid {
+		agent.DiskCapacity = &r.DiskCapacity.Int64
+	}
+	// labels are only ever written by marshalLabels, so they are always a
+	// valid JSON object.
+	_ = unmarshalLabels(r.Labels, &agent.Labels)
 	return agent
 }
 
 // jobresult is the result of a database query for an job
 type jobresult struct {
-	RunID            pgtype.Text `json:"run_id"`
-	Phase            pgtype.Text `json:"phase"`
-	Status           pgtype.Text `json:"status"`
-	Signaled         pgtype.Bool `json:"signaled"`
-	AgentID          pgtype.Text `json:"agent_id"`
-	AgentPoolID      pgtype.Text `json:"agent_pool_id"`
-	WorkspaceID      pgtype.Text `json:"workspace_id"`
-	OrganizationName pgtype.Text `json:"organization_name"`
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
 }
 
-func (r jobresult) toJob() *Job {
+// toJob converts the row into a job, returning ErrMalformedJob if the row
+// does not describe a valid job.
+func (r jobresult) toJob() (*Job, error) {
+	if !strings.HasPrefix(r.RunID.String, "run-") {
+		return nil, fmt.Errorf("%w: invalid run ID: %q", ErrMalformedJob, r.RunID.String)
+	}
+	switch phase := internal.PhaseType(r.Phase.String); phase {
+	case internal.PlanPhase, internal.ApplyPhase:
+	default:
+		return nil, fmt.Errorf("%w: invalid phase: %q", ErrMalformedJob, phase)
+	}
+	switch status := JobStatus(r.Status.String); status {
+	case JobUnallocated, JobAllocated, JobRunning, JobFinished, JobErrored, JobCanceled:
+	default:
+		return nil, fmt.Errorf("%w: invalid status: %q", ErrMalformedJob, status)
+	}
 	job := &Job{
 		Spec: JobSpec{
 			RunID: r.RunID.String,
 			Phase: internal.PhaseType(r.Phase.String),
 		},
-		Status:       JobStatus(r.Status.String),
-		WorkspaceID:  r.WorkspaceID.String,
-		Organization: r.OrganizationName.String,
+		Status:           JobStatus(r.Status.String),
+		WorkspaceID:      r.WorkspaceID.String,
+		Organization:     r.OrganizationName.String,
+		TerraformVersion: r.TerraformVersion.String,
+		CreatedAt:        r.CreatedAt.Time.UTC(),
+		TraceContext:     r.TraceContext.String,
+		Priority:         otfrun.Priority(r.Priority.String),
 	}
 	if r.AgentID.Valid {
 		job.AgentID = &r.AgentID.String
@@ -97,7 +152,34 @@ func (r jobresult) toJob() *Job {
 	if r.Signaled.Valid {
 		job.Signaled = &r.Signaled.Bool
 	}
-	return job
+	if r.AllocatedAt.Valid {
+		job.AllocatedAt = internal.Time(r.AllocatedAt.Time.UTC())
+	}
+	if r.SlaBreachedAt.Valid {
+		job.SLABreachedAt = internal.Time(r.SlaBreachedAt.Time.UTC())
+	}
+	if err := unmarshalLabels(r.Labels, &job.Labels); err != nil {
+		return nil, fmt.Errorf("%w: invalid labels: %w", ErrMalformedJob, err)
+	}
+	return job, nil
+}
+
+// marshalLabels encodes labels for storage, returning nil if there are no
+// labels, which is stored as null.
+func marshalLabels(labels map[string]string) ([]byte, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(labels)
+}
+
+// unmarshalLabels decodes labels retrieved from storage, leaving dst untouched
+// if there are no labels.
+func unmarshalLabels(data []byte, dst *map[string]string) error {
+	if data == nil {
+		return nil
+	}
+	return json.Unmarshal(data, dst)
 }
 
 type agentTokenRow struct {
@@ -105,19 +187,98 @@ type agentTokenRow struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	Description  pgtype.Text        `json:"description"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
 }
 
 func (row agentTokenRow) toAgentToken() *agentToken {
-	return &agentToken{
+	at := &agentToken{
 		ID:          row.AgentTokenID.String,
 		CreatedAt:   row.CreatedAt.Time.UTC(),
 		Description: row.Description.String,
 		AgentPoolID: row.AgentPoolID.String,
 	}
+	if row.ExpiresAt.Valid {
+		t := row.ExpiresAt.Time.UTC()
+		at.ExpiresAt = &t
+	}
+	if row.LastUsedAt.Valid {
+		t := row.LastUsedAt.Time.UTC()
+		at.LastUsedAt = &t
+	}
+	return at
+}
+
+type bootstrapTokenRow struct {
+	AgentBootstrapTokenID pgtype.Text        `json:"agent_bootstrap_token_id"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	Description           pgtype.Text        `json:"description"`
+	AgentPoolID           pgtype.Text        `json:"agent_pool_id"`
+	ExpiresAt             pgtype.Timestamptz `json:"expires_at"`
+	MaxUses               pgtype.Int4        `json:"max_uses"`
+	Uses                  pgtype.Int4        `json:"uses"`
+}
+
+func (row bootstrapTokenRow) toBootstrapToken() *bootstrapToken {
+	bt := &bootstrapToken{
+		ID:          row.AgentBootstrapTokenID.String,
+		CreatedAt:   row.CreatedAt.Time.UTC(),
+		Description: row.Description.String,
+		AgentPoolID: row.AgentPoolID.String,
+		ExpiresAt:   row.ExpiresAt.Time.UTC(),
+		Uses:        int(row.Uses.Int32),
+	}
+	if row.MaxUses.Valid {
+		maxUses := int(row.MaxUses.Int32)
+		bt.MaxUses = &maxUses
+	}
+	return bt
+}
+
+// autoscalerRow is the result of a database query for an agent pool
+// autoscaler
+type autoscalerRow struct {
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WebhookURL       pgtype.Text        `json:"webhook_url"`
+	HmacSecret       pgtype.Text        `json:"hmac_secret"`
+	ScaleUpThreshold pgtype.Int4        `json:"scale_up_threshold"`
+	ScaleDownIdle    pgtype.Int4        `json:"scale_down_idle"`
+	Cooldown         pgtype.Int4        `json:"cooldown"`
+	DryRun           pgtype.Bool        `json:"dry_run"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (r autoscalerRow) toPoolAutoscaler() *PoolAutoscaler {
+	return &PoolAutoscaler{
+		AgentPoolID:      r.AgentPoolID.String,
+		WebhookURL:       r.WebhookURL.String,
+		HMACSecret:       r.HmacSecret.String,
+		ScaleUpThreshold: int(r.ScaleUpThreshold.Int32),
+		ScaleDownIdle:    time.Duration(r.ScaleDownIdle.Int32) * time.Second,
+		Cooldown:         time.Duration(r.Cooldown.Int32) * time.Second,
+		DryRun:           r.DryRun.Bool,
+		CreatedAt:        r.CreatedAt.Time.UTC(),
+		UpdatedAt:        r.UpdatedAt.Time.UTC(),
+	}
 }
 
 type db struct {
 	*sql.Pool
+
+	logger *slog.Logger
+}
+
+// appendJob converts the row into a job and appends it to jobs. A malformed row
+// is logged and skipped rather than failing the whole batch, so that one bad
+// record cannot, for instance, stall an agent's polling loop.
+func (db *db) appendJob(jobs []*Job, r jobresult) []*Job {
+	job, err := r.toJob()
+	if err != nil {
+		db.logger.Error("skipping malformed job", "run_id", r.RunID.String, "phase", r.Phase.String, "err", err)
+		return jobs
+	}
+	return append(jobs, job)
 }
 
 // pools
@@ -125,17 +286,19 @@ type db struct {
 func (db *db) createPool(ctx context.Context, pool *Pool) error {
 	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.InsertAgentPool(ctx, pggen.InsertAgentPoolParams{
-			AgentPoolID:        sql.String(pool.ID),
-			Name:               sql.String(pool.Name),
-			CreatedAt:          sql.Timestamptz(pool.CreatedAt),
-			OrganizationName:   sql.String(pool.Organization),
-			OrganizationScoped: sql.Bool(pool.OrganizationScoped),
+			AgentPoolID:              sql.String(pool.ID),
+			Name:                     sql.String(pool.Name),
+			CreatedAt:                sql.Timestamptz(pool.CreatedAt),
+			OrganizationName:         sql.String(pool.Organization),
+			OrganizationScoped:       sql.Bool(pool.OrganizationScoped),
+			AllowedTerraformVersions: sql.StringPtr(pool.AllowedTerraformVersions),
+			MinAgents:                sql.Int4(pool.MinAgents),
 		})
 		if err != nil {
 			return err
 		}
-		for _, workspaceID := range pool.AllowedWorkspaces {
-			_, err := q.InsertAgentPoolAllowedWorkspace(ctx, sql.String(pool.ID), sql.String(workspaceID))
+		if len(pool.AllowedWorkspaces) > 0 {
+			_, err := q.InsertAgentPoolAllowedWorkspaces(ctx, sql.String(pool.ID), pool.AllowedWorkspaces)
 			if err != nil {
 				return err
 			}
@@ -150,44 +313,94 @@ func (db *db) createPool(ctx context.Context, pool *Pool) error {
 	return nil
 }
 
-func (db *db) updatePool(ctx context.Context, pool *Pool) error {
-	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
-		_, err := q.UpdateAgentPool(ctx, pggen.UpdateAgentPoolParams{
-			PoolID:             sql.String(pool.ID),
-			Name:               sql.String(pool.Name),
-			OrganizationScoped: sql.Bool(pool.OrganizationScoped),
-		})
+// getPoolQuota retrieves the number of undeleted pools in an organization and
+// the organization's maximum number of pools, which is nil if the default
+// maximum applies.
+func (db *db) getPoolQuota(ctx context.Context, organization string) (pools int, max *int, err error) {
+	err = db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		result, err := q.FindAgentPoolQuota(ctx, sql.String(organization))
 		if err != nil {
 			return sql.Error(err)
 		}
-
+		pools = int(result.Pools.Int64)
+		if result.MaxAgentPools.Valid {
+			max = internal.Int(int(result.MaxAgentPools.Int32))
+		}
 		return nil
 	})
+	return pools, max, err
 }
 
-func (db *db) addAgentPoolAllowedWorkspace(ctx context.Context, poolID, workspaceID string) error {
+func (db *db) updatePool(ctx context.Context, pool *Pool) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
-		_, err := q.InsertAgentPoolAllowedWorkspace(ctx, sql.String(poolID), sql.String(workspaceID))
+		_, err := q.UpdateAgentPool(ctx, pggen.UpdateAgentPoolParams{
+			PoolID:                   sql.String(pool.ID),
+			Name:                     sql.String(pool.Name),
+			OrganizationScoped:       sql.Bool(pool.OrganizationScoped),
+			AllowedTerraformVersions: sql.StringPtr(pool.AllowedTerraformVersions),
+			MinAgents:                sql.Int4(pool.MinAgents),
+		})
 		if err != nil {
-			return err
+			return sql.Error(err)
 		}
 
 		return nil
 	})
 }
 
-func (db *db) deleteAgentPoolAllowedWorkspace(ctx context.Context, poolID, workspaceID string) error {
-	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
-		_, err := q.DeleteAgentPoolAllowedWorkspace(ctx, sql.String(poolID), sql.String(workspaceID))
+// listWorkspacesLosingPoolAccess lists the workspaces assigned to the pool
+// that are not allowed to access the pool as it is given, i.e. those that
+// would lose access were the pool to be updated as such.
+func (db *db) listWorkspacesLosingPoolAccess(ctx context.Context, pool *Pool) ([]ImpactedWorkspace, error) {
+	if pool.OrganizationScoped {
+		return nil, nil
+	}
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]ImpactedWorkspace, error) {
+		rows, err := q.FindAgentPoolAssignedWorkspacesNotAllowed(ctx, sql.String(pool.ID), pool.AllowedWorkspaces)
 		if err != nil {
-			return err
+			return nil, sql.Error(err)
 		}
 
-		return nil
+		impacted := make([]ImpactedWorkspace, len(rows))
+		for i, r := range rows {
+			impacted[i] = ImpactedWorkspace{ID: r.WorkspaceID.String, Name: r.Name.String}
+		}
+
+		return impacted, nil
+	})
+}
+
+// updateAgentPoolAllowedWorkspaces updates the workspaces allowed to use a
+// pool from those currently allowed to those desired.
+func (db *db) updateAgentPoolAllowedWorkspaces(ctx context.Context, poolID string, current, desired []string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		return sql.Reconcile(ctx, current, desired,
+			func(ctx context.Context, workspaceIDs []string) error {
+				_, err := q.InsertAgentPoolAllowedWorkspaces(ctx, sql.String(poolID), workspaceIDs)
+				return sql.Error(err)
+			},
+			func(ctx context.Context, workspaceIDs []string) error {
+				_, err := q.DeleteAgentPoolAllowedWorkspaces(ctx, sql.String(poolID), workspaceIDs)
+				return sql.Error(err)
+			},
+		)
 	})
 }
 
+// getPool retrieves a pool, treating a deleted pool as not found.
 func (db *db) getPool(ctx context.Context, poolID string) (*Pool, error) {
+	pool, err := db.findPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if pool.DeletedAt != nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	return pool, nil
+}
+
+// findPool retrieves a pool regardless of whether it has been deleted.
+func (db *db) findPool(ctx context.Context, poolID string) (*Pool, error) {
 	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Pool, error) {
 		result, err := q.FindAgentPool(ctx, sql.String(poolID))
 		if err != nil {
@@ -198,6 +411,22 @@ func (db *db) getPool(ctx context.Context, poolID string) (*Pool, error) {
 	})
 }
 
+func (db *db) getPools(ctx context.Context, poolIDs []string) ([]*Pool, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Pool, error) {
+		rows, err := q.FindAgentPoolsByIDs(ctx, poolIDs)
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		pools := make([]*Pool, len(rows))
+		for i, r := range rows {
+			pools[i] = poolresult(r).toPool()
+		}
+
+		return pools, nil
+	})
+}
+
 func (db *db) getPoolByTokenID(ctx context.Context, tokenID string) (*Pool, error) {
 	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Pool, error) {
 		result, err := q.FindAgentPoolByAgentTokenID(ctx, sql.String(tokenID))
@@ -246,6 +475,52 @@ func (db *db) listPoolsByOrganization(ctx context.Context, organization string,
 	})
 }
 
+func (db *db) listDeletedPoolsByOrganization(ctx context.Context, organization string) ([]*Pool, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Pool, error) {
+		rows, err := q.FindDeletedAgentPoolsByOrganization(ctx, sql.String(organization))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		pools := make([]*Pool, len(rows))
+		for i, r := range rows {
+			pools[i] = poolresult(r).toPool()
+		}
+
+		return pools, nil
+	})
+}
+
+// listPoolsDeletedBefore lists the IDs of pools deleted before the given
+// time.
+func (db *db) listPoolsDeletedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]string, error) {
+		rows, err := q.FindAgentPoolIDsDeletedBefore(ctx, sql.Timestamptz(before))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		poolIDs := make([]string, len(rows))
+		for i, r := range rows {
+			poolIDs[i] = r.String
+		}
+
+		return poolIDs, nil
+	})
+}
+
+// updatePoolDeletedAt sets when a pool was deleted, or unsets it if deletedAt
+// is nil.
+func (db *db) updatePoolDeletedAt(ctx context.Context, poolID string, deletedAt *time.Time) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpdateAgentPoolDeletedAt(ctx, sql.TimestamptzPtr(deletedAt), sql.String(poolID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
 func (db *db) deleteAgentPool(ctx context.Context, poolID string) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.DeleteAgentPool(ctx, sql.String(poolID))
@@ -259,7 +534,11 @@ func (db *db) deleteAgentPool(ctx context.Context, poolID string) error {
 // agents
 
 func (db *db) createAgent(ctx context.Context, agent *Agent) error {
-	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+	labels, err := marshalLabels(agent.Labels)
+	if err != nil {
+		return err
+	}
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.InsertAgent(ctx, pggen.InsertAgentParams{
 			AgentID:      sql.String(agent.ID),
 			Name:         sql.String(agent.Name),
@@ -270,9 +549,14 @@ func (db *db) createAgent(ctx context.Context, agent *Agent) error {
 			LastPingAt:   sql.Timestamptz(agent.LastPingAt),
 			LastStatusAt: sql.Timestamptz(agent.LastStatusAt),
 			AgentPoolID:  sql.StringPtr(agent.AgentPoolID),
+			DiskCapacity: sql.Int64Ptr(agent.DiskCapacity),
+			StatusReason: sql.String(agent.StatusReason),
+			Labels:       labels,
 		})
-
-		return err
+		if err != nil {
+			return err
+		}
+		return insertStatusChange(ctx, q, agent)
 	})
 }
 
@@ -283,6 +567,7 @@ func (db *db) updateAgent(ctx context.Context, agentID string, fn func(*Agent) e
 			return err
 		}
 		agent := agentresult(result).toAgent()
+		from := agent.Status
 		if err := fn(agent); err != nil {
 			return err
 		}
@@ -291,8 +576,16 @@ func (db *db) updateAgent(ctx context.Context, agentID string, fn func(*Agent) e
 			Status:       sql.String(string(agent.Status)),
 			LastPingAt:   sql.Timestamptz(agent.LastPingAt),
 			LastStatusAt: sql.Timestamptz(agent.LastStatusAt),
+			StatusReason: sql.String(agent.StatusReason),
 		})
-		return err
+		if err != nil {
+			return err
+		}
+		if agent.Status == from {
+			// only changes in status are recorded, not pings
+			return nil
+		}
+		return insertStatusChange(ctx, q, agent)
 	})
 	if err != nil {
 		return sql.Error(err)
@@ -300,6 +593,56 @@ func (db *db) updateAgent(ctx context.Context, agentID string, fn func(*Agent) e
 	return nil
 }
 
+// insertStatusChange records the agent's current status in its status history,
+// discarding the oldest changes in excess of maxStatusHistory.
+func insertStatusChange(ctx context.Context, q pggen.Querier, agent *Agent) error {
+	_, err := q.InsertAgentStatusHistory(ctx, pggen.InsertAgentStatusHistoryParams{
+		AgentID:   sql.String(agent.ID),
+		Status:    sql.String(string(agent.Status)),
+		ChangedAt: sql.Timestamptz(agent.LastStatusAt),
+		Reason:    sql.String(agent.StatusReason),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = q.TrimAgentStatusHistory(ctx, sql.String(agent.ID), sql.Int8(maxStatusHistory))
+	return err
+}
+
+func (db *db) listStatusHistory(ctx context.Context, agentID string) ([]*StatusChange, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*StatusChange, error) {
+		rows, err := q.FindAgentStatusHistory(ctx, sql.String(agentID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		changes := make([]*StatusChange, len(rows))
+		for i, r := range rows {
+			changes[i] = &StatusChange{
+				ID:        strconv.FormatInt(r.AgentStatusHistoryID.Int64, 10),
+				Status:    AgentStatus(r.Status.String),
+				ChangedAt: r.ChangedAt.Time.UTC(),
+				Reason:    r.Reason.String,
+			}
+		}
+
+		return changes, nil
+	})
+}
+
+// deleteStatusHistoryBefore deletes up to limit status changes made before the
+// given time, other than the most recent change of each agent, returning the
+// number deleted.
+func (db *db) deleteStatusHistoryBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (int64, error) {
+		tag, err := q.DeleteAgentStatusHistoryBefore(ctx, sql.Timestamptz(before), sql.Int8(limit))
+		if err != nil {
+			return 0, sql.Error(err)
+		}
+		return tag.RowsAffected(), nil
+	})
+}
+
 func (db *db) getAgent(ctx context.Context, agentID string) (*Agent, error) {
 	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Agent, error) {
 		result, err := q.FindAgentByID(ctx, sql.String(agentID))
@@ -382,11 +725,19 @@ func (db *db) deleteAgent(ctx context.Context, agentID string) error {
 }
 
 func (db *db) createJob(ctx context.Context, job *Job) error {
+	labels, err := marshalLabels(job.Labels)
+	if err != nil {
+		return err
+	}
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.InsertJob(ctx, pggen.InsertJobParams{
-			RunID:  sql.String(job.Spec.RunID),
-			Phase:  sql.String(string(job.Spec.Phase)),
-			Status: sql.String(string(job.Status)),
+			RunID:        sql.String(job.Spec.RunID),
+			Phase:        sql.String(string(job.Spec.Phase)),
+			Status:       sql.String(string(job.Status)),
+			AgentPoolID:  sql.StringPtr(job.AgentPoolID),
+			CreatedAt:    sql.Timestamptz(job.CreatedAt),
+			TraceContext: sql.String(job.TraceContext),
+			Labels:       labels,
 		})
 		return sql.Error(err)
 	})
@@ -404,13 +755,13 @@ func (db *db) getAllocatedAndSignaledJobs(ctx context.Context, agentID string) (
 			return nil, sql.Error(err)
 		}
 
-		jobs := make([]*Job, len(allocated)+len(signaled))
-		for i, r := range allocated {
-			jobs[i] = jobresult(r).toJob()
+		jobs := make([]*Job, 0, len(allocated)+len(signaled))
+		for _, r := range allocated {
+			jobs = db.appendJob(jobs, jobresult(r))
 		}
 
-		for i, r := range signaled {
-			jobs[len(allocated)+i] = jobresult(r).toJob()
+		for _, r := range signaled {
+			jobs = db.appendJob(jobs, jobresult(r))
 		}
 
 		return jobs, nil
@@ -424,7 +775,7 @@ func (db *db) getJob(ctx context.Context, spec JobSpec) (*Job, error) {
 			return nil, sql.Error(err)
 		}
 
-		return jobresult(result).toJob(), nil
+		return jobresult(result).toJob()
 	})
 }
 
@@ -435,15 +786,116 @@ func (db *db) listJobs(ctx context.Context) ([]*Job, error) {
 			return nil, sql.Error(err)
 		}
 
-		jobs := make([]*Job, len(rows))
-		for i, r := range rows {
-			jobs[i] = jobresult(r).toJob()
+		jobs := make([]*Job, 0, len(rows))
+		for _, r := range rows {
+			jobs = db.appendJob(jobs, jobresult(r))
+		}
+
+		return jobs, nil
+	})
+}
+
+// listQueuedJobsByPool lists jobs assigned to the pool that have yet to
+// start, i.e. jobs that are either unallocated or allocated.
+func (db *db) listQueuedJobsByPool(ctx context.Context, poolID string) ([]*Job, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Job, error) {
+		rows, err := q.FindQueuedJobsByAgentPoolID(ctx, sql.String(poolID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		jobs := make([]*Job, 0, len(rows))
+		for _, r := range rows {
+			jobs = db.appendJob(jobs, jobresult(r))
+		}
+
+		return jobs, nil
+	})
+}
+
+// listActiveJobsByAgent lists jobs allocated to the agent that have yet to
+// complete, i.e. jobs that are either allocated or running.
+func (db *db) listActiveJobsByAgent(ctx context.Context, agentID string) ([]*Job, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Job, error) {
+		rows, err := q.FindActiveJobsByAgentID(ctx, sql.String(agentID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		jobs := make([]*Job, 0, len(rows))
+		for _, r := range rows {
+			jobs = db.appendJob(jobs, jobresult(r))
 		}
 
 		return jobs, nil
 	})
 }
 
+// markQueueSLABreaches marks unallocated jobs that have waited longer than
+// their organization's queue time SLA as having breached the SLA, returning the
+// newly breached jobs.
+func (db *db) markQueueSLABreaches(ctx context.Context, now time.Time) ([]*Job, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Job, error) {
+		rows, err := q.UpdateQueueSLABreachedJobs(ctx, sql.Timestamptz(now))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		jobs := make([]*Job, 0, len(rows))
+		for _, r := range rows {
+			jobs = db.appendJob(jobs, jobresult(r))
+		}
+
+		return jobs, nil
+	})
+}
+
+// listQueueSLABreaches lists jobs in the organization that have breached the
+// organization's queue time SLA, most recent breach first.
+func (db *db) listQueueSLABreaches(ctx context.Context, organization string) ([]*Job, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Job, error) {
+		rows, err := q.FindQueueSLABreachedJobsByOrganization(ctx, sql.String(organization))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		jobs := make([]*Job, 0, len(rows))
+		for _, r := range rows {
+			jobs = db.appendJob(jobs, jobresult(r))
+		}
+
+		return jobs, nil
+	})
+}
+
+// countJobs counts the jobs in an organization with each status and agent
+// pool, only counting jobs with all of the labels.
+func (db *db) countJobs(ctx context.Context, organization string, labels map[string]string) ([]jobCount, error) {
+	encoded, err := marshalLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]jobCount, error) {
+		rows, err := q.CountJobsByOrganization(ctx, sql.String(organization), encoded)
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		counts := make([]jobCount, len(rows))
+		for i, r := range rows {
+			counts[i] = jobCount{
+				Status: JobStatus(r.Status.String),
+				Count:  int(r.Count.Int32),
+			}
+			if r.AgentPoolID.Valid {
+				counts[i].AgentPoolID = internal.String(r.AgentPoolID.String)
+			}
+		}
+
+		return counts, nil
+	})
+}
+
 func (db *db) updateJob(ctx context.Context, spec JobSpec, fn func(*Job) error) (*Job, error) {
 	job, err := sql.Tx(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Job, error) {
 		result, err := q.FindJobForUpdate(ctx, sql.String(spec.RunID), sql.String(string(spec.Phase)))
@@ -451,17 +903,137 @@ func (db *db) updateJob(ctx context.Context, spec JobSpec, fn func(*Job) error)
 			return nil, err
 		}
 
-		job := jobresult(result).toJob()
+		job, err := jobresult(result).toJob()
+		if err != nil {
+			return nil, err
+		}
 		if err := fn(job); err != nil {
 			return nil, err
 		}
 
 		_, err = q.UpdateJob(ctx, pggen.UpdateJobParams{
-			Status:   sql.String(string(job.Status)),
-			Signaled: sql.BoolPtr(job.Signaled),
-			AgentID:  sql.StringPtr(job.AgentID),
-			RunID:    result.RunID,
-			Phase:    result.Phase,
+			Status:      sql.String(string(job.Status)),
+			Signaled:    sql.BoolPtr(job.Signaled),
+			AgentID:     sql.StringPtr(job.AgentID),
+			AgentPoolID: sql.StringPtr(job.AgentPoolID),
+			AllocatedAt: sql.TimestamptzPtr(job.AllocatedAt),
+			RunID:       result.RunID,
+			Phase:       result.Phase,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return job, nil
+	})
+	if err != nil {
+		return nil, sql.Error(err)
+	}
+	return job, nil
+}
+
+// claimNextJob atomically allocates the next unallocated job eligible for an
+// agent to the agent, returning nil if there is no such job or the agent has
+// no spare capacity, including too little free disk space for another job
+// according to diskEstimate. Jobs of high priority runs, and jobs that have
+// waited longer than the aging period, are claimed ahead of other jobs. The
+// agent is locked for the duration, serializing its claims, and jobs locked by
+// concurrent claims are skipped, so no two agents can claim the same job. A
+// job requiring a terraform version for which unavailable returns true is
+// not claimed.
+func (db *db) claimNextJob(ctx context.Context, agentID string, diskEstimate int64, aging time.Duration, unavailable func(context.Context, string) bool) (*Job, error) {
+	job, err := sql.Tx(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Job, error) {
+		agentResult, err := q.FindAgentByIDForUpdate(ctx, sql.String(agentID))
+		if err != nil {
+			return nil, err
+		}
+		agent := agentresult(agentResult).toAgent()
+		if agent.Status != AgentIdle && agent.Status != AgentBusy {
+			return nil, nil
+		}
+		if agent.CurrentJobs >= agent.MaxJobs {
+			return nil, nil
+		}
+		if !agent.hasDiskFor(diskEstimate) {
+			return nil, nil
+		}
+		// a pool agent is subject to the policies of its pool
+		var pool *Pool
+		if agent.AgentPoolID != nil {
+			poolResult, err := q.FindAgentPool(ctx, sql.String(*agent.AgentPoolID))
+			if err != nil {
+				return nil, err
+			}
+			pool = poolresult(poolResult).toPool()
+
+			// hold jobs until enough of the pool's agents are ready
+			if pool.MinAgents > 0 {
+				agentResults, err := q.FindAgentsByPoolID(ctx, sql.String(pool.ID))
+				if err != nil {
+					return nil, err
+				}
+				var ready int
+				for _, r := range agentResults {
+					poolAgent := agentresult(r).toAgent()
+					if poolAgent.Status == AgentIdle || poolAgent.Status == AgentBusy {
+						ready++
+					}
+				}
+				if ready < pool.MinAgents {
+					return nil, nil
+				}
+			}
+		}
+
+		// an agent with labels only claims jobs with all of its labels
+		labels, err := marshalLabels(agent.Labels)
+		if err != nil {
+			return nil, err
+		}
+		// a zero promote_before time promotes no jobs
+		var promoteBefore time.Time
+		if aging > 0 {
+			promoteBefore = internal.CurrentTimestamp(nil).Add(-aging)
+		}
+		result, err := q.FindNextUnallocatedJobForUpdate(ctx, pggen.FindNextUnallocatedJobForUpdateParams{
+			AgentPoolID:   sql.StringPtr(agent.AgentPoolID),
+			Labels:        labels,
+			PromoteBefore: sql.Timestamptz(promoteBefore),
+		})
+		if err != nil {
+			if errors.Is(sql.Error(err), internal.ErrResourceNotFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		job, err := jobresult(result).toJob()
+		if err != nil {
+			return nil, err
+		}
+		if job.TerraformVersion != "" {
+			// leave a job requiring a version that is not allowed by its
+			// pool, or that cannot be installed, unallocated for the
+			// allocator to reject
+			if pool != nil && pool.CheckTerraformVersion(job.TerraformVersion) != nil {
+				return nil, nil
+			}
+			if unavailable(ctx, job.TerraformVersion) {
+				return nil, nil
+			}
+		}
+		if err := job.allocate(agentID); err != nil {
+			return nil, err
+		}
+		job.AllocatedAt = internal.Time(internal.CurrentTimestamp(nil))
+
+		_, err = q.UpdateJob(ctx, pggen.UpdateJobParams{
+			Status:      sql.String(string(job.Status)),
+			Signaled:    sql.BoolPtr(job.Signaled),
+			AgentID:     sql.StringPtr(job.AgentID),
+			AgentPoolID: sql.StringPtr(job.AgentPoolID),
+			AllocatedAt: sql.TimestamptzPtr(job.AllocatedAt),
+			RunID:       result.RunID,
+			Phase:       result.Phase,
 		})
 		if err != nil {
 			return nil, err
@@ -477,6 +1049,38 @@ func (db *db) updateJob(ctx context.Context, spec JobSpec, fn func(*Job) error)
 
 // agent tokens
 
+func (db *db) upsertAllocatorStatus(ctx context.Context, status *AllocatorStatus) error {
+	encoded, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertAllocatorStatus(ctx, sql.Timestamptz(status.LastAllocatedAt), encoded)
+		return sql.Error(err)
+	})
+}
+
+// getAllocatorStatus retrieves the status recorded by the allocator. If the
+// allocator is yet to record its status then an empty status is returned.
+func (db *db) getAllocatorStatus(ctx context.Context) (*AllocatorStatus, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*AllocatorStatus, error) {
+		result, err := q.FindAllocatorStatus(ctx)
+		if err != nil {
+			err = sql.Error(err)
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				return &AllocatorStatus{}, nil
+			}
+			return nil, err
+		}
+		var status AllocatorStatus
+		if err := json.Unmarshal(result.Status, &status); err != nil {
+			return nil, err
+		}
+		status.LastAllocatedAt = result.LastAllocatedAt.Time.UTC()
+		return &status, nil
+	})
+}
+
 func (db *db) createAgentToken(ctx context.Context, token *agentToken) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.InsertAgentToken(ctx, pggen.InsertAgentTokenParams{
@@ -484,6 +1088,7 @@ func (db *db) createAgentToken(ctx context.Context, token *agentToken) error {
 			Description:  sql.String(token.Description),
 			AgentPoolID:  sql.String(token.AgentPoolID),
 			CreatedAt:    sql.Timestamptz(token.CreatedAt.UTC()),
+			ExpiresAt:    sql.TimestamptzPtr(token.ExpiresAt),
 		})
 		return err
 	})
@@ -516,6 +1121,45 @@ func (db *db) listAgentTokens(ctx context.Context, poolID string) ([]*agentToken
 	})
 }
 
+// listExpiringAgentTokens lists the agent tokens in an organization that expire
+// before the given time, including those that have already expired, ordered by
+// expiry.
+func (db *db) listExpiringAgentTokens(ctx context.Context, organization string, before time.Time) ([]*expiringAgentToken, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*expiringAgentToken, error) {
+		rows, err := q.FindExpiringAgentTokensByOrganization(ctx, sql.String(organization), sql.Timestamptz(before))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		tokens := make([]*expiringAgentToken, len(rows))
+		for i, r := range rows {
+			tokens[i] = &expiringAgentToken{
+				agentToken: agentTokenRow{
+					AgentTokenID: r.AgentTokenID,
+					CreatedAt:    r.CreatedAt,
+					Description:  r.Description,
+					AgentPoolID:  r.AgentPoolID,
+					ExpiresAt:    r.ExpiresAt,
+					LastUsedAt:   r.LastUsedAt,
+				}.toAgentToken(),
+				AgentPoolName: r.AgentPoolName.String,
+			}
+		}
+
+		return tokens, nil
+	})
+}
+
+// updateAgentTokenLastUsedAt records when an agent token was last used. To
+// avoid a write on every request, it is only updated if it was last updated
+// more than a minute ago.
+func (db *db) updateAgentTokenLastUsedAt(ctx context.Context, id string, at time.Time) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpdateAgentTokenLastUsedAt(ctx, sql.Timestamptz(at), sql.String(id))
+		return sql.Error(err)
+	})
+}
+
 func (db *db) deleteAgentToken(ctx context.Context, id string) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.DeleteAgentTokenByID(ctx, sql.String(id))
@@ -526,3 +1170,407 @@ func (db *db) deleteAgentToken(ctx context.Context, id string) error {
 		return nil
 	})
 }
+
+// bootstrap tokens
+
+func (db *db) createBootstrapToken(ctx context.Context, token *bootstrapToken) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertAgentBootstrapToken(ctx, pggen.InsertAgentBootstrapTokenParams{
+			AgentBootstrapTokenID: sql.String(token.ID),
+			CreatedAt:             sql.Timestamptz(token.CreatedAt.UTC()),
+			Description:           sql.String(token.Description),
+			AgentPoolID:           sql.String(token.AgentPoolID),
+			ExpiresAt:             sql.Timestamptz(token.ExpiresAt.UTC()),
+			MaxUses:               sql.Int4Ptr(token.MaxUses),
+		})
+		return err
+	})
+}
+
+func (db *db) getBootstrapToken(ctx context.Context, id string) (*bootstrapToken, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*bootstrapToken, error) {
+		r, err := q.FindAgentBootstrapTokenByID(ctx, sql.String(id))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return bootstrapTokenRow(r).toBootstrapToken(), nil
+	})
+}
+
+func (db *db) listBootstrapTokens(ctx context.Context, poolID string) ([]*bootstrapToken, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*bootstrapToken, error) {
+		rows, err := q.FindAgentBootstrapTokensByAgentPoolID(ctx, sql.String(poolID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		tokens := make([]*bootstrapToken, len(rows))
+		for i, r := range rows {
+			tokens[i] = bootstrapTokenRow(r).toBootstrapToken()
+		}
+
+		return tokens, nil
+	})
+}
+
+// useBootstrapToken records a use of a bootstrap token, returning
+// ErrBootstrapTokenUsedUp if the token has expired or has already been used
+// the maximum number of times.
+func (db *db) useBootstrapToken(ctx context.Context, id string, now time.Time) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UseAgentBootstrapToken(ctx, sql.String(id), sql.Timestamptz(now))
+		if err != nil {
+			if sql.NoRowsInResultError(err) {
+				return ErrBootstrapTokenUsedUp
+			}
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+func (db *db) deleteBootstrapToken(ctx context.Context, id string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteAgentBootstrapTokenByID(ctx, sql.String(id))
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+// agent credentials
+
+func (db *db) createAgentCredential(ctx context.Context, cred *agentCredential) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertAgentCredential(ctx, pggen.InsertAgentCredentialParams{
+			AgentID:               sql.String(cred.AgentID),
+			AgentBootstrapTokenID: sql.String(cred.BootstrapTokenID),
+			TokenHash:             sql.String(cred.TokenHash),
+			CreatedAt:             sql.Timestamptz(cred.CreatedAt.UTC()),
+		})
+		return err
+	})
+}
+
+func (db *db) getAgentCredential(ctx context.Context, agentID string) (*agentCredential, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*agentCredential, error) {
+		r, err := q.FindAgentCredentialByAgentID(ctx, sql.String(agentID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return &agentCredential{
+			AgentID:          r.AgentID.String,
+			BootstrapTokenID: r.AgentBootstrapTokenID.String,
+			TokenHash:        r.TokenHash.String,
+			CreatedAt:        r.CreatedAt.Time.UTC(),
+		}, nil
+	})
+}
+
+// listAgentCredentials lists the IDs of the agents in an organization that
+// have been issued their own token.
+func (db *db) listAgentCredentials(ctx context.Context, organization string) ([]string, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]string, error) {
+		rows, err := q.FindAgentCredentialAgentIDsByOrganization(ctx, sql.String(organization))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		agentIDs := make([]string, len(rows))
+		for i, r := range rows {
+			agentIDs[i] = r.String
+		}
+
+		return agentIDs, nil
+	})
+}
+
+func (db *db) deleteAgentCredential(ctx context.Context, agentID string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteAgentCredentialByAgentID(ctx, sql.String(agentID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+// autoscalers
+
+func (db *db) upsertPoolAutoscaler(ctx context.Context, as *PoolAutoscaler) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertAgentPoolAutoscaler(ctx, pggen.UpsertAgentPoolAutoscalerParams{
+			AgentPoolID:      sql.String(as.AgentPoolID),
+			WebhookURL:       sql.String(as.WebhookURL),
+			HmacSecret:       sql.String(as.HMACSecret),
+			ScaleUpThreshold: sql.Int4(as.ScaleUpThreshold),
+			ScaleDownIdle:    sql.Int4(int(as.ScaleDownIdle.Seconds())),
+			Cooldown:         sql.Int4(int(as.Cooldown.Seconds())),
+			DryRun:           sql.Bool(as.DryRun),
+			CreatedAt:        sql.Timestamptz(as.CreatedAt),
+			UpdatedAt:        sql.Timestamptz(as.UpdatedAt),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *db) getPoolAutoscaler(ctx context.Context, poolID string) (*PoolAutoscaler, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*PoolAutoscaler, error) {
+		r, err := q.FindAgentPoolAutoscaler(ctx, sql.String(poolID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		return autoscalerRow(r).toPoolAutoscaler(), nil
+	})
+}
+
+// listPoolAutoscalers lists all autoscalers, along with the name and
+// organization of their pool, and when each last successfully made a scaling
+// decision.
+func (db *db) listPoolAutoscalers(ctx context.Context) ([]*PoolAutoscaler, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*PoolAutoscaler, error) {
+		rows, err := q.FindAgentPoolAutoscalers(ctx)
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		autoscalers := make([]*PoolAutoscaler, len(rows))
+		for i, r := range rows {
+			as := autoscalerRow{
+				AgentPoolID:      r.AgentPoolID,
+				WebhookURL:       r.WebhookURL,
+				HmacSecret:       r.HmacSecret,
+				ScaleUpThreshold: r.ScaleUpThreshold,
+				ScaleDownIdle:    r.ScaleDownIdle,
+				Cooldown:         r.Cooldown,
+				DryRun:           r.DryRun,
+				CreatedAt:        r.CreatedAt,
+				UpdatedAt:        r.UpdatedAt,
+			}.toPoolAutoscaler()
+			as.poolName = r.AgentPoolName.String
+			as.organization = r.OrganizationName.String
+			if r.LastDecidedAt.Valid {
+				t := r.LastDecidedAt.Time.UTC()
+				as.lastDecidedAt = &t
+			}
+			autoscalers[i] = as
+		}
+
+		return autoscalers, nil
+	})
+}
+
+func (db *db) deletePoolAutoscaler(ctx context.Context, poolID string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteAgentPoolAutoscaler(ctx, sql.String(poolID))
+		return sql.Error(err)
+	})
+}
+
+// recordScalingDecision inserts a scaling decision, discarding the pool's
+// oldest decisions beyond the maximum retained.
+func (db *db) recordScalingDecision(ctx context.Context, decision *ScalingDecision) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertAgentPoolScalingDecision(ctx, pggen.InsertAgentPoolScalingDecisionParams{
+			AgentPoolID:     sql.String(decision.AgentPoolID),
+			CurrentAgents:   sql.Int4(decision.CurrentAgents),
+			UnallocatedJobs: sql.Int4(decision.UnallocatedJobs),
+			IdleAgents:      sql.Int4(decision.IdleAgents),
+			DesiredDelta:    sql.Int4(decision.DesiredDelta),
+			DryRun:          sql.Bool(decision.DryRun),
+			Error:           sql.StringPtr(decision.Error),
+			DecidedAt:       sql.Timestamptz(decision.DecidedAt),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		_, err = q.TrimAgentPoolScalingDecisions(ctx, sql.String(decision.AgentPoolID), sql.Int8(maxScalingDecisions))
+		return sql.Error(err)
+	})
+}
+
+// listScalingDecisions lists a pool's scaling decisions, most recent first.
+func (db *db) listScalingDecisions(ctx context.Context, poolID string) ([]*ScalingDecision, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*ScalingDecision, error) {
+		rows, err := q.FindAgentPoolScalingDecisions(ctx, sql.String(poolID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		decisions := make([]*ScalingDecision, len(rows))
+		for i, r := range rows {
+			decisions[i] = &ScalingDecision{
+				ID:              strconv.Itoa(int(r.ScalingDecisionID.Int32)),
+				AgentPoolID:     r.AgentPoolID.String,
+				CurrentAgents:   int(r.CurrentAgents.Int32),
+				UnallocatedJobs: int(r.UnallocatedJobs.Int32),
+				IdleAgents:      int(r.IdleAgents.Int32),
+				DesiredDelta:    int(r.DesiredDelta.Int32),
+				DryRun:          r.DryRun.Bool,
+				DecidedAt:       r.DecidedAt.Time.UTC(),
+			}
+			if r.Error.Valid {
+				decisions[i].Error = &r.Error.String
+			}
+		}
+
+		return decisions, nil
+	})
+}
+
+// listOrphanedJobs lists jobs whose run no longer exists.
+func (db *db) listOrphanedJobs(ctx context.Context) ([]*OrphanedJob, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*OrphanedJob, error) {
+		rows, err := q.FindOrphanedJobs(ctx)
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		jobs := make([]*OrphanedJob, len(rows))
+		for i, r := range rows {
+			jobs[i] = &OrphanedJob{
+				Spec: JobSpec{
+					RunID: r.RunID.String,
+					Phase: internal.PhaseType(r.Phase.String),
+				},
+				Status: JobStatus(r.Status.String),
+			}
+			if r.AgentID.Valid {
+				jobs[i].AgentID = &r.AgentID.String
+			}
+		}
+
+		return jobs, nil
+	})
+}
+
+// deleteOrphanedJob deletes a job, but only if its run no longer exists.
+func (db *db) deleteOrphanedJob(ctx context.Context, spec JobSpec) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteOrphanedJob(ctx, sql.String(spec.RunID), sql.String(string(spec.Phase)))
+		if err != nil {
+			return sql.Error(err)
+		}
+
+		return nil
+	})
+}
+
+// diagnostics bundles
+
+func (db *db) createDiagnosticsBundle(ctx context.Context, bundle *DiagnosticsBundle) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertAgentDiagnosticsBundle(ctx, pggen.InsertAgentDiagnosticsBundleParams{
+			AgentDiagnosticsBundleID: sql.String(bundle.ID),
+			AgentID:                  sql.String(bundle.AgentID),
+			RequestedBy:              sql.String(bundle.RequestedBy),
+			RequestedAt:              sql.Timestamptz(bundle.RequestedAt),
+		})
+		return sql.Error(err)
+	})
+}
+
+// claimDiagnosticsBundle marks an agent's undelivered request for diagnostics
+// as delivered, returning its ID, or an empty string if there is no such
+// request.
+func (db *db) claimDiagnosticsBundle(ctx context.Context, agentID string, now time.Time) (string, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (string, error) {
+		ids, err := q.ClaimAgentDiagnosticsBundle(ctx, sql.Timestamptz(now), sql.String(agentID))
+		if err != nil {
+			return "", sql.Error(err)
+		}
+		if len(ids) == 0 {
+			return "", nil
+		}
+		return ids[0].String, nil
+	})
+}
+
+// uploadDiagnosticsBundle stores the outcome of a request for diagnostics
+// delivered to the agent, discarding the agent's oldest bundles in excess of
+// maxDiagnosticsBundles.
+func (db *db) uploadDiagnosticsBundle(ctx context.Context, bundleID, agentID string, now time.Time, opts uploadDiagnosticsOptions) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		params := pggen.UploadAgentDiagnosticsBundleParams{
+			CollectedAt:              sql.Timestamptz(now),
+			Error:                    sql.NullString(),
+			Bundle:                   opts.Bundle,
+			AgentDiagnosticsBundleID: sql.String(bundleID),
+			AgentID:                  sql.String(agentID),
+		}
+		if opts.Error != "" {
+			params.Error = sql.String(opts.Error)
+		}
+		if _, err := q.UploadAgentDiagnosticsBundle(ctx, params); err != nil {
+			return sql.Error(err)
+		}
+		_, err := q.TrimAgentDiagnosticsBundles(ctx, sql.String(agentID), sql.Int8(maxDiagnosticsBundles))
+		return sql.Error(err)
+	})
+}
+
+func (db *db) listDiagnosticsBundles(ctx context.Context, organization string) ([]*DiagnosticsBundle, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*DiagnosticsBundle, error) {
+		rows, err := q.FindAgentDiagnosticsBundlesByOrganization(ctx, sql.String(organization))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		bundles := make([]*DiagnosticsBundle, len(rows))
+		for i, r := range rows {
+			bundles[i] = diagnosticsBundleRow(r).toBundle()
+		}
+
+		return bundles, nil
+	})
+}
+
+func (db *db) getDiagnosticsBundle(ctx context.Context, bundleID string) (*DiagnosticsBundle, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*DiagnosticsBundle, error) {
+		r, err := q.FindAgentDiagnosticsBundleByID(ctx, sql.String(bundleID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		bundle := diagnosticsBundleRow{
+			AgentDiagnosticsBundleID: r.AgentDiagnosticsBundleID,
+			AgentID:                  r.AgentID,
+			RequestedBy:              r.RequestedBy,
+			RequestedAt:              r.RequestedAt,
+			DeliveredAt:              r.DeliveredAt,
+			CollectedAt:              r.CollectedAt,
+			Error:                    r.Error,
+			Size:                     r.Size,
+		}.toBundle()
+		bundle.Organization = r.OrganizationName.String
+		bundle.contents = r.Bundle
+		return bundle, nil
+	})
+}
+
+// diagnosticsBundleRow is a row from a database query for diagnostics
+// bundles, without their contents.
+type diagnosticsBundleRow pggen.FindAgentDiagnosticsBundlesByOrganizationRow
+
+func (r diagnosticsBundleRow) toBundle() *DiagnosticsBundle {
+	bundle := &DiagnosticsBundle{
+		ID:          r.AgentDiagnosticsBundleID.String,
+		AgentID:     r.AgentID.String,
+		RequestedBy: r.RequestedBy.String,
+		RequestedAt: r.RequestedAt.Time.UTC(),
+		Size:        int(r.Size.Int32),
+	}
+	if r.DeliveredAt.Valid {
+		deliveredAt := r.DeliveredAt.Time.UTC()
+		bundle.DeliveredAt = &deliveredAt
+	}
+	if r.CollectedAt.Valid {
+		collectedAt := r.CollectedAt.Time.UTC()
+		bundle.CollectedAt = &collectedAt
+	}
+	if r.Error.Valid {
+		bundle.Error = &r.Error.String
+	}
+	return bundle
+}
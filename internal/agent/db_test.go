@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestJobResult_toJob(t *testing.T) {
+	tests := []struct {
+		name string
+		row  jobresult
+		err  bool
+	}{
+		{"valid", jobresult{RunID: sql.String("run-1"), Phase: sql.String("plan"), Status: sql.String("allocated")}, false},
+		{"missing run ID", jobresult{Phase: sql.String("plan"), Status: sql.String("allocated")}, true},
+		{"invalid phase", jobresult{RunID: sql.String("run-1"), Phase: sql.String("bogus"), Status: sql.String("allocated")}, true},
+		{"invalid status", jobresult{RunID: sql.String("run-1"), Phase: sql.String("apply"), Status: sql.String("")}, true},
+		{"labels", jobresult{RunID: sql.String("run-1"), Phase: sql.String("plan"), Status: sql.String("allocated"), Labels: []byte(`{"team":"platform"}`)}, false},
+		{"invalid labels", jobresult{RunID: sql.String("run-1"), Phase: sql.String("plan"), Status: sql.String("allocated"), Labels: []byte(`[]`)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.row.toJob()
+			if tt.err {
+				assert.ErrorIs(t, err, ErrMalformedJob)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDB_appendJob(t *testing.T) {
+	db := &db{logger: slog.New(&xslog.NoopHandler{})}
+
+	rows := []jobresult{
+		{RunID: sql.String("run-1"), Phase: sql.String("plan"), Status: sql.String("allocated"), AgentID: sql.String("agent-1")},
+		{RunID: sql.String("run-2"), Phase: sql.String("bogus"), Status: sql.String("allocated")},
+		{RunID: sql.String("run-3"), Phase: sql.String("apply"), Status: sql.String("running"), Signaled: sql.Bool(true)},
+		{Phase: sql.String("plan"), Status: sql.String("allocated")},
+	}
+	var jobs []*Job
+	for _, r := range rows {
+		jobs = db.appendJob(jobs, r)
+	}
+
+	require.Len(t, jobs, 2)
+	assert.Equal(t, JobSpec{RunID: "run-1", Phase: internal.PlanPhase}, jobs[0].Spec)
+	assert.Equal(t, JobSpec{RunID: "run-3", Phase: internal.ApplyPhase}, jobs[1].Spec)
+	assert.True(t, *jobs[1].Signaled)
+}
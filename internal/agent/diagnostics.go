@@ -0,0 +1,93 @@
+package agent
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+)
+
+const (
+	// maxDiagnosticsSize is the maximum size in bytes of a diagnostics bundle.
+	// The agent omits its oldest logs to keep a bundle within the limit, and
+	// the server refuses larger bundles.
+	maxDiagnosticsSize = 1 << 20
+	// maxDiagnosticsBundles is the maximum number of diagnostics bundles
+	// retained for each agent; the oldest are discarded first.
+	maxDiagnosticsBundles = 5
+	// diagnosticsDisabledReason is reported in lieu of a bundle by an agent
+	// on which the collection of diagnostics is disabled.
+	diagnosticsDisabledReason = "diagnostics collection is disabled on this agent"
+)
+
+var (
+	ErrDiagnosticsPending         = errors.New("agent has yet to receive the previous request for diagnostics")
+	ErrDiagnosticsTooLarge        = errors.New("diagnostics bundle exceeds maximum size")
+	ErrDiagnosticsServerAgent     = errors.New("diagnostics can only be collected from pool agents")
+	ErrDiagnosticsAgentNotRunning = errors.New("diagnostics can only be collected from running agents")
+	ErrDiagnosticsNotCollected    = errors.New("diagnostics bundle has not been collected")
+)
+
+type (
+	// DiagnosticsBundle is a request for an agent to collect diagnostics, and
+	// the bundle of diagnostics the agent uploads in response. The request is
+	// delivered to the agent in response to its next status update.
+	DiagnosticsBundle struct {
+		ID          string
+		AgentID     string
+		RequestedBy string
+		RequestedAt time.Time
+		// DeliveredAt is when the request was delivered to the agent.
+		DeliveredAt *time.Time
+		// CollectedAt is when the agent uploaded the bundle, or the reason it
+		// could not be collected.
+		CollectedAt *time.Time
+		// Error is the reason the agent could not collect the bundle.
+		Error *string
+		// Size of the bundle in bytes.
+		Size int
+		// Organization of the agent's pool. Only populated when the bundle is
+		// retrieved individually.
+		Organization string
+
+		// contents of the bundle. Only populated when the bundle is
+		// retrieved individually.
+		contents []byte
+	}
+
+	// statusUpdateResponse is the response to an agent's status update.
+	statusUpdateResponse struct {
+		// DiagnosticsBundleID is the ID of a request to collect diagnostics,
+		// if any.
+		DiagnosticsBundleID string `json:"diagnostics_bundle_id,omitempty"`
+	}
+
+	// uploadDiagnosticsOptions are the contents of a diagnostics bundle, or the
+	// reason the agent could not collect them.
+	uploadDiagnosticsOptions struct {
+		Bundle []byte `json:"bundle,omitempty"`
+		Error  string `json:"error,omitempty"`
+	}
+)
+
+// Status summarises the progress of the request for diagnostics.
+func (d *DiagnosticsBundle) Status() string {
+	switch {
+	case d.Error != nil:
+		return "failed"
+	case d.CollectedAt != nil:
+		return "collected"
+	case d.DeliveredAt != nil:
+		return "collecting"
+	default:
+		return "requested"
+	}
+}
+
+func (d *DiagnosticsBundle) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", d.ID),
+		slog.String("agent_id", d.AgentID),
+		slog.String("status", d.Status()),
+		slog.Int("size", d.Size),
+	)
+}
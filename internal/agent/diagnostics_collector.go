@@ -0,0 +1,298 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+)
+
+const (
+	// maxDiagnosticsLogLines is the maximum number of the most recent log
+	// lines retained for inclusion in a diagnostics bundle.
+	maxDiagnosticsLogLines = 1000
+	// maxDiagnosticsJobs is the maximum number of the most recent job
+	// outcomes retained for inclusion in a diagnostics bundle.
+	maxDiagnosticsJobs = 20
+	// connectivityCheckTimeout is the time allowed for each connectivity
+	// check.
+	connectivityCheckTimeout = 5 * time.Second
+	// redacted replaces the values of sensitive environment variables.
+	redacted = "REDACTED"
+)
+
+// sensitiveVariable matches the names of environment variables whose values
+// are redacted from a diagnostics bundle.
+var sensitiveVariable = regexp.MustCompile(`(?i)(token|secret|password|passwd|key|credential|auth|private|cookie|session)`)
+
+type (
+	// diagnosticsCollector collects bundles of diagnostics on behalf of a pool
+	// agent.
+	diagnosticsCollector struct {
+		config Config
+		logs   *logRecorder
+		// URLs checked for connectivity when collecting a bundle
+		targets []string
+
+		mu   sync.Mutex
+		jobs []jobOutcome
+	}
+
+	// diagnosticsBundle is the contents of a diagnostics bundle.
+	diagnosticsBundle struct {
+		CollectedAt  time.Time              `json:"collected_at"`
+		AgentID      string                 `json:"agent_id"`
+		Version      string                 `json:"version"`
+		Config       Config                 `json:"config"`
+		Environment  diagnosticsEnvironment `json:"environment"`
+		Jobs         []jobOutcome           `json:"jobs"`
+		Connectivity []connectivityCheck    `json:"connectivity"`
+		Logs         []string               `json:"logs"`
+		// OmittedLogs is the number of the oldest log lines omitted to keep
+		// the bundle within the maximum size.
+		OmittedLogs int `json:"omitted_logs,omitempty"`
+	}
+
+	// diagnosticsEnvironment summarises the environment in which the agent is
+	// running.
+	diagnosticsEnvironment struct {
+		OS        string `json:"os"`
+		Arch      string `json:"arch"`
+		GoVersion string `json:"go_version"`
+		NumCPU    int    `json:"num_cpu"`
+		Hostname  string `json:"hostname"`
+		// Variables are the agent's environment variables, with the values
+		// of sensitive variables redacted.
+		Variables map[string]string `json:"variables"`
+	}
+
+	// jobOutcome is the outcome of a job carried out by the agent.
+	jobOutcome struct {
+		Spec       JobSpec   `json:"spec"`
+		Status     JobStatus `json:"status"`
+		Error      string    `json:"error,omitempty"`
+		FinishedAt time.Time `json:"finished_at"`
+	}
+
+	// connectivityCheck is the result of checking whether the agent can
+	// reach a URL.
+	connectivityCheck struct {
+		URL        string        `json:"url"`
+		StatusCode int           `json:"status_code,omitempty"`
+		Latency    time.Duration `json:"latency"`
+		Error      string        `json:"error,omitempty"`
+	}
+)
+
+func newDiagnosticsCollector(config Config, logs *logRecorder, targets ...string) *diagnosticsCollector {
+	return &diagnosticsCollector{
+		config:  config,
+		logs:    logs,
+		targets: targets,
+	}
+}
+
+// recordJob records the outcome of a job, discarding the oldest outcomes in
+// excess of maxDiagnosticsJobs.
+func (c *diagnosticsCollector) recordJob(spec JobSpec, opts finishJobOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.jobs = append(c.jobs, jobOutcome{
+		Spec:       spec,
+		Status:     opts.Status,
+		Error:      opts.Error,
+		FinishedAt: internal.CurrentTimestamp(nil),
+	})
+	if len(c.jobs) > maxDiagnosticsJobs {
+		c.jobs = c.jobs[len(c.jobs)-maxDiagnosticsJobs:]
+	}
+}
+
+// collect collects a bundle of diagnostics, returning its JSON encoding, which
+// is no larger than maxDiagnosticsSize.
+func (c *diagnosticsCollector) collect(ctx context.Context, agentID string) ([]byte, error) {
+	c.mu.Lock()
+	jobs := append([]jobOutcome{}, c.jobs...)
+	c.mu.Unlock()
+
+	bundle := diagnosticsBundle{
+		CollectedAt:  internal.CurrentTimestamp(nil),
+		AgentID:      agentID,
+		Version:      internal.Version,
+		Config:       c.config,
+		Environment:  newDiagnosticsEnvironment(os.Environ()),
+		Jobs:         jobs,
+		Connectivity: checkConnectivity(ctx, c.targets...),
+		Logs:         c.logs.lines(),
+	}
+	return encodeDiagnosticsBundle(bundle, maxDiagnosticsSize)
+}
+
+// encodeDiagnosticsBundle encodes the bundle as JSON, omitting the oldest log
+// lines as necessary to keep the encoding within max bytes.
+func encodeDiagnosticsBundle(bundle diagnosticsBundle, max int) ([]byte, error) {
+	for {
+		encoded, err := json.Marshal(bundle)
+		if err != nil {
+			return nil, err
+		}
+		excess := len(encoded) - max
+		if excess <= 0 {
+			return encoded, nil
+		}
+		if len(bundle.Logs) == 0 {
+			return nil, fmt.Errorf("%w: %d bytes without logs", ErrDiagnosticsTooLarge, len(encoded))
+		}
+		// omit enough of the oldest lines to make up the excess, allowing for
+		// the quotes and comma surrounding each encoded line, and then
+		// re-encode, in case escaping means yet more lines must be omitted.
+		var omit int
+		for omitted := 0; omit < len(bundle.Logs) && omitted < excess; omit++ {
+			omitted += len(bundle.Logs[omit]) + 3
+		}
+		bundle.Logs = bundle.Logs[omit:]
+		bundle.OmittedLogs += omit
+	}
+}
+
+// newDiagnosticsEnvironment summarises the environment, redacting the
+// values of sensitive environment variables, along with any credentials
+// embedded in URLs, such as those of proxies.
+func newDiagnosticsEnvironment(environ []string) diagnosticsEnvironment {
+	env := diagnosticsEnvironment{
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		GoVersion: runtime.Version(),
+		NumCPU:    runtime.NumCPU(),
+		Variables: make(map[string]string, len(environ)),
+	}
+	env.Hostname, _ = os.Hostname()
+	for _, kv := range environ {
+		k, v, _ := strings.Cut(kv, "=")
+		env.Variables[k] = redactVariable(k, v)
+	}
+	return env
+}
+
+func redactVariable(name, value string) string {
+	if sensitiveVariable.MatchString(name) {
+		return redacted
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		u.User = url.User(redacted)
+		return u.String()
+	}
+	return value
+}
+
+// checkConnectivity checks whether each URL can be reached.
+func checkConnectivity(ctx context.Context, urls ...string) []connectivityCheck {
+	client := &http.Client{Timeout: connectivityCheckTimeout}
+	checks := make([]connectivityCheck, len(urls))
+	for i, u := range urls {
+		checks[i] = connectivityCheck{URL: u}
+		req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+		if err != nil {
+			checks[i].Error = err.Error()
+			continue
+		}
+		start := time.Now()
+		resp, err := client.Do(req)
+		checks[i].Latency = time.Since(start)
+		if err != nil {
+			checks[i].Error = err.Error()
+			continue
+		}
+		resp.Body.Close()
+		checks[i].StatusCode = resp.StatusCode
+	}
+	return checks
+}
+
+// logRecorder is a slog handler that retains the most recent log lines, at
+// all levels, whilst passing records on to another handler.
+type logRecorder struct {
+	slog.Handler
+	recorder slog.Handler
+	ring     *logRing
+}
+
+func newLogRecorder(handler slog.Handler, max int) *logRecorder {
+	ring := &logRing{max: max}
+	return &logRecorder{
+		Handler:  handler,
+		recorder: slog.NewTextHandler(ring, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		ring:     ring,
+	}
+}
+
+func (h *logRecorder) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *logRecorder) Handle(ctx context.Context, r slog.Record) error {
+	if err := h.recorder.Handle(ctx, r.Clone()); err != nil {
+		return err
+	}
+	if h.Handler.Enabled(ctx, r.Level) {
+		return h.Handler.Handle(ctx, r)
+	}
+	return nil
+}
+
+func (h *logRecorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &logRecorder{
+		Handler:  h.Handler.WithAttrs(attrs),
+		recorder: h.recorder.WithAttrs(attrs),
+		ring:     h.ring,
+	}
+}
+
+func (h *logRecorder) WithGroup(name string) slog.Handler {
+	return &logRecorder{
+		Handler:  h.Handler.WithGroup(name),
+		recorder: h.recorder.WithGroup(name),
+		ring:     h.ring,
+	}
+}
+
+// lines returns the recorded log lines, oldest first.
+func (h *logRecorder) lines() []string {
+	return h.ring.lines()
+}
+
+// logRing retains the most recent lines written to it.
+type logRing struct {
+	max int
+
+	mu  sync.Mutex
+	buf []string
+}
+
+func (r *logRing) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf = append(r.buf, string(bytes.TrimRight(p, "\n")))
+	if len(r.buf) > r.max {
+		r.buf = r.buf[len(r.buf)-r.max:]
+	}
+	return len(p), nil
+}
+
+func (r *logRing) lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string{}, r.buf...)
+}
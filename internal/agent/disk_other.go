@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package agent
+
+import "errors"
+
+// freeDisk is unsupported on this platform, in which case the agent does not
+// report its disk capacity.
+func freeDisk(dir string) (int64, error) {
+	return 0, errors.New("determining free disk space is unsupported on this platform")
+}
@@ -0,0 +1,15 @@
+//go:build linux || darwin
+
+package agent
+
+import "syscall"
+
+// freeDisk returns the number of bytes available to unprivileged users on the
+// filesystem containing dir.
+func freeDisk(dir string) (int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return 0, err
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize), nil
+}
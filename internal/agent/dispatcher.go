@@ -0,0 +1,167 @@
+package agent
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/tofutf/tofutf/internal/pubsub"
+)
+
+const (
+	// DefaultJobDispatchers is the default number of dispatchers fanning out
+	// job events to agents waiting for jobs.
+	DefaultJobDispatchers = 1
+
+	// waiterBufferSize is the buffer size of the channel for each agent
+	// waiting for jobs.
+	waiterBufferSize = 10
+)
+
+type (
+	// jobDispatcher fans out job events to agents waiting for jobs, sharing
+	// a subscription to job events between many waiting agents rather than
+	// each agent holding its own subscription. Waiting agents are
+	// partitioned among a number of shards, each of which holds a
+	// subscription only for as long as it has a waiting agent, and each of
+	// which dispatches events concurrently with the other shards.
+	jobDispatcher struct {
+		shards []*dispatchShard
+	}
+
+	dispatchShard struct {
+		broker pubsub.SubscriptionService[*Job]
+
+		mu sync.Mutex
+		// waiters keyed by agent ID, each of which receives only the
+		// events for jobs allocated to its agent.
+		waiters map[string]map[chan pubsub.Event[*Job]]struct{}
+		// generation is incremented with each subscription, permitting the
+		// goroutine for a previous subscription to determine it has been
+		// superseded.
+		generation int
+		// cancel cancels the current subscription; nil if there is no
+		// subscription.
+		cancel context.CancelFunc
+	}
+)
+
+func newJobDispatcher(broker pubsub.SubscriptionService[*Job], shards int) *jobDispatcher {
+	if shards < 1 {
+		shards = DefaultJobDispatchers
+	}
+	d := &jobDispatcher{shards: make([]*dispatchShard, shards)}
+	for i := range d.shards {
+		d.shards[i] = &dispatchShard{
+			broker:  broker,
+			waiters: make(map[string]map[chan pubsub.Event[*Job]]struct{}),
+		}
+	}
+	return d
+}
+
+// wait registers the agent as waiting for job events, returning a stream of
+// events for jobs allocated to the agent. The agent stops waiting by calling
+// the returned function. The events sent prior to registering are not
+// received, so the agent should check for existing jobs only once it is
+// registered. If the stream is closed then events may have been missed and the
+// agent should begin waiting again.
+func (d *jobDispatcher) wait(agentID string) (<-chan pubsub.Event[*Job], func()) {
+	h := fnv.New32a()
+	h.Write([]byte(agentID))
+	return d.shards[h.Sum32()%uint32(len(d.shards))].wait(agentID)
+}
+
+func (s *dispatchShard) wait(agentID string) (<-chan pubsub.Event[*Job], func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel == nil {
+		// subscribe upon the first agent waiting for jobs
+		ctx, cancel := context.WithCancel(context.Background())
+		sub, _ := s.broker.Subscribe(ctx)
+		s.generation++
+		s.cancel = cancel
+		go s.dispatch(sub, s.generation)
+	}
+	waiter := make(chan pubsub.Event[*Job], waiterBufferSize)
+	if s.waiters[agentID] == nil {
+		s.waiters[agentID] = make(map[chan pubsub.Event[*Job]]struct{})
+	}
+	s.waiters[agentID][waiter] = struct{}{}
+
+	return waiter, func() { s.unwait(agentID, waiter) }
+}
+
+func (s *dispatchShard) unwait(agentID string, waiter chan pubsub.Event[*Job]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.waiters[agentID][waiter]; !ok {
+		// already removed
+		return
+	}
+	s.remove(agentID, waiter)
+	if len(s.waiters) == 0 && s.cancel != nil {
+		// unsubscribe upon the last agent to stop waiting
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+// remove removes and closes a waiter. The mutex must be held.
+func (s *dispatchShard) remove(agentID string, waiter chan pubsub.Event[*Job]) {
+	close(waiter)
+	delete(s.waiters[agentID], waiter)
+	if len(s.waiters[agentID]) == 0 {
+		delete(s.waiters, agentID)
+	}
+}
+
+// dispatch forwards events from the subscription to the waiters for the
+// agent to which each job is allocated.
+func (s *dispatchShard) dispatch(sub <-chan pubsub.Event[*Job], generation int) {
+	for event := range sub {
+		if event.Payload.AgentID == nil {
+			continue
+		}
+		agentID := *event.Payload.AgentID
+
+		s.mu.Lock()
+		if s.generation != generation {
+			// the subscription has been superseded and its events are
+			// forwarded by the goroutine for the current subscription, so
+			// forwarding them here too would duplicate them.
+			s.mu.Unlock()
+			continue
+		}
+		for waiter := range s.waiters[agentID] {
+			select {
+			case waiter <- event:
+			default:
+				// the waiter's buffer is full, so remove it and leave it to
+				// the agent to wait again.
+				s.remove(agentID, waiter)
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.generation != generation || s.cancel == nil {
+		// the subscription was closed deliberately
+		return
+	}
+	// the subscription was closed by the broker, whereupon events may have
+	// been missed, so remove all waiters and leave it to their agents to wait
+	// again, whereupon a new subscription is made.
+	for agentID, waiters := range s.waiters {
+		for waiter := range waiters {
+			s.remove(agentID, waiter)
+		}
+	}
+	s.cancel()
+	s.cancel = nil
+}
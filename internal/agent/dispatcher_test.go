@@ -0,0 +1,203 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"go.uber.org/goleak"
+)
+
+// countingBroker is a fake broker that counts subscriptions.
+type countingBroker struct {
+	mu    sync.Mutex
+	subs  map[chan pubsub.Event[*Job]]struct{}
+	total int
+	// lingering, if true, leaves subscriptions open after their context is
+	// canceled, as happens with the real broker until it gets around to
+	// closing them.
+	lingering bool
+}
+
+func newCountingBroker() *countingBroker {
+	return &countingBroker{subs: make(map[chan pubsub.Event[*Job]]struct{})}
+}
+
+func (b *countingBroker) Subscribe(ctx context.Context) (<-chan pubsub.Event[*Job], func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := make(chan pubsub.Event[*Job], 100)
+	b.subs[sub] = struct{}{}
+	b.total++
+	if !b.lingering {
+		go func() {
+			<-ctx.Done()
+			b.close(sub)
+		}()
+	}
+	return sub, func() { b.close(sub) }
+}
+
+func (b *countingBroker) close(sub chan pubsub.Event[*Job]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[sub]; ok {
+		close(sub)
+		delete(b.subs, sub)
+	}
+}
+
+// closeAll closes all subscriptions, as the real broker does to subscribers
+// that fall behind.
+func (b *countingBroker) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		close(sub)
+		delete(b.subs, sub)
+	}
+}
+
+func (b *countingBroker) publish(job *Job) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		sub <- pubsub.Event[*Job]{Type: pubsub.UpdatedEvent, Payload: job}
+	}
+}
+
+func (b *countingBroker) active() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subs)
+}
+
+func TestJobDispatcher(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	broker := newCountingBroker()
+	d := newJobDispatcher(broker, 1)
+
+	sub, unsub := d.wait("agent-1")
+	// events for jobs that are unallocated or allocated to another agent are
+	// not dispatched to the agent.
+	broker.publish(&Job{Status: JobUnallocated})
+	broker.publish(&Job{Status: JobAllocated, AgentID: internal.String("agent-2")})
+	broker.publish(&Job{Status: JobAllocated, AgentID: internal.String("agent-1")})
+
+	event := <-sub
+	assert.Equal(t, "agent-1", *event.Payload.AgentID)
+	assert.Empty(t, sub)
+
+	unsub()
+	assert.Eventually(t, func() bool { return broker.active() == 0 }, time.Second, time.Millisecond)
+	// subscription is made anew upon waiting again
+	_, unsub = d.wait("agent-1")
+	assert.Equal(t, 1, broker.active())
+	assert.Equal(t, 2, broker.total)
+	unsub()
+}
+
+func TestJobDispatcher_SubscriptionClosed(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	broker := newCountingBroker()
+	d := newJobDispatcher(broker, 1)
+
+	sub, unsub := d.wait("agent-1")
+	defer unsub()
+	broker.closeAll()
+
+	// waiter is closed, leaving it to the agent to wait again
+	_, open := <-sub
+	assert.False(t, open)
+
+	sub, unsub = d.wait("agent-1")
+	defer unsub()
+	broker.publish(&Job{Status: JobAllocated, AgentID: internal.String("agent-1")})
+	event := <-sub
+	assert.Equal(t, JobAllocated, event.Payload.Status)
+	assert.Equal(t, 2, broker.total)
+}
+
+func TestJobDispatcher_SupersededSubscription(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	broker := newCountingBroker()
+	broker.lingering = true
+	d := newJobDispatcher(broker, 1)
+
+	// the last agent to stop waiting cancels the subscription but the broker
+	// has yet to close it when the agent waits again, whereupon a second
+	// subscription is made.
+	_, unsub := d.wait("agent-1")
+	unsub()
+	sub, unsub := d.wait("agent-1")
+	defer broker.closeAll()
+	defer unsub()
+	require.Equal(t, 2, broker.active())
+
+	// the event is received on both subscriptions but only dispatched once
+	broker.publish(&Job{Status: JobAllocated, AgentID: internal.String("agent-1")})
+	event := <-sub
+	assert.Equal(t, "agent-1", *event.Payload.AgentID)
+	assert.Never(t, func() bool { return len(sub) > 0 }, 100*time.Millisecond, time.Millisecond)
+}
+
+func TestJobDispatcher_Load(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	const (
+		agents = 1000
+		shards = 4
+	)
+	broker := newCountingBroker()
+	d := newJobDispatcher(broker, shards)
+	ctx := context.Background()
+
+	var (
+		wg  sync.WaitGroup
+		got = make([][]*Job, agents)
+	)
+	for i := 0; i < agents; i++ {
+		agentID := fmt.Sprintf("agent-%d", i)
+		sub, unsub := d.wait(agentID)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer unsub()
+			got[i] = waitForAgentJob(ctx, sub, agentID)
+		}(i)
+	}
+	// thousands of waiting agents share no more than one subscription per
+	// shard.
+	require.LessOrEqual(t, broker.active(), shards)
+
+	for i := 0; i < agents; i++ {
+		broker.publish(&Job{
+			Spec:    JobSpec{RunID: fmt.Sprintf("run-%d", i)},
+			Status:  JobAllocated,
+			AgentID: internal.String(fmt.Sprintf("agent-%d", i)),
+		})
+	}
+	wg.Wait()
+
+	for i, jobs := range got {
+		require.Len(t, jobs, 1)
+		assert.Equal(t, fmt.Sprintf("run-%d", i), jobs[0].Spec.RunID)
+	}
+	assert.LessOrEqual(t, broker.total, shards)
+	// subscriptions are closed once no agents are waiting
+	assert.Eventually(t, func() bool { return broker.active() == 0 }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tofutf/tofutf/internal/releases"
+)
+
+// relayDownloadEvents writes the progress of terraform downloads to w until
+// the events channel is closed.
+func relayDownloadEvents(events <-chan releases.DownloadEvent, w io.Writer) {
+	for event := range events {
+		switch event.Type {
+		case releases.DownloadProgress:
+			if event.Total > 0 {
+				fmt.Fprintf(w, "downloaded %s of %s (%d%%)\n", megabytes(event.Received), megabytes(event.Total), event.Received*100/event.Total)
+			} else {
+				fmt.Fprintf(w, "downloaded %s\n", megabytes(event.Received))
+			}
+		case releases.DownloadCompleted:
+			if event.Err != nil {
+				fmt.Fprintf(w, "failed to download %s, version %s: %s\n", event.Product, event.Version, event.Err)
+			} else {
+				fmt.Fprintf(w, "downloaded %s, version %s (%s)\n", event.Product, event.Version, megabytes(event.Received))
+			}
+		}
+	}
+}
+
+func megabytes(n int64) string {
+	return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+}
+
+// lockedWriter serializes writes to the underlying writer.
+type lockedWriter struct {
+	io.Writer
+	mu sync.Mutex
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.Writer.Write(p)
+}
@@ -0,0 +1,38 @@
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tofutf/tofutf/internal/releases"
+)
+
+func TestRelayDownloadEvents(t *testing.T) {
+	events := make(chan releases.DownloadEvent, 4)
+	events <- releases.DownloadEvent{Type: releases.DownloadStarted, Product: "terraform", Version: "1.2.3", Total: 4 << 20}
+	events <- releases.DownloadEvent{Type: releases.DownloadProgress, Product: "terraform", Version: "1.2.3", Received: 1 << 20, Total: 4 << 20}
+	events <- releases.DownloadEvent{Type: releases.DownloadProgress, Product: "terraform", Version: "1.2.3", Received: 2 << 20, Total: -1}
+	events <- releases.DownloadEvent{Type: releases.DownloadCompleted, Product: "terraform", Version: "1.2.3", Received: 4 << 20, Total: 4 << 20}
+	close(events)
+
+	var buf bytes.Buffer
+	relayDownloadEvents(events, &buf)
+
+	want := "downloaded 1.0 MB of 4.0 MB (25%)\n" +
+		"downloaded 2.0 MB\n" +
+		"downloaded terraform, version 1.2.3 (4.0 MB)\n"
+	assert.Equal(t, want, buf.String())
+
+	t.Run("failed download", func(t *testing.T) {
+		events := make(chan releases.DownloadEvent, 1)
+		events <- releases.DownloadEvent{Type: releases.DownloadCompleted, Product: "terraform", Version: "1.2.3", Err: errors.New("connection reset")}
+		close(events)
+
+		var buf bytes.Buffer
+		relayDownloadEvents(events, &buf)
+
+		assert.Equal(t, "failed to download terraform, version 1.2.3: connection reset\n", buf.String())
+	})
+}
@@ -0,0 +1,77 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+var (
+	// timeoutErrors are fragments of error messages indicating that an
+	// operation timed out.
+	timeoutErrors = []string{
+		"context deadline exceeded",
+		"i/o timeout",
+		"timeout while waiting for",
+	}
+
+	// providerAuthErrors are fragments of error messages, lower-cased,
+	// indicating that a provider failed to authenticate with its API.
+	providerAuthErrors = []string{
+		"no valid credential sources found",
+		"invalidclienttokenid",
+		"expiredtoken",
+		"signaturedoesnotmatch",
+		"could not find default credentials",
+		"building azurerm client",
+		"unable to build authorizer",
+		"authentication failed",
+		"invalid credentials",
+		"401 unauthorized",
+		"status code: 401",
+		"statuscode=401",
+	}
+)
+
+// classifyError determines the category of the error with which a job
+// failed, given the category of errors of the stage of the job that failed.
+func classifyError(stage run.ErrorCategory, err error) run.ErrorCategory {
+	msg := strings.ToLower(err.Error())
+	if isTimeout(err, msg) {
+		return run.TimeoutErrorCategory
+	}
+	switch stage {
+	case run.InitErrorCategory, run.ExecutionErrorCategory:
+		// credentials are checked by terraform init when configuring the
+		// backend, and by plan and apply when configuring providers.
+		if containsAny(msg, providerAuthErrors) {
+			return run.ProviderAuthErrorCategory
+		}
+	case "":
+		return run.UnknownErrorCategory
+	}
+	return stage
+}
+
+func isTimeout(err error, msg string) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return containsAny(msg, timeoutErrors)
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
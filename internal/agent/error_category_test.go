@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name  string
+		stage run.ErrorCategory
+		err   error
+		want  run.ErrorCategory
+	}{
+		{
+			name:  "setup",
+			stage: run.SetupErrorCategory,
+			err:   errors.New("unable to download config: not found"),
+			want:  run.SetupErrorCategory,
+		},
+		{
+			name:  "init",
+			stage: run.InitErrorCategory,
+			err:   errors.New("exit status 1: Failed to query available provider packages"),
+			want:  run.InitErrorCategory,
+		},
+		{
+			name:  "execution",
+			stage: run.ExecutionErrorCategory,
+			err:   errors.New("exit status 1: Invalid reference"),
+			want:  run.ExecutionErrorCategory,
+		},
+		{
+			name:  "provider auth",
+			stage: run.ExecutionErrorCategory,
+			err:   errors.New("exit status 1: Error: No valid credential sources found"),
+			want:  run.ProviderAuthErrorCategory,
+		},
+		{
+			name:  "backend auth during init",
+			stage: run.InitErrorCategory,
+			err:   errors.New("exit status 1: error configuring S3 Backend: InvalidClientTokenId"),
+			want:  run.ProviderAuthErrorCategory,
+		},
+		{
+			name:  "auth message during setup is a setup error",
+			stage: run.SetupErrorCategory,
+			err:   errors.New("downloading state version: 401 Unauthorized"),
+			want:  run.SetupErrorCategory,
+		},
+		{
+			name:  "timeout",
+			stage: run.SetupErrorCategory,
+			err:   fmt.Errorf("downloading terraform: %w", context.DeadlineExceeded),
+			want:  run.TimeoutErrorCategory,
+		},
+		{
+			name:  "resource timeout",
+			stage: run.ExecutionErrorCategory,
+			err:   errors.New("exit status 1: timeout while waiting for state to become 'available'"),
+			want:  run.TimeoutErrorCategory,
+		},
+		{
+			name: "unknown stage",
+			err:  errors.New("boom"),
+			want: run.UnknownErrorCategory,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyError(tt.stage, tt.err))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	tofutfrun "github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/sql"
+)
+
+// maxFinishRetries is the maximum number of times finishing a job is retried
+// after a transient error.
+const maxFinishRetries = 3
+
+// newFinishBackoff returns the backoff policy for retrying the finishing of a
+// job.
+func newFinishBackoff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 100 * time.Millisecond
+	b.MaxInterval = time.Second
+	return backoff.WithMaxRetries(b, maxFinishRetries)
+}
+
+// retryTransient invokes op, retrying it according to the policy for as long
+// as it returns a transient database error. Any other error is returned
+// immediately, as is the last error once the policy gives up.
+func retryTransient(ctx context.Context, logger *slog.Logger, policy backoff.BackOff, op func() error) error {
+	return backoff.RetryNotify(func() error {
+		err := op()
+		if err != nil && !sql.IsTransient(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, backoff.WithContext(policy, ctx), func(err error, next time.Duration) {
+		logger.Warn("retrying after transient error", "backoff", next, "err", err)
+	})
+}
+
+// jobUpdater updates a job.
+type jobUpdater interface {
+	updateJob(ctx context.Context, spec JobSpec, fn func(*Job) error) (*Job, error)
+}
+
+// finishJobAndPhase updates a job and its corresponding run phase to reflect
+// the job having finished, retrying according to the policy upon a transient
+// error. If the run phase cannot be updated then the job is marked errored,
+// lest it remain running indefinitely.
+func finishJobAndPhase(ctx context.Context, logger *slog.Logger, policy backoff.BackOff, db jobUpdater, phases phaseClient, spec JobSpec, opts finishJobOptions) (*Job, error) {
+	var (
+		job *Job
+		// phaseErr is the error from the most recent attempt to update the
+		// corresponding run phase.
+		phaseErr error
+	)
+	// The job and its run phase are updated within the same transaction. An
+	// error aborts the transaction, so upon a transient error the
+	// transaction as a whole is retried.
+	err := retryTransient(ctx, logger, policy, func() (err error) {
+		phaseErr = nil
+		job, err = db.updateJob(ctx, spec, func(job *Job) error {
+			// update corresponding run phase too
+			switch opts.Status {
+			case JobFinished, JobErrored:
+				_, phaseErr = phases.FinishPhase(ctx, spec.RunID, spec.Phase, tofutfrun.PhaseFinishOptions{
+					Errored:       opts.Status == JobErrored,
+					ErrorCategory: opts.ErrorCategory,
+				})
+			case JobCanceled:
+				phaseErr = phases.Cancel(ctx, spec.RunID)
+			}
+			if phaseErr != nil {
+				return phaseErr
+			}
+			return job.finishJob(opts.Status)
+		})
+		return err
+	})
+	if err != nil {
+		logger.Error("finishing job", "spec", spec, "err", err)
+		if phaseErr != nil && ctx.Err() == nil {
+			return nil, errorJob(ctx, logger, db, spec, fmt.Errorf("updating %s phase of run: %w", spec.Phase, phaseErr))
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// errorJob marks a job errored after its run phase failed to be updated,
+// returning cause along with any error from marking the job errored.
+func errorJob(ctx context.Context, logger *slog.Logger, db jobUpdater, spec JobSpec, cause error) error {
+	job, err := db.updateJob(ctx, spec, func(job *Job) error {
+		return job.finishJob(JobErrored)
+	})
+	if err != nil {
+		logger.Error("marking job errored", "spec", spec, "cause", cause, "err", err)
+		return errors.Join(cause, err)
+	}
+	logger.Error("marked job errored", "job", job, "cause", cause)
+	return cause
+}
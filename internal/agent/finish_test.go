@@ -0,0 +1,123 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	tofutfrun "github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestRetryTransient(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(&xslog.NoopHandler{})
+	policy := func() backoff.BackOff {
+		return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, maxFinishRetries)
+	}
+	serializationFailure := &pgconn.PgError{Code: "40001"}
+
+	t.Run("transient phase failure", func(t *testing.T) {
+		var attempts int
+		err := retryTransient(ctx, logger, policy(), func() error {
+			attempts++
+			if attempts == 1 {
+				return serializationFailure
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("permanent phase failure", func(t *testing.T) {
+		var attempts int
+		err := retryTransient(ctx, logger, policy(), func() error {
+			attempts++
+			return tofutfrun.ErrInvalidRunStateTransition
+		})
+		assert.ErrorIs(t, err, tofutfrun.ErrInvalidRunStateTransition)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		var attempts int
+		err := retryTransient(ctx, logger, policy(), func() error {
+			attempts++
+			return serializationFailure
+		})
+		assert.ErrorIs(t, err, serializationFailure)
+		assert.Equal(t, maxFinishRetries+1, attempts)
+	})
+}
+
+func TestFinishJobAndPhase(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(&xslog.NoopHandler{})
+	policy := func() backoff.BackOff {
+		return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, maxFinishRetries)
+	}
+	spec := JobSpec{RunID: "run-123", Phase: internal.PlanPhase}
+	serializationFailure := &pgconn.PgError{Code: "40001"}
+
+	t.Run("transient phase failure", func(t *testing.T) {
+		db := &fakeJobUpdater{job: &Job{Spec: spec, Status: JobRunning}}
+		phases := &fakeFinishPhaseClient{errs: []error{serializationFailure}}
+
+		job, err := finishJobAndPhase(ctx, logger, policy(), db, phases, spec, finishJobOptions{Status: JobFinished})
+		require.NoError(t, err)
+		assert.Equal(t, JobFinished, job.Status)
+		assert.Equal(t, JobFinished, db.job.Status)
+		assert.Equal(t, 2, phases.finished)
+	})
+
+	t.Run("permanent phase failure", func(t *testing.T) {
+		db := &fakeJobUpdater{job: &Job{Spec: spec, Status: JobRunning}}
+		phases := &fakeFinishPhaseClient{errs: []error{tofutfrun.ErrInvalidRunStateTransition}}
+
+		_, err := finishJobAndPhase(ctx, logger, policy(), db, phases, spec, finishJobOptions{Status: JobFinished})
+		assert.ErrorIs(t, err, tofutfrun.ErrInvalidRunStateTransition)
+		assert.Equal(t, 1, phases.finished)
+		// the job is errored rather than left running
+		assert.Equal(t, JobErrored, db.job.Status)
+	})
+}
+
+// fakeJobUpdater updates a single job, discarding the update if fn returns
+// an error, as a transaction would.
+type fakeJobUpdater struct {
+	job *Job
+}
+
+func (f *fakeJobUpdater) updateJob(ctx context.Context, spec JobSpec, fn func(*Job) error) (*Job, error) {
+	job := *f.job
+	if err := fn(&job); err != nil {
+		return nil, err
+	}
+	f.job = &job
+	return &job, nil
+}
+
+// fakeFinishPhaseClient fails to finish a phase with each of errs in turn,
+// and then succeeds.
+type fakeFinishPhaseClient struct {
+	errs     []error
+	finished int
+
+	phaseClient
+}
+
+func (f *fakeFinishPhaseClient) FinishPhase(ctx context.Context, runID string, phase internal.PhaseType, opts tofutfrun.PhaseFinishOptions) (*tofutfrun.Run, error) {
+	f.finished++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	return &tofutfrun.Run{}, nil
+}
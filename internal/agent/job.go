@@ -4,16 +4,21 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/rbac"
 	otfrun "github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
 )
 
 var (
 	ErrInvalidJobStateTransition = errors.New("invalid job state transition")
 	ErrMalformedJobSpecString    = errors.New("malformed stringified job spec")
+	ErrMalformedJob              = errors.New("malformed job")
 )
 
 type JobStatus string
@@ -27,6 +32,22 @@ const (
 	JobCanceled    JobStatus = "canceled"
 )
 
+// Finished returns true if the job has reached a terminal state.
+func (s JobStatus) Finished() bool {
+	switch s {
+	case JobFinished, JobErrored, JobCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// WatchFinishedJobsOptions filters the events delivered by WatchFinishedJobs.
+type WatchFinishedJobsOptions struct {
+	Organization *string // filter by organization name
+	AgentPoolID  *string // filter by agent pool ID
+}
+
 // JobSpec uniquely identifies a job.
 type JobSpec struct {
 	// ID of the run that this job is for.
@@ -57,7 +78,8 @@ type Job struct {
 	Spec JobSpec `jsonapi:"primary,jobs"`
 	// Current status of job.
 	Status JobStatus `jsonapi:"attribute" json:"status"`
-	// ID of agent pool the job's workspace is assigned to use. If non-nil then
+	// ID of agent pool the job is assigned to. The job inherits the pool its
+	// workspace is assigned to use when the job is created. If non-nil then
 	// the job is allocated to a pool agent belonging to the pool. If nil then
 	// the job is allocated to a server agent.
 	AgentPoolID *string `jsonapi:"attribute" json:"agent_pool_id"`
@@ -65,27 +87,112 @@ type Job struct {
 	Organization string `jsonapi:"attribute" json:"organization"`
 	// ID of job's workspace
 	WorkspaceID string `jsonapi:"attribute" json:"workspace_id"`
+	// Version of terraform required by the job's run
+	TerraformVersion string `jsonapi:"attribute" json:"terraform_version"`
 	// ID of agent that this job is allocated to. Only set once job enters
 	// JobAllocated state.
 	AgentID *string `jsonapi:"attribute" json:"agent_id"`
 	// Signaled is non-nil when a cancelation signal has been sent to the job
 	// and it is true when it has been forceably canceled.
 	Signaled *bool `jsonapi:"attribute" json:"signaled"`
+	// Time at which the job was created, i.e. when it was queued.
+	CreatedAt time.Time `jsonapi:"attribute" json:"created_at"`
+	// Time at which the job was allocated to an agent. Only set once job
+	// enters JobAllocated state.
+	AllocatedAt *time.Time `jsonapi:"attribute" json:"allocated_at"`
+	// Time at which the job was found to have waited longer than its
+	// organization's queue time SLA to be allocated to an agent. Nil if the
+	// SLA has not been breached.
+	SLABreachedAt *time.Time `jsonapi:"attribute" json:"sla_breached_at"`
+	// TraceContext is the W3C traceparent of the span in which the job was
+	// created, permitting the spans of subsequent steps in the job's
+	// lifecycle to join the same trace. Empty if tracing is disabled.
+	TraceContext string `jsonapi:"attribute" json:"trace_context,omitempty"`
+	// Labels are copied from the job's run when the job is created, for
+	// routing the job to agents and for reporting.
+	Labels map[string]string `jsonapi:"attribute" json:"labels,omitempty"`
+	// Priority is copied from the job's run when the job is created. Jobs of
+	// high priority runs are allocated ahead of other jobs.
+	Priority otfrun.Priority `jsonapi:"attribute" json:"priority"`
 }
 
-func newJob(run *otfrun.Run) *Job {
+// ListJobsOptions filters the jobs returned by listJobs.
+type ListJobsOptions struct {
+	// Labels restricts jobs to those with all of the labels.
+	Labels map[string]string
+}
+
+// newJob constructs a job for the run's current phase, assigning it to the
+// agent pool the run's workspace uses for that phase.
+func newJob(run *otfrun.Run, ws *workspace.Workspace) *Job {
 	return &Job{
 		Spec: JobSpec{
 			RunID: run.ID,
 			Phase: run.Phase(),
 		},
-		Status:       JobUnallocated,
-		Organization: run.Organization,
-		WorkspaceID:  run.WorkspaceID,
-		AgentPoolID:  run.AgentPoolID,
+		Status:           JobUnallocated,
+		Organization:     run.Organization,
+		WorkspaceID:      run.WorkspaceID,
+		AgentPoolID:      ws.AgentPoolIDForPhase(run.Phase()),
+		TerraformVersion: run.TerraformVersion,
+		CreatedAt:        internal.CurrentTimestamp(nil),
+		Labels:           maps.Clone(run.Labels),
+		Priority:         run.Priority,
 	}
 }
 
+// HasLabels determines whether the job has all of the labels, i.e. each label
+// key is present on the job with the same value. A job has all of no labels.
+func (j *Job) HasLabels(labels map[string]string) bool {
+	for k, v := range labels {
+		if got, ok := j.Labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLabelFilters parses label filters, each in the format key:value, into
+// the labels that jobs must have. Label keys cannot contain a colon, so each
+// filter is split on the first colon.
+func parseLabelFilters(filters []string) (map[string]string, error) {
+	labels := make(map[string]string, len(filters))
+	for _, f := range filters {
+		key, value, ok := strings.Cut(f, ":")
+		if !ok || key == "" {
+			return nil, &internal.HTTPError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: fmt.Sprintf("invalid label filter %q: must be in the format key:value", f),
+			}
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// filterJobs returns the jobs matching the options.
+func filterJobs(jobs []*Job, opts ListJobsOptions) []*Job {
+	if len(opts.Labels) == 0 {
+		return jobs
+	}
+	var filtered []*Job
+	for _, job := range jobs {
+		if job.HasLabels(opts.Labels) {
+			filtered = append(filtered, job)
+		}
+	}
+	return filtered
+}
+
+// QueueTime is the time the job has spent waiting to be allocated to an agent,
+// or, if it has been allocated, the time it spent waiting.
+func (j *Job) QueueTime(now time.Time) time.Duration {
+	if j.AllocatedAt != nil {
+		return j.AllocatedAt.Sub(j.CreatedAt)
+	}
+	return now.Sub(j.CreatedAt)
+}
+
 func (j *Job) MarshalID() string {
 	return j.Spec.String()
 }
@@ -153,10 +260,10 @@ func (j *Job) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePo
 	}
 	// allow actions on same workspace as job depending on run phase
 	switch action {
-	case rbac.DownloadStateAction, rbac.GetStateVersionAction, rbac.GetWorkspaceAction, rbac.GetRunAction, rbac.ListVariableSetsAction, rbac.ListWorkspaceVariablesAction, rbac.PutChunkAction, rbac.DownloadConfigurationVersionAction, rbac.GetPlanFileAction, rbac.CancelRunAction:
+	case rbac.DownloadStateAction, rbac.GetStateVersionAction, rbac.GetWorkspaceAction, rbac.GetRunAction, rbac.ListVariableSetsAction, rbac.ListWorkspaceVariablesAction, rbac.PutChunkAction, rbac.DownloadConfigurationVersionAction, rbac.GetPlanFileAction, rbac.CancelRunAction, rbac.UploadRunArtifactAction, rbac.ResolveVariableSecretsAction:
 		// any phase
 		return true
-	case rbac.UploadLockFileAction, rbac.UploadPlanFileAction, rbac.ApplyRunAction:
+	case rbac.UploadLockFileAction, rbac.UploadModuleManifestAction, rbac.UploadPlanFileAction, rbac.ApplyRunAction:
 		// plan phase
 		if j.Spec.Phase == internal.PlanPhase {
 			return true
@@ -191,6 +298,34 @@ func (j *Job) reallocate(agentID string) error {
 	return nil
 }
 
+// migrate assigns a job that has yet to start to a different agent pool. If
+// the job has already been allocated to an agent then it is reverted to the
+// unallocated state, ready to be allocated to an agent in the new pool.
+func (j *Job) migrate(poolID string) error {
+	switch j.Status {
+	case JobUnallocated, JobAllocated:
+	default:
+		return errors.New("job can only be migrated before it has started")
+	}
+	j.AgentPoolID = &poolID
+	j.AgentID = nil
+	j.AllocatedAt = nil
+	j.Status = JobUnallocated
+	return nil
+}
+
+// requeue reverts a job that has been allocated but has yet to start to the
+// unallocated state, ready to be allocated to another agent.
+func (j *Job) requeue() error {
+	if j.Status != JobAllocated {
+		return errors.New("job can only be requeued when it is in the allocated state")
+	}
+	j.AgentID = nil
+	j.AllocatedAt = nil
+	j.Status = JobUnallocated
+	return nil
+}
+
 // cancel job based on current state of its parent run - depending on its state,
 // the job is signaled and/or its state is updated too.
 func (j *Job) cancel(run *otfrun.Run) (*bool, error) {
@@ -210,11 +345,20 @@ func (j *Job) cancel(run *otfrun.Run) (*bool, error) {
 		}
 	case otfrun.RunForceCanceled:
 		// run has been forceably canceled, so both signal job to forcefully
-		// cancel current operation, and immediately cancel job.
-		signal = internal.Bool(true)
+		// cancel current operation, and immediately cancel job. The job is
+		// only forcefully canceled once the cool-off period following the
+		// graceful cancelation has elapsed.
+		if run.ForceCancelAvailableAt == nil || time.Now().Before(*run.ForceCancelAvailableAt) {
+			return nil, otfrun.ErrRunForceCancelNotAllowed
+		}
+		if j.Status == JobRunning {
+			signal = internal.Bool(true)
+			j.Signaled = signal
+		}
 		if err := j.updateStatus(JobCanceled); err != nil {
 			return nil, err
 		}
+		return signal, nil
 	}
 	if signal != nil {
 		if j.Status != JobRunning {
@@ -239,7 +383,7 @@ func (j *Job) updateStatus(to JobStatus) error {
 	switch j.Status {
 	case JobUnallocated:
 		switch to {
-		case JobAllocated, JobCanceled:
+		case JobAllocated, JobCanceled, JobErrored:
 			isValid = true
 		}
 	case JobAllocated:
@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"slices"
+	"strings"
+)
+
+// jobStatuses are all the statuses a job can be in.
+var jobStatuses = []JobStatus{
+	JobUnallocated,
+	JobAllocated,
+	JobRunning,
+	JobFinished,
+	JobErrored,
+	JobCanceled,
+}
+
+type (
+	// JobCounts are the numbers of jobs in each status in an organization,
+	// optionally broken down by agent pool.
+	JobCounts struct {
+		Organization string
+		// Counts is the number of jobs in each status. Every status is
+		// included, even those without any jobs.
+		Counts map[JobStatus]int
+		// Pools breaks down the counts by the agent pool to which jobs are
+		// assigned, including only the pools with jobs. Jobs for the server
+		// agents are represented by a pool with a nil ID. Nil unless the
+		// breakdown is requested.
+		Pools []PoolJobCounts
+	}
+
+	// PoolJobCounts are the numbers of jobs in each status assigned to an
+	// agent pool.
+	PoolJobCounts struct {
+		// ID of the pool, or nil for jobs for the server agents.
+		AgentPoolID *string
+		Counts      map[JobStatus]int
+	}
+
+	// JobCountsOptions are options for counting jobs.
+	JobCountsOptions struct {
+		// ByPool breaks down the counts by agent pool.
+		ByPool bool
+		// Labels restricts the counts to jobs with all of the labels.
+		Labels map[string]string
+	}
+
+	// jobCount is the number of jobs with a given status and agent pool.
+	jobCount struct {
+		Status      JobStatus
+		AgentPoolID *string
+		Count       int
+	}
+)
+
+// newJobCounts aggregates the numbers of jobs with each status and agent pool.
+func newJobCounts(organization string, counts []jobCount, byPool bool) *JobCounts {
+	jc := &JobCounts{
+		Organization: organization,
+		Counts:       newStatusCounts(),
+	}
+	pools := make(map[string]*PoolJobCounts)
+	for _, c := range counts {
+		jc.Counts[c.Status] += c.Count
+		if !byPool {
+			continue
+		}
+		var key string
+		if c.AgentPoolID != nil {
+			key = *c.AgentPoolID
+		}
+		pool, ok := pools[key]
+		if !ok {
+			pool = &PoolJobCounts{AgentPoolID: c.AgentPoolID, Counts: newStatusCounts()}
+			pools[key] = pool
+		}
+		pool.Counts[c.Status] += c.Count
+	}
+	if byPool {
+		jc.Pools = make([]PoolJobCounts, 0, len(pools))
+		for _, pool := range pools {
+			jc.Pools = append(jc.Pools, *pool)
+		}
+		// server agents first, followed by pools in order of their ID
+		slices.SortFunc(jc.Pools, func(a, b PoolJobCounts) int {
+			switch {
+			case a.AgentPoolID == nil:
+				return -1
+			case b.AgentPoolID == nil:
+				return 1
+			default:
+				return strings.Compare(*a.AgentPoolID, *b.AgentPoolID)
+			}
+		})
+	}
+	return jc
+}
+
+func newStatusCounts() map[JobStatus]int {
+	counts := make(map[JobStatus]int, len(jobStatuses))
+	for _, status := range jobStatuses {
+		counts[status] = 0
+	}
+	return counts
+}
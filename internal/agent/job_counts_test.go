@@ -0,0 +1,72 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestNewJobCounts(t *testing.T) {
+	counts := []jobCount{
+		{Status: JobUnallocated, AgentPoolID: internal.String("pool-2"), Count: 3},
+		{Status: JobUnallocated, Count: 1},
+		{Status: JobRunning, AgentPoolID: internal.String("pool-1"), Count: 2},
+		{Status: JobFinished, AgentPoolID: internal.String("pool-1"), Count: 10},
+		{Status: JobFinished, Count: 5},
+		{Status: JobErrored, AgentPoolID: internal.String("pool-2"), Count: 1},
+	}
+
+	t.Run("totals", func(t *testing.T) {
+		got := newJobCounts("acme", counts, false)
+
+		assert.Equal(t, "acme", got.Organization)
+		assert.Equal(t, map[JobStatus]int{
+			JobUnallocated: 4,
+			JobAllocated:   0,
+			JobRunning:     2,
+			JobFinished:    15,
+			JobErrored:     1,
+			JobCanceled:    0,
+		}, got.Counts)
+		assert.Nil(t, got.Pools)
+	})
+
+	t.Run("by pool", func(t *testing.T) {
+		got := newJobCounts("acme", counts, true)
+
+		// totals are unaffected by the breakdown
+		assert.Equal(t, 15, got.Counts[JobFinished])
+		assert.Equal(t, []PoolJobCounts{
+			{
+				AgentPoolID: nil,
+				Counts: map[JobStatus]int{
+					JobUnallocated: 1, JobAllocated: 0, JobRunning: 0, JobFinished: 5, JobErrored: 0, JobCanceled: 0,
+				},
+			},
+			{
+				AgentPoolID: internal.String("pool-1"),
+				Counts: map[JobStatus]int{
+					JobUnallocated: 0, JobAllocated: 0, JobRunning: 2, JobFinished: 10, JobErrored: 0, JobCanceled: 0,
+				},
+			},
+			{
+				AgentPoolID: internal.String("pool-2"),
+				Counts: map[JobStatus]int{
+					JobUnallocated: 3, JobAllocated: 0, JobRunning: 0, JobFinished: 0, JobErrored: 1, JobCanceled: 0,
+				},
+			},
+		}, got.Pools)
+	})
+
+	t.Run("no jobs", func(t *testing.T) {
+		got := newJobCounts("acme", nil, true)
+
+		assert.Len(t, got.Counts, len(jobStatuses))
+		for _, count := range got.Counts {
+			assert.Zero(t, count)
+		}
+		assert.Empty(t, got.Pools)
+		assert.NotNil(t, got.Pools)
+	})
+}
@@ -2,8 +2,13 @@ package agent
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	otfrun "github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
 )
 
 func Test_jobSpecFromString(t *testing.T) {
@@ -45,6 +50,7 @@ func TestJob_updateStatus(t *testing.T) {
 		{"start job", JobAllocated, JobRunning, nil},
 		{"finish job", JobRunning, JobFinished, nil},
 		{"finish with error", JobRunning, JobErrored, nil},
+		{"reject unallocated job", JobUnallocated, JobErrored, nil},
 		{"cancel unstarted job", JobAllocated, JobCanceled, nil},
 		{"cancel running job", JobRunning, JobCanceled, nil},
 		{"cannot allocate canceled job", JobCanceled, JobAllocated, ErrInvalidJobStateTransition},
@@ -58,3 +64,176 @@ func TestJob_updateStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_migrate(t *testing.T) {
+	tests := []struct {
+		name    string
+		job     *Job
+		want    *Job
+		wantErr bool
+	}{
+		{
+			name: "migrate unallocated job",
+			job:  &Job{Status: JobUnallocated, AgentPoolID: internal.String("pool-1")},
+			want: &Job{Status: JobUnallocated, AgentPoolID: internal.String("pool-2")},
+		},
+		{
+			name: "migrate allocated job",
+			job:  &Job{Status: JobAllocated, AgentPoolID: internal.String("pool-1"), AgentID: internal.String("agent-1")},
+			want: &Job{Status: JobUnallocated, AgentPoolID: internal.String("pool-2")},
+		},
+		{
+			name:    "cannot migrate running job",
+			job:     &Job{Status: JobRunning, AgentPoolID: internal.String("pool-1"), AgentID: internal.String("agent-1")},
+			wantErr: true,
+		},
+		{
+			name:    "cannot migrate finished job",
+			job:     &Job{Status: JobFinished, AgentPoolID: internal.String("pool-1"), AgentID: internal.String("agent-1")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.job.migrate("pool-2")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, tt.job)
+		})
+	}
+}
+
+func TestJob_requeue(t *testing.T) {
+	allocatedAt := time.Now()
+	job := &Job{
+		Status:      JobAllocated,
+		AgentPoolID: internal.String("pool-1"),
+		AgentID:     internal.String("agent-1"),
+		AllocatedAt: &allocatedAt,
+	}
+	assert.NoError(t, job.requeue())
+	assert.Equal(t, &Job{Status: JobUnallocated, AgentPoolID: internal.String("pool-1")}, job)
+
+	// a job can only be requeued before it has started
+	assert.Error(t, (&Job{Status: JobRunning, AgentID: internal.String("agent-1")}).requeue())
+}
+
+func TestJob_cancel(t *testing.T) {
+	t.Run("graceful cancel signals running job", func(t *testing.T) {
+		job := &Job{Status: JobRunning}
+		run := &otfrun.Run{Status: otfrun.RunApplying, CancelSignaledAt: internal.Time(time.Now())}
+		signal, err := job.cancel(run)
+		assert.NoError(t, err)
+		assert.Equal(t, internal.Bool(false), signal)
+		assert.Equal(t, JobRunning, job.Status)
+	})
+
+	t.Run("force cancel kills running job", func(t *testing.T) {
+		job := &Job{Status: JobRunning}
+		run := &otfrun.Run{
+			Status:                 otfrun.RunForceCanceled,
+			ForceCancelAvailableAt: internal.Time(time.Now().Add(-time.Second)),
+		}
+		signal, err := job.cancel(run)
+		assert.NoError(t, err)
+		assert.Equal(t, internal.Bool(true), signal)
+		assert.Equal(t, internal.Bool(true), job.Signaled)
+		assert.Equal(t, JobCanceled, job.Status)
+	})
+
+	t.Run("force cancel before cool off period has elapsed", func(t *testing.T) {
+		job := &Job{Status: JobRunning}
+		run := &otfrun.Run{
+			Status:                 otfrun.RunForceCanceled,
+			ForceCancelAvailableAt: internal.Time(time.Now().Add(time.Minute)),
+		}
+		_, err := job.cancel(run)
+		assert.ErrorIs(t, err, otfrun.ErrRunForceCancelNotAllowed)
+		assert.Nil(t, job.Signaled)
+		assert.Equal(t, JobRunning, job.Status)
+	})
+}
+
+func TestJob_QueueTime(t *testing.T) {
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := created.Add(10 * time.Minute)
+
+	t.Run("unallocated job", func(t *testing.T) {
+		job := &Job{Status: JobUnallocated, CreatedAt: created}
+		assert.Equal(t, 10*time.Minute, job.QueueTime(now))
+	})
+
+	t.Run("allocated job", func(t *testing.T) {
+		job := &Job{
+			Status:      JobAllocated,
+			CreatedAt:   created,
+			AllocatedAt: internal.Time(created.Add(3 * time.Minute)),
+		}
+		assert.Equal(t, 3*time.Minute, job.QueueTime(now))
+	})
+}
+
+func TestNewJob_Labels(t *testing.T) {
+	labels := map[string]string{"team": "platform"}
+	job := newJob(&otfrun.Run{ID: "run-123", Labels: labels}, &workspace.Workspace{})
+	assert.Equal(t, labels, job.Labels)
+
+	// the job's labels are a copy of the run's
+	labels["team"] = "other"
+	assert.Equal(t, "platform", job.Labels["team"])
+}
+
+func TestNewJob_PhasePools(t *testing.T) {
+	ws := &workspace.Workspace{
+		AgentPoolID:      internal.String("pool-default"),
+		ApplyAgentPoolID: internal.String("pool-stable"),
+	}
+	plan := newJob(&otfrun.Run{ID: "run-123", Status: otfrun.RunPlanQueued}, ws)
+	assert.Equal(t, "pool-default", *plan.AgentPoolID)
+
+	apply := newJob(&otfrun.Run{ID: "run-123", Status: otfrun.RunApplyQueued}, ws)
+	assert.Equal(t, "pool-stable", *apply.AgentPoolID)
+
+	ws.PlanAgentPoolID = internal.String("pool-spot")
+	plan = newJob(&otfrun.Run{ID: "run-123", Status: otfrun.RunPlanQueued}, ws)
+	assert.Equal(t, "pool-spot", *plan.AgentPoolID)
+}
+
+func TestFilterJobs(t *testing.T) {
+	prod := &Job{Spec: JobSpec{RunID: "run-1"}, Labels: map[string]string{"env": "prod", "team": "platform"}}
+	dev := &Job{Spec: JobSpec{RunID: "run-2"}, Labels: map[string]string{"env": "dev", "team": "platform"}}
+	unlabelled := &Job{Spec: JobSpec{RunID: "run-3"}}
+	jobs := []*Job{prod, dev, unlabelled}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   []*Job
+	}{
+		{"no filter", nil, jobs},
+		{"single label", map[string]string{"team": "platform"}, []*Job{prod, dev}},
+		{"multiple labels", map[string]string{"team": "platform", "env": "prod"}, []*Job{prod}},
+		{"label with different value", map[string]string{"env": "staging"}, nil},
+		{"empty value does not match missing label", map[string]string{"owner": ""}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterJobs(jobs, ListJobsOptions{Labels: tt.labels})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseLabelFilters(t *testing.T) {
+	got, err := parseLabelFilters([]string{"team:platform", "url:https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform", "url": "https://example.com"}, got)
+
+	_, err = parseLabelFilters([]string{"team"})
+	assert.Error(t, err)
+	_, err = parseLabelFilters([]string{":platform"})
+	assert.Error(t, err)
+}
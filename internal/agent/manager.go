@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/tofutf/tofutf/internal"
@@ -9,6 +10,7 @@ import (
 
 var (
 	pingTimeout            = 30 * time.Second
+	unknownTimeout         = 5 * time.Minute
 	defaultManagerInterval = 10 * time.Second
 )
 
@@ -30,7 +32,7 @@ type manager struct {
 
 type managerClient interface {
 	listAgents(ctx context.Context) ([]*Agent, error)
-	updateAgentStatus(ctx context.Context, agentID string, status AgentStatus) error
+	updateAgentStatus(ctx context.Context, agentID string, status AgentStatus, reason string) (*statusUpdateResponse, error)
 	deleteAgent(ctx context.Context, agentID string) error
 }
 
@@ -86,14 +88,18 @@ func (m *manager) update(ctx context.Context, agent *Agent) error {
 		// update agent status to unknown if the agent has failed to ping within
 		// the timeout.
 		if time.Since(agent.LastPingAt) > pingTimeout {
-			return m.client.updateAgentStatus(ctx, agent.ID, AgentUnknown)
+			reason := fmt.Sprintf("heartbeat timeout: no ping received within %s", pingTimeout)
+			_, err := m.client.updateAgentStatus(ctx, agent.ID, AgentUnknown, reason)
+			return err
 		}
 	case AgentUnknown:
 		// update agent status from unknown to errored if a further period of 5
 		// minutes has elapsed.
-		if time.Since(agent.LastStatusAt) > 5*time.Minute {
+		if time.Since(agent.LastStatusAt) > unknownTimeout {
 			// update agent status to errored.
-			return m.client.updateAgentStatus(ctx, agent.ID, AgentErrored)
+			reason := fmt.Sprintf("heartbeat timeout: no ping received within %s of status becoming unknown", unknownTimeout)
+			_, err := m.client.updateAgentStatus(ctx, agent.ID, AgentErrored, reason)
+			return err
 		}
 	case AgentErrored, AgentExited:
 		// purge agent from database once a further 1 hour has elapsed for
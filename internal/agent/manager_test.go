@@ -16,6 +16,7 @@ func TestManager(t *testing.T) {
 		name        string
 		agent       *Agent
 		want        AgentStatus
+		wantReason  string
 		wantDeleted bool
 	}{
 		{
@@ -24,14 +25,16 @@ func TestManager(t *testing.T) {
 			want:  "",
 		},
 		{
-			name:  "update from idle to unknown",
-			agent: &Agent{Status: AgentIdle, LastPingAt: now.Add(-pingTimeout).Add(-time.Second)},
-			want:  AgentUnknown,
+			name:       "update from idle to unknown",
+			agent:      &Agent{Status: AgentIdle, LastPingAt: now.Add(-pingTimeout).Add(-time.Second)},
+			want:       AgentUnknown,
+			wantReason: "heartbeat timeout: no ping received within 30s",
 		},
 		{
-			name:  "update from unknown to errored",
-			agent: &Agent{Status: AgentUnknown, LastStatusAt: now.Add(-6 * time.Minute)},
-			want:  AgentErrored,
+			name:       "update from unknown to errored",
+			agent:      &Agent{Status: AgentUnknown, LastStatusAt: now.Add(-6 * time.Minute)},
+			want:       AgentErrored,
+			wantReason: "heartbeat timeout: no ping received within 5m0s of status becoming unknown",
 		},
 		{
 			name:        "delete",
@@ -47,6 +50,7 @@ func TestManager(t *testing.T) {
 			err := m.update(context.Background(), tt.agent)
 			require.NoError(t, err)
 			assert.Equal(t, tt.want, svc.status)
+			assert.Equal(t, tt.wantReason, svc.statusReason)
 		})
 	}
 }
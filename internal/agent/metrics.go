@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func init() {
+	prometheus.MustRegister(jobQueueWaitMetric)
+	prometheus.MustRegister(jobDurationMetric)
+}
+
+var (
+	jobQueueWaitMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "otf",
+		Subsystem: "job",
+		Name:      "queue_wait_seconds",
+		Help:      "Time a job waited to be allocated to an agent.",
+		Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12),
+	}, []string{"phase"})
+	jobDurationMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "otf",
+		Subsystem: "job",
+		Name:      "duration_seconds",
+		Help:      "Time taken by a job from its creation until it finished, by its final status.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"phase", "status"})
+)
+
+// exemplarTraceIDKey is the label of the exemplar identifying the trace of a
+// job.
+const exemplarTraceIDKey = "trace_id"
+
+// observeJob observes the duration of a job. If the job has a trace context
+// then the ID of its trace is attached to the observation as an exemplar,
+// permitting an operator to jump from the metric to the job's trace.
+func observeJob(obs prometheus.Observer, job *Job, d time.Duration) {
+	if eo, ok := obs.(prometheus.ExemplarObserver); ok {
+		if id := jobTraceID(job); id != "" {
+			eo.ObserveWithExemplar(d.Seconds(), prometheus.Labels{exemplarTraceIDKey: id})
+			return
+		}
+	}
+	obs.Observe(d.Seconds())
+}
+
+// jobTraceID returns the ID of the trace of a job, or an empty string if the
+// job has no trace context, e.g. because tracing is disabled.
+func jobTraceID(job *Job) string {
+	if job.TraceContext == "" {
+		return ""
+	}
+	carrier := propagation.MapCarrier{traceparentKey: job.TraceContext}
+	ctx := propagation.TraceContext{}.Extract(context.Background(), carrier)
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// observeJobQueueWait observes the time an allocated job waited to be
+// allocated.
+func observeJobQueueWait(job *Job) {
+	if job.AllocatedAt == nil {
+		return
+	}
+	observeJob(jobQueueWaitMetric.WithLabelValues(string(job.Spec.Phase)), job, job.AllocatedAt.Sub(job.CreatedAt))
+}
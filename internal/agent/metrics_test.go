@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestObserveJob(t *testing.T) {
+	ctx := context.Background()
+	spec := JobSpec{RunID: "run-123", Phase: internal.PlanPhase}
+
+	// observe returns the buckets of a new histogram after observing the job.
+	observe := func(t *testing.T, job *Job) []*dto.Bucket {
+		h := prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "test",
+			Buckets: []float64{1, 10},
+		})
+		observeJob(h, job, 5*time.Second)
+
+		var m dto.Metric
+		require.NoError(t, h.Write(&m))
+		return m.GetHistogram().GetBucket()
+	}
+
+	t.Run("with trace context", func(t *testing.T) {
+		tracer := newJobTracer(sdktrace.NewTracerProvider())
+		job := &Job{Spec: spec}
+		_, span := tracer.startCreate(ctx, job)
+		endSpan(span, nil)
+
+		buckets := observe(t, job)
+
+		require.Len(t, buckets, 2)
+		exemplar := buckets[1].GetExemplar()
+		require.NotNil(t, exemplar)
+		assert.Equal(t, 5.0, exemplar.GetValue())
+		require.Len(t, exemplar.GetLabel(), 1)
+		assert.Equal(t, exemplarTraceIDKey, exemplar.GetLabel()[0].GetName())
+		assert.Equal(t, span.SpanContext().TraceID().String(), exemplar.GetLabel()[0].GetValue())
+	})
+
+	t.Run("without trace context", func(t *testing.T) {
+		buckets := observe(t, &Job{Spec: spec})
+
+		require.Len(t, buckets, 2)
+		assert.Equal(t, uint64(1), buckets[1].GetCumulativeCount())
+		assert.Nil(t, buckets[1].GetExemplar())
+	})
+
+	t.Run("invalid trace context", func(t *testing.T) {
+		buckets := observe(t, &Job{Spec: spec, TraceContext: "garbage"})
+
+		require.Len(t, buckets, 2)
+		assert.Nil(t, buckets[1].GetExemplar())
+	})
+}
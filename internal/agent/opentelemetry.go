@@ -130,6 +130,29 @@ func (_d ServiceWithTracing) GetAgentPool(ctx context.Context, poolID string) (p
 	return _d.Service.GetAgentPool(ctx, poolID)
 }
 
+// GetAgentStatusHistory implements Service
+func (_d ServiceWithTracing) GetAgentStatusHistory(ctx context.Context, agentID string) (spa1 []*StatusChange, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Service.GetAgentStatusHistory")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":     ctx,
+				"agentID": agentID}, map[string]interface{}{
+				"spa1": spa1,
+				"err":  err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Service.GetAgentStatusHistory(ctx, agentID)
+}
+
 // GetAgentToken implements Service
 func (_d ServiceWithTracing) GetAgentToken(ctx context.Context, tokenID string) (ap1 *agentToken, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Service.GetAgentToken")
@@ -206,6 +229,22 @@ func (_d ServiceWithTracing) WatchAgents(ctx context.Context) (ch1 <-chan pubsub
 	return _d.Service.WatchAgents(ctx)
 }
 
+// WatchFinishedJobs implements Service
+func (_d ServiceWithTracing) WatchFinishedJobs(ctx context.Context, opts WatchFinishedJobsOptions) (ch1 <-chan pubsub.Event[*Job], f1 func()) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Service.WatchFinishedJobs")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"opts": opts}, map[string]interface{}{
+				"ch1": ch1,
+				"f1":  f1})
+		}
+		_span.End()
+	}()
+	return _d.Service.WatchFinishedJobs(ctx, opts)
+}
+
 // WatchJobs implements Service
 func (_d ServiceWithTracing) WatchJobs(ctx context.Context) (ch1 <-chan pubsub.Event[*Job], f1 func()) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Service.WatchJobs")
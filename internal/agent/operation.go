@@ -28,7 +28,11 @@ const (
 	localStateFilename = "terraform.tfstate"
 	planFilename       = "plan.out"
 	jsonPlanFilename   = "plan.out.json"
+	schemasFilename    = "schemas.json"
 	lockFilename       = ".terraform.lock.hcl"
+	// moduleManifestFilename is the manifest of modules installed by
+	// terraform init.
+	moduleManifestFilename = ".terraform/modules/modules.json"
 )
 
 var ascii = regexp.MustCompile("[[:^ascii:]]")
@@ -45,6 +49,8 @@ type operation struct {
 	ctx           context.Context
 	cancelfn      context.CancelFunc
 	out           io.Writer
+	plainOut      io.Writer // human-readable output, set only with structured run output
+	jsonOut       io.Writer // machine-readable output, set only with structured run output
 	terraformPath string
 	envs          []string
 	variables     []*variable.Variable
@@ -53,6 +59,9 @@ type operation struct {
 	token         []byte
 	agentID       string
 	isPoolAgent   bool
+	// stage is the category of error with which the job fails should the
+	// stage it is currently in fail.
+	stage run.ErrorCategory
 
 	*workdir
 }
@@ -90,7 +99,7 @@ func newOperation(opts newOperationOptions) *operation {
 
 // doAndFinish executes the job and marks the job as complete with the
 // appropriate status.
-func (o *operation) doAndFinish() {
+func (o *operation) doAndFinish() finishJobOptions {
 	// do the job, and then handle any error and send appropriate job status
 	// update
 	err := o.do()
@@ -104,14 +113,15 @@ func (o *operation) doAndFinish() {
 			// force-cancel signal. In which case there is nothing more to be
 			// done other than tell the user what happened.
 			o.logger.Error("job forceably canceled", "err", err)
-			return
+			return finishJobOptions{Status: JobCanceled}
 		}
 		opts.Status = JobCanceled
 		o.logger.Error("job canceled", "err", err)
 	case err != nil:
 		opts.Status = JobErrored
 		opts.Error = err.Error()
-		o.logger.Error("finished job with error", "err", err)
+		opts.ErrorCategory = classifyError(o.stage, err)
+		o.logger.Error("finished job with error", "err", err, "error_category", opts.ErrorCategory)
 	default:
 		opts.Status = JobFinished
 		o.logger.Info("finished job successfully")
@@ -119,10 +129,13 @@ func (o *operation) doAndFinish() {
 	if err := o.agents.finishJob(o.ctx, o.job.Spec, opts); err != nil {
 		o.logger.Error("sending job status", "status", opts.Status, "err", err)
 	}
+	return opts
 }
 
 // do executes the job
 func (o *operation) do() error {
+	o.stage = run.SetupErrorCategory
+
 	// if this is a pool agent using RPC to communicate with the server
 	// then use a new client for this job, configured to authenticate using the
 	// job token and to retry requests upon encountering transient errors.
@@ -154,7 +167,7 @@ func (o *operation) do() error {
 	if err != nil {
 		return fmt.Errorf("retreiving workspace: %w", err)
 	}
-	wd, err := newWorkdir(ws.WorkingDirectory)
+	wd, err := newWorkdir(o.config.WorkDir, ws.WorkingDirectory)
 	if err != nil {
 		return fmt.Errorf("constructing working directory: %w", err)
 	}
@@ -174,6 +187,11 @@ func (o *operation) do() error {
 			o.envs = append(o.envs, ev)
 		}
 	}
+	// debug logging is appended after variables so that it takes precedence
+	// over any log level set by a variable.
+	if run.DebugLogging {
+		o.envs = append(o.envs, "TF_LOG=DEBUG", "TF_LOG_PROVIDER=DEBUG")
+	}
 	writer := logs.NewPhaseWriter(o.ctx, logs.PhaseWriterOptions{
 		RunID:  run.ID,
 		Phase:  run.Phase(),
@@ -181,6 +199,21 @@ func (o *operation) do() error {
 	})
 	defer writer.Close()
 	o.out = writer
+	if run.StructuredRunOutput {
+		jsonWriter := logs.NewPhaseWriter(o.ctx, logs.PhaseWriterOptions{
+			RunID:  run.ID,
+			Phase:  run.Phase(),
+			Stream: internal.JSONLogStream,
+			Writer: o.logs,
+		})
+		defer jsonWriter.Close()
+		o.plainOut = writer
+		o.jsonOut = jsonWriter
+		// terraform prints any line of machine-readable output that is not
+		// JSON as it is, so output other than that produced with the -json
+		// flag is written to both streams.
+		o.out = io.MultiWriter(writer, jsonWriter)
+	}
 
 	// dump info if in debug mode
 	if o.config.Debug {
@@ -198,40 +231,15 @@ func (o *operation) do() error {
 		fmt.Fprintln(o.out)
 	}
 
-	// compile list of steps comprising operation
-	type step func(context.Context) error
-	steps := []step{
-		o.downloadTerraform,
-		o.downloadConfig,
-		o.writeTerraformVars,
-		o.deleteBackendConfig,
-		o.downloadState,
-	}
-	switch run.Phase() {
-	case internal.PlanPhase:
-		steps = append(steps, o.terraformInit)
-		steps = append(steps, o.terraformPlan)
-		steps = append(steps, o.convertPlanToJSON)
-		steps = append(steps, o.uploadPlan)
-		steps = append(steps, o.uploadJSONPlan)
-		steps = append(steps, o.uploadLockFile)
-	case internal.ApplyPhase:
-		// Download lock file from plan phase for the apply phase, to ensure
-		// same providers are used in both phases.
-		steps = append(steps, o.downloadLockFile)
-		steps = append(steps, o.downloadPlanFile)
-		steps = append(steps, o.terraformInit)
-		steps = append(steps, o.terraformApply)
-	}
-
 	// do each step
-	for _, step := range steps {
+	for _, step := range o.steps() {
 		// skip remaining steps if op is canceled
 		if o.canceled {
 			return fmt.Errorf("execution canceled")
 		}
 		// do step
-		if err := step(o.ctx); err != nil {
+		o.stage = step.category
+		if err := step.fn(o.ctx); err != nil {
 			// write error message to output
 			errbuilder := strings.Builder{}
 			errbuilder.WriteRune('\n')
@@ -249,6 +257,46 @@ func (o *operation) do() error {
 	return nil
 }
 
+// step is a step comprising an operation, along with the category of error
+// with which the job fails should the step fail.
+type step struct {
+	fn       func(context.Context) error
+	category run.ErrorCategory
+}
+
+// steps compiles the list of steps comprising the operation.
+func (o *operation) steps() []step {
+	steps := []step{
+		{o.downloadTerraform, run.SetupErrorCategory},
+		{o.downloadConfig, run.SetupErrorCategory},
+		{o.writeTerraformVars, run.SetupErrorCategory},
+		{o.deleteBackendConfig, run.SetupErrorCategory},
+		{o.downloadState, run.SetupErrorCategory},
+	}
+	switch o.Phase() {
+	case internal.PlanPhase:
+		steps = append(steps, step{o.terraformInit, run.InitErrorCategory})
+		steps = append(steps, step{o.terraformPlan, run.ExecutionErrorCategory})
+		steps = append(steps, step{o.convertPlanToJSON, run.ExecutionErrorCategory})
+		steps = append(steps, step{o.uploadPlan, run.ExecutionErrorCategory})
+		steps = append(steps, step{o.uploadJSONPlan, run.ExecutionErrorCategory})
+		if o.StructuredRunOutput {
+			steps = append(steps, step{o.writeProviderSchemas, run.ExecutionErrorCategory})
+			steps = append(steps, step{o.uploadRedactedPlan, run.ExecutionErrorCategory})
+		}
+		steps = append(steps, step{o.uploadLockFile, run.ExecutionErrorCategory})
+		steps = append(steps, step{o.uploadModuleManifest, run.ExecutionErrorCategory})
+	case internal.ApplyPhase:
+		// Download lock file from plan phase for the apply phase, to ensure
+		// same providers are used in both phases.
+		steps = append(steps, step{o.downloadLockFile, run.SetupErrorCategory})
+		steps = append(steps, step{o.downloadPlanFile, run.SetupErrorCategory})
+		steps = append(steps, step{o.terraformInit, run.InitErrorCategory})
+		steps = append(steps, step{o.terraformApply, run.ExecutionErrorCategory})
+	}
+	return steps
+}
+
 func (o *operation) cancel(force, sendSignal bool) {
 	o.canceled = true
 	// cancel context only if forced and if there is a context to cancel
@@ -272,6 +320,7 @@ type (
 	executionOptions struct {
 		sandboxIfEnabled bool
 		redirectStdout   *string
+		structuredOutput bool
 	}
 
 	executionOptionFunc func(*executionOptions)
@@ -292,6 +341,15 @@ func redirectStdout(dst string) executionOptionFunc {
 	}
 }
 
+// structuredOutputIfEnabled splits the machine-readable output of the process
+// into the human-readable and machine-readable streams of logs *if* structured
+// run output is enabled. The process must be invoked with the -json flag.
+func structuredOutputIfEnabled() executionOptionFunc {
+	return func(e *executionOptions) {
+		e.structuredOutput = true
+	}
+}
+
 // execute executes a process.
 func (o *operation) execute(args []string, funcs ...executionOptionFunc) error {
 	if len(args) == 0 {
@@ -316,6 +374,10 @@ func (o *operation) execute(args []string, funcs ...executionOptionFunc) error {
 		}
 		defer dst.Close()
 		cmd.Stdout = dst
+	} else if opts.structuredOutput && o.StructuredRunOutput {
+		events := newStructuredOutputWriter(o.plainOut, o.jsonOut)
+		defer events.Close()
+		cmd.Stdout = events
 	} else {
 		cmd.Stdout = o.out
 	}
@@ -360,8 +422,21 @@ func (o *operation) addSandboxWrapper(args []string) []string {
 }
 
 func (o *operation) downloadTerraform(ctx context.Context) error {
+	// relay the progress of the download to the job's logs, including that of
+	// a download of the same version already in-flight for another job, which
+	// this job waits upon.
+	events, unsubscribe := o.downloader.Subscribe(ctx, o.TerraformVersion)
+	out := &lockedWriter{Writer: o.out}
+	relayed := make(chan struct{})
+	go func() {
+		relayDownloadEvents(events, out)
+		close(relayed)
+	}()
+
 	var err error
-	o.terraformPath, err = o.downloader.Download(ctx, o.TerraformVersion, o.out)
+	o.terraformPath, err = o.downloader.Download(ctx, o.TerraformVersion, out)
+	unsubscribe()
+	<-relayed
 	return err
 }
 
@@ -427,7 +502,10 @@ func (o *operation) terraformPlan(ctx context.Context) error {
 		args = append(args, "-destroy")
 	}
 	args = append(args, "-out="+planFilename)
-	return o.execute(append([]string{o.terraformPath}, args...))
+	if o.StructuredRunOutput {
+		args = append(args, "-json")
+	}
+	return o.execute(append([]string{o.terraformPath}, args...), structuredOutputIfEnabled())
 }
 
 func (o *operation) terraformApply(ctx context.Context) (err error) {
@@ -462,8 +540,11 @@ func (o *operation) terraformApply(ctx context.Context) (err error) {
 	if o.IsDestroy {
 		args = append(args, "-destroy")
 	}
+	if o.StructuredRunOutput {
+		args = append(args, "-json")
+	}
 	args = append(args, planFilename)
-	return o.execute(append([]string{o.terraformPath}, args...), sandboxIfEnabled())
+	return o.execute(append([]string{o.terraformPath}, args...), sandboxIfEnabled(), structuredOutputIfEnabled())
 }
 
 func (o *operation) convertPlanToJSON(ctx context.Context) error {
@@ -498,6 +579,35 @@ func (o *operation) uploadJSONPlan(ctx context.Context) error {
 	return nil
 }
 
+// writeProviderSchemas writes the schemas of the providers used by the
+// configuration to disk, for inclusion in the redacted plan.
+func (o *operation) writeProviderSchemas(ctx context.Context) error {
+	args := []string{"providers", "schema", "-json"}
+	return o.execute(
+		append([]string{o.terraformPath}, args...),
+		redirectStdout(schemasFilename),
+	)
+}
+
+func (o *operation) uploadRedactedPlan(ctx context.Context) error {
+	jsonFile, err := o.readFile(jsonPlanFilename)
+	if err != nil {
+		return err
+	}
+	schemas, err := o.readFile(schemasFilename)
+	if err != nil {
+		return err
+	}
+	redacted, err := run.NewRedactedPlan(jsonFile, schemas)
+	if err != nil {
+		return fmt.Errorf("redacting plan: %w", err)
+	}
+	if err := o.runs.UploadPlanFile(ctx, o.ID, redacted, run.PlanFormatRedactedJSON); err != nil {
+		return fmt.Errorf("unable to upload redacted JSON plan: %w", err)
+	}
+	return nil
+}
+
 func (o *operation) uploadLockFile(ctx context.Context) error {
 	lockFile, err := o.readFile(lockFilename)
 	if errors.Is(err, fs.ErrNotExist) {
@@ -512,6 +622,20 @@ func (o *operation) uploadLockFile(ctx context.Context) error {
 	return nil
 }
 
+func (o *operation) uploadModuleManifest(ctx context.Context) error {
+	manifest, err := o.readFile(moduleManifestFilename)
+	if errors.Is(err, fs.ErrNotExist) {
+		// the configuration doesn't call any modules, which is ok
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("reading module manifest: %w", err)
+	}
+	if err := o.runs.UploadModuleManifest(ctx, o.ID, manifest); err != nil {
+		return fmt.Errorf("unable to upload module manifest: %w", err)
+	}
+	return nil
+}
+
 func (o *operation) downloadPlanFile(ctx context.Context) error {
 	plan, err := o.runs.GetPlanFile(ctx, o.ID, run.PlanFormatBinary)
 	if err != nil {
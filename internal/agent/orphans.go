@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// OrphanReconcilerLockID guarantees only one orphaned job reconciler on a
+// cluster is running at any time.
+const OrphanReconcilerLockID int64 = 5577006791947779422
+
+const defaultOrphanReconcilerInterval = 10 * time.Minute
+
+// OrphanedJob is a job whose run no longer exists, e.g. because the deletion
+// of the run failed to cascade to its jobs.
+type OrphanedJob struct {
+	Spec    JobSpec
+	Status  JobStatus
+	AgentID *string
+}
+
+func (j *OrphanedJob) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("run_id", j.Spec.RunID),
+		slog.String("phase", string(j.Spec.Phase)),
+		slog.String("status", string(j.Status)),
+	}
+	if j.AgentID != nil {
+		attrs = append(attrs, slog.String("agent_id", *j.AgentID))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// orphanReconciler cleans up orphaned jobs. Deleting an orphaned job releases
+// the capacity of any agent it was allocated to.
+//
+// Only one reconciler should be running on a cluster at any one time.
+type orphanReconciler struct {
+	logger *slog.Logger
+	client orphanReconcilerClient
+	// frequency with which the reconciler checks for orphaned jobs.
+	interval time.Duration
+}
+
+type orphanReconcilerClient interface {
+	listOrphanedJobs(ctx context.Context) ([]*OrphanedJob, error)
+	deleteOrphanedJob(ctx context.Context, spec JobSpec) error
+}
+
+func newOrphanReconciler(logger *slog.Logger, client orphanReconcilerClient) *orphanReconciler {
+	return &orphanReconciler{
+		logger:   logger.With("component", "orphaned-job-reconciler"),
+		client:   client,
+		interval: defaultOrphanReconcilerInterval,
+	}
+}
+
+func (r *orphanReconciler) String() string { return "orphaned-job-reconciler" }
+
+// Start the reconciler. Should be invoked in a go routine.
+func (r *orphanReconciler) Start(ctx context.Context) error {
+	// run at startup and then every interval
+	if _, err := r.reconcile(ctx); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := r.reconcile(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reconcile deletes orphaned jobs, returning those that were deleted. A job
+// that cannot be deleted is logged and left for the next reconciliation.
+func (r *orphanReconciler) reconcile(ctx context.Context) ([]*OrphanedJob, error) {
+	orphans, err := r.client.listOrphanedJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deleted := make([]*OrphanedJob, 0, len(orphans))
+	for _, job := range orphans {
+		if err := r.client.deleteOrphanedJob(ctx, job.Spec); err != nil {
+			r.logger.Error("deleting orphaned job", "job", job, "err", err)
+			continue
+		}
+		r.logger.Info("deleted orphaned job", "job", job)
+		deleted = append(deleted, job)
+	}
+	return deleted, nil
+}
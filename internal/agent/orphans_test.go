@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+type fakeOrphanReconcilerClient struct {
+	orphans []*OrphanedJob
+	// jobs that cannot be deleted.
+	undeletable map[JobSpec]bool
+	deleted     []JobSpec
+}
+
+func (f *fakeOrphanReconcilerClient) listOrphanedJobs(context.Context) ([]*OrphanedJob, error) {
+	return f.orphans, nil
+}
+
+func (f *fakeOrphanReconcilerClient) deleteOrphanedJob(ctx context.Context, spec JobSpec) error {
+	if f.undeletable[spec] {
+		return errors.New("connection reset")
+	}
+	f.deleted = append(f.deleted, spec)
+	return nil
+}
+
+func TestOrphanReconciler(t *testing.T) {
+	// fixture of a job that was running on an agent when its run was deleted,
+	// and another job that cannot be deleted.
+	running := &OrphanedJob{
+		Spec:    JobSpec{RunID: "run-deleted", Phase: internal.PlanPhase},
+		Status:  JobRunning,
+		AgentID: internal.String("agent-123"),
+	}
+	undeletable := &OrphanedJob{
+		Spec:   JobSpec{RunID: "run-undeletable", Phase: internal.ApplyPhase},
+		Status: JobUnallocated,
+	}
+	client := &fakeOrphanReconcilerClient{
+		orphans:     []*OrphanedJob{running, undeletable},
+		undeletable: map[JobSpec]bool{undeletable.Spec: true},
+	}
+	r := newOrphanReconciler(slog.New(&xslog.NoopHandler{}), client)
+
+	// a job that cannot be deleted does not prevent other jobs from being
+	// deleted
+	deleted, err := r.reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*OrphanedJob{running}, deleted)
+	assert.Equal(t, []JobSpec{running.Spec}, client.deleted)
+}
@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/resource"
+	tofutfrun "github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+type queuedRunClient interface {
+	List(ctx context.Context, opts tofutfrun.ListOptions) (*resource.Page[*tofutfrun.Run], error)
+}
+
+// jobAllowed determines whether a job should be created for a run on the given
+// workspace. If the workspace is paused then no job is created, and, if reject
+// is true, an error is returned rejecting the run.
+func jobAllowed(ws *workspace.Workspace, reject bool) (bool, error) {
+	if !ws.Paused {
+		return true, nil
+	}
+	if reject {
+		return false, workspace.ErrWorkspacePaused
+	}
+	return false, nil
+}
+
+// createPausedJobs creates jobs for runs that were queued whilst their
+// workspace was paused.
+func (s *service) createPausedJobs(ctx context.Context, ws *workspace.Workspace) error {
+	sysctx := internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "job-creator"})
+	runs, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*tofutfrun.Run], error) {
+		return s.queuedRuns.List(sysctx, tofutfrun.ListOptions{
+			PageOptions: opts,
+			WorkspaceID: &ws.ID,
+			Statuses:    []tofutfrun.Status{tofutfrun.RunPlanQueued, tofutfrun.RunApplyQueued},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		// skip runs that were enqueued before the workspace was paused and
+		// therefore already have a job.
+		_, err := s.db.getJob(ctx, JobSpec{RunID: run.ID, Phase: run.Phase()})
+		if err == nil {
+			continue
+		} else if !errors.Is(err, internal.ErrResourceNotFound) {
+			return err
+		}
+		if err := s.insertJob(ctx, ws, run); err != nil {
+			return err
+		}
+		s.logger.Info("created job for run queued on paused workspace", "run_id", run.ID, "workspace", ws.ID)
+	}
+	return nil
+}
@@ -3,19 +3,39 @@ package agent
 
 import (
 	"errors"
+	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"log/slog"
 
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/resource"
+	"github.com/tofutf/tofutf/internal/semver"
 )
 
 var (
 	ErrCannotDeletePoolReferencedByWorkspaces = errors.New("agent pool is still being used by workspaces in your organization. You must switch your workspaces to a different agent pool or execution mode before you can delete this agent pool")
 	ErrWorkspaceNotAllowedToUsePool           = errors.New("access to this agent pool is not allowed - you must explictly grant access to the workspace first")
 	ErrPoolAssignedWorkspacesNotAllowed       = errors.New("workspaces assigned to the pool have not been granted access to the pool")
+	ErrCannotDeletePoolWithQueuedJobs         = errors.New("agent pool has queued jobs. You must choose to either cancel the jobs or migrate them to another agent pool before you can delete this agent pool")
+	ErrInvalidQueuedJobsMigrationPool         = errors.New("queued jobs must be migrated to a different agent pool in the same organization")
+	ErrPoolNotDeleted                         = errors.New("agent pool has not been deleted")
+	ErrPoolRecoveryWindowElapsed              = errors.New("agent pool can no longer be recovered because its recovery window has elapsed")
+	ErrCannotRecoverPoolNameTaken             = errors.New("another agent pool in your organization has the same name. You must rename or delete it before you can recover this agent pool")
+	ErrTerraformVersionNotAllowedByPool       = errors.New("terraform version is not allowed by the agent pool")
+	ErrPoolQuotaExceeded                      = errors.New("organization has reached its maximum number of agent pools")
+	ErrInvalidMinAgents                       = errors.New("minimum number of agents must not be negative")
+)
+
+const (
+	// CancelQueuedJobs cancels the runs of jobs queued for a pool that is
+	// being deleted.
+	CancelQueuedJobs QueuedJobsAction = "cancel"
+	// MigrateQueuedJobs migrates jobs queued for a pool that is being deleted
+	// to another pool.
+	MigrateQueuedJobs QueuedJobsAction = "migrate"
 )
 
 type (
@@ -37,6 +57,19 @@ type (
 		// IDs of workspaces assigned to the pool. Note: this is a subset of
 		// AllowedWorkspaces.
 		AssignedWorkspaces []string
+		// DeletedAt is when the pool was deleted. Nil if the pool has not
+		// been deleted. A deleted pool is hidden until it is either
+		// recovered or its recovery window elapses, whereupon it is purged.
+		DeletedAt *time.Time
+		// AllowedTerraformVersions is a version constraint, e.g. ">= 1.5, <
+		// 1.8", restricting the terraform versions of jobs the pool's agents
+		// can be allocated. Nil if any version is allowed.
+		AllowedTerraformVersions *string
+		// MinAgents is the minimum number of the pool's agents that must be
+		// ready to accept jobs before any job is allocated to them, e.g. to
+		// avoid a single agent handling every job during a rolling restart.
+		// Zero disables the minimum.
+		MinAgents int
 	}
 
 	CreateAgentPoolOptions struct {
@@ -47,6 +80,13 @@ type (
 		OrganizationScoped *bool
 		// IDs of workspaces allowed to access the pool.
 		AllowedWorkspaces []string
+		// Constraint restricting the terraform versions of jobs allocated to
+		// the pool's agents. Optional.
+		AllowedTerraformVersions *string
+		// Minimum number of agents that must be ready before jobs are
+		// allocated to the pool's agents. Optional; defaults to 0, which
+		// disables the minimum.
+		MinAgents *int
 	}
 
 	updatePoolOptions struct {
@@ -57,6 +97,45 @@ type (
 		// IDs of workspaces assigned to the pool. Note: this is a subset of
 		// AssignedWorkspaces.
 		AssignedWorkspaces []string `schema:"assigned_workspaces"`
+		// Constraint restricting the terraform versions of jobs allocated to
+		// the pool's agents. An empty string removes the constraint.
+		AllowedTerraformVersions *string `schema:"allowed_terraform_versions"`
+		// Minimum number of agents that must be ready before jobs are
+		// allocated to the pool's agents. Zero disables the minimum.
+		MinAgents *int `schema:"min_agents"`
+		// DryRun reports the workspaces that would lose access to the pool
+		// without applying the update.
+		DryRun bool `schema:"dry_run"`
+		// IDs of workspaces assigned to the pool that the caller acknowledges
+		// will lose access to the pool. The update is refused if any
+		// workspace would lose access without being acknowledged.
+		AcknowledgedWorkspaces []string `schema:"acknowledged_workspaces"`
+	}
+
+	// ImpactedWorkspace is a workspace assigned to a pool that would lose
+	// access to the pool as a result of an update.
+	ImpactedWorkspace struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// ImpactError is returned when an update to a pool would revoke access
+	// from workspaces assigned to the pool that have not been acknowledged.
+	ImpactError struct {
+		Workspaces []ImpactedWorkspace
+	}
+
+	// QueuedJobsAction is the action to take on jobs queued for a pool when
+	// the pool is deleted.
+	QueuedJobsAction string
+
+	deletePoolOptions struct {
+		// Action to take on jobs queued for the pool, i.e. jobs that have
+		// not yet started. Only required if there are queued jobs.
+		QueuedJobs QueuedJobsAction `schema:"queued_jobs"`
+		// ID of pool to migrate queued jobs to. Required if QueuedJobs is
+		// MigrateQueuedJobs.
+		MigrateToPoolID *string `schema:"migrate_to_pool_id"`
 	}
 
 	listPoolOptions struct {
@@ -69,9 +148,9 @@ type (
 	}
 )
 
-// newPool constructs a new agent pool. Note: a new pool has a list of allowed
+// NewPool constructs a new agent pool. Note: a new pool has a list of allowed
 // workspaces but not yet a list of assigned workspaces.
-func newPool(opts CreateAgentPoolOptions) (*Pool, error) {
+func NewPool(opts CreateAgentPoolOptions) (*Pool, error) {
 	if opts.Name == "" {
 		return nil, errors.New("name must not be empty")
 	}
@@ -89,6 +168,14 @@ func newPool(opts CreateAgentPoolOptions) (*Pool, error) {
 	if opts.OrganizationScoped != nil {
 		pool.OrganizationScoped = *opts.OrganizationScoped
 	}
+	if err := pool.setAllowedTerraformVersions(opts.AllowedTerraformVersions); err != nil {
+		return nil, err
+	}
+	if opts.MinAgents != nil {
+		if err := pool.setMinAgents(*opts.MinAgents); err != nil {
+			return nil, err
+		}
+	}
 	return pool, nil
 }
 
@@ -105,25 +192,137 @@ func (p *Pool) update(opts updatePoolOptions) error {
 	if opts.AllowedWorkspaces != nil {
 		p.AllowedWorkspaces = opts.AllowedWorkspaces
 	}
-	// if not organization scoped then each assigned workspace must also be
-	// allowed.
-	if !p.OrganizationScoped {
-		for _, assigned := range p.AssignedWorkspaces {
-			if !slices.Contains(p.AllowedWorkspaces, assigned) {
-				return ErrPoolAssignedWorkspacesNotAllowed
-			}
+	if opts.AllowedTerraformVersions != nil {
+		if err := p.setAllowedTerraformVersions(opts.AllowedTerraformVersions); err != nil {
+			return err
 		}
 	}
+	if opts.MinAgents != nil {
+		if err := p.setMinAgents(*opts.MinAgents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Pool) setMinAgents(min int) error {
+	if min < 0 {
+		return ErrInvalidMinAgents
+	}
+	p.MinAgents = min
+	return nil
+}
+
+// setAllowedTerraformVersions sets the constraint restricting the terraform
+// versions the pool allows. A nil or empty constraint allows any version.
+func (p *Pool) setAllowedTerraformVersions(constraint *string) error {
+	if constraint == nil || *constraint == "" {
+		p.AllowedTerraformVersions = nil
+		return nil
+	}
+	if !semver.IsConstraint(*constraint) {
+		return fmt.Errorf("%w: allowed terraform versions must be a version constraint, e.g. \">= 1.5, < 1.8\": %s", internal.ErrInvalidTerraformVersion, *constraint)
+	}
+	p.AllowedTerraformVersions = constraint
+	return nil
+}
+
+// CheckTerraformVersion determines whether the pool allows the given terraform
+// version. Only concrete versions are checked.
+func (p *Pool) CheckTerraformVersion(version string) error {
+	if p.AllowedTerraformVersions == nil || !semver.IsValid(version) {
+		return nil
+	}
+	if !semver.Satisfies(*p.AllowedTerraformVersions, version) {
+		return fmt.Errorf("%w: %s does not satisfy %s", ErrTerraformVersionNotAllowedByPool, version, *p.AllowedTerraformVersions)
+	}
+	return nil
+}
+
+// softDelete marks the pool as deleted.
+func (p *Pool) softDelete() {
+	p.DeletedAt = internal.Time(internal.CurrentTimestamp(nil))
+}
+
+// undelete recovers a deleted pool, provided its recovery window has not
+// elapsed.
+func (p *Pool) undelete(window time.Duration) error {
+	if p.DeletedAt == nil {
+		return ErrPoolNotDeleted
+	}
+	if p.expired(internal.CurrentTimestamp(nil), window) {
+		return ErrPoolRecoveryWindowElapsed
+	}
+	p.DeletedAt = nil
 	return nil
 }
 
+// checkPoolQuota checks whether another pool can be created in an
+// organization that already has the given number of pools. The
+// organization's maximum, if non-nil, takes precedence over the default
+// maximum. A maximum of zero means there is no limit.
+func checkPoolQuota(pools int, max *int, defaultMax int) error {
+	limit := defaultMax
+	if max != nil {
+		limit = *max
+	}
+	if limit > 0 && pools >= limit {
+		return fmt.Errorf("%w: limit is %d", ErrPoolQuotaExceeded, limit)
+	}
+	return nil
+}
+
+// RecoverableUntil returns the time until which a deleted pool can be
+// recovered.
+func (p *Pool) RecoverableUntil(window time.Duration) time.Time {
+	if p.DeletedAt == nil {
+		return time.Time{}
+	}
+	return p.DeletedAt.Add(window)
+}
+
+// expired determines whether the recovery window of a deleted pool has
+// elapsed.
+func (p *Pool) expired(now time.Time, window time.Duration) bool {
+	return p.DeletedAt != nil && !now.Before(p.RecoverableUntil(window))
+}
+
+func (e *ImpactError) Error() string {
+	names := make([]string, len(e.Workspaces))
+	for i, ws := range e.Workspaces {
+		names[i] = ws.Name
+	}
+	return fmt.Sprintf("%s: %s", ErrPoolAssignedWorkspacesNotAllowed, strings.Join(names, ", "))
+}
+
+func (e *ImpactError) Unwrap() error { return ErrPoolAssignedWorkspacesNotAllowed }
+
+// unacknowledged returns those impacted workspaces that have not been
+// acknowledged.
+func unacknowledged(impacted []ImpactedWorkspace, acknowledged []string) []ImpactedWorkspace {
+	var unacked []ImpactedWorkspace
+	for _, ws := range impacted {
+		if !slices.Contains(acknowledged, ws.ID) {
+			unacked = append(unacked, ws)
+		}
+	}
+	return unacked
+}
+
 func (p *Pool) LogValue() slog.Value {
-	return slog.GroupValue(
+	attrs := []slog.Attr{
 		slog.String("id", p.ID),
 		slog.String("name", p.Name),
 		slog.String("organization", p.Organization),
 		slog.Bool("organization_scoped", p.OrganizationScoped),
 		slog.Any("workspaces", p.AssignedWorkspaces),
 		slog.Any("allowed_workspaces", p.AllowedWorkspaces),
-	)
+	}
+	if p.AllowedTerraformVersions != nil {
+		attrs = append(attrs, slog.String("allowed_terraform_versions", *p.AllowedTerraformVersions))
+	}
+	if p.MinAgents > 0 {
+		attrs = append(attrs, slog.Int("min_agents", p.MinAgents))
+	}
+	return slog.GroupValue(attrs...)
 }
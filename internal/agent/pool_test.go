@@ -0,0 +1,182 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestUnacknowledged(t *testing.T) {
+	impacted := []ImpactedWorkspace{
+		{ID: "ws-1", Name: "dev"},
+		{ID: "ws-2", Name: "prod"},
+	}
+
+	assert.Equal(t, impacted, unacknowledged(impacted, nil))
+	assert.Equal(t, impacted[1:], unacknowledged(impacted, []string{"ws-1"}))
+	assert.Empty(t, unacknowledged(impacted, []string{"ws-1", "ws-2"}))
+	assert.Empty(t, unacknowledged(nil, []string{"ws-1"}))
+}
+
+func TestImpactError(t *testing.T) {
+	err := &ImpactError{Workspaces: []ImpactedWorkspace{
+		{ID: "ws-1", Name: "dev"},
+		{ID: "ws-2", Name: "prod"},
+	}}
+
+	assert.True(t, errors.Is(err, ErrPoolAssignedWorkspacesNotAllowed))
+	assert.Equal(t, "workspaces assigned to the pool have not been granted access to the pool: dev, prod", err.Error())
+}
+
+func TestPool_softDelete(t *testing.T) {
+	pool := &Pool{ID: "pool-1", AllowedWorkspaces: []string{"ws-1"}}
+
+	t.Run("delete", func(t *testing.T) {
+		pool.softDelete()
+		require.NotNil(t, pool.DeletedAt)
+		assert.Equal(t, pool.DeletedAt.Add(time.Hour), pool.RecoverableUntil(time.Hour))
+	})
+
+	t.Run("recover", func(t *testing.T) {
+		err := pool.undelete(time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, pool.DeletedAt)
+		assert.Equal(t, []string{"ws-1"}, pool.AllowedWorkspaces)
+	})
+
+	t.Run("cannot recover pool that has not been deleted", func(t *testing.T) {
+		err := pool.undelete(time.Hour)
+		assert.ErrorIs(t, err, ErrPoolNotDeleted)
+	})
+
+	t.Run("cannot recover pool after window elapses", func(t *testing.T) {
+		pool.DeletedAt = internal.Time(time.Now().Add(-2 * time.Hour))
+		err := pool.undelete(time.Hour)
+		assert.ErrorIs(t, err, ErrPoolRecoveryWindowElapsed)
+		assert.NotNil(t, pool.DeletedAt)
+	})
+}
+
+func TestCheckPoolQuota(t *testing.T) {
+	tests := []struct {
+		name       string
+		pools      int
+		max        *int
+		defaultMax int
+		wantErr    bool
+	}{
+		{"no limit", 100, nil, 0, false},
+		{"under default limit", 2, nil, 3, false},
+		{"at default limit", 3, nil, 3, true},
+		{"organization limit overrides default", 3, internal.Int(5), 3, false},
+		{"at organization limit", 1, internal.Int(1), 3, true},
+		{"organization has no limit", 100, internal.Int(0), 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPoolQuota(tt.pools, tt.max, tt.defaultMax)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrPoolQuotaExceeded)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPool_expired(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		deletedAt *time.Time
+		want      bool
+	}{
+		{"not deleted", nil, false},
+		{"within window", internal.Time(now.Add(-time.Minute)), false},
+		{"window elapsed", internal.Time(now.Add(-time.Hour)), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &Pool{DeletedAt: tt.deletedAt}
+			assert.Equal(t, tt.want, pool.expired(now, time.Hour))
+		})
+	}
+}
+
+func TestPool_AllowedTerraformVersions(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		pool, err := NewPool(CreateAgentPoolOptions{
+			Name:                     "pool-1",
+			Organization:             "acme",
+			AllowedTerraformVersions: internal.String(">= 1.5, < 1.8"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ">= 1.5, < 1.8", *pool.AllowedTerraformVersions)
+	})
+
+	t.Run("invalid constraint", func(t *testing.T) {
+		_, err := NewPool(CreateAgentPoolOptions{
+			Name:                     "pool-1",
+			Organization:             "acme",
+			AllowedTerraformVersions: internal.String("1.6.0"),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("remove constraint", func(t *testing.T) {
+		pool := &Pool{AllowedTerraformVersions: internal.String(">= 1.5")}
+		err := pool.update(updatePoolOptions{AllowedTerraformVersions: internal.String("")})
+		require.NoError(t, err)
+		assert.Nil(t, pool.AllowedTerraformVersions)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		pool := &Pool{AllowedTerraformVersions: internal.String(">= 1.5, < 1.8")}
+		assert.NoError(t, pool.CheckTerraformVersion("1.6.0"))
+		assert.ErrorIs(t, pool.CheckTerraformVersion("1.8.0"), ErrTerraformVersionNotAllowedByPool)
+		// unconstrained pool allows any version
+		assert.NoError(t, (&Pool{}).CheckTerraformVersion("1.8.0"))
+	})
+}
+
+func TestPool_MinAgents(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		pool, err := NewPool(CreateAgentPoolOptions{
+			Name:         "pool-1",
+			Organization: "acme",
+			MinAgents:    internal.Int(2),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, pool.MinAgents)
+	})
+
+	t.Run("defaults to disabled", func(t *testing.T) {
+		pool, err := NewPool(CreateAgentPoolOptions{
+			Name:         "pool-1",
+			Organization: "acme",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, pool.MinAgents)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := NewPool(CreateAgentPoolOptions{
+			Name:         "pool-1",
+			Organization: "acme",
+			MinAgents:    internal.Int(-1),
+		})
+		assert.ErrorIs(t, err, ErrInvalidMinAgents)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		pool := &Pool{MinAgents: 2}
+		err := pool.update(updatePoolOptions{MinAgents: internal.Int(0)})
+		require.NoError(t, err)
+		assert.Equal(t, 0, pool.MinAgents)
+	})
+}
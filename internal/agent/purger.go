@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// PurgerLockID guarantees only one purger on a cluster is running at any
+// time.
+const PurgerLockID int64 = 5577006791947779420
+
+const (
+	// DefaultPoolRecoveryWindow is the default duration for which a deleted
+	// pool can be recovered before it is purged.
+	DefaultPoolRecoveryWindow = 7 * 24 * time.Hour
+
+	defaultPurgerInterval = time.Hour
+)
+
+// purger purges deleted pools once their recovery window has elapsed.
+//
+// Only one purger should be running on a cluster at any one time.
+type purger struct {
+	logger *slog.Logger
+	client purgerClient
+	// window is the duration for which a deleted pool can be recovered.
+	window time.Duration
+	// frequency with which the purger checks for pools to purge.
+	interval time.Duration
+}
+
+type purgerClient interface {
+	listPoolsDeletedBefore(ctx context.Context, before time.Time) ([]string, error)
+	deleteAgentPool(ctx context.Context, poolID string) error
+}
+
+func newPurger(logger *slog.Logger, client purgerClient, window time.Duration) *purger {
+	return &purger{
+		logger:   logger.With("component", "agent-pool-purger"),
+		client:   client,
+		window:   window,
+		interval: defaultPurgerInterval,
+	}
+}
+
+func (p *purger) String() string { return "agent-pool-purger" }
+
+// Start the purger. Should be invoked in a go routine.
+func (p *purger) Start(ctx context.Context) error {
+	// run at startup and then every interval
+	if err := p.purge(ctx, time.Now()); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.purge(ctx, time.Now()); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// purge permanently deletes pools whose recovery window has elapsed by now. A
+// pool that cannot be deleted is logged and left for the next purge.
+func (p *purger) purge(ctx context.Context, now time.Time) error {
+	poolIDs, err := p.client.listPoolsDeletedBefore(ctx, now.Add(-p.window))
+	if err != nil {
+		return err
+	}
+	for _, poolID := range poolIDs {
+		if err := p.client.deleteAgentPool(ctx, poolID); err != nil {
+			p.logger.Error("purging deleted agent pool", "agent_pool_id", poolID, "err", err)
+			continue
+		}
+		p.logger.Info("purged deleted agent pool", "agent_pool_id", poolID)
+	}
+	return nil
+}
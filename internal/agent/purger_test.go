@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+type fakePurgerClient struct {
+	// deleted pools keyed by ID, with the time each was deleted.
+	deleted map[string]time.Time
+	// pools that cannot be deleted.
+	undeletable map[string]bool
+	purged      []string
+}
+
+func (f *fakePurgerClient) listPoolsDeletedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	var poolIDs []string
+	for id, deletedAt := range f.deleted {
+		if deletedAt.Before(before) {
+			poolIDs = append(poolIDs, id)
+		}
+	}
+	return poolIDs, nil
+}
+
+func (f *fakePurgerClient) deleteAgentPool(ctx context.Context, poolID string) error {
+	if f.undeletable[poolID] {
+		return errors.New("foreign key violation")
+	}
+	f.purged = append(f.purged, poolID)
+	return nil
+}
+
+func TestPurger(t *testing.T) {
+	now := time.Now()
+	client := &fakePurgerClient{
+		deleted: map[string]time.Time{
+			"pool-recent":      now.Add(-time.Minute),
+			"pool-expired":     now.Add(-2 * time.Hour),
+			"pool-undeletable": now.Add(-2 * time.Hour),
+		},
+		undeletable: map[string]bool{"pool-undeletable": true},
+	}
+	p := newPurger(slog.New(&xslog.NoopHandler{}), client, time.Hour)
+
+	// a pool that cannot be deleted does not prevent other pools from being
+	// purged
+	err := p.purge(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pool-expired"}, client.purged)
+}
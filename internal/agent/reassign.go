@@ -0,0 +1,125 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+var (
+	ErrInvalidReassignmentPool = errors.New("workspaces can only be reassigned to a different pool in the same organization")
+	ErrWorkspaceNotInPool      = errors.New("workspace is not assigned to the pool")
+)
+
+type (
+	// ReassignResult reports the outcome of reassigning workspaces from one
+	// pool to another.
+	ReassignResult struct {
+		// Reassigned lists the IDs of the workspaces reassigned to the
+		// destination pool.
+		Reassigned []string
+		// Failed lists the workspaces that could not be reassigned.
+		Failed []ReassignFailure
+	}
+
+	// ReassignFailure reports why a workspace could not be reassigned.
+	ReassignFailure struct {
+		WorkspaceID string
+		Err         error
+	}
+
+	reassignWorkspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+		Update(ctx context.Context, workspaceID string, opts workspace.UpdateOptions) (*workspace.Workspace, error)
+	}
+)
+
+// ReassignWorkspacesPool reassigns workspaces from one pool to another. Each
+// workspace must be assigned to the source pool and be allowed to access the
+// destination pool; those that are not are reported as failures and left as
+// they are, whereas the rest are reassigned in a single transaction.
+func (s *service) ReassignWorkspacesPool(ctx context.Context, fromPoolID, toPoolID string, workspaceIDs []string) (*ReassignResult, error) {
+	var (
+		subject internal.Subject
+		result  *ReassignResult
+	)
+	// lock the pools and their allowed workspaces to prevent either pool
+	// being deleted or having workspaces' access revoked partway through.
+	err := s.db.Lock(ctx, "agent_pools, agent_pool_allowed_workspaces", func(ctx context.Context, q pggen.Querier) (err error) {
+		from, err := s.db.getPool(ctx, fromPoolID)
+		if err != nil {
+			return err
+		}
+		to, err := s.db.getPool(ctx, toPoolID)
+		if err != nil {
+			return err
+		}
+		subject, err = s.organization.CanAccess(ctx, rbac.UpdateAgentPoolAction, from.Organization)
+		if err != nil {
+			return err
+		}
+		if _, err := s.organization.CanAccess(ctx, rbac.UpdateAgentPoolAction, to.Organization); err != nil {
+			return err
+		}
+		result, err = reassignWorkspaces(ctx, s.poolWorkspaces, from, to, workspaceIDs)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("reassigning workspaces to agent pool", "from", fromPoolID, "to", toPoolID, "workspaces", workspaceIDs, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("reassigned workspaces to agent pool", "from", fromPoolID, "to", toPoolID, "reassigned", result.Reassigned, "failed", len(result.Failed), "subject", subject)
+	return result, nil
+}
+
+// reassignWorkspaces reassigns workspaces from one pool to another. Every
+// workspace is checked before any is reassigned, and those failing the checks
+// are reported rather than failing the reassignment as a whole. The caller is
+// expected to wrap the call in a transaction, so that an error updating a
+// workspace leaves none reassigned.
+func reassignWorkspaces(ctx context.Context, workspaces reassignWorkspaceClient, from, to *Pool, workspaceIDs []string) (*ReassignResult, error) {
+	if from.ID == to.ID || from.Organization != to.Organization {
+		return nil, ErrInvalidReassignmentPool
+	}
+	result := &ReassignResult{}
+	var reassign []string
+	for _, id := range workspaceIDs {
+		if slices.Contains(reassign, id) {
+			// skip duplicate
+			continue
+		}
+		if err := checkReassignment(ctx, workspaces, from, to, id); err != nil {
+			result.Failed = append(result.Failed, ReassignFailure{WorkspaceID: id, Err: err})
+			continue
+		}
+		reassign = append(reassign, id)
+	}
+	for _, id := range reassign {
+		_, err := workspaces.Update(ctx, id, workspace.UpdateOptions{AgentPoolID: &to.ID})
+		if err != nil {
+			return nil, fmt.Errorf("reassigning workspace %s: %w", id, err)
+		}
+		result.Reassigned = append(result.Reassigned, id)
+	}
+	return result, nil
+}
+
+func checkReassignment(ctx context.Context, workspaces reassignWorkspaceClient, from, to *Pool, workspaceID string) error {
+	ws, err := workspaces.Get(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	if ws.AgentPoolID == nil || *ws.AgentPoolID != from.ID {
+		return ErrWorkspaceNotInPool
+	}
+	if !to.OrganizationScoped && !slices.Contains(to.AllowedWorkspaces, ws.ID) {
+		return ErrWorkspaceNotAllowedToUsePool
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+func TestReassignWorkspaces(t *testing.T) {
+	ctx := context.Background()
+	from := &Pool{ID: "pool-1", Organization: "acme", OrganizationScoped: true}
+	to := &Pool{ID: "pool-2", Organization: "acme", AllowedWorkspaces: []string{"ws-1", "ws-2", "ws-3"}}
+
+	newClient := func() *fakeReassignWorkspaceClient {
+		return &fakeReassignWorkspaceClient{workspaces: map[string]*workspace.Workspace{
+			"ws-1": {ID: "ws-1", AgentPoolID: internal.String("pool-1")},
+			"ws-2": {ID: "ws-2", AgentPoolID: internal.String("pool-1")},
+			// assigned to another pool
+			"ws-3": {ID: "ws-3", AgentPoolID: internal.String("pool-3")},
+			// not allowed to access destination pool
+			"ws-4": {ID: "ws-4", AgentPoolID: internal.String("pool-1")},
+		}}
+	}
+
+	t.Run("reassign all", func(t *testing.T) {
+		client := newClient()
+
+		got, err := reassignWorkspaces(ctx, client, from, to, []string{"ws-1", "ws-2"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"ws-1", "ws-2"}, got.Reassigned)
+		assert.Empty(t, got.Failed)
+		assert.Equal(t, "pool-2", *client.workspaces["ws-1"].AgentPoolID)
+		assert.Equal(t, "pool-2", *client.workspaces["ws-2"].AgentPoolID)
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		client := newClient()
+
+		got, err := reassignWorkspaces(ctx, client, from, to, []string{"ws-1", "ws-3", "ws-4", "ws-5", "ws-1"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"ws-1"}, got.Reassigned)
+		require.Len(t, got.Failed, 3)
+		assert.Equal(t, "ws-3", got.Failed[0].WorkspaceID)
+		assert.ErrorIs(t, got.Failed[0].Err, ErrWorkspaceNotInPool)
+		assert.Equal(t, "ws-4", got.Failed[1].WorkspaceID)
+		assert.ErrorIs(t, got.Failed[1].Err, ErrWorkspaceNotAllowedToUsePool)
+		assert.Equal(t, "ws-5", got.Failed[2].WorkspaceID)
+		assert.ErrorIs(t, got.Failed[2].Err, internal.ErrResourceNotFound)
+
+		// failed workspaces are left as they are
+		assert.Equal(t, "pool-2", *client.workspaces["ws-1"].AgentPoolID)
+		assert.Equal(t, "pool-3", *client.workspaces["ws-3"].AgentPoolID)
+		assert.Equal(t, "pool-1", *client.workspaces["ws-4"].AgentPoolID)
+	})
+
+	t.Run("error updating workspace fails reassignment", func(t *testing.T) {
+		client := newClient()
+		client.updateErr = errors.New("database unavailable")
+
+		_, err := reassignWorkspaces(ctx, client, from, to, []string{"ws-1", "ws-2"})
+		assert.ErrorIs(t, err, client.updateErr)
+	})
+
+	t.Run("organization-scoped destination pool", func(t *testing.T) {
+		client := newClient()
+		scoped := &Pool{ID: "pool-2", Organization: "acme", OrganizationScoped: true}
+
+		got, err := reassignWorkspaces(ctx, client, from, scoped, []string{"ws-4"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"ws-4"}, got.Reassigned)
+	})
+
+	t.Run("cannot reassign to same pool", func(t *testing.T) {
+		_, err := reassignWorkspaces(ctx, newClient(), from, from, []string{"ws-1"})
+		assert.ErrorIs(t, err, ErrInvalidReassignmentPool)
+	})
+
+	t.Run("cannot reassign to pool in another organization", func(t *testing.T) {
+		other := &Pool{ID: "pool-2", Organization: "other", OrganizationScoped: true}
+
+		_, err := reassignWorkspaces(ctx, newClient(), from, other, []string{"ws-1"})
+		assert.ErrorIs(t, err, ErrInvalidReassignmentPool)
+	})
+}
+
+type fakeReassignWorkspaceClient struct {
+	workspaces map[string]*workspace.Workspace
+	updateErr  error
+}
+
+func (f *fakeReassignWorkspaceClient) Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	ws, ok := f.workspaces[workspaceID]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return ws, nil
+}
+
+func (f *fakeReassignWorkspaceClient) Update(ctx context.Context, workspaceID string, opts workspace.UpdateOptions) (*workspace.Workspace, error) {
+	if f.updateErr != nil {
+		return nil, f.updateErr
+	}
+	ws := f.workspaces[workspaceID]
+	ws.AgentPoolID = opts.AgentPoolID
+	return ws, nil
+}
@@ -2,24 +2,32 @@ package agent
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
 	tofutfhttp "github.com/tofutf/tofutf/internal/http"
 	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/logs"
+	"github.com/tofutf/tofutf/internal/maintenance"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/pubsub"
 	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/releases"
 	tofutfrun "github.com/tofutf/tofutf/internal/run"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/tokens"
 	"github.com/tofutf/tofutf/internal/workspace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type (
@@ -27,19 +35,47 @@ type (
 		AddHandlers(r *mux.Router)
 		NewAllocator(logger *slog.Logger) *allocator
 		NewManager() *manager
+		NewAutoscaler(logger *slog.Logger) *autoscaler
+		NewPurger(logger *slog.Logger) *purger
+		NewStatusHistoryPurger(logger *slog.Logger) *statusHistoryPurger
+		NewOrphanReconciler(logger *slog.Logger) *orphanReconciler
 		CreateAgentPool(ctx context.Context, opts CreateAgentPoolOptions) (*Pool, error)
 		GetAgentPool(ctx context.Context, poolID string) (*Pool, error)
+		UndeletePool(ctx context.Context, poolID string) (*Pool, error)
+		ReassignWorkspacesPool(ctx context.Context, fromPoolID, toPoolID string, workspaceIDs []string) (*ReassignResult, error)
 		WatchAgentPools(ctx context.Context) (<-chan pubsub.Event[*Pool], func())
 		WatchAgents(ctx context.Context) (<-chan pubsub.Event[*Agent], func())
+		GetAgentStatusHistory(ctx context.Context, agentID string) ([]*StatusChange, error)
 		WatchJobs(ctx context.Context) (<-chan pubsub.Event[*Job], func())
+		WatchFinishedJobs(ctx context.Context, opts WatchFinishedJobsOptions) (<-chan pubsub.Event[*Job], func())
+		GetAllocatorStatus(ctx context.Context) (*AllocatorStatus, error)
+		GetSubscriptionStats(ctx context.Context) ([]pubsub.Stats, error)
+		ReconcileOrphanedJobs(ctx context.Context) ([]*OrphanedJob, error)
+		GetPendingJob(ctx context.Context, runID string) (*PendingJob, error)
+		ListQueueSLABreaches(ctx context.Context, organization string) ([]*Job, error)
+		GetJobCounts(ctx context.Context, organization string, opts JobCountsOptions) (*JobCounts, error)
 		CreateAgentToken(ctx context.Context, poolID string, opts CreateAgentTokenOptions) (*agentToken, []byte, error)
 		GetAgentToken(ctx context.Context, tokenID string) (*agentToken, error)
 		ListAgentTokens(ctx context.Context, poolID string) ([]*agentToken, error)
+		ListExpiringAgentTokens(ctx context.Context, organization string, within time.Duration) ([]*expiringAgentToken, error)
 		DeleteAgentToken(ctx context.Context, tokenID string) (*agentToken, error)
+		CreateBootstrapToken(ctx context.Context, poolID string, opts CreateBootstrapTokenOptions) (*bootstrapToken, []byte, error)
+		ListBootstrapTokens(ctx context.Context, poolID string) ([]*bootstrapToken, error)
+		DeleteBootstrapToken(ctx context.Context, tokenID string) (*bootstrapToken, error)
+		RevokeAgentToken(ctx context.Context, agentID string) (*Agent, error)
+		RequestAgentDiagnostics(ctx context.Context, agentID string) (*DiagnosticsBundle, error)
+		GetAgentDiagnosticsBundle(ctx context.Context, bundleID string) (*DiagnosticsBundle, error)
+		SetPoolAutoscaler(ctx context.Context, poolID string, opts SetPoolAutoscalerOptions) (*PoolAutoscaler, error)
+		GetPoolAutoscaler(ctx context.Context, poolID string) (*PoolAutoscaler, error)
+		DeletePoolAutoscaler(ctx context.Context, poolID string) error
+		ListScalingDecisions(ctx context.Context, poolID string) ([]*ScalingDecision, error)
 
 		registerAgent(ctx context.Context, opts registerAgentOptions) (*Agent, error)
 		getAgentJobs(ctx context.Context, agentID string) ([]*Job, error)
-		updateAgentStatus(ctx context.Context, agentID string, status AgentStatus) error
+		ClaimNextJob(ctx context.Context, agentID string) (*Job, error)
+		updateAgentStatus(ctx context.Context, agentID string, status AgentStatus, reason string) (*statusUpdateResponse, error)
+		deregisterAgent(ctx context.Context, agentID string) error
+		uploadDiagnostics(ctx context.Context, bundleID string, opts uploadDiagnosticsOptions) error
 
 		startJob(ctx context.Context, spec JobSpec) ([]byte, error)
 		finishJob(ctx context.Context, spec JobSpec, opts finishJobOptions) error
@@ -49,14 +85,47 @@ type (
 		logger *slog.Logger
 
 		organization internal.Authorizer
+		site         internal.Authorizer
+		runs         internal.Authorizer
 
 		tfeapi      *tfe
 		api         *api
 		web         *webHandlers
-		poolBroker  pubsub.SubscriptionService[*Pool]
-		agentBroker pubsub.SubscriptionService[*Agent]
-		jobBroker   pubsub.SubscriptionService[*Job]
+		poolBroker  *pubsub.Broker[*Pool]
+		agentBroker *pubsub.Broker[*Agent]
+		jobBroker   *pubsub.Broker[*Job]
 		phases      phaseClient
+		maintenance maintenanceClient
+		releases    releasesClient
+		logs        internal.PutChunkService
+		tracer      *jobTracer
+		workspaces  workspaceClient
+		queuedRuns  queuedRunClient
+		// poolWorkspaces reassigns workspaces to pools
+		poolWorkspaces reassignWorkspaceClient
+
+		// rejectPausedRuns, if true, rejects runs enqueued on a paused
+		// workspace rather than queuing them until the workspace is resumed.
+		rejectPausedRuns bool
+		// dispatcher fans out job events to agents waiting for jobs
+		dispatcher *jobDispatcher
+		// poolRecoveryWindow is the duration for which a deleted pool can be
+		// recovered. Zero means pools are deleted immediately.
+		poolRecoveryWindow time.Duration
+		// jobDiskEstimate is the estimated disk space in bytes required by
+		// a job.
+		jobDiskEstimate int64
+		// statusHistoryRetention is the duration for which agent status
+		// changes are retained.
+		statusHistoryRetention time.Duration
+		// defaultMaxAgentPools is the maximum number of pools in an
+		// organization that has not set its own maximum. Zero means no
+		// limit.
+		defaultMaxAgentPools int
+		// priorityAging is the duration after which a normal priority job
+		// awaiting allocation is treated as high priority. Zero disables
+		// aging.
+		priorityAging time.Duration
 
 		db *db
 		*registrar
@@ -70,9 +139,50 @@ type (
 		html.Renderer
 		*tfeapi.Responder
 
-		RunService       *tofutfrun.Service
-		WorkspaceService *workspace.Service
-		TokensService    *tokens.Service
+		RunService         *tofutfrun.Service
+		WorkspaceService   *workspace.Service
+		TokensService      *tokens.Service
+		MaintenanceService *maintenance.Service
+		ReleasesService    *releases.Service
+		LogsService        *logs.Service
+
+		// TracerProvider, if non-nil, provides the tracer for emitting spans
+		// for each step in the lifecycle of a job.
+		TracerProvider trace.TracerProvider
+
+		// RejectPausedWorkspaceRuns, if true, rejects runs that are enqueued
+		// whilst their workspace is paused. Otherwise they are queued until
+		// the workspace is resumed.
+		RejectPausedWorkspaceRuns bool
+
+		// JobDispatchers is the number of dispatchers fanning out job events
+		// to agents waiting for jobs. Defaults to DefaultJobDispatchers.
+		JobDispatchers int
+
+		// PoolRecoveryWindow is the duration for which a deleted pool can be
+		// recovered before it is purged. Zero means pools are deleted
+		// immediately and cannot be recovered.
+		PoolRecoveryWindow time.Duration
+
+		// JobDiskEstimate is the estimated disk space in bytes required by a
+		// job. Agents reporting less free disk space are not allocated jobs.
+		// Zero disables the check.
+		JobDiskEstimate int64
+
+		// StatusHistoryRetention is the duration for which agent status
+		// changes are retained before they are purged. The most recent change
+		// of each agent is always retained.
+		StatusHistoryRetention time.Duration
+
+		// DefaultMaxAgentPools is the maximum number of agent pools that
+		// can be created in an organization, unless a site admin has set a
+		// maximum for the organization. Zero means no limit.
+		DefaultMaxAgentPools int
+
+		// PriorityAging is the duration after which a job of a normal
+		// priority run awaiting allocation is promoted to high priority, so
+		// that high priority runs cannot starve it. Zero disables aging.
+		PriorityAging time.Duration
 	}
 
 	phaseClient interface {
@@ -86,12 +196,29 @@ type (
 func NewService(opts ServiceOptions) Service {
 	svc := &service{
 		logger:       opts.Logger,
-		db:           &db{Pool: opts.Pool},
+		db:           &db{Pool: opts.Pool, logger: opts.Logger},
 		organization: &organization.Authorizer{Logger: opts.Logger},
+		site:         &internal.SiteAuthorizer{Logger: opts.Logger},
+		runs:         opts.RunService,
 		tokenFactory: &tokenFactory{
 			tokens: opts.TokensService,
 		},
-		phases: opts.RunService,
+		phases:      opts.RunService,
+		maintenance: opts.MaintenanceService,
+		releases:    opts.ReleasesService,
+		logs:        opts.LogsService,
+		tracer:      newJobTracer(opts.TracerProvider),
+		workspaces:  opts.WorkspaceService,
+		queuedRuns:  opts.RunService,
+
+		poolWorkspaces: opts.WorkspaceService,
+
+		rejectPausedRuns:       opts.RejectPausedWorkspaceRuns,
+		poolRecoveryWindow:     opts.PoolRecoveryWindow,
+		jobDiskEstimate:        opts.JobDiskEstimate,
+		statusHistoryRetention: opts.StatusHistoryRetention,
+		defaultMaxAgentPools:   opts.DefaultMaxAgentPools,
+		priorityAging:          opts.PriorityAging,
 	}
 	svc.tfeapi = &tfe{
 		service:   svc,
@@ -102,10 +229,11 @@ func NewService(opts ServiceOptions) Service {
 		Responder: opts.Responder,
 	}
 	svc.web = &webHandlers{
-		Renderer:   opts.Renderer,
-		logger:     opts.Logger,
-		svc:        svc,
-		workspaces: opts.WorkspaceService,
+		Renderer:           opts.Renderer,
+		logger:             opts.Logger,
+		svc:                svc,
+		workspaces:         opts.WorkspaceService,
+		poolRecoveryWindow: opts.PoolRecoveryWindow,
 	}
 	svc.registrar = &registrar{
 		service: svc,
@@ -118,7 +246,9 @@ func NewService(opts ServiceOptions) Service {
 			if action == sql.DeleteAction {
 				return &Pool{ID: id}, nil
 			}
-			return svc.db.getPool(ctx, id)
+			// include deleted pools so that subscribers learn of a pool
+			// being deleted or recovered.
+			return svc.db.findPool(ctx, id)
 		},
 	)
 	svc.agentBroker = pubsub.NewBroker(
@@ -147,6 +277,7 @@ func NewService(opts ServiceOptions) Service {
 			return svc.db.getJob(ctx, spec)
 		},
 	)
+	svc.dispatcher = newJobDispatcher(svc.jobBroker, opts.JobDispatchers)
 	// create jobs when a plan or apply is enqueued
 	opts.RunService.AfterEnqueuePlan(svc.createJob)
 	opts.RunService.AfterEnqueueApply(svc.createJob)
@@ -157,7 +288,9 @@ func NewService(opts ServiceOptions) Service {
 	// check whether a workspace is being created or updated and configured to
 	// use an agent pool, and if so, check that it is allowed to use the pool.
 	opts.WorkspaceService.BeforeCreateWorkspace(svc.checkWorkspacePoolAccess)
-	opts.WorkspaceService.BeforeUpdateWorkspace(svc.checkWorkspacePoolAccess)
+	opts.WorkspaceService.BeforeUpdateWorkspace(svc.checkWorkspacePoolUpdate)
+	// create jobs for runs that were queued whilst their workspace was paused
+	opts.WorkspaceService.AfterResumeWorkspace(svc.createPausedJobs)
 	// Register with auth middleware the agent token kind and a means of
 	// retrieving the appropriate agent corresponding to the agent token ID
 	opts.TokensService.RegisterKind(AgentTokenKind, func(ctx context.Context, tokenID string) (internal.Subject, error) {
@@ -165,6 +298,11 @@ func NewService(opts ServiceOptions) Service {
 		if err != nil {
 			return nil, err
 		}
+		// failing to record when the token was last used should not prevent
+		// the agent from authenticating
+		if err := svc.db.updateAgentTokenLastUsedAt(ctx, tokenID, internal.CurrentTimestamp(nil)); err != nil {
+			svc.logger.Error("recording agent token last used", "id", tokenID, "err", err)
+		}
 		unregistered := &unregisteredPoolAgent{
 			pool:         pool,
 			agentTokenID: tokenID,
@@ -187,6 +325,58 @@ func NewService(opts ServiceOptions) Service {
 		}
 		return unregistered, nil
 	})
+	// Register with auth middleware the bootstrap token kind, which an agent
+	// can only use to register, upon which it is issued its own token.
+	opts.TokensService.RegisterKind(AgentBootstrapTokenKind, func(ctx context.Context, tokenID string) (internal.Subject, error) {
+		bt, err := svc.db.getBootstrapToken(ctx, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := svc.db.getPool(ctx, bt.AgentPoolID)
+		if err != nil {
+			return nil, err
+		}
+		return &unregisteredPoolAgent{
+			pool:             pool,
+			bootstrapTokenID: bt.ID,
+		}, nil
+	})
+	// Register with auth middleware the per-agent token kind, which is only
+	// valid for as long as its hash is retained for the agent.
+	opts.TokensService.RegisterKind(PerAgentTokenKind, func(ctx context.Context, agentID string) (internal.Subject, error) {
+		cred, err := svc.db.getAgentCredential(ctx, agentID)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			return nil, ErrAgentTokenRevoked
+		} else if err != nil {
+			return nil, err
+		}
+		headers, err := tofutfhttp.HeadersFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		token := strings.TrimPrefix(headers.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(hashAgentToken([]byte(token))), []byte(cred.TokenHash)) != 1 {
+			return nil, ErrAgentTokenRevoked
+		}
+		agent, err := svc.getAgent(ctx, agentID)
+		if err != nil {
+			return nil, err
+		}
+		if agent.AgentPoolID == nil {
+			return nil, fmt.Errorf("agent %s does not belong to a pool", agentID)
+		}
+		pool, err := svc.db.getPool(ctx, *agent.AgentPoolID)
+		if err != nil {
+			return nil, err
+		}
+		return &poolAgent{
+			agent: agent,
+			unregisteredPoolAgent: &unregisteredPoolAgent{
+				pool:             pool,
+				bootstrapTokenID: cred.BootstrapTokenID,
+			},
+		}, nil
+	})
 	// Register with auth middleware the job token and a means of
 	// retrieving Job corresponding to token.
 	opts.TokensService.RegisterKind(JobTokenKind, func(ctx context.Context, jobspecString string) (internal.Subject, error) {
@@ -208,34 +398,65 @@ func (s *service) AddHandlers(r *mux.Router) {
 
 func (s *service) NewAllocator(logger *slog.Logger) *allocator {
 	return &allocator{
-		logger: logger,
-		client: s,
+		logger:          logger,
+		client:          s,
+		maintenance:     s.maintenance,
+		releases:        s.releases,
+		slaInterval:     defaultQueueSLAInterval,
+		jobDiskEstimate: s.jobDiskEstimate,
+		priorityAging:   s.priorityAging,
 	}
 }
 
 func (s *service) NewManager() *manager { return newManager(s) }
 
+func (s *service) NewAutoscaler(logger *slog.Logger) *autoscaler {
+	return newAutoscaler(logger, s.db)
+}
+
+func (s *service) NewPurger(logger *slog.Logger) *purger {
+	return newPurger(logger, s.db, s.poolRecoveryWindow)
+}
+
+func (s *service) NewStatusHistoryPurger(logger *slog.Logger) *statusHistoryPurger {
+	return newStatusHistoryPurger(logger, s.db, s.statusHistoryRetention)
+}
+
+func (s *service) NewOrphanReconciler(logger *slog.Logger) *orphanReconciler {
+	return newOrphanReconciler(logger, s.db)
+}
+
 func (s *service) CreateAgentPool(ctx context.Context, opts CreateAgentPoolOptions) (*Pool, error) {
 	subject, err := s.organization.CanAccess(ctx, rbac.CreateAgentPoolAction, opts.Organization)
 	if err != nil {
 		return nil, err
 	}
-	pool, err := newPool(opts)
+	pool, err := NewPool(opts)
 	if err != nil {
 		s.logger.Error("creating agent pool", "subject", subject, "err", err)
 		return nil, err
 	}
-	if err := s.db.createPool(ctx, pool); err != nil {
+	// lock the pools table to prevent concurrent creations exceeding the
+	// organization's quota
+	err = s.db.Lock(ctx, "agent_pools", func(ctx context.Context, q pggen.Querier) error {
+		if err := s.checkPoolQuota(ctx, opts.Organization); err != nil {
+			return err
+		}
+		return s.db.createPool(ctx, pool)
+	})
+	if err != nil {
+		s.logger.Error("creating agent pool", "subject", subject, "organization", opts.Organization, "err", err)
 		return nil, err
 	}
 	s.logger.Info("created agent pool", "subject", subject, "pool", pool)
 	return pool, nil
 }
 
-func (s *service) updateAgentPool(ctx context.Context, poolID string, opts updatePoolOptions) (*Pool, error) {
+func (s *service) updateAgentPool(ctx context.Context, poolID string, opts updatePoolOptions) (*Pool, []ImpactedWorkspace, error) {
 	var (
 		subject       internal.Subject
 		before, after Pool
+		impacted      []ImpactedWorkspace
 	)
 	err := s.db.Lock(ctx, "agent_pools, agent_pool_allowed_workspaces", func(ctx context.Context, q pggen.Querier) (err error) {
 		pool, err := s.db.getPool(ctx, poolID)
@@ -251,30 +472,31 @@ func (s *service) updateAgentPool(ctx context.Context, poolID string, opts updat
 		if err := after.update(opts); err != nil {
 			return err
 		}
-		if err := s.db.updatePool(ctx, &after); err != nil {
+		impacted, err = s.db.listWorkspacesLosingPoolAccess(ctx, &after)
+		if err != nil {
 			return err
 		}
-		// Add/remove allowed workspaces
-		add := internal.DiffStrings(after.AllowedWorkspaces, before.AllowedWorkspaces)
-		remove := internal.DiffStrings(before.AllowedWorkspaces, after.AllowedWorkspaces)
-		for _, workspaceID := range add {
-			if err := s.db.addAgentPoolAllowedWorkspace(ctx, poolID, workspaceID); err != nil {
-				return err
-			}
+		if opts.DryRun {
+			return nil
 		}
-		for _, workspaceID := range remove {
-			if err := s.db.deleteAgentPoolAllowedWorkspace(ctx, poolID, workspaceID); err != nil {
-				return err
-			}
+		if unacked := unacknowledged(impacted, opts.AcknowledgedWorkspaces); len(unacked) > 0 {
+			return &ImpactError{Workspaces: unacked}
 		}
-		return nil
+		if err := s.db.updatePool(ctx, &after); err != nil {
+			return err
+		}
+		return s.db.updateAgentPoolAllowedWorkspaces(ctx, poolID, before.AllowedWorkspaces, after.AllowedWorkspaces)
 	})
 	if err != nil {
 		s.logger.Error("updating agent pool", "agent_pool_id", poolID, "subject", subject, "err", err)
-		return nil, err
+		return nil, impacted, err
 	}
-	s.logger.Info("updated agent pool", "subject", subject, "before", &before, "after", &after)
-	return &after, nil
+	if opts.DryRun {
+		s.logger.Debug("previewed agent pool update", "subject", subject, "before", &before, "after", &after, "impacted", impacted)
+		return &after, impacted, nil
+	}
+	s.logger.Info("updated agent pool", "subject", subject, "before", &before, "after", &after, "impacted", impacted)
+	return &after, impacted, nil
 }
 
 func (s *service) GetAgentPool(ctx context.Context, poolID string) (*Pool, error) {
@@ -293,6 +515,55 @@ func (s *service) GetAgentPool(ctx context.Context, poolID string) (*Pool, error
 	return pool, nil
 }
 
+// getAgentPools retrieves several agent pools in one go, returning those found
+// keyed by ID along with the IDs of those not found.
+func (s *service) getAgentPools(ctx context.Context, poolIDs []string) (map[string]*Pool, []string, error) {
+	found, missing, err := getPools(ctx, s.db, s.organization, poolIDs)
+	if err != nil {
+		s.logger.Error("retrieving agent pools", "agent_pool_ids", poolIDs, "err", err)
+		return nil, nil, err
+	}
+	s.logger.Debug("retrieved agent pools", "found", len(found), "missing", missing)
+	return found, missing, nil
+}
+
+// poolsClient retrieves several pools at once.
+type poolsClient interface {
+	getPools(ctx context.Context, poolIDs []string) ([]*Pool, error)
+}
+
+// getPools retrieves several pools in a single query, returning those found
+// keyed by ID along with the IDs of those not found. The caller must be
+// permitted to access the organization of every pool found.
+func getPools(ctx context.Context, db poolsClient, authorizer internal.Authorizer, poolIDs []string) (map[string]*Pool, []string, error) {
+	if len(poolIDs) == 0 {
+		return map[string]*Pool{}, nil, nil
+	}
+	pools, err := db.getPools(ctx, poolIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	found := make(map[string]*Pool, len(pools))
+	authorized := make(map[string]bool)
+	for _, pool := range pools {
+		// authorize each organization only once
+		if !authorized[pool.Organization] {
+			if _, err := authorizer.CanAccess(ctx, rbac.GetAgentPoolAction, pool.Organization); err != nil {
+				return nil, nil, err
+			}
+			authorized[pool.Organization] = true
+		}
+		found[pool.ID] = pool
+	}
+	var missing []string
+	for _, id := range poolIDs {
+		if _, ok := found[id]; !ok && !slices.Contains(missing, id) {
+			missing = append(missing, id)
+		}
+	}
+	return found, missing, nil
+}
+
 func (s *service) listAllAgentPools(ctx context.Context) ([]*Pool, error) {
 	subject, err := internal.SubjectFromContext(ctx)
 	if err != nil {
@@ -321,55 +592,237 @@ func (s *service) listAgentPoolsByOrganization(ctx context.Context, organization
 	return pools, nil
 }
 
-func (s *service) deleteAgentPool(ctx context.Context, poolID string) (*Pool, error) {
-	pool, subject, err := func() (*Pool, internal.Subject, error) {
+// deleteAgentPool deletes an agent pool. If the pool has queued jobs then
+// opts determines whether they are canceled or migrated to another pool
+// beforehand. The number of queued jobs affected is returned. If there is a
+// recovery window then the pool is only marked as deleted, and it is purged
+// once the window elapses unless it is recovered beforehand.
+func (s *service) deleteAgentPool(ctx context.Context, poolID string, opts deletePoolOptions) (*Pool, int, error) {
+	var (
+		subject  internal.Subject
+		pool     *Pool
+		affected int
+	)
+	err := s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) (err error) {
 		// retrieve pool in order to get organization for authorization
-		pool, err := s.db.getPool(ctx, poolID)
+		pool, err = s.db.getPool(ctx, poolID)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
-		subject, err := s.organization.CanAccess(ctx, rbac.DeleteAgentPoolAction, pool.Organization)
+		subject, err = s.organization.CanAccess(ctx, rbac.DeleteAgentPoolAction, pool.Organization)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 		// only permit pool to be deleted if it is not referenced by any
 		// workspaces (it would raise a foreign key error anyway but friendlier
 		// to return an informative error message).
 		if len(pool.AssignedWorkspaces) > 0 {
-			return nil, nil, ErrCannotDeletePoolReferencedByWorkspaces
+			return ErrCannotDeletePoolReferencedByWorkspaces
 		}
-		if err := s.db.deleteAgentPool(ctx, pool.ID); err != nil {
-			return nil, subject, err
+		affected, err = handleQueuedJobs(ctx, s.db, s.phases, pool, opts)
+		if err != nil {
+			return err
 		}
-		return pool, subject, nil
-	}()
+		if s.poolRecoveryWindow == 0 {
+			return s.db.deleteAgentPool(ctx, pool.ID)
+		}
+		pool.softDelete()
+		return s.db.updatePoolDeletedAt(ctx, pool.ID, pool.DeletedAt)
+	})
 	if err != nil {
 		s.logger.Error("deleting agent pool", "agent_pool_id", poolID, "subject", subject, "err", err)
+		return nil, 0, err
+	}
+	s.logger.Debug("deleted agent pool", "pool", pool, "subject", subject, "queued_jobs", opts.QueuedJobs, "affected_jobs", affected, "recoverable_until", pool.RecoverableUntil(s.poolRecoveryWindow))
+	return pool, affected, nil
+}
+
+// checkPoolQuota checks whether the organization can have another pool.
+func (s *service) checkPoolQuota(ctx context.Context, organization string) error {
+	pools, max, err := s.db.getPoolQuota(ctx, organization)
+	if err != nil {
+		return err
+	}
+	return checkPoolQuota(pools, max, s.defaultMaxAgentPools)
+}
+
+// UndeletePool recovers a deleted pool before its recovery window elapses.
+// The pool is restored along with the workspaces allowed to access it.
+func (s *service) UndeletePool(ctx context.Context, poolID string) (*Pool, error) {
+	var (
+		subject internal.Subject
+		pool    *Pool
+	)
+	err := s.db.Lock(ctx, "agent_pools", func(ctx context.Context, q pggen.Querier) (err error) {
+		pool, err = s.db.findPool(ctx, poolID)
+		if err != nil {
+			return err
+		}
+		subject, err = s.organization.CanAccess(ctx, rbac.DeleteAgentPoolAction, pool.Organization)
+		if err != nil {
+			return err
+		}
+		if err := pool.undelete(s.poolRecoveryWindow); err != nil {
+			return err
+		}
+		// a recovered pool counts towards the organization's quota
+		if err := s.checkPoolQuota(ctx, pool.Organization); err != nil {
+			return err
+		}
+		err = s.db.updatePoolDeletedAt(ctx, pool.ID, nil)
+		if errors.Is(err, internal.ErrResourceAlreadyExists) {
+			// a pool with the same name has since been created
+			return ErrCannotRecoverPoolNameTaken
+		}
+		return err
+	})
+	if err != nil {
+		s.logger.Error("recovering agent pool", "agent_pool_id", poolID, "subject", subject, "err", err)
 		return nil, err
 	}
-	s.logger.Debug("deleted agent pool", "pool", pool, "subject", subject)
+	s.logger.Info("recovered agent pool", "pool", pool, "subject", subject)
 	return pool, nil
 }
 
-// checkWorkspacePoolAccess checks if a workspace has been granted access to a pool. If the
-// pool is organization-scoped then the workspace automatically has access;
-// otherwise access must already have been granted explicity.
-func (s *service) checkWorkspacePoolAccess(ctx context.Context, ws *workspace.Workspace) error {
-	if ws.AgentPoolID == nil {
-		// workspace is not using any pool
-		return nil
+// listDeletedAgentPools lists the deleted pools in an organization that have
+// yet to be purged.
+func (s *service) listDeletedAgentPools(ctx context.Context, organization string) ([]*Pool, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListAgentPoolsAction, organization)
+	if err != nil {
+		return nil, err
 	}
-	pool, err := s.GetAgentPool(ctx, *ws.AgentPoolID)
+	pools, err := s.db.listDeletedPoolsByOrganization(ctx, organization)
 	if err != nil {
-		return err
+		s.logger.Error("listing deleted agent pools", "subject", subject, "err", err)
+		return nil, err
 	}
-	if pool.OrganizationScoped {
-		return nil
-	} else if slices.Contains(pool.AllowedWorkspaces, ws.ID) {
-		// is explicitly granted
+	s.logger.Debug("listed deleted agent pools", "subject", subject, "count", len(pools))
+	return pools, nil
+}
+
+// queuedJobsClient provides access to the jobs queued for a pool.
+type queuedJobsClient interface {
+	getPool(ctx context.Context, poolID string) (*Pool, error)
+	listQueuedJobsByPool(ctx context.Context, poolID string) ([]*Job, error)
+	updateJob(ctx context.Context, spec JobSpec, fn func(*Job) error) (*Job, error)
+}
+
+// handleQueuedJobs either cancels or migrates the jobs queued for a pool that
+// is about to be deleted, depending on opts. An error is returned if there are
+// queued jobs and neither action has been chosen. The number of jobs affected
+// is returned.
+func handleQueuedJobs(ctx context.Context, db queuedJobsClient, phases phaseClient, pool *Pool, opts deletePoolOptions) (int, error) {
+	jobs, err := db.listQueuedJobsByPool(ctx, pool.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+	switch opts.QueuedJobs {
+	case CancelQueuedJobs:
+		for _, job := range jobs {
+			// canceling the run in turn cancels the job
+			if err := phases.Cancel(ctx, job.Spec.RunID); err != nil {
+				return 0, fmt.Errorf("canceling queued job %s: %w", job, err)
+			}
+		}
+	case MigrateQueuedJobs:
+		if opts.MigrateToPoolID == nil || *opts.MigrateToPoolID == pool.ID {
+			return 0, ErrInvalidQueuedJobsMigrationPool
+		}
+		to, err := db.getPool(ctx, *opts.MigrateToPoolID)
+		if err != nil {
+			return 0, err
+		}
+		if to.Organization != pool.Organization {
+			return 0, ErrInvalidQueuedJobsMigrationPool
+		}
+		// check every workspace is permitted to use the new pool before
+		// migrating any jobs.
+		for _, job := range jobs {
+			if !to.OrganizationScoped && !slices.Contains(to.AllowedWorkspaces, job.WorkspaceID) {
+				return 0, ErrWorkspaceNotAllowedToUsePool
+			}
+		}
+		for _, job := range jobs {
+			_, err := db.updateJob(ctx, job.Spec, func(job *Job) error {
+				return job.migrate(to.ID)
+			})
+			if err != nil {
+				return 0, fmt.Errorf("migrating queued job %s: %w", job, err)
+			}
+		}
+	default:
+		return 0, fmt.Errorf("%w: %d queued job(s) found", ErrCannotDeletePoolWithQueuedJobs, len(jobs))
+	}
+	return len(jobs), nil
+}
+
+// checkWorkspacePoolAccess checks if a workspace has been granted access to
+// each of its pools, i.e. its agent pool and any pools for its plan and apply
+// phases. If a pool is organization-scoped then the workspace automatically
+// has access; otherwise access must already have been granted explicity.
+func (s *service) checkWorkspacePoolAccess(ctx context.Context, ws *workspace.Workspace) error {
+	for _, poolID := range ws.AgentPoolIDs() {
+		pool, err := s.GetAgentPool(ctx, poolID)
+		if err != nil {
+			return err
+		}
+		if pool.OrganizationScoped {
+			continue
+		} else if slices.Contains(pool.AllowedWorkspaces, ws.ID) {
+			// is explicitly granted
+			continue
+		}
+		return fmt.Errorf("%w: %s", ErrWorkspaceNotAllowedToUsePool, pool.Name)
+	}
+	return nil
+}
+
+// checkWorkspacePoolUpdate checks that an updated workspace is allowed to use
+// its pools, warning about each of its pools, including those assigned to
+// the plan and apply phases, that has no agents that could carry out its
+// runs.
+func (s *service) checkWorkspacePoolUpdate(ctx context.Context, ws *workspace.Workspace) ([]workspace.Warning, error) {
+	if err := s.checkWorkspacePoolAccess(ctx, ws); err != nil {
+		return nil, err
+	}
+	var warnings []workspace.Warning
+	for _, poolID := range ws.AgentPoolIDs() {
+		agents, err := s.db.listAgentsByPool(ctx, poolID)
+		if err != nil {
+			return nil, err
+		}
+		// an autoscaled pool starts agents on demand
+		_, err = s.db.getPoolAutoscaler(ctx, poolID)
+		if err != nil && !errors.Is(err, internal.ErrResourceNotFound) {
+			return nil, err
+		}
+		autoscaled := err == nil
+		if warning := poolWithoutAgentsWarning(poolID, agents, autoscaled); warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+	return warnings, nil
+}
+
+// poolWithoutAgentsWarning returns a warning if none of a pool's agents are
+// able to accept jobs and the pool is not autoscaled, in which case runs
+// remain queued until an agent registers.
+func poolWithoutAgentsWarning(poolID string, agents []*Agent, autoscaled bool) *workspace.Warning {
+	if autoscaled {
 		return nil
 	}
-	return ErrWorkspaceNotAllowedToUsePool
+	for _, agent := range agents {
+		if agent.Status == AgentIdle || agent.Status == AgentBusy {
+			return nil
+		}
+	}
+	return &workspace.Warning{
+		Code:    "agent-pool-without-agents",
+		Message: fmt.Sprintf("agent pool %s has no running agents; runs remain queued until an agent registers with the pool", poolID),
+	}
 }
 
 func (s *service) WatchAgentPools(ctx context.Context) (<-chan pubsub.Event[*Pool], func()) {
@@ -384,6 +837,44 @@ func (s *service) WatchJobs(ctx context.Context) (<-chan pubsub.Event[*Job], fun
 	return s.jobBroker.Subscribe(ctx)
 }
 
+// WatchFinishedJobs provides a stream of events for jobs that have reached a
+// terminal state, i.e. finished, errored or canceled, optionally filtered by
+// organization and agent pool.
+func (s *service) WatchFinishedJobs(ctx context.Context, opts WatchFinishedJobsOptions) (<-chan pubsub.Event[*Job], func()) {
+	sub, unsub := s.jobBroker.Subscribe(ctx)
+	return filterFinishedJobs(ctx, sub, opts), unsub
+}
+
+// filterFinishedJobs relays those events for jobs that have reached a
+// terminal state and which match the filters. The returned channel is closed
+// once the subscription is closed or the context is canceled, the latter
+// ensuring the relay doesn't block forever on a consumer that stops reading.
+func filterFinishedJobs(ctx context.Context, sub <-chan pubsub.Event[*Job], opts WatchFinishedJobsOptions) <-chan pubsub.Event[*Job] {
+	relay := make(chan pubsub.Event[*Job])
+	go func() {
+		defer close(relay)
+		for event := range sub {
+			if event.Type == pubsub.DeletedEvent || !event.Payload.Status.Finished() {
+				continue
+			}
+			if opts.Organization != nil && event.Payload.Organization != *opts.Organization {
+				continue
+			}
+			if opts.AgentPoolID != nil {
+				if event.Payload.AgentPoolID == nil || *event.Payload.AgentPoolID != *opts.AgentPoolID {
+					continue
+				}
+			}
+			select {
+			case relay <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return relay
+}
+
 func (s *service) registerAgent(ctx context.Context, opts registerAgentOptions) (*Agent, error) {
 	agent, err := func() (*Agent, error) {
 		// subject must be an unregistered agent
@@ -391,11 +882,13 @@ func (s *service) registerAgent(ctx context.Context, opts registerAgentOptions)
 		if err != nil {
 			return nil, err
 		}
+		var bootstrapTokenID string
 		switch agent := subject.(type) {
 		case *unregisteredServerAgent:
 		case *unregisteredPoolAgent:
 			// extract pool ID and use for registration.
 			opts.AgentPoolID = &agent.pool.ID
+			bootstrapTokenID = agent.bootstrapTokenID
 		default:
 			return nil, ErrUnauthorizedAgentRegistration
 		}
@@ -405,9 +898,25 @@ func (s *service) registerAgent(ctx context.Context, opts registerAgentOptions)
 			return nil, err
 		}
 		err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+			if bootstrapTokenID != "" {
+				if err := s.db.useBootstrapToken(ctx, bootstrapTokenID, internal.CurrentTimestamp(nil)); err != nil {
+					return err
+				}
+			}
 			if err := s.db.createAgent(ctx, agent); err != nil {
 				return err
 			}
+			if bootstrapTokenID != "" {
+				// exchange the bootstrap token for the agent's own token
+				cred, token, err := s.newAgentCredential(agent.ID, bootstrapTokenID)
+				if err != nil {
+					return err
+				}
+				if err := s.db.createAgentCredential(ctx, cred); err != nil {
+					return err
+				}
+				agent.Token = string(token)
+			}
 			return nil
 		})
 		if err != nil {
@@ -427,13 +936,17 @@ func (s *service) getAgent(ctx context.Context, agentID string) (*Agent, error)
 	return s.db.getAgent(ctx, agentID)
 }
 
-func (s *service) updateAgentStatus(ctx context.Context, agentID string, to AgentStatus) error {
+// updateAgentStatus updates the status of an agent. When called by a pool
+// agent, any request for the agent to collect diagnostics is delivered in the
+// response. The reason for the status is recorded alongside it; an agent that
+// provides no reason is recorded as having reported its status.
+func (s *service) updateAgentStatus(ctx context.Context, agentID string, to AgentStatus, reason string) (*statusUpdateResponse, error) {
 	// only these subjects may call this endpoint:
 	// (a) the manager, or
 	// (b) an agent with an ID matching agentID
 	subject, err := internal.SubjectFromContext(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	var isAgent bool
 	switch s := subject.(type) {
@@ -441,11 +954,15 @@ func (s *service) updateAgentStatus(ctx context.Context, agentID string, to Agen
 		// ok
 	case *serverAgent, *poolAgent:
 		if s.String() != agentID {
-			return internal.ErrAccessNotPermitted
+			return nil, internal.ErrAccessNotPermitted
 		}
 		isAgent = true
 	default:
-		return internal.ErrAccessNotPermitted
+		return nil, internal.ErrAccessNotPermitted
+	}
+
+	if isAgent && reason == "" {
+		reason = reasonReported
 	}
 
 	// keep a record of what the status was before the update for logging
@@ -453,21 +970,123 @@ func (s *service) updateAgentStatus(ctx context.Context, agentID string, to Agen
 	var from AgentStatus
 	err = s.db.updateAgent(ctx, agentID, func(agent *Agent) error {
 		from = agent.Status
-		return agent.setStatus(to, isAgent)
+		return agent.setStatus(to, isAgent, reason)
 	})
 	if err != nil {
-		s.logger.Error("updating agent status", "agent_id", agentID, "status", to, "subject", subject, "err", err)
-		return err
+		s.logger.Error("updating agent status", "agent_id", agentID, "status", to, "reason", reason, "subject", subject, "err", err)
+		return nil, err
 	}
 	if isAgent && from == to {
 		// if no change in status then log it as a ping
 		s.logger.Debug("received agent ping", "agent_id", agentID)
 	} else {
-		s.logger.Debug("updated agent status", "agent_id", agentID, "from", from, "to", to, "subject", subject)
+		s.logger.Debug("updated agent status", "agent_id", agentID, "from", from, "to", to, "reason", reason, "subject", subject)
+	}
+	var response statusUpdateResponse
+	if _, ok := subject.(*poolAgent); ok {
+		response.DiagnosticsBundleID, err = s.db.claimDiagnosticsBundle(ctx, agentID, internal.CurrentTimestamp(nil))
+		if err != nil {
+			// failing to deliver the request is not fatal to the update; the
+			// request is delivered in response to a later update instead.
+			s.logger.Error("delivering diagnostics request", "agent_id", agentID, "err", err)
+		} else if response.DiagnosticsBundleID != "" {
+			s.logger.Info("delivered diagnostics request", "agent_id", agentID, "bundle_id", response.DiagnosticsBundleID)
+		}
+	}
+	return &response, nil
+}
+
+// deregisterAgent is called by an agent when it shuts down cleanly, marking it
+// as exited immediately rather than leaving the manager to notice it has
+// stopped pinging. Jobs allocated to the agent that have yet to start are
+// requeued for allocation to another agent. Jobs still running can no longer
+// be finished by the agent, so they are errored along with their run phase.
+func (s *service) deregisterAgent(ctx context.Context, agentID string) error {
+	// only an agent with an ID matching agentID may call this endpoint
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	switch subj := subject.(type) {
+	case *serverAgent, *poolAgent:
+		if subj.String() != agentID {
+			return internal.ErrAccessNotPermitted
+		}
+	default:
+		return internal.ErrAccessNotPermitted
+	}
+
+	requeued, errored, err := s.exitAgent(ctx, agentID, reasonDeregistered)
+	if err != nil {
+		s.logger.Error("deregistering agent", "agent_id", agentID, "err", err)
+		return err
 	}
+	s.logger.Info("deregistered agent", "agent_id", agentID, "requeued_jobs", requeued, "errored_jobs", errored)
 	return nil
 }
 
+// exitAgent marks an agent as exited for the given reason, requeuing its jobs
+// that have yet to start and erroring those that are still running, returning
+// the number of jobs requeued and errored respectively.
+func (s *service) exitAgent(ctx context.Context, agentID, reason string) (requeued, errored int, err error) {
+	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		jobs, err := s.db.listActiveJobsByAgent(ctx, agentID)
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			_, err := s.db.updateJob(ctx, job.Spec, func(job *Job) error {
+				if job.Status == JobAllocated {
+					requeued++
+					return job.requeue()
+				}
+				errored++
+				_, err := s.phases.FinishPhase(ctx, job.Spec.RunID, job.Spec.Phase, tofutfrun.PhaseFinishOptions{
+					Errored:       true,
+					ErrorCategory: tofutfrun.AgentLostErrorCategory,
+				})
+				if err != nil {
+					return err
+				}
+				return job.finishJob(JobErrored)
+			})
+			if err != nil {
+				return fmt.Errorf("handling job %s: %w", job, err)
+			}
+		}
+		return s.db.updateAgent(ctx, agentID, func(agent *Agent) error {
+			return agent.setStatus(AgentExited, true, reason)
+		})
+	})
+	return requeued, errored, err
+}
+
+// GetAgentStatusHistory retrieves the agent's recent changes in status, oldest
+// first.
+func (s *service) GetAgentStatusHistory(ctx context.Context, agentID string) ([]*StatusChange, error) {
+	agent, err := s.db.getAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	// server agents are visible to all organizations whereas pool agents are
+	// only visible to the organization of their pool.
+	if !agent.IsServer() {
+		pool, err := s.db.getPool(ctx, *agent.AgentPoolID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.organization.CanAccess(ctx, rbac.ListAgentsAction, pool.Organization); err != nil {
+			return nil, err
+		}
+	}
+	history, err := s.db.listStatusHistory(ctx, agentID)
+	if err != nil {
+		s.logger.Error("retrieving agent status history", "agent_id", agentID, "err", err)
+		return nil, err
+	}
+	return history, nil
+}
+
 func (s *service) listAgents(ctx context.Context) ([]*Agent, error) {
 	return s.db.listAgents(ctx)
 }
@@ -497,12 +1116,28 @@ func (s *service) deleteAgent(ctx context.Context, agentID string) error {
 	return nil
 }
 
-func (s *service) createJob(ctx context.Context, run *tofutfrun.Run) error {
-	job := newJob(run)
-	if err := s.db.createJob(ctx, job); err != nil {
+func (s *service) createJob(ctx context.Context, run *tofutfrun.Run) (err error) {
+	sysctx := internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "job-creator"})
+	ws, err := s.workspaces.Get(sysctx, run.WorkspaceID)
+	if err != nil {
 		return err
 	}
-	return nil
+	if create, err := jobAllowed(ws, s.rejectPausedRuns); err != nil {
+		s.logger.Info("rejected run on paused workspace", "run_id", run.ID, "workspace", ws.ID)
+		return err
+	} else if !create {
+		s.logger.Info("queued run on paused workspace", "run_id", run.ID, "workspace", ws.ID)
+		return nil
+	}
+	return s.insertJob(ctx, ws, run)
+}
+
+func (s *service) insertJob(ctx context.Context, ws *workspace.Workspace, run *tofutfrun.Run) (err error) {
+	job := newJob(run, ws)
+	ctx, span := s.tracer.startCreate(ctx, job)
+	defer func() { endSpan(span, err) }()
+
+	return s.db.createJob(ctx, job)
 }
 
 // cancelJob is called when a user cancels a run - cancelJob determines whether
@@ -547,16 +1182,16 @@ func (s *service) getAgentJobs(ctx context.Context, agentID string) ([]*Job, err
 	if err != nil {
 		return nil, err
 	}
-	switch s := subject.(type) {
+	switch subj := subject.(type) {
 	case *serverAgent, *poolAgent:
-		if s.String() != agentID {
+		if subj.String() != agentID {
 			return nil, internal.ErrAccessNotPermitted
 		}
 	default:
 		return nil, internal.ErrAccessNotPermitted
 	}
 
-	sub, unsub := s.WatchJobs(ctx)
+	sub, unsub := s.dispatcher.wait(agentID)
 	defer unsub()
 	jobs, err := s.db.getAllocatedAndSignaledJobs(ctx, agentID)
 	if err != nil {
@@ -566,40 +1201,166 @@ func (s *service) getAgentJobs(ctx context.Context, agentID string) ([]*Job, err
 		// return existing jobs
 		return jobs, nil
 	}
-	// wait for a job matching criteria to arrive:
-	for event := range sub {
-		job := event.Payload
-		if job.AgentID == nil || *job.AgentID != agentID {
-			continue
-		}
-		switch job.Status {
-		case JobAllocated:
-			return []*Job{job}, nil
-		case JobRunning:
-			if job.Signaled != nil {
-				return []*Job{job}, nil
+	return waitForAgentJob(ctx, sub, agentID), nil
+}
+
+// waitForAgentJob waits for an event for a job that is either allocated to
+// the agent or is to be signaled, returning the job. Nil is returned if the
+// context is canceled or the subscription is closed first.
+func waitForAgentJob(ctx context.Context, sub <-chan pubsub.Event[*Job], agentID string) []*Job {
+	for {
+		select {
+		case event, open := <-sub:
+			if !open {
+				return nil
+			}
+			job := event.Payload
+			if job.AgentID == nil || *job.AgentID != agentID {
+				continue
 			}
+			switch job.Status {
+			case JobAllocated:
+				return []*Job{job}
+			case JobRunning, JobCanceled:
+				// a force canceled job is already canceled but is still to be
+				// signaled.
+				if job.Signaled != nil {
+					return []*Job{job}
+				}
+			}
+		case <-ctx.Done():
+			// the agent has disconnected or the server is shutting down
+			return nil
 		}
 	}
-	return nil, nil
-}
-
-func (s *service) getJob(ctx context.Context, spec JobSpec) (*Job, error) {
-	return s.db.getJob(ctx, spec)
-}
-
-func (s *service) listJobs(ctx context.Context) ([]*Job, error) {
-	return s.db.listJobs(ctx)
 }
 
-func (s *service) allocateJob(ctx context.Context, spec JobSpec, agentID string) (*Job, error) {
-	allocated, err := s.db.updateJob(ctx, spec, func(job *Job) error {
-		return job.allocate(agentID)
-	})
-	if err != nil {
+// ClaimNextJob atomically allocates the next unallocated job eligible for an
+// agent to the agent and returns it. Jobs of high priority runs, and jobs that
+// have waited longer than the priority aging period, are claimed ahead of
+// other jobs, and otherwise the oldest job is claimed first. Unlike waiting
+// for the allocator to allocate a job, no two agents can be handed the same
+// job. If there is no such job, or the agent has no spare capacity, or
+// maintenance mode is active, then it waits until a job can be claimed,
+// returning nil if the context is canceled first.
+//
+// Only the agent with an ID matching agentID can call this method.
+func (s *service) ClaimNextJob(ctx context.Context, agentID string) (*Job, error) {
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch subj := subject.(type) {
+	case *serverAgent, *poolAgent:
+		if subj.String() != agentID {
+			return nil, internal.ErrAccessNotPermitted
+		}
+	default:
+		return nil, internal.ErrAccessNotPermitted
+	}
+
+	// subscribe before attempting a claim so that no job event is missed
+	sub, unsub := s.WatchJobs(ctx)
+	defer unsub()
+	var (
+		paused bool
+		active <-chan bool
+	)
+	if s.maintenance != nil {
+		active, err = s.maintenance.WatchActive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		paused = <-active
+	}
+	for {
+		if !paused {
+			start := time.Now()
+			job, err := s.db.claimNextJob(ctx, agentID, s.jobDiskEstimate, s.priorityAging, s.versionUnavailable)
+			if err != nil {
+				s.logger.Error("claiming job", "agent_id", agentID, "err", err)
+				return nil, err
+			}
+			if job != nil {
+				s.tracer.record(ctx, "job.claim", job.Spec, job, start, nil, attribute.String("agent.id", agentID))
+				observeJobQueueWait(job)
+				s.logger.Info("claimed job", "job", job, "agent_id", agentID)
+				return job, nil
+			}
+		}
+		// wait for a job to become available, for the agent to gain spare
+		// capacity, or for maintenance mode to end.
+	wait:
+		for {
+			select {
+			case event, open := <-sub:
+				if !open {
+					return nil, nil
+				}
+				job := event.Payload
+				switch job.Status {
+				case JobUnallocated:
+					break wait
+				case JobFinished, JobErrored, JobCanceled:
+					// a job rejected before it was allocated may have been
+					// ahead of jobs the agent can claim
+					if job.AgentID == nil || *job.AgentID == agentID {
+						break wait
+					}
+				}
+			case isActive, open := <-active:
+				if !open {
+					return nil, nil
+				}
+				paused = isActive
+				break wait
+			case <-ctx.Done():
+				return nil, nil
+			}
+		}
+	}
+}
+
+// versionUnavailable determines whether a terraform version is neither
+// installed nor available for download. If that cannot be determined then the
+// version is deemed available, as it is by the allocator.
+func (s *service) versionUnavailable(ctx context.Context, version string) bool {
+	if s.releases == nil {
+		return false
+	}
+	if installed, err := s.releases.IsInstalled(ctx, version); err == nil && installed {
+		return false
+	}
+	return errors.Is(s.releases.CheckVersion(ctx, version), releases.ErrVersionUnavailable)
+}
+
+func (s *service) getJob(ctx context.Context, spec JobSpec) (*Job, error) {
+	return s.db.getJob(ctx, spec)
+}
+
+func (s *service) listJobs(ctx context.Context, opts ListJobsOptions) ([]*Job, error) {
+	jobs, err := s.db.listJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterJobs(jobs, opts), nil
+}
+
+func (s *service) allocateJob(ctx context.Context, spec JobSpec, agentID string) (*Job, error) {
+	start := time.Now()
+	allocated, err := s.db.updateJob(ctx, spec, func(job *Job) error {
+		if err := job.allocate(agentID); err != nil {
+			return err
+		}
+		job.AllocatedAt = internal.Time(internal.CurrentTimestamp(nil))
+		return nil
+	})
+	s.tracer.record(ctx, "job.allocate", spec, allocated, start, err, attribute.String("agent.id", agentID))
+	if err != nil {
 		s.logger.Error("allocating job", "spec", spec, "agent_id", agentID, "err", err)
 		return nil, err
 	}
+	observeJobQueueWait(allocated)
 
 	s.logger.Info("allocated job", "job", allocated, "agent_id", agentID)
 	return allocated, nil
@@ -610,10 +1371,15 @@ func (s *service) reallocateJob(ctx context.Context, spec JobSpec, agentID strin
 		from        string // ID of agent that job *was* allocated to
 		reallocated *Job
 	)
+	start := time.Now()
 	reallocated, err := s.db.updateJob(ctx, spec, func(job *Job) error {
 		from = *job.AgentID
 		return job.reallocate(agentID)
 	})
+	s.tracer.record(ctx, "job.reallocate", spec, reallocated, start, err,
+		attribute.String("agent.id", agentID),
+		attribute.String("agent.previous_id", from),
+	)
 	if err != nil {
 		s.logger.Error("re-allocating job", "spec", spec, "from", from, "to", agentID, "err", err)
 		return nil, err
@@ -622,6 +1388,142 @@ func (s *service) reallocateJob(ctx context.Context, spec JobSpec, agentID strin
 	return reallocated, nil
 }
 
+// rejectJob errors a job before it has been allocated to an agent, erroring
+// its run phase too and writing the reason to the phase's logs.
+func (s *service) rejectJob(ctx context.Context, spec JobSpec, reason string) (*Job, error) {
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "job-allocator"})
+	start := time.Now()
+	job, err := s.db.updateJob(ctx, spec, func(job *Job) error {
+		if err := job.finishJob(JobErrored); err != nil {
+			return err
+		}
+		// the phase must be started before it can be finished.
+		if _, err := s.phases.StartPhase(ctx, spec.RunID, spec.Phase, tofutfrun.PhaseStartOptions{}); err != nil {
+			return err
+		}
+		err := s.logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID: spec.RunID,
+			Phase: spec.Phase,
+			Data:  []byte(fmt.Sprintf("%cError: %s\n%c", internal.STX, reason, internal.ETX)),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = s.phases.FinishPhase(ctx, spec.RunID, spec.Phase, tofutfrun.PhaseFinishOptions{
+			Errored:       true,
+			ErrorCategory: tofutfrun.RejectedErrorCategory,
+		})
+		return err
+	})
+	s.tracer.record(ctx, "job.reject", spec, job, start, err, attribute.String("job.reject_reason", reason))
+	if err != nil {
+		s.logger.Error("rejecting job", "spec", spec, "err", err)
+		return nil, err
+	}
+	s.logger.Info("rejected job", "job", job, "reason", reason)
+	return job, nil
+}
+
+func (s *service) recordAllocatorStatus(ctx context.Context, status *AllocatorStatus) error {
+	return s.db.upsertAllocatorStatus(ctx, status)
+}
+
+func (s *service) markQueueSLABreaches(ctx context.Context, now time.Time) ([]*Job, error) {
+	return s.db.markQueueSLABreaches(ctx, now)
+}
+
+// ListQueueSLABreaches lists jobs in an organization that have waited longer
+// than the organization's queue time SLA to be allocated to an agent.
+func (s *service) ListQueueSLABreaches(ctx context.Context, organization string) ([]*Job, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListQueueSLABreachesAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.db.listQueueSLABreaches(ctx, organization)
+	if err != nil {
+		s.logger.Error("listing queue time SLA breaches", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("listed queue time SLA breaches", "organization", organization, "subject", subject, "count", len(jobs))
+	return jobs, nil
+}
+
+// GetJobCounts counts the jobs in an organization in each status, optionally
+// broken down by the agent pool to which they are assigned, and optionally
+// only those jobs with the given labels.
+func (s *service) GetJobCounts(ctx context.Context, organization string, opts JobCountsOptions) (*JobCounts, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetJobCountsAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.db.countJobs(ctx, organization, opts.Labels)
+	if err != nil {
+		s.logger.Error("counting jobs", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	return newJobCounts(organization, counts, opts.ByPool), nil
+}
+
+// GetAllocatorStatus retrieves the status recorded by the allocator at the end
+// of its most recent allocation pass. Only a site admin may retrieve the
+// status.
+func (s *service) GetAllocatorStatus(ctx context.Context) (*AllocatorStatus, error) {
+	if _, err := s.site.CanAccess(ctx, rbac.GetAllocatorStatusAction, ""); err != nil {
+		return nil, err
+	}
+	return s.db.getAllocatorStatus(ctx)
+}
+
+// GetSubscriptionStats reports the current subscriptions to each of the pool,
+// agent and job brokers on this server. Only a site admin may retrieve the
+// stats.
+func (s *service) GetSubscriptionStats(ctx context.Context) ([]pubsub.Stats, error) {
+	if _, err := s.site.CanAccess(ctx, rbac.GetSubscriptionStatsAction, ""); err != nil {
+		return nil, err
+	}
+	return []pubsub.Stats{
+		s.poolBroker.Stats(),
+		s.agentBroker.Stats(),
+		s.jobBroker.Stats(),
+	}, nil
+}
+
+// ReconcileOrphanedJobs deletes jobs whose run no longer exists, returning the
+// deleted jobs.
+func (s *service) ReconcileOrphanedJobs(ctx context.Context) ([]*OrphanedJob, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.ReconcileOrphanedJobsAction, "")
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := newOrphanReconciler(s.logger, s.db).reconcile(ctx)
+	if err != nil {
+		s.logger.Error("reconciling orphaned jobs", "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("reconciled orphaned jobs", "deleted", len(deleted), "subject", subject)
+	return deleted, nil
+}
+
+// GetPendingJob retrieves the job for a run that the allocator was unable to
+// allocate to an agent, along with the reason why. If the allocator has not
+// recorded a pending job for the run then internal.ErrResourceNotFound is
+// returned.
+func (s *service) GetPendingJob(ctx context.Context, runID string) (*PendingJob, error) {
+	if _, err := s.runs.CanAccess(ctx, rbac.GetRunAction, runID); err != nil {
+		return nil, err
+	}
+	status, err := s.db.getAllocatorStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range status.PendingJobs {
+		if job.Spec.RunID == runID {
+			return &job, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
 // startJob starts a job and returns a job token with permissions to
 // carry out the job. Only an agent that has been allocated the job can
 // call this method.
@@ -632,7 +1534,8 @@ func (s *service) startJob(ctx context.Context, spec JobSpec) ([]byte, error) {
 	}
 
 	var token []byte
-	_, err = s.db.updateJob(ctx, spec, func(job *Job) error {
+	start := time.Now()
+	started, err := s.db.updateJob(ctx, spec, func(job *Job) error {
 		if job.AgentID == nil || *job.AgentID != subject.String() {
 			return internal.ErrAccessNotPermitted
 		}
@@ -649,6 +1552,7 @@ func (s *service) startJob(ctx context.Context, spec JobSpec) ([]byte, error) {
 		}
 		return nil
 	})
+	s.tracer.record(ctx, "job.start", spec, started, start, err, attribute.String("agent.id", subject.String()))
 	if err != nil {
 		s.logger.Error("starting job", "spec", spec, "agent", subject, "err", err)
 		return nil, err
@@ -660,9 +1564,18 @@ func (s *service) startJob(ctx context.Context, spec JobSpec) ([]byte, error) {
 type finishJobOptions struct {
 	Status JobStatus `json:"status"`
 	Error  string    `json:"error,omitempty"`
+	// ErrorCategory classifies the error with which the job failed. Agents
+	// predating error categories omit it, in which case the run's error
+	// category is unknown.
+	ErrorCategory tofutfrun.ErrorCategory `json:"error_category,omitempty"`
 }
 
 // finishJob finishes a job. Only the job itself may call this endpoint.
+//
+// The job's slot on its agent is freed up in the same transaction as the
+// update to its status: the number of jobs an agent is running is derived
+// from the statuses of its jobs rather than maintained separately, so there
+// is no count to fall out of step should the server crash.
 func (s *service) finishJob(ctx context.Context, spec JobSpec, opts finishJobOptions) error {
 	{
 		subject, err := internal.SubjectFromContext(ctx)
@@ -674,26 +1587,13 @@ func (s *service) finishJob(ctx context.Context, spec JobSpec, opts finishJobOpt
 			return internal.ErrAccessNotPermitted
 		}
 	}
-	job, err := s.db.updateJob(ctx, spec, func(job *Job) error {
-		// update corresponding run phase too
-		var err error
-		switch opts.Status {
-		case JobFinished, JobErrored:
-			_, err = s.phases.FinishPhase(ctx, spec.RunID, spec.Phase, tofutfrun.PhaseFinishOptions{
-				Errored: opts.Status == JobErrored,
-			})
-		case JobCanceled:
-			err = s.phases.Cancel(ctx, spec.RunID)
-		}
-		if err != nil {
-			return err
-		}
-		return job.finishJob(opts.Status)
-	})
+	start := time.Now()
+	job, err := finishJobAndPhase(ctx, s.logger, newFinishBackoff(), s.db, s.phases, spec, opts)
+	s.tracer.record(ctx, "job.finish", spec, job, start, err)
 	if err != nil {
-		s.logger.Error("finishing job", "spec", spec, "err", err)
 		return err
 	}
+	observeJob(jobDurationMetric.WithLabelValues(string(spec.Phase), string(job.Status)), job, time.Since(job.CreatedAt))
 	if opts.Error != "" {
 		s.logger.Debug("finished job with error", "job", job, "status", opts.Status, "job_error", opts.Error)
 	} else {
@@ -779,6 +1679,24 @@ func (s *service) ListAgentTokens(ctx context.Context, poolID string) ([]*agentT
 	return tokens, nil
 }
 
+// ListExpiringAgentTokens lists the agent tokens across all the pools in an
+// organization that expire within the given duration, including those that
+// have already expired, ordered by expiry.
+func (s *service) ListExpiringAgentTokens(ctx context.Context, organization string, within time.Duration) ([]*expiringAgentToken, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListAgentTokensAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	before := internal.CurrentTimestamp(nil).Add(within)
+	tokens, err := s.db.listExpiringAgentTokens(ctx, organization, before)
+	if err != nil {
+		s.logger.Error("listing expiring agent tokens", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("listed expiring agent tokens", "organization", organization, "within", within, "subject", subject, "count", len(tokens))
+	return tokens, nil
+}
+
 func (s *service) DeleteAgentToken(ctx context.Context, tokenID string) (*agentToken, error) {
 	at, subject, err := func() (*agentToken, internal.Subject, error) {
 		// retrieve agent token and pool in order to get organization for authorization
@@ -807,3 +1725,308 @@ func (s *service) DeleteAgentToken(ctx context.Context, tokenID string) (*agentT
 	s.logger.Info("deleted agent token", "token", at, "subject", subject)
 	return at, nil
 }
+
+// bootstrap tokens
+
+// CreateBootstrapToken creates a short-lived token for provisioning agents in
+// a pool. Each agent that registers using the token is issued its own token.
+func (s *service) CreateBootstrapToken(ctx context.Context, poolID string, opts CreateBootstrapTokenOptions) (*bootstrapToken, []byte, error) {
+	bt, token, subject, err := func() (*bootstrapToken, []byte, internal.Subject, error) {
+		pool, err := s.db.getPool(ctx, poolID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		subject, err := s.organization.CanAccess(ctx, rbac.CreateAgentTokenAction, pool.Organization)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		bt, token, err := s.newBootstrapToken(poolID, opts)
+		if err != nil {
+			return nil, nil, subject, err
+		}
+		if err := s.db.createBootstrapToken(ctx, bt); err != nil {
+			return nil, nil, subject, err
+		}
+		return bt, token, subject, nil
+	}()
+	if err != nil {
+		s.logger.Error("creating bootstrap token", "agent_pool_id", poolID, "subject", subject, "err", err)
+		return nil, nil, err
+	}
+	s.logger.Info("created bootstrap token", "token", bt, "subject", subject)
+	return bt, token, nil
+}
+
+func (s *service) ListBootstrapTokens(ctx context.Context, poolID string) ([]*bootstrapToken, error) {
+	pool, err := s.db.getPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.ListAgentTokensAction, pool.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.db.listBootstrapTokens(ctx, poolID)
+	if err != nil {
+		s.logger.Error("listing bootstrap tokens", "agent_pool_id", poolID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("listed bootstrap tokens", "agent_pool_id", poolID, "subject", subject)
+	return tokens, nil
+}
+
+// DeleteBootstrapToken deletes a bootstrap token. Agents that have already
+// registered using the token are unaffected.
+func (s *service) DeleteBootstrapToken(ctx context.Context, tokenID string) (*bootstrapToken, error) {
+	bt, subject, err := func() (*bootstrapToken, internal.Subject, error) {
+		bt, err := s.db.getBootstrapToken(ctx, tokenID)
+		if err != nil {
+			return nil, nil, err
+		}
+		pool, err := s.db.getPool(ctx, bt.AgentPoolID)
+		if err != nil {
+			return nil, nil, err
+		}
+		subject, err := s.organization.CanAccess(ctx, rbac.DeleteAgentTokenAction, pool.Organization)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.db.deleteBootstrapToken(ctx, tokenID); err != nil {
+			return nil, subject, err
+		}
+		return bt, subject, nil
+	}()
+	if err != nil {
+		s.logger.Error("deleting bootstrap token", "id", tokenID, "err", err)
+		return nil, err
+	}
+	s.logger.Info("deleted bootstrap token", "token", bt, "subject", subject)
+	return bt, nil
+}
+
+// RevokeAgentToken revokes the token issued to an agent that registered using
+// a bootstrap token. The agent can no longer authenticate and is marked as
+// exited, without affecting the other agents in its pool.
+func (s *service) RevokeAgentToken(ctx context.Context, agentID string) (*Agent, error) {
+	agent, subject, err := func() (*Agent, internal.Subject, error) {
+		agent, err := s.db.getAgent(ctx, agentID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if agent.IsServer() {
+			return nil, nil, fmt.Errorf("server agents are not issued their own token")
+		}
+		pool, err := s.db.getPool(ctx, *agent.AgentPoolID)
+		if err != nil {
+			return nil, nil, err
+		}
+		subject, err := s.organization.CanAccess(ctx, rbac.DeleteAgentTokenAction, pool.Organization)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+			if err := s.db.deleteAgentCredential(ctx, agentID); err != nil {
+				return err
+			}
+			switch agent.Status {
+			case AgentExited, AgentErrored:
+				return nil
+			}
+			_, _, err := s.exitAgent(ctx, agentID, fmt.Sprintf("token revoked by %s", subject))
+			return err
+		})
+		if err != nil {
+			return nil, subject, err
+		}
+		return agent, subject, nil
+	}()
+	if err != nil {
+		s.logger.Error("revoking agent token", "agent_id", agentID, "err", err)
+		return nil, err
+	}
+	s.logger.Info("revoked agent token", "agent", agent, "subject", subject)
+	return agent, nil
+}
+
+// listAgentCredentials lists the IDs of the agents in an organization that
+// have been issued their own token.
+func (s *service) listAgentCredentials(ctx context.Context, organization string) ([]string, error) {
+	if _, err := s.organization.CanAccess(ctx, rbac.ListAgentsAction, organization); err != nil {
+		return nil, err
+	}
+	return s.db.listAgentCredentials(ctx, organization)
+}
+
+// RequestAgentDiagnostics requests that a pool agent collect a bundle of
+// diagnostics and upload it to the server. The request is delivered to the
+// agent in response to its next status update.
+func (s *service) RequestAgentDiagnostics(ctx context.Context, agentID string) (*DiagnosticsBundle, error) {
+	bundle, subject, err := func() (*DiagnosticsBundle, internal.Subject, error) {
+		agent, err := s.db.getAgent(ctx, agentID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if agent.IsServer() {
+			return nil, nil, ErrDiagnosticsServerAgent
+		}
+		pool, err := s.db.getPool(ctx, *agent.AgentPoolID)
+		if err != nil {
+			return nil, nil, err
+		}
+		subject, err := s.organization.CanAccess(ctx, rbac.RequestAgentDiagnosticsAction, pool.Organization)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch agent.Status {
+		case AgentExited, AgentErrored:
+			return nil, subject, ErrDiagnosticsAgentNotRunning
+		}
+		bundle := &DiagnosticsBundle{
+			ID:          internal.NewID("diag"),
+			AgentID:     agentID,
+			RequestedBy: subject.String(),
+			RequestedAt: internal.CurrentTimestamp(nil),
+		}
+		if err := s.db.createDiagnosticsBundle(ctx, bundle); err != nil {
+			if errors.Is(err, internal.ErrResourceAlreadyExists) {
+				return nil, subject, ErrDiagnosticsPending
+			}
+			return nil, subject, err
+		}
+		return bundle, subject, nil
+	}()
+	if err != nil {
+		s.logger.Error("requesting agent diagnostics", "agent_id", agentID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("requested agent diagnostics", "bundle", bundle, "subject", subject)
+	return bundle, nil
+}
+
+// uploadDiagnostics receives a bundle of diagnostics from the agent to which the
+// request for diagnostics was delivered.
+func (s *service) uploadDiagnostics(ctx context.Context, bundleID string, opts uploadDiagnosticsOptions) error {
+	// only a pool agent may call this endpoint
+	subject, err := poolAgentFromContext(ctx)
+	if err != nil {
+		return internal.ErrAccessNotPermitted
+	}
+	if len(opts.Bundle) > maxDiagnosticsSize {
+		s.logger.Error("uploading agent diagnostics", "bundle_id", bundleID, "agent_id", subject.agent.ID, "size", len(opts.Bundle), "err", ErrDiagnosticsTooLarge)
+		return ErrDiagnosticsTooLarge
+	}
+	err = s.db.uploadDiagnosticsBundle(ctx, bundleID, subject.agent.ID, internal.CurrentTimestamp(nil), opts)
+	if err != nil {
+		s.logger.Error("uploading agent diagnostics", "bundle_id", bundleID, "agent_id", subject.agent.ID, "err", err)
+		return err
+	}
+	if opts.Error != "" {
+		s.logger.Info("agent failed to collect diagnostics", "bundle_id", bundleID, "agent_id", subject.agent.ID, "reason", opts.Error)
+	} else {
+		s.logger.Info("uploaded agent diagnostics", "bundle_id", bundleID, "agent_id", subject.agent.ID, "size", len(opts.Bundle))
+	}
+	return nil
+}
+
+// GetAgentDiagnosticsBundle retrieves a diagnostics bundle along with its
+// contents.
+func (s *service) GetAgentDiagnosticsBundle(ctx context.Context, bundleID string) (*DiagnosticsBundle, error) {
+	bundle, err := s.db.getDiagnosticsBundle(ctx, bundleID)
+	if err != nil {
+		s.logger.Error("retrieving agent diagnostics", "bundle_id", bundleID, "err", err)
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetAgentDiagnosticsAction, bundle.Organization)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Debug("retrieved agent diagnostics", "bundle", bundle, "subject", subject)
+	return bundle, nil
+}
+
+// listDiagnosticsBundles lists the diagnostics bundles of the agents in an
+// organization, without their contents.
+func (s *service) listDiagnosticsBundles(ctx context.Context, organization string) ([]*DiagnosticsBundle, error) {
+	if _, err := s.organization.CanAccess(ctx, rbac.GetAgentDiagnosticsAction, organization); err != nil {
+		return nil, err
+	}
+	return s.db.listDiagnosticsBundles(ctx, organization)
+}
+
+// SetPoolAutoscaler configures the autoscaling of the agents in a pool,
+// replacing any existing configuration.
+func (s *service) SetPoolAutoscaler(ctx context.Context, poolID string, opts SetPoolAutoscalerOptions) (*PoolAutoscaler, error) {
+	as, subject, err := func() (*PoolAutoscaler, internal.Subject, error) {
+		pool, err := s.db.getPool(ctx, poolID)
+		if err != nil {
+			return nil, nil, err
+		}
+		subject, err := s.organization.CanAccess(ctx, rbac.UpdateAgentPoolAction, pool.Organization)
+		if err != nil {
+			return nil, nil, err
+		}
+		existing, err := s.db.getPoolAutoscaler(ctx, poolID)
+		if err != nil && !errors.Is(err, internal.ErrResourceNotFound) {
+			return nil, subject, err
+		}
+		as, err := newPoolAutoscaler(poolID, existing, opts)
+		if err != nil {
+			return nil, subject, err
+		}
+		if err := s.db.upsertPoolAutoscaler(ctx, as); err != nil {
+			return nil, subject, err
+		}
+		return as, subject, nil
+	}()
+	if err != nil {
+		s.logger.Error("setting agent pool autoscaler", "agent_pool_id", poolID, "subject", subject, "err", err)
+		return nil, err
+	}
+
+	s.logger.Info("set agent pool autoscaler", "autoscaler", as, "subject", subject)
+	return as, nil
+}
+
+// GetPoolAutoscaler retrieves the autoscaler configured for a pool. If the
+// pool has no autoscaler then internal.ErrResourceNotFound is returned.
+func (s *service) GetPoolAutoscaler(ctx context.Context, poolID string) (*PoolAutoscaler, error) {
+	pool, err := s.db.getPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.organization.CanAccess(ctx, rbac.GetAgentPoolAction, pool.Organization); err != nil {
+		return nil, err
+	}
+	return s.db.getPoolAutoscaler(ctx, poolID)
+}
+
+func (s *service) DeletePoolAutoscaler(ctx context.Context, poolID string) error {
+	subject, err := func() (internal.Subject, error) {
+		pool, err := s.db.getPool(ctx, poolID)
+		if err != nil {
+			return nil, err
+		}
+		subject, err := s.organization.CanAccess(ctx, rbac.UpdateAgentPoolAction, pool.Organization)
+		if err != nil {
+			return nil, err
+		}
+		return subject, s.db.deletePoolAutoscaler(ctx, poolID)
+	}()
+	if err != nil {
+		s.logger.Error("deleting agent pool autoscaler", "agent_pool_id", poolID, "subject", subject, "err", err)
+		return err
+	}
+
+	s.logger.Info("deleted agent pool autoscaler", "agent_pool_id", poolID, "subject", subject)
+	return nil
+}
+
+// ListScalingDecisions lists the most recent scaling decisions made by the
+// autoscaler for a pool, most recent first.
+func (s *service) ListScalingDecisions(ctx context.Context, poolID string) ([]*ScalingDecision, error) {
+	if _, err := s.site.CanAccess(ctx, rbac.ListScalingDecisionsAction, ""); err != nil {
+		return nil, err
+	}
+	return s.db.listScalingDecisions(ctx, poolID)
+}
@@ -0,0 +1,422 @@
+package agent
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/workspace"
+	"go.uber.org/goleak"
+)
+
+func TestHandleQueuedJobs(t *testing.T) {
+	pool := &Pool{ID: "pool-1", Organization: "acme"}
+
+	newJobs := func() []*Job {
+		return []*Job{
+			{
+				Spec:        JobSpec{RunID: "run-1", Phase: internal.PlanPhase},
+				Status:      JobUnallocated,
+				AgentPoolID: internal.String("pool-1"),
+				WorkspaceID: "ws-1",
+			},
+			{
+				Spec:        JobSpec{RunID: "run-2", Phase: internal.ApplyPhase},
+				Status:      JobAllocated,
+				AgentPoolID: internal.String("pool-1"),
+				AgentID:     internal.String("agent-1"),
+				WorkspaceID: "ws-2",
+			},
+		}
+	}
+
+	t.Run("no queued jobs", func(t *testing.T) {
+		client := &fakeQueuedJobsClient{}
+
+		affected, err := handleQueuedJobs(context.Background(), client, client, pool, deletePoolOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 0, affected)
+	})
+
+	t.Run("refuse when no action chosen", func(t *testing.T) {
+		client := &fakeQueuedJobsClient{jobs: newJobs()}
+
+		_, err := handleQueuedJobs(context.Background(), client, client, pool, deletePoolOptions{})
+		assert.ErrorIs(t, err, ErrCannotDeletePoolWithQueuedJobs)
+		assert.Empty(t, client.canceled)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		client := &fakeQueuedJobsClient{jobs: newJobs()}
+
+		affected, err := handleQueuedJobs(context.Background(), client, client, pool, deletePoolOptions{
+			QueuedJobs: CancelQueuedJobs,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, affected)
+		assert.Equal(t, []string{"run-1", "run-2"}, client.canceled)
+	})
+
+	t.Run("migrate", func(t *testing.T) {
+		client := &fakeQueuedJobsClient{
+			jobs: newJobs(),
+			pools: []*Pool{
+				{ID: "pool-2", Organization: "acme", OrganizationScoped: true},
+			},
+		}
+
+		affected, err := handleQueuedJobs(context.Background(), client, client, pool, deletePoolOptions{
+			QueuedJobs:      MigrateQueuedJobs,
+			MigrateToPoolID: internal.String("pool-2"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, affected)
+		for _, job := range client.jobs {
+			assert.Equal(t, "pool-2", *job.AgentPoolID)
+			assert.Equal(t, JobUnallocated, job.Status)
+			assert.Nil(t, job.AgentID)
+		}
+	})
+
+	t.Run("migrate to pool with explicitly allowed workspaces", func(t *testing.T) {
+		client := &fakeQueuedJobsClient{
+			jobs: newJobs(),
+			pools: []*Pool{
+				{ID: "pool-2", Organization: "acme", AllowedWorkspaces: []string{"ws-1", "ws-2"}},
+			},
+		}
+
+		affected, err := handleQueuedJobs(context.Background(), client, client, pool, deletePoolOptions{
+			QueuedJobs:      MigrateQueuedJobs,
+			MigrateToPoolID: internal.String("pool-2"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, affected)
+	})
+
+	t.Run("refuse migration without pool", func(t *testing.T) {
+		client := &fakeQueuedJobsClient{jobs: newJobs()}
+
+		_, err := handleQueuedJobs(context.Background(), client, client, pool, deletePoolOptions{
+			QueuedJobs: MigrateQueuedJobs,
+		})
+		assert.ErrorIs(t, err, ErrInvalidQueuedJobsMigrationPool)
+	})
+
+	t.Run("refuse migration to same pool", func(t *testing.T) {
+		client := &fakeQueuedJobsClient{jobs: newJobs()}
+
+		_, err := handleQueuedJobs(context.Background(), client, client, pool, deletePoolOptions{
+			QueuedJobs:      MigrateQueuedJobs,
+			MigrateToPoolID: internal.String("pool-1"),
+		})
+		assert.ErrorIs(t, err, ErrInvalidQueuedJobsMigrationPool)
+	})
+
+	t.Run("refuse migration to pool in different organization", func(t *testing.T) {
+		client := &fakeQueuedJobsClient{
+			jobs: newJobs(),
+			pools: []*Pool{
+				{ID: "pool-2", Organization: "other-org", OrganizationScoped: true},
+			},
+		}
+
+		_, err := handleQueuedJobs(context.Background(), client, client, pool, deletePoolOptions{
+			QueuedJobs:      MigrateQueuedJobs,
+			MigrateToPoolID: internal.String("pool-2"),
+		})
+		assert.ErrorIs(t, err, ErrInvalidQueuedJobsMigrationPool)
+	})
+
+	t.Run("refuse migration to pool that disallows workspace", func(t *testing.T) {
+		client := &fakeQueuedJobsClient{
+			jobs: newJobs(),
+			pools: []*Pool{
+				{ID: "pool-2", Organization: "acme", AllowedWorkspaces: []string{"ws-1"}},
+			},
+		}
+
+		_, err := handleQueuedJobs(context.Background(), client, client, pool, deletePoolOptions{
+			QueuedJobs:      MigrateQueuedJobs,
+			MigrateToPoolID: internal.String("pool-2"),
+		})
+		assert.ErrorIs(t, err, ErrWorkspaceNotAllowedToUsePool)
+		// no jobs should have been migrated
+		for _, job := range client.jobs {
+			assert.Equal(t, "pool-1", *job.AgentPoolID)
+		}
+	})
+}
+
+func TestGetPools(t *testing.T) {
+	ctx := context.Background()
+	client := &fakePoolsClient{pools: []*Pool{
+		{ID: "pool-1", Organization: "acme"},
+		{ID: "pool-2", Organization: "acme"},
+		{ID: "pool-3", Organization: "globex"},
+	}}
+
+	t.Run("mixed found and not found", func(t *testing.T) {
+		authorizer := &fakePoolsAuthorizer{}
+
+		found, missing, err := getPools(ctx, client, authorizer, []string{"pool-1", "pool-404", "pool-3", "pool-404"})
+		require.NoError(t, err)
+
+		assert.Len(t, found, 2)
+		assert.Equal(t, client.pools[0], found["pool-1"])
+		assert.Equal(t, client.pools[2], found["pool-3"])
+		assert.Equal(t, []string{"pool-404"}, missing)
+		// each organization authorized only once
+		assert.Equal(t, []string{"acme", "globex"}, authorizer.organizations)
+	})
+
+	t.Run("none found", func(t *testing.T) {
+		found, missing, err := getPools(ctx, client, &fakePoolsAuthorizer{}, []string{"pool-404"})
+		require.NoError(t, err)
+
+		assert.Empty(t, found)
+		assert.Equal(t, []string{"pool-404"}, missing)
+	})
+
+	t.Run("no IDs", func(t *testing.T) {
+		found, missing, err := getPools(ctx, client, &fakePoolsAuthorizer{}, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, found)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("deny access to pool in other organization", func(t *testing.T) {
+		authorizer := &fakePoolsAuthorizer{deny: "globex"}
+
+		_, _, err := getPools(ctx, client, authorizer, []string{"pool-1", "pool-3"})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+}
+
+type fakePoolsClient struct {
+	pools []*Pool
+}
+
+func (f *fakePoolsClient) getPools(ctx context.Context, poolIDs []string) ([]*Pool, error) {
+	var pools []*Pool
+	for _, pool := range f.pools {
+		if slices.Contains(poolIDs, pool.ID) {
+			pools = append(pools, pool)
+		}
+	}
+	return pools, nil
+}
+
+type fakePoolsAuthorizer struct {
+	deny          string
+	organizations []string
+}
+
+func (f *fakePoolsAuthorizer) CanAccess(ctx context.Context, action rbac.Action, organization string) (internal.Subject, error) {
+	f.organizations = append(f.organizations, organization)
+	if organization == f.deny {
+		return nil, internal.ErrAccessNotPermitted
+	}
+	return &internal.Superuser{}, nil
+}
+
+type fakeQueuedJobsClient struct {
+	pools    []*Pool
+	jobs     []*Job
+	canceled []string
+
+	phaseClient
+}
+
+func (f *fakeQueuedJobsClient) getPool(ctx context.Context, poolID string) (*Pool, error) {
+	for _, pool := range f.pools {
+		if pool.ID == poolID {
+			return pool, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeQueuedJobsClient) listQueuedJobsByPool(ctx context.Context, poolID string) ([]*Job, error) {
+	return f.jobs, nil
+}
+
+func (f *fakeQueuedJobsClient) updateJob(ctx context.Context, spec JobSpec, fn func(*Job) error) (*Job, error) {
+	for _, job := range f.jobs {
+		if job.Spec == spec {
+			if err := fn(job); err != nil {
+				return nil, err
+			}
+			return job, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeQueuedJobsClient) Cancel(ctx context.Context, runID string) error {
+	f.canceled = append(f.canceled, runID)
+	return nil
+}
+
+func TestPoolWithoutAgentsWarning(t *testing.T) {
+	tests := []struct {
+		name       string
+		agents     []*Agent
+		autoscaled bool
+		want       bool
+	}{
+		{"no agents", nil, false, true},
+		{"no running agents", []*Agent{{Status: AgentExited}, {Status: AgentErrored}}, false, true},
+		{"idle agent", []*Agent{{Status: AgentExited}, {Status: AgentIdle}}, false, false},
+		{"busy agent", []*Agent{{Status: AgentBusy}}, false, false},
+		{"autoscaled pool without agents", nil, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := poolWithoutAgentsWarning("pool-123", tt.agents, tt.autoscaled)
+			assert.Equal(t, tt.want, got != nil)
+		})
+	}
+}
+
+func TestFilterFinishedJobs(t *testing.T) {
+	newEvent := func(typ pubsub.EventType, status JobStatus, org, poolID string) pubsub.Event[*Job] {
+		return pubsub.Event[*Job]{
+			Type: typ,
+			Payload: &Job{
+				Spec:         JobSpec{RunID: "run-" + string(status)},
+				Status:       status,
+				Organization: org,
+				AgentPoolID:  internal.String(poolID),
+			},
+		}
+	}
+	events := []pubsub.Event[*Job]{
+		newEvent(pubsub.CreatedEvent, JobUnallocated, "acme", "pool-1"),
+		newEvent(pubsub.UpdatedEvent, JobAllocated, "acme", "pool-1"),
+		newEvent(pubsub.UpdatedEvent, JobRunning, "acme", "pool-1"),
+		newEvent(pubsub.UpdatedEvent, JobFinished, "acme", "pool-1"),
+		newEvent(pubsub.UpdatedEvent, JobErrored, "acme", "pool-2"),
+		newEvent(pubsub.UpdatedEvent, JobCanceled, "globex", "pool-3"),
+		newEvent(pubsub.DeletedEvent, JobFinished, "acme", "pool-1"),
+	}
+	receive := func(opts WatchFinishedJobsOptions) (got []JobStatus) {
+		sub := make(chan pubsub.Event[*Job], len(events))
+		for _, event := range events {
+			sub <- event
+		}
+		close(sub)
+		for event := range filterFinishedJobs(context.Background(), sub, opts) {
+			got = append(got, event.Payload.Status)
+		}
+		return got
+	}
+
+	t.Run("only terminal events", func(t *testing.T) {
+		got := receive(WatchFinishedJobsOptions{})
+		assert.Equal(t, []JobStatus{JobFinished, JobErrored, JobCanceled}, got)
+	})
+
+	t.Run("filter by organization", func(t *testing.T) {
+		got := receive(WatchFinishedJobsOptions{Organization: internal.String("acme")})
+		assert.Equal(t, []JobStatus{JobFinished, JobErrored}, got)
+	})
+
+	t.Run("filter by agent pool", func(t *testing.T) {
+		got := receive(WatchFinishedJobsOptions{AgentPoolID: internal.String("pool-3")})
+		assert.Equal(t, []JobStatus{JobCanceled}, got)
+	})
+
+	t.Run("consumer stops reading", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+		ctx, cancel := context.WithCancel(context.Background())
+		sub := make(chan pubsub.Event[*Job], len(events))
+		for _, event := range events {
+			sub <- event
+		}
+
+		// consumer reads only the first of several terminal events and then
+		// gives up, canceling the subscription, which closes it.
+		relay := filterFinishedJobs(ctx, sub, WatchFinishedJobsOptions{})
+		<-relay
+		cancel()
+		close(sub)
+	})
+}
+
+func TestService_versionUnavailable(t *testing.T) {
+	svc := &service{
+		releases: &fakeReleasesService{
+			installed:   []string{"1.6.0"},
+			unavailable: []string{"1.2.99"},
+		},
+	}
+	ctx := context.Background()
+
+	assert.False(t, svc.versionUnavailable(ctx, "1.6.0"))
+	assert.False(t, svc.versionUnavailable(ctx, "1.7.0"))
+	assert.True(t, svc.versionUnavailable(ctx, "1.2.99"))
+}
+
+func TestJobAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		paused  bool
+		reject  bool
+		want    bool
+		wantErr error
+	}{
+		{"active workspace", false, false, true, nil},
+		{"active workspace rejecting paused runs", false, true, true, nil},
+		{"paused workspace queues run", true, false, false, nil},
+		{"paused workspace rejects run", true, true, false, workspace.ErrWorkspacePaused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jobAllowed(&workspace.Workspace{Paused: tt.paused}, tt.reject)
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWaitForAgentJob(t *testing.T) {
+	t.Run("allocated job", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+		sub := make(chan pubsub.Event[*Job], 2)
+		sub <- pubsub.Event[*Job]{Payload: &Job{Status: JobAllocated, AgentID: internal.String("agent-2")}}
+		sub <- pubsub.Event[*Job]{Payload: &Job{Status: JobAllocated, AgentID: internal.String("agent-1")}}
+
+		got := waitForAgentJob(context.Background(), sub, "agent-1")
+		require.Len(t, got, 1)
+		assert.Equal(t, "agent-1", *got[0].AgentID)
+	})
+
+	t.Run("return promptly upon context cancellation", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+		ctx, cancel := context.WithCancel(context.Background())
+		// subscription is never closed, mimicking a client disconnecting
+		// whilst no job events arrive.
+		sub := make(chan pubsub.Event[*Job])
+
+		done := make(chan []*Job)
+		go func() {
+			done <- waitForAgentJob(ctx, sub, "agent-1")
+		}()
+		cancel()
+
+		select {
+		case got := <-done:
+			assert.Nil(t, got)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for return")
+		}
+	})
+}
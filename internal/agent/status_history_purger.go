@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// StatusHistoryPurgerLockID guarantees only one status history purger on a
+// cluster is running at any time.
+const StatusHistoryPurgerLockID int64 = 5577006791947779424
+
+const (
+	// DefaultStatusHistoryRetention is the default duration for which agent
+	// status changes are retained.
+	DefaultStatusHistoryRetention = 30 * 24 * time.Hour
+
+	defaultStatusHistoryPurgerInterval = time.Hour
+	// statusHistoryPurgeBatchSize is the maximum number of status changes
+	// deleted in one statement, to avoid holding locks on the table for long.
+	statusHistoryPurgeBatchSize = 1000
+)
+
+// statusHistoryPurger purges agent status changes once they are older than the
+// retention window. The most recent change of each agent, which records its
+// current status, is always retained.
+//
+// Only one purger should be running on a cluster at any one time.
+type statusHistoryPurger struct {
+	logger *slog.Logger
+	client statusHistoryPurgerClient
+	// retention is the duration for which status changes are retained.
+	retention time.Duration
+	// frequency with which the purger checks for status changes to purge.
+	interval time.Duration
+	// maximum number of status changes deleted in each batch.
+	batchSize int
+}
+
+type statusHistoryPurgerClient interface {
+	deleteStatusHistoryBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+func newStatusHistoryPurger(logger *slog.Logger, client statusHistoryPurgerClient, retention time.Duration) *statusHistoryPurger {
+	return &statusHistoryPurger{
+		logger:    logger.With("component", "agent-status-history-purger"),
+		client:    client,
+		retention: retention,
+		interval:  defaultStatusHistoryPurgerInterval,
+		batchSize: statusHistoryPurgeBatchSize,
+	}
+}
+
+func (p *statusHistoryPurger) String() string { return "agent-status-history-purger" }
+
+// Start the purger. Should be invoked in a go routine.
+func (p *statusHistoryPurger) Start(ctx context.Context) error {
+	// run at startup and then every interval
+	if err := p.purge(ctx, time.Now()); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.purge(ctx, time.Now()); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// purge deletes status changes older than the retention window by now, in
+// batches, until there are none left to delete.
+func (p *statusHistoryPurger) purge(ctx context.Context, now time.Time) error {
+	before := now.Add(-p.retention)
+	var total int64
+	for {
+		n, err := p.client.deleteStatusHistoryBefore(ctx, before, p.batchSize)
+		if err != nil {
+			return err
+		}
+		total += n
+		if n < int64(p.batchSize) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+	}
+	if total > 0 {
+		p.logger.Info("purged agent status history", "count", total, "before", before)
+	}
+	return nil
+}
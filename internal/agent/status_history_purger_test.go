@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+type fakeStatusHistoryPurgerClient struct {
+	// status changes, oldest first
+	history []fakeStatusChange
+	// number of batches deleted
+	batches int
+}
+
+type fakeStatusChange struct {
+	agentID   string
+	changedAt time.Time
+}
+
+func (f *fakeStatusHistoryPurgerClient) deleteStatusHistoryBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	f.batches++
+	// the most recent change of each agent is spared
+	latest := make(map[string]int)
+	for i, change := range f.history {
+		latest[change.agentID] = i
+	}
+	var (
+		retained []fakeStatusChange
+		deleted  int64
+	)
+	for i, change := range f.history {
+		if deleted < int64(limit) && change.changedAt.Before(before) && latest[change.agentID] != i {
+			deleted++
+			continue
+		}
+		retained = append(retained, change)
+	}
+	f.history = retained
+	return deleted, nil
+}
+
+func TestStatusHistoryPurger(t *testing.T) {
+	now := time.Now()
+	client := &fakeStatusHistoryPurgerClient{
+		history: []fakeStatusChange{
+			{"agent-1", now.Add(-3 * time.Hour)},
+			{"agent-2", now.Add(-3 * time.Hour)},
+			{"agent-1", now.Add(-2 * time.Hour)},
+			{"agent-1", now.Add(-2 * time.Hour)},
+			{"agent-1", now.Add(-time.Minute)},
+			{"agent-1", now.Add(-time.Second)},
+		},
+	}
+	p := newStatusHistoryPurger(slog.New(&xslog.NoopHandler{}), client, time.Hour)
+	p.batchSize = 2
+
+	err := p.purge(context.Background(), now)
+	require.NoError(t, err)
+
+	// old changes are purged in batches until none are left, whereas recent
+	// changes, and the current status of agent-2, are retained.
+	assert.Equal(t, []fakeStatusChange{
+		{"agent-2", now.Add(-3 * time.Hour)},
+		{"agent-1", now.Add(-time.Minute)},
+		{"agent-1", now.Add(-time.Second)},
+	}, client.history)
+	assert.Equal(t, 2, client.batches)
+}
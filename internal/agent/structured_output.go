@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// structuredOutputWriter splits the machine-readable output of terraform's
+// -json flag into two streams: the JSON lines as they are, and the
+// human-readable message of each line.
+type structuredOutputWriter struct {
+	plain io.Writer
+	json  io.Writer
+	// incomplete line withheld until the rest of the line is written
+	partial []byte
+}
+
+// structuredEvent is the subset of a line of terraform's machine-readable
+// output from which a human-readable message is constructed.
+type structuredEvent struct {
+	Message    string `json:"@message"`
+	Diagnostic *struct {
+		Detail string `json:"detail"`
+	} `json:"diagnostic"`
+}
+
+func newStructuredOutputWriter(plain, jsonOut io.Writer) *structuredOutputWriter {
+	return &structuredOutputWriter{plain: plain, json: jsonOut}
+}
+
+// Write writes complete lines to both streams, withholding any incomplete line
+// until it is completed by a subsequent write.
+func (w *structuredOutputWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	i := bytes.LastIndexByte(w.partial, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	lines := w.partial[:i+1]
+	w.partial = append([]byte{}, w.partial[i+1:]...)
+	if err := w.write(lines); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close writes any withheld incomplete line.
+func (w *structuredOutputWriter) Close() error {
+	if len(w.partial) == 0 {
+		return nil
+	}
+	lines := append(w.partial, '\n')
+	w.partial = nil
+	return w.write(lines)
+}
+
+func (w *structuredOutputWriter) write(lines []byte) error {
+	if _, err := w.json.Write(lines); err != nil {
+		return err
+	}
+	var plain bytes.Buffer
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var event structuredEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			// not machine-readable output, e.g. a panic, so write as is.
+			plain.Write(line)
+			continue
+		}
+		plain.WriteString(event.Message)
+		plain.WriteRune('\n')
+		if event.Diagnostic != nil && event.Diagnostic.Detail != "" {
+			plain.WriteRune('\n')
+			plain.WriteString(event.Diagnostic.Detail)
+			plain.WriteRune('\n')
+		}
+	}
+	_, err := w.plain.Write(plain.Bytes())
+	return err
+}
@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuredOutputWriter(t *testing.T) {
+	t.Run("split lines", func(t *testing.T) {
+		var plain, json bytes.Buffer
+		w := newStructuredOutputWriter(&plain, &json)
+
+		_, err := w.Write([]byte(`{"@message":"Terraform 1.6.0","type":"version"}` + "\n"))
+		require.NoError(t, err)
+		_, err = w.Write([]byte(`{"@message":"Plan: 1 to add, 0 to change, 0 to destroy.","type":"change_summary"}` + "\n"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "Terraform 1.6.0\nPlan: 1 to add, 0 to change, 0 to destroy.\n", plain.String())
+		assert.Equal(t, `{"@message":"Terraform 1.6.0","type":"version"}`+"\n"+
+			`{"@message":"Plan: 1 to add, 0 to change, 0 to destroy.","type":"change_summary"}`+"\n", json.String())
+	})
+
+	t.Run("withhold incomplete line", func(t *testing.T) {
+		var plain, json bytes.Buffer
+		w := newStructuredOutputWriter(&plain, &json)
+
+		_, err := w.Write([]byte(`{"@message":"hello`))
+		require.NoError(t, err)
+		assert.Empty(t, plain.String())
+		assert.Empty(t, json.String())
+
+		_, err = w.Write([]byte(` world"}` + "\n" + `{"@message":"incomplete"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "hello world\n", plain.String())
+		assert.Equal(t, `{"@message":"hello world"}`+"\n", json.String())
+
+		// closing writes the incomplete line
+		require.NoError(t, w.Close())
+		assert.Equal(t, "hello world\nincomplete\n", plain.String())
+	})
+
+	t.Run("diagnostic", func(t *testing.T) {
+		var plain, json bytes.Buffer
+		w := newStructuredOutputWriter(&plain, &json)
+
+		_, err := w.Write([]byte(`{"@message":"Error: Invalid reference","type":"diagnostic","diagnostic":{"severity":"error","summary":"Invalid reference","detail":"A reference to a resource type must be followed by at least one attribute access."}}` + "\n"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Error: Invalid reference\n\nA reference to a resource type must be followed by at least one attribute access.\n", plain.String())
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		var plain, json bytes.Buffer
+		w := newStructuredOutputWriter(&plain, &json)
+
+		_, err := w.Write([]byte("panic: runtime error\n"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "panic: runtime error\n", plain.String())
+		assert.Equal(t, "panic: runtime error\n", json.String())
+	})
+}
@@ -19,6 +19,10 @@ type (
 	unregisteredPoolAgent struct {
 		pool         *Pool
 		agentTokenID string
+		// bootstrapTokenID is the ID of the bootstrap token with which the
+		// agent authenticated, if any, in which case the agent is issued its
+		// own token upon registration.
+		bootstrapTokenID string
 
 		internal.Subject
 	}
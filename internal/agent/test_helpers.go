@@ -1,6 +1,13 @@
 package agent
 
-import "context"
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/releases"
+)
 
 type fakeService struct {
 	pool                   *Pool
@@ -8,8 +15,26 @@ type fakeService struct {
 	at                     *agentToken
 	token                  []byte
 	status                 AgentStatus
+	statusReason           string
 	deletedAgentID         string
 	job                    *Job
+	allocatorStatus        *AllocatorStatus
+	pendingJob             *PendingJob
+	breachedJobs           []*Job
+	checkedQueueSLAs       bool
+	updatePoolOptions      updatePoolOptions
+	impacted               []ImpactedWorkspace
+	deletedPools           []*Pool
+	undeleteErr            error
+	deletePoolOptions      deletePoolOptions
+	deleteErr              error
+	bt                     *bootstrapToken
+	bootstrapTokenOptions  CreateBootstrapTokenOptions
+	agent                  *Agent
+	diagnosticsBundle      *DiagnosticsBundle
+	requestDiagnosticsErr  error
+	agents                 []*Agent
+	pools                  map[string]*Pool
 
 	service
 }
@@ -19,6 +44,14 @@ func (f *fakeService) CreateAgentPool(ctx context.Context, opts CreateAgentPoolO
 	return f.pool, nil
 }
 
+func (f *fakeService) updateAgentPool(ctx context.Context, poolID string, opts updatePoolOptions) (*Pool, []ImpactedWorkspace, error) {
+	f.updatePoolOptions = opts
+	if unacked := unacknowledged(f.impacted, opts.AcknowledgedWorkspaces); !opts.DryRun && len(unacked) > 0 {
+		return nil, f.impacted, &ImpactError{Workspaces: unacked}
+	}
+	return f.pool, f.impacted, nil
+}
+
 func (f *fakeService) listAllAgentPools(ctx context.Context) ([]*Pool, error) {
 	return []*Pool{f.pool}, nil
 }
@@ -27,6 +60,54 @@ func (f *fakeService) listAgentPoolsByOrganization(context.Context, string, list
 	return []*Pool{f.pool}, nil
 }
 
+func (f *fakeService) getAgentPools(_ context.Context, poolIDs []string) (map[string]*Pool, []string, error) {
+	found := make(map[string]*Pool)
+	var missing []string
+	for _, id := range poolIDs {
+		if pool, ok := f.pools[id]; ok {
+			found[id] = pool
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return found, missing, nil
+}
+
+func (f *fakeService) listServerAgents(context.Context) ([]*Agent, error) {
+	return nil, nil
+}
+
+func (f *fakeService) listAgentsByOrganization(context.Context, string) ([]*Agent, error) {
+	return f.agents, nil
+}
+
+func (f *fakeService) listAgentCredentials(context.Context, string) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeService) listDiagnosticsBundles(context.Context, string) ([]*DiagnosticsBundle, error) {
+	return nil, nil
+}
+
+func (f *fakeService) listDeletedAgentPools(context.Context, string) ([]*Pool, error) {
+	return f.deletedPools, nil
+}
+
+func (f *fakeService) deleteAgentPool(ctx context.Context, poolID string, opts deletePoolOptions) (*Pool, int, error) {
+	f.deletePoolOptions = opts
+	if f.deleteErr != nil {
+		return nil, 0, f.deleteErr
+	}
+	return f.pool, 0, nil
+}
+
+func (f *fakeService) UndeletePool(context.Context, string) (*Pool, error) {
+	if f.undeleteErr != nil {
+		return nil, f.undeleteErr
+	}
+	return f.pool, nil
+}
+
 func (f *fakeService) CreateAgentToken(context.Context, string, CreateAgentTokenOptions) (*agentToken, []byte, error) {
 	return f.at, f.token, nil
 }
@@ -39,9 +120,31 @@ func (f *fakeService) DeleteAgentToken(context.Context, string) (*agentToken, er
 	return f.at, nil
 }
 
-func (f *fakeService) updateAgentStatus(ctx context.Context, agentID string, status AgentStatus) error {
+func (f *fakeService) CreateBootstrapToken(_ context.Context, _ string, opts CreateBootstrapTokenOptions) (*bootstrapToken, []byte, error) {
+	f.bootstrapTokenOptions = opts
+	return f.bt, f.token, nil
+}
+
+func (f *fakeService) DeleteBootstrapToken(context.Context, string) (*bootstrapToken, error) {
+	return f.bt, nil
+}
+
+func (f *fakeService) RevokeAgentToken(context.Context, string) (*Agent, error) {
+	return f.agent, nil
+}
+
+func (f *fakeService) RequestAgentDiagnostics(context.Context, string) (*DiagnosticsBundle, error) {
+	return f.diagnosticsBundle, f.requestDiagnosticsErr
+}
+
+func (f *fakeService) GetAgentDiagnosticsBundle(context.Context, string) (*DiagnosticsBundle, error) {
+	return f.diagnosticsBundle, nil
+}
+
+func (f *fakeService) updateAgentStatus(ctx context.Context, agentID string, status AgentStatus, reason string) (*statusUpdateResponse, error) {
 	f.status = status
-	return nil
+	f.statusReason = reason
+	return &statusUpdateResponse{}, nil
 }
 
 func (f *fakeService) deleteAgent(ctx context.Context, agentID string) error {
@@ -56,9 +159,57 @@ func (f *fakeService) allocateJob(ctx context.Context, spec JobSpec, agentID str
 	return f.job, nil
 }
 
+func (f *fakeService) rejectJob(ctx context.Context, spec JobSpec, reason string) (*Job, error) {
+	if err := f.job.finishJob(JobErrored); err != nil {
+		return nil, err
+	}
+	return f.job, nil
+}
+
 func (f *fakeService) reallocateJob(ctx context.Context, spec JobSpec, agentID string) (*Job, error) {
 	if err := f.job.reallocate(agentID); err != nil {
 		return nil, err
 	}
 	return f.job, nil
 }
+
+func (f *fakeService) recordAllocatorStatus(ctx context.Context, status *AllocatorStatus) error {
+	f.allocatorStatus = status
+	return nil
+}
+
+func (f *fakeService) markQueueSLABreaches(context.Context, time.Time) ([]*Job, error) {
+	f.checkedQueueSLAs = true
+	return f.breachedJobs, nil
+}
+
+func (f *fakeService) GetPendingJob(context.Context, string) (*PendingJob, error) {
+	if f.pendingJob == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.pendingJob, nil
+}
+
+type fakeReleasesService struct {
+	// versions that are neither installed nor available for download
+	unavailable []string
+	// versions that are installed
+	installed []string
+	// versions that have been prewarmed
+	prewarmed []string
+}
+
+func (f *fakeReleasesService) CheckVersion(ctx context.Context, version string) error {
+	if slices.Contains(f.unavailable, version) {
+		return releases.ErrVersionUnavailable
+	}
+	return nil
+}
+
+func (f *fakeReleasesService) IsInstalled(ctx context.Context, version string) (bool, error) {
+	return slices.Contains(f.installed, version), nil
+}
+
+func (f *fakeReleasesService) Prewarm(ctx context.Context, version string) {
+	f.prewarmed = append(f.prewarmed, version)
+}
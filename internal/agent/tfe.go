@@ -1,7 +1,10 @@
 package agent
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
@@ -16,6 +19,14 @@ type tfe struct {
 	*tfeapi.Responder
 }
 
+// poolUpdateImpact is the result of a dry run update of a pool.
+type poolUpdateImpact struct {
+	// ID of the pool
+	ID string `jsonapi:"primary,agent-pool-update-impacts"`
+	// Workspaces assigned to the pool that would lose access to the pool.
+	Workspaces []ImpactedWorkspace `jsonapi:"attribute" json:"workspaces"`
+}
+
 func (a *tfe) addHandlers(r *mux.Router) {
 	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
 
@@ -27,6 +38,8 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r.HandleFunc("/agent-pools/{pool_id}", a.getAgentPool).Methods("GET")
 	r.HandleFunc("/agent-pools/{pool_id}", a.updateAgentPool).Methods("PATCH")
 	r.HandleFunc("/agent-pools/{pool_id}", a.deleteAgentPool).Methods("DELETE")
+	// Recover a deleted agent pool (OTF extension)
+	r.HandleFunc("/agent-pools/{pool_id}/actions/undelete", a.undeleteAgentPool).Methods("POST")
 
 	// Agent Tokens API
 	//
@@ -35,6 +48,13 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r.HandleFunc("/agent-pools/{pool_id}/authentication-tokens", a.createAgentToken).Methods("POST")
 	r.HandleFunc("/authentication-tokens/{token_id}", a.getAgentToken).Methods("GET")
 	r.HandleFunc("/authentication-tokens/{token_id}", a.deleteAgentToken).Methods("DELETE")
+	r.HandleFunc("/organizations/{organization_name}/authentication-tokens/expiring", a.listExpiringAgentTokens).Methods("GET")
+
+	// Bootstrap tokens (OTF extension)
+	r.HandleFunc("/agent-pools/{pool_id}/bootstrap-tokens", a.listBootstrapTokens).Methods("GET")
+	r.HandleFunc("/agent-pools/{pool_id}/bootstrap-tokens", a.createBootstrapToken).Methods("POST")
+	r.HandleFunc("/bootstrap-tokens/{token_id}", a.deleteBootstrapToken).Methods("DELETE")
+	r.HandleFunc("/agents/{agent_id}/token", a.revokeAgentToken).Methods("DELETE")
 
 	// Feature sets API:
 	//
@@ -56,6 +76,27 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	})
 	// Tests don't check response so return empty response.
 	r.HandleFunc("/admin/organizations/{organization_name}/subscription", func(w http.ResponseWriter, r *http.Request) {})
+
+	// Agent pool autoscaling (OTF extension)
+	r.HandleFunc("/agent-pools/{pool_id}/autoscaler", a.getPoolAutoscaler).Methods("GET")
+	r.HandleFunc("/agent-pools/{pool_id}/autoscaler", a.setPoolAutoscaler).Methods("PUT")
+	r.HandleFunc("/agent-pools/{pool_id}/autoscaler", a.deletePoolAutoscaler).Methods("DELETE")
+	r.HandleFunc("/admin/agent-pools/{pool_id}/scaling-decisions", a.listScalingDecisions).Methods("GET")
+
+	// Allocator diagnostics (OTF extension)
+	r.HandleFunc("/admin/allocator/status", a.getAllocatorStatus).Methods("GET")
+
+	// Broker subscription stats (OTF extension)
+	r.HandleFunc("/admin/subscriptions", a.getSubscriptionStats).Methods("GET")
+
+	// Orphaned job reconciliation (OTF extension)
+	r.HandleFunc("/admin/orphaned-jobs/reconcile", a.reconcileOrphanedJobs).Methods("POST")
+
+	// Queue time SLA breaches (OTF extension)
+	r.HandleFunc("/organizations/{organization_name}/queue-sla-breaches", a.listQueueSLABreaches).Methods("GET")
+
+	// Job counts by status (OTF extension)
+	r.HandleFunc("/organizations/{organization_name}/job-counts", a.getJobCounts).Methods("GET")
 }
 
 // Agent pool handlers
@@ -78,9 +119,11 @@ func (a *tfe) createAgentPool(w http.ResponseWriter, r *http.Request) {
 
 	// convert tfe params to otf opts
 	opts := CreateAgentPoolOptions{
-		Name:               *params.Name,
-		Organization:       organization,
-		OrganizationScoped: params.OrganizationScoped,
+		Name:                     *params.Name,
+		Organization:             organization,
+		OrganizationScoped:       params.OrganizationScoped,
+		AllowedTerraformVersions: params.AllowedTerraformVersions,
+		MinAgents:                params.MinAgents,
 	}
 	opts.AllowedWorkspaces = make([]string, len(params.AllowedWorkspaces))
 	for i, aw := range params.AllowedWorkspaces {
@@ -88,7 +131,19 @@ func (a *tfe) createAgentPool(w http.ResponseWriter, r *http.Request) {
 	}
 
 	pool, err := a.service.CreateAgentPool(r.Context(), opts)
-	if err != nil {
+	if errors.Is(err, ErrPoolQuotaExceeded) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+		})
+		return
+	} else if errors.Is(err, ErrInvalidMinAgents) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
@@ -109,8 +164,10 @@ func (a *tfe) updateAgentPool(w http.ResponseWriter, r *http.Request) {
 
 	// convert tfe params to otf opts
 	opts := updatePoolOptions{
-		Name:               params.Name,
-		OrganizationScoped: params.OrganizationScoped,
+		Name:                     params.Name,
+		OrganizationScoped:       params.OrganizationScoped,
+		AllowedTerraformVersions: params.AllowedTerraformVersions,
+		MinAgents:                params.MinAgents,
 	}
 	if params.AllowedWorkspaces != nil {
 		opts.AllowedWorkspaces = make([]string, len(params.AllowedWorkspaces))
@@ -119,11 +176,41 @@ func (a *tfe) updateAgentPool(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	pool, err := a.service.updateAgentPool(r.Context(), poolID, opts)
-	if err != nil {
+	// non-TFE extensions: dry run the update to preview the workspaces that
+	// would lose access to the pool, and acknowledge those workspaces when
+	// applying the update.
+	var ext struct {
+		DryRun                 bool     `schema:"dry_run"`
+		AcknowledgedWorkspaces []string `schema:"acknowledged_workspaces"`
+	}
+	if err := decode.Query(&ext, r.URL.Query()); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	opts.DryRun = ext.DryRun
+	opts.AcknowledgedWorkspaces = ext.AcknowledgedWorkspaces
+
+	pool, impacted, err := a.service.updateAgentPool(r.Context(), poolID, opts)
+	if errors.Is(err, ErrPoolAssignedWorkspacesNotAllowed) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+		})
+		return
+	} else if errors.Is(err, ErrInvalidMinAgents) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
+	if opts.DryRun {
+		a.Respond(w, r, &poolUpdateImpact{ID: pool.ID, Workspaces: impacted}, http.StatusOK)
+		return
+	}
 	a.Respond(w, r, a.toPool(pool), http.StatusOK)
 }
 
@@ -187,8 +274,20 @@ func (a *tfe) deleteAgentPool(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = a.service.deleteAgentPool(r.Context(), poolID)
-	if err != nil {
+	var opts deletePoolOptions
+	if err := decode.Query(&opts, r.URL.Query()); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	_, _, err = a.service.deleteAgentPool(r.Context(), poolID, opts)
+	if errors.Is(err, ErrCannotDeletePoolWithQueuedJobs) || errors.Is(err, ErrInvalidQueuedJobsMigrationPool) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
@@ -196,6 +295,28 @@ func (a *tfe) deleteAgentPool(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (a *tfe) undeleteAgentPool(w http.ResponseWriter, r *http.Request) {
+	poolID, err := decode.Param("pool_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	pool, err := a.service.UndeletePool(r.Context(), poolID)
+	if errors.Is(err, ErrPoolNotDeleted) || errors.Is(err, ErrPoolRecoveryWindowElapsed) || errors.Is(err, ErrCannotRecoverPoolNameTaken) || errors.Is(err, ErrPoolQuotaExceeded) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toPool(pool), http.StatusOK)
+}
+
 func (a *tfe) toPool(from *Pool) *types.AgentPool {
 	to := &types.AgentPool{
 		ID:   from.ID,
@@ -203,7 +324,9 @@ func (a *tfe) toPool(from *Pool) *types.AgentPool {
 		Organization: &types.Organization{
 			Name: from.Organization,
 		},
-		OrganizationScoped: from.OrganizationScoped,
+		OrganizationScoped:       from.OrganizationScoped,
+		AllowedTerraformVersions: from.AllowedTerraformVersions,
+		MinAgents:                from.MinAgents,
 	}
 	to.Workspaces = make([]*types.Workspace, len(from.AssignedWorkspaces))
 	for i, workspaceID := range from.AssignedWorkspaces {
@@ -231,6 +354,7 @@ func (a *tfe) createAgentToken(w http.ResponseWriter, r *http.Request) {
 
 	at, token, err := a.service.CreateAgentToken(r.Context(), poolID, CreateAgentTokenOptions{
 		Description: params.Description,
+		ExpiresAt:   params.ExpiresAt,
 	})
 	if err != nil {
 		tfeapi.Error(w, err)
@@ -289,6 +413,59 @@ func (a *tfe) listAgentTokens(w http.ResponseWriter, r *http.Request) {
 	a.RespondWithPage(w, r, items, page.Pagination)
 }
 
+func (a *tfe) listExpiringAgentTokens(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params struct {
+		types.ListOptions
+		// Within is the period, as a duration such as 168h, within which
+		// tokens expire. Defaults to a week.
+		Within string `schema:"within"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	within := defaultExpiringAgentTokensWithin
+	if params.Within != "" {
+		within, err = time.ParseDuration(params.Within)
+		if err != nil || within < 0 {
+			tfeapi.Error(w, &internal.HTTPError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: fmt.Sprintf("invalid duration for within: %q", params.Within),
+			})
+			return
+		}
+	}
+
+	tokens, err := a.service.ListExpiringAgentTokens(r.Context(), organization, within)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	page := resource.NewPage(tokens, resource.PageOptions(params.ListOptions), nil)
+
+	now := internal.CurrentTimestamp(nil)
+	items := make([]*types.ExpiringAgentToken, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = &types.ExpiringAgentToken{
+			ID:            from.ID,
+			Description:   from.Description,
+			AgentPoolID:   from.AgentPoolID,
+			AgentPoolName: from.AgentPoolName,
+			CreatedAt:     from.CreatedAt,
+			ExpiresAt:     *from.ExpiresAt,
+			LastUsedAt:    from.LastUsedAt,
+			Expired:       !from.ExpiresAt.After(now),
+		}
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
 func (a *tfe) deleteAgentToken(w http.ResponseWriter, r *http.Request) {
 	tokenID, err := decode.Param("token_id", r)
 	if err != nil {
@@ -310,9 +487,425 @@ func (a *tfe) toAgentToken(from *agentToken, token []byte) *types.AgentToken {
 		ID:          from.ID,
 		CreatedAt:   from.CreatedAt,
 		Description: from.Description,
+		ExpiresAt:   from.ExpiresAt,
+	}
+	if from.LastUsedAt != nil {
+		to.LastUsedAt = *from.LastUsedAt
+	}
+	if token != nil {
+		to.Token = string(token)
+	}
+	return to
+}
+
+// Bootstrap token handlers
+
+func (a *tfe) createBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	poolID, err := decode.Param("pool_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.AgentBootstrapTokenCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	bt, token, err := a.service.CreateBootstrapToken(r.Context(), poolID, CreateBootstrapTokenOptions{
+		Description: params.Description,
+		ExpiresAt:   params.ExpiresAt,
+		MaxUses:     params.MaxUses,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toBootstrapToken(bt, token), http.StatusCreated)
+}
+
+func (a *tfe) listBootstrapTokens(w http.ResponseWriter, r *http.Request) {
+	poolID, err := decode.Param("pool_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ListOptions
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	tokens, err := a.service.ListBootstrapTokens(r.Context(), poolID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	page := resource.NewPage(tokens, resource.PageOptions(params), nil)
+
+	items := make([]*types.AgentBootstrapToken, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = a.toBootstrapToken(from, nil)
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
+func (a *tfe) deleteBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	tokenID, err := decode.Param("token_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if _, err := a.service.DeleteBootstrapToken(r.Context(), tokenID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// revokeAgentToken revokes the token issued to an agent that registered using
+// a bootstrap token.
+func (a *tfe) revokeAgentToken(w http.ResponseWriter, r *http.Request) {
+	agentID, err := decode.Param("agent_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if _, err := a.service.RevokeAgentToken(r.Context(), agentID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) toBootstrapToken(from *bootstrapToken, token []byte) *types.AgentBootstrapToken {
+	to := &types.AgentBootstrapToken{
+		ID:          from.ID,
+		CreatedAt:   from.CreatedAt,
+		Description: from.Description,
+		ExpiresAt:   from.ExpiresAt,
+		MaxUses:     from.MaxUses,
+		Uses:        from.Uses,
 	}
 	if token != nil {
 		to.Token = string(token)
 	}
 	return to
 }
+
+// Autoscaler handlers
+
+func (a *tfe) setPoolAutoscaler(w http.ResponseWriter, r *http.Request) {
+	poolID, err := decode.Param("pool_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.AgentPoolAutoscalerOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if params.WebhookURL == nil {
+		tfeapi.Error(w, &internal.MissingParameterError{Parameter: "webhook-url"})
+		return
+	}
+
+	// convert tfe params to otf opts
+	opts := SetPoolAutoscalerOptions{
+		WebhookURL:       *params.WebhookURL,
+		ScaleUpThreshold: params.ScaleUpThreshold,
+	}
+	if params.HMACSecret != nil {
+		opts.HMACSecret = *params.HMACSecret
+	}
+	if params.ScaleDownIdleSeconds != nil {
+		idle := time.Duration(*params.ScaleDownIdleSeconds) * time.Second
+		opts.ScaleDownIdle = &idle
+	}
+	if params.CooldownSeconds != nil {
+		cooldown := time.Duration(*params.CooldownSeconds) * time.Second
+		opts.Cooldown = &cooldown
+	}
+	if params.DryRun != nil {
+		opts.DryRun = *params.DryRun
+	}
+
+	as, err := a.service.SetPoolAutoscaler(r.Context(), poolID, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidScalingWebhookURL),
+			errors.Is(err, ErrScalingSecretRequired),
+			errors.Is(err, ErrInvalidScaleUpThreshold),
+			errors.Is(err, ErrInvalidScalingDuration):
+			err = &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()}
+		}
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toPoolAutoscaler(as), http.StatusOK)
+}
+
+func (a *tfe) getPoolAutoscaler(w http.ResponseWriter, r *http.Request) {
+	poolID, err := decode.Param("pool_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	as, err := a.service.GetPoolAutoscaler(r.Context(), poolID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toPoolAutoscaler(as), http.StatusOK)
+}
+
+func (a *tfe) deletePoolAutoscaler(w http.ResponseWriter, r *http.Request) {
+	poolID, err := decode.Param("pool_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.service.DeletePoolAutoscaler(r.Context(), poolID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) listScalingDecisions(w http.ResponseWriter, r *http.Request) {
+	poolID, err := decode.Param("pool_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ListOptions
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	decisions, err := a.service.ListScalingDecisions(r.Context(), poolID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	page := resource.NewPage(decisions, resource.PageOptions(params), nil)
+
+	items := make([]*types.AgentPoolScalingDecision, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = &types.AgentPoolScalingDecision{
+			ID:              from.ID,
+			AgentPoolID:     from.AgentPoolID,
+			CurrentAgents:   from.CurrentAgents,
+			UnallocatedJobs: from.UnallocatedJobs,
+			IdleAgents:      from.IdleAgents,
+			DesiredDelta:    from.DesiredDelta,
+			DryRun:          from.DryRun,
+			Error:           from.Error,
+			DecidedAt:       from.DecidedAt,
+		}
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
+func (a *tfe) toPoolAutoscaler(from *PoolAutoscaler) *types.AgentPoolAutoscaler {
+	return &types.AgentPoolAutoscaler{
+		ID:                   from.AgentPoolID,
+		WebhookURL:           from.WebhookURL,
+		ScaleUpThreshold:     from.ScaleUpThreshold,
+		ScaleDownIdleSeconds: int(from.ScaleDownIdle.Seconds()),
+		CooldownSeconds:      int(from.Cooldown.Seconds()),
+		DryRun:               from.DryRun,
+		CreatedAt:            from.CreatedAt,
+		UpdatedAt:            from.UpdatedAt,
+	}
+}
+
+// Allocator handlers
+
+func (a *tfe) getAllocatorStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := a.service.GetAllocatorStatus(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toAllocatorStatus(status), http.StatusOK)
+}
+
+func (a *tfe) getSubscriptionStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := a.service.GetSubscriptionStats(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	now := internal.CurrentTimestamp(nil)
+	items := make([]*types.SubscriptionStats, len(stats))
+	for i, from := range stats {
+		items[i] = &types.SubscriptionStats{
+			ID:                 from.Table,
+			Subscribers:        from.Subscribers,
+			OldestSubscribedAt: from.OldestSubscribedAt,
+		}
+		if from.OldestSubscribedAt != nil {
+			items[i].OldestSubscriptionAgeSeconds = int(now.Sub(*from.OldestSubscribedAt).Seconds())
+		}
+	}
+	a.Respond(w, r, items, http.StatusOK)
+}
+
+func (a *tfe) toAllocatorStatus(from *AllocatorStatus) *types.AllocatorStatus {
+	to := &types.AllocatorStatus{
+		ID:              "allocator",
+		LastAllocatedAt: from.LastAllocatedAt,
+		Paused:          from.Paused,
+		PendingJobs:     make([]types.AllocatorPendingJob, len(from.PendingJobs)),
+		Pools:           make([]types.AllocatorPool, len(from.Pools)),
+	}
+	for i, job := range from.PendingJobs {
+		to.PendingJobs[i] = types.AllocatorPendingJob{
+			RunID:        job.Spec.RunID,
+			Phase:        string(job.Spec.Phase),
+			Status:       string(job.Status),
+			AgentPoolID:  job.AgentPoolID,
+			Organization: job.Organization,
+			WorkspaceID:  job.WorkspaceID,
+			Reason:       string(job.Reason),
+			Explanation:  job.Explain(),
+		}
+	}
+	for i, pool := range from.Pools {
+		to.Pools[i] = types.AllocatorPool{
+			AgentPoolID:     pool.AgentPoolID,
+			Name:            pool.Name,
+			Agents:          pool.Agents,
+			CandidateAgents: pool.Candidates,
+		}
+	}
+	return to
+}
+
+func (a *tfe) reconcileOrphanedJobs(w http.ResponseWriter, r *http.Request) {
+	deleted, err := a.service.ReconcileOrphanedJobs(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.OrphanedJob, len(deleted))
+	for i, from := range deleted {
+		items[i] = &types.OrphanedJob{
+			ID:      from.Spec.String(),
+			RunID:   from.Spec.RunID,
+			Phase:   string(from.Spec.Phase),
+			Status:  string(from.Status),
+			AgentID: from.AgentID,
+		}
+	}
+	a.Respond(w, r, items, http.StatusOK)
+}
+
+func (a *tfe) listQueueSLABreaches(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ListOptions
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	jobs, err := a.service.ListQueueSLABreaches(r.Context(), organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	page := resource.NewPage(jobs, resource.PageOptions(params), nil)
+
+	items := make([]*types.QueueSLABreach, len(page.Items))
+	for i, from := range page.Items {
+		items[i] = a.toQueueSLABreach(from)
+	}
+	a.RespondWithPage(w, r, items, page.Pagination)
+}
+
+func (a *tfe) toQueueSLABreach(from *Job) *types.QueueSLABreach {
+	to := &types.QueueSLABreach{
+		ID:          from.Spec.String(),
+		RunID:       from.Spec.RunID,
+		Phase:       string(from.Spec.Phase),
+		Status:      string(from.Status),
+		WorkspaceID: from.WorkspaceID,
+		AgentPoolID: from.AgentPoolID,
+		CreatedAt:   from.CreatedAt,
+		AllocatedAt: from.AllocatedAt,
+	}
+	if from.SLABreachedAt != nil {
+		to.BreachedAt = *from.SLABreachedAt
+	}
+	// report the queue time as of now, unless the job has since been
+	// allocated.
+	to.QueueTimeSeconds = int(from.QueueTime(internal.CurrentTimestamp(nil)).Seconds())
+	return to
+}
+
+func (a *tfe) getJobCounts(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string   `schema:"organization_name,required"`
+		ByPool       bool     `schema:"by_pool"`
+		Labels       []string `schema:"filter[label]"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	opts := JobCountsOptions{ByPool: params.ByPool}
+	if len(params.Labels) > 0 {
+		labels, err := parseLabelFilters(params.Labels)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		opts.Labels = labels
+	}
+
+	counts, err := a.service.GetJobCounts(r.Context(), params.Organization, opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	to := &types.JobCounts{
+		ID:     counts.Organization,
+		Counts: toStatusCounts(counts.Counts),
+	}
+	if counts.Pools != nil {
+		to.Pools = make([]types.JobCountsPool, len(counts.Pools))
+		for i, pool := range counts.Pools {
+			to.Pools[i] = types.JobCountsPool{
+				AgentPoolID: pool.AgentPoolID,
+				Counts:      toStatusCounts(pool.Counts),
+			}
+		}
+	}
+	a.Respond(w, r, to, http.StatusOK)
+}
+
+func toStatusCounts(from map[JobStatus]int) map[string]int {
+	to := make(map[string]int, len(from))
+	for status, count := range from {
+		to[string(status)] = count
+	}
+	return to
+}
@@ -10,10 +10,17 @@ import (
 )
 
 const (
-	AgentTokenKind tokens.Kind = "agent_token"
-	JobTokenKind   tokens.Kind = "job_token"
+	AgentTokenKind          tokens.Kind = "agent_token"
+	JobTokenKind            tokens.Kind = "job_token"
+	AgentBootstrapTokenKind tokens.Kind = "agent_bootstrap_token"
+	// PerAgentTokenKind is the kind of token issued to an individual agent
+	// upon registering with a bootstrap token.
+	PerAgentTokenKind tokens.Kind = "per_agent_token"
 
 	defaultJobTokenExpiry = 60 * time.Minute
+	// defaultExpiringAgentTokensWithin is the default period within which
+	// agent tokens are considered to be nearing expiry.
+	defaultExpiringAgentTokensWithin = 7 * 24 * time.Hour
 )
 
 type (
@@ -24,10 +31,26 @@ type (
 		CreatedAt   time.Time
 		AgentPoolID string `jsonapi:"attribute" json:"agent_pool_id"`
 		Description string `jsonapi:"attribute" json:"description"`
+		// ExpiresAt is the time at which the token expires. Nil if the token
+		// never expires.
+		ExpiresAt *time.Time `jsonapi:"attribute" json:"expires_at"`
+		// LastUsedAt is the time at which the token was last used to
+		// authenticate. Nil if the token has never been used.
+		LastUsedAt *time.Time `jsonapi:"attribute" json:"last_used_at"`
+	}
+
+	// expiringAgentToken is an agent token that is nearing expiry, along with
+	// the name of its pool.
+	expiringAgentToken struct {
+		*agentToken
+
+		AgentPoolName string
 	}
 
 	CreateAgentTokenOptions struct {
 		Description string `json:"description" schema:"description,required"`
+		// ExpiresAt optionally sets the time at which the token expires.
+		ExpiresAt *time.Time `json:"expires_at,omitempty" schema:"-"`
 	}
 )
 
@@ -37,6 +60,9 @@ func (a *agentToken) LogValue() slog.Value {
 		slog.String("agent_pool_id", string(a.AgentPoolID)),
 		slog.String("description", a.Description),
 	}
+	if a.ExpiresAt != nil {
+		attrs = append(attrs, slog.Time("expires_at", *a.ExpiresAt))
+	}
 	return slog.GroupValue(attrs...)
 }
 
@@ -69,12 +95,20 @@ func (f *tokenFactory) NewAgentToken(poolID string, opts CreateAgentTokenOptions
 		Description: opts.Description,
 		AgentPoolID: poolID,
 	}
+	if opts.ExpiresAt != nil {
+		expiry := opts.ExpiresAt.UTC()
+		if !expiry.After(at.CreatedAt) {
+			return nil, nil, fmt.Errorf("expiry must be in the future")
+		}
+		at.ExpiresAt = &expiry
+	}
 	token, err := f.tokens.NewToken(tokens.NewTokenOptions{
 		Subject: at.ID,
 		Kind:    AgentTokenKind,
 		Claims: map[string]string{
 			"agent_pool_id": poolID,
 		},
+		Expiry: at.ExpiresAt,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,91 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const traceparentKey = "traceparent"
+
+// jobTracer emits a span for each step in the lifecycle of a job: its
+// creation, allocation to an agent, and its start and finish. The trace
+// context of the span in which a job is created is persisted with the job, and
+// the spans of the subsequent steps are made children of that span, so that
+// the lifecycle of a job forms a single trace even though its steps are
+// carried out by different requests, subsystems, and even different otfd
+// instances.
+//
+// If tracing is disabled then the tracer is a no-op, and no trace context is
+// persisted.
+type jobTracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TraceContext
+}
+
+func newJobTracer(tp trace.TracerProvider) *jobTracer {
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+	return &jobTracer{tracer: tp.Tracer("github.com/tofutf/tofutf/internal/agent")}
+}
+
+// startCreate starts the span for the creation of a job, and sets the job's
+// trace context to that of the span.
+func (t *jobTracer) startCreate(ctx context.Context, job *Job) (context.Context, trace.Span) {
+	ctx, span := t.tracer.Start(ctx, "job.create", trace.WithAttributes(jobAttributes(job.Spec)...))
+	if span.SpanContext().IsValid() {
+		carrier := propagation.MapCarrier{}
+		t.propagator.Inject(ctx, carrier)
+		job.TraceContext = carrier.Get(traceparentKey)
+	}
+	return ctx, span
+}
+
+// record records a span for a step in the lifecycle of a job that started at
+// the given time and has just finished, with the given error if the step
+// failed. The job is nil if the step failed before the job was retrieved.
+//
+// The span is made a child of the span in which the job was created, and is
+// linked to the span of the caller, if any.
+func (t *jobTracer) record(ctx context.Context, name string, spec JobSpec, job *Job, start time.Time, err error, attrs ...attribute.KeyValue) {
+	opts := []trace.SpanStartOption{
+		trace.WithTimestamp(start),
+		trace.WithAttributes(jobAttributes(spec)...),
+		trace.WithAttributes(attrs...),
+	}
+	if caller := trace.SpanContextFromContext(ctx); caller.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: caller}))
+	}
+	if job != nil {
+		opts = append(opts, trace.WithAttributes(attribute.String("job.status", string(job.Status))))
+		if job.TraceContext != "" {
+			carrier := propagation.MapCarrier{traceparentKey: job.TraceContext}
+			ctx = t.propagator.Extract(ctx, carrier)
+		}
+	}
+	_, span := t.tracer.Start(ctx, name, opts...)
+	endSpan(span, err)
+}
+
+// endSpan ends the span, recording the error if non-nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func jobAttributes(spec JobSpec) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("job.spec", spec.String()),
+		attribute.String("run.id", spec.RunID),
+		attribute.String("run.phase", string(spec.Phase)),
+	}
+}
@@ -0,0 +1,70 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestJobTracer(t *testing.T) {
+	ctx := context.Background()
+	spec := JobSpec{RunID: "run-123", Phase: internal.PlanPhase}
+
+	t.Run("lifecycle forms a single trace", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		tracer := newJobTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+		job := &Job{Spec: spec, Status: JobUnallocated}
+		_, span := tracer.startCreate(ctx, job)
+		endSpan(span, nil)
+		require.NotEmpty(t, job.TraceContext)
+
+		// the job is allocated by another subsystem, with its own span
+		callerCtx, caller := sdktrace.NewTracerProvider().Tracer("test").Start(ctx, "allocator")
+		job.Status = JobAllocated
+		tracer.record(callerCtx, "job.allocate", spec, job, time.Now(), nil)
+		caller.End()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		create, allocate := spans[0], spans[1]
+		assert.Equal(t, "job.create", create.Name())
+		assert.Equal(t, "job.allocate", allocate.Name())
+		// allocation is a child of creation, and linked to the caller
+		assert.Equal(t, create.SpanContext().TraceID(), allocate.SpanContext().TraceID())
+		assert.Equal(t, create.SpanContext().SpanID(), allocate.Parent().SpanID())
+		require.Len(t, allocate.Links(), 1)
+		assert.Equal(t, caller.SpanContext().SpanID(), allocate.Links()[0].SpanContext.SpanID())
+		assert.Contains(t, allocate.Attributes(), jobAttributes(spec)[1])
+	})
+
+	t.Run("record error", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		tracer := newJobTracer(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+		tracer.record(ctx, "job.start", spec, nil, time.Now(), errors.New("access denied"))
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.False(t, spans[0].Parent().IsValid())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		tracer := newJobTracer(nil)
+
+		job := &Job{Spec: spec}
+		_, span := tracer.startCreate(ctx, job)
+		endSpan(span, nil)
+
+		assert.Empty(t, job.TraceContext)
+	})
+}
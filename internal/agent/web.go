@@ -3,10 +3,14 @@ package agent
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"math"
 	"net/http"
 	"slices"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
@@ -27,15 +31,21 @@ type webHandlers struct {
 	svc        webClient
 	workspaces *workspacepkg.Service
 	logger     *slog.Logger
+	// poolRecoveryWindow is the duration for which a deleted pool can be
+	// recovered.
+	poolRecoveryWindow time.Duration
 }
 
 // webClient gives web handlers access to the agents service endpoints
 type webClient interface {
 	CreateAgentPool(ctx context.Context, opts CreateAgentPoolOptions) (*Pool, error)
 	GetAgentPool(ctx context.Context, poolID string) (*Pool, error)
-	updateAgentPool(ctx context.Context, poolID string, opts updatePoolOptions) (*Pool, error)
+	getAgentPools(ctx context.Context, poolIDs []string) (map[string]*Pool, []string, error)
+	updateAgentPool(ctx context.Context, poolID string, opts updatePoolOptions) (*Pool, []ImpactedWorkspace, error)
 	listAgentPoolsByOrganization(ctx context.Context, organization string, opts listPoolOptions) ([]*Pool, error)
-	deleteAgentPool(ctx context.Context, poolID string) (*Pool, error)
+	deleteAgentPool(ctx context.Context, poolID string, opts deletePoolOptions) (*Pool, int, error)
+	listDeletedAgentPools(ctx context.Context, organization string) ([]*Pool, error)
+	UndeletePool(ctx context.Context, poolID string) (*Pool, error)
 
 	registerAgent(ctx context.Context, opts registerAgentOptions) (*Agent, error)
 	listAgents(ctx context.Context) ([]*Agent, error)
@@ -43,10 +53,22 @@ type webClient interface {
 	listAgentsByPool(ctx context.Context, poolID string) ([]*Agent, error)
 	listServerAgents(ctx context.Context) ([]*Agent, error)
 
+	GetPendingJob(ctx context.Context, runID string) (*PendingJob, error)
+
 	CreateAgentToken(ctx context.Context, poolID string, opts CreateAgentTokenOptions) (*agentToken, []byte, error)
 	GetAgentToken(ctx context.Context, tokenID string) (*agentToken, error)
 	ListAgentTokens(ctx context.Context, poolID string) ([]*agentToken, error)
 	DeleteAgentToken(ctx context.Context, tokenID string) (*agentToken, error)
+
+	CreateBootstrapToken(ctx context.Context, poolID string, opts CreateBootstrapTokenOptions) (*bootstrapToken, []byte, error)
+	ListBootstrapTokens(ctx context.Context, poolID string) ([]*bootstrapToken, error)
+	DeleteBootstrapToken(ctx context.Context, tokenID string) (*bootstrapToken, error)
+	RevokeAgentToken(ctx context.Context, agentID string) (*Agent, error)
+	listAgentCredentials(ctx context.Context, organization string) ([]string, error)
+
+	RequestAgentDiagnostics(ctx context.Context, agentID string) (*DiagnosticsBundle, error)
+	GetAgentDiagnosticsBundle(ctx context.Context, bundleID string) (*DiagnosticsBundle, error)
+	listDiagnosticsBundles(ctx context.Context, organization string) ([]*DiagnosticsBundle, error)
 }
 
 type (
@@ -59,6 +81,29 @@ type (
 	}
 
 	poolWorkspaceList []poolWorkspace
+
+	// deletedPool is a deleted pool that can be recovered until the given
+	// time.
+	deletedPool struct {
+		*Pool
+		Until time.Time
+	}
+
+	// agentItem is an agent listed in the UI, along with whether its own
+	// token can be revoked, which is only so for agents that registered using
+	// a bootstrap token, and whether diagnostics can be collected from it,
+	// which is only so for pool agents.
+	agentItem struct {
+		*Agent
+		CanRevokeToken        bool
+		CanCollectDiagnostics bool
+		// DiagnosticsBundles are the agent's diagnostics bundles, most recent
+		// first.
+		DiagnosticsBundles []*DiagnosticsBundle
+		// Pool is the pool to which the agent belongs; it is only populated
+		// when listing agents from more than one pool.
+		Pool *Pool
+	}
 )
 
 // UnmarshalText is used by gorilla/schema to unmarshal a list of workspaces
@@ -83,11 +128,22 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/agent-pools/{pool_id}", h.getAgentPool).Methods("GET")
 	r.HandleFunc("/agent-pools/{pool_id}/update", h.updateAgentPool).Methods("POST")
 	r.HandleFunc("/agent-pools/{pool_id}/delete", h.deleteAgentPool).Methods("POST")
+	r.HandleFunc("/agent-pools/{pool_id}/undelete", h.undeleteAgentPool).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/pools", h.listAllowedPools).Methods("GET")
 
 	// agent tokens
 	r.HandleFunc("/agent-pools/{pool_id}/agent-tokens/create", h.createAgentToken).Methods("POST")
 	r.HandleFunc("/agent-tokens/{token_id}/delete", h.deleteAgentToken).Methods("POST")
+
+	// bootstrap tokens
+	r.HandleFunc("/agent-pools/{pool_id}/agent-bootstrap-tokens/create", h.createBootstrapToken).Methods("POST")
+	r.HandleFunc("/agent-bootstrap-tokens/{token_id}/delete", h.deleteBootstrapToken).Methods("POST")
+	r.HandleFunc("/agents/{agent_id}/revoke-token", h.revokeAgentToken).Methods("POST")
+	r.HandleFunc("/agents/{agent_id}/collect-diagnostics", h.collectDiagnostics).Methods("POST")
+	r.HandleFunc("/agent-diagnostics-bundles/{bundle_id}/download", h.downloadDiagnosticsBundle).Methods("GET")
+
+	// allocation of a queued run's job
+	r.HandleFunc("/runs/{run_id}/allocation", h.getRunAllocation).Methods("GET")
 }
 
 // agent handlers
@@ -119,15 +175,78 @@ func (h *webHandlers) listAgents(w http.ResponseWriter, r *http.Request) {
 		}
 	})
 
+	items, err := h.newAgentItems(r, org, agents)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// retrieve the pools of the pool agents in one go rather than one at a
+	// time.
+	var poolIDs []string
+	for _, agent := range poolAgents {
+		if !slices.Contains(poolIDs, *agent.AgentPoolID) {
+			poolIDs = append(poolIDs, *agent.AgentPoolID)
+		}
+	}
+	if len(poolIDs) > 0 {
+		pools, _, err := h.svc.getAgentPools(r.Context(), poolIDs)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for i, item := range items {
+			if !item.IsServer() {
+				items[i].Pool = pools[*item.AgentPoolID]
+			}
+		}
+	}
+
 	h.Render("agents_list.tmpl", w, struct {
 		organization.OrganizationPage
-		Agents []*Agent
+		Agents []agentItem
 	}{
 		OrganizationPage: organization.NewPage(r, "agents", org),
-		Agents:           agents,
+		Agents:           items,
 	})
 }
 
+// newAgentItems constructs the items for listing agents in an organization.
+func (h *webHandlers) newAgentItems(r *http.Request, organization string, agents []*Agent) ([]agentItem, error) {
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	var revocable []string
+	if subject.CanAccessOrganization(rbac.DeleteAgentTokenAction, organization) {
+		revocable, err = h.svc.listAgentCredentials(r.Context(), organization)
+		if err != nil {
+			return nil, err
+		}
+	}
+	canDiagnose := subject.CanAccessOrganization(rbac.RequestAgentDiagnosticsAction, organization)
+	bundles := make(map[string][]*DiagnosticsBundle)
+	if subject.CanAccessOrganization(rbac.GetAgentDiagnosticsAction, organization) {
+		all, err := h.svc.listDiagnosticsBundles(r.Context(), organization)
+		if err != nil {
+			return nil, err
+		}
+		for _, bundle := range all {
+			bundles[bundle.AgentID] = append(bundles[bundle.AgentID], bundle)
+		}
+	}
+	items := make([]agentItem, len(agents))
+	for i, agent := range agents {
+		items[i] = agentItem{
+			Agent:                 agent,
+			CanRevokeToken:        slices.Contains(revocable, agent.ID),
+			CanCollectDiagnostics: canDiagnose && !agent.IsServer(),
+			DiagnosticsBundles:    bundles[agent.ID],
+		}
+	}
+	return items, nil
+}
+
 // agent pool handlers
 
 func (h *webHandlers) createAgentPool(w http.ResponseWriter, r *http.Request) {
@@ -138,7 +257,11 @@ func (h *webHandlers) createAgentPool(w http.ResponseWriter, r *http.Request) {
 	}
 
 	pool, err := h.svc.CreateAgentPool(r.Context(), opts)
-	if err != nil {
+	if errors.Is(err, ErrPoolQuotaExceeded) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.AgentPools(opts.Organization), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -160,30 +283,63 @@ func (h *webHandlers) updateAgentPool(w http.ResponseWriter, r *http.Request) {
 	// and allowed-and-assigned, whereas updatePoolOptions handles them both as
 	// a single slice of allowed workspaces.
 	var params struct {
-		Name                 string
-		OrganizationScoped   bool              `schema:"organization_scoped"`
-		AllowedButUnassigned poolWorkspaceList `schema:"allowed_workspaces"`
-		AllowedAndAssigned   poolWorkspaceList `schema:"assigned_workspaces"`
+		Name                     string
+		OrganizationScoped       bool              `schema:"organization_scoped"`
+		AllowedTerraformVersions string            `schema:"allowed_terraform_versions"`
+		MinAgents                int               `schema:"min_agents"`
+		AllowedButUnassigned     poolWorkspaceList `schema:"allowed_workspaces"`
+		AllowedAndAssigned       poolWorkspaceList `schema:"assigned_workspaces"`
+		// preview the update rather than apply it
+		DryRun                 bool     `schema:"dry_run"`
+		AcknowledgedWorkspaces []string `schema:"acknowledged_workspaces"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
 	opts := updatePoolOptions{
-		Name:               &params.Name,
-		OrganizationScoped: &params.OrganizationScoped,
-		AllowedWorkspaces:  make([]string, len(params.AllowedButUnassigned)+len(params.AllowedAndAssigned)),
+		Name:                     &params.Name,
+		OrganizationScoped:       &params.OrganizationScoped,
+		AllowedTerraformVersions: &params.AllowedTerraformVersions,
+		MinAgents:                &params.MinAgents,
+		AllowedWorkspaces:        make([]string, len(params.AllowedButUnassigned)+len(params.AllowedAndAssigned)),
+		DryRun:                   params.DryRun,
+		AcknowledgedWorkspaces:   params.AcknowledgedWorkspaces,
 	}
 	for i, allowed := range append(params.AllowedButUnassigned, params.AllowedAndAssigned...) {
 		opts.AllowedWorkspaces[i] = allowed.ID
 	}
 
-	pool, err := h.svc.updateAgentPool(r.Context(), poolID, opts)
-	if err != nil {
+	pool, impacted, err := h.svc.updateAgentPool(r.Context(), poolID, opts)
+	if errors.Is(err, ErrPoolAssignedWorkspacesNotAllowed) || errors.Is(err, internal.ErrInvalidTerraformVersion) || errors.Is(err, ErrInvalidMinAgents) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.AgentPool(poolID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	if params.DryRun {
+		// render the proposed changes along with the workspaces that would
+		// lose access, together with a form to re-submit the changes with the
+		// impacted workspaces acknowledged.
+		h.Render("agent_pool_update_preview.tmpl", w, struct {
+			organization.OrganizationPage
+			Pool                 *Pool
+			Impacted             []ImpactedWorkspace
+			AllowedButUnassigned poolWorkspaceList
+			AllowedAndAssigned   poolWorkspaceList
+		}{
+			OrganizationPage:     organization.NewPage(r, pool.Name, pool.Organization),
+			Pool:                 pool,
+			Impacted:             impacted,
+			AllowedButUnassigned: params.AllowedButUnassigned,
+			AllowedAndAssigned:   params.AllowedAndAssigned,
+		})
+		return
+	}
+
 	html.FlashSuccess(w, "updated agent pool: "+pool.Name)
 	http.Redirect(w, r, paths.AgentPool(pool.ID), http.StatusFound)
 }
@@ -200,17 +356,28 @@ func (h *webHandlers) listAgentPools(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	deleted, err := h.svc.listDeletedAgentPools(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	deletedPools := make([]deletedPool, len(deleted))
+	for i, pool := range deleted {
+		deletedPools[i] = deletedPool{Pool: pool, Until: pool.RecoverableUntil(h.poolRecoveryWindow)}
+	}
 
 	h.Render("agent_pools_list.tmpl", w, struct {
 		organization.OrganizationPage
 		// list template expects pagination object but we don't paginate token
 		// listing
 		*resource.Pagination
-		Items []*Pool
+		Items        []*Pool
+		DeletedPools []deletedPool
 	}{
 		OrganizationPage: organization.NewPage(r, "agent pools", org),
 		Pagination:       &resource.Pagination{},
 		Items:            pools,
+		DeletedPools:     deletedPools,
 	})
 }
 
@@ -276,11 +443,31 @@ func (h *webHandlers) getAgentPool(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	bootstrapTokens, err := h.svc.ListBootstrapTokens(r.Context(), poolID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	agents, err := h.svc.listAgentsByPool(r.Context(), poolID)
 	if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	agentItems, err := h.newAgentItems(r, pool.Organization, agents)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// other pools in the organization to which queued jobs can be migrated
+	// should this pool be deleted.
+	pools, err := h.svc.listAgentPoolsByOrganization(r.Context(), pool.Organization, listPoolOptions{})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	otherPools := slices.DeleteFunc(pools, func(p *Pool) bool { return p.ID == pool.ID })
 
 	h.Render("agent_pool_get.tmpl", w, struct {
 		organization.OrganizationPage
@@ -290,7 +477,9 @@ func (h *webHandlers) getAgentPool(w http.ResponseWriter, r *http.Request) {
 		AssignedWorkspaces             []poolWorkspace
 		AvailableWorkspaces            []poolWorkspace
 		Tokens                         []*agentToken
-		Agents                         []*Agent
+		BootstrapTokens                []*bootstrapToken
+		Agents                         []agentItem
+		OtherPools                     []*Pool
 	}{
 		OrganizationPage:               organization.NewPage(r, pool.Name, pool.Organization),
 		Pool:                           pool,
@@ -299,7 +488,9 @@ func (h *webHandlers) getAgentPool(w http.ResponseWriter, r *http.Request) {
 		AssignedWorkspaces:             assignedWorkspaces,
 		AvailableWorkspaces:            availableWorkspaces,
 		Tokens:                         tokens,
-		Agents:                         agents,
+		BootstrapTokens:                bootstrapTokens,
+		Agents:                         agentItems,
+		OtherPools:                     otherPools,
 	})
 }
 
@@ -310,16 +501,62 @@ func (h *webHandlers) deleteAgentPool(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pool, err := h.svc.deleteAgentPool(r.Context(), poolID)
-	if err != nil {
+	var opts deletePoolOptions
+	if err := decode.All(&opts, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	pool, affected, err := h.svc.deleteAgentPool(r.Context(), poolID, opts)
+	if errors.Is(err, ErrCannotDeletePoolWithQueuedJobs) ||
+		errors.Is(err, ErrInvalidQueuedJobsMigrationPool) ||
+		errors.Is(err, ErrCannotDeletePoolReferencedByWorkspaces) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.AgentPool(poolID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	html.FlashSuccess(w, "Deleted agent pool: "+pool.Name)
+	msg := "Deleted agent pool: " + pool.Name
+	switch opts.QueuedJobs {
+	case CancelQueuedJobs:
+		msg += fmt.Sprintf(" (canceled %d queued jobs)", affected)
+	case MigrateQueuedJobs:
+		msg += fmt.Sprintf(" (migrated %d queued jobs)", affected)
+	}
+	if pool.DeletedAt != nil {
+		msg += fmt.Sprintf(". It can be recovered until %s", pool.RecoverableUntil(h.poolRecoveryWindow).Format(time.RFC822))
+	}
+	html.FlashSuccess(w, msg)
 	http.Redirect(w, r, paths.AgentPools(pool.Organization), http.StatusFound)
 }
 
+func (h *webHandlers) undeleteAgentPool(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		PoolID       string `schema:"pool_id,required"`
+		Organization string `schema:"organization_name,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	pool, err := h.svc.UndeletePool(r.Context(), params.PoolID)
+	if errors.Is(err, ErrPoolNotDeleted) || errors.Is(err, ErrPoolRecoveryWindowElapsed) || errors.Is(err, ErrCannotRecoverPoolNameTaken) || errors.Is(err, ErrPoolQuotaExceeded) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.AgentPools(params.Organization), http.StatusFound)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "Recovered agent pool: "+pool.Name)
+	http.Redirect(w, r, paths.AgentPool(pool.ID), http.StatusFound)
+}
+
 func (h *webHandlers) listAllowedPools(w http.ResponseWriter, r *http.Request) {
 	workspaceID, err := decode.Param("workspace_id", r)
 	if err != nil {
@@ -339,12 +576,22 @@ func (h *webHandlers) listAllowedPools(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// a phase selects the pool overriding the workspace's pool for the plan
+	// or apply phase, which can be left unset.
+	var phase string
+	switch p := internal.PhaseType(r.URL.Query().Get("phase")); p {
+	case internal.PlanPhase, internal.ApplyPhase:
+		phase = string(p)
+	}
+
 	h.Render("agent_pools_list_allowed.tmpl", w, struct {
 		Pools         []*Pool
 		CurrentPoolID string
+		Phase         string
 	}{
 		Pools:         pools,
 		CurrentPoolID: r.URL.Query().Get("agent_pool_id"),
+		Phase:         phase,
 	})
 }
 
@@ -391,3 +638,156 @@ func (h *webHandlers) deleteAgentToken(w http.ResponseWriter, r *http.Request) {
 	html.FlashSuccess(w, "Deleted token: "+at.Description)
 	http.Redirect(w, r, paths.AgentPool(at.AgentPoolID), http.StatusFound)
 }
+
+// bootstrap token handlers
+
+func (h *webHandlers) createBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	poolID, err := decode.Param("pool_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	var params struct {
+		Description string `schema:"description,required"`
+		// ExpiresIn is the duration, e.g. 1h, until the token expires.
+		ExpiresIn string `schema:"expires_in"`
+		// MaxUses is optional, so it is decoded as a string to distinguish
+		// an empty field from zero.
+		MaxUses string `schema:"max_uses"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	opts := CreateBootstrapTokenOptions{Description: params.Description}
+	if params.ExpiresIn != "" {
+		expiresIn, err := time.ParseDuration(params.ExpiresIn)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		opts.ExpiresAt = internal.Time(internal.CurrentTimestamp(nil).Add(expiresIn))
+	}
+	if params.MaxUses != "" {
+		maxUses, err := strconv.Atoi(params.MaxUses)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		opts.MaxUses = &maxUses
+	}
+
+	_, token, err := h.svc.CreateBootstrapToken(r.Context(), poolID, opts)
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.AgentPool(poolID), http.StatusFound)
+		return
+	}
+
+	if err := tokens.TokenFlashMessage(h, w, token); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, paths.AgentPool(poolID), http.StatusFound)
+}
+
+func (h *webHandlers) deleteBootstrapToken(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("token_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	bt, err := h.svc.DeleteBootstrapToken(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "Deleted bootstrap token: "+bt.Description)
+	http.Redirect(w, r, paths.AgentPool(bt.AgentPoolID), http.StatusFound)
+}
+
+func (h *webHandlers) revokeAgentToken(w http.ResponseWriter, r *http.Request) {
+	agentID, err := decode.Param("agent_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	agent, err := h.svc.RevokeAgentToken(r.Context(), agentID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "Revoked token for agent: "+agent.ID)
+	http.Redirect(w, r, paths.AgentPool(*agent.AgentPoolID), http.StatusFound)
+}
+
+func (h *webHandlers) collectDiagnostics(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		AgentID     string `schema:"agent_id,required"`
+		AgentPoolID string `schema:"agent_pool_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.svc.RequestAgentDiagnostics(r.Context(), params.AgentID)
+	if errors.Is(err, ErrDiagnosticsPending) || errors.Is(err, ErrDiagnosticsAgentNotRunning) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.AgentPool(params.AgentPoolID), http.StatusFound)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "Requested diagnostics from agent: "+params.AgentID+". The agent collects them upon its next status update.")
+	http.Redirect(w, r, paths.AgentPool(params.AgentPoolID), http.StatusFound)
+}
+
+func (h *webHandlers) downloadDiagnosticsBundle(w http.ResponseWriter, r *http.Request) {
+	bundleID, err := decode.Param("bundle_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	bundle, err := h.svc.GetAgentDiagnosticsBundle(r.Context(), bundleID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if bundle.CollectedAt == nil || bundle.Error != nil {
+		h.Error(w, ErrDiagnosticsNotCollected.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.json"`, bundle.AgentID, bundle.ID))
+	w.Write(bundle.contents) //nolint:errcheck
+}
+
+// allocation handlers
+
+// getRunAllocation renders an explanation of why a queued run's job has not
+// been allocated to an agent. Nothing is rendered if the allocator has not
+// recorded a reason.
+func (h *webHandlers) getRunAllocation(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	job, err := h.svc.GetPendingJob(r.Context(), runID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.Render("run_allocation.tmpl", w, job)
+}
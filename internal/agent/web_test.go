@@ -2,9 +2,12 @@ package agent
 
 import (
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/http/html/paths"
 	"github.com/tofutf/tofutf/internal/testutils"
 )
@@ -31,6 +34,98 @@ func TestWebHandlers_createAgentPool(t *testing.T) {
 	testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
 }
 
+func TestWebHandlers_updateAgentPool(t *testing.T) {
+	impacted := []ImpactedWorkspace{{ID: "ws-123", Name: "dev"}}
+
+	t.Run("preview", func(t *testing.T) {
+		svc := &fakeService{
+			pool:     &Pool{ID: "pool-123", Name: "my-pool", AllowedTerraformVersions: internal.String("~> 1.6"), MinAgents: 2},
+			impacted: impacted,
+		}
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc:      svc,
+		}
+		q := "/?pool_id=pool-123&name=my-pool&organization_scoped=false&allowed_terraform_versions=~>+1.6&min_agents=2&dry_run=true"
+		r := httptest.NewRequest("POST", q, nil)
+		w := httptest.NewRecorder()
+
+		h.updateAgentPool(w, r)
+
+		assert.Equal(t, 200, w.Code, w.Body.String())
+		assert.True(t, svc.updatePoolOptions.DryRun)
+		assert.Contains(t, w.Body.String(), `name="acknowledged_workspaces" value="ws-123"`)
+		assert.Equal(t, "~> 1.6", *svc.updatePoolOptions.AllowedTerraformVersions)
+		assert.Contains(t, w.Body.String(), `name="allowed_terraform_versions" value="~&gt; 1.6"`)
+		assert.Equal(t, 2, *svc.updatePoolOptions.MinAgents)
+		assert.Contains(t, w.Body.String(), `name="min_agents" value="2"`)
+	})
+
+	t.Run("unacknowledged", func(t *testing.T) {
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc: &fakeService{
+				pool:     &Pool{ID: "pool-123", Name: "my-pool"},
+				impacted: impacted,
+			},
+		}
+		q := "/?pool_id=pool-123&name=my-pool&organization_scoped=false"
+		r := httptest.NewRequest("POST", q, nil)
+		w := httptest.NewRecorder()
+
+		h.updateAgentPool(w, r)
+
+		testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
+		assert.Contains(t, w.Header().Get("Set-Cookie"), "flash")
+	})
+
+	t.Run("acknowledged", func(t *testing.T) {
+		svc := &fakeService{
+			pool:     &Pool{ID: "pool-123", Name: "my-pool"},
+			impacted: impacted,
+		}
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc:      svc,
+		}
+		q := "/?pool_id=pool-123&name=my-pool&organization_scoped=false&acknowledged_workspaces=ws-123"
+		r := httptest.NewRequest("POST", q, nil)
+		w := httptest.NewRecorder()
+
+		h.updateAgentPool(w, r)
+
+		testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
+		assert.Equal(t, []string{"ws-123"}, svc.updatePoolOptions.AcknowledgedWorkspaces)
+	})
+}
+
+func TestWebHandlers_listAgents(t *testing.T) {
+	h := &webHandlers{
+		Renderer: testutils.NewRenderer(t),
+		svc: &fakeService{
+			agents: []*Agent{
+				{ID: "agent-1", AgentPoolID: internal.String("pool-1")},
+				{ID: "agent-2", AgentPoolID: internal.String("pool-2")},
+				{ID: "agent-3", AgentPoolID: internal.String("pool-1")},
+			},
+			pools: map[string]*Pool{
+				"pool-1": {ID: "pool-1", Name: "pool-one"},
+				"pool-2": {ID: "pool-2", Name: "pool-two"},
+			},
+		},
+	}
+	q := "/?organization_name=acme-org"
+	r := httptest.NewRequest("GET", q, nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &internal.Superuser{}))
+	w := httptest.NewRecorder()
+
+	h.listAgents(w, r)
+
+	assert.Equal(t, 200, w.Code, w.Body.String())
+	assert.Equal(t, 2, strings.Count(w.Body.String(), "pool-one"))
+	assert.Equal(t, 1, strings.Count(w.Body.String(), "pool-two"))
+}
+
 func TestWebHandlers_listAgentPools(t *testing.T) {
 	h := &webHandlers{
 		Renderer: testutils.NewRenderer(t),
@@ -47,6 +142,99 @@ func TestWebHandlers_listAgentPools(t *testing.T) {
 	assert.Equal(t, 200, w.Code, w.Body.String())
 }
 
+func TestWebHandlers_listAgentPools_Deleted(t *testing.T) {
+	h := &webHandlers{
+		Renderer: testutils.NewRenderer(t),
+		svc: &fakeService{
+			pool: &Pool{ID: "pool-123"},
+			deletedPools: []*Pool{
+				{ID: "pool-456", Name: "deleted-pool", Organization: "acme-org", DeletedAt: internal.Time(time.Now())},
+			},
+		},
+		poolRecoveryWindow: time.Hour,
+	}
+	q := "/?organization_name=acme-org"
+	r := httptest.NewRequest("GET", q, nil)
+	w := httptest.NewRecorder()
+
+	h.listAgentPools(w, r)
+
+	assert.Equal(t, 200, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), paths.UndeleteAgentPool("pool-456"))
+}
+
+func TestWebHandlers_deleteAgentPool(t *testing.T) {
+	t.Run("delete", func(t *testing.T) {
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc: &fakeService{
+				pool: &Pool{ID: "pool-123", Name: "my-pool", Organization: "acme-org"},
+			},
+		}
+		q := "/?pool_id=pool-123"
+		r := httptest.NewRequest("POST", q, nil)
+		w := httptest.NewRecorder()
+
+		h.deleteAgentPool(w, r)
+
+		testutils.AssertRedirect(t, w, paths.AgentPools("acme-org"))
+	})
+
+	t.Run("invalid migration pool", func(t *testing.T) {
+		svc := &fakeService{
+			deleteErr: ErrInvalidQueuedJobsMigrationPool,
+		}
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc:      svc,
+		}
+		q := "/?pool_id=pool-123&queued_jobs=migrate&migrate_to_pool_id=pool-123"
+		r := httptest.NewRequest("POST", q, nil)
+		w := httptest.NewRecorder()
+
+		h.deleteAgentPool(w, r)
+
+		assert.Equal(t, MigrateQueuedJobs, svc.deletePoolOptions.QueuedJobs)
+		testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
+		assert.Contains(t, w.Header().Get("Set-Cookie"), "flash")
+	})
+}
+
+func TestWebHandlers_undeleteAgentPool(t *testing.T) {
+	t.Run("recover", func(t *testing.T) {
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc: &fakeService{
+				pool: &Pool{ID: "pool-123", Name: "my-pool"},
+			},
+		}
+		q := "/?pool_id=pool-123&organization_name=acme-org"
+		r := httptest.NewRequest("POST", q, nil)
+		w := httptest.NewRecorder()
+
+		h.undeleteAgentPool(w, r)
+
+		testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
+	})
+
+	t.Run("recovery window elapsed", func(t *testing.T) {
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc: &fakeService{
+				undeleteErr: ErrPoolRecoveryWindowElapsed,
+			},
+		}
+		q := "/?pool_id=pool-123&organization_name=acme-org"
+		r := httptest.NewRequest("POST", q, nil)
+		w := httptest.NewRecorder()
+
+		h.undeleteAgentPool(w, r)
+
+		testutils.AssertRedirect(t, w, paths.AgentPools("acme-org"))
+		assert.Contains(t, w.Header().Get("Set-Cookie"), "flash")
+	})
+}
+
 func TestWebHandlers_createAgentToken(t *testing.T) {
 	h := &webHandlers{
 		Renderer: testutils.NewRenderer(t),
@@ -78,3 +266,165 @@ func TestAgentToken_DeleteHandler(t *testing.T) {
 
 	testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
 }
+
+func TestWebHandlers_createBootstrapToken(t *testing.T) {
+	svc := &fakeService{bt: &bootstrapToken{}}
+	h := &webHandlers{
+		Renderer: testutils.NewRenderer(t),
+		svc:      svc,
+	}
+	q := "/?pool_id=pool-123&description=ci-agents&expires_in=6h&max_uses=50"
+	r := httptest.NewRequest("POST", q, nil)
+	w := httptest.NewRecorder()
+
+	h.createBootstrapToken(w, r)
+
+	testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
+	assert.Equal(t, "ci-agents", svc.bootstrapTokenOptions.Description)
+	if assert.NotNil(t, svc.bootstrapTokenOptions.MaxUses) {
+		assert.Equal(t, 50, *svc.bootstrapTokenOptions.MaxUses)
+	}
+	if assert.NotNil(t, svc.bootstrapTokenOptions.ExpiresAt) {
+		assert.WithinDuration(t, time.Now().Add(6*time.Hour), *svc.bootstrapTokenOptions.ExpiresAt, time.Minute)
+	}
+
+	t.Run("without maximum uses", func(t *testing.T) {
+		q := "/?pool_id=pool-123&description=ci-agents&expires_in=1h&max_uses="
+		r := httptest.NewRequest("POST", q, nil)
+		w := httptest.NewRecorder()
+
+		h.createBootstrapToken(w, r)
+
+		testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
+		assert.Nil(t, svc.bootstrapTokenOptions.MaxUses)
+	})
+}
+
+func TestWebHandlers_revokeAgentToken(t *testing.T) {
+	h := &webHandlers{
+		Renderer: testutils.NewRenderer(t),
+		svc: &fakeService{
+			agent: &Agent{ID: "agent-123", AgentPoolID: internal.String("pool-123")},
+		},
+	}
+	q := "/?agent_id=agent-123"
+	r := httptest.NewRequest("POST", q, nil)
+	w := httptest.NewRecorder()
+
+	h.revokeAgentToken(w, r)
+
+	testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
+	assert.Contains(t, w.Header().Get("Set-Cookie"), "flash")
+}
+
+func TestWebHandlers_collectDiagnostics(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"requested", nil},
+		{"pending", ErrDiagnosticsPending},
+		{"not running", ErrDiagnosticsAgentNotRunning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &webHandlers{
+				Renderer: testutils.NewRenderer(t),
+				svc: &fakeService{
+					diagnosticsBundle:     &DiagnosticsBundle{ID: "diag-123", AgentID: "agent-123"},
+					requestDiagnosticsErr: tt.err,
+				},
+			}
+			q := "/?agent_id=agent-123&agent_pool_id=pool-123"
+			r := httptest.NewRequest("POST", q, nil)
+			w := httptest.NewRecorder()
+
+			h.collectDiagnostics(w, r)
+
+			testutils.AssertRedirect(t, w, paths.AgentPool("pool-123"))
+			assert.Contains(t, w.Header().Get("Set-Cookie"), "flash")
+		})
+	}
+}
+
+func TestWebHandlers_downloadDiagnosticsBundle(t *testing.T) {
+	collectedAt := time.Now()
+	failed := "diagnostics collection is disabled on this agent"
+
+	t.Run("collected", func(t *testing.T) {
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc: &fakeService{
+				diagnosticsBundle: &DiagnosticsBundle{
+					ID:          "diag-123",
+					AgentID:     "agent-123",
+					CollectedAt: &collectedAt,
+					contents:    []byte(`{"version":"v1.0.0"}`),
+				},
+			},
+		}
+		r := httptest.NewRequest("GET", "/?bundle_id=diag-123", nil)
+		w := httptest.NewRecorder()
+
+		h.downloadDiagnosticsBundle(w, r)
+
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, `attachment; filename="agent-123-diag-123.json"`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, `{"version":"v1.0.0"}`, w.Body.String())
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc: &fakeService{
+				diagnosticsBundle: &DiagnosticsBundle{
+					ID:          "diag-123",
+					AgentID:     "agent-123",
+					CollectedAt: &collectedAt,
+					Error:       &failed,
+				},
+			},
+		}
+		r := httptest.NewRequest("GET", "/?bundle_id=diag-123", nil)
+		w := httptest.NewRecorder()
+
+		h.downloadDiagnosticsBundle(w, r)
+
+		assert.Equal(t, 404, w.Code)
+	})
+}
+
+func TestWebHandlers_getRunAllocation(t *testing.T) {
+	t.Run("pending job", func(t *testing.T) {
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc: &fakeService{
+				pendingJob: &PendingJob{
+					Spec:   JobSpec{RunID: "run-123", Phase: internal.PlanPhase},
+					Reason: ReasonAgentsAtCapacity,
+				},
+			},
+		}
+		r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+		w := httptest.NewRecorder()
+
+		h.getRunAllocation(w, r)
+
+		assert.Equal(t, 200, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "All server agents are at capacity")
+	})
+
+	t.Run("no pending job", func(t *testing.T) {
+		h := &webHandlers{
+			Renderer: testutils.NewRenderer(t),
+			svc:      &fakeService{},
+		}
+		r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+		w := httptest.NewRecorder()
+
+		h.getRunAllocation(w, r)
+
+		assert.Equal(t, 200, w.Code, w.Body.String())
+		assert.Empty(t, w.Body.String())
+	})
+}
@@ -12,9 +12,11 @@ type workdir struct {
 	relative string // relative path to working directory
 }
 
-func newWorkdir(workingDirectory string) (*workdir, error) {
+// newWorkdir creates a working directory within parent, or within the system's
+// temporary directory if parent is empty.
+func newWorkdir(parent, workingDirectory string) (*workdir, error) {
 	// create dedicated directory for operation
-	rootDir, err := os.MkdirTemp("", "otf-config-")
+	rootDir, err := os.MkdirTemp(parent, "otf-config-")
 	if err != nil {
 		return nil, err
 	}
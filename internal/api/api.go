@@ -12,6 +12,10 @@ const (
 	DefaultBasePath = "/otfapi"
 	PingEndpoint    = "ping"
 	DefaultAddress  = "localhost:8080"
+
+	// UploadOffsetHeader is set on a conflict response to a chunked upload,
+	// informing the client of the offset from which to resume uploading.
+	UploadOffsetHeader = "Upload-Offset"
 )
 
 type Handlers struct{}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -96,7 +97,14 @@ func NewClient(config Config) (*Client, error) {
 	}
 	if config.RetryRequests {
 		// enable retries
-		client.http.CheckRetry = retryablehttp.DefaultRetryPolicy
+		client.http.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+			if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+				// leave it to the caller to slow down as the server requests,
+				// e.g. by buffering logs rather than blocking on retries.
+				return false, nil
+			}
+			return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+		}
 	} else {
 		// disable retries
 		client.http.CheckRetry = func(_ context.Context, _ *http.Response, err error) (bool, error) {
@@ -106,6 +114,11 @@ func NewClient(config Config) (*Client, error) {
 	return client, nil
 }
 
+// SetToken replaces the API token used to authenticate subsequent requests.
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
 // Hostname returns the server host:port.
 func (c *Client) Hostname() string {
 	return c.baseURL.Host
@@ -322,7 +335,26 @@ func checkResponseCode(r *http.Response) error {
 		// 408 Request Timeout, 504 Gateway Timeout
 		return internal.ErrTimeout
 	case 409:
+		if offset := r.Header.Get(UploadOffsetHeader); offset != "" {
+			expected, err := strconv.Atoi(offset)
+			if err != nil {
+				return fmt.Errorf("invalid %s header: %w", UploadOffsetHeader, err)
+			}
+			return &internal.ChunkOffsetError{Expected: expected}
+		}
+		// include the reason for the conflict if provided, e.g. the entity
+		// holding a workspace lock.
+		if detail := conflictDetail(r); detail != "" {
+			return fmt.Errorf("%w: %s", internal.ErrConflict, detail)
+		}
 		return internal.ErrConflict
+	case 429:
+		// the server is too busy and asks the client to slow down
+		var retryAfter time.Duration
+		if seconds, err := strconv.Atoi(r.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return &internal.BackpressureError{RetryAfter: retryAfter}
 	}
 	// get contents of body and log that in the error message so we know
 	// what it is choking on.
@@ -351,3 +383,24 @@ func checkResponseCode(r *http.Response) error {
 	}
 	return fmt.Errorf(strings.Join(errs, "\n"))
 }
+
+// conflictDetail returns the details of the errors in the body of a conflict
+// response, or an empty string if there are none.
+func conflictDetail(r *http.Response) string {
+	if r.Body == nil {
+		return ""
+	}
+	var payload struct {
+		Errors []*jsonapi.Error `json:"errors"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return ""
+	}
+	var details []string
+	for _, e := range payload.Errors {
+		if e.Detail != "" {
+			details = append(details, e.Detail)
+		}
+	}
+	return strings.Join(details, "\n")
+}
@@ -3,9 +3,11 @@ package api
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DataDog/jsonapi"
 	"github.com/stretchr/testify/assert"
@@ -42,6 +44,36 @@ func TestClient_checkResponseCode(t *testing.T) {
 		{"204 No Content", &http.Response{StatusCode: 204}, nil},
 		{"401 Not Authorized", &http.Response{StatusCode: 401}, internal.ErrUnauthorized},
 		{"404 Not Found", &http.Response{StatusCode: 404}, internal.ErrResourceNotFound},
+		{"409 Conflict", &http.Response{StatusCode: 409}, internal.ErrConflict},
+		{
+			"409 Conflict with upload offset",
+			&http.Response{
+				StatusCode: 409,
+				Header:     http.Header{UploadOffsetHeader: []string{"42"}},
+			},
+			&internal.ChunkOffsetError{Expected: 42},
+		},
+		{
+			"409 Conflict naming lock holder",
+			&http.Response{
+				StatusCode: 409,
+				Body:       newBody(`{"errors":[{"status":"409","title":"Conflict","detail":"workspace already locked: locked by run run-123 (applying, started 4m ago)"}]}`),
+			},
+			fmt.Errorf("%w: workspace already locked: locked by run run-123 (applying, started 4m ago)", internal.ErrConflict),
+		},
+		{
+			"429 Too Many Requests",
+			&http.Response{
+				StatusCode: 429,
+				Header:     http.Header{"Retry-After": []string{"2"}},
+			},
+			&internal.BackpressureError{RetryAfter: 2 * time.Second},
+		},
+		{
+			"429 Too Many Requests without suggested delay",
+			&http.Response{StatusCode: 429},
+			&internal.BackpressureError{},
+		},
 		{
 			"500 Error",
 			&http.Response{
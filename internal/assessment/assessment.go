@@ -0,0 +1,146 @@
+// Package assessment maintains the results of workspace drift-detection
+// plans, i.e. the resources that have changed outside of terraform.
+package assessment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+// LockID guarantees only one recorder on a cluster is running at any time.
+const LockID int64 = 6129484611666145821
+
+type (
+	// Assessment is the result of the most recent drift-detection plan of a
+	// workspace.
+	Assessment struct {
+		// ID of the workspace.
+		ID string `jsonapi:"primary,workspace-assessments"`
+		// RunID is the ID of the drift-detection plan.
+		RunID string `jsonapi:"attribute" json:"run-id"`
+		// DetectedAt is when the plan finished.
+		DetectedAt time.Time `jsonapi:"attribute" json:"detected-at"`
+		// Resources are the resources found to have changed outside of
+		// terraform.
+		Resources []Resource `jsonapi:"attribute" json:"resources"`
+	}
+
+	// Resource is a resource that has changed outside of terraform.
+	Resource struct {
+		Address string `json:"address"`
+		Type    string `json:"type"`
+		// Actions are the actions that would make the state match the
+		// resource, e.g. update, or delete if the resource no longer exists.
+		Actions []run.ChangeAction `json:"actions"`
+		// Attributes are the attributes that have changed.
+		Attributes []Attribute `json:"attributes"`
+	}
+
+	// Attribute is an attribute of a resource that has changed outside of
+	// terraform, with its value in the state before and its actual value
+	// after. Sensitive values are masked.
+	Attribute struct {
+		Name   string          `json:"name"`
+		Before json.RawMessage `json:"before"`
+		After  json.RawMessage `json:"after"`
+	}
+
+	// driftPlan is the subset of a redacted plan describing drift.
+	driftPlan struct {
+		ResourceDrift []struct {
+			Address string `json:"address"`
+			Type    string `json:"type"`
+			Change  struct {
+				Actions []run.ChangeAction `json:"actions"`
+				Before  any                `json:"before"`
+				After   any                `json:"after"`
+			} `json:"change"`
+		} `json:"resource_drift"`
+	}
+)
+
+// Drifted determines whether any resources have changed outside of
+// terraform.
+func (a *Assessment) Drifted() bool { return len(a.Resources) > 0 }
+
+// IsAssessment determines whether a run is a drift-detection plan, i.e. a
+// refresh-only, plan-only run.
+func IsAssessment(r *run.Run) bool {
+	return r.PlanOnly && r.RefreshOnly
+}
+
+// newAssessment constructs an assessment from the JSON plan of a
+// drift-detection plan, masking sensitive values.
+func newAssessment(r *run.Run, planJSON []byte, detectedAt time.Time) (*Assessment, error) {
+	// redact sensitive values; the provider schemas are not needed.
+	redacted, err := run.NewRedactedPlan(planJSON, []byte(`{}`))
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(redacted))
+	// decode numbers as is to avoid losing precision.
+	dec.UseNumber()
+	var plan driftPlan
+	if err := dec.Decode(&plan); err != nil {
+		return nil, fmt.Errorf("unmarshalling plan: %w", err)
+	}
+	assessment := &Assessment{
+		ID:         r.WorkspaceID,
+		RunID:      r.ID,
+		DetectedAt: detectedAt,
+		Resources:  []Resource{},
+	}
+	for _, rd := range plan.ResourceDrift {
+		attrs, err := changedAttributes(rd.Change.Before, rd.Change.After)
+		if err != nil {
+			return nil, fmt.Errorf("comparing attributes of %s: %w", rd.Address, err)
+		}
+		assessment.Resources = append(assessment.Resources, Resource{
+			Address:    rd.Address,
+			Type:       rd.Type,
+			Actions:    rd.Change.Actions,
+			Attributes: attrs,
+		})
+	}
+	return assessment, nil
+}
+
+// changedAttributes compares the top-level attributes of a resource before
+// and after, returning those that differ, sorted by name.
+func changedAttributes(before, after any) ([]Attribute, error) {
+	b, _ := before.(map[string]any)
+	a, _ := after.(map[string]any)
+	var names []string
+	for k := range b {
+		names = append(names, k)
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			names = append(names, k)
+		}
+	}
+	slices.Sort(names)
+
+	attrs := []Attribute{}
+	for _, name := range names {
+		if reflect.DeepEqual(b[name], a[name]) {
+			continue
+		}
+		beforeJSON, err := json.Marshal(b[name])
+		if err != nil {
+			return nil, err
+		}
+		afterJSON, err := json.Marshal(a[name])
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, Attribute{Name: name, Before: beforeJSON, After: afterJSON})
+	}
+	return attrs, nil
+}
@@ -0,0 +1,144 @@
+package assessment
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+const driftedPlan = `{
+  "format_version": "1.2",
+  "resource_drift": [
+    {
+      "address": "aws_instance.web",
+      "type": "aws_instance",
+      "change": {
+        "actions": ["update"],
+        "before": {"instance_type": "t3.micro", "tags": {"env": "prod"}, "ami": "ami-123", "password": "old-secret"},
+        "after": {"instance_type": "t3.large", "tags": {"env": "prod"}, "ami": "ami-123", "password": "new-secret"},
+        "before_sensitive": {"password": true},
+        "after_sensitive": {"password": true}
+      }
+    },
+    {
+      "address": "aws_s3_bucket.logs",
+      "type": "aws_s3_bucket",
+      "change": {
+        "actions": ["delete"],
+        "before": {"bucket": "logs"},
+        "after": null
+      }
+    }
+  ]
+}`
+
+func TestNewAssessment(t *testing.T) {
+	now := time.Now()
+	r := &run.Run{ID: "run-123", WorkspaceID: "ws-123", PlanOnly: true, RefreshOnly: true}
+
+	t.Run("drifted", func(t *testing.T) {
+		got, err := newAssessment(r, []byte(driftedPlan), now)
+		require.NoError(t, err)
+
+		assert.Equal(t, "ws-123", got.ID)
+		assert.Equal(t, "run-123", got.RunID)
+		assert.True(t, got.Drifted())
+		require.Len(t, got.Resources, 2)
+
+		web := got.Resources[0]
+		assert.Equal(t, "aws_instance.web", web.Address)
+		assert.Equal(t, []run.ChangeAction{run.UpdateAction}, web.Actions)
+		assert.Equal(t, []Attribute{
+			{Name: "instance_type", Before: json.RawMessage(`"t3.micro"`), After: json.RawMessage(`"t3.large"`)},
+			{Name: "password", Before: json.RawMessage(`"(sensitive value)"`), After: json.RawMessage(`"(changed sensitive value)"`)},
+		}, web.Attributes)
+
+		logs := got.Resources[1]
+		assert.Equal(t, []Attribute{
+			{Name: "bucket", Before: json.RawMessage(`"logs"`), After: json.RawMessage(`null`)},
+		}, logs.Attributes)
+
+		encoded, err := json.Marshal(got)
+		require.NoError(t, err)
+		assert.NotContains(t, string(encoded), "secret")
+	})
+
+	t.Run("no drift", func(t *testing.T) {
+		got, err := newAssessment(r, []byte(`{"format_version": "1.2"}`), now)
+		require.NoError(t, err)
+		assert.False(t, got.Drifted())
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	detectedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	appliedAt := detectedAt.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		run         *run.Run
+		wantRecord  bool
+		wantCleared *time.Time
+	}{
+		{
+			name: "assessment",
+			run: &run.Run{
+				ID: "run-123", WorkspaceID: "ws-123", PlanOnly: true, RefreshOnly: true,
+				Status:           run.RunPlannedAndFinished,
+				StatusTimestamps: []run.StatusTimestamp{{Status: run.RunPlannedAndFinished, Timestamp: detectedAt}},
+			},
+			wantRecord: true,
+		},
+		{
+			name: "speculative plan is not an assessment",
+			run: &run.Run{
+				ID: "run-123", WorkspaceID: "ws-123", PlanOnly: true,
+				Status:           run.RunPlannedAndFinished,
+				StatusTimestamps: []run.StatusTimestamp{{Status: run.RunPlannedAndFinished, Timestamp: detectedAt}},
+			},
+		},
+		{
+			name: "apply clears assessment",
+			run: &run.Run{
+				ID: "run-123", WorkspaceID: "ws-123",
+				Status:           run.RunApplied,
+				StatusTimestamps: []run.StatusTimestamp{{Status: run.RunApplied, Timestamp: appliedAt}},
+			},
+			wantCleared: &appliedAt,
+		},
+		{
+			name: "assessment in progress",
+			run: &run.Run{
+				ID: "run-123", WorkspaceID: "ws-123", PlanOnly: true, RefreshOnly: true,
+				Status: run.RunPlanning,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeRecorderDB{}
+			r := &Recorder{
+				Logger: slog.New(&xslog.NoopHandler{}),
+				Runs:   &fakeRunClient{plan: []byte(driftedPlan)},
+				db:     db,
+			}
+			require.NoError(t, r.handleRun(context.Background(), tt.run))
+
+			if tt.wantRecord {
+				require.NotNil(t, db.upserted)
+				assert.Equal(t, detectedAt, db.upserted.DetectedAt)
+				assert.Len(t, db.upserted.Resources, 2)
+			} else {
+				assert.Nil(t, db.upserted)
+			}
+			assert.Equal(t, tt.wantCleared, db.clearedBefore)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package assessment
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+// pgdb is the assessment database on postgres
+type pgdb struct {
+	*sql.Pool // provides access to generated SQL queries
+}
+
+// upsert replaces a workspace's assessment, unless the existing assessment
+// was detected more recently.
+func (db *pgdb) upsert(ctx context.Context, a *Assessment) error {
+	resources, err := json.Marshal(a.Resources)
+	if err != nil {
+		return err
+	}
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertWorkspaceAssessment(ctx, pggen.UpsertWorkspaceAssessmentParams{
+			WorkspaceID: sql.String(a.ID),
+			RunID:       sql.String(a.RunID),
+			DetectedAt:  sql.Timestamptz(a.DetectedAt),
+			Resources:   resources,
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) get(ctx context.Context, workspaceID string) (*Assessment, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Assessment, error) {
+		row, err := q.FindWorkspaceAssessment(ctx, sql.String(workspaceID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		a := &Assessment{
+			ID:         row.WorkspaceID.String,
+			RunID:      row.RunID.String,
+			DetectedAt: row.DetectedAt.Time.UTC(),
+		}
+		if err := json.Unmarshal(row.Resources, &a.Resources); err != nil {
+			return nil, err
+		}
+		return a, nil
+	})
+}
+
+// clear deletes a workspace's assessment if it was detected before the given
+// time.
+func (db *pgdb) clear(ctx context.Context, workspaceID string, before time.Time) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteWorkspaceAssessmentBefore(ctx, sql.String(workspaceID), sql.Timestamptz(before))
+		return sql.Error(err)
+	})
+}
@@ -0,0 +1,98 @@
+package assessment
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+// Recorder records the results of drift-detection plans as run events
+// arrive, replacing each workspace's previous result. A workspace's result
+// is cleared once a run is applied, which brings its state up to date.
+type Recorder struct {
+	Logger *slog.Logger
+	Runs   recorderRunClient
+
+	db recorderDB
+}
+
+type recorderDB interface {
+	upsert(ctx context.Context, a *Assessment) error
+	clear(ctx context.Context, workspaceID string, before time.Time) error
+}
+
+// Start starts the recorder daemon. Should be invoked in a go routine.
+func (r *Recorder) Start(ctx context.Context) error {
+	// subscribe to run events
+	sub, unsub := r.Runs.Watch(ctx)
+	defer unsub()
+
+	for event := range sub {
+		if event.Type == pubsub.DeletedEvent {
+			// Skip deleted run events
+			continue
+		}
+		if err := r.handleRun(ctx, event.Payload); err != nil {
+			return err
+		}
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
+
+func (r *Recorder) handleRun(ctx context.Context, rn *run.Run) error {
+	var err error
+	switch {
+	case rn.Status == run.RunPlannedAndFinished && IsAssessment(rn):
+		err = r.record(ctx, rn)
+	case rn.Status == run.RunApplied:
+		err = r.clear(ctx, rn)
+	default:
+		return nil
+	}
+	var fkErr *internal.ForeignKeyError
+	if errors.As(err, &fkErr) || errors.Is(err, internal.ErrResourceNotFound) {
+		// the run or workspace has since been deleted
+		r.Logger.Debug("skipping assessment for deleted run", "run", rn.ID)
+		return nil
+	}
+	return err
+}
+
+func (r *Recorder) record(ctx context.Context, rn *run.Run) error {
+	detectedAt, err := rn.StatusTimestamp(run.RunPlannedAndFinished)
+	if err != nil {
+		return err
+	}
+	plan, err := r.Runs.GetPlanFile(ctx, rn.ID, run.PlanFormatJSON)
+	if err != nil {
+		return err
+	}
+	assessment, err := newAssessment(rn, plan, detectedAt)
+	if err != nil {
+		// don't stop the recorder because of an unparseable plan
+		r.Logger.Error("constructing assessment", "run", rn.ID, "err", err)
+		return nil
+	}
+	if err := r.db.upsert(ctx, assessment); err != nil {
+		return err
+	}
+	r.Logger.Info("recorded assessment", "workspace", rn.WorkspaceID, "run", rn.ID, "drifted_resources", len(assessment.Resources))
+	return nil
+}
+
+func (r *Recorder) clear(ctx context.Context, rn *run.Run) error {
+	appliedAt, err := rn.StatusTimestamp(run.RunApplied)
+	if err != nil {
+		return err
+	}
+	if err := r.db.clear(ctx, rn.WorkspaceID, appliedAt); err != nil {
+		return err
+	}
+	r.Logger.Debug("cleared assessment", "workspace", rn.WorkspaceID, "run", rn.ID)
+	return nil
+}
@@ -0,0 +1,94 @@
+package assessment
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+type (
+	Service struct {
+		logger *slog.Logger
+
+		workspace internal.Authorizer
+		runs      recorderRunClient
+		db        *pgdb
+		api       *tfe
+		web       *webHandlers
+	}
+
+	Options struct {
+		Logger *slog.Logger
+
+		*sql.Pool
+		*tfeapi.Responder
+		html.Renderer
+
+		WorkspaceService *workspace.Service
+		RunService       *run.Service
+	}
+
+	recorderRunClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+		GetPlanFile(ctx context.Context, runID string, format run.PlanFormat) ([]byte, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		logger:    opts.Logger,
+		workspace: opts.WorkspaceService,
+		runs:      opts.RunService,
+		db:        &pgdb{opts.Pool},
+	}
+	svc.api = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	svc.web = &webHandlers{
+		Renderer:   opts.Renderer,
+		workspaces: opts.WorkspaceService,
+		svc:        &svc,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+	s.web.addHandlers(r)
+}
+
+// NewRecorder constructs a recorder that records the results of
+// drift-detection plans as their events arrive.
+func (s *Service) NewRecorder(logger *slog.Logger) *Recorder {
+	return &Recorder{
+		Logger: logger,
+		Runs:   s.runs,
+		db:     s.db,
+	}
+}
+
+// GetAssessment retrieves the result of the most recent drift-detection plan
+// of a workspace. Returns internal.ErrResourceNotFound if the workspace has
+// not been assessed since it was last applied.
+func (s *Service) GetAssessment(ctx context.Context, workspaceID string) (*Assessment, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	assessment, err := s.db.get(ctx, workspaceID)
+	if err != nil {
+		s.logger.Error("retrieving workspace assessment", "workspace", workspaceID, "subject", subject, "err", err)
+		return nil, err
+	}
+	return assessment, nil
+}
@@ -0,0 +1,57 @@
+package assessment
+
+import (
+	"context"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+type (
+	fakeRunClient struct {
+		plan []byte
+	}
+
+	fakeRecorderDB struct {
+		upserted      *Assessment
+		clearedBefore *time.Time
+	}
+
+	fakeWebClient struct {
+		assessment *Assessment
+	}
+
+	fakeWorkspaceClient struct{}
+)
+
+func (f *fakeRunClient) Watch(context.Context) (<-chan pubsub.Event[*run.Run], func()) {
+	return nil, func() {}
+}
+
+func (f *fakeRunClient) GetPlanFile(context.Context, string, run.PlanFormat) ([]byte, error) {
+	return f.plan, nil
+}
+
+func (f *fakeRecorderDB) upsert(_ context.Context, a *Assessment) error {
+	f.upserted = a
+	return nil
+}
+
+func (f *fakeRecorderDB) clear(_ context.Context, _ string, before time.Time) error {
+	f.clearedBefore = &before
+	return nil
+}
+
+func (f *fakeWebClient) GetAssessment(context.Context, string) (*Assessment, error) {
+	if f.assessment == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.assessment, nil
+}
+
+func (f *fakeWorkspaceClient) Get(_ context.Context, workspaceID string) (*workspace.Workspace, error) {
+	return &workspace.Workspace{ID: workspaceID, Name: "dev", Organization: "acme-corp"}, nil
+}
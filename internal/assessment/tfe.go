@@ -0,0 +1,34 @@
+package assessment
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/workspaces/{workspace_id}/assessment", a.getAssessment).Methods("GET")
+}
+
+func (a *tfe) getAssessment(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	assessment, err := a.GetAssessment(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, assessment, http.StatusOK)
+}
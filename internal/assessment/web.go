@@ -0,0 +1,97 @@
+package assessment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+type webHandlers struct {
+	html.Renderer
+
+	workspaces workspaceClient
+	svc        webClient
+}
+
+type (
+	webClient interface {
+		GetAssessment(ctx context.Context, workspaceID string) (*Assessment, error)
+	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+)
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/workspaces/{workspace_id}/drift", h.getDrift).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/drift-badge", h.getDriftBadge).Methods("GET")
+}
+
+// getDrift renders the resources of a workspace that have changed outside of
+// terraform.
+func (h *webHandlers) getDrift(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	ws, err := h.workspaces.Get(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	assessment, err := h.getAssessment(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.Render("workspace_drift.tmpl", w, struct {
+		workspace.WorkspacePage
+		Assessment *Assessment
+	}{
+		WorkspacePage: workspace.NewPage(r, "drift | "+ws.ID, ws),
+		Assessment:    assessment,
+	})
+}
+
+// getDriftBadge renders a badge summarising whether a workspace has drifted.
+func (h *webHandlers) getDriftBadge(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	assessment, err := h.getAssessment(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := h.RenderTemplate("workspace_drift_badge.tmpl", w, struct {
+		WorkspaceID string
+		Assessment  *Assessment
+	}{
+		WorkspaceID: id,
+		Assessment:  assessment,
+	}); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// getAssessment retrieves a workspace's assessment, returning nil if there is
+// none.
+func (h *webHandlers) getAssessment(ctx context.Context, workspaceID string) (*Assessment, error) {
+	assessment, err := h.svc.GetAssessment(ctx, workspaceID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return nil, nil
+	}
+	return assessment, err
+}
@@ -0,0 +1,59 @@
+package assessment
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/testutils"
+	"github.com/tofutf/tofutf/internal/user"
+)
+
+func TestWeb_Drift(t *testing.T) {
+	drifted := &Assessment{
+		ID:         "ws-123",
+		RunID:      "run-123",
+		DetectedAt: time.Now(),
+		Resources: []Resource{
+			{Address: "aws_instance.web", Type: "aws_instance", Attributes: []Attribute{
+				{Name: "instance_type", Before: []byte(`"t3.micro"`), After: []byte(`"t3.large"`)},
+			}},
+		},
+	}
+	notDrifted := &Assessment{ID: "ws-123", RunID: "run-123", DetectedAt: time.Now()}
+
+	tests := []struct {
+		name       string
+		assessment *Assessment
+		wantPage   string
+		wantBadge  string
+	}{
+		{"drifted", drifted, `id="drifted-resources"`, `id="workspace-drifted"`},
+		{"not drifted", notDrifted, `id="no-drift"`, `id="workspace-not-drifted"`},
+		{"not assessed", nil, `id="not-assessed"`, `id="workspace-not-assessed"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &webHandlers{
+				Renderer:   testutils.NewRenderer(t),
+				workspaces: &fakeWorkspaceClient{},
+				svc:        &fakeWebClient{assessment: tt.assessment},
+			}
+
+			r := httptest.NewRequest("GET", "/?workspace_id=ws-123", nil)
+			r = r.WithContext(internal.AddSubjectToContext(r.Context(), &user.User{Username: "bob"}))
+			w := httptest.NewRecorder()
+			h.getDrift(w, r)
+			assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.wantPage)
+
+			r = httptest.NewRequest("GET", "/?workspace_id=ws-123", nil)
+			w = httptest.NewRecorder()
+			h.getDriftBadge(w, r)
+			assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+			assert.Contains(t, w.Body.String(), tt.wantBadge)
+		})
+	}
+}
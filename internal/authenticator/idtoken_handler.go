@@ -10,9 +10,11 @@ import (
 )
 
 var (
-	// "openid" is a required scope for OpenID Connect flows, and profile
-	// gives OTF access to the user's username.
-	DefaultOIDCScopes       = []string{oidc.ScopeOpenID, "profile"}
+	// "openid" is a required scope for OpenID Connect flows, profile
+	// gives OTF access to the user's username, and email gives OTF access to
+	// the user's email address, which is used to link the login to an
+	// existing user with the same verified email address.
+	DefaultOIDCScopes       = []string{oidc.ScopeOpenID, "profile", "email"}
 	ErrMissingOIDCIssuerURL = errors.New("missing oidc-issuer-url")
 )
 
@@ -70,14 +72,7 @@ func newIDTokenHandler(ctx context.Context, opts OIDCConfig) (*idtokenHandler, e
 }
 
 func (o idtokenHandler) getUsername(ctx context.Context, token *oauth2.Token) (string, error) {
-	// Extract the ID Token from OAuth2 token.
-	rawIDToken, ok := token.Extra("id_token").(string)
-	if !ok {
-		return "", errors.New("id_token missing")
-	}
-
-	// Parse and verify ID Token payload.
-	idt, err := o.verifier.Verify(ctx, rawIDToken)
+	idt, err := o.verify(ctx, token)
 	if err != nil {
 		return "", err
 	}
@@ -89,3 +84,36 @@ func (o idtokenHandler) getUsername(ctx context.Context, token *oauth2.Token) (s
 
 	return o.username.value, nil
 }
+
+// getVerifiedEmail extracts the email address from the ID token. An empty
+// string is returned if the token lacks an email address or if the OIDC
+// provider has not verified the address.
+func (o idtokenHandler) getVerifiedEmail(ctx context.Context, token *oauth2.Token) (string, error) {
+	idt, err := o.verify(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := idt.Claims(&claims); err != nil {
+		return "", err
+	}
+	if !claims.EmailVerified {
+		return "", nil
+	}
+	return claims.Email, nil
+}
+
+func (o idtokenHandler) verify(ctx context.Context, token *oauth2.Token) (*oidc.IDToken, error) {
+	// Extract the ID Token from OAuth2 token.
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("id_token missing")
+	}
+
+	// Parse and verify ID Token payload.
+	return o.verifier.Verify(ctx, rawIDToken)
+}
@@ -57,3 +57,43 @@ func Test_idtokenHandler_getUsername(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "bobby", got)
 }
+
+// Test_idtokenHandler_getVerifiedEmail tests extracting the email address from
+// an ID token, which is only returned if the provider has verified it.
+func Test_idtokenHandler_getVerifiedEmail(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+	keySet := &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{key.Public()}}
+	handler := idtokenHandler{
+		verifier: oidc.NewVerifier("", keySet, &oidc.Config{ClientID: "otf"}),
+	}
+
+	tests := []struct {
+		name     string
+		verified bool
+		want     string
+	}{
+		{"verified", true, "bobby@example.com"},
+		{"unverified", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwt.NewBuilder().
+				Audience([]string{"otf"}).
+				Claim("email", "bobby@example.com").
+				Claim("email_verified", tt.verified).
+				IssuedAt(time.Now()).
+				Expiration(time.Now().Add(time.Minute)).
+				Build()
+			require.NoError(t, err)
+			signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, key))
+			require.NoError(t, err)
+
+			got, err := handler.getVerifiedEmail(context.Background(), (&oauth2.Token{}).WithExtra(
+				map[string]any{"id_token": string(signed)},
+			))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/tofutf/tofutf/internal/http/html"
 	"github.com/tofutf/tofutf/internal/http/html/paths"
 	"github.com/tofutf/tofutf/internal/tokens"
+	"github.com/tofutf/tofutf/internal/user"
 	"golang.org/x/oauth2"
 )
 
@@ -28,6 +29,18 @@ type (
 		getUsername(context.Context, *oauth2.Token) (string, error)
 	}
 
+	// verifiedEmailHandler is optionally implemented by a tokenHandler that
+	// can also extract a verified email address from an OAuth access token.
+	verifiedEmailHandler interface {
+		getVerifiedEmail(context.Context, *oauth2.Token) (string, error)
+	}
+
+	// userLinker links a login to an existing user by their verified email
+	// address.
+	userLinker interface {
+		LinkVerifiedEmail(ctx context.Context, username, email string) (*user.User, error)
+	}
+
 	sessionStarter interface {
 		StartSession(w http.ResponseWriter, r *http.Request, opts tokens.StartSessionOptions) error
 	}
@@ -44,6 +57,8 @@ type (
 		OAuthConfig
 
 		sessions sessionStarter
+		// link login to existing user by verified email; optional.
+		users userLinker
 	}
 
 	// OAuthConfig is configuration for constructing an OAuth client
@@ -162,6 +177,25 @@ func (a *OAuthClient) callbackHandler(w http.ResponseWriter, r *http.Request) {
 		html.Error(w, err.Error(), http.StatusInternalServerError, false)
 		return
 	}
+	// Where the provider supplies a verified email address, log in as the user
+	// with that email address, if there is one, rather than as a user with
+	// the username.
+	if handler, ok := a.tokenHandler.(verifiedEmailHandler); ok && a.users != nil {
+		email, err := handler.getVerifiedEmail(r.Context(), token)
+		if err != nil {
+			html.Error(w, err.Error(), http.StatusInternalServerError, false)
+			return
+		}
+		if email != "" {
+			ctx := internal.AddSubjectToContext(r.Context(), &internal.Superuser{Username: "authenticator"})
+			linked, err := a.users.LinkVerifiedEmail(ctx, username, email)
+			if err != nil {
+				html.Error(w, err.Error(), http.StatusInternalServerError, false)
+				return
+			}
+			username = linked.Username
+		}
+	}
 	err = a.sessions.StartSession(w, r, tokens.StartSessionOptions{Username: &username})
 	if err != nil {
 		html.Error(w, err.Error(), http.StatusInternalServerError, false)
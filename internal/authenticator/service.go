@@ -9,6 +9,7 @@ import (
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/http/html"
 	"github.com/tofutf/tofutf/internal/tokens"
+	"github.com/tofutf/tofutf/internal/user"
 )
 
 type (
@@ -19,6 +20,7 @@ type (
 
 		Logger               *slog.Logger
 		TokensService        *tokens.Service
+		UserService          *user.Service
 		OpaqueHandlerConfigs []OpaqueHandlerConfig
 		IDTokenHandlerConfig OIDCConfig
 		SkipTLSVerification  bool
@@ -83,6 +85,9 @@ func NewAuthenticatorService(ctx context.Context, opts Options) (*service, error
 	if err != nil {
 		return nil, err
 	}
+	if opts.UserService != nil {
+		client.users = opts.UserService
+	}
 	svc.clients = append(svc.clients, client)
 	opts.Logger.Info("activated OIDC client", "name", opts.IDTokenHandlerConfig.Name)
 	return &svc, nil
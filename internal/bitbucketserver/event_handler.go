@@ -78,16 +78,22 @@ func HandleEventWithLogging(next EventHandler) EventHandler {
 func BaseHandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 	slog.Debug("handling webhook")
 
-	payload, err := io.ReadAll(r.Body)
-	if err != nil || len(payload) == 0 {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
 		return nil, fmt.Errorf("error reading request body: %w", err)
 	}
 
-	err = ValidateEvent(r, secret, payload)
+	// the signature is calculated over the body as sent, before any decoding
+	err = ValidateEvent(r, secret, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate request: %w", err)
 	}
 
+	payload, err := vcs.WebhookPayload(r.Header.Get("Content-Type"), body)
+	if err != nil {
+		return nil, err
+	}
+
 	var event BitbucketHookEvent
 	err = json.Unmarshal(payload, &event)
 	if err != nil {
@@ -261,7 +267,7 @@ func BaseHandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error)
 		return nil, fmt.Errorf("failed to handle push event")
 	}
 
-	return nil, nil
+	return nil, vcs.NewErrUnknownEvent(event.EventKey)
 }
 
 // getRefType returns the ref type of the event.
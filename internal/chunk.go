@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"fmt"
 	"html/template"
 
 	term2html "github.com/buildkite/terminal-to-html"
@@ -12,12 +13,25 @@ const (
 	ETX = 0x03 // marks the end of logs for a phase
 )
 
+const (
+	// PlainLogStream is the stream of human-readable logs for a phase.
+	PlainLogStream LogStream = ""
+	// JSONLogStream is the stream of machine-readable logs for a phase, i.e.
+	// the JSON lines produced by terraform's -json flag, and is only written
+	// when structured run output is enabled.
+	JSONLogStream LogStream = "json"
+)
+
 type (
+	// LogStream identifies one of the streams of logs written for a phase.
+	LogStream string
+
 	// Chunk is a section of logs for a phase.
 	Chunk struct {
 		ID     string    `json:"id"`     // Uniquely identifies the chunk.
 		RunID  string    `json:"run_id"` // ID of run that generated the chunk
 		Phase  PhaseType `json:"phase"`  // Phase that generated the chunk
+		Stream LogStream `json:"stream"` // Stream to which the chunk belongs
 		Offset int       `json:"offset"` // Position within logs.
 		Data   []byte    `json:"data"`   // The log data
 	}
@@ -25,6 +39,7 @@ type (
 	PutChunkOptions struct {
 		RunID  string    `schema:"run_id,required"`
 		Phase  PhaseType `schema:"phase,required"`
+		Stream LogStream `schema:"stream"`
 		Offset int       `schema:"offset,required"`
 		Data   []byte
 	}
@@ -32,15 +47,37 @@ type (
 	GetChunkOptions struct {
 		RunID  string    `schema:"run_id"`
 		Phase  PhaseType `schema:"phase"`
+		Stream LogStream `schema:"stream"`
 		Limit  int       `schema:"limit"`  // size of the chunk to retrieve
 		Offset int       `schema:"offset"` // position in overall data to seek from.
 	}
 
 	PutChunkService interface {
 		PutChunk(ctx context.Context, opts PutChunkOptions) error
+		// MarkLogsIncomplete marks a stream of logs for a phase as incomplete,
+		// for when a client gives up uploading them. No further chunks are
+		// accepted for the stream.
+		MarkLogsIncomplete(ctx context.Context, runID string, phase PhaseType, stream LogStream) error
+	}
+
+	// ChunkOffsetError is returned when a chunk does not begin at the offset
+	// at which the previously received chunk ended, i.e. it is either out of
+	// order or it overlaps data already received.
+	ChunkOffsetError struct {
+		// Expected is the offset at which the next chunk must begin.
+		Expected int
 	}
 )
 
+func (e *ChunkOffsetError) Error() string {
+	return fmt.Sprintf("unexpected chunk offset: expected next chunk at offset %d", e.Expected)
+}
+
+// Is permits a chunk offset error to be identified as a conflict.
+func (e *ChunkOffsetError) Is(target error) bool {
+	return target == ErrConflict
+}
+
 // Cut returns a new, smaller chunk.
 func (c Chunk) Cut(opts GetChunkOptions) Chunk {
 	if opts.Offset > c.NextOffset() {
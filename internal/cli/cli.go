@@ -13,6 +13,7 @@ import (
 	"github.com/tofutf/tofutf/internal/agent"
 	"github.com/tofutf/tofutf/internal/api"
 	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/releases"
 	"github.com/tofutf/tofutf/internal/run"
 	"github.com/tofutf/tofutf/internal/state"
 	"github.com/tofutf/tofutf/internal/team"
@@ -62,6 +63,7 @@ func (a *CLI) Run(ctx context.Context, args []string, out io.Writer) error {
 	cmd.AddCommand(run.NewCommand(a.client))
 	cmd.AddCommand(state.NewCommand(a.client))
 	cmd.AddCommand(agent.NewAgentsCommand(a.client))
+	cmd.AddCommand(releases.NewCommand(a.client))
 
 	if err := cmdutil.SetFlagsFromEnvVariables(cmd.Flags()); err != nil {
 		return errors.Wrap(err, "failed to populate config from environment vars")
@@ -0,0 +1,40 @@
+package compare
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	otfapi "github.com/tofutf/tofutf/internal/api"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+type api struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/workspaces/compare", a.compare).Methods("GET")
+}
+
+func (a *api) compare(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Left  string `schema:"left,required"`
+		Right string `schema:"right,required"`
+	}
+	if err := decode.Query(&params, r.URL.Query()); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	comparison, err := a.Compare(r.Context(), params.Left, params.Right)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, comparison, http.StatusOK)
+}
@@ -0,0 +1,177 @@
+// Package compare compares the settings of two workspaces.
+package compare
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/tofutf/tofutf/internal/variable"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+type (
+	// Comparison is the difference between the settings of two workspaces.
+	Comparison struct {
+		// ID is the IDs of the left and right workspaces separated by
+		// "..", e.g. ws-abc..ws-def.
+		ID string `jsonapi:"primary,workspace-comparisons"`
+
+		Left  Workspace `jsonapi:"attribute" json:"left"`
+		Right Workspace `jsonapi:"attribute" json:"right"`
+
+		Fields    []Field    `jsonapi:"attribute" json:"fields"`
+		Variables []Variable `jsonapi:"attribute" json:"variables"`
+	}
+
+	// Workspace identifies a workspace in a comparison.
+	Workspace struct {
+		ID           string `json:"id"`
+		Name         string `json:"name"`
+		Organization string `json:"organization"`
+	}
+
+	// Field is a setting compared between the two workspaces. Settings with
+	// multiple values, such as tags, are sorted and joined with commas.
+	Field struct {
+		Name    string `json:"name"`
+		Left    string `json:"left"`
+		Right   string `json:"right"`
+		Differs bool   `json:"differs"`
+	}
+
+	// Variable is a workspace variable compared between the two workspaces,
+	// identified by its category and key.
+	Variable struct {
+		Key      string                    `json:"key"`
+		Category variable.VariableCategory `json:"category"`
+		// Left and Right are nil if the variable is not set on the
+		// respective workspace.
+		Left    *VariableValue `json:"left"`
+		Right   *VariableValue `json:"right"`
+		Differs bool           `json:"differs"`
+	}
+
+	// VariableValue is the value of a variable on one of the workspaces.
+	VariableValue struct {
+		// Value is nil if the variable is sensitive.
+		Value     *string `json:"value"`
+		Sensitive bool    `json:"sensitive"`
+		HCL       bool    `json:"hcl"`
+	}
+)
+
+// Differs determines whether the workspaces differ in any of their settings.
+func (c *Comparison) Differs() bool {
+	for _, f := range c.Fields {
+		if f.Differs {
+			return true
+		}
+	}
+	for _, v := range c.Variables {
+		if v.Differs {
+			return true
+		}
+	}
+	return false
+}
+
+// compare the settings and variables of two workspaces.
+func compare(left, right *workspace.Workspace, leftVars, rightVars []*variable.Variable) *Comparison {
+	return &Comparison{
+		ID:        left.ID + ".." + right.ID,
+		Left:      Workspace{ID: left.ID, Name: left.Name, Organization: left.Organization},
+		Right:     Workspace{ID: right.ID, Name: right.Name, Organization: right.Organization},
+		Fields:    compareFields(left, right),
+		Variables: compareVariables(leftVars, rightVars),
+	}
+}
+
+func compareFields(left, right *workspace.Workspace) []Field {
+	settings := []struct {
+		name  string
+		value func(ws *workspace.Workspace) string
+	}{
+		{"terraform-version", func(ws *workspace.Workspace) string { return ws.TerraformVersion }},
+		{"execution-mode", func(ws *workspace.Workspace) string { return string(ws.ExecutionMode) }},
+		{"agent-pool", func(ws *workspace.Workspace) string {
+			if ws.AgentPoolID == nil {
+				return ""
+			}
+			return *ws.AgentPoolID
+		}},
+		{"trigger-patterns", func(ws *workspace.Workspace) string { return joinSorted(ws.TriggerPatterns) }},
+		{"tags", func(ws *workspace.Workspace) string { return joinSorted(ws.Tags) }},
+	}
+	fields := make([]Field, len(settings))
+	for i, s := range settings {
+		l, r := s.value(left), s.value(right)
+		fields[i] = Field{Name: s.name, Left: l, Right: r, Differs: l != r}
+	}
+	return fields
+}
+
+// compareVariables compares variables by their category and key, sorted by
+// category and then key. The values of sensitive variables are neither
+// included nor compared: a variable that is sensitive on either workspace
+// only differs if it is set on one workspace but not the other, or if its
+// sensitivity or HCL setting differs.
+func compareVariables(leftVars, rightVars []*variable.Variable) []Variable {
+	type id struct {
+		category variable.VariableCategory
+		key      string
+	}
+	byID := make(map[id]*Variable)
+	var ids []id
+	add := func(vars []*variable.Variable, set func(cmp *Variable, value *VariableValue)) {
+		for _, v := range vars {
+			vid := id{v.Category, v.Key}
+			cmp, ok := byID[vid]
+			if !ok {
+				cmp = &Variable{Key: v.Key, Category: v.Category}
+				byID[vid] = cmp
+				ids = append(ids, vid)
+			}
+			value := &VariableValue{Sensitive: v.Sensitive, HCL: v.HCL}
+			if !v.Sensitive {
+				value.Value = &v.Value
+			}
+			set(cmp, value)
+		}
+	}
+	add(leftVars, func(cmp *Variable, value *VariableValue) { cmp.Left = value })
+	add(rightVars, func(cmp *Variable, value *VariableValue) { cmp.Right = value })
+
+	slices.SortFunc(ids, func(a, b id) int {
+		if c := strings.Compare(string(a.category), string(b.category)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.key, b.key)
+	})
+	variables := make([]Variable, len(ids))
+	for i, vid := range ids {
+		cmp := byID[vid]
+		cmp.Differs = valuesDiffer(cmp.Left, cmp.Right)
+		variables[i] = *cmp
+	}
+	return variables
+}
+
+func valuesDiffer(left, right *VariableValue) bool {
+	if left == nil || right == nil {
+		return left != right
+	}
+	if left.Sensitive != right.Sensitive || left.HCL != right.HCL {
+		return true
+	}
+	if left.Sensitive {
+		// both are set, which is all that can be said of sensitive values
+		return false
+	}
+	return *left.Value != *right.Value
+}
+
+func joinSorted(values []string) string {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return strings.Join(sorted, ", ")
+}
@@ -0,0 +1,157 @@
+package compare
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/variable"
+	"github.com/tofutf/tofutf/internal/workspace"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestCompare(t *testing.T) {
+	left := &workspace.Workspace{
+		ID:               "ws-staging",
+		Name:             "staging",
+		Organization:     "acme",
+		TerraformVersion: "1.5.0",
+		ExecutionMode:    workspace.RemoteExecutionMode,
+		Tags:             []string{"b", "a"},
+		TriggerPatterns:  []string{"/modules/**"},
+	}
+	right := &workspace.Workspace{
+		ID:               "ws-prod",
+		Name:             "prod",
+		Organization:     "acme",
+		TerraformVersion: "1.6.0",
+		ExecutionMode:    workspace.AgentExecutionMode,
+		AgentPoolID:      internal.String("apool-1"),
+		Tags:             []string{"a", "b"},
+		TriggerPatterns:  []string{"/modules/**"},
+	}
+
+	got := compare(left, right, nil, nil)
+
+	assert.Equal(t, "ws-staging..ws-prod", got.ID)
+	assert.Equal(t, []Field{
+		{Name: "terraform-version", Left: "1.5.0", Right: "1.6.0", Differs: true},
+		{Name: "execution-mode", Left: "remote", Right: "agent", Differs: true},
+		{Name: "agent-pool", Left: "", Right: "apool-1", Differs: true},
+		{Name: "trigger-patterns", Left: "/modules/**", Right: "/modules/**"},
+		{Name: "tags", Left: "a, b", Right: "a, b"},
+	}, got.Fields)
+	assert.True(t, got.Differs())
+
+	assert.False(t, compare(left, left, nil, nil).Differs())
+}
+
+func TestCompareVariables(t *testing.T) {
+	terraformVar := func(key, value string, sensitive bool) *variable.Variable {
+		return &variable.Variable{Key: key, Value: value, Category: variable.CategoryTerraform, Sensitive: sensitive}
+	}
+	leftVars := []*variable.Variable{
+		terraformVar("region", "eu-west-1", false),
+		terraformVar("instances", "2", false),
+		terraformVar("password", "staging-secret", true),
+		terraformVar("api_key", "same-secret", true),
+		terraformVar("token", "not-so-secret", false),
+		terraformVar("staging_only", "x", false),
+		{Key: "region", Value: "eu-west-1", Category: variable.CategoryEnv},
+	}
+	rightVars := []*variable.Variable{
+		terraformVar("region", "us-east-1", false),
+		terraformVar("instances", "2", false),
+		terraformVar("password", "prod-secret", true),
+		terraformVar("api_key", "same-secret", true),
+		terraformVar("token", "prod-token", true),
+		terraformVar("prod_only", "prod-only-secret", true),
+	}
+
+	got := compareVariables(leftVars, rightVars)
+
+	value := func(v string) *VariableValue { return &VariableValue{Value: &v} }
+	sensitive := &VariableValue{Sensitive: true}
+	assert.Equal(t, []Variable{
+		{Key: "region", Category: variable.CategoryEnv, Left: value("eu-west-1"), Differs: true},
+		{Key: "api_key", Category: variable.CategoryTerraform, Left: sensitive, Right: sensitive},
+		{Key: "instances", Category: variable.CategoryTerraform, Left: value("2"), Right: value("2")},
+		// sensitive values are only compared by whether they are set
+		{Key: "password", Category: variable.CategoryTerraform, Left: sensitive, Right: sensitive},
+		{Key: "prod_only", Category: variable.CategoryTerraform, Right: sensitive, Differs: true},
+		{Key: "region", Category: variable.CategoryTerraform, Left: value("eu-west-1"), Right: value("us-east-1"), Differs: true},
+		{Key: "staging_only", Category: variable.CategoryTerraform, Left: value("x"), Differs: true},
+		{Key: "token", Category: variable.CategoryTerraform, Left: value("not-so-secret"), Right: sensitive, Differs: true},
+	}, got)
+
+	// no trace of a sensitive value should appear in the comparison
+	marshaled, err := json.Marshal(got)
+	require.NoError(t, err)
+	for _, secret := range []string{"staging-secret", "prod-secret", "same-secret", "prod-token", "prod-only-secret"} {
+		assert.NotContains(t, string(marshaled), secret)
+	}
+}
+
+func TestService_Compare(t *testing.T) {
+	ctx := context.Background()
+	workspaces := &fakeWorkspaceClient{}
+	variables := &fakeVariableClient{}
+	svc := &Service{
+		logger:     slog.New(&xslog.NoopHandler{}),
+		workspaces: workspaces,
+		variables:  variables,
+	}
+
+	t.Run("permitted", func(t *testing.T) {
+		svc.workspace = internal.NewAllowAllAuthorizer()
+
+		got, err := svc.Compare(ctx, "ws-left", "ws-right")
+		require.NoError(t, err)
+		assert.Equal(t, "ws-left..ws-right", got.ID)
+	})
+
+	t.Run("denied access to one workspace", func(t *testing.T) {
+		workspaces.retrieved, variables.retrieved = nil, nil
+		svc.workspace = &fakeAuthorizer{deny: "ws-right"}
+
+		_, err := svc.Compare(ctx, "ws-left", "ws-right")
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+		// nothing should be retrieved from either workspace
+		assert.Empty(t, workspaces.retrieved)
+		assert.Empty(t, variables.retrieved)
+	})
+}
+
+type (
+	fakeAuthorizer struct {
+		deny string
+	}
+	fakeWorkspaceClient struct {
+		retrieved []string
+	}
+	fakeVariableClient struct {
+		retrieved []string
+	}
+)
+
+func (f *fakeAuthorizer) CanAccess(_ context.Context, _ rbac.Action, id string) (internal.Subject, error) {
+	if id == f.deny {
+		return nil, internal.ErrAccessNotPermitted
+	}
+	return &internal.Superuser{}, nil
+}
+
+func (f *fakeWorkspaceClient) Get(_ context.Context, workspaceID string) (*workspace.Workspace, error) {
+	f.retrieved = append(f.retrieved, workspaceID)
+	return &workspace.Workspace{ID: workspaceID}, nil
+}
+
+func (f *fakeVariableClient) ListWorkspaceVariables(_ context.Context, workspaceID string) ([]*variable.Variable, error) {
+	f.retrieved = append(f.retrieved, workspaceID)
+	return nil, nil
+}
@@ -0,0 +1,108 @@
+package compare
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/variable"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+type (
+	Service struct {
+		logger *slog.Logger
+
+		workspace  internal.Authorizer
+		workspaces workspaceClient
+		variables  variableClient
+		api        *api
+		web        *webHandlers
+	}
+
+	Options struct {
+		Logger *slog.Logger
+
+		*tfeapi.Responder
+		html.Renderer
+
+		WorkspaceService *workspace.Service
+		VariableService  *variable.Service
+	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+
+	variableClient interface {
+		ListWorkspaceVariables(ctx context.Context, workspaceID string) ([]*variable.Variable, error)
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		logger:     opts.Logger,
+		workspace:  opts.WorkspaceService,
+		workspaces: opts.WorkspaceService,
+		variables:  opts.VariableService,
+	}
+	svc.api = &api{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	svc.web = &webHandlers{
+		Renderer:   opts.Renderer,
+		workspaces: opts.WorkspaceService,
+		svc:        &svc,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+	s.web.addHandlers(r)
+}
+
+// Compare the settings of two workspaces. The subject must have permission to
+// read both workspaces.
+func (s *Service) Compare(ctx context.Context, leftID, rightID string) (*Comparison, error) {
+	// check access to both workspaces before retrieving anything from
+	// either
+	subject, err := s.workspace.CanAccess(ctx, rbac.GetWorkspaceAction, leftID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.workspace.CanAccess(ctx, rbac.GetWorkspaceAction, rightID); err != nil {
+		return nil, err
+	}
+
+	left, leftVars, err := s.get(ctx, leftID)
+	if err != nil {
+		s.logger.Error("comparing workspaces", "left", leftID, "right", rightID, "subject", subject, "err", err)
+		return nil, err
+	}
+	right, rightVars, err := s.get(ctx, rightID)
+	if err != nil {
+		s.logger.Error("comparing workspaces", "left", leftID, "right", rightID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("compared workspaces", "left", leftID, "right", rightID, "subject", subject)
+
+	return compare(left, right, leftVars, rightVars), nil
+}
+
+func (s *Service) get(ctx context.Context, workspaceID string) (*workspace.Workspace, []*variable.Variable, error) {
+	ws, err := s.workspaces.Get(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	vars, err := s.variables.ListWorkspaceVariables(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ws, vars, nil
+}
@@ -0,0 +1,64 @@
+package compare
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+type webHandlers struct {
+	html.Renderer
+
+	workspaces workspaceClient
+	svc        webClient
+}
+
+type webClient interface {
+	Compare(ctx context.Context, leftID, rightID string) (*Comparison, error)
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/workspaces/{workspace_id}/compare", h.compare).Methods("GET")
+}
+
+// compare renders the comparison of a workspace with the workspace specified
+// by the right query parameter, or, if there is no such parameter, a form for
+// specifying the workspace to compare with.
+func (h *webHandlers) compare(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		Right       string `schema:"right"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	ws, err := h.workspaces.Get(r.Context(), params.WorkspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var comparison *Comparison
+	if params.Right != "" {
+		comparison, err = h.svc.Compare(r.Context(), params.WorkspaceID, params.Right)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	h.Render("workspace_compare.tmpl", w, struct {
+		workspace.WorkspacePage
+		Right      string
+		Comparison *Comparison
+	}{
+		WorkspacePage: workspace.NewPage(r, "compare | "+ws.ID, ws),
+		Right:         params.Right,
+		Comparison:    comparison,
+	})
+}
@@ -0,0 +1,53 @@
+package compare
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tofutf/tofutf/internal/testutils"
+	"github.com/tofutf/tofutf/internal/variable"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+func TestWeb_Compare(t *testing.T) {
+	h := &webHandlers{
+		Renderer:   testutils.NewRenderer(t),
+		workspaces: &fakeWorkspaceClient{},
+		svc: &fakeComparer{
+			comparison: compare(
+				&workspace.Workspace{ID: "ws-staging", TerraformVersion: "1.5.0"},
+				&workspace.Workspace{ID: "ws-prod", TerraformVersion: "1.6.0"},
+				[]*variable.Variable{{Key: "password", Value: "staging-secret", Category: variable.CategoryTerraform, Sensitive: true}},
+				[]*variable.Variable{{Key: "password", Value: "prod-secret", Category: variable.CategoryTerraform, Sensitive: true}},
+			),
+		},
+	}
+
+	t.Run("form", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?workspace_id=ws-staging", nil)
+		w := httptest.NewRecorder()
+		h.compare(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+		assert.NotContains(t, w.Body.String(), "comparison-table")
+	})
+
+	t.Run("comparison", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?workspace_id=ws-staging&right=ws-prod", nil)
+		w := httptest.NewRecorder()
+		h.compare(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `id="field-terraform-version"`)
+		assert.NotContains(t, w.Body.String(), "staging-secret")
+		assert.NotContains(t, w.Body.String(), "prod-secret")
+	})
+}
+
+type fakeComparer struct {
+	comparison *Comparison
+}
+
+func (f *fakeComparer) Compare(context.Context, string, string) (*Comparison, error) {
+	return f.comparison, nil
+}
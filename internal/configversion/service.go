@@ -7,6 +7,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/leg100/surl"
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/idempotency"
 	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/sql"
@@ -29,6 +30,7 @@ type (
 
 		WorkspaceAuthorizer internal.Authorizer
 		MaxConfigSize       int64
+		IdempotencyService  *idempotency.Service
 
 		internal.Cache
 		*sql.Pool
@@ -52,6 +54,7 @@ func NewService(opts Options) *Service {
 		Signer:        opts.Signer,
 		Responder:     opts.Responder,
 		maxConfigSize: opts.MaxConfigSize,
+		idempotency:   opts.IdempotencyService,
 	}
 	svc.api = &api{
 		Service:   &svc,
@@ -16,6 +16,7 @@ import (
 	"github.com/tofutf/tofutf/internal"
 	otfhttp "github.com/tofutf/tofutf/internal/http"
 	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/idempotency"
 	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/tfeapi/types"
@@ -29,6 +30,7 @@ type tfe struct {
 	*tfeapi.Responder
 
 	maxConfigSize int64 // Maximum permitted config upload size in bytes
+	idempotency   *idempotency.Service
 }
 
 // tfeConfigsClient gives the tfe handlers access to config version services
@@ -61,8 +63,13 @@ func (a *tfe) createConfigurationVersion(w http.ResponseWriter, r *http.Request)
 		tfeapi.Error(w, err)
 		return
 	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
 	params := types.ConfigurationVersionCreateOptions{}
-	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+	if err := tfeapi.Unmarshal(bytes.NewReader(body), &params); err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
@@ -76,7 +83,19 @@ func (a *tfe) createConfigurationVersion(w http.ResponseWriter, r *http.Request)
 		opts.Source = SourceTerraform
 	}
 
-	cv, err := a.Create(r.Context(), workspaceID, opts)
+	var cv *ConfigurationVersion
+	id, replayed, err := a.idempotency.Do(r, body, func() (string, error) {
+		created, err := a.Create(r.Context(), workspaceID, opts)
+		if err != nil {
+			return "", err
+		}
+		cv = created
+		return created.ID, nil
+	})
+	if replayed {
+		w.Header().Set(idempotency.ReplayedHeader, "true")
+		cv, err = a.Get(r.Context(), id)
+	}
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
@@ -10,6 +10,7 @@ import (
 	"github.com/tofutf/tofutf/internal/repohooks"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
+	"github.com/tofutf/tofutf/internal/vcs"
 	"github.com/tofutf/tofutf/internal/vcsprovider"
 )
 
@@ -53,6 +54,7 @@ type (
 		Logger             *slog.Logger
 		VCSProviderService *vcsprovider.Service
 		RepoHooksService   *repohooks.Service
+		VCSEventSubscriber vcs.Subscriber
 	}
 
 	Service struct {
@@ -65,12 +67,15 @@ type (
 )
 
 func NewService(ctx context.Context, opts Options) *Service {
-	return &Service{
+	svc := &Service{
 		logger:       opts.Logger,
 		vcsproviders: opts.VCSProviderService,
 		repohooks:    opts.RepoHooksService,
 		db:           &db{opts.Pool},
 	}
+	// update connections when a repo is renamed or transferred
+	opts.VCSEventSubscriber.Subscribe(svc.handleEvent)
+	return svc
 }
 
 // Connect an OTF resource to a VCS repo.
@@ -117,3 +122,23 @@ func (s *Service) Disconnect(ctx context.Context, opts DisconnectOptions) error
 		return nil
 	})
 }
+
+// NewReconciler constructs a reconciler that repairs the webhooks of connected
+// repos and heals the paths of repos that have moved.
+func (s *Service) NewReconciler(logger *slog.Logger) *Reconciler {
+	return newReconciler(logger, s)
+}
+
+func (s *Service) getVCSClient(ctx context.Context, vcsProviderID string) (vcs.Client, error) {
+	return s.vcsproviders.GetVCSClient(ctx, vcsProviderID)
+}
+
+// repairRepohook re-synchronises the webhook of a connected repo with the vcs
+// provider, re-creating the webhook if it has gone missing.
+func (s *Service) repairRepohook(ctx context.Context, repo Connection) error {
+	_, err := s.repohooks.CreateRepohook(ctx, repohooks.CreateRepohookOptions{
+		VCSProviderID: repo.VCSProviderID,
+		RepoPath:      repo.Repo,
+	})
+	return err
+}
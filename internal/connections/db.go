@@ -54,3 +54,97 @@ func (db *db) deleteConnection(ctx context.Context, opts DisconnectOptions) (err
 		return err
 	})
 }
+
+// updateRepoPath updates the path of a repo in its connections, returning the
+// IDs of the connected workspaces and modules.
+func (db *db) updateRepoPath(ctx context.Context, opts MoveRepoOptions) (workspaceIDs, moduleIDs []string, err error) {
+	err = db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		rows, err := q.UpdateRepoConnectionsRepoPath(ctx, pggen.UpdateRepoConnectionsRepoPathParams{
+			ToRepoPath:    sql.String(opts.To),
+			VCSProviderID: sql.String(opts.VCSProviderID),
+			FromRepoPath:  sql.String(opts.From),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		for _, row := range rows {
+			if row.WorkspaceID.Valid {
+				workspaceIDs = append(workspaceIDs, row.WorkspaceID.String)
+			}
+			if row.ModuleID.Valid {
+				moduleIDs = append(moduleIDs, row.ModuleID.String)
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// listConnectedRepos lists each repo connected to at least one workspace or
+// module.
+func (db *db) listConnectedRepos(ctx context.Context) ([]Connection, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]Connection, error) {
+		rows, err := q.FindConnectedRepos(ctx)
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		repos := make([]Connection, len(rows))
+		for i, row := range rows {
+			repos[i] = Connection{
+				VCSProviderID: row.VCSProviderID.String,
+				Repo:          row.RepoPath.String,
+			}
+		}
+		return repos, nil
+	})
+}
+
+func (db *db) createRepoMove(ctx context.Context, move *RepoMove) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertRepoMove(ctx, pggen.InsertRepoMoveParams{
+			RepoMoveID:    sql.String(move.ID),
+			VCSProviderID: sql.String(move.VCSProviderID),
+			FromRepoPath:  sql.String(move.From),
+			ToRepoPath:    sql.String(move.To),
+			DetectedBy:    sql.String(string(move.DetectedBy)),
+			WorkspaceIDs:  nonNil(move.WorkspaceIDs),
+			ModuleIDs:     nonNil(move.ModuleIDs),
+			MovedAt:       sql.Timestamptz(move.MovedAt),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+func (db *db) listWorkspaceRepoMoves(ctx context.Context, workspaceID string) ([]*RepoMove, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*RepoMove, error) {
+		rows, err := q.FindRepoMovesByWorkspaceID(ctx, sql.String(workspaceID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		moves := make([]*RepoMove, len(rows))
+		for i, row := range rows {
+			moves[i] = &RepoMove{
+				ID:            row.RepoMoveID.String,
+				VCSProviderID: row.VCSProviderID.String,
+				From:          row.FromRepoPath.String,
+				To:            row.ToRepoPath.String,
+				DetectedBy:    MoveDetector(row.DetectedBy.String),
+				WorkspaceIDs:  row.WorkspaceIDs,
+				ModuleIDs:     row.ModuleIDs,
+				MovedAt:       row.MovedAt.Time.UTC(),
+			}
+		}
+		return moves, nil
+	})
+}
+
+// nonNil returns an empty slice in place of nil, for a NOT NULL array column.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
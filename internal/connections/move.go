@@ -0,0 +1,122 @@
+package connections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+	"github.com/tofutf/tofutf/internal/vcs"
+)
+
+const (
+	// MoveDetectedByWebhook is a move detected by a webhook event sent by
+	// the vcs provider when the repo was renamed or transferred.
+	MoveDetectedByWebhook MoveDetector = "webhook"
+	// MoveDetectedByReconciler is a move detected by the reconciler finding
+	// the vcs provider redirects requests for the repo to its new path.
+	MoveDetectedByReconciler MoveDetector = "reconciler"
+)
+
+type (
+	// MoveDetector identifies how a repo move was detected.
+	MoveDetector string
+
+	// RepoMove records a connected repo that has been renamed or transferred,
+	// and the workspaces and modules whose connections were updated to its
+	// new path.
+	RepoMove struct {
+		ID            string
+		VCSProviderID string
+		From          string // path of repo before it was moved
+		To            string // path of repo after it was moved
+		DetectedBy    MoveDetector
+		WorkspaceIDs  []string
+		ModuleIDs     []string
+		MovedAt       time.Time
+	}
+
+	MoveRepoOptions struct {
+		VCSProviderID string // vcs provider of repo
+		From          string // path of repo before it was moved
+		To            string // path of repo after it was moved
+		DetectedBy    MoveDetector
+	}
+)
+
+func (m *RepoMove) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", m.ID),
+		slog.String("vcs_provider_id", m.VCSProviderID),
+		slog.String("from", m.From),
+		slog.String("to", m.To),
+		slog.String("detected_by", string(m.DetectedBy)),
+		slog.Int("workspaces", len(m.WorkspaceIDs)),
+		slog.Int("modules", len(m.ModuleIDs)),
+	)
+}
+
+// MoveRepo updates the path of a repo that has been renamed or transferred,
+// updating its webhooks and its connections to workspaces and modules, and
+// recording the move along with its previous path. Returns
+// internal.ErrResourceNotFound if nothing is connected to the repo at its
+// previous path.
+func (s *Service) MoveRepo(ctx context.Context, opts MoveRepoOptions) (*RepoMove, error) {
+	move := &RepoMove{
+		ID:            internal.NewID("rm"),
+		VCSProviderID: opts.VCSProviderID,
+		From:          opts.From,
+		To:            opts.To,
+		DetectedBy:    opts.DetectedBy,
+		MovedAt:       internal.CurrentTimestamp(nil),
+	}
+	err := s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) (err error) {
+		move.WorkspaceIDs, move.ModuleIDs, err = s.db.updateRepoPath(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("updating connections: %w", err)
+		}
+		if len(move.WorkspaceIDs) == 0 && len(move.ModuleIDs) == 0 {
+			return internal.ErrResourceNotFound
+		}
+		if err := s.repohooks.UpdateRepoPath(ctx, opts.VCSProviderID, opts.From, opts.To); err != nil {
+			return fmt.Errorf("updating webhooks: %w", err)
+		}
+		return s.db.createRepoMove(ctx, move)
+	})
+	if err != nil {
+		if !errors.Is(err, internal.ErrResourceNotFound) {
+			s.logger.Error("moving repo", "move", move, "err", err)
+		}
+		return nil, err
+	}
+	s.logger.Info("moved repo", "move", move)
+	return move, nil
+}
+
+// ListWorkspaceRepoMoves lists the moves of repos connected to a workspace,
+// most recent first.
+func (s *Service) ListWorkspaceRepoMoves(ctx context.Context, workspaceID string) ([]*RepoMove, error) {
+	return s.db.listWorkspaceRepoMoves(ctx, workspaceID)
+}
+
+// handleEvent moves a repo in response to a vcs event reporting the repo has
+// been renamed or transferred.
+func (s *Service) handleEvent(event vcs.Event) {
+	if event.Type != vcs.EventTypeRepository || event.Action != vcs.ActionMoved {
+		return
+	}
+	// no parent context; handler is called asynchronously
+	ctx := internal.AddSubjectToContext(context.Background(), &internal.Superuser{Username: "connections-service"})
+	_, err := s.MoveRepo(ctx, MoveRepoOptions{
+		VCSProviderID: event.VCSProviderID,
+		From:          event.PreviousRepoPath,
+		To:            event.RepoPath,
+		DetectedBy:    MoveDetectedByWebhook,
+	})
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		s.logger.Debug("ignoring repo move: repo not connected", "vcs_provider_id", event.VCSProviderID, "from", event.PreviousRepoPath)
+	}
+}
@@ -0,0 +1,117 @@
+package connections
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/vcs"
+)
+
+// ReconcilerLockID guarantees only one reconciler on a cluster is running at
+// any time.
+const ReconcilerLockID int64 = 5577006791947779425
+
+const defaultReconcilerInterval = time.Hour
+
+type (
+	// Reconciler periodically repairs the webhooks of connected repos. In
+	// doing so it also checks each repo is still found at its stored path: a
+	// repo renamed or transferred without a webhook event reporting the move
+	// is instead found via the redirect the vcs provider issues for its old
+	// path, in which case the stored path is healed.
+	//
+	// Only one reconciler should be running on a cluster at any one time.
+	Reconciler struct {
+		logger *slog.Logger
+		client reconcilerClient
+		// frequency with which the reconciler checks connected repos.
+		interval time.Duration
+	}
+
+	reconcilerClient interface {
+		listConnectedRepos(ctx context.Context) ([]Connection, error)
+		getVCSClient(ctx context.Context, vcsProviderID string) (vcs.Client, error)
+		repairRepohook(ctx context.Context, repo Connection) error
+		MoveRepo(ctx context.Context, opts MoveRepoOptions) (*RepoMove, error)
+	}
+)
+
+func newReconciler(logger *slog.Logger, client reconcilerClient) *Reconciler {
+	return &Reconciler{
+		logger:   logger.With("component", "repo-reconciler"),
+		client:   client,
+		interval: defaultReconcilerInterval,
+	}
+}
+
+func (r *Reconciler) String() string { return "repo-reconciler" }
+
+// Start the reconciler. Should be invoked in a go routine.
+func (r *Reconciler) Start(ctx context.Context) error {
+	// give reconciler unlimited powers
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "repo-reconciler"})
+
+	// webhooks are repaired when a repo is first connected, so the first
+	// reconciliation waits for the first interval.
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.reconcile(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reconcile checks each connected repo, healing the path of those that have
+// moved, and repairs its webhook. A repo that cannot be checked or repaired is
+// logged and left for the next reconciliation.
+func (r *Reconciler) reconcile(ctx context.Context) error {
+	repos, err := r.client.listConnectedRepos(ctx)
+	if err != nil {
+		return err
+	}
+	for _, repo := range repos {
+		logger := r.logger.With("vcs_provider_id", repo.VCSProviderID, "repo", repo.Repo)
+		client, err := r.client.getVCSClient(ctx, repo.VCSProviderID)
+		if err != nil {
+			logger.Error("retrieving vcs client", "err", err)
+			continue
+		}
+		found, err := client.GetRepository(ctx, repo.Repo)
+		if err != nil {
+			logger.Error("retrieving repository", "err", err)
+			continue
+		}
+		// repo paths are case-insensitive on some vcs providers, so only a
+		// change other than to case is deemed a move.
+		if found.Path != "" && !strings.EqualFold(found.Path, repo.Repo) {
+			_, err := r.client.MoveRepo(ctx, MoveRepoOptions{
+				VCSProviderID: repo.VCSProviderID,
+				From:          repo.Repo,
+				To:            found.Path,
+				DetectedBy:    MoveDetectedByReconciler,
+			})
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				// repo disconnected in the meantime
+				continue
+			} else if err != nil {
+				logger.Error("healing moved repo", "to", found.Path, "err", err)
+				continue
+			}
+			repo.Repo = found.Path
+		}
+		if err := r.client.repairRepohook(ctx, repo); err != nil {
+			logger.Error("repairing webhook", "err", err)
+		}
+	}
+	return nil
+}
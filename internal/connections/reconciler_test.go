@@ -0,0 +1,44 @@
+package connections
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestReconciler(t *testing.T) {
+	client := &fakeReconcilerClient{
+		repos: []Connection{
+			{VCSProviderID: "vcs-1", Repo: "leg100/otf-workspaces"},
+			{VCSProviderID: "vcs-1", Repo: "Leg100/Unmoved"},
+		},
+		moved: map[string]string{
+			"leg100/otf-workspaces": "tofutf/otf-workspaces",
+		},
+	}
+	r := newReconciler(slog.New(&xslog.NoopHandler{}), client)
+
+	err := r.reconcile(context.Background())
+	require.NoError(t, err)
+
+	// only the moved repo should be healed; a difference in case alone is
+	// not a move.
+	assert.Equal(t, []MoveRepoOptions{
+		{
+			VCSProviderID: "vcs-1",
+			From:          "leg100/otf-workspaces",
+			To:            "tofutf/otf-workspaces",
+			DetectedBy:    MoveDetectedByReconciler,
+		},
+	}, client.moves)
+
+	// webhooks should be repaired at the current path of each repo.
+	assert.Equal(t, []Connection{
+		{VCSProviderID: "vcs-1", Repo: "tofutf/otf-workspaces"},
+		{VCSProviderID: "vcs-1", Repo: "Leg100/Unmoved"},
+	}, client.repaired)
+}
@@ -0,0 +1,51 @@
+package connections
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tofutf/tofutf/internal/vcs"
+)
+
+type (
+	fakeReconcilerClient struct {
+		repos []Connection
+		// moved maps the path of a repo to the path it has moved to
+		moved map[string]string
+
+		moves    []MoveRepoOptions
+		repaired []Connection
+	}
+
+	fakeReconcilerVCSClient struct {
+		moved map[string]string
+
+		vcs.Client
+	}
+)
+
+func (f *fakeReconcilerClient) listConnectedRepos(context.Context) ([]Connection, error) {
+	return f.repos, nil
+}
+
+func (f *fakeReconcilerClient) getVCSClient(context.Context, string) (vcs.Client, error) {
+	return &fakeReconcilerVCSClient{moved: f.moved}, nil
+}
+
+func (f *fakeReconcilerClient) repairRepohook(_ context.Context, repo Connection) error {
+	f.repaired = append(f.repaired, repo)
+	return nil
+}
+
+func (f *fakeReconcilerClient) MoveRepo(_ context.Context, opts MoveRepoOptions) (*RepoMove, error) {
+	f.moves = append(f.moves, opts)
+	return &RepoMove{From: opts.From, To: opts.To}, nil
+}
+
+func (f *fakeReconcilerVCSClient) GetRepository(_ context.Context, identifier string) (vcs.Repository, error) {
+	if to, ok := f.moved[identifier]; ok {
+		return vcs.Repository{Path: to}, nil
+	}
+	// mimic a provider that is case-insensitive
+	return vcs.Repository{Path: strings.ToLower(identifier)}, nil
+}
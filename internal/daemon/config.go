@@ -2,13 +2,17 @@ package daemon
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/agent"
 	"github.com/tofutf/tofutf/internal/authenticator"
 	"github.com/tofutf/tofutf/internal/configversion"
 	"github.com/tofutf/tofutf/internal/inmem"
+	"github.com/tofutf/tofutf/internal/secret/vault"
 	"github.com/tofutf/tofutf/internal/tokens"
+	"github.com/tofutf/tofutf/internal/vcs"
 )
 
 var ErrInvalidSecretLength = errors.New("secret must be 16 bytes in size")
@@ -29,24 +33,60 @@ type Config struct {
 
 	BitbucketServerHostname string
 
-	OIDC                         authenticator.OIDCConfig
-	Secret                       []byte // 16-byte secret for signing URLs and encrypting payloads
-	SiteToken                    string
-	Host                         string
-	WebhookHost                  string
-	Address                      string
-	Database                     string
-	MaxConfigSize                int64
-	SSL                          bool
-	CertFile, KeyFile            string
-	EnableRequestLogging         bool
-	DevMode                      bool
-	DisableScheduler             bool
-	RestrictOrganizationCreation bool
-	SiteAdmins                   []string
-	SkipTLSVerification          bool
+	// how events with an unknown type are handled for each cloud
+	GithubUnknownEvents          vcs.UnknownEventPolicy
+	GitlabUnknownEvents          vcs.UnknownEventPolicy
+	BitbucketServerUnknownEvents vcs.UnknownEventPolicy
+
+	OIDC                        authenticator.OIDCConfig
+	Vault                       vault.Config
+	Secret                      []byte // 16-byte secret for signing URLs and encrypting payloads
+	SiteToken                   string
+	AuthLockoutThreshold        int
+	AuthLockoutDuration         time.Duration
+	TrustedProxies              []string
+	Host                        string
+	WebhookHost                 string
+	WebhookReplayMaxAge         time.Duration
+	EventSinks                  []string
+	EventSinkHMACSecret         string
+	Address                     string
+	Database                    string
+	MaxConfigSize               int64
+	MaxArtifactSize             int
+	MaxArtifacts                int
+	ForceCancelCoolOff          time.Duration
+	RejectPausedWorkspaceRuns   bool
+	PriorityAging               time.Duration
+	AgentJobDispatchers         int
+	AgentMaxPools               int
+	AgentPoolRecoveryWindow     time.Duration
+	AgentJobDiskEstimate        int64
+	AgentStatusHistoryRetention time.Duration
+	MaxRunLogSize               int
+	MaxDebugRunLogSize          int
+	MaxConcurrentLogWrites      int
+	SSL                         bool
+	CertFile, KeyFile           string
+	EnableRequestLogging        bool
+	DevMode                     bool
+	DisableScheduler            bool
+	// don't treat a workspace's working directory as an implicit trigger
+	// prefix for VCS events
+	DisableWorkingDirectoryTriggers bool
+	TraceJobs                       bool
+	RestrictOrganizationCreation    bool
+	SiteAdmins                      []string
+	SkipTLSVerification             bool
 	// skip checks for latest terraform version
 	DisableLatestChecker *bool
+	// periodically check for newer releases of tofutf
+	CheckForUpgrades bool
+	// require module versions uploaded directly to be signed
+	RequireModuleSignatures bool
+	// continue serving previously downloaded versions of a module for this
+	// long after a share is revoked
+	ModuleShareGracePeriod time.Duration
 
 	// ProviderProxy configures tofutf's built in provider proxy.
 	ProviderProxy struct {
@@ -71,12 +111,53 @@ func ApplyDefaults(cfg *Config) {
 	}
 }
 
+// Valid validates the config, reporting every problem found rather than just
+// the first.
 func (cfg *Config) Valid() error {
+	var errs []error
 	if cfg.Secret == nil {
-		return &internal.MissingParameterError{Parameter: "secret"}
+		errs = append(errs, &internal.MissingParameterError{Parameter: "secret"})
+	} else if len(cfg.Secret) != 16 {
+		errs = append(errs, ErrInvalidSecretLength)
+	}
+	for _, policy := range []vcs.UnknownEventPolicy{cfg.GithubUnknownEvents, cfg.GitlabUnknownEvents, cfg.BitbucketServerUnknownEvents} {
+		if err := policy.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if cfg.SSL {
+		if cfg.CertFile == "" {
+			errs = append(errs, errors.New("cert-file is required when ssl is enabled"))
+		}
+		if cfg.KeyFile == "" {
+			errs = append(errs, errors.New("key-file is required when ssl is enabled"))
+		}
+	}
+	for _, creds := range []struct{ name, id, secret string }{
+		{"github", cfg.GithubClientID, cfg.GithubClientSecret},
+		{"gitlab", cfg.GitlabClientID, cfg.GitlabClientSecret},
+		{"oidc", cfg.OIDC.ClientID, cfg.OIDC.ClientSecret},
+	} {
+		if (creds.id == "") != (creds.secret == "") {
+			errs = append(errs, fmt.Errorf("%[1]s-client-id and %[1]s-client-secret must be set together", creds.name))
+		}
 	}
-	if len(cfg.Secret) != 16 {
-		return ErrInvalidSecretLength
+	if cfg.OIDC.ClientID != "" && cfg.OIDC.IssuerURL == "" {
+		errs = append(errs, errors.New("oidc-issuer-url is required when oidc-client-id is set"))
+	}
+	if cfg.Vault.Address != "" {
+		switch cfg.Vault.AuthMethod {
+		case vault.AppRoleAuthMethod:
+			if cfg.Vault.RoleID == "" {
+				errs = append(errs, errors.New("vault-role-id is required by the approle auth method"))
+			}
+		case vault.KubernetesAuthMethod:
+			if cfg.Vault.KubernetesRole == "" {
+				errs = append(errs, errors.New("vault-kubernetes-role is required by the kubernetes auth method"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%w: %s", vault.ErrUnknownAuthMethod, cfg.Vault.AuthMethod))
+		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
@@ -0,0 +1,53 @@
+package daemon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/secret/vault"
+	"github.com/tofutf/tofutf/internal/vcs"
+)
+
+func TestConfig_Valid(t *testing.T) {
+	secret := []byte("abcdefghijklmnop")
+
+	t.Run("valid", func(t *testing.T) {
+		cfg := Config{Secret: secret}
+		assert.NoError(t, cfg.Valid())
+	})
+	t.Run("report every problem", func(t *testing.T) {
+		cfg := Config{
+			SSL:                 true,
+			GithubClientID:      "id",
+			GithubUnknownEvents: vcs.UnknownEventPolicy("strict"),
+			Vault:               vault.Config{Address: "https://vault.example.com", AuthMethod: vault.AppRoleAuthMethod},
+		}
+		err := cfg.Valid()
+		require.Error(t, err)
+
+		var missing *internal.MissingParameterError
+		assert.True(t, errors.As(err, &missing))
+		assert.ErrorIs(t, err, vcs.ErrInvalidUnknownEventPolicy)
+		assert.ErrorContains(t, err, "cert-file is required when ssl is enabled")
+		assert.ErrorContains(t, err, "key-file is required when ssl is enabled")
+		assert.ErrorContains(t, err, "github-client-id and github-client-secret must be set together")
+		assert.ErrorContains(t, err, "vault-role-id is required by the approle auth method")
+	})
+	t.Run("invalid secret length", func(t *testing.T) {
+		cfg := Config{Secret: []byte("too-short")}
+		assert.ErrorIs(t, cfg.Valid(), ErrInvalidSecretLength)
+	})
+	t.Run("oidc requires issuer url", func(t *testing.T) {
+		cfg := Config{Secret: secret}
+		cfg.OIDC.ClientID = "id"
+		cfg.OIDC.ClientSecret = "secret"
+		assert.ErrorContains(t, cfg.Valid(), "oidc-issuer-url is required when oidc-client-id is set")
+	})
+	t.Run("unknown vault auth method", func(t *testing.T) {
+		cfg := Config{Secret: secret, Vault: vault.Config{Address: "https://vault.example.com", AuthMethod: "ldap"}}
+		assert.ErrorIs(t, cfg.Valid(), vault.ErrUnknownAuthMethod)
+	})
+}
@@ -12,38 +12,55 @@ import (
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/agent"
 	"github.com/tofutf/tofutf/internal/api"
+	"github.com/tofutf/tofutf/internal/assessment"
 	"github.com/tofutf/tofutf/internal/authenticator"
 	"github.com/tofutf/tofutf/internal/bitbucketserver"
+	"github.com/tofutf/tofutf/internal/compare"
 	"github.com/tofutf/tofutf/internal/configversion"
 	"github.com/tofutf/tofutf/internal/connections"
 	"github.com/tofutf/tofutf/internal/disco"
+	"github.com/tofutf/tofutf/internal/eventsink"
 	"github.com/tofutf/tofutf/internal/ghapphandler"
 	"github.com/tofutf/tofutf/internal/github"
 	"github.com/tofutf/tofutf/internal/gitlab"
 	"github.com/tofutf/tofutf/internal/gpgkeys"
 	"github.com/tofutf/tofutf/internal/http"
 	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/idempotency"
 	"github.com/tofutf/tofutf/internal/inmem"
+	"github.com/tofutf/tofutf/internal/lockout"
 	"github.com/tofutf/tofutf/internal/loginserver"
 	"github.com/tofutf/tofutf/internal/logs"
+	"github.com/tofutf/tofutf/internal/maintenance"
 	"github.com/tofutf/tofutf/internal/module"
+	"github.com/tofutf/tofutf/internal/modulepolicy"
+	"github.com/tofutf/tofutf/internal/nocode"
 	"github.com/tofutf/tofutf/internal/notifications"
 	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/preflight"
 	"github.com/tofutf/tofutf/internal/provider"
 	"github.com/tofutf/tofutf/internal/releases"
 	"github.com/tofutf/tofutf/internal/repohooks"
 	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/runstats"
 	"github.com/tofutf/tofutf/internal/scheduler"
+	"github.com/tofutf/tofutf/internal/schemaguard"
+	"github.com/tofutf/tofutf/internal/scim"
+	"github.com/tofutf/tofutf/internal/secret"
+	"github.com/tofutf/tofutf/internal/secret/vault"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/state"
 	"github.com/tofutf/tofutf/internal/team"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/tokens"
+	"github.com/tofutf/tofutf/internal/upgrade"
 	"github.com/tofutf/tofutf/internal/user"
 	"github.com/tofutf/tofutf/internal/variable"
 	"github.com/tofutf/tofutf/internal/vcs"
 	"github.com/tofutf/tofutf/internal/vcsprovider"
 	"github.com/tofutf/tofutf/internal/workspace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -64,14 +81,27 @@ type (
 		Configs       *configversion.Service
 		Modules       *module.Service
 		Providers     *provider.Service
+		GPGKeys       *gpgkeys.Service
 		VCSProviders  *vcsprovider.Service
 		Tokens        *tokens.Service
 		Teams         *team.Service
 		Users         *user.Service
+		SCIM          *scim.Service
+		Templates     *nocode.Service
 		GithubApp     *github.Service
 		RepoHooks     *repohooks.Service
 		Agents        agent.Service
 		Connections   *connections.Service
+		Maintenance   *maintenance.Service
+		Lockouts      *lockout.Service
+		RunStats      *runstats.Service
+		Assessments   *assessment.Service
+		Compare       *compare.Service
+		Releases      *releases.Service
+		EventSinks    *eventsink.Service
+		Upgrades      *upgrade.Service
+		Idempotency   *idempotency.Service
+		SchemaGuard   *schemaguard.Guard
 		System        *internal.HostnameService
 
 		handlers []internal.Handlers
@@ -140,10 +170,25 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 	// Setup url signer
 	signer := internal.NewSigner(cfg.Secret)
 
+	trustedProxies, err := http.ParseCIDRs(cfg.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("parsing trusted proxies: %w", err)
+	}
+	lockoutService := lockout.NewService(lockout.Options{
+		Logger:         logger,
+		Pool:           db,
+		Listener:       listener,
+		Responder:      responder,
+		Threshold:      cfg.AuthLockoutThreshold,
+		Duration:       cfg.AuthLockoutDuration,
+		TrustedProxies: trustedProxies,
+	})
+
 	tokensService, err := tokens.NewService(tokens.Options{
 		Logger:          logger,
 		GoogleIAPConfig: cfg.GoogleIAPConfig,
 		Secret:          cfg.Secret,
+		LockoutService:  lockoutService,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("setting up authentication middleware: %w", err)
@@ -167,19 +212,36 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		OrganizationService: orgService,
 		TokensService:       tokensService,
 	})
+	upgradeService := upgrade.NewService(upgrade.Options{
+		Logger:    logger,
+		Pool:      db,
+		Responder: responder,
+		Enabled:   cfg.CheckForUpgrades,
+	})
 	userService := user.NewService(user.Options{
-		Logger:        logger,
-		Pool:          db,
-		Renderer:      renderer,
-		Responder:     responder,
-		TokensService: tokensService,
-		SiteToken:     cfg.SiteToken,
-		TeamService:   teamService,
+		Logger:         logger,
+		Pool:           db,
+		Renderer:       renderer,
+		Responder:      responder,
+		TokensService:  tokensService,
+		SiteToken:      cfg.SiteToken,
+		TeamService:    teamService,
+		UpgradeService: upgradeService,
+		LockoutService: lockoutService,
 	})
 	// promote nominated users to site admin
 	if err := userService.SetSiteAdmins(ctx, cfg.SiteAdmins...); err != nil {
 		return nil, err
 	}
+	scimService := scim.NewService(scim.Options{
+		Logger:          logger,
+		Pool:            db,
+		Renderer:        renderer,
+		HostnameService: hostnameService,
+		TokensService:   tokensService,
+		UserService:     userService,
+		TeamService:     teamService,
+	})
 
 	githubAppService := github.NewService(github.Options{
 		Logger:              logger,
@@ -213,6 +275,14 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		VCSProviderService:  vcsProviderService,
 		GithubAppService:    githubAppService,
 		VCSEventBroker:      vcsEventBroker,
+		ReplayMaxAge:        cfg.WebhookReplayMaxAge,
+		Responder:           responder,
+		Renderer:            renderer,
+		UnknownEventPolicies: map[vcs.Kind]vcs.UnknownEventPolicy{
+			vcs.GithubKind:      cfg.GithubUnknownEvents,
+			vcs.GitlabKind:      cfg.GitlabUnknownEvents,
+			vcs.BitbucketServer: cfg.BitbucketServerUnknownEvents,
+		},
 	})
 	repoService.RegisterCloudHandler(vcs.GithubKind, github.HandleEvent)
 	repoService.RegisterCloudHandler(vcs.GitlabKind, gitlab.HandleEvent)
@@ -223,14 +293,32 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		Pool:               db,
 		VCSProviderService: vcsProviderService,
 		RepoHooksService:   repoService,
+		VCSEventSubscriber: vcsEventBroker,
 	})
+	product, err := releases.LookupProduct(cfg.AgentConfig.Product)
+	if err != nil {
+		return nil, err
+	}
 	releasesService := releases.NewService(releases.Options{
-		Logger: logger,
-		Pool:   db,
+		Logger:    logger,
+		Pool:      db,
+		Responder: responder,
+		Renderer:  renderer,
+		// server agents share the service's downloader, so that
+		// redownloading a corrupted binary replaces the binary they use and
+		// the limit on concurrent downloads applies server-wide.
+		Product:                product,
+		TerraformBinDir:        cfg.AgentConfig.TerraformBinDir,
+		MaxConcurrentDownloads: cfg.AgentConfig.MaxConcurrentDownloads,
+		ArchiveCacheDir:        cfg.AgentConfig.ArchiveCacheDir,
 	})
 	if cfg.DisableLatestChecker == nil || !*cfg.DisableLatestChecker {
 		releasesService.StartLatestChecker(ctx)
 	}
+	idempotencyService := idempotency.NewService(idempotency.Options{
+		Logger: logger,
+		Pool:   db,
+	})
 	workspaceService := workspace.NewService(workspace.Options{
 		Logger:              logger,
 		Pool:                db,
@@ -241,6 +329,8 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		TeamService:         teamService,
 		OrganizationService: orgService,
 		VCSProviderService:  vcsProviderService,
+		ReleasesService:     releasesService,
+		IdempotencyService:  idempotencyService,
 	})
 	configService := configversion.NewService(configversion.Options{
 		Logger:              logger,
@@ -250,6 +340,7 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		Cache:               cache,
 		Signer:              signer,
 		MaxConfigSize:       cfg.MaxConfigSize,
+		IdempotencyService:  idempotencyService,
 	})
 
 	runService := run.NewService(run.Options{
@@ -268,15 +359,28 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		Signer:               signer,
 		ReleasesService:      releasesService,
 		TokensService:        tokensService,
+		UserService:          userService,
+		IdempotencyService:   idempotencyService,
+		MaxArtifactSize:      cfg.MaxArtifactSize,
+		MaxArtifacts:         cfg.MaxArtifacts,
+		ForceCancelCoolOff:   cfg.ForceCancelCoolOff,
+		RepohookService:      repoService,
+
+		DisableWorkingDirectoryTriggers: cfg.DisableWorkingDirectoryTriggers,
 	})
-	logsService := logs.NewService(logs.Options{
-		Logger:        logger,
-		Pool:          db,
-		RunAuthorizer: runService,
-		Cache:         cache,
-		Listener:      listener,
-		Verifier:      signer,
+	privateregistryService, err := gpgkeys.NewService(gpgkeys.Options{
+		Logger:                 logger,
+		Pool:                   db,
+		HostnameService:        hostnameService,
+		Signer:                 signer,
+		Renderer:               renderer,
+		OrganizationAuthorizer: orgService,
+		Responder:              responder,
 	})
+	if err != nil {
+		return nil, err
+	}
+
 	moduleService := module.NewService(module.Options{
 		Logger:             logger,
 		Pool:               db,
@@ -287,6 +391,28 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		ConnectionsService: connectionService,
 		RepohookService:    repoService,
 		VCSEventSubscriber: vcsEventBroker,
+		Responder:          responder,
+		GPGKeyService:      privateregistryService,
+		RequireSignatures:  cfg.RequireModuleSignatures,
+		ShareGracePeriod:   cfg.ModuleShareGracePeriod,
+	})
+	nocodeService := nocode.NewService(nocode.Options{
+		Logger:               logger,
+		Pool:                 db,
+		Renderer:             renderer,
+		Responder:            responder,
+		HostnameService:      hostnameService,
+		ModuleService:        moduleService,
+		WorkspaceService:     workspaceService,
+		ConfigVersionService: configService,
+		RunService:           runService,
+	})
+	modulePolicyService := modulepolicy.NewService(modulepolicy.Options{
+		Logger:     logger,
+		Pool:       db,
+		Renderer:   renderer,
+		Responder:  responder,
+		RunService: runService,
 	})
 	providerService := provider.NewService(provider.Options{
 		Logger:             logger,
@@ -296,6 +422,8 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		Renderer:           renderer,
 		ProxyURL:           cfg.ProviderProxy.URL,
 		ProxyIsArtifactory: cfg.ProviderProxy.IsArtifactory,
+		Responder:          responder,
+		GPGKeyService:      privateregistryService,
 	})
 	stateService := state.NewService(state.Options{
 		Logger:           logger,
@@ -306,6 +434,16 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		Responder:        responder,
 		Signer:           signer,
 	})
+	// register the backends from which variables may reference secrets
+	secrets := secret.NewRegistry()
+	vaultConfig := cfg.Vault
+	vaultConfig.SkipTLSVerification = cfg.SkipTLSVerification
+	vaultResolver, err := vault.NewResolver(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("configuring vault: %w", err)
+	}
+	secrets.Register(vault.Backend, vaultResolver)
+
 	variableService := variable.NewService(variable.Options{
 		Logger:              logger,
 		Pool:                db,
@@ -313,18 +451,89 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		Responder:           responder,
 		WorkspaceAuthorizer: workspaceService,
 		WorkspaceService:    workspaceService,
+		StateService:        stateService,
 		RunClient:           runService,
+		Secrets:             secrets,
+	})
+	logsService := logs.NewService(logs.Options{
+		Logger:              logger,
+		Pool:                db,
+		RunAuthorizer:       runService,
+		MaxRunLogSize:       cfg.MaxRunLogSize,
+		MaxDebugRunLogSize:  cfg.MaxDebugRunLogSize,
+		MaxConcurrentWrites: cfg.MaxConcurrentLogWrites,
+		Cache:               cache,
+		Listener:            listener,
+		Verifier:            signer,
+		RunService:          runService,
+		WorkspaceService:    workspaceService,
+		VariableService:     variableService,
+		OrganizationService: orgService,
 	})
 
-	agentService := agent.NewService(agent.ServiceOptions{
+	maintenanceService := maintenance.NewService(maintenance.Options{
+		Logger:    logger,
+		Pool:      db,
+		Listener:  listener,
+		Renderer:  renderer,
+		Responder: responder,
+	})
+
+	runStatsService := runstats.NewService(runstats.Options{
+		Logger:              logger,
+		Pool:                db,
+		Renderer:            renderer,
+		Responder:           responder,
+		WorkspaceAuthorizer: workspaceService,
+		RunService:          runService,
+	})
+
+	assessmentService := assessment.NewService(assessment.Options{
 		Logger:           logger,
 		Pool:             db,
 		Renderer:         renderer,
 		Responder:        responder,
-		RunService:       runService,
 		WorkspaceService: workspaceService,
-		TokensService:    tokensService,
-		Listener:         listener,
+		RunService:       runService,
+	})
+
+	sinks, err := eventsink.ParseConfigs(cfg.EventSinks, cfg.EventSinkHMACSecret)
+	if err != nil {
+		return nil, err
+	}
+	eventSinkService := eventsink.NewService(eventsink.Options{
+		Logger:   logger,
+		Pool:     db,
+		Renderer: renderer,
+		Sinks:    sinks,
+	})
+
+	// job tracing is a no-op unless enabled
+	var jobTracerProvider trace.TracerProvider
+	if cfg.TraceJobs {
+		jobTracerProvider = otel.GetTracerProvider()
+	}
+	agentService := agent.NewService(agent.ServiceOptions{
+		Logger:             logger,
+		Pool:               db,
+		Renderer:           renderer,
+		Responder:          responder,
+		RunService:         runService,
+		WorkspaceService:   workspaceService,
+		TokensService:      tokensService,
+		MaintenanceService: maintenanceService,
+		ReleasesService:    releasesService,
+		LogsService:        logsService,
+		Listener:           listener,
+		TracerProvider:     jobTracerProvider,
+
+		RejectPausedWorkspaceRuns: cfg.RejectPausedWorkspaceRuns,
+		JobDispatchers:            cfg.AgentJobDispatchers,
+		PoolRecoveryWindow:        cfg.AgentPoolRecoveryWindow,
+		JobDiskEstimate:           cfg.AgentJobDiskEstimate,
+		StatusHistoryRetention:    cfg.AgentStatusHistoryRetention,
+		DefaultMaxAgentPools:      cfg.AgentMaxPools,
+		PriorityAging:             cfg.PriorityAging,
 	})
 
 	agentDaemon, err := agent.NewServerDaemon(
@@ -339,6 +548,7 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 			LogsService:                 logsService,
 			AgentService:                agentService,
 			HostnameService:             hostnameService,
+			Downloader:                  releasesService,
 		},
 	)
 	if err != nil {
@@ -350,6 +560,7 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		Renderer:        renderer,
 		HostnameService: hostnameService,
 		TokensService:   tokensService,
+		UserService:     userService,
 		OpaqueHandlerConfigs: []authenticator.OpaqueHandlerConfig{
 			{
 				ClientConstructor: github.NewOAuthClient,
@@ -389,28 +600,29 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		WorkspaceAuthorizer: workspaceService,
 	})
 
-	privateregistryService, err := gpgkeys.NewService(gpgkeys.Options{
-		Logger:                 logger,
-		Pool:                   db,
-		HostnameService:        hostnameService,
-		Signer:                 signer,
-		Renderer:               renderer,
-		OrganizationAuthorizer: orgService,
-		Responder:              responder,
+	compareService := compare.NewService(compare.Options{
+		Logger:           logger,
+		Renderer:         renderer,
+		Responder:        responder,
+		WorkspaceService: workspaceService,
+		VariableService:  variableService,
 	})
-	if err != nil {
-		return nil, err
-	}
 
 	handlers := []internal.Handlers{
 		teamService,
 		userService,
+		scimService,
+		// must precede the workspace service, whose routes would otherwise
+		// treat "compare" as a workspace ID
+		compareService,
 		workspaceService,
 		stateService,
 		orgService,
 		variableService,
 		vcsProviderService,
 		moduleService,
+		nocodeService,
+		modulePolicyService,
 		providerService,
 		runService,
 		logsService,
@@ -426,12 +638,20 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		notificationService,
 		githubAppService,
 		agentService,
+		maintenanceService,
+		lockoutService,
+		runStatsService,
+		assessmentService,
+		releasesService,
+		eventSinkService,
+		upgradeService,
 		disco.Service{},
 		&ghapphandler.Handler{
-			Logger:       logger,
-			Publisher:    vcsEventBroker,
-			GithubApps:   githubAppService,
-			VCSProviders: vcsProviderService,
+			Logger:        logger,
+			Publisher:     vcsEventBroker,
+			GithubApps:    githubAppService,
+			VCSProviders:  vcsProviderService,
+			UnknownEvents: cfg.GithubUnknownEvents,
 		},
 		&api.Handlers{},
 		&tfeapi.Handlers{},
@@ -451,14 +671,27 @@ func New(ctx context.Context, logger *slog.Logger, cfg Config) (*Daemon, error)
 		State:         stateService,
 		Configs:       configService,
 		Modules:       moduleService,
+		Templates:     nocodeService,
 		Providers:     providerService,
+		GPGKeys:       privateregistryService,
 		VCSProviders:  vcsProviderService,
 		Tokens:        tokensService,
 		Teams:         teamService,
 		Users:         userService,
+		SCIM:          scimService,
 		RepoHooks:     repoService,
 		GithubApp:     githubAppService,
 		Connections:   connectionService,
+		Maintenance:   maintenanceService,
+		Lockouts:      lockoutService,
+		RunStats:      runStatsService,
+		Assessments:   assessmentService,
+		Compare:       compareService,
+		Releases:      releasesService,
+		EventSinks:    eventSinkService,
+		Upgrades:      upgradeService,
+		Idempotency:   idempotencyService,
+		SchemaGuard:   schemaguard.NewGuard(schemaguard.Options{Logger: logger, DB: db}),
 		Agents:        agentService,
 		Pool:          db,
 		agent:         agentDaemon,
@@ -482,7 +715,7 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 		KeyFile:              d.KeyFile,
 		EnableRequestLogging: d.EnableRequestLogging,
 		DevMode:              d.DevMode,
-		Middleware:           []mux.MiddlewareFunc{d.Tokens.Middleware()},
+		Middleware:           []mux.MiddlewareFunc{d.SchemaGuard.Middleware(), d.Tokens.Middleware(), d.Maintenance.Middleware()},
 		Handlers:             d.handlers,
 	})
 	if err != nil {
@@ -504,7 +737,18 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 	d.Logger.Info("set system hostname", "hostname", d.System.Hostname())
 	d.Logger.Info("set webhook hostname", "webhook_hostname", d.System.WebhookHostname())
 
+	// Check the schema before starting any subsystems, so that an instance
+	// already older than the schema doesn't start exclusive subsystems.
+	if err := d.SchemaGuard.Check(ctx); err != nil {
+		return fmt.Errorf("checking database schema version: %w", err)
+	}
+
 	subsystems := []*Subsystem{
+		{
+			Name:   "schema-guard",
+			Logger: d.Logger,
+			System: d.SchemaGuard,
+		},
 		{
 			Name:   "listener",
 			Logger: d.Logger,
@@ -530,6 +774,40 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 				Configs:         d.Configs,
 			},
 		},
+		{
+			Name:      "apply-window-releaser",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(run.ApplyWindowLockID),
+			System: &run.ApplyWindowReleaser{
+				Logger:     d.Logger.With("component", "apply-window-releaser"),
+				Workspaces: d.Workspaces,
+				Runs:       d.Runs,
+			},
+		},
+		{
+			Name:      "preflight-checker",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(preflight.LockID),
+			System: &preflight.Checker{
+				Logger:     d.Logger.With("component", "preflight-checker"),
+				Runs:       d.Runs,
+				Workspaces: d.Workspaces,
+				Variables:  d.Variables,
+				Logs:       d.Logs,
+			},
+		},
+		{
+			Name:      "orphaned-job-reconciler",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(agent.OrphanReconcilerLockID),
+			System:    d.Agents.NewOrphanReconciler(d.Logger),
+		},
 		{
 			Name:      "notifier",
 			Logger:    d.Logger,
@@ -545,6 +823,22 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 				Pool:               d.Pool,
 			}),
 		},
+		{
+			Name:      "run-stats-recorder",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(runstats.LockID),
+			System:    d.RunStats.NewRecorder(d.Logger.With("component", "run-stats-recorder")),
+		},
+		{
+			Name:      "assessment-recorder",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(assessment.LockID),
+			System:    d.Assessments.NewRecorder(d.Logger.With("component", "assessment-recorder")),
+		},
 		{
 			Name:      "job-allocator",
 			Logger:    d.Logger,
@@ -561,6 +855,30 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 			LockID:    internal.Int64(agent.ManagerLockID),
 			System:    d.Agents.NewManager(),
 		},
+		{
+			Name:      "agent-autoscaler",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(agent.AutoscalerLockID),
+			System:    d.Agents.NewAutoscaler(d.Logger),
+		},
+		{
+			Name:      "idempotency-key-cleaner",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(idempotency.LockID),
+			System:    d.Idempotency.NewCleaner(d.Logger),
+		},
+		{
+			Name:      "repo-reconciler",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(connections.ReconcilerLockID),
+			System:    d.Connections.NewReconciler(d.Logger),
+		},
 		{
 			Name:   "agent-daemon",
 			Logger: d.Logger,
@@ -568,6 +886,26 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 			System: d.agent,
 		},
 	}
+	if d.AgentPoolRecoveryWindow > 0 {
+		subsystems = append(subsystems, &Subsystem{
+			Name:      "agent-pool-purger",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(agent.PurgerLockID),
+			System:    d.Agents.NewPurger(d.Logger),
+		})
+	}
+	if d.AgentStatusHistoryRetention > 0 {
+		subsystems = append(subsystems, &Subsystem{
+			Name:      "agent-status-history-purger",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(agent.StatusHistoryPurgerLockID),
+			System:    d.Agents.NewStatusHistoryPurger(d.Logger),
+		})
+	}
 	if !d.DisableScheduler {
 		subsystems = append(subsystems, &Subsystem{
 			Name:      "scheduler",
@@ -576,13 +914,47 @@ func (d *Daemon) Start(ctx context.Context, started chan struct{}) error {
 			DB:        d.Pool,
 			LockID:    internal.Int64(scheduler.LockID),
 			System: scheduler.NewScheduler(scheduler.Options{
-				Logger:          d.Logger,
-				WorkspaceClient: d.Workspaces,
-				RunClient:       d.Runs,
+				Logger:            d.Logger,
+				WorkspaceClient:   d.Workspaces,
+				RunClient:         d.Runs,
+				MaintenanceClient: d.Maintenance,
+				PriorityAging:     d.PriorityAging,
 			}),
 		})
 	}
+	if d.EventSinks.HasSinks() {
+		subsystems = append(subsystems, &Subsystem{
+			Name:      "event-exporter",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(eventsink.LockID),
+			System: d.EventSinks.NewExporter(eventsink.ExporterOptions{
+				Logger:             d.Logger,
+				OrganizationClient: d.Organizations,
+				WorkspaceClient:    d.Workspaces,
+				RunClient:          d.Runs,
+				LockoutClient:      d.Lockouts,
+				HostnameClient:     d.System,
+			}),
+		})
+	}
+	if d.Upgrades.Enabled() {
+		subsystems = append(subsystems, &Subsystem{
+			Name:      "upgrade-checker",
+			Logger:    d.Logger,
+			Exclusive: true,
+			DB:        d.Pool,
+			LockID:    internal.Int64(upgrade.LockID),
+			System:    d.Upgrades.NewChecker(d.Logger),
+		})
+	}
 	for _, ss := range subsystems {
+		if ss.Exclusive {
+			// relinquish exclusive subsystems to another instance once this
+			// instance is older than the schema.
+			ss.Stop = d.SchemaGuard.Tripped()
+		}
 		if err := ss.Start(ctx, g); err != nil {
 			return err
 		}
@@ -27,6 +27,9 @@ type (
 		DB subsystemDB
 		// Cluster-unique lock ID. Must be non-nil if Exclusive is true.
 		LockID *int64
+		// Stop, if non-nil, is closed to permanently stop an exclusive
+		// subsystem, releasing its lock to another instance on the cluster.
+		Stop <-chan struct{}
 		// Logger is the included logger
 		Logger *slog.Logger
 	}
@@ -55,6 +58,21 @@ func (s *Subsystem) Start(ctx context.Context, g *errgroup.Group) error {
 	// endpoint calls.
 	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: s.Name})
 
+	// stopped is closed if the subsystem is stopped via the Stop channel.
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(ctx)
+	if s.Exclusive && s.Stop != nil {
+		go func() {
+			select {
+			case <-s.Stop:
+				s.Logger.Warn("stopping subsystem", "name", s.Name)
+				close(stopped)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
 	op := func() (err error) {
 		if s.Exclusive {
 			// block on getting an exclusive lock
@@ -81,9 +99,17 @@ func (s *Subsystem) Start(ctx context.Context, g *errgroup.Group) error {
 	// cancelled then it'll stop retrying and return the context error.
 	policy := backoff.WithContext(backoff.NewExponentialBackOff(), ctx)
 	g.Go(func() error {
-		return backoff.RetryNotify(op, policy, func(err error, next time.Duration) {
+		defer cancel()
+		err := backoff.RetryNotify(op, policy, func(err error, next time.Duration) {
 			s.Logger.Error("restarting subsystem", "name", s.Name, "backoff", next, "err", err)
 		})
+		select {
+		case <-stopped:
+			// stopping a subsystem should not terminate the daemon
+			return nil
+		default:
+			return err
+		}
 	})
 	s.Logger.Info("started subsystem", "name", s.Name)
 	return nil
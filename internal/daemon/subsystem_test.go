@@ -39,11 +39,42 @@ func TestSubsystem(t *testing.T) {
 	}
 }
 
+func TestSubsystem_Stop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stop := make(chan struct{})
+	sub := &Subsystem{
+		Name:      "stoppable",
+		System:    &blockingStartable{},
+		Logger:    slog.New(&xslog.NoopHandler{}),
+		Exclusive: true,
+		DB:        &fakeWaitAndLock{},
+		LockID:    internal.Int64(123),
+		Stop:      stop,
+	}
+	var g errgroup.Group
+	require.NoError(t, sub.Start(ctx, &g))
+
+	close(stop)
+
+	// stopping the subsystem should not return an error, which would
+	// otherwise terminate the daemon.
+	require.NoError(t, g.Wait())
+}
+
 type (
 	fakeStartable   struct{}
 	fakeWaitAndLock struct{}
+	// blockingStartable blocks until its context is canceled.
+	blockingStartable struct{}
 )
 
+func (f *blockingStartable) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
 func (f *fakeStartable) Start(ctx context.Context) error {
 	return nil
 }
@@ -3,6 +3,7 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 )
@@ -28,6 +29,9 @@ var (
 	// ErrInvalidName is returned when the name option has invalid value.
 	ErrInvalidName = errors.New("invalid value for name")
 
+	// ErrUppercaseName is returned when a name must be lowercase but is not.
+	ErrUppercaseName = errors.New("name must be lowercase")
+
 	// ErrEmptyValue is returned when a value is set to an empty string
 	ErrEmptyValue = errors.New("value cannot be empty")
 
@@ -58,6 +62,9 @@ type (
 	HTTPError struct {
 		Code    int
 		Message string
+		// Meta is optional non-standard information about the error, included
+		// in the meta member of a JSON:API error object.
+		Meta any
 	}
 
 	// MissingParameterError occurs when the caller has failed to provide a
@@ -72,6 +79,14 @@ type (
 	}
 
 	InvalidParameterError string
+
+	// BackpressureError occurs when the server is too busy to accept a
+	// request, asking the client to slow down and retry after a delay.
+	BackpressureError struct {
+		// RetryAfter is the suggested delay before retrying. Zero if the
+		// server did not suggest a delay.
+		RetryAfter time.Duration
+	}
 )
 
 func (e InvalidParameterError) Error() string {
@@ -89,3 +104,10 @@ func (e *MissingParameterError) Error() string {
 func (e *ForeignKeyError) Error() string {
 	return e.Detail
 }
+
+func (e *BackpressureError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("server is busy: retry after %s", e.RetryAfter)
+	}
+	return "server is busy"
+}
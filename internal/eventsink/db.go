@@ -0,0 +1,151 @@
+package eventsink
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is the event sink database on postgres
+	pgdb struct {
+		*sql.Pool // provides access to generated SQL queries
+	}
+
+	// statusRow represents the database row for the status of a sink
+	statusRow struct {
+		Name            pgtype.Text        `json:"name"`
+		Delivered       pgtype.Int8        `json:"delivered"`
+		Failures        pgtype.Int8        `json:"failures"`
+		LastDeliveredAt pgtype.Timestamptz `json:"last_delivered_at"`
+		LastFailedAt    pgtype.Timestamptz `json:"last_failed_at"`
+		LastError       pgtype.Text        `json:"last_error"`
+		Pending         pgtype.Int8        `json:"pending"`
+		OldestPendingAt pgtype.Timestamptz `json:"oldest_pending_at"`
+	}
+
+	// outboxEvent is an event awaiting delivery to a sink.
+	outboxEvent struct {
+		id        int64
+		payload   []byte
+		createdAt time.Time
+	}
+)
+
+func (r statusRow) toStatus() *Status {
+	status := &Status{
+		Name:      r.Name.String,
+		Delivered: int(r.Delivered.Int64),
+		Failures:  int(r.Failures.Int64),
+		Pending:   int(r.Pending.Int64),
+	}
+	if r.LastDeliveredAt.Valid {
+		t := r.LastDeliveredAt.Time.UTC()
+		status.LastDeliveredAt = &t
+	}
+	if r.LastFailedAt.Valid {
+		t := r.LastFailedAt.Time.UTC()
+		status.LastFailedAt = &t
+	}
+	if r.LastError.Valid {
+		status.LastError = &r.LastError.String
+	}
+	if r.OldestPendingAt.Valid {
+		t := r.OldestPendingAt.Time.UTC()
+		status.OldestPendingAt = &t
+	}
+	return status
+}
+
+// createSinks records the sinks, unless they have already been recorded.
+func (db *pgdb) createSinks(ctx context.Context, names []string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, name := range names {
+			if _, err := q.InsertEventSink(ctx, sql.String(name)); err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+// record adds an event to the outbox of each of the sinks.
+func (db *pgdb) record(ctx context.Context, sinks []string, payload []byte, createdAt time.Time) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for _, name := range sinks {
+			_, err := q.InsertEventSinkOutbox(ctx, pggen.InsertEventSinkOutboxParams{
+				Sink:      sql.String(name),
+				Payload:   payload,
+				CreatedAt: sql.Timestamptz(createdAt),
+			})
+			if err != nil {
+				return sql.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+// listOutbox lists the oldest events in a sink's outbox, up to limit.
+func (db *pgdb) listOutbox(ctx context.Context, sink string, limit int) ([]outboxEvent, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]outboxEvent, error) {
+		rows, err := q.FindEventSinkOutbox(ctx, sql.String(sink), sql.Int8(limit))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		events := make([]outboxEvent, len(rows))
+		for i, r := range rows {
+			events[i] = outboxEvent{
+				id:        r.EventSinkOutboxID.Int64,
+				payload:   r.Payload,
+				createdAt: r.CreatedAt.Time.UTC(),
+			}
+		}
+		return events, nil
+	})
+}
+
+// delivered removes events up to and including the event with the given ID
+// from a sink's outbox, and updates the sink's status.
+func (db *pgdb) delivered(ctx context.Context, sink string, upTo int64, n int, at time.Time) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.DeleteEventSinkOutbox(ctx, sql.String(sink), sql.Int8(int(upTo))); err != nil {
+			return sql.Error(err)
+		}
+		_, err := q.UpdateEventSinkDelivered(ctx, pggen.UpdateEventSinkDeliveredParams{
+			Name:            sql.String(sink),
+			Delivered:       sql.Int8(n),
+			LastDeliveredAt: sql.Timestamptz(at),
+		})
+		return sql.Error(err)
+	})
+}
+
+// failed updates a sink's status following a failed delivery.
+func (db *pgdb) failed(ctx context.Context, sink string, deliveryErr error, at time.Time) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpdateEventSinkFailed(ctx, pggen.UpdateEventSinkFailedParams{
+			Name:         sql.String(sink),
+			LastFailedAt: sql.Timestamptz(at),
+			LastError:    sql.String(deliveryErr.Error()),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) listStatuses(ctx context.Context) ([]*Status, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Status, error) {
+		rows, err := q.FindEventSinks(ctx)
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		statuses := make([]*Status, len(rows))
+		for i, r := range rows {
+			statuses[i] = statusRow(r).toStatus()
+		}
+		return statuses, nil
+	})
+}
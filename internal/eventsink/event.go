@@ -0,0 +1,193 @@
+package eventsink
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/lockout"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// SchemaVersion is the version of the schema of events delivered to sinks.
+// Fields may be added to the schema without changing its version, but any
+// change that is incompatible with existing consumers increments it.
+const SchemaVersion = 1
+
+// Category classifies an event.
+type Category string
+
+const (
+	// AuditCategory is an event recording a change to an organization or
+	// workspace, or a client being locked out after too many failed
+	// authentication attempts.
+	AuditCategory Category = "audit"
+	// RunCategory is an event in the lifecycle of a run.
+	RunCategory Category = "run"
+)
+
+type (
+	// Event is delivered to event sinks, encoded as JSON.
+	Event struct {
+		SchemaVersion int `json:"schema_version"`
+		// ID uniquely identifies the event. An event may be delivered more
+		// than once, and consumers should use the ID to discard duplicates.
+		ID       string    `json:"id"`
+		Type     string    `json:"type"`
+		Category Category  `json:"category"`
+		Time     time.Time `json:"time"`
+		// Source is the hostname of the OTF cluster from which the event
+		// originated.
+		Source       string `json:"source"`
+		Organization string `json:"organization,omitempty"`
+		WorkspaceID  string `json:"workspace_id,omitempty"`
+		// Data is one of OrganizationData, WorkspaceData, RunData or
+		// AuthLockoutData, depending on the type of event.
+		Data any `json:"data"`
+	}
+
+	// OrganizationData is the data of an organization audit event.
+	OrganizationData struct {
+		ID   string `json:"id"`
+		Name string `json:"name,omitempty"`
+	}
+
+	// WorkspaceData is the data of a workspace audit event.
+	WorkspaceData struct {
+		ID           string `json:"id"`
+		Name         string `json:"name,omitempty"`
+		Organization string `json:"organization,omitempty"`
+		Paused       bool   `json:"paused,omitempty"`
+	}
+
+	// AuthLockoutData is the data of an authentication lockout audit event.
+	AuthLockoutData struct {
+		// ID is of the form <kind>:<value>.
+		ID          string     `json:"id"`
+		Kind        string     `json:"kind"`
+		Value       string     `json:"value"`
+		Failures    int        `json:"failures,omitempty"`
+		LockedUntil *time.Time `json:"locked_until,omitempty"`
+	}
+
+	// RunData is the data of a run lifecycle event.
+	RunData struct {
+		ID                     string    `json:"id"`
+		WorkspaceID            string    `json:"workspace_id,omitempty"`
+		ConfigurationVersionID string    `json:"configuration_version_id,omitempty"`
+		Status                 string    `json:"status,omitempty"`
+		Source                 string    `json:"source,omitempty"`
+		Message                string    `json:"message,omitempty"`
+		PlanOnly               bool      `json:"plan_only"`
+		IsDestroy              bool      `json:"is_destroy"`
+		CreatedAt              time.Time `json:"created_at"`
+		CreatedBy              *string   `json:"created_by,omitempty"`
+	}
+)
+
+func newAuditEvent(source, resource string, eventType pubsub.EventType, now time.Time) *Event {
+	return &Event{
+		SchemaVersion: SchemaVersion,
+		ID:            internal.NewID("evt"),
+		Type:          fmt.Sprintf("%s.%s", resource, eventType),
+		Category:      AuditCategory,
+		Time:          now,
+		Source:        source,
+	}
+}
+
+func newOrganizationEvent(source string, event pubsub.Event[*organization.Organization], now time.Time) *Event {
+	org := event.Payload
+	e := newAuditEvent(source, "organization", event.Type, now)
+	e.Organization = org.Name
+	e.Data = OrganizationData{ID: org.ID, Name: org.Name}
+	return e
+}
+
+func newWorkspaceEvent(source string, event pubsub.Event[*workspace.Workspace], now time.Time) *Event {
+	ws := event.Payload
+	e := newAuditEvent(source, "workspace", event.Type, now)
+	e.Organization = ws.Organization
+	e.WorkspaceID = ws.ID
+	e.Data = WorkspaceData{ID: ws.ID, Name: ws.Name, Organization: ws.Organization, Paused: ws.Paused}
+	return e
+}
+
+// newWorkspacePauseEvent constructs an event for a workspace that has been
+// paused or resumed. The ID is derived from the workspace ID and the time at
+// which it was paused in order that a consumer can discard duplicates of the
+// same pause or resumption.
+func newWorkspacePauseEvent(source string, ws *workspace.Workspace, pausedAt, now time.Time) *Event {
+	action, at := "paused", pausedAt
+	if !ws.Paused {
+		action, at = "resumed", now
+	}
+	e := newAuditEvent(source, "workspace", pubsub.EventType(action), at)
+	e.ID = fmt.Sprintf("%s.%s.%d", ws.ID, action, pausedAt.Unix())
+	e.Organization = ws.Organization
+	e.WorkspaceID = ws.ID
+	e.Data = WorkspaceData{ID: ws.ID, Name: ws.Name, Organization: ws.Organization, Paused: ws.Paused}
+	return e
+}
+
+// newLockoutEvent constructs an event for a client that has been locked out,
+// or whose lockout has been cleared. The ID of a lockout event is derived from
+// the key of the lockout and the time at which it was imposed in order that a
+// consumer can discard duplicates of the same lockout.
+func newLockoutEvent(source string, event pubsub.Event[*lockout.Lockout], now time.Time) *Event {
+	l := event.Payload
+	action := "locked"
+	if event.Type == pubsub.DeletedEvent {
+		action = "cleared"
+	}
+	e := newAuditEvent(source, "auth_lockout", pubsub.EventType(action), now)
+	data := AuthLockoutData{ID: l.Key(), Kind: string(l.Kind), Value: l.Value}
+	if event.Type != pubsub.DeletedEvent {
+		e.ID = fmt.Sprintf("%s.locked.%d", l.Key(), l.CreatedAt.Unix())
+		data.Failures = l.Failures
+		data.LockedUntil = &l.LockedUntil
+	}
+	e.Data = data
+	return e
+}
+
+// newRunEvent constructs an event for a run that has entered a new status, or
+// has been deleted. The ID is derived from the run ID and its status in order
+// that a consumer can discard duplicates of the same transition.
+func newRunEvent(source string, event pubsub.Event[*run.Run], now time.Time) *Event {
+	r := event.Payload
+	e := &Event{
+		SchemaVersion: SchemaVersion,
+		Category:      RunCategory,
+		Time:          now,
+		Source:        source,
+		Organization:  r.Organization,
+		WorkspaceID:   r.WorkspaceID,
+		Data: RunData{
+			ID:                     r.ID,
+			WorkspaceID:            r.WorkspaceID,
+			ConfigurationVersionID: r.ConfigurationVersionID,
+			Status:                 string(r.Status),
+			Source:                 string(r.Source),
+			Message:                r.Message,
+			PlanOnly:               r.PlanOnly,
+			IsDestroy:              r.IsDestroy,
+			CreatedAt:              r.CreatedAt,
+			CreatedBy:              r.CreatedBy,
+		},
+	}
+	if event.Type == pubsub.DeletedEvent {
+		e.ID = fmt.Sprintf("%s.deleted", r.ID)
+		e.Type = "run.deleted"
+		return e
+	}
+	e.ID = fmt.Sprintf("%s.%s", r.ID, r.Status)
+	e.Type = "run.status_changed"
+	if ts, err := r.StatusTimestamp(r.Status); err == nil {
+		e.Time = ts
+	}
+	return e
+}
@@ -0,0 +1,99 @@
+package eventsink
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/lockout"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+func TestEvent_Schema(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	ws := &workspace.Workspace{ID: "ws-123", Name: "dev", Organization: "acme"}
+
+	event := newWorkspaceEvent("otf.example.com", pubsub.NewCreatedEvent(ws), now)
+	event.ID = "evt-123"
+
+	got, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	want := `{
+		"schema_version": 1,
+		"id": "evt-123",
+		"type": "workspace.created",
+		"category": "audit",
+		"time": "2026-10-14T09:00:00Z",
+		"source": "otf.example.com",
+		"organization": "acme",
+		"workspace_id": "ws-123",
+		"data": {"id": "ws-123", "name": "dev", "organization": "acme"}
+	}`
+	assert.JSONEq(t, want, string(got))
+}
+
+func TestNewRunEvent(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	planned := now.Add(-time.Minute)
+	r := &run.Run{
+		ID:               "run-123",
+		WorkspaceID:      "ws-123",
+		Organization:     "acme",
+		Status:           run.RunPlanned,
+		StatusTimestamps: []run.StatusTimestamp{{Status: run.RunPlanned, Timestamp: planned}},
+	}
+
+	t.Run("status changed", func(t *testing.T) {
+		got := newRunEvent("otf.example.com", pubsub.NewUpdatedEvent(r), now)
+		assert.Equal(t, "run-123.planned", got.ID)
+		assert.Equal(t, "run.status_changed", got.Type)
+		assert.Equal(t, RunCategory, got.Category)
+		assert.Equal(t, planned, got.Time)
+		assert.Equal(t, "planned", got.Data.(RunData).Status)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		got := newRunEvent("otf.example.com", pubsub.NewDeletedEvent(&run.Run{ID: "run-123"}), now)
+		assert.Equal(t, "run-123.deleted", got.ID)
+		assert.Equal(t, "run.deleted", got.Type)
+		assert.Equal(t, now, got.Time)
+	})
+}
+
+func TestNewLockoutEvent(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	l := &lockout.Lockout{
+		Kind:        lockout.IPKind,
+		Value:       "192.0.2.1",
+		Failures:    10,
+		CreatedAt:   now,
+		LockedUntil: now.Add(15 * time.Minute),
+	}
+
+	t.Run("locked", func(t *testing.T) {
+		got := newLockoutEvent("otf.example.com", pubsub.NewCreatedEvent(l), now)
+		assert.Equal(t, "ip:192.0.2.1.locked.1791968400", got.ID)
+		assert.Equal(t, "auth_lockout.locked", got.Type)
+		assert.Equal(t, AuditCategory, got.Category)
+		assert.Equal(t, AuthLockoutData{
+			ID:          "ip:192.0.2.1",
+			Kind:        "ip",
+			Value:       "192.0.2.1",
+			Failures:    10,
+			LockedUntil: internal.Time(now.Add(15 * time.Minute)),
+		}, got.Data)
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		cleared := &lockout.Lockout{Kind: lockout.IPKind, Value: "192.0.2.1"}
+		got := newLockoutEvent("otf.example.com", pubsub.NewDeletedEvent(cleared), now)
+		assert.Equal(t, "auth_lockout.cleared", got.Type)
+		assert.Equal(t, AuthLockoutData{ID: "ip:192.0.2.1", Kind: "ip", Value: "192.0.2.1"}, got.Data)
+	})
+}
@@ -0,0 +1,296 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/lockout"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
+	"golang.org/x/sync/errgroup"
+)
+
+// LockID guarantees only one exporter on a cluster is running at any time.
+const LockID int64 = 5577006791947779415
+
+const (
+	// DefaultBatchSize is the default maximum number of events delivered to
+	// a sink at a time.
+	DefaultBatchSize = 100
+
+	defaultDeliveryInterval = 5 * time.Second
+	maxRetryInterval        = 5 * time.Minute
+)
+
+type (
+	// Exporter records audit and run events in the outbox of each sink, and
+	// delivers them asynchronously to the sinks, retrying failed deliveries
+	// with a backoff.
+	Exporter struct {
+		logger *slog.Logger
+
+		organizations exporterOrganizationClient
+		workspaces    exporterWorkspaceClient
+		runs          exporterRunClient
+		lockouts      exporterLockoutClient
+		system        exporterHostnameClient
+
+		db        *pgdb
+		sinks     []Config
+		batchSize int
+		// frequency with which outboxes are checked for events whilst sinks
+		// are healthy
+		interval time.Duration
+
+		// statuses is the last status seen for each active run, used to
+		// record only those run events that change a run's status.
+		statuses map[string]run.Status
+		// paused is the time at which each paused workspace was last seen to
+		// be paused, used to record when a workspace is paused or resumed.
+		paused map[string]time.Time
+	}
+
+	ExporterOptions struct {
+		Logger             *slog.Logger
+		OrganizationClient exporterOrganizationClient
+		WorkspaceClient    exporterWorkspaceClient
+		RunClient          exporterRunClient
+		LockoutClient      exporterLockoutClient
+		HostnameClient     exporterHostnameClient
+		BatchSize          int
+	}
+
+	exporterOrganizationClient interface {
+		WatchOrganizations(context.Context) (<-chan pubsub.Event[*organization.Organization], func())
+	}
+
+	exporterWorkspaceClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*workspace.Workspace], func())
+	}
+
+	exporterRunClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+
+	exporterLockoutClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*lockout.Lockout], func())
+	}
+
+	exporterHostnameClient interface {
+		Hostname() string
+	}
+)
+
+func (s *Service) NewExporter(opts ExporterOptions) *Exporter {
+	e := &Exporter{
+		logger:        opts.Logger.With("component", "event-exporter"),
+		organizations: opts.OrganizationClient,
+		workspaces:    opts.WorkspaceClient,
+		runs:          opts.RunClient,
+		lockouts:      opts.LockoutClient,
+		system:        opts.HostnameClient,
+		db:            s.db,
+		sinks:         s.sinks,
+		batchSize:     opts.BatchSize,
+		interval:      defaultDeliveryInterval,
+		statuses:      make(map[string]run.Status),
+		paused:        make(map[string]time.Time),
+	}
+	if e.batchSize <= 0 {
+		e.batchSize = DefaultBatchSize
+	}
+	return e
+}
+
+// Start the exporter. Should be started in a go-routine.
+func (e *Exporter) Start(ctx context.Context) error {
+	names := make([]string, len(e.sinks))
+	for i, cfg := range e.sinks {
+		names[i] = cfg.Name
+	}
+	if err := e.db.createSinks(ctx, names); err != nil {
+		return err
+	}
+
+	subOrgs, unsubOrgs := e.organizations.WatchOrganizations(ctx)
+	defer unsubOrgs()
+	subWorkspaces, unsubWorkspaces := e.workspaces.Watch(ctx)
+	defer unsubWorkspaces()
+	subRuns, unsubRuns := e.runs.Watch(ctx)
+	defer unsubRuns()
+	subLockouts, unsubLockouts := e.lockouts.Watch(ctx)
+	defer unsubLockouts()
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, cfg := range e.sinks {
+		cfg := cfg
+		s, err := cfg.newSink()
+		if err != nil {
+			return err
+		}
+		g.Go(func() error {
+			e.deliver(ctx, cfg.Name, s)
+			return nil
+		})
+	}
+	g.Go(func() error {
+		now := func() time.Time { return internal.CurrentTimestamp(nil) }
+		for {
+			var events []*Event
+			select {
+			case <-ctx.Done():
+				return nil
+			case ev, ok := <-subOrgs:
+				if !ok {
+					return pubsub.ErrSubscriptionTerminated
+				}
+				events = append(events, newOrganizationEvent(e.system.Hostname(), ev, now()))
+			case ev, ok := <-subWorkspaces:
+				if !ok {
+					return pubsub.ErrSubscriptionTerminated
+				}
+				events = append(events, newWorkspaceEvent(e.system.Hostname(), ev, now()))
+				if event := e.pauseChanged(e.system.Hostname(), ev, now()); event != nil {
+					events = append(events, event)
+				}
+			case ev, ok := <-subRuns:
+				if !ok {
+					return pubsub.ErrSubscriptionTerminated
+				}
+				if !e.statusChanged(ev) {
+					continue
+				}
+				events = append(events, newRunEvent(e.system.Hostname(), ev, now()))
+			case ev, ok := <-subLockouts:
+				if !ok {
+					return pubsub.ErrSubscriptionTerminated
+				}
+				events = append(events, newLockoutEvent(e.system.Hostname(), ev, now()))
+			}
+			for _, event := range events {
+				if err := e.record(ctx, names, event); err != nil {
+					e.logger.Error("recording event", "id", event.ID, "type", event.Type, "err", err)
+				}
+			}
+		}
+	})
+	return g.Wait()
+}
+
+// statusChanged determines whether a run event changes the status of the run
+// since it was last seen, and updates the last seen status accordingly.
+func (e *Exporter) statusChanged(event pubsub.Event[*run.Run]) bool {
+	r := event.Payload
+	if event.Type == pubsub.DeletedEvent {
+		delete(e.statuses, r.ID)
+		return true
+	}
+	if last, ok := e.statuses[r.ID]; ok && last == r.Status {
+		return false
+	}
+	if r.Done() {
+		delete(e.statuses, r.ID)
+	} else {
+		e.statuses[r.ID] = r.Status
+	}
+	return true
+}
+
+// pauseChanged determines whether a workspace event pauses or resumes the
+// workspace since it was last seen, returning an event recording the change if
+// so, and updates the last seen pause state accordingly.
+func (e *Exporter) pauseChanged(source string, event pubsub.Event[*workspace.Workspace], now time.Time) *Event {
+	ws := event.Payload
+	pausedAt, wasPaused := e.paused[ws.ID]
+	switch {
+	case event.Type == pubsub.DeletedEvent:
+		delete(e.paused, ws.ID)
+	case ws.Paused && ws.PausedAt != nil && (!wasPaused || !pausedAt.Equal(*ws.PausedAt)):
+		e.paused[ws.ID] = *ws.PausedAt
+		return newWorkspacePauseEvent(source, ws, *ws.PausedAt, now)
+	case !ws.Paused && wasPaused:
+		delete(e.paused, ws.ID)
+		return newWorkspacePauseEvent(source, ws, pausedAt, now)
+	}
+	return nil
+}
+
+func (e *Exporter) record(ctx context.Context, sinks []string, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return e.db.record(ctx, sinks, payload, internal.CurrentTimestamp(nil))
+}
+
+// deliver repeatedly delivers batches of events from the outbox of a sink until
+// the context is canceled, backing off whenever delivery fails.
+func (e *Exporter) deliver(ctx context.Context, name string, s sink) {
+	policy := backoff.NewExponentialBackOff()
+	policy.MaxInterval = maxRetryInterval
+	policy.MaxElapsedTime = 0 // retry indefinitely
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		n, err := e.flush(ctx, name, s)
+		switch {
+		case err != nil:
+			next := policy.NextBackOff()
+			e.logger.Error("delivering events", "sink", name, "backoff", next, "err", err)
+			timer.Reset(next)
+		case n == e.batchSize:
+			// there may be more events awaiting delivery
+			policy.Reset()
+			timer.Reset(0)
+		default:
+			policy.Reset()
+			timer.Reset(e.interval)
+		}
+	}
+}
+
+// flush delivers the oldest batch of events from the outbox of a sink,
+// returning the number of events delivered.
+func (e *Exporter) flush(ctx context.Context, name string, s sink) (int, error) {
+	events, err := e.db.listOutbox(ctx, name, e.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		lagMetric.WithLabelValues(name).Set(0)
+		return 0, nil
+	}
+	now := internal.CurrentTimestamp(nil)
+	lagMetric.WithLabelValues(name).Set(now.Sub(events[0].createdAt).Seconds())
+
+	payloads := make([][]byte, len(events))
+	for i, event := range events {
+		payloads[i] = event.payload
+	}
+	if err := s.deliver(ctx, payloads); err != nil {
+		failuresMetric.WithLabelValues(name).Inc()
+		if err := e.db.failed(ctx, name, err, now); err != nil {
+			e.logger.Error("recording failed delivery", "sink", name, "err", err)
+		}
+		return 0, err
+	}
+	if err := e.db.delivered(ctx, name, events[len(events)-1].id, len(events), now); err != nil {
+		// the events are re-delivered on the next attempt
+		return 0, err
+	}
+	deliveredMetric.WithLabelValues(name).Add(float64(len(events)))
+	e.logger.Debug("delivered events", "sink", name, "count", len(events))
+	return len(events), nil
+}
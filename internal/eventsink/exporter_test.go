@@ -0,0 +1,58 @@
+package eventsink
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+func TestExporter_statusChanged(t *testing.T) {
+	e := &Exporter{statuses: make(map[string]run.Status)}
+	r := &run.Run{ID: "run-123", Status: run.RunPending}
+
+	assert.True(t, e.statusChanged(pubsub.NewCreatedEvent(r)))
+	// an update that does not change the status is ignored
+	assert.False(t, e.statusChanged(pubsub.NewUpdatedEvent(r)))
+
+	r.Status = run.RunApplied
+	assert.True(t, e.statusChanged(pubsub.NewUpdatedEvent(r)))
+	// finished runs are forgotten
+	assert.NotContains(t, e.statuses, "run-123")
+}
+
+func TestExporter_pauseChanged(t *testing.T) {
+	e := &Exporter{paused: make(map[string]time.Time)}
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	pausedAt := now.Add(-time.Minute)
+	ws := &workspace.Workspace{ID: "ws-123", Organization: "acme"}
+
+	// an active workspace is neither paused nor resumed
+	assert.Nil(t, e.pauseChanged("otf.example.com", pubsub.NewUpdatedEvent(ws), now))
+
+	ws.Paused = true
+	ws.PausedAt = &pausedAt
+	got := e.pauseChanged("otf.example.com", pubsub.NewUpdatedEvent(ws), now)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "workspace.paused", got.Type)
+		assert.Equal(t, fmt.Sprintf("ws-123.paused.%d", pausedAt.Unix()), got.ID)
+		assert.Equal(t, pausedAt, got.Time)
+		assert.True(t, got.Data.(WorkspaceData).Paused)
+	}
+	// an update that does not change whether the workspace is paused is ignored
+	assert.Nil(t, e.pauseChanged("otf.example.com", pubsub.NewUpdatedEvent(ws), now))
+
+	ws.Paused = false
+	ws.PausedAt = nil
+	got = e.pauseChanged("otf.example.com", pubsub.NewUpdatedEvent(ws), now)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "workspace.resumed", got.Type)
+		assert.Equal(t, fmt.Sprintf("ws-123.resumed.%d", pausedAt.Unix()), got.ID)
+		assert.Equal(t, now, got.Time)
+	}
+	assert.NotContains(t, e.paused, "ws-123")
+}
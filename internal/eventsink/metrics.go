@@ -0,0 +1,30 @@
+package eventsink
+
+import "github.com/prometheus/client_golang/prometheus"
+
+func init() {
+	prometheus.MustRegister(lagMetric)
+	prometheus.MustRegister(deliveredMetric)
+	prometheus.MustRegister(failuresMetric)
+}
+
+var (
+	lagMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "otf",
+		Subsystem: "event_sink",
+		Name:      "lag_seconds",
+		Help:      "Age of the oldest event awaiting delivery to an event sink.",
+	}, []string{"sink"})
+	deliveredMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "otf",
+		Subsystem: "event_sink",
+		Name:      "delivered_total",
+		Help:      "Total number of events delivered to an event sink.",
+	}, []string{"sink"})
+	failuresMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "otf",
+		Subsystem: "event_sink",
+		Name:      "failures_total",
+		Help:      "Total number of failed attempts to deliver events to an event sink.",
+	}, []string{"sink"})
+)
@@ -0,0 +1,115 @@
+package eventsink
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/sql"
+)
+
+type (
+	Service struct {
+		logger *slog.Logger
+
+		site  internal.Authorizer
+		db    *pgdb
+		web   *webHandlers
+		sinks []Config
+	}
+
+	Options struct {
+		Logger *slog.Logger
+		Sinks  []Config
+
+		*sql.Pool
+		html.Renderer
+	}
+
+	// Status is the delivery status of a sink.
+	Status struct {
+		Name   string
+		Kind   string
+		Target string
+		// Pending is the number of events awaiting delivery.
+		Pending int
+		// OldestPendingAt is the time at which the oldest event awaiting
+		// delivery was recorded. Nil if there are no events awaiting
+		// delivery.
+		OldestPendingAt *time.Time
+		// Delivered is the total number of events delivered.
+		Delivered int
+		// Failures is the total number of failed attempts to deliver events.
+		Failures        int
+		LastDeliveredAt *time.Time
+		LastFailedAt    *time.Time
+		LastError       *string
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		logger: opts.Logger,
+		site:   &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:     &pgdb{opts.Pool},
+		sinks:  opts.Sinks,
+	}
+	svc.web = &webHandlers{
+		Renderer: opts.Renderer,
+		svc:      &svc,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.web.addHandlers(r)
+}
+
+// HasSinks determines whether any sinks are configured.
+func (s *Service) HasSinks() bool {
+	return len(s.sinks) > 0
+}
+
+// List lists the delivery status of each configured sink. Only a site admin
+// may list the statuses.
+func (s *Service) List(ctx context.Context) ([]*Status, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.GetEventSinksAction, "")
+	if err != nil {
+		return nil, err
+	}
+	recorded, err := s.db.listStatuses(ctx)
+	if err != nil {
+		s.logger.Error("listing event sinks", "subject", subject, "err", err)
+		return nil, err
+	}
+	byName := make(map[string]*Status, len(recorded))
+	for _, status := range recorded {
+		byName[status.Name] = status
+	}
+	// the status of a sink is only recorded once the exporter has started,
+	// and sinks that are no longer configured are omitted.
+	statuses := make([]*Status, len(s.sinks))
+	for i, cfg := range s.sinks {
+		status, ok := byName[cfg.Name]
+		if !ok {
+			status = &Status{Name: cfg.Name}
+		}
+		status.Kind = cfg.Kind()
+		status.Target = cfg.Target()
+		statuses[i] = status
+	}
+	return statuses, nil
+}
+
+// Failing determines whether the most recent attempt to deliver events
+// failed.
+func (s *Status) Failing() bool {
+	if s.LastFailedAt == nil {
+		return false
+	}
+	return s.LastDeliveredAt == nil || s.LastFailedAt.After(*s.LastDeliveredAt)
+}
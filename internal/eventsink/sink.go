@@ -0,0 +1,114 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var ErrInvalidSink = errors.New("invalid event sink")
+
+type (
+	// Config configures an event sink.
+	Config struct {
+		// Name uniquely identifies the sink. Events awaiting delivery are
+		// recorded against the name, so it should not be changed whilst
+		// events are pending.
+		Name string
+		URL  *url.URL
+		// HMACSecret, if non-empty, signs the payloads delivered to an HTTP
+		// sink.
+		HMACSecret string
+	}
+
+	// sink delivers batches of events, each of which is a JSON-encoded Event.
+	sink interface {
+		deliver(ctx context.Context, events [][]byte) error
+	}
+)
+
+// ParseConfig parses a sink configuration of the form <name>=<url>, where the
+// scheme of the URL determines the kind of sink:
+//
+//	http, https: events are POSTed to the URL
+//	syslog, syslog+udp, syslog+tcp: events are sent to a syslog server
+//	file: events are appended to a file
+func ParseConfig(s string) (Config, error) {
+	name, rawURL, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return Config{}, fmt.Errorf("%w: %s: must be of the form <name>=<url>", ErrInvalidSink, s)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Config{}, fmt.Errorf("%w: %s: %w", ErrInvalidSink, name, err)
+	}
+	cfg := Config{Name: name, URL: u}
+	if _, err := cfg.newSink(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// ParseConfigs parses several sink configurations, and checks their names are
+// unique.
+func ParseConfigs(specs []string, hmacSecret string) ([]Config, error) {
+	configs := make([]Config, len(specs))
+	names := make(map[string]bool, len(specs))
+	for i, spec := range specs {
+		cfg, err := ParseConfig(spec)
+		if err != nil {
+			return nil, err
+		}
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("%w: duplicate name: %s", ErrInvalidSink, cfg.Name)
+		}
+		names[cfg.Name] = true
+		cfg.HMACSecret = hmacSecret
+		configs[i] = cfg
+	}
+	return configs, nil
+}
+
+// Kind is the kind of sink: http, syslog or file.
+func (cfg Config) Kind() string {
+	switch cfg.URL.Scheme {
+	case "http", "https":
+		return "http"
+	case "syslog", "syslog+udp", "syslog+tcp":
+		return "syslog"
+	default:
+		return cfg.URL.Scheme
+	}
+}
+
+// Target is the URL of the sink with any credentials removed, suitable for
+// displaying to users.
+func (cfg Config) Target() string {
+	u := *cfg.URL
+	u.User = nil
+	return u.String()
+}
+
+func (cfg Config) newSink() (sink, error) {
+	switch cfg.URL.Scheme {
+	case "http", "https":
+		if cfg.URL.Host == "" {
+			return nil, fmt.Errorf("%w: %s: missing host", ErrInvalidSink, cfg.Name)
+		}
+		return newHTTPSink(cfg), nil
+	case "syslog", "syslog+udp", "syslog+tcp":
+		if cfg.URL.Port() == "" {
+			return nil, fmt.Errorf("%w: %s: missing port", ErrInvalidSink, cfg.Name)
+		}
+		return newSyslogSink(cfg), nil
+	case "file":
+		if cfg.URL.Path == "" {
+			return nil, fmt.Errorf("%w: %s: missing path", ErrInvalidSink, cfg.Name)
+		}
+		return &fileSink{path: cfg.URL.Path}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s: unsupported scheme: %s", ErrInvalidSink, cfg.Name, cfg.URL.Scheme)
+	}
+}
@@ -0,0 +1,31 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"os"
+)
+
+// fileSink appends events to a file as newline-delimited JSON. The file is
+// re-opened for each batch, permitting it to be rotated.
+type fileSink struct {
+	path string
+}
+
+func (s *fileSink) deliver(ctx context.Context, events [][]byte) error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	body := append(bytes.Join(events, []byte("\n")), '\n')
+	if _, err := f.Write(body); err != nil {
+		return err
+	}
+	// ensure events are persisted before they are removed from the outbox
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,81 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader is the header containing the HMAC-SHA256 signature of
+	// the body of a request to an HTTP sink, prefixed with "sha256=".
+	SignatureHeader = "X-OTF-Signature"
+	// SchemaVersionHeader is the header containing the schema version of the
+	// events in the body of a request to an HTTP sink.
+	SchemaVersionHeader = "X-OTF-Schema-Version"
+
+	httpSinkTimeout = 30 * time.Second
+)
+
+// httpSink POSTs a batch of events to a URL as newline-delimited JSON. If the
+// URL contains credentials then they are sent using basic authentication.
+type httpSink struct {
+	client   *http.Client
+	url      string
+	username string
+	password string
+	secret   string
+}
+
+func newHTTPSink(cfg Config) *httpSink {
+	u := *cfg.URL
+	s := &httpSink{
+		client: &http.Client{Timeout: httpSinkTimeout},
+		secret: cfg.HMACSecret,
+	}
+	if u.User != nil {
+		s.username = u.User.Username()
+		s.password, _ = u.User.Password()
+		u.User = nil
+	}
+	s.url = u.String()
+	return s
+}
+
+func (s *httpSink) deliver(ctx context.Context, events [][]byte) error {
+	body := append(bytes.Join(events, []byte("\n")), '\n')
+	req, err := http.NewRequestWithContext(ctx, "POST", s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set(SchemaVersionHeader, strconv.Itoa(SchemaVersion))
+	if s.username != "" || s.password != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+	if s.secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+sign(body, s.secret))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	return nil
+}
+
+// sign returns the hex-encoded HMAC-SHA256 signature of the body.
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,68 @@
+package eventsink
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSink(t *testing.T) {
+	var (
+		gotBody      []byte
+		gotSignature string
+		gotUser      string
+		gotPassword  string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotUser, gotPassword, _ = r.BasicAuth()
+		assert.Equal(t, "1", r.Header.Get(SchemaVersionHeader))
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg, err := ParseConfig("test=" + srv.URL)
+	require.NoError(t, err)
+	cfg.HMACSecret = "secret"
+
+	events := [][]byte{[]byte(`{"id":"1"}`), []byte(`{"id":"2"}`)}
+	err = newHTTPSink(cfg).deliver(context.Background(), events)
+	require.NoError(t, err)
+
+	assert.Equal(t, "{\"id\":\"1\"}\n{\"id\":\"2\"}\n", string(gotBody))
+	assert.Equal(t, "sha256="+sign(gotBody, "secret"), gotSignature)
+	assert.Empty(t, gotUser)
+	assert.Empty(t, gotPassword)
+
+	t.Run("basic auth", func(t *testing.T) {
+		cfg, err := ParseConfig("test=" + strings.Replace(srv.URL, "http://", "http://x:token@", 1))
+		require.NoError(t, err)
+
+		err = newHTTPSink(cfg).deliver(context.Background(), events)
+		require.NoError(t, err)
+
+		assert.Equal(t, "x", gotUser)
+		assert.Equal(t, "token", gotPassword)
+		assert.Empty(t, gotSignature)
+	})
+}
+
+func TestHTTPSink_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg, err := ParseConfig("test=" + srv.URL)
+	require.NoError(t, err)
+
+	err = newHTTPSink(cfg).deliver(context.Background(), [][]byte{[]byte(`{}`)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+const (
+	// syslogPriority is the priority of syslog messages: facility local0 and
+	// severity informational.
+	syslogPriority = 16*8 + 6
+	syslogAppName  = "tofutfd"
+	syslogTimeout  = 30 * time.Second
+)
+
+// syslogSink sends each event as an RFC 5424 message to a syslog server, with
+// the event as the message body. UDP is used unless the url scheme is
+// syslog+tcp, in which case messages are framed using octet counting as per
+// RFC 6587.
+type syslogSink struct {
+	network  string
+	address  string
+	hostname string
+}
+
+func newSyslogSink(cfg Config) *syslogSink {
+	network := "udp"
+	if cfg.URL.Scheme == "syslog+tcp" {
+		network = "tcp"
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+	return &syslogSink{
+		network:  network,
+		address:  cfg.URL.Host,
+		hostname: hostname,
+	}
+}
+
+func (s *syslogSink) deliver(ctx context.Context, events [][]byte) error {
+	dialer := net.Dialer{Timeout: syslogTimeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetWriteDeadline(time.Now().Add(syslogTimeout)); err != nil {
+		return err
+	}
+	for _, event := range events {
+		msg, err := s.format(event)
+		if err != nil {
+			return err
+		}
+		if s.network == "tcp" {
+			msg = append([]byte(fmt.Sprintf("%d ", len(msg))), msg...)
+		}
+		if _, err := conn.Write(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// format formats an event as an RFC 5424 message, using the event time as the
+// timestamp and the event type as the message ID.
+func (s *syslogSink) format(event []byte) ([]byte, error) {
+	var header struct {
+		Type string    `json:"type"`
+		Time time.Time `json:"time"`
+	}
+	if err := json.Unmarshal(event, &header); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>1 %s %s %s %d %s - ",
+		syslogPriority,
+		header.Time.UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		syslogAppName,
+		os.Getpid(),
+		header.Type,
+	)
+	buf.Write(event)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,59 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogSink_TCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// read octet-counted messages until the sink closes the connection
+		var msgs []string
+		r := bufio.NewReader(conn)
+		for {
+			length, err := r.ReadString(' ')
+			if err != nil {
+				break
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(length))
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				break
+			}
+			msgs = append(msgs, string(buf))
+		}
+		received <- msgs
+	}()
+
+	cfg, err := ParseConfig("test=syslog+tcp://" + ln.Addr().String())
+	require.NoError(t, err)
+
+	err = newSyslogSink(cfg).deliver(context.Background(), [][]byte{
+		[]byte(`{"type":"run.status_changed","time":"2026-10-14T09:00:00Z"}`),
+		[]byte(`{"type":"workspace.created","time":"2026-10-14T09:01:00Z"}`),
+	})
+	require.NoError(t, err)
+
+	msgs := <-received
+	require.Len(t, msgs, 2)
+	assert.Regexp(t, `^<134>1 2026-10-14T09:00:00Z \S+ tofutfd \d+ run.status_changed - {"type":"run.status_changed"`, msgs[0])
+	assert.Regexp(t, `^<134>1 2026-10-14T09:01:00Z \S+ tofutfd \d+ workspace.created - `, msgs[1])
+}
@@ -0,0 +1,40 @@
+package eventsink
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal/http/html"
+)
+
+type webHandlers struct {
+	html.Renderer
+
+	svc webClient
+}
+
+type webClient interface {
+	List(ctx context.Context) ([]*Status, error)
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/admin/event-sinks", h.list).Methods("GET")
+}
+
+func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.svc.List(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.Render("event_sink_list.tmpl", w, struct {
+		html.SitePage
+		Sinks []*Status
+	}{
+		SitePage: html.NewSitePage(r, "event sinks"),
+		Sinks:    statuses,
+	})
+}
@@ -19,6 +19,9 @@ type Handler struct {
 
 	VCSProviders *vcsprovider.Service
 	GithubApps   *github.Service
+
+	// UnknownEvents determines how events with an unknown type are handled.
+	UnknownEvents vcs.UnknownEventPolicy
 }
 
 func (h *Handler) AddHandlers(r *mux.Router) {
@@ -39,6 +42,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// use github-specific handler to unmarshal event
 	payload, err := github.HandleEvent(r, app.WebhookSecret)
+	err = h.UnknownEvents.Apply(err)
 	// either ignore the event, return an error, or publish the event onwards
 	var ignore vcs.ErrIgnoreEvent
 	if errors.As(err, &ignore) {
@@ -82,6 +82,9 @@ func NewClient(cfg ClientOptions) (*Client, error) {
 	if cfg.SkipTLSVerification {
 		tripper = otfhttp.InsecureTransport
 	}
+	// cache responses and track rate limits beneath the authenticating
+	// transports, so that each is tracked per credential.
+	tripper = vcs.NewTransport(vcs.GithubKind, cfg.Hostname, tripper)
 	switch {
 	case cfg.AppCredentials != nil:
 		tripper, err = ghinstallation.NewAppsTransport(tripper, cfg.AppCredentials.ID, []byte(cfg.AppCredentials.PrivateKey))
@@ -160,9 +163,15 @@ func (g *Client) GetRepository(ctx context.Context, identifier string) (vcs.Repo
 	if err != nil {
 		return vcs.Repository{}, err
 	}
+	// github redirects requests for a renamed or transferred repo to its new
+	// location, so the path may differ from the identifier.
+	path := repo.GetFullName()
+	if path == "" {
+		path = identifier
+	}
 
 	return vcs.Repository{
-		Path:          identifier,
+		Path:          path,
 		DefaultBranch: repo.GetDefaultBranch(),
 	}, nil
 }
@@ -327,6 +336,8 @@ func (g *Client) CreateWebhook(ctx context.Context, opts vcs.CreateWebhookOption
 			events = append(events, "push")
 		case vcs.EventTypePull:
 			events = append(events, "pull_request")
+		case vcs.EventTypeRepository:
+			events = append(events, "repository")
 		}
 	}
 
@@ -363,6 +374,8 @@ func (g *Client) UpdateWebhook(ctx context.Context, id string, opts vcs.UpdateWe
 			events = append(events, "push")
 		case vcs.EventTypePull:
 			events = append(events, "pull_request")
+		case vcs.EventTypeRepository:
+			events = append(events, "repository")
 		}
 	}
 
@@ -407,6 +420,8 @@ func (g *Client) GetWebhook(ctx context.Context, opts vcs.GetWebhookOptions) (vc
 			events = append(events, vcs.EventTypePush)
 		case "pull_request":
 			events = append(events, vcs.EventTypePull)
+		case "repository":
+			events = append(events, vcs.EventTypeRepository)
 		}
 	}
 
@@ -10,12 +10,19 @@ import (
 	"github.com/tofutf/tofutf/internal/vcs"
 )
 
+// supportedEvents are the types of github event that are handled.
+var supportedEvents = []string{"push", "pull_request", "installation", "repository"}
+
 func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 	payload, err := github.ValidatePayload(r, []byte(secret))
 	if err != nil {
 		return nil, fmt.Errorf("validating payload: %w", err)
 	}
-	raw, err := github.ParseWebHook(github.WebHookType(r), payload)
+	eventType := github.WebHookType(r)
+	if !slices.Contains(supportedEvents, eventType) {
+		return nil, vcs.NewErrUnknownEvent(eventType)
+	}
+	raw, err := github.ParseWebHook(eventType, payload)
 	if err != nil {
 		return nil, fmt.Errorf("parsing payload: %w", err)
 	}
@@ -70,6 +77,7 @@ func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 		to.PullRequestNumber = event.GetPullRequest().GetNumber()
 		to.PullRequestURL = event.GetPullRequest().GetHTMLURL()
 		to.PullRequestTitle = event.GetPullRequest().GetTitle()
+		to.PullRequestDraft = event.GetPullRequest().GetDraft()
 
 		to.SenderUsername = event.GetSender().GetLogin()
 		to.SenderAvatarURL = event.GetSender().GetAvatarURL()
@@ -88,7 +96,7 @@ func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 			} else {
 				to.Action = vcs.ActionDeleted
 			}
-		case "synchronize":
+		case "synchronize", "ready_for_review":
 			to.Action = vcs.ActionUpdated
 		default:
 			// ignore other pull request events
@@ -110,8 +118,33 @@ func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 		to.Action = vcs.ActionDeleted
 		to.Type = vcs.EventTypeInstallation
 		to.GithubAppInstallID = event.GetInstallation().ID
+	case *github.RepositoryEvent:
+		to.Type = vcs.EventTypeRepository
+		to.Action = vcs.ActionMoved
+		to.RepoPath = event.GetRepo().GetFullName()
+		to.SenderUsername = event.GetSender().GetLogin()
+		to.SenderAvatarURL = event.GetSender().GetAvatarURL()
+		to.SenderHTMLURL = event.GetSender().GetHTMLURL()
+		if install := event.GetInstallation(); install != nil {
+			to.GithubAppInstallID = install.ID
+		}
+		// reconstruct the path of the repo prior to it being moved
+		switch event.GetAction() {
+		case "renamed":
+			to.PreviousRepoPath = event.GetRepo().GetOwner().GetLogin() + "/" + event.GetChanges().GetRepo().GetName().GetFrom()
+		case "transferred":
+			from := event.GetChanges().GetOwner().GetOwnerInfo()
+			owner := from.GetOrg().GetLogin()
+			if owner == "" {
+				owner = from.GetUser().GetLogin()
+			}
+			to.PreviousRepoPath = owner + "/" + event.GetRepo().GetName()
+		default:
+			// ignore other repository events
+			return nil, vcs.NewErrIgnoreEvent("unsupported action: %s", event.GetAction())
+		}
 	default:
-		return nil, vcs.NewErrIgnoreEvent("unsupported event: %T", raw)
+		return nil, vcs.NewErrUnknownEvent(fmt.Sprintf("%T", raw))
 	}
 	if err := to.Validate(); err != nil {
 		return nil, fmt.Errorf("failed building OTF event: %w", err)
@@ -1,9 +1,14 @@
 package github
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-github/v55/github"
@@ -106,6 +111,51 @@ func TestEventHandler(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"draft pull request opened",
+			"pull_request",
+			"./testdata/github_pull_opened_draft.json",
+			&vcs.EventPayload{
+				VCSKind:           vcs.GithubKind,
+				Type:              vcs.EventTypePull,
+				RepoPath:          "leg100/otf-workspaces",
+				Branch:            "pr-2",
+				DefaultBranch:     "master",
+				CommitSHA:         "c560613b228f5e189520fbab4078284ea8312bcb",
+				CommitURL:         "https://github.com/tofutf/tofutf-workspaces/commit/c560613b228f5e189520fbab4078284ea8312bcb",
+				PullRequestNumber: 2,
+				PullRequestURL:    "https://github.com/tofutf/tofutf-workspaces/pull/2",
+				PullRequestTitle:  "pr-2",
+				PullRequestDraft:  true,
+				Action:            vcs.ActionCreated,
+				SenderUsername:    "leg100",
+				SenderAvatarURL:   "https://avatars.githubusercontent.com/u/75728?v=4",
+				SenderHTMLURL:     "https://github.com/leg100",
+			},
+			false,
+		},
+		{
+			"pull request ready for review",
+			"pull_request",
+			"./testdata/github_pull_ready_for_review.json",
+			&vcs.EventPayload{
+				VCSKind:           vcs.GithubKind,
+				Type:              vcs.EventTypePull,
+				RepoPath:          "leg100/otf-workspaces",
+				Branch:            "pr-1",
+				DefaultBranch:     "master",
+				CommitSHA:         "067e2b4c6394b3dad3c0ec89ffc428ab60ae7e5d",
+				CommitURL:         "https://github.com/tofutf/tofutf-workspaces/commit/067e2b4c6394b3dad3c0ec89ffc428ab60ae7e5d",
+				PullRequestNumber: 1,
+				PullRequestURL:    "https://github.com/tofutf/tofutf-workspaces/pull/1",
+				PullRequestTitle:  "pr-1",
+				Action:            vcs.ActionUpdated,
+				SenderUsername:    "leg100",
+				SenderAvatarURL:   "https://avatars.githubusercontent.com/u/75728?v=4",
+				SenderHTMLURL:     "https://github.com/leg100",
+			},
+			false,
+		},
 		{
 			"tag pushed",
 			"push",
@@ -125,6 +175,46 @@ func TestEventHandler(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"repository renamed",
+			"repository",
+			"./testdata/github_repository_renamed.json",
+			&vcs.EventPayload{
+				VCSKind:          vcs.GithubKind,
+				Type:             vcs.EventTypeRepository,
+				Action:           vcs.ActionMoved,
+				RepoPath:         "leg100/tofutf-workspaces",
+				PreviousRepoPath: "leg100/otf-workspaces",
+				SenderUsername:   "leg100",
+				SenderAvatarURL:  "https://avatars.githubusercontent.com/u/75728?v=4",
+				SenderHTMLURL:    "https://github.com/leg100",
+			},
+			false,
+		},
+		{
+			"repository transferred",
+			"repository",
+			"./testdata/github_repository_transferred.json",
+			&vcs.EventPayload{
+				VCSKind:            vcs.GithubKind,
+				Type:               vcs.EventTypeRepository,
+				Action:             vcs.ActionMoved,
+				RepoPath:           "tofutf/otf-workspaces",
+				PreviousRepoPath:   "leg100/otf-workspaces",
+				SenderUsername:     "leg100",
+				SenderAvatarURL:    "https://avatars.githubusercontent.com/u/75728?v=4",
+				SenderHTMLURL:      "https://github.com/leg100",
+				GithubAppInstallID: internal.Int64(42997659),
+			},
+			false,
+		},
+		{
+			"ignore repository archived",
+			"repository",
+			"./testdata/github_repository_archived.json",
+			nil,
+			true,
+		},
 		{
 			"ignore github app install created",
 			"installation",
@@ -162,3 +252,43 @@ func TestEventHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestEventHandler_UnknownType(t *testing.T) {
+	f, err := os.Open("./testdata/github_push.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	r := httptest.NewRequest("POST", "/", f)
+	r.Header.Add("Content-type", "application/json")
+	r.Header.Add(github.EventTypeHeader, "ping")
+	_, err = HandleEvent(r, "")
+
+	var ignore vcs.ErrIgnoreEvent
+	require.True(t, errors.As(err, &ignore))
+	assert.True(t, ignore.UnknownType)
+
+	// rejecting unknown types converts the error into one that is no longer
+	// ignored
+	err = vcs.RejectUnknownEvents.Apply(err)
+	assert.ErrorIs(t, err, vcs.ErrRejectedEvent)
+	assert.False(t, errors.As(err, &ignore))
+}
+
+func TestEventHandler_FormEncoded(t *testing.T) {
+	payload, err := os.ReadFile("./testdata/github_push.json")
+	require.NoError(t, err)
+	body := url.Values{"payload": []string{string(payload)}}.Encode()
+
+	// signature is calculated over the form-encoded body
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(body))
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	r.Header.Add("Content-type", "application/x-www-form-urlencoded")
+	r.Header.Add(github.EventTypeHeader, "push")
+	r.Header.Add(github.SHA256SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	got, err := HandleEvent(r, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "leg100/tfc-workspaces", got.RepoPath)
+	assert.Equal(t, "42d6fc7dac35cc7945231195e248af2f6256b522", got.CommitSHA)
+}
@@ -79,7 +79,7 @@ func (h *webHandlers) new(w http.ResponseWriter, r *http.Request) {
 		HookAttrs:   hookAttrs{URL: h.WebhookURL(AppEventsPath)},
 		Redirect:    h.URL(paths.ExchangeCodeGithubApp()),
 		Description: "Trigger terraform runs in OTF from GitHub",
-		Events:      []string{"push", "pull_request"},
+		Events:      []string{"push", "pull_request", "repository"},
 		Public:      false,
 		Permissions: map[string]string{
 			"checks":        "write",
@@ -44,11 +44,13 @@ func NewClient(cfg ClientOptions) (*Client, error) {
 			),
 		}
 	)
+	tripper := http.DefaultTransport
 	if cfg.SkipTLSVerification {
-		options = append(options, gitlab.WithHTTPClient(
-			&http.Client{Transport: otfhttp.InsecureTransport},
-		))
+		tripper = otfhttp.InsecureTransport
 	}
+	options = append(options, gitlab.WithHTTPClient(
+		&http.Client{Transport: vcs.NewTransport(vcs.GitlabKind, cfg.Hostname, tripper)},
+	))
 	if cfg.OAuthToken != nil {
 		client, err = gitlab.NewOAuthClient(cfg.OAuthToken.AccessToken, options...)
 	} else if cfg.PersonalToken != nil {
@@ -14,6 +14,13 @@ import (
 	"github.com/xanzy/go-gitlab"
 )
 
+// supportedEvents are the types of gitlab event that are handled.
+var supportedEvents = []gitlab.EventType{
+	gitlab.EventTypePush,
+	gitlab.EventTypeMergeRequest,
+	gitlab.EventTypeTagPush,
+}
+
 func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 	if token := r.Header.Get("X-Gitlab-Token"); token != secret {
 		return nil, errors.New("token validation failed")
@@ -28,11 +35,19 @@ func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 		}
 		origin = u
 	}
-	payload, err := io.ReadAll(r.Body)
-	if err != nil || len(payload) == 0 {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
 		return nil, errors.New("error reading request body")
 	}
-	rawEvent, err := gitlab.ParseWebhook(gitlab.HookEventType(r), payload)
+	payload, err := vcs.WebhookPayload(r.Header.Get("Content-Type"), body)
+	if err != nil {
+		return nil, err
+	}
+	eventType := gitlab.HookEventType(r)
+	if !slices.Contains(supportedEvents, eventType) {
+		return nil, vcs.NewErrUnknownEvent(string(eventType))
+	}
+	rawEvent, err := gitlab.ParseWebhook(eventType, payload)
 	if err != nil {
 		return nil, fmt.Errorf("parsing webhook: %w", err)
 	}
@@ -80,6 +95,7 @@ func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 		to.PullRequestNumber = event.ObjectAttributes.IID
 		to.PullRequestURL = event.ObjectAttributes.URL
 		to.PullRequestTitle = event.ObjectAttributes.Title
+		to.PullRequestDraft = event.ObjectAttributes.Draft
 		to.DefaultBranch = event.Project.DefaultBranch
 		to.RepoPath = event.Project.PathWithNamespace
 		to.SenderUsername = event.User.Username
@@ -107,7 +123,7 @@ func HandleEvent(r *http.Request, secret string) (*vcs.EventPayload, error) {
 		to.SenderAvatarURL = event.UserAvatar
 		to.SenderHTMLURL = userURL(origin, event.UserUsername)
 	default:
-		return nil, vcs.NewErrIgnoreEvent("unsupported event type: %T", rawEvent)
+		return nil, vcs.NewErrUnknownEvent(fmt.Sprintf("%T", rawEvent))
 	}
 	if err := to.Validate(); err != nil {
 		return nil, fmt.Errorf("failed building OTF event: %w", err)
@@ -23,6 +23,7 @@ type (
 		KeyID            pgtype.Text        `json:"key_id"`
 		CreatedAt        pgtype.Timestamptz `json:"created_at"`
 		UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+		RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
 	}
 
 	pgUpdateOpts struct {
@@ -41,10 +42,16 @@ type (
 		organization string
 		keyID        string
 	}
+
+	pgRevokeOpts struct {
+		organization string
+		keyID        string
+		revokedAt    time.Time
+	}
 )
 
 func (r pgresult) toRegistryGPGKey() *GPGKey {
-	return &GPGKey{
+	key := &GPGKey{
 		OrganizationName: r.OrganizationName.String,
 		ID:               r.ID.String,
 		ASCIIArmor:       r.AsciiArmor.String,
@@ -52,6 +59,10 @@ func (r pgresult) toRegistryGPGKey() *GPGKey {
 		KeyID:            r.KeyID.String,
 		UpdatedAt:        r.UpdatedAt.Time,
 	}
+	if r.RevokedAt.Valid {
+		key.RevokedAt = &r.RevokedAt.Time
+	}
+	return key
 }
 
 type GPGKey struct {
@@ -60,10 +71,19 @@ type GPGKey struct {
 	ASCIIArmor       string
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
+	// RevokedAt is when the key was revoked. A revoked key can no longer be
+	// used to publish artifacts, but artifacts already published under the
+	// key remain valid.
+	RevokedAt *time.Time
 
 	KeyID string
 }
 
+// Revoked determines whether the key has been revoked.
+func (k *GPGKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
 func (db pgdb) getRegistryGPGKey(ctx context.Context, opts pgGetOptions) (*GPGKey, error) {
 	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*GPGKey, error) {
 		row, err := q.GetGPGKey(ctx, sql.String(opts.keyID), sql.String(opts.organization))
@@ -126,6 +146,25 @@ func (db *pgdb) updateRegistryGPGKey(ctx context.Context, opts pgUpdateOpts) err
 	})
 }
 
+func (db *pgdb) revokeRegistryGPGKey(ctx context.Context, opts pgRevokeOpts) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		response, err := q.RevokeGPGKey(ctx, pggen.RevokeGPGKeyParams{
+			RevokedAt:        sql.Timestamptz(opts.revokedAt),
+			KeyID:            sql.String(opts.keyID),
+			OrganizationName: sql.String(opts.organization),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+
+		if count := response.RowsAffected(); count != 1 {
+			return sql.Error(fmt.Errorf("unable to revoke registry gpg key"))
+		}
+
+		return nil
+	})
+}
+
 func (db *pgdb) createRegistryGPGKey(ctx context.Context, key *GPGKey) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.InsertGPGKey(ctx, pggen.InsertGPGKeyParams{
@@ -183,6 +183,51 @@ func (s *Service) Delete(ctx context.Context, opts DeleteOptions) (*GPGKey, erro
 	return key, nil
 }
 
+type RevokeOptions struct {
+	RegistryName string
+	Organization string
+	KeyID        string
+}
+
+// Revoke revokes a key, preventing its use for any further publications.
+// Artifacts already published under the key remain valid.
+func (s *Service) Revoke(ctx context.Context, opts RevokeOptions) (*GPGKey, error) {
+	if opts.RegistryName != "private" {
+		return nil, fmt.Errorf("invalid registry_name, only private registry supports gpg key functionality")
+	}
+
+	_, err := s.orgAuthorizer.CanAccess(ctx, rbac.UpdateGPGKeyAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.db.getRegistryGPGKey(ctx, pgGetOptions{
+		organization: opts.Organization,
+		keyID:        opts.KeyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if key.Revoked() {
+		return key, nil
+	}
+
+	err = s.db.revokeRegistryGPGKey(ctx, pgRevokeOpts{
+		organization: opts.Organization,
+		keyID:        opts.KeyID,
+		revokedAt:    internal.CurrentTimestamp(nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("revoked gpg key", "organization", opts.Organization, "key_id", opts.KeyID)
+
+	return s.db.getRegistryGPGKey(ctx, pgGetOptions{
+		organization: opts.Organization,
+		keyID:        opts.KeyID,
+	})
+}
+
 type ListOptions struct {
 	RegistryName string
 	Namespaces   []string
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/http/decode"
 	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/tfeapi"
@@ -26,6 +27,7 @@ func (h *tfeHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/registry/{registry_name}/v2/gpg-keys/{namespace}/{key_id}", h.get).Methods(http.MethodGet)
 	r.HandleFunc("/registry/{registry_name}/v2/gpg-keys/{namespace}/{key_id}", h.update).Methods(http.MethodPatch)
 	r.HandleFunc("/registry/{registry_name}/v2/gpg-keys/{namespace}/{key_id}", h.delete).Methods(http.MethodDelete)
+	r.HandleFunc("/registry/{registry_name}/v2/gpg-keys/{namespace}/{key_id}/actions/revoke", h.revoke).Methods(http.MethodPost)
 }
 
 type listRouteParams struct {
@@ -116,6 +118,26 @@ func (h *tfeHandlers) delete(w http.ResponseWriter, r *http.Request) {
 	h.Respond(w, r, h.toGPGKey(key), http.StatusCreated)
 }
 
+func (h *tfeHandlers) revoke(w http.ResponseWriter, r *http.Request) {
+	var params getRouteParams
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	key, err := h.svc.Revoke(r.Context(), RevokeOptions{
+		RegistryName: params.RegistryName,
+		Organization: params.Namespace,
+		KeyID:        params.KeyID,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	h.Respond(w, r, h.toGPGKey(key), http.StatusOK)
+}
+
 type updateRouteParams struct {
 	RegistryName string `schema:"registry_name,required"`
 	Namespace    string `schema:"namespace,required"`
@@ -184,7 +206,7 @@ func (h *tfeHandlers) create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *tfeHandlers) toGPGKey(key *GPGKey) *types.GPGKey {
-	return &types.GPGKey{
+	to := &types.GPGKey{
 		ID:             key.ID,
 		ASCIIArmor:     key.ASCIIArmor,
 		Namespace:      key.OrganizationName,
@@ -195,4 +217,8 @@ func (h *tfeHandlers) toGPGKey(key *GPGKey) *types.GPGKey {
 		CreatedAt:      key.CreatedAt.Format(types.ISO8601),
 		UpdatedAt:      key.UpdatedAt.Format(types.ISO8601),
 	}
+	if key.RevokedAt != nil {
+		to.RevokedAt = internal.String(key.RevokedAt.Format(types.ISO8601))
+	}
+	return to
 }
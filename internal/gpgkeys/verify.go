@@ -0,0 +1,88 @@
+package gpgkeys
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/tofutf/tofutf/internal"
+)
+
+// ErrNoValidSignature is returned when a signature cannot be verified with any
+// of an organization's signing keys.
+var ErrNoValidSignature = errors.New("signature is not valid for any of the organization's unrevoked signing keys")
+
+// VerifyOptions are options for verifying a detached signature.
+type VerifyOptions struct {
+	Organization string
+	// KeyID restricts verification to the key with the ID, either its
+	// fingerprint or its long key ID, i.e. the last 16 characters of its
+	// fingerprint. If empty then any of the organization's keys may be used.
+	KeyID string
+	// Message is the signed content.
+	Message []byte
+	// Signature is the detached signature, either binary or ASCII-armored.
+	Signature []byte
+}
+
+// Verify verifies a detached signature with the organization's signing keys,
+// returning the key that made the signature. Revoked keys are not considered,
+// so a revoked key cannot be used for any further publications.
+func (s *Service) Verify(ctx context.Context, opts VerifyOptions) (*GPGKey, error) {
+	keys, err := s.db.listRegistryGPGKeys(ctx, []string{opts.Organization})
+	if err != nil {
+		return nil, err
+	}
+	key, err := verify(keys, opts)
+	if err != nil {
+		s.logger.Info("verifying signature", "organization", opts.Organization, "key_id", opts.KeyID, "err", err)
+		return nil, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		}
+	}
+	return key, nil
+}
+
+func verify(keys []*GPGKey, opts VerifyOptions) (*GPGKey, error) {
+	if len(opts.Signature) == 0 {
+		return nil, errors.New("signature is required")
+	}
+	for _, key := range keys {
+		if key.Revoked() || !key.hasID(opts.KeyID) {
+			continue
+		}
+		keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.ASCIIArmor))
+		if err != nil {
+			// the key was parsed successfully upon creation so this should
+			// not happen
+			continue
+		}
+		if err := checkDetachedSignature(keyring, opts.Message, opts.Signature); err == nil {
+			return key, nil
+		}
+	}
+	return nil, ErrNoValidSignature
+}
+
+func checkDetachedSignature(keyring openpgp.KeyRing, message, signature []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN")) {
+		_, err := openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(message), bytes.NewReader(signature), nil)
+		return err
+	}
+	_, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(message), bytes.NewReader(signature), nil)
+	return err
+}
+
+// hasID determines whether the key has the ID, which is matched against both
+// its fingerprint and its long key ID. An empty ID matches any key.
+func (k *GPGKey) hasID(id string) bool {
+	if id == "" {
+		return true
+	}
+	id = strings.ToUpper(id)
+	return len(id) >= 16 && strings.HasSuffix(strings.ToUpper(k.KeyID), id)
+}
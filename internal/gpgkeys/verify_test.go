@@ -0,0 +1,120 @@
+package gpgkeys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestVerify(t *testing.T) {
+	signer, signerKey := newTestKey(t)
+	_, otherKey := newTestKey(t)
+	revokedKey := *signerKey
+	revokedKey.RevokedAt = internal.Time(internal.CurrentTimestamp(nil))
+
+	message := []byte("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  terraform-provider-null_1.0.0_linux_amd64.zip\n")
+	var binary, armored bytes.Buffer
+	require.NoError(t, openpgp.DetachSign(&binary, signer, bytes.NewReader(message), nil))
+	require.NoError(t, openpgp.ArmoredDetachSign(&armored, signer, bytes.NewReader(message), nil))
+
+	tests := []struct {
+		name    string
+		keys    []*GPGKey
+		opts    VerifyOptions
+		want    *GPGKey
+		wantErr bool
+	}{
+		{
+			name: "binary signature",
+			keys: []*GPGKey{otherKey, signerKey},
+			opts: VerifyOptions{Message: message, Signature: binary.Bytes()},
+			want: signerKey,
+		},
+		{
+			name: "armored signature",
+			keys: []*GPGKey{otherKey, signerKey},
+			opts: VerifyOptions{Message: message, Signature: armored.Bytes()},
+			want: signerKey,
+		},
+		{
+			name: "signed by key with fingerprint",
+			keys: []*GPGKey{signerKey},
+			opts: VerifyOptions{KeyID: signerKey.KeyID, Message: message, Signature: binary.Bytes()},
+			want: signerKey,
+		},
+		{
+			name: "signed by key with long key ID",
+			keys: []*GPGKey{signerKey},
+			opts: VerifyOptions{KeyID: strings.ToLower(signerKey.KeyID[len(signerKey.KeyID)-16:]), Message: message, Signature: binary.Bytes()},
+			want: signerKey,
+		},
+		{
+			name:    "signed by a different key to that specified",
+			keys:    []*GPGKey{otherKey, signerKey},
+			opts:    VerifyOptions{KeyID: otherKey.KeyID, Message: message, Signature: binary.Bytes()},
+			wantErr: true,
+		},
+		{
+			name:    "signed by an unknown key",
+			keys:    []*GPGKey{otherKey},
+			opts:    VerifyOptions{Message: message, Signature: binary.Bytes()},
+			wantErr: true,
+		},
+		{
+			name:    "signed by a revoked key",
+			keys:    []*GPGKey{&revokedKey},
+			opts:    VerifyOptions{Message: message, Signature: binary.Bytes()},
+			wantErr: true,
+		},
+		{
+			name:    "tampered message",
+			keys:    []*GPGKey{signerKey},
+			opts:    VerifyOptions{Message: append(message, 'x'), Signature: binary.Bytes()},
+			wantErr: true,
+		},
+		{
+			name:    "missing signature",
+			keys:    []*GPGKey{signerKey},
+			opts:    VerifyOptions{Message: message},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verify(tt.keys, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newTestKey(t *testing.T) (*openpgp.Entity, *GPGKey) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("tofutf", "", "tofutf@example.com", nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	return entity, &GPGKey{
+		ID:               internal.NewID("gpg"),
+		OrganizationName: "acme",
+		ASCIIArmor:       buf.String(),
+		KeyID:            strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint)),
+	}
+}
@@ -1,6 +1,7 @@
 package html
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/tofutf/tofutf/internal"
@@ -47,3 +48,18 @@ func (v SitePage) CurrentURL() string {
 func (v SitePage) Flashes() ([]flash, error) {
 	return PopFlashes(v.request)
 }
+
+// Banner returns a site-wide message to be displayed at the top of the page,
+// or an empty string if there is no such message.
+func (v SitePage) Banner() string {
+	banner, _ := v.request.Context().Value(bannerCtxKey{}).(string)
+	return banner
+}
+
+type bannerCtxKey struct{}
+
+// AddBanner adds to the context a site-wide message to be displayed at the top
+// of every page.
+func AddBanner(ctx context.Context, msg string) context.Context {
+	return context.WithValue(ctx, bannerCtxKey{}, msg)
+}
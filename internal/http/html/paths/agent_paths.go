@@ -5,33 +5,41 @@ package paths
 import "fmt"
 
 func Agents(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/agents", organization)
+	return fmt.Sprintf("/app/organizations/%s/agents", escape(organization))
 }
 
 func CreateAgent(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/agents/create", organization)
+	return fmt.Sprintf("/app/organizations/%s/agents/create", escape(organization))
 }
 
 func NewAgent(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/agents/new", organization)
+	return fmt.Sprintf("/app/organizations/%s/agents/new", escape(organization))
 }
 
 func Agent(agent string) string {
-	return fmt.Sprintf("/app/agents/%s", agent)
+	return fmt.Sprintf("/app/agents/%s", escape(agent))
 }
 
 func EditAgent(agent string) string {
-	return fmt.Sprintf("/app/agents/%s/edit", agent)
+	return fmt.Sprintf("/app/agents/%s/edit", escape(agent))
 }
 
 func UpdateAgent(agent string) string {
-	return fmt.Sprintf("/app/agents/%s/update", agent)
+	return fmt.Sprintf("/app/agents/%s/update", escape(agent))
 }
 
 func DeleteAgent(agent string) string {
-	return fmt.Sprintf("/app/agents/%s/delete", agent)
+	return fmt.Sprintf("/app/agents/%s/delete", escape(agent))
 }
 
 func WatchAgent(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/agents/watch", organization)
+	return fmt.Sprintf("/app/organizations/%s/agents/watch", escape(organization))
+}
+
+func RevokeTokenAgent(agent string) string {
+	return fmt.Sprintf("/app/agents/%s/revoke_token", escape(agent))
+}
+
+func CollectDiagnosticsAgent(agent string) string {
+	return fmt.Sprintf("/app/agents/%s/collect_diagnostics", escape(agent))
 }
@@ -5,29 +5,33 @@ package paths
 import "fmt"
 
 func AgentPools(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/agent-pools", organization)
+	return fmt.Sprintf("/app/organizations/%s/agent-pools", escape(organization))
 }
 
 func CreateAgentPool(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/agent-pools/create", organization)
+	return fmt.Sprintf("/app/organizations/%s/agent-pools/create", escape(organization))
 }
 
 func NewAgentPool(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/agent-pools/new", organization)
+	return fmt.Sprintf("/app/organizations/%s/agent-pools/new", escape(organization))
 }
 
 func AgentPool(agentPool string) string {
-	return fmt.Sprintf("/app/agent-pools/%s", agentPool)
+	return fmt.Sprintf("/app/agent-pools/%s", escape(agentPool))
 }
 
 func EditAgentPool(agentPool string) string {
-	return fmt.Sprintf("/app/agent-pools/%s/edit", agentPool)
+	return fmt.Sprintf("/app/agent-pools/%s/edit", escape(agentPool))
 }
 
 func UpdateAgentPool(agentPool string) string {
-	return fmt.Sprintf("/app/agent-pools/%s/update", agentPool)
+	return fmt.Sprintf("/app/agent-pools/%s/update", escape(agentPool))
 }
 
 func DeleteAgentPool(agentPool string) string {
-	return fmt.Sprintf("/app/agent-pools/%s/delete", agentPool)
+	return fmt.Sprintf("/app/agent-pools/%s/delete", escape(agentPool))
+}
+
+func UndeleteAgentPool(agentPool string) string {
+	return fmt.Sprintf("/app/agent-pools/%s/undelete", escape(agentPool))
 }
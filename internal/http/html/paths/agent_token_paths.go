@@ -5,29 +5,29 @@ package paths
 import "fmt"
 
 func AgentTokens(agentPool string) string {
-	return fmt.Sprintf("/app/agent-pools/%s/agent-tokens", agentPool)
+	return fmt.Sprintf("/app/agent-pools/%s/agent-tokens", escape(agentPool))
 }
 
 func CreateAgentToken(agentPool string) string {
-	return fmt.Sprintf("/app/agent-pools/%s/agent-tokens/create", agentPool)
+	return fmt.Sprintf("/app/agent-pools/%s/agent-tokens/create", escape(agentPool))
 }
 
 func NewAgentToken(agentPool string) string {
-	return fmt.Sprintf("/app/agent-pools/%s/agent-tokens/new", agentPool)
+	return fmt.Sprintf("/app/agent-pools/%s/agent-tokens/new", escape(agentPool))
 }
 
 func AgentToken(agentToken string) string {
-	return fmt.Sprintf("/app/agent-tokens/%s", agentToken)
+	return fmt.Sprintf("/app/agent-tokens/%s", escape(agentToken))
 }
 
 func EditAgentToken(agentToken string) string {
-	return fmt.Sprintf("/app/agent-tokens/%s/edit", agentToken)
+	return fmt.Sprintf("/app/agent-tokens/%s/edit", escape(agentToken))
 }
 
 func UpdateAgentToken(agentToken string) string {
-	return fmt.Sprintf("/app/agent-tokens/%s/update", agentToken)
+	return fmt.Sprintf("/app/agent-tokens/%s/update", escape(agentToken))
 }
 
 func DeleteAgentToken(agentToken string) string {
-	return fmt.Sprintf("/app/agent-tokens/%s/delete", agentToken)
+	return fmt.Sprintf("/app/agent-tokens/%s/delete", escape(agentToken))
 }
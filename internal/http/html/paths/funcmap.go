@@ -27,6 +27,16 @@ func init() {
 
 	funcmap["createTokenPath"] = CreateToken
 
+	funcmap["maintenancePath"] = Maintenance
+
+	funcmap["updateMaintenancePath"] = UpdateMaintenance
+
+	funcmap["eventSinksPath"] = EventSinks
+
+	funcmap["terraformVersionPolicyPath"] = TerraformVersionPolicy
+
+	funcmap["updateTerraformVersionPolicyPath"] = UpdateTerraformVersionPolicy
+
 	funcmap["githubAppsPath"] = GithubApps
 	funcmap["createGithubAppPath"] = CreateGithubApp
 	funcmap["newGithubAppPath"] = NewGithubApp
@@ -45,6 +55,7 @@ func init() {
 	funcmap["editOrganizationPath"] = EditOrganization
 	funcmap["updateOrganizationPath"] = UpdateOrganization
 	funcmap["deleteOrganizationPath"] = DeleteOrganization
+	funcmap["switcherOrganizationPath"] = SwitcherOrganization
 
 	funcmap["workspacesPath"] = Workspaces
 	funcmap["createWorkspacePath"] = CreateWorkspace
@@ -56,6 +67,10 @@ func init() {
 	funcmap["lockWorkspacePath"] = LockWorkspace
 	funcmap["unlockWorkspacePath"] = UnlockWorkspace
 	funcmap["forceUnlockWorkspacePath"] = ForceUnlockWorkspace
+	funcmap["pauseWorkspacePath"] = PauseWorkspace
+	funcmap["resumeWorkspacePath"] = ResumeWorkspace
+	funcmap["enableDebugLoggingWorkspacePath"] = EnableDebugLoggingWorkspace
+	funcmap["disableDebugLoggingWorkspacePath"] = DisableDebugLoggingWorkspace
 	funcmap["setPermissionWorkspacePath"] = SetPermissionWorkspace
 	funcmap["unsetPermissionWorkspacePath"] = UnsetPermissionWorkspace
 	funcmap["watchWorkspacePath"] = WatchWorkspace
@@ -67,7 +82,11 @@ func init() {
 	funcmap["createTagWorkspacePath"] = CreateTagWorkspace
 	funcmap["deleteTagWorkspacePath"] = DeleteTagWorkspace
 	funcmap["stateWorkspacePath"] = StateWorkspace
+	funcmap["statsWorkspacePath"] = StatsWorkspace
+	funcmap["compareWorkspacePath"] = CompareWorkspace
 	funcmap["poolsWorkspacePath"] = PoolsWorkspace
+	funcmap["driftWorkspacePath"] = DriftWorkspace
+	funcmap["driftBadgeWorkspacePath"] = DriftBadgeWorkspace
 
 	funcmap["runsPath"] = Runs
 	funcmap["createRunPath"] = CreateRun
@@ -83,6 +102,13 @@ func init() {
 	funcmap["retryRunPath"] = RetryRun
 	funcmap["tailRunPath"] = TailRun
 	funcmap["widgetRunPath"] = WidgetRun
+	funcmap["commentRunPath"] = CommentRun
+	funcmap["deleteCommentRunPath"] = DeleteCommentRun
+	funcmap["allocationRunPath"] = AllocationRun
+	funcmap["overrideApplyWindowRunPath"] = OverrideApplyWindowRun
+	funcmap["artifactRunPath"] = ArtifactRun
+	funcmap["canaryRunPath"] = CanaryRun
+	funcmap["proceedRunPath"] = ProceedRun
 
 	funcmap["variablesPath"] = Variables
 	funcmap["createVariablePath"] = CreateVariable
@@ -92,6 +118,9 @@ func init() {
 	funcmap["updateVariablePath"] = UpdateVariable
 	funcmap["deleteVariablePath"] = DeleteVariable
 
+	funcmap["createOutputMappingPath"] = CreateOutputMapping
+	funcmap["deleteOutputMappingPath"] = DeleteOutputMapping
+
 	funcmap["agentsPath"] = Agents
 	funcmap["createAgentPath"] = CreateAgent
 	funcmap["newAgentPath"] = NewAgent
@@ -100,6 +129,17 @@ func init() {
 	funcmap["updateAgentPath"] = UpdateAgent
 	funcmap["deleteAgentPath"] = DeleteAgent
 	funcmap["watchAgentPath"] = WatchAgent
+	funcmap["revokeTokenAgentPath"] = RevokeTokenAgent
+	funcmap["collectDiagnosticsAgentPath"] = CollectDiagnosticsAgent
+
+	funcmap["agentDiagnosticsBundlesPath"] = AgentDiagnosticsBundles
+	funcmap["createAgentDiagnosticsBundlePath"] = CreateAgentDiagnosticsBundle
+	funcmap["newAgentDiagnosticsBundlePath"] = NewAgentDiagnosticsBundle
+	funcmap["agentDiagnosticsBundlePath"] = AgentDiagnosticsBundle
+	funcmap["editAgentDiagnosticsBundlePath"] = EditAgentDiagnosticsBundle
+	funcmap["updateAgentDiagnosticsBundlePath"] = UpdateAgentDiagnosticsBundle
+	funcmap["deleteAgentDiagnosticsBundlePath"] = DeleteAgentDiagnosticsBundle
+	funcmap["downloadAgentDiagnosticsBundlePath"] = DownloadAgentDiagnosticsBundle
 
 	funcmap["agentPoolsPath"] = AgentPools
 	funcmap["createAgentPoolPath"] = CreateAgentPool
@@ -108,6 +148,7 @@ func init() {
 	funcmap["editAgentPoolPath"] = EditAgentPool
 	funcmap["updateAgentPoolPath"] = UpdateAgentPool
 	funcmap["deleteAgentPoolPath"] = DeleteAgentPool
+	funcmap["undeleteAgentPoolPath"] = UndeleteAgentPool
 
 	funcmap["agentTokensPath"] = AgentTokens
 	funcmap["createAgentTokenPath"] = CreateAgentToken
@@ -117,6 +158,14 @@ func init() {
 	funcmap["updateAgentTokenPath"] = UpdateAgentToken
 	funcmap["deleteAgentTokenPath"] = DeleteAgentToken
 
+	funcmap["agentBootstrapTokensPath"] = AgentBootstrapTokens
+	funcmap["createAgentBootstrapTokenPath"] = CreateAgentBootstrapToken
+	funcmap["newAgentBootstrapTokenPath"] = NewAgentBootstrapToken
+	funcmap["agentBootstrapTokenPath"] = AgentBootstrapToken
+	funcmap["editAgentBootstrapTokenPath"] = EditAgentBootstrapToken
+	funcmap["updateAgentBootstrapTokenPath"] = UpdateAgentBootstrapToken
+	funcmap["deleteAgentBootstrapTokenPath"] = DeleteAgentBootstrapToken
+
 	funcmap["variableSetsPath"] = VariableSets
 	funcmap["createVariableSetPath"] = CreateVariableSet
 	funcmap["newVariableSetPath"] = NewVariableSet
@@ -137,6 +186,14 @@ func init() {
 	funcmap["createOrganizationTokenPath"] = CreateOrganizationToken
 	funcmap["deleteOrganizationTokenPath"] = DeleteOrganizationToken
 
+	funcmap["scimTokenPath"] = SCIMToken
+	funcmap["createSCIMTokenPath"] = CreateSCIMToken
+	funcmap["deleteSCIMTokenPath"] = DeleteSCIMToken
+
+	funcmap["vcsDefaultsPath"] = VCSDefaults
+	funcmap["updateVCSDefaultsPath"] = UpdateVCSDefaults
+	funcmap["applyVCSDefaultsPath"] = ApplyVCSDefaults
+
 	funcmap["usersPath"] = Users
 	funcmap["createUserPath"] = CreateUser
 	funcmap["newUserPath"] = NewUser
@@ -163,6 +220,8 @@ func init() {
 	funcmap["updateVCSProviderPath"] = UpdateVCSProvider
 	funcmap["deleteVCSProviderPath"] = DeleteVCSProvider
 	funcmap["newGithubAppVCSProviderPath"] = NewGithubAppVCSProvider
+	funcmap["webhookDeliveriesVCSProviderPath"] = WebhookDeliveriesVCSProvider
+	funcmap["redeliverWebhookDeliveryVCSProviderPath"] = RedeliverWebhookDeliveryVCSProvider
 
 	funcmap["modulesPath"] = Modules
 	funcmap["createModulePath"] = CreateModule
@@ -172,6 +231,21 @@ func init() {
 	funcmap["updateModulePath"] = UpdateModule
 	funcmap["deleteModulePath"] = DeleteModule
 	funcmap["refreshModulePath"] = RefreshModule
+	funcmap["shareModulePath"] = ShareModule
+
+	funcmap["moduleTemplatesPath"] = ModuleTemplates
+	funcmap["createModuleTemplatePath"] = CreateModuleTemplate
+	funcmap["moduleTemplatePath"] = ModuleTemplate
+	funcmap["deleteModuleTemplatePath"] = DeleteModuleTemplate
+	funcmap["provisionModuleTemplatePath"] = ProvisionModuleTemplate
+	funcmap["upgradeWorkspaceModuleTemplatePath"] = UpgradeWorkspaceModuleTemplate
+
+	funcmap["moduleVersionPoliciesPath"] = ModuleVersionPolicies
+	funcmap["createModuleVersionPolicyPath"] = CreateModuleVersionPolicy
+	funcmap["updateModuleVersionPolicyPath"] = UpdateModuleVersionPolicy
+	funcmap["deleteModuleVersionPolicyPath"] = DeleteModuleVersionPolicy
+
+	funcmap["revokeModuleSharePath"] = RevokeModuleShare
 }
 
 func FuncMap() template.FuncMap { return funcmap }
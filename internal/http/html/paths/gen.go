@@ -76,6 +76,8 @@ type controllerSpec struct {
 	skipDefaultActions bool
 	camel              string
 	lowerCamel         string
+	// plural form of the name, if not merely the name followed by an 's'.
+	plural string
 	// disable site-wide prefix
 	noprefix bool
 
@@ -92,6 +94,7 @@ type controller struct {
 	skipDefaultActions bool
 	camel              string
 	lowerCamel         string
+	plural             string
 	// disable site-wide prefix
 	noprefix bool
 
@@ -142,6 +145,31 @@ var specs = []controllerSpec{
 		controllerType: singlePath,
 		path:           "/profile/tokens/create",
 	},
+	{
+		Name:           "maintenance",
+		controllerType: singlePath,
+		path:           "/admin/maintenance",
+	},
+	{
+		Name:           "update_maintenance",
+		controllerType: singlePath,
+		path:           "/admin/maintenance/update",
+	},
+	{
+		Name:           "event_sinks",
+		controllerType: singlePath,
+		path:           "/admin/event-sinks",
+	},
+	{
+		Name:           "terraform_version_policy",
+		controllerType: singlePath,
+		path:           "/admin/terraform-version-policy",
+	},
+	{
+		Name:           "update_terraform_version_policy",
+		controllerType: singlePath,
+		path:           "/admin/terraform-version-policy/update",
+	},
 	{
 		Name:           "github_app",
 		controllerType: resourcePath,
@@ -162,6 +190,12 @@ var specs = []controllerSpec{
 	{
 		Name:           "organization",
 		controllerType: resourcePath,
+		actions: []action{
+			{
+				name:       "switcher",
+				collection: true,
+			},
+		},
 		nested: []controllerSpec{
 			{
 				Name:           "workspace",
@@ -176,6 +210,18 @@ var specs = []controllerSpec{
 					{
 						name: "force-unlock",
 					},
+					{
+						name: "pause",
+					},
+					{
+						name: "resume",
+					},
+					{
+						name: "enable-debug-logging",
+					},
+					{
+						name: "disable-debug-logging",
+					},
 					{
 						name: "set-permission",
 					},
@@ -209,9 +255,21 @@ var specs = []controllerSpec{
 					{
 						name: "state",
 					},
+					{
+						name: "stats",
+					},
+					{
+						name: "compare",
+					},
 					{
 						name: "pools",
 					},
+					{
+						name: "drift",
+					},
+					{
+						name: "drift-badge",
+					},
 				},
 				nested: []controllerSpec{
 					{
@@ -239,12 +297,47 @@ var specs = []controllerSpec{
 							{
 								name: "widget",
 							},
+							{
+								name: "comment",
+							},
+							{
+								name: "delete-comment",
+							},
+							{
+								name: "allocation",
+							},
+							{
+								name: "override-apply-window",
+							},
+							{
+								name: "artifact",
+							},
+							{
+								name: "canary",
+							},
+							{
+								name: "proceed",
+							},
 						},
 					},
 					{
 						Name:           "variable",
 						controllerType: resourcePath,
 					},
+					{
+						Name:               "output_mapping",
+						controllerType:     resourcePath,
+						skipDefaultActions: true,
+						actions: []action{
+							{
+								name:       "create",
+								collection: true,
+							},
+							{
+								name: "delete",
+							},
+						},
+					},
 				},
 			},
 			{
@@ -255,16 +348,40 @@ var specs = []controllerSpec{
 						name:       "watch",
 						collection: true,
 					},
+					{
+						name: "revoke_token",
+					},
+					{
+						name: "collect_diagnostics",
+					},
+				},
+			},
+			{
+				Name:           "agent_diagnostics_bundle",
+				controllerType: resourcePath,
+				actions: []action{
+					{
+						name: "download",
+					},
 				},
 			},
 			{
 				Name:           "agent_pool",
 				controllerType: resourcePath,
+				actions: []action{
+					{
+						name: "undelete",
+					},
+				},
 				nested: []controllerSpec{
 					{
 						Name:           "agent_token",
 						controllerType: resourcePath,
 					},
+					{
+						Name:           "agent_bootstrap_token",
+						controllerType: resourcePath,
+					},
 				},
 			},
 			{
@@ -297,6 +414,50 @@ var specs = []controllerSpec{
 					},
 				},
 			},
+			{
+				Name:               "scim_token",
+				controllerType:     resourcePath,
+				skipDefaultActions: true,
+				path:               "/scim-token",
+				camel:              "SCIMToken",
+				lowerCamel:         "scimToken",
+				actions: []action{
+					{
+						name:       "show",
+						collection: true,
+					},
+					{
+						name:       "create",
+						collection: true,
+					},
+					{
+						name:       "delete",
+						collection: true,
+					},
+				},
+			},
+			{
+				Name:               "vcs_defaults",
+				controllerType:     resourcePath,
+				skipDefaultActions: true,
+				path:               "/vcs-default",
+				camel:              "VCSDefaults",
+				lowerCamel:         "vcsDefaults",
+				actions: []action{
+					{
+						name:       "show",
+						collection: true,
+					},
+					{
+						name:       "update",
+						collection: true,
+					},
+					{
+						name:       "apply",
+						collection: true,
+					},
+				},
+			},
 			{
 				Name:           "user",
 				controllerType: resourcePath,
@@ -323,12 +484,77 @@ var specs = []controllerSpec{
 						name:       "new-github-app",
 						collection: true,
 					},
+					{
+						name: "webhook-deliveries",
+					},
+					{
+						name: "redeliver-webhook-delivery",
+					},
 				},
 			},
 			{
 				Name:           "module",
 				controllerType: resourcePath,
-				actions:        []action{{name: "refresh", collection: false}},
+				actions:        []action{{name: "refresh", collection: false}, {name: "share", collection: false}},
+			},
+			{
+				Name:               "module_template",
+				controllerType:     resourcePath,
+				skipDefaultActions: true,
+				actions: []action{
+					{
+						name:       "list",
+						collection: true,
+					},
+					{
+						name:       "create",
+						collection: true,
+					},
+					{
+						name: "show",
+					},
+					{
+						name: "delete",
+					},
+					{
+						name: "provision",
+					},
+					{
+						name: "upgrade-workspace",
+					},
+				},
+			},
+			{
+				Name:               "module_version_policy",
+				plural:             "module_version_policies",
+				controllerType:     resourcePath,
+				skipDefaultActions: true,
+				actions: []action{
+					{
+						name:       "list",
+						collection: true,
+					},
+					{
+						name:       "create",
+						collection: true,
+					},
+					{
+						name: "update",
+					},
+					{
+						name: "delete",
+					},
+				},
+			},
+			{
+				Name:               "module_share",
+				controllerType:     resourcePath,
+				skipDefaultActions: true,
+				actions: []action{
+					{
+						name: "revoke",
+					},
+				},
 			},
 		},
 	},
@@ -355,6 +581,31 @@ func (r controller) LowerCamel() string {
 	return strcase.ToLowerCamel(r.Name)
 }
 
+// PluralPath returns the path to the collection of resources.
+func (r controller) PluralPath() string {
+	if r.plural != "" {
+		return "/" + strcase.ToKebab(r.plural)
+	}
+	return r.Path() + "s"
+}
+
+// PluralCamel returns the plural form of the resource name in camel case.
+func (r controller) PluralCamel() string {
+	if r.plural != "" {
+		return strcase.ToCamel(r.plural)
+	}
+	return r.Camel() + "s"
+}
+
+// PluralLowerCamel returns the plural form of the resource name in lower
+// camel case.
+func (r controller) PluralLowerCamel() string {
+	if r.plural != "" {
+		return strcase.ToLowerCamel(r.plural)
+	}
+	return r.LowerCamel() + "s"
+}
+
 // FormatString returns a format string for use with fmt.Sprintf within a
 // template for a path helper.
 func (r controller) FormatString(action action) string {
@@ -370,13 +621,11 @@ func (r controller) FormatString(action action) string {
 	}
 	if action.collection {
 		if r.Parent != nil {
-			b.WriteString(r.Parent.Path())
-			b.WriteString("s")
+			b.WriteString(r.Parent.PluralPath())
 			b.WriteString("/%s")
 		}
 	}
-	b.WriteString(r.Path())
-	b.WriteString("s")
+	b.WriteString(r.PluralPath())
 	if action.name == "list" {
 		// list has no explict action specified in the path
 		return b.String()
@@ -400,7 +649,13 @@ func (r controller) FormatString(action action) string {
 // FormatArgs are the args for use with fmt.Sprintf in a path helper in a
 // template.
 func (r controller) FormatArgs(action action) string {
-	return strings.Join(r.params(action.collection), ", ")
+	params := r.params(action.collection)
+	args := make([]string, len(params))
+	for i, p := range params {
+		// escape identifiers so that they're confined to their path segment
+		args[i] = fmt.Sprintf("escape(%s)", p)
+	}
+	return strings.Join(args, ", ")
 }
 
 // HelperName returns the path helper function name for the given action.
@@ -411,7 +666,7 @@ func (r controller) HelperName(action action) string {
 		return r.Camel()
 	case "list":
 		// list path helper is merely the plural form of the resource name
-		return r.Camel() + "s"
+		return r.PluralCamel()
 	default:
 		return strcase.ToCamel(action.name) + r.Camel()
 	}
@@ -425,7 +680,7 @@ func (r controller) FuncMapName(action action) string {
 		return r.LowerCamel() + "Path"
 	case "list":
 		// list funcmap name is merely the plural form of the resource name
-		return r.LowerCamel() + "sPath"
+		return r.PluralLowerCamel() + "Path"
 	default:
 		// funcmap names for all other actions include their name followed by
 		// the resource name
@@ -527,6 +782,7 @@ func buildControllers(parent *controller, specs []controllerSpec) []controller {
 			Name:               spec.Name,
 			camel:              spec.camel,
 			lowerCamel:         spec.lowerCamel,
+			plural:             spec.plural,
 			path:               spec.path,
 			Parent:             parent,
 			controllerType:     spec.controllerType,
@@ -17,19 +17,19 @@ func NewGithubApp() string {
 }
 
 func GithubApp(githubApp string) string {
-	return fmt.Sprintf("/app/github-apps/%s", githubApp)
+	return fmt.Sprintf("/app/github-apps/%s", escape(githubApp))
 }
 
 func EditGithubApp(githubApp string) string {
-	return fmt.Sprintf("/app/github-apps/%s/edit", githubApp)
+	return fmt.Sprintf("/app/github-apps/%s/edit", escape(githubApp))
 }
 
 func UpdateGithubApp(githubApp string) string {
-	return fmt.Sprintf("/app/github-apps/%s/update", githubApp)
+	return fmt.Sprintf("/app/github-apps/%s/update", escape(githubApp))
 }
 
 func DeleteGithubApp(githubApp string) string {
-	return fmt.Sprintf("/app/github-apps/%s/delete", githubApp)
+	return fmt.Sprintf("/app/github-apps/%s/delete", escape(githubApp))
 }
 
 func ExchangeCodeGithubApp() string {
@@ -41,5 +41,5 @@ func CompleteGithubApp() string {
 }
 
 func DeleteInstallGithubApp(githubApp string) string {
-	return fmt.Sprintf("/app/github-apps/%s/delete-install", githubApp)
+	return fmt.Sprintf("/app/github-apps/%s/delete-install", escape(githubApp))
 }
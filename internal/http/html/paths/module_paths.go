@@ -5,33 +5,37 @@ package paths
 import "fmt"
 
 func Modules(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/modules", organization)
+	return fmt.Sprintf("/app/organizations/%s/modules", escape(organization))
 }
 
 func CreateModule(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/modules/create", organization)
+	return fmt.Sprintf("/app/organizations/%s/modules/create", escape(organization))
 }
 
 func NewModule(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/modules/new", organization)
+	return fmt.Sprintf("/app/organizations/%s/modules/new", escape(organization))
 }
 
 func Module(module string) string {
-	return fmt.Sprintf("/app/modules/%s", module)
+	return fmt.Sprintf("/app/modules/%s", escape(module))
 }
 
 func EditModule(module string) string {
-	return fmt.Sprintf("/app/modules/%s/edit", module)
+	return fmt.Sprintf("/app/modules/%s/edit", escape(module))
 }
 
 func UpdateModule(module string) string {
-	return fmt.Sprintf("/app/modules/%s/update", module)
+	return fmt.Sprintf("/app/modules/%s/update", escape(module))
 }
 
 func DeleteModule(module string) string {
-	return fmt.Sprintf("/app/modules/%s/delete", module)
+	return fmt.Sprintf("/app/modules/%s/delete", escape(module))
 }
 
 func RefreshModule(module string) string {
-	return fmt.Sprintf("/app/modules/%s/refresh", module)
+	return fmt.Sprintf("/app/modules/%s/refresh", escape(module))
+}
+
+func ShareModule(module string) string {
+	return fmt.Sprintf("/app/modules/%s/share", escape(module))
 }
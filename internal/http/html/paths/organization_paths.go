@@ -17,17 +17,21 @@ func NewOrganization() string {
 }
 
 func Organization(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s", organization)
+	return fmt.Sprintf("/app/organizations/%s", escape(organization))
 }
 
 func EditOrganization(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/edit", organization)
+	return fmt.Sprintf("/app/organizations/%s/edit", escape(organization))
 }
 
 func UpdateOrganization(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/update", organization)
+	return fmt.Sprintf("/app/organizations/%s/update", escape(organization))
 }
 
 func DeleteOrganization(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/delete", organization)
+	return fmt.Sprintf("/app/organizations/%s/delete", escape(organization))
+}
+
+func SwitcherOrganization() string {
+	return "/app/organizations/switcher"
 }
@@ -1,9 +1,20 @@
 // Package paths provides rails-style path helpers for use with the web app.
 package paths
 
+import "net/url"
+
 //go:generate go run gen.go
 
 const (
 	// site-wide prefix added to all web UI paths requiring authentication.
 	UIPrefix = "/app"
 )
+
+// escape escapes an identifier for use as a path segment, so that reserved
+// characters such as '?', '#' and '%' are treated as part of the identifier
+// rather than terminating or corrupting the path. A slash is escaped too, but
+// note the router decodes paths before matching routes, so identifiers
+// containing slashes must be rejected when they're created.
+func escape(segment string) string {
+	return url.PathEscape(segment)
+}
@@ -0,0 +1,50 @@
+package paths
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"plain identifier", Module("mod-123"), "/app/modules/mod-123"},
+		{"slash", Module("mod/123"), "/app/modules/mod%2F123"},
+		{"question mark", EditModule("mod?123"), "/app/modules/mod%3F123/edit"},
+		{"hash", Module("mod#123"), "/app/modules/mod%23123"},
+		{"percent", Module("mod%2F123"), "/app/modules/mod%252F123"},
+		{"space", Modules("acme corp"), "/app/organizations/acme%20corp/modules"},
+		{"no parameters", Admin(), "/app/admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got)
+		})
+	}
+}
+
+// TestEscape_Routing tests that an identifier with reserved characters is
+// routed intact to the handler.
+func TestEscape_Routing(t *testing.T) {
+	for _, id := range []string{"mod-123", "mod?123", "mod#123", "mod%123", "mod 123"} {
+		t.Run(id, func(t *testing.T) {
+			var got string
+			r := mux.NewRouter()
+			r.HandleFunc("/app/modules/{module_id}/edit", func(w http.ResponseWriter, r *http.Request) {
+				got = mux.Vars(r)["module_id"]
+			})
+
+			req := httptest.NewRequest("GET", EditModule(id), nil)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, id, got)
+		})
+	}
+}
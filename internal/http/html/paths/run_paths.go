@@ -5,57 +5,85 @@ package paths
 import "fmt"
 
 func Runs(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/runs", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/runs", escape(workspace))
 }
 
 func CreateRun(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/runs/create", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/runs/create", escape(workspace))
 }
 
 func NewRun(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/runs/new", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/runs/new", escape(workspace))
 }
 
 func Run(run string) string {
-	return fmt.Sprintf("/app/runs/%s", run)
+	return fmt.Sprintf("/app/runs/%s", escape(run))
 }
 
 func EditRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/edit", run)
+	return fmt.Sprintf("/app/runs/%s/edit", escape(run))
 }
 
 func UpdateRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/update", run)
+	return fmt.Sprintf("/app/runs/%s/update", escape(run))
 }
 
 func DeleteRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/delete", run)
+	return fmt.Sprintf("/app/runs/%s/delete", escape(run))
 }
 
 func ApplyRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/apply", run)
+	return fmt.Sprintf("/app/runs/%s/apply", escape(run))
 }
 
 func DiscardRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/discard", run)
+	return fmt.Sprintf("/app/runs/%s/discard", escape(run))
 }
 
 func CancelRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/cancel", run)
+	return fmt.Sprintf("/app/runs/%s/cancel", escape(run))
 }
 
 func ForceCancelRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/force-cancel", run)
+	return fmt.Sprintf("/app/runs/%s/force-cancel", escape(run))
 }
 
 func RetryRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/retry", run)
+	return fmt.Sprintf("/app/runs/%s/retry", escape(run))
 }
 
 func TailRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/tail", run)
+	return fmt.Sprintf("/app/runs/%s/tail", escape(run))
 }
 
 func WidgetRun(run string) string {
-	return fmt.Sprintf("/app/runs/%s/widget", run)
+	return fmt.Sprintf("/app/runs/%s/widget", escape(run))
+}
+
+func CommentRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/comment", escape(run))
+}
+
+func DeleteCommentRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/delete-comment", escape(run))
+}
+
+func AllocationRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/allocation", escape(run))
+}
+
+func OverrideApplyWindowRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/override-apply-window", escape(run))
+}
+
+func ArtifactRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/artifact", escape(run))
+}
+
+func CanaryRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/canary", escape(run))
+}
+
+func ProceedRun(run string) string {
+	return fmt.Sprintf("/app/runs/%s/proceed", escape(run))
 }
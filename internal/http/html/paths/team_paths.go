@@ -5,37 +5,37 @@ package paths
 import "fmt"
 
 func Teams(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/teams", organization)
+	return fmt.Sprintf("/app/organizations/%s/teams", escape(organization))
 }
 
 func CreateTeam(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/teams/create", organization)
+	return fmt.Sprintf("/app/organizations/%s/teams/create", escape(organization))
 }
 
 func NewTeam(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/teams/new", organization)
+	return fmt.Sprintf("/app/organizations/%s/teams/new", escape(organization))
 }
 
 func Team(team string) string {
-	return fmt.Sprintf("/app/teams/%s", team)
+	return fmt.Sprintf("/app/teams/%s", escape(team))
 }
 
 func EditTeam(team string) string {
-	return fmt.Sprintf("/app/teams/%s/edit", team)
+	return fmt.Sprintf("/app/teams/%s/edit", escape(team))
 }
 
 func UpdateTeam(team string) string {
-	return fmt.Sprintf("/app/teams/%s/update", team)
+	return fmt.Sprintf("/app/teams/%s/update", escape(team))
 }
 
 func DeleteTeam(team string) string {
-	return fmt.Sprintf("/app/teams/%s/delete", team)
+	return fmt.Sprintf("/app/teams/%s/delete", escape(team))
 }
 
 func AddMemberTeam(team string) string {
-	return fmt.Sprintf("/app/teams/%s/add-member", team)
+	return fmt.Sprintf("/app/teams/%s/add-member", escape(team))
 }
 
 func RemoveMemberTeam(team string) string {
-	return fmt.Sprintf("/app/teams/%s/remove-member", team)
+	return fmt.Sprintf("/app/teams/%s/remove-member", escape(team))
 }
@@ -5,29 +5,29 @@ package paths
 import "fmt"
 
 func Users(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/users", organization)
+	return fmt.Sprintf("/app/organizations/%s/users", escape(organization))
 }
 
 func CreateUser(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/users/create", organization)
+	return fmt.Sprintf("/app/organizations/%s/users/create", escape(organization))
 }
 
 func NewUser(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/users/new", organization)
+	return fmt.Sprintf("/app/organizations/%s/users/new", escape(organization))
 }
 
 func User(user string) string {
-	return fmt.Sprintf("/app/users/%s", user)
+	return fmt.Sprintf("/app/users/%s", escape(user))
 }
 
 func EditUser(user string) string {
-	return fmt.Sprintf("/app/users/%s/edit", user)
+	return fmt.Sprintf("/app/users/%s/edit", escape(user))
 }
 
 func UpdateUser(user string) string {
-	return fmt.Sprintf("/app/users/%s/update", user)
+	return fmt.Sprintf("/app/users/%s/update", escape(user))
 }
 
 func DeleteUser(user string) string {
-	return fmt.Sprintf("/app/users/%s/delete", user)
+	return fmt.Sprintf("/app/users/%s/delete", escape(user))
 }
@@ -5,29 +5,29 @@ package paths
 import "fmt"
 
 func Variables(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/variables", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/variables", escape(workspace))
 }
 
 func CreateVariable(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/variables/create", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/variables/create", escape(workspace))
 }
 
 func NewVariable(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/variables/new", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/variables/new", escape(workspace))
 }
 
 func Variable(variable string) string {
-	return fmt.Sprintf("/app/variables/%s", variable)
+	return fmt.Sprintf("/app/variables/%s", escape(variable))
 }
 
 func EditVariable(variable string) string {
-	return fmt.Sprintf("/app/variables/%s/edit", variable)
+	return fmt.Sprintf("/app/variables/%s/edit", escape(variable))
 }
 
 func UpdateVariable(variable string) string {
-	return fmt.Sprintf("/app/variables/%s/update", variable)
+	return fmt.Sprintf("/app/variables/%s/update", escape(variable))
 }
 
 func DeleteVariable(variable string) string {
-	return fmt.Sprintf("/app/variables/%s/delete", variable)
+	return fmt.Sprintf("/app/variables/%s/delete", escape(variable))
 }
@@ -5,29 +5,29 @@ package paths
 import "fmt"
 
 func VariableSets(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/variable-sets", organization)
+	return fmt.Sprintf("/app/organizations/%s/variable-sets", escape(organization))
 }
 
 func CreateVariableSet(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/variable-sets/create", organization)
+	return fmt.Sprintf("/app/organizations/%s/variable-sets/create", escape(organization))
 }
 
 func NewVariableSet(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/variable-sets/new", organization)
+	return fmt.Sprintf("/app/organizations/%s/variable-sets/new", escape(organization))
 }
 
 func VariableSet(variableSet string) string {
-	return fmt.Sprintf("/app/variable-sets/%s", variableSet)
+	return fmt.Sprintf("/app/variable-sets/%s", escape(variableSet))
 }
 
 func EditVariableSet(variableSet string) string {
-	return fmt.Sprintf("/app/variable-sets/%s/edit", variableSet)
+	return fmt.Sprintf("/app/variable-sets/%s/edit", escape(variableSet))
 }
 
 func UpdateVariableSet(variableSet string) string {
-	return fmt.Sprintf("/app/variable-sets/%s/update", variableSet)
+	return fmt.Sprintf("/app/variable-sets/%s/update", escape(variableSet))
 }
 
 func DeleteVariableSet(variableSet string) string {
-	return fmt.Sprintf("/app/variable-sets/%s/delete", variableSet)
+	return fmt.Sprintf("/app/variable-sets/%s/delete", escape(variableSet))
 }
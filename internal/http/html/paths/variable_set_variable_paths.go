@@ -5,29 +5,29 @@ package paths
 import "fmt"
 
 func VariableSetVariables(variableSet string) string {
-	return fmt.Sprintf("/app/variable-sets/%s/variable-set-variables", variableSet)
+	return fmt.Sprintf("/app/variable-sets/%s/variable-set-variables", escape(variableSet))
 }
 
 func CreateVariableSetVariable(variableSet string) string {
-	return fmt.Sprintf("/app/variable-sets/%s/variable-set-variables/create", variableSet)
+	return fmt.Sprintf("/app/variable-sets/%s/variable-set-variables/create", escape(variableSet))
 }
 
 func NewVariableSetVariable(variableSet string) string {
-	return fmt.Sprintf("/app/variable-sets/%s/variable-set-variables/new", variableSet)
+	return fmt.Sprintf("/app/variable-sets/%s/variable-set-variables/new", escape(variableSet))
 }
 
 func VariableSetVariable(variableSetVariable string) string {
-	return fmt.Sprintf("/app/variable-set-variables/%s", variableSetVariable)
+	return fmt.Sprintf("/app/variable-set-variables/%s", escape(variableSetVariable))
 }
 
 func EditVariableSetVariable(variableSetVariable string) string {
-	return fmt.Sprintf("/app/variable-set-variables/%s/edit", variableSetVariable)
+	return fmt.Sprintf("/app/variable-set-variables/%s/edit", escape(variableSetVariable))
 }
 
 func UpdateVariableSetVariable(variableSetVariable string) string {
-	return fmt.Sprintf("/app/variable-set-variables/%s/update", variableSetVariable)
+	return fmt.Sprintf("/app/variable-set-variables/%s/update", escape(variableSetVariable))
 }
 
 func DeleteVariableSetVariable(variableSetVariable string) string {
-	return fmt.Sprintf("/app/variable-set-variables/%s/delete", variableSetVariable)
+	return fmt.Sprintf("/app/variable-set-variables/%s/delete", escape(variableSetVariable))
 }
@@ -5,33 +5,41 @@ package paths
 import "fmt"
 
 func VCSProviders(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/vcs-providers", organization)
+	return fmt.Sprintf("/app/organizations/%s/vcs-providers", escape(organization))
 }
 
 func CreateVCSProvider(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/vcs-providers/create", organization)
+	return fmt.Sprintf("/app/organizations/%s/vcs-providers/create", escape(organization))
 }
 
 func NewVCSProvider(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/vcs-providers/new", organization)
+	return fmt.Sprintf("/app/organizations/%s/vcs-providers/new", escape(organization))
 }
 
 func VCSProvider(vcsProvider string) string {
-	return fmt.Sprintf("/app/vcs-providers/%s", vcsProvider)
+	return fmt.Sprintf("/app/vcs-providers/%s", escape(vcsProvider))
 }
 
 func EditVCSProvider(vcsProvider string) string {
-	return fmt.Sprintf("/app/vcs-providers/%s/edit", vcsProvider)
+	return fmt.Sprintf("/app/vcs-providers/%s/edit", escape(vcsProvider))
 }
 
 func UpdateVCSProvider(vcsProvider string) string {
-	return fmt.Sprintf("/app/vcs-providers/%s/update", vcsProvider)
+	return fmt.Sprintf("/app/vcs-providers/%s/update", escape(vcsProvider))
 }
 
 func DeleteVCSProvider(vcsProvider string) string {
-	return fmt.Sprintf("/app/vcs-providers/%s/delete", vcsProvider)
+	return fmt.Sprintf("/app/vcs-providers/%s/delete", escape(vcsProvider))
 }
 
 func NewGithubAppVCSProvider(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/vcs-providers/new-github-app", organization)
+	return fmt.Sprintf("/app/organizations/%s/vcs-providers/new-github-app", escape(organization))
+}
+
+func WebhookDeliveriesVCSProvider(vcsProvider string) string {
+	return fmt.Sprintf("/app/vcs-providers/%s/webhook-deliveries", escape(vcsProvider))
+}
+
+func RedeliverWebhookDeliveryVCSProvider(vcsProvider string) string {
+	return fmt.Sprintf("/app/vcs-providers/%s/redeliver-webhook-delivery", escape(vcsProvider))
 }
@@ -5,89 +5,121 @@ package paths
 import "fmt"
 
 func Workspaces(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/workspaces", organization)
+	return fmt.Sprintf("/app/organizations/%s/workspaces", escape(organization))
 }
 
 func CreateWorkspace(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/workspaces/create", organization)
+	return fmt.Sprintf("/app/organizations/%s/workspaces/create", escape(organization))
 }
 
 func NewWorkspace(organization string) string {
-	return fmt.Sprintf("/app/organizations/%s/workspaces/new", organization)
+	return fmt.Sprintf("/app/organizations/%s/workspaces/new", escape(organization))
 }
 
 func Workspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s", workspace)
+	return fmt.Sprintf("/app/workspaces/%s", escape(workspace))
 }
 
 func EditWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/edit", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/edit", escape(workspace))
 }
 
 func UpdateWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/update", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/update", escape(workspace))
 }
 
 func DeleteWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/delete", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/delete", escape(workspace))
 }
 
 func LockWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/lock", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/lock", escape(workspace))
 }
 
 func UnlockWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/unlock", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/unlock", escape(workspace))
 }
 
 func ForceUnlockWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/force-unlock", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/force-unlock", escape(workspace))
+}
+
+func PauseWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/pause", escape(workspace))
+}
+
+func ResumeWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/resume", escape(workspace))
+}
+
+func EnableDebugLoggingWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/enable-debug-logging", escape(workspace))
+}
+
+func DisableDebugLoggingWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/disable-debug-logging", escape(workspace))
 }
 
 func SetPermissionWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/set-permission", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/set-permission", escape(workspace))
 }
 
 func UnsetPermissionWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/unset-permission", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/unset-permission", escape(workspace))
 }
 
 func WatchWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/watch", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/watch", escape(workspace))
 }
 
 func ConnectWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/connect", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/connect", escape(workspace))
 }
 
 func DisconnectWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/disconnect", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/disconnect", escape(workspace))
 }
 
 func StartRunWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/start-run", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/start-run", escape(workspace))
 }
 
 func SetupConnectionProviderWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/setup-connection-provider", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/setup-connection-provider", escape(workspace))
 }
 
 func SetupConnectionRepoWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/setup-connection-repo", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/setup-connection-repo", escape(workspace))
 }
 
 func CreateTagWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/create-tag", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/create-tag", escape(workspace))
 }
 
 func DeleteTagWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/delete-tag", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/delete-tag", escape(workspace))
 }
 
 func StateWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/state", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/state", escape(workspace))
+}
+
+func StatsWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/stats", escape(workspace))
+}
+
+func CompareWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/compare", escape(workspace))
 }
 
 func PoolsWorkspace(workspace string) string {
-	return fmt.Sprintf("/app/workspaces/%s/pools", workspace)
+	return fmt.Sprintf("/app/workspaces/%s/pools", escape(workspace))
+}
+
+func DriftWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/drift", escape(workspace))
+}
+
+func DriftBadgeWorkspace(workspace string) string {
+	return fmt.Sprintf("/app/workspaces/%s/drift-badge", escape(workspace))
 }
@@ -87,3 +87,74 @@ func GetClientIP(r *http.Request) (string, error) {
 	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	return host, err
 }
+
+// ClientIP determines the client's IP address, taking into account the
+// X-Forwarded-For header only if the request is sent from one of the trusted
+// proxies. Unlike GetClientIP, a client cannot spoof its address by setting
+// the header itself: the header is walked from right to left, skipping the
+// addresses of trusted proxies, and the first untrusted address is returned,
+// because only that address was added by a trusted proxy.
+func ClientIP(r *http.Request, trusted []*net.IPNet) string {
+	addr := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	if !isTrusted(addr, trusted) {
+		return addr
+	}
+	var hops []string
+	for _, hdr := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(hdr, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			// a malformed address cannot be trusted, nor can any address to
+			// its left.
+			break
+		}
+		addr = hop
+		if !isTrusted(hop, trusted) {
+			break
+		}
+	}
+	return addr
+}
+
+func isTrusted(addr string, trusted []*net.IPNet) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCIDRs parses a list of networks in CIDR notation. An address without a
+// prefix length is parsed as a network containing only that address.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address: %s", cidr)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			cidr = fmt.Sprintf("%s/%d", cidr, bits)
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		networks[i] = network
+	}
+	return networks, nil
+}
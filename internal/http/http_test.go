@@ -102,3 +102,67 @@ func TestGetClientIP(t *testing.T) {
 		})
 	}
 }
+
+func TestClientIP(t *testing.T) {
+	trusted, err := ParseCIDRs([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		forwarded []string
+		remote    string
+		want      string
+	}{
+		{
+			name:   "no forwarded header",
+			remote: "1.2.3.4:1234",
+			want:   "1.2.3.4",
+		},
+		{
+			name:      "forwarded header from untrusted client is ignored",
+			remote:    "1.2.3.4:1234",
+			forwarded: []string{"5.6.7.8"},
+			want:      "1.2.3.4",
+		},
+		{
+			name:      "forwarded header from trusted proxy",
+			remote:    "10.0.0.1:1234",
+			forwarded: []string{"5.6.7.8"},
+			want:      "5.6.7.8",
+		},
+		{
+			name:      "spoofed address to left of client is ignored",
+			remote:    "10.0.0.1:1234",
+			forwarded: []string{"9.9.9.9, 5.6.7.8"},
+			want:      "5.6.7.8",
+		},
+		{
+			name:      "chain of trusted proxies",
+			remote:    "10.0.0.1:1234",
+			forwarded: []string{"9.9.9.9, 5.6.7.8, 192.168.1.1", "10.0.0.2"},
+			want:      "5.6.7.8",
+		},
+		{
+			name:      "malformed address",
+			remote:    "10.0.0.1:1234",
+			forwarded: []string{"5.6.7.8, bogus"},
+			want:      "10.0.0.1",
+		},
+		{
+			name:      "only trusted proxies",
+			remote:    "10.0.0.1:1234",
+			forwarded: []string{"10.0.0.2"},
+			want:      "10.0.0.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("", "/", nil)
+			for _, hdr := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", hdr)
+			}
+			r.RemoteAddr = tt.remote
+			assert.Equal(t, tt.want, ClientIP(r, trusted))
+		})
+	}
+}
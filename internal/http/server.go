@@ -12,6 +12,7 @@ import (
 	"github.com/felixge/httpsnoop"
 	gorillaHandlers "github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 
@@ -84,8 +85,12 @@ func NewServer(logger *slog.Logger, cfg ServerConfig) (*Server, error) {
 		return nil, err
 	}
 
-	// Prometheus metrics
-	r.Handle("/metrics", otelhttp.WithRouteTag("/metrics", promhttp.Handler()))
+	// Prometheus metrics, served in the OpenMetrics format to scrapers that
+	// request it, in order to expose exemplars.
+	r.Handle("/metrics", otelhttp.WithRouteTag("/metrics", promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	)))
 
 	r.Handle("/version", otelhttp.WithRouteTag("/version", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-type", "application/json")
@@ -0,0 +1,64 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// LockID guarantees only one cleaner on a cluster is running at any time.
+const LockID int64 = 5577006791947779423
+
+const defaultCleanerInterval = time.Hour
+
+// Cleaner deletes idempotency keys once they have expired.
+//
+// Only one cleaner should be running on a cluster at any one time.
+type Cleaner struct {
+	logger *slog.Logger
+	db     store
+	// frequency with which the cleaner deletes expired keys.
+	interval time.Duration
+}
+
+func newCleaner(logger *slog.Logger, db store) *Cleaner {
+	return &Cleaner{
+		logger:   logger.With("component", "idempotency-key-cleaner"),
+		db:       db,
+		interval: defaultCleanerInterval,
+	}
+}
+
+func (c *Cleaner) String() string { return "idempotency-key-cleaner" }
+
+// Start the cleaner. Should be invoked in a go routine.
+func (c *Cleaner) Start(ctx context.Context) error {
+	// run at startup and then every interval
+	if err := c.clean(ctx, time.Now()); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.clean(ctx, time.Now()); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// clean deletes keys that have expired by now.
+func (c *Cleaner) clean(ctx context.Context, now time.Time) error {
+	n, err := c.db.deleteCreatedBefore(ctx, now.Add(-TTL))
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		c.logger.Info("deleted expired idempotency keys", "count", n)
+	}
+	return nil
+}
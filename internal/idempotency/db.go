@@ -0,0 +1,78 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+// pgdb stores idempotency keys in a postgres database
+type pgdb struct {
+	*sql.Pool // provides access to generated SQL queries
+}
+
+func (db *pgdb) claim(ctx context.Context, key recordKey, hash string, now, expiredBefore time.Time) (bool, error) {
+	err := db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.ClaimIdempotencyKey(ctx, pggen.ClaimIdempotencyKeyParams{
+			Subject:        sql.String(key.subject),
+			IdempotencyKey: sql.String(key.key),
+			RequestHash:    sql.String(hash),
+			CreatedAt:      sql.Timestamptz(now),
+			ExpiredBefore:  sql.Timestamptz(expiredBefore),
+		})
+		return sql.Error(err)
+	})
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		// an unexpired key already exists
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *pgdb) get(ctx context.Context, key recordKey) (*record, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*record, error) {
+		row, err := q.FindIdempotencyKey(ctx, sql.String(key.subject), sql.String(key.key))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return &record{
+			recordKey:  key,
+			hash:       row.RequestHash.String,
+			resourceID: row.ResourceID.String,
+		}, nil
+	})
+}
+
+func (db *pgdb) setResource(ctx context.Context, key recordKey, resourceID string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpdateIdempotencyKeyResource(ctx, pggen.UpdateIdempotencyKeyResourceParams{
+			ResourceID:     sql.String(resourceID),
+			Subject:        sql.String(key.subject),
+			IdempotencyKey: sql.String(key.key),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) release(ctx context.Context, key recordKey) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.ReleaseIdempotencyKey(ctx, sql.String(key.subject), sql.String(key.key))
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) deleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (int64, error) {
+		tag, err := q.DeleteIdempotencyKeysCreatedBefore(ctx, sql.Timestamptz(before))
+		if err != nil {
+			return 0, sql.Error(err)
+		}
+		return tag.RowsAffected(), nil
+	})
+}
@@ -0,0 +1,188 @@
+// Package idempotency permits API clients to safely retry requests that create
+// resources.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/sql"
+)
+
+const (
+	// Header is the request header in which a client provides an idempotency
+	// key.
+	Header = "Idempotency-Key"
+	// ReplayedHeader is set on a response to a request that replayed an
+	// idempotency key, i.e. a response for a resource created by an earlier
+	// request.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	// TTL is the duration for which an idempotency key is retained.
+	TTL = 24 * time.Hour
+
+	// maxKeyLength is the maximum permitted length of an idempotency key.
+	maxKeyLength = 255
+)
+
+var (
+	// ErrKeyReused is returned when an idempotency key is reused with a
+	// different request.
+	ErrKeyReused = &internal.HTTPError{
+		Code:    http.StatusConflict,
+		Message: "idempotency key has already been used with a different request",
+	}
+	// ErrInProgress is returned when an idempotency key is replayed whilst
+	// the original request is still in-flight.
+	ErrInProgress = &internal.HTTPError{
+		Code:    http.StatusConflict,
+		Message: "a request with the same idempotency key is in progress",
+	}
+	// ErrKeyTooLong is returned when an idempotency key exceeds the maximum
+	// permitted length.
+	ErrKeyTooLong = &internal.HTTPError{
+		Code:    http.StatusUnprocessableEntity,
+		Message: fmt.Sprintf("idempotency key cannot exceed %d characters", maxKeyLength),
+	}
+)
+
+type (
+	// Service ensures requests with the same idempotency key create a
+	// resource no more than once.
+	Service struct {
+		logger *slog.Logger
+		db     store
+	}
+
+	Options struct {
+		Logger *slog.Logger
+		*sql.Pool
+	}
+
+	// store persists idempotency keys.
+	store interface {
+		// claim the key for a request, returning false if the key has
+		// already been claimed and has not expired.
+		claim(ctx context.Context, key recordKey, hash string, now, expiredBefore time.Time) (bool, error)
+		get(ctx context.Context, key recordKey) (*record, error)
+		setResource(ctx context.Context, key recordKey, resourceID string) error
+		// release a claimed key with which a resource was not created.
+		release(ctx context.Context, key recordKey) error
+		deleteCreatedBefore(ctx context.Context, before time.Time) (int64, error)
+	}
+
+	// recordKey uniquely identifies an idempotency key, which are scoped to
+	// the subject making the request.
+	recordKey struct {
+		subject string
+		key     string
+	}
+
+	// record is a claimed idempotency key.
+	record struct {
+		recordKey
+		// hash of the request that claimed the key
+		hash string
+		// ID of resource created by the request; empty whilst the request is
+		// in-flight.
+		resourceID string
+	}
+)
+
+func NewService(opts Options) *Service {
+	return &Service{
+		logger: opts.Logger.With("component", "idempotency"),
+		db:     &pgdb{opts.Pool},
+	}
+}
+
+// NewCleaner constructs a cleaner that deletes expired idempotency keys.
+func (s *Service) NewCleaner(logger *slog.Logger) *Cleaner {
+	return newCleaner(logger, s.db)
+}
+
+// Do invokes create, which creates a resource and returns its ID, unless the
+// request has an idempotency key with which a resource has already been
+// created, in which case the ID of that resource is returned instead, along
+// with true to indicate the request was a replay. The body is the body of the
+// request, which, along with its method and path, must match that of the
+// request that originally used the key.
+//
+// Requests without an idempotency key invoke create unconditionally.
+func (s *Service) Do(r *http.Request, body []byte, create func() (string, error)) (string, bool, error) {
+	key := r.Header.Get(Header)
+	if key == "" {
+		id, err := create()
+		return id, false, err
+	}
+	if len(key) > maxKeyLength {
+		return "", false, ErrKeyTooLong
+	}
+
+	ctx := r.Context()
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	rk := recordKey{subject: fmt.Sprintf("%T:%s", subject, subject), key: key}
+	hash := requestHash(r, body)
+
+	now := internal.CurrentTimestamp(nil)
+	claimed, err := s.db.claim(ctx, rk, hash, now, now.Add(-TTL))
+	if err != nil {
+		s.logger.Error("claiming idempotency key", "subject", subject, "key", key, "err", err)
+		return "", false, err
+	}
+	if !claimed {
+		existing, err := s.db.get(ctx, rk)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			// the original request has only just failed and released the key
+			return "", false, ErrInProgress
+		} else if err != nil {
+			s.logger.Error("retrieving idempotency key", "subject", subject, "key", key, "err", err)
+			return "", false, err
+		}
+		if existing.hash != hash {
+			return "", false, ErrKeyReused
+		}
+		if existing.resourceID == "" {
+			return "", false, ErrInProgress
+		}
+		s.logger.Debug("replayed idempotency key", "subject", subject, "key", key, "resource_id", existing.resourceID)
+		return existing.resourceID, true, nil
+	}
+
+	id, err := create()
+	if err != nil {
+		// release the key so that the client can retry the request
+		if err := s.db.release(ctx, rk); err != nil {
+			s.logger.Error("releasing idempotency key", "subject", subject, "key", key, "err", err)
+		}
+		return "", false, err
+	}
+	if err := s.db.setResource(ctx, rk, id); err != nil {
+		// the resource has nonetheless been created, so don't report an
+		// error; replays of the key are reported as in progress until the
+		// key expires.
+		s.logger.Error("recording resource for idempotency key", "subject", subject, "key", key, "resource_id", id, "err", err)
+	}
+	return id, false, nil
+}
+
+// requestHash returns a hash of a request's method, path and body.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
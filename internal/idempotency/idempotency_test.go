@@ -0,0 +1,286 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+// fakeStore is an in-memory store, with claims made atomically in the same
+// manner as the database.
+type fakeStore struct {
+	records map[recordKey]*fakeRecord
+	mu      sync.Mutex
+}
+
+type fakeRecord struct {
+	record
+	createdAt time.Time
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{records: make(map[recordKey]*fakeRecord)}
+}
+
+func (f *fakeStore) claim(ctx context.Context, key recordKey, hash string, now, expiredBefore time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if existing, ok := f.records[key]; ok && !existing.createdAt.Before(expiredBefore) {
+		return false, nil
+	}
+	f.records[key] = &fakeRecord{record: record{recordKey: key, hash: hash}, createdAt: now}
+	return true, nil
+}
+
+func (f *fakeStore) get(ctx context.Context, key recordKey) (*record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.records[key]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	rec := existing.record
+	return &rec, nil
+}
+
+func (f *fakeStore) setResource(ctx context.Context, key recordKey, resourceID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.records[key].resourceID = resourceID
+	return nil
+}
+
+func (f *fakeStore) release(ctx context.Context, key recordKey) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if existing, ok := f.records[key]; ok && existing.resourceID == "" {
+		delete(f.records, key)
+	}
+	return nil
+}
+
+func (f *fakeStore) deleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var n int64
+	for key, rec := range f.records {
+		if rec.createdAt.Before(before) {
+			delete(f.records, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func newTestService() (*Service, *fakeStore) {
+	db := newFakeStore()
+	return &Service{logger: slog.New(&xslog.NoopHandler{}), db: db}, db
+}
+
+// newRequest constructs a request to create a resource, on behalf of the
+// given user.
+func newRequest(path, key, user string) *http.Request {
+	r := httptest.NewRequest("POST", path, nil)
+	if key != "" {
+		r.Header.Set(Header, key)
+	}
+	return r.WithContext(internal.AddSubjectToContext(r.Context(), &internal.Superuser{Username: user}))
+}
+
+func TestService_Do(t *testing.T) {
+	// creator creates resources with sequential IDs
+	creator := func() func() (string, error) {
+		var n int
+		return func() (string, error) {
+			n++
+			return fmt.Sprintf("run-%d", n), nil
+		}
+	}
+
+	t.Run("no key", func(t *testing.T) {
+		svc, _ := newTestService()
+		create := creator()
+
+		for _, want := range []string{"run-1", "run-2"} {
+			id, replayed, err := svc.Do(newRequest("/runs", "", "bob"), []byte("body"), create)
+			require.NoError(t, err)
+			assert.Equal(t, want, id)
+			assert.False(t, replayed)
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		svc, _ := newTestService()
+		create := creator()
+
+		id, replayed, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), create)
+		require.NoError(t, err)
+		assert.Equal(t, "run-1", id)
+		assert.False(t, replayed)
+
+		id, replayed, err = svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), create)
+		require.NoError(t, err)
+		assert.Equal(t, "run-1", id)
+		assert.True(t, replayed)
+	})
+
+	t.Run("reuse key with different body", func(t *testing.T) {
+		svc, _ := newTestService()
+		create := creator()
+
+		_, _, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), create)
+		require.NoError(t, err)
+
+		_, _, err = svc.Do(newRequest("/runs", "key-1", "bob"), []byte("another body"), create)
+		assert.Equal(t, ErrKeyReused, err)
+	})
+
+	t.Run("reuse key with different path", func(t *testing.T) {
+		svc, _ := newTestService()
+		create := creator()
+
+		_, _, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), create)
+		require.NoError(t, err)
+
+		_, _, err = svc.Do(newRequest("/workspaces", "key-1", "bob"), []byte("body"), create)
+		assert.Equal(t, ErrKeyReused, err)
+	})
+
+	t.Run("keys are scoped to subject", func(t *testing.T) {
+		svc, _ := newTestService()
+		create := creator()
+
+		_, _, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), create)
+		require.NoError(t, err)
+
+		id, replayed, err := svc.Do(newRequest("/runs", "key-1", "alice"), []byte("body"), create)
+		require.NoError(t, err)
+		assert.Equal(t, "run-2", id)
+		assert.False(t, replayed)
+	})
+
+	t.Run("key released upon failure", func(t *testing.T) {
+		svc, _ := newTestService()
+
+		_, _, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), func() (string, error) {
+			return "", errors.New("something went wrong")
+		})
+		require.Error(t, err)
+
+		id, replayed, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), creator())
+		require.NoError(t, err)
+		assert.Equal(t, "run-1", id)
+		assert.False(t, replayed)
+	})
+
+	t.Run("expired key", func(t *testing.T) {
+		svc, db := newTestService()
+		create := creator()
+
+		_, _, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), create)
+		require.NoError(t, err)
+		// age key beyond its TTL
+		for _, rec := range db.records {
+			rec.createdAt = rec.createdAt.Add(-TTL - time.Minute)
+		}
+
+		id, replayed, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("another body"), create)
+		require.NoError(t, err)
+		assert.Equal(t, "run-2", id)
+		assert.False(t, replayed)
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		svc, _ := newTestService()
+
+		_, _, err := svc.Do(newRequest("/runs", strings.Repeat("k", maxKeyLength+1), "bob"), []byte("body"), creator())
+		assert.Equal(t, ErrKeyTooLong, err)
+	})
+}
+
+// TestService_Do_Concurrent tests that concurrent requests with the same key
+// create a resource exactly once.
+func TestService_Do_Concurrent(t *testing.T) {
+	const requests = 20
+
+	svc, _ := newTestService()
+
+	var (
+		created  atomic.Int32
+		inflight atomic.Int32
+		wg       sync.WaitGroup
+		// closed once all but the request creating the resource have
+		// returned
+		others = make(chan struct{})
+		errs   = make(chan error, requests)
+	)
+	create := func() (string, error) {
+		created.Add(1)
+		// hold the creation until every other request has returned, to
+		// ensure they race against an in-flight creation.
+		<-others
+		return "run-1", nil
+	}
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		inflight.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), create)
+			errs <- err
+			if inflight.Add(-1) == 1 {
+				close(others)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	assert.Equal(t, int32(1), created.Load())
+	var inProgress int
+	for err := range errs {
+		if err != nil {
+			assert.Equal(t, ErrInProgress, err)
+			inProgress++
+		}
+	}
+	assert.Equal(t, requests-1, inProgress)
+
+	// once created, a replay should return the resource
+	id, replayed, err := svc.Do(newRequest("/runs", "key-1", "bob"), []byte("body"), create)
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", id)
+	assert.True(t, replayed)
+	assert.Equal(t, int32(1), created.Load())
+}
+
+func TestCleaner(t *testing.T) {
+	db := newFakeStore()
+	now := time.Now()
+	db.records[recordKey{subject: "bob", key: "recent"}] = &fakeRecord{createdAt: now.Add(-time.Hour)}
+	db.records[recordKey{subject: "bob", key: "expired"}] = &fakeRecord{createdAt: now.Add(-TTL - time.Hour)}
+
+	cleaner := newCleaner(slog.New(&xslog.NoopHandler{}), db)
+	require.NoError(t, cleaner.clean(context.Background(), now))
+
+	assert.Len(t, db.records, 1)
+	assert.Contains(t, db.records, recordKey{subject: "bob", key: "recent"})
+}
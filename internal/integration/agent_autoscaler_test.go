@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+)
+
+// TestIntegration_AgentPoolAutoscaler demonstrates configuring the autoscaling
+// of an agent pool.
+func TestIntegration_AgentPoolAutoscaler(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+
+	t.Run("set", func(t *testing.T) {
+		got, err := daemon.Agents.SetPoolAutoscaler(ctx, pool.ID, agentpkg.SetPoolAutoscalerOptions{
+			WebhookURL:       "https://scaler.example.com/hook",
+			HMACSecret:       "secret",
+			ScaleUpThreshold: internal.Int(3),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, got.ScaleUpThreshold)
+	})
+
+	t.Run("update retaining secret", func(t *testing.T) {
+		cooldown := time.Minute
+		_, err := daemon.Agents.SetPoolAutoscaler(ctx, pool.ID, agentpkg.SetPoolAutoscalerOptions{
+			WebhookURL: "https://scaler.example.com/hook",
+			Cooldown:   &cooldown,
+			DryRun:     true,
+		})
+		require.NoError(t, err)
+
+		got, err := daemon.Agents.GetPoolAutoscaler(ctx, pool.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", got.HMACSecret)
+		assert.Equal(t, time.Minute, got.Cooldown)
+		assert.Equal(t, agentpkg.DefaultScaleUpThreshold, got.ScaleUpThreshold)
+		assert.True(t, got.DryRun)
+	})
+
+	t.Run("list scaling decisions", func(t *testing.T) {
+		got, err := daemon.Agents.ListScalingDecisions(ctx, pool.ID)
+		require.NoError(t, err)
+		assert.Len(t, got, 0)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := daemon.Agents.DeletePoolAutoscaler(ctx, pool.ID)
+		require.NoError(t, err)
+
+		_, err = daemon.Agents.GetPoolAutoscaler(ctx, pool.ID)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+}
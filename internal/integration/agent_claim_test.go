@@ -0,0 +1,292 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+	"github.com/tofutf/tofutf/internal/api"
+	"github.com/tofutf/tofutf/internal/daemon"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// TestIntegration_ClaimNextJob demonstrates agents concurrently claiming jobs
+// without any job being claimed by more than one agent.
+func TestIntegration_ClaimNextJob(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+	_, token, err := daemon.Agents.CreateAgentToken(ctx, pool.ID, agentpkg.CreateAgentTokenOptions{
+		Description: "claimants",
+	})
+	require.NoError(t, err)
+
+	const n = 5
+
+	// register agents, each able to run one job at a time
+	clients := make([]*api.Client, n)
+	agentIDs := make([]string, n)
+	for i := range clients {
+		clients[i], agentIDs[i] = registerClaimant(t, ctx, daemon, token, fmt.Sprintf("agent-%d", i))
+	}
+
+	// agents concurrently claim jobs whilst the jobs are created
+	claimed := make([]*agentpkg.Job, n)
+	var wg sync.WaitGroup
+	for i := range clients {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// the allocator competes with the agents for jobs, so an agent
+			// may be left with nothing to claim.
+			ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+
+			req, err := clients[i].NewRequest("POST", "agents/claim", nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			req.Header.Add("otf-agent-id", agentIDs[i])
+			var jobs []*agentpkg.Job
+			err = clients[i].Do(ctx, req, &jobs)
+			if errors.Is(err, context.DeadlineExceeded) {
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.LessOrEqual(t, len(jobs), 1)
+			if len(jobs) == 1 {
+				claimed[i] = jobs[0]
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:          internal.String(fmt.Sprintf("ws-%d", i)),
+			Organization:  internal.String(org.Name),
+			ExecutionMode: workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+			AgentPoolID:   internal.String(pool.ID),
+		})
+		require.NoError(t, err)
+		_ = daemon.createRun(t, ctx, ws, nil)
+	}
+	wg.Wait()
+
+	// no job should be claimed more than once, and each claimed job should
+	// be allocated to its claimant
+	specs := make(map[agentpkg.JobSpec]bool)
+	for i, job := range claimed {
+		if job == nil {
+			continue
+		}
+		assert.False(t, specs[job.Spec], "job %s claimed more than once", job.Spec)
+		specs[job.Spec] = true
+		assert.Equal(t, agentpkg.JobAllocated, job.Status)
+		if assert.NotNil(t, job.AgentID) {
+			assert.Equal(t, agentIDs[i], *job.AgentID)
+		}
+	}
+}
+
+// TestIntegration_ClaimNextJob_TerraformVersionNotAllowed demonstrates an
+// agent not claiming a job for a terraform version its pool does not allow.
+func TestIntegration_ClaimNextJob_TerraformVersionNotAllowed(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:                     "pool-1",
+		Organization:             org.Name,
+		AllowedTerraformVersions: internal.String("< 1.0.0"),
+	})
+	require.NoError(t, err)
+	_, token, err := daemon.Agents.CreateAgentToken(ctx, pool.ID, agentpkg.CreateAgentTokenOptions{
+		Description: "claimant",
+	})
+	require.NoError(t, err)
+	client, agentID := registerClaimant(t, ctx, daemon, token, "agent-1")
+
+	ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:             internal.String("ws-1"),
+		Organization:     internal.String(org.Name),
+		ExecutionMode:    workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+		AgentPoolID:      internal.String(pool.ID),
+		TerraformVersion: internal.String("1.2.0"),
+	})
+	require.NoError(t, err)
+	_ = daemon.createRun(t, ctx, ws, nil)
+
+	assert.Nil(t, claimJob(t, ctx, client, agentID, 3*time.Second))
+}
+
+// TestIntegration_ClaimNextJob_TerraformVersionUnavailable demonstrates an
+// agent not claiming a job requiring a terraform version that can be neither
+// installed nor downloaded, leaving the allocator to reject the job.
+func TestIntegration_ClaimNextJob_TerraformVersionUnavailable(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+	_, token, err := daemon.Agents.CreateAgentToken(ctx, pool.ID, agentpkg.CreateAgentTokenOptions{
+		Description: "claimant",
+	})
+	require.NoError(t, err)
+	client, agentID := registerClaimant(t, ctx, daemon, token, "agent-1")
+
+	ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:          internal.String("ws-1"),
+		Organization:  internal.String(org.Name),
+		ExecutionMode: workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+		AgentPoolID:   internal.String(pool.ID),
+		// no such version was ever released
+		TerraformVersion: internal.String("1.2.99"),
+	})
+	require.NoError(t, err)
+	_ = daemon.createRun(t, ctx, ws, nil)
+
+	assert.Nil(t, claimJob(t, ctx, client, agentID, 3*time.Second))
+}
+
+// TestIntegration_ClaimNextJob_BelowMinAgents demonstrates an agent not
+// claiming a job until its pool has its minimum number of ready agents.
+func TestIntegration_ClaimNextJob_BelowMinAgents(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+		MinAgents:    internal.Int(2),
+	})
+	require.NoError(t, err)
+	_, token, err := daemon.Agents.CreateAgentToken(ctx, pool.ID, agentpkg.CreateAgentTokenOptions{
+		Description: "claimants",
+	})
+	require.NoError(t, err)
+	client, agentID := registerClaimant(t, ctx, daemon, token, "agent-1")
+
+	ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:          internal.String("ws-1"),
+		Organization:  internal.String(org.Name),
+		ExecutionMode: workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+		AgentPoolID:   internal.String(pool.ID),
+	})
+	require.NoError(t, err)
+	_ = daemon.createRun(t, ctx, ws, nil)
+
+	// pool has only one of its two agents
+	assert.Nil(t, claimJob(t, ctx, client, agentID, 3*time.Second))
+}
+
+// TestIntegration_ClaimNextJob_InsufficientDisk demonstrates an agent not
+// claiming a job when it reports too little free disk space for the job.
+func TestIntegration_ClaimNextJob_InsufficientDisk(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, &config{Config: daemon.Config{
+		AgentJobDiskEstimate: 1000,
+	}})
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+	_, token, err := daemon.Agents.CreateAgentToken(ctx, pool.ID, agentpkg.CreateAgentTokenOptions{
+		Description: "claimant",
+	})
+	require.NoError(t, err)
+
+	// register agent reporting less free disk space than the estimate
+	client, err := api.NewClient(api.Config{
+		Address: daemon.System.Hostname(),
+		Token:   string(token),
+	})
+	require.NoError(t, err)
+	req, err := client.NewRequest("POST", "agents/register", map[string]any{
+		"name":          "agent-1",
+		"concurrency":   1,
+		"disk-capacity": 999,
+	})
+	require.NoError(t, err)
+	var agent agentpkg.Agent
+	require.NoError(t, client.Do(ctx, req, &agent))
+
+	ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:          internal.String("ws-1"),
+		Organization:  internal.String(org.Name),
+		ExecutionMode: workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+		AgentPoolID:   internal.String(pool.ID),
+	})
+	require.NoError(t, err)
+	_ = daemon.createRun(t, ctx, ws, nil)
+
+	assert.Nil(t, claimJob(t, ctx, client, agent.ID, 3*time.Second))
+}
+
+// registerClaimant registers a pool agent able to run one job at a time,
+// returning a client for the agent and its ID.
+func registerClaimant(t *testing.T, ctx context.Context, daemon *testDaemon, token []byte, name string) (*api.Client, string) {
+	t.Helper()
+
+	client, err := api.NewClient(api.Config{
+		Address: daemon.System.Hostname(),
+		Token:   string(token),
+	})
+	require.NoError(t, err)
+	req, err := client.NewRequest("POST", "agents/register", map[string]any{
+		"name":        name,
+		"concurrency": 1,
+	})
+	require.NoError(t, err)
+	var agent agentpkg.Agent
+	require.NoError(t, client.Do(ctx, req, &agent))
+	return client, agent.ID
+}
+
+// claimJob claims a job on behalf of an agent, returning nil if no job is
+// claimed before the timeout.
+func claimJob(t *testing.T, ctx context.Context, client *api.Client, agentID string, timeout time.Duration) *agentpkg.Job {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := client.NewRequest("POST", "agents/claim", nil)
+	require.NoError(t, err)
+	req.Header.Add("otf-agent-id", agentID)
+	var jobs []*agentpkg.Job
+	err = client.Do(ctx, req, &jobs)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil
+	}
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(jobs), 1)
+	if len(jobs) == 0 {
+		return nil
+	}
+	return jobs[0]
+}
@@ -0,0 +1,63 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+)
+
+// TestIntegration_AgentDiagnostics demonstrates requesting that a pool agent
+// collect diagnostics, and retrieving the bundle it uploads.
+func TestIntegration_AgentDiagnostics(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	waitForBundle := func(t *testing.T, bundleID string) *agentpkg.DiagnosticsBundle {
+		t.Helper()
+
+		var bundle *agentpkg.DiagnosticsBundle
+		// the request is delivered upon the agent's next status update, which
+		// it sends every 10 seconds.
+		require.Eventually(t, func() bool {
+			var err error
+			bundle, err = daemon.Agents.GetAgentDiagnosticsBundle(ctx, bundleID)
+			require.NoError(t, err)
+			return bundle.CollectedAt != nil
+		}, 30*time.Second, 500*time.Millisecond)
+		return bundle
+	}
+
+	t.Run("collect diagnostics", func(t *testing.T) {
+		registered, shutdown := daemon.startAgent(t, ctx, org.Name, "", "", agentpkg.Config{Name: "agent-1"})
+		defer shutdown()
+
+		requested, err := daemon.Agents.RequestAgentDiagnostics(ctx, registered.ID)
+		require.NoError(t, err)
+
+		// a further request cannot be made until the first is delivered
+		_, err = daemon.Agents.RequestAgentDiagnostics(ctx, registered.ID)
+		assert.ErrorIs(t, err, agentpkg.ErrDiagnosticsPending)
+
+		bundle := waitForBundle(t, requested.ID)
+		assert.Equal(t, "collected", bundle.Status())
+		assert.Equal(t, org.Name, bundle.Organization)
+		assert.NotZero(t, bundle.Size)
+	})
+
+	t.Run("diagnostics disabled", func(t *testing.T) {
+		registered, shutdown := daemon.startAgent(t, ctx, org.Name, "", "", agentpkg.Config{DisableDiagnostics: true})
+		defer shutdown()
+
+		requested, err := daemon.Agents.RequestAgentDiagnostics(ctx, registered.ID)
+		require.NoError(t, err)
+
+		bundle := waitForBundle(t, requested.ID)
+		assert.Equal(t, "failed", bundle.Status())
+		require.NotNil(t, bundle.Error)
+		assert.Contains(t, *bundle.Error, "disabled")
+	})
+}
@@ -0,0 +1,70 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+	"github.com/tofutf/tofutf/internal/daemon"
+	"github.com/tofutf/tofutf/internal/organization"
+)
+
+// TestIntegration_AgentPoolQuota demonstrates limiting the number of agent
+// pools that can be created in an organization.
+func TestIntegration_AgentPoolQuota(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, &config{Config: daemon.Config{
+		AgentMaxPools: 2,
+	}})
+
+	createPool := func(name string) (*agentpkg.Pool, error) {
+		return svc.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+			Name:         name,
+			Organization: org.Name,
+		})
+	}
+
+	t.Run("create pools under limit", func(t *testing.T) {
+		_, err := createPool("pool-1")
+		require.NoError(t, err)
+		_, err = createPool("pool-2")
+		require.NoError(t, err)
+	})
+
+	t.Run("cannot create pool at limit", func(t *testing.T) {
+		_, err := createPool("pool-3")
+		assert.ErrorIs(t, err, agentpkg.ErrPoolQuotaExceeded)
+	})
+
+	t.Run("organization owner cannot raise limit", func(t *testing.T) {
+		_, err := svc.Organizations.Update(ctx, org.Name, organization.UpdateOptions{
+			MaxAgentPools: internal.Int(3),
+		})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
+	t.Run("site admin raises organization limit", func(t *testing.T) {
+		_, err := svc.Organizations.Update(adminCtx, org.Name, organization.UpdateOptions{
+			MaxAgentPools: internal.Int(3),
+		})
+		require.NoError(t, err)
+
+		_, err = createPool("pool-3")
+		require.NoError(t, err)
+
+		_, err = createPool("pool-4")
+		assert.ErrorIs(t, err, agentpkg.ErrPoolQuotaExceeded)
+	})
+
+	t.Run("limit applies per organization", func(t *testing.T) {
+		other := svc.createOrganization(t, ctx)
+		_, err := svc.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+			Name:         "pool-1",
+			Organization: other.Name,
+		})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// TestIntegration_AgentPoolReassign demonstrates reassigning workspaces from
+// one agent pool to another in bulk.
+func TestIntegration_AgentPoolReassign(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, nil)
+
+	ws1 := svc.createWorkspace(t, ctx, org)
+	ws2 := svc.createWorkspace(t, ctx, org)
+	ws3 := svc.createWorkspace(t, ctx, org)
+
+	from, err := svc.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+	// ws2 is not allowed to use the destination pool
+	to, err := svc.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:               "pool-2",
+		Organization:       org.Name,
+		OrganizationScoped: internal.Bool(false),
+		AllowedWorkspaces:  []string{ws1.ID, ws3.ID},
+	})
+	require.NoError(t, err)
+
+	// assign ws1 and ws2 to the source pool; ws3 is left unassigned.
+	for _, ws := range []*workspace.Workspace{ws1, ws2} {
+		_, err := svc.Workspaces.Update(ctx, ws.ID, workspace.UpdateOptions{
+			ExecutionMode: workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+			AgentPoolID:   internal.String(from.ID),
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("user without access to pools cannot reassign", func(t *testing.T) {
+		_, userCtx := svc.createUserCtx(t)
+
+		_, err := svc.Agents.ReassignWorkspacesPool(userCtx, from.ID, to.ID, []string{ws1.ID})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
+	t.Run("reassign with partial failure", func(t *testing.T) {
+		got, err := svc.Agents.ReassignWorkspacesPool(ctx, from.ID, to.ID, []string{ws1.ID, ws2.ID, ws3.ID})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{ws1.ID}, got.Reassigned)
+		require.Len(t, got.Failed, 2)
+		assert.Equal(t, ws2.ID, got.Failed[0].WorkspaceID)
+		assert.ErrorIs(t, got.Failed[0].Err, agentpkg.ErrWorkspaceNotAllowedToUsePool)
+		assert.Equal(t, ws3.ID, got.Failed[1].WorkspaceID)
+		assert.ErrorIs(t, got.Failed[1].Err, agentpkg.ErrWorkspaceNotInPool)
+
+		reassigned, err := svc.Workspaces.Get(ctx, ws1.ID)
+		require.NoError(t, err)
+		assert.Equal(t, to.ID, *reassigned.AgentPoolID)
+
+		unchanged, err := svc.Workspaces.Get(ctx, ws2.ID)
+		require.NoError(t, err)
+		assert.Equal(t, from.ID, *unchanged.AgentPoolID)
+	})
+
+	t.Run("cannot reassign to pool in another organization", func(t *testing.T) {
+		other := svc.createOrganization(t, ctx)
+		otherPool, err := svc.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+			Name:         "pool-1",
+			Organization: other.Name,
+		})
+		require.NoError(t, err)
+
+		_, err = svc.Agents.ReassignWorkspacesPool(ctx, from.ID, otherPool.ID, []string{ws2.ID})
+		assert.ErrorIs(t, err, agentpkg.ErrInvalidReassignmentPool)
+	})
+}
@@ -0,0 +1,96 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+	"github.com/tofutf/tofutf/internal/daemon"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// TestIntegration_AgentPoolRecovery demonstrates deleting an agent pool and
+// then recovering it within its recovery window.
+func TestIntegration_AgentPoolRecovery(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, &config{Config: daemon.Config{
+		AgentPoolRecoveryWindow: time.Hour,
+	}})
+	_, token := svc.createToken(t, ctx, nil)
+	client, err := tfe.NewClient(&tfe.Config{
+		Address: "https://" + svc.System.Hostname(),
+		Token:   string(token),
+	})
+	require.NoError(t, err)
+
+	ws := svc.createWorkspace(t, ctx, org)
+	pool, err := svc.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:               "pool-1",
+		Organization:       org.Name,
+		OrganizationScoped: internal.Bool(false),
+		AllowedWorkspaces:  []string{ws.ID},
+	})
+	require.NoError(t, err)
+
+	t.Run("cannot delete pool referenced by workspaces", func(t *testing.T) {
+		_, err := svc.Workspaces.Update(ctx, ws.ID, workspace.UpdateOptions{
+			ExecutionMode: workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+			AgentPoolID:   internal.String(pool.ID),
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_, err := svc.Workspaces.Update(ctx, ws.ID, workspace.UpdateOptions{
+				ExecutionMode: workspace.ExecutionModePtr(workspace.RemoteExecutionMode),
+			})
+			require.NoError(t, err)
+		})
+
+		err = client.AgentPools.Delete(ctx, pool.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("delete and recover", func(t *testing.T) {
+		err := client.AgentPools.Delete(ctx, pool.ID)
+		require.NoError(t, err)
+
+		// deleted pool is hidden
+		_, err = svc.Agents.GetAgentPool(ctx, pool.ID)
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+		_, err = client.AgentPools.Read(ctx, pool.ID)
+		assert.ErrorIs(t, err, tfe.ErrResourceNotFound)
+
+		recovered, err := svc.Agents.UndeletePool(ctx, pool.ID)
+		require.NoError(t, err)
+		assert.Nil(t, recovered.DeletedAt)
+		assert.Equal(t, []string{ws.ID}, recovered.AllowedWorkspaces)
+
+		got, err := svc.Agents.GetAgentPool(ctx, pool.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{ws.ID}, got.AllowedWorkspaces)
+	})
+
+	t.Run("cannot recover pool that has not been deleted", func(t *testing.T) {
+		_, err := svc.Agents.UndeletePool(ctx, pool.ID)
+		assert.ErrorIs(t, err, agentpkg.ErrPoolNotDeleted)
+	})
+
+	t.Run("cannot recover pool whose name has been taken", func(t *testing.T) {
+		err := client.AgentPools.Delete(ctx, pool.ID)
+		require.NoError(t, err)
+
+		// name of deleted pool may be reused
+		_, err = svc.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+			Name:         "pool-1",
+			Organization: org.Name,
+		})
+		require.NoError(t, err)
+
+		_, err = svc.Agents.UndeletePool(ctx, pool.ID)
+		assert.ErrorIs(t, err, agentpkg.ErrCannotRecoverPoolNameTaken)
+	})
+}
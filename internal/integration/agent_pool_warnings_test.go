@@ -0,0 +1,46 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// TestIntegration_AgentPoolWithoutAgentsWarnings demonstrates a workspace
+// update warning about each of the workspace's pools, including its plan and
+// apply pools, that has no running agents.
+func TestIntegration_AgentPoolWithoutAgentsWarnings(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, nil)
+
+	ws := svc.createWorkspace(t, ctx, org)
+	plan, err := svc.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "plan-pool",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+	apply, err := svc.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "apply-pool",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+
+	_, warnings, err := svc.Workspaces.UpdateWithWarnings(ctx, ws.ID, workspace.UpdateOptions{
+		ExecutionMode:    workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+		AgentPoolID:      internal.String(plan.ID),
+		PlanAgentPoolID:  internal.String(plan.ID),
+		ApplyAgentPoolID: internal.String(apply.ID),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "agent-pool-without-agents", warnings[0].Code)
+	assert.Contains(t, warnings[0].Message, plan.ID)
+	assert.Equal(t, "agent-pool-without-agents", warnings[1].Code)
+	assert.Contains(t, warnings[1].Message, apply.ID)
+}
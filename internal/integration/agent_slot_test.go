@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+	"github.com/tofutf/tofutf/internal/api"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// TestIntegration_FinishJobFreesSlot demonstrates that finishing a job
+// immediately frees up the slot it occupied on its agent, permitting the agent
+// to be allocated another job.
+func TestIntegration_FinishJobFreesSlot(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+	_, token, err := daemon.Agents.CreateAgentToken(ctx, pool.ID, agentpkg.CreateAgentTokenOptions{
+		Description: "slots",
+	})
+	require.NoError(t, err)
+
+	// register an agent able to run only one job at a time
+	client, err := api.NewClient(api.Config{
+		Address: daemon.System.Hostname(),
+		Token:   string(token),
+	})
+	require.NoError(t, err)
+	req, err := client.NewRequest("POST", "agents/register", map[string]any{
+		"name":        "agent-1",
+		"concurrency": 1,
+	})
+	require.NoError(t, err)
+	var agent agentpkg.Agent
+	require.NoError(t, client.Do(ctx, req, &agent))
+
+	createRun := func(name string) string {
+		ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:          internal.String(name),
+			Organization:  internal.String(org.Name),
+			ExecutionMode: workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+			AgentPoolID:   internal.String(pool.ID),
+		})
+		require.NoError(t, err)
+		return daemon.createRun(t, ctx, ws, nil).ID
+	}
+
+	// nextJob waits for the next job to be allocated to the agent, either by
+	// the allocator or by the agent claiming it, returning nil if no job is
+	// allocated before the timeout.
+	nextJob := func(timeout time.Duration) *agentpkg.Job {
+		deadline := time.Now().Add(timeout)
+		for time.Now().Before(deadline) {
+			for _, call := range []struct{ method, path string }{
+				{"GET", "agents/jobs"},
+				{"POST", "agents/claim"},
+			} {
+				ctx, cancel := context.WithTimeout(ctx, time.Second)
+				req, err := client.NewRequest(call.method, call.path, nil)
+				require.NoError(t, err)
+				req.Header.Add("otf-agent-id", agent.ID)
+				var jobs []*agentpkg.Job
+				err = client.Do(ctx, req, &jobs)
+				cancel()
+				if errors.Is(err, context.DeadlineExceeded) {
+					continue
+				}
+				require.NoError(t, err)
+				for _, job := range jobs {
+					if job.Status == agentpkg.JobAllocated {
+						return job
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	run1 := createRun("ws-1")
+	job1 := nextJob(10 * time.Second)
+	require.NotNil(t, job1)
+	require.Equal(t, run1, job1.Spec.RunID)
+
+	req, err = client.NewRequest("POST", "agents/start", &job1.Spec)
+	require.NoError(t, err)
+	req.Header.Add("otf-agent-id", agent.ID)
+	var jobToken bytes.Buffer
+	require.NoError(t, client.Do(ctx, req, &jobToken))
+
+	// the agent's only slot is occupied, so the job for the second run
+	// cannot be allocated to it
+	run2 := createRun("ws-2")
+	require.Nil(t, nextJob(3*time.Second))
+
+	// finish the first job as the job itself
+	jobClient, err := api.NewClient(api.Config{
+		Address: daemon.System.Hostname(),
+		Token:   jobToken.String(),
+	})
+	require.NoError(t, err)
+	req, err = jobClient.NewRequest("POST", "agents/finish", map[string]any{
+		"run_id": job1.Spec.RunID,
+		"phase":  job1.Spec.Phase,
+		"status": agentpkg.JobFinished,
+	})
+	require.NoError(t, err)
+	require.NoError(t, jobClient.Do(ctx, req, nil))
+
+	// the slot is freed up straight away
+	job2 := nextJob(10 * time.Second)
+	require.NotNil(t, job2)
+	assert.Equal(t, run2, job2.Spec.RunID)
+}
@@ -1,11 +1,15 @@
 package integration
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal"
 	agentpkg "github.com/tofutf/tofutf/internal/agent"
+	"github.com/tofutf/tofutf/internal/daemon"
 	"github.com/tofutf/tofutf/internal/pubsub"
 	"github.com/tofutf/tofutf/internal/testutils"
 	"github.com/tofutf/tofutf/internal/workspace"
@@ -75,3 +79,121 @@ func TestIntegration_Agents(t *testing.T) {
 			*event.Payload.AgentID == agent2.ID
 	})
 }
+
+// TestIntegration_AgentStatusHistory demonstrates the recording of an agent's
+// changes in status.
+func TestIntegration_AgentStatusHistory(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	agentsSub, unsub := daemon.Agents.WatchAgents(ctx)
+	defer unsub()
+
+	agent, shutdown := daemon.startAgent(t, ctx, org.Name, "", "", agentpkg.Config{})
+	shutdown()
+
+	// wait for agent to deregister
+	testutils.Wait(t, agentsSub, func(event pubsub.Event[*agentpkg.Agent]) bool {
+		return event.Payload.ID == agent.ID && event.Payload.Status == agentpkg.AgentExited
+	})
+
+	history, err := daemon.Agents.GetAgentStatusHistory(ctx, agent.ID)
+	require.NoError(t, err)
+
+	// changes should be recorded in order, and pings should not be recorded
+	if assert.Len(t, history, 2) {
+		assert.Equal(t, agentpkg.AgentIdle, history[0].Status)
+		assert.Equal(t, "registered", history[0].Reason)
+		assert.Equal(t, agentpkg.AgentExited, history[1].Status)
+		assert.Equal(t, "agent exited", history[1].Reason)
+		assert.False(t, history[1].ChangedAt.Before(history[0].ChangedAt))
+	}
+}
+
+// TestIntegration_AgentStatusHistoryRetention demonstrates the purging of
+// status changes older than the retention window, sparing an agent's current
+// status.
+func TestIntegration_AgentStatusHistoryRetention(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, &config{Config: daemon.Config{
+		AgentStatusHistoryRetention: time.Nanosecond,
+	}})
+
+	agentsSub, unsub := svc.Agents.WatchAgents(ctx)
+	defer unsub()
+
+	agent, shutdown := svc.startAgent(t, ctx, org.Name, "", "", agentpkg.Config{})
+	shutdown()
+
+	testutils.Wait(t, agentsSub, func(event pubsub.Event[*agentpkg.Agent]) bool {
+		return event.Payload.ID == agent.ID && event.Payload.Status == agentpkg.AgentExited
+	})
+
+	// purge once, at startup
+	purgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go svc.Agents.NewStatusHistoryPurger(svc.Logger).Start(purgeCtx) //nolint:errcheck
+
+	// both changes are older than the retention window, but only the change
+	// to idle is purged, retaining the agent's current status
+	require.Eventually(t, func() bool {
+		history, err := svc.Agents.GetAgentStatusHistory(ctx, agent.ID)
+		require.NoError(t, err)
+		return len(history) == 1 && history[0].Status == agentpkg.AgentExited
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+// TestIntegration_AgentDeregistration demonstrates an agent deregistering when
+// it shuts down cleanly, marking itself as exited without waiting for the
+// manager to notice it has stopped pinging.
+func TestIntegration_AgentDeregistration(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+
+	ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:          internal.String("ws-1"),
+		Organization:  internal.String(org.Name),
+		ExecutionMode: workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+		AgentPoolID:   internal.String(pool.ID),
+	})
+	require.NoError(t, err)
+
+	agentsSub, unsubAgents := daemon.Agents.WatchAgents(ctx)
+	defer unsubAgents()
+	jobsSub, unsubJobs := daemon.Agents.WatchJobs(ctx)
+	defer unsubJobs()
+
+	agent, shutdown := daemon.startAgent(t, ctx, org.Name, pool.ID, "", agentpkg.Config{})
+
+	// create a run and wait for the agent to start its job
+	_ = daemon.createRun(t, ctx, ws, nil)
+	testutils.Wait(t, jobsSub, func(event pubsub.Event[*agentpkg.Job]) bool {
+		return event.Payload.Status == agentpkg.JobRunning &&
+			*event.Payload.AgentID == agent.ID
+	})
+
+	shutdown()
+
+	// the agent is marked as exited as soon as it has shut down
+	testutils.Wait(t, agentsSub, func(event pubsub.Event[*agentpkg.Agent]) bool {
+		return event.Payload.ID == agent.ID && event.Payload.Status == agentpkg.AgentExited
+	})
+
+	// and it leaves no job behind in progress
+	testutils.Wait(t, jobsSub, func(event pubsub.Event[*agentpkg.Job]) bool {
+		switch event.Payload.Status {
+		case agentpkg.JobFinished, agentpkg.JobCanceled, agentpkg.JobErrored:
+			return true
+		}
+		return false
+	})
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+)
+
+// TestIntegration_ExpiringAgentTokens demonstrates listing the agent tokens in
+// an organization that are nearing expiry.
+func TestIntegration_ExpiringAgentTokens(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+
+	now := internal.CurrentTimestamp(nil)
+	createToken := func(t *testing.T, poolID, description string, expiresAt *time.Time) {
+		_, _, err := daemon.Agents.CreateAgentToken(ctx, poolID, agentpkg.CreateAgentTokenOptions{
+			Description: description,
+			ExpiresAt:   expiresAt,
+		})
+		require.NoError(t, err)
+	}
+	soon := now.Add(time.Hour)
+	later := now.Add(30 * 24 * time.Hour)
+	createToken(t, pool.ID, "soon", &soon)
+	createToken(t, pool.ID, "later", &later)
+	createToken(t, pool.ID, "never", nil)
+
+	// a token nearing expiry in another organization should not be listed
+	otherOrg := daemon.createOrganization(t, ctx)
+	otherPool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: otherOrg.Name,
+	})
+	require.NoError(t, err)
+	createToken(t, otherPool.ID, "other", &soon)
+
+	t.Run("inside window", func(t *testing.T) {
+		got, err := daemon.Agents.ListExpiringAgentTokens(ctx, org.Name, 24*time.Hour)
+		require.NoError(t, err)
+
+		require.Len(t, got, 1)
+		assert.Equal(t, "soon", got[0].Description)
+		assert.Equal(t, pool.ID, got[0].AgentPoolID)
+		assert.Equal(t, "pool-1", got[0].AgentPoolName)
+		assert.Nil(t, got[0].LastUsedAt)
+	})
+
+	t.Run("wider window", func(t *testing.T) {
+		got, err := daemon.Agents.ListExpiringAgentTokens(ctx, org.Name, 60*24*time.Hour)
+		require.NoError(t, err)
+
+		require.Len(t, got, 2)
+		assert.Equal(t, "soon", got[0].Description)
+		assert.Equal(t, "later", got[1].Description)
+	})
+
+	t.Run("outside window", func(t *testing.T) {
+		got, err := daemon.Agents.ListExpiringAgentTokens(ctx, org.Name, time.Minute)
+		require.NoError(t, err)
+
+		assert.Len(t, got, 0)
+	})
+
+	t.Run("reject expiry in the past", func(t *testing.T) {
+		past := now.Add(-time.Hour)
+		_, _, err := daemon.Agents.CreateAgentToken(ctx, pool.ID, agentpkg.CreateAgentTokenOptions{
+			Description: "past",
+			ExpiresAt:   &past,
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,59 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// TestIntegration_ApplyWindow demonstrates a confirmed run being held outside
+// of its workspace's apply window, and an emergency override applying it
+// regardless.
+func TestIntegration_ApplyWindow(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	// a brief window three days from now, which is never open during the test
+	closed := time.Now().UTC().AddDate(0, 0, 3).Weekday()
+	ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:         internal.String("apply-window"),
+		Organization: internal.String(org.Name),
+		ApplyWindows: workspace.ApplyWindows{
+			{Days: []time.Weekday{closed}, Start: "00:00", End: "00:01"},
+		},
+	})
+	require.NoError(t, err)
+
+	cv := daemon.createAndUploadConfigurationVersion(t, ctx, ws, nil)
+
+	sub, unsub := daemon.Runs.Watch(ctx)
+	defer unsub()
+	_ = daemon.createRun(t, ctx, ws, cv)
+
+	for event := range sub {
+		r := event.Payload
+		switch r.Status {
+		case run.RunPlanned:
+			// plans are unaffected by the window but the apply is held
+			err := daemon.Runs.Apply(ctx, r.ID)
+			require.NoError(t, err)
+
+			got, err := daemon.Runs.Get(ctx, r.ID)
+			require.NoError(t, err)
+			assert.Equal(t, run.RunAwaitingWindow, got.Status)
+
+			err = daemon.Runs.OverrideApplyWindow(ctx, r.ID)
+			require.NoError(t, err)
+		case run.RunApplied:
+			return
+		case run.RunErrored:
+			t.Fatal("run unexpectedly errored")
+		}
+	}
+}
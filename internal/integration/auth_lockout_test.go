@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/daemon"
+	"github.com/tofutf/tofutf/internal/lockout"
+)
+
+// TestIntegration_AuthLockout demonstrates a client being locked out after
+// too many failed authentication attempts, and a site admin clearing the
+// lockout.
+func TestIntegration_AuthLockout(t *testing.T) {
+	integrationTest(t)
+
+	svc, _, ctx := setup(t, &config{Config: daemon.Config{AuthLockoutThreshold: 3}})
+	_, token := svc.createToken(t, ctx, nil)
+
+	ping := func(t *testing.T, token string) *http.Response {
+		t.Helper()
+
+		u := fmt.Sprintf("https://%s/api/v2/ping", svc.System.Hostname())
+		r, err := http.NewRequest("GET", u, nil)
+		require.NoError(t, err)
+		r.Header.Add("Authorization", "Bearer "+token)
+
+		resp, err := http.DefaultClient.Do(r)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	lockouts, unsub := svc.Lockouts.Watch(ctx)
+	defer unsub()
+
+	for i := 0; i < 3; i++ {
+		resp := ping(t, "incorrect")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+	// even a valid token is now rejected
+	resp := ping(t, string(token))
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+	// lockout is persisted, publishing an event
+	event := <-lockouts
+	assert.Equal(t, lockout.IPKind, event.Payload.Kind)
+
+	t.Run("only site admin can list lockouts", func(t *testing.T) {
+		_, err := svc.Lockouts.List(ctx)
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
+	got, err := svc.Lockouts.List(adminCtx)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, 3, got[0].Failures)
+
+	err = svc.Lockouts.Clear(adminCtx, got[0].Key())
+	require.NoError(t, err)
+
+	resp = ping(t, string(token))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	t.Run("clear non-existent lockout", func(t *testing.T) {
+		err := svc.Lockouts.Clear(adminCtx, "ip:192.0.2.1")
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+}
@@ -138,7 +138,7 @@ func setup(t *testing.T, cfg *config, gopts ...github.TestServerOption) (*testDa
 	daemon := &testDaemon{
 		Daemon:     d,
 		TestServer: githubServer,
-		downloader: releases.NewDownloader(cfg.terraformBinDir),
+		downloader: releases.NewDownloader(releases.TerraformProduct, cfg.terraformBinDir, 0, ""),
 	}
 
 	// create a dedicated user account and context for test to use.
@@ -0,0 +1,64 @@
+package integration
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/daemon"
+	"github.com/tofutf/tofutf/internal/eventsink"
+)
+
+// TestIntegration_EventSink demonstrates audit events being exported to a
+// file sink, and the sink's status being reported.
+func TestIntegration_EventSink(t *testing.T) {
+	integrationTest(t)
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	svc, org, ctx := setup(t, &config{Config: daemon.Config{
+		EventSinks: []string{"archive=file://" + path},
+	}})
+	ws := svc.createWorkspace(t, ctx, org)
+
+	// wait for the workspace creation to be delivered
+	var got map[string]any
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false
+		}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var event map[string]any
+			if err := json.Unmarshal([]byte(line), &event); err != nil {
+				return false
+			}
+			if event["type"] == "workspace.created" && event["workspace_id"] == ws.ID {
+				got = event
+				return true
+			}
+		}
+		return false
+	}, 30*time.Second, 100*time.Millisecond)
+
+	assert.Equal(t, float64(eventsink.SchemaVersion), got["schema_version"])
+	assert.Equal(t, "audit", got["category"])
+	assert.Equal(t, org.Name, got["organization"])
+
+	// the status is updated once the events have been written to the file
+	var status *eventsink.Status
+	require.Eventually(t, func() bool {
+		statuses, err := svc.EventSinks.List(ctx)
+		if err != nil || len(statuses) != 1 {
+			return false
+		}
+		status = statuses[0]
+		return status.Delivered > 0
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, "archive", status.Name)
+	assert.Equal(t, "file", status.Kind)
+}
@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHTTPBackend demonstrates using OTF as the terraform CLI's HTTP backend,
+// including locking state and forcibly unlocking it.
+func TestHTTPBackend(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	ws := daemon.createWorkspace(t, ctx, org)
+
+	// the HTTP backend only supports basic auth, so the token is provided as
+	// the password.
+	_, token := daemon.createToken(t, ctx, nil)
+	address := fmt.Sprintf("https://%s/api/v2/workspaces/%s/state", daemon.System.Hostname(), ws.ID)
+	root := createRootModule(t, fmt.Sprintf(`
+terraform {
+  backend "http" {
+    address        = "%[1]s"
+    lock_address   = "%[1]s"
+    unlock_address = "%[1]s"
+    username       = "terraform"
+    password       = "%[2]s"
+  }
+}
+resource "null_resource" "e2e" {}
+`, address, token))
+
+	daemon.tfcli(t, ctx, "init", root)
+	out := daemon.tfcli(t, ctx, "apply", root, "-auto-approve")
+	require.Contains(t, out, "Apply complete! Resources: 1 added, 0 changed, 0 destroyed.")
+
+	// state is written and the lock released
+	_, err := daemon.State.GetCurrent(ctx, ws.ID)
+	require.NoError(t, err)
+	got, err := daemon.Workspaces.Get(ctx, ws.ID)
+	require.NoError(t, err)
+	assert.False(t, got.Locked())
+
+	// lock the workspace as if via the UI; the CLI reports the lock
+	locked, err := daemon.Workspaces.Lock(ctx, ws.ID, nil)
+	require.NoError(t, err)
+	out, err = daemon.tfcliWithError(t, ctx, "plan", root)
+	require.Error(t, err)
+	assert.Contains(t, out, "Error acquiring the state lock")
+	lockID := locked.Lock.StateLockInfo().ID
+	assert.Contains(t, out, lockID)
+
+	// forcibly unlock the workspace with the CLI
+	daemon.tfcli(t, ctx, "force-unlock", root, "-force", lockID)
+	got, err = daemon.Workspaces.Get(ctx, ws.ID)
+	require.NoError(t, err)
+	assert.False(t, got.Locked())
+}
@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/idempotency"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// TestIntegration_Idempotency demonstrates API clients retrying requests to
+// create resources with an idempotency key.
+func TestIntegration_Idempotency(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	_, token := daemon.createToken(t, ctx, nil)
+
+	// post sends a JSON:API request to create a resource, returning the
+	// response status, whether the response was a replay, and the ID of the
+	// resource.
+	post := func(t *testing.T, path, key, body string) (int, bool, string) {
+		t.Helper()
+
+		u := fmt.Sprintf("https://%s/api/v2%s", daemon.System.Hostname(), path)
+		r, err := http.NewRequest("POST", u, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		r.Header.Add("Authorization", "Bearer "+string(token))
+		r.Header.Add("Content-Type", "application/vnd.api+json")
+		r.Header.Add(idempotency.Header, key)
+
+		resp, err := http.DefaultClient.Do(r)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var doc struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+		return resp.StatusCode, resp.Header.Get(idempotency.ReplayedHeader) == "true", doc.Data.ID
+	}
+
+	t.Run("create workspace", func(t *testing.T) {
+		path := fmt.Sprintf("/organizations/%s/workspaces", org.Name)
+		body := `{"data":{"type":"workspaces","attributes":{"name":"idempotent"}}}`
+
+		status, replayed, created := post(t, path, "create-workspace", body)
+		require.Equal(t, http.StatusCreated, status)
+		assert.False(t, replayed)
+
+		status, replayed, replay := post(t, path, "create-workspace", body)
+		require.Equal(t, http.StatusCreated, status)
+		assert.True(t, replayed)
+		assert.Equal(t, created, replay)
+
+		// reuse key with a different body
+		status, _, _ = post(t, path, "create-workspace", `{"data":{"type":"workspaces","attributes":{"name":"another"}}}`)
+		assert.Equal(t, http.StatusConflict, status)
+
+		workspaces, err := daemon.Workspaces.List(ctx, workspace.ListOptions{Organization: internal.String(org.Name)})
+		require.NoError(t, err)
+		assert.Len(t, workspaces.Items, 1)
+	})
+
+	t.Run("concurrently create run", func(t *testing.T) {
+		ws := daemon.createWorkspace(t, ctx, org)
+		cv := daemon.createAndUploadConfigurationVersion(t, ctx, ws, nil)
+
+		const requests = 10
+		body := fmt.Sprintf(`{"data":{"type":"runs","relationships":{"workspace":{"data":{"type":"workspaces","id":%q}},"configuration-version":{"data":{"type":"configuration-versions","id":%q}}}}}`, ws.ID, cv.ID)
+
+		var (
+			wg       sync.WaitGroup
+			statuses = make(chan int, requests)
+		)
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				status, _, _ := post(t, "/runs", "create-run", body)
+				statuses <- status
+			}()
+		}
+		wg.Wait()
+		close(statuses)
+
+		// only the request that created the run, or requests replaying the
+		// key after the run was created, should succeed; requests racing
+		// the creation are reported as a conflict.
+		for status := range statuses {
+			assert.Contains(t, []int{http.StatusCreated, http.StatusConflict}, status)
+		}
+		runs, err := daemon.Runs.List(ctx, run.ListOptions{WorkspaceID: &ws.ID})
+		require.NoError(t, err)
+		assert.Len(t, runs.Items, 1)
+
+		// now the run has been created, retrying the request should replay it
+		status, replayed, id := post(t, "/runs", "create-run", body)
+		assert.Equal(t, http.StatusCreated, status)
+		assert.True(t, replayed)
+		assert.Equal(t, runs.Items[0].ID, id)
+	})
+}
@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	agentpkg "github.com/tofutf/tofutf/internal/agent"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/testutils"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// TestIntegration_JobCounts demonstrates counting the jobs in an organization
+// by status.
+func TestIntegration_JobCounts(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	pool, err := daemon.Agents.CreateAgentPool(ctx, agentpkg.CreateAgentPoolOptions{
+		Name:         "pool-1",
+		Organization: org.Name,
+	})
+	require.NoError(t, err)
+
+	jobsSub, unsub := daemon.Agents.WatchJobs(ctx)
+	defer unsub()
+
+	// create runs on workspaces assigned to a pool without any agents, so
+	// that their jobs remain unallocated, labelling only the first run.
+	for i := 0; i < 2; i++ {
+		ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:          internal.String(fmt.Sprintf("ws-%d", i)),
+			Organization:  internal.String(org.Name),
+			ExecutionMode: workspace.ExecutionModePtr(workspace.AgentExecutionMode),
+			AgentPoolID:   internal.String(pool.ID),
+		})
+		require.NoError(t, err)
+		opts := run.CreateOptions{
+			ConfigurationVersionID: internal.String(daemon.createConfigurationVersion(t, ctx, ws, nil).ID),
+		}
+		if i == 0 {
+			opts.Labels = map[string]string{"team": "platform"}
+		}
+		_, err = daemon.Runs.Create(ctx, ws.ID, opts)
+		require.NoError(t, err)
+	}
+	// create a run in another organization, whose job should not be counted
+	other := daemon.createWorkspace(t, ctx, daemon.createOrganization(t, ctx))
+	_ = daemon.createRun(t, ctx, other, nil)
+
+	// wait for the jobs to be created
+	for i := 0; i < 3; i++ {
+		testutils.Wait(t, jobsSub, func(event pubsub.Event[*agentpkg.Job]) bool {
+			return event.Type == pubsub.CreatedEvent
+		})
+	}
+
+	counts, err := daemon.Agents.GetJobCounts(ctx, org.Name, agentpkg.JobCountsOptions{ByPool: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, counts.Counts[agentpkg.JobUnallocated])
+	if assert.Len(t, counts.Pools, 1) {
+		assert.Equal(t, &pool.ID, counts.Pools[0].AgentPoolID)
+		assert.Equal(t, 2, counts.Pools[0].Counts[agentpkg.JobUnallocated])
+	}
+
+	t.Run("filter by label", func(t *testing.T) {
+		counts, err := daemon.Agents.GetJobCounts(ctx, org.Name, agentpkg.JobCountsOptions{
+			Labels: map[string]string{"team": "platform"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, counts.Counts[agentpkg.JobUnallocated])
+
+		counts, err = daemon.Agents.GetJobCounts(ctx, org.Name, agentpkg.JobCountsOptions{
+			Labels: map[string]string{"team": "other"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, counts.Counts[agentpkg.JobUnallocated])
+	})
+
+	t.Run("requires access to organization", func(t *testing.T) {
+		_, userCtx := daemon.createUserCtx(t)
+		_, err := daemon.Agents.GetJobCounts(userCtx, org.Name, agentpkg.JobCountsOptions{})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+}
@@ -0,0 +1,60 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/pubsub"
+)
+
+// TestIntegration_ListenerReconnect demonstrates the database events listener
+// reconnecting after losing its connection to postgres, terminating existing
+// subscriptions so that subscribers resync, and continuing to deliver events
+// to new subscriptions.
+func TestIntegration_ListenerReconnect(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	sub, unsub := daemon.Workspaces.Watch(ctx)
+	defer unsub()
+
+	// drop the listener's connection
+	_, err := daemon.Pool.Exec(ctx, `
+SELECT pg_terminate_backend(pid)
+FROM pg_stat_activity
+WHERE datname = current_database()
+AND query = 'listen events'
+AND pid <> pg_backend_pid()
+`)
+	require.NoError(t, err)
+
+	// the existing subscription is terminated once the listener reconnects
+	timeout := time.After(30 * time.Second)
+	for terminated := false; !terminated; {
+		select {
+		case _, open := <-sub:
+			terminated = !open
+		case <-timeout:
+			t.Fatal("timed out waiting for subscription to be terminated")
+		}
+	}
+
+	// a new subscription receives events once more
+	sub, unsub = daemon.Workspaces.Watch(ctx)
+	defer unsub()
+	ws := daemon.createWorkspace(t, ctx, org)
+	for {
+		select {
+		case event := <-sub:
+			if event.Type == pubsub.CreatedEvent && event.Payload.ID == ws.ID {
+				assert.Equal(t, ws.Name, event.Payload.Name)
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for workspace created event")
+		}
+	}
+}
@@ -2,6 +2,7 @@ package integration
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,6 +10,8 @@ import (
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/daemon"
 	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/variable"
+	"github.com/tofutf/tofutf/internal/workspace"
 )
 
 func TestLogs(t *testing.T) {
@@ -37,6 +40,125 @@ func TestLogs(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("reject out of order chunk", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		run := svc.createRun(t, ctx, nil, nil)
+
+		err := svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+			Data:  []byte("\x02hello"),
+		})
+		require.NoError(t, err)
+
+		// skip ahead, leaving a gap
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID:  run.ID,
+			Phase:  internal.PlanPhase,
+			Data:   []byte("world\x03"),
+			Offset: 10,
+		})
+		var offsetErr *internal.ChunkOffsetError
+		require.ErrorAs(t, err, &offsetErr)
+		assert.Equal(t, 6, offsetErr.Expected)
+	})
+
+	t.Run("reject overlapping chunk", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		run := svc.createRun(t, ctx, nil, nil)
+
+		err := svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+			Data:  []byte("\x02hello"),
+		})
+		require.NoError(t, err)
+
+		// re-send first chunk
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+			Data:  []byte("\x02hello"),
+		})
+		var offsetErr *internal.ChunkOffsetError
+		require.ErrorAs(t, err, &offsetErr)
+		assert.Equal(t, 6, offsetErr.Expected)
+	})
+
+	t.Run("mark incomplete", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		run := svc.createRun(t, ctx, nil, nil)
+
+		err := svc.Logs.MarkLogsIncomplete(ctx, run.ID, internal.PlanPhase, internal.PlainLogStream)
+		require.NoError(t, err)
+
+		got, err := svc.Logs.GetChunk(ctx, internal.GetChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+		})
+		require.NoError(t, err)
+		assert.True(t, got.IsStart())
+		assert.True(t, got.IsEnd())
+		assert.Contains(t, string(got.Data), "logs are incomplete")
+
+		// no further chunks are accepted
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+			Data:  []byte("\x02hello world\x03"),
+		})
+		var offsetErr *internal.ChunkOffsetError
+		require.ErrorAs(t, err, &offsetErr)
+		assert.Equal(t, 0, offsetErr.Expected)
+	})
+
+	t.Run("truncate logs exceeding maximum size", func(t *testing.T) {
+		svc, _, ctx := setup(t, &config{Config: daemon.Config{MaxRunLogSize: 200}})
+		run := svc.createRun(t, ctx, nil, nil)
+
+		// the trailing bytes of each chunk are withheld until the next chunk
+		// arrives, so the first chunk is long enough that some of it is
+		// persisted before the maximum size is exceeded.
+		hello := "\x02" + strings.Repeat("hello ", 30)
+		err := svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+			Data:  []byte(hello),
+		})
+		require.NoError(t, err)
+
+		// exceeds maximum size and is truncated
+		world := strings.Repeat(" world", 50)
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID:  run.ID,
+			Phase:  internal.PlanPhase,
+			Data:   []byte(world),
+			Offset: len(hello),
+		})
+		require.NoError(t, err)
+
+		// further chunks are accepted but dropped
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID:  run.ID,
+			Phase:  internal.PlanPhase,
+			Data:   []byte(" goodbye\x03"),
+			Offset: len(hello) + len(world),
+		})
+		require.NoError(t, err)
+
+		got, err := svc.Logs.GetChunk(ctx, internal.GetChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+		})
+		require.NoError(t, err)
+		assert.True(t, got.IsStart())
+		assert.True(t, got.IsEnd())
+		assert.True(t, strings.HasPrefix(string(got.Data), "\x02hello hello"))
+		assert.Contains(t, string(got.Data), "logs have been truncated")
+		assert.NotContains(t, string(got.Data), "world")
+		assert.NotContains(t, string(got.Data), "goodbye")
+	})
+
 	t.Run("get chunk", func(t *testing.T) {
 		svc, _, ctx := setup(t, nil)
 		run := svc.createRun(t, ctx, nil, nil)
@@ -119,6 +241,100 @@ func TestLogs(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("mask secrets", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		_, err := svc.Variables.CreateWorkspaceVariable(ctx, ws.ID, variable.CreateVariableOptions{
+			Key:       internal.String("password"),
+			Value:     internal.String("hunter2-hunter2"),
+			Category:  variable.VariableCategoryPtr(variable.CategoryTerraform),
+			Sensitive: internal.Bool(true),
+		})
+		require.NoError(t, err)
+		run := svc.createRun(t, ctx, ws, nil)
+
+		// split secret across two chunks
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+			Data:  []byte("\x02password is hunter2-hun"),
+		})
+		require.NoError(t, err)
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID:  run.ID,
+			Phase:  internal.PlanPhase,
+			Data:   []byte("ter2\x03"),
+			Offset: 24,
+		})
+		require.NoError(t, err)
+
+		got, err := svc.Logs.GetChunk(ctx, internal.GetChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "\x02password is ***\x03", string(got.Data))
+	})
+
+	t.Run("flush withheld logs when phase finishes", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		run := svc.createRun(t, ctx, nil, nil)
+
+		sub, err := svc.Logs.Tail(ctx, internal.GetChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+		})
+		require.NoError(t, err)
+
+		// all but the trailing 128 bytes are streamed straight away.
+		data := "\x02" + strings.Repeat("a", 199)
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+			Data:  []byte(data),
+		})
+		require.NoError(t, err)
+		got := <-sub
+		assert.Equal(t, data[:len(data)-128], string(got.Data))
+		assert.Equal(t, 0, got.Offset)
+
+		// the withheld bytes are streamed once the phase finishes.
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID:  run.ID,
+			Phase:  internal.PlanPhase,
+			Data:   []byte("\x03"),
+			Offset: len(data),
+		})
+		require.NoError(t, err)
+		got = <-sub
+		assert.Equal(t, data[len(data)-128:]+"\x03", string(got.Data))
+		assert.Equal(t, len(data)-128, got.Offset)
+	})
+
+	t.Run("scrubbing disabled", func(t *testing.T) {
+		svc, _, ctx := setup(t, nil)
+		ws := svc.createWorkspace(t, ctx, nil)
+		_, err := svc.Workspaces.Update(ctx, ws.ID, workspace.UpdateOptions{
+			LogScrubbingDisabled: internal.Bool(true),
+		})
+		require.NoError(t, err)
+		run := svc.createRun(t, ctx, ws, nil)
+
+		err = svc.Logs.PutChunk(ctx, internal.PutChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+			Data:  []byte("\x02Authorization: Bearer abc123\x03"),
+		})
+		require.NoError(t, err)
+
+		got, err := svc.Logs.GetChunk(ctx, internal.GetChunkOptions{
+			RunID: run.ID,
+			Phase: internal.PlanPhase,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "\x02Authorization: Bearer abc123\x03", string(got.Data))
+	})
 }
 
 // TestClusterLogs tests the relaying of logs across a cluster of otfd nodes.
@@ -149,11 +365,13 @@ func TestClusterLogs(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	// upload first chunk to local node
+	// upload first chunk to local node, long enough that not all of it is
+	// withheld pending the next chunk.
+	hello := "\x02" + strings.Repeat("hello ", 30)
 	err = local.Logs.PutChunk(ctx, internal.PutChunkOptions{
 		RunID: run.ID,
 		Phase: internal.PlanPhase,
-		Data:  []byte("\x02hello"),
+		Data:  []byte(hello),
 	})
 	require.NoError(t, err)
 
@@ -162,15 +380,16 @@ func TestClusterLogs(t *testing.T) {
 		RunID:  run.ID,
 		Phase:  internal.PlanPhase,
 		Data:   []byte(" world\x03"),
-		Offset: 6,
+		Offset: len(hello),
 	})
 	require.NoError(t, err)
 
+	cut := len(hello) - 128
 	want1 := internal.Chunk{
 		ID:    "1",
 		RunID: run.ID,
 		Phase: internal.PlanPhase,
-		Data:  []byte("\x02hello"),
+		Data:  []byte(hello[:cut]),
 	}
 	require.Equal(t, want1, <-sub)
 
@@ -178,8 +397,8 @@ func TestClusterLogs(t *testing.T) {
 		ID:     "2",
 		RunID:  run.ID,
 		Phase:  internal.PlanPhase,
-		Data:   []byte(" world\x03"),
-		Offset: 6,
+		Data:   []byte(hello[cut:] + " world\x03"),
+		Offset: cut,
 	}
 	require.Equal(t, want2, <-sub)
 }
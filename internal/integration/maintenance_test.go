@@ -0,0 +1,100 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/maintenance"
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+// TestIntegration_Maintenance demonstrates maintenance mode holding back a run
+// and the run then being scheduled once maintenance mode ends.
+func TestIntegration_Maintenance(t *testing.T) {
+	integrationTest(t)
+
+	daemon, _, ctx := setup(t, nil)
+
+	t.Run("only site admin can update", func(t *testing.T) {
+		_, err := daemon.Maintenance.Update(ctx, maintenance.UpdateOptions{Enabled: true})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
+	t.Run("end must be after start", func(t *testing.T) {
+		now := internal.CurrentTimestamp(nil)
+		_, err := daemon.Maintenance.Update(adminCtx, maintenance.UpdateOptions{
+			Enabled:  true,
+			StartsAt: &now,
+			EndsAt:   &now,
+		})
+		assert.ErrorIs(t, err, maintenance.ErrInvalidSchedule)
+	})
+
+	t.Run("audit changes", func(t *testing.T) {
+		_, err := daemon.Maintenance.Update(adminCtx, maintenance.UpdateOptions{
+			Enabled: true,
+			Message: "upgrading database",
+		})
+		require.NoError(t, err)
+		_, err = daemon.Maintenance.Update(adminCtx, maintenance.UpdateOptions{Enabled: false})
+		require.NoError(t, err)
+
+		_, err = daemon.Maintenance.ListChanges(ctx)
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+
+		changes, err := daemon.Maintenance.ListChanges(adminCtx)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(changes), 2)
+		// most recent first
+		assert.True(t, changes[0].OldMode.Enabled)
+		assert.Equal(t, "upgrading database", changes[0].OldMode.Message)
+		assert.False(t, changes[0].NewMode.Enabled)
+		assert.False(t, changes[1].OldMode.Enabled)
+		assert.True(t, changes[1].NewMode.Enabled)
+		assert.NotEmpty(t, changes[0].ChangedBy)
+	})
+
+	t.Run("hold and resume runs", func(t *testing.T) {
+		modes, unsubModes := daemon.Maintenance.Watch(ctx)
+		defer unsubModes()
+
+		mode, err := daemon.Maintenance.Update(adminCtx, maintenance.UpdateOptions{
+			Enabled: true,
+			Message: "upgrading database",
+		})
+		require.NoError(t, err)
+		assert.True(t, mode.Active(internal.CurrentTimestamp(nil)))
+		// wait for maintenance mode event to be published
+		<-modes
+
+		runs, unsubRuns := daemon.Runs.Watch(ctx)
+		defer unsubRuns()
+
+		// run should be held in the pending state
+		r := daemon.createRun(t, ctx, nil, nil)
+		timeout := time.After(2 * time.Second)
+	held:
+		for {
+			select {
+			case event := <-runs:
+				if event.Payload.ID == r.ID {
+					require.Equal(t, run.RunPending, event.Payload.Status)
+				}
+			case <-timeout:
+				break held
+			}
+		}
+
+		// disable maintenance mode and the run should be scheduled
+		_, err = daemon.Maintenance.Update(adminCtx, maintenance.UpdateOptions{Enabled: false})
+		require.NoError(t, err)
+		for event := range runs {
+			if event.Payload.ID == r.ID && event.Payload.Status == run.RunPlanQueued {
+				break
+			}
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package integration
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/github"
+	"github.com/tofutf/tofutf/internal/module"
+	"github.com/tofutf/tofutf/internal/nocode"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/testutils"
+	"github.com/tofutf/tofutf/internal/vcs"
+)
+
+// TestIntegration_ModuleTemplate demonstrates provisioning a workspace from a
+// module template, and upgrading the workspace when the template is updated to
+// a newer module version.
+func TestIntegration_ModuleTemplate(t *testing.T) {
+	integrationTest(t)
+
+	repo := vcs.NewTestModuleRepo("aws", "mod")
+	daemon, org, ctx := setup(t, nil,
+		github.WithRepo(repo),
+		github.WithRefs("tags/v0.0.1", "tags/v0.1.0"),
+		github.WithArchive(testutils.ReadFile(t, "./fixtures/github.module.tar.gz")),
+	)
+	provider := daemon.createVCSProvider(t, ctx, org)
+	mod, err := daemon.Modules.PublishModule(ctx, module.PublishOptions{
+		VCSProviderID: provider.ID,
+		Repo:          module.Repo(repo),
+	})
+	require.NoError(t, err)
+
+	tmpl, err := daemon.Templates.CreateTemplate(ctx, nocode.CreateTemplateOptions{
+		ModuleID: mod.ID,
+		Version:  "0.0.1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []nocode.Variable{{Name: "foo"}}, tmpl.Variables)
+	assert.Equal(t, []nocode.Output{{Name: "foo"}}, tmpl.Outputs)
+
+	t.Run("module can only have one template", func(t *testing.T) {
+		_, err := daemon.Templates.CreateTemplate(ctx, nocode.CreateTemplateOptions{
+			ModuleID: mod.ID,
+			Version:  "0.1.0",
+		})
+		assert.ErrorIs(t, err, internal.ErrResourceAlreadyExists)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := daemon.Templates.UpdateTemplate(ctx, tmpl.ID, nocode.UpdateTemplateOptions{
+			Version: internal.String("9.9.9"),
+		})
+		assert.ErrorIs(t, err, nocode.ErrModuleVersionNotAvailable)
+	})
+
+	// latestConfig retrieves the workspace's latest runs and the contents of
+	// the generated main.tf of the most recent run.
+	latestConfig := func(t *testing.T, workspaceID string) ([]*run.Run, string) {
+		t.Helper()
+
+		runs, err := daemon.Runs.List(ctx, run.ListOptions{WorkspaceID: &workspaceID})
+		require.NoError(t, err)
+		require.NotEmpty(t, runs.Items)
+
+		tarball, err := daemon.Configs.DownloadConfig(ctx, runs.Items[0].ConfigurationVersionID)
+		require.NoError(t, err)
+		dir := t.TempDir()
+		require.NoError(t, internal.Unpack(bytes.NewReader(tarball), dir))
+		config, err := os.ReadFile(filepath.Join(dir, "main.tf"))
+		require.NoError(t, err)
+		return runs.Items, string(config)
+	}
+
+	provisioned, err := daemon.Templates.Provision(ctx, tmpl.ID, nocode.ProvisionOptions{
+		Name:      "provisioned",
+		Variables: map[string]string{"foo": "baz"},
+	})
+	require.NoError(t, err)
+
+	t.Run("workspace is provisioned with a run", func(t *testing.T) {
+		ws, err := daemon.Workspaces.Get(ctx, provisioned.WorkspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, "provisioned", ws.Name)
+		assert.Equal(t, nocode.SourceName, ws.SourceName)
+
+		runs, config := latestConfig(t, provisioned.WorkspaceID)
+		assert.Len(t, runs, 1)
+		assert.Contains(t, config, `version = "0.0.1"`)
+		assert.Contains(t, config, `foo     = "baz"`)
+	})
+
+	t.Run("reject invalid variables before creating workspace", func(t *testing.T) {
+		_, err := daemon.Templates.Provision(ctx, tmpl.ID, nocode.ProvisionOptions{
+			Name:      "invalid",
+			Variables: map[string]string{"bar": "baz"},
+		})
+		assert.ErrorIs(t, err, nocode.ErrUnknownVariable)
+
+		_, err = daemon.Workspaces.GetByName(ctx, org.Name, "invalid")
+		assert.ErrorIs(t, err, internal.ErrResourceNotFound)
+	})
+
+	t.Run("workspace is up to date", func(t *testing.T) {
+		_, err := daemon.Templates.Upgrade(ctx, provisioned.WorkspaceID, nocode.UpgradeOptions{})
+		assert.ErrorIs(t, err, nocode.ErrWorkspaceUpToDate)
+	})
+
+	t.Run("upgrade workspace to newer template version", func(t *testing.T) {
+		_, err := daemon.Templates.UpdateTemplate(ctx, tmpl.ID, nocode.UpdateTemplateOptions{
+			Version: internal.String("0.1.0"),
+		})
+		require.NoError(t, err)
+
+		workspaces, err := daemon.Templates.ListWorkspaces(ctx, tmpl.ID)
+		require.NoError(t, err)
+		require.Len(t, workspaces, 1)
+		assert.Equal(t, "0.0.1", workspaces[0].Version)
+
+		upgraded, err := daemon.Templates.Upgrade(ctx, provisioned.WorkspaceID, nocode.UpgradeOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "0.1.0", upgraded.Version)
+
+		runs, config := latestConfig(t, provisioned.WorkspaceID)
+		assert.Len(t, runs, 2)
+		assert.Contains(t, config, `version = "0.1.0"`)
+		// values are carried over from the previous configuration
+		assert.Contains(t, config, `foo     = "baz"`)
+	})
+
+	t.Run("deleting template retains workspaces", func(t *testing.T) {
+		_, err := daemon.Templates.DeleteTemplate(ctx, tmpl.ID)
+		require.NoError(t, err)
+
+		_, err = daemon.Workspaces.Get(ctx, provisioned.WorkspaceID)
+		assert.NoError(t, err)
+	})
+}
@@ -118,6 +118,65 @@ func TestIntegration_Organization(t *testing.T) {
 		assert.Equal(t, 0, len(got.Items))
 	})
 
+	t.Run("list with search and sort", func(t *testing.T) {
+		svc, _, ctx := setup(t, &config{skipDefaultOrganization: true})
+		prefix := internal.GenerateRandomString(4)
+		for _, name := range []string{"beta", "alpha", "other"} {
+			_, err := svc.Organizations.Create(ctx, organization.CreateOptions{
+				Name: internal.String(prefix + "-" + name),
+			})
+			require.NoError(t, err)
+		}
+
+		got, err := svc.Organizations.List(adminCtx, organization.ListOptions{
+			Search:      prefix + "-",
+			Sort:        "name",
+			PageOptions: resource.PageOptions{PageSize: 2},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(got.Items))
+		assert.Equal(t, prefix+"-alpha", got.Items[0].Name)
+		assert.Equal(t, prefix+"-beta", got.Items[1].Name)
+		assert.Equal(t, 3, got.TotalCount)
+
+		got, err = svc.Organizations.List(adminCtx, organization.ListOptions{
+			Search: prefix + "-",
+			Sort:   "-created-at",
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(got.Items))
+		assert.Equal(t, prefix+"-other", got.Items[0].Name)
+
+		_, err = svc.Organizations.List(adminCtx, organization.ListOptions{Sort: "email"})
+		assert.Error(t, err)
+	})
+
+	t.Run("list organizations for user", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		_ = svc.createOrganization(t, ctx)
+		_ = svc.createOrganization(t, adminCtx) // org not belonging to user
+		user, err := internal.SubjectFromContext(ctx)
+		require.NoError(t, err)
+
+		got, err := svc.Organizations.ListForUser(ctx, user.String(), organization.ListForUserOptions{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(got))
+
+		got, err = svc.Organizations.ListForUser(ctx, user.String(), organization.ListForUserOptions{Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(got))
+
+		got, err = svc.Organizations.ListForUser(ctx, user.String(), organization.ListForUserOptions{Search: org.Name, Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(got))
+		assert.Equal(t, org.Name, got[0].Name)
+
+		// another user cannot list the user's organizations
+		_, otherCtx := svc.createUserCtx(t)
+		_, err = svc.Organizations.ListForUser(otherCtx, user.String(), organization.ListForUserOptions{Limit: 10})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
 	t.Run("get", func(t *testing.T) {
 		svc, _, ctx := setup(t, nil)
 		want := svc.createOrganization(t, ctx)
@@ -0,0 +1,163 @@
+package integration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/daemon"
+	"github.com/tofutf/tofutf/internal/gpgkeys"
+	"github.com/tofutf/tofutf/internal/module"
+	"github.com/tofutf/tofutf/internal/provider"
+	"github.com/tofutf/tofutf/internal/testutils"
+)
+
+// TestIntegration_RegistrySigning demonstrates publishing provider and module
+// versions signed with an organization's signing key, and revoking the key.
+func TestIntegration_RegistrySigning(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, &config{Config: daemon.Config{RequireModuleSignatures: true}})
+
+	entity, err := openpgp.NewEntity("acme", "", "acme@example.com", nil)
+	require.NoError(t, err)
+	var armored bytes.Buffer
+	w, err := armor.Encode(&armored, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	sign := func(message []byte) []byte {
+		var sig bytes.Buffer
+		require.NoError(t, openpgp.DetachSign(&sig, entity, bytes.NewReader(message), nil))
+		return sig.Bytes()
+	}
+
+	key, err := svc.GPGKeys.Create(ctx, gpgkeys.CreateOptions{
+		RegistryName: "private",
+		Organization: org.Name,
+		ASCIIArmor:   armored.String(),
+	})
+	require.NoError(t, err)
+
+	archive := []byte("provider archive")
+	sum := sha256.Sum256(archive)
+	shasums := func(version string) []byte {
+		return []byte(fmt.Sprintf("%s  terraform-provider-null_%s_linux_amd64.zip\n", hex.EncodeToString(sum[:]), version))
+	}
+
+	version, err := svc.Providers.PublishVersion(ctx, provider.PublishVersionOptions{
+		Organization:     org.Name,
+		Name:             "null",
+		Version:          "1.0.0",
+		KeyID:            key.KeyID,
+		Shasums:          shasums("1.0.0"),
+		ShasumsSignature: sign(shasums("1.0.0")),
+	})
+	require.NoError(t, err)
+
+	t.Run("reject archive not matching SHA256SUMS", func(t *testing.T) {
+		_, err := svc.Providers.UploadPlatform(ctx, provider.UploadPlatformOptions{
+			VersionID: version.ID,
+			OS:        "linux",
+			Arch:      "amd64",
+			Archive:   []byte("tampered"),
+		})
+		assert.Error(t, err)
+	})
+
+	_, err = svc.Providers.UploadPlatform(ctx, provider.UploadPlatformOptions{
+		VersionID: version.ID,
+		OS:        "linux",
+		Arch:      "amd64",
+		Archive:   archive,
+	})
+	require.NoError(t, err)
+
+	t.Run("reject SHA256SUMS signed by unregistered key", func(t *testing.T) {
+		other, err := openpgp.NewEntity("other", "", "other@example.com", nil)
+		require.NoError(t, err)
+		var sig bytes.Buffer
+		require.NoError(t, openpgp.DetachSign(&sig, other, bytes.NewReader(shasums("1.1.0")), nil))
+
+		_, err = svc.Providers.PublishVersion(ctx, provider.PublishVersionOptions{
+			Organization:     org.Name,
+			Name:             "null",
+			Version:          "1.1.0",
+			KeyID:            key.KeyID,
+			Shasums:          shasums("1.1.0"),
+			ShasumsSignature: sig.Bytes(),
+		})
+		assert.Error(t, err)
+	})
+
+	// the signing key is served on the download endpoint so that terraform
+	// can verify the package
+	manifest, err := svc.Providers.FindProviderPackage(ctx, provider.FindProviderPackageOptions{
+		Namespace: org.Name,
+		Type:      "null",
+		Version:   "1.0.0",
+		OS:        "linux",
+		Arch:      "amd64",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), manifest.Shasum)
+	require.Len(t, manifest.SigningKeys.GpgPublicKeys, 1)
+	assert.Equal(t, armored.String(), manifest.SigningKeys.GpgPublicKeys[0].ASCIIArmor)
+
+	t.Run("upload signed module version", func(t *testing.T) {
+		mod := svc.createModule(t, ctx, org)
+		tarball := testutils.ReadFile(t, "../module/testdata/module.tar.gz")
+
+		// signatures are required
+		_, err := svc.Modules.UploadVersion(ctx, module.UploadVersionOptions{
+			ModuleID: mod.ID,
+			Version:  "1.0.0",
+			Tarball:  tarball,
+		})
+		assert.Error(t, err)
+
+		modver, err := svc.Modules.UploadVersion(ctx, module.UploadVersionOptions{
+			ModuleID:  mod.ID,
+			Version:   "1.0.0",
+			Tarball:   tarball,
+			Signature: sign(tarball),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, module.ModuleVersionStatusOK, modver.Status)
+	})
+
+	_, err = svc.GPGKeys.Revoke(ctx, gpgkeys.RevokeOptions{
+		RegistryName: "private",
+		Organization: org.Name,
+		KeyID:        key.KeyID,
+	})
+	require.NoError(t, err)
+
+	t.Run("revoked key cannot be used to publish", func(t *testing.T) {
+		_, err := svc.Providers.PublishVersion(ctx, provider.PublishVersionOptions{
+			Organization:     org.Name,
+			Name:             "null",
+			Version:          "1.1.0",
+			KeyID:            key.KeyID,
+			Shasums:          shasums("1.1.0"),
+			ShasumsSignature: sign(shasums("1.1.0")),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("version published with revoked key remains available", func(t *testing.T) {
+		versions, err := svc.Providers.GetProviderVersions(ctx, provider.GetProviderVersionsOptions{
+			Namespace: org.Name,
+			Type:      "null",
+		})
+		require.NoError(t, err)
+		require.Len(t, versions.Versions, 1)
+		assert.Equal(t, "1.0.0", versions.Versions[0].Version)
+	})
+}
@@ -0,0 +1,52 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/daemon"
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+func TestIntegration_RunArtifacts(t *testing.T) {
+	integrationTest(t)
+
+	svc, _, ctx := setup(t, &config{Config: daemon.Config{MaxArtifacts: 2}})
+	r := svc.createRun(t, ctx, nil, nil)
+
+	t.Run("upload", func(t *testing.T) {
+		got, err := svc.Runs.UploadArtifact(ctx, r.ID, "sbom.json", "application/json", []byte(`{"bom":[]}`))
+		require.NoError(t, err)
+		assert.Equal(t, "sbom.json", got.Name)
+		assert.Equal(t, 10, got.Size)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		_, err := svc.Runs.UploadArtifact(ctx, r.ID, "report.txt", "", []byte("first"))
+		require.NoError(t, err)
+		_, err = svc.Runs.UploadArtifact(ctx, r.ID, "report.txt", "text/plain", []byte("second"))
+		require.NoError(t, err)
+
+		got, err := svc.Runs.GetArtifact(ctx, r.ID, "report.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", got.ContentType)
+		assert.Equal(t, []byte("second"), got.Data)
+	})
+
+	t.Run("too many artifacts", func(t *testing.T) {
+		_, err := svc.Runs.UploadArtifact(ctx, r.ID, "kubeconfig", "", []byte("apiVersion: v1"))
+		assert.ErrorIs(t, err, run.ErrTooManyArtifacts)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		got, err := svc.Runs.ListArtifacts(ctx, r.ID)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "report.txt", got[0].Name)
+		assert.Equal(t, "sbom.json", got[1].Name)
+		// data is omitted from listings
+		assert.Nil(t, got[0].Data)
+	})
+
+}
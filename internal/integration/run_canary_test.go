@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+// TestIntegration_RunCanary demonstrates a canary apply: applying a subset of
+// a planned run's changes via a targeted child run, and then proceeding to a
+// full apply of the parent run with a freshly generated plan.
+func TestIntegration_RunCanary(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+	ws := daemon.createWorkspace(t, ctx, org)
+
+	root := t.TempDir()
+	config := `
+resource "null_resource" "canary" {}
+resource "null_resource" "rest" {}
+`
+	err := os.WriteFile(filepath.Join(root, "main.tf"), []byte(config), 0o777)
+	require.NoError(t, err)
+	tarball, err := internal.Pack(root)
+	require.NoError(t, err)
+	cv := daemon.createConfigurationVersion(t, ctx, ws, nil)
+	err = daemon.Configs.UploadConfig(ctx, cv.ID, tarball)
+	require.NoError(t, err)
+
+	sub, unsub := daemon.Runs.Watch(ctx)
+	defer unsub()
+	parent := daemon.createRun(t, ctx, ws, cv)
+
+	// wait for run to reach the given status
+	wait := func(runID string, want run.Status) {
+		t.Helper()
+		for event := range sub {
+			r := event.Payload
+			if r.ID != runID {
+				continue
+			}
+			if r.Status == want {
+				return
+			}
+			require.False(t, r.Done(), "run %s unexpectedly finished with status %s", r.ID, r.Status)
+		}
+	}
+	wait(parent.ID, run.RunPlanned)
+
+	changes, err := daemon.Runs.PlannedChanges(ctx, parent.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	t.Run("cannot select address not in plan", func(t *testing.T) {
+		_, err := daemon.Runs.CreateCanaryRun(ctx, parent.ID, []string{"null_resource.missing"})
+		assert.ErrorIs(t, err, run.ErrCanaryAddrNotInPlan)
+	})
+
+	t.Run("cannot select all changes", func(t *testing.T) {
+		_, err := daemon.Runs.CreateCanaryRun(ctx, parent.ID, []string{"null_resource.canary", "null_resource.rest"})
+		assert.ErrorIs(t, err, run.ErrCanaryIncludesAllChanges)
+	})
+
+	canary, err := daemon.Runs.CreateCanaryRun(ctx, parent.ID, []string{"null_resource.canary"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"null_resource.canary"}, canary.TargetAddrs)
+	assert.Equal(t, &parent.ID, canary.ParentRunID)
+
+	// parent is discarded to make way for its canary
+	got, err := daemon.Runs.Get(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, run.RunDiscarded, got.Status)
+
+	t.Run("cannot proceed before canary has applied", func(t *testing.T) {
+		_, err := daemon.Runs.ProceedAfterCanary(ctx, parent.ID)
+		assert.ErrorIs(t, err, run.ErrCanaryNotApplied)
+	})
+
+	wait(canary.ID, run.RunPlanned)
+	err = daemon.Runs.Apply(ctx, canary.ID)
+	require.NoError(t, err)
+	wait(canary.ID, run.RunApplied)
+
+	full, err := daemon.Runs.ProceedAfterCanary(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &parent.ID, full.ParentRunID)
+	assert.Empty(t, full.TargetAddrs)
+
+	// the full run only has the remaining change left to apply
+	wait(full.ID, run.RunPlanned)
+	changes, err = daemon.Runs.PlannedChanges(ctx, full.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "null_resource.rest", changes[0].Address)
+
+	// relationship is visible from the parent
+	relations, err := daemon.Runs.GetCanary(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, canary.ID, relations.Canary.ID)
+	assert.Equal(t, full.ID, relations.Full.ID)
+
+	t.Run("cannot proceed more than once", func(t *testing.T) {
+		_, err := daemon.Runs.ProceedAfterCanary(ctx, parent.ID)
+		assert.ErrorIs(t, err, run.ErrCanaryAlreadyProceeded)
+	})
+}
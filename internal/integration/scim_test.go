@@ -0,0 +1,224 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/scim"
+	"github.com/tofutf/tofutf/internal/user"
+)
+
+// TestIntegration_SCIM demonstrates an identity provider provisioning users and
+// teams via SCIM.
+func TestIntegration_SCIM(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	t.Run("only site admin can create token", func(t *testing.T) {
+		_, _, err := daemon.SCIM.CreateToken(ctx, org.Name)
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
+	_, token, err := daemon.SCIM.CreateToken(adminCtx, org.Name)
+	require.NoError(t, err)
+
+	// scimRequest sends a SCIM request and decodes the response into a map.
+	baseURL := fmt.Sprintf("https://%s%s/organizations/%s", daemon.System.Hostname(), scim.BasePath, org.Name)
+	scimRequest := func(t *testing.T, method, path string, body any) (int, map[string]any) {
+		t.Helper()
+
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		r, err := http.NewRequest(method, baseURL+path, &buf)
+		require.NoError(t, err)
+		r.Header.Add("Authorization", "Bearer "+string(token))
+		r.Header.Add("Content-Type", "application/scim+json")
+
+		resp, err := http.DefaultClient.Do(r)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		got := make(map[string]any)
+		if len(b) > 0 {
+			require.NoError(t, json.Unmarshal(b, &got), string(b))
+		}
+		return resp.StatusCode, got
+	}
+
+	var aliceID string
+	t.Run("create user", func(t *testing.T) {
+		status, got := scimRequest(t, "POST", "/Users", map[string]any{
+			"schemas":    []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
+			"userName":   "alice",
+			"externalId": "alice-ext",
+			"active":     true,
+			"emails":     []map[string]any{{"value": "Alice@example.com", "primary": true}},
+		})
+		require.Equal(t, http.StatusCreated, status, got)
+		assert.Equal(t, "alice", got["userName"])
+		aliceID = got["id"].(string)
+	})
+
+	t.Run("create user with existing external ID", func(t *testing.T) {
+		status, got := scimRequest(t, "POST", "/Users", map[string]any{
+			"userName":   "alice2",
+			"externalId": "alice-ext",
+		})
+		assert.Equal(t, http.StatusConflict, status)
+		assert.Equal(t, "uniqueness", got["scimType"])
+	})
+
+	t.Run("filter users by userName", func(t *testing.T) {
+		status, got := scimRequest(t, "GET", `/Users?filter=userName+eq+%22alice%22`, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(1), got["totalResults"])
+
+		status, got = scimRequest(t, "GET", `/Users?filter=userName+eq+%22nobody%22`, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(0), got["totalResults"])
+	})
+
+	t.Run("filter users by externalId", func(t *testing.T) {
+		status, got := scimRequest(t, "GET", `/Users?filter=externalId+eq+%22alice-ext%22`, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(1), got["totalResults"])
+	})
+
+	t.Run("merge with existing user by verified email", func(t *testing.T) {
+		// user previously logged in via OIDC
+		existing, err := daemon.Users.LinkVerifiedEmail(adminCtx, "bob-oidc", "bob@example.com")
+		require.NoError(t, err)
+
+		status, got := scimRequest(t, "POST", "/Users", map[string]any{
+			"userName":   "bob@example.com",
+			"externalId": "bob-ext",
+			"emails":     []map[string]any{{"value": "bob@example.com", "primary": true}},
+		})
+		require.Equal(t, http.StatusCreated, status, got)
+		assert.Equal(t, existing.ID, got["id"])
+		assert.Equal(t, "bob@example.com", got["userName"])
+
+		// subsequent logins via OIDC use the merged user
+		linked, err := daemon.Users.LinkVerifiedEmail(adminCtx, "bob-oidc", "BOB@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, linked.ID)
+		assert.Equal(t, "bob@example.com", linked.Username)
+	})
+
+	var groupID string
+	t.Run("create group", func(t *testing.T) {
+		status, got := scimRequest(t, "POST", "/Groups", map[string]any{
+			"displayName": "engineers",
+			"externalId":  "engineers-ext",
+			"members":     []map[string]any{{"value": aliceID}},
+		})
+		require.Equal(t, http.StatusCreated, status, got)
+		groupID = got["id"].(string)
+
+		engineers, err := daemon.Teams.Get(adminCtx, org.Name, "engineers")
+		require.NoError(t, err)
+		assert.Equal(t, groupID, engineers.ID)
+		alice, err := daemon.Users.GetUser(adminCtx, user.UserSpec{UserID: &aliceID})
+		require.NoError(t, err)
+		assert.True(t, alice.IsTeamMember(groupID))
+	})
+
+	t.Run("filter groups by displayName", func(t *testing.T) {
+		status, got := scimRequest(t, "GET", `/Groups?filter=displayName+eq+%22engineers%22`, nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(1), got["totalResults"])
+	})
+
+	t.Run("patch group members", func(t *testing.T) {
+		status, got := scimRequest(t, "PATCH", "/Groups/"+groupID, map[string]any{
+			"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+			"Operations": []map[string]any{
+				{"op": "Remove", "path": fmt.Sprintf(`members[value eq "%s"]`, aliceID)},
+			},
+		})
+		require.Equal(t, http.StatusOK, status, got)
+		assert.Empty(t, got["members"])
+
+		status, got = scimRequest(t, "PATCH", "/Groups/"+groupID, map[string]any{
+			"Operations": []map[string]any{
+				{"op": "Add", "path": "members", "value": []map[string]any{{"value": aliceID}}},
+			},
+		})
+		require.Equal(t, http.StatusOK, status, got)
+		assert.Len(t, got["members"], 1)
+	})
+
+	t.Run("deactivate user", func(t *testing.T) {
+		status, got := scimRequest(t, "PATCH", "/Users/"+aliceID, map[string]any{
+			"Operations": []map[string]any{
+				{"op": "Replace", "value": map[string]any{"active": "False"}},
+			},
+		})
+		require.Equal(t, http.StatusOK, status, got)
+		assert.Equal(t, false, got["active"])
+
+		alice, err := daemon.Users.GetUser(adminCtx, user.UserSpec{UserID: &aliceID})
+		require.NoError(t, err)
+		assert.True(t, alice.Deactivated)
+		assert.Empty(t, alice.Teams)
+
+		// alice can no longer authenticate
+		_, err = daemon.Tokens.GetOrCreateUISubject(adminCtx, "alice")
+		assert.ErrorIs(t, err, user.ErrUserDeactivated)
+	})
+
+	t.Run("delete user deactivates user", func(t *testing.T) {
+		bob, err := daemon.Users.GetUser(adminCtx, user.UserSpec{Username: internal.String("bob@example.com")})
+		require.NoError(t, err)
+
+		status, _ := scimRequest(t, "DELETE", "/Users/"+bob.ID, nil)
+		require.Equal(t, http.StatusNoContent, status)
+
+		bob, err = daemon.Users.GetUser(adminCtx, user.UserSpec{UserID: &bob.ID})
+		require.NoError(t, err)
+		assert.True(t, bob.Deactivated)
+	})
+
+	t.Run("cannot manage users of another organization", func(t *testing.T) {
+		other := daemon.createOrganization(t, ctx)
+		outsider := daemon.createUser(t, user.WithTeams(daemon.createTeam(t, ctx, other)))
+
+		status, _ := scimRequest(t, "DELETE", "/Users/"+outsider.ID, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+
+		status, _ = scimRequest(t, "GET", "/Users/"+outsider.ID, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+
+		status, got := scimRequest(t, "GET", fmt.Sprintf(`/Users?filter=userName+eq+%%22%s%%22`, outsider.Username), nil)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, float64(0), got["totalResults"])
+
+		outsider, err := daemon.Users.GetUser(adminCtx, user.UserSpec{UserID: &outsider.ID})
+		require.NoError(t, err)
+		assert.False(t, outsider.Deactivated)
+	})
+
+	t.Run("token is confined to its organization", func(t *testing.T) {
+		other := daemon.createOrganization(t, ctx)
+		u := fmt.Sprintf("https://%s%s/organizations/%s/Users", daemon.System.Hostname(), scim.BasePath, other.Name)
+		r, err := http.NewRequest("GET", u, nil)
+		require.NoError(t, err)
+		r.Header.Add("Authorization", "Bearer "+string(token))
+		resp, err := http.DefaultClient.Do(r)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
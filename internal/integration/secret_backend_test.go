@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/daemon"
+	"github.com/tofutf/tofutf/internal/secret/vault"
+	"github.com/tofutf/tofutf/internal/variable"
+)
+
+// TestIntegration_SecretBackend demonstrates a variable referencing a secret
+// in vault, the value of which is resolved for the effective variables of a
+// run.
+func TestIntegration_SecretBackend(t *testing.T) {
+	integrationTest(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/approle/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"auth":{"client_token":"vault-token","lease_duration":3600}}`))
+	})
+	mux.HandleFunc("/v1/secret/data/db", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"data":{"password":"hunter2"},"metadata":{"version":1}}}`))
+	})
+	vaultServer := httptest.NewServer(mux)
+	t.Cleanup(vaultServer.Close)
+
+	svc, _, ctx := setup(t, &config{Config: daemon.Config{
+		Vault: vault.Config{
+			Address:    vaultServer.URL,
+			AuthMethod: vault.AppRoleAuthMethod,
+			RoleID:     "role-id",
+			SecretID:   "secret-id",
+		},
+	}})
+	ws := svc.createWorkspace(t, ctx, nil)
+	_, err := svc.Variables.CreateWorkspaceVariable(ctx, ws.ID, variable.CreateVariableOptions{
+		Key:      internal.String("db_password"),
+		Value:    internal.String("vault:secret/data/db#password"),
+		Category: variable.VariableCategoryPtr(variable.CategoryTerraform),
+	})
+	require.NoError(t, err)
+	r := svc.createRun(t, ctx, ws, nil)
+
+	got, err := svc.Variables.ListEffectiveVariables(ctx, r.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "hunter2", got[0].Value)
+	assert.True(t, got[0].Sensitive)
+
+	// the resolved value is never persisted
+	stored, err := svc.Variables.ListWorkspaceVariables(ctx, ws.ID)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "vault:secret/data/db#password", stored[0].Value)
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := svc.Variables.CreateWorkspaceVariable(ctx, ws.ID, variable.CreateVariableOptions{
+			Key:      internal.String("api_key"),
+			Value:    internal.String("vault:secret/data/api#key"),
+			Category: variable.VariableCategoryPtr(variable.CategoryEnv),
+		})
+		require.NoError(t, err)
+
+		_, err = svc.Variables.ListEffectiveVariables(ctx, r.ID)
+		assert.ErrorIs(t, err, variable.ErrSecretUnavailable)
+		assert.ErrorContains(t, err, "variable api_key references vault secret secret/data/api")
+	})
+}
@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/releases"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+func TestIntegration_TerraformVersionPolicy(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil)
+
+	// workspace created before the policy exists
+	legacy, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:             internal.String("legacy"),
+		Organization:     &org.Name,
+		TerraformVersion: internal.String("1.2.0"),
+	})
+	require.NoError(t, err)
+
+	t.Run("only site admin can update", func(t *testing.T) {
+		_, err := daemon.Releases.UpdateVersionPolicy(ctx, releases.UpdateVersionPolicyOptions{MinVersion: "1.3.0"})
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+
+	t.Run("default must be permitted", func(t *testing.T) {
+		_, err := daemon.Releases.UpdateVersionPolicy(adminCtx, releases.UpdateVersionPolicyOptions{
+			MinVersion:     "1.3.0",
+			DefaultVersion: "1.2.0",
+		})
+		assert.ErrorIs(t, err, releases.ErrInvalidVersionPolicy)
+	})
+
+	_, err = daemon.Releases.UpdateVersionPolicy(adminCtx, releases.UpdateVersionPolicyOptions{
+		MinVersion:     "1.3.0",
+		MaxVersion:     "1.6.0",
+		DefaultVersion: "1.5.7",
+	})
+	require.NoError(t, err)
+
+	t.Run("new workspace uses configured default", func(t *testing.T) {
+		ws := daemon.createWorkspace(t, ctx, org)
+		assert.Equal(t, "1.5.7", ws.TerraformVersion)
+	})
+
+	t.Run("create workspace with forbidden version", func(t *testing.T) {
+		_, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:             internal.String("too-old"),
+			Organization:     &org.Name,
+			TerraformVersion: internal.String("1.2.9"),
+		})
+		assert.ErrorIs(t, err, releases.ErrForbiddenTerraformVersion)
+	})
+
+	t.Run("update workspace to forbidden version", func(t *testing.T) {
+		ws := daemon.createWorkspace(t, ctx, org)
+		_, err := daemon.Workspaces.Update(ctx, ws.ID, workspace.UpdateOptions{
+			TerraformVersion: internal.String("1.7.0"),
+		})
+		assert.ErrorIs(t, err, releases.ErrForbiddenTerraformVersion)
+	})
+
+	t.Run("update other settings on workspace using forbidden version", func(t *testing.T) {
+		_, err := daemon.Workspaces.Update(ctx, legacy.ID, workspace.UpdateOptions{
+			TerraformVersion: internal.String("1.2.0"),
+			Description:      internal.String("pre-dates policy"),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("refuse run on workspace using forbidden version", func(t *testing.T) {
+		cv := daemon.createConfigurationVersion(t, ctx, legacy, nil)
+		_, err := daemon.Runs.Create(ctx, legacy.ID, run.CreateOptions{
+			ConfigurationVersionID: internal.String(cv.ID),
+		})
+		assert.ErrorIs(t, err, releases.ErrForbiddenTerraformVersion)
+	})
+
+	t.Run("refuse run overriding workspace version with forbidden version", func(t *testing.T) {
+		ws := daemon.createWorkspace(t, ctx, org)
+		cv := daemon.createConfigurationVersion(t, ctx, ws, nil)
+		_, err := daemon.Runs.Create(ctx, ws.ID, run.CreateOptions{
+			ConfigurationVersionID: internal.String(cv.ID),
+			TerraformVersion:       internal.String("1.2.0"),
+		})
+		assert.ErrorIs(t, err, releases.ErrForbiddenTerraformVersion)
+	})
+}
@@ -197,6 +197,26 @@ func TestUser(t *testing.T) {
 		assert.NotContains(t, got.Teams, team)
 	})
 
+	t.Run("set team membership", func(t *testing.T) {
+		svc, org, ctx := setup(t, nil)
+		team := svc.createTeam(t, ctx, org)
+		stays := svc.createUser(t, otfuser.WithTeams(team))
+		leaves := svc.createUser(t, otfuser.WithTeams(team))
+		joins := svc.createUser(t)
+
+		err := svc.Users.SetTeamMembership(ctx, team.ID, []string{stays.Username, joins.Username, "new-kid"})
+		require.NoError(t, err)
+
+		members, err := svc.Users.ListTeamUsers(ctx, team.ID)
+		require.NoError(t, err)
+		usernames := make([]string, len(members))
+		for i, m := range members {
+			usernames[i] = m.Username
+		}
+		assert.ElementsMatch(t, []string{stays.Username, joins.Username, "new-kid"}, usernames)
+		assert.NotContains(t, usernames, leaves.Username)
+	})
+
 	t.Run("cannot remove last owner", func(t *testing.T) {
 		// automatically creates org and owners team
 		svc, org, ctx := setup(t, nil)
@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/github"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/repohooks"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/testutils"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// TestWebhookDelivery demonstrates the recording of webhook deliveries and
+// their subsequent redelivery.
+func TestWebhookDelivery(t *testing.T) {
+	integrationTest(t)
+
+	daemon, org, ctx := setup(t, nil,
+		github.WithRepo("leg100/tfc-workspaces"),
+		github.WithCommit("0335fb07bb0244b7a169ee89d15c7703e4aaf7de"),
+		github.WithArchive(testutils.ReadFile(t, "../testdata/github.tar.gz")),
+	)
+	provider := daemon.createVCSProvider(t, ctx, org)
+	_, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:         internal.String("dev"),
+		Organization: internal.String(org.Name),
+		ConnectOptions: &workspace.ConnectOptions{
+			VCSProviderID: &provider.ID,
+			RepoPath:      internal.String("leg100/tfc-workspaces"),
+		},
+	})
+	require.NoError(t, err)
+
+	runsSub, unsub := daemon.Runs.Watch(ctx)
+	defer unsub()
+
+	// push event should trigger a run and be recorded as a delivery
+	push := testutils.ReadFile(t, "fixtures/github_push.json")
+	daemon.SendEvent(t, github.PushEvent, push)
+	testutils.Wait(t, runsSub, func(event pubsub.Event[*run.Run]) bool {
+		return event.Type == pubsub.CreatedEvent
+	})
+
+	deliveries, err := daemon.RepoHooks.ListDeliveries(ctx, provider.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	original := deliveries[0]
+	assert.Equal(t, repohooks.DeliveryPublished, original.Status)
+	assert.True(t, original.Replayable())
+
+	// redelivering the event should trigger another run
+	replay, err := daemon.RepoHooks.Redeliver(ctx, original.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repohooks.DeliveryPublished, replay.Status)
+	assert.Equal(t, &original.ID, replay.ReplayOf)
+	testutils.Wait(t, runsSub, func(event pubsub.Event[*run.Run]) bool {
+		return event.Type == pubsub.CreatedEvent
+	})
+
+	deliveries, err = daemon.RepoHooks.ListDeliveries(ctx, provider.ID)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 2)
+}
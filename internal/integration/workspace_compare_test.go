@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/DataDog/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/compare"
+	"github.com/tofutf/tofutf/internal/variable"
+)
+
+// TestIntegration_WorkspaceCompare demonstrates comparing the settings of two
+// workspaces via the API.
+func TestIntegration_WorkspaceCompare(t *testing.T) {
+	integrationTest(t)
+
+	svc, org, ctx := setup(t, nil)
+	staging := svc.createWorkspace(t, ctx, org)
+	prod := svc.createWorkspace(t, ctx, org)
+	for ws, secret := range map[string]string{staging.ID: "staging-secret", prod.ID: "prod-secret"} {
+		_, err := svc.Variables.CreateWorkspaceVariable(ctx, ws, variable.CreateVariableOptions{
+			Key:       internal.String("password"),
+			Value:     internal.String(secret),
+			Category:  variable.VariableCategoryPtr(variable.CategoryTerraform),
+			Sensitive: internal.Bool(true),
+		})
+		require.NoError(t, err)
+	}
+	_, token := svc.createToken(t, ctx, nil)
+
+	u := fmt.Sprintf("https://%s/otfapi/workspaces/compare?left=%s&right=%s", svc.System.Hostname(), staging.ID, prod.ID)
+	r, err := http.NewRequest("GET", u, nil)
+	require.NoError(t, err)
+	r.Header.Add("Authorization", "Bearer "+string(token))
+
+	resp, err := http.DefaultClient.Do(r)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode, string(b))
+
+	// sensitive values must not be leaked
+	assert.NotContains(t, string(b), "staging-secret")
+	assert.NotContains(t, string(b), "prod-secret")
+
+	var got compare.Comparison
+	require.NoError(t, jsonapi.Unmarshal(b, &got))
+	assert.Equal(t, staging.Name, got.Left.Name)
+	assert.Equal(t, prod.Name, got.Right.Name)
+	if assert.Len(t, got.Variables, 1) {
+		// both sensitive values are set, so they are considered identical
+		assert.False(t, got.Variables[0].Differs)
+	}
+
+	t.Run("requires access to both workspaces", func(t *testing.T) {
+		_, userCtx := svc.createUserCtx(t)
+		_, err := svc.Compare.Compare(userCtx, staging.ID, prod.ID)
+		assert.ErrorIs(t, err, internal.ErrAccessNotPermitted)
+	})
+}
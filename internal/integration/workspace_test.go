@@ -9,6 +9,7 @@ import (
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/connections"
 	"github.com/tofutf/tofutf/internal/github"
+	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/pubsub"
 	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/resource"
@@ -428,4 +429,115 @@ func TestWorkspace(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, 0, len(results.Items))
 	})
+
+	t.Run("delete protected workspace", func(t *testing.T) {
+		daemon, org, ctx := setup(t, nil)
+
+		ws, err := daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:              internal.String(uuid.NewString()),
+			Organization:      internal.String(org.Name),
+			DeletionProtected: internal.Bool(true),
+		})
+		require.NoError(t, err)
+		assert.True(t, ws.DeletionProtected)
+
+		_, err = daemon.Workspaces.Delete(ctx, ws.ID)
+		assert.ErrorIs(t, err, workspace.ErrWorkspaceDeletionProtected)
+
+		// clear protection and then delete
+		_, err = daemon.Workspaces.Update(ctx, ws.ID, workspace.UpdateOptions{
+			DeletionProtected: internal.Bool(false),
+		})
+		require.NoError(t, err)
+
+		// clearing protection is recorded in the workspace's activity
+		events, err := daemon.Workspaces.ListEvents(ctx, ws.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, workspace.DeletionProtectionClearedEvent, events[0].Kind)
+		assert.NotEmpty(t, events[0].Subject)
+
+		_, err = daemon.Workspaces.Delete(ctx, ws.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("inherit organization default deletion protection", func(t *testing.T) {
+		daemon, org, ctx := setup(t, nil)
+
+		_, err := daemon.Organizations.Update(ctx, org.Name, organization.UpdateOptions{
+			DefaultDeletionProtection: internal.Bool(true),
+		})
+		require.NoError(t, err)
+
+		ws := daemon.createWorkspace(t, ctx, org)
+		assert.True(t, ws.DeletionProtected)
+	})
+
+	t.Run("workspace name case", func(t *testing.T) {
+		daemon, _, ctx := setup(t, nil)
+
+		createOrg := func(t *testing.T, nameCase organization.WorkspaceNameCase) *organization.Organization {
+			org, err := daemon.Organizations.Create(ctx, organization.CreateOptions{
+				Name:              internal.String(uuid.NewString()),
+				WorkspaceNameCase: &nameCase,
+			})
+			require.NoError(t, err)
+			return org
+		}
+		create := func(org *organization.Organization, name string) (*workspace.Workspace, error) {
+			return daemon.Workspaces.Create(ctx, workspace.CreateOptions{
+				Name:         internal.String(name),
+				Organization: internal.String(org.Name),
+			})
+		}
+
+		t.Run("sensitive permits names differing only in case", func(t *testing.T) {
+			org := createOrg(t, organization.CaseSensitiveWorkspaceNames)
+
+			_, err := create(org, "Prod")
+			require.NoError(t, err)
+			_, err = create(org, "prod")
+			require.NoError(t, err)
+		})
+
+		t.Run("lowercase rejects uppercase names", func(t *testing.T) {
+			org := createOrg(t, organization.LowercaseWorkspaceNames)
+
+			_, err := create(org, "Prod")
+			assert.ErrorIs(t, err, internal.ErrUppercaseName)
+
+			ws, err := create(org, "prod")
+			require.NoError(t, err)
+
+			_, err = daemon.Workspaces.Update(ctx, ws.ID, workspace.UpdateOptions{
+				Name: internal.String("Prod"),
+			})
+			assert.ErrorIs(t, err, internal.ErrUppercaseName)
+		})
+
+		t.Run("insensitive rejects names differing only in case", func(t *testing.T) {
+			org := createOrg(t, organization.CaseInsensitiveWorkspaceNames)
+
+			_, err := create(org, "Prod")
+			require.NoError(t, err)
+
+			_, err = create(org, "prod")
+			assert.ErrorIs(t, err, internal.ErrResourceAlreadyExists)
+
+			dev, err := create(org, "dev")
+			require.NoError(t, err)
+
+			_, err = daemon.Workspaces.Update(ctx, dev.ID, workspace.UpdateOptions{
+				Name: internal.String("PROD"),
+			})
+			assert.ErrorIs(t, err, internal.ErrResourceAlreadyExists)
+
+			// renaming a workspace to a different case of its own name is
+			// permitted
+			_, err = daemon.Workspaces.Update(ctx, dev.ID, workspace.UpdateOptions{
+				Name: internal.String("Dev"),
+			})
+			require.NoError(t, err)
+		})
+	})
 }
@@ -0,0 +1,83 @@
+package lockout
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is the lockout database on postgres
+	pgdb struct {
+		*sql.Pool // provides access to generated SQL queries
+	}
+
+	// row represents the database row for a lockout
+	row struct {
+		LockoutKey  pgtype.Text        `json:"lockout_key"`
+		Kind        pgtype.Text        `json:"kind"`
+		Value       pgtype.Text        `json:"value"`
+		Failures    pgtype.Int4        `json:"failures"`
+		CreatedAt   pgtype.Timestamptz `json:"created_at"`
+		LockedUntil pgtype.Timestamptz `json:"locked_until"`
+	}
+)
+
+func (r row) toLockout() *Lockout {
+	return &Lockout{
+		Kind:        Kind(r.Kind.String),
+		Value:       r.Value.String,
+		Failures:    int(r.Failures.Int32),
+		CreatedAt:   r.CreatedAt.Time.UTC(),
+		LockedUntil: r.LockedUntil.Time.UTC(),
+	}
+}
+
+func (db *pgdb) upsert(ctx context.Context, lockout *Lockout) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertAuthLockout(ctx, pggen.UpsertAuthLockoutParams{
+			LockoutKey:  sql.String(lockout.Key()),
+			Kind:        sql.String(string(lockout.Kind)),
+			Value:       sql.String(lockout.Value),
+			Failures:    sql.Int4(lockout.Failures),
+			CreatedAt:   sql.Timestamptz(lockout.CreatedAt),
+			LockedUntil: sql.Timestamptz(lockout.LockedUntil),
+		})
+		return err
+	})
+}
+
+func (db *pgdb) get(ctx context.Context, key string) (*Lockout, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Lockout, error) {
+		result, err := q.FindAuthLockout(ctx, sql.String(key))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return row(result).toLockout(), nil
+	})
+}
+
+// listActive lists the lockouts in effect at the given time.
+func (db *pgdb) listActive(ctx context.Context, now time.Time) ([]*Lockout, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Lockout, error) {
+		rows, err := q.FindActiveAuthLockouts(ctx, sql.Timestamptz(now))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		lockouts := make([]*Lockout, len(rows))
+		for i, r := range rows {
+			lockouts[i] = row(r).toLockout()
+		}
+		return lockouts, nil
+	})
+}
+
+func (db *pgdb) delete(ctx context.Context, key string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteAuthLockout(ctx, sql.String(key))
+		return sql.Error(err)
+	})
+}
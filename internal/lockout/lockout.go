@@ -0,0 +1,136 @@
+// Package lockout protects authentication against brute-force attacks, by
+// delaying and then temporarily locking out clients that repeatedly fail to
+// authenticate.
+package lockout
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultThreshold is the default number of consecutive failed
+	// authentication attempts after which a client is locked out.
+	DefaultThreshold = 10
+	// DefaultDuration is the default duration of a lockout.
+	DefaultDuration = 15 * time.Minute
+
+	// freeFailures is the number of consecutive failed attempts permitted
+	// before the client is first delayed.
+	freeFailures = 3
+	// minDelay is the delay imposed after the first delayed failure, which
+	// doubles with each further failure up to maxDelay.
+	minDelay = time.Second
+	maxDelay = 30 * time.Second
+)
+
+// Kind is the kind of client that is locked out.
+type Kind string
+
+const (
+	// IPKind identifies a client by its IP address.
+	IPKind Kind = "ip"
+	// UsernameKind identifies a client by the username with which it
+	// attempts to login.
+	UsernameKind Kind = "username"
+)
+
+type (
+	// Lockout prevents a client from authenticating until it expires, or is
+	// cleared by a site admin.
+	Lockout struct {
+		Kind  Kind
+		Value string
+		// Failures is the number of consecutive failed attempts that
+		// triggered the lockout.
+		Failures    int
+		CreatedAt   time.Time
+		LockedUntil time.Time
+	}
+
+	// Attempt is an attempt by a client to authenticate.
+	Attempt struct {
+		// IP address of the client.
+		IP string
+		// Username the client is attempting to login as. Empty if the
+		// attempt is not made with a username, e.g. with an API token, in
+		// which case the client is only identified by its IP address.
+		Username string
+	}
+
+	// LockedError is returned when a client attempting to authenticate is
+	// either delayed or locked out.
+	LockedError struct {
+		// RetryAfter is the duration the client must wait before retrying.
+		RetryAfter time.Duration
+		// Lockout is non-nil if the client is locked out rather than
+		// delayed.
+		Lockout *Lockout
+	}
+)
+
+// Key uniquely identifies the client that is locked out.
+func (l *Lockout) Key() string { return newKey(l.Kind, l.Value) }
+
+// Active determines whether the lockout is in effect at the given time.
+func (l *Lockout) Active(now time.Time) bool { return now.Before(l.LockedUntil) }
+
+func (l *Lockout) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("kind", string(l.Kind)),
+		slog.String("value", l.Value),
+		slog.Int("failures", l.Failures),
+		slog.Time("locked_until", l.LockedUntil),
+	)
+}
+
+// keys returns the keys of the clients making the attempt.
+func (a Attempt) keys() []string {
+	keys := []string{newKey(IPKind, a.IP)}
+	if a.Username != "" {
+		keys = append(keys, newKey(UsernameKind, a.Username))
+	}
+	return keys
+}
+
+func (e *LockedError) Error() string {
+	if e.Lockout != nil {
+		return fmt.Sprintf("too many failed authentication attempts: locked out until %s", e.Lockout.LockedUntil.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("too many failed authentication attempts: retry after %s", e.RetryAfter)
+}
+
+// RetryAfterSeconds is the value of the Retry-After header to send to the
+// client, rounding up to the nearest second.
+func (e *LockedError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+func newKey(kind Kind, value string) string {
+	return string(kind) + ":" + value
+}
+
+// parseKey parses a key into the kind and value of the client.
+func parseKey(key string) (Kind, string, error) {
+	kind, value, ok := strings.Cut(key, ":")
+	if !ok || value == "" || (Kind(kind) != IPKind && Kind(kind) != UsernameKind) {
+		return "", "", fmt.Errorf("malformed lockout key: %s", key)
+	}
+	return Kind(kind), value, nil
+}
+
+// delay returns the delay imposed upon a client after the given number of
+// consecutive failed attempts.
+func delay(failures int) time.Duration {
+	if failures <= freeFailures {
+		return 0
+	}
+	n := failures - freeFailures - 1
+	if n >= 16 {
+		return maxDelay
+	}
+	return min(minDelay<<n, maxDelay)
+}
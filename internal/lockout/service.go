@@ -0,0 +1,211 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	otfhttp "github.com/tofutf/tofutf/internal/http"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+type (
+	Service struct {
+		logger *slog.Logger
+
+		site    internal.Authorizer
+		db      *pgdb
+		tfeapi  *tfe
+		broker  *pubsub.Broker[*Lockout]
+		tracker *tracker
+
+		// trustedProxies are the networks of the proxies whose
+		// X-Forwarded-For header is trusted to determine a client's IP
+		// address.
+		trustedProxies []*net.IPNet
+
+		// loaded is true once the cache of lockouts has been populated from
+		// the database.
+		loaded bool
+		mu     sync.Mutex
+	}
+
+	Options struct {
+		Logger *slog.Logger
+		// Threshold is the number of consecutive failed authentication
+		// attempts after which a client is locked out. Zero disables
+		// lockouts, although failed attempts are still delayed.
+		Threshold int
+		// Duration of a lockout. Defaults to DefaultDuration.
+		Duration time.Duration
+		// TrustedProxies are the networks of reverse proxies in front of
+		// tofutf, whose X-Forwarded-For header is trusted to determine a
+		// client's IP address.
+		TrustedProxies []*net.IPNet
+
+		*sql.Pool
+		*sql.Listener
+		*tfeapi.Responder
+	}
+)
+
+func NewService(opts Options) *Service {
+	if opts.Duration <= 0 {
+		opts.Duration = DefaultDuration
+	}
+	svc := Service{
+		logger:         opts.Logger.With("component", "lockout"),
+		site:           &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:             &pgdb{opts.Pool},
+		tracker:        newTracker(opts.Threshold, opts.Duration),
+		trustedProxies: opts.TrustedProxies,
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	// keep the cache of lockouts up to date with lockouts imposed and
+	// cleared on all nodes
+	svc.broker = pubsub.NewBroker(
+		opts.Logger,
+		opts.Listener,
+		"auth_lockouts",
+		func(ctx context.Context, key string, action sql.Action) (*Lockout, error) {
+			if action == sql.DeleteAction {
+				kind, value, err := parseKey(key)
+				if err != nil {
+					return nil, err
+				}
+				svc.tracker.unlock(key)
+				return &Lockout{Kind: kind, Value: value}, nil
+			}
+			lockout, err := svc.db.get(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			svc.tracker.lock(lockout)
+			return lockout, nil
+		},
+	)
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.tfeapi.addHandlers(r)
+}
+
+// NewAttempt constructs an attempt to authenticate from a request, with an
+// optional username.
+func (s *Service) NewAttempt(r *http.Request, username string) Attempt {
+	return Attempt{
+		IP:       otfhttp.ClientIP(r, s.trustedProxies),
+		Username: username,
+	}
+}
+
+// Check whether an attempt to authenticate is permitted. A *LockedError is
+// returned if the client is either delayed due to its previous failed
+// attempts, or locked out.
+func (s *Service) Check(ctx context.Context, attempt Attempt) error {
+	s.load(ctx)
+	if err := s.tracker.check(attempt.keys(), internal.CurrentTimestamp(nil)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Fail records a failed attempt to authenticate, locking out the client if it
+// has now failed too many times.
+func (s *Service) Fail(ctx context.Context, attempt Attempt) {
+	lockouts := s.tracker.fail(attempt.keys(), internal.CurrentTimestamp(nil))
+	for _, lockout := range lockouts {
+		s.logger.Warn("locked out client after too many failed authentication attempts", "lockout", lockout)
+		// persist the lockout so that it takes effect on all nodes
+		if err := s.db.upsert(ctx, lockout); err != nil {
+			s.logger.Error("persisting lockout", "lockout", lockout, "err", err)
+		}
+	}
+}
+
+// Succeed records a successful attempt to authenticate, forgetting the
+// client's previous failed attempts.
+func (s *Service) Succeed(ctx context.Context, attempt Attempt) {
+	s.tracker.succeed(attempt.keys())
+}
+
+// List lists the lockouts currently in effect.
+func (s *Service) List(ctx context.Context) ([]*Lockout, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.ListAuthLockoutsAction, "")
+	if err != nil {
+		return nil, err
+	}
+	lockouts, err := s.db.listActive(ctx, internal.CurrentTimestamp(nil))
+	if err != nil {
+		s.logger.Error("listing lockouts", "subject", subject, "err", err)
+		return nil, err
+	}
+	return lockouts, nil
+}
+
+// Clear a lockout, permitting the client to authenticate again. The lockout is
+// identified by its key.
+func (s *Service) Clear(ctx context.Context, key string) error {
+	subject, err := s.site.CanAccess(ctx, rbac.ClearAuthLockoutAction, "")
+	if err != nil {
+		return err
+	}
+	if err := s.db.delete(ctx, key); err != nil {
+		s.logger.Error("clearing lockout", "key", key, "subject", subject, "err", err)
+		return err
+	}
+	s.tracker.unlock(key)
+	s.logger.Info("cleared lockout", "key", key, "subject", subject)
+	return nil
+}
+
+// Watch lockout events.
+func (s *Service) Watch(ctx context.Context) (<-chan pubsub.Event[*Lockout], func()) {
+	return s.broker.Subscribe(ctx)
+}
+
+// load populates the cache with the lockouts in the database, unless it has
+// already been populated. Lockouts subsequently imposed or cleared are cached
+// via the broker.
+func (s *Service) load(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded {
+		return
+	}
+	lockouts, err := s.db.listActive(ctx, internal.CurrentTimestamp(nil))
+	if err != nil {
+		// try again on the next attempt
+		s.logger.Error("loading lockouts", "err", err)
+		return
+	}
+	for _, lockout := range lockouts {
+		s.tracker.lock(lockout)
+	}
+	s.loaded = true
+}
+
+// Reject responds to a client whose attempt to authenticate is not permitted,
+// informing it when it may retry.
+func Reject(w http.ResponseWriter, err error) {
+	var locked *LockedError
+	if errors.As(err, &locked) {
+		w.Header().Set("Retry-After", strconv.Itoa(locked.RetryAfterSeconds()))
+	}
+	http.Error(w, err.Error(), http.StatusTooManyRequests)
+}
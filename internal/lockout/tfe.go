@@ -0,0 +1,59 @@
+package lockout
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/admin/auth-lockouts", a.listLockouts).Methods("GET")
+	r.HandleFunc("/admin/auth-lockouts/{lockout_id}", a.clearLockout).Methods("DELETE")
+}
+
+func (a *tfe) listLockouts(w http.ResponseWriter, r *http.Request) {
+	lockouts, err := a.Service.List(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	to := make([]*types.AuthLockout, len(lockouts))
+	for i, from := range lockouts {
+		to[i] = a.toLockout(from)
+	}
+	a.Respond(w, r, to, http.StatusOK)
+}
+
+func (a *tfe) clearLockout(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("lockout_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.Service.Clear(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) toLockout(from *Lockout) *types.AuthLockout {
+	return &types.AuthLockout{
+		ID:          from.Key(),
+		Kind:        string(from.Kind),
+		Value:       from.Value,
+		Failures:    from.Failures,
+		CreatedAt:   from.CreatedAt,
+		LockedUntil: from.LockedUntil,
+	}
+}
@@ -0,0 +1,167 @@
+package lockout
+
+import (
+	"sync"
+	"time"
+)
+
+const pruneInterval = time.Minute
+
+type (
+	// tracker tracks consecutive failed authentication attempts in memory,
+	// and keeps a cache of lockouts, including those imposed by other nodes.
+	tracker struct {
+		// threshold is the number of consecutive failed attempts after
+		// which a client is locked out. Zero disables lockouts, in which
+		// case clients are only delayed.
+		threshold int
+		// duration of a lockout, and the duration after which a client's
+		// failed attempts are forgotten.
+		duration time.Duration
+
+		failures map[string]*failures
+		lockouts map[string]*Lockout
+		// pruned is when the tracker was last pruned.
+		pruned time.Time
+		mu     sync.Mutex
+	}
+
+	// failures is a client's record of consecutive failed attempts.
+	failures struct {
+		count int
+		last  time.Time
+	}
+)
+
+func newTracker(threshold int, duration time.Duration) *tracker {
+	return &tracker{
+		threshold: threshold,
+		duration:  duration,
+		failures:  make(map[string]*failures),
+		lockouts:  make(map[string]*Lockout),
+	}
+}
+
+// check whether the clients identified by the keys are permitted to attempt
+// to authenticate at the given time, returning an error if any of them is
+// delayed or locked out.
+func (t *tracker) check(keys []string, now time.Time) *LockedError {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var err *LockedError
+	for _, key := range keys {
+		if lockout, ok := t.lockouts[key]; ok {
+			if lockout.Active(now) {
+				retry := lockout.LockedUntil.Sub(now)
+				if err == nil || err.Lockout == nil || retry > err.RetryAfter {
+					err = &LockedError{RetryAfter: retry, Lockout: lockout}
+				}
+				continue
+			}
+			delete(t.lockouts, key)
+		}
+		f, ok := t.failures[key]
+		if !ok {
+			continue
+		}
+		if until := f.last.Add(delay(f.count)); now.Before(until) {
+			if err == nil || (err.Lockout == nil && until.Sub(now) > err.RetryAfter) {
+				err = &LockedError{RetryAfter: until.Sub(now)}
+			}
+		}
+	}
+	return err
+}
+
+// fail records a failed attempt by the clients identified by the keys at the
+// given time, returning a lockout for each client that has now reached the
+// threshold.
+func (t *tracker) fail(keys []string, now time.Time) []*Lockout {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(now)
+
+	var lockouts []*Lockout
+	for _, key := range keys {
+		f, ok := t.failures[key]
+		if !ok || t.stale(f, now) {
+			f = &failures{}
+			t.failures[key] = f
+		}
+		f.count++
+		f.last = now
+		if t.threshold > 0 && f.count >= t.threshold {
+			kind, value, err := parseKey(key)
+			if err != nil {
+				continue
+			}
+			lockout := &Lockout{
+				Kind:        kind,
+				Value:       value,
+				Failures:    f.count,
+				CreatedAt:   now,
+				LockedUntil: now.Add(t.duration),
+			}
+			t.lockouts[key] = lockout
+			// start afresh once the lockout expires
+			delete(t.failures, key)
+			lockouts = append(lockouts, lockout)
+		}
+	}
+	return lockouts
+}
+
+// succeed forgets the failed attempts of the clients identified by the keys.
+func (t *tracker) succeed(keys []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, key := range keys {
+		delete(t.failures, key)
+	}
+}
+
+// lock caches a lockout.
+func (t *tracker) lock(lockout *Lockout) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lockouts[lockout.Key()] = lockout
+}
+
+// unlock removes a lockout from the cache, and forgets the client's failed
+// attempts.
+func (t *tracker) unlock(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.lockouts, key)
+	delete(t.failures, key)
+}
+
+// prune forgets failed attempts and lockouts that are no longer relevant,
+// preventing the tracker from growing without bound. The tracker is pruned at
+// most once every pruneInterval. Must be called with the mutex held.
+func (t *tracker) prune(now time.Time) {
+	if now.Sub(t.pruned) < pruneInterval {
+		return
+	}
+	t.pruned = now
+	for key, f := range t.failures {
+		if t.stale(f, now) {
+			delete(t.failures, key)
+		}
+	}
+	for key, lockout := range t.lockouts {
+		if !lockout.Active(now) {
+			delete(t.lockouts, key)
+		}
+	}
+}
+
+// stale determines whether failed attempts are too old to be counted.
+func (t *tracker) stale(f *failures, now time.Time) bool {
+	return now.Sub(f.last) > max(t.duration, maxDelay)
+}
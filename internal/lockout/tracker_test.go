@@ -0,0 +1,148 @@
+package lockout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelay(t *testing.T) {
+	assert.Equal(t, time.Duration(0), delay(1))
+	assert.Equal(t, time.Duration(0), delay(freeFailures))
+	assert.Equal(t, time.Second, delay(freeFailures+1))
+	assert.Equal(t, 2*time.Second, delay(freeFailures+2))
+	assert.Equal(t, 4*time.Second, delay(freeFailures+3))
+	assert.Equal(t, maxDelay, delay(freeFailures+10))
+	assert.Equal(t, maxDelay, delay(1000))
+}
+
+func TestTracker(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	ip := Attempt{IP: "192.0.2.1"}.keys()
+
+	t.Run("escalating delays", func(t *testing.T) {
+		tr := newTracker(10, time.Minute)
+
+		for i := 0; i < freeFailures; i++ {
+			assert.Empty(t, tr.fail(ip, now))
+			assert.Nil(t, tr.check(ip, now))
+		}
+		tr.fail(ip, now)
+		err := tr.check(ip, now)
+		require.NotNil(t, err)
+		assert.Equal(t, time.Second, err.RetryAfter)
+		assert.Nil(t, err.Lockout)
+
+		// permitted once the delay has elapsed, but the next failure doubles
+		// the delay
+		now := now.Add(time.Second)
+		assert.Nil(t, tr.check(ip, now))
+		tr.fail(ip, now)
+		err = tr.check(ip, now)
+		require.NotNil(t, err)
+		assert.Equal(t, 2*time.Second, err.RetryAfter)
+	})
+
+	t.Run("lockout", func(t *testing.T) {
+		tr := newTracker(3, time.Minute)
+
+		assert.Empty(t, tr.fail(ip, now))
+		assert.Empty(t, tr.fail(ip, now))
+		lockouts := tr.fail(ip, now)
+		require.Len(t, lockouts, 1)
+		assert.Equal(t, &Lockout{
+			Kind:        IPKind,
+			Value:       "192.0.2.1",
+			Failures:    3,
+			CreatedAt:   now,
+			LockedUntil: now.Add(time.Minute),
+		}, lockouts[0])
+
+		err := tr.check(ip, now.Add(10*time.Second))
+		require.NotNil(t, err)
+		assert.Equal(t, 50*time.Second, err.RetryAfter)
+		assert.Equal(t, lockouts[0], err.Lockout)
+
+		// lockout expires and the client starts afresh
+		assert.Nil(t, tr.check(ip, now.Add(time.Minute)))
+		assert.Empty(t, tr.fail(ip, now.Add(time.Minute)))
+	})
+
+	t.Run("lockout disabled", func(t *testing.T) {
+		tr := newTracker(0, time.Minute)
+
+		for i := 0; i < 100; i++ {
+			assert.Empty(t, tr.fail(ip, now))
+		}
+		err := tr.check(ip, now)
+		require.NotNil(t, err)
+		assert.Nil(t, err.Lockout)
+		assert.Equal(t, maxDelay, err.RetryAfter)
+	})
+
+	t.Run("username locked out from many addresses", func(t *testing.T) {
+		tr := newTracker(3, time.Minute)
+
+		for _, addr := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"} {
+			tr.fail(Attempt{IP: addr, Username: "site-admin"}.keys(), now)
+		}
+		err := tr.check(Attempt{IP: "192.0.2.4", Username: "site-admin"}.keys(), now)
+		require.NotNil(t, err)
+		require.NotNil(t, err.Lockout)
+		assert.Equal(t, UsernameKind, err.Lockout.Kind)
+
+		// the addresses themselves are not locked out
+		assert.Nil(t, tr.check(Attempt{IP: "192.0.2.4"}.keys(), now))
+	})
+
+	t.Run("success forgets failures", func(t *testing.T) {
+		tr := newTracker(3, time.Minute)
+
+		tr.fail(ip, now)
+		tr.fail(ip, now)
+		tr.succeed(ip)
+		assert.Empty(t, tr.fail(ip, now))
+		assert.Empty(t, tr.fail(ip, now))
+	})
+
+	t.Run("stale failures are forgotten", func(t *testing.T) {
+		tr := newTracker(3, time.Minute)
+
+		tr.fail(ip, now)
+		tr.fail(ip, now)
+		assert.Empty(t, tr.fail(ip, now.Add(2*time.Minute)))
+	})
+
+	t.Run("unlock", func(t *testing.T) {
+		tr := newTracker(1, time.Minute)
+
+		lockouts := tr.fail(ip, now)
+		require.Len(t, lockouts, 1)
+		tr.unlock(lockouts[0].Key())
+		assert.Nil(t, tr.check(ip, now))
+	})
+
+	t.Run("lockout imposed by another node", func(t *testing.T) {
+		tr := newTracker(10, time.Minute)
+
+		tr.lock(&Lockout{Kind: IPKind, Value: "192.0.2.1", LockedUntil: now.Add(time.Minute)})
+		err := tr.check(ip, now)
+		require.NotNil(t, err)
+		assert.NotNil(t, err.Lockout)
+	})
+}
+
+func TestParseKey(t *testing.T) {
+	kind, value, err := parseKey("ip:2001:db8::1")
+	require.NoError(t, err)
+	assert.Equal(t, IPKind, kind)
+	assert.Equal(t, "2001:db8::1", value)
+
+	_, _, err = parseKey("bogus:192.0.2.1")
+	assert.Error(t, err)
+
+	_, _, err = parseKey("ip:")
+	assert.Error(t, err)
+}
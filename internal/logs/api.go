@@ -2,8 +2,11 @@ package logs
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
 
 	otfapi "github.com/tofutf/tofutf/internal/api"
 
@@ -23,10 +26,14 @@ func (a *api) addHandlers(r *mux.Router) {
 	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
 	signed.Use(internal.VerifySignedURL(a.Verifier))
 	signed.HandleFunc("/runs/{run_id}/logs/{phase}", a.getLogs).Methods("GET")
+	signed.HandleFunc("/runs/{run_id}/logs/{phase}/{stream:json}", a.getLogs).Methods("GET")
 
 	// client is typically otf-agent
 	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
 	r.HandleFunc("/runs/{run_id}/logs/{phase}", a.putLogs).Methods("PUT")
+	r.HandleFunc("/runs/{run_id}/logs/{phase}/incomplete", a.markIncomplete).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/logs/{phase}/{stream:json}", a.putLogs).Methods("PUT")
+	r.HandleFunc("/runs/{run_id}/logs/{phase}/{stream:json}/incomplete", a.markIncomplete).Methods("POST")
 }
 
 func (a *api) getLogs(w http.ResponseWriter, r *http.Request) {
@@ -59,7 +66,38 @@ func (a *api) putLogs(w http.ResponseWriter, r *http.Request) {
 	}
 	opts.Data = buf.Bytes()
 	if err := a.svc.PutChunk(r.Context(), opts); err != nil {
+		var offsetErr *internal.ChunkOffsetError
+		if errors.As(err, &offsetErr) {
+			// inform client where to resume from
+			w.Header().Set(otfapi.UploadOffsetHeader, strconv.Itoa(offsetErr.Expected))
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		var backpressureErr *internal.BackpressureError
+		if errors.As(err, &backpressureErr) {
+			// inform client how long to wait before retrying
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(backpressureErr.RetryAfter.Seconds()))))
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
 }
+
+func (a *api) markIncomplete(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID  string             `schema:"run_id,required"`
+		Phase  internal.PhaseType `schema:"phase,required"`
+		Stream internal.LogStream `schema:"stream"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err := a.svc.MarkLogsIncomplete(r.Context(), params.RunID, params.Phase, params.Stream); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -15,8 +15,7 @@ type Client struct {
 }
 
 func (c *Client) PutChunk(ctx context.Context, opts internal.PutChunkOptions) error {
-	u := fmt.Sprintf("runs/%s/logs/%s", url.QueryEscape(opts.RunID), url.QueryEscape(string(opts.Phase)))
-	req, err := c.NewRequest("PUT", u, opts.Data)
+	req, err := c.NewRequest("PUT", logsPath(opts.RunID, opts.Phase, opts.Stream), opts.Data)
 	if err != nil {
 		return err
 	}
@@ -33,3 +32,20 @@ func (c *Client) PutChunk(ctx context.Context, opts internal.PutChunkOptions) er
 
 	return nil
 }
+
+func (c *Client) MarkLogsIncomplete(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream) error {
+	req, err := c.NewRequest("POST", logsPath(runID, phase, stream)+"/incomplete", nil)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, req, nil)
+}
+
+// logsPath returns the path to a stream of logs for a phase.
+func logsPath(runID string, phase internal.PhaseType, stream internal.LogStream) string {
+	u := fmt.Sprintf("runs/%s/logs/%s", url.QueryEscape(runID), url.QueryEscape(string(phase)))
+	if stream != internal.PlainLogStream {
+		u += "/" + url.QueryEscape(string(stream))
+	}
+	return u
+}
@@ -5,38 +5,232 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
 )
 
+// incompleteNotice terminates the logs for a phase that the client gave up
+// uploading.
+var incompleteNotice = append([]byte("\n\x1b[1;33mWarning: these logs are incomplete: some of the output could not be uploaded.\x1b[0m\n"), internal.ETX)
+
+// truncatedNotice is appended to the logs for a phase once the logs for its
+// run have exceeded the maximum size.
+const truncatedNotice = "\n\x1b[1;33mWarning: these logs have been truncated: the logs for this run exceed the maximum size of %d bytes.\x1b[0m\n"
+
 // pgdb is a logs database on postgres
 type pgdb struct {
 	*sql.Pool // provides access to generated SQL queries
-}
 
-// put persists a chunk of logs to the DB and returns the chunk updated with a
-// unique identifier
+	// maxRunSize is the maximum total size in bytes of the logs for all
+	// phases of a run. Zero means no limit.
+	maxRunSize int
+	// maxDebugRunSize is the maximum total size in bytes of the logs for all
+	// phases of a run with debug logging enabled. It only ever raises
+	// maxRunSize. Zero means no limit.
+	maxDebugRunSize int
+}
 
-// put persists data to the DB and returns a unique identifier for the chunk
-func (db *pgdb) put(ctx context.Context, opts internal.PutChunkOptions) (string, error) {
-	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (string, error) {
+// put persists data to the DB and returns a unique identifier for the chunk.
+//
+// Chunks for a stream of logs for a phase must be received in order: a chunk that does not begin
+// where the previously received chunk ended is rejected with an error
+// informing the client of the offset at which the next chunk must begin, as
+// is any chunk received after the logs for the phase have finished.
+//
+// Once the logs for all phases of the run exceed the maximum size, which is
+// higher for a run with debug logging enabled, the chunk
+// is dropped in favour of a notice of truncation, and any further chunks for
+// the phase are dropped too. Dropped chunks are nonetheless accepted, so that
+// the client carries on uploading and the job is unaffected.
+//
+// The data is first passed through the redactor, together with any data
+// withheld from the previous chunk for the phase. Because the redactor may
+// withhold data and alter its length, the persisted chunk's offset is
+// tracked separately from the offset of the chunk received. If all of the
+// data is withheld then nothing is persisted and an empty identifier is
+// returned.
+func (db *pgdb) put(ctx context.Context, opts internal.PutChunkOptions, r *redactor) (string, error) {
+	return sql.Tx(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (string, error) {
 		if len(opts.Data) == 0 {
 			return "", fmt.Errorf("refusing to persist empty chunk")
 		}
 
-		id, err := q.InsertLogChunk(ctx, pggen.InsertLogChunkParams{
-			RunID:  sql.String(opts.RunID),
-			Phase:  sql.String(string(opts.Phase)),
-			Chunk:  opts.Data,
-			Offset: sql.Int4(opts.Offset),
+		buf, err := db.lockBuffer(ctx, q, opts.RunID, opts.Phase, opts.Stream)
+		if err != nil {
+			return "", err
+		}
+		if expected := int(buf.InputOffset.Int32); buf.Finished.Bool || opts.Offset != expected {
+			return "", &internal.ChunkOffsetError{Expected: expected}
+		}
+
+		end := internal.Chunk{Data: opts.Data}.IsEnd()
+		redacted, carry := r.redact(append(buf.Data, opts.Data...), end)
+
+		truncated := buf.Truncated.Bool
+		if truncated {
+			// drop the chunk, retaining only the end marker, if any.
+			redacted, carry = nil, []byte{}
+			if end {
+				redacted = []byte{internal.ETX}
+			}
+		} else if db.maxRunSize > 0 {
+			limit, err := db.maxSize(ctx, q, opts.RunID)
+			if err != nil {
+				return "", err
+			}
+			if limit > 0 {
+				size, err := q.FindRunLogSize(ctx, sql.String(opts.RunID))
+				if err != nil {
+					return "", sql.Error(err)
+				}
+				if int(size.Int32)+len(redacted) > limit {
+					// drop the chunk in favour of a notice of truncation.
+					truncated = true
+					redacted, carry = truncatedNoticeFor(limit, buf.OutputOffset.Int32 == 0, end), []byte{}
+				}
+			}
+		}
+
+		id, err := db.insertChunk(ctx, q, opts.RunID, opts.Phase, opts.Stream, redacted, buf.OutputOffset)
+		if err != nil {
+			return "", err
+		}
+		if end {
+			// no more logs for the phase
+			carry = []byte{}
+		}
+		_, err = q.UpdateLogBuffer(ctx, pggen.UpdateLogBufferParams{
+			Data:         carry,
+			InputOffset:  sql.Int4(opts.Offset + len(opts.Data)),
+			OutputOffset: sql.Int4(int(buf.OutputOffset.Int32) + len(redacted)),
+			Finished:     sql.Bool(end),
+			Truncated:    sql.Bool(truncated),
+			RunID:        sql.String(opts.RunID),
+			Phase:        sql.String(string(opts.Phase)),
+			Stream:       sql.String(string(opts.Stream)),
 		})
 		if err != nil {
 			return "", sql.Error(err)
 		}
+		return id, nil
+	})
+}
 
-		return strconv.Itoa(int(id.Int32)), nil
+// maxSize returns the maximum total size in bytes of the logs for the run,
+// which is raised if the run has debug logging enabled. Zero means no limit.
+func (db *pgdb) maxSize(ctx context.Context, q pggen.Querier, runID string) (int, error) {
+	debug, err := q.FindRunDebugLogging(ctx, sql.String(runID))
+	if err != nil {
+		return 0, sql.Error(err)
+	}
+	if !debug.Bool {
+		return db.maxRunSize, nil
+	}
+	if db.maxDebugRunSize == 0 {
+		return 0, nil
+	}
+	return max(db.maxRunSize, db.maxDebugRunSize), nil
+}
+
+// truncatedNoticeFor returns a notice of truncation for a phase whose run's
+// logs have exceeded the limit, beginning with the start marker if no logs
+// have yet been persisted for the phase, and finishing with the end marker if
+// the phase has ended.
+func truncatedNoticeFor(limit int, start, end bool) []byte {
+	var notice []byte
+	if start {
+		notice = append(notice, internal.STX)
+	}
+	notice = append(notice, fmt.Sprintf(truncatedNotice, limit)...)
+	if end {
+		notice = append(notice, internal.ETX)
+	}
+	return notice
+}
+
+// markIncomplete finishes a stream of logs for a phase, flushing any data
+// withheld by the redactor followed by a notice that the logs are incomplete.
+// If the stream has already finished then nothing is persisted and an empty
+// identifier is returned.
+func (db *pgdb) markIncomplete(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream, r *redactor) (string, error) {
+	return sql.Tx(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (string, error) {
+		buf, err := db.lockBuffer(ctx, q, runID, phase, stream)
+		if err != nil {
+			return "", err
+		}
+		if buf.Finished.Bool {
+			return "", nil
+		}
+
+		redacted, _ := r.redact(buf.Data, true)
+		if buf.InputOffset.Int32 == 0 {
+			// no logs received at all
+			redacted = append(redacted, internal.STX)
+		}
+		redacted = append(redacted, incompleteNotice...)
+
+		id, err := db.insertChunk(ctx, q, runID, phase, stream, redacted, buf.OutputOffset)
+		if err != nil {
+			return "", err
+		}
+		_, err = q.UpdateLogBuffer(ctx, pggen.UpdateLogBufferParams{
+			Data:         []byte{},
+			InputOffset:  buf.InputOffset,
+			OutputOffset: sql.Int4(int(buf.OutputOffset.Int32) + len(redacted)),
+			Finished:     sql.Bool(true),
+			Truncated:    buf.Truncated,
+			RunID:        sql.String(runID),
+			Phase:        sql.String(string(phase)),
+			Stream:       sql.String(string(stream)),
+		})
+		if err != nil {
+			return "", sql.Error(err)
+		}
+		return id, nil
+	})
+}
+
+// lockBuffer retrieves the buffer for a stream of logs for a phase, creating it
+// if it does not exist, and locks it for the remainder of the transaction.
+func (db *pgdb) lockBuffer(ctx context.Context, q pggen.Querier, runID string, phase internal.PhaseType, stream internal.LogStream) (pggen.FindLogBufferForUpdateRow, error) {
+	_, err := q.InsertLogBuffer(ctx, pggen.InsertLogBufferParams{
+		RunID:  sql.String(runID),
+		Phase:  sql.String(string(phase)),
+		Stream: sql.String(string(stream)),
 	})
+	if err != nil {
+		return pggen.FindLogBufferForUpdateRow{}, sql.Error(err)
+	}
+	buf, err := q.FindLogBufferForUpdate(ctx, pggen.FindLogBufferForUpdateParams{
+		RunID:  sql.String(runID),
+		Phase:  sql.String(string(phase)),
+		Stream: sql.String(string(stream)),
+	})
+	if err != nil {
+		return pggen.FindLogBufferForUpdateRow{}, sql.Error(err)
+	}
+	return buf, nil
+}
+
+// insertChunk persists a chunk of logs, returning its unique identifier. An
+// empty chunk is not persisted and an empty identifier is returned.
+func (db *pgdb) insertChunk(ctx context.Context, q pggen.Querier, runID string, phase internal.PhaseType, stream internal.LogStream, data []byte, offset pgtype.Int4) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	chunkID, err := q.InsertLogChunk(ctx, pggen.InsertLogChunkParams{
+		RunID:  sql.String(runID),
+		Phase:  sql.String(string(phase)),
+		Stream: sql.String(string(stream)),
+		Chunk:  data,
+		Offset: offset,
+	})
+	if err != nil {
+		return "", sql.Error(err)
+	}
+	return strconv.Itoa(int(chunkID.Int32)), nil
 }
 
 func (db *pgdb) getChunk(ctx context.Context, chunkID string) (internal.Chunk, error) {
@@ -55,15 +249,20 @@ func (db *pgdb) getChunk(ctx context.Context, chunkID string) (internal.Chunk, e
 			ID:     chunkID,
 			RunID:  chunk.RunID.String,
 			Phase:  internal.PhaseType(chunk.Phase.String),
+			Stream: internal.LogStream(chunk.Stream.String),
 			Data:   chunk.Chunk,
 			Offset: int(chunk.Offset.Int32),
 		}, nil
 	})
 }
 
-func (db *pgdb) getLogs(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error) {
+func (db *pgdb) getLogs(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream) ([]byte, error) {
 	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]byte, error) {
-		data, err := q.FindLogs(ctx, sql.String(runID), sql.String(string(phase)))
+		data, err := q.FindLogs(ctx, pggen.FindLogsParams{
+			RunID:  sql.String(runID),
+			Phase:  sql.String(string(phase)),
+			Stream: sql.String(string(stream)),
+		})
 		if err != nil {
 			// Don't consider no rows an error because logs may not have been
 			// uploaded yet.
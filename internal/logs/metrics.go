@@ -0,0 +1,14 @@
+package logs
+
+import "github.com/prometheus/client_golang/prometheus"
+
+func init() {
+	prometheus.MustRegister(backpressureMetric)
+}
+
+var backpressureMetric = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "otf",
+	Subsystem: "logs",
+	Name:      "backpressure_total",
+	Help:      "Total number of log uploads rejected because the log write path was saturated, asking the client to slow down.",
+})
@@ -2,26 +2,65 @@ package logs
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/tofutf/tofutf/internal"
 )
 
+const (
+	// defaultMaxBufferSize is the default maximum number of bytes of logs
+	// retained whilst they are yet to be successfully uploaded.
+	defaultMaxBufferSize = 1 << 20 // 1MiB
+	// defaultCloseRetries is the default number of additional attempts to
+	// upload outstanding logs when the writer is closed.
+	defaultCloseRetries = 5
+	// defaultRetryInterval is the default interval between attempts to upload
+	// outstanding logs when the writer is closed.
+	defaultRetryInterval = 2 * time.Second
+)
+
 type (
 	// PhaseWriter writes logs on behalf of a run phase.
+	//
+	// Logs are uploaded in chunks, each carrying the offset at which it begins.
+	// Logs that fail to upload are retained in a bounded buffer and re-sent
+	// with the next write, resuming from the offset up to which the server
+	// reports having received logs. If the buffer overflows, or the logs
+	// still cannot be uploaded when the writer is closed, the writer gives up,
+	// marking the logs for the phase as incomplete and discarding further
+	// writes.
+	//
+	// If the server is too busy to accept logs then uploads are deferred for
+	// the delay it suggests, with logs retained in the buffer in the meantime.
+	// Should the buffer fill up, or the writer be closed, before the server is
+	// ready, then the writer waits rather than giving up.
 	PhaseWriter struct {
 		ctx     context.Context    // permits canceling mid-flow
-		started bool               // has first chunk been sent?
+		started bool               // has first chunk been written?
 		id      string             // ID of run to write logs on behalf of.
 		phase   internal.PhaseType // run phase
-		offset  int                // current position in stream
+		stream  internal.LogStream // stream of logs for phase
+		offset  int                // position in stream up to which logs have been uploaded
+		pending []byte             // logs yet to be uploaded
+		failed  bool               // has writer given up uploading?
+
+		throttledUntil time.Time // uploads deferred until then at server's request
+
+		maxBufferSize int           // max size of pending logs
+		closeRetries  int           // attempts to upload pending logs upon close
+		retryInterval time.Duration // interval between attempts upon close
 
 		internal.PutChunkService // for uploading logs to server
 	}
 
 	PhaseWriterOptions struct {
-		RunID  string
-		Phase  internal.PhaseType
+		RunID string
+		Phase internal.PhaseType
+		// Stream to write logs to. Defaults to the stream of human-readable
+		// logs.
+		Stream internal.LogStream
 		Writer internal.PutChunkService
 	}
 )
@@ -33,52 +72,171 @@ func NewPhaseWriter(ctx context.Context, opts PhaseWriterOptions) *PhaseWriter {
 		ctx:             ctx,
 		id:              opts.RunID,
 		phase:           opts.Phase,
+		stream:          opts.Stream,
+		maxBufferSize:   defaultMaxBufferSize,
+		closeRetries:    defaultCloseRetries,
+		retryInterval:   defaultRetryInterval,
 		PutChunkService: opts.Writer,
 	}
 }
 
-// Write uploads a chunk of logs to the server.
+// Write uploads a chunk of logs to the server. An error is only returned if
+// the writer gives up uploading logs and then fails to mark them as
+// incomplete.
 func (w *PhaseWriter) Write(p []byte) (int, error) {
-	// TODO: io.Writer's should not retain p but do we need to copy it? Does
-	// this code 'retain' p? Does the cache or the database 'retain' p?
-	data := make([]byte, len(p))
-	copy(data, p)
+	if w.failed {
+		// discard logs
+		return len(p), nil
+	}
 
 	if !w.started {
 		w.started = true
-		data = append([]byte{internal.STX}, data...)
+		w.pending = append(w.pending, internal.STX)
 	}
+	w.pending = append(w.pending, p...)
 
-	chunk := internal.PutChunkOptions{
-		RunID:  w.id,
-		Phase:  w.phase,
-		Data:   data,
-		Offset: w.offset,
+	if time.Now().Before(w.throttledUntil) && len(w.pending) <= w.maxBufferSize {
+		// server has asked for uploads to be deferred; retain logs until then
+		return len(p), nil
 	}
-	w.offset += len(data)
-
-	if err := w.PutChunk(w.ctx, chunk); err != nil {
-		return 0, fmt.Errorf("writing log stream: %w", err)
+	if err := w.flush(); err != nil {
+		if len(w.pending) <= w.maxBufferSize && !isUnrecoverable(err) {
+			// retain logs and retry with the next write
+			return len(p), nil
+		}
+		if err := w.giveUp(); err != nil {
+			return 0, fmt.Errorf("writing log stream: %w", err)
+		}
 	}
-
 	return len(p), nil
 }
 
 // Close must be called to complete writing job logs
 func (w *PhaseWriter) Close() error {
-	opts := internal.PutChunkOptions{
-		RunID:  w.id,
-		Phase:  w.phase,
-		Offset: w.offset,
+	if w.failed {
+		return nil
 	}
+
 	if w.started {
-		opts.Data = []byte{internal.ETX}
+		w.pending = append(w.pending, internal.ETX)
 	} else {
-		opts.Data = []byte{internal.STX, internal.ETX}
+		w.pending = append(w.pending, internal.STX, internal.ETX)
+	}
+
+	err := w.flush()
+	for retries := 0; err != nil && !isUnrecoverable(err); {
+		// a busy server is waited upon without limit, whereas other failures
+		// are only retried so many times.
+		if !isBackpressure(err) {
+			if retries == w.closeRetries {
+				break
+			}
+			retries++
+			select {
+			case <-w.ctx.Done():
+				return w.ctx.Err()
+			case <-time.After(w.retryInterval):
+			}
+		}
+		err = w.flush()
+	}
+	if err != nil {
+		return w.giveUp()
+	}
+	return nil
+}
+
+// flush uploads pending logs. If the server reports having received logs up
+// to a different offset then only the logs it is yet to receive are re-sent.
+// If the server is too busy then uploading is deferred, and if the buffer is
+// full then flush waits until the server accepts the logs.
+func (w *PhaseWriter) flush() error {
+	for len(w.pending) > 0 {
+		if err := w.waitUntilUnthrottled(); err != nil {
+			return err
+		}
+		err := w.PutChunk(w.ctx, internal.PutChunkOptions{
+			RunID:  w.id,
+			Phase:  w.phase,
+			Stream: w.stream,
+			Data:   w.pending,
+			Offset: w.offset,
+		})
+		var (
+			offsetErr       *internal.ChunkOffsetError
+			backpressureErr *internal.BackpressureError
+		)
+		if errors.As(err, &backpressureErr) {
+			delay := backpressureErr.RetryAfter
+			if delay == 0 {
+				delay = w.retryInterval
+			}
+			w.throttledUntil = time.Now().Add(delay)
+			if len(w.pending) <= w.maxBufferSize {
+				return err
+			}
+			// buffer is full: wait for the server rather than discard logs
+			continue
+		} else if errors.As(err, &offsetErr) {
+			received := offsetErr.Expected - w.offset
+			if received <= 0 || received > len(w.pending) {
+				// server expects logs that are either no longer retained or
+				// yet to be written, or it refuses to accept any more.
+				return &unrecoverableError{err}
+			}
+			// server received some or all of the logs previously, e.g. the
+			// response to an earlier upload was lost.
+			w.pending = w.pending[received:]
+			w.offset = offsetErr.Expected
+			continue
+		} else if err != nil {
+			return err
+		}
+		w.offset += len(w.pending)
+		w.pending = nil
 	}
+	return nil
+}
+
+// waitUntilUnthrottled waits until the time before which the server has asked
+// for uploads to be deferred.
+func (w *PhaseWriter) waitUntilUnthrottled() error {
+	delay := time.Until(w.throttledUntil)
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
 
-	if err := w.PutChunk(w.ctx, opts); err != nil {
-		return err
+// giveUp stops uploading logs, marking the stream of logs for the phase as
+// incomplete.
+func (w *PhaseWriter) giveUp() error {
+	w.failed = true
+	w.pending = nil
+	if err := w.MarkLogsIncomplete(w.ctx, w.id, w.phase, w.stream); err != nil {
+		return fmt.Errorf("marking logs incomplete: %w", err)
 	}
 	return nil
 }
+
+// unrecoverableError is an upload error that retrying cannot fix.
+type unrecoverableError struct {
+	error
+}
+
+func (e *unrecoverableError) Unwrap() error { return e.error }
+
+func isUnrecoverable(err error) bool {
+	var unrecoverable *unrecoverableError
+	return errors.As(err, &unrecoverable)
+}
+
+func isBackpressure(err error) bool {
+	var backpressure *internal.BackpressureError
+	return errors.As(err, &backpressure)
+}
@@ -0,0 +1,231 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+// fakeLogServer receives chunks in the same manner as the logs service,
+// rejecting chunks that don't begin where the previous chunk ended.
+type fakeLogServer struct {
+	received   []byte
+	incomplete bool
+	// number of subsequent uploads to fail before they reach the server
+	failures int
+	// number of subsequent uploads to receive but report as having failed
+	lostResponses int
+	// number of subsequent uploads to reject because the server is too busy,
+	// suggesting the client retries after retryAfter.
+	saturated  int
+	retryAfter time.Duration
+}
+
+func (f *fakeLogServer) PutChunk(ctx context.Context, opts internal.PutChunkOptions) error {
+	if f.saturated > 0 {
+		f.saturated--
+		return &internal.BackpressureError{RetryAfter: f.retryAfter}
+	}
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("connection reset")
+	}
+	if f.incomplete || opts.Offset != len(f.received) {
+		return &internal.ChunkOffsetError{Expected: len(f.received)}
+	}
+	f.received = append(f.received, opts.Data...)
+	if f.lostResponses > 0 {
+		f.lostResponses--
+		return errors.New("timeout awaiting response")
+	}
+	return nil
+}
+
+func (f *fakeLogServer) MarkLogsIncomplete(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream) error {
+	f.incomplete = true
+	return nil
+}
+
+func newTestPhaseWriter(server *fakeLogServer) *PhaseWriter {
+	w := NewPhaseWriter(context.Background(), PhaseWriterOptions{
+		RunID:  "run-123",
+		Phase:  internal.PlanPhase,
+		Writer: server,
+	})
+	w.retryInterval = 0
+	return w
+}
+
+func TestPhaseWriter(t *testing.T) {
+	t.Run("upload", func(t *testing.T) {
+		server := &fakeLogServer{}
+		w := newTestPhaseWriter(server)
+
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+		_, err = w.Write([]byte(" world"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "\x02hello world\x03", string(server.received))
+		assert.False(t, server.incomplete)
+	})
+
+	t.Run("no logs", func(t *testing.T) {
+		server := &fakeLogServer{}
+		w := newTestPhaseWriter(server)
+
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "\x02\x03", string(server.received))
+	})
+
+	t.Run("resume after failed upload", func(t *testing.T) {
+		server := &fakeLogServer{}
+		w := newTestPhaseWriter(server)
+
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		// upload fails but logs should be retained and re-sent with the next
+		// write
+		server.failures = 1
+		_, err = w.Write([]byte(" world"))
+		require.NoError(t, err)
+		assert.Equal(t, "\x02hello", string(server.received))
+
+		_, err = w.Write([]byte("!"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "\x02hello world!\x03", string(server.received))
+	})
+
+	t.Run("resume after lost response", func(t *testing.T) {
+		server := &fakeLogServer{}
+		w := newTestPhaseWriter(server)
+
+		// server receives logs but writer doesn't know it has
+		server.lostResponses = 1
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		// server should inform writer it has already received logs, and
+		// writer should only send the logs it has yet to receive.
+		_, err = w.Write([]byte(" world"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "\x02hello world\x03", string(server.received))
+	})
+
+	t.Run("retry upon close", func(t *testing.T) {
+		server := &fakeLogServer{}
+		w := newTestPhaseWriter(server)
+
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		server.failures = 3
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "\x02hello\x03", string(server.received))
+		assert.False(t, server.incomplete)
+	})
+
+	t.Run("give up upon close", func(t *testing.T) {
+		server := &fakeLogServer{}
+		w := newTestPhaseWriter(server)
+
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		server.failures = defaultCloseRetries + 1
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "\x02hello", string(server.received))
+		assert.True(t, server.incomplete)
+	})
+
+	t.Run("give up when buffer is full", func(t *testing.T) {
+		server := &fakeLogServer{}
+		w := newTestPhaseWriter(server)
+		w.maxBufferSize = 10
+
+		server.failures = 100
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+		assert.False(t, server.incomplete)
+
+		// exceed buffer
+		_, err = w.Write([]byte(" world"))
+		require.NoError(t, err)
+		assert.True(t, server.incomplete)
+
+		// further logs are discarded
+		server.failures = 0
+		_, err = w.Write([]byte("!"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.Empty(t, server.received)
+	})
+
+	t.Run("defer uploads whilst server is busy", func(t *testing.T) {
+		server := &fakeLogServer{saturated: 1, retryAfter: 100 * time.Millisecond}
+		w := newTestPhaseWriter(server)
+
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		// upload is deferred rather than retried straight away, even though
+		// the server would now accept it
+		_, err = w.Write([]byte(" world"))
+		require.NoError(t, err)
+		assert.Empty(t, server.received)
+
+		// writer waits for the server upon close
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "\x02hello world\x03", string(server.received))
+		assert.False(t, server.incomplete)
+	})
+
+	t.Run("wait for busy server when buffer is full", func(t *testing.T) {
+		server := &fakeLogServer{saturated: 3, retryAfter: time.Millisecond}
+		w := newTestPhaseWriter(server)
+		w.maxBufferSize = 10
+
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		// exceed buffer: writer should wait until the server accepts the
+		// logs rather than give up
+		_, err = w.Write([]byte(" world"))
+		require.NoError(t, err)
+		assert.Equal(t, "\x02hello world", string(server.received))
+		assert.False(t, server.incomplete)
+
+		require.NoError(t, w.Close())
+		assert.Equal(t, "\x02hello world\x03", string(server.received))
+	})
+
+	t.Run("wait for busy server upon close", func(t *testing.T) {
+		server := &fakeLogServer{retryAfter: time.Millisecond}
+		w := newTestPhaseWriter(server)
+
+		_, err := w.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		// busy server does not count towards the number of retries
+		server.saturated = defaultCloseRetries + 5
+		require.NoError(t, w.Close())
+
+		assert.Equal(t, "\x02hello\x03", string(server.received))
+		assert.False(t, server.incomplete)
+	})
+}
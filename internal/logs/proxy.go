@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync/atomic"
+	"time"
 
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/pubsub"
 )
 
+// backpressureRetryAfter is the delay suggested to clients whose log uploads
+// are rejected because the write path is saturated.
+const backpressureRetryAfter = time.Second
+
 type (
 	// proxy is a caching proxy for log chunks
 	proxy struct {
@@ -16,60 +22,87 @@ type (
 		db     proxydb
 		broker pubsub.SubscriptionService[internal.Chunk]
 		logger *slog.Logger
+
+		// generation is incremented to invalidate the entire cache; it is
+		// included in cache keys so that entries from previous generations
+		// are no longer retrieved and are left to expire.
+		generation atomic.Uint64
+
+		// writes limits the number of concurrent writes of chunks to the db.
+		// Nil means there is no limit.
+		writes chan struct{}
 	}
 
 	proxydb interface {
-		getLogs(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error)
-		put(ctx context.Context, opts internal.PutChunkOptions) (string, error)
+		getLogs(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream) ([]byte, error)
+		put(ctx context.Context, opts internal.PutChunkOptions, r *redactor) (string, error)
+		markIncomplete(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream, r *redactor) (string, error)
 	}
 )
 
 // Start chunk proxy daemon, which keeps the cache up-to-date with logs
 // published across the cluster.
 func (p *proxy) Start(ctx context.Context) error {
-	// TODO: if it loses its connection to the stream it should keep retrying,
-	// with a backoff alg, and it should invalidate the cache *entirely* because
-	// it may have missed updates, potentially rendering the cache stale.
 	sub, unsub := p.broker.Subscribe(ctx)
 	defer unsub()
 
-	for event := range sub {
-		chunk := event.Payload
-		key := cacheKey(chunk.RunID, chunk.Phase)
-
-		var logs []byte
-		// The first log chunk can be written straight to the cache, whereas
-		// successive chunks require the cache to be checked first.
-		if chunk.IsStart() {
-			logs = chunk.Data
-		} else {
-			if existing, err := p.cache.Get(key); err != nil {
-				// no cache entry; retrieve logs from db
-				logs, err = p.db.getLogs(ctx, chunk.RunID, chunk.Phase)
-				if err != nil {
-					return err
-				}
-			} else {
-				// append received chunk to existing cached logs
-				logs = append(existing, chunk.Data...)
+	for {
+		select {
+		case event, open := <-sub:
+			if !open {
+				// The subscription is terminated if the proxy falls behind or
+				// the database listener reconnects, either of which means
+				// updates may have been missed, potentially rendering the
+				// cache stale. So invalidate the cache entirely, and leave
+				// the subsystem to restart the proxy, with a backoff.
+				p.generation.Add(1)
+				return pubsub.ErrSubscriptionTerminated
+			}
+			if err := p.cacheChunk(ctx, event.Payload); err != nil {
+				return err
 			}
+		case <-ctx.Done():
+			return nil
 		}
-		if err := p.cache.Set(key, logs); err != nil {
-			p.logger.Error("caching log chunk", "err", err)
+	}
+}
+
+// cacheChunk adds a chunk published across the cluster to the cache.
+func (p *proxy) cacheChunk(ctx context.Context, chunk internal.Chunk) error {
+	key := p.cacheKey(chunk.RunID, chunk.Phase, chunk.Stream)
+
+	var logs []byte
+	// The first log chunk can be written straight to the cache, whereas
+	// successive chunks require the cache to be checked first.
+	if chunk.IsStart() {
+		logs = chunk.Data
+	} else {
+		if existing, err := p.cache.Get(key); err != nil {
+			// no cache entry; retrieve logs from db
+			logs, err = p.db.getLogs(ctx, chunk.RunID, chunk.Phase, chunk.Stream)
+			if err != nil {
+				return err
+			}
+		} else {
+			// append received chunk to existing cached logs
+			logs = append(existing, chunk.Data...)
 		}
 	}
-	return pubsub.ErrSubscriptionTerminated
+	if err := p.cache.Set(key, logs); err != nil {
+		p.logger.Error("caching log chunk", "err", err)
+	}
+	return nil
 }
 
 // GetChunk attempts to retrieve a chunk from the cache before falling back to
 // using the backend store.
 func (p *proxy) get(ctx context.Context, opts internal.GetChunkOptions) (internal.Chunk, error) {
-	key := cacheKey(opts.RunID, opts.Phase)
+	key := p.cacheKey(opts.RunID, opts.Phase, opts.Stream)
 
 	data, err := p.cache.Get(key)
 	if err != nil {
 		// fall back to retrieving from db...
-		data, err = p.db.getLogs(ctx, opts.RunID, opts.Phase)
+		data, err = p.db.getLogs(ctx, opts.RunID, opts.Phase, opts.Stream)
 		if err != nil {
 			return internal.Chunk{}, err
 		}
@@ -78,19 +111,52 @@ func (p *proxy) get(ctx context.Context, opts internal.GetChunkOptions) (interna
 			p.logger.Error("caching log chunk", "err", err)
 		}
 	}
-	chunk := internal.Chunk{RunID: opts.RunID, Phase: opts.Phase, Data: data}
+	chunk := internal.Chunk{RunID: opts.RunID, Phase: opts.Phase, Stream: opts.Stream, Data: data}
 	// Cut chunk down to requested size.
 	return chunk.Cut(opts), nil
 }
 
-// put writes a chunk of data to the db
-func (p *proxy) put(ctx context.Context, opts internal.PutChunkOptions) error {
+// put writes a chunk of data to the db, masking secrets using the redactor.
+// If the maximum number of concurrent writes is already in progress then an
+// *internal.BackpressureError is returned, asking the client to slow down
+// rather than queue further writes.
+func (p *proxy) put(ctx context.Context, opts internal.PutChunkOptions, r *redactor) error {
+	if p.writes != nil {
+		select {
+		case p.writes <- struct{}{}:
+			defer func() { <-p.writes }()
+		default:
+			backpressureMetric.Inc()
+			return &internal.BackpressureError{RetryAfter: backpressureRetryAfter}
+		}
+	}
+	// db triggers an event, which proxy listens for to populate its cache
+	_, err := p.db.put(ctx, opts, r)
+	return err
+}
+
+// markIncomplete finishes a stream of logs for a phase with a notice that they
+// are incomplete.
+func (p *proxy) markIncomplete(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream, r *redactor) error {
 	// db triggers an event, which proxy listens for to populate its cache
-	_, err := p.db.put(ctx, opts)
+	_, err := p.db.markIncomplete(ctx, runID, phase, stream, r)
 	return err
 }
 
+// cacheKey generates a key for caching log chunks within the current
+// generation of the cache.
+func (p *proxy) cacheKey(runID string, phase internal.PhaseType, stream internal.LogStream) string {
+	key := cacheKey(runID, phase, stream)
+	if gen := p.generation.Load(); gen > 0 {
+		return fmt.Sprintf("%d.%s", gen, key)
+	}
+	return key
+}
+
 // cacheKey generates a key for caching log chunks.
-func cacheKey(runID string, phase internal.PhaseType) string {
-	return fmt.Sprintf("%s.%s.log", runID, string(phase))
+func cacheKey(runID string, phase internal.PhaseType, stream internal.LogStream) string {
+	if stream == internal.PlainLogStream {
+		return fmt.Sprintf("%s.%s.log", runID, string(phase))
+	}
+	return fmt.Sprintf("%s.%s.%s.log", runID, string(phase), string(stream))
 }
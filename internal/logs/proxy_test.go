@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"go.uber.org/goleak"
 )
 
 // TestProxy_Get tests get() with and without a cached entry
@@ -46,6 +50,19 @@ func TestProxy_Get(t *testing.T) {
 		// cache should be populated now
 		assert.Equal(t, "hello world", string(cache.cache["run-123.plan.log"]))
 	})
+
+	t.Run("cache hit for machine-readable stream", func(t *testing.T) {
+		cache := newFakeCache("run-123.plan.log", "hello world", "run-123.plan.json.log", `{"@message":"hello world"}`)
+		proxy := &proxy{cache: cache}
+
+		opts := opts
+		opts.Stream = internal.JSONLogStream
+		got, err := proxy.get(ctx, opts)
+		require.NoError(t, err)
+
+		want := internal.Chunk{RunID: "run-123", Phase: internal.PlanPhase, Stream: internal.JSONLogStream, Offset: 3, Data: []byte(`mess`)}
+		assert.Equal(t, want, got)
+	})
 }
 
 func FuzzProxy_Get(f *testing.F) {
@@ -88,3 +105,91 @@ func FuzzProxy_Get(f *testing.F) {
 		assert.Equal(t, want, got)
 	})
 }
+
+func TestProxy_Start(t *testing.T) {
+	t.Run("return upon context cancellation", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+		ctx, cancel := context.WithCancel(context.Background())
+		proxy := &proxy{broker: &fakeOpenSubService{}}
+
+		done := make(chan error)
+		go func() {
+			done <- proxy.Start(ctx)
+		}()
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for proxy to stop")
+		}
+	})
+
+	t.Run("cache published chunk", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+		ctx, cancel := context.WithCancel(context.Background())
+		sub := make(chan pubsub.Event[internal.Chunk])
+		cache := newFakeCache()
+		proxy := &proxy{broker: &fakeSubService{stream: sub}, cache: cache}
+
+		done := make(chan error)
+		go func() {
+			done <- proxy.Start(ctx)
+		}()
+		sub <- pubsub.Event[internal.Chunk]{Payload: internal.Chunk{
+			RunID: "run-123",
+			Phase: internal.PlanPhase,
+			Data:  []byte("\x02hello"),
+		}}
+		cancel()
+		<-done
+
+		assert.Equal(t, "\x02hello", string(cache.cache["run-123.plan.log"]))
+	})
+	t.Run("invalidate cache upon subscription termination", func(t *testing.T) {
+		cache := newFakeCache("run-123.plan.log", "stale")
+		db := &fakeDB{data: []byte("hello world")}
+		proxy := &proxy{broker: &fakeTerminatedSubService{}, cache: cache, db: db}
+
+		err := proxy.Start(context.Background())
+		assert.Equal(t, pubsub.ErrSubscriptionTerminated, err)
+
+		// updates may have been missed so the stale entry is no longer used
+		got, err := proxy.get(context.Background(), internal.GetChunkOptions{
+			RunID: "run-123",
+			Phase: internal.PlanPhase,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(got.Data))
+	})
+}
+
+func TestProxy_Put(t *testing.T) {
+	ctx := context.Background()
+	opts := internal.PutChunkOptions{RunID: "run-123", Phase: internal.PlanPhase, Data: []byte("hello")}
+
+	t.Run("ask client to slow down when writes are saturated", func(t *testing.T) {
+		db := &fakeBlockingDB{started: make(chan struct{}, 2), release: make(chan struct{})}
+		proxy := &proxy{db: db, writes: make(chan struct{}, 1)}
+		before := testutil.ToFloat64(backpressureMetric)
+
+		// occupy the only write slot
+		done := make(chan error)
+		go func() {
+			done <- proxy.put(ctx, opts, nil)
+		}()
+		<-db.started
+
+		err := proxy.put(ctx, opts, nil)
+		var backpressureErr *internal.BackpressureError
+		require.ErrorAs(t, err, &backpressureErr)
+		assert.Equal(t, backpressureRetryAfter, backpressureErr.RetryAfter)
+		assert.Equal(t, before+1, testutil.ToFloat64(backpressureMetric))
+
+		// once the write completes the slot is freed
+		close(db.release)
+		require.NoError(t, <-done)
+		require.NoError(t, proxy.put(ctx, opts, nil))
+	})
+}
@@ -0,0 +1,284 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/variable"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+const (
+	// mask replaces secrets in logs.
+	mask = "***"
+	// minCarry is the minimum number of trailing bytes withheld from a chunk,
+	// in order that a secret split across chunks is still masked. A pattern
+	// match longer than this that straddles two chunks goes unmasked: the
+	// carry is bounded so that logs continue to stream promptly rather than
+	// being withheld until the phase finishes. Streamed logs therefore lag
+	// the agent's output by up to this many bytes, or by the length of the
+	// longest sensitive value if greater, until the phase finishes and the
+	// withheld bytes are flushed.
+	minCarry = 128
+	// minSecretLength is the minimum length of a sensitive value for it to be
+	// masked; masking shorter values would mangle unrelated output.
+	minSecretLength = 4
+)
+
+// credentialPatterns match common credentials. Where a pattern has a capture
+// group then only the group is masked.
+var credentialPatterns = []*regexp.Regexp{
+	// AWS access key ID
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	// AWS secret access key
+	regexp.MustCompile(`(?i)aws_secret_access_key["']?\s*[:=]\s*["']?([A-Za-z0-9/+]{40})`),
+	// bearer token, of at least 16 characters from the token character set
+	// of RFC 6750, so that prose such as "bearer tokens" is left alone.
+	regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]{16,}=*)`),
+}
+
+// redactor masks secrets in logs.
+type redactor struct {
+	secrets  [][]byte
+	patterns []*regexp.Regexp
+	// carry is the number of trailing bytes withheld from a chunk in case
+	// they form the beginning of a secret.
+	carry int
+}
+
+// newRedactor constructs a redactor that masks the given sensitive values,
+// along with their base64-encoded forms, and common credentials.
+func newRedactor(sensitive ...string) *redactor {
+	r := &redactor{patterns: credentialPatterns, carry: minCarry}
+	for _, v := range sensitive {
+		if len(v) < minSecretLength {
+			continue
+		}
+		forms := []string{
+			v,
+			base64.RawStdEncoding.EncodeToString([]byte(v)),
+			base64.RawURLEncoding.EncodeToString([]byte(v)),
+		}
+		for _, f := range forms {
+			r.secrets = append(r.secrets, []byte(f))
+			r.carry = max(r.carry, len(f))
+		}
+	}
+	return r
+}
+
+// addPatterns adds further patterns whose matches are to be masked.
+func (r *redactor) addPatterns(patterns ...*regexp.Regexp) {
+	// copy to avoid appending to the shared credential patterns
+	r.patterns = append(append([]*regexp.Regexp(nil), r.patterns...), patterns...)
+}
+
+// noopRedactor masks nothing and withholds nothing.
+var noopRedactor = &redactor{}
+
+// match is the location of a secret in logs.
+type match struct {
+	// start and end of the whole match.
+	start, end int
+	// start and end of the portion to be masked.
+	maskStart, maskEnd int
+}
+
+// redact masks secrets in buf. Unless final is true, trailing bytes that may
+// be the beginning of a secret continuing in the next chunk are withheld and
+// returned as the carry, which should be prepended to the next chunk.
+func (r *redactor) redact(buf []byte, final bool) (out, carry []byte) {
+	matches := r.find(buf)
+
+	cut := len(buf)
+	if !final {
+		cut = max(0, len(buf)-r.carry)
+		// don't split a multi-byte character across chunks
+		for cut > 0 && cut < len(buf) && !utf8.RuneStart(buf[cut]) {
+			cut--
+		}
+	}
+	// withhold any secret straddling the cut, in its entirety.
+	for moved := true; moved; {
+		moved = false
+		for _, m := range matches {
+			if m.start < cut && m.end > cut {
+				cut = m.start
+				moved = true
+			}
+		}
+	}
+
+	out = make([]byte, 0, cut)
+	var pos int
+	for _, m := range merge(matches, cut) {
+		out = append(out, buf[pos:m.maskStart]...)
+		out = append(out, mask...)
+		pos = m.maskEnd
+	}
+	out = append(out, buf[pos:cut]...)
+	carry = append([]byte(nil), buf[cut:]...)
+	return out, carry
+}
+
+// find locates secrets in buf
+func (r *redactor) find(buf []byte) []match {
+	var matches []match
+	for _, secret := range r.secrets {
+		for i := 0; ; {
+			j := bytes.Index(buf[i:], secret)
+			if j < 0 {
+				break
+			}
+			start := i + j
+			end := start + len(secret)
+			matches = append(matches, match{start, end, start, end})
+			i = end
+		}
+	}
+	for _, p := range r.patterns {
+		for _, loc := range p.FindAllSubmatchIndex(buf, -1) {
+			m := match{loc[0], loc[1], loc[0], loc[1]}
+			if len(loc) > 2 && loc[2] >= 0 {
+				m.maskStart, m.maskEnd = loc[2], loc[3]
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// merge returns the portions to be masked of those matches that lie wholly
+// before the cut, sorted and with overlapping portions merged.
+func merge(matches []match, cut int) []match {
+	var masks []match
+	for _, m := range matches {
+		if m.end <= cut {
+			masks = append(masks, m)
+		}
+	}
+	sort.Slice(masks, func(i, j int) bool {
+		return masks[i].maskStart < masks[j].maskStart
+	})
+	var merged []match
+	for _, m := range masks {
+		if n := len(merged); n > 0 && m.maskStart <= merged[n-1].maskEnd {
+			merged[n-1].maskEnd = max(merged[n-1].maskEnd, m.maskEnd)
+			continue
+		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+// redactorTTL is the length of time a run's redactor is cached before it is
+// re-constructed, picking up any changes to its workspace.
+const redactorTTL = time.Minute
+
+type (
+	// redactorCache constructs and caches redactors for runs.
+	redactorCache struct {
+		runs          redactorRunClient
+		workspaces    redactorWorkspaceClient
+		variables     redactorVariableClient
+		organizations redactorOrganizationClient
+
+		mu      sync.Mutex
+		entries map[string]cachedRedactor // keyed by run ID
+	}
+
+	cachedRedactor struct {
+		*redactor
+		created time.Time
+	}
+
+	redactorRunClient interface {
+		Get(ctx context.Context, runID string) (*run.Run, error)
+	}
+
+	redactorWorkspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+
+	redactorVariableClient interface {
+		ListEffectiveVariables(ctx context.Context, runID string) ([]*variable.Variable, error)
+	}
+
+	redactorOrganizationClient interface {
+		Get(ctx context.Context, name string) (*organization.Organization, error)
+	}
+)
+
+// get retrieves the redactor for a run, which masks sensitive variables and
+// matches of the organization's redaction patterns. If scrubbing is disabled on
+// the run's workspace then a redactor is returned that masks nothing.
+func (c *redactorCache) get(ctx context.Context, runID string) (*redactor, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	if entry, ok := c.entries[runID]; ok && now.Sub(entry.created) < redactorTTL {
+		c.mu.Unlock()
+		return entry.redactor, nil
+	}
+	c.mu.Unlock()
+
+	// the caller may not be permitted to retrieve variables, so retrieve them
+	// as a superuser.
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "logs-redactor"})
+
+	r, err := c.runs.Get(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving run: %w", err)
+	}
+	ws, err := c.workspaces.Get(ctx, r.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving workspace: %w", err)
+	}
+	redactor := noopRedactor
+	if !ws.LogScrubbingDisabled {
+		vars, err := c.variables.ListEffectiveVariables(ctx, runID)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving variables: %w", err)
+		}
+		var sensitive []string
+		for _, v := range vars {
+			if v.Sensitive {
+				sensitive = append(sensitive, v.Value)
+			}
+		}
+		org, err := c.organizations.Get(ctx, ws.Organization)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving organization: %w", err)
+		}
+		patterns := make([]*regexp.Regexp, len(org.LogRedactionPatterns))
+		for i, p := range org.LogRedactionPatterns {
+			patterns[i], err = regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("compiling log redaction pattern: %w", err)
+			}
+		}
+		redactor = newRedactor(sensitive...)
+		redactor.addPatterns(patterns...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// evict expired entries
+	for id, entry := range c.entries {
+		if now.Sub(entry.created) >= redactorTTL {
+			delete(c.entries, id)
+		}
+	}
+	c.entries[runID] = cachedRedactor{redactor: redactor, created: now}
+	return redactor, nil
+}
@@ -2,6 +2,7 @@ package logs
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/gorilla/mux"
@@ -11,6 +12,18 @@ import (
 	"github.com/tofutf/tofutf/internal/sql"
 )
 
+// DefaultMaxRunLogSize is the default maximum total size in bytes of the logs
+// for all phases of a run.
+const DefaultMaxRunLogSize = 100 * 1024 * 1024
+
+// DefaultMaxDebugRunLogSize is the default maximum total size in bytes of the
+// logs for all phases of a run with debug logging enabled.
+const DefaultMaxDebugRunLogSize = 5 * DefaultMaxRunLogSize
+
+// DefaultMaxConcurrentWrites is the default maximum number of chunks of logs
+// written concurrently.
+const DefaultMaxConcurrentWrites = 32
+
 type (
 	Service struct {
 		logger *slog.Logger
@@ -22,12 +35,14 @@ type (
 		broker pubsub.SubscriptionService[internal.Chunk]
 
 		chunkproxy
+		redactors *redactorCache
 	}
 
 	chunkproxy interface {
 		Start(ctx context.Context) error
 		get(ctx context.Context, opts internal.GetChunkOptions) (internal.Chunk, error)
-		put(ctx context.Context, opts internal.PutChunkOptions) error
+		put(ctx context.Context, opts internal.PutChunkOptions, r *redactor) error
+		markIncomplete(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream, r *redactor) error
 	}
 
 	Options struct {
@@ -38,14 +53,43 @@ type (
 		internal.Verifier
 
 		RunAuthorizer internal.Authorizer
+
+		// MaxRunLogSize is the maximum total size in bytes of the logs for
+		// all phases of a run, beyond which logs are truncated. Zero means no
+		// limit.
+		MaxRunLogSize int
+		// MaxDebugRunLogSize is the maximum total size in bytes of the logs
+		// for all phases of a run with debug logging enabled, which is used
+		// in place of MaxRunLogSize if larger. Zero means no limit.
+		MaxDebugRunLogSize int
+		// MaxConcurrentWrites is the maximum number of chunks of logs written
+		// concurrently, beyond which clients are asked to slow down. Zero
+		// means no limit.
+		MaxConcurrentWrites int
+
+		RunService          redactorRunClient
+		WorkspaceService    redactorWorkspaceClient
+		VariableService     redactorVariableClient
+		OrganizationService redactorOrganizationClient
 	}
 )
 
 func NewService(opts Options) *Service {
-	db := &pgdb{opts.Pool}
+	db := &pgdb{
+		Pool:            opts.Pool,
+		maxRunSize:      opts.MaxRunLogSize,
+		maxDebugRunSize: opts.MaxDebugRunLogSize,
+	}
 	svc := Service{
 		logger: opts.Logger,
 		run:    opts.RunAuthorizer,
+		redactors: &redactorCache{
+			runs:          opts.RunService,
+			workspaces:    opts.WorkspaceService,
+			variables:     opts.VariableService,
+			organizations: opts.OrganizationService,
+			entries:       make(map[string]cachedRedactor),
+		},
 	}
 	svc.api = &api{
 		Verifier: opts.Verifier,
@@ -66,12 +110,16 @@ func NewService(opts Options) *Service {
 			return db.getChunk(ctx, id)
 		},
 	)
-	svc.chunkproxy = &proxy{
+	p := &proxy{
 		logger: opts.Logger,
 		cache:  opts.Cache,
 		db:     db,
 		broker: svc.broker,
 	}
+	if opts.MaxConcurrentWrites > 0 {
+		p.writes = make(chan struct{}, opts.MaxConcurrentWrites)
+	}
+	svc.chunkproxy = p
 	return &svc
 }
 
@@ -84,7 +132,7 @@ func (s *Service) WatchLogs(ctx context.Context) (<-chan pubsub.Event[internal.C
 	return s.broker.Subscribe(ctx)
 }
 
-// GetChunk reads a chunk of logs for a phase.
+// GetChunk reads a chunk of a stream of logs for a phase.
 //
 // NOTE: unauthenticated - access granted only via signed URL
 func (s *Service) GetChunk(ctx context.Context, opts internal.GetChunkOptions) (internal.Chunk, error) {
@@ -97,23 +145,63 @@ func (s *Service) GetChunk(ctx context.Context, opts internal.GetChunkOptions) (
 	return logs, nil
 }
 
-// PutChunk writes a chunk of logs for a phase
+// PutChunk writes a chunk of a stream of logs for a phase. Secrets are masked
+// before the chunk is persisted, unless disabled on the run's workspace.
+//
+// The chunk must begin at the offset at which the previous chunk for the
+// stream ended, otherwise an *internal.ChunkOffsetError is returned, informing the
+// caller of the offset from which to resume. If too many chunks are being
+// written concurrently then an *internal.BackpressureError is returned, asking
+// the caller to slow down.
 func (s *Service) PutChunk(ctx context.Context, opts internal.PutChunkOptions) error {
 	_, err := s.run.CanAccess(ctx, rbac.PutChunkAction, opts.RunID)
 	if err != nil {
 		return err
 	}
 
-	if err := s.chunkproxy.put(ctx, opts); err != nil {
-		s.logger.Error("writing logs", "id", opts.RunID, "phase", opts.Phase, "offset", opts.Offset, "err", err)
+	redactor, err := s.redactors.get(ctx, opts.RunID)
+	if err != nil {
+		s.logger.Error("writing logs", "id", opts.RunID, "phase", opts.Phase, "stream", opts.Stream, "offset", opts.Offset, "err", err)
+		return err
+	}
+	if err := s.chunkproxy.put(ctx, opts, redactor); err != nil {
+		var backpressureErr *internal.BackpressureError
+		if errors.As(err, &backpressureErr) {
+			s.logger.Warn("throttling logs", "id", opts.RunID, "phase", opts.Phase, "stream", opts.Stream, "offset", opts.Offset, "retry_after", backpressureErr.RetryAfter)
+			return err
+		}
+		s.logger.Error("writing logs", "id", opts.RunID, "phase", opts.Phase, "stream", opts.Stream, "offset", opts.Offset, "err", err)
+		return err
+	}
+	s.logger.Debug("written logs", "id", opts.RunID, "phase", opts.Phase, "stream", opts.Stream, "offset", opts.Offset)
+
+	return nil
+}
+
+// MarkLogsIncomplete finishes a stream of logs for a phase with a notice that
+// they are incomplete, for use when the caller has given up uploading them. No
+// further chunks are then accepted for the stream.
+func (s *Service) MarkLogsIncomplete(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream) error {
+	_, err := s.run.CanAccess(ctx, rbac.PutChunkAction, runID)
+	if err != nil {
+		return err
+	}
+
+	redactor, err := s.redactors.get(ctx, runID)
+	if err != nil {
+		s.logger.Error("marking logs incomplete", "id", runID, "phase", phase, "stream", stream, "err", err)
+		return err
+	}
+	if err := s.chunkproxy.markIncomplete(ctx, runID, phase, stream, redactor); err != nil {
+		s.logger.Error("marking logs incomplete", "id", runID, "phase", phase, "stream", stream, "err", err)
 		return err
 	}
-	s.logger.Debug("written logs", "id", opts.RunID, "phase", opts.Phase, "offset", opts.Offset)
+	s.logger.Warn("marked logs incomplete", "id", runID, "phase", phase, "stream", stream)
 
 	return nil
 }
 
-// Tail logs for a phase. Offset specifies the number of bytes into the logs
+// Tail a stream of logs for a phase. Offset specifies the number of bytes into the logs
 // from which to start tailing.
 func (s *Service) Tail(ctx context.Context, opts internal.GetChunkOptions) (<-chan internal.Chunk, error) {
 	subject, err := s.run.CanAccess(ctx, rbac.TailLogsAction, opts.RunID)
@@ -143,8 +231,8 @@ func (s *Service) Tail(ctx context.Context, opts internal.GetChunkOptions) (<-ch
 		// relay chunks from subscription
 		for ev := range sub {
 			chunk := ev.Payload
-			if opts.RunID != chunk.RunID || opts.Phase != chunk.Phase {
-				// skip logs for different run/phase
+			if opts.RunID != chunk.RunID || opts.Phase != chunk.Phase || opts.Stream != chunk.Stream {
+				// skip logs for different run/phase/stream
 				continue
 			}
 			if chunk.Offset < opts.Offset {
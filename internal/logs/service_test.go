@@ -184,4 +184,40 @@ func TestTail(t *testing.T) {
 		// chunk for other run is skipped but chunk for this run is received
 		assert.Equal(t, want, <-stream)
 	})
+	t.Run("ignore chunk for other stream", func(t *testing.T) {
+		sub := make(chan pubsub.Event[internal.Chunk])
+		svc := &Service{
+			chunkproxy: &fakeTailProxy{},
+			broker:     &fakeSubService{stream: sub},
+			logger:     slog.New(&xslog.NoopHandler{}),
+			run:        &fakeAuthorizer{},
+		}
+
+		stream, err := svc.Tail(ctx, internal.GetChunkOptions{
+			RunID: "run-123",
+			Phase: internal.PlanPhase,
+		})
+		require.NoError(t, err)
+
+		// publish chunk for machine-readable stream
+		sub <- pubsub.Event[internal.Chunk]{
+			Payload: internal.Chunk{
+				RunID:  "run-123",
+				Phase:  internal.PlanPhase,
+				Stream: internal.JSONLogStream,
+				Data:   []byte(`\x02{"@message":"hello"}`),
+			},
+		}
+
+		// publish chunk for human-readable stream
+		want := internal.Chunk{
+			RunID: "run-123",
+			Phase: internal.PlanPhase,
+			Data:  []byte("\x02hello"),
+		}
+		sub <- pubsub.Event[internal.Chunk]{Payload: want}
+		// chunk for other stream is skipped but chunk for tailed stream is
+		// received
+		assert.Equal(t, want, <-stream)
+	})
 }
@@ -19,6 +19,14 @@ type (
 		proxydb
 	}
 
+	// fakeBlockingDB blocks writes until released, notifying when each write
+	// starts.
+	fakeBlockingDB struct {
+		started chan struct{}
+		release chan struct{}
+		proxydb
+	}
+
 	fakeTailProxy struct {
 		// fake chunk to return
 		chunk internal.Chunk
@@ -30,7 +38,7 @@ type (
 
 func newFakeCache(keyvalues ...string) *fakeCache {
 	cache := make(map[string][]byte, len(keyvalues)/2)
-	for i := 0; i < len(keyvalues)/2; i += 2 {
+	for i := 0; i < len(keyvalues)-1; i += 2 {
 		cache[keyvalues[i]] = []byte(keyvalues[i+1])
 	}
 	return &fakeCache{cache}
@@ -49,10 +57,16 @@ func (c *fakeCache) Get(key string) ([]byte, error) {
 	return val, nil
 }
 
-func (s *fakeDB) getLogs(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error) {
+func (s *fakeDB) getLogs(ctx context.Context, runID string, phase internal.PhaseType, stream internal.LogStream) ([]byte, error) {
 	return s.data, nil
 }
 
+func (s *fakeBlockingDB) put(ctx context.Context, opts internal.PutChunkOptions, r *redactor) (string, error) {
+	s.started <- struct{}{}
+	<-s.release
+	return "", nil
+}
+
 func (f *fakeTailProxy) get(ctx context.Context, opts internal.GetChunkOptions) (internal.Chunk, error) {
 	return f.chunk, nil
 }
@@ -72,5 +86,25 @@ func (f *fakeSubService) Subscribe(ctx context.Context) (<-chan pubsub.Event[int
 		<-ctx.Done()
 		close(f.stream)
 	}()
-	return f.stream, nil
+	return f.stream, func() {}
+}
+
+// fakeOpenSubService provides a subscription that is never closed.
+type fakeOpenSubService struct {
+	pubsub.SubscriptionService[internal.Chunk]
+}
+
+func (f *fakeOpenSubService) Subscribe(ctx context.Context) (<-chan pubsub.Event[internal.Chunk], func()) {
+	return make(chan pubsub.Event[internal.Chunk]), func() {}
+}
+
+// fakeTerminatedSubService provides a subscription that has been terminated.
+type fakeTerminatedSubService struct {
+	pubsub.SubscriptionService[internal.Chunk]
+}
+
+func (f *fakeTerminatedSubService) Subscribe(ctx context.Context) (<-chan pubsub.Event[internal.Chunk], func()) {
+	sub := make(chan pubsub.Event[internal.Chunk])
+	close(sub)
+	return sub, func() {}
 }
@@ -0,0 +1,126 @@
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is the maintenance mode database on postgres
+	pgdb struct {
+		*sql.Pool // provides access to generated SQL queries
+	}
+
+	// row represents the database row for the maintenance mode
+	row struct {
+		MaintenanceModeID pgtype.Text        `json:"maintenance_mode_id"`
+		Enabled           pgtype.Bool        `json:"enabled"`
+		Message           pgtype.Text        `json:"message"`
+		StartsAt          pgtype.Timestamptz `json:"starts_at"`
+		EndsAt            pgtype.Timestamptz `json:"ends_at"`
+		UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+		UpdatedBy         pgtype.Text        `json:"updated_by"`
+	}
+)
+
+func (r row) toMode() *Mode {
+	mode := &Mode{
+		Enabled:   r.Enabled.Bool,
+		Message:   r.Message.String,
+		UpdatedAt: r.UpdatedAt.Time.UTC(),
+		UpdatedBy: r.UpdatedBy.String,
+	}
+	if r.StartsAt.Valid {
+		mode.StartsAt = internal.Time(r.StartsAt.Time.UTC())
+	}
+	if r.EndsAt.Valid {
+		mode.EndsAt = internal.Time(r.EndsAt.Time.UTC())
+	}
+	return mode
+}
+
+func (db *pgdb) upsert(ctx context.Context, mode *Mode) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertMaintenanceMode(ctx, pggen.UpsertMaintenanceModeParams{
+			Enabled:   sql.Bool(mode.Enabled),
+			Message:   sql.String(mode.Message),
+			StartsAt:  sql.TimestamptzPtr(mode.StartsAt),
+			EndsAt:    sql.TimestamptzPtr(mode.EndsAt),
+			UpdatedAt: sql.Timestamptz(mode.UpdatedAt),
+			UpdatedBy: sql.String(mode.UpdatedBy),
+		})
+		return err
+	})
+}
+
+// get retrieves the maintenance mode. If maintenance mode has never been
+// configured then a disabled mode is returned.
+func (db *pgdb) get(ctx context.Context) (*Mode, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Mode, error) {
+		result, err := q.FindMaintenanceMode(ctx)
+		if err != nil {
+			err = sql.Error(err)
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				return &Mode{}, nil
+			}
+			return nil, err
+		}
+		return row(result).toMode(), nil
+	})
+}
+
+func (db *pgdb) createChange(ctx context.Context, change *Change) error {
+	oldMode, err := json.Marshal(change.OldMode)
+	if err != nil {
+		return err
+	}
+	newMode, err := json.Marshal(change.NewMode)
+	if err != nil {
+		return err
+	}
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertMaintenanceModeChange(ctx, pggen.InsertMaintenanceModeChangeParams{
+			MaintenanceModeChangeID: sql.String(change.ID),
+			ChangedAt:               sql.Timestamptz(change.ChangedAt),
+			ChangedBy:               sql.String(change.ChangedBy),
+			OldMode:                 oldMode,
+			NewMode:                 newMode,
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+// listChanges lists the changes made to the maintenance mode, most recent
+// first.
+func (db *pgdb) listChanges(ctx context.Context) ([]*Change, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Change, error) {
+		rows, err := q.FindMaintenanceModeChanges(ctx)
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		changes := make([]*Change, len(rows))
+		for i, row := range rows {
+			changes[i] = &Change{
+				ID:        row.MaintenanceModeChangeID.String,
+				ChangedAt: row.ChangedAt.Time.UTC(),
+				ChangedBy: row.ChangedBy.String,
+			}
+			if err := json.Unmarshal(row.OldMode, &changes[i].OldMode); err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(row.NewMode, &changes[i].NewMode); err != nil {
+				return nil, err
+			}
+		}
+		return changes, nil
+	})
+}
@@ -0,0 +1,110 @@
+// Package maintenance provides a site-wide maintenance mode, during which the
+// scheduling of runs and the allocation of jobs is paused.
+package maintenance
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("maintenance mode must end after it starts")
+
+type (
+	// Mode is the site-wide maintenance mode. Whilst maintenance mode is
+	// active pending runs are held back and jobs are not allocated to agents,
+	// but in-progress phases are permitted to finish and the API and web app
+	// continue to serve requests, other than API requests to create runs,
+	// which are rejected.
+	Mode struct {
+		// Enabled is true if a site admin has switched maintenance mode on.
+		// Whether it is in effect is determined by Active, which takes into
+		// account the optional start and end times.
+		Enabled bool `json:"enabled"`
+		// Message to display to users explaining the pause.
+		Message string `json:"message,omitempty"`
+		// StartsAt is the optional time at which maintenance mode
+		// automatically takes effect.
+		StartsAt *time.Time `json:"starts_at,omitempty"`
+		// EndsAt is the optional time at which maintenance mode automatically
+		// ends.
+		EndsAt *time.Time `json:"ends_at,omitempty"`
+		// UpdatedAt is the time the mode was last updated.
+		UpdatedAt time.Time `json:"-"`
+		// UpdatedBy is the subject that last updated the mode.
+		UpdatedBy string `json:"-"`
+	}
+
+	// Change is an audit record of an update to the maintenance mode.
+	Change struct {
+		ID        string
+		ChangedAt time.Time
+		ChangedBy string // subject that made the change
+		OldMode   *Mode
+		NewMode   *Mode
+	}
+
+	UpdateOptions struct {
+		Enabled  bool
+		Message  string
+		StartsAt *time.Time
+		EndsAt   *time.Time
+	}
+)
+
+func newMode(opts UpdateOptions, now time.Time, subject string) (*Mode, error) {
+	if opts.StartsAt != nil && opts.EndsAt != nil && !opts.EndsAt.After(*opts.StartsAt) {
+		return nil, ErrInvalidSchedule
+	}
+	return &Mode{
+		Enabled:   opts.Enabled,
+		Message:   opts.Message,
+		StartsAt:  opts.StartsAt,
+		EndsAt:    opts.EndsAt,
+		UpdatedAt: now,
+		UpdatedBy: subject,
+	}, nil
+}
+
+// Active determines whether maintenance mode is in effect at the given time.
+func (m *Mode) Active(now time.Time) bool {
+	if !m.Enabled {
+		return false
+	}
+	if m.StartsAt != nil && now.Before(*m.StartsAt) {
+		return false
+	}
+	if m.EndsAt != nil && !now.Before(*m.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// nextTransition returns the next time after now at which maintenance mode
+// either automatically takes effect or automatically ends. Nil is returned if
+// there is no such time.
+func (m *Mode) nextTransition(now time.Time) *time.Time {
+	if !m.Enabled {
+		return nil
+	}
+	if m.StartsAt != nil && now.Before(*m.StartsAt) {
+		return m.StartsAt
+	}
+	if m.EndsAt != nil && now.Before(*m.EndsAt) {
+		return m.EndsAt
+	}
+	return nil
+}
+
+func (m *Mode) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Bool("enabled", m.Enabled),
+	}
+	if m.StartsAt != nil {
+		attrs = append(attrs, slog.Time("starts_at", *m.StartsAt))
+	}
+	if m.EndsAt != nil {
+		attrs = append(attrs, slog.Time("ends_at", *m.EndsAt))
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,100 @@
+package maintenance
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestMode_Active(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		mode     Mode
+		want     bool
+		wantNext *time.Time
+	}{
+		{"disabled", Mode{}, false, nil},
+		{"disabled with schedule", Mode{StartsAt: &past, EndsAt: &future}, false, nil},
+		{"enabled", Mode{Enabled: true}, true, nil},
+		{"scheduled to start", Mode{Enabled: true, StartsAt: &future}, false, &future},
+		{"started", Mode{Enabled: true, StartsAt: &past}, true, nil},
+		{"scheduled to end", Mode{Enabled: true, EndsAt: &future}, true, &future},
+		{"ended", Mode{Enabled: true, EndsAt: &past}, false, nil},
+		{"within schedule", Mode{Enabled: true, StartsAt: &past, EndsAt: &future}, true, &future},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.mode.Active(now))
+			assert.Equal(t, tt.wantNext, tt.mode.nextTransition(now))
+		})
+	}
+}
+
+func TestNewMode(t *testing.T) {
+	now := internal.CurrentTimestamp(nil)
+	later := now.Add(time.Hour)
+
+	t.Run("valid schedule", func(t *testing.T) {
+		mode, err := newMode(UpdateOptions{Enabled: true, StartsAt: &now, EndsAt: &later}, now, "bobby")
+		assert.NoError(t, err)
+		assert.Equal(t, "bobby", mode.UpdatedBy)
+		assert.Equal(t, now, mode.UpdatedAt)
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		_, err := newMode(UpdateOptions{Enabled: true, StartsAt: &later, EndsAt: &now}, now, "bobby")
+		assert.ErrorIs(t, err, ErrInvalidSchedule)
+	})
+}
+
+func TestService_Middleware(t *testing.T) {
+	later := internal.CurrentTimestamp(nil).Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		mode     *Mode
+		method   string
+		path     string
+		wantCode int
+	}{
+		{"permit create run when inactive", &Mode{}, http.MethodPost, "/api/v2/runs", http.StatusOK},
+		{"permit get run when active", &Mode{Enabled: true}, http.MethodGet, "/api/v2/runs/run-123", http.StatusOK},
+		{"permit apply run when active", &Mode{Enabled: true}, http.MethodPost, "/api/v2/runs/run-123/actions/apply", http.StatusOK},
+		{"reject create run when active", &Mode{Enabled: true, Message: "upgrading database"}, http.MethodPost, "/api/v2/runs", http.StatusServiceUnavailable},
+		{"reject create run with retry", &Mode{Enabled: true, EndsAt: &later}, http.MethodPost, "/api/v2/runs", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &Service{
+				logger: slog.New(&xslog.NoopHandler{}),
+				cached: tt.mode,
+			}
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			svc.Middleware()(next).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.mode.Enabled {
+				assert.Equal(t, "active", w.Header().Get(HeaderName))
+			}
+			if tt.wantCode == http.StatusServiceUnavailable {
+				assert.Contains(t, w.Body.String(), "Maintenance mode is active")
+				assert.Contains(t, w.Body.String(), tt.mode.Message)
+				assert.Equal(t, tt.mode.EndsAt != nil, w.Header().Get("Retry-After") != "")
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package maintenance
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+// HeaderName is the name of the header added to responses whilst maintenance
+// mode is active.
+const HeaderName = "X-Maintenance-Mode"
+
+type (
+	Service struct {
+		logger *slog.Logger
+
+		site   internal.Authorizer
+		db     *pgdb
+		web    *webHandlers
+		tfeapi *tfe
+		broker *pubsub.Broker[*Mode]
+
+		// cached copy of the mode, kept up to date with database events, for
+		// use by the middleware.
+		cached *Mode
+		mu     sync.Mutex
+	}
+
+	Options struct {
+		Logger *slog.Logger
+
+		*sql.Pool
+		*sql.Listener
+		*tfeapi.Responder
+		html.Renderer
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		logger: opts.Logger,
+		site:   &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:     &pgdb{opts.Pool},
+	}
+	svc.web = &webHandlers{
+		Renderer: opts.Renderer,
+		svc:      &svc,
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	svc.broker = pubsub.NewBroker(
+		opts.Logger,
+		opts.Listener,
+		"maintenance_mode",
+		func(ctx context.Context, id string, action sql.Action) (*Mode, error) {
+			if action == sql.DeleteAction {
+				return &Mode{}, nil
+			}
+			mode, err := svc.db.get(ctx)
+			if err != nil {
+				return nil, err
+			}
+			svc.setCached(mode)
+			return mode, nil
+		},
+	)
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.web.addHandlers(r)
+	s.tfeapi.addHandlers(r)
+}
+
+// Get retrieves the maintenance mode. Any subject may retrieve the mode, in
+// order that the reason for any pause can be explained to all users.
+func (s *Service) Get(ctx context.Context) (*Mode, error) {
+	return s.db.get(ctx)
+}
+
+// Update updates the maintenance mode, replacing its existing settings. Only a
+// site admin may update the mode.
+func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*Mode, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.UpdateMaintenanceModeAction, "")
+	if err != nil {
+		return nil, err
+	}
+	mode, err := newMode(opts, internal.CurrentTimestamp(nil), subject.String())
+	if err != nil {
+		s.logger.Error("updating maintenance mode", "subject", subject, "err", err)
+		return nil, err
+	}
+	// persist the mode along with an audit record of the change.
+	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		old, err := s.db.get(ctx)
+		if err != nil {
+			return err
+		}
+		if err := s.db.upsert(ctx, mode); err != nil {
+			return err
+		}
+		return s.db.createChange(ctx, &Change{
+			ID:        internal.NewID("mmc"),
+			ChangedAt: mode.UpdatedAt,
+			ChangedBy: mode.UpdatedBy,
+			OldMode:   old,
+			NewMode:   mode,
+		})
+	})
+	if err != nil {
+		s.logger.Error("updating maintenance mode", "subject", subject, "err", err)
+		return nil, err
+	}
+	s.setCached(mode)
+	s.logger.Info("updated maintenance mode", "mode", mode, "subject", subject)
+	return mode, nil
+}
+
+// ListChanges lists the audit records of updates to the maintenance mode,
+// most recent first. Only a site admin may list the changes.
+func (s *Service) ListChanges(ctx context.Context) ([]*Change, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.ListMaintenanceModeChangesAction, "")
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.db.listChanges(ctx)
+	if err != nil {
+		s.logger.Error("listing maintenance mode changes", "subject", subject, "err", err)
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Watch maintenance mode events.
+func (s *Service) Watch(ctx context.Context) (<-chan pubsub.Event[*Mode], func()) {
+	return s.broker.Subscribe(ctx)
+}
+
+// WatchActive returns a channel that first receives whether maintenance mode
+// is currently active, and then receives its new state each time it is either
+// activated or deactivated, whether due to the mode being updated or due to
+// its scheduled start or end time being reached. The channel is closed when
+// the context is canceled or the underlying subscription is terminated.
+func (s *Service) WatchActive(ctx context.Context) (<-chan bool, error) {
+	sub, unsub := s.Watch(ctx)
+	mode, err := s.Get(ctx)
+	if err != nil {
+		unsub()
+		return nil, err
+	}
+	ch := make(chan bool)
+	go func() {
+		defer close(ch)
+		defer unsub()
+
+		var last *bool
+		for {
+			now := internal.CurrentTimestamp(nil)
+			if active := mode.Active(now); last == nil || *last != active {
+				select {
+				case ch <- active:
+				case <-ctx.Done():
+					return
+				}
+				last = &active
+			}
+			// wake up either when the mode is updated or when its next
+			// scheduled transition is due.
+			var (
+				timer   *time.Timer
+				timerCh <-chan time.Time
+			)
+			if next := mode.nextTransition(now); next != nil {
+				timer = time.NewTimer(next.Sub(now))
+				timerCh = timer.C
+			}
+			select {
+			case event, ok := <-sub:
+				if !ok {
+					return
+				}
+				mode = event.Payload
+			case <-timerCh:
+			case <-ctx.Done():
+				return
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// Middleware informs clients whilst maintenance mode is active: web pages
+// display a banner explaining the pause, API requests to create runs are
+// rejected with an error explaining the pause, and all responses carry a
+// header.
+func (s *Service) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			now := internal.CurrentTimestamp(nil)
+			mode, err := s.getCached(r.Context())
+			if err != nil {
+				s.logger.Error("retrieving maintenance mode", "err", err)
+			} else if mode.Active(now) {
+				w.Header().Set(HeaderName, "active")
+				if isCreateRunRequest(r) {
+					if mode.EndsAt != nil {
+						w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(mode.EndsAt.Sub(now).Seconds()))))
+					}
+					tfeapi.Error(w, &internal.HTTPError{
+						Code:    http.StatusServiceUnavailable,
+						Message: message(mode, "runs cannot be created until it ends."),
+					})
+					return
+				}
+				r = r.WithContext(html.AddBanner(r.Context(), message(mode, "new runs will not start until it ends.")))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isCreateRunRequest determines whether the request is an API request to
+// create a run.
+func isCreateRunRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && r.URL.Path == tfeapi.APIPrefixV2+"runs"
+}
+
+func (s *Service) getCached(ctx context.Context) (*Mode, error) {
+	s.mu.Lock()
+	cached := s.cached
+	s.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+	mode, err := s.db.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.setCached(mode)
+	return mode, nil
+}
+
+func (s *Service) setCached(mode *Mode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cached = mode
+}
+
+// message explains to users that maintenance mode is active, along with the
+// consequence for the user.
+func message(mode *Mode, consequence string) string {
+	msg := "Maintenance mode is active: " + consequence
+	if mode.EndsAt != nil {
+		msg = "Maintenance mode is active until " + mode.EndsAt.Format(time.RFC1123) + ": " + consequence
+	}
+	if mode.Message != "" {
+		msg += " " + mode.Message
+	}
+	return msg
+}
@@ -0,0 +1,71 @@
+package maintenance
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/admin/maintenance-mode", a.getMaintenanceMode).Methods("GET")
+	r.HandleFunc("/admin/maintenance-mode", a.updateMaintenanceMode).Methods("PATCH")
+}
+
+func (a *tfe) getMaintenanceMode(w http.ResponseWriter, r *http.Request) {
+	mode, err := a.Service.Get(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toMode(mode), http.StatusOK)
+}
+
+func (a *tfe) updateMaintenanceMode(w http.ResponseWriter, r *http.Request) {
+	var params types.MaintenanceModeUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	mode, err := a.Service.Update(r.Context(), UpdateOptions{
+		Enabled:  params.Enabled,
+		Message:  params.Message,
+		StartsAt: params.StartsAt,
+		EndsAt:   params.EndsAt,
+	})
+	if errors.Is(err, ErrInvalidSchedule) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toMode(mode), http.StatusOK)
+}
+
+func (a *tfe) toMode(from *Mode) *types.MaintenanceMode {
+	return &types.MaintenanceMode{
+		ID:        "site",
+		Enabled:   from.Enabled,
+		Active:    from.Active(internal.CurrentTimestamp(nil)),
+		Message:   from.Message,
+		StartsAt:  from.StartsAt,
+		EndsAt:    from.EndsAt,
+		UpdatedAt: from.UpdatedAt,
+		UpdatedBy: from.UpdatedBy,
+	}
+}
@@ -0,0 +1,116 @@
+package maintenance
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+// scheduleLayout is the layout of the start and end times submitted by the
+// datetime-local inputs in the web form, interpreted as UTC.
+const scheduleLayout = "2006-01-02T15:04"
+
+type webHandlers struct {
+	html.Renderer
+
+	svc webClient
+}
+
+type webClient interface {
+	Get(ctx context.Context) (*Mode, error)
+	Update(ctx context.Context, opts UpdateOptions) (*Mode, error)
+	ListChanges(ctx context.Context) ([]*Change, error)
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/admin/maintenance", h.get).Methods("GET")
+	r.HandleFunc("/admin/maintenance/update", h.update).Methods("POST")
+}
+
+func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	mode, err := h.svc.Get(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var changes []*Change
+	if subject.CanAccessSite(rbac.ListMaintenanceModeChangesAction) {
+		changes, err = h.svc.ListChanges(r.Context())
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	h.Render("maintenance_get.tmpl", w, struct {
+		html.SitePage
+		*Mode
+		Active         bool
+		CanUpdate      bool
+		ScheduleLayout string
+		Changes        []*Change
+	}{
+		SitePage:       html.NewSitePage(r, "maintenance mode"),
+		Mode:           mode,
+		Active:         mode.Active(internal.CurrentTimestamp(nil)),
+		CanUpdate:      subject.CanAccessSite(rbac.UpdateMaintenanceModeAction),
+		ScheduleLayout: scheduleLayout,
+		Changes:        changes,
+	})
+}
+
+func (h *webHandlers) update(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Enabled  bool   `schema:"enabled"`
+		Message  string `schema:"message"`
+		StartsAt string `schema:"starts_at"`
+		EndsAt   string `schema:"ends_at"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	opts := UpdateOptions{
+		Enabled: params.Enabled,
+		Message: params.Message,
+	}
+	for _, field := range []struct {
+		value string
+		dst   **time.Time
+	}{
+		{params.StartsAt, &opts.StartsAt},
+		{params.EndsAt, &opts.EndsAt},
+	} {
+		if field.value == "" {
+			continue
+		}
+		t, err := time.Parse(scheduleLayout, field.value)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		*field.dst = &t
+	}
+
+	if _, err := h.svc.Update(r.Context(), opts); err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Maintenance(), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "updated maintenance mode")
+	http.Redirect(w, r, paths.Maintenance(), http.StatusFound)
+}
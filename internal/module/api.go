@@ -2,6 +2,8 @@ package module
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -9,12 +11,14 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/leg100/surl"
 	"github.com/tofutf/tofutf/internal"
+	otfapi "github.com/tofutf/tofutf/internal/api"
 	"github.com/tofutf/tofutf/internal/http/decode"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 )
 
 type api struct {
 	*surl.Signer
+	*tfeapi.Responder
 
 	svc *Service
 }
@@ -25,6 +29,18 @@ func (h *api) addHandlers(r *mux.Router) {
 	signed.Use(internal.VerifySignedURL(h.Signer))
 	signed.HandleFunc("/modules/download/{module_version_id}.tar.gz", h.downloadModuleVersion).Methods("GET")
 
+	// direct publication routes
+	otf := r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+	otf.HandleFunc("/modules/{module_id}/versions", h.uploadVersion).Methods("POST")
+
+	// sharing routes
+	otf.HandleFunc("/modules/{module_id}/shares", h.shareModule).Methods("POST")
+	otf.HandleFunc("/modules/{module_id}/shares", h.listModuleShares).Methods("GET")
+	otf.HandleFunc("/modules/{module_id}/downloads", h.listModuleDownloads).Methods("GET")
+	otf.HandleFunc("/organizations/{organization_name}/module-shares", h.shareRegistry).Methods("POST")
+	otf.HandleFunc("/organizations/{organization_name}/module-shares", h.listRegistryShares).Methods("GET")
+	otf.HandleFunc("/module-shares/{share_id}", h.revokeShare).Methods("DELETE")
+
 	// authenticated module api routes
 	//
 	// Implements the Module Registry Protocol:
@@ -63,7 +79,7 @@ func (h *api) listAvailableVersions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	mod, err := h.svc.GetModule(r.Context(), GetModuleOptions{
+	mod, err := h.svc.ResolveModule(r.Context(), GetModuleOptions{
 		Name:         params.Name,
 		Provider:     params.Provider,
 		Organization: params.Organization,
@@ -104,7 +120,7 @@ func (h *api) getModuleVersionDownloadLink(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	mod, err := h.svc.GetModule(r.Context(), GetModuleOptions{
+	mod, err := h.svc.ResolveModule(r.Context(), GetModuleOptions{
 		Name:         params.Name,
 		Provider:     params.Provider,
 		Organization: params.Organization,
@@ -125,6 +141,12 @@ func (h *api) getModuleVersionDownloadLink(w http.ResponseWriter, r *http.Reques
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// attribute the download to the organization whose registry address was
+	// used, which differs from the module's organization if it is shared.
+	if err := h.svc.recordDownload(r.Context(), version.ID, params.Organization); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	w.Header().Add("X-Terraform-Get", signed)
 	w.WriteHeader(http.StatusNoContent)
@@ -145,3 +167,163 @@ func (h *api) downloadModuleVersion(w http.ResponseWriter, r *http.Request) {
 
 	w.Write(tarball) //nolint:errcheck
 }
+
+// maxUploadSize is the maximum size of a multipart request uploading a module
+// version, i.e. its tarball and signature.
+const maxUploadSize = 32 << 20
+
+// uploadVersion publishes a module version from a multipart form containing
+// the version, the tarball and, optionally, a detached signature of the
+// tarball.
+func (h *api) uploadVersion(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := decode.Param("module_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	}
+	opts := UploadVersionOptions{
+		ModuleID: moduleID,
+		Version:  r.FormValue("version"),
+	}
+	opts.Tarball, err = readFormFile(r, "tarball")
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if _, _, err := r.FormFile("signature"); err == nil {
+		opts.Signature, err = readFormFile(r, "signature")
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+	}
+
+	modver, err := h.svc.UploadVersion(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	h.Respond(w, r, modver, http.StatusCreated)
+}
+
+func (h *api) shareModule(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := decode.Param("module_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts CreateModuleShareOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	share, err := h.svc.ShareModule(r.Context(), moduleID, opts)
+	if err != nil {
+		h.shareError(w, err)
+		return
+	}
+	h.Respond(w, r, share, http.StatusCreated)
+}
+
+func (h *api) shareRegistry(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts CreateModuleShareOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	share, err := h.svc.ShareRegistry(r.Context(), org, opts)
+	if err != nil {
+		h.shareError(w, err)
+		return
+	}
+	h.Respond(w, r, share, http.StatusCreated)
+}
+
+func (h *api) listModuleShares(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := decode.Param("module_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	shares, err := h.svc.ListModuleShares(r.Context(), moduleID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	h.Respond(w, r, shares, http.StatusOK)
+}
+
+func (h *api) listRegistryShares(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	shares, err := h.svc.ListRegistryShares(r.Context(), org)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	h.Respond(w, r, shares, http.StatusOK)
+}
+
+func (h *api) revokeShare(w http.ResponseWriter, r *http.Request) {
+	shareID, err := decode.Param("share_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if _, err := h.svc.RevokeModuleShare(r.Context(), shareID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *api) listModuleDownloads(w http.ResponseWriter, r *http.Request) {
+	moduleID, err := decode.Param("module_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	downloads, err := h.svc.ListModuleDownloads(r.Context(), moduleID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(downloads); err != nil {
+		tfeapi.Error(w, err)
+	}
+}
+
+func (h *api) shareError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrCannotShareWithSelf) {
+		err = &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		}
+	}
+	tfeapi.Error(w, err)
+}
+
+func readFormFile(r *http.Request, name string) ([]byte, error) {
+	f, _, err := r.FormFile(name)
+	if err != nil {
+		return nil, &internal.MissingParameterError{Parameter: name}
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
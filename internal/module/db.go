@@ -3,6 +3,7 @@ package module
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/tofutf/tofutf/internal/connections"
@@ -29,6 +30,16 @@ type (
 		ModuleConnection pggen.RepoConnections  `json:"module_connection"`
 		Versions         []pggen.ModuleVersions `json:"versions"`
 	}
+
+	// shareRow is a row from a database query for module shares.
+	shareRow struct {
+		ModuleShareID    pgtype.Text        `json:"module_share_id"`
+		CreatedAt        pgtype.Timestamptz `json:"created_at"`
+		OrganizationName pgtype.Text        `json:"organization_name"`
+		ModuleID         pgtype.Text        `json:"module_id"`
+		SharedWith       pgtype.Text        `json:"shared_with"`
+		RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
+	}
 )
 
 func (db *pgdb) createModule(ctx context.Context, mod *Module) error {
@@ -179,6 +190,144 @@ func (db *pgdb) getTarball(ctx context.Context, versionID string) ([]byte, error
 	})
 }
 
+func (db *pgdb) createShare(ctx context.Context, share *ModuleShare) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertModuleShare(ctx, pggen.InsertModuleShareParams{
+			ModuleShareID:    sql.String(share.ID),
+			CreatedAt:        sql.Timestamptz(share.CreatedAt),
+			OrganizationName: sql.String(share.Organization),
+			ModuleID:         sql.StringPtr(share.ModuleID),
+			SharedWith:       sql.StringPtr(share.SharedWith),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) getShare(ctx context.Context, shareID string) (*ModuleShare, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*ModuleShare, error) {
+		row, err := q.FindModuleShareByID(ctx, sql.String(shareID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return shareRow(row).toShare(), nil
+	})
+}
+
+func (db *pgdb) listSharesByModule(ctx context.Context, moduleID string) ([]*ModuleShare, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*ModuleShare, error) {
+		rows, err := q.FindModuleSharesByModuleID(ctx, sql.String(moduleID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		shares := make([]*ModuleShare, len(rows))
+		for i, r := range rows {
+			shares[i] = shareRow(r).toShare()
+		}
+		return shares, nil
+	})
+}
+
+func (db *pgdb) listSharesByOrganization(ctx context.Context, organization string) ([]*ModuleShare, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*ModuleShare, error) {
+		rows, err := q.FindModuleSharesByOrganization(ctx, sql.String(organization))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		shares := make([]*ModuleShare, len(rows))
+		for i, r := range rows {
+			shares[i] = shareRow(r).toShare()
+		}
+		return shares, nil
+	})
+}
+
+// listSharedModules lists the modules in other organizations with the given
+// name and provider that are shared with an organization, along with the
+// shares granting access to them.
+func (db *pgdb) listSharedModules(ctx context.Context, opts GetModuleOptions) ([]sharedModule, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]sharedModule, error) {
+		rows, err := q.FindSharedModules(ctx, pggen.FindSharedModulesParams{
+			Name:       sql.String(opts.Name),
+			Provider:   sql.String(opts.Provider),
+			SharedWith: sql.String(opts.Organization),
+		})
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		shared := make([]sharedModule, len(rows))
+		for i, r := range rows {
+			shared[i] = sharedModule{
+				ModuleID: r.SharedModuleID.String,
+				Share: shareRow{
+					ModuleShareID:    r.ModuleShareID,
+					CreatedAt:        r.CreatedAt,
+					OrganizationName: r.OrganizationName,
+					ModuleID:         r.ModuleID,
+					SharedWith:       r.SharedWith,
+					RevokedAt:        r.RevokedAt,
+				}.toShare(),
+			}
+		}
+		return shared, nil
+	})
+}
+
+func (db *pgdb) revokeShare(ctx context.Context, shareID string, revokedAt time.Time) (*ModuleShare, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*ModuleShare, error) {
+		row, err := q.RevokeModuleShareByID(ctx, sql.Timestamptz(revokedAt), sql.String(shareID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return shareRow(row).toShare(), nil
+	})
+}
+
+func (db *pgdb) createDownload(ctx context.Context, versionID, organization string, downloadedAt time.Time) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertModuleDownload(ctx, pggen.InsertModuleDownloadParams{
+			ModuleVersionID:  sql.String(versionID),
+			OrganizationName: sql.String(organization),
+			DownloadedAt:     sql.Timestamptz(downloadedAt),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) listDownloads(ctx context.Context, moduleID string) ([]ModuleDownloads, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]ModuleDownloads, error) {
+		rows, err := q.CountModuleDownloadsByModuleID(ctx, sql.String(moduleID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		downloads := make([]ModuleDownloads, len(rows))
+		for i, r := range rows {
+			downloads[i] = ModuleDownloads{
+				Organization: r.OrganizationName.String,
+				Downloads:    int(r.Downloads.Int64),
+			}
+		}
+		return downloads, nil
+	})
+}
+
+func (db *pgdb) listVersionIDsDownloadedBefore(ctx context.Context, moduleID, organization string, before time.Time) ([]string, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]string, error) {
+		rows, err := q.FindModuleVersionIDsDownloadedBefore(ctx, pggen.FindModuleVersionIDsDownloadedBeforeParams{
+			ModuleID:         sql.String(moduleID),
+			OrganizationName: sql.String(organization),
+			DownloadedBefore: sql.Timestamptz(before),
+		})
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		ids := make([]string, len(rows))
+		for i, r := range rows {
+			ids[i] = r.String
+		}
+		return ids, nil
+	})
+}
+
 // toModule converts a database row into a module
 func (row moduleRow) toModule() *Module {
 	module := &Module{
@@ -212,6 +361,26 @@ func (row moduleRow) toModule() *Module {
 	return module
 }
 
+// toShare converts a database row into a module share
+func (row shareRow) toShare() *ModuleShare {
+	share := &ModuleShare{
+		ID:           row.ModuleShareID.String,
+		CreatedAt:    row.CreatedAt.Time.UTC(),
+		Organization: row.OrganizationName.String,
+	}
+	if row.ModuleID.Valid {
+		share.ModuleID = &row.ModuleID.String
+	}
+	if row.SharedWith.Valid {
+		share.SharedWith = &row.SharedWith.String
+	}
+	if row.RevokedAt.Valid {
+		revokedAt := row.RevokedAt.Time.UTC()
+		share.RevokedAt = &revokedAt
+	}
+	return share
+}
+
 type byVersion []pggen.ModuleVersions
 
 func (v byVersion) Len() int      { return len(v) }
@@ -46,13 +46,13 @@ type (
 	ModuleStatus string
 
 	ModuleVersion struct {
-		ID          string
-		ModuleID    string
-		Version     string
-		CreatedAt   time.Time
-		UpdatedAt   time.Time
-		Status      ModuleVersionStatus
-		StatusError string
+		ID          string              `jsonapi:"primary,module-versions"`
+		ModuleID    string              `jsonapi:"attribute" json:"module-id"`
+		Version     string              `jsonapi:"attribute" json:"version"`
+		CreatedAt   time.Time           `jsonapi:"attribute" json:"created-at"`
+		UpdatedAt   time.Time           `jsonapi:"attribute" json:"updated-at"`
+		Status      ModuleVersionStatus `jsonapi:"attribute" json:"status"`
+		StatusError string              `jsonapi:"attribute" json:"status-error,omitempty"`
 		// TODO: download counters
 	}
 
@@ -78,6 +78,16 @@ type (
 		ModuleID string
 		Version  string
 	}
+	// UploadVersionOptions are options for publishing a module version by
+	// uploading its tarball.
+	UploadVersionOptions struct {
+		ModuleID string
+		Version  string
+		Tarball  []byte
+		// Signature is a detached signature of the tarball made by one of the
+		// organization's signing keys. Required if signatures are required.
+		Signature []byte
+	}
 	UpdateModuleVersionStatusOptions struct {
 		ID     string
 		Status ModuleVersionStatus
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/surl"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/connections"
+	"github.com/tofutf/tofutf/internal/gpgkeys"
 	"github.com/tofutf/tofutf/internal/http/html"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/rbac"
@@ -17,6 +20,7 @@ import (
 	"github.com/tofutf/tofutf/internal/semver"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
+	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/vcs"
 	"github.com/tofutf/tofutf/internal/vcsprovider"
 )
@@ -31,6 +35,16 @@ type (
 		web          *webHandlers
 		vcsproviders *vcsprovider.Service
 		connections  *connections.Service
+		signatures   signatureVerifier
+
+		requireSignatures bool
+		shareGracePeriod  time.Duration
+	}
+
+	// signatureVerifier verifies signatures with an organization's signing
+	// keys.
+	signatureVerifier interface {
+		Verify(ctx context.Context, opts gpgkeys.VerifyOptions) (*gpgkeys.GPGKey, error)
 	}
 
 	Options struct {
@@ -40,25 +54,41 @@ type (
 		*internal.HostnameService
 		*surl.Signer
 		html.Renderer
+		*tfeapi.Responder
 
 		RepohookService    *repohooks.Service
 		VCSProviderService *vcsprovider.Service
 		ConnectionsService *connections.Service
 		VCSEventSubscriber vcs.Subscriber
+		GPGKeyService      *gpgkeys.Service
+
+		// RequireSignatures requires module versions uploaded directly to be
+		// accompanied by a signature made by one of the organization's
+		// signing keys.
+		RequireSignatures bool
+
+		// ShareGracePeriod is the duration after a module share is revoked
+		// for which consumers can continue to download the versions they
+		// downloaded before it was revoked.
+		ShareGracePeriod time.Duration
 	}
 )
 
 func NewService(opts Options) *Service {
 	svc := Service{
-		logger:       opts.Logger,
-		connections:  opts.ConnectionsService,
-		organization: &organization.Authorizer{Logger: opts.Logger},
-		db:           &pgdb{opts.Pool},
-		vcsproviders: opts.VCSProviderService,
+		logger:            opts.Logger,
+		connections:       opts.ConnectionsService,
+		organization:      &organization.Authorizer{Logger: opts.Logger},
+		db:                &pgdb{opts.Pool},
+		vcsproviders:      opts.VCSProviderService,
+		signatures:        opts.GPGKeyService,
+		requireSignatures: opts.RequireSignatures,
+		shareGracePeriod:  opts.ShareGracePeriod,
 	}
 	svc.api = &api{
-		svc:    &svc,
-		Signer: opts.Signer,
+		svc:       &svc,
+		Signer:    opts.Signer,
+		Responder: opts.Responder,
 	}
 	svc.web = &webHandlers{
 		Renderer:     opts.Renderer,
@@ -212,6 +242,57 @@ func (s *Service) PublishVersion(ctx context.Context, opts PublishVersionOptions
 	return s.uploadVersion(ctx, modver.ID, tarball)
 }
 
+// UploadVersion publishes a module version by uploading its tarball, rather
+// than retrieving it from a VCS repository. If a signature is provided, or
+// signatures are required, then the tarball must be signed by one of the
+// organization's unrevoked signing keys.
+func (s *Service) UploadVersion(ctx context.Context, opts UploadVersionOptions) (*ModuleVersion, error) {
+	module, err := s.db.getModuleByID(ctx, opts.ModuleID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateModuleVersionAction, module.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if !semver.IsValid(opts.Version) {
+		return nil, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf("invalid module version: %s", opts.Version),
+		}
+	}
+	if _, err := unmarshalTerraformModule(opts.Tarball); err != nil {
+		return nil, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf("invalid module tarball: %s", err.Error()),
+		}
+	}
+	if len(opts.Signature) > 0 || s.requireSignatures {
+		key, err := s.signatures.Verify(ctx, gpgkeys.VerifyOptions{
+			Organization: module.Organization,
+			Message:      opts.Tarball,
+			Signature:    opts.Signature,
+		})
+		if err != nil {
+			s.logger.Error("uploading module version", "module", module, "version", opts.Version, "subject", subject, "err", err)
+			return nil, err
+		}
+		s.logger.Info("verified module version signature", "module", module, "version", opts.Version, "key_id", key.KeyID)
+	}
+	modver, err := s.CreateVersion(ctx, CreateModuleVersionOptions{
+		ModuleID: module.ID,
+		Version:  opts.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.uploadVersion(ctx, modver.ID, opts.Tarball); err != nil {
+		return nil, err
+	}
+	modver.Status = ModuleVersionStatusOK
+	return modver, nil
+}
+
 func (s *Service) CreateModule(ctx context.Context, opts CreateOptions) (*Module, error) {
 	subject, err := s.organization.CanAccess(ctx, rbac.CreateModuleAction, opts.Organization)
 	if err != nil {
@@ -330,6 +411,9 @@ func (s *Service) RefreshModule(ctx context.Context, id string) (*Module, error)
 	if err != nil {
 		return nil, err
 	}
+	// refreshing is not urgent, so give way to other calls to the provider
+	// when its rate limit quota runs low.
+	ctx = vcs.WithBackgroundPriority(ctx)
 
 	tags, err := client.ListTags(ctx, vcs.ListTagsOptions{
 		Repo: module.Connection.Repo,
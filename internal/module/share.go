@@ -0,0 +1,318 @@
+package module
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+// DefaultShareGracePeriod is the default duration after a module share is
+// revoked for which consumers can continue to download the versions they
+// downloaded before it was revoked.
+const DefaultShareGracePeriod = 7 * 24 * time.Hour
+
+var (
+	ErrCannotShareWithSelf   = errors.New("modules cannot be shared with the organization they belong to")
+	ErrAmbiguousSharedModule = errors.New("more than one module shared with the organization matches")
+)
+
+type (
+	// ModuleShare shares an organization's modules with other organizations,
+	// permitting them to resolve the modules using addresses under their own
+	// registry.
+	ModuleShare struct {
+		ID        string    `jsonapi:"primary,module-shares"`
+		CreatedAt time.Time `jsonapi:"attribute" json:"created-at"`
+		// Organization is the organization sharing its modules.
+		Organization string `jsonapi:"attribute" json:"organization"`
+		// ModuleID is the module being shared. Nil shares every module in the
+		// organization's registry.
+		ModuleID *string `jsonapi:"attribute" json:"module-id,omitempty"`
+		// SharedWith is the organization the modules are shared with. Nil
+		// shares them with every organization.
+		SharedWith *string `jsonapi:"attribute" json:"shared-with,omitempty"`
+		// RevokedAt is when the share was revoked. Nil if it has not been
+		// revoked.
+		RevokedAt *time.Time `jsonapi:"attribute" json:"revoked-at,omitempty"`
+	}
+
+	CreateModuleShareOptions struct {
+		// SharedWith is the organization to share with. Nil shares with every
+		// organization.
+		SharedWith *string `json:"shared-with" schema:"shared_with"`
+	}
+
+	// ModuleDownloads is the number of downloads of a module's versions
+	// attributed to an organization.
+	ModuleDownloads struct {
+		Organization string `json:"organization"`
+		Downloads    int    `json:"downloads"`
+	}
+
+	// sharedModule is a module in another organization along with a share
+	// granting an organization access to it.
+	sharedModule struct {
+		ModuleID string
+		Share    *ModuleShare
+	}
+
+	// shareAccess is an organization's access to a module shared with it.
+	shareAccess struct {
+		ModuleID string
+		// RevokedAt is set if the module is only accessible through a share
+		// revoked within the grace period, in which case only the versions
+		// downloaded before it was revoked are accessible.
+		RevokedAt *time.Time
+	}
+)
+
+func newModuleShare(organization string, moduleID *string, opts CreateModuleShareOptions) (*ModuleShare, error) {
+	if opts.SharedWith != nil && *opts.SharedWith == "" {
+		// an empty organization from a web form shares with every organization
+		opts.SharedWith = nil
+	}
+	if opts.SharedWith != nil && *opts.SharedWith == organization {
+		return nil, ErrCannotShareWithSelf
+	}
+	return &ModuleShare{
+		ID:           internal.NewID("modshare"),
+		CreatedAt:    internal.CurrentTimestamp(nil),
+		Organization: organization,
+		ModuleID:     moduleID,
+		SharedWith:   opts.SharedWith,
+	}, nil
+}
+
+// Global determines whether the share is with every organization.
+func (s *ModuleShare) Global() bool { return s.SharedWith == nil }
+
+// Revoked determines whether the share has been revoked.
+func (s *ModuleShare) Revoked() bool { return s.RevokedAt != nil }
+
+func (s *ModuleShare) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", s.ID),
+		slog.String("organization", s.Organization),
+	}
+	if s.ModuleID != nil {
+		attrs = append(attrs, slog.String("module_id", *s.ModuleID))
+	}
+	if s.SharedWith != nil {
+		attrs = append(attrs, slog.String("shared_with", *s.SharedWith))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// resolveSharedModule determines which of the modules shared with an
+// organization it can access. A module is accessible through an unrevoked
+// share, or through a share revoked within the grace period. An error is
+// returned if no module is accessible, or if more than one is.
+func resolveSharedModule(shared []sharedModule, now time.Time, grace time.Duration) (*shareAccess, error) {
+	accessible := make(map[string]*shareAccess)
+	var ids []string
+	for _, sm := range shared {
+		if sm.Share.Revoked() && !sm.Share.RevokedAt.Add(grace).After(now) {
+			// grace period has elapsed
+			continue
+		}
+		access, ok := accessible[sm.ModuleID]
+		if !ok {
+			access = &shareAccess{ModuleID: sm.ModuleID, RevokedAt: sm.Share.RevokedAt}
+			accessible[sm.ModuleID] = access
+			ids = append(ids, sm.ModuleID)
+			continue
+		}
+		switch {
+		case access.RevokedAt == nil:
+			// already accessible through an unrevoked share
+		case !sm.Share.Revoked():
+			access.RevokedAt = nil
+		case sm.Share.RevokedAt.After(*access.RevokedAt):
+			// prefer the most recently revoked share, which permits the
+			// most versions
+			access.RevokedAt = sm.Share.RevokedAt
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return nil, internal.ErrResourceNotFound
+	case 1:
+		return accessible[ids[0]], nil
+	default:
+		return nil, ErrAmbiguousSharedModule
+	}
+}
+
+// ShareModule shares a module with another organization, or with every
+// organization.
+func (s *Service) ShareModule(ctx context.Context, moduleID string, opts CreateModuleShareOptions) (*ModuleShare, error) {
+	module, err := s.db.getModuleByID(ctx, moduleID)
+	if err != nil {
+		return nil, err
+	}
+	return s.createShare(ctx, module.Organization, &module.ID, opts)
+}
+
+// ShareRegistry shares every module in an organization's registry with another
+// organization, or with every organization.
+func (s *Service) ShareRegistry(ctx context.Context, organization string, opts CreateModuleShareOptions) (*ModuleShare, error) {
+	return s.createShare(ctx, organization, nil, opts)
+}
+
+func (s *Service) createShare(ctx context.Context, organization string, moduleID *string, opts CreateModuleShareOptions) (*ModuleShare, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ShareModuleAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	share, err := newModuleShare(organization, moduleID, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.createShare(ctx, share); err != nil {
+		var fkerr *internal.ForeignKeyError
+		if errors.As(err, &fkerr) && share.SharedWith != nil {
+			err = &internal.HTTPError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: fmt.Sprintf("organization not found: %s", *share.SharedWith),
+			}
+		}
+		s.logger.Error("sharing modules", "subject", subject, "share", share, "err", err)
+		return nil, err
+	}
+	s.logger.Info("shared modules", "subject", subject, "share", share)
+	return share, nil
+}
+
+// ListModuleShares lists the shares of a module, including those sharing the
+// whole of its organization's registry, and those that have been revoked.
+func (s *Service) ListModuleShares(ctx context.Context, moduleID string) ([]*ModuleShare, error) {
+	module, err := s.db.getModuleByID(ctx, moduleID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetModuleAction, module.Organization)
+	if err != nil {
+		return nil, err
+	}
+	shares, err := s.db.listSharesByModule(ctx, moduleID)
+	if err != nil {
+		s.logger.Error("listing module shares", "module", module, "subject", subject, "err", err)
+		return nil, err
+	}
+	return shares, nil
+}
+
+// ListRegistryShares lists the shares of an organization's modules, including
+// those that have been revoked.
+func (s *Service) ListRegistryShares(ctx context.Context, organization string) ([]*ModuleShare, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListModulesAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	shares, err := s.db.listSharesByOrganization(ctx, organization)
+	if err != nil {
+		s.logger.Error("listing module shares", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	return shares, nil
+}
+
+// RevokeModuleShare revokes a share. Consumers continue to be able to download
+// the versions they downloaded before the share was revoked until the grace
+// period elapses.
+func (s *Service) RevokeModuleShare(ctx context.Context, shareID string) (*ModuleShare, error) {
+	share, err := s.db.getShare(ctx, shareID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.ShareModuleAction, share.Organization)
+	if err != nil {
+		return nil, err
+	}
+	share, err = s.db.revokeShare(ctx, shareID, internal.CurrentTimestamp(nil))
+	if err != nil {
+		s.logger.Error("revoking module share", "id", shareID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("revoked module share", "share", share, "subject", subject)
+	return share, nil
+}
+
+// ListModuleDownloads lists the number of downloads of a module's versions,
+// attributed to the organizations that downloaded them.
+func (s *Service) ListModuleDownloads(ctx context.Context, moduleID string) ([]ModuleDownloads, error) {
+	module, err := s.db.getModuleByID(ctx, moduleID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetModuleAction, module.Organization)
+	if err != nil {
+		return nil, err
+	}
+	downloads, err := s.db.listDownloads(ctx, moduleID)
+	if err != nil {
+		s.logger.Error("listing module downloads", "module", module, "subject", subject, "err", err)
+		return nil, err
+	}
+	return downloads, nil
+}
+
+// ResolveModule retrieves the module with the registry address under an
+// organization. If the organization has no such module of its own then the
+// address resolves to a module shared with it by another organization.
+func (s *Service) ResolveModule(ctx context.Context, opts GetModuleOptions) (*Module, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetModuleAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	module, err := s.db.getModule(ctx, opts)
+	if err == nil {
+		return module, nil
+	} else if !errors.Is(err, internal.ErrResourceNotFound) {
+		s.logger.Error("resolving module", "module", opts, "subject", subject, "err", err)
+		return nil, err
+	}
+	shared, err := s.db.listSharedModules(ctx, opts)
+	if err != nil {
+		s.logger.Error("resolving module", "module", opts, "subject", subject, "err", err)
+		return nil, err
+	}
+	access, err := resolveSharedModule(shared, internal.CurrentTimestamp(nil), s.shareGracePeriod)
+	if err != nil {
+		return nil, err
+	}
+	module, err = s.db.getModuleByID(ctx, access.ModuleID)
+	if err != nil {
+		return nil, err
+	}
+	if access.RevokedAt != nil {
+		// share has been revoked, so only serve the versions downloaded
+		// before it was revoked.
+		downloaded, err := s.db.listVersionIDsDownloadedBefore(ctx, module.ID, opts.Organization, *access.RevokedAt)
+		if err != nil {
+			return nil, err
+		}
+		module.Versions = slices.DeleteFunc(module.Versions, func(modver ModuleVersion) bool {
+			return !slices.Contains(downloaded, modver.ID)
+		})
+	}
+	s.logger.Debug("resolved shared module", "subject", subject, "organization", opts.Organization, "module", module, "revoked_at", access.RevokedAt)
+	return module, nil
+}
+
+// recordDownload records the download of a module version, attributing it to
+// the organization whose registry address it was downloaded from.
+func (s *Service) recordDownload(ctx context.Context, versionID, organization string) error {
+	if err := s.db.createDownload(ctx, versionID, organization, internal.CurrentTimestamp(nil)); err != nil {
+		s.logger.Error("recording module download", "module_version_id", versionID, "organization", organization, "err", err)
+		return err
+	}
+	return nil
+}
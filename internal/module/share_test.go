@@ -0,0 +1,107 @@
+package module
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestNewModuleShare(t *testing.T) {
+	t.Run("share with organization", func(t *testing.T) {
+		share, err := newModuleShare("acme-corp", internal.String("mod-123"), CreateModuleShareOptions{SharedWith: internal.String("other-org")})
+		require.NoError(t, err)
+		assert.False(t, share.Global())
+		assert.False(t, share.Revoked())
+	})
+	t.Run("empty organization shares globally", func(t *testing.T) {
+		share, err := newModuleShare("acme-corp", nil, CreateModuleShareOptions{SharedWith: internal.String("")})
+		require.NoError(t, err)
+		assert.True(t, share.Global())
+	})
+	t.Run("cannot share with self", func(t *testing.T) {
+		_, err := newModuleShare("acme-corp", nil, CreateModuleShareOptions{SharedWith: internal.String("acme-corp")})
+		assert.ErrorIs(t, err, ErrCannotShareWithSelf)
+	})
+}
+
+func TestResolveSharedModule(t *testing.T) {
+	now := time.Now()
+	grace := time.Hour
+	active := &ModuleShare{ID: "modshare-active"}
+	recentlyRevoked := &ModuleShare{ID: "modshare-recent", RevokedAt: internal.Time(now.Add(-time.Minute))}
+	revoked := &ModuleShare{ID: "modshare-revoked", RevokedAt: internal.Time(now.Add(-30 * time.Minute))}
+	expired := &ModuleShare{ID: "modshare-expired", RevokedAt: internal.Time(now.Add(-2 * time.Hour))}
+
+	tests := []struct {
+		name    string
+		shared  []sharedModule
+		want    *shareAccess
+		wantErr error
+	}{
+		{
+			name:    "not shared",
+			wantErr: internal.ErrResourceNotFound,
+		},
+		{
+			name:   "active share",
+			shared: []sharedModule{{ModuleID: "mod-1", Share: active}},
+			want:   &shareAccess{ModuleID: "mod-1"},
+		},
+		{
+			name:   "revoked within grace period",
+			shared: []sharedModule{{ModuleID: "mod-1", Share: revoked}},
+			want:   &shareAccess{ModuleID: "mod-1", RevokedAt: revoked.RevokedAt},
+		},
+		{
+			name:    "revoked after grace period",
+			shared:  []sharedModule{{ModuleID: "mod-1", Share: expired}},
+			wantErr: internal.ErrResourceNotFound,
+		},
+		{
+			name: "active share takes precedence over revoked share",
+			shared: []sharedModule{
+				{ModuleID: "mod-1", Share: revoked},
+				{ModuleID: "mod-1", Share: active},
+			},
+			want: &shareAccess{ModuleID: "mod-1"},
+		},
+		{
+			name: "most recently revoked share takes precedence",
+			shared: []sharedModule{
+				{ModuleID: "mod-1", Share: revoked},
+				{ModuleID: "mod-1", Share: recentlyRevoked},
+			},
+			want: &shareAccess{ModuleID: "mod-1", RevokedAt: recentlyRevoked.RevokedAt},
+		},
+		{
+			name: "expired share of another module is ignored",
+			shared: []sharedModule{
+				{ModuleID: "mod-1", Share: active},
+				{ModuleID: "mod-2", Share: expired},
+			},
+			want: &shareAccess{ModuleID: "mod-1"},
+		},
+		{
+			name: "ambiguous",
+			shared: []sharedModule{
+				{ModuleID: "mod-1", Share: active},
+				{ModuleID: "mod-2", Share: revoked},
+			},
+			wantErr: ErrAmbiguousSharedModule,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSharedModule(tt.shared, now, grace)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -15,6 +15,9 @@ type fakeService struct {
 	repos    []string
 	hostname string
 
+	shares    []*ModuleShare
+	downloads []ModuleDownloads
+
 	Service
 	internal.HostnameService
 }
@@ -35,6 +38,42 @@ func (f *fakeService) ListModules(context.Context, ListModulesOptions) ([]*Modul
 	return []*Module{f.mod}, nil
 }
 
+func (f *fakeService) ShareModule(_ context.Context, moduleID string, opts CreateModuleShareOptions) (*ModuleShare, error) {
+	share, err := newModuleShare(f.mod.Organization, &moduleID, opts)
+	if err != nil {
+		return nil, err
+	}
+	f.shares = append(f.shares, share)
+	return share, nil
+}
+
+func (f *fakeService) ShareRegistry(_ context.Context, organization string, opts CreateModuleShareOptions) (*ModuleShare, error) {
+	share, err := newModuleShare(organization, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	f.shares = append(f.shares, share)
+	return share, nil
+}
+
+func (f *fakeService) ListModuleShares(context.Context, string) ([]*ModuleShare, error) {
+	return f.shares, nil
+}
+
+func (f *fakeService) RevokeModuleShare(_ context.Context, shareID string) (*ModuleShare, error) {
+	for _, share := range f.shares {
+		if share.ID == shareID {
+			share.RevokedAt = internal.Time(internal.CurrentTimestamp(nil))
+			return share, nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+func (f *fakeService) ListModuleDownloads(context.Context, string) ([]ModuleDownloads, error) {
+	return f.downloads, nil
+}
+
 func (f *fakeService) Get(context.Context, string) (*vcsprovider.VCSProvider, error) {
 	return f.vcsprovs[0], nil
 }
@@ -47,6 +47,12 @@ type (
 		PublishModule(context.Context, PublishOptions) (*Module, error)
 		DeleteModule(ctx context.Context, id string) (*Module, error)
 		RefreshModule(ctx context.Context, id string) (*Module, error)
+
+		ShareModule(ctx context.Context, moduleID string, opts CreateModuleShareOptions) (*ModuleShare, error)
+		ShareRegistry(ctx context.Context, organization string, opts CreateModuleShareOptions) (*ModuleShare, error)
+		ListModuleShares(ctx context.Context, moduleID string) ([]*ModuleShare, error)
+		RevokeModuleShare(ctx context.Context, shareID string) (*ModuleShare, error)
+		ListModuleDownloads(ctx context.Context, moduleID string) ([]ModuleDownloads, error)
 	}
 
 	// vcsprovidersClient provides web handlers with access to vcs providers
@@ -68,6 +74,8 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/modules/{module_id}", h.get).Methods("GET")
 	r.HandleFunc("/modules/{module_id}/delete", h.delete).Methods("POST")
 	r.HandleFunc("/modules/{module_id}/refresh", h.refresh).Methods("POST")
+	r.HandleFunc("/modules/{module_id}/share", h.share).Methods("POST")
+	r.HandleFunc("/module-shares/{module_share_id}/revoke", h.revokeShare).Methods("POST")
 }
 
 func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
@@ -140,6 +148,23 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	shares, err := h.client.ListModuleShares(r.Context(), module.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	downloads, err := h.client.ListModuleDownloads(r.Context(), module.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	h.Render("module_get.tmpl", w, struct {
 		organization.OrganizationPage
 		Module                    *Module
@@ -152,6 +177,10 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 		ModuleStatusSetupFailed   ModuleStatus
 		ModuleStatusSetupComplete ModuleStatus
 		ModuleVersionStatusOK     ModuleVersionStatus
+		CanCreateTemplate         bool
+		Shares                    []*ModuleShare
+		Downloads                 []ModuleDownloads
+		CanShare                  bool
 	}{
 		OrganizationPage:          organization.NewPage(r, module.ID, module.Organization),
 		Module:                    module,
@@ -164,6 +193,10 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 		ModuleStatusSetupFailed:   ModuleStatusSetupFailed,
 		ModuleStatusSetupComplete: ModuleStatusSetupComplete,
 		ModuleVersionStatusOK:     ModuleVersionStatusOK,
+		CanCreateTemplate:         subject.CanAccessOrganization(rbac.CreateModuleTemplateAction, module.Organization),
+		Shares:                    shares,
+		Downloads:                 downloads,
+		CanShare:                  subject.CanAccessOrganization(rbac.ShareModuleAction, module.Organization),
 	})
 }
 
@@ -186,6 +219,61 @@ func (h *webHandlers) refresh(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, paths.Module(module.ID), http.StatusFound)
 }
 
+func (h *webHandlers) share(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID         string `schema:"module_id,required"`
+		SharedWith string `schema:"shared_with"`
+		// Scope is either "module", sharing only the module, or "registry",
+		// sharing every module in the organization's registry.
+		Scope string `schema:"scope"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	opts := CreateModuleShareOptions{SharedWith: &params.SharedWith}
+	var err error
+	if params.Scope == "registry" {
+		var module *Module
+		module, err = h.client.GetModuleByID(r.Context(), params.ID)
+		if err == nil {
+			_, err = h.client.ShareRegistry(r.Context(), module.Organization, opts)
+		}
+	} else {
+		_, err = h.client.ShareModule(r.Context(), params.ID, opts)
+	}
+	var httpErr *internal.HTTPError
+	if errors.Is(err, ErrCannotShareWithSelf) || errors.Is(err, internal.ErrResourceAlreadyExists) || errors.As(err, &httpErr) {
+		html.FlashError(w, err.Error())
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else {
+		html.FlashSuccess(w, "shared module")
+	}
+	http.Redirect(w, r, paths.Module(params.ID), http.StatusFound)
+}
+
+func (h *webHandlers) revokeShare(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID       string `schema:"module_share_id,required"`
+		ModuleID string `schema:"module_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if _, err := h.client.RevokeModuleShare(r.Context(), params.ID); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "revoked module share")
+	http.Redirect(w, r, paths.Module(params.ModuleID), http.StatusFound)
+}
+
 func (h *webHandlers) new(w http.ResponseWriter, r *http.Request) {
 	var params struct {
 		Step newModuleStep `schema:"step"`
@@ -4,6 +4,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -66,10 +67,17 @@ func TestGetModule(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			h := newTestWebHandlers(t, withMod(&tt.mod), withTarball(tarball), withHostname("fake-host.org"))
+			h := newTestWebHandlers(t, withMod(&tt.mod), withTarball(tarball), withHostname("fake-host.org"),
+				withShares(
+					&ModuleShare{ID: "modshare-1", SharedWith: internal.String("other-org")},
+					&ModuleShare{ID: "modshare-2", RevokedAt: internal.Time(time.Now())},
+				),
+				withDownloads(ModuleDownloads{Organization: "other-org", Downloads: 3}),
+			)
 
 			q := "/?module_id=mod-123&version=1.0.0"
 			r := httptest.NewRequest("GET", q, nil)
+			r = r.WithContext(internal.AddSubjectToContext(r.Context(), &user.User{ID: "janitor"}))
 			w := httptest.NewRecorder()
 			h.get(w, r)
 			if !assert.Equal(t, 200, w.Code) {
@@ -154,6 +162,76 @@ func TestNewModule_Delete(t *testing.T) {
 	}
 }
 
+func TestWeb_ShareModule(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		wantModuleID   bool
+		wantSharedWith *string
+	}{
+		{
+			name:           "share module with organization",
+			query:          "/?module_id=mod-123&shared_with=other-org&scope=module",
+			wantModuleID:   true,
+			wantSharedWith: internal.String("other-org"),
+		},
+		{
+			name:         "share module globally",
+			query:        "/?module_id=mod-123&shared_with=&scope=module",
+			wantModuleID: true,
+		},
+		{
+			name:           "share registry",
+			query:          "/?module_id=mod-123&shared_with=other-org&scope=registry",
+			wantSharedWith: internal.String("other-org"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestWebHandlers(t, withMod(&Module{ID: "mod-123", Organization: "acme-corp"}))
+
+			r := httptest.NewRequest("POST", tt.query, nil)
+			w := httptest.NewRecorder()
+			h.share(w, r)
+			if assert.Equal(t, 302, w.Code) {
+				redirect, err := w.Result().Location()
+				require.NoError(t, err)
+				assert.Equal(t, paths.Module("mod-123"), redirect.Path)
+			}
+			shares := h.client.(*fakeService).shares
+			require.Len(t, shares, 1)
+			assert.Equal(t, "acme-corp", shares[0].Organization)
+			assert.Equal(t, tt.wantModuleID, shares[0].ModuleID != nil)
+			assert.Equal(t, tt.wantSharedWith, shares[0].SharedWith)
+		})
+	}
+
+	t.Run("cannot share with self", func(t *testing.T) {
+		h := newTestWebHandlers(t, withMod(&Module{ID: "mod-123", Organization: "acme-corp"}))
+
+		r := httptest.NewRequest("POST", "/?module_id=mod-123&shared_with=acme-corp", nil)
+		w := httptest.NewRecorder()
+		h.share(w, r)
+		assert.Equal(t, 302, w.Code)
+		assert.Empty(t, h.client.(*fakeService).shares)
+	})
+}
+
+func TestWeb_RevokeModuleShare(t *testing.T) {
+	share := &ModuleShare{ID: "modshare-123"}
+	h := newTestWebHandlers(t, withMod(&Module{ID: "mod-123"}), withShares(share))
+
+	r := httptest.NewRequest("POST", "/?module_share_id=modshare-123&module_id=mod-123", nil)
+	w := httptest.NewRecorder()
+	h.revokeShare(w, r)
+	if assert.Equal(t, 302, w.Code) {
+		redirect, err := w.Result().Location()
+		require.NoError(t, err)
+		assert.Equal(t, paths.Module("mod-123"), redirect.Path)
+	}
+	assert.True(t, share.Revoked())
+}
+
 func newTestWebHandlers(t *testing.T, opts ...testWebOption) *webHandlers {
 	var svc fakeService
 	for _, fn := range opts {
@@ -198,3 +276,15 @@ func withHostname(hostname string) testWebOption {
 		svc.hostname = hostname
 	}
 }
+
+func withShares(shares ...*ModuleShare) testWebOption {
+	return func(svc *fakeService) {
+		svc.shares = shares
+	}
+}
+
+func withDownloads(downloads ...ModuleDownloads) testWebOption {
+	return func(svc *fakeService) {
+		svc.downloads = downloads
+	}
+}
@@ -0,0 +1,88 @@
+package modulepolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/semver"
+)
+
+// PolicyCheckName is the name of the policy check carried out on runs in
+// organizations with module version policies.
+const PolicyCheckName = "module-versions"
+
+type (
+	// manifest is the manifest of modules installed by terraform init, i.e.
+	// .terraform/modules/modules.json.
+	manifest struct {
+		Modules []installedModule `json:"Modules"`
+	}
+
+	// installedModule is a module installed by terraform init.
+	installedModule struct {
+		// Key is the path of the module call from the root module, e.g.
+		// network.subnets for a module call named subnets within a module
+		// call named network. The root module has an empty key.
+		Key string `json:"Key"`
+		// Source is the address from which the module was installed.
+		Source string `json:"Source"`
+		// Version is the version of the module that was installed. Only
+		// modules installed from a registry have a version.
+		Version string `json:"Version"`
+	}
+)
+
+// check evaluates the modules installed by a run against an organization's
+// module version policies, returning a violation for each module with a
+// version that does not satisfy its policy's constraints.
+func check(policies []*Policy, moduleManifest []byte) ([]run.PolicyViolation, error) {
+	if len(moduleManifest) == 0 {
+		// the configuration doesn't call any modules
+		return nil, nil
+	}
+	var m manifest
+	if err := json.Unmarshal(moduleManifest, &m); err != nil {
+		return nil, fmt.Errorf("parsing module manifest: %w", err)
+	}
+	byModule := make(map[string]*Policy, len(policies))
+	for _, p := range policies {
+		byModule[p.Module] = p
+	}
+	var violations []run.PolicyViolation
+	for _, mod := range m.Modules {
+		if mod.Version == "" {
+			// root module, or a module not installed from a registry
+			continue
+		}
+		addr, err := normalizeModule(mod.Source)
+		if err != nil {
+			continue
+		}
+		policy, ok := byModule[addr]
+		if !ok {
+			continue
+		}
+		if semver.Satisfies(policy.Constraints, mod.Version) {
+			continue
+		}
+		violations = append(violations, run.PolicyViolation{
+			Message: fmt.Sprintf("%s (%s) version %s does not satisfy constraints %q",
+				moduleCallAddress(mod.Key), addr, mod.Version, policy.Constraints),
+			Fail: policy.Enforcement == FailEnforcement,
+		})
+	}
+	return violations, nil
+}
+
+// moduleCallAddress converts the key of an installed module into the address
+// by which terraform refers to the module call, e.g. network.subnets becomes
+// module.network.module.subnets.
+func moduleCallAddress(key string) string {
+	names := strings.Split(key, ".")
+	for i, name := range names {
+		names[i] = "module." + name
+	}
+	return strings.Join(names, ".")
+}
@@ -0,0 +1,56 @@
+package modulepolicy
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+func TestCheck(t *testing.T) {
+	manifest, err := os.ReadFile("testdata/modules.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		policies []*Policy
+		manifest []byte
+		want     []run.PolicyViolation
+	}{
+		{
+			name: "satisfied",
+			policies: []*Policy{
+				{Module: "terraform-aws-modules/vpc/aws", Constraints: "~> 5.1", Enforcement: FailEnforcement},
+			},
+			manifest: manifest,
+		},
+		{
+			name: "violated",
+			policies: []*Policy{
+				{Module: "terraform-aws-modules/vpc/aws", Constraints: "~> 5.1", Enforcement: FailEnforcement},
+				{Module: "terraform-aws-modules/security-group/aws", Constraints: ">= 5.0", Enforcement: WarnEnforcement},
+				{Module: "tofutf.example.com/acme/tags/null", Constraints: "< 1.0", Enforcement: FailEnforcement},
+			},
+			manifest: manifest,
+			want: []run.PolicyViolation{
+				{Message: `module.network.module.sg (terraform-aws-modules/security-group/aws) version 4.17.2 does not satisfy constraints ">= 5.0"`},
+				{Message: `module.tags (tofutf.example.com/acme/tags/null) version 1.2.0 does not satisfy constraints "< 1.0"`, Fail: true},
+			},
+		},
+		{
+			name: "no modules",
+			policies: []*Policy{
+				{Module: "terraform-aws-modules/vpc/aws", Constraints: "~> 5.1", Enforcement: FailEnforcement},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := check(tt.policies, tt.manifest)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
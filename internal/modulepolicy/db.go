@@ -0,0 +1,107 @@
+package modulepolicy
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+// pgdb stores module version policies in a postgres database
+type pgdb struct {
+	*sql.Pool // provides access to generated SQL queries
+}
+
+// policyRow is the row result of a database query for module version
+// policies
+type policyRow struct {
+	ModuleVersionPolicyID pgtype.Text        `json:"module_version_policy_id"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	OrganizationName      pgtype.Text        `json:"organization_name"`
+	Module                pgtype.Text        `json:"module"`
+	Constraints           pgtype.Text        `json:"constraints"`
+	Enforcement           pgtype.Text        `json:"enforcement"`
+}
+
+func (row policyRow) toPolicy() *Policy {
+	return &Policy{
+		ID:           row.ModuleVersionPolicyID.String,
+		CreatedAt:    row.CreatedAt.Time.UTC(),
+		UpdatedAt:    row.UpdatedAt.Time.UTC(),
+		Organization: row.OrganizationName.String,
+		Module:       row.Module.String,
+		Constraints:  row.Constraints.String,
+		Enforcement:  Enforcement(row.Enforcement.String),
+	}
+}
+
+func (db *pgdb) createPolicy(ctx context.Context, policy *Policy) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertModuleVersionPolicy(ctx, pggen.InsertModuleVersionPolicyParams{
+			ModuleVersionPolicyID: sql.String(policy.ID),
+			CreatedAt:             sql.Timestamptz(policy.CreatedAt),
+			UpdatedAt:             sql.Timestamptz(policy.UpdatedAt),
+			OrganizationName:      sql.String(policy.Organization),
+			Module:                sql.String(policy.Module),
+			Constraints:           sql.String(policy.Constraints),
+			Enforcement:           sql.String(string(policy.Enforcement)),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) updatePolicy(ctx context.Context, policyID string, fn func(*Policy) error) (*Policy, error) {
+	return sql.Tx(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Policy, error) {
+		row, err := q.FindModuleVersionPolicyForUpdate(ctx, sql.String(policyID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		policy := policyRow(row).toPolicy()
+		if err := fn(policy); err != nil {
+			return nil, err
+		}
+		_, err = q.UpdateModuleVersionPolicy(ctx, pggen.UpdateModuleVersionPolicyParams{
+			UpdatedAt:             sql.Timestamptz(policy.UpdatedAt),
+			Constraints:           sql.String(policy.Constraints),
+			Enforcement:           sql.String(string(policy.Enforcement)),
+			ModuleVersionPolicyID: row.ModuleVersionPolicyID,
+		})
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return policy, nil
+	})
+}
+
+func (db *pgdb) listPolicies(ctx context.Context, organization string) ([]*Policy, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Policy, error) {
+		rows, err := q.FindModuleVersionPoliciesByOrganization(ctx, sql.String(organization))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		policies := make([]*Policy, len(rows))
+		for i, r := range rows {
+			policies[i] = policyRow(r).toPolicy()
+		}
+		return policies, nil
+	})
+}
+
+func (db *pgdb) getPolicy(ctx context.Context, policyID string) (*Policy, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Policy, error) {
+		row, err := q.FindModuleVersionPolicyByID(ctx, sql.String(policyID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return policyRow(row).toPolicy(), nil
+	})
+}
+
+func (db *pgdb) deletePolicy(ctx context.Context, policyID string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteModuleVersionPolicyByID(ctx, sql.String(policyID))
+		return sql.Error(err)
+	})
+}
@@ -0,0 +1,175 @@
+// Package modulepolicy restricts the versions of registry modules that runs in
+// an organization are permitted to use.
+package modulepolicy
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/semver"
+)
+
+const (
+	// WarnEnforcement reports a violation of a policy without affecting the
+	// run.
+	WarnEnforcement Enforcement = "warn"
+	// FailEnforcement fails a run that violates a policy.
+	FailEnforcement Enforcement = "fail"
+)
+
+var (
+	ErrInvalidModuleAddress = errors.New("module must be a registry module address of the form [hostname/]namespace/name/provider")
+	ErrInvalidConstraints   = errors.New("invalid version constraints")
+	ErrInvalidEnforcement   = errors.New("enforcement must be either warn or fail")
+
+	// publicRegistries are the hostnames of the public registries. Modules
+	// sourced from a public registry are addressed without a hostname.
+	publicRegistries = []string{"registry.terraform.io", "registry.opentofu.org"}
+
+	// registryNamePattern matches a valid namespace, name or provider in a
+	// registry module address.
+	registryNamePattern = regexp.MustCompile(`^[0-9a-z][0-9a-z_-]*$`)
+)
+
+type (
+	// Policy restricts the versions of a registry module that runs in an
+	// organization are permitted to use.
+	Policy struct {
+		ID           string
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+		Organization string
+		// Module is the address of the registry module, e.g.
+		// hashicorp/consul/aws for a module on a public registry, or
+		// tofutf.example.com/acme/vpc/aws for a module on a private registry.
+		Module string
+		// Constraints are the permitted versions of the module, using the
+		// same syntax as a module's version argument, e.g. "~> 1.2".
+		Constraints string
+		Enforcement Enforcement
+	}
+
+	// Enforcement determines what happens to a run that violates a policy.
+	Enforcement string
+
+	CreateOptions struct {
+		Organization string
+		Module       string
+		Constraints  string
+		// Enforcement defaults to FailEnforcement.
+		Enforcement *Enforcement
+	}
+
+	UpdateOptions struct {
+		Constraints *string
+		Enforcement *Enforcement
+	}
+)
+
+func newPolicy(opts CreateOptions) (*Policy, error) {
+	module, err := normalizeModule(opts.Module)
+	if err != nil {
+		return nil, err
+	}
+	now := internal.CurrentTimestamp(nil)
+	policy := &Policy{
+		ID:           internal.NewID("modpol"),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Organization: opts.Organization,
+		Module:       module,
+		Enforcement:  FailEnforcement,
+	}
+	if err := policy.setConstraints(opts.Constraints); err != nil {
+		return nil, err
+	}
+	if opts.Enforcement != nil {
+		if err := policy.setEnforcement(*opts.Enforcement); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+func (p *Policy) update(opts UpdateOptions) error {
+	if opts.Constraints != nil {
+		if err := p.setConstraints(*opts.Constraints); err != nil {
+			return err
+		}
+	}
+	if opts.Enforcement != nil {
+		if err := p.setEnforcement(*opts.Enforcement); err != nil {
+			return err
+		}
+	}
+	p.UpdatedAt = internal.CurrentTimestamp(nil)
+	return nil
+}
+
+func (p *Policy) setConstraints(constraints string) error {
+	constraints = strings.TrimSpace(constraints)
+	if !semver.IsConstraint(constraints) {
+		return fmt.Errorf("%w: %q", ErrInvalidConstraints, constraints)
+	}
+	p.Constraints = constraints
+	return nil
+}
+
+func (p *Policy) setEnforcement(enforcement Enforcement) error {
+	switch enforcement {
+	case WarnEnforcement, FailEnforcement:
+		p.Enforcement = enforcement
+		return nil
+	default:
+		return ErrInvalidEnforcement
+	}
+}
+
+func (p *Policy) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", p.ID),
+		slog.String("organization", p.Organization),
+		slog.String("module", p.Module),
+		slog.String("constraints", p.Constraints),
+		slog.String("enforcement", string(p.Enforcement)),
+	)
+}
+
+// normalizeModule normalizes the address of a registry module, so that
+// addresses referring to the same module are identical: the address is
+// lowercased, any subdirectory is removed, and the hostname of a public
+// registry is removed.
+func normalizeModule(addr string) (string, error) {
+	addr = strings.ToLower(strings.TrimSpace(addr))
+	// remove subdirectory, e.g. hashicorp/consul/aws//modules/consul-cluster
+	addr, _, _ = strings.Cut(addr, "//")
+	parts := strings.Split(addr, "/")
+	switch len(parts) {
+	case 3:
+	case 4:
+		for _, public := range publicRegistries {
+			if parts[0] == public {
+				parts = parts[1:]
+				break
+			}
+		}
+	default:
+		return "", ErrInvalidModuleAddress
+	}
+	for i, part := range parts {
+		if part == "" {
+			return "", ErrInvalidModuleAddress
+		}
+		// the namespace, name and provider are limited to a subset of
+		// characters, which rules out local paths and VCS sources
+		if i >= len(parts)-3 && !registryNamePattern.MatchString(part) {
+			return "", ErrInvalidModuleAddress
+		}
+	}
+	return strings.Join(parts, "/"), nil
+}
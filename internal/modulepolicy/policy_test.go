@@ -0,0 +1,91 @@
+package modulepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPolicy(t *testing.T) {
+	warn := WarnEnforcement
+	invalid := Enforcement("block")
+
+	tests := []struct {
+		name string
+		opts CreateOptions
+		want *Policy
+		err  error
+	}{
+		{
+			name: "defaults to fail",
+			opts: CreateOptions{Organization: "acme", Module: "hashicorp/consul/aws", Constraints: "~> 0.1"},
+			want: &Policy{Organization: "acme", Module: "hashicorp/consul/aws", Constraints: "~> 0.1", Enforcement: FailEnforcement},
+		},
+		{
+			name: "warn",
+			opts: CreateOptions{Organization: "acme", Module: "hashicorp/consul/aws", Constraints: ">= 0.1, < 0.3", Enforcement: &warn},
+			want: &Policy{Organization: "acme", Module: "hashicorp/consul/aws", Constraints: ">= 0.1, < 0.3", Enforcement: WarnEnforcement},
+		},
+		{
+			name: "invalid module",
+			opts: CreateOptions{Organization: "acme", Module: "git::https://example.com/vpc.git", Constraints: "~> 0.1"},
+			err:  ErrInvalidModuleAddress,
+		},
+		{
+			name: "invalid constraints",
+			opts: CreateOptions{Organization: "acme", Module: "hashicorp/consul/aws", Constraints: "0.1"},
+			err:  ErrInvalidConstraints,
+		},
+		{
+			name: "invalid enforcement",
+			opts: CreateOptions{Organization: "acme", Module: "hashicorp/consul/aws", Constraints: "~> 0.1", Enforcement: &invalid},
+			err:  ErrInvalidEnforcement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newPolicy(tt.opts)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Organization, got.Organization)
+			assert.Equal(t, tt.want.Module, got.Module)
+			assert.Equal(t, tt.want.Constraints, got.Constraints)
+			assert.Equal(t, tt.want.Enforcement, got.Enforcement)
+		})
+	}
+}
+
+func TestNormalizeModule(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+		err  error
+	}{
+		{"hashicorp/consul/aws", "hashicorp/consul/aws", nil},
+		{"Hashicorp/Consul/AWS", "hashicorp/consul/aws", nil},
+		{"registry.terraform.io/hashicorp/consul/aws", "hashicorp/consul/aws", nil},
+		{"registry.opentofu.org/hashicorp/consul/aws", "hashicorp/consul/aws", nil},
+		{"hashicorp/consul/aws//modules/consul-cluster", "hashicorp/consul/aws", nil},
+		{"tofutf.example.com/acme/vpc/aws", "tofutf.example.com/acme/vpc/aws", nil},
+		{"tofutf.example.com:8443/acme/vpc/aws", "tofutf.example.com:8443/acme/vpc/aws", nil},
+		{"./modules/vpc", "", ErrInvalidModuleAddress},
+		{"github.com/acme/vpc", "", ErrInvalidModuleAddress},
+		{"git::https://example.com/vpc.git", "", ErrInvalidModuleAddress},
+		{"acme//aws", "", ErrInvalidModuleAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			got, err := normalizeModule(tt.addr)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
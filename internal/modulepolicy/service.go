@@ -0,0 +1,171 @@
+package modulepolicy
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+type (
+	// Service manages module version policies, and checks runs against them
+	// once their plan has finished.
+	Service struct {
+		logger       *slog.Logger
+		organization internal.Authorizer
+		db           *pgdb
+		tfeapi       *tfe
+		web          *webHandlers
+	}
+
+	Options struct {
+		Logger     *slog.Logger
+		RunService *run.Service
+
+		*sql.Pool
+		*tfeapi.Responder
+		html.Renderer
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.Pool},
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	svc.web = &webHandlers{
+		Renderer: opts.Renderer,
+		svc:      &svc,
+	}
+	opts.RunService.AddPolicyCheck(svc.checkRun)
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.tfeapi.addHandlers(r)
+	s.web.addHandlers(r)
+}
+
+func (s *Service) CreatePolicy(ctx context.Context, opts CreateOptions) (*Policy, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateModuleVersionPolicyAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := newPolicy(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.createPolicy(ctx, policy); err != nil {
+		s.logger.Error("creating module version policy", "subject", subject, "policy", policy, "err", err)
+		return nil, err
+	}
+	s.logger.Info("created module version policy", "subject", subject, "policy", policy)
+	return policy, nil
+}
+
+func (s *Service) UpdatePolicy(ctx context.Context, policyID string, opts UpdateOptions) (*Policy, error) {
+	policy, err := s.db.getPolicy(ctx, policyID)
+	if err != nil {
+		s.logger.Error("retrieving module version policy", "id", policyID, "err", err)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateModuleVersionPolicyAction, policy.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.updatePolicy(ctx, policyID, func(policy *Policy) error {
+		return policy.update(opts)
+	})
+	if err != nil {
+		s.logger.Error("updating module version policy", "subject", subject, "policy", policy, "err", err)
+		return nil, err
+	}
+	s.logger.Info("updated module version policy", "subject", subject, "before", policy, "after", updated)
+	return updated, nil
+}
+
+func (s *Service) GetPolicy(ctx context.Context, policyID string) (*Policy, error) {
+	policy, err := s.db.getPolicy(ctx, policyID)
+	if err != nil {
+		s.logger.Error("retrieving module version policy", "id", policyID, "err", err)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.GetModuleVersionPolicyAction, policy.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("retrieved module version policy", "subject", subject, "policy", policy)
+	return policy, nil
+}
+
+func (s *Service) ListPolicies(ctx context.Context, organization string) ([]*Policy, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListModuleVersionPoliciesAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := s.db.listPolicies(ctx, organization)
+	if err != nil {
+		s.logger.Error("listing module version policies", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("listed module version policies", "organization", organization, "subject", subject, "count", len(policies))
+	return policies, nil
+}
+
+func (s *Service) DeletePolicy(ctx context.Context, policyID string) (*Policy, error) {
+	policy, err := s.db.getPolicy(ctx, policyID)
+	if err != nil {
+		s.logger.Error("retrieving module version policy", "id", policyID, "err", err)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteModuleVersionPolicyAction, policy.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.deletePolicy(ctx, policyID); err != nil {
+		s.logger.Error("deleting module version policy", "subject", subject, "policy", policy, "err", err)
+		return nil, err
+	}
+	s.logger.Info("deleted module version policy", "subject", subject, "policy", policy)
+	return policy, nil
+}
+
+// checkRun checks the modules installed by a run against the policies of the
+// run's organization. No check is carried out if the organization has no
+// policies.
+func (s *Service) checkRun(ctx context.Context, r *run.Run, plan run.PolicyCheckInput) (*run.PolicyCheck, error) {
+	// the run service invokes the check on behalf of the job finishing the
+	// plan, so policies are retrieved without an authorization check.
+	policies, err := s.db.listPolicies(ctx, r.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	violations, err := check(policies, plan.ModuleManifest)
+	if err != nil {
+		return nil, err
+	}
+	return &run.PolicyCheck{Name: PolicyCheckName, Violations: violations}, nil
+}
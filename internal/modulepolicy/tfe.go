@@ -0,0 +1,148 @@
+package modulepolicy
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/module-version-policies", a.listPolicies).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/module-version-policies", a.createPolicy).Methods("POST")
+	r.HandleFunc("/module-version-policies/{policy_id}", a.getPolicy).Methods("GET")
+	r.HandleFunc("/module-version-policies/{policy_id}", a.updatePolicy).Methods("PATCH")
+	r.HandleFunc("/module-version-policies/{policy_id}", a.deletePolicy).Methods("DELETE")
+}
+
+func (a *tfe) listPolicies(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	policies, err := a.ListPolicies(r.Context(), organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.ModuleVersionPolicy, len(policies))
+	for i, from := range policies {
+		items[i] = a.toPolicy(from)
+	}
+	a.Respond(w, r, items, http.StatusOK)
+}
+
+func (a *tfe) createPolicy(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ModuleVersionPolicyCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	policy, err := a.CreatePolicy(r.Context(), CreateOptions{
+		Organization: organization,
+		Module:       params.Module,
+		Constraints:  params.Constraints,
+		Enforcement:  (*Enforcement)(params.Enforcement),
+	})
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toPolicy(policy), http.StatusCreated)
+}
+
+func (a *tfe) getPolicy(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	policy, err := a.GetPolicy(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toPolicy(policy), http.StatusOK)
+}
+
+func (a *tfe) updatePolicy(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ModuleVersionPolicyUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	policy, err := a.UpdatePolicy(r.Context(), id, UpdateOptions{
+		Constraints: params.Constraints,
+		Enforcement: (*Enforcement)(params.Enforcement),
+	})
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toPolicy(policy), http.StatusOK)
+}
+
+func (a *tfe) deletePolicy(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if _, err := a.DeletePolicy(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// error reports invalid policies as a 422.
+func (a *tfe) error(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidModuleAddress),
+		errors.Is(err, ErrInvalidConstraints),
+		errors.Is(err, ErrInvalidEnforcement):
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+	default:
+		tfeapi.Error(w, err)
+	}
+}
+
+func (a *tfe) toPolicy(from *Policy) *types.ModuleVersionPolicy {
+	return &types.ModuleVersionPolicy{
+		ID:           from.ID,
+		Module:       from.Module,
+		Constraints:  from.Constraints,
+		Enforcement:  string(from.Enforcement),
+		CreatedAt:    from.CreatedAt,
+		UpdatedAt:    from.UpdatedAt,
+		Organization: &types.Organization{Name: from.Organization},
+	}
+}
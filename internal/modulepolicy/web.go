@@ -0,0 +1,136 @@
+package modulepolicy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+type (
+	webHandlers struct {
+		html.Renderer
+
+		svc webClient
+	}
+
+	webClient interface {
+		CreatePolicy(ctx context.Context, opts CreateOptions) (*Policy, error)
+		UpdatePolicy(ctx context.Context, policyID string, opts UpdateOptions) (*Policy, error)
+		ListPolicies(ctx context.Context, organization string) ([]*Policy, error)
+		DeletePolicy(ctx context.Context, policyID string) (*Policy, error)
+	}
+)
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/module-version-policies", h.list).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/module-version-policies/create", h.create).Methods("POST")
+	r.HandleFunc("/module-version-policies/{policy_id}/update", h.update).Methods("POST")
+	r.HandleFunc("/module-version-policies/{policy_id}/delete", h.delete).Methods("POST")
+}
+
+func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	policies, err := h.svc.ListPolicies(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("module_version_policy_list.tmpl", w, struct {
+		organization.OrganizationPage
+		Items     []*Policy
+		CanManage bool
+	}{
+		OrganizationPage: organization.NewPage(r, "module version policies", org),
+		Items:            policies,
+		CanManage:        subject.CanAccessOrganization(rbac.CreateModuleVersionPolicyAction, org),
+	})
+}
+
+func (h *webHandlers) create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string      `schema:"organization_name,required"`
+		Module       string      `schema:"module,required"`
+		Constraints  string      `schema:"constraints,required"`
+		Enforcement  Enforcement `schema:"enforcement,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	policy, err := h.svc.CreatePolicy(r.Context(), CreateOptions{
+		Organization: params.Organization,
+		Module:       params.Module,
+		Constraints:  params.Constraints,
+		Enforcement:  &params.Enforcement,
+	})
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.ModuleVersionPolicies(params.Organization), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "added version policy for module "+policy.Module)
+	http.Redirect(w, r, paths.ModuleVersionPolicies(policy.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) update(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID          string      `schema:"policy_id,required"`
+		Constraints string      `schema:"constraints,required"`
+		Enforcement Enforcement `schema:"enforcement,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	policy, err := h.svc.UpdatePolicy(r.Context(), params.ID, UpdateOptions{
+		Constraints: &params.Constraints,
+		Enforcement: &params.Enforcement,
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	html.FlashSuccess(w, "updated version policy for module "+policy.Module)
+	http.Redirect(w, r, paths.ModuleVersionPolicies(policy.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) delete(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("policy_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	policy, err := h.svc.DeletePolicy(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted version policy for module "+policy.Module)
+	http.Redirect(w, r, paths.ModuleVersionPolicies(policy.Organization), http.StatusFound)
+}
@@ -0,0 +1,240 @@
+package nocode
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+// pgdb stores module templates in a postgres database
+type pgdb struct {
+	*sql.Pool // provides access to generated SQL queries
+}
+
+// templateRow is the row result of a database query for module templates
+type templateRow struct {
+	ModuleTemplateID pgtype.Text        `json:"module_template_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	ModuleID         pgtype.Text        `json:"module_id"`
+	ModuleVersionID  pgtype.Text        `json:"module_version_id"`
+	Variables        []byte             `json:"variables"`
+	Outputs          []byte             `json:"outputs"`
+	ModuleName       pgtype.Text        `json:"module_name"`
+	ModuleProvider   pgtype.Text        `json:"module_provider"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	Version          pgtype.Text        `json:"version"`
+}
+
+func (row templateRow) toTemplate() (*Template, error) {
+	tmpl := &Template{
+		ID:              row.ModuleTemplateID.String,
+		CreatedAt:       row.CreatedAt.Time.UTC(),
+		UpdatedAt:       row.UpdatedAt.Time.UTC(),
+		ModuleID:        row.ModuleID.String,
+		ModuleName:      row.ModuleName.String,
+		ModuleProvider:  row.ModuleProvider.String,
+		Organization:    row.OrganizationName.String,
+		ModuleVersionID: row.ModuleVersionID.String,
+		Version:         row.Version.String,
+	}
+	if err := json.Unmarshal(row.Variables, &tmpl.Variables); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(row.Outputs, &tmpl.Outputs); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// workspaceRow is the row result of a database query for workspaces
+// provisioned from a module template.
+type workspaceRow struct {
+	WorkspaceID      pgtype.Text `json:"workspace_id"`
+	ModuleTemplateID pgtype.Text `json:"module_template_id"`
+	Version          pgtype.Text `json:"version"`
+	Variables        []byte      `json:"variables"`
+	WorkspaceName    pgtype.Text `json:"workspace_name"`
+}
+
+func (row workspaceRow) toWorkspace() (*Workspace, error) {
+	ws := &Workspace{
+		WorkspaceID:   row.WorkspaceID.String,
+		WorkspaceName: row.WorkspaceName.String,
+		TemplateID:    row.ModuleTemplateID.String,
+		Version:       row.Version.String,
+	}
+	if err := json.Unmarshal(row.Variables, &ws.Variables); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+func (db *pgdb) createTemplate(ctx context.Context, tmpl *Template) error {
+	variables, err := json.Marshal(tmpl.Variables)
+	if err != nil {
+		return err
+	}
+	outputs, err := json.Marshal(tmpl.Outputs)
+	if err != nil {
+		return err
+	}
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertModuleTemplate(ctx, pggen.InsertModuleTemplateParams{
+			ModuleTemplateID: sql.String(tmpl.ID),
+			CreatedAt:        sql.Timestamptz(tmpl.CreatedAt),
+			UpdatedAt:        sql.Timestamptz(tmpl.UpdatedAt),
+			ModuleID:         sql.String(tmpl.ModuleID),
+			ModuleVersionID:  sql.String(tmpl.ModuleVersionID),
+			Variables:        variables,
+			Outputs:          outputs,
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) updateTemplate(ctx context.Context, templateID string, fn func(context.Context, *Template) error) (*Template, error) {
+	return sql.Tx(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Template, error) {
+		row, err := q.FindModuleTemplateForUpdate(ctx, sql.String(templateID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		tmpl, err := templateRow(row).toTemplate()
+		if err != nil {
+			return nil, err
+		}
+		if err := fn(ctx, tmpl); err != nil {
+			return nil, err
+		}
+		variables, err := json.Marshal(tmpl.Variables)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err := json.Marshal(tmpl.Outputs)
+		if err != nil {
+			return nil, err
+		}
+		_, err = q.UpdateModuleTemplate(ctx, pggen.UpdateModuleTemplateParams{
+			UpdatedAt:        sql.Timestamptz(tmpl.UpdatedAt),
+			ModuleVersionID:  sql.String(tmpl.ModuleVersionID),
+			Variables:        variables,
+			Outputs:          outputs,
+			ModuleTemplateID: row.ModuleTemplateID,
+		})
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return tmpl, nil
+	})
+}
+
+func (db *pgdb) listTemplates(ctx context.Context, organization string) ([]*Template, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Template, error) {
+		rows, err := q.FindModuleTemplatesByOrganization(ctx, sql.String(organization))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		templates := make([]*Template, len(rows))
+		for i, r := range rows {
+			tmpl, err := templateRow(r).toTemplate()
+			if err != nil {
+				return nil, err
+			}
+			templates[i] = tmpl
+		}
+		return templates, nil
+	})
+}
+
+func (db *pgdb) getTemplate(ctx context.Context, templateID string) (*Template, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Template, error) {
+		row, err := q.FindModuleTemplateByID(ctx, sql.String(templateID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return templateRow(row).toTemplate()
+	})
+}
+
+func (db *pgdb) getTemplateByModuleID(ctx context.Context, moduleID string) (*Template, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Template, error) {
+		row, err := q.FindModuleTemplateByModuleID(ctx, sql.String(moduleID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return templateRow(row).toTemplate()
+	})
+}
+
+func (db *pgdb) deleteTemplate(ctx context.Context, templateID string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteModuleTemplateByID(ctx, sql.String(templateID))
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) createWorkspace(ctx context.Context, ws *Workspace) error {
+	variables, err := json.Marshal(ws.Variables)
+	if err != nil {
+		return err
+	}
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertModuleTemplateWorkspace(ctx, pggen.InsertModuleTemplateWorkspaceParams{
+			WorkspaceID:      sql.String(ws.WorkspaceID),
+			ModuleTemplateID: sql.String(ws.TemplateID),
+			Version:          sql.String(ws.Version),
+			Variables:        variables,
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) updateWorkspace(ctx context.Context, workspaceID string, fn func(context.Context, *Workspace) error) (*Workspace, error) {
+	return sql.Tx(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Workspace, error) {
+		row, err := q.FindModuleTemplateWorkspaceForUpdate(ctx, sql.String(workspaceID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		ws, err := workspaceRow(row).toWorkspace()
+		if err != nil {
+			return nil, err
+		}
+		if err := fn(ctx, ws); err != nil {
+			return nil, err
+		}
+		variables, err := json.Marshal(ws.Variables)
+		if err != nil {
+			return nil, err
+		}
+		_, err = q.UpdateModuleTemplateWorkspace(ctx, pggen.UpdateModuleTemplateWorkspaceParams{
+			Version:     sql.String(ws.Version),
+			Variables:   variables,
+			WorkspaceID: row.WorkspaceID,
+		})
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return ws, nil
+	})
+}
+
+func (db *pgdb) listWorkspaces(ctx context.Context, templateID string) ([]*Workspace, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Workspace, error) {
+		rows, err := q.FindModuleTemplateWorkspacesByTemplateID(ctx, sql.String(templateID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		workspaces := make([]*Workspace, len(rows))
+		for i, r := range rows {
+			ws, err := workspaceRow(r).toWorkspace()
+			if err != nil {
+				return nil, err
+			}
+			workspaces[i] = ws
+		}
+		return workspaces, nil
+	})
+}
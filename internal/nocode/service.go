@@ -0,0 +1,434 @@
+package nocode
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/gorilla/mux"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/configversion"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/module"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+type (
+	// Service provisions workspaces from module templates.
+	Service struct {
+		logger       *slog.Logger
+		organization internal.Authorizer
+		workspace    internal.Authorizer
+		db           *pgdb
+		modules      moduleClient
+		workspaces   workspaceClient
+		configs      configClient
+		runs         runClient
+		hostname     hostnameClient
+		tfeapi       *tfe
+		web          *webHandlers
+	}
+
+	Options struct {
+		Logger               *slog.Logger
+		ModuleService        *module.Service
+		WorkspaceService     *workspace.Service
+		ConfigVersionService *configversion.Service
+		RunService           *run.Service
+		HostnameService      *internal.HostnameService
+
+		*sql.Pool
+		*tfeapi.Responder
+		html.Renderer
+	}
+
+	moduleClient interface {
+		GetModuleByID(ctx context.Context, id string) (*module.Module, error)
+		GetModuleInfo(ctx context.Context, versionID string) (*module.TerraformModule, error)
+	}
+
+	workspaceClient interface {
+		Create(ctx context.Context, opts workspace.CreateOptions) (*workspace.Workspace, error)
+	}
+
+	configClient interface {
+		Create(ctx context.Context, workspaceID string, opts configversion.CreateOptions) (*configversion.ConfigurationVersion, error)
+		UploadConfig(ctx context.Context, id string, config []byte) error
+	}
+
+	runClient interface {
+		Create(ctx context.Context, workspaceID string, opts run.CreateOptions) (*run.Run, error)
+	}
+
+	hostnameClient interface {
+		Hostname() string
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		logger:       opts.Logger,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		workspace:    opts.WorkspaceService,
+		db:           &pgdb{opts.Pool},
+		modules:      opts.ModuleService,
+		workspaces:   opts.WorkspaceService,
+		configs:      opts.ConfigVersionService,
+		runs:         opts.RunService,
+		hostname:     opts.HostnameService,
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	svc.web = &webHandlers{
+		Renderer: opts.Renderer,
+		svc:      &svc,
+		system:   opts.HostnameService,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.tfeapi.addHandlers(r)
+	s.web.addHandlers(r)
+}
+
+// CreateTemplate marks a module version as a template.
+func (s *Service) CreateTemplate(ctx context.Context, opts CreateTemplateOptions) (*Template, error) {
+	mod, err := s.modules.GetModuleByID(ctx, opts.ModuleID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateModuleTemplateAction, mod.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	now := internal.CurrentTimestamp(nil)
+	tmpl := &Template{
+		ID:             internal.NewID("modtmpl"),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		ModuleID:       mod.ID,
+		ModuleName:     mod.Name,
+		ModuleProvider: mod.Provider,
+		Organization:   mod.Organization,
+	}
+	info, err := s.setVersion(ctx, tmpl, mod, opts.Version)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Variables = variablesFromModule(info)
+	if opts.Variables != nil {
+		if err := validateVariables(opts.Variables, info); err != nil {
+			return nil, err
+		}
+		tmpl.Variables = opts.Variables
+	}
+
+	if err := s.db.createTemplate(ctx, tmpl); err != nil {
+		s.logger.Error("creating module template", "subject", subject, "template", tmpl, "err", err)
+		return nil, err
+	}
+	s.logger.Info("created module template", "subject", subject, "template", tmpl)
+	return tmpl, nil
+}
+
+// UpdateTemplate updates a template. Workspaces already provisioned from the
+// template are unaffected until they are upgraded.
+func (s *Service) UpdateTemplate(ctx context.Context, templateID string, opts UpdateTemplateOptions) (*Template, error) {
+	tmpl, err := s.db.getTemplate(ctx, templateID)
+	if err != nil {
+		s.logger.Error("retrieving module template", "id", templateID, "err", err)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateModuleTemplateAction, tmpl.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.updateTemplate(ctx, templateID, func(ctx context.Context, tmpl *Template) error {
+		var (
+			info *tfconfig.Module
+			err  error
+		)
+		if opts.Version != nil {
+			mod, err := s.modules.GetModuleByID(ctx, tmpl.ModuleID)
+			if err != nil {
+				return err
+			}
+			if info, err = s.setVersion(ctx, tmpl, mod, *opts.Version); err != nil {
+				return err
+			}
+			if opts.Variables == nil {
+				variables := variablesFromModule(info)
+				retainDefaults(variables, tmpl.Variables)
+				tmpl.Variables = variables
+			}
+		} else if info, err = s.moduleInfo(ctx, tmpl.ModuleVersionID); err != nil {
+			return err
+		}
+		if opts.Variables != nil {
+			if err := validateVariables(opts.Variables, info); err != nil {
+				return err
+			}
+			tmpl.Variables = opts.Variables
+		}
+		tmpl.UpdatedAt = internal.CurrentTimestamp(nil)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("updating module template", "subject", subject, "template", tmpl, "err", err)
+		return nil, err
+	}
+	s.logger.Info("updated module template", "subject", subject, "before", tmpl, "after", updated)
+	return updated, nil
+}
+
+// setVersion sets the template's module version, which must be available,
+// returning the module version's contents.
+func (s *Service) setVersion(ctx context.Context, tmpl *Template, mod *module.Module, version string) (*tfconfig.Module, error) {
+	modver := mod.Version(version)
+	if modver == nil || modver.Status != module.ModuleVersionStatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrModuleVersionNotAvailable, version)
+	}
+	info, err := s.moduleInfo(ctx, modver.ID)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.ModuleVersionID = modver.ID
+	tmpl.Version = modver.Version
+	tmpl.Outputs = outputsFromModule(info)
+	return info, nil
+}
+
+func (s *Service) moduleInfo(ctx context.Context, versionID string) (*tfconfig.Module, error) {
+	info, err := s.modules.GetModuleInfo(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	return info.Module, nil
+}
+
+func (s *Service) GetTemplate(ctx context.Context, templateID string) (*Template, error) {
+	tmpl, err := s.db.getTemplate(ctx, templateID)
+	if err != nil {
+		s.logger.Error("retrieving module template", "id", templateID, "err", err)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.GetModuleTemplateAction, tmpl.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("retrieved module template", "subject", subject, "template", tmpl)
+	return tmpl, nil
+}
+
+func (s *Service) GetTemplateByModuleID(ctx context.Context, moduleID string) (*Template, error) {
+	tmpl, err := s.db.getTemplateByModuleID(ctx, moduleID)
+	if err != nil {
+		s.logger.Error("retrieving module template", "module_id", moduleID, "err", err)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.GetModuleTemplateAction, tmpl.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("retrieved module template", "subject", subject, "template", tmpl)
+	return tmpl, nil
+}
+
+func (s *Service) ListTemplates(ctx context.Context, organization string) ([]*Template, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.ListModuleTemplatesAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.db.listTemplates(ctx, organization)
+	if err != nil {
+		s.logger.Error("listing module templates", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("listed module templates", "organization", organization, "subject", subject, "count", len(templates))
+	return templates, nil
+}
+
+// DeleteTemplate deletes a template. Workspaces provisioned from the template
+// are retained but can no longer be upgraded.
+func (s *Service) DeleteTemplate(ctx context.Context, templateID string) (*Template, error) {
+	tmpl, err := s.db.getTemplate(ctx, templateID)
+	if err != nil {
+		s.logger.Error("retrieving module template", "id", templateID, "err", err)
+		return nil, err
+	}
+
+	subject, err := s.organization.CanAccess(ctx, rbac.DeleteModuleTemplateAction, tmpl.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.deleteTemplate(ctx, templateID); err != nil {
+		s.logger.Error("deleting module template", "subject", subject, "template", tmpl, "err", err)
+		return nil, err
+	}
+	s.logger.Info("deleted module template", "subject", subject, "template", tmpl)
+	return tmpl, nil
+}
+
+// ListWorkspaces lists the workspaces provisioned from a template.
+func (s *Service) ListWorkspaces(ctx context.Context, templateID string) ([]*Workspace, error) {
+	tmpl, err := s.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := s.db.listWorkspaces(ctx, tmpl.ID)
+	if err != nil {
+		s.logger.Error("listing module template workspaces", "template", tmpl, "err", err)
+		return nil, err
+	}
+	return workspaces, nil
+}
+
+// Provision creates a workspace from a template, uploading a configuration
+// calling the template's module with the given values, and queues the
+// workspace's first run.
+func (s *Service) Provision(ctx context.Context, templateID string, opts ProvisionOptions) (*Workspace, error) {
+	tmpl, err := s.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := tmpl.values(opts.Variables)
+	if err != nil {
+		return nil, err
+	}
+	// generate configuration before creating workspace in case the
+	// configuration cannot be generated.
+	config, err := tmpl.generateConfig(s.hostname.Hostname(), values)
+	if err != nil {
+		return nil, err
+	}
+
+	var provisioned *Workspace
+	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		ws, err := s.workspaces.Create(ctx, workspace.CreateOptions{
+			Name:         &opts.Name,
+			Organization: &tmpl.Organization,
+			SourceName:   internal.String(SourceName),
+			SourceURL:    internal.String(paths.ModuleTemplate(tmpl.ID)),
+		})
+		if err != nil {
+			return err
+		}
+		provisioned = &Workspace{
+			WorkspaceID:   ws.ID,
+			WorkspaceName: ws.Name,
+			TemplateID:    tmpl.ID,
+			Version:       tmpl.Version,
+			Variables:     given(opts.Variables),
+		}
+		if err := s.db.createWorkspace(ctx, provisioned); err != nil {
+			return err
+		}
+		return s.startRun(ctx, ws.ID, config)
+	})
+	if err != nil {
+		s.logger.Error("provisioning workspace from module template", "template", tmpl, "name", opts.Name, "err", err)
+		return nil, err
+	}
+	s.logger.Info("provisioned workspace from module template", "template", tmpl, "workspace_id", provisioned.WorkspaceID)
+	return provisioned, nil
+}
+
+// Upgrade regenerates the configuration of a workspace provisioned from a
+// template, calling the template's current module version, and queues a run.
+func (s *Service) Upgrade(ctx context.Context, workspaceID string, opts UpgradeOptions) (*Workspace, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	upgraded, err := s.db.updateWorkspace(ctx, workspaceID, func(ctx context.Context, ws *Workspace) error {
+		tmpl, err := s.db.getTemplate(ctx, ws.TemplateID)
+		if err != nil {
+			return err
+		}
+		if !ws.UpgradeAvailable(tmpl) {
+			return ErrWorkspaceUpToDate
+		}
+		// carry over values for variables still in the template's schema.
+		variables := make(map[string]string, len(ws.Variables)+len(opts.Variables))
+		for name, value := range ws.Variables {
+			if slices.ContainsFunc(tmpl.Variables, func(v Variable) bool { return v.Name == name }) {
+				variables[name] = value
+			}
+		}
+		for name, value := range opts.Variables {
+			variables[name] = value
+		}
+		values, err := tmpl.values(variables)
+		if err != nil {
+			return err
+		}
+		config, err := tmpl.generateConfig(s.hostname.Hostname(), values)
+		if err != nil {
+			return err
+		}
+		if err := s.startRun(ctx, ws.WorkspaceID, config); err != nil {
+			return err
+		}
+		ws.Version = tmpl.Version
+		ws.Variables = given(variables)
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("upgrading workspace to module template version", "workspace_id", workspaceID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("upgraded workspace to module template version", "workspace_id", workspaceID, "subject", subject, "version", upgraded.Version)
+	return upgraded, nil
+}
+
+// startRun uploads the configuration to the workspace and queues a run.
+func (s *Service) startRun(ctx context.Context, workspaceID string, config []byte) error {
+	cv, err := s.configs.Create(ctx, workspaceID, configversion.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if err := s.configs.UploadConfig(ctx, cv.ID, config); err != nil {
+		return err
+	}
+	_, err = s.runs.Create(ctx, workspaceID, run.CreateOptions{
+		ConfigurationVersionID: &cv.ID,
+	})
+	return err
+}
+
+// given returns the values that were actually provided, so that a variable
+// without a value continues to pick up the template's default.
+func given(values map[string]string) map[string]string {
+	given := make(map[string]string, len(values))
+	for name, value := range values {
+		if value != "" {
+			given[name] = value
+		}
+	}
+	return given
+}
@@ -0,0 +1,333 @@
+// Package nocode provisions workspaces from registry module templates, without
+// users having to write any configuration.
+package nocode
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// SourceName is the source name assigned to workspaces provisioned from
+	// a template.
+	SourceName = "module template"
+
+	// moduleName is the name of the module block in the generated root
+	// configuration.
+	moduleName = "main"
+	// configFilename is the name of the file containing the generated root
+	// configuration.
+	configFilename = "main.tf"
+)
+
+var (
+	ErrModuleVersionNotAvailable = errors.New("module version is not available")
+	ErrMissingVariable           = errors.New("missing value for required variable")
+	ErrUnknownVariable           = errors.New("unknown variable")
+	ErrInvalidVariableValue      = errors.New("invalid variable value")
+	ErrWorkspaceUpToDate         = errors.New("workspace is already using the template's module version")
+)
+
+type (
+	// Template is a version of a registry module from which workspaces can be
+	// provisioned.
+	Template struct {
+		ID        string
+		CreatedAt time.Time
+		UpdatedAt time.Time
+		// Module from which workspaces are provisioned.
+		ModuleID       string
+		ModuleName     string
+		ModuleProvider string
+		Organization   string
+		// Version of the module from which workspaces are provisioned.
+		ModuleVersionID string
+		Version         string
+		// Variables is the schema of the values collected when provisioning
+		// a workspace.
+		Variables []Variable
+		// Outputs are the module outputs exposed by the generated configuration.
+		Outputs []Output
+	}
+
+	// Variable is an input variable of the template's module.
+	Variable struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		// Type is the variable's type constraint, e.g. string, number, bool,
+		// list(string). A value for a variable with a type other than string,
+		// number, or bool is expected to be an HCL expression.
+		Type string `json:"type,omitempty"`
+		// Default is the value used when provisioning a workspace without a
+		// value for the variable. Overrides the module's own default.
+		Default *string `json:"default,omitempty"`
+		// Required is true if a value must be provided when provisioning a
+		// workspace.
+		Required bool `json:"required"`
+	}
+
+	// Output is an output of the template's module.
+	Output struct {
+		Name      string `json:"name"`
+		Sensitive bool   `json:"sensitive,omitempty"`
+	}
+
+	// Workspace is a workspace provisioned from a template.
+	Workspace struct {
+		WorkspaceID   string
+		WorkspaceName string
+		TemplateID    string
+		// Version of the module with which the workspace's configuration was
+		// last generated.
+		Version string
+		// Variables are the values with which the workspace's configuration
+		// was last generated.
+		Variables map[string]string
+	}
+
+	CreateTemplateOptions struct {
+		ModuleID string
+		Version  string
+		// Variables defaults to the module's input variables.
+		Variables []Variable
+	}
+
+	UpdateTemplateOptions struct {
+		// Version updates the module version from which workspaces are
+		// provisioned.
+		Version *string
+		// Variables replaces the variable schema. If nil and the version is
+		// updated then the schema is derived from the module's input variables,
+		// retaining the defaults of existing variables.
+		Variables []Variable
+	}
+
+	ProvisionOptions struct {
+		// Name of the workspace to create.
+		Name string
+		// Variables are the values for the template's variables.
+		Variables map[string]string
+	}
+
+	UpgradeOptions struct {
+		// Variables sets values for variables introduced by the template's
+		// newer module version, or overrides existing values.
+		Variables map[string]string
+	}
+)
+
+// variablesFromModule derives a variable schema from a module's input
+// variables.
+func variablesFromModule(mod *tfconfig.Module) []Variable {
+	variables := make([]Variable, 0, len(mod.Variables))
+	for _, v := range mod.Variables {
+		variables = append(variables, Variable{
+			Name:        v.Name,
+			Description: v.Description,
+			Type:        v.Type,
+			Required:    v.Required,
+		})
+	}
+	slices.SortFunc(variables, func(a, b Variable) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return variables
+}
+
+// outputsFromModule derives the outputs to expose from a module's outputs.
+func outputsFromModule(mod *tfconfig.Module) []Output {
+	outputs := make([]Output, 0, len(mod.Outputs))
+	for _, o := range mod.Outputs {
+		outputs = append(outputs, Output{Name: o.Name, Sensitive: o.Sensitive})
+	}
+	slices.SortFunc(outputs, func(a, b Output) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return outputs
+}
+
+// validateVariables validates a variable schema against a module's input
+// variables.
+func validateVariables(variables []Variable, mod *tfconfig.Module) error {
+	for _, v := range variables {
+		if _, ok := mod.Variables[v.Name]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownVariable, v.Name)
+		}
+		if v.Default != nil {
+			if _, err := v.tokens(*v.Default); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// retainDefaults sets the defaults of variables to that of the variable of
+// the same name in previous.
+func retainDefaults(variables, previous []Variable) {
+	for i, v := range variables {
+		for _, prev := range previous {
+			if prev.Name == v.Name && prev.Default != nil {
+				variables[i].Default = prev.Default
+			}
+		}
+	}
+}
+
+// Source is the template's module address in the registry.
+func (t *Template) Source(hostname string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", hostname, t.Organization, t.ModuleName, t.ModuleProvider)
+}
+
+// values validates the given values against the template's variables,
+// returning the values to use in generating a configuration, with defaults
+// applied.
+func (t *Template) values(given map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(t.Variables))
+	for name := range given {
+		if !slices.ContainsFunc(t.Variables, func(v Variable) bool { return v.Name == name }) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownVariable, name)
+		}
+	}
+	for _, v := range t.Variables {
+		value, ok := given[v.Name]
+		if !ok || value == "" {
+			if v.Default != nil {
+				values[v.Name] = *v.Default
+			} else if v.Required {
+				return nil, fmt.Errorf("%w: %s", ErrMissingVariable, v.Name)
+			}
+			continue
+		}
+		if _, err := v.tokens(value); err != nil {
+			return nil, err
+		}
+		values[v.Name] = value
+	}
+	return values, nil
+}
+
+// tokens converts the value into tokens for the variable's type.
+func (v Variable) tokens(value string) (hclwrite.Tokens, error) {
+	invalid := func(err error) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidVariableValue, v.Name, err)
+	}
+	switch v.Type {
+	case "", "string":
+		return hclwrite.TokensForValue(cty.StringVal(value)), nil
+	case "number":
+		n, err := cty.ParseNumberVal(value)
+		if err != nil {
+			return nil, invalid(err)
+		}
+		return hclwrite.TokensForValue(n), nil
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, invalid(err)
+		}
+		return hclwrite.TokensForValue(cty.BoolVal(b)), nil
+	default:
+		// Permit only a single expression that doesn't reference anything,
+		// which might otherwise, say, read files from the agent.
+		expr, diags := hclsyntax.ParseExpression([]byte(value), v.Name, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, invalid(diags)
+		}
+		if len(expr.Variables()) > 0 || hasFunctionCall(expr) {
+			return nil, invalid(errors.New("expression must be a literal value"))
+		}
+		f, diags := hclwrite.ParseConfig([]byte("value = "+value), v.Name, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, invalid(diags)
+		}
+		return f.Body().GetAttribute("value").Expr().BuildTokens(nil), nil
+	}
+}
+
+func hasFunctionCall(expr hclsyntax.Expression) (found bool) {
+	hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		if _, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// generateConfig generates a root configuration calling the template's module
+// with the given values, which are expected to have been validated, returning
+// the configuration as a tarball.
+func (t *Template) generateConfig(hostname string, values map[string]string) ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	module := body.AppendNewBlock("module", []string{moduleName}).Body()
+	module.SetAttributeValue("source", cty.StringVal(t.Source(hostname)))
+	module.SetAttributeValue("version", cty.StringVal(t.Version))
+	for _, v := range t.Variables {
+		value, ok := values[v.Name]
+		if !ok {
+			continue
+		}
+		tokens, err := v.tokens(value)
+		if err != nil {
+			return nil, err
+		}
+		module.SetAttributeRaw(v.Name, tokens)
+	}
+
+	for _, o := range t.Outputs {
+		body.AppendNewline()
+		output := body.AppendNewBlock("output", []string{o.Name}).Body()
+		output.SetAttributeTraversal("value", hcl.Traversal{
+			hcl.TraverseRoot{Name: "module"},
+			hcl.TraverseAttr{Name: moduleName},
+			hcl.TraverseAttr{Name: o.Name},
+		})
+		if o.Sensitive {
+			output.SetAttributeValue("sensitive", cty.True)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "nocode-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, configFilename), f.Bytes(), 0o644); err != nil {
+		return nil, err
+	}
+	return internal.Pack(dir)
+}
+
+func (t *Template) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", t.ID),
+		slog.String("organization", t.Organization),
+		slog.String("module", t.ModuleName),
+		slog.String("provider", t.ModuleProvider),
+		slog.String("version", t.Version),
+	)
+}
+
+// UpgradeAvailable determines whether the workspace's configuration was
+// generated with a module version other than the template's current version.
+func (ws *Workspace) UpgradeAvailable(tmpl *Template) bool {
+	return ws.Version != tmpl.Version
+}
@@ -0,0 +1,148 @@
+package nocode
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestTemplate_values(t *testing.T) {
+	tmpl := &Template{
+		Variables: []Variable{
+			{Name: "name", Type: "string", Required: true},
+			{Name: "replicas", Type: "number", Default: internal.String("3")},
+			{Name: "public", Type: "bool"},
+			{Name: "zones", Type: "list(string)"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		given map[string]string
+		want  map[string]string
+		err   error
+	}{
+		{
+			name:  "required and default",
+			given: map[string]string{"name": "web"},
+			want:  map[string]string{"name": "web", "replicas": "3"},
+		},
+		{
+			name:  "override default",
+			given: map[string]string{"name": "web", "replicas": "5", "public": "true", "zones": `["a", "b"]`},
+			want:  map[string]string{"name": "web", "replicas": "5", "public": "true", "zones": `["a", "b"]`},
+		},
+		{
+			name:  "empty value is treated as missing",
+			given: map[string]string{"name": "web", "replicas": ""},
+			want:  map[string]string{"name": "web", "replicas": "3"},
+		},
+		{
+			name:  "missing required variable",
+			given: map[string]string{"replicas": "5"},
+			err:   ErrMissingVariable,
+		},
+		{
+			name:  "unknown variable",
+			given: map[string]string{"name": "web", "region": "eu"},
+			err:   ErrUnknownVariable,
+		},
+		{
+			name:  "invalid number",
+			given: map[string]string{"name": "web", "replicas": "lots"},
+			err:   ErrInvalidVariableValue,
+		},
+		{
+			name:  "invalid bool",
+			given: map[string]string{"name": "web", "public": "maybe"},
+			err:   ErrInvalidVariableValue,
+		},
+		{
+			name:  "expression referencing a variable",
+			given: map[string]string{"name": "web", "zones": "[var.secret]"},
+			err:   ErrInvalidVariableValue,
+		},
+		{
+			name:  "expression calling a function",
+			given: map[string]string{"name": "web", "zones": `[file("/etc/passwd")]`},
+			err:   ErrInvalidVariableValue,
+		},
+		{
+			name:  "more than one expression",
+			given: map[string]string{"name": "web", "zones": "[]\nsource = \"evil\""},
+			err:   ErrInvalidVariableValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmpl.values(tt.given)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTemplate_generateConfig(t *testing.T) {
+	tmpl := &Template{
+		ModuleName:     "web",
+		ModuleProvider: "aws",
+		Organization:   "acme",
+		Version:        "1.2.0",
+		Variables: []Variable{
+			{Name: "name", Type: "string"},
+			{Name: "replicas", Type: "number"},
+			{Name: "zones", Type: "list(string)"},
+		},
+		Outputs: []Output{
+			{Name: "endpoint"},
+			{Name: "password", Sensitive: true},
+		},
+	}
+
+	tarball, err := tmpl.generateConfig("otf.acme.com", map[string]string{
+		"name":     `web "prod"`,
+		"replicas": "3",
+		"zones":    `["a", "b"]`,
+	})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, internal.Unpack(bytes.NewReader(tarball), dir))
+	got, err := os.ReadFile(filepath.Join(dir, "main.tf"))
+	require.NoError(t, err)
+
+	want := `module "main" {
+  source   = "otf.acme.com/acme/web/aws"
+  version  = "1.2.0"
+  name     = "web \"prod\""
+  replicas = 3
+  zones    = ["a", "b"]
+}
+
+output "endpoint" {
+  value = module.main.endpoint
+}
+
+output "password" {
+  value     = module.main.password
+  sensitive = true
+}
+`
+	assert.Equal(t, want, string(got))
+}
+
+func TestWorkspace_UpgradeAvailable(t *testing.T) {
+	tmpl := &Template{Version: "1.1.0"}
+
+	assert.True(t, (&Workspace{Version: "1.0.0"}).UpgradeAvailable(tmpl))
+	assert.False(t, (&Workspace{Version: "1.1.0"}).UpgradeAvailable(tmpl))
+}
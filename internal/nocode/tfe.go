@@ -0,0 +1,272 @@
+package nocode
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/organizations/{organization_name}/module-templates", a.listTemplates).Methods("GET")
+	r.HandleFunc("/module-templates", a.createTemplate).Methods("POST")
+	r.HandleFunc("/module-templates/{template_id}", a.getTemplate).Methods("GET")
+	r.HandleFunc("/module-templates/{template_id}", a.updateTemplate).Methods("PATCH")
+	r.HandleFunc("/module-templates/{template_id}", a.deleteTemplate).Methods("DELETE")
+	r.HandleFunc("/module-templates/{template_id}/workspaces", a.listWorkspaces).Methods("GET")
+	r.HandleFunc("/module-templates/{template_id}/workspaces", a.provision).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/actions/upgrade-module-template", a.upgrade).Methods("POST")
+}
+
+func (a *tfe) listTemplates(w http.ResponseWriter, r *http.Request) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	templates, err := a.ListTemplates(r.Context(), organization)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.ModuleTemplate, len(templates))
+	for i, from := range templates {
+		items[i] = a.toTemplate(from)
+	}
+	a.Respond(w, r, items, http.StatusOK)
+}
+
+func (a *tfe) createTemplate(w http.ResponseWriter, r *http.Request) {
+	var params types.ModuleTemplateCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	tmpl, err := a.CreateTemplate(r.Context(), CreateTemplateOptions{
+		ModuleID:  params.ModuleID,
+		Version:   params.Version,
+		Variables: fromVariables(params.Variables),
+	})
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toTemplate(tmpl), http.StatusCreated)
+}
+
+func (a *tfe) getTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("template_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	tmpl, err := a.GetTemplate(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toTemplate(tmpl), http.StatusOK)
+}
+
+func (a *tfe) updateTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("template_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ModuleTemplateUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	tmpl, err := a.UpdateTemplate(r.Context(), id, UpdateTemplateOptions{
+		Version:   params.Version,
+		Variables: fromVariables(params.Variables),
+	})
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toTemplate(tmpl), http.StatusOK)
+}
+
+func (a *tfe) deleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("template_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if _, err := a.DeleteTemplate(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *tfe) listWorkspaces(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("template_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	tmpl, err := a.GetTemplate(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	workspaces, err := a.ListWorkspaces(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.ModuleTemplateWorkspace, len(workspaces))
+	for i, from := range workspaces {
+		items[i] = a.toWorkspace(from, tmpl)
+	}
+	a.Respond(w, r, items, http.StatusOK)
+}
+
+func (a *tfe) provision(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("template_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ModuleTemplateProvisionOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	ws, err := a.Provision(r.Context(), id, ProvisionOptions{
+		Name:      params.Name,
+		Variables: fromValues(params.Variables),
+	})
+	if err != nil {
+		a.error(w, err)
+		return
+	}
+	tmpl, err := a.GetTemplate(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toWorkspace(ws, tmpl), http.StatusCreated)
+}
+
+func (a *tfe) upgrade(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.ModuleTemplateUpgradeOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	ws, err := a.Upgrade(r.Context(), id, UpgradeOptions{
+		Variables: fromValues(params.Variables),
+	})
+	if errors.Is(err, ErrWorkspaceUpToDate) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusConflict, Message: err.Error()})
+		return
+	} else if err != nil {
+		a.error(w, err)
+		return
+	}
+	tmpl, err := a.GetTemplate(r.Context(), ws.TemplateID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toWorkspace(ws, tmpl), http.StatusOK)
+}
+
+// error reports invalid templates and variable values as a 422.
+func (a *tfe) error(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrModuleVersionNotAvailable),
+		errors.Is(err, ErrMissingVariable),
+		errors.Is(err, ErrUnknownVariable),
+		errors.Is(err, ErrInvalidVariableValue):
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+	default:
+		tfeapi.Error(w, err)
+	}
+}
+
+func (a *tfe) toTemplate(from *Template) *types.ModuleTemplate {
+	to := &types.ModuleTemplate{
+		ID:             from.ID,
+		ModuleID:       from.ModuleID,
+		ModuleName:     from.ModuleName,
+		ModuleProvider: from.ModuleProvider,
+		Version:        from.Version,
+		Source:         from.Source(a.hostname.Hostname()),
+		Variables:      make([]types.ModuleTemplateVariable, len(from.Variables)),
+		CreatedAt:      from.CreatedAt,
+		UpdatedAt:      from.UpdatedAt,
+		Organization:   &types.Organization{Name: from.Organization},
+	}
+	for i, v := range from.Variables {
+		to.Variables[i] = types.ModuleTemplateVariable(v)
+	}
+	return to
+}
+
+func (a *tfe) toWorkspace(from *Workspace, tmpl *Template) *types.ModuleTemplateWorkspace {
+	to := &types.ModuleTemplateWorkspace{
+		ID:               from.WorkspaceID,
+		Name:             from.WorkspaceName,
+		Version:          from.Version,
+		UpgradeAvailable: from.UpgradeAvailable(tmpl),
+		Variables:        make([]types.ModuleTemplateVariableValue, 0, len(from.Variables)),
+		ModuleTemplate:   &types.ModuleTemplate{ID: tmpl.ID},
+	}
+	for _, v := range tmpl.Variables {
+		if value, ok := from.Variables[v.Name]; ok {
+			to.Variables = append(to.Variables, types.ModuleTemplateVariableValue{Name: v.Name, Value: value})
+		}
+	}
+	return to
+}
+
+func fromVariables(from []types.ModuleTemplateVariable) []Variable {
+	if from == nil {
+		return nil
+	}
+	to := make([]Variable, len(from))
+	for i, v := range from {
+		to[i] = Variable(v)
+	}
+	return to
+}
+
+func fromValues(from []types.ModuleTemplateVariableValue) map[string]string {
+	to := make(map[string]string, len(from))
+	for _, v := range from {
+		to[v.Name] = v.Value
+	}
+	return to
+}
@@ -0,0 +1,216 @@
+package nocode
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+type (
+	webHandlers struct {
+		html.Renderer
+
+		svc    webClient
+		system hostnameClient
+	}
+
+	webClient interface {
+		CreateTemplate(ctx context.Context, opts CreateTemplateOptions) (*Template, error)
+		UpdateTemplate(ctx context.Context, templateID string, opts UpdateTemplateOptions) (*Template, error)
+		GetTemplate(ctx context.Context, templateID string) (*Template, error)
+		GetTemplateByModuleID(ctx context.Context, moduleID string) (*Template, error)
+		ListTemplates(ctx context.Context, organization string) ([]*Template, error)
+		DeleteTemplate(ctx context.Context, templateID string) (*Template, error)
+		ListWorkspaces(ctx context.Context, templateID string) ([]*Workspace, error)
+		Provision(ctx context.Context, templateID string, opts ProvisionOptions) (*Workspace, error)
+		Upgrade(ctx context.Context, workspaceID string, opts UpgradeOptions) (*Workspace, error)
+	}
+)
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/module-templates", h.list).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/module-templates/create", h.create).Methods("POST")
+	r.HandleFunc("/module-templates/{module_template_id}", h.get).Methods("GET")
+	r.HandleFunc("/module-templates/{module_template_id}/delete", h.delete).Methods("POST")
+	r.HandleFunc("/module-templates/{module_template_id}/provision", h.provision).Methods("POST")
+	r.HandleFunc("/module-templates/{module_template_id}/upgrade-workspace", h.upgrade).Methods("POST")
+}
+
+func (h *webHandlers) list(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	templates, err := h.svc.ListTemplates(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("module_template_list.tmpl", w, struct {
+		organization.OrganizationPage
+		Items []*Template
+	}{
+		OrganizationPage: organization.NewPage(r, "module templates", org),
+		Items:            templates,
+	})
+}
+
+// create marks a module version as a template, or if the module already has a
+// template then updates the template to the version.
+func (h *webHandlers) create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ModuleID string `schema:"module_id,required"`
+		Version  string `schema:"version,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	tmpl, err := h.svc.CreateTemplate(r.Context(), CreateTemplateOptions{
+		ModuleID: params.ModuleID,
+		Version:  params.Version,
+	})
+	if errors.Is(err, internal.ErrResourceAlreadyExists) {
+		tmpl, err = h.svc.GetTemplateByModuleID(r.Context(), params.ModuleID)
+		if err == nil {
+			tmpl, err = h.svc.UpdateTemplate(r.Context(), tmpl.ID, UpdateTemplateOptions{
+				Version: &params.Version,
+			})
+		}
+	}
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Module(params.ModuleID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "module version "+tmpl.Version+" is now the template")
+	http.Redirect(w, r, paths.ModuleTemplate(tmpl.ID), http.StatusFound)
+}
+
+func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("module_template_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	tmpl, err := h.svc.GetTemplate(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	workspaces, err := h.svc.ListWorkspaces(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("module_template_get.tmpl", w, struct {
+		organization.OrganizationPage
+		Template     *Template
+		Source       string
+		Workspaces   []*Workspace
+		CanProvision bool
+		CanDelete    bool
+	}{
+		OrganizationPage: organization.NewPage(r, tmpl.ModuleName, tmpl.Organization),
+		Template:         tmpl,
+		Source:           tmpl.Source(h.system.Hostname()),
+		Workspaces:       workspaces,
+		CanProvision:     subject.CanAccessOrganization(rbac.CreateWorkspaceAction, tmpl.Organization),
+		CanDelete:        subject.CanAccessOrganization(rbac.DeleteModuleTemplateAction, tmpl.Organization),
+	})
+}
+
+func (h *webHandlers) delete(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("module_template_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	tmpl, err := h.svc.DeleteTemplate(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted module template: "+tmpl.ModuleName)
+	http.Redirect(w, r, paths.ModuleTemplates(tmpl.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) provision(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID   string `schema:"module_template_id,required"`
+		Name string `schema:"name,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	tmpl, err := h.svc.GetTemplate(r.Context(), params.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// each variable has a form field named after the variable
+	variables := make(map[string]string, len(tmpl.Variables))
+	for _, v := range tmpl.Variables {
+		variables[v.Name] = r.PostFormValue("variable." + v.Name)
+	}
+
+	ws, err := h.svc.Provision(r.Context(), tmpl.ID, ProvisionOptions{
+		Name:      params.Name,
+		Variables: variables,
+	})
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.ModuleTemplate(tmpl.ID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "provisioned workspace: "+ws.WorkspaceName)
+	http.Redirect(w, r, paths.Workspace(ws.WorkspaceID), http.StatusFound)
+}
+
+func (h *webHandlers) upgrade(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ID          string `schema:"module_template_id,required"`
+		WorkspaceID string `schema:"workspace_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	ws, err := h.svc.Upgrade(r.Context(), params.WorkspaceID, UpgradeOptions{})
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.ModuleTemplate(params.ID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "upgraded workspace "+ws.WorkspaceName+" to version "+ws.Version)
+	http.Redirect(w, r, paths.ModuleTemplate(params.ID), http.StatusFound)
+}
@@ -26,7 +26,9 @@ type (
 		WorkspaceID                 string
 		WorkspaceName               string
 		OrganizationName            string
-		Notifications               []genericNotificationPayload
+		// RunLabels are the labels attached to the run. OTF extension.
+		RunLabels     map[string]string `json:",omitempty"`
+		Notifications []genericNotificationPayload
 	}
 
 	genericNotificationPayload struct {
@@ -35,6 +37,8 @@ type (
 		RunStatus    run.Status
 		RunUpdatedAt time.Time
 		RunUpdatedBy string
+		// Mentions are the usernames mentioned in a comment. OTF extension.
+		Mentions []string `json:",omitempty"`
 	}
 
 	genericClient struct {
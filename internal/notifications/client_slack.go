@@ -35,7 +35,39 @@ func newSlackClient(cfg *Config) (*slackClient, error) {
 }
 
 func (c *slackClient) Publish(ctx context.Context, n *notification) error {
-	data, err := json.Marshal(slackMessage{
+	data, err := json.Marshal(newSlackMessage(n))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", c.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func newSlackMessage(n *notification) slackMessage {
+	if n.comment != nil {
+		text := fmt.Sprintf("*%s* commented on run <%s|%s/%s>:\n%s", n.comment.Author, n.runURL(), n.workspace.Organization, n.workspace.Name, n.comment.Body)
+		if len(n.comment.Mentions) > 0 {
+			text += "\nmentioned: " + strings.Join(n.comment.Mentions, ", ")
+		}
+		return slackMessage{
+			Blocks: []slackBlock{
+				{
+					Type: "section",
+					Text: &slackBlock{Type: "mrkdwn", Text: text},
+				},
+			},
+		}
+	}
+	return slackMessage{
 		Blocks: []slackBlock{
 			{
 				Type: "section",
@@ -52,19 +84,5 @@ func (c *slackClient) Publish(ctx context.Context, n *notification) error {
 				},
 			},
 		},
-	})
-	if err != nil {
-		return err
-	}
-	req, err := http.NewRequest("POST", c.url, bytes.NewReader(data))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-type", "application/json")
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
 	}
-	resp.Body.Close()
-	return nil
 }
@@ -29,6 +29,8 @@ const (
 	TriggerApplying       Trigger = "run:applying"
 	TriggerCompleted      Trigger = "run:completed"
 	TriggerErrored        Trigger = "run:errored"
+	// TriggerCommented is triggered when a run is commented on. OTF extension.
+	TriggerCommented Trigger = "run:commented"
 )
 
 var (
@@ -208,7 +210,8 @@ func validTriggers(triggers []Trigger) error {
 			TriggerNeedsAttention,
 			TriggerApplying,
 			TriggerCompleted,
-			TriggerErrored:
+			TriggerErrored,
+			TriggerCommented:
 		default:
 			return ErrInvalidTrigger
 		}
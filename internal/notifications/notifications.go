@@ -20,6 +20,9 @@ type notification struct {
 	trigger   Trigger
 	config    *Config
 	hostname  string
+
+	// comment is the comment being notified, if any.
+	comment *run.Comment
 }
 
 func (n *notification) LogValue() slog.Value {
@@ -29,6 +32,9 @@ func (n *notification) LogValue() slog.Value {
 		slog.String("trigger", string(n.trigger)),
 		slog.String("destination", string(n.config.DestinationType)),
 	}
+	if n.comment != nil {
+		attrs = append(attrs, slog.String("comment", n.comment.ID))
+	}
 	return slog.GroupValue(attrs...)
 }
 
@@ -39,7 +45,7 @@ func (n *notification) genericPayload() (*GenericPayload, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &GenericPayload{
+	payload := &GenericPayload{
 		PayloadVersion:              1,
 		NotificationConfigurationID: "",
 		RunURL:                      n.runURL(),
@@ -48,6 +54,7 @@ func (n *notification) genericPayload() (*GenericPayload, error) {
 		WorkspaceID:                 n.workspace.ID,
 		WorkspaceName:               n.workspace.Name,
 		OrganizationName:            n.workspace.Organization,
+		RunLabels:                   n.run.Labels,
 		Notifications: []genericNotificationPayload{
 			{
 				Trigger:      n.trigger,
@@ -55,7 +62,13 @@ func (n *notification) genericPayload() (*GenericPayload, error) {
 				RunUpdatedAt: runUpdatedAt,
 			},
 		},
-	}, nil
+	}
+	if n.comment != nil {
+		payload.Notifications[0].Message = n.comment.Body
+		payload.Notifications[0].RunUpdatedBy = n.comment.Author
+		payload.Notifications[0].Mentions = n.comment.Mentions
+	}
+	return payload, nil
 }
 
 func (n *notification) runURL() string {
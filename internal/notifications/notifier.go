@@ -45,7 +45,9 @@ type (
 	}
 
 	notifierRunClient interface {
+		Get(ctx context.Context, runID string) (*run.Run, error)
 		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+		WatchComments(context.Context) (<-chan pubsub.Event[*run.Comment], func())
 	}
 
 	notifierNotificationClient interface {
@@ -70,9 +72,12 @@ func NewNotifier(opts NotifierOptions) *Notifier {
 
 // Start the notifier daemon. Should be started in a go-routine.
 func (s *Notifier) Start(ctx context.Context) error {
-	// subscribe to both run events and notification config events
+	// subscribe to run events, run comment events, and notification config
+	// events
 	subRuns, unsubRuns := s.runs.Watch(ctx)
 	defer unsubRuns()
+	subComments, unsubComments := s.runs.WatchComments(ctx)
+	defer unsubComments()
 	subConfigs, unsubConfigs := s.notifications.Watch(ctx)
 	defer unsubConfigs()
 
@@ -93,6 +98,17 @@ func (s *Notifier) Start(ctx context.Context) error {
 			if err := s.handleRun(ctx, event.Payload); err != nil {
 				s.logger.Error("handling event", "event", event.Type, "err", err)
 			}
+		case event, ok := <-subComments:
+			if !ok {
+				return pubsub.ErrSubscriptionTerminated
+			}
+			if event.Type != pubsub.CreatedEvent {
+				// only new comments are notified
+				continue
+			}
+			if err := s.handleComment(ctx, event.Payload); err != nil {
+				s.logger.Error("handling event", "event", event.Type, "err", err)
+			}
 		case event, ok := <-subConfigs:
 			if !ok {
 				return pubsub.ErrSubscriptionTerminated
@@ -125,7 +141,27 @@ func (s *Notifier) handleRun(ctx context.Context, r *run.Run) error {
 		// ignore queued events
 		return nil
 	}
+	return s.notify(ctx, r, nil, func(cfg *Config) (Trigger, bool) {
+		return cfg.matchTrigger(r)
+	})
+}
+
+// handleComment notifies a comment on a run to destinations configured with
+// the comment trigger. The usernames mentioned in the comment are included in
+// the notification.
+func (s *Notifier) handleComment(ctx context.Context, comment *run.Comment) error {
+	r, err := s.runs.Get(ctx, comment.RunID)
+	if err != nil {
+		return err
+	}
+	return s.notify(ctx, r, comment, func(cfg *Config) (Trigger, bool) {
+		return TriggerCommented, cfg.hasTrigger(TriggerCommented)
+	})
+}
 
+// notify publishes a notification to each enabled destination configured for
+// the run's workspace, for which match returns a matching trigger.
+func (s *Notifier) notify(ctx context.Context, r *run.Run, comment *run.Comment, match func(*Config) (Trigger, bool)) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -143,7 +179,7 @@ func (s *Notifier) handleRun(ctx context.Context, r *run.Run) error {
 			// skip config with no triggers
 			continue
 		}
-		trigger, matches := cfg.matchTrigger(r)
+		trigger, matches := match(cfg)
 		if !matches {
 			// skip config with no matching trigger
 			continue
@@ -169,6 +205,7 @@ func (s *Notifier) handleRun(ctx context.Context, r *run.Run) error {
 		msg := &notification{
 			run:       r,
 			workspace: ws,
+			comment:   comment,
 			trigger:   trigger,
 			config:    cfg,
 			hostname:  s.system.Hostname(),
@@ -112,6 +112,50 @@ func TestNotifier_handleRun_multiple(t *testing.T) {
 	assert.Equal(t, planningRun, <-published)
 }
 
+func TestNotifier_handleComment(t *testing.T) {
+	ctx := context.Background()
+	commentedRun := &run.Run{
+		ID:          "run-123",
+		Status:      run.RunPlanned,
+		WorkspaceID: "ws-123",
+	}
+	comment := &run.Comment{
+		RunID:    "run-123",
+		Author:   "bob",
+		Body:     "@alice please review",
+		Mentions: []string{"alice"},
+	}
+
+	tests := []struct {
+		name          string
+		cfg           *Config
+		wantPublished bool
+	}{
+		{"matching trigger", newTestConfig(t, "ws-123", DestinationGeneric, "", TriggerCommented), true},
+		{"mis-matching trigger", newTestConfig(t, "ws-123", DestinationGeneric, "", TriggerPlanning), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published := make(chan *run.Run, 100)
+			notifier := &Notifier{
+				logger:     slog.New(&xslog.NoopHandler{}),
+				workspaces: &fakeWorkspaceService{},
+				system:     &fakeHostnameService{},
+				runs:       &fakeRunService{run: commentedRun},
+				cache:      newTestCache(t, &fakeFactory{published}, tt.cfg),
+			}
+
+			err := notifier.handleComment(ctx, comment)
+			require.NoError(t, err)
+			if tt.wantPublished {
+				assert.Equal(t, commentedRun, <-published)
+			} else {
+				assert.Equal(t, 0, len(published))
+			}
+		})
+	}
+}
+
 func TestNotifier_handleConfig(t *testing.T) {
 	ctx := context.Background()
 	notifier := &Notifier{
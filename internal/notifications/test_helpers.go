@@ -18,6 +18,10 @@ type (
 	fakeWorkspaceService struct {
 		workspace.Service
 	}
+	fakeRunService struct {
+		notifierRunClient
+		run *run.Run
+	}
 	fakeHostnameService struct {
 		*internal.HostnameService
 	}
@@ -62,6 +66,10 @@ func (db *fakeWorkspaceService) Get(context.Context, string) (*workspace.Workspa
 	return nil, nil
 }
 
+func (f *fakeRunService) Get(context.Context, string) (*run.Run, error) {
+	return f.run, nil
+}
+
 func (db *fakeHostnameService) Hostname() string { return "" }
 
 func (f *fakeFactory) newClient(cfg *Config) (client, error) {
@@ -14,7 +14,9 @@ type (
 	// dbListOptions represents the options for listing organizations via the
 	// database.
 	dbListOptions struct {
-		names []string // filter organizations by name if non-nil
+		names  []string // filter organizations by name if non-nil
+		search string   // filter organizations by partial name if non-empty
+		sort   string   // order organizations by name or creation time
 		resource.PageOptions
 	}
 )
@@ -31,6 +33,12 @@ type row struct {
 	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
 	AllowForceDeleteWorkspaces pgtype.Bool        `json:"allow_force_delete_workspaces"`
 	CostEstimationEnabled      pgtype.Bool        `json:"cost_estimation_enabled"`
+	DefaultDeletionProtection  pgtype.Bool        `json:"default_deletion_protection"`
+	LogRedactionPatterns       []string           `json:"log_redaction_patterns"`
+	QueueTimeSla               pgtype.Int4        `json:"queue_time_sla"`
+	WorkspaceNameCase          pgtype.Text        `json:"workspace_name_case"`
+	MaxAgentPools              pgtype.Int4        `json:"max_agent_pools"`
+	MaxHighPriorityRuns        pgtype.Int4        `json:"max_high_priority_runs"`
 }
 
 // row converts an organization database row into an
@@ -43,6 +51,11 @@ func (r row) toOrganization() *Organization {
 		Name:                       r.Name.String,
 		AllowForceDeleteWorkspaces: r.AllowForceDeleteWorkspaces.Bool,
 		CostEstimationEnabled:      r.CostEstimationEnabled.Bool,
+		DefaultDeletionProtection:  r.DefaultDeletionProtection.Bool,
+		LogRedactionPatterns:       r.LogRedactionPatterns,
+		QueueTimeSLA:               int(r.QueueTimeSla.Int32),
+		WorkspaceNameCase:          WorkspaceNameCase(r.WorkspaceNameCase.String),
+		MaxHighPriorityRuns:        int(r.MaxHighPriorityRuns.Int32),
 	}
 	if r.SessionRemember.Valid {
 		sessionRememberInt := int(r.SessionRemember.Int32)
@@ -55,6 +68,10 @@ func (r row) toOrganization() *Organization {
 	if r.Email.Valid {
 		org.Email = &r.Email.String
 	}
+	if r.MaxAgentPools.Valid {
+		maxAgentPools := int(r.MaxAgentPools.Int32)
+		org.MaxAgentPools = &maxAgentPools
+	}
 	if r.CollaboratorAuthPolicy.Valid {
 		org.CollaboratorAuthPolicy = &r.CollaboratorAuthPolicy.String
 	}
@@ -79,6 +96,12 @@ func (db *pgdb) create(ctx context.Context, org *Organization) error {
 			CollaboratorAuthPolicy:     sql.StringPtr(org.CollaboratorAuthPolicy),
 			CostEstimationEnabled:      sql.Bool(org.CostEstimationEnabled),
 			AllowForceDeleteWorkspaces: sql.Bool(org.AllowForceDeleteWorkspaces),
+			DefaultDeletionProtection:  sql.Bool(org.DefaultDeletionProtection),
+			LogRedactionPatterns:       org.LogRedactionPatterns,
+			QueueTimeSla:               sql.Int4(org.QueueTimeSLA),
+			WorkspaceNameCase:          sql.String(string(org.WorkspaceNameCase)),
+			MaxAgentPools:              sql.Int4Ptr(org.MaxAgentPools),
+			MaxHighPriorityRuns:        sql.Int4(org.MaxHighPriorityRuns),
 		})
 		if err != nil {
 			return sql.Error(err)
@@ -109,6 +132,12 @@ func (db *pgdb) update(ctx context.Context, name string, fn func(*Organization)
 			SessionTimeout:             sql.Int4Ptr(org.SessionTimeout),
 			UpdatedAt:                  sql.Timestamptz(org.UpdatedAt),
 			AllowForceDeleteWorkspaces: sql.Bool(org.AllowForceDeleteWorkspaces),
+			DefaultDeletionProtection:  sql.Bool(org.DefaultDeletionProtection),
+			LogRedactionPatterns:       org.LogRedactionPatterns,
+			QueueTimeSla:               sql.Int4(org.QueueTimeSLA),
+			WorkspaceNameCase:          sql.String(string(org.WorkspaceNameCase)),
+			MaxAgentPools:              sql.Int4Ptr(org.MaxAgentPools),
+			MaxHighPriorityRuns:        sql.Int4(org.MaxHighPriorityRuns),
 		})
 		if err != nil {
 			return err
@@ -126,6 +155,8 @@ func (db *pgdb) list(ctx context.Context, opts dbListOptions) (*resource.Page[*O
 
 		rows, err := q.FindOrganizations(ctx, pggen.FindOrganizationsParams{
 			Names:  opts.names,
+			Search: sql.String(opts.search),
+			Sort:   sql.String(opts.sort),
 			Limit:  opts.GetLimit(),
 			Offset: opts.GetOffset(),
 		})
@@ -133,7 +164,7 @@ func (db *pgdb) list(ctx context.Context, opts dbListOptions) (*resource.Page[*O
 			return nil, err
 		}
 
-		count, err := q.CountOrganizations(ctx, opts.names)
+		count, err := q.CountOrganizations(ctx, opts.names, sql.String(opts.search))
 		if err != nil {
 			return nil, err
 		}
@@ -147,6 +178,25 @@ func (db *pgdb) list(ctx context.Context, opts dbListOptions) (*resource.Page[*O
 	})
 }
 
+func (db *pgdb) listForUser(ctx context.Context, username string, opts ListForUserOptions) ([]*Organization, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Organization, error) {
+		rows, err := q.FindOrganizationsByUsername(ctx, pggen.FindOrganizationsByUsernameParams{
+			Search:   sql.String(opts.Search),
+			Username: sql.String(username),
+			Limit:    sql.Int8(opts.Limit),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]*Organization, len(rows))
+		for i, r := range rows {
+			items[i] = row(r).toOrganization()
+		}
+		return items, nil
+	})
+}
+
 func (db *pgdb) get(ctx context.Context, name string) (*Organization, error) {
 	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Organization, error) {
 		r, err := q.FindOrganizationByName(ctx, sql.String(name))
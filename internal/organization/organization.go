@@ -2,6 +2,10 @@
 package organization
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
 	"time"
 
 	"github.com/tofutf/tofutf/internal"
@@ -11,6 +15,34 @@ import (
 const (
 	DefaultSessionTimeout    = 20160
 	DefaultSessionExpiration = 20160
+
+	// DefaultMaxHighPriorityRuns is the default maximum number of incomplete
+	// high priority runs permitted in an organization.
+	DefaultMaxHighPriorityRuns = 1
+)
+
+var (
+	ErrInvalidLogRedactionPattern = errors.New("invalid log redaction pattern")
+	ErrInvalidQueueTimeSLA        = errors.New("queue time SLA cannot be negative")
+	ErrInvalidWorkspaceNameCase   = errors.New("invalid workspace name case")
+	ErrInvalidMaxAgentPools       = errors.New("maximum number of agent pools cannot be negative")
+	ErrInvalidMaxHighPriorityRuns = errors.New("maximum number of high priority runs cannot be negative")
+)
+
+// WorkspaceNameCase determines how the case of workspace names in an
+// organization is treated.
+type WorkspaceNameCase string
+
+const (
+	// CaseSensitiveWorkspaceNames treats names that differ only in case, e.g.
+	// Prod and prod, as belonging to different workspaces. This is the
+	// default.
+	CaseSensitiveWorkspaceNames WorkspaceNameCase = "sensitive"
+	// LowercaseWorkspaceNames requires workspace names to be lowercase.
+	LowercaseWorkspaceNames WorkspaceNameCase = "lowercase"
+	// CaseInsensitiveWorkspaceNames rejects a workspace name that differs only
+	// in case from the name of an existing workspace.
+	CaseInsensitiveWorkspaceNames WorkspaceNameCase = "insensitive"
 )
 
 type (
@@ -29,13 +61,58 @@ type (
 		SessionTimeout             *int
 		AllowForceDeleteWorkspaces bool
 		CostEstimationEnabled      bool
+
+		// DefaultDeletionProtection is the deletion protection setting applied
+		// to new workspaces that don't specify one.
+		DefaultDeletionProtection bool `jsonapi:"attribute" json:"default-deletion-protection"`
+
+		// LogRedactionPatterns are regular expressions matching values to be
+		// masked in the logs of runs in the organization.
+		LogRedactionPatterns []string `jsonapi:"attribute" json:"log-redaction-patterns"`
+
+		// QueueTimeSLA is the maximum number of minutes a job in the
+		// organization should wait to be allocated to an agent before it is
+		// deemed to have breached the SLA. Zero disables the SLA.
+		QueueTimeSLA int `jsonapi:"attribute" json:"queue-time-sla"`
+
+		// WorkspaceNameCase determines how the case of workspace names is
+		// treated. It only applies to workspaces created or renamed after it
+		// is set.
+		WorkspaceNameCase WorkspaceNameCase `jsonapi:"attribute" json:"workspace-name-case"`
+
+		// MaxAgentPools is the maximum number of agent pools permitted in the
+		// organization. Zero means no limit. If nil then the site-wide
+		// default applies.
+		MaxAgentPools *int `jsonapi:"attribute" json:"max-agent-pools"`
+
+		// MaxHighPriorityRuns is the maximum number of incomplete high
+		// priority runs permitted in the organization at any one time. Zero
+		// means no limit.
+		MaxHighPriorityRuns int `jsonapi:"attribute" json:"max-high-priority-runs"`
 	}
 
 	// UpdateOptions represents the options for updating an organization.
 	UpdateOptions struct {
-		Name            *string
-		SessionRemember *int
-		SessionTimeout  *int
+		Name                      *string
+		SessionRemember           *int
+		SessionTimeout            *int
+		DefaultDeletionProtection *bool
+		// LogRedactionPatterns replaces the organization's log redaction
+		// patterns if non-nil; an empty slice removes them.
+		LogRedactionPatterns []string
+		// QueueTimeSLA sets the queue time SLA in minutes; zero disables it.
+		QueueTimeSLA *int
+		// WorkspaceNameCase sets how the case of workspace names is treated.
+		WorkspaceNameCase *WorkspaceNameCase
+		// MaxAgentPools sets the maximum number of agent pools, overriding
+		// the site-wide default; zero means no limit.
+		MaxAgentPools *int
+		// ResetMaxAgentPools, if true, removes the override, reverting to the
+		// site-wide default maximum number of agent pools.
+		ResetMaxAgentPools bool
+		// MaxHighPriorityRuns sets the maximum number of incomplete high
+		// priority runs; zero means no limit.
+		MaxHighPriorityRuns *int
 
 		// TFE fields that OTF does not support but persists merely to pass the
 		// go-tfe integration tests
@@ -48,7 +125,13 @@ type (
 	// CreateOptions represents the options for creating an organization. See
 	// types.CreateOptions for more details.
 	CreateOptions struct {
-		Name *string
+		Name                      *string
+		DefaultDeletionProtection *bool
+		LogRedactionPatterns      []string
+		QueueTimeSLA              *int
+		WorkspaceNameCase         *WorkspaceNameCase
+		MaxAgentPools             *int
+		MaxHighPriorityRuns       *int
 
 		// TFE fields that OTF does not support but persists merely to pass the
 		// go-tfe integration tests
@@ -72,6 +155,8 @@ func NewOrganization(opts CreateOptions) (*Organization, error) {
 		ID:                     internal.NewID("org"),
 		Email:                  opts.Email,
 		CollaboratorAuthPolicy: opts.CollaboratorAuthPolicy,
+		WorkspaceNameCase:      CaseSensitiveWorkspaceNames,
+		MaxHighPriorityRuns:    DefaultMaxHighPriorityRuns,
 	}
 	if opts.SessionTimeout != nil {
 		org.SessionTimeout = opts.SessionTimeout
@@ -85,11 +170,54 @@ func NewOrganization(opts CreateOptions) (*Organization, error) {
 	if opts.CostEstimationEnabled != nil {
 		org.CostEstimationEnabled = *opts.CostEstimationEnabled
 	}
+	if opts.DefaultDeletionProtection != nil {
+		org.DefaultDeletionProtection = *opts.DefaultDeletionProtection
+	}
+	if err := validateLogRedactionPatterns(opts.LogRedactionPatterns); err != nil {
+		return nil, err
+	}
+	if len(opts.LogRedactionPatterns) > 0 {
+		org.LogRedactionPatterns = opts.LogRedactionPatterns
+	}
+	if opts.QueueTimeSLA != nil {
+		if *opts.QueueTimeSLA < 0 {
+			return nil, ErrInvalidQueueTimeSLA
+		}
+		org.QueueTimeSLA = *opts.QueueTimeSLA
+	}
+	if opts.WorkspaceNameCase != nil {
+		if err := opts.WorkspaceNameCase.Validate(); err != nil {
+			return nil, err
+		}
+		org.WorkspaceNameCase = *opts.WorkspaceNameCase
+	}
+	if opts.MaxAgentPools != nil {
+		if *opts.MaxAgentPools < 0 {
+			return nil, ErrInvalidMaxAgentPools
+		}
+		org.MaxAgentPools = opts.MaxAgentPools
+	}
+	if opts.MaxHighPriorityRuns != nil {
+		if *opts.MaxHighPriorityRuns < 0 {
+			return nil, ErrInvalidMaxHighPriorityRuns
+		}
+		org.MaxHighPriorityRuns = *opts.MaxHighPriorityRuns
+	}
 	return &org, nil
 }
 
 func (org *Organization) String() string { return org.ID }
 
+// Validate checks the workspace name case is one of the supported values.
+func (c WorkspaceNameCase) Validate() error {
+	switch c {
+	case CaseSensitiveWorkspaceNames, LowercaseWorkspaceNames, CaseInsensitiveWorkspaceNames:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidWorkspaceNameCase, c)
+	}
+}
+
 func (org *Organization) Update(opts UpdateOptions) error {
 	if opts.Name != nil {
 		org.Name = *opts.Name
@@ -112,6 +240,76 @@ func (org *Organization) Update(opts UpdateOptions) error {
 	if opts.AllowForceDeleteWorkspaces != nil {
 		org.AllowForceDeleteWorkspaces = *opts.AllowForceDeleteWorkspaces
 	}
+	if opts.DefaultDeletionProtection != nil {
+		org.DefaultDeletionProtection = *opts.DefaultDeletionProtection
+	}
+	if opts.LogRedactionPatterns != nil {
+		if err := validateLogRedactionPatterns(opts.LogRedactionPatterns); err != nil {
+			return err
+		}
+		if len(opts.LogRedactionPatterns) == 0 {
+			org.LogRedactionPatterns = nil
+		} else {
+			org.LogRedactionPatterns = opts.LogRedactionPatterns
+		}
+	}
+	if opts.QueueTimeSLA != nil {
+		if *opts.QueueTimeSLA < 0 {
+			return ErrInvalidQueueTimeSLA
+		}
+		org.QueueTimeSLA = *opts.QueueTimeSLA
+	}
+	if opts.WorkspaceNameCase != nil {
+		if err := opts.WorkspaceNameCase.Validate(); err != nil {
+			return err
+		}
+		org.WorkspaceNameCase = *opts.WorkspaceNameCase
+	}
+	if opts.MaxAgentPools != nil {
+		if *opts.MaxAgentPools < 0 {
+			return ErrInvalidMaxAgentPools
+		}
+		org.MaxAgentPools = opts.MaxAgentPools
+	}
+	if opts.ResetMaxAgentPools {
+		org.MaxAgentPools = nil
+	}
+	if opts.MaxHighPriorityRuns != nil {
+		if *opts.MaxHighPriorityRuns < 0 {
+			return ErrInvalidMaxHighPriorityRuns
+		}
+		org.MaxHighPriorityRuns = *opts.MaxHighPriorityRuns
+	}
 	org.UpdatedAt = internal.CurrentTimestamp(nil)
 	return nil
 }
+
+// validateLogRedactionPatterns checks each pattern is a valid regular
+// expression that cannot match an empty string, which would otherwise insert
+// a mask between every character of the logs.
+func validateLogRedactionPatterns(patterns []string) error {
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidLogRedactionPattern, err.Error())
+		}
+		if re.MatchString("") {
+			return fmt.Errorf("%w: %q matches an empty string", ErrInvalidLogRedactionPattern, p)
+		}
+	}
+	return nil
+}
+
+// validateSort checks the order in which organizations are to be listed is
+// one of the supported values. An empty value selects the default order.
+func validateSort(sort string) error {
+	switch sort {
+	case "", "name", "-name", "created-at", "-created-at":
+		return nil
+	default:
+		return &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf("invalid sort: %s: must be one of name, -name, created-at or -created-at", sort),
+		}
+	}
+}
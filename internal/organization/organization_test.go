@@ -0,0 +1,176 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestOrganization_LogRedactionPatterns(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		org, err := NewOrganization(CreateOptions{
+			Name:                 internal.String("acme"),
+			LogRedactionPatterns: []string{`ghp_[A-Za-z0-9]{36}`},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{`ghp_[A-Za-z0-9]{36}`}, org.LogRedactionPatterns)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := NewOrganization(CreateOptions{
+			Name:                 internal.String("acme"),
+			LogRedactionPatterns: []string{`(`},
+		})
+		assert.ErrorIs(t, err, ErrInvalidLogRedactionPattern)
+	})
+
+	t.Run("pattern matching empty string", func(t *testing.T) {
+		_, err := NewOrganization(CreateOptions{
+			Name:                 internal.String("acme"),
+			LogRedactionPatterns: []string{`x*`},
+		})
+		assert.ErrorIs(t, err, ErrInvalidLogRedactionPattern)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		org := &Organization{LogRedactionPatterns: []string{`secret-\d+`}}
+
+		// nil leaves patterns untouched
+		require.NoError(t, org.Update(UpdateOptions{}))
+		assert.Equal(t, []string{`secret-\d+`}, org.LogRedactionPatterns)
+
+		// empty slice removes patterns
+		require.NoError(t, org.Update(UpdateOptions{LogRedactionPatterns: []string{}}))
+		assert.Nil(t, org.LogRedactionPatterns)
+	})
+}
+
+func TestOrganization_QueueTimeSLA(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		org, err := NewOrganization(CreateOptions{
+			Name:         internal.String("acme"),
+			QueueTimeSLA: internal.Int(15),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 15, org.QueueTimeSLA)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := NewOrganization(CreateOptions{
+			Name:         internal.String("acme"),
+			QueueTimeSLA: internal.Int(-1),
+		})
+		assert.ErrorIs(t, err, ErrInvalidQueueTimeSLA)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		org := &Organization{QueueTimeSLA: 15}
+
+		// nil leaves SLA untouched
+		require.NoError(t, org.Update(UpdateOptions{}))
+		assert.Equal(t, 15, org.QueueTimeSLA)
+
+		// zero disables SLA
+		require.NoError(t, org.Update(UpdateOptions{QueueTimeSLA: internal.Int(0)}))
+		assert.Equal(t, 0, org.QueueTimeSLA)
+
+		assert.ErrorIs(t, org.Update(UpdateOptions{QueueTimeSLA: internal.Int(-5)}), ErrInvalidQueueTimeSLA)
+	})
+}
+
+func TestOrganization_WorkspaceNameCase(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		org, err := NewOrganization(CreateOptions{Name: internal.String("acme")})
+		require.NoError(t, err)
+		assert.Equal(t, CaseSensitiveWorkspaceNames, org.WorkspaceNameCase)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		nameCase := CaseInsensitiveWorkspaceNames
+		org, err := NewOrganization(CreateOptions{
+			Name:              internal.String("acme"),
+			WorkspaceNameCase: &nameCase,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, CaseInsensitiveWorkspaceNames, org.WorkspaceNameCase)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		nameCase := WorkspaceNameCase("upper")
+		_, err := NewOrganization(CreateOptions{
+			Name:              internal.String("acme"),
+			WorkspaceNameCase: &nameCase,
+		})
+		assert.ErrorIs(t, err, ErrInvalidWorkspaceNameCase)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		org := &Organization{WorkspaceNameCase: CaseSensitiveWorkspaceNames}
+
+		lowercase := LowercaseWorkspaceNames
+		require.NoError(t, org.Update(UpdateOptions{WorkspaceNameCase: &lowercase}))
+		assert.Equal(t, LowercaseWorkspaceNames, org.WorkspaceNameCase)
+
+		invalid := WorkspaceNameCase("")
+		assert.ErrorIs(t, org.Update(UpdateOptions{WorkspaceNameCase: &invalid}), ErrInvalidWorkspaceNameCase)
+	})
+}
+
+func TestOrganization_MaxAgentPools(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		org, err := NewOrganization(CreateOptions{Name: internal.String("acme")})
+		require.NoError(t, err)
+		assert.Nil(t, org.MaxAgentPools)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewOrganization(CreateOptions{
+			Name:          internal.String("acme"),
+			MaxAgentPools: internal.Int(-1),
+		})
+		assert.ErrorIs(t, err, ErrInvalidMaxAgentPools)
+	})
+
+	t.Run("update and reset", func(t *testing.T) {
+		org := &Organization{}
+
+		require.NoError(t, org.Update(UpdateOptions{MaxAgentPools: internal.Int(3)}))
+		assert.Equal(t, internal.Int(3), org.MaxAgentPools)
+
+		assert.ErrorIs(t, org.Update(UpdateOptions{MaxAgentPools: internal.Int(-1)}), ErrInvalidMaxAgentPools)
+
+		require.NoError(t, org.Update(UpdateOptions{ResetMaxAgentPools: true}))
+		assert.Nil(t, org.MaxAgentPools)
+	})
+}
+
+func TestOrganization_MaxHighPriorityRuns(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		org, err := NewOrganization(CreateOptions{Name: internal.String("acme")})
+		require.NoError(t, err)
+		assert.Equal(t, DefaultMaxHighPriorityRuns, org.MaxHighPriorityRuns)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewOrganization(CreateOptions{
+			Name:                internal.String("acme"),
+			MaxHighPriorityRuns: internal.Int(-1),
+		})
+		assert.ErrorIs(t, err, ErrInvalidMaxHighPriorityRuns)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		org := &Organization{MaxHighPriorityRuns: 1}
+
+		require.NoError(t, org.Update(UpdateOptions{MaxHighPriorityRuns: internal.Int(3)}))
+		assert.Equal(t, 3, org.MaxHighPriorityRuns)
+
+		// zero means no limit
+		require.NoError(t, org.Update(UpdateOptions{MaxHighPriorityRuns: internal.Int(0)}))
+		assert.Equal(t, 0, org.MaxHighPriorityRuns)
+
+		assert.ErrorIs(t, org.Update(UpdateOptions{MaxHighPriorityRuns: internal.Int(-1)}), ErrInvalidMaxHighPriorityRuns)
+	})
+}
@@ -49,8 +49,26 @@ type (
 
 	// ListOptions represents the options for listing organizations.
 	ListOptions struct {
+		// Search filters organizations by those whose name contains the
+		// search string.
+		Search string `schema:"q,omitempty"`
+		// Sort determines the order in which organizations are listed: one of
+		// name, -name, created-at or -created-at. Defaults to most recently
+		// updated first.
+		Sort string `schema:"sort,omitempty"`
+
 		resource.PageOptions
 	}
+
+	// ListForUserOptions represents the options for listing the organizations
+	// of which a user is a member.
+	ListForUserOptions struct {
+		// Search filters organizations by those whose name contains the
+		// search string.
+		Search string
+		// Limit is the maximum number of organizations to list.
+		Limit int
+	}
 )
 
 func NewService(opts Options) *Service {
@@ -111,12 +129,17 @@ func (s *Service) WatchOrganizations(ctx context.Context) (<-chan pubsub.Event[*
 // Create creates an organization. Only users can create
 // organizations, or, if RestrictOrganizationCreation is true, then only the
 // site admin can create organizations. Creating an organization automatically
-// creates an owners team and adds creator as an owner.
+// creates an owners team and adds creator as an owner. Only the site admin
+// can override the maximum number of agent pools.
 func (s *Service) Create(ctx context.Context, opts CreateOptions) (*Organization, error) {
 	creator, err := s.restrictOrganizationCreation(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if opts.MaxAgentPools != nil && !creator.IsSiteAdmin() {
+		s.logger.Error("unauthorized action", "action", rbac.CreateOrganizationAction, "max_agent_pools", *opts.MaxAgentPools, "subject", creator)
+		return nil, internal.ErrAccessNotPermitted
+	}
 
 	org, err := NewOrganization(opts)
 	if err != nil {
@@ -152,6 +175,12 @@ func (s *Service) Update(ctx context.Context, name string, opts UpdateOptions) (
 	if err != nil {
 		return nil, err
 	}
+	// only site admins may override the maximum number of agent pools, lest
+	// owners lift the limit on their own organization.
+	if (opts.MaxAgentPools != nil || opts.ResetMaxAgentPools) && !subject.IsSiteAdmin() {
+		s.logger.Error("unauthorized action", "action", rbac.UpdateOrganizationAction, "organization", name, "max_agent_pools", opts.MaxAgentPools, "subject", subject)
+		return nil, internal.ErrAccessNotPermitted
+	}
 
 	org, err := s.db.update(ctx, name, func(org *Organization) error {
 		return org.Update(opts)
@@ -178,10 +207,35 @@ func (s *Service) List(ctx context.Context, opts ListOptions) (*resource.Page[*O
 	if err != nil {
 		return nil, err
 	}
-	if subject.CanAccessSite(rbac.ListOrganizationsAction) {
-		return s.db.list(ctx, dbListOptions{PageOptions: opts.PageOptions})
+	if err := validateSort(opts.Sort); err != nil {
+		return nil, err
+	}
+	dbopts := dbListOptions{
+		search:      opts.Search,
+		sort:        opts.Sort,
+		PageOptions: opts.PageOptions,
+	}
+	if !subject.CanAccessSite(rbac.ListOrganizationsAction) {
+		dbopts.names = subject.Organizations()
+	}
+	return s.db.list(ctx, dbopts)
+}
+
+// ListForUser lists the organizations of which a user is a member, ordered by
+// name. Unlike List, it retrieves only as many organizations as the limit
+// permits, rather than relying upon all of the user's memberships, making it
+// suitable for a typeahead. The subject must either be the user or have
+// site-wide permission to list organizations.
+func (s *Service) ListForUser(ctx context.Context, username string, opts ListForUserOptions) ([]*Organization, error) {
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if subject.String() != username && !subject.CanAccessSite(rbac.ListOrganizationsAction) {
+		s.logger.Error("unauthorized action", "action", rbac.ListOrganizationsAction.String(), "username", username, "subject", subject)
+		return nil, internal.ErrAccessNotPermitted
 	}
-	return s.db.list(ctx, dbListOptions{PageOptions: opts.PageOptions, names: subject.Organizations()})
+	return s.db.listForUser(ctx, username, opts)
 }
 
 func (s *Service) Get(ctx context.Context, name string) (*Organization, error) {
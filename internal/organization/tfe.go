@@ -2,6 +2,7 @@ package organization
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"reflect"
 
@@ -51,8 +52,17 @@ func (a *tfe) createOrganization(w http.ResponseWriter, r *http.Request) {
 		SessionRemember:            opts.SessionRemember,
 		SessionTimeout:             opts.SessionTimeout,
 		AllowForceDeleteWorkspaces: opts.AllowForceDeleteWorkspaces,
+		DefaultDeletionProtection:  opts.DefaultDeletionProtection,
+		LogRedactionPatterns:       opts.LogRedactionPatterns,
+		QueueTimeSLA:               opts.QueueTimeSLA,
+		WorkspaceNameCase:          (*WorkspaceNameCase)(opts.WorkspaceNameCase),
+		MaxAgentPools:              opts.MaxAgentPools,
+		MaxHighPriorityRuns:        opts.MaxHighPriorityRuns,
 	})
-	if err != nil {
+	if errors.Is(err, ErrInvalidLogRedactionPattern) || errors.Is(err, ErrInvalidQueueTimeSLA) || errors.Is(err, ErrInvalidWorkspaceNameCase) || errors.Is(err, ErrInvalidMaxAgentPools) || errors.Is(err, ErrInvalidMaxHighPriorityRuns) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
@@ -117,8 +127,17 @@ func (a *tfe) updateOrganization(w http.ResponseWriter, r *http.Request) {
 		SessionRemember:            opts.SessionRemember,
 		SessionTimeout:             opts.SessionTimeout,
 		AllowForceDeleteWorkspaces: opts.AllowForceDeleteWorkspaces,
+		DefaultDeletionProtection:  opts.DefaultDeletionProtection,
+		LogRedactionPatterns:       opts.LogRedactionPatterns,
+		QueueTimeSLA:               opts.QueueTimeSLA,
+		WorkspaceNameCase:          (*WorkspaceNameCase)(opts.WorkspaceNameCase),
+		MaxAgentPools:              opts.MaxAgentPools,
+		MaxHighPriorityRuns:        opts.MaxHighPriorityRuns,
 	})
-	if err != nil {
+	if errors.Is(err, ErrInvalidLogRedactionPattern) || errors.Is(err, ErrInvalidQueueTimeSLA) || errors.Is(err, ErrInvalidWorkspaceNameCase) || errors.Is(err, ErrInvalidMaxAgentPools) || errors.Is(err, ErrInvalidMaxHighPriorityRuns) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
@@ -261,6 +280,12 @@ func (a *tfe) toOrganization(from *Organization) *types.Organization {
 		SessionTimeout:             from.SessionTimeout,
 		AllowForceDeleteWorkspaces: from.AllowForceDeleteWorkspaces,
 		CostEstimationEnabled:      from.CostEstimationEnabled,
+		DefaultDeletionProtection:  from.DefaultDeletionProtection,
+		LogRedactionPatterns:       from.LogRedactionPatterns,
+		QueueTimeSLA:               from.QueueTimeSLA,
+		WorkspaceNameCase:          string(from.WorkspaceNameCase),
+		MaxAgentPools:              from.MaxAgentPools,
+		MaxHighPriorityRuns:        from.MaxHighPriorityRuns,
 		// go-tfe tests expect this attribute to be equal to 5
 		RemainingTestableCount: 5,
 	}
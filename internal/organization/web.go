@@ -2,7 +2,10 @@ package organization
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
@@ -14,6 +17,10 @@ import (
 	"github.com/tofutf/tofutf/internal/tokens"
 )
 
+// switcherLimit is the maximum number of organizations offered by the
+// organization switcher.
+const switcherLimit = 10
+
 type (
 	// web is the web application for organizations
 	web struct {
@@ -29,6 +36,7 @@ type (
 		Update(ctx context.Context, name string, opts UpdateOptions) (*Organization, error)
 		Get(ctx context.Context, name string) (*Organization, error)
 		List(ctx context.Context, opts ListOptions) (*resource.Page[*Organization], error)
+		ListForUser(ctx context.Context, username string, opts ListForUserOptions) ([]*Organization, error)
 		Delete(ctx context.Context, name string) error
 
 		CreateToken(ctx context.Context, opts CreateOrganizationTokenOptions) (*OrganizationToken, []byte, error)
@@ -58,6 +66,7 @@ func (a *web) addHandlers(r *mux.Router) {
 
 	r.HandleFunc("/organizations", a.list).Methods("GET")
 	r.HandleFunc("/organizations/new", a.new).Methods("GET")
+	r.HandleFunc("/organizations/switcher", a.switcher).Methods("GET")
 	r.HandleFunc("/organizations/create", a.create).Methods("POST")
 	r.HandleFunc("/organizations/{name}", a.get).Methods("GET")
 	r.HandleFunc("/organizations/{name}/edit", a.edit).Methods("GET")
@@ -99,14 +108,22 @@ func (a *web) create(w http.ResponseWriter, r *http.Request) {
 
 func (a *web) list(w http.ResponseWriter, r *http.Request) {
 	var params struct {
-		PageNumber int `schema:"page[number]"`
+		Search     string `schema:"search[name],omitempty"`
+		Sort       string `schema:"sort,omitempty"`
+		PageNumber int    `schema:"page[number]"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		a.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
+	if err := validateSort(params.Sort); err != nil {
+		a.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
 
 	organizations, err := a.svc.List(r.Context(), ListOptions{
+		Search: params.Search,
+		Sort:   params.Sort,
 		PageOptions: resource.PageOptions{
 			PageNumber: params.PageNumber,
 			PageSize:   html.PageSize,
@@ -130,15 +147,75 @@ func (a *web) list(w http.ResponseWriter, r *http.Request) {
 		canCreate = true
 	}
 
-	a.Render("organization_list.tmpl", w, struct {
+	response := struct {
 		html.SitePage
 		*resource.Page[*Organization]
 		CanCreate bool
+		Search    string
+		Sort      string
 	}{
 		SitePage:  html.NewSitePage(r, "organizations"),
 		Page:      organizations,
 		CanCreate: canCreate,
-	})
+		Search:    params.Search,
+		Sort:      params.Sort,
+	}
+
+	if isHTMX := r.Header.Get("HX-Request"); isHTMX == "true" {
+		if err := a.RenderTemplate("organization_listing.tmpl", w, response); err != nil {
+			a.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		a.Render("organization_list.tmpl", w, response)
+	}
+}
+
+// switcher renders the organizations matching a partial name for the
+// organization switcher's typeahead. Users are offered the organizations of
+// which they are a member; site admins are offered all organizations.
+func (a *web) switcher(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Search string `schema:"search[name],omitempty"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		a.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		a.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var organizations []*Organization
+	if subject.CanAccessSite(rbac.ListOrganizationsAction) {
+		page, err := a.svc.List(r.Context(), ListOptions{
+			Search:      params.Search,
+			Sort:        "name",
+			PageOptions: resource.PageOptions{PageSize: switcherLimit},
+		})
+		if err != nil {
+			a.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		organizations = page.Items
+	} else {
+		organizations, err = a.svc.ListForUser(r.Context(), subject.String(), ListForUserOptions{
+			Search: params.Search,
+			Limit:  switcherLimit,
+		})
+		if err != nil {
+			a.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := a.RenderTemplate("organization_switcher.tmpl", w, organizations); err != nil {
+		a.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 }
 
 func (a *web) get(w http.ResponseWriter, r *http.Request) {
@@ -187,18 +264,55 @@ func (a *web) edit(w http.ResponseWriter, r *http.Request) {
 
 func (a *web) update(w http.ResponseWriter, r *http.Request) {
 	var params struct {
-		Name        string `schema:"name,required"`
-		UpdatedName string `schema:"new_name,required"`
+		Name                      string `schema:"name,required"`
+		UpdatedName               string `schema:"new_name,required"`
+		DefaultDeletionProtection bool   `schema:"default_deletion_protection"`
+		LogRedactionPatterns      string `schema:"log_redaction_patterns"`
+		QueueTimeSLA              *int   `schema:"queue_time_sla"`
+		WorkspaceNameCase         string `schema:"workspace_name_case"`
+		MaxHighPriorityRuns       *int   `schema:"max_high_priority_runs"`
+		// MaxAgentPools is only submitted by site admins; an empty value
+		// reverts to the site-wide default.
+		MaxAgentPools *string `schema:"max_agent_pools"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		a.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
 
+	var maxAgentPools *int
+	if params.MaxAgentPools != nil && *params.MaxAgentPools != "" {
+		n, err := strconv.Atoi(*params.MaxAgentPools)
+		if err != nil {
+			a.Error(w, "invalid maximum number of agent pools: "+err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		maxAgentPools = &n
+	}
+
+	// one pattern per line
+	patterns := []string{}
+	for _, line := range strings.Split(params.LogRedactionPatterns, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			patterns = append(patterns, line)
+		}
+	}
+
 	org, err := a.svc.Update(r.Context(), params.Name, UpdateOptions{
-		Name: &params.UpdatedName,
+		Name:                      &params.UpdatedName,
+		DefaultDeletionProtection: &params.DefaultDeletionProtection,
+		LogRedactionPatterns:      patterns,
+		QueueTimeSLA:              params.QueueTimeSLA,
+		WorkspaceNameCase:         (*WorkspaceNameCase)(&params.WorkspaceNameCase),
+		MaxAgentPools:             maxAgentPools,
+		ResetMaxAgentPools:        params.MaxAgentPools != nil && *params.MaxAgentPools == "",
+		MaxHighPriorityRuns:       params.MaxHighPriorityRuns,
 	})
-	if err != nil {
+	if errors.Is(err, ErrInvalidLogRedactionPattern) || errors.Is(err, ErrInvalidQueueTimeSLA) || errors.Is(err, ErrInvalidWorkspaceNameCase) || errors.Is(err, ErrInvalidMaxAgentPools) || errors.Is(err, ErrInvalidMaxHighPriorityRuns) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.EditOrganization(params.Name), http.StatusFound)
+		return
+	} else if err != nil {
 		a.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
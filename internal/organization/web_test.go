@@ -141,6 +141,65 @@ func TestWeb_ListHandler(t *testing.T) {
 	})
 }
 
+func TestWeb_ListHandler_Sort(t *testing.T) {
+	svc := &web{
+		svc:      &fakeWebService{},
+		Renderer: testutils.NewRenderer(t),
+	}
+
+	t.Run("valid sort", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?sort=-created-at&search[name]=acme", nil)
+		r = r.WithContext(internal.AddSubjectToContext(context.Background(), &internal.Superuser{}))
+		w := httptest.NewRecorder()
+		svc.list(w, r)
+		assert.Equal(t, 200, w.Code)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/?sort=email", nil)
+		r = r.WithContext(internal.AddSubjectToContext(context.Background(), &internal.Superuser{}))
+		w := httptest.NewRecorder()
+		svc.list(w, r)
+		assert.Equal(t, 422, w.Code)
+	})
+}
+
+func TestWeb_SwitcherHandler(t *testing.T) {
+	svc := &web{
+		svc: &fakeWebService{
+			orgs:     []*Organization{{Name: "acme"}, {Name: "acme-staging"}},
+			userOrgs: []*Organization{{Name: "acme"}},
+		},
+		Renderer: testutils.NewRenderer(t),
+	}
+
+	tests := []struct {
+		name    string
+		subject internal.Subject
+		want    []string
+	}{
+		{"site admin offered all organizations", &internal.Superuser{}, []string{"acme", "acme-staging"}},
+		{"user offered their organizations", &unprivilegedSubject{}, []string{"acme"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?search[name]=acme", nil)
+			r = r.WithContext(internal.AddSubjectToContext(context.Background(), tt.subject))
+			w := httptest.NewRecorder()
+			svc.switcher(w, r)
+			assert.Equal(t, 200, w.Code)
+
+			doc, err := htmlquery.Parse(w.Body)
+			require.NoError(t, err)
+			var got []string
+			for _, link := range htmlquery.Find(doc, `//a`) {
+				got = append(got, htmlquery.InnerText(link))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestWeb_DeleteHandler(t *testing.T) {
 	svc := &web{
 		svc: &fakeWebService{
@@ -157,7 +216,8 @@ func TestWeb_DeleteHandler(t *testing.T) {
 
 type (
 	fakeWebService struct {
-		orgs []*Organization
+		orgs     []*Organization
+		userOrgs []*Organization
 
 		webService
 	}
@@ -175,6 +235,10 @@ func (f *fakeWebService) List(ctx context.Context, opts ListOptions) (*resource.
 	return resource.NewPage(f.orgs, opts.PageOptions, nil), nil
 }
 
+func (f *fakeWebService) ListForUser(context.Context, string, ListForUserOptions) ([]*Organization, error) {
+	return f.userOrgs, nil
+}
+
 func (f *fakeWebService) Delete(context.Context, string) error {
 	return nil
 }
@@ -182,3 +246,7 @@ func (f *fakeWebService) Delete(context.Context, string) error {
 func (s *unprivilegedSubject) CanAccessSite(_ rbac.Action) bool {
 	return false
 }
+
+func (s *unprivilegedSubject) String() string {
+	return "bobby"
+}
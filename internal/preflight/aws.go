@@ -0,0 +1,163 @@
+package preflight
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const awsDefaultRegion = "us-east-1"
+
+// awsInvalidCredentialsCodes are the STS error codes indicating credentials are
+// invalid, as opposed to some other problem with the request.
+var awsInvalidCredentialsCodes = map[string]bool{
+	"InvalidClientTokenId":        true,
+	"SignatureDoesNotMatch":       true,
+	"ExpiredToken":                true,
+	"InvalidAccessKeyId":          true,
+	"UnrecognizedClientException": true,
+}
+
+// awsCheck checks AWS credentials by calling STS GetCallerIdentity, which
+// succeeds for any valid credentials regardless of their permissions.
+type awsCheck struct {
+	client *http.Client
+	// endpoint overrides the regional STS endpoint; for testing purposes.
+	endpoint string
+}
+
+// awsErrorResponse is the body of an STS error response.
+type awsErrorResponse struct {
+	Error struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Error"`
+}
+
+func (c *awsCheck) check(ctx context.Context, env map[string]string) error {
+	accessKeyID := env["AWS_ACCESS_KEY_ID"]
+	secretAccessKey := env["AWS_SECRET_ACCESS_KEY"]
+	if accessKeyID == "" || secretAccessKey == "" {
+		return errCredentialsNotSet
+	}
+	region := env["AWS_REGION"]
+	if region == "" {
+		region = env["AWS_DEFAULT_REGION"]
+	}
+	if region == "" {
+		region = awsDefaultRegion
+	}
+	endpoint := c.endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://sts.%s.amazonaws.com/", region)
+	}
+
+	body := url.Values{
+		"Action":  []string{"GetCallerIdentity"},
+		"Version": []string{"2011-06-15"},
+	}.Encode()
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if token := env["AWS_SESSION_TOKEN"]; token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+	signAWSRequest(req, []byte(body), accessKeyID, secretAccessKey, region, "sts", time.Now())
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling STS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	var errResp awsErrorResponse
+	if b, err := io.ReadAll(resp.Body); err == nil {
+		_ = xml.Unmarshal(b, &errResp)
+	}
+	if resp.StatusCode < 500 && awsInvalidCredentialsCodes[errResp.Error.Code] {
+		return &invalidCredentialsError{
+			provider: "AWS",
+			reason:   fmt.Sprintf("%s: %s", errResp.Error.Code, errResp.Error.Message),
+		}
+	}
+	return fmt.Errorf("unexpected response from STS: %s %s", resp.Status, errResp.Error.Code)
+}
+
+// signAWSRequest signs a request using AWS Signature Version 4.
+func signAWSRequest(req *http.Request, body []byte, accessKeyID, secretAccessKey, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("Host", req.URL.Host)
+
+	// canonical headers are lowercased and sorted
+	var names []string
+	headers := make(map[string]string)
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		names = append(names, lower)
+		headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,134 @@
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	azureDefaultLoginEndpoint      = "https://login.microsoftonline.com"
+	azureDefaultManagementEndpoint = "https://management.azure.com"
+	azureSubscriptionAPIVersion    = "2022-12-01"
+)
+
+// azureCheck checks the client secret of an Azure service principal by
+// exchanging it for an access token for Azure Resource Manager, and then, if a
+// subscription is specified, checking the service principal can read the
+// subscription.
+type azureCheck struct {
+	client *http.Client
+	// loginEndpoint and managementEndpoint override Azure's public cloud
+	// endpoints; for testing purposes.
+	loginEndpoint      string
+	managementEndpoint string
+}
+
+func (c *azureCheck) check(ctx context.Context, env map[string]string) error {
+	var (
+		clientID       = env["ARM_CLIENT_ID"]
+		clientSecret   = env["ARM_CLIENT_SECRET"]
+		tenantID       = env["ARM_TENANT_ID"]
+		subscriptionID = env["ARM_SUBSCRIPTION_ID"]
+	)
+	// only client secrets are checked; other means of authenticating, e.g.
+	// managed identities, are sourced from the agent's host.
+	if clientID == "" || clientSecret == "" || tenantID == "" {
+		return errCredentialsNotSet
+	}
+	loginEndpoint := c.loginEndpoint
+	if loginEndpoint == "" {
+		loginEndpoint = azureDefaultLoginEndpoint
+	}
+	managementEndpoint := c.managementEndpoint
+	if managementEndpoint == "" {
+		managementEndpoint = azureDefaultManagementEndpoint
+	}
+
+	token, err := c.accessToken(ctx, loginEndpoint, managementEndpoint, tenantID, clientID, clientSecret)
+	if err != nil {
+		return err
+	}
+	if subscriptionID == "" {
+		return nil
+	}
+
+	u := fmt.Sprintf("%s/subscriptions/%s?api-version=%s", managementEndpoint, url.PathEscape(subscriptionID), azureSubscriptionAPIVersion)
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("retrieving subscription: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case isRejection(resp) || resp.StatusCode == http.StatusNotFound:
+		var errResp struct {
+			Error struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return &invalidCredentialsError{
+			provider: "Azure",
+			reason:   fmt.Sprintf("service principal cannot access subscription %s: %s", subscriptionID, errResp.Error.Code),
+		}
+	default:
+		return fmt.Errorf("unexpected response retrieving subscription: %s", resp.Status)
+	}
+}
+
+// accessToken exchanges a client secret for an access token using the client
+// credentials flow.
+func (c *azureCheck) accessToken(ctx context.Context, loginEndpoint, managementEndpoint, tenantID, clientID, clientSecret string) (string, error) {
+	form := url.Values{
+		"grant_type":    []string{"client_credentials"},
+		"client_id":     []string{clientID},
+		"client_secret": []string{clientSecret},
+		"scope":         []string{managementEndpoint + "/.default"},
+	}.Encode()
+	u := fmt.Sprintf("%s/%s/oauth2/v2.0/token", loginEndpoint, url.PathEscape(tenantID))
+	req, err := http.NewRequestWithContext(ctx, "POST", u, strings.NewReader(form))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("retrieving access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+		// Microsoft Entra error codes, e.g. 7000215 for an invalid client
+		// secret.
+		ErrorCodes []int `json:"error_codes"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	switch {
+	case resp.StatusCode == http.StatusOK && body.AccessToken != "":
+		return body.AccessToken, nil
+	case isRejection(resp):
+		reason := body.Error
+		if len(body.ErrorCodes) > 0 {
+			reason = fmt.Sprintf("%s (AADSTS%d)", reason, body.ErrorCodes[0])
+		}
+		return "", &invalidCredentialsError{
+			provider: "Azure",
+			reason:   fmt.Sprintf("retrieving access token: %s", reason),
+		}
+	default:
+		return "", fmt.Errorf("unexpected response retrieving access token: %s", resp.Status)
+	}
+}
@@ -0,0 +1,238 @@
+// Package preflight validates the cloud credentials of runs before their plans
+// are enqueued.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/resource"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/variable"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// LockID guarantees only one pre-flight checker on a cluster is running at any
+// time.
+const LockID int64 = 5577006791947779421
+
+// defaultTimeout is the default maximum length of time permitted for all of a
+// run's checks.
+var defaultTimeout = 10 * time.Second
+
+type (
+	// Checker checks the cloud credentials of runs held for pre-flight checks,
+	// enqueueing their plans if the checks pass, or erroring the runs if the
+	// credentials are definitively invalid.
+	//
+	// Checks fail open: if a check is inconclusive, e.g. it times out or
+	// the provider is unavailable, then the plan is enqueued regardless.
+	Checker struct {
+		Logger     *slog.Logger
+		Runs       runClient
+		Workspaces workspaceClient
+		Variables  variableClient
+		Logs       logsClient
+
+		// maximum duration of a run's checks; defaults to defaultTimeout.
+		timeout time.Duration
+		// checks by kind; defaults to checks against each provider's public
+		// endpoints.
+		checks map[workspace.PreflightCheck]credentialCheck
+
+		mu sync.Mutex
+		// runs currently being checked
+		checking map[string]struct{}
+	}
+
+	runClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+		List(ctx context.Context, opts run.ListOptions) (*resource.Page[*run.Run], error)
+		EnqueuePlan(ctx context.Context, runID string) (*run.Run, error)
+		FailPreflight(ctx context.Context, runID string) (*run.Run, error)
+	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+
+	variableClient interface {
+		ListEffectiveVariables(ctx context.Context, runID string) ([]*variable.Variable, error)
+	}
+
+	logsClient interface {
+		PutChunk(ctx context.Context, opts internal.PutChunkOptions) error
+	}
+
+	// credentialCheck checks a provider's credentials, which are sourced from
+	// a run's environment variables.
+	credentialCheck interface {
+		check(ctx context.Context, env map[string]string) error
+	}
+)
+
+// Start starts the checker. Should be invoked in a go routine.
+func (c *Checker) Start(ctx context.Context) error {
+	if c.timeout == 0 {
+		c.timeout = defaultTimeout
+	}
+	if c.checks == nil {
+		client := &http.Client{}
+		c.checks = map[workspace.PreflightCheck]credentialCheck{
+			workspace.AWSPreflightCheck:   &awsCheck{client: client},
+			workspace.GCPPreflightCheck:   &gcpCheck{client: client},
+			workspace.AzurePreflightCheck: &azureCheck{client: client},
+		}
+	}
+	c.checking = make(map[string]struct{})
+
+	// subscribe to run events before listing runs to avoid missing any runs
+	// held in the meantime.
+	sub, unsub := c.Runs.Watch(ctx)
+	defer unsub()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// check runs already being held, e.g. before a restart
+	runs, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*run.Run], error) {
+		return c.Runs.List(ctx, run.ListOptions{
+			PageOptions: opts,
+			Statuses:    []run.Status{run.RunPreflighting},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for _, r := range runs {
+		c.startCheck(ctx, &wg, r)
+	}
+	for event := range sub {
+		if event.Type == pubsub.DeletedEvent {
+			continue
+		}
+		if event.Payload.Status != run.RunPreflighting {
+			continue
+		}
+		c.startCheck(ctx, &wg, event.Payload)
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
+
+// startCheck checks a run in a go routine, unless the run is already being
+// checked.
+func (c *Checker) startCheck(ctx context.Context, wg *sync.WaitGroup, r *run.Run) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.checking[r.ID]; ok {
+		return
+	}
+	c.checking[r.ID] = struct{}{}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			c.mu.Lock()
+			delete(c.checking, r.ID)
+			c.mu.Unlock()
+		}()
+		c.checkRun(ctx, r)
+	}()
+}
+
+// checkRun checks the credentials of a run, and then either enqueues its plan
+// or errors the run.
+func (c *Checker) checkRun(ctx context.Context, r *run.Run) {
+	if invalid := c.runChecks(ctx, r); invalid != nil {
+		c.fail(ctx, r.ID, invalid)
+		return
+	}
+	if _, err := c.Runs.EnqueuePlan(ctx, r.ID); err != nil {
+		// the run may have been canceled in the meantime.
+		c.Logger.Error("enqueuing plan after pre-flight checks", "run_id", r.ID, "err", err)
+		return
+	}
+	c.Logger.Info("passed pre-flight checks", "run_id", r.ID)
+}
+
+// runChecks runs the checks the run's workspace has enabled, returning an
+// error only if credentials are definitively invalid.
+func (c *Checker) runChecks(ctx context.Context, r *run.Run) *invalidCredentialsError {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	logger := c.Logger.With("run_id", r.ID)
+
+	checks, env, err := c.getCredentials(ctx, r)
+	if err != nil {
+		logger.Warn("skipping pre-flight checks", "err", err)
+		return nil
+	}
+	for _, kind := range checks {
+		check, ok := c.checks[kind]
+		if !ok {
+			continue
+		}
+		err := check.check(ctx, env)
+		var invalid *invalidCredentialsError
+		switch {
+		case err == nil:
+			logger.Info("pre-flight check passed", "check", kind)
+		case errors.As(err, &invalid):
+			return invalid
+		case errors.Is(err, errCredentialsNotSet):
+			logger.Info("skipping pre-flight check", "check", kind, "reason", err)
+		default:
+			// fail open
+			logger.Warn("pre-flight check inconclusive", "check", kind, "err", err)
+		}
+	}
+	return nil
+}
+
+// getCredentials retrieves the checks enabled for the run's workspace, along
+// with the run's environment variables from which credentials are sourced.
+func (c *Checker) getCredentials(ctx context.Context, r *run.Run) ([]workspace.PreflightCheck, map[string]string, error) {
+	ws, err := c.Workspaces.Get(ctx, r.WorkspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving workspace: %w", err)
+	}
+	vars, err := c.Variables.ListEffectiveVariables(ctx, r.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving variables: %w", err)
+	}
+	env := make(map[string]string)
+	for _, v := range vars {
+		if v.Category == variable.CategoryEnv {
+			env[v.Key] = v.Value
+		}
+	}
+	return ws.PreflightChecks, env, nil
+}
+
+// fail errors the run, writing the reason to the logs of its plan.
+func (c *Checker) fail(ctx context.Context, runID string, invalid *invalidCredentialsError) {
+	msg := fmt.Sprintf("%cError: pre-flight check failed: %s\n\nCorrect the workspace's credentials, or create the run with skip-preflight set to bypass the workspace's pre-flight checks.\n%c", internal.STX, invalid, internal.ETX)
+	err := c.Logs.PutChunk(ctx, internal.PutChunkOptions{
+		RunID: runID,
+		Phase: internal.PlanPhase,
+		Data:  []byte(msg),
+	})
+	if err != nil {
+		c.Logger.Error("writing pre-flight check failure to logs", "run_id", runID, "err", err)
+	}
+	if _, err := c.Runs.FailPreflight(ctx, runID); err != nil {
+		c.Logger.Error("failing pre-flight checks", "run_id", runID, "err", err)
+		return
+	}
+	c.Logger.Info("failed pre-flight checks", "run_id", runID, "reason", invalid)
+}
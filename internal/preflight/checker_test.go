@@ -0,0 +1,173 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/resource"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/variable"
+	"github.com/tofutf/tofutf/internal/workspace"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestChecker(t *testing.T) {
+	tests := []struct {
+		name string
+		// error returned by check; nil means check passes
+		err error
+		// whether check blocks until timeout
+		block bool
+		// want plan enqueued; otherwise want run failed
+		wantPlan bool
+	}{
+		{"pass", nil, false, true},
+		{"invalid credentials", &invalidCredentialsError{provider: "AWS", reason: "InvalidClientTokenId"}, false, false},
+		{"credentials not set", errCredentialsNotSet, false, true},
+		{"inconclusive", errors.New("unexpected response from STS: 503 Service Unavailable"), false, true},
+		{"timeout", nil, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := &fakeRunClient{
+				held:   []*run.Run{{ID: "run-123", WorkspaceID: "ws-123", Status: run.RunPreflighting}},
+				events: make(chan pubsub.Event[*run.Run]),
+			}
+			logs := &fakeLogsClient{}
+			checker := &Checker{
+				Logger: slog.New(&xslog.NoopHandler{}),
+				Runs:   runs,
+				Workspaces: &fakeWorkspaceClient{ws: &workspace.Workspace{
+					ID:              "ws-123",
+					PreflightChecks: workspace.PreflightChecks{workspace.AWSPreflightCheck},
+				}},
+				Variables: &fakeVariableClient{vars: []*variable.Variable{
+					{Key: "AWS_SECRET_ACCESS_KEY", Value: "hunter2", Category: variable.CategoryEnv},
+				}},
+				Logs:    logs,
+				timeout: 10 * time.Millisecond,
+				checks: map[workspace.PreflightCheck]credentialCheck{
+					workspace.AWSPreflightCheck: &fakeCheck{err: tt.err, block: tt.block},
+				},
+			}
+			// closing the subscription stops the checker once it has
+			// finished checking the held run.
+			close(runs.events)
+			err := checker.Start(context.Background())
+			require.ErrorIs(t, err, pubsub.ErrSubscriptionTerminated)
+
+			if tt.wantPlan {
+				assert.Equal(t, []string{"run-123"}, runs.enqueued)
+				assert.Empty(t, runs.failed)
+				assert.Empty(t, logs.chunks)
+			} else {
+				assert.Empty(t, runs.enqueued)
+				assert.Equal(t, []string{"run-123"}, runs.failed)
+				require.Len(t, logs.chunks, 1)
+				assert.Equal(t, internal.PlanPhase, logs.chunks[0].Phase)
+				assert.Contains(t, string(logs.chunks[0].Data), "invalid AWS credentials: InvalidClientTokenId")
+				assert.NotContains(t, string(logs.chunks[0].Data), "hunter2")
+			}
+		})
+	}
+
+	t.Run("check run held after start", func(t *testing.T) {
+		runs := &fakeRunClient{
+			events: make(chan pubsub.Event[*run.Run], 2),
+		}
+		checker := &Checker{
+			Logger:     slog.New(&xslog.NoopHandler{}),
+			Runs:       runs,
+			Workspaces: &fakeWorkspaceClient{ws: &workspace.Workspace{ID: "ws-123"}},
+			Variables:  &fakeVariableClient{},
+			Logs:       &fakeLogsClient{},
+			checks:     map[workspace.PreflightCheck]credentialCheck{},
+		}
+		runs.events <- pubsub.Event[*run.Run]{Payload: &run.Run{ID: "run-1", Status: run.RunPending}}
+		runs.events <- pubsub.Event[*run.Run]{Payload: &run.Run{ID: "run-2", Status: run.RunPreflighting}}
+		close(runs.events)
+		err := checker.Start(context.Background())
+		require.ErrorIs(t, err, pubsub.ErrSubscriptionTerminated)
+
+		assert.Equal(t, []string{"run-2"}, runs.enqueued)
+	})
+}
+
+type (
+	fakeRunClient struct {
+		held   []*run.Run
+		events chan pubsub.Event[*run.Run]
+
+		mu       sync.Mutex
+		enqueued []string
+		failed   []string
+	}
+
+	fakeWorkspaceClient struct {
+		ws *workspace.Workspace
+	}
+
+	fakeVariableClient struct {
+		vars []*variable.Variable
+	}
+
+	fakeLogsClient struct {
+		chunks []internal.PutChunkOptions
+	}
+
+	fakeCheck struct {
+		err   error
+		block bool
+	}
+)
+
+func (f *fakeRunClient) Watch(context.Context) (<-chan pubsub.Event[*run.Run], func()) {
+	return f.events, func() {}
+}
+
+func (f *fakeRunClient) List(context.Context, run.ListOptions) (*resource.Page[*run.Run], error) {
+	return resource.NewPage(f.held, resource.PageOptions{}, nil), nil
+}
+
+func (f *fakeRunClient) EnqueuePlan(ctx context.Context, runID string) (*run.Run, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.enqueued = append(f.enqueued, runID)
+	return &run.Run{ID: runID}, nil
+}
+
+func (f *fakeRunClient) FailPreflight(ctx context.Context, runID string) (*run.Run, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed = append(f.failed, runID)
+	return &run.Run{ID: runID}, nil
+}
+
+func (f *fakeWorkspaceClient) Get(context.Context, string) (*workspace.Workspace, error) {
+	return f.ws, nil
+}
+
+func (f *fakeVariableClient) ListEffectiveVariables(context.Context, string) ([]*variable.Variable, error) {
+	return f.vars, nil
+}
+
+func (f *fakeLogsClient) PutChunk(ctx context.Context, opts internal.PutChunkOptions) error {
+	f.chunks = append(f.chunks, opts)
+	return nil
+}
+
+func (f *fakeCheck) check(ctx context.Context, env map[string]string) error {
+	if f.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return f.err
+}
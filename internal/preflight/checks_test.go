@@ -0,0 +1,206 @@
+package preflight
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		require.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential="), auth)
+		require.NotEmpty(t, r.Header.Get("X-Amz-Date"))
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "GetCallerIdentity", r.PostForm.Get("Action"))
+
+		switch {
+		case strings.Contains(auth, "Credential=AKIDINVALID/"):
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>InvalidClientTokenId</Code><Message>The security token included in the request is invalid.</Message></Error></ErrorResponse>`))
+		case strings.Contains(auth, "Credential=AKIDUNAVAILABLE/"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			require.Contains(t, auth, "/eu-west-2/sts/aws4_request")
+			require.Equal(t, "session-token", r.Header.Get("X-Amz-Security-Token"))
+			w.Write([]byte(`<GetCallerIdentityResponse></GetCallerIdentityResponse>`))
+		}
+	}))
+	defer srv.Close()
+	check := &awsCheck{client: srv.Client(), endpoint: srv.URL}
+	ctx := context.Background()
+
+	t.Run("valid credentials", func(t *testing.T) {
+		err := check.check(ctx, map[string]string{
+			"AWS_ACCESS_KEY_ID":     "AKIDVALID",
+			"AWS_SECRET_ACCESS_KEY": "hunter2",
+			"AWS_SESSION_TOKEN":     "session-token",
+			"AWS_REGION":            "eu-west-2",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		err := check.check(ctx, map[string]string{
+			"AWS_ACCESS_KEY_ID":     "AKIDINVALID",
+			"AWS_SECRET_ACCESS_KEY": "hunter2",
+		})
+		var invalid *invalidCredentialsError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, "invalid AWS credentials: InvalidClientTokenId: The security token included in the request is invalid.", err.Error())
+		assert.NotContains(t, err.Error(), "hunter2")
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		err := check.check(ctx, map[string]string{
+			"AWS_ACCESS_KEY_ID":     "AKIDUNAVAILABLE",
+			"AWS_SECRET_ACCESS_KEY": "hunter2",
+		})
+		var invalid *invalidCredentialsError
+		require.Error(t, err)
+		assert.False(t, errors.As(err, &invalid))
+	})
+
+	t.Run("credentials not set", func(t *testing.T) {
+		err := check.check(ctx, map[string]string{})
+		assert.ErrorIs(t, err, errCredentialsNotSet)
+	})
+}
+
+func TestGCPCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tokeninfo", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		// token must not be sent in the query
+		require.Empty(t, r.URL.Query().Get("access_token"))
+		if r.PostForm.Get("access_token") == "valid-token" {
+			w.Write([]byte(`{"expires_in": "3599"}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "invalid_token", "error_description": "Invalid Value"}`))
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "invalid_grant", "error_description": "Invalid JWT Signature."}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	check := &gcpCheck{client: srv.Client(), tokenInfoEndpoint: srv.URL + "/tokeninfo"}
+	ctx := context.Background()
+
+	t.Run("valid access token", func(t *testing.T) {
+		err := check.check(ctx, map[string]string{"GOOGLE_OAUTH_ACCESS_TOKEN": "valid-token"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid access token", func(t *testing.T) {
+		err := check.check(ctx, map[string]string{"GOOGLE_OAUTH_ACCESS_TOKEN": "expired-token"})
+		var invalid *invalidCredentialsError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, "invalid GCP credentials: access token rejected: invalid_token", err.Error())
+	})
+
+	t.Run("revoked service account key", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+		creds, err := json.Marshal(map[string]string{
+			"type":           "service_account",
+			"client_email":   "terraform@acme.iam.gserviceaccount.com",
+			"private_key_id": "abc123",
+			"private_key":    string(keyPEM),
+			"token_uri":      srv.URL + "/token",
+		})
+		require.NoError(t, err)
+
+		err = check.check(ctx, map[string]string{"GOOGLE_CREDENTIALS": string(creds)})
+		var invalid *invalidCredentialsError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, "invalid GCP credentials: exchanging credentials for access token: invalid_grant", err.Error())
+		assert.NotContains(t, err.Error(), "PRIVATE KEY")
+	})
+
+	t.Run("malformed credentials", func(t *testing.T) {
+		err := check.check(ctx, map[string]string{"GOOGLE_CREDENTIALS": `{"type": "unknown"}`})
+		var invalid *invalidCredentialsError
+		require.ErrorAs(t, err, &invalid)
+	})
+
+	t.Run("path to credentials file", func(t *testing.T) {
+		err := check.check(ctx, map[string]string{"GOOGLE_CREDENTIALS": "/etc/gcp/creds.json"})
+		assert.ErrorIs(t, err, errCredentialsNotSet)
+	})
+}
+
+func TestAzureCheck(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant-123/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		if r.PostForm.Get("client_secret") != "correct-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_client", "error_description": "AADSTS7000215: Invalid client secret provided.", "error_codes": [7000215]}`))
+			return
+		}
+		w.Write([]byte(`{"access_token": "arm-token"}`))
+	})
+	mux.HandleFunc("/subscriptions/", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer arm-token", r.Header.Get("Authorization"))
+		if r.URL.Path == "/subscriptions/sub-123" {
+			w.Write([]byte(`{"subscriptionId": "sub-123"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"code": "SubscriptionNotFound", "message": "The subscription could not be found."}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	check := &azureCheck{client: srv.Client(), loginEndpoint: srv.URL, managementEndpoint: srv.URL}
+	ctx := context.Background()
+
+	env := func(secret, subscription string) map[string]string {
+		return map[string]string{
+			"ARM_CLIENT_ID":       "client-123",
+			"ARM_CLIENT_SECRET":   secret,
+			"ARM_TENANT_ID":       "tenant-123",
+			"ARM_SUBSCRIPTION_ID": subscription,
+		}
+	}
+
+	t.Run("valid credentials", func(t *testing.T) {
+		assert.NoError(t, check.check(ctx, env("correct-secret", "sub-123")))
+	})
+
+	t.Run("invalid client secret", func(t *testing.T) {
+		err := check.check(ctx, env("wrong-secret", "sub-123"))
+		var invalid *invalidCredentialsError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, "invalid Azure credentials: retrieving access token: invalid_client (AADSTS7000215)", err.Error())
+		assert.NotContains(t, err.Error(), "wrong-secret")
+	})
+
+	t.Run("unknown subscription", func(t *testing.T) {
+		err := check.check(ctx, env("correct-secret", "sub-unknown"))
+		var invalid *invalidCredentialsError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, "invalid Azure credentials: service principal cannot access subscription sub-unknown: SubscriptionNotFound", err.Error())
+	})
+
+	t.Run("credentials not set", func(t *testing.T) {
+		err := check.check(ctx, map[string]string{"ARM_USE_MSI": "true"})
+		assert.ErrorIs(t, err, errCredentialsNotSet)
+	})
+}
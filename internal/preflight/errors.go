@@ -0,0 +1,37 @@
+package preflight
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// errCredentialsNotSet is returned by a check when the run's environment
+// variables do not include the credentials it checks, e.g. because the agent
+// sources credentials from its host instead.
+var errCredentialsNotSet = errors.New("credentials not set in environment variables")
+
+// invalidCredentialsError is returned by a check when a provider has
+// definitively rejected credentials.
+//
+// NOTE: the reason must never include credential material.
+type invalidCredentialsError struct {
+	provider string
+	reason   string
+}
+
+func (e *invalidCredentialsError) Error() string {
+	return fmt.Sprintf("invalid %s credentials: %s", e.provider, e.reason)
+}
+
+// isRejection determines whether a provider's response rejects the
+// credentials presented to it, as opposed to failing for some other reason
+// such as rate limiting or unavailability.
+func isRejection(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,122 @@
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	gcpDefaultTokenInfoEndpoint = "https://oauth2.googleapis.com/tokeninfo"
+	gcpScope                    = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// gcpCheck checks GCP credentials, either an access token or the JSON
+// credentials of a service account, by retrieving information about the access
+// token from Google's token info endpoint.
+type gcpCheck struct {
+	client *http.Client
+	// tokenInfoEndpoint overrides Google's token info endpoint; for testing
+	// purposes.
+	tokenInfoEndpoint string
+}
+
+func (c *gcpCheck) check(ctx context.Context, env map[string]string) error {
+	token, err := c.accessToken(ctx, env)
+	if err != nil {
+		return err
+	}
+	endpoint := c.tokenInfoEndpoint
+	if endpoint == "" {
+		endpoint = gcpDefaultTokenInfoEndpoint
+	}
+	// send the token in the body rather than the query so that it cannot
+	// appear in any error.
+	form := url.Values{"access_token": []string{token}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("retrieving token info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case isRejection(resp):
+		var errResp struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return &invalidCredentialsError{
+			provider: "GCP",
+			reason:   fmt.Sprintf("access token rejected: %s", errResp.Error),
+		}
+	default:
+		return fmt.Errorf("unexpected response from token info endpoint: %s", resp.Status)
+	}
+}
+
+// accessToken returns an access token, either from GOOGLE_OAUTH_ACCESS_TOKEN,
+// or by exchanging the credentials in GOOGLE_CREDENTIALS.
+func (c *gcpCheck) accessToken(ctx context.Context, env map[string]string) (string, error) {
+	if token := env["GOOGLE_OAUTH_ACCESS_TOKEN"]; token != "" {
+		return token, nil
+	}
+	contents := env["GOOGLE_CREDENTIALS"]
+	if contents == "" {
+		return "", errCredentialsNotSet
+	}
+	if !json.Valid([]byte(contents)) {
+		// the google provider also accepts a path to a credentials file,
+		// which cannot be read here.
+		return "", errCredentialsNotSet
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.client)
+	creds, err := google.CredentialsFromJSON(ctx, []byte(contents), gcpScope)
+	if err != nil {
+		// do not include the underlying error, lest it include part of the
+		// credentials.
+		return "", &invalidCredentialsError{
+			provider: "GCP",
+			reason:   "GOOGLE_CREDENTIALS is not a valid credentials file",
+		}
+	}
+	token, err := creds.TokenSource.Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.Response != nil && isRejection(retrieveErr.Response) {
+			reason := retrieveErr.ErrorCode
+			if reason == "" {
+				// the service account flow does not parse the error code
+				// from the response.
+				var body struct {
+					Error string `json:"error"`
+				}
+				_ = json.Unmarshal(retrieveErr.Body, &body)
+				reason = body.Error
+			}
+			if reason == "" {
+				reason = retrieveErr.Response.Status
+			}
+			return "", &invalidCredentialsError{
+				provider: "GCP",
+				reason:   fmt.Sprintf("exchanging credentials for access token: %s", reason),
+			}
+		}
+		return "", fmt.Errorf("exchanging credentials for access token: %w", err)
+	}
+	return token.AccessToken, nil
+}
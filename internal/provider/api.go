@@ -2,21 +2,37 @@ package provider
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/surl"
+	"github.com/tofutf/tofutf/internal"
+	otfapi "github.com/tofutf/tofutf/internal/api"
 	"github.com/tofutf/tofutf/internal/http/decode"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 )
 
 type apiHandlers struct {
 	*surl.Signer
+	*tfeapi.Responder
 
 	svc *Service
 }
 
 func (h *apiHandlers) addHandlers(r *mux.Router) {
+	// signed routes
+	signed := r.PathPrefix("/signed/{signature.expiry}").Subrouter()
+	signed.Use(internal.VerifySignedURL(h.Signer))
+	signed.HandleFunc("/providers/download/{provider_version_id}/SHA256SUMS", h.downloadShasums).Methods("GET")
+	signed.HandleFunc("/providers/download/{provider_version_id}/SHA256SUMS.sig", h.downloadShasumsSignature).Methods("GET")
+	signed.HandleFunc("/providers/download/{provider_version_id}/{os}/{arch}/{filename}", h.downloadArchive).Methods("GET")
+
+	// private registry publication routes
+	api := r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+	api.HandleFunc("/organizations/{organization_name}/providers/{name}/versions", h.publishVersion).Methods("POST")
+	api.HandleFunc("/providers/versions/{provider_version_id}/platforms/{os}/{arch}", h.uploadPlatform).Methods("PUT")
+
 	// authenticated module api routes
 	//
 	// Implements the Module Registry Protocol:
@@ -91,3 +107,102 @@ func (h *apiHandlers) findProviderPackage(w http.ResponseWriter, r *http.Request
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
+
+func (h *apiHandlers) publishVersion(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization string `schema:"organization_name,required"`
+		Name         string `schema:"name,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts PublishVersionOptions
+	if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	opts.Organization = params.Organization
+	opts.Name = params.Name
+
+	version, err := h.svc.PublishVersion(r.Context(), opts)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	h.Respond(w, r, version, http.StatusCreated)
+}
+
+func (h *apiHandlers) uploadPlatform(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		VersionID string `schema:"provider_version_id,required"`
+		OS        string `schema:"os,required"`
+		Arch      string `schema:"arch,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	archive, err := io.ReadAll(r.Body)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	version, err := h.svc.UploadPlatform(r.Context(), UploadPlatformOptions{
+		VersionID: params.VersionID,
+		OS:        params.OS,
+		Arch:      params.Arch,
+		Archive:   archive,
+	})
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	h.Respond(w, r, version, http.StatusOK)
+}
+
+func (h *apiHandlers) downloadArchive(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		VersionID string `schema:"provider_version_id,required"`
+		OS        string `schema:"os,required"`
+		Arch      string `schema:"arch,required"`
+	}
+	if err := decode.Route(&params, r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	archive, err := h.svc.downloadArchive(r.Context(), params.VersionID, params.OS, params.Arch)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-type", "application/zip")
+	w.Write(archive) //nolint:errcheck
+}
+
+func (h *apiHandlers) downloadShasums(w http.ResponseWriter, r *http.Request) {
+	h.writeShasums(w, r, false)
+}
+
+func (h *apiHandlers) downloadShasumsSignature(w http.ResponseWriter, r *http.Request) {
+	h.writeShasums(w, r, true)
+}
+
+func (h *apiHandlers) writeShasums(w http.ResponseWriter, r *http.Request, signature bool) {
+	id, err := decode.Param("provider_version_id", r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	content, err := h.svc.downloadShasums(r.Context(), id, signature)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Write(content) //nolint:errcheck
+}
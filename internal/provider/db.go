@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is the registry of private provider versions on postgres
+	pgdb struct {
+		*sql.Pool // provides access to generated SQL queries
+	}
+
+	versionRow struct {
+		ProviderVersionID pgtype.Text        `json:"provider_version_id"`
+		OrganizationName  pgtype.Text        `json:"organization_name"`
+		Name              pgtype.Text        `json:"name"`
+		Version           pgtype.Text        `json:"version"`
+		Protocols         []string           `json:"protocols"`
+		KeyID             pgtype.Text        `json:"key_id"`
+		AsciiArmor        pgtype.Text        `json:"ascii_armor"`
+		Shasums           []byte             `json:"shasums"`
+		ShasumsSignature  []byte             `json:"shasums_signature"`
+		CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	}
+)
+
+func (row versionRow) toVersion() *Version {
+	return &Version{
+		ID:               row.ProviderVersionID.String,
+		Organization:     row.OrganizationName.String,
+		Name:             row.Name.String,
+		Version:          row.Version.String,
+		Protocols:        row.Protocols,
+		KeyID:            row.KeyID.String,
+		ASCIIArmor:       row.AsciiArmor.String,
+		Shasums:          row.Shasums,
+		ShasumsSignature: row.ShasumsSignature,
+		CreatedAt:        row.CreatedAt.Time.UTC(),
+	}
+}
+
+func (db *pgdb) createVersion(ctx context.Context, v *Version) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertProviderVersion(ctx, pggen.InsertProviderVersionParams{
+			ProviderVersionID: sql.String(v.ID),
+			OrganizationName:  sql.String(v.Organization),
+			Name:              sql.String(v.Name),
+			Version:           sql.String(v.Version),
+			Protocols:         v.Protocols,
+			KeyID:             sql.String(v.KeyID),
+			AsciiArmor:        sql.String(v.ASCIIArmor),
+			Shasums:           v.Shasums,
+			ShasumsSignature:  v.ShasumsSignature,
+			CreatedAt:         sql.Timestamptz(v.CreatedAt),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+// listVersions lists the versions of an organization's provider, along with
+// their platforms.
+func (db *pgdb) listVersions(ctx context.Context, organization, name string) ([]*Version, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Version, error) {
+		rows, err := q.FindProviderVersions(ctx, sql.String(organization), sql.String(name))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		versions := make([]*Version, len(rows))
+		for i, r := range rows {
+			versions[i] = versionRow(r).toVersion()
+		}
+		if err := db.addPlatforms(ctx, q, versions...); err != nil {
+			return nil, err
+		}
+		return versions, nil
+	})
+}
+
+func (db *pgdb) getVersion(ctx context.Context, versionID string) (*Version, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Version, error) {
+		row, err := q.FindProviderVersionByID(ctx, sql.String(versionID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		version := versionRow(row).toVersion()
+		if err := db.addPlatforms(ctx, q, version); err != nil {
+			return nil, err
+		}
+		return version, nil
+	})
+}
+
+func (db *pgdb) addPlatforms(ctx context.Context, q pggen.Querier, versions ...*Version) error {
+	if len(versions) == 0 {
+		return nil
+	}
+	ids := make([]string, len(versions))
+	byID := make(map[string]*Version, len(versions))
+	for i, v := range versions {
+		ids[i] = v.ID
+		byID[v.ID] = v
+	}
+	rows, err := q.FindProviderPlatforms(ctx, ids)
+	if err != nil {
+		return sql.Error(err)
+	}
+	for _, r := range rows {
+		v := byID[r.ProviderVersionID.String]
+		v.Platforms = append(v.Platforms, Platform{
+			OS:       r.Os.String,
+			Arch:     r.Arch.String,
+			Filename: r.Filename.String,
+			Shasum:   r.Shasum.String,
+		})
+	}
+	return nil
+}
+
+func (db *pgdb) createPlatform(ctx context.Context, versionID string, platform Platform, archive []byte) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertProviderPlatform(ctx, pggen.InsertProviderPlatformParams{
+			ProviderVersionID: sql.String(versionID),
+			Os:                sql.String(platform.OS),
+			Arch:              sql.String(platform.Arch),
+			Filename:          sql.String(platform.Filename),
+			Shasum:            sql.String(platform.Shasum),
+			Archive:           archive,
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) getArchive(ctx context.Context, versionID, os, arch string) ([]byte, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]byte, error) {
+		archive, err := q.FindProviderPlatformArchive(ctx, pggen.FindProviderPlatformArchiveParams{
+			ProviderVersionID: sql.String(versionID),
+			Os:                sql.String(os),
+			Arch:              sql.String(arch),
+		})
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return archive, nil
+	})
+}
@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/gpgkeys"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/semver"
+)
+
+type (
+	// PublishVersionOptions are options for publishing a provider version to
+	// an organization's private registry.
+	PublishVersionOptions struct {
+		Organization string `json:"-"`
+		Name         string `json:"-"`
+
+		Version string `json:"version"`
+		// Protocols supported by the provider version. Defaults to
+		// DefaultProtocols.
+		Protocols []string `json:"protocols,omitempty"`
+		// KeyID identifies the organization's signing key with which
+		// SHA256SUMS is signed.
+		KeyID string `json:"key_id"`
+		// Shasums is the content of the SHA256SUMS file listing the checksums
+		// of the version's platform archives.
+		Shasums []byte `json:"shasums"`
+		// ShasumsSignature is the detached signature of SHA256SUMS.
+		ShasumsSignature []byte `json:"shasums_signature"`
+	}
+
+	// UploadPlatformOptions are options for uploading the archive of a
+	// provider version for a platform.
+	UploadPlatformOptions struct {
+		VersionID string
+		OS        string
+		Arch      string
+		Archive   []byte
+	}
+
+	// signatureVerifier verifies signatures with an organization's signing
+	// keys.
+	signatureVerifier interface {
+		Verify(ctx context.Context, opts gpgkeys.VerifyOptions) (*gpgkeys.GPGKey, error)
+	}
+)
+
+// PublishVersion publishes a provider version to the organization's private
+// registry. Its SHA256SUMS must be signed by one of the organization's
+// unrevoked signing keys. Archives for each platform are uploaded to the
+// version afterwards.
+func (s *Service) PublishVersion(ctx context.Context, opts PublishVersionOptions) (*Version, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateProviderVersionAction, opts.Organization)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.newVersion(ctx, opts)
+	if err != nil {
+		s.logger.Error("publishing provider version", "organization", opts.Organization, "name", opts.Name, "version", opts.Version, "subject", subject, "err", err)
+		return nil, err
+	}
+	if err := s.db.createVersion(ctx, version); err != nil {
+		s.logger.Error("publishing provider version", "organization", opts.Organization, "name", opts.Name, "version", opts.Version, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("published provider version", "organization", opts.Organization, "name", opts.Name, "version", opts.Version, "key_id", version.KeyID, "subject", subject)
+	return version, nil
+}
+
+func (s *Service) newVersion(ctx context.Context, opts PublishVersionOptions) (*Version, error) {
+	if opts.Name == "" {
+		return nil, internal.ErrRequiredName
+	}
+	if !semver.IsValid(opts.Version) {
+		return nil, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf("invalid provider version: %s", opts.Version),
+		}
+	}
+	if opts.KeyID == "" {
+		return nil, &internal.MissingParameterError{Parameter: "key_id"}
+	}
+	if _, err := parseShasums(opts.Shasums); err != nil {
+		return nil, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		}
+	}
+	key, err := s.signatures.Verify(ctx, gpgkeys.VerifyOptions{
+		Organization: opts.Organization,
+		KeyID:        opts.KeyID,
+		Message:      opts.Shasums,
+		Signature:    opts.ShasumsSignature,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Protocols) == 0 {
+		opts.Protocols = DefaultProtocols
+	}
+	return &Version{
+		ID:               internal.NewID("prv"),
+		Organization:     opts.Organization,
+		Name:             opts.Name,
+		Version:          opts.Version,
+		Protocols:        opts.Protocols,
+		KeyID:            key.KeyID,
+		ASCIIArmor:       key.ASCIIArmor,
+		Shasums:          opts.Shasums,
+		ShasumsSignature: opts.ShasumsSignature,
+		CreatedAt:        internal.CurrentTimestamp(nil),
+	}, nil
+}
+
+// UploadPlatform uploads the archive of a provider version for a platform. The
+// checksum of the archive must match that listed for it in the version's
+// SHA256SUMS.
+func (s *Service) UploadPlatform(ctx context.Context, opts UploadPlatformOptions) (*Version, error) {
+	version, err := s.db.getVersion(ctx, opts.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.CreateProviderVersionAction, version.Organization)
+	if err != nil {
+		return nil, err
+	}
+	platform, err := version.newPlatform(opts.OS, opts.Arch, opts.Archive)
+	if err != nil {
+		s.logger.Error("uploading provider archive", "provider_version", version.ID, "os", opts.OS, "arch", opts.Arch, "subject", subject, "err", err)
+		return nil, err
+	}
+	if err := s.db.createPlatform(ctx, version.ID, platform, opts.Archive); err != nil {
+		s.logger.Error("uploading provider archive", "provider_version", version.ID, "os", opts.OS, "arch", opts.Arch, "subject", subject, "err", err)
+		return nil, err
+	}
+	version.Platforms = append(version.Platforms, platform)
+	s.logger.Info("uploaded provider archive", "provider_version", version.ID, "os", opts.OS, "arch", opts.Arch, "subject", subject)
+	return version, nil
+}
+
+func (v *Version) newPlatform(os, arch string, archive []byte) (Platform, error) {
+	filename := v.Filename(os, arch)
+	want, err := v.checksum(filename)
+	if err != nil {
+		return Platform{}, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		}
+	}
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return Platform{}, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf("checksum of %s does not match SHA256SUMS: expected %s but got %s", filename, want, got),
+		}
+	}
+	return Platform{OS: os, Arch: arch, Filename: filename, Shasum: want}, nil
+}
+
+// listPrivateVersions lists the versions of a provider in the private
+// registry of the organization with the same name as the namespace. If there
+// are none then the provider is not private and nil is returned without
+// checking authorization, permitting the caller to fall back to the proxy.
+func (s *Service) listPrivateVersions(ctx context.Context, namespace, name string) ([]*Version, error) {
+	versions, err := s.db.listVersions(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	if _, err := s.organization.CanAccess(ctx, rbac.GetProviderVersionAction, namespace); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// toProviderVersions converts private provider versions into the response of
+// the list available versions endpoint, omitting versions for which no archive
+// has yet been uploaded.
+func toProviderVersions(versions []*Version) *ProviderVersions {
+	to := &ProviderVersions{Versions: []ProviderVersionsVersions{}}
+	for _, v := range versions {
+		if len(v.Platforms) == 0 {
+			continue
+		}
+		version := ProviderVersionsVersions{
+			Version:   v.Version,
+			Protocols: v.Protocols,
+		}
+		for _, p := range v.Platforms {
+			version.Platforms = append(version.Platforms, ProviderPlatform{Os: p.OS, Arch: p.Arch})
+		}
+		to.Versions = append(to.Versions, version)
+	}
+	return to
+}
+
+// manifest constructs the response of the find package endpoint for the
+// platform of a private provider version. The manifest includes the key with
+// which SHA256SUMS was signed, permitting terraform to verify the package.
+func (s *Service) manifest(v *Version, platform *Platform) (*ProviderVersionManifest, error) {
+	prefix := "/providers/download/" + v.ID
+	downloadURL, err := s.signer.Sign(fmt.Sprintf("%s/%s/%s/%s", prefix, platform.OS, platform.Arch, platform.Filename), time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	shasumsURL, err := s.signer.Sign(prefix+"/SHA256SUMS", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	signatureURL, err := s.signer.Sign(prefix+"/SHA256SUMS.sig", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	return &ProviderVersionManifest{
+		Protocols:           v.Protocols,
+		Os:                  platform.OS,
+		Arch:                platform.Arch,
+		Filename:            platform.Filename,
+		DownloadURL:         downloadURL,
+		ShasumsURL:          shasumsURL,
+		ShasumsSignatureURL: signatureURL,
+		Shasum:              platform.Shasum,
+		SigningKeys: SigningKeys{
+			GpgPublicKeys: []GPGPublicKey{
+				{
+					KeyID:      v.KeyID,
+					ASCIIArmor: v.ASCIIArmor,
+				},
+			},
+		},
+	}, nil
+}
+
+// downloadArchive should be accessed via signed URL
+func (s *Service) downloadArchive(ctx context.Context, versionID, os, arch string) ([]byte, error) {
+	archive, err := s.db.getArchive(ctx, versionID, os, arch)
+	if err != nil {
+		s.logger.Error("downloading provider archive", "provider_version", versionID, "os", os, "arch", arch, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("downloaded provider archive", "provider_version", versionID, "os", os, "arch", arch)
+	return archive, nil
+}
+
+// downloadShasums should be accessed via signed URL. It returns the version's
+// SHA256SUMS, or its signature if signature is true.
+func (s *Service) downloadShasums(ctx context.Context, versionID string, signature bool) ([]byte, error) {
+	version, err := s.db.getVersion(ctx, versionID)
+	if err != nil {
+		s.logger.Error("downloading provider SHA256SUMS", "provider_version", versionID, "err", err)
+		return nil, err
+	}
+	if signature {
+		return version.ShasumsSignature, nil
+	}
+	return version.Shasums, nil
+}
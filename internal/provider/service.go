@@ -12,9 +12,11 @@ import (
 	"github.com/leg100/surl"
 	"github.com/pkg/errors"
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/gpgkeys"
 	"github.com/tofutf/tofutf/internal/http/html"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/tfeapi"
 )
 
 type (
@@ -25,15 +27,22 @@ type (
 		*internal.HostnameService
 		*surl.Signer
 		html.Renderer
+		*tfeapi.Responder
 
 		ProxyURL           string
 		ProxyIsArtifactory bool
+
+		GPGKeyService *gpgkeys.Service
 	}
 
 	Service struct {
 		logger       *slog.Logger
 		organization internal.Authorizer
 
+		db         *pgdb
+		signer     *surl.Signer
+		signatures signatureVerifier
+
 		api *apiHandlers
 		web *webHandlers
 
@@ -48,13 +57,17 @@ func NewService(opts Options) *Service {
 	svc := Service{
 		logger:             opts.Logger,
 		organization:       &organization.Authorizer{Logger: opts.Logger},
+		db:                 &pgdb{opts.Pool},
+		signer:             opts.Signer,
+		signatures:         opts.GPGKeyService,
 		proxyURL:           opts.ProxyURL,
 		proxyIsArtifactory: opts.ProxyIsArtifactory,
 		client:             &http.Client{},
 	}
 	svc.api = &apiHandlers{
-		svc:    &svc,
-		Signer: opts.Signer,
+		svc:       &svc,
+		Signer:    opts.Signer,
+		Responder: opts.Responder,
 	}
 	svc.web = &webHandlers{
 		client:   &svc,
@@ -91,8 +104,18 @@ type ProviderPlatform struct {
 	Arch string `json:"arch"`
 }
 
-// GetProviderVersions retrieves the list of versions that exist for a given provider.
+// GetProviderVersions retrieves the list of versions that exist for a given
+// provider. Versions of a provider published to the private registry of the
+// organization named by the namespace take precedence over the proxy.
 func (s *Service) GetProviderVersions(ctx context.Context, options GetProviderVersionsOptions) (*ProviderVersions, error) {
+	private, err := s.listPrivateVersions(ctx, options.Namespace, options.Type)
+	if err != nil {
+		return nil, err
+	}
+	if private != nil {
+		return toProviderVersions(private), nil
+	}
+
 	s.logger.Info("proxying provider versions request", "namespace", options.Namespace, "type", options.Type)
 
 	versionsURL, err := url.JoinPath(s.proxyURL, options.Namespace, options.Type, "versions")
@@ -137,30 +160,52 @@ type FindProviderPackageOptions struct {
 }
 
 type ProviderVersionManifest struct {
-	Protocols           []string `json:"protocols"`
-	Os                  string   `json:"os"`
-	Arch                string   `json:"arch"`
-	Filename            string   `json:"filename"`
-	DownloadURL         string   `json:"download_url"`
-	ShasumsURL          string   `json:"shasums_url"`
-	ShasumsSignatureURL string   `json:"shasums_signature_url"`
-	Shasum              string   `json:"shasum"`
-	SigningKeys         struct {
-		GpgPublicKeys []struct {
-			KeyID          string `json:"key_id"`
-			ASCIIArmor     string `json:"ascii_armor"`
-			TrustSignature string `json:"trust_signature"`
-			Source         string `json:"source"`
-			SourceURL      string `json:"source_url"`
-		} `json:"gpg_public_keys"`
-	} `json:"signing_keys"`
+	Protocols           []string    `json:"protocols"`
+	Os                  string      `json:"os"`
+	Arch                string      `json:"arch"`
+	Filename            string      `json:"filename"`
+	DownloadURL         string      `json:"download_url"`
+	ShasumsURL          string      `json:"shasums_url"`
+	ShasumsSignatureURL string      `json:"shasums_signature_url"`
+	Shasum              string      `json:"shasum"`
+	SigningKeys         SigningKeys `json:"signing_keys"`
+}
+
+type SigningKeys struct {
+	GpgPublicKeys []GPGPublicKey `json:"gpg_public_keys"`
 }
 
+type GPGPublicKey struct {
+	KeyID          string `json:"key_id"`
+	ASCIIArmor     string `json:"ascii_armor"`
+	TrustSignature string `json:"trust_signature"`
+	Source         string `json:"source"`
+	SourceURL      string `json:"source_url"`
+}
+
+// FindProviderPackage retrieves the manifest of a provider package. A version
+// of a provider published to the private registry of the organization named by
+// the namespace takes precedence over the proxy.
 func (s Service) FindProviderPackage(ctx context.Context, options FindProviderPackageOptions) (*ProviderVersionManifest, error) {
+	private, err := s.listPrivateVersions(ctx, options.Namespace, options.Type)
+	if err != nil {
+		return nil, err
+	}
+	if private != nil {
+		for _, v := range private {
+			if v.Version != options.Version {
+				continue
+			}
+			if platform := v.Platform(options.OS, options.Arch); platform != nil {
+				return s.manifest(v, platform)
+			}
+		}
+		return nil, internal.ErrResourceNotFound
+	}
+
 	s.logger.Info("proxying provider download request", "namespace", options.Namespace, "type", options.Type, "version", options.Version, "os", options.OS, "arch", options.Arch)
 
 	var manifestURL string
-	var err error
 	if s.proxyIsArtifactory {
 		// artifactory is kinda garbage and doesn't actually build correct URLs for terraform at this point in time.
 		manifestURL, err = url.JoinPath(s.proxyURL, options.Namespace, options.Type, options.Version, options.OS, options.Arch)
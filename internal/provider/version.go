@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultProtocols are the provider protocol versions a provider version is
+// assumed to support if none are specified upon publication.
+var DefaultProtocols = []string{"5.0"}
+
+type (
+	// Version is a version of a provider published to an organization's
+	// private registry. The checksums of its platform archives are listed in
+	// its SHA256SUMS, which is signed by one of the organization's signing
+	// keys.
+	Version struct {
+		ID           string    `jsonapi:"primary,provider-versions"`
+		Organization string    `jsonapi:"attribute" json:"organization"`
+		Name         string    `jsonapi:"attribute" json:"name"`
+		Version      string    `jsonapi:"attribute" json:"version"`
+		Protocols    []string  `jsonapi:"attribute" json:"protocols"`
+		KeyID        string    `jsonapi:"attribute" json:"key-id"`
+		CreatedAt    time.Time `jsonapi:"attribute" json:"created-at"`
+		// Platforms for which an archive has been uploaded.
+		Platforms []Platform `jsonapi:"attribute" json:"platforms"`
+
+		// ASCIIArmor is the public key with which SHA256SUMS was signed. It is
+		// retained with the version so that the version remains valid even if
+		// the key is subsequently revoked.
+		ASCIIArmor       string `jsonapi:"-" json:"-"`
+		Shasums          []byte `jsonapi:"-" json:"-"`
+		ShasumsSignature []byte `jsonapi:"-" json:"-"`
+	}
+
+	// Platform is an operating system and architecture for which a provider
+	// version archive has been uploaded.
+	Platform struct {
+		OS       string `json:"os"`
+		Arch     string `json:"arch"`
+		Filename string `json:"filename"`
+		Shasum   string `json:"shasum"`
+	}
+)
+
+// Filename is the name of the archive of the provider version for the
+// platform, per the naming convention used in SHA256SUMS.
+func (v *Version) Filename(os, arch string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_%s_%s.zip", v.Name, v.Version, os, arch)
+}
+
+// Platform retrieves the platform with the os and arch, returning nil if no
+// archive has been uploaded for the platform.
+func (v *Version) Platform(os, arch string) *Platform {
+	for i := range v.Platforms {
+		if v.Platforms[i].OS == os && v.Platforms[i].Arch == arch {
+			return &v.Platforms[i]
+		}
+	}
+	return nil
+}
+
+// checksum retrieves the checksum of the archive with the filename from the
+// version's SHA256SUMS.
+func (v *Version) checksum(filename string) (string, error) {
+	shasums, err := parseShasums(v.Shasums)
+	if err != nil {
+		return "", err
+	}
+	checksum, ok := shasums[filename]
+	if !ok {
+		return "", fmt.Errorf("SHA256SUMS has no checksum for %s", filename)
+	}
+	return checksum, nil
+}
+
+// parseShasums parses the contents of a SHA256SUMS file, as produced by
+// sha256sum, returning a map of filename to checksum.
+func parseShasums(b []byte) (map[string]string, error) {
+	shasums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		checksum, filename, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("malformed SHA256SUMS line: %s", line)
+		}
+		// sha256sum prefixes the filename with an asterisk in binary mode
+		filename = strings.TrimPrefix(strings.TrimSpace(filename), "*")
+		if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != 32 {
+			return nil, fmt.Errorf("malformed SHA256SUMS checksum: %s", checksum)
+		}
+		shasums[filename] = strings.ToLower(checksum)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(shasums) == 0 {
+		return nil, errors.New("SHA256SUMS is empty")
+	}
+	return shasums, nil
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShasums(t *testing.T) {
+	sum := sha256.Sum256([]byte("archive"))
+	checksum := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name    string
+		shasums string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "text mode",
+			shasums: fmt.Sprintf("%s  terraform-provider-null_1.0.0_linux_amd64.zip\n", checksum),
+			want:    map[string]string{"terraform-provider-null_1.0.0_linux_amd64.zip": checksum},
+		},
+		{
+			name:    "binary mode",
+			shasums: fmt.Sprintf("%s *terraform-provider-null_1.0.0_linux_amd64.zip\n\n", checksum),
+			want:    map[string]string{"terraform-provider-null_1.0.0_linux_amd64.zip": checksum},
+		},
+		{
+			name:    "malformed checksum",
+			shasums: "abc  terraform-provider-null_1.0.0_linux_amd64.zip\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing filename",
+			shasums: checksum,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			shasums: "\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseShasums([]byte(tt.shasums))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVersion_NewPlatform(t *testing.T) {
+	archive := []byte("archive")
+	sum := sha256.Sum256(archive)
+	checksum := hex.EncodeToString(sum[:])
+	version := &Version{
+		Name:    "null",
+		Version: "1.0.0",
+		Shasums: []byte(fmt.Sprintf("%s  terraform-provider-null_1.0.0_linux_amd64.zip\n", checksum)),
+	}
+
+	t.Run("matching checksum", func(t *testing.T) {
+		got, err := version.newPlatform("linux", "amd64", archive)
+		require.NoError(t, err)
+		assert.Equal(t, Platform{
+			OS:       "linux",
+			Arch:     "amd64",
+			Filename: "terraform-provider-null_1.0.0_linux_amd64.zip",
+			Shasum:   checksum,
+		}, got)
+	})
+
+	t.Run("mismatching checksum", func(t *testing.T) {
+		_, err := version.newPlatform("linux", "amd64", []byte("tampered"))
+		assert.Error(t, err)
+	})
+
+	t.Run("platform missing from SHA256SUMS", func(t *testing.T) {
+		_, err := version.newPlatform("darwin", "arm64", archive)
+		assert.Error(t, err)
+	})
+}
+
+func TestToProviderVersions(t *testing.T) {
+	got := toProviderVersions([]*Version{
+		{
+			Version:   "1.0.0",
+			Protocols: DefaultProtocols,
+			Platforms: []Platform{{OS: "linux", Arch: "amd64"}, {OS: "darwin", Arch: "arm64"}},
+		},
+		// no archives have been uploaded for this version yet
+		{Version: "1.1.0", Protocols: DefaultProtocols},
+	})
+
+	assert.Equal(t, &ProviderVersions{
+		Versions: []ProviderVersionsVersions{
+			{
+				Version:   "1.0.0",
+				Protocols: DefaultProtocols,
+				Platforms: []ProviderPlatform{{Os: "linux", Arch: "amd64"}, {Os: "darwin", Arch: "arm64"}},
+			},
+		},
+	}, got)
+}
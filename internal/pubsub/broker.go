@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/tofutf/tofutf/internal/sql"
 )
@@ -22,45 +23,71 @@ var ErrSubscriptionTerminated = errors.New("broker terminated the subscription")
 type Broker[T any] struct {
 	logger *slog.Logger
 
-	subs   map[chan Event[T]]struct{} // subscriptions
-	mu     sync.Mutex                 // sync access to map
+	subs   map[chan Event[T]]subscription // subscriptions
+	mu     sync.Mutex                     // sync access to map
 	getter GetterFunc[T]
 	table  string
 }
 
+// subscription is the broker's record of a subscription.
+type subscription struct {
+	done         chan struct{} // closed upon unsubscribing
+	subscribedAt time.Time
+}
+
+// Stats is a snapshot of a broker's subscriptions, for diagnostic purposes.
+type Stats struct {
+	// Table is the database table whose events the broker forwards.
+	Table string
+	// Subscribers is the number of current subscriptions.
+	Subscribers int
+	// OldestSubscribedAt is when the oldest current subscription was made;
+	// nil if there are no subscriptions.
+	OldestSubscribedAt *time.Time
+}
+
 // GetterFunc retrieves the type T using its unique id.
 type GetterFunc[T any] func(ctx context.Context, id string, action sql.Action) (T, error)
 
 // databaseListener is the upstream database events listener
 type databaseListener interface {
 	RegisterFunc(table string, ff sql.ForwardFunc)
+	RegisterReconnectFunc(fn sql.ReconnectFunc)
 }
 
 func NewBroker[T any](logger *slog.Logger, listener databaseListener, table string, getter GetterFunc[T]) *Broker[T] {
 	b := &Broker[T]{
 		logger: logger.With("component", "broker"),
-		subs:   make(map[chan Event[T]]struct{}),
+		subs:   make(map[chan Event[T]]subscription),
 		getter: getter,
 		table:  table,
 	}
 	listener.RegisterFunc(table, b.forward)
+	listener.RegisterReconnectFunc(b.resync)
 	return b
 }
 
 // Subscribe subscribes the caller to a stream of events. The caller can close
 // the subscription by either canceling the context or calling the returned
-// unsubscribe function.
+// unsubscribe function. Either way the subscription is removed regardless of
+// whether the caller drains the stream.
 func (b *Broker[T]) Subscribe(ctx context.Context) (<-chan Event[T], func()) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
 	sub := make(chan Event[T], subBufferSize)
-	b.subs[sub] = struct{}{}
+	done := make(chan struct{})
+	b.subs[sub] = subscription{done: done, subscribedAt: time.Now()}
+	subscribers.WithLabelValues(b.table).Inc()
 
-	// when the context is canceled remove the subscriber
+	// when the context is canceled remove the subscriber, and stop waiting
+	// once the subscriber is removed by other means.
 	go func() {
-		<-ctx.Done()
-		b.unsubscribe(sub)
+		select {
+		case <-ctx.Done():
+			b.unsubscribe(sub)
+		case <-done:
+		}
 	}()
 
 	return sub, func() { b.unsubscribe(sub) }
@@ -70,12 +97,51 @@ func (b *Broker[T]) unsubscribe(sub chan Event[T]) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if _, ok := b.subs[sub]; !ok {
+	s, ok := b.subs[sub]
+	if !ok {
 		// already unsubscribed
 		return
 	}
-	close(sub)
 	delete(b.subs, sub)
+	subscribers.WithLabelValues(b.table).Dec()
+	close(s.done)
+	close(sub)
+}
+
+// resync terminates all subscriptions after the listener has reconnected,
+// because subscribers may have missed events whilst it was disconnected. Upon
+// their subscription being terminated, subscribers are expected to reload
+// their state and re-subscribe.
+func (b *Broker[T]) resync(ctx context.Context) {
+	b.mu.Lock()
+	subs := make([]chan Event[T], 0, len(b.subs))
+	for sub := range b.subs {
+		subs = append(subs, sub)
+	}
+	b.mu.Unlock()
+
+	if len(subs) > 0 {
+		b.logger.Warn("terminating subscriptions to resync after reconnect", "table", b.table, "subscribers", len(subs))
+	}
+	for _, sub := range subs {
+		b.unsubscribe(sub)
+	}
+}
+
+// Stats reports the number of current subscriptions and when the oldest was
+// made.
+func (b *Broker[T]) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := Stats{Table: b.table, Subscribers: len(b.subs)}
+	for _, s := range b.subs {
+		if stats.OldestSubscribedAt == nil || s.subscribedAt.Before(*stats.OldestSubscribedAt) {
+			subscribedAt := s.subscribedAt
+			stats.OldestSubscribedAt = &subscribedAt
+		}
+	}
+	return stats
 }
 
 // forward retrieves the type T uniquely identified by id and forwards it onto
@@ -5,9 +5,12 @@ import (
 	"log/slog"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/xslog"
+	"go.uber.org/goleak"
 )
 
 type foo struct {
@@ -42,6 +45,43 @@ func TestBroker_UnsubscribeViaContext(t *testing.T) {
 	assert.Equal(t, 0, len(broker.subs))
 }
 
+func TestBroker_Stats(t *testing.T) {
+	ctx := context.Background()
+	broker := NewBroker[*foo](slog.New(&xslog.NoopHandler{}), &fakeListener{}, "bars", nil)
+
+	stats := broker.Stats()
+	assert.Equal(t, "bars", stats.Table)
+	assert.Equal(t, 0, stats.Subscribers)
+	assert.Nil(t, stats.OldestSubscribedAt)
+
+	_, unsub1 := broker.Subscribe(ctx)
+	oldest := broker.Stats().OldestSubscribedAt
+	require.NotNil(t, oldest)
+
+	_, unsub2 := broker.Subscribe(ctx)
+	stats = broker.Stats()
+	assert.Equal(t, 2, stats.Subscribers)
+	assert.Equal(t, oldest, stats.OldestSubscribedAt)
+	assert.Equal(t, 2.0, testutil.ToFloat64(subscribers.WithLabelValues("bars")))
+
+	// unsubscribing the oldest makes the remaining subscription the oldest
+	unsub1()
+	stats = broker.Stats()
+	assert.Equal(t, 1, stats.Subscribers)
+	require.NotNil(t, stats.OldestSubscribedAt)
+	assert.False(t, stats.OldestSubscribedAt.Before(*oldest))
+
+	// unsubscribing again has no effect
+	unsub1()
+	assert.Equal(t, 1, broker.Stats().Subscribers)
+
+	unsub2()
+	stats = broker.Stats()
+	assert.Equal(t, 0, stats.Subscribers)
+	assert.Nil(t, stats.OldestSubscribedAt)
+	assert.Equal(t, 0.0, testutil.ToFloat64(subscribers.WithLabelValues("bars")))
+}
+
 func TestBroker_forward(t *testing.T) {
 	ctx := context.Background()
 	broker := NewBroker[*foo](slog.New(&xslog.NoopHandler{}), &fakeListener{}, "foos", fooGetter)
@@ -71,3 +111,47 @@ func TestBroker_UnsubscribeFullSubscriber(t *testing.T) {
 	}
 	assert.Equal(t, 0, len(broker.subs))
 }
+
+func TestBroker_ResyncOnReconnect(t *testing.T) {
+	ctx := context.Background()
+	listener := &fakeListener{}
+	broker := NewBroker[*foo](slog.New(&xslog.NoopHandler{}), listener, "foos", fooGetter)
+
+	sub1, _ := broker.Subscribe(ctx)
+	sub2, _ := broker.Subscribe(ctx)
+	require.NotNil(t, listener.reconnect)
+
+	// upon the listener reconnecting all subscriptions are terminated
+	listener.reconnect(ctx)
+	_, open := <-sub1
+	assert.False(t, open)
+	_, open = <-sub2
+	assert.False(t, open)
+	assert.Equal(t, 0, len(broker.subs))
+
+	// subscribers re-subscribe and receive events once more
+	sub3, unsub := broker.Subscribe(ctx)
+	defer unsub()
+	broker.forward(ctx, "bar", sql.InsertAction)
+	assert.Equal(t, Event[*foo]{Type: CreatedEvent, Payload: &foo{id: "bar"}}, <-sub3)
+}
+
+func TestBroker_NoGoroutineLeak(t *testing.T) {
+	t.Run("unsubscribe without canceling context", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+		broker := NewBroker[*foo](slog.New(&xslog.NoopHandler{}), &fakeListener{}, "foos", nil)
+
+		_, unsub := broker.Subscribe(context.Background())
+		unsub()
+	})
+
+	t.Run("cancel context without draining subscription", func(t *testing.T) {
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+		ctx, cancel := context.WithCancel(context.Background())
+		broker := NewBroker[*foo](slog.New(&xslog.NoopHandler{}), &fakeListener{}, "foos", fooGetter)
+
+		broker.Subscribe(ctx)
+		broker.forward(ctx, "bar", sql.InsertAction)
+		cancel()
+	})
+}
@@ -3,12 +3,12 @@ package pubsub
 import "github.com/prometheus/client_golang/prometheus"
 
 func init() {
-	prometheus.MustRegister(totalSubscribers)
+	prometheus.MustRegister(subscribers)
 }
 
-var totalSubscribers = prometheus.NewGauge(prometheus.GaugeOpts{
+var subscribers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Namespace: "otf",
 	Subsystem: "pub_sub",
 	Name:      "total_subscribers",
-	Help:      "Total number of subscribers.",
-})
+	Help:      "Total number of subscribers, by the table whose events are subscribed to.",
+}, []string{"table"})
@@ -2,7 +2,13 @@ package pubsub
 
 import "github.com/tofutf/tofutf/internal/sql"
 
-type fakeListener struct{}
+type fakeListener struct {
+	reconnect sql.ReconnectFunc
+}
 
 func (f *fakeListener) RegisterFunc(table string, ff sql.ForwardFunc) {
 }
+
+func (f *fakeListener) RegisterReconnectFunc(fn sql.ReconnectFunc) {
+	f.reconnect = fn
+}
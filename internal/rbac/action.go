@@ -80,6 +80,8 @@ const (
 	DeleteRunAction
 	CancelRunAction
 	ForceCancelRunAction
+	UpdateRunLabelsAction
+	CreateRunCommentAction
 	EnqueuePlanAction
 	PutChunkAction
 	TailLogsAction
@@ -156,4 +158,72 @@ const (
 	UpdateGPGKeyAction
 	GetGPGKeyAction
 	DeleteGPGKeyAction
+
+	UpdateMaintenanceModeAction
+
+	ListWebhookDeliveriesAction
+	RedeliverWebhookDeliveryAction
+
+	GetAllocatorStatusAction
+
+	ListQueueSLABreachesAction
+
+	RedownloadTerraformAction
+
+	UpdateTerraformVersionPolicyAction
+
+	UpdateUserAction
+
+	GetSCIMTokenAction
+	CreateSCIMTokenAction
+	DeleteSCIMTokenAction
+
+	CreateModuleTemplateAction
+	UpdateModuleTemplateAction
+	ListModuleTemplatesAction
+	GetModuleTemplateAction
+	DeleteModuleTemplateAction
+
+	OverrideApplyWindowAction
+
+	GetEventSinksAction
+
+	UploadRunArtifactAction
+
+	ListScalingDecisionsAction
+
+	GetUpgradeStatusAction
+
+	PauseWorkspaceAction
+
+	ReconcileOrphanedJobsAction
+
+	CreateModuleVersionPolicyAction
+	UpdateModuleVersionPolicyAction
+	ListModuleVersionPoliciesAction
+	GetModuleVersionPolicyAction
+	DeleteModuleVersionPolicyAction
+
+	UploadModuleManifestAction
+
+	RequestAgentDiagnosticsAction
+	GetAgentDiagnosticsAction
+
+	GetSubscriptionStatsAction
+
+	ListAuthLockoutsAction
+	ClearAuthLockoutAction
+
+	GetJobCountsAction
+
+	ResolveVariableSecretsAction
+
+	CreateProviderVersionAction
+	GetProviderVersionAction
+
+	ShareModuleAction
+
+	CreateHighPriorityRunAction
+
+	ListMaintenanceModeChangesAction
 )
@@ -69,70 +69,118 @@ func _() {
 	_ = x[DeleteRunAction-58]
 	_ = x[CancelRunAction-59]
 	_ = x[ForceCancelRunAction-60]
-	_ = x[EnqueuePlanAction-61]
-	_ = x[PutChunkAction-62]
-	_ = x[TailLogsAction-63]
-	_ = x[GetPlanFileAction-64]
-	_ = x[UploadPlanFileAction-65]
-	_ = x[GetLockFileAction-66]
-	_ = x[UploadLockFileAction-67]
-	_ = x[ListWorkspacesAction-68]
-	_ = x[GetWorkspaceAction-69]
-	_ = x[CreateWorkspaceAction-70]
-	_ = x[DeleteWorkspaceAction-71]
-	_ = x[SetWorkspacePermissionAction-72]
-	_ = x[UnsetWorkspacePermissionAction-73]
-	_ = x[UpdateWorkspaceAction-74]
-	_ = x[ListTagsAction-75]
-	_ = x[DeleteTagsAction-76]
-	_ = x[TagWorkspacesAction-77]
-	_ = x[AddTagsAction-78]
-	_ = x[RemoveTagsAction-79]
-	_ = x[ListWorkspaceTags-80]
-	_ = x[LockWorkspaceAction-81]
-	_ = x[UnlockWorkspaceAction-82]
-	_ = x[ForceUnlockWorkspaceAction-83]
-	_ = x[CreateStateVersionAction-84]
-	_ = x[ListStateVersionsAction-85]
-	_ = x[GetStateVersionAction-86]
-	_ = x[DeleteStateVersionAction-87]
-	_ = x[RollbackStateVersionAction-88]
-	_ = x[UploadStateAction-89]
-	_ = x[DownloadStateAction-90]
-	_ = x[GetStateVersionOutputAction-91]
-	_ = x[CreateConfigurationVersionAction-92]
-	_ = x[ListConfigurationVersionsAction-93]
-	_ = x[GetConfigurationVersionAction-94]
-	_ = x[DownloadConfigurationVersionAction-95]
-	_ = x[DeleteConfigurationVersionAction-96]
-	_ = x[CreateUserAction-97]
-	_ = x[ListUsersAction-98]
-	_ = x[GetUserAction-99]
-	_ = x[DeleteUserAction-100]
-	_ = x[CreateTeamAction-101]
-	_ = x[UpdateTeamAction-102]
-	_ = x[GetTeamAction-103]
-	_ = x[ListTeamsAction-104]
-	_ = x[DeleteTeamAction-105]
-	_ = x[AddTeamMembershipAction-106]
-	_ = x[RemoveTeamMembershipAction-107]
-	_ = x[CreateNotificationConfigurationAction-108]
-	_ = x[UpdateNotificationConfigurationAction-109]
-	_ = x[ListNotificationConfigurationsAction-110]
-	_ = x[GetNotificationConfigurationAction-111]
-	_ = x[DeleteNotificationConfigurationAction-112]
-	_ = x[CreateGithubAppAction-113]
-	_ = x[UpdateGithubAppAction-114]
-	_ = x[GetGithubAppAction-115]
-	_ = x[ListGithubAppsAction-116]
-	_ = x[DeleteGithubAppAction-117]
-	_ = x[CreateGithubAppInstallAction-118]
-	_ = x[DeleteGithubAppInstallAction-119]
+	_ = x[UpdateRunLabelsAction-61]
+	_ = x[CreateRunCommentAction-62]
+	_ = x[EnqueuePlanAction-63]
+	_ = x[PutChunkAction-64]
+	_ = x[TailLogsAction-65]
+	_ = x[GetPlanFileAction-66]
+	_ = x[UploadPlanFileAction-67]
+	_ = x[GetLockFileAction-68]
+	_ = x[UploadLockFileAction-69]
+	_ = x[ListWorkspacesAction-70]
+	_ = x[GetWorkspaceAction-71]
+	_ = x[CreateWorkspaceAction-72]
+	_ = x[DeleteWorkspaceAction-73]
+	_ = x[SetWorkspacePermissionAction-74]
+	_ = x[UnsetWorkspacePermissionAction-75]
+	_ = x[UpdateWorkspaceAction-76]
+	_ = x[ListTagsAction-77]
+	_ = x[DeleteTagsAction-78]
+	_ = x[TagWorkspacesAction-79]
+	_ = x[AddTagsAction-80]
+	_ = x[RemoveTagsAction-81]
+	_ = x[ListWorkspaceTags-82]
+	_ = x[LockWorkspaceAction-83]
+	_ = x[UnlockWorkspaceAction-84]
+	_ = x[ForceUnlockWorkspaceAction-85]
+	_ = x[CreateStateVersionAction-86]
+	_ = x[ListStateVersionsAction-87]
+	_ = x[GetStateVersionAction-88]
+	_ = x[DeleteStateVersionAction-89]
+	_ = x[RollbackStateVersionAction-90]
+	_ = x[UploadStateAction-91]
+	_ = x[DownloadStateAction-92]
+	_ = x[GetStateVersionOutputAction-93]
+	_ = x[CreateConfigurationVersionAction-94]
+	_ = x[ListConfigurationVersionsAction-95]
+	_ = x[GetConfigurationVersionAction-96]
+	_ = x[DownloadConfigurationVersionAction-97]
+	_ = x[DeleteConfigurationVersionAction-98]
+	_ = x[CreateUserAction-99]
+	_ = x[ListUsersAction-100]
+	_ = x[GetUserAction-101]
+	_ = x[DeleteUserAction-102]
+	_ = x[CreateTeamAction-103]
+	_ = x[UpdateTeamAction-104]
+	_ = x[GetTeamAction-105]
+	_ = x[ListTeamsAction-106]
+	_ = x[DeleteTeamAction-107]
+	_ = x[AddTeamMembershipAction-108]
+	_ = x[RemoveTeamMembershipAction-109]
+	_ = x[CreateNotificationConfigurationAction-110]
+	_ = x[UpdateNotificationConfigurationAction-111]
+	_ = x[ListNotificationConfigurationsAction-112]
+	_ = x[GetNotificationConfigurationAction-113]
+	_ = x[DeleteNotificationConfigurationAction-114]
+	_ = x[CreateGithubAppAction-115]
+	_ = x[UpdateGithubAppAction-116]
+	_ = x[GetGithubAppAction-117]
+	_ = x[ListGithubAppsAction-118]
+	_ = x[DeleteGithubAppAction-119]
+	_ = x[CreateGithubAppInstallAction-120]
+	_ = x[DeleteGithubAppInstallAction-121]
+	_ = x[CreateGPGKeyAction-122]
+	_ = x[ListGPGKeyAction-123]
+	_ = x[UpdateGPGKeyAction-124]
+	_ = x[GetGPGKeyAction-125]
+	_ = x[DeleteGPGKeyAction-126]
+	_ = x[UpdateMaintenanceModeAction-127]
+	_ = x[ListWebhookDeliveriesAction-128]
+	_ = x[RedeliverWebhookDeliveryAction-129]
+	_ = x[GetAllocatorStatusAction-130]
+	_ = x[ListQueueSLABreachesAction-131]
+	_ = x[RedownloadTerraformAction-132]
+	_ = x[UpdateTerraformVersionPolicyAction-133]
+	_ = x[UpdateUserAction-134]
+	_ = x[GetSCIMTokenAction-135]
+	_ = x[CreateSCIMTokenAction-136]
+	_ = x[DeleteSCIMTokenAction-137]
+	_ = x[CreateModuleTemplateAction-138]
+	_ = x[UpdateModuleTemplateAction-139]
+	_ = x[ListModuleTemplatesAction-140]
+	_ = x[GetModuleTemplateAction-141]
+	_ = x[DeleteModuleTemplateAction-142]
+	_ = x[OverrideApplyWindowAction-143]
+	_ = x[GetEventSinksAction-144]
+	_ = x[UploadRunArtifactAction-145]
+	_ = x[ListScalingDecisionsAction-146]
+	_ = x[GetUpgradeStatusAction-147]
+	_ = x[PauseWorkspaceAction-148]
+	_ = x[ReconcileOrphanedJobsAction-149]
+	_ = x[CreateModuleVersionPolicyAction-150]
+	_ = x[UpdateModuleVersionPolicyAction-151]
+	_ = x[ListModuleVersionPoliciesAction-152]
+	_ = x[GetModuleVersionPolicyAction-153]
+	_ = x[DeleteModuleVersionPolicyAction-154]
+	_ = x[UploadModuleManifestAction-155]
+	_ = x[RequestAgentDiagnosticsAction-156]
+	_ = x[GetAgentDiagnosticsAction-157]
+	_ = x[GetSubscriptionStatsAction-158]
+	_ = x[ListAuthLockoutsAction-159]
+	_ = x[ClearAuthLockoutAction-160]
+	_ = x[GetJobCountsAction-161]
+	_ = x[ResolveVariableSecretsAction-162]
+	_ = x[CreateProviderVersionAction-163]
+	_ = x[GetProviderVersionAction-164]
+	_ = x[ShareModuleAction-165]
+	_ = x[CreateHighPriorityRunAction-166]
+	_ = x[ListMaintenanceModeChangesAction-167]
 }
 
-const _Action_name = "WatchActionCreateOrganizationActionUpdateOrganizationActionGetOrganizationActionListOrganizationsActionGetEntitlementsActionDeleteOrganizationActionCreateVCSProviderActionGetVCSProviderActionListVCSProvidersActionDeleteVCSProviderActionCreateAgentPoolActionUpdateAgentPoolActionListAgentPoolsActionGetAgentPoolActionDeleteAgentPoolActionCreateAgentTokenActionListAgentTokensActionGetAgentTokenActionDeleteAgentTokenActionListAgentsActionWatchAgentsActionCreateOrganizationTokenActionDeleteOrganizationTokenActionCreateRunTokenActionCreateTeamTokenActionGetTeamTokenActionDeleteTeamTokenActionCreateModuleActionCreateModuleVersionActionUpdateModuleActionListModulesActionGetModuleActionDeleteModuleActionDeleteModuleVersionActionCreateWorkspaceVariableActionUpdateWorkspaceVariableActionListWorkspaceVariablesActionGetWorkspaceVariableActionDeleteWorkspaceVariableActionCreateVariableSetActionUpdateVariableSetActionListVariableSetsActionGetVariableSetActionDeleteVariableSetActionCreateVariableSetVariableActionUpdateVariableSetVariableActionGetVariableSetVariableActionDeleteVariableSetVariableActionAddVariableToSetActionRemoveVariableFromSetActionApplyVariableSetToWorkspacesActionDeleteVariableSetFromWorkspacesActionGetRunActionListRunsActionApplyRunActionCreateRunActionDiscardRunActionDeleteRunActionCancelRunActionForceCancelRunActionEnqueuePlanActionPutChunkActionTailLogsActionGetPlanFileActionUploadPlanFileActionGetLockFileActionUploadLockFileActionListWorkspacesActionGetWorkspaceActionCreateWorkspaceActionDeleteWorkspaceActionSetWorkspacePermissionActionUnsetWorkspacePermissionActionUpdateWorkspaceActionListTagsActionDeleteTagsActionTagWorkspacesActionAddTagsActionRemoveTagsActionListWorkspaceTagsLockWorkspaceActionUnlockWorkspaceActionForceUnlockWorkspaceActionCreateStateVersionActionListStateVersionsActionGetStateVersionActionDeleteStateVersionActionRollbackStateVersionActionUploadStateActionDownloadStateActionGetStateVersionOutputActionCreateConfigurationVersionActionListConfigurationVersionsActionGetConfigurationVersionActionDownloadConfigurationVersionActionDeleteConfigurationVersionActionCreateUserActionListUsersActionGetUserActionDeleteUserActionCreateTeamActionUpdateTeamActionGetTeamActionListTeamsActionDeleteTeamActionAddTeamMembershipActionRemoveTeamMembershipActionCreateNotificationConfigurationActionUpdateNotificationConfigurationActionListNotificationConfigurationsActionGetNotificationConfigurationActionDeleteNotificationConfigurationActionCreateGithubAppActionUpdateGithubAppActionGetGithubAppActionListGithubAppsActionDeleteGithubAppActionCreateGithubAppInstallActionDeleteGithubAppInstallAction"
+const _Action_name = "WatchActionCreateOrganizationActionUpdateOrganizationActionGetOrganizationActionListOrganizationsActionGetEntitlementsActionDeleteOrganizationActionCreateVCSProviderActionGetVCSProviderActionListVCSProvidersActionDeleteVCSProviderActionCreateAgentPoolActionUpdateAgentPoolActionListAgentPoolsActionGetAgentPoolActionDeleteAgentPoolActionCreateAgentTokenActionListAgentTokensActionGetAgentTokenActionDeleteAgentTokenActionListAgentsActionWatchAgentsActionCreateOrganizationTokenActionDeleteOrganizationTokenActionCreateRunTokenActionCreateTeamTokenActionGetTeamTokenActionDeleteTeamTokenActionCreateModuleActionCreateModuleVersionActionUpdateModuleActionListModulesActionGetModuleActionDeleteModuleActionDeleteModuleVersionActionCreateWorkspaceVariableActionUpdateWorkspaceVariableActionListWorkspaceVariablesActionGetWorkspaceVariableActionDeleteWorkspaceVariableActionCreateVariableSetActionUpdateVariableSetActionListVariableSetsActionGetVariableSetActionDeleteVariableSetActionCreateVariableSetVariableActionUpdateVariableSetVariableActionGetVariableSetVariableActionDeleteVariableSetVariableActionAddVariableToSetActionRemoveVariableFromSetActionApplyVariableSetToWorkspacesActionDeleteVariableSetFromWorkspacesActionGetRunActionListRunsActionApplyRunActionCreateRunActionDiscardRunActionDeleteRunActionCancelRunActionForceCancelRunActionUpdateRunLabelsActionCreateRunCommentActionEnqueuePlanActionPutChunkActionTailLogsActionGetPlanFileActionUploadPlanFileActionGetLockFileActionUploadLockFileActionListWorkspacesActionGetWorkspaceActionCreateWorkspaceActionDeleteWorkspaceActionSetWorkspacePermissionActionUnsetWorkspacePermissionActionUpdateWorkspaceActionListTagsActionDeleteTagsActionTagWorkspacesActionAddTagsActionRemoveTagsActionListWorkspaceTagsLockWorkspaceActionUnlockWorkspaceActionForceUnlockWorkspaceActionCreateStateVersionActionListStateVersionsActionGetStateVersionActionDeleteStateVersionActionRollbackStateVersionActionUploadStateActionDownloadStateActionGetStateVersionOutputActionCreateConfigurationVersionActionListConfigurationVersionsActionGetConfigurationVersionActionDownloadConfigurationVersionActionDeleteConfigurationVersionActionCreateUserActionListUsersActionGetUserActionDeleteUserActionCreateTeamActionUpdateTeamActionGetTeamActionListTeamsActionDeleteTeamActionAddTeamMembershipActionRemoveTeamMembershipActionCreateNotificationConfigurationActionUpdateNotificationConfigurationActionListNotificationConfigurationsActionGetNotificationConfigurationActionDeleteNotificationConfigurationActionCreateGithubAppActionUpdateGithubAppActionGetGithubAppActionListGithubAppsActionDeleteGithubAppActionCreateGithubAppInstallActionDeleteGithubAppInstallActionCreateGPGKeyActionListGPGKeyActionUpdateGPGKeyActionGetGPGKeyActionDeleteGPGKeyActionUpdateMaintenanceModeActionListWebhookDeliveriesActionRedeliverWebhookDeliveryActionGetAllocatorStatusActionListQueueSLABreachesActionRedownloadTerraformActionUpdateTerraformVersionPolicyActionUpdateUserActionGetSCIMTokenActionCreateSCIMTokenActionDeleteSCIMTokenActionCreateModuleTemplateActionUpdateModuleTemplateActionListModuleTemplatesActionGetModuleTemplateActionDeleteModuleTemplateActionOverrideApplyWindowActionGetEventSinksActionUploadRunArtifactActionListScalingDecisionsActionGetUpgradeStatusActionPauseWorkspaceActionReconcileOrphanedJobsActionCreateModuleVersionPolicyActionUpdateModuleVersionPolicyActionListModuleVersionPoliciesActionGetModuleVersionPolicyActionDeleteModuleVersionPolicyActionUploadModuleManifestActionRequestAgentDiagnosticsActionGetAgentDiagnosticsActionGetSubscriptionStatsActionListAuthLockoutsActionClearAuthLockoutActionGetJobCountsActionResolveVariableSecretsActionCreateProviderVersionActionGetProviderVersionActionShareModuleActionCreateHighPriorityRunActionListMaintenanceModeChangesAction"
 
-var _Action_index = [...]uint16{0, 11, 35, 59, 80, 103, 124, 148, 171, 191, 213, 236, 257, 278, 298, 316, 337, 359, 380, 399, 421, 437, 454, 483, 512, 532, 553, 571, 592, 610, 635, 653, 670, 685, 703, 728, 757, 786, 814, 840, 869, 892, 915, 937, 957, 980, 1011, 1042, 1070, 1101, 1123, 1150, 1184, 1221, 1233, 1247, 1261, 1276, 1292, 1307, 1322, 1342, 1359, 1373, 1387, 1404, 1424, 1441, 1461, 1481, 1499, 1520, 1541, 1569, 1599, 1620, 1634, 1650, 1669, 1682, 1698, 1715, 1734, 1755, 1781, 1805, 1828, 1849, 1873, 1899, 1916, 1935, 1962, 1994, 2025, 2054, 2088, 2120, 2136, 2151, 2164, 2180, 2196, 2212, 2225, 2240, 2256, 2279, 2305, 2342, 2379, 2415, 2449, 2486, 2507, 2528, 2546, 2566, 2587, 2615, 2643}
+var _Action_index = [...]uint16{0, 11, 35, 59, 80, 103, 124, 148, 171, 191, 213, 236, 257, 278, 298, 316, 337, 359, 380, 399, 421, 437, 454, 483, 512, 532, 553, 571, 592, 610, 635, 653, 670, 685, 703, 728, 757, 786, 814, 840, 869, 892, 915, 937, 957, 980, 1011, 1042, 1070, 1101, 1123, 1150, 1184, 1221, 1233, 1247, 1261, 1276, 1292, 1307, 1322, 1342, 1363, 1385, 1402, 1416, 1430, 1447, 1467, 1484, 1504, 1524, 1542, 1563, 1584, 1612, 1642, 1663, 1677, 1693, 1712, 1725, 1741, 1758, 1777, 1798, 1824, 1848, 1871, 1892, 1916, 1942, 1959, 1978, 2005, 2037, 2068, 2097, 2131, 2163, 2179, 2194, 2207, 2223, 2239, 2255, 2268, 2283, 2299, 2322, 2348, 2385, 2422, 2458, 2492, 2529, 2550, 2571, 2589, 2609, 2630, 2658, 2686, 2704, 2720, 2738, 2753, 2771, 2798, 2825, 2855, 2879, 2905, 2930, 2964, 2980, 2998, 3019, 3040, 3066, 3092, 3117, 3140, 3166, 3191, 3210, 3233, 3259, 3281, 3301, 3328, 3359, 3390, 3421, 3449, 3480, 3506, 3535, 3560, 3586, 3608, 3630, 3648, 3676, 3703, 3727, 3744, 3771, 3803}
 
 func (i Action) String() string {
 	if i < 0 || i >= Action(len(_Action_index)-1) {
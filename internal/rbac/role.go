@@ -9,21 +9,27 @@ var (
 	OrganizationMinPermissions = Role{
 		name: "minimum",
 		permissions: map[Action]bool{
-			GetOrganizationAction:  true,
-			GetEntitlementsAction:  true,
-			ListModulesAction:      true,
-			GetModuleAction:        true,
-			GetTeamAction:          true,
-			ListTeamsAction:        true,
-			GetUserAction:          true,
-			ListUsersAction:        true,
-			ListTagsAction:         true,
-			ListVCSProvidersAction: true,
-			GetVCSProviderAction:   true,
-			ListVariableSetsAction: true,
-			GetVariableSetAction:   true,
-			WatchAgentsAction:      true,
-			ListAgentsAction:       true,
+			GetOrganizationAction:           true,
+			GetEntitlementsAction:           true,
+			ListModulesAction:               true,
+			GetModuleAction:                 true,
+			ListModuleTemplatesAction:       true,
+			GetModuleTemplateAction:         true,
+			ListModuleVersionPoliciesAction: true,
+			GetModuleVersionPolicyAction:    true,
+			GetTeamAction:                   true,
+			ListTeamsAction:                 true,
+			GetUserAction:                   true,
+			ListUsersAction:                 true,
+			ListTagsAction:                  true,
+			ListVCSProvidersAction:          true,
+			GetVCSProviderAction:            true,
+			ListVariableSetsAction:          true,
+			GetVariableSetAction:            true,
+			WatchAgentsAction:               true,
+			ListAgentsAction:                true,
+			GetJobCountsAction:              true,
+			GetProviderVersionAction:        true,
 		},
 	}
 
@@ -48,6 +54,7 @@ var (
 			TailLogsAction:                       true,
 			ListNotificationConfigurationsAction: true,
 			GetNotificationConfigurationAction:   true,
+			CreateRunCommentAction:               true,
 		},
 	}
 
@@ -69,6 +76,7 @@ var (
 		permissions: map[Action]bool{
 			ApplyRunAction:                        true,
 			CancelRunAction:                       true,
+			UpdateRunLabelsAction:                 true,
 			LockWorkspaceAction:                   true,
 			UnlockWorkspaceAction:                 true,
 			CreateWorkspaceVariableAction:         true,
@@ -90,7 +98,11 @@ var (
 			UnsetWorkspacePermissionAction: true,
 			DeleteWorkspaceAction:          true,
 			ForceUnlockWorkspaceAction:     true,
+			ForceCancelRunAction:           true,
+			PauseWorkspaceAction:           true,
 			UpdateWorkspaceAction:          true,
+			OverrideApplyWindowAction:      true,
+			CreateHighPriorityRunAction:    true,
 		},
 		inherits: &WorkspaceWriteRole,
 	}
@@ -124,10 +136,15 @@ var (
 	RegistryManagerRole = Role{
 		name: "registry-manager",
 		permissions: map[Action]bool{
-			CreateModuleAction:        true,
-			CreateModuleVersionAction: true,
-			UpdateModuleAction:        true,
-			DeleteModuleAction:        true,
+			CreateModuleAction:          true,
+			CreateModuleVersionAction:   true,
+			UpdateModuleAction:          true,
+			DeleteModuleAction:          true,
+			CreateModuleTemplateAction:  true,
+			UpdateModuleTemplateAction:  true,
+			DeleteModuleTemplateAction:  true,
+			CreateProviderVersionAction: true,
+			ShareModuleAction:           true,
 		},
 	}
 )
@@ -151,6 +168,18 @@ func (r Role) IsAllowed(action Action) bool {
 	return false
 }
 
+// Actions returns the actions the role permits, including those it inherits,
+// in the order in which they are declared.
+func (r Role) Actions() []Action {
+	var actions []Action
+	for i := 0; i < len(_Action_index)-1; i++ {
+		if r.IsAllowed(Action(i)) {
+			actions = append(actions, Action(i))
+		}
+	}
+	return actions
+}
+
 func (r Role) String() string {
 	return r.name
 }
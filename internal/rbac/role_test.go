@@ -10,14 +10,21 @@ func TestRole_IsAllowed(t *testing.T) {
 	assert.True(t, WorkspacePlanRole.IsAllowed(CreateRunAction))
 	assert.True(t, WorkspacePlanRole.IsAllowed(ListRunsAction))
 	assert.True(t, WorkspacePlanRole.IsAllowed(TailLogsAction))
+	assert.False(t, WorkspacePlanRole.IsAllowed(UpdateRunLabelsAction))
+	assert.True(t, WorkspacePlanRole.IsAllowed(CreateRunCommentAction))
 
 	assert.True(t, WorkspaceWriteRole.IsAllowed(ApplyRunAction))
 	assert.True(t, WorkspaceWriteRole.IsAllowed(CancelRunAction))
 	assert.True(t, WorkspaceWriteRole.IsAllowed(CreateRunAction))
 	assert.True(t, WorkspaceWriteRole.IsAllowed(ListRunsAction))
 	assert.True(t, WorkspaceWriteRole.IsAllowed(TailLogsAction))
+	assert.True(t, WorkspaceWriteRole.IsAllowed(UpdateRunLabelsAction))
+	assert.False(t, WorkspaceWriteRole.IsAllowed(ForceCancelRunAction))
+	assert.False(t, WorkspaceWriteRole.IsAllowed(CreateHighPriorityRunAction))
 
 	assert.True(t, WorkspaceAdminRole.IsAllowed(SetWorkspacePermissionAction))
+	assert.True(t, WorkspaceAdminRole.IsAllowed(ForceCancelRunAction))
+	assert.True(t, WorkspaceAdminRole.IsAllowed(CreateHighPriorityRunAction))
 	assert.True(t, WorkspaceAdminRole.IsAllowed(ApplyRunAction))
 	assert.True(t, WorkspaceWriteRole.IsAllowed(CancelRunAction))
 	assert.True(t, WorkspaceAdminRole.IsAllowed(CreateRunAction))
@@ -0,0 +1,271 @@
+package rbac
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownScope is returned when a token scope is neither a coarse scope nor
+// the name of an action.
+var ErrUnknownScope = errors.New("unknown token scope")
+
+var (
+	// scopeMinimum permits the actions that every coarse scope requires, e.g.
+	// for terraform to check an organization's entitlements.
+	scopeMinimum = Role{
+		name: "minimum",
+		permissions: map[Action]bool{
+			GetOrganizationAction: true,
+			GetEntitlementsAction: true,
+		},
+	}
+
+	// RunsReadScope permits reading runs and their logs.
+	RunsReadScope = Role{
+		name: "runs:read",
+		permissions: map[Action]bool{
+			GetRunAction:                    true,
+			ListRunsAction:                  true,
+			TailLogsAction:                  true,
+			WatchAction:                     true,
+			GetPlanFileAction:               true,
+			GetWorkspaceAction:              true,
+			ListWorkspacesAction:            true,
+			GetConfigurationVersionAction:   true,
+			ListConfigurationVersionsAction: true,
+		},
+		inherits: &scopeMinimum,
+	}
+
+	// RunsWriteScope permits triggering, applying and canceling runs.
+	RunsWriteScope = Role{
+		name: "runs:write",
+		permissions: map[Action]bool{
+			CreateRunAction:                  true,
+			CreateHighPriorityRunAction:      true,
+			ApplyRunAction:                   true,
+			DiscardRunAction:                 true,
+			CancelRunAction:                  true,
+			UpdateRunLabelsAction:            true,
+			CreateRunCommentAction:           true,
+			CreateConfigurationVersionAction: true,
+		},
+		inherits: &RunsReadScope,
+	}
+
+	// StateReadScope permits reading state.
+	StateReadScope = Role{
+		name: "state:read",
+		permissions: map[Action]bool{
+			GetWorkspaceAction:          true,
+			ListWorkspacesAction:        true,
+			ListStateVersionsAction:     true,
+			GetStateVersionAction:       true,
+			DownloadStateAction:         true,
+			GetStateVersionOutputAction: true,
+		},
+		inherits: &scopeMinimum,
+	}
+
+	// StateWriteScope permits writing state.
+	StateWriteScope = Role{
+		name: "state:write",
+		permissions: map[Action]bool{
+			CreateStateVersionAction:   true,
+			UploadStateAction:          true,
+			RollbackStateVersionAction: true,
+			DeleteStateVersionAction:   true,
+			LockWorkspaceAction:        true,
+			UnlockWorkspaceAction:      true,
+		},
+		inherits: &StateReadScope,
+	}
+
+	// WorkspacesReadScope permits reading workspaces.
+	WorkspacesReadScope = Role{
+		name: "workspaces:read",
+		permissions: map[Action]bool{
+			GetWorkspaceAction:                   true,
+			ListWorkspacesAction:                 true,
+			ListWorkspaceTags:                    true,
+			ListTagsAction:                       true,
+			ListNotificationConfigurationsAction: true,
+			GetNotificationConfigurationAction:   true,
+		},
+		inherits: &scopeMinimum,
+	}
+
+	// WorkspacesWriteScope permits managing workspaces.
+	WorkspacesWriteScope = Role{
+		name: "workspaces:write",
+		permissions: map[Action]bool{
+			CreateWorkspaceAction:                 true,
+			UpdateWorkspaceAction:                 true,
+			DeleteWorkspaceAction:                 true,
+			LockWorkspaceAction:                   true,
+			UnlockWorkspaceAction:                 true,
+			ForceUnlockWorkspaceAction:            true,
+			PauseWorkspaceAction:                  true,
+			SetWorkspacePermissionAction:          true,
+			UnsetWorkspacePermissionAction:        true,
+			AddTagsAction:                         true,
+			RemoveTagsAction:                      true,
+			TagWorkspacesAction:                   true,
+			DeleteTagsAction:                      true,
+			CreateNotificationConfigurationAction: true,
+			UpdateNotificationConfigurationAction: true,
+			DeleteNotificationConfigurationAction: true,
+		},
+		inherits: &WorkspacesReadScope,
+	}
+
+	// VariablesReadScope permits reading workspace variables and variable
+	// sets.
+	VariablesReadScope = Role{
+		name: "variables:read",
+		permissions: map[Action]bool{
+			GetWorkspaceAction:           true,
+			ListWorkspaceVariablesAction: true,
+			GetWorkspaceVariableAction:   true,
+			ListVariableSetsAction:       true,
+			GetVariableSetAction:         true,
+			GetVariableSetVariableAction: true,
+		},
+		inherits: &scopeMinimum,
+	}
+
+	// VariablesWriteScope permits managing workspace variables and variable
+	// sets.
+	VariablesWriteScope = Role{
+		name: "variables:write",
+		permissions: map[Action]bool{
+			CreateWorkspaceVariableAction:         true,
+			UpdateWorkspaceVariableAction:         true,
+			DeleteWorkspaceVariableAction:         true,
+			CreateVariableSetAction:               true,
+			UpdateVariableSetAction:               true,
+			DeleteVariableSetAction:               true,
+			CreateVariableSetVariableAction:       true,
+			UpdateVariableSetVariableAction:       true,
+			DeleteVariableSetVariableAction:       true,
+			AddVariableToSetAction:                true,
+			RemoveVariableFromSetAction:           true,
+			ApplyVariableSetToWorkspacesAction:    true,
+			DeleteVariableSetFromWorkspacesAction: true,
+		},
+		inherits: &VariablesReadScope,
+	}
+
+	// ModulesReadScope permits reading the registry.
+	ModulesReadScope = Role{
+		name: "modules:read",
+		permissions: map[Action]bool{
+			ListModulesAction:               true,
+			GetModuleAction:                 true,
+			GetProviderVersionAction:        true,
+			ListModuleTemplatesAction:       true,
+			GetModuleTemplateAction:         true,
+			ListModuleVersionPoliciesAction: true,
+			GetModuleVersionPolicyAction:    true,
+		},
+		inherits: &scopeMinimum,
+	}
+
+	// ModulesWriteScope permits publishing to and managing the registry.
+	ModulesWriteScope = Role{
+		name: "modules:write",
+		permissions: map[Action]bool{
+			CreateModuleAction:          true,
+			CreateModuleVersionAction:   true,
+			UpdateModuleAction:          true,
+			DeleteModuleAction:          true,
+			DeleteModuleVersionAction:   true,
+			UploadModuleManifestAction:  true,
+			CreateProviderVersionAction: true,
+			CreateModuleTemplateAction:  true,
+			UpdateModuleTemplateAction:  true,
+			DeleteModuleTemplateAction:  true,
+			ShareModuleAction:           true,
+		},
+		inherits: &ModulesReadScope,
+	}
+
+	// CoarseScopes are the coarse scopes a token can be restricted to, each
+	// permitting a set of related actions.
+	CoarseScopes = []Role{
+		RunsReadScope,
+		RunsWriteScope,
+		StateReadScope,
+		StateWriteScope,
+		WorkspacesReadScope,
+		WorkspacesWriteScope,
+		VariablesReadScope,
+		VariablesWriteScope,
+		ModulesReadScope,
+		ModulesWriteScope,
+	}
+)
+
+// Scopes restricts the actions an API token permits to a subset of those
+// permitted to the token's subject. An action is permitted if any one of the
+// scopes permits it. Nil scopes are unrestricted.
+type Scopes []Role
+
+// NewScopes constructs scopes from their string representations, each either
+// a coarse scope, e.g. runs:write, or the name of an individual action, e.g.
+// GetRunAction. No scopes returns nil scopes.
+func NewScopes(scopes []string) (Scopes, error) {
+	if len(scopes) == 0 {
+		return nil, nil
+	}
+	to := make(Scopes, len(scopes))
+	for i, s := range scopes {
+		scope, err := ScopeFromString(s)
+		if err != nil {
+			return nil, err
+		}
+		to[i] = scope
+	}
+	return to, nil
+}
+
+// ScopeFromString returns the scope with the given string representation,
+// either a coarse scope, or the name of an individual action.
+func ScopeFromString(scope string) (Role, error) {
+	for _, coarse := range CoarseScopes {
+		if coarse.name == scope {
+			return coarse, nil
+		}
+	}
+	for i := 0; i < len(_Action_index)-1; i++ {
+		if action := Action(i); action.String() == scope {
+			return Role{name: scope, permissions: map[Action]bool{action: true}}, nil
+		}
+	}
+	return Role{}, fmt.Errorf("%w: %s", ErrUnknownScope, scope)
+}
+
+// IsAllowed determines whether the scopes permit the action.
+func (s Scopes) IsAllowed(action Action) bool {
+	if s == nil {
+		return true
+	}
+	for _, scope := range s {
+		if scope.IsAllowed(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// Strings returns the string representations of the scopes.
+func (s Scopes) Strings() []string {
+	if s == nil {
+		return nil
+	}
+	to := make([]string, len(s))
+	for i, scope := range s {
+		to[i] = scope.String()
+	}
+	return to
+}
@@ -0,0 +1,39 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewScopes(t *testing.T) {
+	t.Run("coarse scopes and actions", func(t *testing.T) {
+		scopes, err := NewScopes([]string{"runs:write", "GetStateVersionAction"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"runs:write", "GetStateVersionAction"}, scopes.Strings())
+
+		assert.True(t, scopes.IsAllowed(CreateRunAction))
+		assert.True(t, scopes.IsAllowed(GetRunAction))
+		assert.True(t, scopes.IsAllowed(GetStateVersionAction))
+		assert.False(t, scopes.IsAllowed(DownloadStateAction))
+		assert.False(t, scopes.IsAllowed(CreateWorkspaceAction))
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := NewScopes([]string{"runs:destroy"})
+		assert.ErrorIs(t, err, ErrUnknownScope)
+	})
+
+	t.Run("no scopes are unrestricted", func(t *testing.T) {
+		scopes, err := NewScopes(nil)
+		require.NoError(t, err)
+		assert.Nil(t, scopes)
+		assert.True(t, scopes.IsAllowed(DeleteOrganizationAction))
+	})
+}
+
+func TestRole_Actions(t *testing.T) {
+	assert.Equal(t, []Action{GetOrganizationAction, GetEntitlementsAction}, scopeMinimum.Actions())
+	assert.Contains(t, RunsWriteScope.Actions(), GetRunAction)
+}
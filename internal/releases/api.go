@@ -0,0 +1,94 @@
+package releases
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	otfapi "github.com/tofutf/tofutf/internal/api"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+type (
+	api struct {
+		*Service
+		*tfeapi.Responder
+	}
+
+	// TerraformVersion is a version of terraform that has been downloaded.
+	TerraformVersion struct {
+		Version string `jsonapi:"primary,terraform-versions"`
+		// SHA256 checksum of the verified archive from which the binary was
+		// extracted.
+		Checksum string `jsonapi:"attribute" json:"checksum"`
+	}
+
+	// TerraformDownload describes where to download a version of terraform
+	// for a platform.
+	TerraformDownload struct {
+		Version string `jsonapi:"primary,terraform-downloads"`
+		OS      string `jsonapi:"attribute" json:"os"`
+		Arch    string `jsonapi:"attribute" json:"arch"`
+		// URL of the zip archive containing the binary.
+		URL string `jsonapi:"attribute" json:"url"`
+		// ChecksumsURL is the URL of the SHA256 checksums of the version's
+		// archives.
+		ChecksumsURL string `jsonapi:"attribute" json:"checksums_url"`
+	}
+)
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
+
+	r.HandleFunc("/terraform-versions/{version}/redownload", a.redownload).Methods("POST")
+	r.HandleFunc("/terraform-versions/{version}/download-url", a.getDownloadURL).Methods("GET")
+}
+
+func (a *api) redownload(w http.ResponseWriter, r *http.Request) {
+	version, err := decode.Param("version", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	checksum, err := a.Redownload(r.Context(), version)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, &TerraformVersion{Version: version, Checksum: checksum}, http.StatusOK)
+}
+
+func (a *api) getDownloadURL(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Version string `schema:"version,required"`
+		OS      string `schema:"os,required"`
+		Arch    string `schema:"arch,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	}
+	urls, err := a.DownloadURLs(params.Version, params.OS, params.Arch)
+	if errors.Is(err, ErrInvalidPlatform) || errors.Is(err, internal.ErrInvalidTerraformVersion) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, &TerraformDownload{
+		Version:      params.Version,
+		OS:           params.OS,
+		Arch:         params.Arch,
+		URL:          urls.Archive,
+		ChecksumsURL: urls.Checksums,
+	}, http.StatusOK)
+}
@@ -0,0 +1,84 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	otfapi "github.com/tofutf/tofutf/internal/api"
+)
+
+type (
+	CLI struct {
+		cliService
+	}
+
+	cliService interface {
+		Redownload(ctx context.Context, version string) (string, error)
+		DownloadURLs(ctx context.Context, version, goos, goarch string) (DownloadURLs, error)
+	}
+)
+
+func NewCommand(client *otfapi.Client) *cobra.Command {
+	cli := &CLI{}
+	cmd := &cobra.Command{
+		Use:   "terraform",
+		Short: "Terraform version management",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmd.Parent().PersistentPreRunE(cmd.Parent(), args); err != nil {
+				return err
+			}
+			cli.cliService = &Client{Client: client}
+			return nil
+		},
+	}
+	cmd.AddCommand(cli.redownloadCommand())
+	cmd.AddCommand(cli.downloadURLCommand())
+
+	return cmd
+}
+
+func (a *CLI) redownloadCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:           "redownload [version]",
+		Short:         "Delete and download again a version of terraform on the server",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checksum, err := a.Redownload(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully redownloaded terraform %s (sha256: %s)\n", args[0], checksum)
+
+			return nil
+		},
+	}
+}
+
+func (a *CLI) downloadURLCommand() *cobra.Command {
+	var goos, goarch string
+	cmd := &cobra.Command{
+		Use:           "download-url [version]",
+		Short:         "Show the URLs from which a version of terraform is downloaded",
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			urls, err := a.DownloadURLs(cmd.Context(), args[0], goos, goarch)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Archive: %s\nChecksums: %s\n", urls.Archive, urls.Checksums)
+
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&goos, "os", runtime.GOOS, "Operating system of the binary")
+	cmd.Flags().StringVar(&goarch, "arch", runtime.GOARCH, "Architecture of the binary")
+	return cmd
+}
@@ -0,0 +1,52 @@
+package releases
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCLIService struct {
+	checksum string
+
+	goos, goarch string
+}
+
+func (f *fakeCLIService) Redownload(context.Context, string) (string, error) {
+	return f.checksum, nil
+}
+
+func (f *fakeCLIService) DownloadURLs(_ context.Context, version, goos, goarch string) (DownloadURLs, error) {
+	f.goos, f.goarch = goos, goarch
+	return DownloadURLs{
+		Archive:   "https://releases.hashicorp.com/terraform/" + version + "/terraform_" + version + "_" + goos + "_" + goarch + ".zip",
+		Checksums: "https://releases.hashicorp.com/terraform/" + version + "/terraform_" + version + "_SHA256SUMS",
+	}, nil
+}
+
+func TestRedownloadCommand(t *testing.T) {
+	cli := &CLI{cliService: &fakeCLIService{checksum: "abc123"}}
+	cmd := cli.redownloadCommand()
+	cmd.SetArgs([]string{"1.2.3"})
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "Successfully redownloaded terraform 1.2.3 (sha256: abc123)\n", got.String())
+}
+
+func TestDownloadURLCommand(t *testing.T) {
+	svc := &fakeCLIService{}
+	cli := &CLI{cliService: svc}
+	cmd := cli.downloadURLCommand()
+	cmd.SetArgs([]string{"1.2.3", "--os", "darwin", "--arch", "arm64"})
+	got := bytes.Buffer{}
+	cmd.SetOut(&got)
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "darwin", svc.goos)
+	assert.Equal(t, "arm64", svc.goarch)
+	want := "Archive: https://releases.hashicorp.com/terraform/1.2.3/terraform_1.2.3_darwin_arm64.zip\nChecksums: https://releases.hashicorp.com/terraform/1.2.3/terraform_1.2.3_SHA256SUMS\n"
+	assert.Equal(t, want, got.String())
+}
@@ -0,0 +1,46 @@
+package releases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	otfapi "github.com/tofutf/tofutf/internal/api"
+)
+
+type Client struct {
+	*otfapi.Client
+}
+
+// Redownload requests the server to redownload a version of terraform,
+// returning the verified checksum.
+func (c *Client) Redownload(ctx context.Context, version string) (string, error) {
+	u := fmt.Sprintf("terraform-versions/%s/redownload", url.PathEscape(version))
+	req, err := c.NewRequest("POST", u, nil)
+	if err != nil {
+		return "", err
+	}
+	var tv TerraformVersion
+	if err := c.Do(ctx, req, &tv); err != nil {
+		return "", err
+	}
+	return tv.Checksum, nil
+}
+
+// DownloadURLs retrieves the URLs from which the server would download a
+// version of terraform for a platform.
+func (c *Client) DownloadURLs(ctx context.Context, version, goos, goarch string) (DownloadURLs, error) {
+	u := fmt.Sprintf("terraform-versions/%s/download-url?%s", url.PathEscape(version), url.Values{
+		"os":   {goos},
+		"arch": {goarch},
+	}.Encode())
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return DownloadURLs{}, err
+	}
+	var td TerraformDownload
+	if err := c.Do(ctx, req, &td); err != nil {
+		return DownloadURLs{}, err
+	}
+	return DownloadURLs{Archive: td.URL, Checksums: td.ChecksumsURL}, nil
+}
@@ -2,6 +2,7 @@ package releases
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/tofutf/tofutf/internal"
@@ -58,3 +59,39 @@ func (db *db) getLatest(ctx context.Context) (string, time.Time, error) {
 
 	return latest.Version, latest.Checkpoint, nil
 }
+
+func (db *db) upsertVersionPolicy(ctx context.Context, policy *VersionPolicy) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertTerraformVersionPolicy(ctx, pggen.UpsertTerraformVersionPolicyParams{
+			MinVersion:     sql.String(policy.MinVersion),
+			MaxVersion:     sql.String(policy.MaxVersion),
+			DefaultVersion: sql.String(policy.DefaultVersion),
+			UpdatedAt:      sql.Timestamptz(policy.UpdatedAt),
+			UpdatedBy:      sql.String(policy.UpdatedBy),
+		})
+		return err
+	})
+}
+
+// getVersionPolicy retrieves the terraform version policy. If the policy has
+// never been configured then an empty policy, which permits any version, is
+// returned.
+func (db *db) getVersionPolicy(ctx context.Context) (*VersionPolicy, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*VersionPolicy, error) {
+		result, err := q.FindTerraformVersionPolicy(ctx)
+		if err != nil {
+			err = sql.Error(err)
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				return &VersionPolicy{}, nil
+			}
+			return nil, err
+		}
+		return &VersionPolicy{
+			MinVersion:     result.MinVersion.String,
+			MaxVersion:     result.MaxVersion.String,
+			DefaultVersion: result.DefaultVersion.String,
+			UpdatedAt:      result.UpdatedAt.Time.UTC(),
+			UpdatedBy:      result.UpdatedBy.String,
+		}, nil
+	})
+}
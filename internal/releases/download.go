@@ -2,37 +2,93 @@ package releases
 
 import (
 	"archive/zip"
+	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/natefinch/atomic"
 	"github.com/tofutf/tofutf/internal"
 )
 
+// ErrChecksumMismatch is returned when a downloaded archive does not match its
+// published checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
 // download represents a current download of a version of terraform
 type download struct {
 	// for outputting progress updates
 	io.Writer
 
+	product   Product
 	version   string
 	src, dest string
 	client    *http.Client
+
+	// URL of the checksums published for the version; if set the archive is
+	// verified against them.
+	checksums string
+	// path at which the archive is cached; if empty the archive is not
+	// cached.
+	cache string
+	// SHA256 checksum of the archive, set once verified.
+	checksum string
+
+	// for publishing download events; optional.
+	events *downloadBroker
+	// started is true once a started event has been published.
+	started bool
+	// size of archive, or -1 if unknown
+	total int64
+	// number of bytes of archive received
+	received int64
 }
 
-func (d *download) download(ctx context.Context) error {
+func (d *download) download(ctx context.Context) (err error) {
 	if internal.Exists(d.dest) {
 		return nil
 	}
+	defer func() {
+		if d.started {
+			d.publish(DownloadEvent{Type: DownloadCompleted, Received: d.received, Total: d.total, Err: err})
+		}
+	}()
 
-	zipfile, err := d.getZipfile(ctx)
-	if err != nil {
-		return fmt.Errorf("downloading zipfile from %s: %w", d.src, err)
+	zipfile := d.cached()
+	if zipfile == "" {
+		zipfile, err = d.getZipfile(ctx)
+		if err != nil {
+			return fmt.Errorf("downloading zipfile from %s: %w", d.src, err)
+		}
+		defer os.Remove(zipfile)
+	}
+
+	if d.checksums != "" {
+		if err := d.verify(ctx, zipfile); err != nil {
+			if zipfile == d.cache {
+				// don't reuse an archive that doesn't match its published
+				// checksum
+				d.evict()
+			}
+			return fmt.Errorf("verifying zipfile: %w", err)
+		}
+	}
+
+	if d.cache != "" && zipfile != d.cache {
+		if err := d.store(zipfile); err != nil {
+			// caching is only an optimisation, so carry on regardless
+			d.Write([]byte("warning: caching archive: " + err.Error() + "\n")) //nolint:errcheck
+		}
 	}
-	defer os.Remove(zipfile)
 
 	if err := os.MkdirAll(filepath.Dir(d.dest), 0o777); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
@@ -67,16 +123,148 @@ func (d *download) getZipfile(ctx context.Context) (string, error) {
 	}
 	defer tmp.Close()
 
-	d.Write([]byte("downloading terraform, version " + d.version + "\n")) //nolint:errcheck
+	d.Write([]byte("downloading " + d.product.Name + ", version " + d.version + "\n")) //nolint:errcheck
+
+	d.total = res.ContentLength
+	d.started = true
+	d.publish(DownloadEvent{Type: DownloadStarted, Total: d.total})
 
-	_, err = io.Copy(tmp, res.Body)
+	pw := &progressWriter{
+		Writer:  tmp,
+		event:   DownloadEvent{Type: DownloadProgress, Total: d.total},
+		publish: d.publish,
+		last:    time.Now(),
+	}
+	d.received, err = io.Copy(pw, res.Body)
 	if err != nil {
+		// remove partially downloaded archive
+		os.Remove(tmp.Name())
 		return "", fmt.Errorf("copying to disk: %w", err)
 	}
 
 	return tmp.Name(), nil
 }
 
+// publish a download event, populating its product and version.
+func (d *download) publish(event DownloadEvent) {
+	if d.events == nil {
+		return
+	}
+	event.Product = d.product.Name
+	event.Version = d.version
+	d.events.publish(event)
+}
+
+// verify the zipfile against the checksums published for the version.
+func (d *download) verify(ctx context.Context, zipfile string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", d.checksums, nil)
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	res, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("retrieving checksums: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return fmt.Errorf("retrieving checksums: received non-200 HTTP code: %d", res.StatusCode)
+	}
+
+	// find checksum for the zipfile, which is listed alongside those for
+	// other platforms, one per line, in the format: <checksum>  <filename>
+	var want string
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == path.Base(d.src) {
+			want = fields[0]
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading checksums: %w", err)
+	}
+	if want == "" {
+		return fmt.Errorf("checksum not found for %s", path.Base(d.src))
+	}
+
+	got, err := checksum(zipfile)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%w: want %s; got %s", ErrChecksumMismatch, want, got)
+	}
+	d.checksum = want
+	return nil
+}
+
+// cached returns the path of the cached copy of the archive, or an empty
+// string if there is no cached copy. A copy that no longer matches the
+// checksum recorded when it was cached is discarded.
+func (d *download) cached() string {
+	if d.cache == "" {
+		return ""
+	}
+	want, err := os.ReadFile(d.cache + ".sha256")
+	if err != nil {
+		return ""
+	}
+	if got, err := checksum(d.cache); err != nil || got != string(want) {
+		d.evict()
+		return ""
+	}
+	d.Write([]byte("using cached archive of " + d.product.Name + ", version " + d.version + "\n")) //nolint:errcheck
+	return d.cache
+}
+
+// store the archive in the cache, along with its checksum, against which it
+// is verified before it is reused.
+func (d *download) store(zipfile string) error {
+	sum := d.checksum
+	if sum == "" {
+		var err error
+		if sum, err = checksum(zipfile); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(d.cache), 0o755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	f, err := os.Open(zipfile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := atomic.WriteFile(d.cache, f); err != nil {
+		return err
+	}
+	// write the checksum last, so that a partially cached archive is never
+	// reused
+	return atomic.WriteFile(d.cache+".sha256", strings.NewReader(sum))
+}
+
+// evict removes the cached copy of the archive.
+func (d *download) evict() {
+	os.Remove(d.cache + ".sha256")
+	os.Remove(d.cache)
+}
+
+// checksum returns the hex-encoded SHA256 checksum of a file.
+func checksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("computing checksum: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func (d *download) unzip(zipfile string) error {
 	zr, err := zip.OpenReader(zipfile)
 	if err != nil {
@@ -85,17 +273,17 @@ func (d *download) unzip(zipfile string) error {
 	defer zr.Close()
 
 	for _, f := range zr.File {
-		if f.Name == "terraform" {
+		if f.Name == d.product.BinaryName {
 			fr, err := f.Open()
 			if err != nil {
 				return err
 			}
 			defer fr.Close()
 			if err := atomic.WriteFile(d.dest, fr, atomic.DefaultFileMode(0o755)); err != nil {
-				return fmt.Errorf("writing terraform binary: %w", err)
+				return fmt.Errorf("writing %s binary: %w", d.product.BinaryName, err)
 			}
 			return nil
 		}
 	}
-	return fmt.Errorf("terraform binary not found")
+	return fmt.Errorf("%s binary not found", d.product.BinaryName)
 }
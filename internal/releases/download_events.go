@@ -0,0 +1,131 @@
+package releases
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	DownloadStarted   DownloadEventType = "started"
+	DownloadProgress  DownloadEventType = "progress"
+	DownloadCompleted DownloadEventType = "completed"
+
+	// downloadSubBufferSize is the buffer size of the channel for each
+	// subscription to download events.
+	downloadSubBufferSize = 100
+)
+
+// progressInterval is the minimum interval between progress events for a
+// download.
+var progressInterval = time.Second
+
+type (
+	// DownloadEventType identifies the stage of a download reported by a
+	// download event.
+	DownloadEventType string
+
+	// DownloadEvent reports the progress of a download of a version of a
+	// product.
+	DownloadEvent struct {
+		Type    DownloadEventType
+		Product string
+		Version string
+		// Received is the number of bytes of the archive received so far.
+		Received int64
+		// Total is the size of the archive in bytes, or -1 if unknown.
+		Total int64
+		// Err is set on a completed event if the download failed.
+		Err error
+	}
+
+	// downloadBroker relays download events to subscribers, keyed by
+	// version.
+	downloadBroker struct {
+		subs map[string]map[chan DownloadEvent]struct{}
+		mu   sync.Mutex
+	}
+)
+
+// Subscribe subscribes the caller to events for downloads of the given
+// version. The caller can close the subscription by either canceling the
+// context or calling the returned unsubscribe function.
+func (b *downloadBroker) Subscribe(ctx context.Context, version string) (<-chan DownloadEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subs == nil {
+		b.subs = make(map[string]map[chan DownloadEvent]struct{})
+	}
+	if b.subs[version] == nil {
+		b.subs[version] = make(map[chan DownloadEvent]struct{})
+	}
+	sub := make(chan DownloadEvent, downloadSubBufferSize)
+	b.subs[version][sub] = struct{}{}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.unsubscribe(version, sub)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return sub, func() {
+		once.Do(func() { close(done) })
+		b.unsubscribe(version, sub)
+	}
+}
+
+func (b *downloadBroker) unsubscribe(version string, sub chan DownloadEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[version][sub]; !ok {
+		// already unsubscribed
+		return
+	}
+	close(sub)
+	delete(b.subs[version], sub)
+	if len(b.subs[version]) == 0 {
+		delete(b.subs, version)
+	}
+}
+
+func (b *downloadBroker) publish(event DownloadEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs[event.Version] {
+		select {
+		case sub <- event:
+		default:
+			// the subscriber's buffer is full; progress is only reported
+			// for the benefit of humans, so rather than blocking the download
+			// the event is dropped.
+		}
+	}
+}
+
+// progressWriter counts the bytes written to it and publishes progress events,
+// at most once every progressInterval.
+type progressWriter struct {
+	io.Writer
+
+	event   DownloadEvent
+	publish func(DownloadEvent)
+	last    time.Time
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.event.Received += int64(n)
+	if time.Since(w.last) >= progressInterval {
+		w.last = time.Now()
+		w.publish(w.event)
+	}
+	return n, err
+}
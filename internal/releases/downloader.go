@@ -2,87 +2,342 @@ package releases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
+	"regexp"
 	"runtime"
+	"sync"
+	"time"
 
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/semver"
 )
 
 const hashicorpReleasesHost = "releases.hashicorp.com"
 
+// DefaultMaxConcurrentDownloads is the default maximum number of distinct
+// terraform versions a downloader downloads at any one time.
+const DefaultMaxConcurrentDownloads = 4
+
 var defaultTerraformBinDir = path.Join(os.TempDir(), "otf-terraform-bins")
 
+var (
+	// locks ensure only one download at a time of each version, keyed by the
+	// destination path of its binary, regardless of how many downloaders
+	// share the destination directory.
+	locks   = make(map[string]chan struct{})
+	locksMu sync.Mutex
+)
+
+// ErrInvalidPlatform is returned when an operating system or architecture is
+// invalid.
+var ErrInvalidPlatform = errors.New("invalid platform")
+
+// ErrDownloadCanceled is returned to callers downloading, or waiting to
+// download, a version whose download has been canceled.
+var ErrDownloadCanceled = errors.New("download canceled")
+
+// validPlatform matches a valid operating system or architecture, e.g. linux
+// or amd64.
+var validPlatform = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// DownloadURLs are the URLs from which a release is downloaded.
+type DownloadURLs struct {
+	// Archive is the URL of the release's zip archive for a platform.
+	Archive string
+	// Checksums is the URL of the SHA256 checksums of the release's
+	// archives.
+	Checksums string
+}
+
+// canceler cancels a caller's download.
+type canceler struct {
+	cancel context.CancelCauseFunc
+}
+
 // downloader downloads terraform binaries
 type downloader struct {
-	destdir string        // destination directory for binaries
-	host    string        // server hosting binaries
-	client  *http.Client  // client for downloading from server via http
-	mu      chan struct{} // ensures only one download at a time
+	destdir  string        // destination directory for binaries
+	cachedir string        // directory in which archives are cached; optional
+	product  Product       // product whose binaries are downloaded
+	client   *http.Client  // client for downloading from server via http
+	slots    chan struct{} // limits number of concurrent downloads
+
+	events *downloadBroker // relays events for downloads to subscribers
+
+	// cancel funcs for the callers downloading, or waiting to download, each
+	// version
+	cancels   map[string]map[*canceler]struct{}
+	cancelsMu sync.Mutex
+
+	// cache of versions available for download
+	availableVersions []string
+	availableAt       time.Time
+	availableMu       sync.Mutex
 }
 
-// NewDownloader constructs a terraform downloader, with destdir set as the
-// parent directory into which the binaries are downloaded. Pass an empty string
-// to use a default.
-func NewDownloader(destdir string) *downloader {
+// NewDownloader constructs a downloader of the product's binaries, with
+// destdir set as the parent directory into which the binaries are
+// downloaded, and which downloads at most maxConcurrent distinct versions at
+// any one time. Pass a zero product, an empty string and zero respectively to
+// use defaults. If cachedir is non-empty then downloaded archives are cached
+// in the directory, so that a binary can be extracted again, e.g. after
+// destdir is wiped, without downloading its archive again.
+func NewDownloader(product Product, destdir string, maxConcurrent int, cachedir string) *downloader {
+	if product == (Product{}) {
+		product = TerraformProduct
+	}
 	if destdir == "" {
 		destdir = defaultTerraformBinDir
 	}
-
-	mu := make(chan struct{}, 1)
-	mu <- struct{}{}
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentDownloads
+	}
 
 	return &downloader{
-		host:    hashicorpReleasesHost,
-		destdir: destdir,
-		client:  &http.Client{},
-		mu:      mu,
+		product:  product,
+		destdir:  destdir,
+		cachedir: cachedir,
+		client:   &http.Client{},
+		slots:    make(chan struct{}, maxConcurrent),
+		events:   &downloadBroker{},
+		cancels:  make(map[string]map[*canceler]struct{}),
 	}
 }
 
+// lock returns the lock for a destination path.
+func lock(dest string) chan struct{} {
+	locksMu.Lock()
+	defer locksMu.Unlock()
+
+	mu, ok := locks[dest]
+	if !ok {
+		mu = make(chan struct{}, 1)
+		mu <- struct{}{}
+		locks[dest] = mu
+	}
+	return mu
+}
+
+// acquire waits until the caller is permitted to download the given version,
+// which is once no other download of the same version is in-flight and a
+// download slot is free. The returned func must be called to release the
+// version and the slot.
+//
+// The version is locked before a slot is taken, so that callers waiting on a
+// download of the same version don't occupy slots that would otherwise be
+// used to download other versions.
+func (d *downloader) acquire(ctx context.Context, version string) (func(), error) {
+	mu := lock(d.dest(version))
+	select {
+	case <-mu:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case d.slots <- struct{}{}:
+	case <-ctx.Done():
+		mu <- struct{}{}
+		return nil, ctx.Err()
+	}
+	return func() {
+		<-d.slots
+		mu <- struct{}{}
+	}, nil
+}
+
 // Download ensures the given version of terraform is available on the local
-// filesystem and returns its path. Thread-safe: if a Download is in-flight and
-// another Download is requested then it'll be made to wait until the
-// former has finished.
+// filesystem and returns its path. Thread-safe: if a Download of the same
+// version is in-flight then it'll be made to wait until the former has
+// finished, and if the maximum number of concurrent downloads are in-flight
+// then it'll be made to wait until one of them has finished.
 func (d *downloader) Download(ctx context.Context, version string, w io.Writer) (string, error) {
 	if internal.Exists(d.dest(version)) {
 		return d.dest(version), nil
 	}
 
-	select {
-	case <-d.mu:
-	case <-ctx.Done():
-		return "", ctx.Err()
+	ctx, untrack := d.track(ctx, version)
+	defer untrack()
+
+	release, err := d.acquire(ctx, version)
+	if err != nil {
+		return "", canceled(ctx, version, err)
 	}
+	defer release()
 
-	err := (&download{
+	err = (&download{
 		Writer:  w,
+		product: d.product,
 		version: version,
 		src:     d.src(version),
 		dest:    d.dest(version),
+		cache:   d.cache(version),
 		client:  d.client,
+		events:  d.events,
 	}).download(ctx)
 
-	d.mu <- struct{}{}
+	return d.dest(version), canceled(ctx, version, err)
+}
+
+// Redownload deletes the given version of terraform from the local filesystem,
+// e.g. because it has become corrupted, and downloads it again, verifying the
+// downloaded archive against the checksums published alongside it. The
+// verified SHA256 checksum of the archive is returned. Like Download, it
+// waits for any in-flight download of the same version and for a free
+// download slot.
+func (d *downloader) Redownload(ctx context.Context, version string) (string, error) {
+	ctx, untrack := d.track(ctx, version)
+	defer untrack()
+
+	release, err := d.acquire(ctx, version)
+	if err != nil {
+		return "", canceled(ctx, version, err)
+	}
+	defer release()
+
+	if err := os.Remove(d.dest(version)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("removing existing binary: %w", err)
+	}
+
+	dl := &download{
+		Writer:    io.Discard,
+		product:   d.product,
+		version:   version,
+		src:       d.src(version),
+		dest:      d.dest(version),
+		checksums: d.checksums(version),
+		cache:     d.cache(version),
+		client:    d.client,
+		events:    d.events,
+	}
+	if err := dl.download(ctx); err != nil {
+		return "", canceled(ctx, version, err)
+	}
+	return dl.checksum, nil
+}
+
+// Cancel cancels the in-flight download of the given version, along with
+// any callers waiting to download the version, each of which is returned
+// ErrDownloadCanceled. The partially downloaded archive is removed. Returns
+// false if the version is neither being downloaded nor waiting to be
+// downloaded.
+func (d *downloader) Cancel(version string) bool {
+	d.cancelsMu.Lock()
+	defer d.cancelsMu.Unlock()
+
+	for c := range d.cancels[version] {
+		c.cancel(ErrDownloadCanceled)
+	}
+	return len(d.cancels[version]) > 0
+}
+
+// downloading lists the versions being downloaded or waiting to be
+// downloaded.
+func (d *downloader) downloading() []string {
+	d.cancelsMu.Lock()
+	defer d.cancelsMu.Unlock()
+
+	versions := make([]string, 0, len(d.cancels))
+	for version := range d.cancels {
+		versions = append(versions, version)
+	}
+	return versions
+}
 
-	return d.dest(version), err
+// track registers the caller's download of a version so that it can be
+// canceled, returning a context that is canceled along with the download and
+// a func to deregister the download once finished.
+func (d *downloader) track(ctx context.Context, version string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	c := &canceler{cancel: cancel}
+
+	d.cancelsMu.Lock()
+	if d.cancels[version] == nil {
+		d.cancels[version] = make(map[*canceler]struct{})
+	}
+	d.cancels[version][c] = struct{}{}
+	d.cancelsMu.Unlock()
+
+	return ctx, func() {
+		d.cancelsMu.Lock()
+		delete(d.cancels[version], c)
+		if len(d.cancels[version]) == 0 {
+			delete(d.cancels, version)
+		}
+		d.cancelsMu.Unlock()
+		cancel(nil)
+	}
+}
+
+// canceled returns ErrDownloadCanceled in place of err if the download was
+// canceled.
+func canceled(ctx context.Context, version string, err error) error {
+	if err != nil && errors.Is(context.Cause(ctx), ErrDownloadCanceled) {
+		return fmt.Errorf("%w: %s", ErrDownloadCanceled, version)
+	}
+	return err
+}
+
+// Subscribe subscribes the caller to events for downloads of the given
+// version, including a download already in-flight. The subscription is closed
+// by canceling the context or calling the returned func.
+func (d *downloader) Subscribe(ctx context.Context, version string) (<-chan DownloadEvent, func()) {
+	return d.events.Subscribe(ctx, version)
+}
+
+// DownloadURLs returns the URLs from which the given version of the product
+// is downloaded for the given platform, without downloading it, e.g. for a CI
+// system that downloads the binary itself.
+func (d *downloader) DownloadURLs(version, goos, goarch string) (DownloadURLs, error) {
+	if !semver.IsValid(version) {
+		return DownloadURLs{}, fmt.Errorf("%w: %s", internal.ErrInvalidTerraformVersion, version)
+	}
+	if !validPlatform.MatchString(goos) || !validPlatform.MatchString(goarch) {
+		return DownloadURLs{}, fmt.Errorf("%w: %s_%s", ErrInvalidPlatform, goos, goarch)
+	}
+	return DownloadURLs{
+		Archive: (&url.URL{
+			Scheme: "https",
+			Host:   d.product.Host,
+			Path:   d.product.expandFor(d.product.ArchivePath, version, goos, goarch),
+		}).String(),
+		Checksums: d.checksums(version),
+	}, nil
 }
 
 func (d *downloader) src(version string) string {
 	return (&url.URL{
 		Scheme: "https",
-		Host:   d.host,
-		Path: path.Join(
-			"terraform",
-			version,
-			fmt.Sprintf("terraform_%s_%s_%s.zip", version, runtime.GOOS, runtime.GOARCH)),
+		Host:   d.product.Host,
+		Path:   d.product.expand(d.product.ArchivePath, version),
+	}).String()
+}
+
+func (d *downloader) checksums(version string) string {
+	return (&url.URL{
+		Scheme: "https",
+		Host:   d.product.Host,
+		Path:   d.product.expand(d.product.ChecksumsPath, version),
 	}).String()
 }
 
 func (d *downloader) dest(version string) string {
-	return path.Join(d.destdir, version, "terraform")
+	return path.Join(d.destdir, version, d.product.BinaryName)
+}
+
+// cache returns the path at which the archive for the version is cached,
+// keyed by product, version and platform, or an empty string if archives are
+// not cached.
+func (d *downloader) cache(version string) string {
+	if d.cachedir == "" {
+		return ""
+	}
+	return filepath.Join(d.cachedir, d.product.Name, version, runtime.GOOS+"_"+runtime.GOARCH, path.Base(d.src(version)))
 }
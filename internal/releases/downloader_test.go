@@ -3,14 +3,21 @@ package releases
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
 	otfhttp "github.com/tofutf/tofutf/internal/http"
 )
 
@@ -24,8 +31,8 @@ func TestDownloader(t *testing.T) {
 	u, err := url.Parse(srv.URL)
 	require.NoError(t, err)
 
-	dl := NewDownloader(t.TempDir())
-	dl.host = u.Host
+	dl := NewDownloader(TerraformProduct, t.TempDir(), 0, "")
+	serveFrom(dl, u.Host)
 	dl.client = &http.Client{
 		Transport: otfhttp.InsecureTransport,
 	}
@@ -39,3 +46,383 @@ func TestDownloader(t *testing.T) {
 	assert.Equal(t, "I am a fake terraform binary\n", string(tfbin))
 	assert.Equal(t, "downloading terraform, version 1.2.3\n", buf.String())
 }
+
+func TestDownloader_Events(t *testing.T) {
+	srv := httptest.NewTLSServer(http.FileServer(http.Dir("testdata/releases")))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	dl := NewDownloader(TerraformProduct, t.TempDir(), 0, "")
+	serveFrom(dl, u.Host)
+	dl.client = &http.Client{
+		Transport: otfhttp.InsecureTransport,
+	}
+
+	sub, unsubscribe := dl.Subscribe(context.Background(), "1.2.3")
+	// events for other versions should not be received
+	other, unsubscribeOther := dl.Subscribe(context.Background(), "1.2.4")
+
+	_, err = dl.Download(context.Background(), "1.2.3", io.Discard)
+	require.NoError(t, err)
+	unsubscribe()
+	unsubscribeOther()
+
+	var got []DownloadEvent
+	for event := range sub {
+		got = append(got, event)
+	}
+	require.GreaterOrEqual(t, len(got), 2)
+
+	started := got[0]
+	assert.Equal(t, DownloadStarted, started.Type)
+	assert.Equal(t, "terraform", started.Product)
+	assert.Equal(t, "1.2.3", started.Version)
+
+	completed := got[len(got)-1]
+	assert.Equal(t, DownloadCompleted, completed.Type)
+	assert.Equal(t, "1.2.3", completed.Version)
+	assert.NoError(t, completed.Err)
+	assert.Greater(t, completed.Received, int64(0))
+	assert.Equal(t, completed.Total, completed.Received)
+
+	for _, event := range got[1 : len(got)-1] {
+		assert.Equal(t, DownloadProgress, event.Type)
+	}
+
+	_, ok := <-other
+	assert.False(t, ok)
+}
+
+func TestDownloader_Redownload(t *testing.T) {
+	const checksum = "60bc3b808b2a3ac8d02aa2f5777e1f477471aa64ca98d2b27681ae92cfdb7ca5"
+
+	setup := func(t *testing.T, handler http.Handler) *downloader {
+		srv := httptest.NewTLSServer(handler)
+		t.Cleanup(srv.Close)
+		u, err := url.Parse(srv.URL)
+		require.NoError(t, err)
+
+		dl := NewDownloader(TerraformProduct, t.TempDir(), 0, "")
+		serveFrom(dl, u.Host)
+		dl.client = &http.Client{
+			Transport: otfhttp.InsecureTransport,
+		}
+		return dl
+	}
+
+	t.Run("replace corrupted binary", func(t *testing.T) {
+		dl := setup(t, http.FileServer(http.Dir("testdata/releases")))
+
+		tfpath, err := dl.Download(context.Background(), "1.2.3", io.Discard)
+		require.NoError(t, err)
+		// corrupt binary
+		require.NoError(t, os.WriteFile(tfpath, []byte("garbage"), 0o755))
+
+		got, err := dl.Redownload(context.Background(), "1.2.3")
+		require.NoError(t, err)
+		assert.Equal(t, checksum, got)
+
+		tfbin, err := os.ReadFile(tfpath)
+		require.NoError(t, err)
+		assert.Equal(t, "I am a fake terraform binary\n", string(tfbin))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle("/", http.FileServer(http.Dir("testdata/releases")))
+		mux.HandleFunc("/terraform/1.2.3/terraform_1.2.3_SHA256SUMS", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%064d  terraform_1.2.3_%s_%s.zip\n", 0, runtime.GOOS, runtime.GOARCH)
+		})
+		dl := setup(t, mux)
+
+		_, err := dl.Redownload(context.Background(), "1.2.3")
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+		assert.NoFileExists(t, dl.dest("1.2.3"))
+	})
+}
+
+func TestDownloader_ArchiveCache(t *testing.T) {
+	setup := func(t *testing.T) (*downloader, *atomic.Int32) {
+		var requests atomic.Int32
+		fileserver := http.FileServer(http.Dir("testdata/releases"))
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			fileserver.ServeHTTP(w, r)
+		}))
+		t.Cleanup(srv.Close)
+		u, err := url.Parse(srv.URL)
+		require.NoError(t, err)
+
+		dl := NewDownloader(TerraformProduct, t.TempDir(), 0, t.TempDir())
+		serveFrom(dl, u.Host)
+		dl.client = &http.Client{
+			Transport: otfhttp.InsecureTransport,
+		}
+		return dl, &requests
+	}
+
+	t.Run("reuse cached archive", func(t *testing.T) {
+		dl, requests := setup(t)
+
+		_, err := dl.Download(context.Background(), "1.2.3", io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), requests.Load())
+		assert.FileExists(t, dl.cache("1.2.3"))
+
+		// wipe binaries
+		require.NoError(t, os.RemoveAll(dl.destdir))
+
+		buf := new(bytes.Buffer)
+		tfpath, err := dl.Download(context.Background(), "1.2.3", buf)
+		require.NoError(t, err)
+		tfbin, err := os.ReadFile(tfpath)
+		require.NoError(t, err)
+		assert.Equal(t, "I am a fake terraform binary\n", string(tfbin))
+		assert.Equal(t, "using cached archive of terraform, version 1.2.3\n", buf.String())
+		// the archive should not have been downloaded again
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("discard corrupted cached archive", func(t *testing.T) {
+		dl, requests := setup(t)
+
+		_, err := dl.Download(context.Background(), "1.2.3", io.Discard)
+		require.NoError(t, err)
+		require.NoError(t, os.RemoveAll(dl.destdir))
+		// corrupt cached archive
+		require.NoError(t, os.WriteFile(dl.cache("1.2.3"), []byte("garbage"), 0o644))
+
+		tfpath, err := dl.Download(context.Background(), "1.2.3", io.Discard)
+		require.NoError(t, err)
+		tfbin, err := os.ReadFile(tfpath)
+		require.NoError(t, err)
+		assert.Equal(t, "I am a fake terraform binary\n", string(tfbin))
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("redownload verifies cached archive", func(t *testing.T) {
+		dl, requests := setup(t)
+
+		_, err := dl.Download(context.Background(), "1.2.3", io.Discard)
+		require.NoError(t, err)
+
+		got, err := dl.Redownload(context.Background(), "1.2.3")
+		require.NoError(t, err)
+		assert.Equal(t, "60bc3b808b2a3ac8d02aa2f5777e1f477471aa64ca98d2b27681ae92cfdb7ca5", got)
+		// only the checksums should have been retrieved
+		assert.Equal(t, int32(2), requests.Load())
+	})
+}
+
+func TestDownloader_Concurrency(t *testing.T) {
+	const maxConcurrent = 2
+
+	zipfile, err := os.ReadFile(fmt.Sprintf("testdata/releases/terraform/1.2.3/terraform_1.2.3_%s_%s.zip", runtime.GOOS, runtime.GOARCH))
+	require.NoError(t, err)
+
+	// serve the same archive for every version, holding each request until
+	// told to proceed, and keeping track of the number of requests in-flight.
+	var (
+		inflight, peak, requests atomic.Int32
+		proceed                  = make(chan struct{})
+	)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			if p := peak.Load(); n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-proceed
+		w.Write(zipfile) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	dl := NewDownloader(TerraformProduct, t.TempDir(), maxConcurrent, "")
+	serveFrom(dl, u.Host)
+	dl.client = &http.Client{
+		Transport: otfhttp.InsecureTransport,
+	}
+
+	// download five distinct versions, and the first version twice over.
+	versions := []string{"1.0.0", "1.0.0", "1.1.0", "1.2.0", "1.3.0", "1.4.0"}
+	var wg sync.WaitGroup
+	errs := make(chan error, len(versions))
+	for _, v := range versions {
+		wg.Add(1)
+		go func(v string) {
+			defer wg.Done()
+			_, err := dl.Download(context.Background(), v, io.Discard)
+			errs <- err
+		}(v)
+	}
+
+	// wait for downloads to fill the available slots
+	require.Eventually(t, func() bool {
+		return inflight.Load() == maxConcurrent
+	}, time.Second, 10*time.Millisecond)
+	// remaining downloads should be queued rather than in-flight
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(maxConcurrent), inflight.Load())
+
+	close(proceed)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(maxConcurrent), peak.Load())
+	// the duplicate download of the same version waited for the first and
+	// then found the binary already downloaded.
+	assert.Equal(t, int32(5), requests.Load())
+	for _, v := range versions {
+		assert.FileExists(t, dl.dest(v))
+	}
+}
+
+func TestDownloader_Cancel(t *testing.T) {
+	// send part of the archive and then stall until the client gives up.
+	var requests atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Length", "1024")
+		w.Write([]byte("partial")) //nolint:errcheck
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	// partially downloaded archives are written to the temp dir
+	tmpdir := t.TempDir()
+	t.Setenv("TMPDIR", tmpdir)
+
+	dl := NewDownloader(TerraformProduct, t.TempDir(), 0, "")
+	serveFrom(dl, u.Host)
+	dl.client = &http.Client{
+		Transport: otfhttp.InsecureTransport,
+	}
+
+	// download the version, along with a second caller waiting on the first
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := dl.Download(context.Background(), "1.2.3", io.Discard)
+			errs <- err
+		}()
+	}
+	require.Eventually(t, func() bool {
+		dl.cancelsMu.Lock()
+		defer dl.cancelsMu.Unlock()
+		return requests.Load() == 1 && len(dl.cancels["1.2.3"]) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	assert.False(t, dl.Cancel("1.2.4"))
+	assert.True(t, dl.Cancel("1.2.3"))
+
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, <-errs, ErrDownloadCanceled)
+	}
+	// the partially downloaded archive should have been removed
+	entries, err := os.ReadDir(tmpdir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.NoFileExists(t, dl.dest("1.2.3"))
+	assert.Empty(t, dl.downloading())
+	// the waiting caller should not have gone on to download the version
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// serveFrom points the downloader at a host serving releases of its product.
+func serveFrom(dl *downloader, host string) {
+	dl.product.Host = host
+	dl.product.IndexHost = host
+}
+
+func TestDownloader_DownloadURLs(t *testing.T) {
+	mirror := TerraformProduct
+	mirror.Host = "mirror.example.com"
+
+	tests := []struct {
+		name          string
+		product       Product
+		version       string
+		os            string
+		arch          string
+		wantArchive   string
+		wantChecksums string
+		wantErr       error
+	}{
+		{
+			name:          "terraform",
+			product:       TerraformProduct,
+			version:       "1.6.0",
+			os:            "linux",
+			arch:          "amd64",
+			wantArchive:   "https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_linux_amd64.zip",
+			wantChecksums: "https://releases.hashicorp.com/terraform/1.6.0/terraform_1.6.0_SHA256SUMS",
+		},
+		{
+			name:          "opentofu",
+			product:       OpenTofuProduct,
+			version:       "1.6.1",
+			os:            "darwin",
+			arch:          "arm64",
+			wantArchive:   "https://github.com/opentofu/opentofu/releases/download/v1.6.1/tofu_1.6.1_darwin_arm64.zip",
+			wantChecksums: "https://github.com/opentofu/opentofu/releases/download/v1.6.1/tofu_1.6.1_SHA256SUMS",
+		},
+		{
+			name:          "mirror",
+			product:       mirror,
+			version:       "1.7.0-beta1",
+			os:            "windows",
+			arch:          "386",
+			wantArchive:   "https://mirror.example.com/terraform/1.7.0-beta1/terraform_1.7.0-beta1_windows_386.zip",
+			wantChecksums: "https://mirror.example.com/terraform/1.7.0-beta1/terraform_1.7.0-beta1_SHA256SUMS",
+		},
+		{
+			name:    "invalid version",
+			product: TerraformProduct,
+			version: "latest",
+			os:      "linux",
+			arch:    "amd64",
+			wantErr: internal.ErrInvalidTerraformVersion,
+		},
+		{
+			name:    "invalid os",
+			product: TerraformProduct,
+			version: "1.6.0",
+			os:      "../linux",
+			arch:    "amd64",
+			wantErr: ErrInvalidPlatform,
+		},
+		{
+			name:    "missing arch",
+			product: TerraformProduct,
+			version: "1.6.0",
+			os:      "linux",
+			wantErr: ErrInvalidPlatform,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dl := NewDownloader(tt.product, t.TempDir(), 0, "")
+
+			got, err := dl.DownloadURLs(tt.version, tt.os, tt.arch)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArchive, got.Archive)
+			assert.Equal(t, tt.wantChecksums, got.Checksums)
+		})
+	}
+}
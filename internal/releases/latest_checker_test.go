@@ -1,6 +1,7 @@
 package releases
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal/testutils"
+	"go.uber.org/goleak"
 )
 
 func Test_latestChecker(t *testing.T) {
@@ -44,3 +46,28 @@ func Test_latestChecker(t *testing.T) {
 		})
 	}
 }
+
+func Test_poll(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	polled := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		poll(ctx, time.Millisecond, func() {
+			select {
+			case polled <- struct{}{}:
+			default:
+			}
+		})
+		close(done)
+	}()
+	<-polled
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for poller to stop")
+	}
+}
@@ -0,0 +1,157 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/semver"
+)
+
+var (
+	// ErrForbiddenTerraformVersion is returned when a terraform version is
+	// not permitted by the site's terraform version policy.
+	ErrForbiddenTerraformVersion = errors.New("terraform version forbidden by site terraform version policy")
+
+	// ErrInvalidVersionPolicy is returned when a terraform version policy's
+	// settings are inconsistent with one another.
+	ErrInvalidVersionPolicy = errors.New("invalid terraform version policy")
+)
+
+type (
+	// VersionPolicy is the site-wide policy governing which terraform versions
+	// workspaces may use. Each setting is optional, and an empty string means
+	// the setting is unset.
+	VersionPolicy struct {
+		// MinVersion is the oldest terraform version workspaces may use.
+		MinVersion string
+		// MaxVersion is the newest terraform version workspaces may use.
+		MaxVersion string
+		// DefaultVersion is the terraform version assigned to new workspaces
+		// that don't specify a version. If unset then DefaultTerraformVersion
+		// is used instead.
+		DefaultVersion string
+		// UpdatedAt is the time the policy was last updated.
+		UpdatedAt time.Time
+		// UpdatedBy is the subject that last updated the policy.
+		UpdatedBy string
+	}
+
+	UpdateVersionPolicyOptions struct {
+		MinVersion     string
+		MaxVersion     string
+		DefaultVersion string
+	}
+)
+
+func newVersionPolicy(opts UpdateVersionPolicyOptions, now time.Time, subject string) (*VersionPolicy, error) {
+	for _, v := range []string{opts.MinVersion, opts.MaxVersion, opts.DefaultVersion} {
+		if v != "" && !semver.IsValid(v) {
+			return nil, fmt.Errorf("%w: %w: %s", ErrInvalidVersionPolicy, internal.ErrInvalidTerraformVersion, v)
+		}
+	}
+	if opts.MinVersion != "" && opts.MaxVersion != "" && semver.Compare(opts.MinVersion, opts.MaxVersion) > 0 {
+		return nil, fmt.Errorf("%w: minimum version %s is newer than maximum version %s", ErrInvalidVersionPolicy, opts.MinVersion, opts.MaxVersion)
+	}
+	policy := &VersionPolicy{
+		MinVersion:     opts.MinVersion,
+		MaxVersion:     opts.MaxVersion,
+		DefaultVersion: opts.DefaultVersion,
+		UpdatedAt:      now,
+		UpdatedBy:      subject,
+	}
+	// the default, whether configured or not, must itself be permitted.
+	if err := policy.Check(policy.Default()); err != nil {
+		return nil, fmt.Errorf("%w: default version: %w", ErrInvalidVersionPolicy, err)
+	}
+	return policy, nil
+}
+
+// Default returns the terraform version to assign to new workspaces.
+func (p *VersionPolicy) Default() string {
+	if p.DefaultVersion != "" {
+		return p.DefaultVersion
+	}
+	return DefaultTerraformVersion
+}
+
+// Check determines whether the policy permits the given terraform version.
+// Only concrete versions are checked; "latest" and version constraints are
+// permitted because they are only resolved to a concrete version when a run
+// is created, at which point the resolved version is checked.
+func (p *VersionPolicy) Check(version string) error {
+	if !semver.IsValid(version) {
+		return nil
+	}
+	if p.MinVersion != "" && semver.Compare(version, p.MinVersion) < 0 {
+		return fmt.Errorf("%w: %s is older than the minimum allowed version %s", ErrForbiddenTerraformVersion, version, p.MinVersion)
+	}
+	if p.MaxVersion != "" && semver.Compare(version, p.MaxVersion) > 0 {
+		return fmt.Errorf("%w: %s is newer than the maximum allowed version %s", ErrForbiddenTerraformVersion, version, p.MaxVersion)
+	}
+	return nil
+}
+
+// GetVersionPolicy retrieves the terraform version policy. Any subject may
+// retrieve the policy, in order that users can discover which versions they
+// may use.
+func (s *Service) GetVersionPolicy(ctx context.Context) (*VersionPolicy, error) {
+	return s.db.getVersionPolicy(ctx)
+}
+
+// UpdateVersionPolicy updates the terraform version policy, replacing its
+// existing settings. Only a site admin may update the policy. Existing
+// workspaces are left unchanged, but runs are refused for those workspaces
+// using a version the policy forbids. In-flight downloads of versions the
+// policy forbids are canceled.
+func (s *Service) UpdateVersionPolicy(ctx context.Context, opts UpdateVersionPolicyOptions) (*VersionPolicy, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.UpdateTerraformVersionPolicyAction, "")
+	if err != nil {
+		return nil, err
+	}
+	policy, err := newVersionPolicy(opts, internal.CurrentTimestamp(nil), subject.String())
+	if err != nil {
+		s.logger.Error("updating terraform version policy", "subject", subject, "err", err)
+		return nil, err
+	}
+	if err := s.db.upsertVersionPolicy(ctx, policy); err != nil {
+		s.logger.Error("updating terraform version policy", "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("updated terraform version policy", "min", policy.MinVersion, "max", policy.MaxVersion, "default", policy.DefaultVersion, "subject", subject)
+
+	// abort in-flight downloads of versions the policy now forbids
+	for _, version := range s.downloading() {
+		if policy.Check(version) == nil {
+			continue
+		}
+		if s.Cancel(version) {
+			s.logger.Info("canceled download of forbidden terraform version", "version", version, "subject", subject)
+		}
+	}
+	return policy, nil
+}
+
+// CheckVersionPolicy returns an error wrapping ErrForbiddenTerraformVersion
+// if the terraform version policy forbids the given version.
+func (s *Service) CheckVersionPolicy(ctx context.Context, version string) error {
+	policy, err := s.GetVersionPolicy(ctx)
+	if err != nil {
+		return err
+	}
+	return policy.Check(version)
+}
+
+// DefaultVersion returns the terraform version to assign to new workspaces,
+// which is the version configured by the terraform version policy or, if
+// unset, DefaultTerraformVersion.
+func (s *Service) DefaultVersion(ctx context.Context) (string, error) {
+	policy, err := s.GetVersionPolicy(ctx)
+	if err != nil {
+		return "", err
+	}
+	return policy.Default(), nil
+}
@@ -0,0 +1,66 @@
+package releases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestNewVersionPolicy(t *testing.T) {
+	now := internal.CurrentTimestamp(nil)
+
+	tests := []struct {
+		name string
+		opts UpdateVersionPolicyOptions
+		want error
+	}{
+		{"empty", UpdateVersionPolicyOptions{}, nil},
+		{"range", UpdateVersionPolicyOptions{MinVersion: "1.3.0", MaxVersion: "1.6.0"}, nil},
+		{"default within range", UpdateVersionPolicyOptions{MinVersion: "1.3.0", MaxVersion: "1.6.0", DefaultVersion: "1.5.7"}, nil},
+		{"invalid version", UpdateVersionPolicyOptions{MinVersion: "latest"}, internal.ErrInvalidTerraformVersion},
+		{"min newer than max", UpdateVersionPolicyOptions{MinVersion: "1.6.0", MaxVersion: "1.3.0"}, ErrInvalidVersionPolicy},
+		{"default outside range", UpdateVersionPolicyOptions{MinVersion: "1.3.0", DefaultVersion: "1.2.0"}, ErrForbiddenTerraformVersion},
+		{"builtin default outside range", UpdateVersionPolicyOptions{MinVersion: "1.7.0"}, ErrInvalidVersionPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := newVersionPolicy(tt.opts, now, "bobby")
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "bobby", policy.UpdatedBy)
+		})
+	}
+}
+
+func TestVersionPolicy_Check(t *testing.T) {
+	policy := VersionPolicy{MinVersion: "1.3.0", MaxVersion: "1.6.0"}
+
+	tests := []struct {
+		name    string
+		version string
+		want    error
+	}{
+		{"within range", "1.5.7", nil},
+		{"minimum", "1.3.0", nil},
+		{"maximum", "1.6.0", nil},
+		{"too old", "1.2.9", ErrForbiddenTerraformVersion},
+		{"too new", "1.6.1", ErrForbiddenTerraformVersion},
+		{"latest", LatestVersionString, nil},
+		{"constraint", "~> 1.2.0", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, policy.Check(tt.version), tt.want)
+		})
+	}
+}
+
+func TestVersionPolicy_Default(t *testing.T) {
+	assert.Equal(t, DefaultTerraformVersion, (&VersionPolicy{}).Default())
+	assert.Equal(t, "1.5.7", (&VersionPolicy{DefaultVersion: "1.5.7"}).Default())
+}
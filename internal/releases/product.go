@@ -0,0 +1,97 @@
+package releases
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// ErrUnknownProduct is returned when a product name does not correspond to a
+// known product.
+var ErrUnknownProduct = errors.New("unknown product")
+
+var (
+	// TerraformProduct is terraform, as released by HashiCorp.
+	TerraformProduct = Product{
+		Name:          "terraform",
+		BinaryName:    "terraform",
+		Host:          hashicorpReleasesHost,
+		ArchivePath:   "/terraform/{version}/terraform_{version}_{os}_{arch}.zip",
+		ChecksumsPath: "/terraform/{version}/terraform_{version}_SHA256SUMS",
+		IndexHost:     hashicorpReleasesHost,
+		IndexPath:     "/terraform/index.json",
+	}
+	// OpenTofuProduct is OpenTofu, the open source fork of terraform, whose
+	// releases are published on GitHub.
+	OpenTofuProduct = Product{
+		Name:          "opentofu",
+		BinaryName:    "tofu",
+		Host:          "github.com",
+		ArchivePath:   "/opentofu/opentofu/releases/download/v{version}/tofu_{version}_{os}_{arch}.zip",
+		ChecksumsPath: "/opentofu/opentofu/releases/download/v{version}/tofu_{version}_SHA256SUMS",
+		IndexHost:     "get.opentofu.org",
+		IndexPath:     "/tofu/api.json",
+	}
+
+	products = map[string]Product{
+		TerraformProduct.Name: TerraformProduct,
+		OpenTofuProduct.Name:  OpenTofuProduct,
+	}
+)
+
+// Product is a product compatible with terraform, describing where its
+// releases are published and how they are laid out.
+//
+// The paths may contain the placeholders {version}, {os} and {arch}, which
+// are replaced with the release version and the platform's operating
+// system and architecture respectively.
+type Product struct {
+	// Name uniquely identifies the product.
+	Name string
+	// BinaryName is the name of the product's executable, both within its
+	// release archives and once installed.
+	BinaryName string
+	// Host is the server hosting the product's release archives and
+	// checksums.
+	Host string
+	// ArchivePath is the path on Host of a release's zip archive.
+	ArchivePath string
+	// ChecksumsPath is the path on Host of the SHA256 checksums of a
+	// release's archives.
+	ChecksumsPath string
+	// IndexHost is the server hosting the index of the product's releases.
+	IndexHost string
+	// IndexPath is the path on IndexHost of the index of the product's
+	// releases.
+	IndexPath string
+}
+
+// LookupProduct returns the product with the given name. An empty name
+// returns TerraformProduct.
+func LookupProduct(name string) (Product, error) {
+	if name == "" {
+		return TerraformProduct, nil
+	}
+	product, ok := products[name]
+	if !ok {
+		return Product{}, fmt.Errorf("%w: %s", ErrUnknownProduct, name)
+	}
+	return product, nil
+}
+
+// expand replaces the placeholders in a path for the given version and the
+// current platform.
+func (p Product) expand(path, version string) string {
+	return p.expandFor(path, version, runtime.GOOS, runtime.GOARCH)
+}
+
+// expandFor replaces the placeholders in a path for the given version and
+// platform.
+func (p Product) expandFor(path, version, goos, goarch string) string {
+	return strings.NewReplacer(
+		"{version}", version,
+		"{os}", goos,
+		"{arch}", goarch,
+	).Replace(path)
+}
@@ -0,0 +1,108 @@
+package releases
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otfhttp "github.com/tofutf/tofutf/internal/http"
+)
+
+func TestProducts(t *testing.T) {
+	srv := httptest.NewTLSServer(http.FileServer(http.Dir("testdata/releases")))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	tests := []struct {
+		product       Product
+		version       string
+		wantBinary    string
+		wantProgress  string
+		wantAvailable []string
+	}{
+		{
+			product:       TerraformProduct,
+			version:       "1.2.3",
+			wantBinary:    "I am a fake terraform binary\n",
+			wantProgress:  "downloading terraform, version 1.2.3\n",
+			wantAvailable: []string{"1.5.7", "1.6.0", "1.6.6", "1.7.0-beta1"},
+		},
+		{
+			product:       OpenTofuProduct,
+			version:       "1.6.0",
+			wantBinary:    "I am a fake tofu binary\n",
+			wantProgress:  "downloading opentofu, version 1.6.0\n",
+			wantAvailable: []string{"1.7.0-alpha1", "1.6.1", "1.6.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.product.Name, func(t *testing.T) {
+			dl := NewDownloader(tt.product, t.TempDir(), 0, "")
+			serveFrom(dl, u.Host)
+			dl.client = &http.Client{Transport: otfhttp.InsecureTransport}
+
+			buf := new(bytes.Buffer)
+			binpath, err := dl.Download(context.Background(), tt.version, buf)
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(dl.destdir, tt.version, tt.product.BinaryName), binpath)
+			assert.Equal(t, tt.wantProgress, buf.String())
+			bin, err := os.ReadFile(binpath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBinary, string(bin))
+
+			// verified redownload locates the checksums of the product
+			_, err = dl.Redownload(context.Background(), tt.version)
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{tt.version}, dl.installed())
+
+			available, err := dl.available(context.Background())
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.wantAvailable, available)
+		})
+	}
+
+	t.Run("ignore binaries of other products", func(t *testing.T) {
+		destdir := t.TempDir()
+		terraform := NewDownloader(TerraformProduct, destdir, 0, "")
+		serveFrom(terraform, u.Host)
+		terraform.client = &http.Client{Transport: otfhttp.InsecureTransport}
+		_, err := terraform.Download(context.Background(), "1.2.3", new(bytes.Buffer))
+		require.NoError(t, err)
+
+		tofu := NewDownloader(OpenTofuProduct, destdir, 0, "")
+		assert.Empty(t, tofu.installed())
+	})
+}
+
+func TestLookupProduct(t *testing.T) {
+	tests := []struct {
+		name string
+		want Product
+		err  error
+	}{
+		{"", TerraformProduct, nil},
+		{"terraform", TerraformProduct, nil},
+		{"opentofu", OpenTofuProduct, nil},
+		{"tofu", Product{}, ErrUnknownProduct},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LookupProduct(tt.name)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
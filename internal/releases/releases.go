@@ -8,12 +8,19 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/semver"
 	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/tfeapi"
 )
 
 const (
+	// DefaultTerraformVersion is the terraform version assigned to new
+	// workspaces unless the terraform version policy configures a different
+	// default.
 	DefaultTerraformVersion = "1.6.0"
 	LatestVersionString     = "latest"
 )
@@ -24,14 +31,27 @@ type (
 		*downloader
 		latestChecker
 
-		db *db
+		db     *db
+		site   internal.Authorizer
+		api    *api
+		web    *webHandlers
+		tfeapi *tfe
 	}
 
 	Options struct {
 		*sql.Pool
+		*tfeapi.Responder
+		html.Renderer
 
 		Logger          *slog.Logger
-		TerraformBinDir string // destination directory for terraform binaries
+		Product         Product // product whose binaries are downloaded
+		TerraformBinDir string  // destination directory for terraform binaries
+		// maximum number of distinct terraform versions downloaded at any one
+		// time; zero uses DefaultMaxConcurrentDownloads.
+		MaxConcurrentDownloads int
+		// directory in which downloaded archives are cached; caching is
+		// disabled if empty.
+		ArchiveCacheDir string
 	}
 )
 
@@ -40,11 +60,48 @@ func NewService(opts Options) *Service {
 		logger:        opts.Logger,
 		db:            &db{opts.Pool},
 		latestChecker: latestChecker{latestEndpoint},
-		downloader:    NewDownloader(opts.TerraformBinDir),
+		downloader:    NewDownloader(opts.Product, opts.TerraformBinDir, opts.MaxConcurrentDownloads, opts.ArchiveCacheDir),
+		site:          &internal.SiteAuthorizer{Logger: opts.Logger},
+	}
+	svc.api = &api{
+		Service:   svc,
+		Responder: opts.Responder,
+	}
+	svc.web = &webHandlers{
+		Renderer: opts.Renderer,
+		svc:      svc,
+	}
+	svc.tfeapi = &tfe{
+		Service:   svc,
+		Responder: opts.Responder,
 	}
 	return svc
 }
 
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+	s.web.addHandlers(r)
+	s.tfeapi.addHandlers(r)
+}
+
+// Redownload deletes the given version of terraform from the local filesystem
+// and downloads it again, verifying it against its published checksums, for
+// when the binary has become corrupted. The verified checksum is returned.
+// Only a site admin may redownload terraform.
+func (s *Service) Redownload(ctx context.Context, version string) (string, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.RedownloadTerraformAction, "")
+	if err != nil {
+		return "", err
+	}
+	checksum, err := s.downloader.Redownload(ctx, version)
+	if err != nil {
+		s.logger.Error("redownloading terraform", "version", version, "subject", subject, "err", err)
+		return "", err
+	}
+	s.logger.Info("redownloaded terraform", "version", version, "checksum", checksum, "subject", subject)
+	return checksum, nil
+}
+
 // StartLatestChecker starts the latest checker go routine, checking the Hashicorp
 // API endpoint for a new latest version.
 func (s *Service) StartLatestChecker(ctx context.Context) {
@@ -82,18 +139,21 @@ func (s *Service) StartLatestChecker(ctx context.Context) {
 	// check once at startup
 	check()
 	// ...and check every 5 mins thereafter
-	go func() {
-		ticker := time.NewTicker(5 * time.Minute)
-		for {
-			select {
-			case <-ticker.C:
-				check()
-			case <-ctx.Done():
-				ticker.Stop()
-				return
-			}
+	go poll(ctx, 5*time.Minute, check)
+}
+
+// poll invokes fn at every interval until the context is canceled.
+func poll(ctx context.Context, interval time.Duration, fn func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			fn()
+		case <-ctx.Done():
+			return
 		}
-	}()
+	}
 }
 
 // GetLatest returns the latest terraform version and the time when it was
@@ -104,7 +164,11 @@ func (s *Service) GetLatest(ctx context.Context) (string, time.Time, error) {
 	if errors.Is(err, internal.ErrResourceNotFound) {
 		// no latest version has yet been persisted to the database so return
 		// the default version instead
-		return DefaultTerraformVersion, time.Time{}, nil
+		version, err := s.DefaultVersion(ctx)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		return version, time.Time{}, nil
 	} else if err != nil {
 		return "", time.Time{}, err
 	}
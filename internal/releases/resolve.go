@@ -0,0 +1,185 @@
+package releases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/semver"
+)
+
+// availableTTL is the length of time for which the list of versions available
+// for download is cached.
+const availableTTL = time.Hour
+
+// ErrNoMatchingVersion is returned when no terraform version satisfies a
+// version constraint.
+var ErrNoMatchingVersion = errors.New("no terraform version satisfies constraint")
+
+// ErrVersionUnavailable is returned when a terraform version is neither
+// installed nor available for download.
+var ErrVersionUnavailable = errors.New("terraform version is neither installed nor available for download")
+
+// ResolveVersion returns the newest terraform version satisfying the
+// constraint, selecting from those versions already installed and those
+// available for download.
+func (s *Service) ResolveVersion(ctx context.Context, constraint string) (string, error) {
+	versions := s.installed()
+	available, err := s.available(ctx)
+	if err != nil {
+		// fallback to selecting from installed versions only
+		s.logger.Warn("listing available terraform versions", "err", err)
+	}
+	versions = append(versions, available...)
+
+	v, ok := semver.Latest(constraint, versions)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNoMatchingVersion, constraint)
+	}
+	return v, nil
+}
+
+// CheckVersion checks that the terraform version is either already installed
+// or available for download, returning ErrVersionUnavailable if it is neither.
+// Any other error means the versions available for download could not be
+// determined.
+func (s *Service) CheckVersion(ctx context.Context, version string) error {
+	if internal.Exists(s.dest(version)) {
+		return nil
+	}
+	available, err := s.available(ctx)
+	if err != nil {
+		return fmt.Errorf("listing available terraform versions: %w", err)
+	}
+	if !slices.Contains(available, version) {
+		return fmt.Errorf("%w: %s", ErrVersionUnavailable, version)
+	}
+	return nil
+}
+
+// IsInstalled determines whether the terraform version is installed in the
+// terraform bin directory, i.e. whether a run requiring the version can
+// proceed without first downloading it. Only a verified binary counts as
+// installed: a non-empty, executable regular file. A binary is only ever
+// written once its archive has been downloaded and unzipped in full.
+func (s *Service) IsInstalled(ctx context.Context, version string) (bool, error) {
+	if !semver.IsValid(version) {
+		return false, fmt.Errorf("%w: %s", internal.ErrInvalidTerraformVersion, version)
+	}
+	info, err := os.Stat(s.dest(version))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("checking terraform binary: %w", err)
+	}
+	if !info.Mode().IsRegular() || info.Size() == 0 || info.Mode().Perm()&0o111 == 0 {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Prewarm downloads the terraform version in the background if it is not
+// already installed, so that a run requiring the version need not wait for it
+// to be downloaded. Downloads of the same version are not duplicated.
+func (s *Service) Prewarm(ctx context.Context, version string) {
+	go func() {
+		if _, err := s.Download(ctx, version, io.Discard); err != nil {
+			s.logger.Error("prewarming terraform", "version", version, "err", err)
+			return
+		}
+		s.logger.Debug("prewarmed terraform", "version", version)
+	}()
+}
+
+// installed lists the versions of terraform that have been downloaded.
+func (d *downloader) installed() []string {
+	entries, err := os.ReadDir(d.destdir)
+	if err != nil {
+		return nil
+	}
+	var versions []string
+	for _, e := range entries {
+		if !e.IsDir() || !semver.IsValid(e.Name()) {
+			continue
+		}
+		if internal.Exists(d.dest(e.Name())) {
+			versions = append(versions, e.Name())
+		}
+	}
+	return versions
+}
+
+// available lists the versions of terraform available for download.
+func (d *downloader) available(ctx context.Context) ([]string, error) {
+	d.availableMu.Lock()
+	defer d.availableMu.Unlock()
+
+	if d.availableVersions != nil && time.Since(d.availableAt) < availableTTL {
+		return d.availableVersions, nil
+	}
+
+	index := (&url.URL{
+		Scheme: "https",
+		Host:   d.product.IndexHost,
+		Path:   d.product.IndexPath,
+	}).String()
+	req, err := http.NewRequestWithContext(ctx, "GET", index, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("%s return non-200 status code: %s", index, resp.Status)
+	}
+	versions, err := decodeIndex(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", index, err)
+	}
+	d.availableVersions = versions
+	d.availableAt = time.Now()
+	return versions, nil
+}
+
+// decodeIndex decodes the versions listed in an index of releases. Terraform's
+// index lists versions as an object keyed by version, whereas OpenTofu's
+// index lists them as an array of objects identified by version.
+func decodeIndex(r io.Reader) ([]string, error) {
+	var payload struct {
+		Versions json.RawMessage `json:"versions"`
+	}
+	if err := json.NewDecoder(r).Decode(&payload); err != nil {
+		return nil, err
+	}
+	var keyed map[string]json.RawMessage
+	if err := json.Unmarshal(payload.Versions, &keyed); err == nil {
+		versions := make([]string, 0, len(keyed))
+		for v := range keyed {
+			versions = append(versions, v)
+		}
+		return versions, nil
+	}
+	var listed []struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(payload.Versions, &listed); err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(listed))
+	for _, v := range listed {
+		versions = append(versions, v.ID)
+	}
+	return versions, nil
+}
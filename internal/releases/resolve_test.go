@@ -0,0 +1,161 @@
+package releases
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	otfhttp "github.com/tofutf/tofutf/internal/http"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestService_ResolveVersion(t *testing.T) {
+	ctx := context.Background()
+
+	// setup web server serving the index of available versions
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir("testdata/releases")))
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	// install a version that is not available for download
+	dl := NewDownloader(TerraformProduct, t.TempDir(), 0, "")
+	serveFrom(dl, u.Host)
+	dl.client = &http.Client{Transport: otfhttp.InsecureTransport}
+	require.NoError(t, os.MkdirAll(filepath.Join(dl.destdir, "1.6.9"), 0o755))
+	require.NoError(t, os.WriteFile(dl.dest("1.6.9"), nil, 0o755))
+
+	svc := &Service{logger: slog.New(&xslog.NoopHandler{}), downloader: dl}
+
+	tests := []struct {
+		name       string
+		constraint string
+		want       string
+	}{
+		{"newest installed", "~> 1.6.0", "1.6.9"},
+		{"newest available", "< 1.6.9", "1.6.6"},
+		{"exact", "= 1.5.7", "1.5.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.ResolveVersion(ctx, tt.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := svc.ResolveVersion(ctx, "~> 2.0")
+		assert.ErrorIs(t, err, ErrNoMatchingVersion)
+	})
+
+	t.Run("fallback to installed when index unavailable", func(t *testing.T) {
+		dl := NewDownloader(TerraformProduct, dl.destdir, 0, "")
+		serveFrom(dl, "127.0.0.1:1")
+		svc := &Service{logger: slog.New(&xslog.NoopHandler{}), downloader: dl}
+
+		got, err := svc.ResolveVersion(ctx, "~> 1.6")
+		require.NoError(t, err)
+		assert.Equal(t, "1.6.9", got)
+
+		_, err = svc.ResolveVersion(ctx, "= 1.5.7")
+		assert.ErrorIs(t, err, ErrNoMatchingVersion)
+	})
+}
+
+func TestService_CheckVersion(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir("testdata/releases")))
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	dl := NewDownloader(TerraformProduct, t.TempDir(), 0, "")
+	serveFrom(dl, u.Host)
+	dl.client = &http.Client{Transport: otfhttp.InsecureTransport}
+	require.NoError(t, os.MkdirAll(filepath.Join(dl.destdir, "1.6.9"), 0o755))
+	require.NoError(t, os.WriteFile(dl.dest("1.6.9"), nil, 0o755))
+
+	svc := &Service{logger: slog.New(&xslog.NoopHandler{}), downloader: dl}
+
+	t.Run("installed", func(t *testing.T) {
+		assert.NoError(t, svc.CheckVersion(ctx, "1.6.9"))
+	})
+
+	t.Run("available for download", func(t *testing.T) {
+		assert.NoError(t, svc.CheckVersion(ctx, "1.5.7"))
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		err := svc.CheckVersion(ctx, "0.0.1")
+		assert.ErrorIs(t, err, ErrVersionUnavailable)
+	})
+
+	t.Run("index unavailable", func(t *testing.T) {
+		dl := NewDownloader(TerraformProduct, dl.destdir, 0, "")
+		serveFrom(dl, "127.0.0.1:1")
+		svc := &Service{logger: slog.New(&xslog.NoopHandler{}), downloader: dl}
+
+		assert.NoError(t, svc.CheckVersion(ctx, "1.6.9"))
+
+		err := svc.CheckVersion(ctx, "1.5.7")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrVersionUnavailable)
+	})
+}
+
+func TestService_IsInstalled(t *testing.T) {
+	ctx := context.Background()
+
+	dl := NewDownloader(TerraformProduct, t.TempDir(), 0, "")
+	svc := &Service{logger: slog.New(&xslog.NoopHandler{}), downloader: dl}
+
+	install := func(t *testing.T, version string, content []byte, perm os.FileMode) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dl.destdir, version), 0o755))
+		require.NoError(t, os.WriteFile(dl.dest(version), content, perm))
+	}
+	install(t, "1.6.9", []byte("I am a fake terraform binary\n"), 0o755)
+	// binary is not executable
+	install(t, "1.6.8", []byte("I am a fake terraform binary\n"), 0o644)
+	// binary is empty
+	install(t, "1.6.7", nil, 0o755)
+	// directory in place of binary
+	require.NoError(t, os.MkdirAll(dl.dest("1.6.6"), 0o755))
+
+	tests := []struct {
+		name    string
+		version string
+		want    bool
+	}{
+		{"installed", "1.6.9", true},
+		{"missing", "1.5.7", false},
+		{"not executable", "1.6.8", false},
+		{"empty", "1.6.7", false},
+		{"not a file", "1.6.6", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.IsInstalled(ctx, tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, err := svc.IsInstalled(ctx, "../1.6.9")
+		assert.ErrorIs(t, err, internal.ErrInvalidTerraformVersion)
+	})
+}
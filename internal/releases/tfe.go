@@ -0,0 +1,68 @@
+package releases
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/admin/terraform-version-policy", a.getVersionPolicy).Methods("GET")
+	r.HandleFunc("/admin/terraform-version-policy", a.updateVersionPolicy).Methods("PATCH")
+}
+
+func (a *tfe) getVersionPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := a.GetVersionPolicy(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toVersionPolicy(policy), http.StatusOK)
+}
+
+func (a *tfe) updateVersionPolicy(w http.ResponseWriter, r *http.Request) {
+	var params types.TerraformVersionPolicyUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	policy, err := a.UpdateVersionPolicy(r.Context(), UpdateVersionPolicyOptions{
+		MinVersion:     params.MinVersion,
+		MaxVersion:     params.MaxVersion,
+		DefaultVersion: params.DefaultVersion,
+	})
+	if errors.Is(err, ErrInvalidVersionPolicy) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toVersionPolicy(policy), http.StatusOK)
+}
+
+func (a *tfe) toVersionPolicy(from *VersionPolicy) *types.TerraformVersionPolicy {
+	return &types.TerraformVersionPolicy{
+		ID:             "site",
+		MinVersion:     from.MinVersion,
+		MaxVersion:     from.MaxVersion,
+		DefaultVersion: from.DefaultVersion,
+		UpdatedAt:      from.UpdatedAt,
+		UpdatedBy:      from.UpdatedBy,
+	}
+}
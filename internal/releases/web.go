@@ -0,0 +1,81 @@
+package releases
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+type webHandlers struct {
+	html.Renderer
+
+	svc webClient
+}
+
+type webClient interface {
+	GetVersionPolicy(ctx context.Context) (*VersionPolicy, error)
+	UpdateVersionPolicy(ctx context.Context, opts UpdateVersionPolicyOptions) (*VersionPolicy, error)
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/admin/terraform-version-policy", h.getVersionPolicy).Methods("GET")
+	r.HandleFunc("/admin/terraform-version-policy/update", h.updateVersionPolicy).Methods("POST")
+}
+
+func (h *webHandlers) getVersionPolicy(w http.ResponseWriter, r *http.Request) {
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	policy, err := h.svc.GetVersionPolicy(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.Render("terraform_version_policy_get.tmpl", w, struct {
+		html.SitePage
+		*VersionPolicy
+		BuiltinDefault string
+		CanUpdate      bool
+	}{
+		SitePage:       html.NewSitePage(r, "terraform version policy"),
+		VersionPolicy:  policy,
+		BuiltinDefault: DefaultTerraformVersion,
+		CanUpdate:      subject.CanAccessSite(rbac.UpdateTerraformVersionPolicyAction),
+	})
+}
+
+func (h *webHandlers) updateVersionPolicy(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		MinVersion     string `schema:"min_version"`
+		MaxVersion     string `schema:"max_version"`
+		DefaultVersion string `schema:"default_version"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.svc.UpdateVersionPolicy(r.Context(), UpdateVersionPolicyOptions{
+		MinVersion:     params.MinVersion,
+		MaxVersion:     params.MaxVersion,
+		DefaultVersion: params.DefaultVersion,
+	})
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.TerraformVersionPolicy(), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "updated terraform version policy")
+	http.Redirect(w, r, paths.TerraformVersionPolicy(), http.StatusFound)
+}
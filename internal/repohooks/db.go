@@ -2,6 +2,7 @@ package repohooks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -120,6 +121,23 @@ func (db *db) updateHookCloudID(ctx context.Context, id uuid.UUID, cloudID strin
 	})
 }
 
+// updateRepoPath updates the repo path of the hooks of a repo that has been
+// renamed or transferred.
+func (db *db) updateRepoPath(ctx context.Context, vcsProviderID, from, to string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpdateRepohooksRepoPath(ctx, pggen.UpdateRepohooksRepoPathParams{
+			ToRepoPath:    sql.String(to),
+			VCSProviderID: sql.String(vcsProviderID),
+			FromRepoPath:  sql.String(from),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+
+		return nil
+	})
+}
+
 func (db *db) deleteHook(ctx context.Context, id uuid.UUID) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.DeleteRepohookByID(ctx, sql.UUID(id))
@@ -147,3 +165,106 @@ func (db *db) fromRow(row hookRow) (*hook, error) {
 
 	return newRepohook(opts)
 }
+
+func (db *db) createDelivery(ctx context.Context, d *Delivery) error {
+	headers, err := json.Marshal(d.Headers)
+	if err != nil {
+		return err
+	}
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertRepohookDelivery(ctx, pggen.InsertRepohookDeliveryParams{
+			RepohookDeliveryID: sql.String(d.ID),
+			RepohookID:         sql.UUID(d.RepohookID),
+			ReceivedAt:         sql.Timestamptz(d.ReceivedAt),
+			Headers:            headers,
+			Body:               d.Body,
+			Status:             sql.String(string(d.Status)),
+			Error:              sql.StringPtr(d.Error),
+			ReplayOf:           sql.StringPtr(d.ReplayOf),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		_, err = q.TrimRepohookDeliveries(ctx, sql.UUID(d.RepohookID), sql.Int8(maxDeliveries))
+		return sql.Error(err)
+	})
+}
+
+func (db *db) getDelivery(ctx context.Context, id string) (*Delivery, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Delivery, error) {
+		row, err := q.FindRepohookDeliveryByID(ctx, sql.String(id))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		d := &Delivery{
+			ID:           row.RepohookDeliveryID.String,
+			RepohookID:   row.RepohookID.Bytes,
+			ReceivedAt:   row.ReceivedAt.Time.UTC(),
+			Body:         row.Body,
+			Status:       DeliveryStatus(row.Status.String),
+			organization: row.OrganizationName.String,
+			hasBody:      row.Body != nil,
+		}
+		if err := json.Unmarshal(row.Headers, &d.Headers); err != nil {
+			return nil, err
+		}
+		if row.Error.Valid {
+			d.Error = &row.Error.String
+		}
+		if row.ReplayOf.Valid {
+			d.ReplayOf = &row.ReplayOf.String
+		}
+		if row.TriggerDecisions != nil {
+			if err := json.Unmarshal(row.TriggerDecisions, &d.TriggerDecisions); err != nil {
+				return nil, err
+			}
+		}
+		return d, nil
+	})
+}
+
+func (db *db) listDeliveries(ctx context.Context, vcsProviderID string) ([]*Delivery, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Delivery, error) {
+		rows, err := q.FindRepohookDeliveriesByVCSProviderID(ctx, sql.String(vcsProviderID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		deliveries := make([]*Delivery, len(rows))
+		for i, row := range rows {
+			d := &Delivery{
+				ID:         row.RepohookDeliveryID.String,
+				RepohookID: row.RepohookID.Bytes,
+				RepoPath:   row.RepoPath.String,
+				ReceivedAt: row.ReceivedAt.Time.UTC(),
+				Status:     DeliveryStatus(row.Status.String),
+				hasBody:    row.HasBody.Bool,
+			}
+			if row.Error.Valid {
+				d.Error = &row.Error.String
+			}
+			if row.ReplayOf.Valid {
+				d.ReplayOf = &row.ReplayOf.String
+			}
+			if row.TriggerDecisions != nil {
+				if err := json.Unmarshal(row.TriggerDecisions, &d.TriggerDecisions); err != nil {
+					return nil, err
+				}
+			}
+			deliveries[i] = d
+		}
+
+		return deliveries, nil
+	})
+}
+
+func (db *db) updateTriggerDecisions(ctx context.Context, deliveryID string, decisions []TriggerDecision) error {
+	encoded, err := json.Marshal(decisions)
+	if err != nil {
+		return err
+	}
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpdateRepohookDeliveryTriggerDecisions(ctx, encoded, sql.String(deliveryID))
+		return sql.Error(err)
+	})
+}
@@ -0,0 +1,200 @@
+package repohooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tofutf/tofutf/internal"
+)
+
+const (
+	// MaxDeliveryBodySize is the maximum size of a request body persisted in
+	// the delivery log. Deliveries with larger bodies are still recorded but
+	// cannot be replayed.
+	MaxDeliveryBodySize = 1024 * 1024
+	// DefaultReplayMaxAge is the default maximum age of a delivery that may be
+	// replayed.
+	DefaultReplayMaxAge = 7 * 24 * time.Hour
+
+	// maxDeliveries is the maximum number of deliveries retained for each
+	// repohook. Older deliveries are discarded.
+	maxDeliveries = 100
+
+	// redacted replaces secrets in persisted headers.
+	redacted = "REDACTED"
+)
+
+var (
+	ErrReplayTooOld     = errors.New("delivery is too old to be replayed")
+	ErrReplayNoBody     = errors.New("delivery was too large to be persisted and cannot be replayed")
+	ErrReplayNotAllowed = errors.New("delivery failed to be processed and cannot be replayed")
+)
+
+// secretHeaders are request headers sent by VCS providers that either contain
+// the webhook secret or are signed with it.
+var secretHeaders = []string{
+	"X-Hub-Signature",     // github (sha1) and bitbucket server (sha256)
+	"X-Hub-Signature-256", // github
+	"X-Gitlab-Token",      // gitlab
+}
+
+// DeliveryStatus is the outcome of processing a delivery.
+type DeliveryStatus string
+
+const (
+	// DeliveryPublished is a delivery that was successfully unmarshaled and
+	// its event published.
+	DeliveryPublished DeliveryStatus = "published"
+	// DeliveryIgnored is a delivery that was deliberately ignored.
+	DeliveryIgnored DeliveryStatus = "ignored"
+	// DeliveryFailed is a delivery that failed to be unmarshaled, either
+	// because it failed validation or because it could not be parsed.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Delivery is a request received from a VCS provider on a repohook, recorded
+// in the delivery log.
+type Delivery struct {
+	ID         string
+	RepohookID uuid.UUID
+	// RepoPath is the repo from which the delivery originated.
+	RepoPath   string
+	ReceivedAt time.Time
+	// Headers are the request headers, with secrets redacted.
+	Headers http.Header
+	// Body is the request body. It is nil if the body exceeded
+	// MaxDeliveryBodySize.
+	Body   []byte
+	Status DeliveryStatus
+	// Error explains why the delivery failed or was ignored.
+	Error *string
+	// ReplayOf is the ID of the original delivery if this delivery is a
+	// replay, unless the original delivery has since been trimmed.
+	ReplayOf *string
+	// TriggerDecisions record, for each workspace connected to the repo,
+	// whether the event triggered a run and why. Nil until the event has
+	// been handled.
+	TriggerDecisions []TriggerDecision
+
+	// organization to which the delivery belongs; used for authorization.
+	organization string
+	// hasBody is true if the body was persisted; used in listings, which
+	// omit the body.
+	hasBody bool
+}
+
+// TriggerDecision records whether the event in a delivery triggered a run in
+// a workspace, and why.
+type TriggerDecision struct {
+	WorkspaceID   string `json:"workspace_id"`
+	WorkspaceName string `json:"workspace_name"`
+	Triggered     bool   `json:"triggered"`
+	Reason        string `json:"reason"`
+}
+
+func newDelivery(hookID uuid.UUID, headers http.Header, body []byte) *Delivery {
+	d := &Delivery{
+		ID:         internal.NewID("rhd"),
+		RepohookID: hookID,
+		ReceivedAt: internal.CurrentTimestamp(nil),
+		Headers:    redactHeaders(headers),
+	}
+	if len(body) <= MaxDeliveryBodySize {
+		d.Body = body
+		d.hasBody = true
+	}
+	return d
+}
+
+// Replayable determines whether the delivery can in principle be replayed,
+// regardless of its age. Failed deliveries cannot be replayed because it
+// cannot be established that they were sent by the VCS provider.
+func (d *Delivery) Replayable() bool {
+	return d.hasBody && d.Status != DeliveryFailed
+}
+
+func (d *Delivery) canReplay(now time.Time, maxAge time.Duration) error {
+	if !d.hasBody {
+		return ErrReplayNoBody
+	}
+	if d.Status == DeliveryFailed {
+		return ErrReplayNotAllowed
+	}
+	if now.Sub(d.ReceivedAt) > maxAge {
+		return ErrReplayTooOld
+	}
+	return nil
+}
+
+func (d *Delivery) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", d.ID),
+		slog.String("repohook_id", d.RepohookID.String()),
+		slog.String("status", string(d.Status)),
+	}
+	if d.ReplayOf != nil {
+		attrs = append(attrs, slog.String("replay_of", *d.ReplayOf))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// redactHeaders returns a copy of the headers with secrets redacted. The
+// algorithm prefix of a signature is retained in order that the signature can
+// be re-computed upon replay.
+func redactHeaders(src http.Header) http.Header {
+	dst := src.Clone()
+	dst.Del("Authorization")
+	dst.Del("Cookie")
+	for _, name := range secretHeaders {
+		v := dst.Get(name)
+		if v == "" {
+			continue
+		}
+		if algo, _, ok := strings.Cut(v, "="); ok {
+			dst.Set(name, algo+"="+redacted)
+		} else {
+			dst.Set(name, redacted)
+		}
+	}
+	return dst
+}
+
+// restoreHeaders returns a copy of redacted headers with their secrets
+// restored, re-computing signatures of the body using the secret.
+func restoreHeaders(src http.Header, body []byte, secret string) http.Header {
+	dst := src.Clone()
+	for _, name := range secretHeaders {
+		v := dst.Get(name)
+		if v == "" {
+			continue
+		}
+		algo, _, ok := strings.Cut(v, "=")
+		if !ok {
+			// unsigned; the header is the secret itself
+			dst.Set(name, secret)
+			continue
+		}
+		var h func() hash.Hash
+		switch algo {
+		case "sha1":
+			h = sha1.New
+		case "sha256":
+			h = sha256.New
+		default:
+			continue
+		}
+		mac := hmac.New(h, []byte(secret))
+		mac.Write(body)
+		dst.Set(name, algo+"="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	return dst
+}
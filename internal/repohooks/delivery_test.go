@@ -0,0 +1,80 @@
+package repohooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelivery_RedactAndRestoreHeaders(t *testing.T) {
+	body := []byte(`{"ref":"refs/heads/main"}`)
+	secret := "top-secret"
+
+	// sign request as github would
+	signed := http.Header{}
+	signed.Set("Content-Type", "application/json")
+	signed.Set("X-Github-Event", "push")
+	signed.Set("X-Hub-Signature-256", "sha256="+hmacHex(t, body, secret))
+	signed.Set("X-Gitlab-Token", secret)
+
+	redactedHeaders := redactHeaders(signed)
+	assert.Equal(t, "sha256=REDACTED", redactedHeaders.Get("X-Hub-Signature-256"))
+	assert.Equal(t, "REDACTED", redactedHeaders.Get("X-Gitlab-Token"))
+	assert.Equal(t, "push", redactedHeaders.Get("X-Github-Event"))
+	// original headers should be left untouched
+	assert.Equal(t, secret, signed.Get("X-Gitlab-Token"))
+
+	restored := restoreHeaders(redactedHeaders, body, secret)
+	assert.Equal(t, signed, restored)
+
+	// restored request should pass validation
+	r, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+	require.NoError(t, err)
+	r.Header = restored
+	_, err = github.ValidatePayload(r, []byte(secret))
+	assert.NoError(t, err)
+}
+
+func TestDelivery_canReplay(t *testing.T) {
+	now := time.Now()
+	maxAge := time.Hour
+
+	tests := []struct {
+		name     string
+		delivery Delivery
+		want     error
+	}{
+		{"published", Delivery{Status: DeliveryPublished, ReceivedAt: now, hasBody: true}, nil},
+		{"ignored", Delivery{Status: DeliveryIgnored, ReceivedAt: now, hasBody: true}, nil},
+		{"failed", Delivery{Status: DeliveryFailed, ReceivedAt: now, hasBody: true}, ErrReplayNotAllowed},
+		{"body too large", Delivery{Status: DeliveryPublished, ReceivedAt: now}, ErrReplayNoBody},
+		{"too old", Delivery{Status: DeliveryPublished, ReceivedAt: now.Add(-2 * time.Hour), hasBody: true}, ErrReplayTooOld},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.delivery.canReplay(now, maxAge))
+		})
+	}
+}
+
+func TestNewDelivery_BodyTooLarge(t *testing.T) {
+	d := newDelivery([16]byte{}, http.Header{}, make([]byte, MaxDeliveryBodySize+1))
+	assert.Nil(t, d.Body)
+	assert.False(t, d.Replayable())
+}
+
+func hmacHex(t *testing.T, body []byte, secret string) string {
+	t.Helper()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
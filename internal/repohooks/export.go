@@ -0,0 +1,130 @@
+package repohooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/vcs"
+	"github.com/tofutf/tofutf/internal/vcsprovider"
+)
+
+var (
+	ErrHookConfigMissingRepoPath    = errors.New("webhook configuration is missing a repo path")
+	ErrHookConfigMissingVCSProvider = errors.New("webhook configuration is missing a vcs provider ID")
+	ErrHookConfigKindMismatch       = errors.New("webhook configuration's cloud does not match its vcs provider")
+)
+
+// HookConfig is the exportable configuration of a repohook. It omits the
+// hook's secret and its cloud ID, both of which are generated afresh when the
+// hook is re-registered on import.
+type HookConfig struct {
+	RepoPath      string   `json:"repo-path"`
+	Cloud         vcs.Kind `json:"cloud"`
+	VCSProviderID string   `json:"vcs-provider-id"`
+}
+
+func newHookConfig(h *hook) HookConfig {
+	return HookConfig{
+		RepoPath:      h.repoPath,
+		Cloud:         h.cloud,
+		VCSProviderID: h.vcsProviderID,
+	}
+}
+
+// validate checks the configuration references the given vcs provider.
+func (c HookConfig) validate(provider *vcsprovider.VCSProvider) error {
+	if c.RepoPath == "" {
+		return ErrHookConfigMissingRepoPath
+	}
+	if c.Cloud != "" && c.Cloud != provider.Kind {
+		return fmt.Errorf("%w: %s: cloud %s, vcs provider %s", ErrHookConfigKindMismatch, c.RepoPath, c.Cloud, provider.Kind)
+	}
+	return nil
+}
+
+// ExportRepohooks exports the configurations of the repohooks belonging to a
+// VCS provider.
+func (s *Service) ExportRepohooks(ctx context.Context, vcsProviderID string) ([]HookConfig, error) {
+	provider, err := s.vcsproviders.Get(ctx, vcsProviderID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.GetVCSProviderAction, provider.Organization)
+	if err != nil {
+		return nil, err
+	}
+	hooks, err := s.db.listHooks(ctx)
+	if err != nil {
+		s.logger.Error("exporting webhooks", "vcs_provider_id", vcsProviderID, "subject", subject, "err", err)
+		return nil, err
+	}
+	configs := []HookConfig{}
+	for _, h := range hooks {
+		if h.vcsProviderID == vcsProviderID {
+			configs = append(configs, newHookConfig(h))
+		}
+	}
+	s.logger.Info("exported webhooks", "vcs_provider_id", vcsProviderID, "subject", subject, "count", len(configs))
+	return configs, nil
+}
+
+// ImportRepohooks imports repohook configurations, re-registering each hook
+// on its repo with a freshly generated secret. Every configuration is
+// validated before any hook is registered: its vcs provider must exist and be
+// accessible, its cloud must match the provider's kind, and its repo must
+// exist.
+func (s *Service) ImportRepohooks(ctx context.Context, configs []HookConfig) ([]uuid.UUID, error) {
+	for _, cfg := range configs {
+		if err := s.validateHookConfig(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+	ids := make([]uuid.UUID, len(configs))
+	for i, cfg := range configs {
+		id, err := s.CreateRepohook(ctx, CreateRepohookOptions{
+			VCSProviderID: cfg.VCSProviderID,
+			RepoPath:      cfg.RepoPath,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("importing webhook for %s: %w", cfg.RepoPath, err)
+		}
+		ids[i] = id
+	}
+	s.logger.Info("imported webhooks", "count", len(configs))
+	return ids, nil
+}
+
+func (s *Service) validateHookConfig(ctx context.Context, cfg HookConfig) error {
+	if cfg.VCSProviderID == "" {
+		return ErrHookConfigMissingVCSProvider
+	}
+	provider, err := s.vcsproviders.Get(ctx, cfg.VCSProviderID)
+	if err != nil {
+		return fmt.Errorf("retrieving vcs provider %s: %w", cfg.VCSProviderID, err)
+	}
+	if _, err := s.organization.CanAccess(ctx, rbac.CreateVCSProviderAction, provider.Organization); err != nil {
+		return err
+	}
+	if err := cfg.validate(provider); err != nil {
+		return err
+	}
+	client, err := s.vcsproviders.GetVCSClient(ctx, cfg.VCSProviderID)
+	if err != nil {
+		return fmt.Errorf("retrieving vcs client: %w", err)
+	}
+	if _, err := client.GetRepository(ctx, cfg.RepoPath); err != nil {
+		return fmt.Errorf("checking repository %s exists: %w", cfg.RepoPath, err)
+	}
+	return nil
+}
+
+// isHookConfigError determines whether err is the result of an invalid
+// webhook configuration.
+func isHookConfigError(err error) bool {
+	return errors.Is(err, ErrHookConfigMissingRepoPath) ||
+		errors.Is(err, ErrHookConfigMissingVCSProvider) ||
+		errors.Is(err, ErrHookConfigKindMismatch)
+}
@@ -0,0 +1,89 @@
+package repohooks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/vcs"
+	"github.com/tofutf/tofutf/internal/vcsprovider"
+)
+
+func TestHookConfig_RoundTrip(t *testing.T) {
+	hostname := internal.NewHostnameService("fakehost.org")
+	original, err := newRepohook(newRepohookOptions{
+		vcsProviderID:   "vcs-123",
+		secret:          internal.String("top-secret"),
+		repoPath:        "leg100/tofutf",
+		cloud:           vcs.GithubKind,
+		cloudID:         internal.String("123"),
+		HostnameService: hostname,
+	})
+	require.NoError(t, err)
+
+	exported, err := json.Marshal([]HookConfig{newHookConfig(original)})
+	require.NoError(t, err)
+	assert.NotContains(t, string(exported), "top-secret")
+
+	var imported []HookConfig
+	require.NoError(t, json.Unmarshal(exported, &imported))
+	require.Len(t, imported, 1)
+	assert.Equal(t, HookConfig{RepoPath: "leg100/tofutf", Cloud: vcs.GithubKind, VCSProviderID: "vcs-123"}, imported[0])
+
+	provider := &vcsprovider.VCSProvider{ID: "vcs-123", Kind: vcs.GithubKind}
+	require.NoError(t, imported[0].validate(provider))
+
+	// re-register hook
+	recreated, err := newRepohook(newRepohookOptions{
+		vcsProviderID:   imported[0].VCSProviderID,
+		repoPath:        imported[0].RepoPath,
+		cloud:           imported[0].Cloud,
+		HostnameService: hostname,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, newHookConfig(original), newHookConfig(recreated))
+	assert.NotEqual(t, original.secret, recreated.secret)
+	assert.NotEqual(t, original.id, recreated.id)
+	assert.Nil(t, recreated.cloudID)
+}
+
+func TestHookConfig_Validate(t *testing.T) {
+	provider := &vcsprovider.VCSProvider{ID: "vcs-123", Kind: vcs.GithubKind}
+
+	tests := []struct {
+		name    string
+		cfg     HookConfig
+		wantErr error
+	}{
+		{
+			name: "valid",
+			cfg:  HookConfig{RepoPath: "leg100/tofutf", Cloud: vcs.GithubKind, VCSProviderID: "vcs-123"},
+		},
+		{
+			name: "cloud omitted",
+			cfg:  HookConfig{RepoPath: "leg100/tofutf", VCSProviderID: "vcs-123"},
+		},
+		{
+			name:    "missing repo path",
+			cfg:     HookConfig{Cloud: vcs.GithubKind, VCSProviderID: "vcs-123"},
+			wantErr: ErrHookConfigMissingRepoPath,
+		},
+		{
+			name:    "cloud does not match vcs provider",
+			cfg:     HookConfig{RepoPath: "leg100/tofutf", Cloud: vcs.GitlabKind, VCSProviderID: "vcs-123"},
+			wantErr: ErrHookConfigKindMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validate(provider)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
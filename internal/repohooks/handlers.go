@@ -1,11 +1,15 @@
 package repohooks
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"path"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -14,6 +18,8 @@ import (
 	"github.com/tofutf/tofutf/internal/vcs"
 )
 
+var errNoUnmarshaler = errors.New("no event unmarshaler found for event")
+
 const (
 	// handlerPrefix is the URL path prefix for endpoints receiving vcs events
 	handlerPrefix = "/webhooks/vcs"
@@ -26,12 +32,17 @@ type (
 
 		cloudHandlers *internal.SafeMap[vcs.Kind, EventUnmarshaler]
 		logger        *slog.Logger
+		// unknownEvents determines for each cloud how events with an unknown
+		// type are handled.
+		unknownEvents map[vcs.Kind]vcs.UnknownEventPolicy
 
 		handlerDB
 	}
 
 	// EventUnmarshaler validates the request using the secret and unmarshals
-	// the event contained in the request body. If the request is to be ignored
+	// the event contained in the request body. The body may be JSON or
+	// form-encoded, according to the request's content type (see
+	// vcs.WebhookPayload). If the request is to be ignored
 	// then the unmarshaler should return vcs.ErrIgnoreEvent, explaining why the
 	// event was ignored.
 	EventUnmarshaler func(r *http.Request, secret string) (*vcs.EventPayload, error)
@@ -39,6 +50,7 @@ type (
 	// handleDB is the database the handler interacts with
 	handlerDB interface {
 		getHookByID(context.Context, uuid.UUID) (*hook, error)
+		createDelivery(context.Context, *Delivery) error
 	}
 )
 
@@ -52,10 +64,42 @@ func newHandler(logger *slog.Logger, publisher vcs.Publisher, db handlerDB) *han
 }
 
 func (h *handlers) AddHandlers(r *mux.Router) {
+	r.HandleFunc(handlerPrefix, h.kindsHandler).Methods("GET")
 	r.HandleFunc(path.Join(handlerPrefix, "{webhook_id}"), h.repohookHandler)
 }
 
+// kinds returns the kinds of VCS provider for which an event unmarshaler is
+// registered, sorted alphabetically.
+func (h *handlers) kinds() []vcs.Kind {
+	kinds := h.cloudHandlers.Keys()
+	slices.Sort(kinds)
+	return kinds
+}
+
+// kindsHandler lists the kinds of VCS provider whose events are supported.
+// The endpoint is unauthenticated, like the webhook endpoint, and reveals
+// nothing other than the kinds.
+func (h *handlers) kindsHandler(w http.ResponseWriter, r *http.Request) {
+	payload := struct {
+		Kinds []vcs.Kind `json:"kinds"`
+	}{
+		Kinds: h.kinds(),
+	}
+	w.Header().Set("Content-type", "application/json")
+	json.NewEncoder(w).Encode(payload) //nolint:errcheck
+}
+
 func (h *handlers) repohookHandler(w http.ResponseWriter, r *http.Request) {
+	// read body in order to persist it in the delivery log, and replace it
+	// for the benefit of the unmarshaler. The body must be read before
+	// decoding parameters, which would otherwise consume the body of a
+	// form-encoded request.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var opts struct {
 		ID uuid.UUID `schema:"webhook_id,required"`
 	}
@@ -70,27 +114,64 @@ func (h *handlers) repohookHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	h.logger.Debug("received vcs event", "repohook_id", opts.ID, "repo", hook.repoPath, "cloud", hook.cloud)
 
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	delivery := newDelivery(hook.id, r.Header, body)
+
+	err = h.handle(r, hook, delivery)
+	if errors.Is(err, errNoUnmarshaler) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+}
+
+// handle unmarshals the event in the request and publishes it, recording the
+// outcome in the delivery log.
+func (h *handlers) handle(r *http.Request, hook *hook, delivery *Delivery) error {
 	// look up cloud-specific handler for event
 	cloudHandler, ok := h.cloudHandlers.Get(hook.cloud)
 	if !ok {
-		h.logger.Error("no event unmarshaler found for event", "repohook_id", opts.ID, "repo", hook.repoPath, "cloud", hook.cloud)
-		http.Error(w, "no event unmarshaler found for event", http.StatusNotFound)
-		return
+		h.logger.Error("no event unmarshaler found for event", "repohook_id", hook.id, "repo", hook.repoPath, "cloud", hook.cloud)
+		return errNoUnmarshaler
 	}
 	// handle event
 	payload, err := cloudHandler(r, hook.secret)
+	err = h.unknownEvents[hook.cloud].Apply(err)
 	// either ignore the event, return an error, or publish the event onwards
 	var ignore vcs.ErrIgnoreEvent
 	if errors.As(err, &ignore) {
-		h.logger.Info("ignoring event: "+err.Error(), "repohook_id", opts.ID, "repo", hook.repoPath, "cloud", hook.cloud)
-		return
+		h.logger.Info("ignoring event: "+err.Error(), "repohook_id", hook.id, "repo", hook.repoPath, "cloud", hook.cloud)
+		delivery.Status = DeliveryIgnored
+		delivery.Error = internal.String(err.Error())
+		h.recordDelivery(r.Context(), delivery)
+		return nil
 	} else if err != nil {
-		h.logger.Error("handling vcs event", "repohook_id", opts.ID, "repo", hook.repoPath, "cloud", hook.cloud, "err", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		h.logger.Error("handling vcs event", "repohook_id", hook.id, "repo", hook.repoPath, "cloud", hook.cloud, "err", err)
+		delivery.Status = DeliveryFailed
+		delivery.Error = internal.String(err.Error())
+		h.recordDelivery(r.Context(), delivery)
+		return err
 	}
+	// record the delivery before publishing the event, so that the delivery
+	// exists by the time subscribers record their handling of the event.
+	delivery.Status = DeliveryPublished
+	h.recordDelivery(r.Context(), delivery)
 	h.Publish(vcs.Event{
-		EventHeader:  vcs.EventHeader{VCSProviderID: hook.vcsProviderID},
+		EventHeader: vcs.EventHeader{
+			VCSProviderID: hook.vcsProviderID,
+			DeliveryID:    delivery.ID,
+		},
 		EventPayload: *payload,
 	})
+	return nil
+}
+
+// recordDelivery persists the delivery in the delivery log. Failure to do so
+// is not deemed fatal to the handling of the event.
+func (h *handlers) recordDelivery(ctx context.Context, delivery *Delivery) {
+	if err := h.createDelivery(ctx, delivery); err != nil {
+		h.logger.Error("recording webhook delivery", "delivery", delivery, "err", err)
+	}
 }
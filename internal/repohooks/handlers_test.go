@@ -2,9 +2,12 @@ package repohooks
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -36,22 +39,136 @@ func Test_repohookHandler(t *testing.T) {
 	})
 
 	w := httptest.NewRecorder()
-	r := httptest.NewRequest("POST", "/?webhook_id=158c758a-7090-11ed-a843-d398c839c7ad", nil)
+	r := httptest.NewRequest("POST", "/?webhook_id=158c758a-7090-11ed-a843-d398c839c7ad", strings.NewReader(`{"foo":"bar"}`))
+	r.Header.Set("X-Hub-Signature-256", "sha256=abc123")
 	handler.repohookHandler(w, r)
 	assert.Equal(t, 200, w.Code, "response body: %s", w.Body.String())
 
+	// delivery should be recorded with its secret redacted
+	db := handler.handlerDB.(*fakeHandlerDB)
+	require.Len(t, db.deliveries, 1)
+
+	// event should reference the delivery
 	want := vcs.Event{
 		EventHeader: vcs.EventHeader{
 			VCSProviderID: "vcs-123",
+			DeliveryID:    db.deliveries[0].ID,
 		},
 		EventPayload: vcs.EventPayload{RepoPath: hook.repoPath},
 	}
 	assert.Equal(t, want, broker.got)
+	assert.Equal(t, DeliveryPublished, db.deliveries[0].Status)
+	assert.Equal(t, `{"foo":"bar"}`, string(db.deliveries[0].Body))
+	assert.Equal(t, "sha256=REDACTED", db.deliveries[0].Headers.Get("X-Hub-Signature-256"))
+}
+
+func Test_repohookHandler_FormEncoded(t *testing.T) {
+	hook, err := newRepohook(newRepohookOptions{
+		vcsProviderID:   "vcs-123",
+		cloud:           vcs.GithubKind,
+		HostnameService: internal.NewHostnameService("fakehost.org"),
+	})
+	require.NoError(t, err)
+
+	handler := newHandler(
+		slog.New(&xslog.NoopHandler{}),
+		&fakeBroker{},
+		&fakeHandlerDB{
+			hook: hook,
+		},
+	)
+	// the unmarshaler should receive the form-encoded body intact
+	var got string
+	handler.cloudHandlers.Set(vcs.GithubKind, func(r *http.Request, _ string) (*vcs.EventPayload, error) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		got = string(body)
+		return &vcs.EventPayload{}, nil
+	})
+
+	body := url.Values{"payload": []string{`{"foo":"bar"}`}}.Encode()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/?webhook_id=158c758a-7090-11ed-a843-d398c839c7ad", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	handler.repohookHandler(w, r)
+	assert.Equal(t, 200, w.Code, "response body: %s", w.Body.String())
+	assert.Equal(t, body, got)
+
+	db := handler.handlerDB.(*fakeHandlerDB)
+	require.Len(t, db.deliveries, 1)
+	assert.Equal(t, body, string(db.deliveries[0].Body))
+}
+
+func Test_repohookHandler_UnknownEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     vcs.UnknownEventPolicy
+		wantCode   int
+		wantStatus DeliveryStatus
+	}{
+		{"default", "", 200, DeliveryIgnored},
+		{"ignore", vcs.IgnoreUnknownEvents, 200, DeliveryIgnored},
+		{"reject", vcs.RejectUnknownEvents, 400, DeliveryFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook, err := newRepohook(newRepohookOptions{
+				vcsProviderID:   "vcs-123",
+				cloud:           vcs.GithubKind,
+				HostnameService: internal.NewHostnameService("fakehost.org"),
+			})
+			require.NoError(t, err)
+
+			broker := &fakeBroker{}
+			handler := newHandler(
+				slog.New(&xslog.NoopHandler{}),
+				broker,
+				&fakeHandlerDB{
+					hook: hook,
+				},
+			)
+			handler.unknownEvents = map[vcs.Kind]vcs.UnknownEventPolicy{vcs.GithubKind: tt.policy}
+			handler.cloudHandlers.Set(vcs.GithubKind, func(*http.Request, string) (*vcs.EventPayload, error) {
+				return nil, vcs.NewErrUnknownEvent("ping")
+			})
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/?webhook_id=158c758a-7090-11ed-a843-d398c839c7ad", strings.NewReader(`{"foo":"bar"}`))
+			handler.repohookHandler(w, r)
+			assert.Equal(t, tt.wantCode, w.Code, "response body: %s", w.Body.String())
+
+			// event should never be published
+			assert.Equal(t, vcs.Event{}, broker.got)
+
+			db := handler.handlerDB.(*fakeHandlerDB)
+			require.Len(t, db.deliveries, 1)
+			assert.Equal(t, tt.wantStatus, db.deliveries[0].Status)
+		})
+	}
+}
+
+func Test_kindsHandler(t *testing.T) {
+	handler := newHandler(slog.New(&xslog.NoopHandler{}), &fakeBroker{}, &fakeHandlerDB{})
+	handler.cloudHandlers.Set(vcs.GitlabKind, func(*http.Request, string) (*vcs.EventPayload, error) {
+		return nil, nil
+	})
+	handler.cloudHandlers.Set(vcs.GithubKind, func(*http.Request, string) (*vcs.EventPayload, error) {
+		return nil, nil
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	handler.kindsHandler(w, r)
+	assert.Equal(t, 200, w.Code, "response body: %s", w.Body.String())
+	assert.JSONEq(t, `{"kinds":["github","gitlab"]}`, w.Body.String())
 }
 
 type (
 	fakeHandlerDB struct {
-		hook *hook
+		hook       *hook
+		deliveries []*Delivery
 	}
 	fakeBroker struct {
 		got vcs.Event
@@ -62,4 +179,9 @@ func (db *fakeHandlerDB) getHookByID(context.Context, uuid.UUID) (*hook, error)
 	return db.hook, nil
 }
 
+func (db *fakeHandlerDB) createDelivery(_ context.Context, d *Delivery) error {
+	db.deliveries = append(db.deliveries, d)
+	return nil
+}
+
 func (f *fakeBroker) Publish(got vcs.Event) { f.got = got }
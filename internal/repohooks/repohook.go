@@ -14,6 +14,7 @@ import (
 var defaultEvents = []vcs.EventType{
 	vcs.EventTypePush,
 	vcs.EventTypePull,
+	vcs.EventTypeRepository,
 }
 
 type (
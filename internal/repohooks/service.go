@@ -1,16 +1,23 @@
 package repohooks
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/github"
+	"github.com/tofutf/tofutf/internal/http/html"
 	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
+	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/vcs"
 	"github.com/tofutf/tofutf/internal/vcsprovider"
 )
@@ -23,6 +30,12 @@ type (
 
 		logger       *slog.Logger
 		vcsproviders *vcsprovider.Service
+		organization internal.Authorizer
+		web          *webHandlers
+		tfeapi       *tfe
+
+		// replayMaxAge is the maximum age of a delivery that may be replayed.
+		replayMaxAge time.Duration
 	}
 
 	Options struct {
@@ -32,8 +45,18 @@ type (
 		VCSEventBroker      *vcs.Broker
 		Logger              *slog.Logger
 
+		// ReplayMaxAge is the maximum age of a delivery that may be replayed.
+		// Defaults to DefaultReplayMaxAge.
+		ReplayMaxAge time.Duration
+		// UnknownEventPolicies determines for each cloud how events with an
+		// unknown type are handled. Clouds without a policy ignore such
+		// events.
+		UnknownEventPolicies map[vcs.Kind]vcs.UnknownEventPolicy
+
 		*sql.Pool
 		*internal.HostnameService
+		*tfeapi.Responder
+		html.Renderer
 	}
 
 	CreateRepohookOptions struct {
@@ -54,6 +77,20 @@ func NewService(ctx context.Context, opts Options) *Service {
 			db,
 		),
 		synchroniser: &synchroniser{logger: opts.Logger, syncdb: db},
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		replayMaxAge: opts.ReplayMaxAge,
+	}
+	if svc.replayMaxAge == 0 {
+		svc.replayMaxAge = DefaultReplayMaxAge
+	}
+	svc.handlers.unknownEvents = opts.UnknownEventPolicies
+	svc.web = &webHandlers{
+		Renderer: opts.Renderer,
+		svc:      svc,
+	}
+	svc.tfeapi = &tfe{
+		Service:   svc,
+		Responder: opts.Responder,
 	}
 	// Delete webhooks prior to the deletion of VCS providers. VCS providers are
 	// necessary for the deletion of webhooks from VCS repos. Hence we need to
@@ -110,10 +147,100 @@ func (s *Service) CreateRepohook(ctx context.Context, opts CreateRepohookOptions
 	return hook.id, nil
 }
 
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.handlers.AddHandlers(r)
+	s.web.addHandlers(r)
+	s.tfeapi.addHandlers(r)
+}
+
 func (s *Service) RegisterCloudHandler(kind vcs.Kind, h EventUnmarshaler) {
 	s.handlers.cloudHandlers.Set(kind, h)
 }
 
+// CloudHandlerKinds lists the kinds of VCS provider for which an event
+// handler has been registered.
+func (s *Service) CloudHandlerKinds() []vcs.Kind {
+	return s.handlers.kinds()
+}
+
+// ListDeliveries lists the deliveries received by the repohooks of a VCS
+// provider, most recent first.
+func (s *Service) ListDeliveries(ctx context.Context, vcsProviderID string) ([]*Delivery, error) {
+	provider, err := s.vcsproviders.Get(ctx, vcsProviderID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.organization.CanAccess(ctx, rbac.ListWebhookDeliveriesAction, provider.Organization); err != nil {
+		return nil, err
+	}
+	return s.db.listDeliveries(ctx, vcsProviderID)
+}
+
+// Redeliver replays a delivery as if the VCS provider had re-sent it,
+// unmarshaling its event and publishing it once more. The replay is itself
+// recorded as a new delivery linked to the original. Replaying a replay
+// replays the original delivery.
+func (s *Service) Redeliver(ctx context.Context, deliveryID string) (*Delivery, error) {
+	original, err := s.db.getDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := s.organization.CanAccess(ctx, rbac.RedeliverWebhookDeliveryAction, original.organization)
+	if err != nil {
+		return nil, err
+	}
+	if original.ReplayOf != nil {
+		original, err = s.db.getDelivery(ctx, *original.ReplayOf)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := original.canReplay(internal.CurrentTimestamp(nil), s.replayMaxAge); err != nil {
+		s.logger.Error("replaying webhook delivery", "delivery", original, "subject", subject, "err", err)
+		return nil, err
+	}
+	hook, err := s.db.getHookByID(ctx, original.RepohookID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving webhook: %w", err)
+	}
+	r, err := http.NewRequestWithContext(ctx, "POST", hook.endpoint, bytes.NewReader(original.Body))
+	if err != nil {
+		return nil, err
+	}
+	r.Header = restoreHeaders(original.Headers, original.Body, hook.secret)
+
+	replay := newDelivery(hook.id, original.Headers, original.Body)
+	replay.ReplayOf = &original.ID
+	// the outcome of the replay is recorded in the replay's status, so the
+	// error is not returned to the caller.
+	_ = s.handle(r, hook, replay)
+	s.logger.Info("replayed webhook delivery", "delivery", replay, "subject", subject)
+	return replay, nil
+}
+
+// RecordTriggerDecisions records on a delivery the decisions taken on whether
+// its event triggered a run in each of the workspaces connected to the repo.
+func (s *Service) RecordTriggerDecisions(ctx context.Context, deliveryID string, decisions []TriggerDecision) error {
+	if err := s.db.updateTriggerDecisions(ctx, deliveryID, decisions); err != nil {
+		s.logger.Error("recording trigger decisions", "delivery_id", deliveryID, "err", err)
+		return err
+	}
+	s.logger.Debug("recorded trigger decisions", "delivery_id", deliveryID, "workspaces", len(decisions))
+	return nil
+}
+
+// UpdateRepoPath updates the path of the repo of any webhooks for a repo that
+// has been renamed or transferred. The vcs provider maintains the webhook on
+// the repo itself, so only the path stored in the database is updated.
+func (s *Service) UpdateRepoPath(ctx context.Context, vcsProviderID, from, to string) error {
+	if err := s.db.updateRepoPath(ctx, vcsProviderID, from, to); err != nil {
+		s.logger.Error("updating webhook repo path", "vcs_provider_id", vcsProviderID, "from", from, "to", to, "err", err)
+		return err
+	}
+	s.logger.Debug("updated webhook repo path", "vcs_provider_id", vcsProviderID, "from", from, "to", to)
+	return nil
+}
+
 func (s *Service) DeleteUnreferencedRepohooks(ctx context.Context) error {
 	hooks, err := s.db.listUnreferencedRepohooks(ctx)
 	if err != nil {
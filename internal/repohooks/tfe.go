@@ -0,0 +1,132 @@
+package repohooks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/vcs-providers/{vcs_provider_id}/webhook-deliveries", a.listDeliveries).Methods("GET")
+	r.HandleFunc("/webhook-deliveries/{delivery_id}/actions/redeliver", a.redeliver).Methods("POST")
+	r.HandleFunc("/vcs-providers/{vcs_provider_id}/webhooks/export", a.exportHooks).Methods("GET")
+	r.HandleFunc("/webhooks/import", a.importHooks).Methods("POST")
+}
+
+func (a *tfe) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	providerID, err := decode.Param("vcs_provider_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	deliveries, err := a.Service.ListDeliveries(r.Context(), providerID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	to := make([]*types.WebhookDelivery, len(deliveries))
+	for i, from := range deliveries {
+		to[i] = a.toDelivery(from)
+	}
+	a.Respond(w, r, to, http.StatusOK)
+}
+
+func (a *tfe) redeliver(w http.ResponseWriter, r *http.Request) {
+	deliveryID, err := decode.Param("delivery_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	replay, err := a.Service.Redeliver(r.Context(), deliveryID)
+	if errors.Is(err, ErrReplayTooOld) || errors.Is(err, ErrReplayNoBody) || errors.Is(err, ErrReplayNotAllowed) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toDelivery(replay), http.StatusCreated)
+}
+
+func (a *tfe) exportHooks(w http.ResponseWriter, r *http.Request) {
+	providerID, err := decode.Param("vcs_provider_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	configs, err := a.Service.ExportRepohooks(r.Context(), providerID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(configs); err != nil {
+		tfeapi.Error(w, err)
+	}
+}
+
+func (a *tfe) importHooks(w http.ResponseWriter, r *http.Request) {
+	var configs []HookConfig
+	if err := json.NewDecoder(r.Body).Decode(&configs); err != nil {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	}
+	ids, err := a.Service.ImportRepohooks(r.Context(), configs)
+	if isHookConfigError(err) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(ids); err != nil {
+		tfeapi.Error(w, err)
+	}
+}
+
+func (a *tfe) toDelivery(from *Delivery) *types.WebhookDelivery {
+	decisions := make([]types.WebhookDeliveryTriggerDecision, len(from.TriggerDecisions))
+	for i, d := range from.TriggerDecisions {
+		decisions[i] = types.WebhookDeliveryTriggerDecision{
+			WorkspaceID:   d.WorkspaceID,
+			WorkspaceName: d.WorkspaceName,
+			Triggered:     d.Triggered,
+			Reason:        d.Reason,
+		}
+	}
+	return &types.WebhookDelivery{
+		ID:         from.ID,
+		RepoPath:   from.RepoPath,
+		ReceivedAt: from.ReceivedAt,
+		Status:     string(from.Status),
+		Error:      from.Error,
+		ReplayOf:   from.ReplayOf,
+		Replayable: from.Replayable(),
+
+		TriggerDecisions: decisions,
+	}
+}
@@ -0,0 +1,84 @@
+package repohooks
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+type webHandlers struct {
+	html.Renderer
+
+	svc *Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/vcs-providers/{vcs_provider_id}/webhook-deliveries", h.listDeliveries).Methods("GET")
+	r.HandleFunc("/vcs-providers/{vcs_provider_id}/redeliver-webhook-delivery", h.redeliver).Methods("POST")
+}
+
+func (h *webHandlers) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	providerID, err := decode.Param("vcs_provider_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	provider, err := h.svc.vcsproviders.Get(r.Context(), providerID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	deliveries, err := h.svc.ListDeliveries(r.Context(), providerID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("webhook_delivery_list.tmpl", w, struct {
+		organization.OrganizationPage
+		VCSProviderID   string
+		VCSProviderName string
+		Deliveries      []*Delivery
+		CanRedeliver    bool
+	}{
+		OrganizationPage: organization.NewPage(r, "webhook deliveries", provider.Organization),
+		VCSProviderID:    provider.ID,
+		VCSProviderName:  provider.String(),
+		Deliveries:       deliveries,
+		CanRedeliver:     subject.CanAccessOrganization(rbac.RedeliverWebhookDeliveryAction, provider.Organization),
+	})
+}
+
+func (h *webHandlers) redeliver(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		VCSProviderID string `schema:"vcs_provider_id,required"`
+		DeliveryID    string `schema:"delivery_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	replay, err := h.svc.Redeliver(r.Context(), params.DeliveryID)
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.WebhookDeliveriesVCSProvider(params.VCSProviderID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "redelivered webhook delivery: "+string(replay.Status))
+	http.Redirect(w, r, paths.WebhookDeliveriesVCSProvider(params.VCSProviderID), http.StatusFound)
+}
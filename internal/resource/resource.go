@@ -4,6 +4,7 @@ package resource
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/tofutf/tofutf/internal"
 )
@@ -11,12 +12,29 @@ import (
 // A regular expression used to validate resource name.
 var validName = regexp.MustCompile(`^[a-zA-Z0-9\-_]+$`)
 
-func ValidateName(name *string) error {
+// NameOption applies an additional check to a resource name.
+type NameOption func(name string) error
+
+// LowercaseName is a NameOption that rejects names containing uppercase
+// letters.
+func LowercaseName(name string) error {
+	if name != strings.ToLower(name) {
+		return internal.ErrUppercaseName
+	}
+	return nil
+}
+
+func ValidateName(name *string, opts ...NameOption) error {
 	if name == nil {
 		return internal.ErrRequiredName
 	}
 	if !validName.MatchString(*name) {
 		return internal.ErrInvalidName
 	}
+	for _, fn := range opts {
+		if err := fn(*name); err != nil {
+			return err
+		}
+	}
 	return nil
 }
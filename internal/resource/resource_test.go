@@ -11,16 +11,21 @@ func TestValidateName(t *testing.T) {
 	tests := []struct {
 		name         string
 		resourceName *string
+		opts         []NameOption
 		want         error
 	}{
-		{"nil", nil, internal.ErrRequiredName},
-		{"dot", internal.String("."), internal.ErrInvalidName},
-		{"underscore", internal.String("_"), nil},
-		{"acme-corp", internal.String("acme-corp"), nil},
+		{"nil", nil, nil, internal.ErrRequiredName},
+		{"dot", internal.String("."), nil, internal.ErrInvalidName},
+		{"underscore", internal.String("_"), nil, nil},
+		{"acme-corp", internal.String("acme-corp"), nil, nil},
+		{"mixed case", internal.String("Acme-Corp"), nil, nil},
+		{"lowercase", internal.String("acme-corp"), []NameOption{LowercaseName}, nil},
+		{"uppercase when lowercase required", internal.String("Acme-Corp"), []NameOption{LowercaseName}, internal.ErrUppercaseName},
+		{"invalid before lowercase check", internal.String("Acme.Corp"), []NameOption{LowercaseName}, internal.ErrInvalidName},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateName(tt.resourceName)
+			err := ValidateName(tt.resourceName, tt.opts...)
 			assert.Equal(t, tt.want, err)
 		})
 	}
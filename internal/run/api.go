@@ -2,22 +2,62 @@ package run
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
 	otfapi "github.com/tofutf/tofutf/internal/api"
 	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 )
 
-type api struct {
-	*Service
-	*tfeapi.Responder
-	logger *slog.Logger
+type (
+	api struct {
+		HandlerClient
+		*tfeapi.Responder
+		logger *slog.Logger
+
+		// maxArtifactSize is the maximum size in bytes of an uploaded
+		// artifact.
+		maxArtifactSize int
+	}
+
+	// HandlerClient provides the OTF API handlers with access to runs. It is
+	// implemented by Service, and can be implemented by a fake in order to
+	// serve the API handlers without a database.
+	HandlerClient interface {
+		Get(ctx context.Context, runID string) (*Run, error)
+		List(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error)
+		GetPlanFile(ctx context.Context, runID string, format PlanFormat) ([]byte, error)
+		UploadPlanFile(ctx context.Context, runID string, plan []byte, format PlanFormat) error
+		GetLockFile(ctx context.Context, runID string) ([]byte, error)
+		UploadLockFile(ctx context.Context, runID string, file []byte) error
+		UploadModuleManifest(ctx context.Context, runID string, manifest []byte) error
+		ListArtifacts(ctx context.Context, runID string) ([]*Artifact, error)
+		GetArtifact(ctx context.Context, runID, name string) (*Artifact, error)
+		UploadArtifact(ctx context.Context, runID, name, contentType string, data []byte) (*Artifact, error)
+	}
+)
+
+// NewHandlers constructs the handlers for the OTF runs API, using client to
+// access runs. The TFE and web UI handlers are not included.
+func NewHandlers(client HandlerClient, responder *tfeapi.Responder, logger *slog.Logger) internal.Handlers {
+	return &api{
+		HandlerClient:   client,
+		Responder:       responder,
+		logger:          logger,
+		maxArtifactSize: DefaultMaxArtifactSize,
+	}
 }
 
+func (a *api) AddHandlers(r *mux.Router) { a.addHandlers(r) }
+
 func (a *api) addHandlers(r *mux.Router) {
 	r = r.PathPrefix(otfapi.DefaultBasePath).Subrouter()
 	r.HandleFunc("/runs", a.list).Methods("GET")
@@ -26,6 +66,10 @@ func (a *api) addHandlers(r *mux.Router) {
 	r.HandleFunc("/runs/{id}/planfile", a.uploadPlanFile).Methods("PUT")
 	r.HandleFunc("/runs/{id}/lockfile", a.getLockFile).Methods("GET")
 	r.HandleFunc("/runs/{id}/lockfile", a.uploadLockFile).Methods("PUT")
+	r.HandleFunc("/runs/{id}/module-manifest", a.uploadModuleManifest).Methods("PUT")
+	r.HandleFunc("/runs/{id}/artifacts", a.listArtifacts).Methods("GET")
+	r.HandleFunc("/runs/{id}/artifacts/{name}", a.getArtifact).Methods("GET")
+	r.HandleFunc("/runs/{id}/artifacts/{name}", a.uploadArtifact).Methods("PUT")
 }
 
 func (a *api) list(w http.ResponseWriter, r *http.Request) {
@@ -135,3 +179,96 @@ func (a *api) uploadLockFile(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusAccepted)
 }
+
+func (a *api) uploadModuleManifest(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, r.Body); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := a.UploadModuleManifest(r.Context(), id, buf.Bytes()); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *api) listArtifacts(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	artifacts, err := a.ListArtifacts(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, artifacts, http.StatusOK)
+}
+
+func (a *api) getArtifact(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID string `schema:"id,required"`
+		Name  string `schema:"name,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	artifact, err := a.GetArtifact(r.Context(), params.RunID, params.Name)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	writeArtifact(w, artifact)
+}
+
+func (a *api) uploadArtifact(w http.ResponseWriter, r *http.Request) {
+	// take the parameters from the path alone; parsing the form would consume
+	// the body of an artifact with a form content type.
+	vars := mux.Vars(r)
+	runID, name := vars["id"], vars["name"]
+	// read one byte more than the maximum size so that an oversized artifact
+	// is rejected rather than truncated.
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, io.LimitReader(r.Body, int64(a.maxArtifactSize)+1)); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	artifact, err := a.UploadArtifact(r.Context(), runID, name, r.Header.Get("Content-Type"), buf.Bytes())
+	if err != nil {
+		tfeapi.Error(w, artifactHTTPError(err))
+		return
+	}
+	a.Respond(w, r, artifact, http.StatusCreated)
+}
+
+// writeArtifact writes the data of an artifact as a download.
+func writeArtifact(w http.ResponseWriter, artifact *Artifact) {
+	w.Header().Set("Content-Type", artifact.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", artifact.Name))
+	if _, err := w.Write(artifact.Data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// artifactHTTPError assigns an HTTP status code to an error uploading an
+// artifact.
+func artifactHTTPError(err error) error {
+	switch {
+	case errors.Is(err, ErrArtifactTooLarge):
+		return &internal.HTTPError{Code: http.StatusRequestEntityTooLarge, Message: err.Error()}
+	case errors.Is(err, ErrTooManyArtifacts):
+		return &internal.HTTPError{Code: http.StatusConflict, Message: err.Error()}
+	case errors.Is(err, ErrInvalidArtifactName), errors.Is(err, ErrEmptyArtifact), errors.Is(err, ErrInvalidArtifactContentType):
+		return &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()}
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+package run
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/resource"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// ApplyWindowLockID guarantees only one apply window releaser on a cluster is
+// running at any time.
+const ApplyWindowLockID int64 = 5577006791947779414
+
+var defaultApplyWindowInterval = time.Minute
+
+type (
+	// ApplyWindowReleaser enqueues applies for runs that are awaiting an apply
+	// window once their workspace's window opens.
+	ApplyWindowReleaser struct {
+		Logger     *slog.Logger
+		Workspaces applyWindowWorkspaceClient
+		Runs       applyWindowRunClient
+
+		// frequency with which runs are checked; defaults to every minute.
+		interval time.Duration
+	}
+
+	applyWindowWorkspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+
+	applyWindowRunClient interface {
+		List(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error)
+		Apply(ctx context.Context, runID string) error
+	}
+)
+
+// Start starts the releaser. Should be invoked in a go routine.
+func (r *ApplyWindowReleaser) Start(ctx context.Context) error {
+	interval := r.interval
+	if interval == 0 {
+		interval = defaultApplyWindowInterval
+	}
+	// run at startup and then every interval
+	if err := r.release(ctx); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.release(ctx); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// release enqueues applies for those runs awaiting an apply window whose
+// workspace now has an open window.
+func (r *ApplyWindowReleaser) release(ctx context.Context) error {
+	runs, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Run], error) {
+		return r.Runs.List(ctx, ListOptions{
+			PageOptions: opts,
+			Statuses:    []Status{RunAwaitingWindow},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	now := internal.CurrentTimestamp(nil)
+	for _, run := range runs {
+		ws, err := r.Workspaces.Get(ctx, run.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		if !ws.ApplyWindows.Open(now) {
+			continue
+		}
+		if err := r.Runs.Apply(ctx, run.ID); err != nil {
+			// the run may have been discarded or canceled in the meantime,
+			// so log the error rather than stopping the releaser.
+			r.Logger.Error("releasing run awaiting apply window", "id", run.ID, "err", err)
+			continue
+		}
+		r.Logger.Info("released run awaiting apply window", "id", run.ID)
+	}
+	return nil
+}
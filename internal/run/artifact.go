@@ -0,0 +1,92 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"regexp"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+)
+
+const (
+	// DefaultMaxArtifactSize is the default maximum size in bytes of a run
+	// artifact.
+	DefaultMaxArtifactSize = 10 * 1024 * 1024
+	// DefaultMaxArtifacts is the default maximum number of artifacts per run.
+	DefaultMaxArtifacts = 20
+
+	defaultArtifactContentType = "application/octet-stream"
+)
+
+var (
+	ErrInvalidArtifactName        = errors.New("artifact name must be 1-128 characters consisting of letters, numbers, '.', '_' and '-', and must not begin with '.'")
+	ErrEmptyArtifact              = errors.New("artifact cannot be empty")
+	ErrArtifactTooLarge           = errors.New("artifact exceeds maximum size")
+	ErrTooManyArtifacts           = errors.New("run has reached the maximum number of artifacts")
+	ErrInvalidArtifactContentType = errors.New("invalid artifact content type")
+
+	artifactNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_\-][a-zA-Z0-9._\-]{0,127}$`)
+)
+
+type (
+	// Artifact is a file attached to a run, such as an SBOM or a policy
+	// report, uploaded by the job carrying out the run. Uploading an artifact
+	// with the same name as an existing artifact replaces it. Artifacts are
+	// deleted along with their run.
+	Artifact struct {
+		ID          string    `jsonapi:"primary,run-artifacts"`
+		RunID       string    `jsonapi:"attribute" json:"run_id"`
+		Name        string    `jsonapi:"attribute" json:"name"`
+		ContentType string    `jsonapi:"attribute" json:"content_type"`
+		Size        int       `jsonapi:"attribute" json:"size"`
+		CreatedAt   time.Time `jsonapi:"attribute" json:"created_at"`
+		// Data is only populated when retrieving an individual artifact.
+		Data []byte `json:"-"`
+	}
+
+	// artifactLimits are the limits on the artifacts uploaded to a run.
+	artifactLimits struct {
+		// maxSize is the maximum size in bytes of an artifact.
+		maxSize int
+		// maxCount is the maximum number of artifacts per run.
+		maxCount int
+	}
+)
+
+// NewArtifact constructs an artifact for a run, applying the same validation as
+// the service using the default limits. It is intended for implementations of
+// the service other than Service, such as fakes.
+func NewArtifact(runID, name, contentType string, data []byte) (*Artifact, error) {
+	return newArtifact(runID, name, contentType, data, artifactLimits{
+		maxSize:  DefaultMaxArtifactSize,
+		maxCount: DefaultMaxArtifacts,
+	})
+}
+
+func newArtifact(runID, name, contentType string, data []byte, limits artifactLimits) (*Artifact, error) {
+	if !artifactNameRegex.MatchString(name) {
+		return nil, ErrInvalidArtifactName
+	}
+	if len(data) == 0 {
+		return nil, ErrEmptyArtifact
+	}
+	if len(data) > limits.maxSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrArtifactTooLarge, limits.maxSize)
+	}
+	if contentType == "" {
+		contentType = defaultArtifactContentType
+	} else if _, _, err := mime.ParseMediaType(contentType); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArtifactContentType, err)
+	}
+	return &Artifact{
+		ID:          internal.NewID("rart"),
+		RunID:       runID,
+		Name:        name,
+		ContentType: contentType,
+		Size:        len(data),
+		Data:        data,
+		CreatedAt:   internal.CurrentTimestamp(nil),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewArtifact(t *testing.T) {
+	limits := artifactLimits{maxSize: 10, maxCount: 1}
+
+	t.Run("defaults content type", func(t *testing.T) {
+		got, err := newArtifact("run-123", "sbom.json", "", []byte("{}"), limits)
+		require.NoError(t, err)
+		assert.Equal(t, "run-123", got.RunID)
+		assert.Equal(t, "application/octet-stream", got.ContentType)
+		assert.Equal(t, 2, got.Size)
+	})
+
+	tests := []struct {
+		name        string
+		artifact    string
+		contentType string
+		data        []byte
+		want        error
+	}{
+		{"valid", "policy-report_v1.txt", "text/plain; charset=utf-8", []byte("ok"), nil},
+		{"empty name", "", "", []byte("ok"), ErrInvalidArtifactName},
+		{"leading dot", ".kubeconfig", "", []byte("ok"), ErrInvalidArtifactName},
+		{"path separator", "../kubeconfig", "", []byte("ok"), ErrInvalidArtifactName},
+		{"empty", "sbom.json", "", nil, ErrEmptyArtifact},
+		{"too large", "sbom.json", "", []byte("01234567890"), ErrArtifactTooLarge},
+		{"invalid content type", "sbom.json", "application/", []byte("ok"), ErrInvalidArtifactContentType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newArtifact("run-123", tt.artifact, tt.contentType, tt.data, limits)
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+var (
+	ErrCanaryRunNotPlanned      = errors.New("a canary apply can only be created from a planned run")
+	ErrCanaryAlreadyCreated     = errors.New("run already has a canary run")
+	ErrCanaryNotApplied         = errors.New("canary run has not been applied")
+	ErrCanaryAlreadyProceeded   = errors.New("run has already proceeded to a full apply")
+	ErrNoCanaryAddrs            = errors.New("no resource addresses selected for the canary apply")
+	ErrCanaryAddrNotInPlan      = errors.New("address is not a planned resource change")
+	ErrCanaryIncludesAllChanges = errors.New("a canary apply must include fewer than all of the planned resource changes")
+)
+
+// Canary relates a parent run to its child runs: the canary run, which applies
+// a subset of the parent's planned resource changes, and the run that applies
+// the remainder once the canary has applied cleanly.
+type Canary struct {
+	// Canary is the canary run. Nil if the parent has no canary.
+	Canary *Run
+	// Full is the run applying all of the parent's changes, created with a
+	// freshly generated plan after the canary has applied. Nil if the parent
+	// has not yet proceeded to a full apply.
+	Full *Run
+}
+
+// newCanary constructs a canary from the children of a parent run, earliest
+// first. A parent run has at most two children: the first is its canary run,
+// the second its full run.
+func newCanary(children []*Run) Canary {
+	var c Canary
+	if len(children) > 0 {
+		c.Canary = children[0]
+	}
+	if len(children) > 1 {
+		c.Full = children[1]
+	}
+	return c
+}
+
+// CanProceed determines whether the parent can proceed to a full apply.
+func (c Canary) CanProceed() bool {
+	return c.Canary != nil && c.Canary.Status == RunApplied && c.Full == nil
+}
+
+// PlannedChanges lists the resources with changes in the plan of a run, from
+// which a subset can be selected for a canary apply.
+func (s *Service) PlannedChanges(ctx context.Context, runID string) ([]ResourceChange, error) {
+	planJSON, err := s.GetPlanFile(ctx, runID, PlanFormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	var planFile PlanFile
+	if err := json.Unmarshal(planJSON, &planFile); err != nil {
+		return nil, fmt.Errorf("parsing plan file: %w", err)
+	}
+	return planFile.changedResources(), nil
+}
+
+// CreateCanaryRun creates a canary run from a planned run, targeting a subset
+// of the run's planned resource changes so that they are applied before the
+// rest. The planned run is discarded, freeing its workspace for the canary run,
+// and once the canary run has applied the planned run can proceed to a full
+// apply with ProceedAfterCanary.
+func (s *Service) CreateCanaryRun(ctx context.Context, runID string, addrs []string) (*Run, error) {
+	subject, err := s.CanAccess(ctx, rbac.DiscardRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, ErrNoCanaryAddrs
+	}
+	changes, err := s.PlannedChanges(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCanaryAddrs(changes, addrs); err != nil {
+		return nil, err
+	}
+
+	var canary *Run
+	err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) (err error) {
+		parent, err := s.db.getRunForUpdate(ctx, runID)
+		if err != nil {
+			return err
+		}
+		if parent.Status != RunPlanned {
+			return ErrCanaryRunNotPlanned
+		}
+		children, err := s.db.listChildRuns(ctx, runID)
+		if err != nil {
+			return err
+		}
+		if len(children) > 0 {
+			return ErrCanaryAlreadyCreated
+		}
+		opts := parent.childOptions(fmt.Sprintf("Canary apply of %s", parent.ID))
+		opts.TargetAddrs = addrs
+		// the targeted plan may differ from the selected changes, e.g. by
+		// including their dependencies, so it must be confirmed before it is
+		// applied.
+		opts.AutoApply = internal.Bool(false)
+		canary, err = s.Create(ctx, parent.WorkspaceID, opts)
+		if err != nil {
+			return err
+		}
+		_, err = s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+			return run.Discard()
+		})
+		return err
+	})
+	if err != nil {
+		s.logger.Error("creating canary run", "parent", runID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("created canary run", "id", canary.ID, "parent", runID, "target_addrs", addrs, "subject", subject)
+
+	return canary, nil
+}
+
+// ProceedAfterCanary proceeds to a full apply of a run whose canary run has
+// applied cleanly, creating a run with a freshly generated plan of all the
+// parent run's changes.
+func (s *Service) ProceedAfterCanary(ctx context.Context, runID string) (*Run, error) {
+	subject, err := s.CanAccess(ctx, rbac.CreateRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	var full *Run
+	err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) (err error) {
+		// lock the parent to prevent it proceeding more than once
+		parent, err := s.db.getRunForUpdate(ctx, runID)
+		if err != nil {
+			return err
+		}
+		children, err := s.db.listChildRuns(ctx, runID)
+		if err != nil {
+			return err
+		}
+		canary := newCanary(children)
+		if canary.Full != nil {
+			return ErrCanaryAlreadyProceeded
+		}
+		if !canary.CanProceed() {
+			return ErrCanaryNotApplied
+		}
+		full, err = s.Create(ctx, parent.WorkspaceID, parent.childOptions(fmt.Sprintf("Full apply of %s following canary %s", parent.ID, canary.Canary.ID)))
+		return err
+	})
+	if err != nil {
+		s.logger.Error("proceeding after canary run", "parent", runID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("proceeded after canary run", "id", full.ID, "parent", runID, "subject", subject)
+
+	return full, nil
+}
+
+// GetCanary retrieves the child runs of a run.
+func (s *Service) GetCanary(ctx context.Context, runID string) (Canary, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return Canary{}, err
+	}
+	children, err := s.db.listChildRuns(ctx, runID)
+	if err != nil {
+		s.logger.Error("listing child runs", "id", runID, "subject", subject, "err", err)
+		return Canary{}, err
+	}
+	return newCanary(children), nil
+}
+
+// childOptions returns options for creating a child of the run, using the same
+// configuration version and settings as the run.
+func (r *Run) childOptions(message string) CreateOptions {
+	return CreateOptions{
+		ConfigurationVersionID: &r.ConfigurationVersionID,
+		IsDestroy:              &r.IsDestroy,
+		Refresh:                &r.Refresh,
+		Message:                &message,
+		TargetAddrs:            r.TargetAddrs,
+		ReplaceAddrs:           r.ReplaceAddrs,
+		TerraformVersion:       &r.TerraformVersion,
+		AllowEmptyApply:        &r.AllowEmptyApply,
+		Variables:              r.Variables,
+		Source:                 r.Source,
+		PlanOnly:               internal.Bool(false),
+		parentRunID:            &r.ID,
+	}
+}
+
+// validateCanaryAddrs checks that the addresses selected for a canary apply
+// are a subset of the planned resource changes, and not all of them.
+func validateCanaryAddrs(changes []ResourceChange, addrs []string) error {
+	planned := make([]string, len(changes))
+	for i, rc := range changes {
+		planned[i] = rc.Address
+	}
+	for _, addr := range addrs {
+		if !slices.Contains(planned, addr) {
+			return fmt.Errorf("%w: %s", ErrCanaryAddrNotInPlan, addr)
+		}
+	}
+	var remaining int
+	for _, addr := range planned {
+		if !slices.Contains(addrs, addr) {
+			remaining++
+		}
+	}
+	if remaining == 0 {
+		return ErrCanaryIncludesAllChanges
+	}
+	return nil
+}
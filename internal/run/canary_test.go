@@ -0,0 +1,100 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCanaryAddrs(t *testing.T) {
+	changes := []ResourceChange{
+		{Address: "null_resource.a"},
+		{Address: "null_resource.b"},
+		{Address: "null_resource.c"},
+	}
+
+	tests := []struct {
+		name  string
+		addrs []string
+		want  error
+	}{
+		{"subset", []string{"null_resource.a"}, nil},
+		{"address not in plan", []string{"null_resource.a", "null_resource.d"}, ErrCanaryAddrNotInPlan},
+		{"all changes", []string{"null_resource.a", "null_resource.b", "null_resource.c"}, ErrCanaryIncludesAllChanges},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCanaryAddrs(changes, tt.addrs)
+			if tt.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanFile_ChangedResources(t *testing.T) {
+	file := PlanFile{
+		ResourceChanges: []ResourceChange{
+			{Address: "null_resource.created", Change: Change{Actions: []ChangeAction{CreateAction}}},
+			{Address: "null_resource.unchanged", Change: Change{Actions: []ChangeAction{"no-op"}}},
+			{Address: "null_resource.replaced", Change: Change{Actions: []ChangeAction{DeleteAction, CreateAction}}},
+			{Address: "data.null_data_source.read", Change: Change{Actions: []ChangeAction{"read"}}},
+		},
+	}
+
+	got := file.changedResources()
+
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "null_resource.created", got[0].Address)
+		assert.Equal(t, "null_resource.replaced", got[1].Address)
+	}
+}
+
+func TestNewCanary(t *testing.T) {
+	canary := &Run{ID: "run-canary", Status: RunApplied}
+	full := &Run{ID: "run-full", Status: RunPending}
+
+	t.Run("no children", func(t *testing.T) {
+		got := newCanary(nil)
+		assert.Nil(t, got.Canary)
+		assert.False(t, got.CanProceed())
+	})
+
+	t.Run("canary applied", func(t *testing.T) {
+		got := newCanary([]*Run{canary})
+		assert.Equal(t, canary, got.Canary)
+		assert.True(t, got.CanProceed())
+	})
+
+	t.Run("canary not applied", func(t *testing.T) {
+		got := newCanary([]*Run{{ID: "run-canary", Status: RunErrored}})
+		assert.False(t, got.CanProceed())
+	})
+
+	t.Run("already proceeded", func(t *testing.T) {
+		got := newCanary([]*Run{canary, full})
+		assert.Equal(t, full, got.Full)
+		assert.False(t, got.CanProceed())
+	})
+}
+
+func TestRun_ChildOptions(t *testing.T) {
+	parent := &Run{
+		ID:                     "run-parent",
+		ConfigurationVersionID: "cv-1",
+		IsDestroy:              true,
+		TargetAddrs:            []string{"module.a"},
+		Source:                 SourceAPI,
+	}
+
+	got := parent.childOptions("canary")
+
+	assert.Equal(t, "cv-1", *got.ConfigurationVersionID)
+	assert.True(t, *got.IsDestroy)
+	assert.Equal(t, []string{"module.a"}, got.TargetAddrs)
+	assert.Equal(t, "canary", *got.Message)
+	assert.Equal(t, SourceAPI, got.Source)
+	assert.Equal(t, "run-parent", *got.parentRunID)
+}
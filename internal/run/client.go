@@ -77,6 +77,18 @@ func (c *Client) UploadLockFile(ctx context.Context, runID string, lockfile []by
 	return nil
 }
 
+func (c *Client) UploadModuleManifest(ctx context.Context, runID string, manifest []byte) error {
+	u := fmt.Sprintf("runs/%s/module-manifest", url.QueryEscape(runID))
+	req, err := c.NewRequest("PUT", u, manifest)
+	if err != nil {
+		return err
+	}
+	if err := c.Do(ctx, req, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *Client) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error) {
 	req, err := c.NewRequest("GET", "runs", &opts)
 	if err != nil {
@@ -0,0 +1,70 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tofutf/tofutf/internal"
+)
+
+// MaxCommentLength is the maximum number of characters in a comment.
+const MaxCommentLength = 10000
+
+var (
+	ErrEmptyComment   = errors.New("comment cannot be empty")
+	ErrCommentTooLong = fmt.Errorf("comment cannot exceed %d characters", MaxCommentLength)
+
+	// mentionRegex matches @-mentions of usernames. The mention must not be
+	// preceded by a word character, so that email addresses are not mistaken
+	// for mentions.
+	mentionRegex = regexp.MustCompile(`(?:^|[^\w@])@([a-zA-Z0-9_-]+(?:\.[a-zA-Z0-9_-]+)*)`)
+)
+
+type (
+	// Comment is a comment on a run. Comments cannot be edited, only deleted,
+	// either by their author or by an owner of the organization.
+	Comment struct {
+		ID        string
+		RunID     string
+		Body      string
+		CreatedAt time.Time
+		// Author is the username of the comment's author.
+		Author string
+		// Mentions are the usernames @-mentioned in the body.
+		Mentions []string
+	}
+)
+
+func newComment(runID, author, body string) (*Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrEmptyComment
+	}
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return nil, ErrCommentTooLong
+	}
+	return &Comment{
+		ID:        internal.NewID("wsc"),
+		RunID:     runID,
+		Body:      body,
+		Author:    author,
+		CreatedAt: internal.CurrentTimestamp(nil),
+		Mentions:  parseMentions(body),
+	}, nil
+}
+
+// parseMentions returns the unique usernames @-mentioned in a comment body, in
+// the order in which they first appear.
+func parseMentions(body string) (mentions []string) {
+	for _, match := range mentionRegex.FindAllStringSubmatch(body, -1) {
+		if username := match[1]; !slices.Contains(mentions, username) {
+			mentions = append(mentions, username)
+		}
+	}
+	return mentions
+}
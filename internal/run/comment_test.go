@@ -0,0 +1,49 @@
+package run
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComment(t *testing.T) {
+	t.Run("trims body", func(t *testing.T) {
+		got, err := newComment("run-123", "bob", "  looks good @alice \n")
+		require.NoError(t, err)
+		assert.Equal(t, "run-123", got.RunID)
+		assert.Equal(t, "bob", got.Author)
+		assert.Equal(t, "looks good @alice", got.Body)
+		assert.Equal(t, []string{"alice"}, got.Mentions)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		_, err := newComment("run-123", "bob", " \n\t")
+		assert.Equal(t, ErrEmptyComment, err)
+	})
+
+	t.Run("body too long", func(t *testing.T) {
+		_, err := newComment("run-123", "bob", strings.Repeat("a", MaxCommentLength+1))
+		assert.Equal(t, ErrCommentTooLong, err)
+	})
+}
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"no mentions", "looks good to me", nil},
+		{"single mention", "@alice please review", []string{"alice"}},
+		{"unique and in order", "@bob and @alice, then @bob again", []string{"bob", "alice"}},
+		{"email is not a mention", "contact bob@example.com", nil},
+		{"trailing full stop", "thanks @alice.smith.", []string{"alice.smith"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseMentions(tt.body))
+		})
+	}
+}
@@ -2,7 +2,9 @@ package run
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 
@@ -23,38 +25,48 @@ type (
 
 	// pgresult is the result of a database query for a run.
 	pgresult struct {
-		RunID                  pgtype.Text                   `json:"run_id"`
-		CreatedAt              pgtype.Timestamptz            `json:"created_at"`
-		CancelSignaledAt       pgtype.Timestamptz            `json:"cancel_signaled_at"`
-		IsDestroy              pgtype.Bool                   `json:"is_destroy"`
-		PositionInQueue        pgtype.Int4                   `json:"position_in_queue"`
-		Refresh                pgtype.Bool                   `json:"refresh"`
-		RefreshOnly            pgtype.Bool                   `json:"refresh_only"`
-		Source                 pgtype.Text                   `json:"source"`
-		Status                 pgtype.Text                   `json:"status"`
-		PlanStatus             pgtype.Text                   `json:"plan_status"`
-		ApplyStatus            pgtype.Text                   `json:"apply_status"`
-		ReplaceAddrs           []string                      `json:"replace_addrs"`
-		TargetAddrs            []string                      `json:"target_addrs"`
-		AutoApply              pgtype.Bool                   `json:"auto_apply"`
-		PlanResourceReport     pggen.Report                  `json:"plan_resource_report"`
-		PlanOutputReport       pggen.Report                  `json:"plan_output_report"`
-		ApplyResourceReport    pggen.Report                  `json:"apply_resource_report"`
-		ConfigurationVersionID pgtype.Text                   `json:"configuration_version_id"`
-		WorkspaceID            pgtype.Text                   `json:"workspace_id"`
-		PlanOnly               pgtype.Bool                   `json:"plan_only"`
-		CreatedBy              pgtype.Text                   `json:"created_by"`
-		TerraformVersion       pgtype.Text                   `json:"terraform_version"`
-		AllowEmptyApply        pgtype.Bool                   `json:"allow_empty_apply"`
-		ExecutionMode          pgtype.Text                   `json:"execution_mode"`
-		Latest                 pgtype.Bool                   `json:"latest"`
-		OrganizationName       pgtype.Text                   `json:"organization_name"`
-		CostEstimationEnabled  pgtype.Bool                   `json:"cost_estimation_enabled"`
-		IngressAttributes      pggen.IngressAttributes       `json:"ingress_attributes"`
-		RunStatusTimestamps    []pggen.RunStatusTimestamps   `json:"run_status_timestamps"`
-		PlanStatusTimestamps   []pggen.PhaseStatusTimestamps `json:"plan_status_timestamps"`
-		ApplyStatusTimestamps  []pggen.PhaseStatusTimestamps `json:"apply_status_timestamps"`
-		RunVariables           []pggen.RunVariables          `json:"run_variables"`
+		RunID                      pgtype.Text                   `json:"run_id"`
+		CreatedAt                  pgtype.Timestamptz            `json:"created_at"`
+		CancelSignaledAt           pgtype.Timestamptz            `json:"cancel_signaled_at"`
+		ForceCancelAvailableAt     pgtype.Timestamptz            `json:"force_cancel_available_at"`
+		ForceCanceledBy            pgtype.Text                   `json:"force_canceled_by"`
+		ForceCancelReason          pgtype.Text                   `json:"force_cancel_reason"`
+		IsDestroy                  pgtype.Bool                   `json:"is_destroy"`
+		PositionInQueue            pgtype.Int4                   `json:"position_in_queue"`
+		Refresh                    pgtype.Bool                   `json:"refresh"`
+		RefreshOnly                pgtype.Bool                   `json:"refresh_only"`
+		Source                     pgtype.Text                   `json:"source"`
+		Status                     pgtype.Text                   `json:"status"`
+		PlanStatus                 pgtype.Text                   `json:"plan_status"`
+		ApplyStatus                pgtype.Text                   `json:"apply_status"`
+		ReplaceAddrs               []string                      `json:"replace_addrs"`
+		TargetAddrs                []string                      `json:"target_addrs"`
+		AutoApply                  pgtype.Bool                   `json:"auto_apply"`
+		PlanResourceReport         pggen.Report                  `json:"plan_resource_report"`
+		PlanOutputReport           pggen.Report                  `json:"plan_output_report"`
+		ApplyResourceReport        pggen.Report                  `json:"apply_resource_report"`
+		ConfigurationVersionID     pgtype.Text                   `json:"configuration_version_id"`
+		WorkspaceID                pgtype.Text                   `json:"workspace_id"`
+		PlanOnly                   pgtype.Bool                   `json:"plan_only"`
+		CreatedBy                  pgtype.Text                   `json:"created_by"`
+		TerraformVersion           pgtype.Text                   `json:"terraform_version"`
+		AllowEmptyApply            pgtype.Bool                   `json:"allow_empty_apply"`
+		SkipPreflight              pgtype.Bool                   `json:"skip_preflight"`
+		DebugLogging               pgtype.Bool                   `json:"debug_logging"`
+		ErrorCategory              pgtype.Text                   `json:"error_category"`
+		ParentRunID                pgtype.Text                   `json:"parent_run_id"`
+		Priority                   pgtype.Text                   `json:"priority"`
+		ExecutionMode              pgtype.Text                   `json:"execution_mode"`
+		StructuredRunOutputEnabled pgtype.Bool                   `json:"structured_run_output_enabled"`
+		Latest                     pgtype.Bool                   `json:"latest"`
+		OrganizationName           pgtype.Text                   `json:"organization_name"`
+		CostEstimationEnabled      pgtype.Bool                   `json:"cost_estimation_enabled"`
+		IngressAttributes          pggen.IngressAttributes       `json:"ingress_attributes"`
+		RunStatusTimestamps        []pggen.RunStatusTimestamps   `json:"run_status_timestamps"`
+		PlanStatusTimestamps       []pggen.PhaseStatusTimestamps `json:"plan_status_timestamps"`
+		ApplyStatusTimestamps      []pggen.PhaseStatusTimestamps `json:"apply_status_timestamps"`
+		RunVariables               []pggen.RunVariables          `json:"run_variables"`
+		RunLabels                  []pggen.RunLabels             `json:"run_labels"`
 	}
 )
 
@@ -73,8 +85,13 @@ func (result pgresult) toRun() *Run {
 		AutoApply:              result.AutoApply.Bool,
 		PlanOnly:               result.PlanOnly.Bool,
 		AllowEmptyApply:        result.AllowEmptyApply.Bool,
+		SkipPreflight:          result.SkipPreflight.Bool,
+		DebugLogging:           result.DebugLogging.Bool,
+		ErrorCategory:          ErrorCategory(result.ErrorCategory.String),
+		Priority:               Priority(result.Priority.String),
 		TerraformVersion:       result.TerraformVersion.String,
 		ExecutionMode:          workspace.ExecutionMode(result.ExecutionMode.String),
+		StructuredRunOutput:    result.StructuredRunOutputEnabled.Bool,
 		Latest:                 result.Latest.Bool,
 		Organization:           result.OrganizationName.String,
 		WorkspaceID:            result.WorkspaceID.String,
@@ -136,18 +153,53 @@ func (result pgresult) toRun() *Run {
 			run.Variables[i] = Variable{Key: v.Key.String, Value: v.Value.String}
 		}
 	}
+	if len(result.RunLabels) > 0 {
+		run.Labels = make(map[string]string, len(result.RunLabels))
+		for _, l := range result.RunLabels {
+			run.Labels[l.Key.String] = l.Value.String
+		}
+	}
 	if result.CreatedBy.Valid {
 		run.CreatedBy = &result.CreatedBy.String
 	}
+	if result.ParentRunID.Valid {
+		run.ParentRunID = &result.ParentRunID.String
+	}
 	if result.CancelSignaledAt.Valid {
 		run.CancelSignaledAt = internal.Time(result.CancelSignaledAt.Time.UTC())
 	}
+	if result.ForceCancelAvailableAt.Valid {
+		run.ForceCancelAvailableAt = internal.Time(result.ForceCancelAvailableAt.Time.UTC())
+	}
+	if result.ForceCanceledBy.Valid {
+		run.ForceCanceledBy = &result.ForceCanceledBy.String
+	}
+	if result.ForceCancelReason.Valid {
+		run.ForceCancelReason = &result.ForceCancelReason.String
+	}
 	if result.IngressAttributes != (pggen.IngressAttributes{}) {
 		run.IngressAttributes = configversion.NewIngressFromRow(result.IngressAttributes)
 	}
 	return &run
 }
 
+// CreateHighPriorityRun persists a high priority run to the DB, provided its
+// organization has not reached its maximum number of incomplete high priority
+// runs. The organization is locked for the duration to prevent concurrent
+// creations exceeding the maximum.
+func (db *pgdb) CreateHighPriorityRun(ctx context.Context, run *Run) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		result, err := q.FindHighPriorityRunQuota(ctx, sql.String(run.Organization))
+		if err != nil {
+			return sql.Error(err)
+		}
+		if err := checkHighPriorityRunLimit(int(result.Runs.Int64), int(result.MaxHighPriorityRuns.Int32)); err != nil {
+			return err
+		}
+		return db.CreateRun(ctx, run)
+	})
+}
+
 // CreateRun persists a Run to the DB.
 func (db *pgdb) CreateRun(ctx context.Context, run *Run) error {
 	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
@@ -165,10 +217,14 @@ func (db *pgdb) CreateRun(ctx context.Context, run *Run) error {
 			AutoApply:              sql.Bool(run.AutoApply),
 			PlanOnly:               sql.Bool(run.PlanOnly),
 			AllowEmptyApply:        sql.Bool(run.AllowEmptyApply),
+			SkipPreflight:          sql.Bool(run.SkipPreflight),
+			DebugLogging:           sql.Bool(run.DebugLogging),
 			TerraformVersion:       sql.String(run.TerraformVersion),
 			ConfigurationVersionID: sql.String(run.ConfigurationVersionID),
 			WorkspaceID:            sql.String(run.WorkspaceID),
 			CreatedBy:              sql.StringPtr(run.CreatedBy),
+			ParentRunID:            sql.StringPtr(run.ParentRunID),
+			Priority:               sql.String(string(run.Priority)),
 		})
 		for _, v := range run.Variables {
 			_, err = q.InsertRunVariable(ctx, pggen.InsertRunVariableParams{
@@ -183,6 +239,9 @@ func (db *pgdb) CreateRun(ctx context.Context, run *Run) error {
 		if err != nil {
 			return fmt.Errorf("inserting run: %w", err)
 		}
+		if err := db.insertLabels(ctx, run.ID, run.Labels); err != nil {
+			return fmt.Errorf("inserting run labels: %w", err)
+		}
 		_, err = q.InsertPlan(ctx, sql.String(run.ID), sql.String(string(run.Plan.Status)))
 		if err != nil {
 			return fmt.Errorf("inserting plan: %w", err)
@@ -220,6 +279,9 @@ func (db *pgdb) UpdateStatus(ctx context.Context, runID string, fn func(*Run) er
 		planStatus := run.Plan.Status
 		applyStatus := run.Apply.Status
 		cancelSignaledAt := run.CancelSignaledAt
+		forceCancelAvailableAt := run.ForceCancelAvailableAt
+		forceCanceledBy := run.ForceCanceledBy
+		errorCategory := run.ErrorCategory
 
 		if err := fn(run); err != nil {
 			return err
@@ -258,6 +320,13 @@ func (db *pgdb) UpdateStatus(ctx context.Context, runID string, fn func(*Run) er
 			}
 		}
 
+		if run.ErrorCategory != errorCategory {
+			_, err := q.UpdateRunErrorCategory(ctx, sql.String(string(run.ErrorCategory)), sql.String(run.ID))
+			if err != nil {
+				return err
+			}
+		}
+
 		if run.CancelSignaledAt != cancelSignaledAt && run.CancelSignaledAt != nil {
 			_, err := q.UpdateCancelSignaledAt(ctx, sql.Timestamptz(*run.CancelSignaledAt), sql.String(run.ID))
 			if err != nil {
@@ -265,6 +334,24 @@ func (db *pgdb) UpdateStatus(ctx context.Context, runID string, fn func(*Run) er
 			}
 		}
 
+		if run.ForceCancelAvailableAt != forceCancelAvailableAt && run.ForceCancelAvailableAt != nil {
+			_, err := q.UpdateForceCancelAvailableAt(ctx, sql.Timestamptz(*run.ForceCancelAvailableAt), sql.String(run.ID))
+			if err != nil {
+				return err
+			}
+		}
+
+		if run.ForceCanceledBy != forceCanceledBy && run.ForceCanceledBy != nil {
+			_, err := q.UpdateRunForceCanceledBy(ctx, pggen.UpdateRunForceCanceledByParams{
+				ForceCanceledBy:   sql.StringPtr(run.ForceCanceledBy),
+				ForceCancelReason: sql.StringPtr(run.ForceCancelReason),
+				ID:                sql.String(run.ID),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 	return run, err
@@ -331,6 +418,14 @@ func (db *pgdb) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[
 		if opts.PlanOnly != nil {
 			planOnly = strconv.FormatBool(*opts.PlanOnly)
 		}
+		var labelKey, labelValue *string
+		if opts.Label != nil {
+			labelKey, labelValue = &opts.Label.Key, &opts.Label.Value
+		}
+		errorCategories := []string{"%"}
+		if len(opts.ErrorCategories) > 0 {
+			errorCategories = internal.ToStringSlice(opts.ErrorCategories)
+		}
 
 		rows, err := q.FindRuns(ctx, pggen.FindRunsParams{
 			OrganizationNames: []string{organization},
@@ -338,9 +433,12 @@ func (db *pgdb) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[
 			WorkspaceIds:      []string{workspaceID},
 			CommitSHA:         sql.StringPtr(opts.CommitSHA),
 			VCSUsername:       sql.StringPtr(opts.VCSUsername),
+			LabelKey:          sql.StringPtr(labelKey),
+			LabelValue:        sql.StringPtr(labelValue),
 			Sources:           sources,
 			Statuses:          statuses,
 			PlanOnly:          []string{planOnly},
+			ErrorCategories:   errorCategories,
 			Limit:             opts.GetLimit(),
 			Offset:            opts.GetOffset(),
 		})
@@ -354,9 +452,12 @@ func (db *pgdb) ListRuns(ctx context.Context, opts ListOptions) (*resource.Page[
 			WorkspaceIds:      []string{workspaceID},
 			CommitSHA:         sql.StringPtr(opts.CommitSHA),
 			VCSUsername:       sql.StringPtr(opts.VCSUsername),
+			LabelKey:          sql.StringPtr(labelKey),
+			LabelValue:        sql.StringPtr(labelValue),
 			Sources:           sources,
 			Statuses:          statuses,
 			PlanOnly:          []string{planOnly},
+			ErrorCategories:   errorCategories,
 		})
 		if err != nil {
 			return nil, err
@@ -383,6 +484,58 @@ func (db *pgdb) GetRun(ctx context.Context, runID string) (*Run, error) {
 	})
 }
 
+// getRunForUpdate retrieves a run, locking it until the end of the
+// transaction.
+func (db *pgdb) getRunForUpdate(ctx context.Context, runID string) (*Run, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Run, error) {
+		result, err := q.FindRunByIDForUpdate(ctx, sql.String(runID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return pgresult(result).toRun(), nil
+	})
+}
+
+// listChildRuns lists the runs created from the run, earliest first.
+func (db *pgdb) listChildRuns(ctx context.Context, runID string) ([]*Run, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Run, error) {
+		ids, err := q.FindChildRunIDs(ctx, sql.String(runID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		children := make([]*Run, len(ids))
+		for i, id := range ids {
+			result, err := q.FindRunByID(ctx, id)
+			if err != nil {
+				return nil, sql.Error(err)
+			}
+			children[i] = pgresult(result).toRun()
+		}
+		return children, nil
+	})
+}
+
+// UpdateLabels replaces the labels of a run.
+func (db *pgdb) UpdateLabels(ctx context.Context, runID string, labels map[string]string) (*Run, error) {
+	return sql.Tx(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Run, error) {
+		// select ...for update
+		if _, err := q.FindRunByIDForUpdate(ctx, sql.String(runID)); err != nil {
+			return nil, sql.Error(err)
+		}
+		if _, err := q.DeleteRunLabels(ctx, sql.String(runID)); err != nil {
+			return nil, err
+		}
+		if err := db.insertLabels(ctx, runID, labels); err != nil {
+			return nil, err
+		}
+		result, err := q.FindRunByID(ctx, sql.String(runID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return pgresult(result).toRun(), nil
+	})
+}
+
 // SetPlanFile writes a plan file to the db
 func (db *pgdb) SetPlanFile(ctx context.Context, runID string, file []byte, format PlanFormat) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
@@ -393,6 +546,9 @@ func (db *pgdb) SetPlanFile(ctx context.Context, runID string, file []byte, form
 		case PlanFormatJSON:
 			_, err := q.UpdatePlanJSONByID(ctx, file, sql.String(runID))
 			return err
+		case PlanFormatRedactedJSON:
+			_, err := q.UpdatePlanRedactedJSONByID(ctx, file, sql.String(runID))
+			return err
 		default:
 			return fmt.Errorf("unknown plan format: %s", string(format))
 		}
@@ -407,6 +563,8 @@ func (db *pgdb) GetPlanFile(ctx context.Context, runID string, format PlanFormat
 			return q.GetPlanBinByID(ctx, sql.String(runID))
 		case PlanFormatJSON:
 			return q.GetPlanJSONByID(ctx, sql.String(runID))
+		case PlanFormatRedactedJSON:
+			return q.GetPlanRedactedJSONByID(ctx, sql.String(runID))
 		default:
 			return nil, fmt.Errorf("unknown plan format: %s", string(format))
 		}
@@ -428,6 +586,20 @@ func (db *pgdb) SetLockFile(ctx context.Context, runID string, lockFile []byte)
 	})
 }
 
+func (db *pgdb) getModuleManifest(ctx context.Context, runID string) ([]byte, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]byte, error) {
+		manifest, err := q.GetModuleManifestByID(ctx, sql.String(runID))
+		return manifest, sql.Error(err)
+	})
+}
+
+func (db *pgdb) setModuleManifest(ctx context.Context, runID string, manifest []byte) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.PutModuleManifest(ctx, manifest, sql.String(runID))
+		return sql.Error(err)
+	})
+}
+
 // DeleteRun deletes a run from the DB
 func (db *pgdb) DeleteRun(ctx context.Context, id string) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
@@ -451,6 +623,22 @@ func (db *pgdb) insertRunStatusTimestamp(ctx context.Context, run *Run) error {
 	})
 }
 
+func (db *pgdb) insertLabels(ctx context.Context, runID string, labels map[string]string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		for k, v := range labels {
+			_, err := q.InsertRunLabel(ctx, pggen.InsertRunLabelParams{
+				RunID: sql.String(runID),
+				Key:   sql.String(k),
+				Value: sql.String(v),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (db *pgdb) insertPhaseStatusTimestamp(ctx context.Context, phase Phase) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
 		ts, err := phase.StatusTimestamp(phase.Status)
@@ -470,7 +658,11 @@ func (db *pgdb) insertPhaseStatusTimestamp(ctx context.Context, phase Phase) err
 
 func (db *pgdb) findLogs(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error) {
 	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]byte, error) {
-		data, err := q.FindLogs(ctx, sql.String(runID), sql.String(string(phase)))
+		data, err := q.FindLogs(ctx, pggen.FindLogsParams{
+			RunID:  sql.String(runID),
+			Phase:  sql.String(string(phase)),
+			Stream: sql.String(string(internal.PlainLogStream)),
+		})
 		if err != nil {
 			// Don't consider no rows an error because logs may not have been
 			// uploaded yet.
@@ -483,3 +675,223 @@ func (db *pgdb) findLogs(ctx context.Context, runID string, phase internal.Phase
 		return data, nil
 	})
 }
+
+// findTruncatedLogPhases returns the phases of a run whose logs have been
+// truncated for exceeding the maximum size.
+func (db *pgdb) findTruncatedLogPhases(ctx context.Context, runID string) ([]internal.PhaseType, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]internal.PhaseType, error) {
+		rows, err := q.FindTruncatedLogPhases(ctx, sql.String(runID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		phases := make([]internal.PhaseType, len(rows))
+		for i, r := range rows {
+			phases[i] = internal.PhaseType(r.String)
+		}
+		return phases, nil
+	})
+}
+
+// commentRow is the row result of a database query for run comments
+type commentRow struct {
+	CommentID pgtype.Text        `json:"comment_id"`
+	RunID     pgtype.Text        `json:"run_id"`
+	Body      pgtype.Text        `json:"body"`
+	Author    pgtype.Text        `json:"author"`
+	Mentions  []string           `json:"mentions"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (r commentRow) toComment() *Comment {
+	return &Comment{
+		ID:        r.CommentID.String,
+		RunID:     r.RunID.String,
+		Body:      r.Body.String,
+		Author:    r.Author.String,
+		Mentions:  r.Mentions,
+		CreatedAt: r.CreatedAt.Time.UTC(),
+	}
+}
+
+func (db *pgdb) createComment(ctx context.Context, comment *Comment) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertRunComment(ctx, pggen.InsertRunCommentParams{
+			CommentID: sql.String(comment.ID),
+			RunID:     sql.String(comment.RunID),
+			Body:      sql.String(comment.Body),
+			Author:    sql.String(comment.Author),
+			Mentions:  comment.Mentions,
+			CreatedAt: sql.Timestamptz(comment.CreatedAt),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) listComments(ctx context.Context, runID string) ([]*Comment, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Comment, error) {
+		rows, err := q.FindRunComments(ctx, sql.String(runID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		comments := make([]*Comment, len(rows))
+		for i, r := range rows {
+			comments[i] = commentRow(r).toComment()
+		}
+		return comments, nil
+	})
+}
+
+func (db *pgdb) getComment(ctx context.Context, commentID string) (*Comment, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Comment, error) {
+		row, err := q.FindRunCommentByID(ctx, sql.String(commentID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return commentRow(row).toComment(), nil
+	})
+}
+
+func (db *pgdb) deleteComment(ctx context.Context, commentID string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteRunCommentByID(ctx, sql.String(commentID))
+		return sql.Error(err)
+	})
+}
+
+// artifactRow is the row result of a database query for run artifacts
+type artifactRow struct {
+	RunArtifactID pgtype.Text        `json:"run_artifact_id"`
+	RunID         pgtype.Text        `json:"run_id"`
+	Name          pgtype.Text        `json:"name"`
+	ContentType   pgtype.Text        `json:"content_type"`
+	Size          pgtype.Int4        `json:"size"`
+	Data          []byte             `json:"data"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+func (r artifactRow) toArtifact() *Artifact {
+	return &Artifact{
+		ID:          r.RunArtifactID.String,
+		RunID:       r.RunID.String,
+		Name:        r.Name.String,
+		ContentType: r.ContentType.String,
+		Size:        int(r.Size.Int32),
+		Data:        r.Data,
+		CreatedAt:   r.CreatedAt.Time.UTC(),
+	}
+}
+
+// uploadArtifact inserts an artifact, replacing any existing artifact with the
+// same name, unless the run already has the maximum number of artifacts.
+func (db *pgdb) uploadArtifact(ctx context.Context, artifact *Artifact, maxCount int) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		// select ...for update, serializing uploads to the run so that the
+		// count cannot be exceeded by concurrent uploads.
+		if _, err := q.FindRunByIDForUpdate(ctx, sql.String(artifact.RunID)); err != nil {
+			return sql.Error(err)
+		}
+		existing, err := q.FindRunArtifacts(ctx, sql.String(artifact.RunID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		replacing := slices.ContainsFunc(existing, func(r pggen.FindRunArtifactsRow) bool {
+			return r.Name.String == artifact.Name
+		})
+		if !replacing && len(existing) >= maxCount {
+			return fmt.Errorf("%w: %d", ErrTooManyArtifacts, maxCount)
+		}
+		_, err = q.UpsertRunArtifact(ctx, pggen.UpsertRunArtifactParams{
+			RunArtifactID: sql.String(artifact.ID),
+			RunID:         sql.String(artifact.RunID),
+			Name:          sql.String(artifact.Name),
+			ContentType:   sql.String(artifact.ContentType),
+			Size:          sql.Int4(artifact.Size),
+			Data:          artifact.Data,
+			CreatedAt:     sql.Timestamptz(artifact.CreatedAt),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) listArtifacts(ctx context.Context, runID string) ([]*Artifact, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Artifact, error) {
+		rows, err := q.FindRunArtifacts(ctx, sql.String(runID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		artifacts := make([]*Artifact, len(rows))
+		for i, r := range rows {
+			artifacts[i] = artifactRow{
+				RunArtifactID: r.RunArtifactID,
+				RunID:         r.RunID,
+				Name:          r.Name,
+				ContentType:   r.ContentType,
+				Size:          r.Size,
+				CreatedAt:     r.CreatedAt,
+			}.toArtifact()
+		}
+		return artifacts, nil
+	})
+}
+
+func (db *pgdb) getArtifact(ctx context.Context, runID, name string) (*Artifact, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Artifact, error) {
+		row, err := q.FindRunArtifactByName(ctx, sql.String(runID), sql.String(name))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return artifactRow(row).toArtifact(), nil
+	})
+}
+
+// policyCheckRow is the row result of a database query for run policy checks
+type policyCheckRow struct {
+	RunID      pgtype.Text        `json:"run_id"`
+	Name       pgtype.Text        `json:"name"`
+	Violations []byte             `json:"violations"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+func (r policyCheckRow) toPolicyCheck() (*PolicyCheck, error) {
+	check := &PolicyCheck{
+		RunID:     r.RunID.String,
+		Name:      r.Name.String,
+		CreatedAt: r.CreatedAt.Time.UTC(),
+	}
+	if err := json.Unmarshal(r.Violations, &check.Violations); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+func (db *pgdb) upsertPolicyCheck(ctx context.Context, check *PolicyCheck) error {
+	violations, err := json.Marshal(check.Violations)
+	if err != nil {
+		return err
+	}
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertRunPolicyCheck(ctx, pggen.UpsertRunPolicyCheckParams{
+			RunID:      sql.String(check.RunID),
+			Name:       sql.String(check.Name),
+			Violations: violations,
+			CreatedAt:  sql.Timestamptz(check.CreatedAt),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) listPolicyChecks(ctx context.Context, runID string) ([]*PolicyCheck, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*PolicyCheck, error) {
+		rows, err := q.FindRunPolicyChecks(ctx, sql.String(runID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		checks := make([]*PolicyCheck, len(rows))
+		for i, r := range rows {
+			if checks[i], err = policyCheckRow(r).toPolicyCheck(); err != nil {
+				return nil, err
+			}
+		}
+		return checks, nil
+	})
+}
@@ -0,0 +1,120 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorCategory classifies why a run errored.
+type ErrorCategory string
+
+const (
+	// SetupErrorCategory is a failure preparing to run terraform, e.g.
+	// installing terraform or downloading the configuration or state.
+	SetupErrorCategory ErrorCategory = "setup"
+	// InitErrorCategory is a failure running terraform init.
+	InitErrorCategory ErrorCategory = "init"
+	// ProviderAuthErrorCategory is a failure of a provider to authenticate
+	// with its API.
+	ProviderAuthErrorCategory ErrorCategory = "provider_auth"
+	// ExecutionErrorCategory is a failure running terraform plan or apply.
+	ExecutionErrorCategory ErrorCategory = "execution"
+	// TimeoutErrorCategory is a failure due to an operation timing out.
+	TimeoutErrorCategory ErrorCategory = "timeout"
+	// AgentLostErrorCategory is a failure due to the agent running the job
+	// exiting or losing contact with the server before finishing it.
+	AgentLostErrorCategory ErrorCategory = "agent_lost"
+	// RejectedErrorCategory is a job rejected before it was allocated to an
+	// agent, e.g. because its terraform version is not allowed.
+	RejectedErrorCategory ErrorCategory = "rejected"
+	// PreflightErrorCategory is a failure of a workspace's pre-flight checks.
+	PreflightErrorCategory ErrorCategory = "preflight"
+	// PolicyCheckErrorCategory is a plan that failed a policy check.
+	PolicyCheckErrorCategory ErrorCategory = "policy_check"
+	// UnknownErrorCategory is a failure for which no category was reported,
+	// e.g. by an agent predating error categories.
+	UnknownErrorCategory ErrorCategory = "unknown"
+)
+
+var (
+	ErrInvalidErrorCategory = errors.New("invalid error category")
+
+	errorCategories = []ErrorCategory{
+		SetupErrorCategory,
+		InitErrorCategory,
+		ProviderAuthErrorCategory,
+		ExecutionErrorCategory,
+		TimeoutErrorCategory,
+		AgentLostErrorCategory,
+		RejectedErrorCategory,
+		PreflightErrorCategory,
+		PolicyCheckErrorCategory,
+		UnknownErrorCategory,
+	}
+)
+
+func (c ErrorCategory) String() string { return string(c) }
+
+// Description describes the category for humans.
+func (c ErrorCategory) Description() string {
+	switch c {
+	case SetupErrorCategory:
+		return "Setup failed"
+	case InitErrorCategory:
+		return "Initialization failed"
+	case ProviderAuthErrorCategory:
+		return "Provider authentication failed"
+	case ExecutionErrorCategory:
+		return "Execution failed"
+	case TimeoutErrorCategory:
+		return "Timed out"
+	case AgentLostErrorCategory:
+		return "Agent lost"
+	case RejectedErrorCategory:
+		return "Rejected"
+	case PreflightErrorCategory:
+		return "Pre-flight checks failed"
+	case PolicyCheckErrorCategory:
+		return "Policy check failed"
+	default:
+		return "Unknown error"
+	}
+}
+
+// ErrorCategories lists all error categories.
+func ErrorCategories() []ErrorCategory {
+	return errorCategories
+}
+
+// parseErrorCategories parses a comma-separated list of error categories.
+func parseErrorCategories(s string) ([]ErrorCategory, error) {
+	var categories []ErrorCategory
+	for _, c := range strings.Split(s, ",") {
+		category := ErrorCategory(strings.TrimSpace(c))
+		if !category.valid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidErrorCategory, c)
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+func (c ErrorCategory) valid() bool {
+	for _, category := range errorCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// orUnknown returns the category, or the unknown category if the category is
+// empty or unrecognised, e.g. because it was reported by an agent that is
+// older or newer than the server.
+func (c ErrorCategory) orUnknown() ErrorCategory {
+	if !c.valid() {
+		return UnknownErrorCategory
+	}
+	return c
+}
@@ -0,0 +1,23 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrorCategories(t *testing.T) {
+	got, err := parseErrorCategories("init, provider_auth")
+	require.NoError(t, err)
+	assert.Equal(t, []ErrorCategory{InitErrorCategory, ProviderAuthErrorCategory}, got)
+
+	_, err = parseErrorCategories("init,bogus")
+	assert.ErrorIs(t, err, ErrInvalidErrorCategory)
+}
+
+func TestErrorCategory_orUnknown(t *testing.T) {
+	assert.Equal(t, SetupErrorCategory, SetupErrorCategory.orUnknown())
+	assert.Equal(t, UnknownErrorCategory, ErrorCategory("").orUnknown())
+	assert.Equal(t, UnknownErrorCategory, ErrorCategory("from_the_future").orUnknown())
+}
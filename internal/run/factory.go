@@ -8,6 +8,7 @@ import (
 	"github.com/tofutf/tofutf/internal/configversion"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/releases"
+	"github.com/tofutf/tofutf/internal/semver"
 	"github.com/tofutf/tofutf/internal/vcs"
 	"github.com/tofutf/tofutf/internal/workspace"
 )
@@ -43,11 +44,16 @@ type (
 
 	factoryReleasesClient interface {
 		GetLatest(ctx context.Context) (string, time.Time, error)
+		ResolveVersion(ctx context.Context, constraint string) (string, error)
+		CheckVersionPolicy(ctx context.Context, version string) error
 	}
 )
 
 // NewRun constructs a new run using the provided options.
 func (f *factory) NewRun(ctx context.Context, workspaceID string, opts CreateOptions) (*Run, error) {
+	if err := validateCreateOptions(opts); err != nil {
+		return nil, err
+	}
 	ws, err := f.workspaces.Get(ctx, workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve workspace: %w", err)
@@ -62,6 +68,24 @@ func (f *factory) NewRun(ctx context.Context, workspaceID string, opts CreateOpt
 		if err != nil {
 			return nil, fmt.Errorf("failed to retrieve terraform version: %w", err)
 		}
+	} else if !semver.IsValid(ws.TerraformVersion) && semver.IsConstraint(ws.TerraformVersion) {
+		// workspace specifies a version constraint: resolve it to a concrete
+		// version, which is recorded on the run.
+		ws.TerraformVersion, err = f.releases.ResolveVersion(ctx, ws.TerraformVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve terraform version: %w", err)
+		}
+	}
+
+	// refuse the run if its terraform version is forbidden by the site's
+	// version policy, which may have been introduced after the workspace's
+	// version was set, rather than leave the agent to download it.
+	version := ws.TerraformVersion
+	if opts.TerraformVersion != nil {
+		version = *opts.TerraformVersion
+	}
+	if err := f.releases.CheckVersionPolicy(ctx, version); err != nil {
+		return nil, fmt.Errorf("cannot create run: %w; update the workspace terraform version", err)
 	}
 
 	// retrieve or create config: if a config version ID is specified then
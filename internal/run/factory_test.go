@@ -11,6 +11,7 @@ import (
 	"github.com/tofutf/tofutf/internal/configversion"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/releases"
+	"github.com/tofutf/tofutf/internal/semver"
 	"github.com/tofutf/tofutf/internal/vcs"
 	"github.com/tofutf/tofutf/internal/workspace"
 )
@@ -36,6 +37,19 @@ func TestFactory(t *testing.T) {
 		assert.False(t, got.AutoApply)
 	})
 
+	t.Run("invalid priority", func(t *testing.T) {
+		f := newTestFactory(
+			&organization.Organization{},
+			&workspace.Workspace{},
+			&configversion.ConfigurationVersion{},
+			"",
+		)
+
+		priority := Priority("urgent")
+		_, err := f.NewRun(ctx, "", CreateOptions{Priority: &priority})
+		assert.ErrorIs(t, err, ErrInvalidPriority)
+	})
+
 	t.Run("speculative run", func(t *testing.T) {
 		f := newTestFactory(
 			&organization.Organization{},
@@ -139,6 +153,71 @@ func TestFactory(t *testing.T) {
 
 		assert.Equal(t, "1.2.3", got.TerraformVersion)
 	})
+
+	t.Run("resolve version constraint", func(t *testing.T) {
+		f := newTestFactory(
+			&organization.Organization{},
+			&workspace.Workspace{TerraformVersion: "~> 1.2"},
+			&configversion.ConfigurationVersion{},
+			"1.2.3",
+		)
+
+		got, err := f.NewRun(ctx, "", CreateOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, "1.2.3", got.TerraformVersion)
+	})
+
+	t.Run("no version satisfies constraint", func(t *testing.T) {
+		f := newTestFactory(
+			&organization.Organization{},
+			&workspace.Workspace{TerraformVersion: "~> 2.0"},
+			&configversion.ConfigurationVersion{},
+			"1.2.3",
+		)
+
+		_, err := f.NewRun(ctx, "", CreateOptions{})
+		assert.ErrorIs(t, err, releases.ErrNoMatchingVersion)
+	})
+
+	t.Run("version forbidden by policy", func(t *testing.T) {
+		f := newTestFactory(
+			&organization.Organization{},
+			&workspace.Workspace{TerraformVersion: "1.2.0"},
+			&configversion.ConfigurationVersion{},
+			"1.2.3",
+		)
+		f.releases = &fakeReleasesService{policy: releases.VersionPolicy{MinVersion: "1.3.0"}}
+
+		_, err := f.NewRun(ctx, "", CreateOptions{})
+		assert.ErrorIs(t, err, releases.ErrForbiddenTerraformVersion)
+	})
+
+	t.Run("resolved version forbidden by policy", func(t *testing.T) {
+		f := newTestFactory(
+			&organization.Organization{},
+			&workspace.Workspace{TerraformVersion: releases.LatestVersionString},
+			&configversion.ConfigurationVersion{},
+			"1.7.0",
+		)
+		f.releases = &fakeReleasesService{latestVersion: "1.7.0", policy: releases.VersionPolicy{MaxVersion: "1.6.0"}}
+
+		_, err := f.NewRun(ctx, "", CreateOptions{})
+		assert.ErrorIs(t, err, releases.ErrForbiddenTerraformVersion)
+	})
+
+	t.Run("override version forbidden by policy", func(t *testing.T) {
+		f := newTestFactory(
+			&organization.Organization{},
+			&workspace.Workspace{TerraformVersion: "1.5.7"},
+			&configversion.ConfigurationVersion{},
+			"1.2.3",
+		)
+		f.releases = &fakeReleasesService{policy: releases.VersionPolicy{MinVersion: "1.3.0"}}
+
+		_, err := f.NewRun(ctx, "", CreateOptions{TerraformVersion: internal.String("1.2.0")})
+		assert.ErrorIs(t, err, releases.ErrForbiddenTerraformVersion)
+	})
 }
 
 type (
@@ -157,6 +236,7 @@ type (
 	}
 	fakeReleasesService struct {
 		latestVersion string
+		policy        releases.VersionPolicy
 	}
 )
 
@@ -213,3 +293,14 @@ func (f *fakeFactoryCloudClient) GetCommit(context.Context, string, string) (vcs
 func (f *fakeReleasesService) GetLatest(context.Context) (string, time.Time, error) {
 	return f.latestVersion, time.Time{}, nil
 }
+
+func (f *fakeReleasesService) ResolveVersion(_ context.Context, constraint string) (string, error) {
+	if v, ok := semver.Latest(constraint, []string{f.latestVersion}); ok {
+		return v, nil
+	}
+	return "", releases.ErrNoMatchingVersion
+}
+
+func (f *fakeReleasesService) CheckVersionPolicy(_ context.Context, version string) error {
+	return f.policy.Check(version)
+}
@@ -0,0 +1,61 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaxLabels is the maximum number of labels a run can have.
+	MaxLabels = 20
+	// MaxLabelKeyLength is the maximum length of a label key.
+	MaxLabelKeyLength = 63
+	// MaxLabelValueLength is the maximum length of a label value.
+	MaxLabelValueLength = 255
+)
+
+var (
+	ErrInvalidLabels      = errors.New("invalid run labels")
+	ErrInvalidLabelFilter = errors.New("label filter must be in the format key:value")
+
+	labelKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_./-]*$`)
+)
+
+// LabelFilter filters runs by label.
+type LabelFilter struct {
+	Key   string
+	Value string
+}
+
+// validateLabels checks labels are within the permitted limits and that their
+// keys contain only permitted characters.
+func validateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("%w: a run cannot have more than %d labels", ErrInvalidLabels, MaxLabels)
+	}
+	for k, v := range labels {
+		if len(k) > MaxLabelKeyLength {
+			return fmt.Errorf("%w: label key %q exceeds %d characters", ErrInvalidLabels, k, MaxLabelKeyLength)
+		}
+		if !labelKeyRegex.MatchString(k) {
+			return fmt.Errorf("%w: label key %q must start with an alphanumeric character and contain only alphanumeric characters, '_', '.', '/' and '-'", ErrInvalidLabels, k)
+		}
+		if len(v) > MaxLabelValueLength {
+			return fmt.Errorf("%w: value of label %q exceeds %d characters", ErrInvalidLabels, k, MaxLabelValueLength)
+		}
+	}
+	return nil
+}
+
+// parseLabelFilter parses a label filter in the format key:value. Label keys
+// cannot contain a colon, so the filter is split on the first colon, and the
+// value may contain colons.
+func parseLabelFilter(s string) (*LabelFilter, error) {
+	key, value, ok := strings.Cut(s, ":")
+	if !ok || key == "" {
+		return nil, ErrInvalidLabelFilter
+	}
+	return &LabelFilter{Key: key, Value: value}, nil
+}
@@ -0,0 +1,90 @@
+package run
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLabels(t *testing.T) {
+	tooMany := make(map[string]string, MaxLabels+1)
+	for i := 0; i <= MaxLabels; i++ {
+		tooMany[fmt.Sprintf("key-%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   error
+	}{
+		{
+			name: "no labels",
+		},
+		{
+			name:   "valid labels",
+			labels: map[string]string{"ticket": "ABC-1", "team/name": "platform", "v1.2_x": ""},
+		},
+		{
+			name:   "too many labels",
+			labels: tooMany,
+			want:   ErrInvalidLabels,
+		},
+		{
+			name:   "key with invalid character",
+			labels: map[string]string{"ticket:id": "ABC-1"},
+			want:   ErrInvalidLabels,
+		},
+		{
+			name:   "key must start with alphanumeric character",
+			labels: map[string]string{"-ticket": "ABC-1"},
+			want:   ErrInvalidLabels,
+		},
+		{
+			name:   "key too long",
+			labels: map[string]string{strings.Repeat("a", MaxLabelKeyLength+1): "ABC-1"},
+			want:   ErrInvalidLabels,
+		},
+		{
+			name:   "value too long",
+			labels: map[string]string{"ticket": strings.Repeat("a", MaxLabelValueLength+1)},
+			want:   ErrInvalidLabels,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLabels(tt.labels)
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseLabelFilter(t *testing.T) {
+	t.Run("key and value", func(t *testing.T) {
+		got, err := parseLabelFilter("ticket:ABC-1")
+		require.NoError(t, err)
+		assert.Equal(t, &LabelFilter{Key: "ticket", Value: "ABC-1"}, got)
+	})
+
+	t.Run("value containing colon", func(t *testing.T) {
+		got, err := parseLabelFilter("url:https://example.com")
+		require.NoError(t, err)
+		assert.Equal(t, &LabelFilter{Key: "url", Value: "https://example.com"}, got)
+	})
+
+	t.Run("missing colon", func(t *testing.T) {
+		_, err := parseLabelFilter("ticket")
+		assert.Equal(t, ErrInvalidLabelFilter, err)
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		_, err := parseLabelFilter(":ABC-1")
+		assert.Equal(t, ErrInvalidLabelFilter, err)
+	})
+}
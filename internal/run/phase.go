@@ -44,6 +44,13 @@ type (
 	// PhaseFinishOptions report the status of a phase upon finishing.
 	PhaseFinishOptions struct {
 		Errored bool `json:"errored,omitempty"`
+		// ErrorCategory classifies why the phase errored. Ignored unless
+		// Errored is true. Defaults to UnknownErrorCategory.
+		ErrorCategory ErrorCategory `json:"error_category,omitempty"`
+
+		// policyCheckFailed is set by the service if the plan has failed a
+		// policy check.
+		policyCheckFailed bool
 	}
 
 	PhaseStatusTimestamp struct {
@@ -24,7 +24,8 @@ type (
 
 	// ResourceChange represents a proposed change to a resource in a plan file
 	ResourceChange struct {
-		Change Change
+		Address string
+		Change  Change
 	}
 
 	// Change represents the type of change being made
@@ -65,6 +66,29 @@ func (pf *PlanFile) Summarize() (resource, output Report) {
 	return
 }
 
+// HasChanges determines whether the resource is to be created, updated or
+// deleted, as opposed to being left as it is or only read.
+func (rc ResourceChange) HasChanges() bool {
+	for _, action := range rc.Change.Actions {
+		switch action {
+		case CreateAction, UpdateAction, DeleteAction:
+			return true
+		}
+	}
+	return false
+}
+
+// changedResources returns the resources with changes in the plan file.
+func (pf *PlanFile) changedResources() []ResourceChange {
+	var changed []ResourceChange
+	for _, rc := range pf.ResourceChanges {
+		if rc.HasChanges() {
+			changed = append(changed, rc)
+		}
+	}
+	return changed
+}
+
 // CompilePlanReports compiles reports of planned changes from a JSON
 // representation of a plan file: one report for planned *resources*, and
 // another for planned *outputs*.
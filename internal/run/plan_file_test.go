@@ -19,6 +19,7 @@ func TestPlanFile(t *testing.T) {
 	want := PlanFile{
 		ResourceChanges: []ResourceChange{
 			{
+				Address: "module.random.random_id.test",
 				Change: Change{
 					Actions: []ChangeAction{
 						CreateAction,
@@ -26,6 +27,7 @@ func TestPlanFile(t *testing.T) {
 				},
 			},
 			{
+				Address: "null_resource.example",
 				Change: Change{
 					Actions: []ChangeAction{
 						CreateAction,
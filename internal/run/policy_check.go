@@ -0,0 +1,141 @@
+package run
+
+import (
+	"context"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+const (
+	PolicyCheckPassed PolicyCheckStatus = "passed"
+	PolicyCheckWarned PolicyCheckStatus = "warned"
+	PolicyCheckFailed PolicyCheckStatus = "failed"
+)
+
+type (
+	// PolicyCheck is the result of evaluating a run's plan against a set of
+	// policies once the plan phase has finished. A run that violates a policy
+	// that fails runs errors instead of proceeding to the apply phase.
+	PolicyCheck struct {
+		RunID string
+		// Name identifies the set of policies, e.g. module-versions.
+		Name       string
+		Violations []PolicyViolation
+		CreatedAt  time.Time
+	}
+
+	// PolicyViolation is a violation of a policy by a run.
+	PolicyViolation struct {
+		// Message explains the violation.
+		Message string `json:"message"`
+		// Fail is true if the violation fails the run; otherwise it is only a
+		// warning.
+		Fail bool `json:"fail"`
+	}
+
+	PolicyCheckStatus string
+
+	// PolicyCheckFunc evaluates a run's plan against a set of policies,
+	// returning the result, or nil if none of the policies apply to the run.
+	PolicyCheckFunc func(ctx context.Context, run *Run, plan PolicyCheckInput) (*PolicyCheck, error)
+
+	// PolicyCheckInput is the output of a run's plan phase that is evaluated
+	// against policies.
+	PolicyCheckInput struct {
+		// PlanJSON is the plan in JSON format.
+		PlanJSON []byte
+		// ModuleManifest is the manifest of modules installed by terraform
+		// init, i.e. .terraform/modules/modules.json. It is nil if the
+		// configuration doesn't call any modules.
+		ModuleManifest []byte
+	}
+)
+
+// Status summarises the outcome of the check: it has failed if any of its
+// violations fail the run, and warned if there are only warnings.
+func (c *PolicyCheck) Status() PolicyCheckStatus {
+	status := PolicyCheckPassed
+	for _, v := range c.Violations {
+		if v.Fail {
+			return PolicyCheckFailed
+		}
+		status = PolicyCheckWarned
+	}
+	return status
+}
+
+// AddPolicyCheck adds a check to be carried out on each run once its plan has
+// finished.
+func (s *Service) AddPolicyCheck(check PolicyCheckFunc) {
+	s.policyChecks = append(s.policyChecks, check)
+}
+
+// checkPolicies carries out policy checks on a run whose plan has finished.
+func (s *Service) checkPolicies(ctx context.Context, runID string) ([]*PolicyCheck, error) {
+	if len(s.policyChecks) == 0 {
+		return nil, nil
+	}
+	run, err := s.db.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.GetPlanFile(ctx, runID, PlanFormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := s.db.getModuleManifest(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	input := PolicyCheckInput{PlanJSON: plan, ModuleManifest: manifest}
+
+	var checks []*PolicyCheck
+	for _, fn := range s.policyChecks {
+		check, err := fn(ctx, run, input)
+		if err != nil {
+			return nil, err
+		}
+		if check == nil {
+			continue
+		}
+		check.RunID = runID
+		check.CreatedAt = internal.CurrentTimestamp(nil)
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// ListPolicyChecks lists the results of the policy checks carried out on a
+// run.
+func (s *Service) ListPolicyChecks(ctx context.Context, runID string) ([]*PolicyCheck, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := s.db.listPolicyChecks(ctx, runID)
+	if err != nil {
+		s.logger.Error("listing run policy checks", "run", runID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("listed run policy checks", "run", runID, "count", len(checks), "subject", subject)
+	return checks, nil
+}
+
+// UploadModuleManifest persists the manifest of modules installed during a
+// run's plan phase.
+func (s *Service) UploadModuleManifest(ctx context.Context, runID string, manifest []byte) error {
+	subject, err := s.CanAccess(ctx, rbac.UploadModuleManifestAction, runID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.setModuleManifest(ctx, runID, manifest); err != nil {
+		s.logger.Error("uploading module manifest", "id", runID, "subject", subject, "err", err)
+		return err
+	}
+	s.logger.Info("uploaded module manifest", "id", runID)
+	return nil
+}
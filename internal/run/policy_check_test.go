@@ -0,0 +1,25 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyCheck_Status(t *testing.T) {
+	tests := []struct {
+		name       string
+		violations []PolicyViolation
+		want       PolicyCheckStatus
+	}{
+		{"no violations", nil, PolicyCheckPassed},
+		{"warnings", []PolicyViolation{{Message: "a"}, {Message: "b"}}, PolicyCheckWarned},
+		{"failure", []PolicyViolation{{Message: "a"}, {Message: "b", Fail: true}}, PolicyCheckFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := &PolicyCheck{Violations: tt.violations}
+			assert.Equal(t, tt.want, check.Status())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package run
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Priority determines the order in which a workspace's runs are scheduled,
+// and the order in which their jobs are allocated to agents.
+type Priority string
+
+const (
+	// PriorityNormal is the priority of runs unless otherwise specified.
+	PriorityNormal Priority = "normal"
+	// PriorityHigh is the priority of urgent runs, e.g. emergency changes
+	// during an incident, which are scheduled and allocated ahead of normal
+	// priority runs.
+	PriorityHigh Priority = "high"
+
+	// DefaultPriorityAging is the default length of time after which a
+	// normal priority run or job still waiting to be scheduled or allocated
+	// is promoted to high priority.
+	DefaultPriorityAging = 30 * time.Minute
+)
+
+var (
+	ErrInvalidPriority = errors.New("invalid run priority")
+	// ErrHighPriorityRunLimitReached is returned when creating a high
+	// priority run in an organization that already has its maximum number of
+	// incomplete high priority runs.
+	ErrHighPriorityRunLimitReached = errors.New("organization has reached its maximum number of high priority runs")
+)
+
+// Validate checks the priority is one of the supported values.
+func (p Priority) Validate() error {
+	switch p {
+	case PriorityNormal, PriorityHigh:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidPriority, p)
+	}
+}
+
+// EffectivePriority returns the priority of a run or job that has been waiting
+// since the given time. A normal priority run or job that has waited longer
+// than the aging period is promoted to high priority, so that a steady stream
+// of high priority runs cannot starve it indefinitely. An aging period of zero
+// disables promotion.
+func EffectivePriority(p Priority, waitingSince, now time.Time, aging time.Duration) Priority {
+	if p == PriorityNormal && aging > 0 && now.Sub(waitingSince) > aging {
+		return PriorityHigh
+	}
+	return p
+}
+
+// checkHighPriorityRunLimit checks whether another high priority run can be
+// created in an organization that already has the given number of incomplete
+// high priority runs. A maximum of zero means there is no limit.
+func checkHighPriorityRunLimit(runs, max int) error {
+	if max > 0 && runs >= max {
+		return fmt.Errorf("%w: limit is %d", ErrHighPriorityRunLimitReached, max)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriority_Validate(t *testing.T) {
+	assert.NoError(t, PriorityNormal.Validate())
+	assert.NoError(t, PriorityHigh.Validate())
+	assert.ErrorIs(t, Priority("urgent").Validate(), ErrInvalidPriority)
+	assert.ErrorIs(t, Priority("").Validate(), ErrInvalidPriority)
+}
+
+func TestEffectivePriority(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		priority     Priority
+		waitingSince time.Time
+		aging        time.Duration
+		want         Priority
+	}{
+		{
+			name:         "normal priority within aging period",
+			priority:     PriorityNormal,
+			waitingSince: now.Add(-time.Minute),
+			aging:        time.Hour,
+			want:         PriorityNormal,
+		},
+		{
+			name:         "normal priority promoted after aging period",
+			priority:     PriorityNormal,
+			waitingSince: now.Add(-2 * time.Hour),
+			aging:        time.Hour,
+			want:         PriorityHigh,
+		},
+		{
+			name:         "aging disabled",
+			priority:     PriorityNormal,
+			waitingSince: now.Add(-2 * time.Hour),
+			want:         PriorityNormal,
+		},
+		{
+			name:         "high priority",
+			priority:     PriorityHigh,
+			waitingSince: now,
+			aging:        time.Hour,
+			want:         PriorityHigh,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EffectivePriority(tt.priority, tt.waitingSince, now, tt.aging)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckHighPriorityRunLimit(t *testing.T) {
+	assert.NoError(t, checkHighPriorityRunLimit(0, 1))
+	assert.ErrorIs(t, checkHighPriorityRunLimit(1, 1), ErrHighPriorityRunLimitReached)
+	assert.ErrorIs(t, checkHighPriorityRunLimit(3, 2), ErrHighPriorityRunLimitReached)
+	// zero means no limit
+	assert.NoError(t, checkHighPriorityRunLimit(100, 0))
+}
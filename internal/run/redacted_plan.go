@@ -0,0 +1,175 @@
+package run
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+const (
+	// sensitivePlaceholder replaces sensitive values in a redacted plan...
+	sensitivePlaceholder = "(sensitive value)"
+	// ...unless the value is changed by the plan, in which case this replaces
+	// its value after the change.
+	changedSensitivePlaceholder = "(changed sensitive value)"
+)
+
+type (
+	// redactedPlan is the redacted JSON plan that terraform renders when
+	// structured run output is enabled: a JSON plan with its sensitive values
+	// redacted, together with the schemas of its providers.
+	redactedPlan struct {
+		PlanFormatVersion     string                       `json:"plan_format_version"`
+		OutputChanges         map[string]json.RawMessage   `json:"output_changes,omitempty"`
+		ResourceChanges       []map[string]json.RawMessage `json:"resource_changes,omitempty"`
+		ResourceDrift         []map[string]json.RawMessage `json:"resource_drift,omitempty"`
+		RelevantAttributes    json.RawMessage              `json:"relevant_attributes,omitempty"`
+		ProviderFormatVersion string                       `json:"provider_format_version"`
+		ProviderSchemas       json.RawMessage              `json:"provider_schemas,omitempty"`
+	}
+
+	// jsonPlan is the subset of the JSON plan produced by `terraform show
+	// -json` that is included in a redacted plan.
+	jsonPlan struct {
+		FormatVersion      string                       `json:"format_version"`
+		OutputChanges      map[string]json.RawMessage   `json:"output_changes"`
+		ResourceChanges    []map[string]json.RawMessage `json:"resource_changes"`
+		ResourceDrift      []map[string]json.RawMessage `json:"resource_drift"`
+		RelevantAttributes json.RawMessage              `json:"relevant_attributes"`
+	}
+
+	// jsonProviderSchemas is the output of `terraform providers schema -json`.
+	jsonProviderSchemas struct {
+		FormatVersion   string          `json:"format_version"`
+		ProviderSchemas json.RawMessage `json:"provider_schemas"`
+	}
+)
+
+// NewRedactedPlan constructs a redacted plan from a JSON plan and the JSON
+// schemas of its providers. Sensitive values are replaced with a placeholder,
+// preserving only whether they are null and whether they are changed by the
+// plan.
+func NewRedactedPlan(plan, schemas []byte) ([]byte, error) {
+	var p jsonPlan
+	if err := json.Unmarshal(plan, &p); err != nil {
+		return nil, fmt.Errorf("unmarshalling plan: %w", err)
+	}
+	var s jsonProviderSchemas
+	if err := json.Unmarshal(schemas, &s); err != nil {
+		return nil, fmt.Errorf("unmarshalling provider schemas: %w", err)
+	}
+	redacted := redactedPlan{
+		PlanFormatVersion:     p.FormatVersion,
+		OutputChanges:         make(map[string]json.RawMessage, len(p.OutputChanges)),
+		ResourceChanges:       p.ResourceChanges,
+		ResourceDrift:         p.ResourceDrift,
+		RelevantAttributes:    p.RelevantAttributes,
+		ProviderFormatVersion: s.FormatVersion,
+		ProviderSchemas:       s.ProviderSchemas,
+	}
+	for name, change := range p.OutputChanges {
+		change, err := redactChange(change)
+		if err != nil {
+			return nil, fmt.Errorf("redacting output %s: %w", name, err)
+		}
+		redacted.OutputChanges[name] = change
+	}
+	for _, resources := range [][]map[string]json.RawMessage{p.ResourceChanges, p.ResourceDrift} {
+		for _, rc := range resources {
+			change, err := redactChange(rc["change"])
+			if err != nil {
+				return nil, fmt.Errorf("redacting resource %s: %w", rc["address"], err)
+			}
+			rc["change"] = change
+		}
+	}
+	return json.Marshal(redacted)
+}
+
+// redactChange redacts the sensitive values of a change in a JSON plan.
+func redactChange(raw json.RawMessage) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return raw, nil
+	}
+	// decode numbers as is to avoid losing precision.
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var change map[string]any
+	if err := dec.Decode(&change); err != nil {
+		return nil, err
+	}
+	change["before"], change["after"] = redactValues(
+		change["before"],
+		change["after"],
+		change["before_sensitive"],
+		change["after_sensitive"],
+	)
+	return json.Marshal(change)
+}
+
+// redactValues replaces the values marked as sensitive in the before and
+// after values of a change with a placeholder. A sensitive value that differs
+// between before and after is given a different placeholder in each, so that
+// the change is still rendered as such.
+func redactValues(before, after, beforeSensitive, afterSensitive any) (any, any) {
+	if beforeSensitive == true || afterSensitive == true {
+		changed := !reflect.DeepEqual(before, after)
+		if before != nil {
+			before = sensitivePlaceholder
+		}
+		if after != nil {
+			after = sensitivePlaceholder
+			if changed && before != nil {
+				after = changedSensitivePlaceholder
+			}
+		}
+		return before, after
+	}
+	beforeMap, beforeIsMap := before.(map[string]any)
+	afterMap, afterIsMap := after.(map[string]any)
+	if beforeIsMap || afterIsMap {
+		bs, _ := beforeSensitive.(map[string]any)
+		as, _ := afterSensitive.(map[string]any)
+		keys := make(map[string]struct{})
+		for k := range bs {
+			keys[k] = struct{}{}
+		}
+		for k := range as {
+			keys[k] = struct{}{}
+		}
+		for k := range keys {
+			b, a := redactValues(beforeMap[k], afterMap[k], bs[k], as[k])
+			if _, ok := beforeMap[k]; ok {
+				beforeMap[k] = b
+			}
+			if _, ok := afterMap[k]; ok {
+				afterMap[k] = a
+			}
+		}
+		return before, after
+	}
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+	if beforeIsList || afterIsList {
+		bs, _ := beforeSensitive.([]any)
+		as, _ := afterSensitive.([]any)
+		for i := 0; i < max(len(beforeList), len(afterList)); i++ {
+			b, a := redactValues(elem(beforeList, i), elem(afterList, i), elem(bs, i), elem(as, i))
+			if i < len(beforeList) {
+				beforeList[i] = b
+			}
+			if i < len(afterList) {
+				afterList[i] = a
+			}
+		}
+	}
+	return before, after
+}
+
+func elem(list []any, i int) any {
+	if i < len(list) {
+		return list[i]
+	}
+	return nil
+}
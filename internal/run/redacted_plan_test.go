@@ -0,0 +1,102 @@
+package run
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRedactedPlan(t *testing.T) {
+	plan := []byte(`{
+  "format_version": "1.2",
+  "resource_changes": [
+    {
+      "address": "random_password.db",
+      "type": "random_password",
+      "change": {
+        "actions": ["update"],
+        "before": {"length": 16, "result": "hunter2", "keepers": {"a": "b"}},
+        "after": {"length": 16, "result": "correct-horse", "keepers": {"a": "b"}},
+        "before_sensitive": {"result": true},
+        "after_sensitive": {"result": true}
+      }
+    },
+    {
+      "address": "null_resource.list",
+      "type": "null_resource",
+      "change": {
+        "actions": ["create"],
+        "before": null,
+        "after": {"triggers": ["secret", "public"]},
+        "before_sensitive": false,
+        "after_sensitive": {"triggers": [true, false]}
+      }
+    }
+  ],
+  "output_changes": {
+    "password": {
+      "actions": ["no-op"],
+      "before": "hunter2",
+      "after": "hunter2",
+      "before_sensitive": true,
+      "after_sensitive": true
+    }
+  },
+  "relevant_attributes": [{"resource": "random_password.db", "attribute": ["result"]}]
+}`)
+	schemas := []byte(`{
+  "format_version": "1.0",
+  "provider_schemas": {"registry.terraform.io/hashicorp/random": {}}
+}`)
+
+	redacted, err := NewRedactedPlan(plan, schemas)
+	require.NoError(t, err)
+
+	var got struct {
+		PlanFormatVersion string `json:"plan_format_version"`
+		ResourceChanges   []struct {
+			Address string `json:"address"`
+			Change  struct {
+				Before map[string]any `json:"before"`
+				After  map[string]any `json:"after"`
+			} `json:"change"`
+		} `json:"resource_changes"`
+		OutputChanges map[string]struct {
+			Before any `json:"before"`
+			After  any `json:"after"`
+		} `json:"output_changes"`
+		RelevantAttributes    json.RawMessage `json:"relevant_attributes"`
+		ProviderFormatVersion string          `json:"provider_format_version"`
+		ProviderSchemas       map[string]any  `json:"provider_schemas"`
+	}
+	require.NoError(t, json.Unmarshal(redacted, &got))
+
+	assert.Equal(t, "1.2", got.PlanFormatVersion)
+	assert.Equal(t, "1.0", got.ProviderFormatVersion)
+	assert.Contains(t, got.ProviderSchemas, "registry.terraform.io/hashicorp/random")
+	assert.JSONEq(t, `[{"resource": "random_password.db", "attribute": ["result"]}]`, string(got.RelevantAttributes))
+
+	require.Len(t, got.ResourceChanges, 2)
+	// changed sensitive value is redacted with differing placeholders
+	db := got.ResourceChanges[0].Change
+	assert.Equal(t, sensitivePlaceholder, db.Before["result"])
+	assert.Equal(t, changedSensitivePlaceholder, db.After["result"])
+	// values that are not sensitive are retained
+	assert.Equal(t, float64(16), db.After["length"])
+	assert.Equal(t, map[string]any{"a": "b"}, db.After["keepers"])
+
+	// sensitive element of list is redacted
+	list := got.ResourceChanges[1].Change
+	assert.Nil(t, list.Before)
+	assert.Equal(t, []any{sensitivePlaceholder, "public"}, list.After["triggers"])
+
+	// unchanged sensitive value is redacted with the same placeholder
+	assert.Equal(t, sensitivePlaceholder, got.OutputChanges["password"].Before)
+	assert.Equal(t, sensitivePlaceholder, got.OutputChanges["password"].After)
+
+	assert.NotContains(t, string(redacted), "hunter2")
+	assert.NotContains(t, string(redacted), "correct-horse")
+	assert.NotContains(t, string(redacted), `"secret"`)
+}
@@ -99,7 +99,7 @@ func (r *Reporter) handleRun(ctx context.Context, run *Run) error {
 		description string
 	)
 	switch run.Status {
-	case RunPending, RunPlanQueued, RunApplyQueued:
+	case RunPending, RunPreflighting, RunPlanQueued, RunApplyQueued, RunAwaitingWindow:
 		status = vcs.PendingStatus
 	case RunPlanning, RunApplying, RunPlanned, RunConfirmed:
 		status = vcs.RunningStatus
@@ -18,8 +18,9 @@ import (
 )
 
 const (
-	PlanFormatBinary = "bin"  // plan file in binary format
-	PlanFormatJSON   = "json" // plan file in json format
+	PlanFormatBinary       = "bin"           // plan file in binary format
+	PlanFormatJSON         = "json"          // plan file in json format
+	PlanFormatRedactedJSON = "redacted-json" // plan file in json format with sensitive values redacted, along with provider schemas
 
 	PlanOnlyOperation     Operation = "plan-only"
 	PlanAndApplyOperation Operation = "plan-and-apply"
@@ -40,30 +41,45 @@ type (
 
 	// Run is a terraform run.
 	Run struct {
-		ID                     string                  `jsonapi:"primary,runs"`
-		CreatedAt              time.Time               `jsonapi:"attribute" json:"created_at"`
-		IsDestroy              bool                    `jsonapi:"attribute" json:"is_destroy"`
-		CancelSignaledAt       *time.Time              `jsonapi:"attribute" json:"cancel_signaled_at"`
-		Message                string                  `jsonapi:"attribute" json:"message"`
-		Organization           string                  `jsonapi:"attribute" json:"organization"`
-		Refresh                bool                    `jsonapi:"attribute" json:"refresh"`
-		RefreshOnly            bool                    `jsonapi:"attribute" json:"refresh_only"`
-		ReplaceAddrs           []string                `jsonapi:"attribute" json:"replace_addrs"`
-		PositionInQueue        int                     `jsonapi:"attribute" json:"position_in_queue"`
-		TargetAddrs            []string                `jsonapi:"attribute" json:"target_addrs"`
-		TerraformVersion       string                  `jsonapi:"attribute" json:"terraform_version"`
-		AllowEmptyApply        bool                    `jsonapi:"attribute" json:"allow_empty_apply"`
-		AutoApply              bool                    `jsonapi:"attribute" json:"auto_apply"`
-		PlanOnly               bool                    `jsonapi:"attribute" json:"plan_only"`
-		Source                 Source                  `jsonapi:"attribute" json:"source"`
-		Status                 Status                  `jsonapi:"attribute" json:"status"`
+		ID                     string     `jsonapi:"primary,runs"`
+		CreatedAt              time.Time  `jsonapi:"attribute" json:"created_at"`
+		IsDestroy              bool       `jsonapi:"attribute" json:"is_destroy"`
+		CancelSignaledAt       *time.Time `jsonapi:"attribute" json:"cancel_signaled_at"`
+		ForceCancelAvailableAt *time.Time `jsonapi:"attribute" json:"force_cancel_available_at"`
+		Message                string     `jsonapi:"attribute" json:"message"`
+		Organization           string     `jsonapi:"attribute" json:"organization"`
+		Refresh                bool       `jsonapi:"attribute" json:"refresh"`
+		RefreshOnly            bool       `jsonapi:"attribute" json:"refresh_only"`
+		ReplaceAddrs           []string   `jsonapi:"attribute" json:"replace_addrs"`
+		PositionInQueue        int        `jsonapi:"attribute" json:"position_in_queue"`
+		TargetAddrs            []string   `jsonapi:"attribute" json:"target_addrs"`
+		TerraformVersion       string     `jsonapi:"attribute" json:"terraform_version"`
+		AllowEmptyApply        bool       `jsonapi:"attribute" json:"allow_empty_apply"`
+		AutoApply              bool       `jsonapi:"attribute" json:"auto_apply"`
+		// SkipPreflight is true if the run bypasses its workspace's
+		// pre-flight checks.
+		SkipPreflight bool `jsonapi:"attribute" json:"skip_preflight"`
+		PlanOnly      bool `jsonapi:"attribute" json:"plan_only"`
+		// DebugLogging is true if the run's jobs are run with terraform's
+		// debug logging enabled.
+		DebugLogging bool   `jsonapi:"attribute" json:"debug_logging"`
+		Source       Source `jsonapi:"attribute" json:"source"`
+		Status       Status `jsonapi:"attribute" json:"status"`
+		// ErrorCategory classifies why the run errored. It is empty unless
+		// the run has errored.
+		ErrorCategory          ErrorCategory           `jsonapi:"attribute" json:"error_category"`
 		WorkspaceID            string                  `jsonapi:"attribute" json:"workspace_id"`
 		ConfigurationVersionID string                  `jsonapi:"attribute" json:"configuration_version_id"`
 		ExecutionMode          workspace.ExecutionMode `jsonapi:"attribute" json:"execution_mode"`
-		AgentPoolID            *string                 `jsonapi:"attribute" json:"agent_pool_id"`
-		Variables              []Variable              `jsonapi:"attribute" json:"variables"`
-		Plan                   Phase                   `jsonapi:"attribute" json:"plan"`
-		Apply                  Phase                   `jsonapi:"attribute" json:"apply"`
+		// StructuredRunOutput is true if the run's workspace has structured
+		// run output enabled, in which case terraform's machine-readable
+		// output is streamed in addition to its human-readable output.
+		StructuredRunOutput bool              `jsonapi:"attribute" json:"structured_run_output"`
+		AgentPoolID         *string           `jsonapi:"attribute" json:"agent_pool_id"`
+		Variables           []Variable        `jsonapi:"attribute" json:"variables"`
+		Labels              map[string]string `jsonapi:"attribute" json:"labels"`
+		Plan                Phase             `jsonapi:"attribute" json:"plan"`
+		Apply               Phase             `jsonapi:"attribute" json:"apply"`
 
 		// Timestamps of when a state transition occured. Ordered earliest
 		// first.
@@ -78,6 +94,22 @@ type (
 		// instead triggered by a VCS event.
 		CreatedBy *string
 
+		// ParentRunID is the ID of the run from which this run was created,
+		// i.e. the run of which this run is a canary, or the parent run
+		// proceeding to a full apply after its canary. Nil if the run has no
+		// parent.
+		ParentRunID *string `jsonapi:"attribute" json:"parent_run_id"`
+
+		// Priority determines the order in which the run is scheduled and its
+		// jobs are allocated to agents.
+		Priority Priority `jsonapi:"attribute" json:"priority"`
+
+		// ForceCanceledBy is the user who forceably canceled the run, and
+		// ForceCancelReason is the reason they gave for doing so. Both are nil
+		// unless the run has been force canceled.
+		ForceCanceledBy   *string `jsonapi:"attribute" json:"force_canceled_by"`
+		ForceCancelReason *string `jsonapi:"attribute" json:"force_cancel_reason"`
+
 		// OTF doesn't support cost estimation but some go-tfe API tests expect
 		// a run to enter the RunCostEstimated state, and this boolean
 		// determines whether to enter that state upon finishing a plan.
@@ -111,11 +143,26 @@ type (
 		Source                 Source
 		TerraformVersion       *string
 		AllowEmptyApply        *bool
+		// SkipPreflight bypasses the workspace's pre-flight checks.
+		SkipPreflight *bool
 		// PlanOnly specifies if this is a speculative, plan-only run that
 		// Terraform cannot apply. Takes precedence over whether the
 		// configuration version is marked as speculative or not.
-		PlanOnly  *bool
-		Variables []Variable
+		PlanOnly *bool
+		// DebugLogging enables or disables debug logging for this run,
+		// overriding the workspace's debug logging setting. If nil, the
+		// workspace's setting applies.
+		DebugLogging *bool
+		Variables    []Variable
+		// Labels are arbitrary key-value pairs for correlating the run with
+		// other systems.
+		Labels map[string]string
+		// Priority sets the priority of the run. Defaults to normal. Creating
+		// a high priority run requires permission to do so.
+		Priority *Priority
+
+		// parentRunID is the ID of the run from which the run is created.
+		parentRunID *string
 
 		// testing purposes
 		now *time.Time
@@ -140,6 +187,10 @@ type (
 		CommitSHA *string
 		// Filter by VCS user's username that triggered a run
 		VCSUsername *string
+		// Filter by label
+		Label *LabelFilter
+		// Filter by error categories (with an implicit OR condition)
+		ErrorCategories []ErrorCategory
 	}
 
 	// WatchOptions filters events returned by the Watch endpoint.
@@ -149,6 +200,17 @@ type (
 	}
 )
 
+// New constructs a new run for the workspace, using the given organization and
+// configuration version, and applying the same validation as the service. It
+// is intended for implementations of the service other than Service, such as
+// fakes.
+func New(ctx context.Context, org *organization.Organization, cv *configversion.ConfigurationVersion, ws *workspace.Workspace, opts CreateOptions) (*Run, error) {
+	if err := validateCreateOptions(opts); err != nil {
+		return nil, err
+	}
+	return newRun(ctx, org, cv, ws, opts), nil
+}
+
 // newRun creates a new run with defaults.
 func newRun(ctx context.Context, org *organization.Organization, cv *configversion.ConfigurationVersion, ws *workspace.Workspace, opts CreateOptions) *Run {
 	run := Run{
@@ -168,6 +230,9 @@ func newRun(ctx context.Context, org *organization.Organization, cv *configversi
 		Source:                 opts.Source,
 		TerraformVersion:       ws.TerraformVersion,
 		Variables:              opts.Variables,
+		Labels:                 opts.Labels,
+		ParentRunID:            opts.parentRunID,
+		Priority:               PriorityNormal,
 	}
 	run.Plan = newPhase(run.ID, internal.PlanPhase)
 	run.Apply = newPhase(run.ID, internal.ApplyPhase)
@@ -182,6 +247,9 @@ func newRun(ctx context.Context, org *organization.Organization, cv *configversi
 	if opts.AllowEmptyApply != nil {
 		run.AllowEmptyApply = *opts.AllowEmptyApply
 	}
+	if opts.SkipPreflight != nil {
+		run.SkipPreflight = *opts.SkipPreflight
+	}
 	if user, _ := user.UserFromContext(ctx); user != nil {
 		run.CreatedBy = &user.Username
 	}
@@ -200,9 +268,28 @@ func newRun(ctx context.Context, org *organization.Organization, cv *configversi
 	if opts.PlanOnly != nil {
 		run.PlanOnly = *opts.PlanOnly
 	}
+	if opts.DebugLogging != nil {
+		run.DebugLogging = *opts.DebugLogging
+	}
+	if opts.Priority != nil {
+		run.Priority = *opts.Priority
+	}
 	return &run
 }
 
+// validateCreateOptions validates the options for creating a run.
+func validateCreateOptions(opts CreateOptions) error {
+	if err := validateLabels(opts.Labels); err != nil {
+		return err
+	}
+	if opts.Priority != nil {
+		if err := opts.Priority.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *Run) String() string { return r.ID }
 
 func (r *Run) Queued() bool {
@@ -272,7 +359,7 @@ func (r *Run) Phase() internal.PhaseType {
 	switch r.Status {
 	case RunPending:
 		return internal.PendingPhase
-	case RunPlanQueued, RunPlanning, RunPlanned:
+	case RunPreflighting, RunPlanQueued, RunPlanning, RunPlanned, RunAwaitingWindow:
 		return internal.PlanPhase
 	case RunApplyQueued, RunApplying, RunApplied:
 		return internal.ApplyPhase
@@ -316,8 +403,15 @@ func (r *Run) InProgress() bool {
 //
 // The force arg when set to true forceably cancels the run. This is only
 // allowed when an attempt has already been made to cancel the run
-// non-forceably. The force arg is only respected when isUser is true.
+// non-forceably and the cool-off period has since elapsed. The force arg is
+// only respected when isUser is true.
 func (r *Run) Cancel(isUser, force bool) error {
+	return r.cancel(isUser, force, DefaultForceCancelCoolOff)
+}
+
+// cancel cancels the run, permitting it to be forceably canceled once coolOff
+// has elapsed since a cancelation signal is sent.
+func (r *Run) cancel(isUser, force bool, coolOff time.Duration) error {
 	if force {
 		if isUser {
 			if !r.ForceCancelable() {
@@ -330,7 +424,7 @@ func (r *Run) Cancel(isUser, force bool) error {
 	}
 	var signal bool
 	switch r.Status {
-	case RunPending:
+	case RunPending, RunPreflighting:
 		r.Plan.UpdateStatus(PhaseUnreachable)
 		r.Apply.UpdateStatus(PhaseUnreachable)
 	case RunPlanQueued:
@@ -345,7 +439,7 @@ func (r *Run) Cancel(isUser, force bool) error {
 			r.Plan.UpdateStatus(PhaseCanceled)
 			r.Apply.UpdateStatus(PhaseUnreachable)
 		}
-	case RunPlanned:
+	case RunPlanned, RunAwaitingWindow:
 		r.Apply.UpdateStatus(PhaseUnreachable)
 	case RunApplying:
 		if isUser && !force {
@@ -362,7 +456,9 @@ func (r *Run) Cancel(isUser, force bool) error {
 		// set timestamp to indicate signal is to be sent, but do not set
 		// status to RunCanceled yet.
 		now := internal.CurrentTimestamp(nil)
+		availableAt := now.Add(coolOff)
 		r.CancelSignaledAt = &now
+		r.ForceCancelAvailableAt = &availableAt
 		return nil
 	}
 	if force {
@@ -379,43 +475,26 @@ func (r *Run) Cancelable() bool {
 		return false
 	}
 	switch r.Status {
-	case RunPending, RunPlanQueued, RunPlanning, RunApplyQueued, RunApplying:
+	case RunPending, RunPreflighting, RunPlanQueued, RunPlanning, RunApplyQueued, RunApplying:
 		return true
 	default:
 		return false
 	}
 }
 
-// ForceCancelable determines whether run can be forceably cancelled.
+// DefaultForceCancelCoolOff is the default length of time that must elapse
+// after a run has been sent a cancelation signal before it can be forceably
+// canceled.
+const DefaultForceCancelCoolOff = 10 * time.Second
+
+// ForceCancelable determines whether run can be forceably cancelled. It is
+// only possible to do so when an attempt has previously been made to cancel
+// the run non-forceably and the cool-off period has since elapsed.
 func (r *Run) ForceCancelable() bool {
-	availableAt := r.ForceCancelAvailableAt()
-	if availableAt == nil || time.Now().Before(*availableAt) {
+	if r.Done() || r.ForceCancelAvailableAt == nil {
 		return false
 	}
-	return true
-}
-
-// ForceCancelAvailableAt provides the time from which point it is permitted to
-// forceably cancel the run. It only possible to do so when an attempt has
-// previously been made to cancel the run non-forceably and a cool-off period
-// has elapsed.
-func (r *Run) ForceCancelAvailableAt() *time.Time {
-	if r.Done() || r.CancelSignaledAt == nil {
-		// cannot force cancel a run that is already complete or when no attempt
-		// has previously been made to cancel run.
-		return nil
-	}
-	return r.cancelCoolOff()
-}
-
-const forceCancelCoolOff = time.Second * 10
-
-func (r *Run) cancelCoolOff() *time.Time {
-	if r.CancelSignaledAt == nil {
-		return nil
-	}
-	cooledOff := r.CancelSignaledAt.Add(forceCancelCoolOff)
-	return &cooledOff
+	return !time.Now().Before(*r.ForceCancelAvailableAt)
 }
 
 // StartedAt returns the time the run was created.
@@ -437,7 +516,9 @@ func (r *Run) Done() bool {
 // EnqueuePlan enqueues a plan for the run. It also sets the run as the latest
 // run for its workspace (speculative runs are ignored).
 func (r *Run) EnqueuePlan() error {
-	if r.Status != RunPending {
+	switch r.Status {
+	case RunPending, RunPreflighting:
+	default:
 		return fmt.Errorf("cannot enqueue run with status %s", r.Status)
 	}
 	r.updateStatus(RunPlanQueued, nil)
@@ -446,6 +527,29 @@ func (r *Run) EnqueuePlan() error {
 	return nil
 }
 
+// AwaitPreflight holds a run from being planned until its workspace's
+// pre-flight checks have passed.
+func (r *Run) AwaitPreflight() error {
+	if r.Status != RunPending {
+		return fmt.Errorf("cannot hold run with status %s", r.Status)
+	}
+	r.updateStatus(RunPreflighting, nil)
+	return nil
+}
+
+// FailPreflight errors a run that has failed its workspace's pre-flight
+// checks.
+func (r *Run) FailPreflight() error {
+	if r.Status != RunPreflighting {
+		return fmt.Errorf("cannot fail pre-flight checks for run with status %s", r.Status)
+	}
+	r.updateStatus(RunErrored, nil)
+	r.ErrorCategory = PreflightErrorCategory
+	r.Plan.UpdateStatus(PhaseErrored)
+	r.Apply.UpdateStatus(PhaseUnreachable)
+	return nil
+}
+
 func (*Run) CanAccessSite(action rbac.Action) bool {
 	// run cannot carry out site-level actions
 	return false
@@ -463,7 +567,7 @@ func (r *Run) CanAccessWorkspace(action rbac.Action, policy *internal.WorkspaceP
 
 func (r *Run) EnqueueApply() error {
 	switch r.Status {
-	case RunPlanned, RunCostEstimated:
+	case RunPlanned, RunCostEstimated, RunAwaitingWindow:
 		// applyable statuses
 	default:
 		return fmt.Errorf("cannot apply run with status %s", r.Status)
@@ -473,6 +577,19 @@ func (r *Run) EnqueueApply() error {
 	return nil
 }
 
+// AwaitApplyWindow holds a run that would otherwise have been applied until
+// its workspace's next apply window opens.
+func (r *Run) AwaitApplyWindow() error {
+	switch r.Status {
+	case RunPlanned, RunCostEstimated:
+		// applyable statuses
+	default:
+		return fmt.Errorf("cannot hold run with status %s", r.Status)
+	}
+	r.updateStatus(RunAwaitingWindow, nil)
+	return nil
+}
+
 func (r *Run) StatusTimestamp(status Status) (time.Time, error) {
 	for _, rst := range r.StatusTimestamps {
 		if rst.Status == status {
@@ -514,10 +631,19 @@ func (r *Run) Finish(phase internal.PhaseType, opts PhaseFinishOptions) (autoapp
 		}
 		if opts.Errored {
 			r.updateStatus(RunErrored, nil)
+			r.ErrorCategory = opts.ErrorCategory.orUnknown()
 			r.Plan.UpdateStatus(PhaseErrored)
 			r.Apply.UpdateStatus(PhaseUnreachable)
 			return false, nil
 		}
+		if opts.policyCheckFailed {
+			// the plan itself succeeded but the run cannot proceed.
+			r.updateStatus(RunErrored, nil)
+			r.ErrorCategory = PolicyCheckErrorCategory
+			r.Plan.UpdateStatus(PhaseFinished)
+			r.Apply.UpdateStatus(PhaseUnreachable)
+			return false, nil
+		}
 		// Enter RunCostEstimated state if cost estimation is enabled. OTF does
 		// not support cost estimation but enter this state only in order to
 		// satisfy the go-tfe tests.
@@ -540,6 +666,7 @@ func (r *Run) Finish(phase internal.PhaseType, opts PhaseFinishOptions) (autoapp
 		}
 		if opts.Errored {
 			r.updateStatus(RunErrored, nil)
+			r.ErrorCategory = opts.ErrorCategory.orUnknown()
 			r.Apply.UpdateStatus(PhaseErrored)
 		} else {
 			r.updateStatus(RunApplied, nil)
@@ -563,7 +690,7 @@ func (r *Run) updateStatus(status Status, now *time.Time) *Run {
 // Discardable determines whether run can be discarded.
 func (r *Run) Discardable() bool {
 	switch r.Status {
-	case RunPending, RunPlanned, RunCostEstimated:
+	case RunPending, RunPlanned, RunCostEstimated, RunAwaitingWindow:
 		return true
 	default:
 		return false
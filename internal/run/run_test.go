@@ -22,6 +22,19 @@ func TestRun_New_CreatedBy(t *testing.T) {
 	assert.Equal(t, "terry", *run.CreatedBy)
 }
 
+func TestRun_New_DebugLogging(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, newTestRun(ctx, CreateOptions{}).DebugLogging)
+	assert.True(t, newTestRun(ctx, CreateOptions{DebugLogging: internal.Bool(true)}).DebugLogging)
+}
+
+func TestRun_New_Priority(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, PriorityNormal, newTestRun(ctx, CreateOptions{}).Priority)
+	high := PriorityHigh
+	assert.Equal(t, PriorityHigh, newTestRun(ctx, CreateOptions{Priority: &high}).Priority)
+}
+
 func TestRun_States(t *testing.T) {
 	ctx := context.Background()
 
@@ -43,6 +56,46 @@ func TestRun_States(t *testing.T) {
 		require.Equal(t, PhasePending, run.Apply.Status)
 	})
 
+	t.Run("await pre-flight checks", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+
+		require.NoError(t, run.AwaitPreflight())
+
+		require.Equal(t, RunPreflighting, run.Status)
+		require.Equal(t, PhasePending, run.Plan.Status)
+		require.True(t, run.Cancelable())
+	})
+
+	t.Run("enqueue plan once pre-flight checks pass", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPreflighting
+
+		require.NoError(t, run.EnqueuePlan())
+
+		require.Equal(t, RunPlanQueued, run.Status)
+		require.Equal(t, PhaseQueued, run.Plan.Status)
+	})
+
+	t.Run("fail pre-flight checks", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPreflighting
+
+		require.NoError(t, run.FailPreflight())
+
+		require.Equal(t, RunErrored, run.Status)
+		require.Equal(t, PreflightErrorCategory, run.ErrorCategory)
+		require.Equal(t, PhaseErrored, run.Plan.Status)
+		require.Equal(t, PhaseUnreachable, run.Apply.Status)
+		require.True(t, run.Done())
+	})
+
+	t.Run("cannot fail pre-flight checks once plan enqueued", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPlanQueued
+
+		require.Error(t, run.FailPreflight())
+	})
+
 	t.Run("start plan", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunPlanQueued
@@ -70,14 +123,42 @@ func TestRun_States(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunPlanning
 
-		_, err := run.Finish(internal.PlanPhase, PhaseFinishOptions{Errored: true})
+		_, err := run.Finish(internal.PlanPhase, PhaseFinishOptions{Errored: true, ErrorCategory: InitErrorCategory})
 		require.NoError(t, err)
 
 		require.Equal(t, RunErrored, run.Status)
+		require.Equal(t, InitErrorCategory, run.ErrorCategory)
 		require.Equal(t, PhaseErrored, run.Plan.Status)
 		require.Equal(t, PhaseUnreachable, run.Apply.Status)
 	})
 
+	t.Run("finish plan with errors without error category", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPlanning
+
+		// e.g. reported by an agent predating error categories
+		_, err := run.Finish(internal.PlanPhase, PhaseFinishOptions{Errored: true})
+		require.NoError(t, err)
+
+		require.Equal(t, UnknownErrorCategory, run.ErrorCategory)
+	})
+
+	t.Run("finish plan that failed a policy check", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{AutoApply: internal.Bool(true)})
+		run.Status = RunPlanning
+
+		run.Plan.ResourceReport = &Report{Additions: 1}
+
+		autoapply, err := run.Finish(internal.PlanPhase, PhaseFinishOptions{policyCheckFailed: true})
+		require.NoError(t, err)
+
+		require.False(t, autoapply)
+		require.Equal(t, RunErrored, run.Status)
+		require.Equal(t, PolicyCheckErrorCategory, run.ErrorCategory)
+		require.Equal(t, PhaseFinished, run.Plan.Status)
+		require.Equal(t, PhaseUnreachable, run.Apply.Status)
+	})
+
 	t.Run("finish plan with resource changes", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunPlanning
@@ -148,6 +229,34 @@ func TestRun_States(t *testing.T) {
 		require.Equal(t, PhaseQueued, run.Apply.Status)
 	})
 
+	t.Run("await apply window", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPlanned
+
+		require.NoError(t, run.AwaitApplyWindow())
+
+		require.Equal(t, RunAwaitingWindow, run.Status)
+		require.Equal(t, PhasePending, run.Apply.Status)
+		require.True(t, run.Discardable())
+	})
+
+	t.Run("cannot await apply window once apply enqueued", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunApplyQueued
+
+		require.Error(t, run.AwaitApplyWindow())
+	})
+
+	t.Run("enqueue apply when apply window opens", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunAwaitingWindow
+
+		require.NoError(t, run.EnqueueApply())
+
+		require.Equal(t, RunApplyQueued, run.Status)
+		require.Equal(t, PhaseQueued, run.Apply.Status)
+	})
+
 	t.Run("start apply", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunApplyQueued
@@ -173,10 +282,11 @@ func TestRun_States(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunApplying
 
-		_, err := run.Finish(internal.ApplyPhase, PhaseFinishOptions{Errored: true})
+		_, err := run.Finish(internal.ApplyPhase, PhaseFinishOptions{Errored: true, ErrorCategory: AgentLostErrorCategory})
 		require.NoError(t, err)
 
 		require.Equal(t, RunErrored, run.Status)
+		require.Equal(t, AgentLostErrorCategory, run.ErrorCategory)
 		require.Equal(t, PhaseErrored, run.Apply.Status)
 	})
 
@@ -197,6 +307,10 @@ func TestRun_States(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotZero(t, run.CancelSignaledAt)
 		assert.Equal(t, RunPlanning, run.Status)
+		// force cancel is available once the cool off period has elapsed
+		require.NotNil(t, run.ForceCancelAvailableAt)
+		assert.Equal(t, run.CancelSignaledAt.Add(DefaultForceCancelCoolOff), *run.ForceCancelAvailableAt)
+		assert.False(t, run.ForceCancelable())
 	})
 
 	t.Run("when non-user cancels a planning run, it should be placed into canceled state", func(t *testing.T) {
@@ -230,12 +344,23 @@ func TestRun_States(t *testing.T) {
 		run.Status = RunPlanning
 		// gracefully canceled 11 seconds ago
 		run.CancelSignaledAt = internal.Time(time.Now().Add(-11 * time.Second))
+		run.ForceCancelAvailableAt = internal.Time(time.Now().Add(-time.Second))
 		// force cancel now
 		err := run.Cancel(true, true)
 		require.NoError(t, err)
 		assert.Equal(t, RunForceCanceled, run.Status)
 	})
 
+	t.Run("cannot force cancel a run before cool off period has elapsed", func(t *testing.T) {
+		run := newTestRun(ctx, CreateOptions{})
+		run.Status = RunPlanning
+		err := run.cancel(true, false, time.Minute)
+		require.NoError(t, err)
+		err = run.Cancel(true, true)
+		assert.Equal(t, ErrRunForceCancelNotAllowed, err)
+		assert.Equal(t, RunPlanning, run.Status)
+	})
+
 	t.Run("non-user cannot force cancel a run", func(t *testing.T) {
 		run := newTestRun(ctx, CreateOptions{})
 		run.Status = RunPlanning
@@ -5,16 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/leg100/surl"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/configversion"
 	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/idempotency"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/pubsub"
 	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/releases"
+	"github.com/tofutf/tofutf/internal/repohooks"
 	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
@@ -49,11 +52,20 @@ type (
 		afterForceCancelHooks  []func(context.Context, *Run) error
 		afterEnqueuePlanHooks  []func(context.Context, *Run) error
 		afterEnqueueApplyHooks []func(context.Context, *Run) error
+		policyChecks           []PolicyCheckFunc
 		broker                 pubsub.SubscriptionService[*Run]
+		commentBroker          pubsub.SubscriptionService[*Comment]
+		users                  commentUserClient
+		artifactLimits         artifactLimits
+		forceCancelCoolOff     time.Duration
 
 		*factory
 	}
 
+	commentUserClient interface {
+		GetUser(ctx context.Context, spec user.UserSpec) (*user.User, error)
+	}
+
 	Options struct {
 		WorkspaceAuthorizer internal.Authorizer
 		VCSEventSubscriber  vcs.Subscriber
@@ -64,8 +76,27 @@ type (
 		ReleasesService      *releases.Service
 		VCSProviderService   *vcsprovider.Service
 		TokensService        *tokens.Service
+		UserService          *user.Service
+		IdempotencyService   *idempotency.Service
+		RepohookService      *repohooks.Service
 		Logger               *slog.Logger
 
+		// MaxArtifactSize is the maximum size in bytes of a run artifact.
+		// Defaults to DefaultMaxArtifactSize.
+		MaxArtifactSize int
+		// MaxArtifacts is the maximum number of artifacts per run. Defaults to
+		// DefaultMaxArtifacts.
+		MaxArtifacts int
+		// ForceCancelCoolOff is the length of time after a run is sent a
+		// cancelation signal before it can be forceably canceled. Defaults to
+		// DefaultForceCancelCoolOff.
+		ForceCancelCoolOff time.Duration
+		// DisableWorkingDirectoryTriggers disables treating the working
+		// directory of a workspace as an implicit trigger prefix, i.e. runs
+		// are triggered regardless of which files a VCS event changes unless
+		// the workspace has trigger patterns.
+		DisableWorkingDirectoryTriggers bool
+
 		internal.Cache
 		*sql.Pool
 		*tfeapi.Responder
@@ -86,6 +117,21 @@ func NewService(opts Options) *Service {
 		organization:        &organization.Authorizer{Logger: opts.Logger},
 		workspaceAuthorizer: opts.WorkspaceAuthorizer,
 		authorizer:          &authorizer{db, opts.WorkspaceAuthorizer},
+		users:               opts.UserService,
+		artifactLimits: artifactLimits{
+			maxSize:  opts.MaxArtifactSize,
+			maxCount: opts.MaxArtifacts,
+		},
+	}
+	svc.forceCancelCoolOff = opts.ForceCancelCoolOff
+	if svc.forceCancelCoolOff <= 0 {
+		svc.forceCancelCoolOff = DefaultForceCancelCoolOff
+	}
+	if svc.artifactLimits.maxSize <= 0 {
+		svc.artifactLimits.maxSize = DefaultMaxArtifactSize
+	}
+	if svc.artifactLimits.maxCount <= 0 {
+		svc.artifactLimits.maxCount = DefaultMaxArtifacts
 	}
 	svc.factory = &factory{
 		organizations: opts.OrganizationService,
@@ -101,15 +147,17 @@ func NewService(opts Options) *Service {
 		workspaces: opts.WorkspaceService,
 	}
 	svc.tfeapi = &tfe{
-		Service:    &svc,
-		workspaces: opts.WorkspaceService,
-		Responder:  opts.Responder,
-		Signer:     opts.Signer,
+		Service:     &svc,
+		workspaces:  opts.WorkspaceService,
+		idempotency: opts.IdempotencyService,
+		Responder:   opts.Responder,
+		Signer:      opts.Signer,
 	}
 	svc.api = &api{
-		Service:   &svc,
-		Responder: opts.Responder,
-		logger:    opts.Logger,
+		HandlerClient:   &svc,
+		Responder:       opts.Responder,
+		logger:          opts.Logger,
+		maxArtifactSize: svc.artifactLimits.maxSize,
 	}
 	spawner := &Spawner{
 		logger:     opts.Logger.With("component", "spawner"),
@@ -117,6 +165,11 @@ func NewService(opts Options) *Service {
 		workspaces: opts.WorkspaceService,
 		vcs:        opts.VCSProviderService,
 		runs:       &svc,
+
+		workingDirectoryTriggers: !opts.DisableWorkingDirectoryTriggers,
+	}
+	if opts.RepohookService != nil {
+		spawner.deliveries = opts.RepohookService
 	}
 	svc.broker = pubsub.NewBroker(
 		opts.Logger,
@@ -129,6 +182,17 @@ func NewService(opts Options) *Service {
 			return db.GetRun(ctx, id)
 		},
 	)
+	svc.commentBroker = pubsub.NewBroker(
+		opts.Logger,
+		opts.Listener,
+		"run_comments",
+		func(ctx context.Context, id string, action sql.Action) (*Comment, error) {
+			if action == sql.DeleteAction {
+				return &Comment{ID: id}, nil
+			}
+			return db.getComment(ctx, id)
+		},
+	)
 
 	// Fetch related resources when API requests their inclusion
 	opts.Responder.Register(tfeapi.IncludeCreatedBy, svc.tfeapi.includeCreatedBy)
@@ -161,12 +225,31 @@ func (s *Service) Create(ctx context.Context, workspaceID string, opts CreateOpt
 		s.logger.Error("constructing new run", "subject", subject, "err", err)
 		return nil, err
 	}
+	// unless overridden, the run counts towards the expiry of the workspace's
+	// debug logging
+	if opts.DebugLogging == nil {
+		run.DebugLogging, err = s.workspaces.UseDebugLogging(ctx, workspaceID)
+		if err != nil {
+			s.logger.Error("determining debug logging for new run", "workspace_id", workspaceID, "subject", subject, "err", err)
+			return nil, err
+		}
+	}
 
-	if err = s.db.CreateRun(ctx, run); err != nil {
-		s.logger.Error("creating run", "id", run.ID, "workspace_id", run.WorkspaceID, "subject", subject, "err", err)
+	if run.Priority == PriorityHigh {
+		// creating a high priority run requires an additional permission, and
+		// is subject to the organization's limit on high priority runs.
+		if _, err := s.workspaceAuthorizer.CanAccess(ctx, rbac.CreateHighPriorityRunAction, workspaceID); err != nil {
+			return nil, err
+		}
+		err = s.db.CreateHighPriorityRun(ctx, run)
+	} else {
+		err = s.db.CreateRun(ctx, run)
+	}
+	if err != nil {
+		s.logger.Error("creating run", "id", run.ID, "workspace_id", run.WorkspaceID, "priority", run.Priority, "subject", subject, "err", err)
 		return nil, err
 	}
-	s.logger.Info("created run", "id", run.ID, "workspace_id", run.WorkspaceID, "subject", subject)
+	s.logger.Info("created run", "id", run.ID, "workspace_id", run.WorkspaceID, "priority", run.Priority, "subject", subject)
 
 	return run, nil
 }
@@ -188,6 +271,27 @@ func (s *Service) Get(ctx context.Context, runID string) (*Run, error) {
 	return run, nil
 }
 
+// UpdateLabels replaces the labels of a run. Labels are otherwise immutable
+// once a run has been created.
+func (s *Service) UpdateLabels(ctx context.Context, runID string, labels map[string]string) (*Run, error) {
+	subject, err := s.CanAccess(ctx, rbac.UpdateRunLabelsAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateLabels(labels); err != nil {
+		return nil, err
+	}
+
+	run, err := s.db.UpdateLabels(ctx, runID, labels)
+	if err != nil {
+		s.logger.Error("updating run labels", "id", runID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("updated run labels", "id", runID, "subject", subject)
+
+	return run, nil
+}
+
 // List retrieves multiple runs. Use opts to filter and paginate the
 // list.
 func (s *Service) List(ctx context.Context, opts ListOptions) (*resource.Page[*Run], error) {
@@ -237,12 +341,25 @@ func (s *Service) EnqueuePlan(ctx context.Context, runID string) (run *Run, err
 			return err
 		}
 		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+			if run.Status == RunPending && !run.SkipPreflight {
+				ws, err := s.workspaces.Get(ctx, run.WorkspaceID)
+				if err != nil {
+					return err
+				}
+				if len(ws.PreflightChecks) > 0 {
+					return run.AwaitPreflight()
+				}
+			}
 			return run.EnqueuePlan()
 		})
 		if err != nil {
 			s.logger.Error("enqueuing plan", "id", runID, "subject", subject, "err", err)
 			return err
 		}
+		if run.Status == RunPreflighting {
+			s.logger.Info("holding plan for pre-flight checks", "id", runID, "subject", subject)
+			return nil
+		}
 		s.logger.Info("enqueued plan", "id", runID, "subject", subject)
 		// invoke AfterEnqueuePlan hooks
 		for _, hook := range s.afterEnqueuePlanHooks {
@@ -255,6 +372,26 @@ func (s *Service) EnqueuePlan(ctx context.Context, runID string) (run *Run, err
 	return
 }
 
+// FailPreflight errors a run that has failed its workspace's pre-flight
+// checks.
+//
+// NOTE: this is an internal action, invoked by the pre-flight checker only.
+func (s *Service) FailPreflight(ctx context.Context, runID string) (*Run, error) {
+	subject, err := s.CanAccess(ctx, rbac.EnqueuePlanAction, runID)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+		return run.FailPreflight()
+	})
+	if err != nil {
+		s.logger.Error("failing pre-flight checks", "id", runID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("failed pre-flight checks", "id", runID, "subject", subject)
+	return run, nil
+}
+
 func (s *Service) AfterEnqueuePlan(hook func(context.Context, *Run) error) {
 	// add hook to list of hooks to be triggered after plan is enqueued
 	s.afterEnqueuePlanHooks = append(s.afterEnqueuePlanHooks, hook)
@@ -311,8 +448,27 @@ func (s *Service) FinishPhase(ctx context.Context, runID string, phase internal.
 			opts.Errored = true
 		}
 	}
+	var checks []*PolicyCheck
+	if phase == internal.PlanPhase && !opts.Errored {
+		var err error
+		checks, err = s.checkPolicies(ctx, runID)
+		if err != nil {
+			s.logger.Error("checking policies", "id", runID, "err", err)
+			opts.Errored = true
+		}
+		for _, check := range checks {
+			if check.Status() == PolicyCheckFailed {
+				opts.policyCheckFailed = true
+			}
+		}
+	}
 	var run *Run
 	err := s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) (err error) {
+		for _, check := range checks {
+			if err := s.db.upsertPolicyCheck(ctx, check); err != nil {
+				return err
+			}
+		}
 		var autoapply bool
 		run, err = s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
 			autoapply, err = run.Finish(phase, opts)
@@ -330,6 +486,9 @@ func (s *Service) FinishPhase(ctx context.Context, runID string, phase internal.
 		s.logger.Error("finishing "+string(phase), "id", runID, "subject", "err", err)
 		return nil, err
 	}
+	for _, check := range checks {
+		s.logger.Info("checked policies", "id", runID, "name", check.Name, "status", check.Status(), "violations", len(check.Violations))
+	}
 	s.logger.Info("finished "+string(phase), "id", runID, "resource_changes", resourceReport, "output_changes", outputReport, "run_status", run.Status)
 	return run, nil
 }
@@ -338,6 +497,11 @@ func (s *Service) Watch(ctx context.Context) (<-chan pubsub.Event[*Run], func())
 	return s.broker.Subscribe(ctx)
 }
 
+// WatchComments provides access to a stream of run comment events.
+func (s *Service) WatchComments(ctx context.Context) (<-chan pubsub.Event[*Comment], func()) {
+	return s.commentBroker.Subscribe(ctx)
+}
+
 // watchWithOptions provides authenticated access to a stream of run events,
 // with the option to filter events.
 func (s *Service) watchWithOptions(ctx context.Context, opts WatchOptions) (<-chan pubsub.Event[*Run], error) {
@@ -381,21 +545,55 @@ func (s *Service) watchWithOptions(ctx context.Context, opts WatchOptions) (<-ch
 	return relay, nil
 }
 
-// Apply enqueues an apply for the run.
+// Apply enqueues an apply for the run. If the run's workspace has apply
+// windows and none is currently open then the run is instead held until the
+// next window opens.
 func (s *Service) Apply(ctx context.Context, runID string) error {
+	return s.apply(ctx, runID, false)
+}
+
+// OverrideApplyWindow enqueues an apply for the run regardless of whether its
+// workspace's apply windows are open.
+func (s *Service) OverrideApplyWindow(ctx context.Context, runID string) error {
+	return s.apply(ctx, runID, true)
+}
+
+func (s *Service) apply(ctx context.Context, runID string, override bool) error {
+	action := rbac.ApplyRunAction
+	if override {
+		action = rbac.OverrideApplyWindowAction
+	}
 	return s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
-		subject, err := s.CanAccess(ctx, rbac.ApplyRunAction, runID)
+		subject, err := s.CanAccess(ctx, action, runID)
 		if err != nil {
 			return err
 		}
+		var next *time.Time
 		run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) error {
+			if !override {
+				ws, err := s.workspaces.Get(ctx, run.WorkspaceID)
+				if err != nil {
+					return err
+				}
+				if now := internal.CurrentTimestamp(nil); !ws.ApplyWindows.Open(now) {
+					next = ws.ApplyWindows.Next(now)
+					return run.AwaitApplyWindow()
+				}
+			}
 			return run.EnqueueApply()
 		})
 		if err != nil {
 			s.logger.Error("enqueuing apply", "id", runID, "subject", subject, "err", err)
 			return err
 		}
+		if run.Status == RunAwaitingWindow {
+			s.logger.Info("holding apply until apply window opens", "id", runID, "next_window", next, "subject", subject)
+			return nil
+		}
 
+		if override {
+			s.logger.Info("overrode apply window", "id", runID, "workspace", run.WorkspaceID, "subject", subject)
+		}
 		s.logger.Info("enqueued apply", "id", runID, "subject", subject)
 		// invoke AfterEnqueueApply hooks
 		for _, hook := range s.afterEnqueueApplyHooks {
@@ -441,7 +639,7 @@ func (s *Service) Cancel(ctx context.Context, runID string) error {
 		_, isUser := subject.(*user.User)
 
 		run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
-			return run.Cancel(isUser, false)
+			return run.cancel(isUser, false, s.forceCancelCoolOff)
 		})
 		if err != nil {
 			s.logger.Error("canceling run", "id", runID, "subject", subject, "err", err)
@@ -467,21 +665,38 @@ func (s *Service) AfterCancelRun(hook func(context.Context, *Run) error) {
 	s.afterCancelHooks = append(s.afterCancelHooks, hook)
 }
 
-// ForceCancel forcefully cancels a run.
-func (s *Service) ForceCancel(ctx context.Context, runID string) error {
+// ForceCancelOptions are options for forceably canceling a run.
+type ForceCancelOptions struct {
+	// Reason the run is being forceably canceled. Required.
+	Reason string
+}
+
+// ForceCancel forcefully cancels a run, recording who did so and why. The run
+// must have already been sent a cancelation signal, and the cool-off period
+// must have elapsed since.
+func (s *Service) ForceCancel(ctx context.Context, runID string, opts ForceCancelOptions) error {
+	if opts.Reason == "" {
+		return &internal.MissingParameterError{Parameter: "reason"}
+	}
 	return s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
 		subject, err := s.CanAccess(ctx, rbac.ForceCancelRunAction, runID)
 		if err != nil {
 			return err
 		}
 		run, err := s.db.UpdateStatus(ctx, runID, func(run *Run) (err error) {
-			return run.Cancel(true, true)
+			if err := run.cancel(true, true, s.forceCancelCoolOff); err != nil {
+				return err
+			}
+			forcedBy := subject.String()
+			run.ForceCanceledBy = &forcedBy
+			run.ForceCancelReason = &opts.Reason
+			return nil
 		})
 		if err != nil {
 			s.logger.Error("force canceling run", "id", runID, "subject", subject, "err", err)
 			return err
 		}
-		s.logger.Info("force canceled run", "id", runID, "subject", subject)
+		s.logger.Info("force canceled run", "id", runID, "subject", subject, "reason", opts.Reason)
 		// invoke AfterForceCancelRun hooks
 		for _, hook := range s.afterForceCancelHooks {
 			if err := hook(ctx, run); err != nil {
@@ -593,6 +808,10 @@ func (s *Service) getLogs(ctx context.Context, runID string, phase internal.Phas
 	return s.db.findLogs(ctx, runID, phase)
 }
 
+func (s *Service) getTruncatedLogPhases(ctx context.Context, runID string) ([]internal.PhaseType, error) {
+	return s.db.findTruncatedLogPhases(ctx, runID)
+}
+
 func (s *Service) autoQueueRun(ctx context.Context, ws *workspace.Workspace) error {
 	// Auto queue a run only if configured on the worspace and the workspace is
 	// a connected to a VCS repo.
@@ -604,3 +823,162 @@ func (s *Service) autoQueueRun(ctx context.Context, ws *workspace.Workspace) err
 	}
 	return nil
 }
+
+// CreateComment adds a comment to a run. Any usernames @-mentioned in the
+// comment that do not belong to a user are ignored.
+func (s *Service) CreateComment(ctx context.Context, runID, body string) (*Comment, error) {
+	subject, err := s.CanAccess(ctx, rbac.CreateRunCommentAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := newComment(runID, subject.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	comment.Mentions, err = s.existingUsers(ctx, comment.Mentions)
+	if err != nil {
+		s.logger.Error("creating run comment", "run", runID, "subject", subject, "err", err)
+		return nil, err
+	}
+	if err := s.db.createComment(ctx, comment); err != nil {
+		s.logger.Error("creating run comment", "run", runID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("created run comment", "id", comment.ID, "run", runID, "mentions", comment.Mentions, "subject", subject)
+	return comment, nil
+}
+
+// ListComments lists a run's comments, oldest first.
+func (s *Service) ListComments(ctx context.Context, runID string) ([]*Comment, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.db.listComments(ctx, runID)
+	if err != nil {
+		s.logger.Error("listing run comments", "run", runID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("listed run comments", "run", runID, "count", len(comments), "subject", subject)
+	return comments, nil
+}
+
+func (s *Service) GetComment(ctx context.Context, commentID string) (*Comment, error) {
+	comment, err := s.db.getComment(ctx, commentID)
+	if err != nil {
+		s.logger.Error("retrieving run comment", "id", commentID, "err", err)
+		return nil, err
+	}
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, comment.RunID)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Debug("retrieved run comment", "id", commentID, "subject", subject)
+	return comment, nil
+}
+
+// DeleteComment deletes a comment. Only the author of the comment or an owner
+// of the organization is permitted to delete it.
+func (s *Service) DeleteComment(ctx context.Context, commentID string) error {
+	comment, err := s.db.getComment(ctx, commentID)
+	if err != nil {
+		s.logger.Error("retrieving run comment", "id", commentID, "err", err)
+		return err
+	}
+	run, err := s.db.GetRun(ctx, comment.RunID)
+	if err != nil {
+		s.logger.Error("retrieving run", "id", comment.RunID, "err", err)
+		return err
+	}
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, run.ID)
+	if err != nil {
+		return err
+	}
+	if subject.String() != comment.Author && !subject.IsOwner(run.Organization) {
+		s.logger.Error("unauthorized action", "action", "DeleteRunComment", "id", commentID, "subject", subject)
+		return internal.ErrAccessNotPermitted
+	}
+
+	if err := s.db.deleteComment(ctx, commentID); err != nil {
+		s.logger.Error("deleting run comment", "id", commentID, "subject", subject, "err", err)
+		return err
+	}
+	s.logger.Info("deleted run comment", "id", commentID, "run", run.ID, "subject", subject)
+	return nil
+}
+
+// UploadArtifact attaches an artifact to a run, replacing any existing artifact
+// with the same name. An empty content type defaults to
+// application/octet-stream.
+func (s *Service) UploadArtifact(ctx context.Context, runID, name, contentType string, data []byte) (*Artifact, error) {
+	subject, err := s.CanAccess(ctx, rbac.UploadRunArtifactAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := newArtifact(runID, name, contentType, data, s.artifactLimits)
+	if err != nil {
+		s.logger.Error("uploading run artifact", "run", runID, "name", name, "subject", subject, "err", err)
+		return nil, err
+	}
+	if err := s.db.uploadArtifact(ctx, artifact, s.artifactLimits.maxCount); err != nil {
+		s.logger.Error("uploading run artifact", "run", runID, "name", name, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("uploaded run artifact", "run", runID, "name", name, "size", artifact.Size, "subject", subject)
+	return artifact, nil
+}
+
+// ListArtifacts lists a run's artifacts, ordered by name. The data of the
+// artifacts is omitted.
+func (s *Service) ListArtifacts(ctx context.Context, runID string) ([]*Artifact, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := s.db.listArtifacts(ctx, runID)
+	if err != nil {
+		s.logger.Error("listing run artifacts", "run", runID, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("listed run artifacts", "run", runID, "count", len(artifacts), "subject", subject)
+	return artifacts, nil
+}
+
+// GetArtifact retrieves a run's artifact by name, including its data.
+func (s *Service) GetArtifact(ctx context.Context, runID, name string) (*Artifact, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetRunAction, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := s.db.getArtifact(ctx, runID, name)
+	if err != nil {
+		s.logger.Error("retrieving run artifact", "run", runID, "name", name, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("retrieved run artifact", "run", runID, "name", name, "subject", subject)
+	return artifact, nil
+}
+
+// existingUsers filters usernames, returning only those belonging to a user.
+func (s *Service) existingUsers(ctx context.Context, usernames []string) ([]string, error) {
+	// the caller may not be permitted to retrieve users, so retrieve them as a
+	// superuser.
+	ctx = internal.AddSubjectToContext(ctx, &internal.Superuser{Username: "run-comments"})
+
+	var existing []string
+	for _, username := range usernames {
+		_, err := s.users.GetUser(ctx, user.UserSpec{Username: &username})
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		existing = append(existing, username)
+	}
+	return existing, nil
+}
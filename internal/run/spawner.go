@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"path"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/gobwas/glob"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/configversion"
+	"github.com/tofutf/tofutf/internal/repohooks"
 	"github.com/tofutf/tofutf/internal/vcs"
 	"github.com/tofutf/tofutf/internal/workspace"
 )
@@ -21,6 +25,12 @@ type (
 		workspaces spawnerWorkspaceClient
 		vcs        spawnerVCSClient
 		runs       spawnerRunClient
+		// records trigger decisions on webhook deliveries; optional.
+		deliveries spawnerDeliveryClient
+
+		// treat the working directory of a workspace as an implicit trigger
+		// prefix
+		workingDirectoryTriggers bool
 	}
 
 	spawnerWorkspaceClient interface {
@@ -41,6 +51,10 @@ type (
 	spawnerRunClient interface {
 		Create(ctx context.Context, workspaceID string, opts CreateOptions) (*Run, error)
 	}
+
+	spawnerDeliveryClient interface {
+		RecordTriggerDecisions(ctx context.Context, deliveryID string, decisions []repohooks.TriggerDecision) error
+	}
 )
 
 func (s *Spawner) handle(event vcs.Event) {
@@ -81,65 +95,66 @@ func (s *Spawner) handleWithError(logger *slog.Logger, event vcs.Event) error {
 		return nil
 	}
 
+	// decide which workspaces the event triggers, recording the reason for
+	// each decision.
+	var (
+		decisions = make([]repohooks.TriggerDecision, 0, len(workspaces))
+		triggered []*workspace.Workspace
+	)
+	decide := func(ws *workspace.Workspace, trigger bool, reason string) {
+		decisions = append(decisions, repohooks.TriggerDecision{
+			WorkspaceID:   ws.ID,
+			WorkspaceName: ws.Name,
+			Triggered:     trigger,
+			Reason:        reason,
+		})
+		if trigger {
+			triggered = append(triggered, ws)
+		}
+	}
+
 	// filter out workspaces based on info contained in the event
-	n := 0
+	var candidates []*workspace.Workspace
 	for _, ws := range workspaces {
-		switch event.Type {
-		case vcs.EventTypeTag:
-			// skip workspaces with a non-nil tag regex that doesn't match the
-			// tag event
-			if ws.Connection.TagsRegex != "" {
-				re := regexp.MustCompile(ws.Connection.TagsRegex)
-				if !re.MatchString(event.Tag) {
-					continue
-				}
-			}
-		case vcs.EventTypePush:
-			if ws.Connection.Branch != "" {
-				// skip workspaces with a user-specified branch that doesn't match the
-				// event branch
-				if ws.Connection.Branch != event.Branch {
-					continue
-				}
-			} else {
-				// skip workspaces with default branch for events on non-default
-				// branches
-				if event.Branch != event.DefaultBranch {
-					continue
-				}
-			}
-			if ws.Connection.TagsRegex != "" {
-				// skip workspaces which specify a tags regex
-				continue
-			}
+		if reason, ok := matchEvent(ws, event); !ok {
+			decide(ws, false, reason)
+			continue
 		}
+		candidates = append(candidates, ws)
+	}
 
-		// only tag and push events contain a list of changed files
-		switch event.Type {
-		case vcs.EventTypeTag, vcs.EventTypePush:
-			// filter workspaces with trigger pattern that doesn't match any of the
-			// files in the event
-			if ws.TriggerPatterns != nil {
-				if !globMatch(event.Paths, ws.TriggerPatterns) {
-					continue
-				}
-			}
+	var client vcs.Client
+	if len(candidates) > 0 {
+		client, err = s.vcs.GetVCSClient(ctx, event.VCSProviderID)
+		if err != nil {
+			return err
 		}
-		workspaces[n] = ws
-		n++
 	}
-	if n == 0 {
+
+	// filter out workspaces based on the files changed by the event. Pull
+	// request events don't contain a list of changed files; instead an API
+	// call is necessary to retrieve the list, which is only performed if at
+	// least one workspace has file triggers.
+	paths := event.Paths
+	if event.Type == vcs.EventTypePull && slices.ContainsFunc(candidates, s.hasFileTriggers) {
+		paths, err = client.ListPullRequestFiles(ctx, event.RepoPath, event.PullRequestNumber)
+		if err != nil {
+			return fmt.Errorf("retrieving list of files in pull request from cloud provider: %w", err)
+		}
+	}
+	for _, ws := range candidates {
+		trigger, reason := s.matchPaths(ws, paths)
+		decide(ws, trigger, reason)
+	}
+	s.recordDecisions(ctx, logger, event, decisions)
+
+	if len(triggered) == 0 {
 		// no workspaces survived the filter
 		logger.Debug("ignoring vcs event: no matching triggers found")
 		return nil
 	}
-	workspaces = workspaces[:n]
 
 	// fetch tarball
-	client, err := s.vcs.GetVCSClient(ctx, event.VCSProviderID)
-	if err != nil {
-		return err
-	}
 	tarball, _, err := client.GetRepoTarball(ctx, vcs.GetRepoTarballOptions{
 		Repo: event.RepoPath,
 		Ref:  &event.CommitSHA,
@@ -148,38 +163,8 @@ func (s *Spawner) handleWithError(logger *slog.Logger, event vcs.Event) error {
 		return fmt.Errorf("retrieving repo tarball: %w", err)
 	}
 
-	// pull request events don't contain a list of changed files; instead an API
-	// call is necsssary to retrieve the list of changed files
-	if event.Type == vcs.EventTypePull {
-		// only perform API call if at least one workspace has file triggers
-		// enabled.
-		var listFiles bool
-		for _, ws := range workspaces {
-			if ws.TriggerPatterns != nil {
-				listFiles = true
-				break
-			}
-		}
-		if listFiles {
-			paths, err := client.ListPullRequestFiles(ctx, event.RepoPath, event.PullRequestNumber)
-			if err != nil {
-				return fmt.Errorf("retrieving list of files in pull request from cloud provider: %w", err)
-			}
-			n := 0
-			for _, ws := range workspaces {
-				if ws.TriggerPatterns != nil && !globMatch(paths, ws.TriggerPatterns) {
-					// skip workspace
-					continue
-				}
-				workspaces[n] = ws
-				n++
-			}
-			workspaces = workspaces[:n]
-		}
-	}
-
 	// create a config version for each workspace and spawn run.
-	for _, ws := range workspaces {
+	for _, ws := range triggered {
 		cvOpts := configversion.CreateOptions{
 			// pull request events trigger speculative runs
 			Speculative: internal.Bool(event.Type == vcs.EventTypePull),
@@ -228,19 +213,144 @@ func (s *Spawner) handleWithError(logger *slog.Logger, event vcs.Event) error {
 	return nil
 }
 
-// globMatch returns true if any of the paths match any of the glob patterns.
-func globMatch(paths []string, patterns []string) bool {
-	if len(paths) == 0 || len(patterns) == 0 {
-		return false
+// matchEvent determines whether the workspace is triggered by the event
+// according to its type, branch, tag and so on, returning the reason if it is
+// not.
+func matchEvent(ws *workspace.Workspace, event vcs.Event) (string, bool) {
+	switch event.Type {
+	case vcs.EventTypeTag:
+		// skip workspaces with a non-nil tag regex that doesn't match the
+		// tag event
+		if ws.Connection.TagsRegex != "" {
+			re := regexp.MustCompile(ws.Connection.TagsRegex)
+			if !re.MatchString(event.Tag) {
+				return fmt.Sprintf("tag %s does not match tags regex %s", event.Tag, ws.Connection.TagsRegex), false
+			}
+		}
+	case vcs.EventTypePush:
+		if ws.Connection.Branch != "" {
+			// skip workspaces with a user-specified branch that doesn't match the
+			// event branch
+			if ws.Connection.Branch != event.Branch {
+				return fmt.Sprintf("push to branch %s, not to the workspace branch %s", event.Branch, ws.Connection.Branch), false
+			}
+		} else {
+			// skip workspaces with default branch for events on non-default
+			// branches
+			if event.Branch != event.DefaultBranch {
+				return fmt.Sprintf("push to branch %s, not to the default branch %s", event.Branch, event.DefaultBranch), false
+			}
+		}
+		if ws.Connection.TagsRegex != "" {
+			// skip workspaces which specify a tags regex
+			return "workspace is triggered by tags, not pushes", false
+		}
+	case vcs.EventTypePull:
+		// skip workspaces with speculative plans disabled
+		if !ws.SpeculativeEnabled {
+			return "speculative plans are disabled", false
+		}
+		// skip workspaces that skip draft pull requests
+		if event.PullRequestDraft && ws.Connection.SkipDrafts {
+			return "workspace skips draft pull requests", false
+		}
+	}
+	return "", true
+}
+
+// hasFileTriggers determines whether runs are only triggered in the workspace
+// by changes to particular files.
+func (s *Spawner) hasFileTriggers(ws *workspace.Workspace) bool {
+	return ws.TriggerPatterns != nil || s.workingDirectoryTrigger(ws) != ""
+}
+
+// workingDirectoryTrigger returns the working directory of the workspace if it
+// is to be treated as an implicit trigger prefix, or an empty string if it is
+// not. The directory is relative to the root of the repo, without leading or
+// trailing slashes.
+func (s *Spawner) workingDirectoryTrigger(ws *workspace.Workspace) string {
+	if !s.workingDirectoryTriggers {
+		return ""
+	}
+	return strings.Trim(path.Clean("/"+ws.WorkingDirectory), "/")
+}
+
+// matchPaths determines whether the workspace is triggered by changes to the
+// given files, returning the reason for the decision. A workspace with
+// neither trigger patterns nor a working directory is triggered regardless
+// of which files changed. Otherwise the workspace is triggered if any of the
+// files match one of its trigger patterns, or are within its working
+// directory.
+//
+// The old and new paths of renamed or moved files are both included in the
+// changed files, so moving a file into or out of the working directory
+// triggers the workspace.
+func (s *Spawner) matchPaths(ws *workspace.Workspace, paths []string) (bool, string) {
+	dir := s.workingDirectoryTrigger(ws)
+	if dir != "" && len(paths) == 0 {
+		// without a list of changed files, e.g. for a tag, the working
+		// directory cannot be used to filter the event.
+		if ws.TriggerPatterns == nil {
+			return true, "no changed files were reported to match against the working directory"
+		}
+		dir = ""
+	}
+	if ws.TriggerPatterns == nil && dir == "" {
+		return true, "workspace has no file triggers"
 	}
+	if path, pattern, ok := globMatch(paths, ws.TriggerPatterns); ok {
+		return true, fmt.Sprintf("changed file %s matches trigger pattern %s", path, pattern)
+	}
+	if dir != "" {
+		for _, path := range paths {
+			if withinDirectory(path, dir) {
+				return true, fmt.Sprintf("changed file %s is within working directory %s", path, dir)
+			}
+		}
+	}
+	switch {
+	case ws.TriggerPatterns != nil && dir != "":
+		return false, fmt.Sprintf("no changed files match trigger patterns or are within working directory %s", dir)
+	case dir != "":
+		return false, fmt.Sprintf("no changed files are within working directory %s", dir)
+	default:
+		return false, "no changed files match trigger patterns"
+	}
+}
+
+// recordDecisions records the trigger decisions on the webhook delivery
+// containing the event. Failure to do so is not deemed fatal to the handling
+// of the event.
+func (s *Spawner) recordDecisions(ctx context.Context, logger *slog.Logger, event vcs.Event, decisions []repohooks.TriggerDecision) {
+	if s.deliveries == nil || event.DeliveryID == "" {
+		return
+	}
+	slices.SortFunc(decisions, func(a, b repohooks.TriggerDecision) int {
+		return strings.Compare(a.WorkspaceName, b.WorkspaceName)
+	})
+	if err := s.deliveries.RecordTriggerDecisions(ctx, event.DeliveryID, decisions); err != nil {
+		logger.Error("recording trigger decisions", "delivery_id", event.DeliveryID, "err", err)
+	}
+}
+
+// withinDirectory determines whether the path is within the directory, both
+// relative to the root of the repo.
+func withinDirectory(p, dir string) bool {
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	return p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// globMatch returns the first of the paths to match any of the glob patterns,
+// along with the pattern it matches.
+func globMatch(paths []string, patterns []string) (string, string, bool) {
 	for _, pattern := range patterns {
 		g := glob.MustCompile(pattern)
 		for _, path := range paths {
 			if g.Match(path) {
 				// only one match is necessary
-				return true
+				return path, pattern, true
 			}
 		}
 	}
-	return false
+	return "", "", false
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal/configversion"
+	"github.com/tofutf/tofutf/internal/repohooks"
 	"github.com/tofutf/tofutf/internal/vcs"
 	"github.com/tofutf/tofutf/internal/workspace"
 	"github.com/tofutf/tofutf/internal/xslog"
@@ -21,6 +22,8 @@ func TestSpawner(t *testing.T) {
 		event vcs.Event
 		// file paths to return from stubbed client.ListPullRequestFiles
 		pullFiles []string
+		// don't treat working directory as a trigger prefix
+		disableWorkingDirectoryTriggers bool
 		// want spawned run
 		spawn bool
 	}{
@@ -76,7 +79,7 @@ func TestSpawner(t *testing.T) {
 		},
 		{
 			name: "spawn run for opened pull request",
-			ws:   &workspace.Workspace{Connection: &workspace.Connection{}},
+			ws:   &workspace.Workspace{SpeculativeEnabled: true, Connection: &workspace.Connection{}},
 			event: vcs.Event{
 				EventPayload: vcs.EventPayload{
 					Type: vcs.EventTypePull, Action: vcs.ActionCreated,
@@ -86,7 +89,7 @@ func TestSpawner(t *testing.T) {
 		},
 		{
 			name: "spawn run for update to pull request",
-			ws:   &workspace.Workspace{Connection: &workspace.Connection{}},
+			ws:   &workspace.Workspace{SpeculativeEnabled: true, Connection: &workspace.Connection{}},
 			event: vcs.Event{
 				EventPayload: vcs.EventPayload{
 					Type:   vcs.EventTypePull,
@@ -95,6 +98,41 @@ func TestSpawner(t *testing.T) {
 			},
 			spawn: true,
 		},
+		{
+			name: "spawn run for draft pull request",
+			ws:   &workspace.Workspace{SpeculativeEnabled: true, Connection: &workspace.Connection{}},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:             vcs.EventTypePull,
+					Action:           vcs.ActionCreated,
+					PullRequestDraft: true,
+				},
+			},
+			spawn: true,
+		},
+		{
+			name: "skip run for draft pull request for workspace that skips drafts",
+			ws:   &workspace.Workspace{SpeculativeEnabled: true, Connection: &workspace.Connection{SkipDrafts: true}},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:             vcs.EventTypePull,
+					Action:           vcs.ActionCreated,
+					PullRequestDraft: true,
+				},
+			},
+			spawn: false,
+		},
+		{
+			name: "skip run for pull request for workspace with speculative plans disabled",
+			ws:   &workspace.Workspace{Connection: &workspace.Connection{}},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:   vcs.EventTypePull,
+					Action: vcs.ActionCreated,
+				},
+			},
+			spawn: false,
+		},
 		{
 			name: "skip run for push event for workspace with tags regex",
 			ws:   &workspace.Workspace{Connection: &workspace.Connection{TagsRegex: "0.1.2"}},
@@ -164,8 +202,9 @@ func TestSpawner(t *testing.T) {
 		{
 			name: "spawn run for pull event for workspace with matching file trigger pattern",
 			ws: &workspace.Workspace{
-				TriggerPatterns: []string{"/foo/*.tf"},
-				Connection:      &workspace.Connection{},
+				SpeculativeEnabled: true,
+				TriggerPatterns:    []string{"/foo/*.tf"},
+				Connection:         &workspace.Connection{},
 			},
 			event: vcs.Event{
 				EventPayload: vcs.EventPayload{
@@ -179,8 +218,9 @@ func TestSpawner(t *testing.T) {
 		{
 			name: "skip run for pull event for workspace with non-matching file trigger pattern",
 			ws: &workspace.Workspace{
-				TriggerPatterns: []string{"/foo/*.tf"},
-				Connection:      &workspace.Connection{},
+				SpeculativeEnabled: true,
+				TriggerPatterns:    []string{"/foo/*.tf"},
+				Connection:         &workspace.Connection{},
 			},
 			event: vcs.Event{
 				EventPayload: vcs.EventPayload{
@@ -191,6 +231,143 @@ func TestSpawner(t *testing.T) {
 			pullFiles: []string{"README.md", ".gitignore"},
 			spawn:     false,
 		},
+		{
+			name: "spawn run for push event changing files within working directory",
+			ws: &workspace.Workspace{
+				WorkingDirectory: "envs/prod",
+				Connection:       &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:          vcs.EventTypePush,
+					Action:        vcs.ActionCreated,
+					Branch:        "main",
+					DefaultBranch: "main",
+					Paths:         []string{"README.md", "envs/prod/main.tf"},
+				},
+			},
+			spawn: true,
+		},
+		{
+			name: "skip run for push event changing files outside working directory",
+			ws: &workspace.Workspace{
+				WorkingDirectory: "envs/prod",
+				Connection:       &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:          vcs.EventTypePush,
+					Action:        vcs.ActionCreated,
+					Branch:        "main",
+					DefaultBranch: "main",
+					Paths:         []string{"envs/production/main.tf", "envs/staging/main.tf"},
+				},
+			},
+			spawn: false,
+		},
+		{
+			name: "spawn run for push event moving file out of working directory",
+			ws: &workspace.Workspace{
+				WorkingDirectory: "./envs/prod/",
+				Connection:       &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:          vcs.EventTypePush,
+					Action:        vcs.ActionCreated,
+					Branch:        "main",
+					DefaultBranch: "main",
+					// file moved from envs/prod to modules
+					Paths: []string{"envs/prod/vpc.tf", "modules/vpc.tf"},
+				},
+			},
+			spawn: true,
+		},
+		{
+			name: "spawn run for pull event changing files within working directory",
+			ws: &workspace.Workspace{
+				SpeculativeEnabled: true,
+				WorkingDirectory:   "envs/prod",
+				Connection:         &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:   vcs.EventTypePull,
+					Action: vcs.ActionUpdated,
+				},
+			},
+			pullFiles: []string{"envs/prod/main.tf"},
+			spawn:     true,
+		},
+		{
+			name: "spawn run for push event matching trigger pattern outside working directory",
+			ws: &workspace.Workspace{
+				WorkingDirectory: "envs/prod",
+				TriggerPatterns:  []string{"modules/**"},
+				Connection:       &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:          vcs.EventTypePush,
+					Action:        vcs.ActionCreated,
+					Branch:        "main",
+					DefaultBranch: "main",
+					Paths:         []string{"modules/vpc/main.tf"},
+				},
+			},
+			spawn: true,
+		},
+		{
+			name: "skip run for push event matching neither trigger pattern nor working directory",
+			ws: &workspace.Workspace{
+				WorkingDirectory: "envs/prod",
+				TriggerPatterns:  []string{"modules/**"},
+				Connection:       &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:          vcs.EventTypePush,
+					Action:        vcs.ActionCreated,
+					Branch:        "main",
+					DefaultBranch: "main",
+					Paths:         []string{"docs/index.md"},
+				},
+			},
+			spawn: false,
+		},
+		{
+			name: "spawn run for tag event for workspace with working directory",
+			ws: &workspace.Workspace{
+				WorkingDirectory: "envs/prod",
+				Connection:       &workspace.Connection{TagsRegex: `^v\d+`},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:   vcs.EventTypeTag,
+					Action: vcs.ActionCreated,
+					Tag:    "v1",
+				},
+			},
+			spawn: true,
+		},
+		{
+			name: "spawn run for push event outside working directory with working directory triggers disabled",
+			ws: &workspace.Workspace{
+				WorkingDirectory: "envs/prod",
+				Connection:       &workspace.Connection{},
+			},
+			event: vcs.Event{
+				EventPayload: vcs.EventPayload{
+					Type:          vcs.EventTypePush,
+					Action:        vcs.ActionCreated,
+					Branch:        "main",
+					DefaultBranch: "main",
+					Paths:         []string{"docs/index.md"},
+				},
+			},
+			disableWorkingDirectoryTriggers: true,
+			spawn:                           true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -204,6 +381,7 @@ func TestSpawner(t *testing.T) {
 				vcs: &fakeSpawnerVCSProviderClient{
 					pullFiles: tt.pullFiles,
 				},
+				workingDirectoryTriggers: !tt.disableWorkingDirectoryTriggers,
 			}
 			err := spawner.handleWithError(slog.New(&xslog.NoopHandler{}), tt.event)
 			require.NoError(t, err)
@@ -244,3 +422,50 @@ func (f *fakeSpawnerCloudClient) GetRepoTarball(context.Context, vcs.GetRepoTarb
 func (f *fakeSpawnerCloudClient) ListPullRequestFiles(ctx context.Context, repo string, pull int) ([]string, error) {
 	return f.pullFiles, nil
 }
+
+func TestSpawner_TriggerDecisions(t *testing.T) {
+	deliveries := &fakeSpawnerDeliveryClient{}
+	spawner := Spawner{
+		configs: &configversion.FakeService{},
+		workspaces: &workspace.FakeService{
+			Workspaces: []*workspace.Workspace{
+				{ID: "ws-prod", Name: "prod", WorkingDirectory: "envs/prod", Connection: &workspace.Connection{}},
+				{ID: "ws-dev", Name: "dev", Connection: &workspace.Connection{Branch: "dev"}},
+				{ID: "ws-staging", Name: "staging", WorkingDirectory: "envs/staging", Connection: &workspace.Connection{}},
+			},
+		},
+		runs:                     &fakeSpawnerRunClient{},
+		vcs:                      &fakeSpawnerVCSProviderClient{},
+		deliveries:               deliveries,
+		workingDirectoryTriggers: true,
+	}
+	err := spawner.handleWithError(slog.New(&xslog.NoopHandler{}), vcs.Event{
+		EventHeader: vcs.EventHeader{DeliveryID: "delivery-123"},
+		EventPayload: vcs.EventPayload{
+			Type:          vcs.EventTypePush,
+			Action:        vcs.ActionCreated,
+			Branch:        "main",
+			DefaultBranch: "main",
+			Paths:         []string{"envs/prod/main.tf"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "delivery-123", deliveries.deliveryID)
+	assert.Equal(t, []repohooks.TriggerDecision{
+		{WorkspaceID: "ws-dev", WorkspaceName: "dev", Triggered: false, Reason: "push to branch main, not to the workspace branch dev"},
+		{WorkspaceID: "ws-prod", WorkspaceName: "prod", Triggered: true, Reason: "changed file envs/prod/main.tf is within working directory envs/prod"},
+		{WorkspaceID: "ws-staging", WorkspaceName: "staging", Triggered: false, Reason: "no changed files are within working directory envs/staging"},
+	}, deliveries.decisions)
+}
+
+type fakeSpawnerDeliveryClient struct {
+	deliveryID string
+	decisions  []repohooks.TriggerDecision
+}
+
+func (f *fakeSpawnerDeliveryClient) RecordTriggerDecisions(_ context.Context, deliveryID string, decisions []repohooks.TriggerDecision) error {
+	f.deliveryID = deliveryID
+	f.decisions = decisions
+	return nil
+}
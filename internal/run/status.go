@@ -21,6 +21,14 @@ const (
 	RunPlannedAndFinished Status = "planned_and_finished"
 	RunPlanning           Status = "planning"
 
+	// RunAwaitingWindow is the status of a run that would have been applied
+	// but is held until its workspace's next apply window opens.
+	RunAwaitingWindow Status = "awaiting_window"
+
+	// RunPreflighting is the status of a run that is held from being planned
+	// until its workspace's pre-flight checks have passed.
+	RunPreflighting Status = "preflighting"
+
 	// OTF doesn't support cost estimation but go-tfe API tests expect this
 	// status so it is included expressly to pass the tests.
 	RunCostEstimated Status = "cost_estimated"
@@ -49,10 +57,12 @@ var (
 	ActiveRun = []Status{
 		RunApplyQueued,
 		RunApplying,
+		RunAwaitingWindow,
 		RunConfirmed,
 		RunPlanQueued,
 		RunPlanned,
 		RunPlanning,
+		RunPreflighting,
 	}
 	IncompleteRun = append(ActiveRun, RunPending)
 )
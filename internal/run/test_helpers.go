@@ -22,8 +22,11 @@ func (f *fakeSubService) Subscribe(context.Context) (<-chan pubsub.Event[*Run],
 
 type (
 	fakeWebServices struct {
-		runs []*Run
-		ws   *workspace.Workspace
+		runs     []*Run
+		ws       *workspace.Workspace
+		comments []*Comment
+
+		forceCancelOpts *ForceCancelOptions
 
 		// fakeWebServices does not implement all of webRunClient
 		webRunClient
@@ -38,6 +41,12 @@ func withWorkspace(workspace *workspace.Workspace) fakeWebServiceOption {
 	}
 }
 
+func withComments(comments ...*Comment) fakeWebServiceOption {
+	return func(svc *fakeWebServices) {
+		svc.comments = comments
+	}
+}
+
 func withRuns(runs ...*Run) fakeWebServiceOption {
 	return func(svc *fakeWebServices) {
 		svc.runs = runs
@@ -62,6 +71,10 @@ func newTestWebHandlers(t *testing.T, opts ...fakeWebServiceOption) *webHandlers
 	}
 }
 
+func (f *fakeWebServices) GetCanary(context.Context, string) (Canary, error) {
+	return Canary{}, nil
+}
+
 func (f *fakeWebServices) Create(ctx context.Context, workspaceID string, opts CreateOptions) (*Run, error) {
 	return f.runs[0], nil
 }
@@ -74,12 +87,33 @@ func (f *fakeWebServices) List(ctx context.Context, opts ListOptions) (*resource
 	return resource.NewPage(f.runs, opts.PageOptions, nil), nil
 }
 
+func (f *fakeWebServices) ListComments(context.Context, string) ([]*Comment, error) {
+	return f.comments, nil
+}
+
+func (f *fakeWebServices) ListArtifacts(context.Context, string) ([]*Artifact, error) {
+	return nil, nil
+}
+
+func (f *fakeWebServices) ListPolicyChecks(context.Context, string) ([]*PolicyCheck, error) {
+	return nil, nil
+}
+
 func (f *fakeWebServices) getLogs(context.Context, string, internal.PhaseType) ([]byte, error) {
 	return nil, nil
 }
 
+func (f *fakeWebServices) getTruncatedLogPhases(context.Context, string) ([]internal.PhaseType, error) {
+	return nil, nil
+}
+
 func (f *fakeWebServices) Cancel(context.Context, string) error { return nil }
 
+func (f *fakeWebServices) ForceCancel(ctx context.Context, runID string, opts ForceCancelOptions) error {
+	f.forceCancelOpts = &opts
+	return nil
+}
+
 func (f *fakeWebServices) Get(ctx context.Context, runID string) (*Run, error) {
 	return f.runs[0], nil
 }
@@ -87,3 +121,7 @@ func (f *fakeWebServices) Get(ctx context.Context, runID string) (*Run, error) {
 func (f *fakeWebServices) Apply(ctx context.Context, runID string) error {
 	return nil
 }
+
+func (f *fakeWebServices) OverrideApplyWindow(ctx context.Context, runID string) error {
+	return nil
+}
@@ -1,8 +1,12 @@
 package run
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"slices"
 	"time"
@@ -11,7 +15,9 @@ import (
 	"github.com/tofutf/tofutf/internal"
 	otfhttp "github.com/tofutf/tofutf/internal/http"
 	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/idempotency"
 	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/releases"
 	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/tfeapi/types"
@@ -23,7 +29,8 @@ type tfe struct {
 	internal.Signer
 	*tfeapi.Responder
 
-	workspaces *workspace.Service
+	workspaces  *workspace.Service
+	idempotency *idempotency.Service
 }
 
 func (a *tfe) addHandlers(r *mux.Router) {
@@ -38,22 +45,37 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r.HandleFunc("/runs/{id}/actions/discard", a.discardRun).Methods("POST")
 	r.HandleFunc("/runs/{id}/actions/cancel", a.cancelRun).Methods("POST")
 	r.HandleFunc("/runs/{id}/actions/force-cancel", a.forceCancelRun).Methods("POST")
+	r.HandleFunc("/runs/{id}/labels", a.updateRunLabels).Methods("PATCH")
+	r.HandleFunc("/runs/{id}/actions/canary", a.createCanaryRun).Methods("POST")
+	r.HandleFunc("/runs/{id}/actions/proceed", a.proceedAfterCanary).Methods("POST")
 	r.HandleFunc("/organizations/{organization_name}/runs/queue", a.getRunQueue).Methods("GET")
 
 	// Plan routes
 	r.HandleFunc("/plans/{plan_id}", a.getPlan).Methods("GET")
 	r.HandleFunc("/plans/{plan_id}/json-output", a.getPlanJSON).Methods("GET")
+	r.HandleFunc("/plans/{plan_id}/json-output-redacted", a.getPlanRedactedJSON).Methods("GET")
 
 	// Apply routes
 	r.HandleFunc("/applies/{apply_id}", a.getApply).Methods("GET")
 
 	// Run events routes
 	r.HandleFunc("/runs/{id}/run-events", a.listRunEvents).Methods("GET")
+
+	// Comment routes
+	r.HandleFunc("/runs/{id}/comments", a.createComment).Methods("POST")
+	r.HandleFunc("/runs/{id}/comments", a.listComments).Methods("GET")
+	r.HandleFunc("/comments/{comment_id}", a.getComment).Methods("GET")
+	r.HandleFunc("/comments/{comment_id}", a.deleteComment).Methods("DELETE")
 }
 
 func (a *tfe) createRun(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
 	var params types.RunCreateOptions
-	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+	if err := tfeapi.Unmarshal(bytes.NewReader(body), &params); err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
@@ -74,6 +96,10 @@ func (a *tfe) createRun(w http.ResponseWriter, r *http.Request) {
 		Source:           SourceAPI,
 		AllowEmptyApply:  params.AllowEmptyApply,
 		TerraformVersion: params.TerraformVersion,
+		Labels:           params.Labels,
+		SkipPreflight:    params.SkipPreflight,
+		DebugLogging:     params.DebugLogging,
+		Priority:         (*Priority)(params.Priority),
 	}
 	if params.ConfigurationVersion != nil {
 		opts.ConfigurationVersionID = &params.ConfigurationVersion.ID
@@ -86,9 +112,33 @@ func (a *tfe) createRun(w http.ResponseWriter, r *http.Request) {
 		opts.Variables[i] = Variable{Key: from.Key, Value: from.Value}
 	}
 
-	run, err := a.Create(r.Context(), params.Workspace.ID, opts)
-	if err != nil {
-		tfeapi.Error(w, err)
+	var run *Run
+	id, replayed, err := a.idempotency.Do(r, body, func() (string, error) {
+		created, err := a.Create(r.Context(), params.Workspace.ID, opts)
+		if err != nil {
+			return "", err
+		}
+		run = created
+		return created.ID, nil
+	})
+	if replayed {
+		w.Header().Set(idempotency.ReplayedHeader, "true")
+		run, err = a.Get(r.Context(), id)
+	}
+	if errors.Is(err, releases.ErrForbiddenTerraformVersion) || errors.Is(err, ErrInvalidPriority) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if errors.Is(err, ErrHighPriorityRunLimitReached) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		labelsError(w, err)
 		return
 	}
 
@@ -138,19 +188,118 @@ func (a *tfe) listRuns(w http.ResponseWriter, r *http.Request) {
 	if slices.Contains(operations, string(types.RunOperationPlanOnly)) {
 		planOnly = internal.Bool(true)
 	}
+	var label *LabelFilter
+	if params.Label != "" {
+		var err error
+		label, err = parseLabelFilter(params.Label)
+		if err != nil {
+			labelsError(w, err)
+			return
+		}
+	}
+	var errorCategories []ErrorCategory
+	if params.ErrorCategory != "" {
+		var err error
+		errorCategories, err = parseErrorCategories(params.ErrorCategory)
+		if err != nil {
+			tfeapi.Error(w, &internal.HTTPError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: err.Error(),
+			})
+			return
+		}
+	}
 
 	a.listRunsWithOptions(w, r, ListOptions{
-		Organization: params.Organization,
-		WorkspaceID:  params.WorkspaceID,
-		PageOptions:  resource.PageOptions(params.ListOptions),
-		Statuses:     statuses,
-		Sources:      sources,
-		PlanOnly:     planOnly,
-		CommitSHA:    params.Commit,
-		VCSUsername:  params.User,
+		Organization:    params.Organization,
+		WorkspaceID:     params.WorkspaceID,
+		PageOptions:     resource.PageOptions(params.ListOptions),
+		Statuses:        statuses,
+		Sources:         sources,
+		PlanOnly:        planOnly,
+		CommitSHA:       params.Commit,
+		VCSUsername:     params.User,
+		Label:           label,
+		ErrorCategories: errorCategories,
 	})
 }
 
+func (a *tfe) updateRunLabels(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RunLabelsUpdateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	run, err := a.UpdateLabels(r.Context(), id, params.Labels)
+	if err != nil {
+		labelsError(w, err)
+		return
+	}
+
+	converted, err := a.toRun(run, r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, converted, http.StatusOK)
+}
+
+// createCanaryRun creates a canary run from a planned run. OTF extension.
+func (a *tfe) createCanaryRun(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.RunCanaryOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	run, err := a.CreateCanaryRun(r.Context(), id, params.TargetAddrs)
+	if err != nil {
+		canaryError(w, err)
+		return
+	}
+
+	converted, err := a.toRun(run, r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, converted, http.StatusCreated)
+}
+
+// proceedAfterCanary creates a run applying all the changes of a run whose
+// canary run has applied. OTF extension.
+func (a *tfe) proceedAfterCanary(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	run, err := a.ProceedAfterCanary(r.Context(), id)
+	if err != nil {
+		canaryError(w, err)
+		return
+	}
+
+	converted, err := a.toRun(run, r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, converted, http.StatusCreated)
+}
+
 func (a *tfe) getRunQueue(w http.ResponseWriter, r *http.Request) {
 	a.listRunsWithOptions(w, r, ListOptions{
 		Statuses: []Status{RunPlanQueued, RunApplyQueued},
@@ -229,7 +378,17 @@ func (a *tfe) forceCancelRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := a.ForceCancel(r.Context(), id); err != nil {
+	var params types.RunForceCancelOptions
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil && !errors.Is(err, io.EOF) {
+		tfeapi.Error(w, err)
+		return
+	}
+	var opts ForceCancelOptions
+	if params.Comment != nil {
+		opts.Reason = *params.Comment
+	}
+
+	if err := a.ForceCancel(r.Context(), id, opts); err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
@@ -254,7 +413,7 @@ func (a *tfe) getPlan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	plan, err := a.toPlan(run.Plan, r)
+	plan, err := a.toPlan(run, r)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
@@ -285,6 +444,30 @@ func (a *tfe) getPlanJSON(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getPlanRedactedJSON retrieves a plan object's plan file in JSON format, with
+// sensitive values redacted and with the schemas of its providers, which
+// terraform uses to render the plan when structured run output is enabled.
+//
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/plans#retrieve-the-json-execution-plan
+func (a *tfe) getPlanRedactedJSON(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("plan_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	// otf's plan IDs are simply the corresponding run ID
+	json, err := a.GetPlanFile(r.Context(), internal.ConvertID(id, "run"), PlanFormatRedactedJSON)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if _, err := w.Write(json); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 func (a *tfe) getApply(w http.ResponseWriter, r *http.Request) {
 	id, err := decode.Param("apply_id", r)
 	if err != nil {
@@ -299,7 +482,7 @@ func (a *tfe) getApply(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	apply, err := a.toApply(run.Apply, r)
+	apply, err := a.toApply(run, r)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
@@ -315,6 +498,81 @@ func (a *tfe) listRunEvents(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, []*types.RunEvent{}, http.StatusOK)
 }
 
+func (a *tfe) createComment(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params types.CommentCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	comment, err := a.CreateComment(r.Context(), id, params.Body)
+	if errors.Is(err, ErrEmptyComment) || errors.Is(err, ErrCommentTooLong) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toComment(comment), http.StatusCreated)
+}
+
+func (a *tfe) listComments(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	comments, err := a.ListComments(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	items := make([]*types.Comment, len(comments))
+	for i, from := range comments {
+		items[i] = a.toComment(from)
+	}
+	a.Respond(w, r, items, http.StatusOK)
+}
+
+func (a *tfe) getComment(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("comment_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	comment, err := a.GetComment(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, a.toComment(comment), http.StatusOK)
+}
+
+func (a *tfe) deleteComment(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("comment_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	if err := a.DeleteComment(r.Context(), id); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (a *tfe) includeCurrentRun(ctx context.Context, v any) ([]any, error) {
 	ws, ok := v.(*types.Workspace)
 	if !ok {
@@ -398,7 +656,7 @@ func (a *tfe) toRun(from *Run, ctx context.Context) (*types.Run, error) {
 		Actions: &types.RunActions{
 			IsCancelable:      from.Cancelable(),
 			IsConfirmable:     from.Confirmable(),
-			IsForceCancelable: from.CancelSignaledAt != nil,
+			IsForceCancelable: from.ForceCancelable(),
 			IsDiscardable:     from.Discardable(),
 		},
 		AllowEmptyApply:  from.AllowEmptyApply,
@@ -419,6 +677,12 @@ func (a *tfe) toRun(from *Run, ctx context.Context) (*types.Run, error) {
 		StatusTimestamps: &timestamps,
 		TargetAddrs:      from.TargetAddrs,
 		TerraformVersion: from.TerraformVersion,
+		Labels:           from.Labels,
+		ErrorCategory:    string(from.ErrorCategory),
+		ParentRunID:      from.ParentRunID,
+		SkipPreflight:    from.SkipPreflight,
+		DebugLogging:     from.DebugLogging,
+		Priority:         string(from.Priority),
 		// Relations
 		Plan:  &types.Plan{ID: internal.ConvertID(from.ID, "plan")},
 		Apply: &types.Apply{ID: internal.ConvertID(from.ID, "apply")},
@@ -446,20 +710,19 @@ func (a *tfe) toRun(from *Run, ctx context.Context) (*types.Run, error) {
 	// if the run has not been successfully canceled and the run is yet to reach
 	// RunCanceled status. As a compromise, it is set in either of these
 	// circumstances.
-	if timestamps.CanceledAt != nil {
-		// run successfully canceled
-		cooledOff := timestamps.CanceledAt.Add(forceCancelCoolOff)
-		to.ForceCancelAvailableAt = &cooledOff
-	} else if from.CancelSignaledAt != nil {
-		// run not successfully canceled yet
-		cooledOff := from.CancelSignaledAt.Add(forceCancelCoolOff)
+	if from.ForceCancelAvailableAt != nil {
+		to.ForceCancelAvailableAt = from.ForceCancelAvailableAt
+	} else if timestamps.CanceledAt != nil {
+		// run canceled without first being sent a cancelation signal
+		cooledOff := timestamps.CanceledAt.Add(a.forceCancelCoolOff)
 		to.ForceCancelAvailableAt = &cooledOff
 	}
 	return to, nil
 }
 
-func (a *tfe) toPlan(plan Phase, r *http.Request) (*types.Plan, error) {
-	logURL, err := a.logURL(r, plan)
+func (a *tfe) toPlan(run *Run, r *http.Request) (*types.Plan, error) {
+	plan := run.Plan
+	logURL, err := a.logURL(r, run, plan)
 	if err != nil {
 		return nil, err
 	}
@@ -474,8 +737,9 @@ func (a *tfe) toPlan(plan Phase, r *http.Request) (*types.Plan, error) {
 	}, nil
 }
 
-func (a *tfe) toApply(apply Phase, r *http.Request) (*types.Apply, error) {
-	logURL, err := a.logURL(r, apply)
+func (a *tfe) toApply(run *Run, r *http.Request) (*types.Apply, error) {
+	apply := run.Apply
+	logURL, err := a.logURL(r, run, apply)
 	if err != nil {
 		return nil, err
 	}
@@ -522,8 +786,14 @@ func (a *tfe) toPhaseTimestamps(from []PhaseStatusTimestamp) *types.PhaseStatusT
 	return &timestamps
 }
 
-func (a *tfe) logURL(r *http.Request, phase Phase) (string, error) {
+// logURL returns a signed URL for reading the logs of a phase of the run. If the
+// run has structured run output enabled then the URL is for the stream of
+// machine-readable logs, which terraform renders itself.
+func (a *tfe) logURL(r *http.Request, run *Run, phase Phase) (string, error) {
 	logs := fmt.Sprintf("/runs/%s/logs/%s", phase.RunID, phase.PhaseType)
+	if run.StructuredRunOutput {
+		logs += "/" + string(internal.JSONLogStream)
+	}
 	logs, err := a.Sign(logs, time.Hour)
 	if err != nil {
 		return "", err
@@ -531,3 +801,48 @@ func (a *tfe) logURL(r *http.Request, phase Phase) (string, error) {
 	// terraform CLI expects an absolute URL
 	return otfhttp.Absolute(r, logs), nil
 }
+
+func (a *tfe) toComment(from *Comment) *types.Comment {
+	return &types.Comment{
+		ID:        from.ID,
+		Body:      from.Body,
+		Author:    from.Author,
+		CreatedAt: from.CreatedAt,
+		Mentions:  from.Mentions,
+	}
+}
+
+// labelsError writes an HTTP response for an error, reporting invalid labels
+// and label filters as a 422.
+func labelsError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrInvalidLabels) || errors.Is(err, ErrInvalidLabelFilter) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+	} else {
+		tfeapi.Error(w, err)
+	}
+}
+
+func canaryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNoCanaryAddrs),
+		errors.Is(err, ErrCanaryAddrNotInPlan),
+		errors.Is(err, ErrCanaryIncludesAllChanges):
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+	case errors.Is(err, ErrCanaryRunNotPlanned),
+		errors.Is(err, ErrCanaryAlreadyCreated),
+		errors.Is(err, ErrCanaryNotApplied),
+		errors.Is(err, ErrCanaryAlreadyProceeded):
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+		})
+	default:
+		tfeapi.Error(w, err)
+	}
+}
@@ -3,8 +3,11 @@ package run
 import (
 	"bytes"
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
@@ -32,11 +35,23 @@ type (
 		Get(ctx context.Context, id string) (*Run, error)
 		Delete(ctx context.Context, runID string) error
 		Cancel(ctx context.Context, runID string) error
-		ForceCancel(ctx context.Context, runID string) error
+		ForceCancel(ctx context.Context, runID string, opts ForceCancelOptions) error
 		Apply(ctx context.Context, runID string) error
+		OverrideApplyWindow(ctx context.Context, runID string) error
 		Discard(ctx context.Context, runID string) error
+		CreateComment(ctx context.Context, runID, body string) (*Comment, error)
+		ListComments(ctx context.Context, runID string) ([]*Comment, error)
+		DeleteComment(ctx context.Context, commentID string) error
+		ListArtifacts(ctx context.Context, runID string) ([]*Artifact, error)
+		ListPolicyChecks(ctx context.Context, runID string) ([]*PolicyCheck, error)
+		GetArtifact(ctx context.Context, runID, name string) (*Artifact, error)
+		PlannedChanges(ctx context.Context, runID string) ([]ResourceChange, error)
+		CreateCanaryRun(ctx context.Context, runID string, addrs []string) (*Run, error)
+		ProceedAfterCanary(ctx context.Context, runID string) (*Run, error)
+		GetCanary(ctx context.Context, runID string) (Canary, error)
 
 		getLogs(ctx context.Context, runID string, phase internal.PhaseType) ([]byte, error)
+		getTruncatedLogPhases(ctx context.Context, runID string) ([]internal.PhaseType, error)
 		watchWithOptions(ctx context.Context, opts WatchOptions) (<-chan pubsub.Event[*Run], error)
 	}
 
@@ -57,8 +72,15 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/runs/{run_id}/cancel", h.cancel).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/force-cancel", h.forceCancel).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/apply", h.apply).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/override-apply-window", h.overrideApplyWindow).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/discard", h.discard).Methods("POST")
 	r.HandleFunc("/runs/{run_id}/retry", h.retry).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/comment", h.createComment).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/delete-comment", h.deleteComment).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/artifact", h.getArtifact).Methods("GET")
+	r.HandleFunc("/runs/{run_id}/canary", h.getCanary).Methods("GET")
+	r.HandleFunc("/runs/{run_id}/canary", h.createCanary).Methods("POST")
+	r.HandleFunc("/runs/{run_id}/proceed", h.proceed).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/watch", h.watch).Methods("GET")
 
 	// this handles the link the terraform CLI shows during a plan/apply.
@@ -69,6 +91,7 @@ func (h *webHandlers) createRun(w http.ResponseWriter, r *http.Request) {
 	var params struct {
 		WorkspaceID string    `schema:"workspace_id,required"`
 		Operation   Operation `schema:"operation,required"`
+		Priority    *Priority `schema:"priority"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -79,6 +102,7 @@ func (h *webHandlers) createRun(w http.ResponseWriter, r *http.Request) {
 		IsDestroy: internal.Bool(params.Operation == DestroyAllOperation),
 		PlanOnly:  internal.Bool(params.Operation == PlanOnlyOperation),
 		Source:    SourceUI,
+		Priority:  params.Priority,
 	})
 	if err != nil {
 		html.FlashError(w, err.Error())
@@ -175,20 +199,152 @@ func (h *webHandlers) get(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	truncated, err := h.runs.getTruncatedLogPhases(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	comments, err := h.runs.ListComments(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	artifacts, err := h.runs.ListArtifacts(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	checks, err := h.runs.ListPolicyChecks(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	subject, err := internal.SubjectFromContext(r.Context())
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	policy, err := h.workspaces.GetPolicy(r.Context(), ws.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	canary, err := h.runs.GetCanary(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	h.Render("run_get.tmpl", w, struct {
 		workspace.WorkspacePage
 		Run       *Run
 		PlanLogs  internal.Chunk
 		ApplyLogs internal.Chunk
+		// PlanLogsTruncated and ApplyLogsTruncated are true if the logs for
+		// the respective phase have been truncated for exceeding the maximum
+		// size.
+		PlanLogsTruncated  bool
+		ApplyLogsTruncated bool
+		Comments           []*Comment
+		Artifacts          []*Artifact
+		PolicyChecks       []*PolicyCheck
+		// Username of the current user, who can delete their own comments.
+		Username string
+		// IsOwner is true if the current user can delete any comment.
+		IsOwner bool
+		// NextApplyWindow is when the workspace's next apply window opens.
+		NextApplyWindow *time.Time
+		// CanOverrideApplyWindow is true if the current user can apply the
+		// run outside of an apply window.
+		CanOverrideApplyWindow bool
+		// Canary lists the runs created from the run for a canary apply.
+		Canary Canary
 	}{
-		WorkspacePage: workspace.NewPage(r, run.ID, ws),
-		Run:           run,
-		PlanLogs:      internal.Chunk{Data: planLogs},
-		ApplyLogs:     internal.Chunk{Data: applyLogs},
+		WorkspacePage:          workspace.NewPage(r, run.ID, ws),
+		Run:                    run,
+		PlanLogs:               internal.Chunk{Data: planLogs},
+		ApplyLogs:              internal.Chunk{Data: applyLogs},
+		PlanLogsTruncated:      slices.Contains(truncated, internal.PlanPhase),
+		ApplyLogsTruncated:     slices.Contains(truncated, internal.ApplyPhase),
+		Comments:               comments,
+		Artifacts:              artifacts,
+		PolicyChecks:           checks,
+		Username:               subject.String(),
+		IsOwner:                subject.IsOwner(run.Organization),
+		NextApplyWindow:        nextApplyWindow(run, ws),
+		CanOverrideApplyWindow: subject.CanAccessWorkspace(rbac.OverrideApplyWindowAction, policy),
+		Canary:                 canary,
 	})
 }
 
+// nextApplyWindow returns when the next apply window opens for a run awaiting
+// one; nil is returned for any other run.
+func nextApplyWindow(run *Run, ws *workspace.Workspace) *time.Time {
+	if run.Status != RunAwaitingWindow {
+		return nil
+	}
+	return ws.ApplyWindows.Next(internal.CurrentTimestamp(nil))
+}
+
+func (h *webHandlers) createComment(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID string `schema:"run_id,required"`
+		Body  string `schema:"body"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.runs.CreateComment(r.Context(), params.RunID, params.Body)
+	if errors.Is(err, ErrEmptyComment) || errors.Is(err, ErrCommentTooLong) {
+		html.FlashError(w, err.Error())
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, paths.Run(params.RunID)+"#comments", http.StatusFound)
+}
+
+func (h *webHandlers) deleteComment(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID     string `schema:"run_id,required"`
+		CommentID string `schema:"comment_id,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := h.runs.DeleteComment(r.Context(), params.CommentID); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "deleted comment")
+	http.Redirect(w, r, paths.Run(params.RunID)+"#comments", http.StatusFound)
+}
+
+func (h *webHandlers) getArtifact(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID string `schema:"run_id,required"`
+		Name  string `schema:"name,required"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	artifact, err := h.runs.GetArtifact(r.Context(), params.RunID, params.Name)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		h.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeArtifact(w, artifact)
+}
+
 // getWidget renders a run "widget", i.e. the container that
 // contains info about a run. Intended for use with an ajax request.
 func (h *webHandlers) getWidget(w http.ResponseWriter, r *http.Request) {
@@ -245,18 +401,33 @@ func (h *webHandlers) cancel(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *webHandlers) forceCancel(w http.ResponseWriter, r *http.Request) {
-	runID, err := decode.Param("run_id", r)
-	if err != nil {
+	var params struct {
+		RunID        string `schema:"run_id,required"`
+		Reason       string `schema:"reason"`
+		Confirmation string `schema:"confirmation"`
+	}
+	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
 		return
 	}
+	// the user must confirm they really want to force cancel the run by
+	// entering its ID.
+	if params.Confirmation != params.RunID {
+		html.FlashError(w, "force cancel not confirmed: enter the run ID to confirm")
+		http.Redirect(w, r, paths.Run(params.RunID), http.StatusFound)
+		return
+	}
 
-	if err := h.runs.ForceCancel(r.Context(), runID); err != nil {
+	err := h.runs.ForceCancel(r.Context(), params.RunID, ForceCancelOptions{Reason: params.Reason})
+	var missing *internal.MissingParameterError
+	if errors.As(err, &missing) || errors.Is(err, ErrRunForceCancelNotAllowed) {
+		html.FlashError(w, err.Error())
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	http.Redirect(w, r, paths.Run(runID), http.StatusFound)
+	http.Redirect(w, r, paths.Run(params.RunID), http.StatusFound)
 }
 
 func (h *webHandlers) apply(w http.ResponseWriter, r *http.Request) {
@@ -274,6 +445,21 @@ func (h *webHandlers) apply(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, paths.Run(runID)+"#apply", http.StatusFound)
 }
 
+func (h *webHandlers) overrideApplyWindow(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = h.runs.OverrideApplyWindow(r.Context(), runID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, paths.Run(runID)+"#apply", http.StatusFound)
+}
+
 func (h *webHandlers) discard(w http.ResponseWriter, r *http.Request) {
 	runID, err := decode.Param("run_id", r)
 	if err != nil {
@@ -289,6 +475,79 @@ func (h *webHandlers) discard(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, paths.Run(runID), http.StatusFound)
 }
 
+func (h *webHandlers) getCanary(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	run, err := h.runs.Get(r.Context(), runID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ws, err := h.workspaces.Get(r.Context(), run.WorkspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	changes, err := h.runs.PlannedChanges(r.Context(), run.ID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.Render("run_canary.tmpl", w, struct {
+		workspace.WorkspacePage
+		Run     *Run
+		Changes []ResourceChange
+	}{
+		WorkspacePage: workspace.NewPage(r, "canary apply", ws),
+		Run:           run,
+		Changes:       changes,
+	})
+}
+
+func (h *webHandlers) createCanary(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RunID string   `schema:"run_id,required"`
+		Addrs []string `schema:"addrs"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	canary, err := h.runs.CreateCanaryRun(r.Context(), params.RunID, params.Addrs)
+	if errors.Is(err, ErrNoCanaryAddrs) || errors.Is(err, ErrCanaryAddrNotInPlan) || errors.Is(err, ErrCanaryIncludesAllChanges) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.CanaryRun(params.RunID), http.StatusFound)
+		return
+	} else if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Run(params.RunID), http.StatusFound)
+		return
+	}
+	http.Redirect(w, r, paths.Run(canary.ID), http.StatusFound)
+}
+
+func (h *webHandlers) proceed(w http.ResponseWriter, r *http.Request) {
+	runID, err := decode.Param("run_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	full, err := h.runs.ProceedAfterCanary(r.Context(), runID)
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Run(runID), http.StatusFound)
+		return
+	}
+	http.Redirect(w, r, paths.Run(full.ID), http.StatusFound)
+}
+
 func (h *webHandlers) retry(w http.ResponseWriter, r *http.Request) {
 	runID, err := decode.Param("run_id", r)
 	if err != nil {
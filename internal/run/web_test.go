@@ -3,9 +3,12 @@ package run
 import (
 	"fmt"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/http/html/paths"
 	"github.com/tofutf/tofutf/internal/testutils"
@@ -61,11 +64,35 @@ func TestWeb_GetHandler(t *testing.T) {
 	)
 
 	r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &user.User{Username: "bob"}))
 	w := httptest.NewRecorder()
 	h.get(w, r)
 	assert.Equal(t, 200, w.Code, "output: %s", w.Body.String())
 }
 
+func TestWeb_GetHandler_Comments(t *testing.T) {
+	h := newTestWebHandlers(t,
+		withWorkspace(&workspace.Workspace{ID: "ws-123"}),
+		withRuns((&Run{ID: "run-123", WorkspaceID: "ws-1"}).updateStatus(RunPending, nil)),
+		withComments(
+			&Comment{ID: "wsc-1", RunID: "run-123", Author: "bob", Body: "mine"},
+			&Comment{ID: "wsc-2", RunID: "run-123", Author: "alice", Body: "theirs"},
+		),
+	)
+
+	r := httptest.NewRequest("GET", "/?run_id=run-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &user.User{Username: "bob"}))
+	w := httptest.NewRecorder()
+	h.get(w, r)
+	require.Equal(t, 200, w.Code, "output: %s", w.Body.String())
+
+	assert.Contains(t, w.Body.String(), "mine")
+	assert.Contains(t, w.Body.String(), "theirs")
+	// only the author's own comment can be deleted by a non-owner
+	assert.Contains(t, w.Body.String(), `value="wsc-1"`)
+	assert.NotContains(t, w.Body.String(), `value="wsc-2"`)
+}
+
 func TestRuns_CancelHandler(t *testing.T) {
 	h := newTestWebHandlers(t, withRuns(&Run{ID: "run-1"}))
 
@@ -75,6 +102,44 @@ func TestRuns_CancelHandler(t *testing.T) {
 	testutils.AssertRedirect(t, w, paths.Run("run-1"))
 }
 
+func TestRuns_ForceCancelHandler(t *testing.T) {
+	t.Run("confirmed", func(t *testing.T) {
+		h := newTestWebHandlers(t, withRuns(&Run{ID: "run-1"}))
+
+		form := strings.NewReader(url.Values{
+			"run_id":       {"run-1"},
+			"reason":       {"apply is hung"},
+			"confirmation": {"run-1"},
+		}.Encode())
+		r := httptest.NewRequest("POST", "/", form)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.forceCancel(w, r)
+		testutils.AssertRedirect(t, w, paths.Run("run-1"))
+
+		svc := h.runs.(*fakeWebServices)
+		require.NotNil(t, svc.forceCancelOpts)
+		assert.Equal(t, "apply is hung", svc.forceCancelOpts.Reason)
+	})
+
+	t.Run("not confirmed", func(t *testing.T) {
+		h := newTestWebHandlers(t, withRuns(&Run{ID: "run-1"}))
+
+		form := strings.NewReader(url.Values{
+			"run_id":       {"run-1"},
+			"reason":       {"apply is hung"},
+			"confirmation": {"run-2"},
+		}.Encode())
+		r := httptest.NewRequest("POST", "/", form)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.forceCancel(w, r)
+		testutils.AssertRedirect(t, w, paths.Run("run-1"))
+
+		assert.Nil(t, h.runs.(*fakeWebServices).forceCancelOpts)
+	})
+}
+
 func TestWebHandlers_CreateRun_Connected(t *testing.T) {
 	h := newTestWebHandlers(t,
 		withRuns(&Run{ID: "run-1"}),
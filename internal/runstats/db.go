@@ -0,0 +1,73 @@
+package runstats
+
+import (
+	"context"
+	"time"
+
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+type (
+	// pgdb is the run statistics database on postgres
+	pgdb struct {
+		*sql.Pool // provides access to generated SQL queries
+	}
+
+	// totalsRow represents the database row for summed statistics
+	totalsRow pggen.SumWorkspaceRunStatsRow
+)
+
+func (r totalsRow) toTotals() totals {
+	return totals{
+		runsCreated:   int(r.RunsCreated.Int32),
+		runsApplied:   int(r.RunsApplied.Int32),
+		runsErrored:   int(r.RunsErrored.Int32),
+		runsCanceled:  int(r.RunsCanceled.Int32),
+		planCount:     int(r.PlanCount.Int32),
+		planDuration:  time.Duration(r.PlanDurationMs.Int64) * time.Millisecond,
+		applyCount:    int(r.ApplyCount.Int32),
+		applyDuration: time.Duration(r.ApplyDurationMs.Int64) * time.Millisecond,
+	}
+}
+
+// record records a contribution, unless it has already been recorded.
+func (db *pgdb) record(ctx context.Context, c contribution) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertWorkspaceRunStats(ctx, pggen.InsertWorkspaceRunStatsParams{
+			RunID:           sql.String(c.runID),
+			Status:          sql.String(string(c.status)),
+			WorkspaceID:     sql.String(c.workspaceID),
+			RecordedAt:      sql.Timestamptz(c.recordedAt),
+			RunsCreated:     sql.Int4(c.runsCreated),
+			RunsApplied:     sql.Int4(c.runsApplied),
+			RunsErrored:     sql.Int4(c.runsErrored),
+			RunsCanceled:    sql.Int4(c.runsCanceled),
+			PlanCount:       sql.Int4(c.planCount),
+			PlanDurationMs:  sql.Int8(int(c.planDuration.Milliseconds())),
+			ApplyCount:      sql.Int4(c.applyCount),
+			ApplyDurationMs: sql.Int8(int(c.applyDuration.Milliseconds())),
+		})
+		return sql.Error(err)
+	})
+}
+
+func (db *pgdb) sumWorkspace(ctx context.Context, workspaceID string, since time.Time) (totals, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (totals, error) {
+		result, err := q.SumWorkspaceRunStats(ctx, sql.String(workspaceID), sql.Timestamptz(since))
+		if err != nil {
+			return totals{}, sql.Error(err)
+		}
+		return totalsRow(result).toTotals(), nil
+	})
+}
+
+func (db *pgdb) sumOrganization(ctx context.Context, organization string, since time.Time) (totals, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (totals, error) {
+		result, err := q.SumOrganizationRunStats(ctx, sql.String(organization), sql.Timestamptz(since))
+		if err != nil {
+			return totals{}, sql.Error(err)
+		}
+		return totalsRow(result).toTotals(), nil
+	})
+}
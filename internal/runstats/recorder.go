@@ -0,0 +1,63 @@
+package runstats
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+// Recorder records the contributions of runs to their workspace's
+// statistics as run events arrive. Contributions are recorded at most once,
+// so replayed or duplicate events do not skew the statistics.
+type Recorder struct {
+	Logger *slog.Logger
+	Runs   recorderRunClient
+
+	db recorderDB
+}
+
+type recorderDB interface {
+	record(ctx context.Context, c contribution) error
+}
+
+// Start starts the recorder daemon. Should be invoked in a go routine.
+func (r *Recorder) Start(ctx context.Context) error {
+	// subscribe to run events
+	sub, unsub := r.Runs.Watch(ctx)
+	defer unsub()
+
+	for event := range sub {
+		if event.Type == pubsub.DeletedEvent {
+			// Skip deleted run events
+			continue
+		}
+		if err := r.handleRun(ctx, event.Payload); err != nil {
+			return err
+		}
+	}
+	return pubsub.ErrSubscriptionTerminated
+}
+
+func (r *Recorder) handleRun(ctx context.Context, run *run.Run) error {
+	contribs, err := contributions(run)
+	if err != nil {
+		r.Logger.Error("determining run stats", "run", run.ID, "err", err)
+		return nil
+	}
+	for _, c := range contribs {
+		if err := r.db.record(ctx, c); err != nil {
+			var fkErr *internal.ForeignKeyError
+			if errors.As(err, &fkErr) {
+				// the workspace has since been deleted
+				r.Logger.Debug("skipping run stats for deleted workspace", "run", run.ID)
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Package runstats maintains statistics of the runs of each workspace.
+package runstats
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+const (
+	// LockID guarantees only one recorder on a cluster is running at any
+	// time.
+	LockID int64 = 5577006791947779419
+
+	// DefaultPeriod is the default period over which statistics are
+	// reported.
+	DefaultPeriod = "30d"
+	// maxPeriodDays is the maximum number of days over which statistics can
+	// be reported.
+	maxPeriodDays = 365
+)
+
+var (
+	ErrInvalidPeriod = fmt.Errorf("period must be a number of days between 1 and %d, e.g. %s", maxPeriodDays, DefaultPeriod)
+
+	periodRegex = regexp.MustCompile(`^(\d+)d$`)
+)
+
+type (
+	// Stats are the statistics of the runs of a workspace, or of all the
+	// workspaces in an organization, over a period of days.
+	Stats struct {
+		// ID of the workspace, or name of the organization.
+		ID string `jsonapi:"primary,run-stats"`
+		// Period over which the statistics are reported, e.g. 30d.
+		Period string `jsonapi:"attribute" json:"period"`
+		// Since is the start of the first day of the period.
+		Since time.Time `jsonapi:"attribute" json:"since"`
+
+		RunsCreated  int `jsonapi:"attribute" json:"runs-created"`
+		RunsApplied  int `jsonapi:"attribute" json:"runs-applied"`
+		RunsErrored  int `jsonapi:"attribute" json:"runs-errored"`
+		RunsCanceled int `jsonapi:"attribute" json:"runs-canceled"`
+		// ApplySuccessRate is the proportion, between 0 and 1, of runs that
+		// were applied rather than errored. Nil if no runs were applied or
+		// errored.
+		ApplySuccessRate *float64 `jsonapi:"attribute" json:"apply-success-rate"`
+		// MeanPlanDuration is the mean time in seconds taken by a plan. Nil
+		// if no plans finished.
+		MeanPlanDuration *float64 `jsonapi:"attribute" json:"mean-plan-duration"`
+		// MeanApplyDuration is the mean time in seconds taken by an apply.
+		// Nil if no applies finished.
+		MeanApplyDuration *float64 `jsonapi:"attribute" json:"mean-apply-duration"`
+	}
+
+	// totals are the summed daily statistics from which Stats are derived.
+	totals struct {
+		runsCreated, runsApplied, runsErrored, runsCanceled int
+		planCount, applyCount                               int
+		planDuration, applyDuration                         time.Duration
+	}
+
+	// contribution is a run's contribution to its workspace's statistics
+	// whilst the run is in a particular status. A run makes a contribution
+	// when it is created, and another when it reaches a final status.
+	contribution struct {
+		runID       string
+		workspaceID string
+		status      run.Status
+		recordedAt  time.Time
+		totals
+	}
+)
+
+// parsePeriod parses a period in the format <days>d, returning the start of
+// the first day of the period, such that the period ends with the current
+// day.
+func parsePeriod(period string, now time.Time) (time.Time, error) {
+	matches := periodRegex.FindStringSubmatch(period)
+	if matches == nil {
+		return time.Time{}, ErrInvalidPeriod
+	}
+	days, err := strconv.Atoi(matches[1])
+	if err != nil || days < 1 || days > maxPeriodDays {
+		return time.Time{}, ErrInvalidPeriod
+	}
+	today := now.UTC().Truncate(24 * time.Hour)
+	return today.AddDate(0, 0, -(days - 1)), nil
+}
+
+func newStats(id, period string, since time.Time, t totals) *Stats {
+	stats := &Stats{
+		ID:           id,
+		Period:       period,
+		Since:        since,
+		RunsCreated:  t.runsCreated,
+		RunsApplied:  t.runsApplied,
+		RunsErrored:  t.runsErrored,
+		RunsCanceled: t.runsCanceled,
+	}
+	if completed := t.runsApplied + t.runsErrored; completed > 0 {
+		rate := float64(t.runsApplied) / float64(completed)
+		stats.ApplySuccessRate = &rate
+	}
+	if t.planCount > 0 {
+		mean := t.planDuration.Seconds() / float64(t.planCount)
+		stats.MeanPlanDuration = &mean
+	}
+	if t.applyCount > 0 {
+		mean := t.applyDuration.Seconds() / float64(t.applyCount)
+		stats.MeanApplyDuration = &mean
+	}
+	return stats
+}
+
+// contributions returns the contributions a run makes to its workspace's
+// statistics given its current status. The contributions are the same each
+// time they're determined for a run in a given status, permitting them to be
+// recorded idempotently.
+func contributions(r *run.Run) ([]contribution, error) {
+	created := contribution{
+		runID:       r.ID,
+		workspaceID: r.WorkspaceID,
+		status:      run.RunPending,
+		recordedAt:  r.CreatedAt,
+		totals:      totals{runsCreated: 1},
+	}
+	if !r.Done() {
+		return []contribution{created}, nil
+	}
+	finishedAt, err := r.StatusTimestamp(r.Status)
+	if err != nil {
+		return nil, fmt.Errorf("determining when run %s finished: %w", r.ID, err)
+	}
+	finished := contribution{
+		runID:       r.ID,
+		workspaceID: r.WorkspaceID,
+		status:      r.Status,
+		recordedAt:  finishedAt,
+	}
+	switch r.Status {
+	case run.RunApplied:
+		finished.runsApplied = 1
+	case run.RunErrored:
+		finished.runsErrored = 1
+	case run.RunCanceled, run.RunForceCanceled:
+		finished.runsCanceled = 1
+	}
+	if d, ok := phaseDuration(r.Plan); ok {
+		finished.planCount = 1
+		finished.planDuration = d
+	}
+	if d, ok := phaseDuration(r.Apply); ok {
+		finished.applyCount = 1
+		finished.applyDuration = d
+	}
+	return []contribution{created, finished}, nil
+}
+
+// phaseDuration returns the time a phase spent running, provided it ran to
+// completion, whether successfully or not.
+func phaseDuration(phase run.Phase) (time.Duration, bool) {
+	switch phase.Status {
+	case run.PhaseFinished, run.PhaseErrored:
+	default:
+		return 0, false
+	}
+	started, err := phase.StatusTimestamp(run.PhaseRunning)
+	if err != nil {
+		return 0, false
+	}
+	finished, err := phase.StatusTimestamp(phase.Status)
+	if err != nil {
+		return 0, false
+	}
+	return finished.Sub(started), true
+}
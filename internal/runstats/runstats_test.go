@@ -0,0 +1,149 @@
+package runstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/run"
+)
+
+func TestParsePeriod(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		period string
+		want   time.Time
+		err    error
+	}{
+		{"1d", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), nil},
+		{"30d", time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC), nil},
+		{"365d", time.Date(2023, 3, 17, 0, 0, 0, 0, time.UTC), nil},
+		{"0d", time.Time{}, ErrInvalidPeriod},
+		{"366d", time.Time{}, ErrInvalidPeriod},
+		{"30", time.Time{}, ErrInvalidPeriod},
+		{"2w", time.Time{}, ErrInvalidPeriod},
+		{"", time.Time{}, ErrInvalidPeriod},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			got, err := parsePeriod(tt.period, now)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewStats(t *testing.T) {
+	t.Run("no runs", func(t *testing.T) {
+		got := newStats("ws-123", "30d", time.Time{}, totals{})
+
+		assert.Nil(t, got.ApplySuccessRate)
+		assert.Nil(t, got.MeanPlanDuration)
+		assert.Nil(t, got.MeanApplyDuration)
+	})
+
+	t.Run("runs", func(t *testing.T) {
+		got := newStats("ws-123", "30d", time.Time{}, totals{
+			runsCreated:   5,
+			runsApplied:   3,
+			runsErrored:   1,
+			runsCanceled:  1,
+			planCount:     4,
+			planDuration:  40 * time.Second,
+			applyCount:    3,
+			applyDuration: 90 * time.Second,
+		})
+
+		assert.Equal(t, 5, got.RunsCreated)
+		require.NotNil(t, got.ApplySuccessRate)
+		assert.Equal(t, 0.75, *got.ApplySuccessRate)
+		require.NotNil(t, got.MeanPlanDuration)
+		assert.Equal(t, 10.0, *got.MeanPlanDuration)
+		require.NotNil(t, got.MeanApplyDuration)
+		assert.Equal(t, 30.0, *got.MeanApplyDuration)
+	})
+}
+
+func TestContributions(t *testing.T) {
+	created := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	newRun := func(status run.Status) *run.Run {
+		return &run.Run{
+			ID:          "run-123",
+			WorkspaceID: "ws-123",
+			CreatedAt:   created,
+			Status:      status,
+			StatusTimestamps: []run.StatusTimestamp{
+				{Status: run.RunPending, Timestamp: created},
+				{Status: status, Timestamp: created.Add(time.Minute)},
+			},
+			Plan: run.Phase{
+				Status: run.PhaseFinished,
+				StatusTimestamps: []run.PhaseStatusTimestamp{
+					{Status: run.PhaseRunning, Timestamp: created.Add(10 * time.Second)},
+					{Status: run.PhaseFinished, Timestamp: created.Add(30 * time.Second)},
+				},
+			},
+			Apply: run.Phase{
+				Status: run.PhaseFinished,
+				StatusTimestamps: []run.PhaseStatusTimestamp{
+					{Status: run.PhaseRunning, Timestamp: created.Add(40 * time.Second)},
+					{Status: run.PhaseFinished, Timestamp: created.Add(time.Minute)},
+				},
+			},
+		}
+	}
+
+	t.Run("created", func(t *testing.T) {
+		got, err := contributions(newRun(run.RunPlanning))
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(got))
+		assert.Equal(t, run.RunPending, got[0].status)
+		assert.Equal(t, created, got[0].recordedAt)
+		assert.Equal(t, totals{runsCreated: 1}, got[0].totals)
+	})
+
+	t.Run("applied", func(t *testing.T) {
+		got, err := contributions(newRun(run.RunApplied))
+		require.NoError(t, err)
+
+		require.Equal(t, 2, len(got))
+		assert.Equal(t, run.RunApplied, got[1].status)
+		assert.Equal(t, created.Add(time.Minute), got[1].recordedAt)
+		assert.Equal(t, totals{
+			runsApplied:   1,
+			planCount:     1,
+			planDuration:  20 * time.Second,
+			applyCount:    1,
+			applyDuration: 20 * time.Second,
+		}, got[1].totals)
+	})
+
+	t.Run("force canceled", func(t *testing.T) {
+		got, err := contributions(newRun(run.RunForceCanceled))
+		require.NoError(t, err)
+
+		require.Equal(t, 2, len(got))
+		assert.Equal(t, 1, got[1].runsCanceled)
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		first, err := contributions(newRun(run.RunErrored))
+		require.NoError(t, err)
+		second, err := contributions(newRun(run.RunErrored))
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("missing timestamp", func(t *testing.T) {
+		r := newRun(run.RunApplied)
+		r.StatusTimestamps = nil
+
+		_, err := contributions(r)
+		assert.ErrorIs(t, err, internal.ErrStatusTimestampNotFound)
+	})
+}
@@ -0,0 +1,116 @@
+package runstats
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+type (
+	Service struct {
+		logger *slog.Logger
+
+		workspace    internal.Authorizer
+		organization internal.Authorizer
+		runs         recorderRunClient
+		db           *pgdb
+		api          *tfe
+		web          *webHandlers
+	}
+
+	Options struct {
+		Logger *slog.Logger
+
+		*sql.Pool
+		*tfeapi.Responder
+		html.Renderer
+
+		WorkspaceAuthorizer internal.Authorizer
+		RunService          *run.Service
+	}
+
+	recorderRunClient interface {
+		Watch(context.Context) (<-chan pubsub.Event[*run.Run], func())
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		logger:       opts.Logger,
+		workspace:    opts.WorkspaceAuthorizer,
+		organization: &organization.Authorizer{Logger: opts.Logger},
+		runs:         opts.RunService,
+		db:           &pgdb{opts.Pool},
+	}
+	svc.api = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	svc.web = &webHandlers{
+		Renderer: opts.Renderer,
+		svc:      &svc,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+	s.web.addHandlers(r)
+}
+
+// NewRecorder constructs a recorder that records the statistics of runs as
+// their events arrive.
+func (s *Service) NewRecorder(logger *slog.Logger) *Recorder {
+	return &Recorder{
+		Logger: logger,
+		Runs:   s.runs,
+		db:     s.db,
+	}
+}
+
+// GetWorkspaceStats retrieves the statistics of the runs of a workspace over
+// the given period, e.g. 30d.
+func (s *Service) GetWorkspaceStats(ctx context.Context, workspaceID, period string) (*Stats, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	since, err := parsePeriod(period, internal.CurrentTimestamp(nil))
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.db.sumWorkspace(ctx, workspaceID, since)
+	if err != nil {
+		s.logger.Error("retrieving workspace run stats", "workspace", workspaceID, "subject", subject, "err", err)
+		return nil, err
+	}
+	return newStats(workspaceID, period, since, totals), nil
+}
+
+// GetOrganizationStats retrieves the statistics of the runs of all the
+// workspaces in an organization over the given period, e.g. 30d.
+func (s *Service) GetOrganizationStats(ctx context.Context, organization, period string) (*Stats, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetOrganizationAction, organization)
+	if err != nil {
+		return nil, err
+	}
+	since, err := parsePeriod(period, internal.CurrentTimestamp(nil))
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.db.sumOrganization(ctx, organization, since)
+	if err != nil {
+		s.logger.Error("retrieving organization run stats", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	return newStats(organization, period, since, totals), nil
+}
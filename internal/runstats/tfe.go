@@ -0,0 +1,57 @@
+package runstats
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	r.HandleFunc("/workspaces/{workspace_id}/stats", a.getWorkspaceStats).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/stats", a.getOrganizationStats).Methods("GET")
+}
+
+func (a *tfe) getWorkspaceStats(w http.ResponseWriter, r *http.Request) {
+	a.getStats(w, r, "workspace_id", a.GetWorkspaceStats)
+}
+
+func (a *tfe) getOrganizationStats(w http.ResponseWriter, r *http.Request) {
+	a.getStats(w, r, "organization_name", a.GetOrganizationStats)
+}
+
+func (a *tfe) getStats(w http.ResponseWriter, r *http.Request, param string, get func(ctx context.Context, id, period string) (*Stats, error)) {
+	id, err := decode.Param(param, r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = DefaultPeriod
+	}
+
+	stats, err := get(r.Context(), id, period)
+	if errors.Is(err, ErrInvalidPeriod) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, stats, http.StatusOK)
+}
@@ -0,0 +1,72 @@
+package runstats
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+)
+
+type webHandlers struct {
+	html.Renderer
+
+	svc webClient
+}
+
+type webClient interface {
+	GetWorkspaceStats(ctx context.Context, workspaceID, period string) (*Stats, error)
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/workspaces/{workspace_id}/stats", h.getWorkspaceStats).Methods("GET")
+}
+
+func (h *webHandlers) getWorkspaceStats(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	stats, err := h.svc.GetWorkspaceStats(r.Context(), id, DefaultPeriod)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := h.RenderTemplate("workspace_stats.tmpl", w, struct {
+		*Stats
+		SuccessRate       string
+		MeanPlanDuration  string
+		MeanApplyDuration string
+	}{
+		Stats:             stats,
+		SuccessRate:       formatRate(stats.ApplySuccessRate),
+		MeanPlanDuration:  formatSeconds(stats.MeanPlanDuration),
+		MeanApplyDuration: formatSeconds(stats.MeanApplyDuration),
+	}); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// formatRate formats a rate between 0 and 1 as a percentage, or as a dash if
+// there is no rate.
+func formatRate(rate *float64) string {
+	if rate == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", *rate*100)
+}
+
+// formatSeconds formats a number of seconds as a duration, or as a dash if
+// there is no number.
+func formatSeconds(seconds *float64) string {
+	if seconds == nil {
+		return "-"
+	}
+	return (time.Duration(*seconds * float64(time.Second))).Round(time.Second).String()
+}
@@ -31,3 +31,15 @@ func (r *SafeMap[K, V]) Get(key K) (V, bool) {
 	value, ok := r.m[key]
 	return value, ok
 }
+
+// Keys returns the keys in the map, in no particular order.
+func (r *SafeMap[K, V]) Keys() []K {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]K, 0, len(r.m))
+	for k := range r.m {
+		keys = append(keys, k)
+	}
+	return keys
+}
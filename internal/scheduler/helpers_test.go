@@ -19,6 +19,9 @@ func (f *fakeQueueFactory) newQueue(queueOptions) eventHandler {
 type fakeQueue struct {
 	gotWorkspace *workspace.Workspace
 	gotRun       *run.Run
+
+	held     []*run.Run
+	released *[]string
 }
 
 func (q *fakeQueue) handleWorkspace(ctx context.Context, ws *workspace.Workspace) error {
@@ -30,3 +33,12 @@ func (q *fakeQueue) handleRun(ctx context.Context, run *run.Run) error {
 	q.gotRun = run
 	return nil
 }
+
+func (q *fakeQueue) heldRuns() []*run.Run {
+	return q.held
+}
+
+func (q *fakeQueue) releaseRun(ctx context.Context, run *run.Run) error {
+	*q.released = append(*q.released, run.ID)
+	return nil
+}
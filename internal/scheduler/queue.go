@@ -5,7 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
+	"github.com/tofutf/tofutf/internal"
 	otfrun "github.com/tofutf/tofutf/internal/run"
 	"github.com/tofutf/tofutf/internal/workspace"
 )
@@ -21,6 +24,13 @@ type (
 		ws      *workspace.Workspace
 		current *otfrun.Run
 		queue   []*otfrun.Run
+		// runs held back from scheduling whilst paused
+		held []*otfrun.Run
+		// paused reports whether scheduling is paused
+		paused func() bool
+		// priorityAging is the length of time after which a normal priority
+		// run waiting in the queue is treated as high priority.
+		priorityAging time.Duration
 	}
 
 	queueOptions struct {
@@ -30,6 +40,9 @@ type (
 		runClient
 
 		*workspace.Workspace
+
+		paused        func() bool
+		priorityAging time.Duration
 	}
 
 	queueMaker struct{}
@@ -41,6 +54,8 @@ func (queueMaker) newQueue(opts queueOptions) eventHandler {
 		runClient:       opts.runClient,
 		workspaceClient: opts.workspaceClient,
 		ws:              opts.Workspace,
+		paused:          opts.paused,
+		priorityAging:   opts.priorityAging,
 	}
 }
 
@@ -57,8 +72,15 @@ func (q *queue) handleWorkspace(ctx context.Context, workspace *workspace.Worksp
 }
 
 func (q *queue) handleRun(ctx context.Context, run *otfrun.Run) error {
+	if run.Done() {
+		// a held run that has since been canceled no longer needs releasing
+		q.unhold(run.ID)
+	}
 	if run.PlanOnly {
 		if run.Status == otfrun.RunPending {
+			if q.hold(run) {
+				return nil
+			}
 			// immediately enqueue onto global queue
 			_, err := q.EnqueuePlan(ctx, run.ID)
 			if err != nil {
@@ -106,11 +128,29 @@ func (q *queue) handleRun(ctx context.Context, run *otfrun.Run) error {
 			}
 		}
 		// run is not in queue; add it
-		q.queue = append(q.queue, run)
+		q.enqueue(run)
 	}
 	return nil
 }
 
+// enqueue adds a run to the back of the workspace queue, unless it is a high
+// priority run, in which case it is added ahead of any normal priority runs,
+// other than those that have waited long enough to be treated as high
+// priority.
+func (q *queue) enqueue(run *otfrun.Run) {
+	i := len(q.queue)
+	if run.Priority == otfrun.PriorityHigh {
+		now := internal.CurrentTimestamp(nil)
+		for ; i > 0; i-- {
+			queued := q.queue[i-1]
+			if otfrun.EffectivePriority(queued.Priority, queued.CreatedAt, now, q.priorityAging) == otfrun.PriorityHigh {
+				break
+			}
+		}
+	}
+	q.queue = slices.Insert(q.queue, i, run)
+}
+
 func (q *queue) setCurrentRun(ctx context.Context, run *otfrun.Run) error {
 	q.current = run
 
@@ -134,6 +174,11 @@ func (q *queue) scheduleRun(ctx context.Context, run *otfrun.Run) error {
 		return nil
 	}
 
+	// if scheduling is paused then hold run until it is unpaused.
+	if q.hold(run) {
+		return nil
+	}
+
 	// if workspace is userLocked by a user then do not schedule;
 	// instead wait for an unlock event to arrive.
 	if q.ws.Lock != nil && q.ws.Lock.LockKind == workspace.UserLock {
@@ -143,10 +188,11 @@ func (q *queue) scheduleRun(ctx context.Context, run *otfrun.Run) error {
 
 	ws, err := q.Lock(ctx, q.ws.ID, &run.ID)
 	if err != nil {
-		if errors.Is(err, workspace.ErrWorkspaceAlreadyLocked) {
+		var locked *workspace.LockedError
+		if errors.As(err, &locked) {
 			// User has locked workspace in the small window of time between
 			// getting the lock above and attempting to enqueue plan.
-			q.logger.Info("workspace locked by user; cannot schedule run", "run", run.ID)
+			q.logger.Info("workspace locked by user; cannot schedule run", "run", run.ID, "holder", locked.Lock.Describe(internal.CurrentTimestamp(nil)))
 			return nil
 		}
 		return err
@@ -161,3 +207,43 @@ func (q *queue) scheduleRun(ctx context.Context, run *otfrun.Run) error {
 	q.current = current
 	return nil
 }
+
+// hold holds back a run from being scheduled if scheduling is paused,
+// returning true if the run is held.
+func (q *queue) hold(run *otfrun.Run) bool {
+	if q.paused == nil || !q.paused() {
+		return false
+	}
+	for i, held := range q.held {
+		if held.ID == run.ID {
+			q.held[i] = run
+			return true
+		}
+	}
+	q.logger.Info("maintenance mode active; holding run", "run", run.ID)
+	q.held = append(q.held, run)
+	return true
+}
+
+func (q *queue) unhold(runID string) {
+	q.held = slices.DeleteFunc(q.held, func(held *otfrun.Run) bool {
+		return held.ID == runID
+	})
+}
+
+func (q *queue) heldRuns() []*otfrun.Run {
+	return slices.Clone(q.held)
+}
+
+func (q *queue) releaseRun(ctx context.Context, run *otfrun.Run) error {
+	q.unhold(run.ID)
+	if run.PlanOnly {
+		_, err := q.EnqueuePlan(ctx, run.ID)
+		return err
+	}
+	if q.current != nil && q.current.ID == run.ID {
+		return q.scheduleRun(ctx, q.current)
+	}
+	// run is no longer the current run
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -76,6 +77,33 @@ func TestQueue(t *testing.T) {
 		assert.False(t, q.ws.Locked())
 	})
 
+	t.Run("high priority run", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		now := time.Now()
+		run1 := &tofutfrun.Run{ID: "run-1", WorkspaceID: "ws-123", Status: tofutfrun.RunPending, Priority: tofutfrun.PriorityNormal, CreatedAt: now}
+		// aged normal priority run
+		run2 := &tofutfrun.Run{ID: "run-2", WorkspaceID: "ws-123", Status: tofutfrun.RunPending, Priority: tofutfrun.PriorityNormal, CreatedAt: now.Add(-2 * time.Hour)}
+		run3 := &tofutfrun.Run{ID: "run-3", WorkspaceID: "ws-123", Status: tofutfrun.RunPending, Priority: tofutfrun.PriorityNormal, CreatedAt: now}
+		run4 := &tofutfrun.Run{ID: "run-4", WorkspaceID: "ws-123", Status: tofutfrun.RunPending, Priority: tofutfrun.PriorityHigh, CreatedAt: now}
+		app := newFakeQueueApp(ws, run1, run2, run3, run4)
+		q := newTestQueue(app, ws)
+		q.priorityAging = time.Hour
+
+		for _, run := range []*tofutfrun.Run{run1, run2, run3, run4} {
+			err := q.handleRun(ctx, run)
+			require.NoError(t, err)
+		}
+
+		// high priority run jumps the normal priority run but not the aged
+		// run, and does not replace the current run.
+		assert.Equal(t, run1.ID, q.current.ID)
+		if assert.Equal(t, 3, len(q.queue)) {
+			assert.Equal(t, run2.ID, q.queue[0].ID)
+			assert.Equal(t, run4.ID, q.queue[1].ID)
+			assert.Equal(t, run3.ID, q.queue[2].ID)
+		}
+	})
+
 	t.Run("speculative run", func(t *testing.T) {
 		ws := &workspace.Workspace{ID: "ws-123"}
 		run := &tofutfrun.Run{Status: tofutfrun.RunPending, WorkspaceID: "ws-123", PlanOnly: true}
@@ -126,6 +154,62 @@ func TestQueue(t *testing.T) {
 		assert.Equal(t, tofutfrun.RunPlanning, run.Status)
 	})
 
+	t.Run("hold runs whilst paused", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		run := &tofutfrun.Run{ID: "run-1", WorkspaceID: "ws-123", Status: tofutfrun.RunPending}
+		speculative := &tofutfrun.Run{ID: "run-2", WorkspaceID: "ws-123", Status: tofutfrun.RunPending, PlanOnly: true}
+		app := newFakeQueueApp(ws, run, speculative)
+		q := newTestQueue(app, ws)
+		paused := true
+		q.paused = func() bool { return paused }
+
+		// runs should be neither scheduled nor lock the workspace
+		err := q.handleRun(ctx, run)
+		require.NoError(t, err)
+		err = q.handleRun(ctx, speculative)
+		require.NoError(t, err)
+		assert.Equal(t, run.ID, q.current.ID)
+		assert.Equal(t, tofutfrun.RunPending, run.Status)
+		assert.Equal(t, tofutfrun.RunPending, speculative.Status)
+		assert.False(t, q.ws.Locked())
+		assert.Equal(t, []*tofutfrun.Run{run, speculative}, q.heldRuns())
+
+		// workspace event should not schedule the held run
+		err = q.handleWorkspace(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, tofutfrun.RunPending, run.Status)
+		assert.Len(t, q.heldRuns(), 2)
+
+		// unpause and release runs
+		paused = false
+		for _, held := range q.heldRuns() {
+			err = q.releaseRun(ctx, held)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, tofutfrun.RunPlanQueued, run.Status)
+		assert.Equal(t, tofutfrun.RunPlanQueued, speculative.Status)
+		assert.True(t, q.ws.Locked())
+		assert.Empty(t, q.heldRuns())
+	})
+
+	t.Run("canceled run is no longer held", func(t *testing.T) {
+		ws := &workspace.Workspace{ID: "ws-123"}
+		run := &tofutfrun.Run{ID: "run-1", WorkspaceID: "ws-123", Status: tofutfrun.RunPending, PlanOnly: true}
+		app := newFakeQueueApp(ws, run)
+		q := newTestQueue(app, ws)
+		q.paused = func() bool { return true }
+
+		err := q.handleRun(ctx, run)
+		require.NoError(t, err)
+		assert.Len(t, q.heldRuns(), 1)
+
+		err = run.Cancel(false, false)
+		require.NoError(t, err)
+		err = q.handleRun(ctx, run)
+		require.NoError(t, err)
+		assert.Empty(t, q.heldRuns())
+	})
+
 	t.Run("do not set current run if already latest run on workspace", func(t *testing.T) {
 		run := &tofutfrun.Run{WorkspaceID: "ws-123"}
 		ws := &workspace.Workspace{ID: "ws-123", LatestRun: &workspace.LatestRun{ID: run.ID}}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/tofutf/tofutf/internal/pubsub"
 	"github.com/tofutf/tofutf/internal/resource"
@@ -17,17 +19,27 @@ import (
 const LockID int64 = 5577006791947779410
 
 type (
-	// scheduler performs two principle tasks :
+	// scheduler performs three principle tasks :
 	// (a) manages lifecycle of workspace queues, creating/destroying them
 	// (b) relays run and workspace events onto queues.
+	// (c) pauses and resumes queues when maintenance mode is activated and
+	// deactivated.
 	scheduler struct {
 		logger *slog.Logger
 
-		workspaces workspaceClient
-		runs       runClient
+		workspaces  workspaceClient
+		runs        runClient
+		maintenance maintenanceClient
 
 		queues map[string]eventHandler
 		queueFactory
+
+		// paused is true whilst maintenance mode is active.
+		paused bool
+
+		// priorityAging is the length of time after which a normal priority
+		// run is scheduled as if it were high priority.
+		priorityAging time.Duration
 	}
 
 	workspaceClient interface {
@@ -44,20 +56,32 @@ type (
 		EnqueuePlan(ctx context.Context, runID string) (*run.Run, error)
 	}
 
+	maintenanceClient interface {
+		WatchActive(ctx context.Context) (<-chan bool, error)
+	}
+
 	Options struct {
 		Logger *slog.Logger
 
-		WorkspaceClient workspaceClient
-		RunClient       runClient
+		WorkspaceClient   workspaceClient
+		RunClient         runClient
+		MaintenanceClient maintenanceClient
+
+		// PriorityAging is the length of time after which a normal priority
+		// run waiting to be scheduled is treated as high priority, lest it be
+		// starved by high priority runs. Zero disables aging.
+		PriorityAging time.Duration
 	}
 )
 
 func NewScheduler(opts Options) *scheduler {
 	return &scheduler{
-		logger:       opts.Logger.With("component", "scheduler"),
-		workspaces:   opts.WorkspaceClient,
-		runs:         opts.RunClient,
-		queueFactory: queueMaker{},
+		logger:        opts.Logger.With("component", "scheduler"),
+		workspaces:    opts.WorkspaceClient,
+		runs:          opts.RunClient,
+		maintenance:   opts.MaintenanceClient,
+		queueFactory:  queueMaker{},
+		priorityAging: opts.PriorityAging,
 	}
 }
 
@@ -76,6 +100,25 @@ func (s *scheduler) Start(ctx context.Context) error {
 	subRuns, unsubRuns := s.runs.Watch(ctx)
 	defer unsubRuns()
 
+	// subscribe to maintenance mode, and determine whether it is currently
+	// active before any runs are scheduled.
+	subMaintenance, err := s.maintenance.WatchActive(ctx)
+	if err != nil {
+		return fmt.Errorf("watching maintenance mode: %w", err)
+	}
+	select {
+	case paused, ok := <-subMaintenance:
+		if !ok {
+			return pubsub.ErrSubscriptionTerminated
+		}
+		s.paused = paused
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if s.paused {
+		s.logger.Info("maintenance mode active; holding pending runs")
+	}
+
 	// retrieve all existing workspaces
 	workspaces, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*workspace.Workspace], error) {
 		return s.workspaces.List(ctx, workspace.ListOptions{
@@ -142,6 +185,13 @@ func (s *scheduler) Start(ctx context.Context) error {
 			if err := s.handleRunEvent(ctx, runEvent); err != nil {
 				return err
 			}
+		case paused, ok := <-subMaintenance:
+			if !ok {
+				return pubsub.ErrSubscriptionTerminated
+			}
+			if err := s.handleMaintenance(ctx, paused); err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -159,6 +209,8 @@ func (s *scheduler) handleWorkspaceEvent(ctx context.Context, event pubsub.Event
 			runClient:       s.runs,
 			workspaceClient: s.workspaces,
 			Workspace:       event.Payload,
+			paused:          func() bool { return s.paused },
+			priorityAging:   s.priorityAging,
 		})
 		s.queues[event.Payload.ID] = q
 	}
@@ -186,3 +238,36 @@ func (s *scheduler) handleRunEvent(ctx context.Context, event pubsub.Event[*run.
 	}
 	return nil
 }
+
+// handleMaintenance pauses or resumes the scheduling of runs. Upon resumption
+// runs held back whilst paused are scheduled in the order they were created.
+func (s *scheduler) handleMaintenance(ctx context.Context, paused bool) error {
+	if s.paused == paused {
+		return nil
+	}
+	s.paused = paused
+	if paused {
+		s.logger.Info("maintenance mode activated; holding pending runs")
+		return nil
+	}
+	type heldRun struct {
+		queue eventHandler
+		run   *run.Run
+	}
+	var held []heldRun
+	for _, q := range s.queues {
+		for _, run := range q.heldRuns() {
+			held = append(held, heldRun{queue: q, run: run})
+		}
+	}
+	slices.SortStableFunc(held, func(a, b heldRun) int {
+		return a.run.CreatedAt.Compare(b.run.CreatedAt)
+	})
+	s.logger.Info("maintenance mode deactivated; resuming held runs", "runs", len(held))
+	for _, h := range held {
+		if err := h.queue.releaseRun(ctx, h.run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -66,4 +67,48 @@ func TestScheduler(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, want, q.gotRun)
 	})
+	t.Run("resume held runs in order of creation", func(t *testing.T) {
+		now := time.Now()
+		var released []string
+		scheduler := scheduler{
+			logger: slog.New(&xslog.NoopHandler{}),
+			queues: map[string]eventHandler{
+				"ws-1": &fakeQueue{
+					released: &released,
+					held: []*run.Run{
+						{ID: "run-3", CreatedAt: now.Add(3 * time.Second)},
+					},
+				},
+				"ws-2": &fakeQueue{
+					released: &released,
+					held: []*run.Run{
+						{ID: "run-1", CreatedAt: now.Add(time.Second)},
+						{ID: "run-4", CreatedAt: now.Add(4 * time.Second), PlanOnly: true},
+					},
+				},
+				"ws-3": &fakeQueue{
+					released: &released,
+					held: []*run.Run{
+						{ID: "run-2", CreatedAt: now.Add(2 * time.Second)},
+					},
+				},
+			},
+			paused: true,
+		}
+		err := scheduler.handleMaintenance(ctx, false)
+		require.NoError(t, err)
+
+		assert.False(t, scheduler.paused)
+		assert.Equal(t, []string{"run-1", "run-2", "run-3", "run-4"}, released)
+	})
+
+	t.Run("pause", func(t *testing.T) {
+		scheduler := scheduler{
+			logger: slog.New(&xslog.NoopHandler{}),
+		}
+		err := scheduler.handleMaintenance(ctx, true)
+		require.NoError(t, err)
+
+		assert.True(t, scheduler.paused)
+	})
 }
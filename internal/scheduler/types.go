@@ -15,4 +15,8 @@ type queueFactory interface {
 type eventHandler interface {
 	handleRun(context.Context, *run.Run) error
 	handleWorkspace(context.Context, *workspace.Workspace) error
+	// heldRuns returns runs held back from scheduling whilst paused.
+	heldRuns() []*run.Run
+	// releaseRun schedules a run that was held back whilst paused.
+	releaseRun(context.Context, *run.Run) error
 }
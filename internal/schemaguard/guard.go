@@ -0,0 +1,151 @@
+// Package schemaguard detects when an instance is running a version older than
+// the database schema, which happens when a newer version is deployed
+// alongside it, and protects the database from the instance whilst it awaits
+// replacement.
+package schemaguard
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+const (
+	// HeaderName is the name of the header added to responses once the
+	// instance is found to be behind the database schema.
+	HeaderName = "X-OTF-Schema-Drift"
+
+	// Message explains to clients why a request has been rejected.
+	Message = "instance restarting for upgrade: this instance is older than the database schema and is awaiting replacement"
+
+	defaultInterval = 30 * time.Second
+	// retryAfter is the number of seconds clients are advised to wait before
+	// retrying a rejected request.
+	retryAfter = "30"
+)
+
+type (
+	// Guard checks, at startup and periodically thereafter, whether the
+	// database schema has been migrated beyond the version this binary was
+	// built for. Once it has, the guard is tripped: mutating requests are
+	// rejected and exclusive subsystems are stopped. A tripped guard is never
+	// reset; the instance must be upgraded.
+	Guard struct {
+		logger   *slog.Logger
+		db       guardDB
+		built    int64
+		interval time.Duration
+
+		tripped chan struct{}
+		once    sync.Once
+	}
+
+	Options struct {
+		Logger *slog.Logger
+		DB     guardDB
+	}
+
+	guardDB interface {
+		UnknownMigrations(ctx context.Context) ([]int64, error)
+	}
+)
+
+func NewGuard(opts Options) *Guard {
+	return &Guard{
+		logger:   opts.Logger.With("component", "schema-guard"),
+		db:       opts.DB,
+		built:    sql.MigrationVersion,
+		interval: defaultInterval,
+		tripped:  make(chan struct{}),
+	}
+}
+
+// Start periodically checks the schema until the context is canceled or the
+// guard is tripped. Should be started in a go-routine.
+func (g *Guard) Start(ctx context.Context) error {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-g.tripped:
+			// there is nothing more to check; wait to be shutdown.
+			<-ctx.Done()
+			return nil
+		case <-ticker.C:
+			if err := g.Check(ctx); err != nil && ctx.Err() == nil {
+				g.logger.Error("checking database schema version", "err", err)
+			}
+		}
+	}
+}
+
+// Check compares the applied migrations with those embedded in the binary,
+// tripping the guard if migrations unknown to the binary have been applied.
+func (g *Guard) Check(ctx context.Context) error {
+	unknown, err := g.db.UnknownMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	g.once.Do(func() {
+		g.logger.Error("database schema is newer than this instance: rejecting mutating requests and stopping exclusive subsystems until the instance is upgraded",
+			"built_for_version", g.built,
+			"missing_migrations", unknown,
+		)
+		close(g.tripped)
+	})
+	return nil
+}
+
+// Tripped returns a channel that is closed once the guard is tripped.
+func (g *Guard) Tripped() <-chan struct{} {
+	return g.tripped
+}
+
+func (g *Guard) isTripped() bool {
+	select {
+	case <-g.tripped:
+		return true
+	default:
+		return false
+	}
+}
+
+// Middleware rejects mutating requests with a 503 once the guard is tripped.
+// Other requests are permitted, with web pages displaying a banner
+// explaining the situation.
+func (g *Guard) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !g.isTripped() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set(HeaderName, fmt.Sprintf("built-for=%d", g.built))
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				r = r.WithContext(html.AddBanner(r.Context(), "This instance is restarting for an upgrade: changes cannot be made until it is replaced."))
+				next.ServeHTTP(w, r)
+			default:
+				w.Header().Set("Retry-After", retryAfter)
+				tfeapi.Error(w, &internal.HTTPError{
+					Code:    http.StatusServiceUnavailable,
+					Message: Message,
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package schemaguard
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestGuard_Check(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("up to date", func(t *testing.T) {
+		g := newTestGuard(&fakeDB{})
+
+		require.NoError(t, g.Check(ctx))
+		assert.False(t, g.isTripped())
+	})
+
+	t.Run("behind", func(t *testing.T) {
+		g := newTestGuard(&fakeDB{unknown: []int64{20991231000000}})
+
+		require.NoError(t, g.Check(ctx))
+		assert.True(t, g.isTripped())
+
+		// checking again should not panic on closing an already closed
+		// channel
+		require.NoError(t, g.Check(ctx))
+	})
+}
+
+func TestGuard_Middleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		tripped  bool
+		method   string
+		wantCode int
+	}{
+		{"permit get", false, http.MethodGet, http.StatusOK},
+		{"permit post", false, http.MethodPost, http.StatusOK},
+		{"permit get when behind", true, http.MethodGet, http.StatusOK},
+		{"reject post when behind", true, http.MethodPost, http.StatusServiceUnavailable},
+		{"reject delete when behind", true, http.MethodDelete, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &fakeDB{}
+			if tt.tripped {
+				db.unknown = []int64{20991231000000}
+			}
+			g := newTestGuard(db)
+			require.NoError(t, g.Check(context.Background()))
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, "/api/v2/organizations", nil)
+			g.Middleware()(next).ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode == http.StatusServiceUnavailable {
+				assert.Equal(t, retryAfter, w.Header().Get("Retry-After"))
+				assert.Contains(t, w.Body.String(), "instance restarting for upgrade")
+			}
+			if tt.tripped {
+				assert.NotEmpty(t, w.Header().Get(HeaderName))
+			}
+		})
+	}
+}
+
+func newTestGuard(db guardDB) *Guard {
+	return NewGuard(Options{
+		Logger: slog.New(&xslog.NoopHandler{}),
+		DB:     db,
+	})
+}
+
+type fakeDB struct {
+	unknown []int64
+}
+
+func (f *fakeDB) UnknownMigrations(context.Context) ([]int64, error) {
+	return f.unknown, nil
+}
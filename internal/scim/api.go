@@ -0,0 +1,439 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/team"
+	"github.com/tofutf/tofutf/internal/user"
+)
+
+// api provides the SCIM endpoints, each of which requires the SCIM token of
+// the organization in the path.
+type api struct {
+	svc *Service
+}
+
+func (a *api) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(BasePath + "/organizations/{organization_name}").Subrouter()
+
+	r.HandleFunc("/Users", a.listUsers).Methods("GET")
+	r.HandleFunc("/Users", a.createUser).Methods("POST")
+	r.HandleFunc("/Users/{id}", a.getUser).Methods("GET")
+	r.HandleFunc("/Users/{id}", a.replaceUser).Methods("PUT")
+	r.HandleFunc("/Users/{id}", a.patchUser).Methods("PATCH")
+	r.HandleFunc("/Users/{id}", a.deleteUser).Methods("DELETE")
+
+	r.HandleFunc("/Groups", a.listGroups).Methods("GET")
+	r.HandleFunc("/Groups", a.createGroup).Methods("POST")
+	r.HandleFunc("/Groups/{id}", a.getGroup).Methods("GET")
+	r.HandleFunc("/Groups/{id}", a.replaceGroup).Methods("PUT")
+	r.HandleFunc("/Groups/{id}", a.patchGroup).Methods("PATCH")
+	r.HandleFunc("/Groups/{id}", a.deleteGroup).Methods("DELETE")
+}
+
+//
+// Users
+//
+
+func (a *api) listUsers(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	f, err := parseFilter(r.URL.Query().Get("filter"), "userName", "externalId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	users, err := a.svc.listUsers(r.Context(), organization, f)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resources := make([]any, len(users))
+	for i, u := range users {
+		resources[i] = newUserResource(u)
+	}
+	writeList(w, r, resources)
+}
+
+func (a *api) getUser(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	u, err := a.svc.getUser(r.Context(), organization, mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newUserResource(u))
+}
+
+func (a *api) createUser(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var res userResource
+	if err := decodeBody(r, &res); err != nil {
+		writeError(w, err)
+		return
+	}
+	u, err := a.svc.createUser(r.Context(), organization, &res)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newUserResource(u))
+}
+
+func (a *api) replaceUser(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var res userResource
+	if err := decodeBody(r, &res); err != nil {
+		writeError(w, err)
+		return
+	}
+	u, err := a.svc.getUser(r.Context(), organization, mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	u, err = a.svc.updateUser(r.Context(), u, &res)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newUserResource(u))
+}
+
+func (a *api) patchUser(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var patch patchRequest
+	if err := decodeBody(r, &patch); err != nil {
+		writeError(w, err)
+		return
+	}
+	u, err := a.svc.getUser(r.Context(), organization, mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	res := newUserResource(u)
+	if err := res.applyPatch(patch.Operations); err != nil {
+		writeError(w, err)
+		return
+	}
+	u, err = a.svc.updateUser(r.Context(), u, res)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newUserResource(u))
+}
+
+func (a *api) deleteUser(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := a.svc.deactivateUser(r.Context(), organization, mux.Vars(r)["id"]); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//
+// Groups
+//
+
+func (a *api) listGroups(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	f, err := parseFilter(r.URL.Query().Get("filter"), "displayName", "externalId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	teams, err := a.svc.listGroups(r.Context(), organization, f)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resources := make([]any, len(teams))
+	for i, t := range teams {
+		res, err := a.newGroupResource(r.Context(), t)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		resources[i] = res
+	}
+	writeList(w, r, resources)
+}
+
+func (a *api) getGroup(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := a.svc.getGroup(r.Context(), organization, mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	a.writeGroup(w, r, http.StatusOK, t)
+}
+
+func (a *api) createGroup(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var res groupResource
+	if err := decodeBody(r, &res); err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := a.svc.createGroup(r.Context(), organization, &res)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	a.writeGroup(w, r, http.StatusCreated, t)
+}
+
+func (a *api) replaceGroup(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var res groupResource
+	if err := decodeBody(r, &res); err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := a.svc.getGroup(r.Context(), organization, mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err = a.svc.updateGroup(r.Context(), t, &res)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	a.writeGroup(w, r, http.StatusOK, t)
+}
+
+func (a *api) patchGroup(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var patch patchRequest
+	if err := decodeBody(r, &patch); err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := a.svc.getGroup(r.Context(), organization, mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	res, err := a.newGroupResource(r.Context(), t)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := res.applyPatch(patch.Operations); err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err = a.svc.updateGroup(r.Context(), t, res)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	a.writeGroup(w, r, http.StatusOK, t)
+}
+
+func (a *api) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	organization, err := a.authorize(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := a.svc.deleteGroup(r.Context(), organization, mux.Vars(r)["id"]); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *api) writeGroup(w http.ResponseWriter, r *http.Request, status int, t *team.Team) {
+	res, err := a.newGroupResource(r.Context(), t)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, res)
+}
+
+// authorize checks the request is authenticated with the SCIM token of the
+// organization in the path, returning the organization.
+func (a *api) authorize(r *http.Request) (string, error) {
+	organization, err := decode.Param("organization_name", r)
+	if err != nil {
+		return "", err
+	}
+	token, err := tokenFromContext(r.Context())
+	if err != nil || token.Organization != organization {
+		return "", internal.ErrAccessNotPermitted
+	}
+	return organization, nil
+}
+
+func newUserResource(u *user.User) *userResource {
+	res := &userResource{
+		Schemas:  []string{userSchema},
+		ID:       u.ID,
+		UserName: u.Username,
+		Active:   internal.Bool(!u.Deactivated),
+		Meta: &meta{
+			ResourceType: "User",
+			Created:      u.CreatedAt,
+			LastModified: u.UpdatedAt,
+		},
+	}
+	if u.ExternalID != nil {
+		res.ExternalID = *u.ExternalID
+	}
+	if u.Email != nil {
+		res.Emails = []email{{Value: *u.Email, Primary: true}}
+	}
+	return res
+}
+
+func (a *api) newGroupResource(ctx context.Context, t *team.Team) (*groupResource, error) {
+	members, err := a.svc.listMembers(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	res := &groupResource{
+		Schemas:     []string{groupSchema},
+		ID:          t.ID,
+		DisplayName: t.Name,
+		Members:     make([]member, len(members)),
+		Meta: &meta{
+			ResourceType: "Group",
+			Created:      t.CreatedAt,
+			LastModified: t.CreatedAt,
+		},
+	}
+	if t.SSOTeamID != nil {
+		res.ExternalID = *t.SSOTeamID
+	}
+	for i, u := range members {
+		res.Members[i] = member{Value: u.ID, Display: u.Username}
+	}
+	return res, nil
+}
+
+func decodeBody(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return invalidSyntax("invalid request body: %s", err.Error())
+	}
+	return nil
+}
+
+// writeList writes a list response, paginated according to the startIndex
+// and count query parameters.
+func writeList(w http.ResponseWriter, r *http.Request, resources []any) {
+	startIndex, count := 1, len(resources)
+	if s := r.URL.Query().Get("startIndex"); s != "" {
+		if i, err := strconv.Atoi(s); err == nil && i > 1 {
+			startIndex = i
+		}
+	}
+	if s := r.URL.Query().Get("count"); s != "" {
+		if i, err := strconv.Atoi(s); err == nil && i >= 0 {
+			count = i
+		}
+	}
+	page := []any{}
+	if start := startIndex - 1; start < len(resources) {
+		end := min(start+count, len(resources))
+		page = resources[start:end]
+	}
+	writeJSON(w, http.StatusOK, &listResponse{
+		Schemas:      []string{listSchema},
+		TotalResults: len(resources),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(page),
+		Resources:    page,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
+
+// writeError writes an error using the SCIM error schema.
+func writeError(w http.ResponseWriter, err error) {
+	scimErr := &Error{Status: http.StatusInternalServerError, Detail: err.Error()}
+	switch {
+	case errors.As(err, &scimErr):
+	case errors.Is(err, internal.ErrResourceNotFound):
+		scimErr.Status = http.StatusNotFound
+	case errors.Is(err, internal.ErrResourceAlreadyExists):
+		scimErr.Status = http.StatusConflict
+		scimErr.SCIMType = "uniqueness"
+	case errors.Is(err, internal.ErrAccessNotPermitted):
+		scimErr.Status = http.StatusForbidden
+	case errors.Is(err, user.ErrCannotDeleteOnlyOwner):
+		scimErr.Status = http.StatusBadRequest
+		scimErr.SCIMType = "mutability"
+	}
+	writeJSON(w, scimErr.Status, struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		SCIMType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail"`
+	}{
+		Schemas:  []string{errorSchema},
+		Status:   strconv.Itoa(scimErr.Status),
+		SCIMType: scimErr.SCIMType,
+		Detail:   scimErr.Detail,
+	})
+}
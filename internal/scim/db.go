@@ -0,0 +1,70 @@
+package scim
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+// pgdb stores SCIM tokens in a postgres database
+type pgdb struct {
+	*sql.Pool // provides access to generated SQL queries
+}
+
+// tokenRow is the row result of a database query for SCIM tokens
+type tokenRow struct {
+	ScimTokenID      pgtype.Text        `json:"scim_token_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+}
+
+func (result tokenRow) toToken() *Token {
+	return &Token{
+		ID:           result.ScimTokenID.String,
+		CreatedAt:    result.CreatedAt.Time.UTC(),
+		Organization: result.OrganizationName.String,
+	}
+}
+
+func (db *pgdb) upsertToken(ctx context.Context, token *Token) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertSCIMToken(ctx, pggen.UpsertSCIMTokenParams{
+			ScimTokenID:      sql.String(token.ID),
+			CreatedAt:        sql.Timestamptz(token.CreatedAt),
+			OrganizationName: sql.String(token.Organization),
+		})
+		return err
+	})
+}
+
+func (db *pgdb) getToken(ctx context.Context, organization string) (*Token, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Token, error) {
+		result, err := q.FindSCIMTokenByOrganization(ctx, sql.String(organization))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return tokenRow(result).toToken(), nil
+	})
+}
+
+func (db *pgdb) getTokenByID(ctx context.Context, tokenID string) (*Token, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Token, error) {
+		result, err := q.FindSCIMTokenByID(ctx, sql.String(tokenID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		return tokenRow(result).toToken(), nil
+	})
+}
+
+func (db *pgdb) deleteToken(ctx context.Context, organization string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteSCIMTokenByOrganization(ctx, sql.String(organization))
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,369 @@
+// Package scim implements a SCIM 2.0 server, permitting an identity provider
+// to provision users and to map its groups to teams.
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// BasePath is the URL path prefix for SCIM endpoints.
+	BasePath = "/scim/v2"
+
+	contentType = "application/scim+json"
+
+	userSchema  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	groupSchema = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	listSchema  = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	patchSchema = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	errorSchema = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+type (
+	// userResource is the SCIM representation of a user.
+	userResource struct {
+		Schemas    []string `json:"schemas"`
+		ID         string   `json:"id,omitempty"`
+		ExternalID string   `json:"externalId,omitempty"`
+		UserName   string   `json:"userName"`
+		Active     *bool    `json:"active,omitempty"`
+		Emails     []email  `json:"emails,omitempty"`
+		Meta       *meta    `json:"meta,omitempty"`
+	}
+
+	email struct {
+		Value   string `json:"value"`
+		Type    string `json:"type,omitempty"`
+		Primary bool   `json:"primary,omitempty"`
+	}
+
+	// groupResource is the SCIM representation of a group, which maps to a
+	// team.
+	groupResource struct {
+		Schemas     []string `json:"schemas"`
+		ID          string   `json:"id,omitempty"`
+		ExternalID  string   `json:"externalId,omitempty"`
+		DisplayName string   `json:"displayName"`
+		Members     []member `json:"members"`
+		Meta        *meta    `json:"meta,omitempty"`
+	}
+
+	// member is a member of a group, identified by user ID.
+	member struct {
+		Value   string `json:"value"`
+		Display string `json:"display,omitempty"`
+	}
+
+	meta struct {
+		ResourceType string    `json:"resourceType"`
+		Created      time.Time `json:"created"`
+		LastModified time.Time `json:"lastModified"`
+	}
+
+	listResponse struct {
+		Schemas      []string `json:"schemas"`
+		TotalResults int      `json:"totalResults"`
+		StartIndex   int      `json:"startIndex"`
+		ItemsPerPage int      `json:"itemsPerPage"`
+		Resources    []any    `json:"Resources"`
+	}
+
+	patchRequest struct {
+		Schemas    []string         `json:"schemas"`
+		Operations []patchOperation `json:"Operations"`
+	}
+
+	patchOperation struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	// filter is a SCIM filter expression of the form <attribute> eq "<value>",
+	// which is the only form of expression identity providers use to look up
+	// existing resources.
+	filter struct {
+		attribute string
+		value     string
+	}
+
+	// Error is a SCIM error, returned to the client with the SCIM error
+	// schema.
+	Error struct {
+		Status   int
+		SCIMType string
+		Detail   string
+	}
+)
+
+func (e *Error) Error() string { return e.Detail }
+
+func invalidFilter(format string, a ...any) *Error {
+	return &Error{Status: http.StatusBadRequest, SCIMType: "invalidFilter", Detail: fmt.Sprintf(format, a...)}
+}
+
+func invalidValue(format string, a ...any) *Error {
+	return &Error{Status: http.StatusBadRequest, SCIMType: "invalidValue", Detail: fmt.Sprintf(format, a...)}
+}
+
+func invalidSyntax(format string, a ...any) *Error {
+	return &Error{Status: http.StatusBadRequest, SCIMType: "invalidSyntax", Detail: fmt.Sprintf(format, a...)}
+}
+
+var (
+	filterRegex = regexp.MustCompile(`(?i)^\s*(\w+)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+	// memberRegex matches a path selecting a group member by user ID, e.g.
+	// members[value eq "user-123"]
+	memberRegex = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+("(?:[^"\\]|\\.)*")\s*\]$`)
+)
+
+// parseFilter parses a filter expression, returning nil if the expression is
+// empty. Only the given attributes may be filtered on; attribute names are
+// case-insensitive.
+func parseFilter(expr string, attributes ...string) (*filter, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	matches := filterRegex.FindStringSubmatch(expr)
+	if matches == nil {
+		return nil, invalidFilter("unsupported filter: %s", expr)
+	}
+	var value string
+	if err := json.Unmarshal([]byte(matches[2]), &value); err != nil {
+		return nil, invalidFilter("invalid filter value: %s", matches[2])
+	}
+	for _, attr := range attributes {
+		if strings.EqualFold(attr, matches[1]) {
+			return &filter{attribute: attr, value: value}, nil
+		}
+	}
+	return nil, invalidFilter("unsupported filter attribute: %s", matches[1])
+}
+
+func (r *userResource) active() bool {
+	return r.Active == nil || *r.Active
+}
+
+// primaryEmail returns the primary email address, or the first email address
+// if none is marked primary.
+func (r *userResource) primaryEmail() string {
+	for _, e := range r.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(r.Emails) > 0 {
+		return r.Emails[0].Value
+	}
+	return ""
+}
+
+// applyPatch applies patch operations to the user. Operations on attributes
+// that are not persisted, e.g. name or title, are ignored.
+func (r *userResource) applyPatch(ops []patchOperation) error {
+	return applyPatch(ops, r.setAttribute)
+}
+
+func (r *userResource) setAttribute(op, path string, value json.RawMessage) error {
+	switch lowerPath := strings.ToLower(path); {
+	case lowerPath == "username":
+		if op == "remove" {
+			return invalidValue("userName is required")
+		}
+		return unmarshalString(value, &r.UserName)
+	case lowerPath == "externalid":
+		if op == "remove" {
+			r.ExternalID = ""
+			return nil
+		}
+		return unmarshalString(value, &r.ExternalID)
+	case lowerPath == "active":
+		if op == "remove" {
+			return invalidValue("active cannot be removed")
+		}
+		active, err := unmarshalBool(value)
+		if err != nil {
+			return err
+		}
+		r.Active = &active
+		return nil
+	case lowerPath == "emails":
+		if op == "remove" {
+			r.Emails = nil
+			return nil
+		}
+		var emails []email
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return invalidValue("invalid emails: %s", err.Error())
+		}
+		if op == "add" {
+			r.Emails = append(r.Emails, emails...)
+		} else {
+			r.Emails = emails
+		}
+		return nil
+	case strings.HasPrefix(lowerPath, "emails["):
+		// a path selecting a particular email, e.g. emails[type eq
+		// "work"].value; only one email is persisted so treat it as the
+		// primary email.
+		if op == "remove" {
+			r.Emails = nil
+			return nil
+		}
+		var address string
+		if err := unmarshalString(value, &address); err != nil {
+			return err
+		}
+		r.Emails = []email{{Value: address, Primary: true}}
+		return nil
+	}
+	return nil
+}
+
+// applyPatch applies patch operations to the group.
+func (r *groupResource) applyPatch(ops []patchOperation) error {
+	return applyPatch(ops, r.setAttribute)
+}
+
+func (r *groupResource) setAttribute(op, path string, value json.RawMessage) error {
+	if matches := memberRegex.FindStringSubmatch(path); matches != nil {
+		if op != "remove" {
+			return invalidSyntax("unsupported operation on member: %s", op)
+		}
+		var id string
+		if err := json.Unmarshal([]byte(matches[1]), &id); err != nil {
+			return invalidSyntax("invalid path: %s", path)
+		}
+		r.removeMembers(member{Value: id})
+		return nil
+	}
+	switch strings.ToLower(path) {
+	case "displayname":
+		if op == "remove" {
+			return invalidValue("displayName is required")
+		}
+		return unmarshalString(value, &r.DisplayName)
+	case "externalid":
+		if op == "remove" {
+			r.ExternalID = ""
+			return nil
+		}
+		return unmarshalString(value, &r.ExternalID)
+	case "members":
+		var members []member
+		if len(value) > 0 && string(value) != "null" {
+			if err := json.Unmarshal(value, &members); err != nil {
+				return invalidValue("invalid members: %s", err.Error())
+			}
+		}
+		switch op {
+		case "add":
+			r.addMembers(members...)
+		case "replace":
+			r.Members = nil
+			r.addMembers(members...)
+		case "remove":
+			if members == nil {
+				// remove all members
+				r.Members = nil
+			} else {
+				r.removeMembers(members...)
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+func (r *groupResource) addMembers(members ...member) {
+	for _, m := range members {
+		if !r.hasMember(m.Value) {
+			r.Members = append(r.Members, m)
+		}
+	}
+}
+
+func (r *groupResource) removeMembers(members ...member) {
+	remove := make(map[string]bool, len(members))
+	for _, m := range members {
+		remove[m.Value] = true
+	}
+	var kept []member
+	for _, m := range r.Members {
+		if !remove[m.Value] {
+			kept = append(kept, m)
+		}
+	}
+	r.Members = kept
+}
+
+func (r *groupResource) hasMember(id string) bool {
+	for _, m := range r.Members {
+		if m.Value == id {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPatch applies patch operations via the setter. An operation without a
+// path has a value that is an object of attributes, each of which is set in
+// turn.
+func applyPatch(ops []patchOperation, set func(op, path string, value json.RawMessage) error) error {
+	for _, o := range ops {
+		op := strings.ToLower(o.Op)
+		switch op {
+		case "add", "replace", "remove":
+		default:
+			return invalidSyntax("unsupported patch operation: %s", o.Op)
+		}
+		if o.Path != "" {
+			if err := set(op, o.Path, o.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		if op == "remove" {
+			return &Error{Status: http.StatusBadRequest, SCIMType: "noTarget", Detail: "remove operation requires a path"}
+		}
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(o.Value, &attributes); err != nil {
+			return invalidValue("patch operation without a path requires an object value")
+		}
+		for path, value := range attributes {
+			if err := set(op, path, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func unmarshalString(value json.RawMessage, dst *string) error {
+	if err := json.Unmarshal(value, dst); err != nil {
+		return invalidValue("expected a string: %s", string(value))
+	}
+	return nil
+}
+
+// unmarshalBool unmarshals a boolean, which some identity providers send as a
+// string, e.g. "False".
+func unmarshalBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, invalidValue("expected a boolean: %s", string(value))
+}
@@ -0,0 +1,180 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/team"
+	"github.com/tofutf/tofutf/internal/user"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want *filter
+		err  bool
+	}{
+		{"empty", "", nil, false},
+		{"userName", `userName eq "alice"`, &filter{attribute: "userName", value: "alice"}, false},
+		{"case-insensitive", `USERNAME EQ "alice"`, &filter{attribute: "userName", value: "alice"}, false},
+		{"escaped quote", `externalId eq "a\"b"`, &filter{attribute: "externalId", value: `a"b`}, false},
+		{"unsupported attribute", `title eq "boss"`, nil, true},
+		{"unsupported operator", `userName co "ali"`, nil, true},
+		{"compound expression", `userName eq "alice" and externalId eq "1"`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.expr, "userName", "externalId")
+			if tt.err {
+				var scimErr *Error
+				require.ErrorAs(t, err, &scimErr)
+				assert.Equal(t, "invalidFilter", scimErr.SCIMType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUserResource_ApplyPatch(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  string
+		want userResource
+	}{
+		{
+			name: "replace active with path",
+			ops:  `[{"op":"replace","path":"active","value":false}]`,
+			want: userResource{UserName: "alice", Active: internal.Bool(false)},
+		},
+		{
+			name: "replace active without path",
+			ops:  `[{"op":"Replace","value":{"active":"False"}}]`,
+			want: userResource{UserName: "alice", Active: internal.Bool(false)},
+		},
+		{
+			name: "replace userName and externalId",
+			ops:  `[{"op":"Replace","path":"userName","value":"bob"},{"op":"Add","path":"externalId","value":"123"}]`,
+			want: userResource{UserName: "bob", ExternalID: "123", Active: internal.Bool(true)},
+		},
+		{
+			name: "replace email with filtered path",
+			ops:  `[{"op":"Replace","path":"emails[type eq \"work\"].value","value":"bob@example.com"}]`,
+			want: userResource{UserName: "alice", Active: internal.Bool(true), Emails: []email{{Value: "bob@example.com", Primary: true}}},
+		},
+		{
+			name: "ignore unsupported attributes",
+			ops:  `[{"op":"Replace","path":"name.givenName","value":"Alice"}]`,
+			want: userResource{UserName: "alice", Active: internal.Bool(true)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []patchOperation
+			require.NoError(t, json.Unmarshal([]byte(tt.ops), &ops))
+
+			res := userResource{UserName: "alice", Active: internal.Bool(true)}
+			require.NoError(t, res.applyPatch(ops))
+			assert.Equal(t, tt.want, res)
+		})
+	}
+
+	t.Run("remove userName", func(t *testing.T) {
+		res := userResource{UserName: "alice"}
+		err := res.applyPatch([]patchOperation{{Op: "remove", Path: "userName"}})
+		var scimErr *Error
+		require.ErrorAs(t, err, &scimErr)
+		assert.Equal(t, "invalidValue", scimErr.SCIMType)
+	})
+
+	t.Run("unsupported operation", func(t *testing.T) {
+		res := userResource{UserName: "alice"}
+		err := res.applyPatch([]patchOperation{{Op: "move", Path: "userName"}})
+		var scimErr *Error
+		require.ErrorAs(t, err, &scimErr)
+		assert.Equal(t, "invalidSyntax", scimErr.SCIMType)
+	})
+}
+
+func TestGroupResource_ApplyPatch(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  string
+		want []member
+	}{
+		{
+			name: "add members",
+			ops:  `[{"op":"Add","path":"members","value":[{"value":"user-2"},{"value":"user-3"}]}]`,
+			want: []member{{Value: "user-1"}, {Value: "user-2"}, {Value: "user-3"}},
+		},
+		{
+			name: "add existing member",
+			ops:  `[{"op":"Add","path":"members","value":[{"value":"user-1"}]}]`,
+			want: []member{{Value: "user-1"}},
+		},
+		{
+			name: "remove member with filtered path",
+			ops:  `[{"op":"Remove","path":"members[value eq \"user-1\"]"}]`,
+			want: nil,
+		},
+		{
+			name: "remove member with value",
+			ops:  `[{"op":"Remove","path":"members","value":[{"value":"user-1"}]}]`,
+			want: nil,
+		},
+		{
+			name: "replace members",
+			ops:  `[{"op":"Replace","path":"members","value":[{"value":"user-2"}]}]`,
+			want: []member{{Value: "user-2"}},
+		},
+		{
+			name: "add members without path",
+			ops:  `[{"op":"Add","value":{"members":[{"value":"user-2"}]}}]`,
+			want: []member{{Value: "user-1"}, {Value: "user-2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []patchOperation
+			require.NoError(t, json.Unmarshal([]byte(tt.ops), &ops))
+
+			res := groupResource{DisplayName: "engineers", Members: []member{{Value: "user-1"}}}
+			require.NoError(t, res.applyPatch(ops))
+			assert.Equal(t, tt.want, res.Members)
+		})
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		res := groupResource{DisplayName: "engineers"}
+		err := res.applyPatch([]patchOperation{{Op: "replace", Path: "displayName", Value: json.RawMessage(`"developers"`)}})
+		require.NoError(t, err)
+		assert.Equal(t, "developers", res.DisplayName)
+	})
+}
+
+func TestInScope(t *testing.T) {
+	acme := &team.Team{Organization: "acme"}
+	globex := &team.Team{Organization: "globex"}
+
+	tests := []struct {
+		name  string
+		teams []*team.Team
+		want  bool
+	}{
+		{"member of organization", []*team.Team{acme}, true},
+		{"member of organization and another", []*team.Team{globex, acme}, true},
+		{"member of no organization", nil, true},
+		{"member of another organization only", []*team.Team{globex}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &user.User{Teams: tt.teams}
+			assert.Equal(t, tt.want, inScope(u, "acme"))
+		})
+	}
+}
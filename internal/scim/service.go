@@ -0,0 +1,448 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/team"
+	"github.com/tofutf/tofutf/internal/tokens"
+	"github.com/tofutf/tofutf/internal/user"
+)
+
+type (
+	// Service provisions users and teams on behalf of an identity provider.
+	Service struct {
+		logger *slog.Logger
+		site   internal.Authorizer
+		db     *pgdb
+		users  userClient
+		teams  teamClient
+		api    *api
+		web    *webHandlers
+
+		*tokenFactory
+	}
+
+	Options struct {
+		Logger          *slog.Logger
+		TokensService   *tokens.Service
+		UserService     *user.Service
+		TeamService     *team.Service
+		HostnameService *internal.HostnameService
+
+		*sql.Pool
+		html.Renderer
+	}
+
+	userClient interface {
+		Create(ctx context.Context, username string, opts ...user.NewUserOption) (*user.User, error)
+		GetUser(ctx context.Context, spec user.UserSpec) (*user.User, error)
+		ListOrganizationUsers(ctx context.Context, organization string) ([]*user.User, error)
+		ListTeamUsers(ctx context.Context, teamID string) ([]*user.User, error)
+		Update(ctx context.Context, username string, opts user.UpdateUserOptions) (*user.User, error)
+		Deactivate(ctx context.Context, username string) (*user.User, error)
+		Reactivate(ctx context.Context, username string) (*user.User, error)
+		AddTeamMembership(ctx context.Context, teamID string, usernames []string) error
+		RemoveTeamMembership(ctx context.Context, teamID string, usernames []string) error
+	}
+
+	teamClient interface {
+		Create(ctx context.Context, organization string, opts team.CreateTeamOptions) (*team.Team, error)
+		Update(ctx context.Context, teamID string, opts team.UpdateTeamOptions) (*team.Team, error)
+		List(ctx context.Context, organization string) ([]*team.Team, error)
+		GetByID(ctx context.Context, teamID string) (*team.Team, error)
+		Delete(ctx context.Context, teamID string) error
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		logger:       opts.Logger,
+		site:         &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:           &pgdb{opts.Pool},
+		users:        opts.UserService,
+		teams:        opts.TeamService,
+		tokenFactory: &tokenFactory{tokens: opts.TokensService},
+	}
+	svc.api = &api{svc: &svc}
+	svc.web = &webHandlers{
+		Renderer:        opts.Renderer,
+		HostnameService: opts.HostnameService,
+		svc:             &svc,
+	}
+	// Register with auth middleware the SCIM token kind and a means of
+	// retrieving the token.
+	opts.TokensService.RegisterKind(TokenKind, func(ctx context.Context, tokenID string) (internal.Subject, error) {
+		return svc.db.getTokenByID(ctx, tokenID)
+	})
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.api.addHandlers(r)
+	s.web.addHandlers(r)
+}
+
+// CreateToken creates a SCIM token for an organization, replacing any
+// existing token. Only a site admin may create a token, because the token
+// permits provisioning users, who are not confined to the organization.
+func (s *Service) CreateToken(ctx context.Context, organization string) (*Token, []byte, error) {
+	subject, err := s.site.CanAccess(ctx, rbac.CreateSCIMTokenAction, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	st, token, err := s.newToken(organization)
+	if err != nil {
+		s.logger.Error("constructing SCIM token", "organization", organization, "subject", subject, "err", err)
+		return nil, nil, err
+	}
+
+	if err := s.db.upsertToken(ctx, st); err != nil {
+		s.logger.Error("creating SCIM token", "organization", organization, "subject", subject, "err", err)
+		return nil, nil, err
+	}
+
+	s.logger.Info("created SCIM token", "organization", organization, "subject", subject)
+
+	return st, token, nil
+}
+
+// GetToken retrieves an organization's SCIM token.
+func (s *Service) GetToken(ctx context.Context, organization string) (*Token, error) {
+	_, err := s.site.CanAccess(ctx, rbac.GetSCIMTokenAction, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.db.getToken(ctx, organization)
+}
+
+// DeleteToken deletes an organization's SCIM token.
+func (s *Service) DeleteToken(ctx context.Context, organization string) error {
+	subject, err := s.site.CanAccess(ctx, rbac.DeleteSCIMTokenAction, "")
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.deleteToken(ctx, organization); err != nil {
+		s.logger.Error("deleting SCIM token", "organization", organization, "subject", subject, "err", err)
+		return err
+	}
+
+	s.logger.Info("deleted SCIM token", "organization", organization, "subject", subject)
+
+	return nil
+}
+
+//
+// Users
+//
+
+// listUsers lists users. Without a filter, the organization's users are
+// listed; otherwise the user matching the filter is listed, providing the
+// user is within the organization's scope, because the identity provider uses
+// the filter to look up a user before deciding whether to create them.
+func (s *Service) listUsers(ctx context.Context, organization string, f *filter) ([]*user.User, error) {
+	if f == nil {
+		return s.users.ListOrganizationUsers(ctx, organization)
+	}
+	var spec user.UserSpec
+	switch f.attribute {
+	case "userName":
+		spec.Username = &f.value
+	case "externalId":
+		spec.ExternalID = &f.value
+	}
+	u, err := s.users.GetUser(ctx, spec)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !inScope(u, organization) {
+		return nil, nil
+	}
+	return []*user.User{u}, nil
+}
+
+// getUser retrieves a user, which must be within the organization's scope.
+func (s *Service) getUser(ctx context.Context, organization, id string) (*user.User, error) {
+	u, err := s.users.GetUser(ctx, user.UserSpec{UserID: &id})
+	if err != nil {
+		return nil, err
+	}
+	if !inScope(u, organization) {
+		return nil, internal.ErrResourceNotFound
+	}
+	return u, nil
+}
+
+// inScope determines whether a user is within the scope of an organization's
+// SCIM token, i.e. whether the user is a member of the organization, or a
+// member of no organization at all, as is the case for a user who is yet to
+// be added to a group, or who has been deactivated. Users are global, so
+// without this an organization's identity provider could otherwise read and
+// deactivate the users of other organizations.
+func inScope(u *user.User, organization string) bool {
+	orgs := u.Organizations()
+	return len(orgs) == 0 || slices.Contains(orgs, organization)
+}
+
+// createUser provisions a user. If a user with the same email address exists,
+// e.g. a user who previously logged in via OIDC, then that user is merged with
+// the provisioned user rather than creating another user.
+func (s *Service) createUser(ctx context.Context, organization string, res *userResource) (*user.User, error) {
+	if res.UserName == "" {
+		return nil, invalidValue("userName is required")
+	}
+	if res.ExternalID != "" {
+		_, err := s.users.GetUser(ctx, user.UserSpec{ExternalID: &res.ExternalID})
+		if err == nil {
+			return nil, internal.ErrResourceAlreadyExists
+		} else if !errors.Is(err, internal.ErrResourceNotFound) {
+			return nil, err
+		}
+	}
+	if email := res.primaryEmail(); email != "" {
+		existing, err := s.users.GetUser(ctx, user.UserSpec{Email: &email})
+		if err == nil {
+			if existing.ExternalID != nil || !inScope(existing, organization) {
+				// already provisioned, or belongs to another organization
+				return nil, internal.ErrResourceAlreadyExists
+			}
+			s.logger.Info("merging provisioned user with existing user", "username", res.UserName, "user", existing)
+			return s.updateUser(ctx, existing, res)
+		} else if !errors.Is(err, internal.ErrResourceNotFound) {
+			return nil, err
+		}
+	}
+
+	var opts []user.NewUserOption
+	if res.ExternalID != "" {
+		opts = append(opts, user.WithExternalID(res.ExternalID))
+	}
+	if email := res.primaryEmail(); email != "" {
+		opts = append(opts, user.WithEmail(email))
+	}
+	u, err := s.users.Create(ctx, res.UserName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !res.active() {
+		return s.users.Deactivate(ctx, u.Username)
+	}
+	return u, nil
+}
+
+// updateUser updates a user to match the provisioned user. Email addresses
+// and external IDs are never cleared, in order that the user can continue to
+// be matched upon login and provisioning.
+func (s *Service) updateUser(ctx context.Context, u *user.User, res *userResource) (*user.User, error) {
+	if u.IsSiteAdmin() {
+		return nil, internal.ErrAccessNotPermitted
+	}
+	if res.UserName == "" {
+		return nil, invalidValue("userName is required")
+	}
+	var (
+		opts    user.UpdateUserOptions
+		changed bool
+	)
+	if res.UserName != u.Username {
+		opts.Username = &res.UserName
+		changed = true
+	}
+	if res.ExternalID != "" && (u.ExternalID == nil || *u.ExternalID != res.ExternalID) {
+		opts.ExternalID = &res.ExternalID
+		changed = true
+	}
+	if email := res.primaryEmail(); email != "" && (u.Email == nil || *u.Email != email) {
+		opts.Email = &email
+		changed = true
+	}
+	var err error
+	if changed {
+		if u, err = s.users.Update(ctx, u.Username, opts); err != nil {
+			return nil, err
+		}
+	}
+	if res.active() && u.Deactivated {
+		return s.users.Reactivate(ctx, u.Username)
+	} else if !res.active() && !u.Deactivated {
+		return s.users.Deactivate(ctx, u.Username)
+	}
+	return u, nil
+}
+
+// deactivateUser deactivates a user, revoking their sessions and tokens and
+// removing them from all teams. Users are never deleted, in order to preserve
+// their history.
+func (s *Service) deactivateUser(ctx context.Context, organization, id string) error {
+	u, err := s.getUser(ctx, organization, id)
+	if err != nil {
+		return err
+	}
+	if u.IsSiteAdmin() {
+		return internal.ErrAccessNotPermitted
+	}
+	_, err = s.users.Deactivate(ctx, u.Username)
+	return err
+}
+
+//
+// Groups
+//
+
+// listGroups lists the organization's teams, optionally filtered by name or
+// external ID.
+func (s *Service) listGroups(ctx context.Context, organization string, f *filter) ([]*team.Team, error) {
+	teams, err := s.teams.List(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return teams, nil
+	}
+	var filtered []*team.Team
+	for _, t := range teams {
+		switch f.attribute {
+		case "displayName":
+			if t.Name == f.value {
+				filtered = append(filtered, t)
+			}
+		case "externalId":
+			if t.SSOTeamID != nil && *t.SSOTeamID == f.value {
+				filtered = append(filtered, t)
+			}
+		}
+	}
+	return filtered, nil
+}
+
+// getGroup retrieves a team, which must belong to the organization.
+func (s *Service) getGroup(ctx context.Context, organization, id string) (*team.Team, error) {
+	t, err := s.teams.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Organization != organization {
+		return nil, internal.ErrResourceNotFound
+	}
+	return t, nil
+}
+
+// listMembers lists a team's members.
+func (s *Service) listMembers(ctx context.Context, teamID string) ([]*user.User, error) {
+	return s.users.ListTeamUsers(ctx, teamID)
+}
+
+// createGroup creates a team, using the group's external ID as the team's
+// SSO team ID.
+func (s *Service) createGroup(ctx context.Context, organization string, res *groupResource) (*team.Team, error) {
+	if res.DisplayName == "" {
+		return nil, invalidValue("displayName is required")
+	}
+	opts := team.CreateTeamOptions{Name: &res.DisplayName}
+	if res.ExternalID != "" {
+		opts.SSOTeamID = &res.ExternalID
+	}
+	t, err := s.teams.Create(ctx, organization, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.setMembers(ctx, t, res.Members); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// updateGroup updates a team to match the group, including its members.
+func (s *Service) updateGroup(ctx context.Context, t *team.Team, res *groupResource) (*team.Team, error) {
+	if res.DisplayName == "" {
+		return nil, invalidValue("displayName is required")
+	}
+	var (
+		opts    team.UpdateTeamOptions
+		changed bool
+	)
+	if res.DisplayName != t.Name {
+		opts.Name = &res.DisplayName
+		changed = true
+	}
+	if res.ExternalID != "" && (t.SSOTeamID == nil || *t.SSOTeamID != res.ExternalID) {
+		opts.SSOTeamID = &res.ExternalID
+		changed = true
+	}
+	if changed {
+		var err error
+		if t, err = s.teams.Update(ctx, t.ID, opts); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.setMembers(ctx, t, res.Members); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// setMembers authoritatively sets a team's members. Deactivated users are
+// not added.
+func (s *Service) setMembers(ctx context.Context, t *team.Team, members []member) error {
+	current, err := s.users.ListTeamUsers(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	have := make(map[string]bool, len(current))
+	for _, u := range current {
+		have[u.ID] = true
+	}
+	want := make(map[string]bool, len(members))
+	var add, remove []string
+	for _, m := range members {
+		want[m.Value] = true
+		if have[m.Value] {
+			continue
+		}
+		u, err := s.getUser(ctx, t.Organization, m.Value)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			return invalidValue("no such member: %s", m.Value)
+		} else if err != nil {
+			return err
+		}
+		if u.Deactivated {
+			continue
+		}
+		add = append(add, u.Username)
+	}
+	for _, u := range current {
+		if !want[u.ID] {
+			remove = append(remove, u.Username)
+		}
+	}
+	if len(add) > 0 {
+		if err := s.users.AddTeamMembership(ctx, t.ID, add); err != nil {
+			return err
+		}
+	}
+	if len(remove) > 0 {
+		if err := s.users.RemoveTeamMembership(ctx, t.ID, remove); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) deleteGroup(ctx context.Context, organization, id string) error {
+	if _, err := s.getGroup(ctx, organization, id); err != nil {
+		return err
+	}
+	return s.teams.Delete(ctx, id)
+}
@@ -0,0 +1,99 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/tokens"
+)
+
+const TokenKind tokens.Kind = "scim_token"
+
+var _ internal.Subject = (*Token)(nil)
+
+type (
+	// Token is a bearer token authenticating an identity provider provisioning
+	// users and teams via SCIM. An organization has at most one token.
+	Token struct {
+		ID        string
+		CreatedAt time.Time
+		// Token provisions the organization's teams
+		Organization string
+	}
+
+	// tokenFactory constructs SCIM tokens
+	tokenFactory struct {
+		tokens *tokens.Service
+	}
+)
+
+func (f *tokenFactory) newToken(organization string) (*Token, []byte, error) {
+	st := Token{
+		ID:           internal.NewID("scim"),
+		CreatedAt:    internal.CurrentTimestamp(nil),
+		Organization: organization,
+	}
+	token, err := f.tokens.NewToken(tokens.NewTokenOptions{
+		Subject: st.ID,
+		Kind:    TokenKind,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &st, token, nil
+}
+
+// CanAccessSite permits the token to provision users, which are not scoped
+// to an organization.
+func (t *Token) CanAccessSite(action rbac.Action) bool {
+	switch action {
+	case rbac.CreateUserAction, rbac.GetUserAction, rbac.UpdateUserAction:
+		return true
+	}
+	return false
+}
+
+func (t *Token) CanAccessTeam(rbac.Action, string) bool {
+	return false
+}
+
+// CanAccessOrganization permits the token to provision the teams of its
+// organization.
+func (t *Token) CanAccessOrganization(action rbac.Action, org string) bool {
+	if t.Organization != org {
+		return false
+	}
+	switch action {
+	case rbac.CreateTeamAction, rbac.UpdateTeamAction, rbac.GetTeamAction, rbac.ListTeamsAction, rbac.DeleteTeamAction, rbac.AddTeamMembershipAction, rbac.RemoveTeamMembershipAction, rbac.ListUsersAction:
+		return true
+	}
+	return false
+}
+
+func (t *Token) CanAccessWorkspace(rbac.Action, internal.WorkspacePolicy) bool {
+	return false
+}
+
+func (t *Token) IsOwner(string) bool { return false }
+func (t *Token) IsSiteAdmin() bool   { return false }
+func (t *Token) String() string      { return t.ID }
+
+func (t *Token) Organizations() []string {
+	return []string{t.Organization}
+}
+
+// tokenFromContext retrieves a SCIM token from a context
+func tokenFromContext(ctx context.Context) (*Token, error) {
+	subj, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, ok := subj.(*Token)
+	if !ok {
+		return nil, fmt.Errorf("no SCIM token in context")
+	}
+	return token, nil
+}
@@ -0,0 +1,85 @@
+package scim
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/tokens"
+)
+
+type webHandlers struct {
+	html.Renderer
+	*internal.HostnameService
+
+	svc *Service
+}
+
+func (h *webHandlers) addHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/scim-tokens/show", h.getToken).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/scim-tokens/create", h.createToken).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/scim-tokens/delete", h.deleteToken).Methods("POST")
+}
+
+func (h *webHandlers) getToken(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	token, err := h.svc.GetToken(r.Context(), org)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		token = nil
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.Render("scim_token.tmpl", w, struct {
+		organization.OrganizationPage
+		Token   *Token
+		BaseURL string
+	}{
+		OrganizationPage: organization.NewPage(r, "SCIM token", org),
+		Token:            token,
+		BaseURL:          h.URL(BasePath + "/organizations/" + org),
+	})
+}
+
+func (h *webHandlers) createToken(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	_, token, err := h.svc.CreateToken(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := tokens.TokenFlashMessage(h, w, token); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, paths.SCIMToken(org), http.StatusFound)
+}
+
+func (h *webHandlers) deleteToken(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err := h.svc.DeleteToken(r.Context(), org); err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	html.FlashSuccess(w, "Deleted SCIM token")
+	http.Redirect(w, r, paths.SCIMToken(org), http.StatusFound)
+}
@@ -0,0 +1,78 @@
+// Package secret resolves references to secrets held in external secret
+// backends, such as Vault.
+package secret
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type (
+	// Reference refers to a secret in a backend, and takes the form
+	// <backend>:<path>#<key>, e.g. vault:secret/data/foo#password.
+	Reference struct {
+		// Backend is the name of the backend holding the secret.
+		Backend string
+		// Path of the secret within the backend.
+		Path string
+		// Key identifies the value within the secret.
+		Key string
+	}
+
+	// Resolver retrieves the values of secrets from a backend.
+	Resolver interface {
+		Resolve(ctx context.Context, path, key string) (string, error)
+	}
+
+	// Registry maintains the resolvers for each backend. A nil registry has no
+	// backends.
+	Registry struct {
+		resolvers map[string]Resolver
+	}
+)
+
+func NewRegistry() *Registry {
+	return &Registry{resolvers: make(map[string]Resolver)}
+}
+
+// Register the resolver for a backend. Values prefixed with the name of the
+// backend followed by a colon are thereafter treated as references to its
+// secrets.
+func (r *Registry) Register(backend string, resolver Resolver) {
+	r.resolvers[backend] = resolver
+}
+
+// Parse parses the value as a reference to a secret in a registered backend,
+// returning false if the value is not such a reference.
+func (r *Registry) Parse(value string) (Reference, bool) {
+	if r == nil {
+		return Reference{}, false
+	}
+	backend, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return Reference{}, false
+	}
+	if _, ok := r.resolvers[backend]; !ok {
+		return Reference{}, false
+	}
+	// the key follows the last hash, permitting a hash in the path
+	i := strings.LastIndex(rest, "#")
+	if i < 1 || i == len(rest)-1 {
+		return Reference{}, false
+	}
+	return Reference{Backend: backend, Path: rest[:i], Key: rest[i+1:]}, true
+}
+
+// Resolve retrieves the value of the referenced secret.
+func (r *Registry) Resolve(ctx context.Context, ref Reference) (string, error) {
+	resolver, ok := r.resolvers[ref.Backend]
+	if !ok {
+		return "", fmt.Errorf("unknown secret backend: %s", ref.Backend)
+	}
+	return resolver.Resolve(ctx, ref.Path, ref.Key)
+}
+
+func (ref Reference) String() string {
+	return fmt.Sprintf("%s:%s#%s", ref.Backend, ref.Path, ref.Key)
+}
@@ -0,0 +1,60 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Parse(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("vault", fakeResolver{})
+
+	tests := []struct {
+		name  string
+		value string
+		want  Reference
+		ok    bool
+	}{
+		{"reference", "vault:secret/data/foo#password", Reference{Backend: "vault", Path: "secret/data/foo", Key: "password"}, true},
+		{"hash in path", "vault:secret/data/foo#bar#password", Reference{Backend: "vault", Path: "secret/data/foo#bar", Key: "password"}, true},
+		{"unregistered backend", "aws:prod/db#password", Reference{}, false},
+		{"missing key", "vault:secret/data/foo", Reference{}, false},
+		{"empty key", "vault:secret/data/foo#", Reference{}, false},
+		{"empty path", "vault:#password", Reference{}, false},
+		{"plain value", "eu-west-1", Reference{}, false},
+		{"url", "https://example.com/#anchor", Reference{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := registry.Parse(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("nil registry", func(t *testing.T) {
+		var registry *Registry
+		_, ok := registry.Parse("vault:secret/data/foo#password")
+		assert.False(t, ok)
+	})
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("vault", fakeResolver{})
+
+	ref, ok := registry.Parse("vault:secret/data/foo#password")
+	require.True(t, ok)
+	got, err := registry.Resolve(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, "secret/data/foo/password", got)
+}
+
+type fakeResolver struct{}
+
+func (fakeResolver) Resolve(_ context.Context, path, key string) (string, error) {
+	return path + "/" + key, nil
+}
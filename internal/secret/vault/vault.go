@@ -0,0 +1,259 @@
+// Package vault resolves references to secrets held in HashiCorp Vault.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	otfhttp "github.com/tofutf/tofutf/internal/http"
+	"github.com/tofutf/tofutf/internal/secret"
+)
+
+const (
+	// Backend is the name by which vault secrets are referenced, e.g.
+	// vault:secret/data/foo#password
+	Backend = "vault"
+
+	AppRoleAuthMethod    = "approle"
+	KubernetesAuthMethod = "kubernetes"
+
+	// DefaultKubernetesTokenPath is the path to the service account token
+	// mounted into a kubernetes pod.
+	DefaultKubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	clientTimeout = 30 * time.Second
+	// renew the client token this long before it expires
+	tokenExpiryMargin = 30 * time.Second
+)
+
+var (
+	ErrNotConfigured     = errors.New("vault is not configured")
+	ErrUnknownAuthMethod = errors.New("unknown vault auth method: must be approle or kubernetes")
+)
+
+type (
+	// Config configures the vault client.
+	Config struct {
+		// Address of the vault server, e.g. https://vault.example.com:8200.
+		// Vault is disabled if empty.
+		Address string
+		// Namespace is the vault enterprise namespace, if any.
+		Namespace string
+		// AuthMethod is the method with which to authenticate: approle or
+		// kubernetes.
+		AuthMethod string
+		// AuthMount is the path at which the auth method is mounted. Defaults
+		// to the name of the auth method.
+		AuthMount string
+		// RoleID and SecretID are the credentials for the approle auth method.
+		RoleID   string
+		SecretID string
+		// KubernetesRole is the role with which to authenticate using the
+		// kubernetes auth method.
+		KubernetesRole string
+		// KubernetesTokenPath is the path to the service account token with
+		// which to authenticate using the kubernetes auth method.
+		KubernetesTokenPath string
+		// Skip TLS verification when communicating with vault.
+		SkipTLSVerification bool
+	}
+
+	// Client retrieves secrets from vault, logging in with the configured auth
+	// method and logging in again once its token expires.
+	Client struct {
+		Config
+
+		client *http.Client
+
+		mu     sync.Mutex
+		token  string
+		expiry time.Time
+	}
+
+	// unconfigured is the resolver used when vault is not configured, so
+	// that referencing a vault secret is an error rather than the reference
+	// being passed on as is.
+	unconfigured struct{}
+
+	response struct {
+		Data   map[string]any `json:"data"`
+		Auth   *auth          `json:"auth"`
+		Errors []string       `json:"errors"`
+	}
+
+	auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	}
+)
+
+// NewResolver constructs a resolver for vault secrets. If vault is not
+// configured then the resolver reports as much whenever a secret is
+// referenced.
+func NewResolver(cfg Config) (secret.Resolver, error) {
+	if cfg.Address == "" {
+		return unconfigured{}, nil
+	}
+	return NewClient(cfg)
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	switch cfg.AuthMethod {
+	case AppRoleAuthMethod:
+		if cfg.RoleID == "" {
+			return nil, errors.New("vault approle auth method requires a role ID")
+		}
+	case KubernetesAuthMethod:
+		if cfg.KubernetesRole == "" {
+			return nil, errors.New("vault kubernetes auth method requires a role")
+		}
+		if cfg.KubernetesTokenPath == "" {
+			cfg.KubernetesTokenPath = DefaultKubernetesTokenPath
+		}
+	default:
+		return nil, ErrUnknownAuthMethod
+	}
+	if cfg.AuthMount == "" {
+		cfg.AuthMount = cfg.AuthMethod
+	}
+	client := &http.Client{Timeout: clientTimeout}
+	if cfg.SkipTLSVerification {
+		client.Transport = otfhttp.InsecureTransport
+	}
+	return &Client{
+		Config: cfg,
+		client: client,
+	}, nil
+}
+
+// Resolve retrieves the value of the key of the secret at the path. Both KV
+// version 1 and version 2 secrets are supported; for the latter the path
+// includes the data prefix, e.g. secret/data/foo.
+func (c *Client) Resolve(ctx context.Context, path, key string) (string, error) {
+	token, err := c.getToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.do(ctx, "GET", "/v1/"+strings.TrimPrefix(path, "/"), token, nil)
+	if err != nil {
+		return "", fmt.Errorf("reading secret: %w", err)
+	}
+	data := resp.Data
+	// a KV version 2 secret nests its values within data alongside its
+	// metadata
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secret has no key %s", key)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	// a non-string value is used in its JSON form
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// getToken returns a client token, logging in if there is no token or it is
+// about to expire.
+func (c *Client) getToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && (c.expiry.IsZero() || time.Now().Before(c.expiry)) {
+		return c.token, nil
+	}
+	body, err := c.loginBody()
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.do(ctx, "POST", fmt.Sprintf("/v1/auth/%s/login", c.AuthMount), "", body)
+	if err != nil {
+		return "", fmt.Errorf("logging in to vault with %s auth method: %w", c.AuthMethod, err)
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("logging in to vault with %s auth method: no token received", c.AuthMethod)
+	}
+	c.token = resp.Auth.ClientToken
+	c.expiry = time.Time{}
+	if lease := time.Duration(resp.Auth.LeaseDuration) * time.Second; lease > 0 {
+		c.expiry = time.Now().Add(lease - min(tokenExpiryMargin, lease/2))
+	}
+	return c.token, nil
+}
+
+func (c *Client) loginBody() (map[string]string, error) {
+	switch c.AuthMethod {
+	case AppRoleAuthMethod:
+		return map[string]string{"role_id": c.RoleID, "secret_id": c.SecretID}, nil
+	case KubernetesAuthMethod:
+		// read the token on every login because kubernetes rotates it
+		jwt, err := os.ReadFile(c.KubernetesTokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading kubernetes service account token: %w", err)
+		}
+		return map[string]string{"role": c.KubernetesRole, "jwt": strings.TrimSpace(string(jwt))}, nil
+	default:
+		return nil, ErrUnknownAuthMethod
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path, token string, body any) (*response, error) {
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.Address, "/")+path, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if c.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.Namespace)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var decoded response
+	// an error response may have an empty body
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusForbidden && token != "" {
+			// the token may have been revoked, so log in again next time
+			c.mu.Lock()
+			c.token = ""
+			c.mu.Unlock()
+		}
+		if len(decoded.Errors) > 0 {
+			return nil, fmt.Errorf("%s: %s", resp.Status, strings.Join(decoded.Errors, "; "))
+		}
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	return &decoded, nil
+}
+
+func (unconfigured) Resolve(context.Context, string, string) (string, error) {
+	return "", ErrNotConfigured
+}
@@ -0,0 +1,167 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Resolve(t *testing.T) {
+	ctx := context.Background()
+	vault := newFakeVault(t)
+
+	t.Run("approle", func(t *testing.T) {
+		client, err := NewClient(Config{
+			Address:    vault.URL,
+			AuthMethod: AppRoleAuthMethod,
+			RoleID:     "role-id",
+			SecretID:   "secret-id",
+		})
+		require.NoError(t, err)
+
+		got, err := client.Resolve(ctx, "secret/data/foo", "password")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", got)
+
+		t.Run("kv version 1", func(t *testing.T) {
+			got, err := client.Resolve(ctx, "kv/foo", "password")
+			require.NoError(t, err)
+			assert.Equal(t, "letmein", got)
+		})
+
+		t.Run("non-string value", func(t *testing.T) {
+			got, err := client.Resolve(ctx, "secret/data/foo", "ports")
+			require.NoError(t, err)
+			assert.Equal(t, "[80,443]", got)
+		})
+
+		t.Run("missing key", func(t *testing.T) {
+			_, err := client.Resolve(ctx, "secret/data/foo", "nope")
+			assert.EqualError(t, err, "secret has no key nope")
+		})
+
+		t.Run("missing secret", func(t *testing.T) {
+			_, err := client.Resolve(ctx, "secret/data/nope", "password")
+			assert.EqualError(t, err, "reading secret: unexpected response: 404 Not Found")
+		})
+
+		t.Run("permission denied logs in again", func(t *testing.T) {
+			vault.logins = 0
+			_, err := client.Resolve(ctx, "secret/data/forbidden", "password")
+			assert.EqualError(t, err, "reading secret: 403 Forbidden: permission denied")
+
+			_, err = client.Resolve(ctx, "secret/data/foo", "password")
+			require.NoError(t, err)
+			assert.Equal(t, 1, vault.logins)
+		})
+	})
+
+	t.Run("kubernetes", func(t *testing.T) {
+		tokenPath := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenPath, []byte("service-account-jwt\n"), 0o600))
+		client, err := NewClient(Config{
+			Address:             vault.URL,
+			AuthMethod:          KubernetesAuthMethod,
+			KubernetesRole:      "tofutf",
+			KubernetesTokenPath: tokenPath,
+		})
+		require.NoError(t, err)
+
+		got, err := client.Resolve(ctx, "secret/data/foo", "password")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", got)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		client, err := NewClient(Config{
+			Address:    vault.URL,
+			AuthMethod: AppRoleAuthMethod,
+			RoleID:     "role-id",
+			SecretID:   "wrong",
+		})
+		require.NoError(t, err)
+
+		_, err = client.Resolve(ctx, "secret/data/foo", "password")
+		assert.EqualError(t, err, "logging in to vault with approle auth method: 400 Bad Request: invalid role or secret ID")
+	})
+}
+
+func TestNewResolver(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		resolver, err := NewResolver(Config{})
+		require.NoError(t, err)
+
+		_, err = resolver.Resolve(context.Background(), "secret/data/foo", "password")
+		assert.ErrorIs(t, err, ErrNotConfigured)
+	})
+
+	t.Run("unknown auth method", func(t *testing.T) {
+		_, err := NewResolver(Config{Address: "https://vault.example.com", AuthMethod: "ldap"})
+		assert.ErrorIs(t, err, ErrUnknownAuthMethod)
+	})
+}
+
+type fakeVault struct {
+	*httptest.Server
+
+	logins int
+}
+
+// newFakeVault starts a server mimicking the vault API, with approle and
+// kubernetes auth methods and both KV secrets engines.
+func newFakeVault(t *testing.T) *fakeVault {
+	const token = "vault-token"
+	vault := &fakeVault{}
+	mux := http.NewServeMux()
+	login := func(w http.ResponseWriter, r *http.Request, valid func(map[string]string) bool) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if !valid(body) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+			return
+		}
+		vault.logins++
+		w.Write([]byte(`{"auth":{"client_token":"` + token + `","lease_duration":3600}}`))
+	}
+	mux.HandleFunc("/v1/auth/approle/login", func(w http.ResponseWriter, r *http.Request) {
+		login(w, r, func(body map[string]string) bool {
+			return body["role_id"] == "role-id" && body["secret_id"] == "secret-id"
+		})
+	})
+	mux.HandleFunc("/v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+		login(w, r, func(body map[string]string) bool {
+			return body["role"] == "tofutf" && body["jwt"] == "service-account-jwt"
+		})
+	})
+	secret := func(path, body string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != token {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			w.Write([]byte(body))
+		})
+	}
+	secret("/v1/secret/data/foo", `{"data":{"data":{"password":"hunter2","ports":[80,443]},"metadata":{"version":1}}}`)
+	secret("/v1/kv/foo", `{"data":{"password":"letmein"}}`)
+	mux.HandleFunc("/v1/secret/data/nope", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[]}`))
+	})
+	mux.HandleFunc("/v1/secret/data/forbidden", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errors":["permission denied"]}`))
+	})
+	vault.Server = httptest.NewServer(mux)
+	t.Cleanup(vault.Close)
+	return vault
+}
@@ -0,0 +1,102 @@
+package semver
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// IsConstraint determines whether s is a valid version constraint, using the
+// same syntax as terraform's required_version, e.g. "~> 1.6" or
+// ">= 1.5, < 1.7". Unlike terraform, every constraint must include an
+// operator, in order that a constraint is not mistaken for a malformed
+// version, e.g. "1,2,0".
+func IsConstraint(s string) bool {
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" || !strings.ContainsAny(part[:1], "~<>=!") {
+			return false
+		}
+	}
+	_, err := version.NewConstraint(s)
+	return err == nil
+}
+
+// Latest returns the newest version in versions satisfying the constraint. If
+// no version satisfies the constraint then false is returned. Pre-release
+// versions only satisfy a constraint that explicitly references a
+// pre-release.
+func Latest(constraint string, versions []string) (string, bool) {
+	c, err := version.NewConstraint(constraint)
+	if err != nil {
+		return "", false
+	}
+	var latest *version.Version
+	for _, s := range versions {
+		v, err := version.NewVersion(s)
+		if err != nil {
+			continue
+		}
+		if !c.Check(v) {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+		}
+	}
+	if latest == nil {
+		return "", false
+	}
+	return latest.Original(), true
+}
+
+// Satisfies determines whether version v satisfies the constraint. False is
+// returned if either the constraint or the version is invalid.
+func Satisfies(constraint, v string) bool {
+	c, err := version.NewConstraint(constraint)
+	if err != nil {
+		return false
+	}
+	parsed, err := version.NewVersion(v)
+	if err != nil {
+		return false
+	}
+	return c.Check(parsed)
+}
+
+// AllowsBelow determines whether the constraint is satisfied by versions
+// below version v, i.e. whether the constraint lacks a lower bound, or has a
+// lower bound below v. False is returned if either the constraint or the
+// version is invalid.
+func AllowsBelow(constraint, v string) bool {
+	if !IsConstraint(constraint) {
+		return false
+	}
+	floor, err := version.NewVersion(v)
+	if err != nil {
+		return false
+	}
+	var lower *version.Version
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+		// only these operators bound a constraint from below
+		op, ok := "", false
+		for _, prefix := range []string{">=", "~>", ">", "="} {
+			if strings.HasPrefix(part, prefix) {
+				op, ok = prefix, true
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		bound, err := version.NewVersion(strings.TrimSpace(strings.TrimPrefix(part, op)))
+		if err != nil {
+			return false
+		}
+		if lower == nil || bound.GreaterThan(lower) {
+			lower = bound
+		}
+	}
+	return lower == nil || lower.LessThan(floor)
+}
@@ -0,0 +1,99 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       bool
+	}{
+		{"~> 1.6", true},
+		{">= 1.5, < 1.7", true},
+		{"= 1.6.0", true},
+		{"1.6.0", false},
+		{"1,2,0", false},
+		{"~> ", false},
+		{"", false},
+		{"latest", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsConstraint(tt.constraint))
+		})
+	}
+}
+
+func TestLatest(t *testing.T) {
+	versions := []string{"1.5.7", "1.6.0", "1.6.6", "1.7.0-beta1", "1.7.5", "2.0.0", "garbage"}
+
+	tests := []struct {
+		name       string
+		constraint string
+		want       string
+		found      bool
+	}{
+		{"pessimistic minor", "~> 1.6", "1.7.5", true},
+		{"pessimistic patch", "~> 1.6.0", "1.6.6", true},
+		{"range", ">= 1.5, < 1.7", "1.6.6", true},
+		{"exact", "= 1.5.7", "1.5.7", true},
+		{"excludes pre-release", "< 1.7.5, > 1.6.6", "", false},
+		{"explicit pre-release", "= 1.7.0-beta1", "1.7.0-beta1", true},
+		{"no match", "~> 3.0", "", false},
+		{"invalid constraint", "~>", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := Latest(tt.constraint, versions)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		version    string
+		want       bool
+	}{
+		{"in range", ">= 1.5, < 1.7", "1.6.0", true},
+		{"below range", ">= 1.5, < 1.7", "1.4.9", false},
+		{"above range", ">= 1.5, < 1.7", "1.7.0", false},
+		{"invalid constraint", "garbage", "1.6.0", false},
+		{"invalid version", ">= 1.5", "garbage", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Satisfies(tt.constraint, tt.version))
+		})
+	}
+}
+
+func TestAllowsBelow(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		want       bool
+	}{
+		{"pessimistic above minimum", "~> 1.6", false},
+		{"pessimistic at minimum", "~> 1.2.0", false},
+		{"pessimistic below minimum", "~> 0.12", true},
+		{"range above minimum", ">= 1.5, < 1.7", false},
+		{"range straddling minimum", ">= 1.0, < 1.7", true},
+		{"exclusive lower bound at minimum", "> 1.2.0", false},
+		{"no lower bound", "< 1.7", true},
+		{"exact below minimum", "= 1.1.0", true},
+		{"highest lower bound wins", ">= 1.0, >= 1.3", false},
+		{"invalid constraint", "garbage", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AllowsBelow(tt.constraint, "1.2.0"))
+		})
+	}
+}
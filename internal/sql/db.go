@@ -87,7 +87,7 @@ func New(ctx context.Context, opts Options) (*Pool, error) {
 
 	// goose gets upset with max_pool_conns parameter so pass it the unaltered
 	// connection string
-	if err := migrate(opts.Logger, opts.ConnString); err != nil {
+	if err := migrate(ctx, opts.Logger, opts.ConnString); err != nil {
 		return nil, err
 	}
 
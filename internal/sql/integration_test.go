@@ -277,3 +277,100 @@ func TestPool(t *testing.T) {
 		}
 	})
 }
+
+// BenchmarkReconcile compares adding and then removing 1,000 team members one
+// statement at a time with doing so in batches.
+func BenchmarkReconcile(b *testing.B) {
+	_, pool := sql.NewTestContainerPool(b)
+	ctx := context.Background()
+
+	const teamID = "team-123"
+	usernames := make([]string, 1000)
+	err := pool.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		now := sql.Timestamptz(internal.CurrentTimestamp(nil))
+		_, err := q.InsertOrganization(ctx, pggen.InsertOrganizationParams{
+			ID:                         sql.String("org-123"),
+			CreatedAt:                  now,
+			UpdatedAt:                  now,
+			Name:                       sql.String("acmeco"),
+			CostEstimationEnabled:      sql.Bool(false),
+			AllowForceDeleteWorkspaces: sql.Bool(false),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = q.InsertTeam(ctx, pggen.InsertTeamParams{
+			ID:                              sql.String(teamID),
+			Name:                            sql.String("devs"),
+			CreatedAt:                       now,
+			OrganizationName:                sql.String("acmeco"),
+			Visibility:                      sql.String("secret"),
+			PermissionManageWorkspaces:      sql.Bool(false),
+			PermissionManageVCS:             sql.Bool(false),
+			PermissionManageModules:         sql.Bool(false),
+			PermissionManageProviders:       sql.Bool(false),
+			PermissionManagePolicies:        sql.Bool(false),
+			PermissionManagePolicyOverrides: sql.Bool(false),
+		})
+		if err != nil {
+			return err
+		}
+		for i := range usernames {
+			usernames[i] = fmt.Sprintf("user-%d", i)
+			_, err := q.InsertUser(ctx, pggen.InsertUserParams{
+				ID:        sql.String(fmt.Sprintf("user-id-%d", i)),
+				Username:  sql.String(usernames[i]),
+				CreatedAt: now,
+				UpdatedAt: now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(b, err)
+
+	b.Run("one statement per member", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			err := pool.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+				for _, username := range usernames {
+					if _, err := q.InsertTeamMembership(ctx, []string{username}, sql.String(teamID)); err != nil {
+						return err
+					}
+				}
+				for _, username := range usernames {
+					if _, err := q.DeleteTeamMembership(ctx, []string{username}, sql.String(teamID)); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("batched", func(b *testing.B) {
+		insert := func(q pggen.Querier) func(context.Context, []string) error {
+			return func(ctx context.Context, members []string) error {
+				_, err := q.InsertTeamMembership(ctx, members, sql.String(teamID))
+				return err
+			}
+		}
+		remove := func(q pggen.Querier) func(context.Context, []string) error {
+			return func(ctx context.Context, members []string) error {
+				_, err := q.DeleteTeamMembership(ctx, members, sql.String(teamID))
+				return err
+			}
+		}
+		for n := 0; n < b.N; n++ {
+			err := pool.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+				if err := sql.Reconcile(ctx, nil, usernames, insert(q), remove(q)); err != nil {
+					return err
+				}
+				return sql.Reconcile(ctx, usernames, nil, insert(q), remove(q))
+			})
+			require.NoError(b, err)
+		}
+	})
+}
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"log/slog"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -36,14 +38,22 @@ type (
 		channel     string        // postgres notification channel name
 		pool        pool          // pool from which to acquire a dedicated connection to postgres
 		islistening chan struct{} // semaphore that's closed once broker is listening
+		once        sync.Once     // close semaphore only once
+		connected   bool          // whether listener has ever connected
 
-		mu         sync.Mutex             // sync access to maps
-		forwarders map[string]ForwardFunc // maps table name to getter
+		mu           sync.Mutex             // sync access to maps
+		forwarders   map[string]ForwardFunc // maps table name to getter
+		reconnectors []ReconnectFunc
 	}
 
 	// ForwardFunc handles forwarding the id and action onto subscribers.
 	ForwardFunc func(ctx context.Context, id string, action Action)
 
+	// ReconnectFunc is called once the listener has reconnected to postgres.
+	// Any events that occurred whilst the listener was disconnected have been
+	// missed, so state derived from events should be reloaded.
+	ReconnectFunc func(ctx context.Context)
+
 	// database connection pool
 	pool interface {
 		Acquire(ctx context.Context) (*pgxpool.Conn, error)
@@ -70,33 +80,81 @@ func (b *Listener) RegisterFunc(table string, getter ForwardFunc) {
 	b.forwarders[table] = getter
 }
 
+// RegisterReconnectFunc registers a function to be called whenever the
+// listener reconnects to postgres after losing its connection.
+func (b *Listener) RegisterReconnectFunc(fn ReconnectFunc) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.reconnectors = append(b.reconnectors, fn)
+}
+
 // Start the pubsub daemon; listen to notifications from postgres and forward to
 // local pubsub broker. The listening channel is closed once the broker has
 // started listening; from this point onwards published messages will be
 // forwarded.
+//
+// If the connection to postgres is lost then the listener reconnects, backing
+// off between attempts, and upon reconnecting calls the registered reconnect
+// funcs.
 func (b *Listener) Start(ctx context.Context) error {
-	conn, err := b.pool.Acquire(ctx)
+	policy := backoff.NewExponentialBackOff()
+	// keep trying to reconnect indefinitely
+	policy.MaxElapsedTime = 0
+
+	for {
+		err := b.listen(ctx, func() {
+			policy.Reset()
+			if b.connected {
+				b.logger.Info("reconnected to postgres")
+				b.reconnected(ctx)
+			}
+			b.connected = true
+		})
+		if ctx.Err() != nil {
+			// parent has decided to shutdown so exit without error
+			return nil
+		}
+		wait := policy.NextBackOff()
+		b.logger.Error("lost connection to postgres; reconnecting", "backoff", wait, "err", err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// listen acquires a connection, listens on it for notifications, and forwards
+// them until an error occurs. The listening func is called once the listener
+// is listening.
+func (b *Listener) listen(ctx context.Context, listening func()) error {
+	pooled, err := b.pool.Acquire(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to acquire postgres connection: %w", err)
 	}
-	defer conn.Release()
+	// the connection is dedicated to listening and may be broken, so it is
+	// closed rather than returned to the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background()) //nolint:errcheck
 
 	if _, err := conn.Exec(ctx, "listen "+b.channel); err != nil {
 		return err
 	}
 	b.logger.Debug("listening for events")
-	close(b.islistening) // close semaphore to indicate broker is now listening
+	// close semaphore to indicate broker is now listening
+	b.once.Do(func() { close(b.islistening) })
+	listening()
 
 	for {
-		notification, err := conn.Conn().WaitForNotification(ctx)
+		notification, err := conn.WaitForNotification(ctx)
 		if err != nil {
 			select {
 			case <-ctx.Done():
 				// parent has decided to shutdown so exit without error
 				return nil
 			default:
-				b.logger.Error("waiting for postgres notification", "err", err)
-				return err
+				return fmt.Errorf("waiting for postgres notification: %w", err)
 			}
 		}
 		var pge event
@@ -104,7 +162,9 @@ func (b *Listener) Start(ctx context.Context) error {
 			b.logger.Error("unmarshaling postgres notification", "err", err)
 			continue
 		}
+		b.mu.Lock()
 		forwarder, ok := b.forwarders[string(pge.Table)]
+		b.mu.Unlock()
 		if !ok {
 			b.logger.Error("no getter found for table", "table", pge.Table)
 			continue
@@ -113,6 +173,18 @@ func (b *Listener) Start(ctx context.Context) error {
 	}
 }
 
+func (b *Listener) reconnected(ctx context.Context) {
+	listenerReconnects.Inc()
+
+	b.mu.Lock()
+	reconnectors := append([]ReconnectFunc(nil), b.reconnectors...)
+	b.mu.Unlock()
+
+	for _, fn := range reconnectors {
+		fn(ctx)
+	}
+}
+
 func (b *Listener) Started() <-chan struct{} {
 	return b.islistening
 }
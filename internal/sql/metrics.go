@@ -0,0 +1,14 @@
+package sql
+
+import "github.com/prometheus/client_golang/prometheus"
+
+func init() {
+	prometheus.MustRegister(listenerReconnects)
+}
+
+var listenerReconnects = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "otf",
+	Subsystem: "listener",
+	Name:      "reconnects_total",
+	Help:      "Total number of times the database events listener has reconnected to postgres.",
+})
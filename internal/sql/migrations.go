@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"log/slog"
@@ -11,6 +12,10 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
+// migrationLockID guarantees only one instance on a cluster migrates the
+// database at any one time.
+const migrationLockID int64 = 5577006791947779416
+
 var (
 	mu sync.Mutex
 
@@ -18,7 +23,7 @@ var (
 	migrations embed.FS
 )
 
-func migrate(logger *slog.Logger, connStr string) error {
+func migrate(ctx context.Context, logger *slog.Logger, connStr string) error {
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -41,7 +46,25 @@ func migrate(logger *slog.Logger, connStr string) error {
 		return fmt.Errorf("setting postgres dialect for migrations: %w", err)
 	}
 
-	if err := goose.Up(db, "migrations"); err != nil {
+	// Hold a session-level advisory lock for the duration of the migration, so
+	// that replicas starting in parallel don't race one another. The lock must
+	// be released on the same connection on which it was obtained.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring connection for migration lock: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("obtaining migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+			logger.Error("releasing migration lock", "err", err)
+		}
+	}()
+
+	if err := goose.UpContext(ctx, db, "migrations"); err != nil {
 		return fmt.Errorf("unable to migrate database: %w", err)
 	}
 
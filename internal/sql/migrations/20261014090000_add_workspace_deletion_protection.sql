@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN deletion_protected BOOL NOT NULL DEFAULT false;
+ALTER TABLE organizations ADD COLUMN default_deletion_protection BOOL NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE organizations DROP COLUMN default_deletion_protection;
+ALTER TABLE workspaces DROP COLUMN deletion_protected;
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE jobs ADD COLUMN agent_pool_id TEXT REFERENCES agent_pools ON UPDATE CASCADE ON DELETE SET NULL;
+
+UPDATE jobs j
+SET agent_pool_id = w.agent_pool_id
+FROM runs r
+JOIN workspaces w USING (workspace_id)
+WHERE j.run_id = r.run_id;
+
+-- +goose Down
+ALTER TABLE jobs DROP COLUMN agent_pool_id;
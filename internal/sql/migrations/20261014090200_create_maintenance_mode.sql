@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- maintenance_mode holds at most one row, the site-wide maintenance mode.
+CREATE TABLE IF NOT EXISTS maintenance_mode (
+    maintenance_mode_id TEXT,
+    enabled             BOOLEAN NOT NULL,
+    message             TEXT NOT NULL,
+    starts_at           TIMESTAMPTZ,
+    ends_at             TIMESTAMPTZ,
+    updated_at          TIMESTAMPTZ NOT NULL,
+    updated_by          TEXT NOT NULL,
+                        PRIMARY KEY (maintenance_mode_id),
+                        CHECK (maintenance_mode_id = 'site'),
+                        CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE OR REPLACE FUNCTION maintenance_mode_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.maintenance_mode_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_event
+AFTER INSERT OR UPDATE OR DELETE ON maintenance_mode
+    FOR EACH ROW EXECUTE PROCEDURE maintenance_mode_notify_event();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS notify_event ON maintenance_mode;
+DROP FUNCTION IF EXISTS maintenance_mode_notify_event;
+DROP TABLE IF EXISTS maintenance_mode;
+
+-- +goose StatementEnd
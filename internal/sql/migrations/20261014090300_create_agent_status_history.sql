@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS agent_status_history (
+    agent_status_history_id BIGSERIAL PRIMARY KEY,
+    agent_id TEXT REFERENCES agents ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    status TEXT NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS agent_status_history_agent_id_idx ON agent_status_history (agent_id, agent_status_history_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS agent_status_history;
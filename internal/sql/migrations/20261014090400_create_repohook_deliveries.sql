@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS repohook_deliveries (
+    repohook_delivery_id TEXT PRIMARY KEY,
+    repohook_id UUID REFERENCES repohooks ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL,
+    headers BYTEA NOT NULL,
+    body BYTEA,
+    status TEXT NOT NULL,
+    error TEXT,
+    replay_of TEXT REFERENCES repohook_deliveries ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS repohook_deliveries_repohook_id_idx ON repohook_deliveries (repohook_id, received_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS repohook_deliveries;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN log_scrubbing_disabled BOOL NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN log_scrubbing_disabled;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS log_buffers (
+    run_id TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    phase TEXT REFERENCES phases ON UPDATE CASCADE NOT NULL,
+    data BYTEA NOT NULL,
+    input_offset INTEGER NOT NULL,
+    output_offset INTEGER NOT NULL,
+    PRIMARY KEY (run_id, phase)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS log_buffers;
@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN vcs_skip_drafts BOOL NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS organization_vcs_defaults (
+    organization_name   TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    trigger_prefixes    TEXT[],
+    trigger_patterns    TEXT[],
+    vcs_tags_regex      TEXT,
+    speculative_enabled BOOL,
+    vcs_skip_drafts     BOOL,
+    PRIMARY KEY (organization_name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS organization_vcs_defaults;
+ALTER TABLE workspaces DROP COLUMN vcs_skip_drafts;
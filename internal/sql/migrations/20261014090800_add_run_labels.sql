@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_labels (
+    run_id TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (run_id, key)
+);
+
+-- support looking up runs by label
+CREATE INDEX IF NOT EXISTS run_labels_key_value_idx ON run_labels (key, value);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_labels;
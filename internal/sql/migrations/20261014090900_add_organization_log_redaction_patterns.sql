@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE organizations ADD COLUMN log_redaction_patterns TEXT[];
+
+-- +goose Down
+ALTER TABLE organizations DROP COLUMN log_redaction_patterns;
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS run_comments (
+    comment_id TEXT,
+    run_id     TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    body       TEXT NOT NULL,
+    author     TEXT NOT NULL,
+    mentions   TEXT[],
+    created_at TIMESTAMPTZ NOT NULL,
+               PRIMARY KEY (comment_id)
+);
+
+CREATE INDEX IF NOT EXISTS run_comments_run_id_idx ON run_comments (run_id);
+
+CREATE OR REPLACE FUNCTION run_comments_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.comment_id);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_event
+AFTER INSERT OR UPDATE OR DELETE ON run_comments
+    FOR EACH ROW EXECUTE PROCEDURE run_comments_notify_event();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS notify_event ON run_comments;
+DROP FUNCTION IF EXISTS run_comments_notify_event;
+DROP TABLE IF EXISTS run_comments;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- allocator_status holds at most one row, the status recorded by the job
+-- allocator at the end of its most recent allocation pass.
+CREATE TABLE IF NOT EXISTS allocator_status (
+    allocator_status_id TEXT,
+    last_allocated_at   TIMESTAMPTZ NOT NULL,
+    status              JSONB NOT NULL,
+                        PRIMARY KEY (allocator_status_id),
+                        CHECK (allocator_status_id = 'allocator')
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS allocator_status;
+
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE organizations ADD COLUMN queue_time_sla INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE jobs ALTER COLUMN created_at DROP DEFAULT;
+ALTER TABLE jobs ADD COLUMN allocated_at TIMESTAMPTZ;
+ALTER TABLE jobs ADD COLUMN sla_breached_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE jobs DROP COLUMN sla_breached_at;
+ALTER TABLE jobs DROP COLUMN allocated_at;
+ALTER TABLE jobs DROP COLUMN created_at;
+ALTER TABLE organizations DROP COLUMN queue_time_sla;
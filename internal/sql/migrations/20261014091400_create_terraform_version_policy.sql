@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- terraform_version_policy holds at most one row, the site-wide policy
+-- governing which terraform versions workspaces may use. Empty versions are
+-- unset.
+CREATE TABLE IF NOT EXISTS terraform_version_policy (
+    terraform_version_policy_id TEXT,
+    min_version                 TEXT NOT NULL,
+    max_version                 TEXT NOT NULL,
+    default_version             TEXT NOT NULL,
+    updated_at                  TIMESTAMPTZ NOT NULL,
+    updated_by                  TEXT NOT NULL,
+                                PRIMARY KEY (terraform_version_policy_id),
+                                CHECK (terraform_version_policy_id = 'site')
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS terraform_version_policy;
+
+-- +goose StatementEnd
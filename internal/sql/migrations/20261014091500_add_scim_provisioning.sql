@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- email is a verified email address, either provided by an identity provider
+-- when provisioning the user via SCIM or verified by the OIDC provider upon
+-- login; external_id is the identifier assigned to the user by the identity
+-- provider provisioning the user via SCIM.
+ALTER TABLE users
+    ADD COLUMN email TEXT,
+    ADD COLUMN external_id TEXT,
+    ADD COLUMN deactivated BOOLEAN NOT NULL DEFAULT false,
+    ADD CONSTRAINT users_email_uniq UNIQUE (email),
+    ADD CONSTRAINT users_external_id_uniq UNIQUE (external_id);
+
+-- scim_tokens holds at most one token per organization, which authenticates
+-- an identity provider provisioning users and teams via SCIM.
+CREATE TABLE IF NOT EXISTS scim_tokens (
+    scim_token_id     TEXT,
+    created_at        TIMESTAMPTZ NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+                      PRIMARY KEY (scim_token_id),
+                      UNIQUE (organization_name)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS scim_tokens;
+ALTER TABLE users
+    DROP COLUMN email,
+    DROP COLUMN external_id,
+    DROP COLUMN deactivated;
+
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- module_templates marks a version of a registry module as a template from
+-- which workspaces can be provisioned; variables is the schema of the values
+-- collected when provisioning a workspace, and outputs are the module outputs
+-- exposed by the generated configuration.
+CREATE TABLE IF NOT EXISTS module_templates (
+    module_template_id TEXT,
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL,
+    module_id          TEXT REFERENCES modules ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    module_version_id  TEXT REFERENCES module_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    variables          JSONB NOT NULL,
+    outputs            JSONB NOT NULL,
+                       PRIMARY KEY (module_template_id),
+                       UNIQUE (module_id)
+);
+
+-- module_template_workspaces records the workspaces provisioned from a
+-- template, along with the module version and variable values with which
+-- their configuration was last generated.
+CREATE TABLE IF NOT EXISTS module_template_workspaces (
+    workspace_id       TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    module_template_id TEXT REFERENCES module_templates ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    version            TEXT NOT NULL,
+    variables          JSONB NOT NULL,
+                       PRIMARY KEY (workspace_id)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS module_template_workspaces;
+DROP TABLE IF EXISTS module_templates;
+
+-- +goose StatementEnd
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN apply_windows JSONB;
+INSERT INTO run_statuses (status) VALUES ('awaiting_window');
+
+-- +goose Down
+DELETE FROM run_statuses WHERE status = 'awaiting_window';
+ALTER TABLE workspaces DROP COLUMN apply_windows;
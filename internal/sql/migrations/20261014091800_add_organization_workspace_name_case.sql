@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE organizations ADD COLUMN workspace_name_case TEXT NOT NULL DEFAULT 'sensitive';
+CREATE INDEX IF NOT EXISTS workspaces_lower_name_idx ON workspaces (organization_name, lower(name));
+
+-- +goose Down
+DROP INDEX IF EXISTS workspaces_lower_name_idx;
+ALTER TABLE organizations DROP COLUMN workspace_name_case;
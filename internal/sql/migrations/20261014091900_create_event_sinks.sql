@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- event_sink_outbox holds events awaiting delivery to an event sink. Each event
+-- is recorded once for each sink and deleted once delivered to that sink. The
+-- payload is JSON rather than JSONB in order to preserve the encoding.
+CREATE TABLE IF NOT EXISTS event_sink_outbox (
+    event_sink_outbox_id BIGSERIAL,
+    sink                 TEXT NOT NULL,
+    payload              JSON NOT NULL,
+    created_at           TIMESTAMPTZ NOT NULL,
+                         PRIMARY KEY (event_sink_outbox_id)
+);
+
+CREATE INDEX IF NOT EXISTS event_sink_outbox_sink_idx ON event_sink_outbox (sink, event_sink_outbox_id);
+
+-- event_sinks records the delivery status of each event sink.
+CREATE TABLE IF NOT EXISTS event_sinks (
+    name                 TEXT,
+    delivered            BIGINT NOT NULL DEFAULT 0,
+    failures             BIGINT NOT NULL DEFAULT 0,
+    last_delivered_at    TIMESTAMPTZ,
+    last_failed_at       TIMESTAMPTZ,
+    last_error           TEXT,
+                         PRIMARY KEY (name)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS event_sinks;
+DROP TABLE IF EXISTS event_sink_outbox;
+
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE agent_tokens
+    ADD COLUMN expires_at TIMESTAMPTZ,
+    ADD COLUMN last_used_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE agent_tokens
+    DROP COLUMN last_used_at,
+    DROP COLUMN expires_at;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE jobs ADD COLUMN trace_context TEXT;
+
+-- +goose Down
+ALTER TABLE jobs DROP COLUMN trace_context;
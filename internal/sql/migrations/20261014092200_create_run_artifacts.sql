@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS run_artifacts (
+    run_artifact_id TEXT,
+    run_id          TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name            TEXT NOT NULL,
+    content_type    TEXT NOT NULL,
+    size            INTEGER NOT NULL,
+    data            BYTEA NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+                    PRIMARY KEY (run_artifact_id),
+                    UNIQUE (run_id, name)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS run_artifacts;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE log_buffers ADD COLUMN truncated BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE log_buffers DROP COLUMN truncated;
@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS agent_pool_autoscalers (
+    agent_pool_id      TEXT REFERENCES agent_pools ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    webhook_url        TEXT NOT NULL,
+    hmac_secret        TEXT NOT NULL,
+    scale_up_threshold INTEGER NOT NULL,
+    scale_down_idle    INTEGER NOT NULL,
+    cooldown           INTEGER NOT NULL,
+    dry_run            BOOLEAN NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL,
+                       PRIMARY KEY (agent_pool_id)
+);
+
+CREATE TABLE IF NOT EXISTS agent_pool_scaling_decisions (
+    scaling_decision_id SERIAL,
+    agent_pool_id       TEXT REFERENCES agent_pools ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    current_agents      INTEGER NOT NULL,
+    unallocated_jobs    INTEGER NOT NULL,
+    idle_agents         INTEGER NOT NULL,
+    desired_delta       INTEGER NOT NULL,
+    dry_run             BOOLEAN NOT NULL,
+    error               TEXT,
+    decided_at          TIMESTAMPTZ NOT NULL,
+                        PRIMARY KEY (scaling_decision_id)
+);
+
+CREATE INDEX IF NOT EXISTS agent_pool_scaling_decisions_agent_pool_id_idx ON agent_pool_scaling_decisions (agent_pool_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS agent_pool_scaling_decisions;
+DROP TABLE IF EXISTS agent_pool_autoscalers;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN lock_acquired_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN lock_acquired_at;
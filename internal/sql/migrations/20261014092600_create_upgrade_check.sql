@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS upgrade_check (
+    upgrade_check_id TEXT NOT NULL,
+    latest_version   TEXT NOT NULL,
+    changelog_url    TEXT NOT NULL,
+    checked_at       TIMESTAMPTZ NOT NULL,
+                     PRIMARY KEY (upgrade_check_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS upgrade_check;
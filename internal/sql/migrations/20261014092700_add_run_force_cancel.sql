@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE runs
+    ADD COLUMN force_cancel_available_at TIMESTAMPTZ,
+    ADD COLUMN force_canceled_by TEXT,
+    ADD COLUMN force_cancel_reason TEXT;
+
+UPDATE runs
+SET force_cancel_available_at = cancel_signaled_at + interval '10 seconds'
+WHERE cancel_signaled_at IS NOT NULL;
+
+-- +goose Down
+ALTER TABLE runs
+    DROP COLUMN force_cancel_available_at,
+    DROP COLUMN force_canceled_by,
+    DROP COLUMN force_cancel_reason;
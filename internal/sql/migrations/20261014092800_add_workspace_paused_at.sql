@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN paused_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE workspaces DROP COLUMN paused_at;
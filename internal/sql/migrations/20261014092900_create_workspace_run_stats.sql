@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workspace_run_stats (
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    day DATE NOT NULL,
+    runs_created INTEGER DEFAULT 0 NOT NULL,
+    runs_applied INTEGER DEFAULT 0 NOT NULL,
+    runs_errored INTEGER DEFAULT 0 NOT NULL,
+    runs_canceled INTEGER DEFAULT 0 NOT NULL,
+    plan_count INTEGER DEFAULT 0 NOT NULL,
+    plan_duration_ms BIGINT DEFAULT 0 NOT NULL,
+    apply_count INTEGER DEFAULT 0 NOT NULL,
+    apply_duration_ms BIGINT DEFAULT 0 NOT NULL,
+    PRIMARY KEY (workspace_id, day)
+);
+
+-- records which contributions to workspace_run_stats have been made, keyed by
+-- run and the status the run was in, so that contributions are only made once.
+CREATE TABLE IF NOT EXISTS workspace_run_stats_entries (
+    run_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    PRIMARY KEY (run_id, status)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_run_stats_entries;
+DROP TABLE IF EXISTS workspace_run_stats;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS workspace_output_mappings (
+    output_mapping_id TEXT PRIMARY KEY,
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    key TEXT NOT NULL,
+    -- deliberately not a foreign key: deleting the source workspace leaves the
+    -- mapping in place so that runs fail with an explanation rather than
+    -- silently lacking the variable.
+    source_workspace_id TEXT NOT NULL,
+    source_output TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (workspace_id, key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_output_mappings;
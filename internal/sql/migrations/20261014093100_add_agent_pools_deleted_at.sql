@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE agent_pools ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- the name of a deleted pool is only reserved if it is recovered, so
+-- uniqueness only applies to pools that have not been deleted.
+ALTER TABLE agent_pools DROP CONSTRAINT agent_pools_organization_name_name_key;
+CREATE UNIQUE INDEX agent_pools_organization_name_name_key
+    ON agent_pools (organization_name, name)
+    WHERE deleted_at IS NULL;
+
+-- +goose Down
+DELETE FROM agent_pools WHERE deleted_at IS NOT NULL;
+DROP INDEX agent_pools_organization_name_name_key;
+ALTER TABLE agent_pools ADD CONSTRAINT agent_pools_organization_name_name_key UNIQUE (organization_name, name);
+ALTER TABLE agent_pools DROP COLUMN deleted_at;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- the logs for a phase are written to one or more streams: the human-readable
+-- stream is identified by an empty string.
+ALTER TABLE logs ADD COLUMN stream TEXT NOT NULL DEFAULT '';
+ALTER TABLE log_buffers ADD COLUMN stream TEXT NOT NULL DEFAULT '';
+ALTER TABLE log_buffers DROP CONSTRAINT log_buffers_pkey;
+ALTER TABLE log_buffers ADD PRIMARY KEY (run_id, phase, stream);
+
+ALTER TABLE plans ADD COLUMN plan_redacted_json BYTEA;
+
+-- +goose Down
+ALTER TABLE plans DROP COLUMN plan_redacted_json;
+
+DELETE FROM log_buffers WHERE stream <> '';
+ALTER TABLE log_buffers DROP CONSTRAINT log_buffers_pkey;
+ALTER TABLE log_buffers ADD PRIMARY KEY (run_id, phase);
+ALTER TABLE log_buffers DROP COLUMN stream;
+DELETE FROM logs WHERE stream <> '';
+ALTER TABLE logs DROP COLUMN stream;
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS agent_bootstrap_tokens (
+    agent_bootstrap_token_id TEXT NOT NULL,
+    created_at               TIMESTAMPTZ NOT NULL,
+    description              TEXT NOT NULL,
+    agent_pool_id            TEXT REFERENCES agent_pools ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    expires_at               TIMESTAMPTZ NOT NULL,
+    -- max_uses is null if the token can be used any number of times before it
+    -- expires.
+    max_uses                 INT,
+    uses                     INT NOT NULL DEFAULT 0,
+                             PRIMARY KEY (agent_bootstrap_token_id)
+);
+
+-- agent_credentials are the tokens issued to agents that registered using a
+-- bootstrap token. Only a hash of each token is retained.
+CREATE TABLE IF NOT EXISTS agent_credentials (
+    agent_id                 TEXT REFERENCES agents ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    agent_bootstrap_token_id TEXT NOT NULL,
+    token_hash               TEXT NOT NULL,
+    created_at               TIMESTAMPTZ NOT NULL,
+                             PRIMARY KEY (agent_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS agent_credentials;
+DROP TABLE IF EXISTS agent_bootstrap_tokens;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE agent_pools ADD COLUMN allowed_terraform_versions TEXT;
+
+-- +goose Down
+ALTER TABLE agent_pools DROP COLUMN allowed_terraform_versions;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN preflight_checks TEXT[];
+ALTER TABLE runs ADD COLUMN skip_preflight BOOL DEFAULT false NOT NULL;
+INSERT INTO run_statuses (status) VALUES ('preflighting');
+
+-- +goose Down
+DELETE FROM run_statuses WHERE status = 'preflighting';
+ALTER TABLE runs DROP COLUMN skip_preflight;
+ALTER TABLE workspaces DROP COLUMN preflight_checks;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- module_version_policies restricts the versions of a registry module that
+-- runs in an organization are permitted to use; enforcement is either 'warn'
+-- or 'fail'.
+CREATE TABLE IF NOT EXISTS module_version_policies (
+    module_version_policy_id TEXT,
+    created_at               TIMESTAMPTZ NOT NULL,
+    updated_at               TIMESTAMPTZ NOT NULL,
+    organization_name        TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    module                   TEXT NOT NULL,
+    constraints              TEXT NOT NULL,
+    enforcement              TEXT NOT NULL,
+                             PRIMARY KEY (module_version_policy_id),
+                             UNIQUE (organization_name, module)
+);
+
+-- run_policy_checks records the outcome of evaluating a run's plan against a
+-- set of policies; violations is a list of the policies the plan violated.
+CREATE TABLE IF NOT EXISTS run_policy_checks (
+    run_id     TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name       TEXT NOT NULL,
+    violations JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+               PRIMARY KEY (run_id, name)
+);
+
+-- module_manifest is the manifest of modules installed by terraform init
+-- during the plan phase.
+ALTER TABLE runs ADD COLUMN module_manifest BYTEA;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE runs DROP COLUMN module_manifest;
+DROP TABLE IF EXISTS run_policy_checks;
+DROP TABLE IF EXISTS module_version_policies;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- idempotency_keys records the keys with which API clients have created
+-- resources, scoped to the subject making the request; resource_id is null
+-- whilst the creation is in-flight.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    subject         TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    request_hash    TEXT NOT NULL,
+    resource_id     TEXT,
+    created_at      TIMESTAMPTZ NOT NULL,
+                    PRIMARY KEY (subject, idempotency_key)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS idempotency_keys;
+
+-- +goose StatementEnd
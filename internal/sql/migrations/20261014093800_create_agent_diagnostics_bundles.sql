@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS agent_diagnostics_bundles (
+    agent_diagnostics_bundle_id TEXT PRIMARY KEY,
+    agent_id TEXT REFERENCES agents ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    requested_by TEXT NOT NULL,
+    requested_at TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    collected_at TIMESTAMPTZ,
+    error TEXT,
+    bundle BYTEA
+);
+
+CREATE INDEX IF NOT EXISTS agent_diagnostics_bundles_agent_id_idx ON agent_diagnostics_bundles (agent_id, requested_at);
+
+-- an agent has at most one request that is yet to be delivered to it
+CREATE UNIQUE INDEX IF NOT EXISTS agent_diagnostics_bundles_undelivered_idx ON agent_diagnostics_bundles (agent_id) WHERE delivered_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS agent_diagnostics_bundles;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE agents ADD COLUMN disk_capacity BIGINT;
+
+-- +goose Down
+ALTER TABLE agents DROP COLUMN disk_capacity;
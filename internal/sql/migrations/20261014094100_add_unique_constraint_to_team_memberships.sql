@@ -0,0 +1,11 @@
+-- +goose Up
+DELETE FROM team_memberships a
+USING team_memberships b
+WHERE a.ctid < b.ctid
+AND a.team_id = b.team_id
+AND a.username = b.username
+;
+ALTER TABLE team_memberships ADD CONSTRAINT team_memberships_team_id_username_key UNIQUE (team_id, username);
+
+-- +goose Down
+ALTER TABLE team_memberships DROP CONSTRAINT team_memberships_team_id_username_key;
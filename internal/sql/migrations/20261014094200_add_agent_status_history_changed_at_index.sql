@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS agent_status_history_changed_at_idx ON agent_status_history (changed_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS agent_status_history_changed_at_idx;
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- auth_lockouts holds the lockouts imposed upon clients, identified either by
+-- IP address or by username, after too many failed authentication attempts.
+CREATE TABLE IF NOT EXISTS auth_lockouts (
+    lockout_key  TEXT,
+    kind         TEXT NOT NULL,
+    value        TEXT NOT NULL,
+    failures     INTEGER NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ NOT NULL,
+                 PRIMARY KEY (lockout_key)
+);
+
+CREATE OR REPLACE FUNCTION auth_lockouts_notify_event() RETURNS TRIGGER AS $$
+DECLARE
+    record RECORD;
+    notification JSON;
+BEGIN
+    IF (TG_OP = 'DELETE') THEN
+        record = OLD;
+    ELSE
+        record = NEW;
+    END IF;
+    notification = json_build_object(
+                      'table',TG_TABLE_NAME,
+                      'action', TG_OP,
+                      'id', record.lockout_key);
+    PERFORM pg_notify('events', notification::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_event
+AFTER INSERT OR UPDATE OR DELETE ON auth_lockouts
+    FOR EACH ROW EXECUTE PROCEDURE auth_lockouts_notify_event();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS notify_event ON auth_lockouts;
+DROP FUNCTION IF EXISTS auth_lockouts_notify_event;
+DROP TABLE IF EXISTS auth_lockouts;
+
+-- +goose StatementEnd
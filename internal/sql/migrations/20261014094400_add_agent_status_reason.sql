@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE agents ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE agent_status_history ADD COLUMN reason TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE agent_status_history DROP COLUMN reason;
+ALTER TABLE agents DROP COLUMN status_reason;
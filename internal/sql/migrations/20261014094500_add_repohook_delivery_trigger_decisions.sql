@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE repohook_deliveries ADD COLUMN trigger_decisions JSONB;
+
+-- +goose Down
+ALTER TABLE repohook_deliveries DROP COLUMN IF EXISTS trigger_decisions;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE workspaces ADD COLUMN debug_logging_expires_at TIMESTAMPTZ;
+ALTER TABLE workspaces ADD COLUMN debug_logging_runs_remaining INTEGER;
+ALTER TABLE runs ADD COLUMN debug_logging BOOL DEFAULT false NOT NULL;
+
+-- +goose Down
+ALTER TABLE runs DROP COLUMN debug_logging;
+ALTER TABLE workspaces DROP COLUMN debug_logging_runs_remaining;
+ALTER TABLE workspaces DROP COLUMN debug_logging_expires_at;
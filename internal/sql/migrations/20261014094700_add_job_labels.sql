@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE jobs ADD COLUMN labels JSONB;
+ALTER TABLE agents ADD COLUMN labels JSONB;
+
+-- +goose Down
+ALTER TABLE agents DROP COLUMN labels;
+ALTER TABLE jobs DROP COLUMN labels;
@@ -0,0 +1,31 @@
+-- +goose Up
+ALTER TABLE registry_gpg_keys ADD COLUMN revoked_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS registry_provider_versions (
+    provider_version_id TEXT PRIMARY KEY,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    name TEXT NOT NULL,
+    version TEXT NOT NULL,
+    protocols TEXT[] NOT NULL,
+    key_id TEXT NOT NULL,
+    ascii_armor TEXT NOT NULL,
+    shasums BYTEA NOT NULL,
+    shasums_signature BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (organization_name, name, version)
+);
+
+CREATE TABLE IF NOT EXISTS registry_provider_platforms (
+    provider_version_id TEXT REFERENCES registry_provider_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    os TEXT NOT NULL,
+    arch TEXT NOT NULL,
+    filename TEXT NOT NULL,
+    shasum TEXT NOT NULL,
+    archive BYTEA NOT NULL,
+    PRIMARY KEY (provider_version_id, os, arch)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS registry_provider_platforms;
+DROP TABLE IF EXISTS registry_provider_versions;
+ALTER TABLE registry_gpg_keys DROP COLUMN revoked_at;
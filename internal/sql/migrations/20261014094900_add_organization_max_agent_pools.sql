@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE organizations ADD COLUMN max_agent_pools INTEGER;
+
+-- +goose Down
+ALTER TABLE organizations DROP COLUMN max_agent_pools;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE runs ADD COLUMN parent_run_id TEXT REFERENCES runs (run_id) ON UPDATE CASCADE ON DELETE SET NULL;
+CREATE INDEX runs_parent_run_id_idx ON runs (parent_run_id);
+
+-- +goose Down
+DROP INDEX runs_parent_run_id_idx;
+ALTER TABLE runs DROP COLUMN parent_run_id;
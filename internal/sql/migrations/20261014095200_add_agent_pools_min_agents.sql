@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE agent_pools ADD COLUMN min_agents INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE agent_pools DROP COLUMN min_agents;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- module_shares shares an organization's modules with other organizations: a
+-- share with a null module_id shares every module in the organization's
+-- registry, and a share with a null shared_with shares them with every
+-- organization. A revoked share is retained so that consumers can continue
+-- to download the versions they downloaded before it was revoked for a grace
+-- period.
+CREATE TABLE IF NOT EXISTS module_shares (
+    module_share_id   TEXT,
+    created_at        TIMESTAMPTZ NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    module_id         TEXT REFERENCES modules ON UPDATE CASCADE ON DELETE CASCADE,
+    shared_with       TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE,
+    revoked_at        TIMESTAMPTZ,
+                      PRIMARY KEY (module_share_id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS module_shares_unrevoked_idx
+    ON module_shares (organization_name, COALESCE(module_id, ''), COALESCE(shared_with, ''))
+    WHERE revoked_at IS NULL;
+
+-- module_downloads records each download of a module version, attributed to
+-- the organization whose registry address the version was downloaded from.
+CREATE TABLE IF NOT EXISTS module_downloads (
+    module_version_id TEXT REFERENCES module_versions ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    organization_name TEXT REFERENCES organizations (name) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    downloaded_at     TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS module_downloads_module_version_id_idx ON module_downloads (module_version_id, organization_name);
+
+-- +goose Down
+DROP TABLE IF EXISTS module_downloads;
+DROP TABLE IF EXISTS module_shares;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- workspace_assessments holds the result of the most recent drift-detection
+-- plan of each workspace: the resources found to have changed outside of
+-- terraform.
+CREATE TABLE IF NOT EXISTS workspace_assessments (
+    workspace_id TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    run_id       TEXT REFERENCES runs ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    detected_at  TIMESTAMPTZ NOT NULL,
+    resources    JSONB NOT NULL,
+                 PRIMARY KEY (workspace_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_assessments;
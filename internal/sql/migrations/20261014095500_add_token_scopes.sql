@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE tokens ADD COLUMN scopes TEXT[];
+
+-- +goose Down
+ALTER TABLE tokens DROP COLUMN scopes;
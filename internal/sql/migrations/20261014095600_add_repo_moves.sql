@@ -0,0 +1,18 @@
+-- +goose Up
+-- repo_moves records each connected VCS repo that has been renamed or
+-- transferred, along with the workspaces and modules whose connections were
+-- updated to its new path.
+CREATE TABLE IF NOT EXISTS repo_moves (
+    repo_move_id    TEXT,
+    vcs_provider_id TEXT REFERENCES vcs_providers ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    from_repo_path  TEXT NOT NULL,
+    to_repo_path    TEXT NOT NULL,
+    detected_by     TEXT NOT NULL,
+    workspace_ids   TEXT[] NOT NULL,
+    module_ids      TEXT[] NOT NULL,
+    moved_at        TIMESTAMPTZ NOT NULL,
+                    PRIMARY KEY (repo_move_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS repo_moves;
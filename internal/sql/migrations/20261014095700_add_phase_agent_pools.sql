@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE workspaces
+    ADD COLUMN plan_agent_pool_id TEXT,
+    ADD COLUMN apply_agent_pool_id TEXT,
+    ADD CONSTRAINT plan_agent_pool_fk FOREIGN KEY (plan_agent_pool_id)
+        REFERENCES agent_pools ON UPDATE CASCADE,
+    ADD CONSTRAINT apply_agent_pool_fk FOREIGN KEY (apply_agent_pool_id)
+        REFERENCES agent_pools ON UPDATE CASCADE;
+
+-- +goose Down
+ALTER TABLE workspaces
+    DROP COLUMN apply_agent_pool_id,
+    DROP COLUMN plan_agent_pool_id;
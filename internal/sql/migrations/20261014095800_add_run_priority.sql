@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE runs ADD COLUMN priority TEXT NOT NULL DEFAULT 'normal';
+ALTER TABLE organizations ADD COLUMN max_high_priority_runs INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE organizations DROP COLUMN max_high_priority_runs;
+ALTER TABLE runs DROP COLUMN priority;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- workspace_events records notable changes made to a workspace, and by whom,
+-- for display in the workspace's activity feed.
+CREATE TABLE IF NOT EXISTS workspace_events (
+    workspace_event_id TEXT,
+    workspace_id       TEXT REFERENCES workspaces ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    kind               TEXT NOT NULL,
+    subject            TEXT NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL,
+                       PRIMARY KEY (workspace_event_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS workspace_events;
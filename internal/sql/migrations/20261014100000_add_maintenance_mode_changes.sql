@@ -0,0 +1,14 @@
+-- +goose Up
+-- maintenance_mode_changes audits each update to the maintenance mode,
+-- recording who made it, when, and the mode before and after.
+CREATE TABLE IF NOT EXISTS maintenance_mode_changes (
+    maintenance_mode_change_id TEXT,
+    changed_at                 TIMESTAMPTZ NOT NULL,
+    changed_by                 TEXT NOT NULL,
+    old_mode                   JSONB NOT NULL,
+    new_mode                   JSONB NOT NULL,
+                               PRIMARY KEY (maintenance_mode_change_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS maintenance_mode_changes;
@@ -34,8 +34,62 @@ type Querier interface {
 
 	DeleteAgent(ctx context.Context, agentID pgtype.Text) (DeleteAgentRow, error)
 
+	InsertAgentBootstrapToken(ctx context.Context, params InsertAgentBootstrapTokenParams) (pgconn.CommandTag, error)
+
+	FindAgentBootstrapTokenByID(ctx context.Context, agentBootstrapTokenID pgtype.Text) (FindAgentBootstrapTokenByIDRow, error)
+
+	FindAgentBootstrapTokensByAgentPoolID(ctx context.Context, agentPoolID pgtype.Text) ([]FindAgentBootstrapTokensByAgentPoolIDRow, error)
+
+	// UseAgentBootstrapToken records a use of the token, returning no rows if the
+	// token has expired or has been used the maximum number of times.
+	//
+	UseAgentBootstrapToken(ctx context.Context, agentBootstrapTokenID pgtype.Text, now pgtype.Timestamptz) (pgtype.Text, error)
+
+	DeleteAgentBootstrapTokenByID(ctx context.Context, agentBootstrapTokenID pgtype.Text) (pgtype.Text, error)
+
+	InsertAgentCredential(ctx context.Context, params InsertAgentCredentialParams) (pgconn.CommandTag, error)
+
+	FindAgentCredentialByAgentID(ctx context.Context, agentID pgtype.Text) (FindAgentCredentialByAgentIDRow, error)
+
+	// FindAgentCredentialAgentIDsByOrganization lists the IDs of the agents in an
+	// organization that have been issued their own token.
+	//
+	FindAgentCredentialAgentIDsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]pgtype.Text, error)
+
+	DeleteAgentCredentialByAgentID(ctx context.Context, agentID pgtype.Text) (pgtype.Text, error)
+
+	InsertAgentDiagnosticsBundle(ctx context.Context, params InsertAgentDiagnosticsBundleParams) (pgconn.CommandTag, error)
+
+	// ClaimAgentDiagnosticsBundle marks the agent's undelivered request for a
+	// diagnostics bundle as delivered, returning its ID.
+	//
+	ClaimAgentDiagnosticsBundle(ctx context.Context, deliveredAt pgtype.Timestamptz, agentID pgtype.Text) ([]pgtype.Text, error)
+
+	// UploadAgentDiagnosticsBundle stores the bundle collected by an agent, or the
+	// reason it could not be collected, for a request delivered to the agent.
+	//
+	UploadAgentDiagnosticsBundle(ctx context.Context, params UploadAgentDiagnosticsBundleParams) (pgtype.Text, error)
+
+	// TrimAgentDiagnosticsBundles deletes all but the most recent limit
+	// diagnostics bundles for an agent.
+	//
+	TrimAgentDiagnosticsBundles(ctx context.Context, agentID pgtype.Text, limit pgtype.Int8) (pgconn.CommandTag, error)
+
+	// FindAgentDiagnosticsBundlesByOrganization lists the diagnostics bundles of
+	// the agents in an organization, without their contents.
+	//
+	FindAgentDiagnosticsBundlesByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindAgentDiagnosticsBundlesByOrganizationRow, error)
+
+	FindAgentDiagnosticsBundleByID(ctx context.Context, agentDiagnosticsBundleID pgtype.Text) (FindAgentDiagnosticsBundleByIDRow, error)
+
 	InsertAgentPool(ctx context.Context, params InsertAgentPoolParams) (pgconn.CommandTag, error)
 
+	// Find the number of undeleted pools in an organization along with the
+	// maximum number of pools permitted in the organization, which is null if the
+	// organization uses the default maximum.
+	//
+	FindAgentPoolQuota(ctx context.Context, organizationName pgtype.Text) (FindAgentPoolQuotaRow, error)
+
 	FindAgentPools(ctx context.Context) ([]FindAgentPoolsRow, error)
 
 	// Find agent pools in an organization, optionally filtering by any combination of:
@@ -47,15 +101,66 @@ type Querier interface {
 
 	FindAgentPool(ctx context.Context, poolID pgtype.Text) (FindAgentPoolRow, error)
 
+	FindAgentPoolsByIDs(ctx context.Context, poolIds []string) ([]FindAgentPoolsByIDsRow, error)
+
 	FindAgentPoolByAgentTokenID(ctx context.Context, agentTokenID pgtype.Text) (FindAgentPoolByAgentTokenIDRow, error)
 
 	UpdateAgentPool(ctx context.Context, params UpdateAgentPoolParams) (UpdateAgentPoolRow, error)
 
+	FindDeletedAgentPoolsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindDeletedAgentPoolsByOrganizationRow, error)
+
+	// Find the IDs of pools deleted before the given time, i.e. those whose
+	// recovery window has elapsed.
+	//
+	FindAgentPoolIDsDeletedBefore(ctx context.Context, deletedBefore pgtype.Timestamptz) ([]pgtype.Text, error)
+
+	UpdateAgentPoolDeletedAt(ctx context.Context, deletedAt pgtype.Timestamptz, poolID pgtype.Text) (pgconn.CommandTag, error)
+
 	DeleteAgentPool(ctx context.Context, poolID pgtype.Text) (DeleteAgentPoolRow, error)
 
-	InsertAgentPoolAllowedWorkspace(ctx context.Context, poolID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	InsertAgentPoolAllowedWorkspaces(ctx context.Context, poolID pgtype.Text, workspaceIds []string) (pgconn.CommandTag, error)
+
+	DeleteAgentPoolAllowedWorkspaces(ctx context.Context, poolID pgtype.Text, workspaceIds []string) (pgconn.CommandTag, error)
+
+	// Find workspaces assigned to a pool that are not among the given workspaces
+	// allowed to use the pool, i.e. those that would lose access to the pool.
+	//
+	FindAgentPoolAssignedWorkspacesNotAllowed(ctx context.Context, poolID pgtype.Text, allowedWorkspaceIds []string) ([]FindAgentPoolAssignedWorkspacesNotAllowedRow, error)
+
+	UpsertAgentPoolAutoscaler(ctx context.Context, params UpsertAgentPoolAutoscalerParams) (pgconn.CommandTag, error)
+
+	// FindAgentPoolAutoscalers finds all autoscalers, along with the time of the
+	// most recent successful scaling decision for each pool.
+	//
+	FindAgentPoolAutoscalers(ctx context.Context) ([]FindAgentPoolAutoscalersRow, error)
+
+	FindAgentPoolAutoscaler(ctx context.Context, agentPoolID pgtype.Text) (FindAgentPoolAutoscalerRow, error)
+
+	DeleteAgentPoolAutoscaler(ctx context.Context, agentPoolID pgtype.Text) (pgtype.Text, error)
+
+	InsertAgentPoolScalingDecision(ctx context.Context, params InsertAgentPoolScalingDecisionParams) (pgconn.CommandTag, error)
+
+	// TrimAgentPoolScalingDecisions deletes all but the most recent limit scaling
+	// decisions for a pool.
+	//
+	TrimAgentPoolScalingDecisions(ctx context.Context, agentPoolID pgtype.Text, limit pgtype.Int8) (pgconn.CommandTag, error)
+
+	FindAgentPoolScalingDecisions(ctx context.Context, agentPoolID pgtype.Text) ([]FindAgentPoolScalingDecisionsRow, error)
 
-	DeleteAgentPoolAllowedWorkspace(ctx context.Context, poolID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+	InsertAgentStatusHistory(ctx context.Context, params InsertAgentStatusHistoryParams) (pgconn.CommandTag, error)
+
+	// TrimAgentStatusHistory deletes all but the most recent limit status changes
+	// for an agent.
+	//
+	TrimAgentStatusHistory(ctx context.Context, agentID pgtype.Text, limit pgtype.Int8) (pgconn.CommandTag, error)
+
+	// DeleteAgentStatusHistoryBefore deletes up to limit status changes made
+	// before the given time, sparing the most recent change of each agent, which
+	// records its current status.
+	//
+	DeleteAgentStatusHistoryBefore(ctx context.Context, changedBefore pgtype.Timestamptz, limit pgtype.Int8) (pgconn.CommandTag, error)
+
+	FindAgentStatusHistory(ctx context.Context, agentID pgtype.Text) ([]FindAgentStatusHistoryRow, error)
 
 	InsertAgentToken(ctx context.Context, params InsertAgentTokenParams) (pgconn.CommandTag, error)
 
@@ -63,14 +168,30 @@ type Querier interface {
 
 	FindAgentTokensByAgentPoolID(ctx context.Context, agentPoolID pgtype.Text) ([]FindAgentTokensByAgentPoolIDRow, error)
 
+	FindExpiringAgentTokensByOrganization(ctx context.Context, organizationName pgtype.Text, expiresBefore pgtype.Timestamptz) ([]FindExpiringAgentTokensByOrganizationRow, error)
+
+	UpdateAgentTokenLastUsedAt(ctx context.Context, lastUsedAt pgtype.Timestamptz, agentTokenID pgtype.Text) (pgconn.CommandTag, error)
+
 	DeleteAgentTokenByID(ctx context.Context, agentTokenID pgtype.Text) (pgtype.Text, error)
 
+	UpsertAllocatorStatus(ctx context.Context, lastAllocatedAt pgtype.Timestamptz, status []byte) (pgconn.CommandTag, error)
+
+	FindAllocatorStatus(ctx context.Context) (FindAllocatorStatusRow, error)
+
 	InsertApply(ctx context.Context, runID pgtype.Text, status pgtype.Text) (pgconn.CommandTag, error)
 
 	UpdateAppliedChangesByID(ctx context.Context, params UpdateAppliedChangesByIDParams) (pgtype.Text, error)
 
 	UpdateApplyStatusByID(ctx context.Context, status pgtype.Text, runID pgtype.Text) (pgtype.Text, error)
 
+	UpsertAuthLockout(ctx context.Context, params UpsertAuthLockoutParams) (pgconn.CommandTag, error)
+
+	FindActiveAuthLockouts(ctx context.Context, now pgtype.Timestamptz) ([]FindActiveAuthLockoutsRow, error)
+
+	FindAuthLockout(ctx context.Context, lockoutKey pgtype.Text) (FindAuthLockoutRow, error)
+
+	DeleteAuthLockout(ctx context.Context, lockoutKey pgtype.Text) (pgtype.Text, error)
+
 	InsertConfigurationVersion(ctx context.Context, params InsertConfigurationVersionParams) (pgconn.CommandTag, error)
 
 	InsertConfigurationVersionStatusTimestamp(ctx context.Context, params InsertConfigurationVersionStatusTimestampParams) (InsertConfigurationVersionStatusTimestampRow, error)
@@ -101,6 +222,29 @@ type Querier interface {
 
 	DeleteConfigurationVersionByID(ctx context.Context, id pgtype.Text) (pgtype.Text, error)
 
+	InsertEventSinkOutbox(ctx context.Context, params InsertEventSinkOutboxParams) (pgconn.CommandTag, error)
+
+	// FindEventSinkOutbox finds the oldest events awaiting delivery to a sink, up
+	// to a limit.
+	//
+	FindEventSinkOutbox(ctx context.Context, sink pgtype.Text, limit pgtype.Int8) ([]FindEventSinkOutboxRow, error)
+
+	// DeleteEventSinkOutbox deletes the events awaiting delivery to a sink up to
+	// and including the event with the given ID.
+	//
+	DeleteEventSinkOutbox(ctx context.Context, sink pgtype.Text, eventSinkOutboxID pgtype.Int8) (pgconn.CommandTag, error)
+
+	InsertEventSink(ctx context.Context, name pgtype.Text) (pgconn.CommandTag, error)
+
+	UpdateEventSinkDelivered(ctx context.Context, params UpdateEventSinkDeliveredParams) (pgconn.CommandTag, error)
+
+	UpdateEventSinkFailed(ctx context.Context, params UpdateEventSinkFailedParams) (pgconn.CommandTag, error)
+
+	// FindEventSinks finds the status of each event sink, including the number of
+	// events awaiting delivery and the time at which the oldest was created.
+	//
+	FindEventSinks(ctx context.Context) ([]FindEventSinksRow, error)
+
 	InsertGithubApp(ctx context.Context, params InsertGithubAppParams) (pgconn.CommandTag, error)
 
 	FindGithubApp(ctx context.Context) (FindGithubAppRow, error)
@@ -119,8 +263,29 @@ type Querier interface {
 
 	GetGPGKey(ctx context.Context, keyID pgtype.Text, organizationName pgtype.Text) (GetGPGKeyRow, error)
 
+	RevokeGPGKey(ctx context.Context, params RevokeGPGKeyParams) (pgconn.CommandTag, error)
+
+	// ClaimIdempotencyKey inserts a key, or replaces a key that has expired,
+	// returning no rows if an unexpired key already exists.
+	//
+	ClaimIdempotencyKey(ctx context.Context, params ClaimIdempotencyKeyParams) (ClaimIdempotencyKeyRow, error)
+
+	FindIdempotencyKey(ctx context.Context, subject pgtype.Text, idempotencyKey pgtype.Text) (FindIdempotencyKeyRow, error)
+
+	UpdateIdempotencyKeyResource(ctx context.Context, params UpdateIdempotencyKeyResourceParams) (pgconn.CommandTag, error)
+
+	// ReleaseIdempotencyKey deletes a key that was claimed but with which no
+	// resource was created.
+	//
+	ReleaseIdempotencyKey(ctx context.Context, subject pgtype.Text, idempotencyKey pgtype.Text) (pgconn.CommandTag, error)
+
+	DeleteIdempotencyKeysCreatedBefore(ctx context.Context, createdBefore pgtype.Timestamptz) (pgconn.CommandTag, error)
+
 	InsertIngressAttributes(ctx context.Context, params InsertIngressAttributesParams) (pgconn.CommandTag, error)
 
+	// Insert job, assigning it to the agent pool its workspace is currently
+	// configured to use.
+	//
 	InsertJob(ctx context.Context, params InsertJobParams) (pgconn.CommandTag, error)
 
 	FindJobs(ctx context.Context) ([]FindJobsRow, error)
@@ -131,12 +296,72 @@ type Querier interface {
 
 	FindAllocatedJobs(ctx context.Context, agentID pgtype.Text) ([]FindAllocatedJobsRow, error)
 
+	FindActiveJobsByAgentID(ctx context.Context, agentID pgtype.Text) ([]FindActiveJobsByAgentIDRow, error)
+
+	FindQueuedJobsByAgentPoolID(ctx context.Context, agentPoolID pgtype.Text) ([]FindQueuedJobsByAgentPoolIDRow, error)
+
+	// Find the oldest unallocated job for an agent pool, or for the server agents
+	// if the pool ID is null, optionally with all of the given labels, and lock it
+	// for update, skipping jobs already locked by another transaction.
+	//
+	FindNextUnallocatedJobForUpdate(ctx context.Context, params FindNextUnallocatedJobForUpdateParams) (FindNextUnallocatedJobForUpdateRow, error)
+
 	// Find signaled jobs and then immediately update signal with null.
 	//
 	FindAndUpdateSignaledJobs(ctx context.Context, agentID pgtype.Text) ([]FindAndUpdateSignaledJobsRow, error)
 
+	// Mark unallocated jobs that have waited longer than their organization's
+	// queue time SLA as having breached the SLA, returning the newly breached
+	// jobs.
+	//
+	UpdateQueueSLABreachedJobs(ctx context.Context, now pgtype.Timestamptz) ([]UpdateQueueSLABreachedJobsRow, error)
+
+	FindQueueSLABreachedJobsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindQueueSLABreachedJobsByOrganizationRow, error)
+
 	UpdateJob(ctx context.Context, params UpdateJobParams) (UpdateJobRow, error)
 
+	// Find jobs whose run no longer exists.
+	//
+	FindOrphanedJobs(ctx context.Context) ([]FindOrphanedJobsRow, error)
+
+	// Delete a job, but only if its run no longer exists.
+	//
+	DeleteOrphanedJob(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (pgconn.CommandTag, error)
+
+	// Count jobs in an organization by status and agent pool, optionally only
+	// those jobs with all of the given labels.
+	//
+	CountJobsByOrganization(ctx context.Context, organizationName pgtype.Text, labels []byte) ([]CountJobsByOrganizationRow, error)
+
+	// InsertLogBuffer creates the buffer for a stream of logs for a run phase if
+	// it does not already exist. Logs for a phase always begin at offset zero.
+	//
+	InsertLogBuffer(ctx context.Context, params InsertLogBufferParams) (pgconn.CommandTag, error)
+
+	FindLogBufferForUpdate(ctx context.Context, params FindLogBufferForUpdateParams) (FindLogBufferForUpdateRow, error)
+
+	UpdateLogBuffer(ctx context.Context, params UpdateLogBufferParams) (pgconn.CommandTag, error)
+
+	// FindRunLogSize returns the total size of the persisted logs for all phases
+	// of a run.
+	//
+	FindRunLogSize(ctx context.Context, runID pgtype.Text) (pgtype.Int4, error)
+
+	FindRunDebugLogging(ctx context.Context, runID pgtype.Text) (pgtype.Bool, error)
+
+	FindTruncatedLogPhases(ctx context.Context, runID pgtype.Text) ([]pgtype.Text, error)
+
+	UpsertMaintenanceMode(ctx context.Context, params UpsertMaintenanceModeParams) (pgconn.CommandTag, error)
+
+	FindMaintenanceMode(ctx context.Context) (FindMaintenanceModeRow, error)
+
+	InsertMaintenanceModeChange(ctx context.Context, params InsertMaintenanceModeChangeParams) (pgconn.CommandTag, error)
+
+	// FindMaintenanceModeChanges finds the changes made to the maintenance mode,
+	// most recent first.
+	//
+	FindMaintenanceModeChanges(ctx context.Context) ([]FindMaintenanceModeChangesRow, error)
+
 	InsertModule(ctx context.Context, params InsertModuleParams) (pgconn.CommandTag, error)
 
 	InsertModuleVersion(ctx context.Context, params InsertModuleVersionParams) (InsertModuleVersionRow, error)
@@ -163,6 +388,68 @@ type Querier interface {
 
 	DeleteModuleVersionByID(ctx context.Context, moduleVersionID pgtype.Text) (pgtype.Text, error)
 
+	InsertModuleShare(ctx context.Context, params InsertModuleShareParams) (pgconn.CommandTag, error)
+
+	FindModuleSharesByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindModuleSharesByOrganizationRow, error)
+
+	// FindModuleSharesByModuleID finds the shares of a module, including those
+	// sharing the whole of its organization's registry.
+	//
+	FindModuleSharesByModuleID(ctx context.Context, moduleID pgtype.Text) ([]FindModuleSharesByModuleIDRow, error)
+
+	FindModuleShareByID(ctx context.Context, moduleShareID pgtype.Text) (FindModuleShareByIDRow, error)
+
+	// FindSharedModules finds modules in other organizations with the given name
+	// and provider that are shared with an organization, along with the shares
+	// granting access, including revoked shares.
+	//
+	FindSharedModules(ctx context.Context, params FindSharedModulesParams) ([]FindSharedModulesRow, error)
+
+	RevokeModuleShareByID(ctx context.Context, revokedAt pgtype.Timestamptz, moduleShareID pgtype.Text) (RevokeModuleShareByIDRow, error)
+
+	InsertModuleDownload(ctx context.Context, params InsertModuleDownloadParams) (pgconn.CommandTag, error)
+
+	CountModuleDownloadsByModuleID(ctx context.Context, moduleID pgtype.Text) ([]CountModuleDownloadsByModuleIDRow, error)
+
+	// FindModuleVersionIDsDownloadedBefore finds the versions of a module that an
+	// organization downloaded before the given time.
+	//
+	FindModuleVersionIDsDownloadedBefore(ctx context.Context, params FindModuleVersionIDsDownloadedBeforeParams) ([]pgtype.Text, error)
+
+	InsertModuleTemplate(ctx context.Context, params InsertModuleTemplateParams) (pgconn.CommandTag, error)
+
+	UpdateModuleTemplate(ctx context.Context, params UpdateModuleTemplateParams) (pgconn.CommandTag, error)
+
+	FindModuleTemplatesByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindModuleTemplatesByOrganizationRow, error)
+
+	FindModuleTemplateByID(ctx context.Context, moduleTemplateID pgtype.Text) (FindModuleTemplateByIDRow, error)
+
+	FindModuleTemplateByModuleID(ctx context.Context, moduleID pgtype.Text) (FindModuleTemplateByModuleIDRow, error)
+
+	FindModuleTemplateForUpdate(ctx context.Context, moduleTemplateID pgtype.Text) (FindModuleTemplateForUpdateRow, error)
+
+	DeleteModuleTemplateByID(ctx context.Context, moduleTemplateID pgtype.Text) (pgtype.Text, error)
+
+	InsertModuleTemplateWorkspace(ctx context.Context, params InsertModuleTemplateWorkspaceParams) (pgconn.CommandTag, error)
+
+	UpdateModuleTemplateWorkspace(ctx context.Context, params UpdateModuleTemplateWorkspaceParams) (pgconn.CommandTag, error)
+
+	FindModuleTemplateWorkspacesByTemplateID(ctx context.Context, moduleTemplateID pgtype.Text) ([]FindModuleTemplateWorkspacesByTemplateIDRow, error)
+
+	FindModuleTemplateWorkspaceForUpdate(ctx context.Context, workspaceID pgtype.Text) (FindModuleTemplateWorkspaceForUpdateRow, error)
+
+	InsertModuleVersionPolicy(ctx context.Context, params InsertModuleVersionPolicyParams) (pgconn.CommandTag, error)
+
+	UpdateModuleVersionPolicy(ctx context.Context, params UpdateModuleVersionPolicyParams) (pgconn.CommandTag, error)
+
+	FindModuleVersionPoliciesByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindModuleVersionPoliciesByOrganizationRow, error)
+
+	FindModuleVersionPolicyByID(ctx context.Context, moduleVersionPolicyID pgtype.Text) (FindModuleVersionPolicyByIDRow, error)
+
+	FindModuleVersionPolicyForUpdate(ctx context.Context, moduleVersionPolicyID pgtype.Text) (FindModuleVersionPolicyForUpdateRow, error)
+
+	DeleteModuleVersionPolicyByID(ctx context.Context, moduleVersionPolicyID pgtype.Text) (pgtype.Text, error)
+
 	InsertNotificationConfiguration(ctx context.Context, params InsertNotificationConfigurationParams) (pgconn.CommandTag, error)
 
 	FindNotificationConfigurationsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindNotificationConfigurationsByWorkspaceIDRow, error)
@@ -189,7 +476,9 @@ type Querier interface {
 
 	FindOrganizations(ctx context.Context, params FindOrganizationsParams) ([]FindOrganizationsRow, error)
 
-	CountOrganizations(ctx context.Context, names []string) (pgtype.Int8, error)
+	CountOrganizations(ctx context.Context, names []string, search pgtype.Text) (pgtype.Int8, error)
+
+	FindOrganizationsByUsername(ctx context.Context, params FindOrganizationsByUsernameParams) ([]FindOrganizationsByUsernameRow, error)
 
 	UpdateOrganizationByName(ctx context.Context, params UpdateOrganizationByNameParams) (pgtype.Text, error)
 
@@ -209,9 +498,9 @@ type Querier interface {
 
 	InsertLogChunk(ctx context.Context, params InsertLogChunkParams) (pgtype.Int4, error)
 
-	// FindLogs retrieves all the logs for the given run and phase.
+	// FindLogs retrieves all the logs for the given run, phase and stream.
 	//
-	FindLogs(ctx context.Context, runID pgtype.Text, phase pgtype.Text) ([]byte, error)
+	FindLogs(ctx context.Context, params FindLogsParams) ([]byte, error)
 
 	FindLogChunkByID(ctx context.Context, chunkID pgtype.Int4) (FindLogChunkByIDRow, error)
 
@@ -225,10 +514,26 @@ type Querier interface {
 
 	GetPlanJSONByID(ctx context.Context, runID pgtype.Text) ([]byte, error)
 
+	GetPlanRedactedJSONByID(ctx context.Context, runID pgtype.Text) ([]byte, error)
+
 	UpdatePlanBinByID(ctx context.Context, planBin []byte, runID pgtype.Text) (pgtype.Text, error)
 
 	UpdatePlanJSONByID(ctx context.Context, planJSON []byte, runID pgtype.Text) (pgtype.Text, error)
 
+	UpdatePlanRedactedJSONByID(ctx context.Context, planRedactedJSON []byte, runID pgtype.Text) (pgtype.Text, error)
+
+	InsertProviderVersion(ctx context.Context, params InsertProviderVersionParams) (pgconn.CommandTag, error)
+
+	FindProviderVersions(ctx context.Context, organizationName pgtype.Text, name pgtype.Text) ([]FindProviderVersionsRow, error)
+
+	FindProviderVersionByID(ctx context.Context, providerVersionID pgtype.Text) (FindProviderVersionByIDRow, error)
+
+	InsertProviderPlatform(ctx context.Context, params InsertProviderPlatformParams) (pgconn.CommandTag, error)
+
+	FindProviderPlatforms(ctx context.Context, providerVersionIds []string) ([]FindProviderPlatformsRow, error)
+
+	FindProviderPlatformArchive(ctx context.Context, params FindProviderPlatformArchiveParams) ([]byte, error)
+
 	InsertLatestTerraformVersion(ctx context.Context, version pgtype.Text) (pgconn.CommandTag, error)
 
 	UpdateLatestTerraformVersion(ctx context.Context, version pgtype.Text) (pgconn.CommandTag, error)
@@ -241,6 +546,20 @@ type Querier interface {
 
 	DeleteModuleConnectionByID(ctx context.Context, moduleID pgtype.Text) (DeleteModuleConnectionByIDRow, error)
 
+	// UpdateRepoConnectionsRepoPath updates the path of a repo connected to
+	// workspaces and modules, returning the updated connections.
+	//
+	UpdateRepoConnectionsRepoPath(ctx context.Context, params UpdateRepoConnectionsRepoPathParams) ([]UpdateRepoConnectionsRepoPathRow, error)
+
+	FindConnectedRepos(ctx context.Context) ([]FindConnectedReposRow, error)
+
+	InsertRepoMove(ctx context.Context, params InsertRepoMoveParams) (pgconn.CommandTag, error)
+
+	// FindRepoMovesByWorkspaceID finds the moves of repos connected to a
+	// workspace, most recent first.
+	//
+	FindRepoMovesByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindRepoMovesByWorkspaceIDRow, error)
+
 	InsertRepohook(ctx context.Context, params InsertRepohookParams) (InsertRepohookRow, error)
 
 	UpdateRepohookVCSID(ctx context.Context, vcsID pgtype.Text, repohookID pgtype.UUID) (UpdateRepohookVCSIDRow, error)
@@ -255,16 +574,45 @@ type Querier interface {
 
 	DeleteRepohookByID(ctx context.Context, repohookID pgtype.UUID) (DeleteRepohookByIDRow, error)
 
+	UpdateRepohooksRepoPath(ctx context.Context, params UpdateRepohooksRepoPathParams) (pgconn.CommandTag, error)
+
+	InsertRepohookDelivery(ctx context.Context, params InsertRepohookDeliveryParams) (pgconn.CommandTag, error)
+
+	// TrimRepohookDeliveries deletes all but the most recent limit deliveries for
+	// a repohook.
+	//
+	TrimRepohookDeliveries(ctx context.Context, repohookID pgtype.UUID, limit pgtype.Int8) (pgconn.CommandTag, error)
+
+	FindRepohookDeliveryByID(ctx context.Context, repohookDeliveryID pgtype.Text) (FindRepohookDeliveryByIDRow, error)
+
+	// FindRepohookDeliveriesByVCSProviderID finds the deliveries received by the
+	// repohooks of a VCS provider, most recent first. Headers and bodies are
+	// omitted.
+	//
+	FindRepohookDeliveriesByVCSProviderID(ctx context.Context, vcsProviderID pgtype.Text) ([]FindRepohookDeliveriesByVCSProviderIDRow, error)
+
+	// UpdateRepohookDeliveryTriggerDecisions records the decisions taken on
+	// whether the event in a delivery triggers a run in each of the workspaces
+	// connected to the repo.
+	//
+	UpdateRepohookDeliveryTriggerDecisions(ctx context.Context, triggerDecisions []byte, repohookDeliveryID pgtype.Text) (pgconn.CommandTag, error)
+
 	InsertRun(ctx context.Context, params InsertRunParams) (pgconn.CommandTag, error)
 
 	InsertRunStatusTimestamp(ctx context.Context, params InsertRunStatusTimestampParams) (pgconn.CommandTag, error)
 
 	InsertRunVariable(ctx context.Context, params InsertRunVariableParams) (pgconn.CommandTag, error)
 
+	InsertRunLabel(ctx context.Context, params InsertRunLabelParams) (pgconn.CommandTag, error)
+
+	DeleteRunLabels(ctx context.Context, runID pgtype.Text) (pgconn.CommandTag, error)
+
 	FindRuns(ctx context.Context, params FindRunsParams) ([]FindRunsRow, error)
 
 	CountRuns(ctx context.Context, params CountRunsParams) (pgtype.Int8, error)
 
+	FindHighPriorityRunQuota(ctx context.Context, organizationName pgtype.Text) (FindHighPriorityRunQuotaRow, error)
+
 	FindRunByID(ctx context.Context, runID pgtype.Text) (FindRunByIDRow, error)
 
 	FindRunByIDForUpdate(ctx context.Context, runID pgtype.Text) (FindRunByIDForUpdateRow, error)
@@ -273,12 +621,52 @@ type Querier interface {
 
 	GetLockFileByID(ctx context.Context, runID pgtype.Text) ([]byte, error)
 
+	PutModuleManifest(ctx context.Context, moduleManifest []byte, runID pgtype.Text) (pgtype.Text, error)
+
+	GetModuleManifestByID(ctx context.Context, runID pgtype.Text) ([]byte, error)
+
 	UpdateRunStatus(ctx context.Context, status pgtype.Text, id pgtype.Text) (pgtype.Text, error)
 
+	FindChildRunIDs(ctx context.Context, parentRunID pgtype.Text) ([]pgtype.Text, error)
+
+	UpdateRunErrorCategory(ctx context.Context, errorCategory pgtype.Text, id pgtype.Text) (pgtype.Text, error)
+
 	UpdateCancelSignaledAt(ctx context.Context, cancelSignaledAt pgtype.Timestamptz, id pgtype.Text) (pgtype.Text, error)
 
+	UpdateForceCancelAvailableAt(ctx context.Context, forceCancelAvailableAt pgtype.Timestamptz, id pgtype.Text) (pgtype.Text, error)
+
+	UpdateRunForceCanceledBy(ctx context.Context, params UpdateRunForceCanceledByParams) (pgtype.Text, error)
+
 	DeleteRunByID(ctx context.Context, runID pgtype.Text) (pgtype.Text, error)
 
+	UpsertRunArtifact(ctx context.Context, params UpsertRunArtifactParams) (pgconn.CommandTag, error)
+
+	// FindRunArtifacts finds a run's artifacts, excluding their data.
+	//
+	FindRunArtifacts(ctx context.Context, runID pgtype.Text) ([]FindRunArtifactsRow, error)
+
+	FindRunArtifactByName(ctx context.Context, runID pgtype.Text, name pgtype.Text) (FindRunArtifactByNameRow, error)
+
+	InsertRunComment(ctx context.Context, params InsertRunCommentParams) (pgconn.CommandTag, error)
+
+	FindRunComments(ctx context.Context, runID pgtype.Text) ([]FindRunCommentsRow, error)
+
+	FindRunCommentByID(ctx context.Context, commentID pgtype.Text) (FindRunCommentByIDRow, error)
+
+	DeleteRunCommentByID(ctx context.Context, commentID pgtype.Text) (pgtype.Text, error)
+
+	UpsertRunPolicyCheck(ctx context.Context, params UpsertRunPolicyCheckParams) (pgconn.CommandTag, error)
+
+	FindRunPolicyChecks(ctx context.Context, runID pgtype.Text) ([]FindRunPolicyChecksRow, error)
+
+	UpsertSCIMToken(ctx context.Context, params UpsertSCIMTokenParams) (pgconn.CommandTag, error)
+
+	FindSCIMTokenByOrganization(ctx context.Context, organizationName pgtype.Text) (FindSCIMTokenByOrganizationRow, error)
+
+	FindSCIMTokenByID(ctx context.Context, scimTokenID pgtype.Text) (FindSCIMTokenByIDRow, error)
+
+	DeleteSCIMTokenByOrganization(ctx context.Context, organizationName pgtype.Text) (pgtype.Text, error)
+
 	InsertStateVersion(ctx context.Context, params InsertStateVersionParams) (pgconn.CommandTag, error)
 
 	UpdateState(ctx context.Context, state []byte, stateVersionID pgtype.Text) (pgconn.CommandTag, error)
@@ -345,12 +733,18 @@ type Querier interface {
 
 	DeleteTeamMembership(ctx context.Context, usernames []string, teamID pgtype.Text) ([]pgtype.Text, error)
 
+	DeleteTeamMembershipsByUsername(ctx context.Context, username pgtype.Text) ([]pgtype.Text, error)
+
 	InsertTeamToken(ctx context.Context, params InsertTeamTokenParams) (pgconn.CommandTag, error)
 
 	FindTeamTokensByID(ctx context.Context, teamID pgtype.Text) ([]FindTeamTokensByIDRow, error)
 
 	DeleteTeamTokenByID(ctx context.Context, teamID pgtype.Text) (pgtype.Text, error)
 
+	UpsertTerraformVersionPolicy(ctx context.Context, params UpsertTerraformVersionPolicyParams) (pgconn.CommandTag, error)
+
+	FindTerraformVersionPolicy(ctx context.Context) (FindTerraformVersionPolicyRow, error)
+
 	InsertToken(ctx context.Context, params InsertTokenParams) (pgconn.CommandTag, error)
 
 	FindTokensByUsername(ctx context.Context, username pgtype.Text) ([]FindTokensByUsernameRow, error)
@@ -359,6 +753,12 @@ type Querier interface {
 
 	DeleteTokenByID(ctx context.Context, tokenID pgtype.Text) (pgtype.Text, error)
 
+	DeleteTokensByUsername(ctx context.Context, username pgtype.Text) ([]pgtype.Text, error)
+
+	UpsertUpgradeCheck(ctx context.Context, params UpsertUpgradeCheckParams) (pgconn.CommandTag, error)
+
+	FindUpgradeCheck(ctx context.Context) (FindUpgradeCheckRow, error)
+
 	InsertUser(ctx context.Context, params InsertUserParams) (pgconn.CommandTag, error)
 
 	FindUsers(ctx context.Context) ([]FindUsersRow, error)
@@ -373,6 +773,12 @@ type Querier interface {
 
 	FindUserByAuthenticationTokenID(ctx context.Context, tokenID pgtype.Text) (FindUserByAuthenticationTokenIDRow, error)
 
+	FindUserByExternalID(ctx context.Context, externalID pgtype.Text) (FindUserByExternalIDRow, error)
+
+	FindUserByEmail(ctx context.Context, email pgtype.Text) (FindUserByEmailRow, error)
+
+	UpdateUser(ctx context.Context, params UpdateUserParams) (pgconn.CommandTag, error)
+
 	UpdateUserSiteAdmins(ctx context.Context, usernames []string) ([]pgtype.Text, error)
 
 	ResetUserSiteAdmins(ctx context.Context) ([]pgtype.Text, error)
@@ -415,6 +821,10 @@ type Querier interface {
 
 	DeleteVariableSetWorkspaces(ctx context.Context, variableSetID pgtype.Text) (pgconn.CommandTag, error)
 
+	UpsertVCSDefaults(ctx context.Context, params UpsertVCSDefaultsParams) (pgconn.CommandTag, error)
+
+	FindVCSDefaults(ctx context.Context, organizationName pgtype.Text) (FindVCSDefaultsRow, error)
+
 	InsertVCSProvider(ctx context.Context, params InsertVCSProviderParams) (pgconn.CommandTag, error)
 
 	FindVCSProvidersByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindVCSProvidersByOrganizationRow, error)
@@ -453,18 +863,60 @@ type Querier interface {
 
 	UpdateWorkspaceLockByID(ctx context.Context, params UpdateWorkspaceLockByIDParams) (pgconn.CommandTag, error)
 
+	UpdateWorkspacePausedAt(ctx context.Context, pausedAt pgtype.Timestamptz, workspaceID pgtype.Text) (pgconn.CommandTag, error)
+
+	UpdateWorkspaceDebugLogging(ctx context.Context, params UpdateWorkspaceDebugLoggingParams) (pgconn.CommandTag, error)
+
 	UpdateWorkspaceLatestRun(ctx context.Context, runID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error)
 
 	UpdateWorkspaceCurrentStateVersionID(ctx context.Context, stateVersionID pgtype.Text, workspaceID pgtype.Text) (pgtype.Text, error)
 
 	DeleteWorkspaceByID(ctx context.Context, workspaceID pgtype.Text) (pgconn.CommandTag, error)
 
+	FindWorkspaceNamesCaseInsensitive(ctx context.Context, params FindWorkspaceNamesCaseInsensitiveParams) ([]pgtype.Text, error)
+
+	// UpsertWorkspaceAssessment replaces a workspace's assessment, unless it was
+	// detected more recently than the replacement.
+	//
+	UpsertWorkspaceAssessment(ctx context.Context, params UpsertWorkspaceAssessmentParams) (pgconn.CommandTag, error)
+
+	FindWorkspaceAssessment(ctx context.Context, workspaceID pgtype.Text) (FindWorkspaceAssessmentRow, error)
+
+	// DeleteWorkspaceAssessmentBefore deletes a workspace's assessment if it was
+	// detected before the given time.
+	//
+	DeleteWorkspaceAssessmentBefore(ctx context.Context, workspaceID pgtype.Text, before pgtype.Timestamptz) (pgconn.CommandTag, error)
+
+	InsertWorkspaceEvent(ctx context.Context, params InsertWorkspaceEventParams) (pgconn.CommandTag, error)
+
+	// FindWorkspaceEventsByWorkspaceID finds the events recorded for a workspace,
+	// most recent first.
+	//
+	FindWorkspaceEventsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindWorkspaceEventsByWorkspaceIDRow, error)
+
+	InsertWorkspaceOutputMapping(ctx context.Context, params InsertWorkspaceOutputMappingParams) (pgconn.CommandTag, error)
+
+	FindWorkspaceOutputMappings(ctx context.Context, workspaceID pgtype.Text) ([]FindWorkspaceOutputMappingsRow, error)
+
+	FindWorkspaceOutputMapping(ctx context.Context, outputMappingID pgtype.Text) (FindWorkspaceOutputMappingRow, error)
+
+	DeleteWorkspaceOutputMapping(ctx context.Context, outputMappingID pgtype.Text) (pgtype.Text, error)
+
 	UpsertWorkspacePermission(ctx context.Context, params UpsertWorkspacePermissionParams) (pgconn.CommandTag, error)
 
 	FindWorkspacePermissionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindWorkspacePermissionsByWorkspaceIDRow, error)
 
 	DeleteWorkspacePermissionByID(ctx context.Context, workspaceID pgtype.Text, teamID pgtype.Text) (pgconn.CommandTag, error)
 
+	// InsertWorkspaceRunStats adds a run's contribution to its workspace's run
+	// statistics for the day, unless a contribution for the run in the same status
+	// has already been made.
+	InsertWorkspaceRunStats(ctx context.Context, params InsertWorkspaceRunStatsParams) (pgconn.CommandTag, error)
+
+	SumWorkspaceRunStats(ctx context.Context, workspaceID pgtype.Text, since pgtype.Timestamptz) (SumWorkspaceRunStatsRow, error)
+
+	SumOrganizationRunStats(ctx context.Context, organizationName pgtype.Text, since pgtype.Timestamptz) (SumOrganizationRunStatsRow, error)
+
 	InsertWorkspaceVariable(ctx context.Context, variableID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error)
 
 	FindWorkspaceVariablesByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) ([]FindWorkspaceVariablesByWorkspaceIDRow, error)
@@ -596,6 +1048,13 @@ type Report struct {
 	Destructions pgtype.Int4 `json:"destructions"`
 }
 
+// RunLabels represents the Postgres composite type "run_labels".
+type RunLabels struct {
+	RunID pgtype.Text `json:"run_id"`
+	Key   pgtype.Text `json:"key"`
+	Value pgtype.Text `json:"value"`
+}
+
 // RunStatusTimestamps represents the Postgres composite type "run_status_timestamps".
 type RunStatusTimestamps struct {
 	RunID     pgtype.Text        `json:"run_id"`
@@ -661,11 +1120,14 @@ type Teams struct {
 
 // Users represents the Postgres composite type "users".
 type Users struct {
-	UserID    pgtype.Text        `json:"user_id"`
-	Username  pgtype.Text        `json:"username"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	SiteAdmin pgtype.Bool        `json:"site_admin"`
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
 }
 
 // Variables represents the Postgres composite type "variables".
@@ -1370,6 +1832,66 @@ func init() {
 	addHook(register_newReport)
 }
 
+// codec_newRunLabels is a codec for the composite type of the same name
+func codec_newRunLabels(conn genericConn) (pgtype.Codec, error) {
+
+	field0, ok := conn.TypeMap().TypeForName("text")
+	if !ok {
+		return nil, fmt.Errorf("type not found: text")
+	}
+
+	field1, ok := conn.TypeMap().TypeForName("text")
+	if !ok {
+		return nil, fmt.Errorf("type not found: text")
+	}
+
+	field2, ok := conn.TypeMap().TypeForName("text")
+	if !ok {
+		return nil, fmt.Errorf("type not found: text")
+	}
+
+	return &pgtype.CompositeCodec{
+		Fields: []pgtype.CompositeCodecField{
+
+			{
+				Name: "run_id",
+				Type: field0,
+			},
+
+			{
+				Name: "key",
+				Type: field1,
+			},
+
+			{
+				Name: "value",
+				Type: field2,
+			},
+		},
+	}, nil
+}
+
+func register_newRunLabels(
+	ctx context.Context,
+	conn genericConn,
+) error {
+	t, err := conn.LoadType(
+		ctx,
+		"\"run_labels\"",
+	)
+	if err != nil {
+		return fmt.Errorf("newRunLabels failed to load type: %w", err)
+	}
+
+	conn.TypeMap().RegisterType(t)
+
+	return nil
+}
+
+func init() {
+	addHook(register_newRunLabels)
+}
+
 // codec_newRunStatusTimestamps is a codec for the composite type of the same name
 func codec_newRunStatusTimestamps(conn genericConn) (pgtype.Codec, error) {
 
@@ -1978,6 +2500,21 @@ func codec_newUsers(conn genericConn) (pgtype.Codec, error) {
 		return nil, fmt.Errorf("type not found: bool")
 	}
 
+	field5, ok := conn.TypeMap().TypeForName("text")
+	if !ok {
+		return nil, fmt.Errorf("type not found: text")
+	}
+
+	field6, ok := conn.TypeMap().TypeForName("text")
+	if !ok {
+		return nil, fmt.Errorf("type not found: text")
+	}
+
+	field7, ok := conn.TypeMap().TypeForName("bool")
+	if !ok {
+		return nil, fmt.Errorf("type not found: bool")
+	}
+
 	return &pgtype.CompositeCodec{
 		Fields: []pgtype.CompositeCodecField{
 
@@ -2005,6 +2542,21 @@ func codec_newUsers(conn genericConn) (pgtype.Codec, error) {
 				Name: "site_admin",
 				Type: field4,
 			},
+
+			{
+				Name: "email",
+				Type: field5,
+			},
+
+			{
+				Name: "external_id",
+				Type: field6,
+			},
+
+			{
+				Name: "deactivated",
+				Type: field7,
+			},
 		},
 	}, nil
 }
@@ -2239,6 +2791,39 @@ func init() {
 	addHook(register_newPhaseStatusTimestampsArray)
 }
 
+// codec_newRunLabelsArray is a codec for the composite type of the same name
+func codec_newRunLabelsArray(conn genericConn) (pgtype.Codec, error) {
+	elementType, ok := conn.TypeMap().TypeForName("run_labels")
+	if !ok {
+		return nil, fmt.Errorf("type not found: run_labels")
+	}
+
+	return &pgtype.ArrayCodec{
+		ElementType: elementType,
+	}, nil
+}
+
+func register_newRunLabelsArray(
+	ctx context.Context,
+	conn genericConn,
+) error {
+	t, err := conn.LoadType(
+		ctx,
+		"\"_run_labels\"",
+	)
+	if err != nil {
+		return fmt.Errorf("newRunLabelsArray failed to load type: %w", err)
+	}
+
+	conn.TypeMap().RegisterType(t)
+
+	return nil
+}
+
+func init() {
+	addHook(register_newRunLabelsArray)
+}
+
 // codec_newRunStatusTimestampsArray is a codec for the composite type of the same name
 func codec_newRunStatusTimestampsArray(conn genericConn) (pgtype.Codec, error) {
 	elementType, ok := conn.TypeMap().TypeForName("run_status_timestamps")
@@ -2413,7 +2998,10 @@ const insertAgentSQL = `INSERT INTO agents (
     last_ping_at,
     last_status_at,
     status,
-    agent_pool_id
+    agent_pool_id,
+    disk_capacity,
+    status_reason,
+    labels
 ) VALUES (
     $1,
     $2,
@@ -2423,7 +3011,10 @@ const insertAgentSQL = `INSERT INTO agents (
     $6,
     $7,
     $8,
-    $9
+    $9,
+    $10,
+    $11,
+    $12
 );`
 
 type InsertAgentParams struct {
@@ -2436,12 +3027,15 @@ type InsertAgentParams struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 }
 
 // InsertAgent implements Querier.InsertAgent.
 func (q *DBQuerier) InsertAgent(ctx context.Context, params InsertAgentParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertAgent")
-	cmdTag, err := q.conn.Exec(ctx, insertAgentSQL, params.AgentID, params.Name, params.Version, params.MaxJobs, params.IPAddress, params.LastPingAt, params.LastStatusAt, params.Status, params.AgentPoolID)
+	cmdTag, err := q.conn.Exec(ctx, insertAgentSQL, params.AgentID, params.Name, params.Version, params.MaxJobs, params.IPAddress, params.LastPingAt, params.LastStatusAt, params.Status, params.AgentPoolID, params.DiskCapacity, params.StatusReason, params.Labels)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertAgent: %w", err)
 	}
@@ -2451,14 +3045,16 @@ func (q *DBQuerier) InsertAgent(ctx context.Context, params InsertAgentParams) (
 const updateAgentSQL = `UPDATE agents
 SET status = $1,
     last_ping_at = $2,
-    last_status_at = $3
-WHERE agent_id = $4
+    last_status_at = $3,
+    status_reason = $4
+WHERE agent_id = $5
 RETURNING *;`
 
 type UpdateAgentParams struct {
 	Status       pgtype.Text        `json:"status"`
 	LastPingAt   pgtype.Timestamptz `json:"last_ping_at"`
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
+	StatusReason pgtype.Text        `json:"status_reason"`
 	AgentID      pgtype.Text        `json:"agent_id"`
 }
 
@@ -2472,12 +3068,15 @@ type UpdateAgentRow struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 }
 
 // UpdateAgent implements Querier.UpdateAgent.
 func (q *DBQuerier) UpdateAgent(ctx context.Context, params UpdateAgentParams) (UpdateAgentRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateAgent")
-	rows, err := q.conn.Query(ctx, updateAgentSQL, params.Status, params.LastPingAt, params.LastStatusAt, params.AgentID)
+	rows, err := q.conn.Query(ctx, updateAgentSQL, params.Status, params.LastPingAt, params.LastStatusAt, params.StatusReason, params.AgentID)
 	if err != nil {
 		return UpdateAgentRow{}, fmt.Errorf("query UpdateAgent: %w", err)
 	}
@@ -2493,6 +3092,9 @@ func (q *DBQuerier) UpdateAgent(ctx context.Context, params UpdateAgentParams) (
 			&item.LastStatusAt, // 'last_status_at', 'LastStatusAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Status,       // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID,  // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DiskCapacity, // 'disk_capacity', 'DiskCapacity', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.StatusReason, // 'status_reason', 'StatusReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,       // 'labels', 'Labels', '[]byte', '', '[]byte'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -2521,6 +3123,9 @@ type FindAgentsRow struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 	CurrentJobs  pgtype.Int8        `json:"current_jobs"`
 }
 
@@ -2543,6 +3148,9 @@ func (q *DBQuerier) FindAgents(ctx context.Context) ([]FindAgentsRow, error) {
 			&item.LastStatusAt, // 'last_status_at', 'LastStatusAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Status,       // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID,  // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DiskCapacity, // 'disk_capacity', 'DiskCapacity', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.StatusReason, // 'status_reason', 'StatusReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,       // 'labels', 'Labels', '[]byte', '', '[]byte'
 			&item.CurrentJobs,  // 'current_jobs', 'CurrentJobs', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
@@ -2561,6 +3169,7 @@ const findAgentsByOrganizationSQL = `SELECT
 FROM agents a
 JOIN agent_pools ap USING (agent_pool_id)
 WHERE ap.organization_name = $1
+AND   ap.deleted_at IS NULL
 GROUP BY a.agent_id
 ORDER BY last_ping_at DESC;`
 
@@ -2574,6 +3183,9 @@ type FindAgentsByOrganizationRow struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 	CurrentJobs  pgtype.Int8        `json:"current_jobs"`
 }
 
@@ -2596,6 +3208,9 @@ func (q *DBQuerier) FindAgentsByOrganization(ctx context.Context, organizationNa
 			&item.LastStatusAt, // 'last_status_at', 'LastStatusAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Status,       // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID,  // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DiskCapacity, // 'disk_capacity', 'DiskCapacity', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.StatusReason, // 'status_reason', 'StatusReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,       // 'labels', 'Labels', '[]byte', '', '[]byte'
 			&item.CurrentJobs,  // 'current_jobs', 'CurrentJobs', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
@@ -2627,6 +3242,9 @@ type FindAgentsByPoolIDRow struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 	CurrentJobs  pgtype.Int8        `json:"current_jobs"`
 }
 
@@ -2649,6 +3267,9 @@ func (q *DBQuerier) FindAgentsByPoolID(ctx context.Context, agentPoolID pgtype.T
 			&item.LastStatusAt, // 'last_status_at', 'LastStatusAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Status,       // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID,  // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DiskCapacity, // 'disk_capacity', 'DiskCapacity', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.StatusReason, // 'status_reason', 'StatusReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,       // 'labels', 'Labels', '[]byte', '', '[]byte'
 			&item.CurrentJobs,  // 'current_jobs', 'CurrentJobs', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
@@ -2679,6 +3300,9 @@ type FindServerAgentsRow struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 	CurrentJobs  pgtype.Int8        `json:"current_jobs"`
 }
 
@@ -2701,6 +3325,9 @@ func (q *DBQuerier) FindServerAgents(ctx context.Context) ([]FindServerAgentsRow
 			&item.LastStatusAt, // 'last_status_at', 'LastStatusAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Status,       // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID,  // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DiskCapacity, // 'disk_capacity', 'DiskCapacity', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.StatusReason, // 'status_reason', 'StatusReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,       // 'labels', 'Labels', '[]byte', '', '[]byte'
 			&item.CurrentJobs,  // 'current_jobs', 'CurrentJobs', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
@@ -2731,6 +3358,9 @@ type FindAgentByIDRow struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 	CurrentJobs  pgtype.Int8        `json:"current_jobs"`
 }
 
@@ -2753,6 +3383,9 @@ func (q *DBQuerier) FindAgentByID(ctx context.Context, agentID pgtype.Text) (Fin
 			&item.LastStatusAt, // 'last_status_at', 'LastStatusAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Status,       // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID,  // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DiskCapacity, // 'disk_capacity', 'DiskCapacity', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.StatusReason, // 'status_reason', 'StatusReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,       // 'labels', 'Labels', '[]byte', '', '[]byte'
 			&item.CurrentJobs,  // 'current_jobs', 'CurrentJobs', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
@@ -2782,6 +3415,9 @@ type FindAgentByIDForUpdateRow struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 	CurrentJobs  pgtype.Int8        `json:"current_jobs"`
 }
 
@@ -2804,6 +3440,9 @@ func (q *DBQuerier) FindAgentByIDForUpdate(ctx context.Context, agentID pgtype.T
 			&item.LastStatusAt, // 'last_status_at', 'LastStatusAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Status,       // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID,  // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DiskCapacity, // 'disk_capacity', 'DiskCapacity', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.StatusReason, // 'status_reason', 'StatusReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,       // 'labels', 'Labels', '[]byte', '', '[]byte'
 			&item.CurrentJobs,  // 'current_jobs', 'CurrentJobs', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
@@ -2827,6 +3466,9 @@ type DeleteAgentRow struct {
 	LastStatusAt pgtype.Timestamptz `json:"last_status_at"`
 	Status       pgtype.Text        `json:"status"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	DiskCapacity pgtype.Int8        `json:"disk_capacity"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+	Labels       []byte             `json:"labels"`
 }
 
 // DeleteAgent implements Querier.DeleteAgent.
@@ -2848,6 +3490,9 @@ func (q *DBQuerier) DeleteAgent(ctx context.Context, agentID pgtype.Text) (Delet
 			&item.LastStatusAt, // 'last_status_at', 'LastStatusAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Status,       // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID,  // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DiskCapacity, // 'disk_capacity', 'DiskCapacity', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.StatusReason, // 'status_reason', 'StatusReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,       // 'labels', 'Labels', '[]byte', '', '[]byte'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
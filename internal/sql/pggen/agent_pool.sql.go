@@ -18,38 +18,80 @@ const insertAgentPoolSQL = `INSERT INTO agent_pools (
     name,
     created_at,
     organization_name,
-    organization_scoped
+    organization_scoped,
+    allowed_terraform_versions,
+    min_agents
 ) VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6,
+    $7
 );`
 
 type InsertAgentPoolParams struct {
-	AgentPoolID        pgtype.Text        `json:"agent_pool_id"`
-	Name               pgtype.Text        `json:"name"`
-	CreatedAt          pgtype.Timestamptz `json:"created_at"`
-	OrganizationName   pgtype.Text        `json:"organization_name"`
-	OrganizationScoped pgtype.Bool        `json:"organization_scoped"`
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
 }
 
 // InsertAgentPool implements Querier.InsertAgentPool.
 func (q *DBQuerier) InsertAgentPool(ctx context.Context, params InsertAgentPoolParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertAgentPool")
-	cmdTag, err := q.conn.Exec(ctx, insertAgentPoolSQL, params.AgentPoolID, params.Name, params.CreatedAt, params.OrganizationName, params.OrganizationScoped)
+	cmdTag, err := q.conn.Exec(ctx, insertAgentPoolSQL, params.AgentPoolID, params.Name, params.CreatedAt, params.OrganizationName, params.OrganizationScoped, params.AllowedTerraformVersions, params.MinAgents)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertAgentPool: %w", err)
 	}
 	return cmdTag, err
 }
 
+const findAgentPoolQuotaSQL = `SELECT
+    (
+        SELECT count(*)
+        FROM agent_pools ap
+        WHERE ap.organization_name = o.name
+        AND   ap.deleted_at IS NULL
+    ) AS pools,
+    o.max_agent_pools
+FROM organizations o
+WHERE o.name = $1
+;`
+
+type FindAgentPoolQuotaRow struct {
+	Pools         pgtype.Int8 `json:"pools"`
+	MaxAgentPools pgtype.Int4 `json:"max_agent_pools"`
+}
+
+// FindAgentPoolQuota implements Querier.FindAgentPoolQuota.
+func (q *DBQuerier) FindAgentPoolQuota(ctx context.Context, organizationName pgtype.Text) (FindAgentPoolQuotaRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindAgentPoolQuota")
+	rows, err := q.conn.Query(ctx, findAgentPoolQuotaSQL, organizationName)
+	if err != nil {
+		return FindAgentPoolQuotaRow{}, fmt.Errorf("query FindAgentPoolQuota: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindAgentPoolQuotaRow, error) {
+		var item FindAgentPoolQuotaRow
+		if err := row.Scan(&item.Pools, // 'pools', 'Pools', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.MaxAgentPools, // 'max_agent_pools', 'MaxAgentPools', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
 const findAgentPoolsSQL = `SELECT ap.*,
     (
         SELECT array_agg(w.workspace_id)
         FROM workspaces w
-        WHERE w.agent_pool_id = ap.agent_pool_id
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
     ) AS workspace_ids,
     (
         SELECT array_agg(aw.workspace_id)
@@ -57,17 +99,21 @@ const findAgentPoolsSQL = `SELECT ap.*,
         WHERE aw.agent_pool_id = ap.agent_pool_id
     ) AS allowed_workspace_ids
 FROM agent_pools ap
+WHERE ap.deleted_at IS NULL
 ORDER BY ap.created_at DESC
 ;`
 
 type FindAgentPoolsRow struct {
-	AgentPoolID         pgtype.Text        `json:"agent_pool_id"`
-	Name                pgtype.Text        `json:"name"`
-	CreatedAt           pgtype.Timestamptz `json:"created_at"`
-	OrganizationName    pgtype.Text        `json:"organization_name"`
-	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
-	WorkspaceIds        []string           `json:"workspace_ids"`
-	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	DeletedAt                pgtype.Timestamptz `json:"deleted_at"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
+	WorkspaceIds             []string           `json:"workspace_ids"`
+	AllowedWorkspaceIds      []string           `json:"allowed_workspace_ids"`
 }
 
 // FindAgentPools implements Querier.FindAgentPools.
@@ -81,12 +127,15 @@ func (q *DBQuerier) FindAgentPools(ctx context.Context) ([]FindAgentPoolsRow, er
 	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindAgentPoolsRow, error) {
 		var item FindAgentPoolsRow
 		if err := row.Scan(&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Name,                // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt,           // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.OrganizationName,    // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.OrganizationScoped,  // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.WorkspaceIds,        // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
-			&item.AllowedWorkspaceIds, // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
+			&item.Name,                     // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,                // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.OrganizationName,         // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationScoped,       // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DeletedAt,                // 'deleted_at', 'DeletedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllowedTerraformVersions, // 'allowed_terraform_versions', 'AllowedTerraformVersions', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MinAgents,                // 'min_agents', 'MinAgents', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceIds,             // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
+			&item.AllowedWorkspaceIds,      // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -98,7 +147,7 @@ const findAgentPoolsByOrganizationSQL = `SELECT ap.*,
     (
         SELECT array_agg(w.workspace_id)
         FROM workspaces w
-        WHERE w.agent_pool_id = ap.agent_pool_id
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
     ) AS workspace_ids,
     (
         SELECT array_agg(aw.workspace_id)
@@ -108,6 +157,7 @@ const findAgentPoolsByOrganizationSQL = `SELECT ap.*,
 FROM agent_pools ap
 LEFT JOIN (agent_pool_allowed_workspaces aw JOIN workspaces w USING (workspace_id)) ON ap.agent_pool_id = aw.agent_pool_id
 WHERE ap.organization_name = $1
+AND   ap.deleted_at IS NULL
 AND   (($2::text IS NULL) OR ap.name LIKE '%' || $2 || '%')
 AND   (($3::text IS NULL) OR
        ap.organization_scoped OR
@@ -129,13 +179,16 @@ type FindAgentPoolsByOrganizationParams struct {
 }
 
 type FindAgentPoolsByOrganizationRow struct {
-	AgentPoolID         pgtype.Text        `json:"agent_pool_id"`
-	Name                pgtype.Text        `json:"name"`
-	CreatedAt           pgtype.Timestamptz `json:"created_at"`
-	OrganizationName    pgtype.Text        `json:"organization_name"`
-	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
-	WorkspaceIds        []string           `json:"workspace_ids"`
-	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	DeletedAt                pgtype.Timestamptz `json:"deleted_at"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
+	WorkspaceIds             []string           `json:"workspace_ids"`
+	AllowedWorkspaceIds      []string           `json:"allowed_workspace_ids"`
 }
 
 // FindAgentPoolsByOrganization implements Querier.FindAgentPoolsByOrganization.
@@ -149,12 +202,15 @@ func (q *DBQuerier) FindAgentPoolsByOrganization(ctx context.Context, params Fin
 	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindAgentPoolsByOrganizationRow, error) {
 		var item FindAgentPoolsByOrganizationRow
 		if err := row.Scan(&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Name,                // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt,           // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.OrganizationName,    // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.OrganizationScoped,  // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.WorkspaceIds,        // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
-			&item.AllowedWorkspaceIds, // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
+			&item.Name,                     // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,                // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.OrganizationName,         // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationScoped,       // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DeletedAt,                // 'deleted_at', 'DeletedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllowedTerraformVersions, // 'allowed_terraform_versions', 'AllowedTerraformVersions', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MinAgents,                // 'min_agents', 'MinAgents', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceIds,             // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
+			&item.AllowedWorkspaceIds,      // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -166,7 +222,7 @@ const findAgentPoolSQL = `SELECT ap.*,
     (
         SELECT array_agg(w.workspace_id)
         FROM workspaces w
-        WHERE w.agent_pool_id = ap.agent_pool_id
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
     ) AS workspace_ids,
     (
         SELECT array_agg(aw.workspace_id)
@@ -179,13 +235,16 @@ GROUP BY ap.agent_pool_id
 ;`
 
 type FindAgentPoolRow struct {
-	AgentPoolID         pgtype.Text        `json:"agent_pool_id"`
-	Name                pgtype.Text        `json:"name"`
-	CreatedAt           pgtype.Timestamptz `json:"created_at"`
-	OrganizationName    pgtype.Text        `json:"organization_name"`
-	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
-	WorkspaceIds        []string           `json:"workspace_ids"`
-	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	DeletedAt                pgtype.Timestamptz `json:"deleted_at"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
+	WorkspaceIds             []string           `json:"workspace_ids"`
+	AllowedWorkspaceIds      []string           `json:"allowed_workspace_ids"`
 }
 
 // FindAgentPool implements Querier.FindAgentPool.
@@ -199,12 +258,72 @@ func (q *DBQuerier) FindAgentPool(ctx context.Context, poolID pgtype.Text) (Find
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindAgentPoolRow, error) {
 		var item FindAgentPoolRow
 		if err := row.Scan(&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Name,                // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt,           // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.OrganizationName,    // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.OrganizationScoped,  // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.WorkspaceIds,        // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
-			&item.AllowedWorkspaceIds, // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
+			&item.Name,                     // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,                // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.OrganizationName,         // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationScoped,       // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DeletedAt,                // 'deleted_at', 'DeletedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllowedTerraformVersions, // 'allowed_terraform_versions', 'AllowedTerraformVersions', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MinAgents,                // 'min_agents', 'MinAgents', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceIds,             // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
+			&item.AllowedWorkspaceIds,      // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const findAgentPoolsByIDsSQL = `SELECT ap.*,
+    (
+        SELECT array_agg(w.workspace_id)
+        FROM workspaces w
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(aw.workspace_id)
+        FROM agent_pool_allowed_workspaces aw
+        WHERE aw.agent_pool_id = ap.agent_pool_id
+    ) AS allowed_workspace_ids
+FROM agent_pools ap
+WHERE ap.agent_pool_id = ANY($1)
+AND   ap.deleted_at IS NULL
+GROUP BY ap.agent_pool_id
+;`
+
+type FindAgentPoolsByIDsRow struct {
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	DeletedAt                pgtype.Timestamptz `json:"deleted_at"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
+	WorkspaceIds             []string           `json:"workspace_ids"`
+	AllowedWorkspaceIds      []string           `json:"allowed_workspace_ids"`
+}
+
+// FindAgentPoolsByIDs implements Querier.FindAgentPoolsByIDs.
+func (q *DBQuerier) FindAgentPoolsByIDs(ctx context.Context, poolIds []string) ([]FindAgentPoolsByIDsRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindAgentPoolsByIDs")
+	rows, err := q.conn.Query(ctx, findAgentPoolsByIDsSQL, poolIds)
+	if err != nil {
+		return nil, fmt.Errorf("query FindAgentPoolsByIDs: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindAgentPoolsByIDsRow, error) {
+		var item FindAgentPoolsByIDsRow
+		if err := row.Scan(&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Name,                     // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,                // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.OrganizationName,         // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationScoped,       // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DeletedAt,                // 'deleted_at', 'DeletedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllowedTerraformVersions, // 'allowed_terraform_versions', 'AllowedTerraformVersions', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MinAgents,                // 'min_agents', 'MinAgents', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceIds,             // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
+			&item.AllowedWorkspaceIds,      // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -216,7 +335,7 @@ const findAgentPoolByAgentTokenIDSQL = `SELECT ap.*,
     (
         SELECT array_agg(w.workspace_id)
         FROM workspaces w
-        WHERE w.agent_pool_id = ap.agent_pool_id
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
     ) AS workspace_ids,
     (
         SELECT array_agg(aw.workspace_id)
@@ -226,17 +345,21 @@ const findAgentPoolByAgentTokenIDSQL = `SELECT ap.*,
 FROM agent_pools ap
 JOIN agent_tokens at USING (agent_pool_id)
 WHERE at.agent_token_id = $1
+AND   ap.deleted_at IS NULL
 GROUP BY ap.agent_pool_id
 ;`
 
 type FindAgentPoolByAgentTokenIDRow struct {
-	AgentPoolID         pgtype.Text        `json:"agent_pool_id"`
-	Name                pgtype.Text        `json:"name"`
-	CreatedAt           pgtype.Timestamptz `json:"created_at"`
-	OrganizationName    pgtype.Text        `json:"organization_name"`
-	OrganizationScoped  pgtype.Bool        `json:"organization_scoped"`
-	WorkspaceIds        []string           `json:"workspace_ids"`
-	AllowedWorkspaceIds []string           `json:"allowed_workspace_ids"`
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	DeletedAt                pgtype.Timestamptz `json:"deleted_at"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
+	WorkspaceIds             []string           `json:"workspace_ids"`
+	AllowedWorkspaceIds      []string           `json:"allowed_workspace_ids"`
 }
 
 // FindAgentPoolByAgentTokenID implements Querier.FindAgentPoolByAgentTokenID.
@@ -250,12 +373,15 @@ func (q *DBQuerier) FindAgentPoolByAgentTokenID(ctx context.Context, agentTokenI
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindAgentPoolByAgentTokenIDRow, error) {
 		var item FindAgentPoolByAgentTokenIDRow
 		if err := row.Scan(&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Name,                // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt,           // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.OrganizationName,    // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.OrganizationScoped,  // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.WorkspaceIds,        // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
-			&item.AllowedWorkspaceIds, // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
+			&item.Name,                     // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,                // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.OrganizationName,         // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationScoped,       // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DeletedAt,                // 'deleted_at', 'DeletedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllowedTerraformVersions, // 'allowed_terraform_versions', 'AllowedTerraformVersions', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MinAgents,                // 'min_agents', 'MinAgents', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceIds,             // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
+			&item.AllowedWorkspaceIds,      // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -265,28 +391,35 @@ func (q *DBQuerier) FindAgentPoolByAgentTokenID(ctx context.Context, agentTokenI
 
 const updateAgentPoolSQL = `UPDATE agent_pools
 SET name = $1,
-    organization_scoped = $2
-WHERE agent_pool_id = $3
+    organization_scoped = $2,
+    allowed_terraform_versions = $3,
+    min_agents = $4
+WHERE agent_pool_id = $5
 RETURNING *;`
 
 type UpdateAgentPoolParams struct {
-	Name               pgtype.Text `json:"name"`
-	OrganizationScoped pgtype.Bool `json:"organization_scoped"`
-	PoolID             pgtype.Text `json:"pool_id"`
+	Name                     pgtype.Text `json:"name"`
+	OrganizationScoped       pgtype.Bool `json:"organization_scoped"`
+	AllowedTerraformVersions pgtype.Text `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4 `json:"min_agents"`
+	PoolID                   pgtype.Text `json:"pool_id"`
 }
 
 type UpdateAgentPoolRow struct {
-	AgentPoolID        pgtype.Text        `json:"agent_pool_id"`
-	Name               pgtype.Text        `json:"name"`
-	CreatedAt          pgtype.Timestamptz `json:"created_at"`
-	OrganizationName   pgtype.Text        `json:"organization_name"`
-	OrganizationScoped pgtype.Bool        `json:"organization_scoped"`
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	DeletedAt                pgtype.Timestamptz `json:"deleted_at"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
 }
 
 // UpdateAgentPool implements Querier.UpdateAgentPool.
 func (q *DBQuerier) UpdateAgentPool(ctx context.Context, params UpdateAgentPoolParams) (UpdateAgentPoolRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateAgentPool")
-	rows, err := q.conn.Query(ctx, updateAgentPoolSQL, params.Name, params.OrganizationScoped, params.PoolID)
+	rows, err := q.conn.Query(ctx, updateAgentPoolSQL, params.Name, params.OrganizationScoped, params.AllowedTerraformVersions, params.MinAgents, params.PoolID)
 	if err != nil {
 		return UpdateAgentPoolRow{}, fmt.Errorf("query UpdateAgentPool: %w", err)
 	}
@@ -294,10 +427,13 @@ func (q *DBQuerier) UpdateAgentPool(ctx context.Context, params UpdateAgentPoolP
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (UpdateAgentPoolRow, error) {
 		var item UpdateAgentPoolRow
 		if err := row.Scan(&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Name,               // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt,          // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.OrganizationName,   // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.OrganizationScoped, // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Name,                     // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,                // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.OrganizationName,         // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationScoped,       // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DeletedAt,                // 'deleted_at', 'DeletedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllowedTerraformVersions, // 'allowed_terraform_versions', 'AllowedTerraformVersions', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MinAgents,                // 'min_agents', 'MinAgents', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -305,6 +441,100 @@ func (q *DBQuerier) UpdateAgentPool(ctx context.Context, params UpdateAgentPoolP
 	})
 }
 
+const findDeletedAgentPoolsByOrganizationSQL = `SELECT ap.*,
+    (
+        SELECT array_agg(w.workspace_id)
+        FROM workspaces w
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(aw.workspace_id)
+        FROM agent_pool_allowed_workspaces aw
+        WHERE aw.agent_pool_id = ap.agent_pool_id
+    ) AS allowed_workspace_ids
+FROM agent_pools ap
+WHERE ap.organization_name = $1
+AND   ap.deleted_at IS NOT NULL
+ORDER BY ap.deleted_at DESC
+;`
+
+type FindDeletedAgentPoolsByOrganizationRow struct {
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	DeletedAt                pgtype.Timestamptz `json:"deleted_at"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
+	WorkspaceIds             []string           `json:"workspace_ids"`
+	AllowedWorkspaceIds      []string           `json:"allowed_workspace_ids"`
+}
+
+// FindDeletedAgentPoolsByOrganization implements Querier.FindDeletedAgentPoolsByOrganization.
+func (q *DBQuerier) FindDeletedAgentPoolsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindDeletedAgentPoolsByOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindDeletedAgentPoolsByOrganization")
+	rows, err := q.conn.Query(ctx, findDeletedAgentPoolsByOrganizationSQL, organizationName)
+	if err != nil {
+		return nil, fmt.Errorf("query FindDeletedAgentPoolsByOrganization: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindDeletedAgentPoolsByOrganizationRow, error) {
+		var item FindDeletedAgentPoolsByOrganizationRow
+		if err := row.Scan(&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Name,                     // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,                // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.OrganizationName,         // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationScoped,       // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DeletedAt,                // 'deleted_at', 'DeletedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllowedTerraformVersions, // 'allowed_terraform_versions', 'AllowedTerraformVersions', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MinAgents,                // 'min_agents', 'MinAgents', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceIds,             // 'workspace_ids', 'WorkspaceIds', '[]string', '', '[]string'
+			&item.AllowedWorkspaceIds,      // 'allowed_workspace_ids', 'AllowedWorkspaceIds', '[]string', '', '[]string'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const findAgentPoolIDsDeletedBeforeSQL = `SELECT agent_pool_id
+FROM agent_pools
+WHERE deleted_at < $1
+;`
+
+// FindAgentPoolIDsDeletedBefore implements Querier.FindAgentPoolIDsDeletedBefore.
+func (q *DBQuerier) FindAgentPoolIDsDeletedBefore(ctx context.Context, deletedBefore pgtype.Timestamptz) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindAgentPoolIDsDeletedBefore")
+	rows, err := q.conn.Query(ctx, findAgentPoolIDsDeletedBeforeSQL, deletedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("query FindAgentPoolIDsDeletedBefore: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const updateAgentPoolDeletedAtSQL = `UPDATE agent_pools
+SET deleted_at = $1
+WHERE agent_pool_id = $2
+;`
+
+// UpdateAgentPoolDeletedAt implements Querier.UpdateAgentPoolDeletedAt.
+func (q *DBQuerier) UpdateAgentPoolDeletedAt(ctx context.Context, deletedAt pgtype.Timestamptz, poolID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateAgentPoolDeletedAt")
+	cmdTag, err := q.conn.Exec(ctx, updateAgentPoolDeletedAtSQL, deletedAt, poolID)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query UpdateAgentPoolDeletedAt: %w", err)
+	}
+	return cmdTag, err
+}
+
 const deleteAgentPoolSQL = `DELETE
 FROM agent_pools
 WHERE agent_pool_id = $1
@@ -312,11 +542,14 @@ RETURNING *
 ;`
 
 type DeleteAgentPoolRow struct {
-	AgentPoolID        pgtype.Text        `json:"agent_pool_id"`
-	Name               pgtype.Text        `json:"name"`
-	CreatedAt          pgtype.Timestamptz `json:"created_at"`
-	OrganizationName   pgtype.Text        `json:"organization_name"`
-	OrganizationScoped pgtype.Bool        `json:"organization_scoped"`
+	AgentPoolID              pgtype.Text        `json:"agent_pool_id"`
+	Name                     pgtype.Text        `json:"name"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	OrganizationName         pgtype.Text        `json:"organization_name"`
+	OrganizationScoped       pgtype.Bool        `json:"organization_scoped"`
+	DeletedAt                pgtype.Timestamptz `json:"deleted_at"`
+	AllowedTerraformVersions pgtype.Text        `json:"allowed_terraform_versions"`
+	MinAgents                pgtype.Int4        `json:"min_agents"`
 }
 
 // DeleteAgentPool implements Querier.DeleteAgentPool.
@@ -330,10 +563,13 @@ func (q *DBQuerier) DeleteAgentPool(ctx context.Context, poolID pgtype.Text) (De
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (DeleteAgentPoolRow, error) {
 		var item DeleteAgentPoolRow
 		if err := row.Scan(&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Name,               // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt,          // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.OrganizationName,   // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.OrganizationScoped, // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Name,                     // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,                // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.OrganizationName,         // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationScoped,       // 'organization_scoped', 'OrganizationScoped', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DeletedAt,                // 'deleted_at', 'DeletedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllowedTerraformVersions, // 'allowed_terraform_versions', 'AllowedTerraformVersions', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MinAgents,                // 'min_agents', 'MinAgents', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -341,36 +577,68 @@ func (q *DBQuerier) DeleteAgentPool(ctx context.Context, poolID pgtype.Text) (De
 	})
 }
 
-const insertAgentPoolAllowedWorkspaceSQL = `INSERT INTO agent_pool_allowed_workspaces (
+const insertAgentPoolAllowedWorkspacesSQL = `INSERT INTO agent_pool_allowed_workspaces (
     agent_pool_id,
     workspace_id
-) VALUES (
-    $1,
-    $2
-);`
+)
+SELECT $1, workspace_id
+FROM unnest($2::text[]) t(workspace_id)
+ON CONFLICT (agent_pool_id, workspace_id) DO NOTHING
+;`
 
-// InsertAgentPoolAllowedWorkspace implements Querier.InsertAgentPoolAllowedWorkspace.
-func (q *DBQuerier) InsertAgentPoolAllowedWorkspace(ctx context.Context, poolID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error) {
-	ctx = context.WithValue(ctx, "pggen_query_name", "InsertAgentPoolAllowedWorkspace")
-	cmdTag, err := q.conn.Exec(ctx, insertAgentPoolAllowedWorkspaceSQL, poolID, workspaceID)
+// InsertAgentPoolAllowedWorkspaces implements Querier.InsertAgentPoolAllowedWorkspaces.
+func (q *DBQuerier) InsertAgentPoolAllowedWorkspaces(ctx context.Context, poolID pgtype.Text, workspaceIds []string) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertAgentPoolAllowedWorkspaces")
+	cmdTag, err := q.conn.Exec(ctx, insertAgentPoolAllowedWorkspacesSQL, poolID, workspaceIds)
 	if err != nil {
-		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertAgentPoolAllowedWorkspace: %w", err)
+		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertAgentPoolAllowedWorkspaces: %w", err)
 	}
 	return cmdTag, err
 }
 
-const deleteAgentPoolAllowedWorkspaceSQL = `DELETE
+const deleteAgentPoolAllowedWorkspacesSQL = `DELETE
 FROM agent_pool_allowed_workspaces
 WHERE agent_pool_id = $1
-AND workspace_id = $2
+AND workspace_id = ANY($2::text[])
 ;`
 
-// DeleteAgentPoolAllowedWorkspace implements Querier.DeleteAgentPoolAllowedWorkspace.
-func (q *DBQuerier) DeleteAgentPoolAllowedWorkspace(ctx context.Context, poolID pgtype.Text, workspaceID pgtype.Text) (pgconn.CommandTag, error) {
-	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteAgentPoolAllowedWorkspace")
-	cmdTag, err := q.conn.Exec(ctx, deleteAgentPoolAllowedWorkspaceSQL, poolID, workspaceID)
+// DeleteAgentPoolAllowedWorkspaces implements Querier.DeleteAgentPoolAllowedWorkspaces.
+func (q *DBQuerier) DeleteAgentPoolAllowedWorkspaces(ctx context.Context, poolID pgtype.Text, workspaceIds []string) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteAgentPoolAllowedWorkspaces")
+	cmdTag, err := q.conn.Exec(ctx, deleteAgentPoolAllowedWorkspacesSQL, poolID, workspaceIds)
 	if err != nil {
-		return pgconn.CommandTag{}, fmt.Errorf("exec query DeleteAgentPoolAllowedWorkspace: %w", err)
+		return pgconn.CommandTag{}, fmt.Errorf("exec query DeleteAgentPoolAllowedWorkspaces: %w", err)
 	}
 	return cmdTag, err
 }
+
+const findAgentPoolAssignedWorkspacesNotAllowedSQL = `SELECT w.workspace_id, w.name
+FROM workspaces w
+WHERE $1 IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
+AND   w.workspace_id <> ALL(COALESCE($2::text[], '{}'))
+ORDER BY w.name
+;`
+
+type FindAgentPoolAssignedWorkspacesNotAllowedRow struct {
+	WorkspaceID pgtype.Text `json:"workspace_id"`
+	Name        pgtype.Text `json:"name"`
+}
+
+// FindAgentPoolAssignedWorkspacesNotAllowed implements Querier.FindAgentPoolAssignedWorkspacesNotAllowed.
+func (q *DBQuerier) FindAgentPoolAssignedWorkspacesNotAllowed(ctx context.Context, poolID pgtype.Text, allowedWorkspaceIds []string) ([]FindAgentPoolAssignedWorkspacesNotAllowedRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindAgentPoolAssignedWorkspacesNotAllowed")
+	rows, err := q.conn.Query(ctx, findAgentPoolAssignedWorkspacesNotAllowedSQL, poolID, allowedWorkspaceIds)
+	if err != nil {
+		return nil, fmt.Errorf("query FindAgentPoolAssignedWorkspacesNotAllowed: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindAgentPoolAssignedWorkspacesNotAllowedRow, error) {
+		var item FindAgentPoolAssignedWorkspacesNotAllowedRow
+		if err := row.Scan(&item.WorkspaceID, // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Name, // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
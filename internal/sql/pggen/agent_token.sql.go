@@ -17,12 +17,14 @@ const insertAgentTokenSQL = `INSERT INTO agent_tokens (
     agent_token_id,
     created_at,
     description,
-    agent_pool_id
+    agent_pool_id,
+    expires_at
 ) VALUES (
     $1,
     $2,
     $3,
-    $4
+    $4,
+    $5
 );`
 
 type InsertAgentTokenParams struct {
@@ -30,12 +32,13 @@ type InsertAgentTokenParams struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	Description  pgtype.Text        `json:"description"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
 }
 
 // InsertAgentToken implements Querier.InsertAgentToken.
 func (q *DBQuerier) InsertAgentToken(ctx context.Context, params InsertAgentTokenParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertAgentToken")
-	cmdTag, err := q.conn.Exec(ctx, insertAgentTokenSQL, params.AgentTokenID, params.CreatedAt, params.Description, params.AgentPoolID)
+	cmdTag, err := q.conn.Exec(ctx, insertAgentTokenSQL, params.AgentTokenID, params.CreatedAt, params.Description, params.AgentPoolID, params.ExpiresAt)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertAgentToken: %w", err)
 	}
@@ -52,6 +55,8 @@ type FindAgentTokenByIDRow struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	Description  pgtype.Text        `json:"description"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
 }
 
 // FindAgentTokenByID implements Querier.FindAgentTokenByID.
@@ -68,6 +73,8 @@ func (q *DBQuerier) FindAgentTokenByID(ctx context.Context, agentTokenID pgtype.
 			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Description, // 'description', 'Description', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExpiresAt,   // 'expires_at', 'ExpiresAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.LastUsedAt,  // 'last_used_at', 'LastUsedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -86,6 +93,8 @@ type FindAgentTokensByAgentPoolIDRow struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	Description  pgtype.Text        `json:"description"`
 	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
 }
 
 // FindAgentTokensByAgentPoolID implements Querier.FindAgentTokensByAgentPoolID.
@@ -102,6 +111,8 @@ func (q *DBQuerier) FindAgentTokensByAgentPoolID(ctx context.Context, agentPoolI
 			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Description, // 'description', 'Description', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExpiresAt,   // 'expires_at', 'ExpiresAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.LastUsedAt,  // 'last_used_at', 'LastUsedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -109,6 +120,68 @@ func (q *DBQuerier) FindAgentTokensByAgentPoolID(ctx context.Context, agentPoolI
 	})
 }
 
+const findExpiringAgentTokensByOrganizationSQL = `SELECT
+    at.*,
+    ap.name AS agent_pool_name
+FROM agent_tokens at
+JOIN agent_pools ap USING (agent_pool_id)
+WHERE ap.organization_name = $1
+AND   ap.deleted_at IS NULL
+AND   at.expires_at IS NOT NULL
+AND   at.expires_at <= $2
+ORDER BY at.expires_at ASC
+;`
+
+type FindExpiringAgentTokensByOrganizationRow struct {
+	AgentTokenID  pgtype.Text        `json:"agent_token_id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	Description   pgtype.Text        `json:"description"`
+	AgentPoolID   pgtype.Text        `json:"agent_pool_id"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt    pgtype.Timestamptz `json:"last_used_at"`
+	AgentPoolName pgtype.Text        `json:"agent_pool_name"`
+}
+
+// FindExpiringAgentTokensByOrganization implements Querier.FindExpiringAgentTokensByOrganization.
+func (q *DBQuerier) FindExpiringAgentTokensByOrganization(ctx context.Context, organizationName pgtype.Text, expiresBefore pgtype.Timestamptz) ([]FindExpiringAgentTokensByOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindExpiringAgentTokensByOrganization")
+	rows, err := q.conn.Query(ctx, findExpiringAgentTokensByOrganizationSQL, organizationName, expiresBefore)
+	if err != nil {
+		return nil, fmt.Errorf("query FindExpiringAgentTokensByOrganization: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindExpiringAgentTokensByOrganizationRow, error) {
+		var item FindExpiringAgentTokensByOrganizationRow
+		if err := row.Scan(&item.AgentTokenID, // 'agent_token_id', 'AgentTokenID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,     // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.Description,   // 'description', 'Description', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AgentPoolID,   // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExpiresAt,     // 'expires_at', 'ExpiresAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.LastUsedAt,    // 'last_used_at', 'LastUsedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AgentPoolName, // 'agent_pool_name', 'AgentPoolName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const updateAgentTokenLastUsedAtSQL = `UPDATE agent_tokens
+SET last_used_at = $1
+WHERE agent_token_id = $2
+AND   (last_used_at IS NULL OR last_used_at < $1::timestamptz - interval '1 minute')
+;`
+
+// UpdateAgentTokenLastUsedAt implements Querier.UpdateAgentTokenLastUsedAt.
+func (q *DBQuerier) UpdateAgentTokenLastUsedAt(ctx context.Context, lastUsedAt pgtype.Timestamptz, agentTokenID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateAgentTokenLastUsedAt")
+	cmdTag, err := q.conn.Exec(ctx, updateAgentTokenLastUsedAtSQL, lastUsedAt, agentTokenID)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query UpdateAgentTokenLastUsedAt: %w", err)
+	}
+	return cmdTag, err
+}
+
 const deleteAgentTokenByIDSQL = `DELETE
 FROM agent_tokens
 WHERE agent_token_id = $1
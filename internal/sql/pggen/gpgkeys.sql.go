@@ -96,6 +96,7 @@ type ListGPGKeysRow struct {
 	KeyID            pgtype.Text        `json:"key_id"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
 }
 
 // ListGPGKeys implements Querier.ListGPGKeys.
@@ -114,6 +115,7 @@ func (q *DBQuerier) ListGPGKeys(ctx context.Context, organizationNames []string)
 			&item.KeyID,            // 'key_id', 'KeyID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.UpdatedAt,        // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.RevokedAt,        // 'revoked_at', 'RevokedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -133,6 +135,7 @@ type GetGPGKeyRow struct {
 	KeyID            pgtype.Text        `json:"key_id"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
 }
 
 // GetGPGKey implements Querier.GetGPGKey.
@@ -151,9 +154,32 @@ func (q *DBQuerier) GetGPGKey(ctx context.Context, keyID pgtype.Text, organizati
 			&item.KeyID,            // 'key_id', 'KeyID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.UpdatedAt,        // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.RevokedAt,        // 'revoked_at', 'RevokedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
 		return item, nil
 	})
 }
+
+const revokeGPGKeySQL = `UPDATE registry_gpg_keys
+SET revoked_at = $1,
+    updated_at = $1
+WHERE key_id = $2 AND
+    organization_name = $3;`
+
+type RevokeGPGKeyParams struct {
+	RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
+	KeyID            pgtype.Text        `json:"key_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+}
+
+// RevokeGPGKey implements Querier.RevokeGPGKey.
+func (q *DBQuerier) RevokeGPGKey(ctx context.Context, params RevokeGPGKeyParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "RevokeGPGKey")
+	cmdTag, err := q.conn.Exec(ctx, revokeGPGKeySQL, params.RevokedAt, params.KeyID, params.OrganizationName)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query RevokeGPGKey: %w", err)
+	}
+	return cmdTag, err
+}
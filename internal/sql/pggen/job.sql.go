@@ -16,23 +16,35 @@ var _ genericConn = (*pgx.Conn)(nil)
 const insertJobSQL = `INSERT INTO jobs (
     run_id,
     phase,
-    status
+    status,
+    agent_pool_id,
+    created_at,
+    trace_context,
+    labels
 ) VALUES (
     $1,
     $2,
-    $3
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
 );`
 
 type InsertJobParams struct {
-	RunID  pgtype.Text `json:"run_id"`
-	Phase  pgtype.Text `json:"phase"`
-	Status pgtype.Text `json:"status"`
+	RunID        pgtype.Text        `json:"run_id"`
+	Phase        pgtype.Text        `json:"phase"`
+	Status       pgtype.Text        `json:"status"`
+	AgentPoolID  pgtype.Text        `json:"agent_pool_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	TraceContext pgtype.Text        `json:"trace_context"`
+	Labels       []byte             `json:"labels"`
 }
 
 // InsertJob implements Querier.InsertJob.
 func (q *DBQuerier) InsertJob(ctx context.Context, params InsertJobParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertJob")
-	cmdTag, err := q.conn.Exec(ctx, insertJobSQL, params.RunID, params.Phase, params.Status)
+	cmdTag, err := q.conn.Exec(ctx, insertJobSQL, params.RunID, params.Phase, params.Status, params.AgentPoolID, params.CreatedAt, params.TraceContext, params.Labels)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertJob: %w", err)
 	}
@@ -45,23 +57,37 @@ const findJobsSQL = `SELECT
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
 FROM jobs j
 JOIN runs r USING (run_id)
 JOIN workspaces w USING (workspace_id)
 ;`
 
 type FindJobsRow struct {
-	RunID            pgtype.Text `json:"run_id"`
-	Phase            pgtype.Text `json:"phase"`
-	Status           pgtype.Text `json:"status"`
-	Signaled         pgtype.Bool `json:"signaled"`
-	AgentID          pgtype.Text `json:"agent_id"`
-	AgentPoolID      pgtype.Text `json:"agent_pool_id"`
-	WorkspaceID      pgtype.Text `json:"workspace_id"`
-	OrganizationName pgtype.Text `json:"organization_name"`
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
 }
 
 // FindJobs implements Querier.FindJobs.
@@ -82,6 +108,13 @@ func (q *DBQuerier) FindJobs(ctx context.Context) ([]FindJobsRow, error) {
 			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -95,9 +128,16 @@ const findJobSQL = `SELECT
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
 FROM jobs j
 JOIN runs r USING (run_id)
 JOIN workspaces w USING (workspace_id)
@@ -106,14 +146,21 @@ AND   phase = $2
 ;`
 
 type FindJobRow struct {
-	RunID            pgtype.Text `json:"run_id"`
-	Phase            pgtype.Text `json:"phase"`
-	Status           pgtype.Text `json:"status"`
-	Signaled         pgtype.Bool `json:"signaled"`
-	AgentID          pgtype.Text `json:"agent_id"`
-	AgentPoolID      pgtype.Text `json:"agent_pool_id"`
-	WorkspaceID      pgtype.Text `json:"workspace_id"`
-	OrganizationName pgtype.Text `json:"organization_name"`
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
 }
 
 // FindJob implements Querier.FindJob.
@@ -134,6 +181,13 @@ func (q *DBQuerier) FindJob(ctx context.Context, runID pgtype.Text, phase pgtype
 			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -147,9 +201,16 @@ const findJobForUpdateSQL = `SELECT
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
 FROM jobs j
 JOIN runs r USING (run_id)
 JOIN workspaces w USING (workspace_id)
@@ -159,14 +220,21 @@ FOR UPDATE OF j
 ;`
 
 type FindJobForUpdateRow struct {
-	RunID            pgtype.Text `json:"run_id"`
-	Phase            pgtype.Text `json:"phase"`
-	Status           pgtype.Text `json:"status"`
-	Signaled         pgtype.Bool `json:"signaled"`
-	AgentID          pgtype.Text `json:"agent_id"`
-	AgentPoolID      pgtype.Text `json:"agent_pool_id"`
-	WorkspaceID      pgtype.Text `json:"workspace_id"`
-	OrganizationName pgtype.Text `json:"organization_name"`
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
 }
 
 // FindJobForUpdate implements Querier.FindJobForUpdate.
@@ -187,6 +255,13 @@ func (q *DBQuerier) FindJobForUpdate(ctx context.Context, runID pgtype.Text, pha
 			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -200,9 +275,16 @@ const findAllocatedJobsSQL = `SELECT
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
 FROM jobs j
 JOIN runs r USING (run_id)
 JOIN workspaces w USING (workspace_id)
@@ -210,14 +292,21 @@ WHERE j.agent_id = $1
 AND   j.status = 'allocated';`
 
 type FindAllocatedJobsRow struct {
-	RunID            pgtype.Text `json:"run_id"`
-	Phase            pgtype.Text `json:"phase"`
-	Status           pgtype.Text `json:"status"`
-	Signaled         pgtype.Bool `json:"signaled"`
-	AgentID          pgtype.Text `json:"agent_id"`
-	AgentPoolID      pgtype.Text `json:"agent_pool_id"`
-	WorkspaceID      pgtype.Text `json:"workspace_id"`
-	OrganizationName pgtype.Text `json:"organization_name"`
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
 }
 
 // FindAllocatedJobs implements Querier.FindAllocatedJobs.
@@ -238,6 +327,240 @@ func (q *DBQuerier) FindAllocatedJobs(ctx context.Context, agentID pgtype.Text)
 			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const findActiveJobsByAgentIDSQL = `SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE j.agent_id = $1
+AND   j.status IN ('allocated', 'running');`
+
+type FindActiveJobsByAgentIDRow struct {
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
+}
+
+// FindActiveJobsByAgentID implements Querier.FindActiveJobsByAgentID.
+func (q *DBQuerier) FindActiveJobsByAgentID(ctx context.Context, agentID pgtype.Text) ([]FindActiveJobsByAgentIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindActiveJobsByAgentID")
+	rows, err := q.conn.Query(ctx, findActiveJobsByAgentIDSQL, agentID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindActiveJobsByAgentID: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindActiveJobsByAgentIDRow, error) {
+		var item FindActiveJobsByAgentIDRow
+		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Phase,            // 'phase', 'Phase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,           // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Signaled,         // 'signaled', 'Signaled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.AgentID,          // 'agent_id', 'AgentID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const findQueuedJobsByAgentPoolIDSQL = `SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE j.agent_pool_id = $1
+AND   j.status IN ('unallocated', 'allocated');`
+
+type FindQueuedJobsByAgentPoolIDRow struct {
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
+}
+
+// FindQueuedJobsByAgentPoolID implements Querier.FindQueuedJobsByAgentPoolID.
+func (q *DBQuerier) FindQueuedJobsByAgentPoolID(ctx context.Context, agentPoolID pgtype.Text) ([]FindQueuedJobsByAgentPoolIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindQueuedJobsByAgentPoolID")
+	rows, err := q.conn.Query(ctx, findQueuedJobsByAgentPoolIDSQL, agentPoolID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindQueuedJobsByAgentPoolID: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindQueuedJobsByAgentPoolIDRow, error) {
+		var item FindQueuedJobsByAgentPoolIDRow
+		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Phase,            // 'phase', 'Phase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,           // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Signaled,         // 'signaled', 'Signaled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.AgentID,          // 'agent_id', 'AgentID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const findNextUnallocatedJobForUpdateSQL = `SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE j.status = 'unallocated'
+AND   j.agent_pool_id IS NOT DISTINCT FROM $1
+AND   ($2::jsonb IS NULL OR j.labels @> $2)
+ORDER BY (r.priority = 'high' OR j.created_at < $3) DESC, j.created_at
+LIMIT 1
+FOR UPDATE OF j SKIP LOCKED
+;`
+
+type FindNextUnallocatedJobForUpdateRow struct {
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
+}
+
+type FindNextUnallocatedJobForUpdateParams struct {
+	AgentPoolID   pgtype.Text        `json:"agent_pool_id"`
+	Labels        []byte             `json:"labels"`
+	PromoteBefore pgtype.Timestamptz `json:"promote_before"`
+}
+
+// FindNextUnallocatedJobForUpdate implements Querier.FindNextUnallocatedJobForUpdate.
+func (q *DBQuerier) FindNextUnallocatedJobForUpdate(ctx context.Context, params FindNextUnallocatedJobForUpdateParams) (FindNextUnallocatedJobForUpdateRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindNextUnallocatedJobForUpdate")
+	rows, err := q.conn.Query(ctx, findNextUnallocatedJobForUpdateSQL, params.AgentPoolID, params.Labels, params.PromoteBefore)
+	if err != nil {
+		return FindNextUnallocatedJobForUpdateRow{}, fmt.Errorf("query FindNextUnallocatedJobForUpdate: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindNextUnallocatedJobForUpdateRow, error) {
+		var item FindNextUnallocatedJobForUpdateRow
+		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Phase,            // 'phase', 'Phase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,           // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Signaled,         // 'signaled', 'Signaled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.AgentID,          // 'agent_id', 'AgentID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -251,7 +574,7 @@ FROM runs r, workspaces w
 WHERE j.run_id = r.run_id
 AND   r.workspace_id = w.workspace_id
 AND   j.agent_id = $1
-AND   j.status = 'running'
+AND   j.status IN ('running', 'canceled')
 AND   j.signaled IS NOT NULL
 RETURNING
     j.run_id,
@@ -259,20 +582,34 @@ RETURNING
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
 ;`
 
 type FindAndUpdateSignaledJobsRow struct {
-	RunID            pgtype.Text `json:"run_id"`
-	Phase            pgtype.Text `json:"phase"`
-	Status           pgtype.Text `json:"status"`
-	Signaled         pgtype.Bool `json:"signaled"`
-	AgentID          pgtype.Text `json:"agent_id"`
-	AgentPoolID      pgtype.Text `json:"agent_pool_id"`
-	WorkspaceID      pgtype.Text `json:"workspace_id"`
-	OrganizationName pgtype.Text `json:"organization_name"`
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
 }
 
 // FindAndUpdateSignaledJobs implements Querier.FindAndUpdateSignaledJobs.
@@ -293,6 +630,165 @@ func (q *DBQuerier) FindAndUpdateSignaledJobs(ctx context.Context, agentID pgtyp
 			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const updateQueueSLABreachedJobsSQL = `UPDATE jobs AS j
+SET sla_breached_at = $1
+FROM runs r, workspaces w, organizations o
+WHERE j.run_id = r.run_id
+AND   r.workspace_id = w.workspace_id
+AND   w.organization_name = o.name
+AND   j.status = 'unallocated'
+AND   j.sla_breached_at IS NULL
+AND   o.queue_time_sla > 0
+AND   j.created_at + make_interval(mins => o.queue_time_sla) < $1
+RETURNING
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+;`
+
+type UpdateQueueSLABreachedJobsRow struct {
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
+}
+
+// UpdateQueueSLABreachedJobs implements Querier.UpdateQueueSLABreachedJobs.
+func (q *DBQuerier) UpdateQueueSLABreachedJobs(ctx context.Context, now pgtype.Timestamptz) ([]UpdateQueueSLABreachedJobsRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateQueueSLABreachedJobs")
+	rows, err := q.conn.Query(ctx, updateQueueSLABreachedJobsSQL, now)
+	if err != nil {
+		return nil, fmt.Errorf("query UpdateQueueSLABreachedJobs: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (UpdateQueueSLABreachedJobsRow, error) {
+		var item UpdateQueueSLABreachedJobsRow
+		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Phase,            // 'phase', 'Phase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,           // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Signaled,         // 'signaled', 'Signaled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.AgentID,          // 'agent_id', 'AgentID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const findQueueSLABreachedJobsByOrganizationSQL = `SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE w.organization_name = $1
+AND   j.sla_breached_at IS NOT NULL
+ORDER BY j.sla_breached_at DESC
+;`
+
+type FindQueueSLABreachedJobsByOrganizationRow struct {
+	RunID            pgtype.Text        `json:"run_id"`
+	Phase            pgtype.Text        `json:"phase"`
+	Status           pgtype.Text        `json:"status"`
+	Signaled         pgtype.Bool        `json:"signaled"`
+	AgentID          pgtype.Text        `json:"agent_id"`
+	AgentPoolID      pgtype.Text        `json:"agent_pool_id"`
+	WorkspaceID      pgtype.Text        `json:"workspace_id"`
+	OrganizationName pgtype.Text        `json:"organization_name"`
+	TerraformVersion pgtype.Text        `json:"terraform_version"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt      pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt    pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext     pgtype.Text        `json:"trace_context"`
+	Labels           []byte             `json:"labels"`
+	Priority         pgtype.Text        `json:"priority"`
+}
+
+// FindQueueSLABreachedJobsByOrganization implements Querier.FindQueueSLABreachedJobsByOrganization.
+func (q *DBQuerier) FindQueueSLABreachedJobsByOrganization(ctx context.Context, organizationName pgtype.Text) ([]FindQueueSLABreachedJobsByOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindQueueSLABreachedJobsByOrganization")
+	rows, err := q.conn.Query(ctx, findQueueSLABreachedJobsByOrganizationSQL, organizationName)
+	if err != nil {
+		return nil, fmt.Errorf("query FindQueueSLABreachedJobsByOrganization: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindQueueSLABreachedJobsByOrganizationRow, error) {
+		var item FindQueueSLABreachedJobsByOrganizationRow
+		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Phase,            // 'phase', 'Phase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,           // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Signaled,         // 'signaled', 'Signaled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.AgentID,          // 'agent_id', 'AgentID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AgentPoolID,      // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.WorkspaceID,      // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.OrganizationName, // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion, // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,        // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,      // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt,    // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,     // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,           // 'labels', 'Labels', '[]byte', '', '[]byte'
+			&item.Priority,         // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -303,31 +799,41 @@ func (q *DBQuerier) FindAndUpdateSignaledJobs(ctx context.Context, agentID pgtyp
 const updateJobSQL = `UPDATE jobs
 SET status   = $1,
     signaled = $2,
-    agent_id = $3
-WHERE run_id = $4
-AND   phase = $5
+    agent_id = $3,
+    agent_pool_id = $4,
+    allocated_at = $5
+WHERE run_id = $6
+AND   phase = $7
 RETURNING *;`
 
 type UpdateJobParams struct {
-	Status   pgtype.Text `json:"status"`
-	Signaled pgtype.Bool `json:"signaled"`
-	AgentID  pgtype.Text `json:"agent_id"`
-	RunID    pgtype.Text `json:"run_id"`
-	Phase    pgtype.Text `json:"phase"`
+	Status      pgtype.Text        `json:"status"`
+	Signaled    pgtype.Bool        `json:"signaled"`
+	AgentID     pgtype.Text        `json:"agent_id"`
+	AgentPoolID pgtype.Text        `json:"agent_pool_id"`
+	AllocatedAt pgtype.Timestamptz `json:"allocated_at"`
+	RunID       pgtype.Text        `json:"run_id"`
+	Phase       pgtype.Text        `json:"phase"`
 }
 
 type UpdateJobRow struct {
-	RunID    pgtype.Text `json:"run_id"`
-	Phase    pgtype.Text `json:"phase"`
-	Status   pgtype.Text `json:"status"`
-	AgentID  pgtype.Text `json:"agent_id"`
-	Signaled pgtype.Bool `json:"signaled"`
+	RunID         pgtype.Text        `json:"run_id"`
+	Phase         pgtype.Text        `json:"phase"`
+	Status        pgtype.Text        `json:"status"`
+	AgentID       pgtype.Text        `json:"agent_id"`
+	Signaled      pgtype.Bool        `json:"signaled"`
+	AgentPoolID   pgtype.Text        `json:"agent_pool_id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	AllocatedAt   pgtype.Timestamptz `json:"allocated_at"`
+	SlaBreachedAt pgtype.Timestamptz `json:"sla_breached_at"`
+	TraceContext  pgtype.Text        `json:"trace_context"`
+	Labels        []byte             `json:"labels"`
 }
 
 // UpdateJob implements Querier.UpdateJob.
 func (q *DBQuerier) UpdateJob(ctx context.Context, params UpdateJobParams) (UpdateJobRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateJob")
-	rows, err := q.conn.Query(ctx, updateJobSQL, params.Status, params.Signaled, params.AgentID, params.RunID, params.Phase)
+	rows, err := q.conn.Query(ctx, updateJobSQL, params.Status, params.Signaled, params.AgentID, params.AgentPoolID, params.AllocatedAt, params.RunID, params.Phase)
 	if err != nil {
 		return UpdateJobRow{}, fmt.Errorf("query UpdateJob: %w", err)
 	}
@@ -335,10 +841,107 @@ func (q *DBQuerier) UpdateJob(ctx context.Context, params UpdateJobParams) (Upda
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (UpdateJobRow, error) {
 		var item UpdateJobRow
 		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Phase,    // 'phase', 'Phase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Status,   // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.AgentID,  // 'agent_id', 'AgentID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Signaled, // 'signaled', 'Signaled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Phase,         // 'phase', 'Phase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,        // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AgentID,       // 'agent_id', 'AgentID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Signaled,      // 'signaled', 'Signaled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.AgentPoolID,   // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,     // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.AllocatedAt,   // 'allocated_at', 'AllocatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SlaBreachedAt, // 'sla_breached_at', 'SlaBreachedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.TraceContext,  // 'trace_context', 'TraceContext', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Labels,        // 'labels', 'Labels', '[]byte', '', '[]byte'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const findOrphanedJobsSQL = `SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.agent_id
+FROM jobs j
+WHERE NOT EXISTS (SELECT FROM runs r WHERE r.run_id = j.run_id)
+;`
+
+type FindOrphanedJobsRow struct {
+	RunID   pgtype.Text `json:"run_id"`
+	Phase   pgtype.Text `json:"phase"`
+	Status  pgtype.Text `json:"status"`
+	AgentID pgtype.Text `json:"agent_id"`
+}
+
+// FindOrphanedJobs implements Querier.FindOrphanedJobs.
+func (q *DBQuerier) FindOrphanedJobs(ctx context.Context) ([]FindOrphanedJobsRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrphanedJobs")
+	rows, err := q.conn.Query(ctx, findOrphanedJobsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("query FindOrphanedJobs: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindOrphanedJobsRow, error) {
+		var item FindOrphanedJobsRow
+		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Phase,   // 'phase', 'Phase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,  // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AgentID, // 'agent_id', 'AgentID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const deleteOrphanedJobSQL = `DELETE FROM jobs j
+WHERE j.run_id = $1
+AND   j.phase = $2
+AND   NOT EXISTS (SELECT FROM runs r WHERE r.run_id = j.run_id)
+;`
+
+// DeleteOrphanedJob implements Querier.DeleteOrphanedJob.
+func (q *DBQuerier) DeleteOrphanedJob(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteOrphanedJob")
+	cmdTag, err := q.conn.Exec(ctx, deleteOrphanedJobSQL, runID, phase)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query DeleteOrphanedJob: %w", err)
+	}
+	return cmdTag, err
+}
+
+const countJobsByOrganizationSQL = `SELECT
+    j.status,
+    j.agent_pool_id,
+    count(*)::int AS count
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE w.organization_name = $1
+AND   ($2::jsonb IS NULL OR j.labels @> $2)
+GROUP BY j.status, j.agent_pool_id
+;`
+
+type CountJobsByOrganizationRow struct {
+	Status      pgtype.Text `json:"status"`
+	AgentPoolID pgtype.Text `json:"agent_pool_id"`
+	Count       pgtype.Int4 `json:"count"`
+}
+
+// CountJobsByOrganization implements Querier.CountJobsByOrganization.
+func (q *DBQuerier) CountJobsByOrganization(ctx context.Context, organizationName pgtype.Text, labels []byte) ([]CountJobsByOrganizationRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "CountJobsByOrganization")
+	rows, err := q.conn.Query(ctx, countJobsByOrganizationSQL, organizationName, labels)
+	if err != nil {
+		return nil, fmt.Errorf("query CountJobsByOrganization: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (CountJobsByOrganizationRow, error) {
+		var item CountJobsByOrganizationRow
+		if err := row.Scan(&item.Status, // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AgentPoolID, // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Count,       // 'count', 'Count', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -37,6 +37,53 @@ func NewQuerierWithTracing(base Querier, instance string, spanDecorator ...func(
 	return d
 }
 
+// ClaimAgentDiagnosticsBundle implements Querier
+func (_d QuerierWithTracing) ClaimAgentDiagnosticsBundle(ctx context.Context, deliveredAt pgtype.Timestamptz, agentID pgtype.Text) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.ClaimAgentDiagnosticsBundle")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"deliveredAt": deliveredAt,
+				"agentID":     agentID}, map[string]interface{}{
+				"ta1": ta1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.ClaimAgentDiagnosticsBundle(ctx, deliveredAt, agentID)
+}
+
+// ClaimIdempotencyKey implements Querier
+func (_d QuerierWithTracing) ClaimIdempotencyKey(ctx context.Context, params ClaimIdempotencyKeyParams) (c1 ClaimIdempotencyKeyRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.ClaimIdempotencyKey")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c1":  c1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.ClaimIdempotencyKey(ctx, params)
+}
+
 // CountConfigurationVersionsByWorkspaceID implements Querier
 func (_d QuerierWithTracing) CountConfigurationVersionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (i1 pgtype.Int8, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.CountConfigurationVersionsByWorkspaceID")
@@ -60,14 +107,62 @@ func (_d QuerierWithTracing) CountConfigurationVersionsByWorkspaceID(ctx context
 	return _d.Querier.CountConfigurationVersionsByWorkspaceID(ctx, workspaceID)
 }
 
+// CountJobsByOrganization implements Querier
+func (_d QuerierWithTracing) CountJobsByOrganization(ctx context.Context, organizationName pgtype.Text, labels []byte) (ca1 []CountJobsByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.CountJobsByOrganization")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName,
+				"labels":           labels}, map[string]interface{}{
+				"ca1": ca1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.CountJobsByOrganization(ctx, organizationName, labels)
+}
+
+// CountModuleDownloadsByModuleID implements Querier
+func (_d QuerierWithTracing) CountModuleDownloadsByModuleID(ctx context.Context, moduleID pgtype.Text) (ca1 []CountModuleDownloadsByModuleIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.CountModuleDownloadsByModuleID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"moduleID": moduleID}, map[string]interface{}{
+				"ca1": ca1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.CountModuleDownloadsByModuleID(ctx, moduleID)
+}
+
 // CountOrganizations implements Querier
-func (_d QuerierWithTracing) CountOrganizations(ctx context.Context, names []string) (i1 pgtype.Int8, err error) {
+func (_d QuerierWithTracing) CountOrganizations(ctx context.Context, names []string, search pgtype.Text) (i1 pgtype.Int8, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.CountOrganizations")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":   ctx,
-				"names": names}, map[string]interface{}{
+				"ctx":    ctx,
+				"names":  names,
+				"search": search}, map[string]interface{}{
 				"i1":  i1,
 				"err": err})
 		} else if err != nil {
@@ -80,7 +175,7 @@ func (_d QuerierWithTracing) CountOrganizations(ctx context.Context, names []str
 
 		_span.End()
 	}()
-	return _d.Querier.CountOrganizations(ctx, names)
+	return _d.Querier.CountOrganizations(ctx, names, search)
 }
 
 // CountRuns implements Querier
@@ -245,6 +340,52 @@ func (_d QuerierWithTracing) DeleteAgent(ctx context.Context, agentID pgtype.Tex
 	return _d.Querier.DeleteAgent(ctx, agentID)
 }
 
+// DeleteAgentBootstrapTokenByID implements Querier
+func (_d QuerierWithTracing) DeleteAgentBootstrapTokenByID(ctx context.Context, agentBootstrapTokenID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteAgentBootstrapTokenByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":                   ctx,
+				"agentBootstrapTokenID": agentBootstrapTokenID}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteAgentBootstrapTokenByID(ctx, agentBootstrapTokenID)
+}
+
+// DeleteAgentCredentialByAgentID implements Querier
+func (_d QuerierWithTracing) DeleteAgentCredentialByAgentID(ctx context.Context, agentID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteAgentCredentialByAgentID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":     ctx,
+				"agentID": agentID}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteAgentCredentialByAgentID(ctx, agentID)
+}
+
 // DeleteAgentPool implements Querier
 func (_d QuerierWithTracing) DeleteAgentPool(ctx context.Context, poolID pgtype.Text) (d1 DeleteAgentPoolRow, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteAgentPool")
@@ -268,15 +409,62 @@ func (_d QuerierWithTracing) DeleteAgentPool(ctx context.Context, poolID pgtype.
 	return _d.Querier.DeleteAgentPool(ctx, poolID)
 }
 
-// DeleteAgentPoolAllowedWorkspace implements Querier
-func (_d QuerierWithTracing) DeleteAgentPoolAllowedWorkspace(ctx context.Context, poolID pgtype.Text, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteAgentPoolAllowedWorkspace")
+// DeleteAgentPoolAllowedWorkspaces implements Querier
+func (_d QuerierWithTracing) DeleteAgentPoolAllowedWorkspaces(ctx context.Context, poolID pgtype.Text, workspaceIds []string) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteAgentPoolAllowedWorkspaces")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":          ctx,
+				"poolID":       poolID,
+				"workspaceIds": workspaceIds}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteAgentPoolAllowedWorkspaces(ctx, poolID, workspaceIds)
+}
+
+// DeleteAgentPoolAutoscaler implements Querier
+func (_d QuerierWithTracing) DeleteAgentPoolAutoscaler(ctx context.Context, agentPoolID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteAgentPoolAutoscaler")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":         ctx,
-				"poolID":      poolID,
-				"workspaceID": workspaceID}, map[string]interface{}{
+				"agentPoolID": agentPoolID}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteAgentPoolAutoscaler(ctx, agentPoolID)
+}
+
+// DeleteAgentStatusHistoryBefore implements Querier
+func (_d QuerierWithTracing) DeleteAgentStatusHistoryBefore(ctx context.Context, changedBefore pgtype.Timestamptz, limit pgtype.Int8) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteAgentStatusHistoryBefore")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"changedBefore": changedBefore,
+				"limit":         limit}, map[string]interface{}{
 				"c2":  c2,
 				"err": err})
 		} else if err != nil {
@@ -289,7 +477,7 @@ func (_d QuerierWithTracing) DeleteAgentPoolAllowedWorkspace(ctx context.Context
 
 		_span.End()
 	}()
-	return _d.Querier.DeleteAgentPoolAllowedWorkspace(ctx, poolID, workspaceID)
+	return _d.Querier.DeleteAgentStatusHistoryBefore(ctx, changedBefore, limit)
 }
 
 // DeleteAgentTokenByID implements Querier
@@ -315,6 +503,29 @@ func (_d QuerierWithTracing) DeleteAgentTokenByID(ctx context.Context, agentToke
 	return _d.Querier.DeleteAgentTokenByID(ctx, agentTokenID)
 }
 
+// DeleteAuthLockout implements Querier
+func (_d QuerierWithTracing) DeleteAuthLockout(ctx context.Context, lockoutKey pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteAuthLockout")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"lockoutKey": lockoutKey}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteAuthLockout(ctx, lockoutKey)
+}
+
 // DeleteConfigurationVersionByID implements Querier
 func (_d QuerierWithTracing) DeleteConfigurationVersionByID(ctx context.Context, id pgtype.Text) (t1 pgtype.Text, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteConfigurationVersionByID")
@@ -338,6 +549,30 @@ func (_d QuerierWithTracing) DeleteConfigurationVersionByID(ctx context.Context,
 	return _d.Querier.DeleteConfigurationVersionByID(ctx, id)
 }
 
+// DeleteEventSinkOutbox implements Querier
+func (_d QuerierWithTracing) DeleteEventSinkOutbox(ctx context.Context, sink pgtype.Text, eventSinkOutboxID pgtype.Int8) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteEventSinkOutbox")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":               ctx,
+				"sink":              sink,
+				"eventSinkOutboxID": eventSinkOutboxID}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteEventSinkOutbox(ctx, sink, eventSinkOutboxID)
+}
+
 // DeleteGPGKey implements Querier
 func (_d QuerierWithTracing) DeleteGPGKey(ctx context.Context, keyID pgtype.Text, organizationName pgtype.Text) (c2 pgconn.CommandTag, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteGPGKey")
@@ -385,6 +620,29 @@ func (_d QuerierWithTracing) DeleteGithubApp(ctx context.Context, githubAppID pg
 	return _d.Querier.DeleteGithubApp(ctx, githubAppID)
 }
 
+// DeleteIdempotencyKeysCreatedBefore implements Querier
+func (_d QuerierWithTracing) DeleteIdempotencyKeysCreatedBefore(ctx context.Context, createdBefore pgtype.Timestamptz) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteIdempotencyKeysCreatedBefore")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"createdBefore": createdBefore}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteIdempotencyKeysCreatedBefore(ctx, createdBefore)
+}
+
 // DeleteModuleByID implements Querier
 func (_d QuerierWithTracing) DeleteModuleByID(ctx context.Context, moduleID pgtype.Text) (t1 pgtype.Text, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteModuleByID")
@@ -431,6 +689,29 @@ func (_d QuerierWithTracing) DeleteModuleConnectionByID(ctx context.Context, mod
 	return _d.Querier.DeleteModuleConnectionByID(ctx, moduleID)
 }
 
+// DeleteModuleTemplateByID implements Querier
+func (_d QuerierWithTracing) DeleteModuleTemplateByID(ctx context.Context, moduleTemplateID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteModuleTemplateByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"moduleTemplateID": moduleTemplateID}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteModuleTemplateByID(ctx, moduleTemplateID)
+}
+
 // DeleteModuleVersionByID implements Querier
 func (_d QuerierWithTracing) DeleteModuleVersionByID(ctx context.Context, moduleVersionID pgtype.Text) (t1 pgtype.Text, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteModuleVersionByID")
@@ -454,6 +735,29 @@ func (_d QuerierWithTracing) DeleteModuleVersionByID(ctx context.Context, module
 	return _d.Querier.DeleteModuleVersionByID(ctx, moduleVersionID)
 }
 
+// DeleteModuleVersionPolicyByID implements Querier
+func (_d QuerierWithTracing) DeleteModuleVersionPolicyByID(ctx context.Context, moduleVersionPolicyID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteModuleVersionPolicyByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":                   ctx,
+				"moduleVersionPolicyID": moduleVersionPolicyID}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteModuleVersionPolicyByID(ctx, moduleVersionPolicyID)
+}
+
 // DeleteNotificationConfigurationByID implements Querier
 func (_d QuerierWithTracing) DeleteNotificationConfigurationByID(ctx context.Context, notificationConfigurationID pgtype.Text) (t1 pgtype.Text, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteNotificationConfigurationByID")
@@ -523,15 +827,16 @@ func (_d QuerierWithTracing) DeleteOrganizationByName(ctx context.Context, name
 	return _d.Querier.DeleteOrganizationByName(ctx, name)
 }
 
-// DeleteRepohookByID implements Querier
-func (_d QuerierWithTracing) DeleteRepohookByID(ctx context.Context, repohookID pgtype.UUID) (d1 DeleteRepohookByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteRepohookByID")
+// DeleteOrphanedJob implements Querier
+func (_d QuerierWithTracing) DeleteOrphanedJob(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteOrphanedJob")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":        ctx,
-				"repohookID": repohookID}, map[string]interface{}{
-				"d1":  d1,
+				"ctx":   ctx,
+				"runID": runID,
+				"phase": phase}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -543,17 +848,40 @@ func (_d QuerierWithTracing) DeleteRepohookByID(ctx context.Context, repohookID
 
 		_span.End()
 	}()
-	return _d.Querier.DeleteRepohookByID(ctx, repohookID)
+	return _d.Querier.DeleteOrphanedJob(ctx, runID, phase)
 }
 
-// DeleteRunByID implements Querier
-func (_d QuerierWithTracing) DeleteRunByID(ctx context.Context, runID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteRunByID")
+// DeleteRepohookByID implements Querier
+func (_d QuerierWithTracing) DeleteRepohookByID(ctx context.Context, repohookID pgtype.UUID) (d1 DeleteRepohookByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteRepohookByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":   ctx,
-				"runID": runID}, map[string]interface{}{
+				"ctx":        ctx,
+				"repohookID": repohookID}, map[string]interface{}{
+				"d1":  d1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteRepohookByID(ctx, repohookID)
+}
+
+// DeleteRunByID implements Querier
+func (_d QuerierWithTracing) DeleteRunByID(ctx context.Context, runID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteRunByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
 				"t1":  t1,
 				"err": err})
 		} else if err != nil {
@@ -569,6 +897,75 @@ func (_d QuerierWithTracing) DeleteRunByID(ctx context.Context, runID pgtype.Tex
 	return _d.Querier.DeleteRunByID(ctx, runID)
 }
 
+// DeleteRunCommentByID implements Querier
+func (_d QuerierWithTracing) DeleteRunCommentByID(ctx context.Context, commentID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteRunCommentByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":       ctx,
+				"commentID": commentID}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteRunCommentByID(ctx, commentID)
+}
+
+// DeleteRunLabels implements Querier
+func (_d QuerierWithTracing) DeleteRunLabels(ctx context.Context, runID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteRunLabels")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteRunLabels(ctx, runID)
+}
+
+// DeleteSCIMTokenByOrganization implements Querier
+func (_d QuerierWithTracing) DeleteSCIMTokenByOrganization(ctx context.Context, organizationName pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteSCIMTokenByOrganization")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteSCIMTokenByOrganization(ctx, organizationName)
+}
+
 // DeleteStateVersionByID implements Querier
 func (_d QuerierWithTracing) DeleteStateVersionByID(ctx context.Context, stateVersionID pgtype.Text) (t1 pgtype.Text, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteStateVersionByID")
@@ -663,6 +1060,29 @@ func (_d QuerierWithTracing) DeleteTeamMembership(ctx context.Context, usernames
 	return _d.Querier.DeleteTeamMembership(ctx, usernames, teamID)
 }
 
+// DeleteTeamMembershipsByUsername implements Querier
+func (_d QuerierWithTracing) DeleteTeamMembershipsByUsername(ctx context.Context, username pgtype.Text) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteTeamMembershipsByUsername")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"username": username}, map[string]interface{}{
+				"ta1": ta1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteTeamMembershipsByUsername(ctx, username)
+}
+
 // DeleteTeamTokenByID implements Querier
 func (_d QuerierWithTracing) DeleteTeamTokenByID(ctx context.Context, teamID pgtype.Text) (t1 pgtype.Text, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteTeamTokenByID")
@@ -709,6 +1129,29 @@ func (_d QuerierWithTracing) DeleteTokenByID(ctx context.Context, tokenID pgtype
 	return _d.Querier.DeleteTokenByID(ctx, tokenID)
 }
 
+// DeleteTokensByUsername implements Querier
+func (_d QuerierWithTracing) DeleteTokensByUsername(ctx context.Context, username pgtype.Text) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteTokensByUsername")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"username": username}, map[string]interface{}{
+				"ta1": ta1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteTokensByUsername(ctx, username)
+}
+
 // DeleteUserByID implements Querier
 func (_d QuerierWithTracing) DeleteUserByID(ctx context.Context, userID pgtype.Text) (t1 pgtype.Text, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteUserByID")
@@ -895,6 +1338,30 @@ func (_d QuerierWithTracing) DeleteVariableSetWorkspaces(ctx context.Context, va
 	return _d.Querier.DeleteVariableSetWorkspaces(ctx, variableSetID)
 }
 
+// DeleteWorkspaceAssessmentBefore implements Querier
+func (_d QuerierWithTracing) DeleteWorkspaceAssessmentBefore(ctx context.Context, workspaceID pgtype.Text, before pgtype.Timestamptz) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteWorkspaceAssessmentBefore")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID,
+				"before":      before}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteWorkspaceAssessmentBefore(ctx, workspaceID, before)
+}
+
 // DeleteWorkspaceByID implements Querier
 func (_d QuerierWithTracing) DeleteWorkspaceByID(ctx context.Context, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteWorkspaceByID")
@@ -941,6 +1408,29 @@ func (_d QuerierWithTracing) DeleteWorkspaceConnectionByID(ctx context.Context,
 	return _d.Querier.DeleteWorkspaceConnectionByID(ctx, workspaceID)
 }
 
+// DeleteWorkspaceOutputMapping implements Querier
+func (_d QuerierWithTracing) DeleteWorkspaceOutputMapping(ctx context.Context, outputMappingID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteWorkspaceOutputMapping")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":             ctx,
+				"outputMappingID": outputMappingID}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.DeleteWorkspaceOutputMapping(ctx, outputMappingID)
+}
+
 // DeleteWorkspacePermissionByID implements Querier
 func (_d QuerierWithTracing) DeleteWorkspacePermissionByID(ctx context.Context, workspaceID pgtype.Text, teamID pgtype.Text) (c2 pgconn.CommandTag, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.DeleteWorkspacePermissionByID")
@@ -1058,15 +1548,15 @@ func (_d QuerierWithTracing) DownloadConfigurationVersion(ctx context.Context, c
 	return _d.Querier.DownloadConfigurationVersion(ctx, configurationVersionID)
 }
 
-// FindAgentByID implements Querier
-func (_d QuerierWithTracing) FindAgentByID(ctx context.Context, agentID pgtype.Text) (f1 FindAgentByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentByID")
+// FindActiveAuthLockouts implements Querier
+func (_d QuerierWithTracing) FindActiveAuthLockouts(ctx context.Context, now pgtype.Timestamptz) (fa1 []FindActiveAuthLockoutsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindActiveAuthLockouts")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"agentID": agentID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx": ctx,
+				"now": now}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1078,18 +1568,18 @@ func (_d QuerierWithTracing) FindAgentByID(ctx context.Context, agentID pgtype.T
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentByID(ctx, agentID)
+	return _d.Querier.FindActiveAuthLockouts(ctx, now)
 }
 
-// FindAgentByIDForUpdate implements Querier
-func (_d QuerierWithTracing) FindAgentByIDForUpdate(ctx context.Context, agentID pgtype.Text) (f1 FindAgentByIDForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentByIDForUpdate")
+// FindActiveJobsByAgentID implements Querier
+func (_d QuerierWithTracing) FindActiveJobsByAgentID(ctx context.Context, agentID pgtype.Text) (fa1 []FindActiveJobsByAgentIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindActiveJobsByAgentID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":     ctx,
 				"agentID": agentID}, map[string]interface{}{
-				"f1":  f1,
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1101,17 +1591,17 @@ func (_d QuerierWithTracing) FindAgentByIDForUpdate(ctx context.Context, agentID
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentByIDForUpdate(ctx, agentID)
+	return _d.Querier.FindActiveJobsByAgentID(ctx, agentID)
 }
 
-// FindAgentPool implements Querier
-func (_d QuerierWithTracing) FindAgentPool(ctx context.Context, poolID pgtype.Text) (f1 FindAgentPoolRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPool")
+// FindAgentBootstrapTokenByID implements Querier
+func (_d QuerierWithTracing) FindAgentBootstrapTokenByID(ctx context.Context, agentBootstrapTokenID pgtype.Text) (f1 FindAgentBootstrapTokenByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentBootstrapTokenByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"poolID": poolID}, map[string]interface{}{
+				"ctx":                   ctx,
+				"agentBootstrapTokenID": agentBootstrapTokenID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -1124,18 +1614,18 @@ func (_d QuerierWithTracing) FindAgentPool(ctx context.Context, poolID pgtype.Te
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentPool(ctx, poolID)
+	return _d.Querier.FindAgentBootstrapTokenByID(ctx, agentBootstrapTokenID)
 }
 
-// FindAgentPoolByAgentTokenID implements Querier
-func (_d QuerierWithTracing) FindAgentPoolByAgentTokenID(ctx context.Context, agentTokenID pgtype.Text) (f1 FindAgentPoolByAgentTokenIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolByAgentTokenID")
+// FindAgentBootstrapTokensByAgentPoolID implements Querier
+func (_d QuerierWithTracing) FindAgentBootstrapTokensByAgentPoolID(ctx context.Context, agentPoolID pgtype.Text) (fa1 []FindAgentBootstrapTokensByAgentPoolIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentBootstrapTokensByAgentPoolID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":          ctx,
-				"agentTokenID": agentTokenID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":         ctx,
+				"agentPoolID": agentPoolID}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1147,17 +1637,18 @@ func (_d QuerierWithTracing) FindAgentPoolByAgentTokenID(ctx context.Context, ag
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentPoolByAgentTokenID(ctx, agentTokenID)
+	return _d.Querier.FindAgentBootstrapTokensByAgentPoolID(ctx, agentPoolID)
 }
 
-// FindAgentPools implements Querier
-func (_d QuerierWithTracing) FindAgentPools(ctx context.Context) (fa1 []FindAgentPoolsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPools")
+// FindAgentByID implements Querier
+func (_d QuerierWithTracing) FindAgentByID(ctx context.Context, agentID pgtype.Text) (f1 FindAgentByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":     ctx,
+				"agentID": agentID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1169,18 +1660,18 @@ func (_d QuerierWithTracing) FindAgentPools(ctx context.Context) (fa1 []FindAgen
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentPools(ctx)
+	return _d.Querier.FindAgentByID(ctx, agentID)
 }
 
-// FindAgentPoolsByOrganization implements Querier
-func (_d QuerierWithTracing) FindAgentPoolsByOrganization(ctx context.Context, params FindAgentPoolsByOrganizationParams) (fa1 []FindAgentPoolsByOrganizationRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolsByOrganization")
+// FindAgentByIDForUpdate implements Querier
+func (_d QuerierWithTracing) FindAgentByIDForUpdate(ctx context.Context, agentID pgtype.Text) (f1 FindAgentByIDForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentByIDForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":     ctx,
+				"agentID": agentID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1192,18 +1683,18 @@ func (_d QuerierWithTracing) FindAgentPoolsByOrganization(ctx context.Context, p
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentPoolsByOrganization(ctx, params)
+	return _d.Querier.FindAgentByIDForUpdate(ctx, agentID)
 }
 
-// FindAgentTokenByID implements Querier
-func (_d QuerierWithTracing) FindAgentTokenByID(ctx context.Context, agentTokenID pgtype.Text) (f1 FindAgentTokenByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentTokenByID")
+// FindAgentCredentialAgentIDsByOrganization implements Querier
+func (_d QuerierWithTracing) FindAgentCredentialAgentIDsByOrganization(ctx context.Context, organizationName pgtype.Text) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentCredentialAgentIDsByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":          ctx,
-				"agentTokenID": agentTokenID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"ta1": ta1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1215,18 +1706,18 @@ func (_d QuerierWithTracing) FindAgentTokenByID(ctx context.Context, agentTokenI
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentTokenByID(ctx, agentTokenID)
+	return _d.Querier.FindAgentCredentialAgentIDsByOrganization(ctx, organizationName)
 }
 
-// FindAgentTokensByAgentPoolID implements Querier
-func (_d QuerierWithTracing) FindAgentTokensByAgentPoolID(ctx context.Context, agentPoolID pgtype.Text) (fa1 []FindAgentTokensByAgentPoolIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentTokensByAgentPoolID")
+// FindAgentCredentialByAgentID implements Querier
+func (_d QuerierWithTracing) FindAgentCredentialByAgentID(ctx context.Context, agentID pgtype.Text) (f1 FindAgentCredentialByAgentIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentCredentialByAgentID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"agentPoolID": agentPoolID}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":     ctx,
+				"agentID": agentID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1238,17 +1729,18 @@ func (_d QuerierWithTracing) FindAgentTokensByAgentPoolID(ctx context.Context, a
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentTokensByAgentPoolID(ctx, agentPoolID)
+	return _d.Querier.FindAgentCredentialByAgentID(ctx, agentID)
 }
 
-// FindAgents implements Querier
-func (_d QuerierWithTracing) FindAgents(ctx context.Context) (fa1 []FindAgentsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgents")
+// FindAgentDiagnosticsBundleByID implements Querier
+func (_d QuerierWithTracing) FindAgentDiagnosticsBundleByID(ctx context.Context, agentDiagnosticsBundleID pgtype.Text) (f1 FindAgentDiagnosticsBundleByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentDiagnosticsBundleByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":                      ctx,
+				"agentDiagnosticsBundleID": agentDiagnosticsBundleID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1260,12 +1752,12 @@ func (_d QuerierWithTracing) FindAgents(ctx context.Context) (fa1 []FindAgentsRo
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgents(ctx)
+	return _d.Querier.FindAgentDiagnosticsBundleByID(ctx, agentDiagnosticsBundleID)
 }
 
-// FindAgentsByOrganization implements Querier
-func (_d QuerierWithTracing) FindAgentsByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindAgentsByOrganizationRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentsByOrganization")
+// FindAgentDiagnosticsBundlesByOrganization implements Querier
+func (_d QuerierWithTracing) FindAgentDiagnosticsBundlesByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindAgentDiagnosticsBundlesByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentDiagnosticsBundlesByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -1283,20 +1775,20 @@ func (_d QuerierWithTracing) FindAgentsByOrganization(ctx context.Context, organ
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentsByOrganization(ctx, organizationName)
+	return _d.Querier.FindAgentDiagnosticsBundlesByOrganization(ctx, organizationName)
 }
 
-// FindAgentsByPoolID implements Querier
-func (_d QuerierWithTracing) FindAgentsByPoolID(ctx context.Context, agentPoolID pgtype.Text) (fa1 []FindAgentsByPoolIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentsByPoolID")
+// FindAgentPool implements Querier
+func (_d QuerierWithTracing) FindAgentPool(ctx context.Context, poolID pgtype.Text) (f1 FindAgentPoolRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPool")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"agentPoolID": agentPoolID}, map[string]interface{}{
-				"fa1": fa1,
-				"err": err})
-		} else if err != nil {
+				"ctx":    ctx,
+				"poolID": poolID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
 			_span.RecordError(err)
 			_span.SetAttributes(
 				attribute.String("event", "error"),
@@ -1306,16 +1798,18 @@ func (_d QuerierWithTracing) FindAgentsByPoolID(ctx context.Context, agentPoolID
 
 		_span.End()
 	}()
-	return _d.Querier.FindAgentsByPoolID(ctx, agentPoolID)
+	return _d.Querier.FindAgentPool(ctx, poolID)
 }
 
-// FindAllNotificationConfigurations implements Querier
-func (_d QuerierWithTracing) FindAllNotificationConfigurations(ctx context.Context) (fa1 []FindAllNotificationConfigurationsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAllNotificationConfigurations")
+// FindAgentPoolAssignedWorkspacesNotAllowed implements Querier
+func (_d QuerierWithTracing) FindAgentPoolAssignedWorkspacesNotAllowed(ctx context.Context, poolID pgtype.Text, allowedWorkspaceIds []string) (fa1 []FindAgentPoolAssignedWorkspacesNotAllowedRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolAssignedWorkspacesNotAllowed")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
+				"ctx":                 ctx,
+				"poolID":              poolID,
+				"allowedWorkspaceIds": allowedWorkspaceIds}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -1328,18 +1822,18 @@ func (_d QuerierWithTracing) FindAllNotificationConfigurations(ctx context.Conte
 
 		_span.End()
 	}()
-	return _d.Querier.FindAllNotificationConfigurations(ctx)
+	return _d.Querier.FindAgentPoolAssignedWorkspacesNotAllowed(ctx, poolID, allowedWorkspaceIds)
 }
 
-// FindAllocatedJobs implements Querier
-func (_d QuerierWithTracing) FindAllocatedJobs(ctx context.Context, agentID pgtype.Text) (fa1 []FindAllocatedJobsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAllocatedJobs")
+// FindAgentPoolAutoscaler implements Querier
+func (_d QuerierWithTracing) FindAgentPoolAutoscaler(ctx context.Context, agentPoolID pgtype.Text) (f1 FindAgentPoolAutoscalerRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolAutoscaler")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"agentID": agentID}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":         ctx,
+				"agentPoolID": agentPoolID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1351,17 +1845,16 @@ func (_d QuerierWithTracing) FindAllocatedJobs(ctx context.Context, agentID pgty
 
 		_span.End()
 	}()
-	return _d.Querier.FindAllocatedJobs(ctx, agentID)
+	return _d.Querier.FindAgentPoolAutoscaler(ctx, agentPoolID)
 }
 
-// FindAndUpdateSignaledJobs implements Querier
-func (_d QuerierWithTracing) FindAndUpdateSignaledJobs(ctx context.Context, agentID pgtype.Text) (fa1 []FindAndUpdateSignaledJobsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAndUpdateSignaledJobs")
+// FindAgentPoolAutoscalers implements Querier
+func (_d QuerierWithTracing) FindAgentPoolAutoscalers(ctx context.Context) (fa1 []FindAgentPoolAutoscalersRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolAutoscalers")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"agentID": agentID}, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -1374,17 +1867,17 @@ func (_d QuerierWithTracing) FindAndUpdateSignaledJobs(ctx context.Context, agen
 
 		_span.End()
 	}()
-	return _d.Querier.FindAndUpdateSignaledJobs(ctx, agentID)
+	return _d.Querier.FindAgentPoolAutoscalers(ctx)
 }
 
-// FindConfigurationVersionByID implements Querier
-func (_d QuerierWithTracing) FindConfigurationVersionByID(ctx context.Context, configurationVersionID pgtype.Text) (f1 FindConfigurationVersionByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindConfigurationVersionByID")
+// FindAgentPoolByAgentTokenID implements Querier
+func (_d QuerierWithTracing) FindAgentPoolByAgentTokenID(ctx context.Context, agentTokenID pgtype.Text) (f1 FindAgentPoolByAgentTokenIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolByAgentTokenID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":                    ctx,
-				"configurationVersionID": configurationVersionID}, map[string]interface{}{
+				"ctx":          ctx,
+				"agentTokenID": agentTokenID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -1397,18 +1890,18 @@ func (_d QuerierWithTracing) FindConfigurationVersionByID(ctx context.Context, c
 
 		_span.End()
 	}()
-	return _d.Querier.FindConfigurationVersionByID(ctx, configurationVersionID)
+	return _d.Querier.FindAgentPoolByAgentTokenID(ctx, agentTokenID)
 }
 
-// FindConfigurationVersionByIDForUpdate implements Querier
-func (_d QuerierWithTracing) FindConfigurationVersionByIDForUpdate(ctx context.Context, configurationVersionID pgtype.Text) (f1 FindConfigurationVersionByIDForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindConfigurationVersionByIDForUpdate")
+// FindAgentPoolIDsDeletedBefore implements Querier
+func (_d QuerierWithTracing) FindAgentPoolIDsDeletedBefore(ctx context.Context, deletedBefore pgtype.Timestamptz) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolIDsDeletedBefore")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":                    ctx,
-				"configurationVersionID": configurationVersionID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":           ctx,
+				"deletedBefore": deletedBefore}, map[string]interface{}{
+				"ta1": ta1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1420,17 +1913,17 @@ func (_d QuerierWithTracing) FindConfigurationVersionByIDForUpdate(ctx context.C
 
 		_span.End()
 	}()
-	return _d.Querier.FindConfigurationVersionByIDForUpdate(ctx, configurationVersionID)
+	return _d.Querier.FindAgentPoolIDsDeletedBefore(ctx, deletedBefore)
 }
 
-// FindConfigurationVersionLatestByWorkspaceID implements Querier
-func (_d QuerierWithTracing) FindConfigurationVersionLatestByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (f1 FindConfigurationVersionLatestByWorkspaceIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindConfigurationVersionLatestByWorkspaceID")
+// FindAgentPoolQuota implements Querier
+func (_d QuerierWithTracing) FindAgentPoolQuota(ctx context.Context, organizationName pgtype.Text) (f1 FindAgentPoolQuotaRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolQuota")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"workspaceID": workspaceID}, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -1443,17 +1936,17 @@ func (_d QuerierWithTracing) FindConfigurationVersionLatestByWorkspaceID(ctx con
 
 		_span.End()
 	}()
-	return _d.Querier.FindConfigurationVersionLatestByWorkspaceID(ctx, workspaceID)
+	return _d.Querier.FindAgentPoolQuota(ctx, organizationName)
 }
 
-// FindConfigurationVersionsByWorkspaceID implements Querier
-func (_d QuerierWithTracing) FindConfigurationVersionsByWorkspaceID(ctx context.Context, params FindConfigurationVersionsByWorkspaceIDParams) (fa1 []FindConfigurationVersionsByWorkspaceIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindConfigurationVersionsByWorkspaceID")
+// FindAgentPoolScalingDecisions implements Querier
+func (_d QuerierWithTracing) FindAgentPoolScalingDecisions(ctx context.Context, agentPoolID pgtype.Text) (fa1 []FindAgentPoolScalingDecisionsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolScalingDecisions")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx":         ctx,
+				"agentPoolID": agentPoolID}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -1466,18 +1959,17 @@ func (_d QuerierWithTracing) FindConfigurationVersionsByWorkspaceID(ctx context.
 
 		_span.End()
 	}()
-	return _d.Querier.FindConfigurationVersionsByWorkspaceID(ctx, params)
+	return _d.Querier.FindAgentPoolScalingDecisions(ctx, agentPoolID)
 }
 
-// FindCurrentStateVersionByWorkspaceID implements Querier
-func (_d QuerierWithTracing) FindCurrentStateVersionByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (f1 FindCurrentStateVersionByWorkspaceIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindCurrentStateVersionByWorkspaceID")
+// FindAgentPools implements Querier
+func (_d QuerierWithTracing) FindAgentPools(ctx context.Context) (fa1 []FindAgentPoolsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPools")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"workspaceID": workspaceID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx": ctx}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1489,17 +1981,18 @@ func (_d QuerierWithTracing) FindCurrentStateVersionByWorkspaceID(ctx context.Co
 
 		_span.End()
 	}()
-	return _d.Querier.FindCurrentStateVersionByWorkspaceID(ctx, workspaceID)
+	return _d.Querier.FindAgentPools(ctx)
 }
 
-// FindGithubApp implements Querier
-func (_d QuerierWithTracing) FindGithubApp(ctx context.Context) (f1 FindGithubAppRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindGithubApp")
+// FindAgentPoolsByIDs implements Querier
+func (_d QuerierWithTracing) FindAgentPoolsByIDs(ctx context.Context, poolIds []string) (fa1 []FindAgentPoolsByIDsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolsByIDs")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":     ctx,
+				"poolIds": poolIds}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1511,19 +2004,18 @@ func (_d QuerierWithTracing) FindGithubApp(ctx context.Context) (f1 FindGithubAp
 
 		_span.End()
 	}()
-	return _d.Querier.FindGithubApp(ctx)
+	return _d.Querier.FindAgentPoolsByIDs(ctx, poolIds)
 }
 
-// FindJob implements Querier
-func (_d QuerierWithTracing) FindJob(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (f1 FindJobRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindJob")
+// FindAgentPoolsByOrganization implements Querier
+func (_d QuerierWithTracing) FindAgentPoolsByOrganization(ctx context.Context, params FindAgentPoolsByOrganizationParams) (fa1 []FindAgentPoolsByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentPoolsByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":   ctx,
-				"runID": runID,
-				"phase": phase}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1535,19 +2027,18 @@ func (_d QuerierWithTracing) FindJob(ctx context.Context, runID pgtype.Text, pha
 
 		_span.End()
 	}()
-	return _d.Querier.FindJob(ctx, runID, phase)
+	return _d.Querier.FindAgentPoolsByOrganization(ctx, params)
 }
 
-// FindJobForUpdate implements Querier
-func (_d QuerierWithTracing) FindJobForUpdate(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (f1 FindJobForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindJobForUpdate")
+// FindAgentStatusHistory implements Querier
+func (_d QuerierWithTracing) FindAgentStatusHistory(ctx context.Context, agentID pgtype.Text) (fa1 []FindAgentStatusHistoryRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentStatusHistory")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":   ctx,
-				"runID": runID,
-				"phase": phase}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":     ctx,
+				"agentID": agentID}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1559,17 +2050,18 @@ func (_d QuerierWithTracing) FindJobForUpdate(ctx context.Context, runID pgtype.
 
 		_span.End()
 	}()
-	return _d.Querier.FindJobForUpdate(ctx, runID, phase)
+	return _d.Querier.FindAgentStatusHistory(ctx, agentID)
 }
 
-// FindJobs implements Querier
-func (_d QuerierWithTracing) FindJobs(ctx context.Context) (fa1 []FindJobsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindJobs")
+// FindAgentTokenByID implements Querier
+func (_d QuerierWithTracing) FindAgentTokenByID(ctx context.Context, agentTokenID pgtype.Text) (f1 FindAgentTokenByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentTokenByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":          ctx,
+				"agentTokenID": agentTokenID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1581,16 +2073,17 @@ func (_d QuerierWithTracing) FindJobs(ctx context.Context) (fa1 []FindJobsRow, e
 
 		_span.End()
 	}()
-	return _d.Querier.FindJobs(ctx)
+	return _d.Querier.FindAgentTokenByID(ctx, agentTokenID)
 }
 
-// FindLatestTerraformVersion implements Querier
-func (_d QuerierWithTracing) FindLatestTerraformVersion(ctx context.Context) (fa1 []FindLatestTerraformVersionRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindLatestTerraformVersion")
+// FindAgentTokensByAgentPoolID implements Querier
+func (_d QuerierWithTracing) FindAgentTokensByAgentPoolID(ctx context.Context, agentPoolID pgtype.Text) (fa1 []FindAgentTokensByAgentPoolIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentTokensByAgentPoolID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
+				"ctx":         ctx,
+				"agentPoolID": agentPoolID}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -1603,18 +2096,17 @@ func (_d QuerierWithTracing) FindLatestTerraformVersion(ctx context.Context) (fa
 
 		_span.End()
 	}()
-	return _d.Querier.FindLatestTerraformVersion(ctx)
+	return _d.Querier.FindAgentTokensByAgentPoolID(ctx, agentPoolID)
 }
 
-// FindLogChunkByID implements Querier
-func (_d QuerierWithTracing) FindLogChunkByID(ctx context.Context, chunkID pgtype.Int4) (f1 FindLogChunkByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindLogChunkByID")
+// FindAgents implements Querier
+func (_d QuerierWithTracing) FindAgents(ctx context.Context) (fa1 []FindAgentsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgents")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"chunkID": chunkID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx": ctx}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1626,19 +2118,18 @@ func (_d QuerierWithTracing) FindLogChunkByID(ctx context.Context, chunkID pgtyp
 
 		_span.End()
 	}()
-	return _d.Querier.FindLogChunkByID(ctx, chunkID)
+	return _d.Querier.FindAgents(ctx)
 }
 
-// FindLogs implements Querier
-func (_d QuerierWithTracing) FindLogs(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (ba1 []byte, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindLogs")
+// FindAgentsByOrganization implements Querier
+func (_d QuerierWithTracing) FindAgentsByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindAgentsByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentsByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":   ctx,
-				"runID": runID,
-				"phase": phase}, map[string]interface{}{
-				"ba1": ba1,
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1650,19 +2141,18 @@ func (_d QuerierWithTracing) FindLogs(ctx context.Context, runID pgtype.Text, ph
 
 		_span.End()
 	}()
-	return _d.Querier.FindLogs(ctx, runID, phase)
+	return _d.Querier.FindAgentsByOrganization(ctx, organizationName)
 }
 
-// FindModuleByConnection implements Querier
-func (_d QuerierWithTracing) FindModuleByConnection(ctx context.Context, vcsProviderID pgtype.Text, repoPath pgtype.Text) (f1 FindModuleByConnectionRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleByConnection")
+// FindAgentsByPoolID implements Querier
+func (_d QuerierWithTracing) FindAgentsByPoolID(ctx context.Context, agentPoolID pgtype.Text) (fa1 []FindAgentsByPoolIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAgentsByPoolID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":           ctx,
-				"vcsProviderID": vcsProviderID,
-				"repoPath":      repoPath}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":         ctx,
+				"agentPoolID": agentPoolID}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1674,18 +2164,17 @@ func (_d QuerierWithTracing) FindModuleByConnection(ctx context.Context, vcsProv
 
 		_span.End()
 	}()
-	return _d.Querier.FindModuleByConnection(ctx, vcsProviderID, repoPath)
+	return _d.Querier.FindAgentsByPoolID(ctx, agentPoolID)
 }
 
-// FindModuleByID implements Querier
-func (_d QuerierWithTracing) FindModuleByID(ctx context.Context, id pgtype.Text) (f1 FindModuleByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleByID")
+// FindAllNotificationConfigurations implements Querier
+func (_d QuerierWithTracing) FindAllNotificationConfigurations(ctx context.Context) (fa1 []FindAllNotificationConfigurationsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAllNotificationConfigurations")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx,
-				"id":  id}, map[string]interface{}{
-				"f1":  f1,
+				"ctx": ctx}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1697,18 +2186,18 @@ func (_d QuerierWithTracing) FindModuleByID(ctx context.Context, id pgtype.Text)
 
 		_span.End()
 	}()
-	return _d.Querier.FindModuleByID(ctx, id)
+	return _d.Querier.FindAllNotificationConfigurations(ctx)
 }
 
-// FindModuleByModuleVersionID implements Querier
-func (_d QuerierWithTracing) FindModuleByModuleVersionID(ctx context.Context, moduleVersionID pgtype.Text) (f1 FindModuleByModuleVersionIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleByModuleVersionID")
+// FindAllocatedJobs implements Querier
+func (_d QuerierWithTracing) FindAllocatedJobs(ctx context.Context, agentID pgtype.Text) (fa1 []FindAllocatedJobsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAllocatedJobs")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":             ctx,
-				"moduleVersionID": moduleVersionID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":     ctx,
+				"agentID": agentID}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1720,17 +2209,16 @@ func (_d QuerierWithTracing) FindModuleByModuleVersionID(ctx context.Context, mo
 
 		_span.End()
 	}()
-	return _d.Querier.FindModuleByModuleVersionID(ctx, moduleVersionID)
+	return _d.Querier.FindAllocatedJobs(ctx, agentID)
 }
 
-// FindModuleByName implements Querier
-func (_d QuerierWithTracing) FindModuleByName(ctx context.Context, params FindModuleByNameParams) (f1 FindModuleByNameRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleByName")
+// FindAllocatorStatus implements Querier
+func (_d QuerierWithTracing) FindAllocatorStatus(ctx context.Context) (f1 FindAllocatorStatusRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAllocatorStatus")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -1743,18 +2231,18 @@ func (_d QuerierWithTracing) FindModuleByName(ctx context.Context, params FindMo
 
 		_span.End()
 	}()
-	return _d.Querier.FindModuleByName(ctx, params)
+	return _d.Querier.FindAllocatorStatus(ctx)
 }
 
-// FindModuleTarball implements Querier
-func (_d QuerierWithTracing) FindModuleTarball(ctx context.Context, moduleVersionID pgtype.Text) (ba1 []byte, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleTarball")
+// FindAndUpdateSignaledJobs implements Querier
+func (_d QuerierWithTracing) FindAndUpdateSignaledJobs(ctx context.Context, agentID pgtype.Text) (fa1 []FindAndUpdateSignaledJobsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAndUpdateSignaledJobs")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":             ctx,
-				"moduleVersionID": moduleVersionID}, map[string]interface{}{
-				"ba1": ba1,
+				"ctx":     ctx,
+				"agentID": agentID}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1766,17 +2254,17 @@ func (_d QuerierWithTracing) FindModuleTarball(ctx context.Context, moduleVersio
 
 		_span.End()
 	}()
-	return _d.Querier.FindModuleTarball(ctx, moduleVersionID)
+	return _d.Querier.FindAndUpdateSignaledJobs(ctx, agentID)
 }
 
-// FindNotificationConfiguration implements Querier
-func (_d QuerierWithTracing) FindNotificationConfiguration(ctx context.Context, notificationConfigurationID pgtype.Text) (f1 FindNotificationConfigurationRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindNotificationConfiguration")
+// FindAuthLockout implements Querier
+func (_d QuerierWithTracing) FindAuthLockout(ctx context.Context, lockoutKey pgtype.Text) (f1 FindAuthLockoutRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindAuthLockout")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":                         ctx,
-				"notificationConfigurationID": notificationConfigurationID}, map[string]interface{}{
+				"ctx":        ctx,
+				"lockoutKey": lockoutKey}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -1789,18 +2277,18 @@ func (_d QuerierWithTracing) FindNotificationConfiguration(ctx context.Context,
 
 		_span.End()
 	}()
-	return _d.Querier.FindNotificationConfiguration(ctx, notificationConfigurationID)
+	return _d.Querier.FindAuthLockout(ctx, lockoutKey)
 }
 
-// FindNotificationConfigurationForUpdate implements Querier
-func (_d QuerierWithTracing) FindNotificationConfigurationForUpdate(ctx context.Context, notificationConfigurationID pgtype.Text) (f1 FindNotificationConfigurationForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindNotificationConfigurationForUpdate")
+// FindChildRunIDs implements Querier
+func (_d QuerierWithTracing) FindChildRunIDs(ctx context.Context, parentRunID pgtype.Text) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindChildRunIDs")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":                         ctx,
-				"notificationConfigurationID": notificationConfigurationID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":         ctx,
+				"parentRunID": parentRunID}, map[string]interface{}{
+				"ta1": ta1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1812,18 +2300,18 @@ func (_d QuerierWithTracing) FindNotificationConfigurationForUpdate(ctx context.
 
 		_span.End()
 	}()
-	return _d.Querier.FindNotificationConfigurationForUpdate(ctx, notificationConfigurationID)
+	return _d.Querier.FindChildRunIDs(ctx, parentRunID)
 }
 
-// FindNotificationConfigurationsByWorkspaceID implements Querier
-func (_d QuerierWithTracing) FindNotificationConfigurationsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindNotificationConfigurationsByWorkspaceIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindNotificationConfigurationsByWorkspaceID")
+// FindConfigurationVersionByID implements Querier
+func (_d QuerierWithTracing) FindConfigurationVersionByID(ctx context.Context, configurationVersionID pgtype.Text) (f1 FindConfigurationVersionByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindConfigurationVersionByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"workspaceID": workspaceID}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":                    ctx,
+				"configurationVersionID": configurationVersionID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1835,17 +2323,17 @@ func (_d QuerierWithTracing) FindNotificationConfigurationsByWorkspaceID(ctx con
 
 		_span.End()
 	}()
-	return _d.Querier.FindNotificationConfigurationsByWorkspaceID(ctx, workspaceID)
+	return _d.Querier.FindConfigurationVersionByID(ctx, configurationVersionID)
 }
 
-// FindOrganizationByID implements Querier
-func (_d QuerierWithTracing) FindOrganizationByID(ctx context.Context, organizationID pgtype.Text) (f1 FindOrganizationByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationByID")
+// FindConfigurationVersionByIDForUpdate implements Querier
+func (_d QuerierWithTracing) FindConfigurationVersionByIDForUpdate(ctx context.Context, configurationVersionID pgtype.Text) (f1 FindConfigurationVersionByIDForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindConfigurationVersionByIDForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":            ctx,
-				"organizationID": organizationID}, map[string]interface{}{
+				"ctx":                    ctx,
+				"configurationVersionID": configurationVersionID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -1858,17 +2346,17 @@ func (_d QuerierWithTracing) FindOrganizationByID(ctx context.Context, organizat
 
 		_span.End()
 	}()
-	return _d.Querier.FindOrganizationByID(ctx, organizationID)
+	return _d.Querier.FindConfigurationVersionByIDForUpdate(ctx, configurationVersionID)
 }
 
-// FindOrganizationByName implements Querier
-func (_d QuerierWithTracing) FindOrganizationByName(ctx context.Context, name pgtype.Text) (f1 FindOrganizationByNameRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationByName")
+// FindConfigurationVersionLatestByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindConfigurationVersionLatestByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (f1 FindConfigurationVersionLatestByWorkspaceIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindConfigurationVersionLatestByWorkspaceID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":  ctx,
-				"name": name}, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -1881,18 +2369,18 @@ func (_d QuerierWithTracing) FindOrganizationByName(ctx context.Context, name pg
 
 		_span.End()
 	}()
-	return _d.Querier.FindOrganizationByName(ctx, name)
+	return _d.Querier.FindConfigurationVersionLatestByWorkspaceID(ctx, workspaceID)
 }
 
-// FindOrganizationByNameForUpdate implements Querier
-func (_d QuerierWithTracing) FindOrganizationByNameForUpdate(ctx context.Context, name pgtype.Text) (f1 FindOrganizationByNameForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationByNameForUpdate")
+// FindConfigurationVersionsByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindConfigurationVersionsByWorkspaceID(ctx context.Context, params FindConfigurationVersionsByWorkspaceIDParams) (fa1 []FindConfigurationVersionsByWorkspaceIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindConfigurationVersionsByWorkspaceID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":  ctx,
-				"name": name}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1904,18 +2392,40 @@ func (_d QuerierWithTracing) FindOrganizationByNameForUpdate(ctx context.Context
 
 		_span.End()
 	}()
-	return _d.Querier.FindOrganizationByNameForUpdate(ctx, name)
+	return _d.Querier.FindConfigurationVersionsByWorkspaceID(ctx, params)
 }
 
-// FindOrganizationNameByWorkspaceID implements Querier
-func (_d QuerierWithTracing) FindOrganizationNameByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationNameByWorkspaceID")
+// FindConnectedRepos implements Querier
+func (_d QuerierWithTracing) FindConnectedRepos(ctx context.Context) (fa1 []FindConnectedReposRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindConnectedRepos")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindConnectedRepos(ctx)
+}
+
+// FindCurrentStateVersionByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindCurrentStateVersionByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (f1 FindCurrentStateVersionByWorkspaceIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindCurrentStateVersionByWorkspaceID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":         ctx,
 				"workspaceID": workspaceID}, map[string]interface{}{
-				"t1":  t1,
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1927,12 +2437,12 @@ func (_d QuerierWithTracing) FindOrganizationNameByWorkspaceID(ctx context.Conte
 
 		_span.End()
 	}()
-	return _d.Querier.FindOrganizationNameByWorkspaceID(ctx, workspaceID)
+	return _d.Querier.FindCurrentStateVersionByWorkspaceID(ctx, workspaceID)
 }
 
-// FindOrganizationTokens implements Querier
-func (_d QuerierWithTracing) FindOrganizationTokens(ctx context.Context, organizationName pgtype.Text) (fa1 []FindOrganizationTokensRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationTokens")
+// FindDeletedAgentPoolsByOrganization implements Querier
+func (_d QuerierWithTracing) FindDeletedAgentPoolsByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindDeletedAgentPoolsByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindDeletedAgentPoolsByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -1950,18 +2460,19 @@ func (_d QuerierWithTracing) FindOrganizationTokens(ctx context.Context, organiz
 
 		_span.End()
 	}()
-	return _d.Querier.FindOrganizationTokens(ctx, organizationName)
+	return _d.Querier.FindDeletedAgentPoolsByOrganization(ctx, organizationName)
 }
 
-// FindOrganizationTokensByID implements Querier
-func (_d QuerierWithTracing) FindOrganizationTokensByID(ctx context.Context, organizationTokenID pgtype.Text) (f1 FindOrganizationTokensByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationTokensByID")
+// FindEventSinkOutbox implements Querier
+func (_d QuerierWithTracing) FindEventSinkOutbox(ctx context.Context, sink pgtype.Text, limit pgtype.Int8) (fa1 []FindEventSinkOutboxRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindEventSinkOutbox")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":                 ctx,
-				"organizationTokenID": organizationTokenID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":   ctx,
+				"sink":  sink,
+				"limit": limit}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1973,18 +2484,17 @@ func (_d QuerierWithTracing) FindOrganizationTokensByID(ctx context.Context, org
 
 		_span.End()
 	}()
-	return _d.Querier.FindOrganizationTokensByID(ctx, organizationTokenID)
+	return _d.Querier.FindEventSinkOutbox(ctx, sink, limit)
 }
 
-// FindOrganizationTokensByName implements Querier
-func (_d QuerierWithTracing) FindOrganizationTokensByName(ctx context.Context, organizationName pgtype.Text) (f1 FindOrganizationTokensByNameRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationTokensByName")
+// FindEventSinks implements Querier
+func (_d QuerierWithTracing) FindEventSinks(ctx context.Context) (fa1 []FindEventSinksRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindEventSinks")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"organizationName": organizationName}, map[string]interface{}{
-				"f1":  f1,
+				"ctx": ctx}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -1996,17 +2506,18 @@ func (_d QuerierWithTracing) FindOrganizationTokensByName(ctx context.Context, o
 
 		_span.End()
 	}()
-	return _d.Querier.FindOrganizationTokensByName(ctx, organizationName)
+	return _d.Querier.FindEventSinks(ctx)
 }
 
-// FindOrganizations implements Querier
-func (_d QuerierWithTracing) FindOrganizations(ctx context.Context, params FindOrganizationsParams) (fa1 []FindOrganizationsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizations")
+// FindExpiringAgentTokensByOrganization implements Querier
+func (_d QuerierWithTracing) FindExpiringAgentTokensByOrganization(ctx context.Context, organizationName pgtype.Text, expiresBefore pgtype.Timestamptz) (fa1 []FindExpiringAgentTokensByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindExpiringAgentTokensByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName,
+				"expiresBefore":    expiresBefore}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -2019,17 +2530,16 @@ func (_d QuerierWithTracing) FindOrganizations(ctx context.Context, params FindO
 
 		_span.End()
 	}()
-	return _d.Querier.FindOrganizations(ctx, params)
+	return _d.Querier.FindExpiringAgentTokensByOrganization(ctx, organizationName, expiresBefore)
 }
 
-// FindRepohookByID implements Querier
-func (_d QuerierWithTracing) FindRepohookByID(ctx context.Context, repohookID pgtype.UUID) (f1 FindRepohookByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRepohookByID")
+// FindGithubApp implements Querier
+func (_d QuerierWithTracing) FindGithubApp(ctx context.Context) (f1 FindGithubAppRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindGithubApp")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":        ctx,
-				"repohookID": repohookID}, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2042,19 +2552,18 @@ func (_d QuerierWithTracing) FindRepohookByID(ctx context.Context, repohookID pg
 
 		_span.End()
 	}()
-	return _d.Querier.FindRepohookByID(ctx, repohookID)
+	return _d.Querier.FindGithubApp(ctx)
 }
 
-// FindRepohookByRepoAndProvider implements Querier
-func (_d QuerierWithTracing) FindRepohookByRepoAndProvider(ctx context.Context, repoPath pgtype.Text, vcsProviderID pgtype.Text) (fa1 []FindRepohookByRepoAndProviderRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRepohookByRepoAndProvider")
+// FindHighPriorityRunQuota implements Querier
+func (_d QuerierWithTracing) FindHighPriorityRunQuota(ctx context.Context, organizationName pgtype.Text) (f1 FindHighPriorityRunQuotaRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindHighPriorityRunQuota")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":           ctx,
-				"repoPath":      repoPath,
-				"vcsProviderID": vcsProviderID}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2066,17 +2575,19 @@ func (_d QuerierWithTracing) FindRepohookByRepoAndProvider(ctx context.Context,
 
 		_span.End()
 	}()
-	return _d.Querier.FindRepohookByRepoAndProvider(ctx, repoPath, vcsProviderID)
+	return _d.Querier.FindHighPriorityRunQuota(ctx, organizationName)
 }
 
-// FindRepohooks implements Querier
-func (_d QuerierWithTracing) FindRepohooks(ctx context.Context) (fa1 []FindRepohooksRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRepohooks")
+// FindIdempotencyKey implements Querier
+func (_d QuerierWithTracing) FindIdempotencyKey(ctx context.Context, subject pgtype.Text, idempotencyKey pgtype.Text) (f1 FindIdempotencyKeyRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindIdempotencyKey")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":            ctx,
+				"subject":        subject,
+				"idempotencyKey": idempotencyKey}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2088,17 +2599,18 @@ func (_d QuerierWithTracing) FindRepohooks(ctx context.Context) (fa1 []FindRepoh
 
 		_span.End()
 	}()
-	return _d.Querier.FindRepohooks(ctx)
+	return _d.Querier.FindIdempotencyKey(ctx, subject, idempotencyKey)
 }
 
-// FindRunByID implements Querier
-func (_d QuerierWithTracing) FindRunByID(ctx context.Context, runID pgtype.Text) (f1 FindRunByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunByID")
+// FindJob implements Querier
+func (_d QuerierWithTracing) FindJob(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (f1 FindJobRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindJob")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":   ctx,
-				"runID": runID}, map[string]interface{}{
+				"runID": runID,
+				"phase": phase}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2111,17 +2623,18 @@ func (_d QuerierWithTracing) FindRunByID(ctx context.Context, runID pgtype.Text)
 
 		_span.End()
 	}()
-	return _d.Querier.FindRunByID(ctx, runID)
+	return _d.Querier.FindJob(ctx, runID, phase)
 }
 
-// FindRunByIDForUpdate implements Querier
-func (_d QuerierWithTracing) FindRunByIDForUpdate(ctx context.Context, runID pgtype.Text) (f1 FindRunByIDForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunByIDForUpdate")
+// FindJobForUpdate implements Querier
+func (_d QuerierWithTracing) FindJobForUpdate(ctx context.Context, runID pgtype.Text, phase pgtype.Text) (f1 FindJobForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindJobForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":   ctx,
-				"runID": runID}, map[string]interface{}{
+				"runID": runID,
+				"phase": phase}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2134,17 +2647,16 @@ func (_d QuerierWithTracing) FindRunByIDForUpdate(ctx context.Context, runID pgt
 
 		_span.End()
 	}()
-	return _d.Querier.FindRunByIDForUpdate(ctx, runID)
+	return _d.Querier.FindJobForUpdate(ctx, runID, phase)
 }
 
-// FindRuns implements Querier
-func (_d QuerierWithTracing) FindRuns(ctx context.Context, params FindRunsParams) (fa1 []FindRunsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRuns")
+// FindJobs implements Querier
+func (_d QuerierWithTracing) FindJobs(ctx context.Context) (fa1 []FindJobsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindJobs")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -2157,12 +2669,12 @@ func (_d QuerierWithTracing) FindRuns(ctx context.Context, params FindRunsParams
 
 		_span.End()
 	}()
-	return _d.Querier.FindRuns(ctx, params)
+	return _d.Querier.FindJobs(ctx)
 }
 
-// FindServerAgents implements Querier
-func (_d QuerierWithTracing) FindServerAgents(ctx context.Context) (fa1 []FindServerAgentsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindServerAgents")
+// FindLatestTerraformVersion implements Querier
+func (_d QuerierWithTracing) FindLatestTerraformVersion(ctx context.Context) (fa1 []FindLatestTerraformVersionRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindLatestTerraformVersion")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -2179,17 +2691,17 @@ func (_d QuerierWithTracing) FindServerAgents(ctx context.Context) (fa1 []FindSe
 
 		_span.End()
 	}()
-	return _d.Querier.FindServerAgents(ctx)
+	return _d.Querier.FindLatestTerraformVersion(ctx)
 }
 
-// FindStateVersionByID implements Querier
-func (_d QuerierWithTracing) FindStateVersionByID(ctx context.Context, id pgtype.Text) (f1 FindStateVersionByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionByID")
+// FindLogBufferForUpdate implements Querier
+func (_d QuerierWithTracing) FindLogBufferForUpdate(ctx context.Context, params FindLogBufferForUpdateParams) (f1 FindLogBufferForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindLogBufferForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx,
-				"id":  id}, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2202,17 +2714,17 @@ func (_d QuerierWithTracing) FindStateVersionByID(ctx context.Context, id pgtype
 
 		_span.End()
 	}()
-	return _d.Querier.FindStateVersionByID(ctx, id)
+	return _d.Querier.FindLogBufferForUpdate(ctx, params)
 }
 
-// FindStateVersionByIDForUpdate implements Querier
-func (_d QuerierWithTracing) FindStateVersionByIDForUpdate(ctx context.Context, id pgtype.Text) (f1 FindStateVersionByIDForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionByIDForUpdate")
+// FindLogChunkByID implements Querier
+func (_d QuerierWithTracing) FindLogChunkByID(ctx context.Context, chunkID pgtype.Int4) (f1 FindLogChunkByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindLogChunkByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx,
-				"id":  id}, map[string]interface{}{
+				"ctx":     ctx,
+				"chunkID": chunkID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2225,18 +2737,18 @@ func (_d QuerierWithTracing) FindStateVersionByIDForUpdate(ctx context.Context,
 
 		_span.End()
 	}()
-	return _d.Querier.FindStateVersionByIDForUpdate(ctx, id)
+	return _d.Querier.FindLogChunkByID(ctx, chunkID)
 }
 
-// FindStateVersionOutputByID implements Querier
-func (_d QuerierWithTracing) FindStateVersionOutputByID(ctx context.Context, id pgtype.Text) (f1 FindStateVersionOutputByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionOutputByID")
+// FindLogs implements Querier
+func (_d QuerierWithTracing) FindLogs(ctx context.Context, params FindLogsParams) (ba1 []byte, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindLogs")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx,
-				"id":  id}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"ba1": ba1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2248,18 +2760,17 @@ func (_d QuerierWithTracing) FindStateVersionOutputByID(ctx context.Context, id
 
 		_span.End()
 	}()
-	return _d.Querier.FindStateVersionOutputByID(ctx, id)
+	return _d.Querier.FindLogs(ctx, params)
 }
 
-// FindStateVersionStateByID implements Querier
-func (_d QuerierWithTracing) FindStateVersionStateByID(ctx context.Context, id pgtype.Text) (ba1 []byte, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionStateByID")
+// FindMaintenanceMode implements Querier
+func (_d QuerierWithTracing) FindMaintenanceMode(ctx context.Context) (f1 FindMaintenanceModeRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindMaintenanceMode")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx,
-				"id":  id}, map[string]interface{}{
-				"ba1": ba1,
+				"ctx": ctx}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2271,17 +2782,16 @@ func (_d QuerierWithTracing) FindStateVersionStateByID(ctx context.Context, id p
 
 		_span.End()
 	}()
-	return _d.Querier.FindStateVersionStateByID(ctx, id)
+	return _d.Querier.FindMaintenanceMode(ctx)
 }
 
-// FindStateVersionsByWorkspaceID implements Querier
-func (_d QuerierWithTracing) FindStateVersionsByWorkspaceID(ctx context.Context, params FindStateVersionsByWorkspaceIDParams) (fa1 []FindStateVersionsByWorkspaceIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionsByWorkspaceID")
+// FindMaintenanceModeChanges implements Querier
+func (_d QuerierWithTracing) FindMaintenanceModeChanges(ctx context.Context) (fa1 []FindMaintenanceModeChangesRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindMaintenanceModeChanges")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -2294,18 +2804,18 @@ func (_d QuerierWithTracing) FindStateVersionsByWorkspaceID(ctx context.Context,
 
 		_span.End()
 	}()
-	return _d.Querier.FindStateVersionsByWorkspaceID(ctx, params)
+	return _d.Querier.FindMaintenanceModeChanges(ctx)
 }
 
-// FindTagByID implements Querier
-func (_d QuerierWithTracing) FindTagByID(ctx context.Context, tagID pgtype.Text, organizationName pgtype.Text) (f1 FindTagByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTagByID")
+// FindModuleByConnection implements Querier
+func (_d QuerierWithTracing) FindModuleByConnection(ctx context.Context, vcsProviderID pgtype.Text, repoPath pgtype.Text) (f1 FindModuleByConnectionRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleByConnection")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"tagID":            tagID,
-				"organizationName": organizationName}, map[string]interface{}{
+				"ctx":           ctx,
+				"vcsProviderID": vcsProviderID,
+				"repoPath":      repoPath}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2318,18 +2828,17 @@ func (_d QuerierWithTracing) FindTagByID(ctx context.Context, tagID pgtype.Text,
 
 		_span.End()
 	}()
-	return _d.Querier.FindTagByID(ctx, tagID, organizationName)
+	return _d.Querier.FindModuleByConnection(ctx, vcsProviderID, repoPath)
 }
 
-// FindTagByName implements Querier
-func (_d QuerierWithTracing) FindTagByName(ctx context.Context, name pgtype.Text, organizationName pgtype.Text) (f1 FindTagByNameRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTagByName")
+// FindModuleByID implements Querier
+func (_d QuerierWithTracing) FindModuleByID(ctx context.Context, id pgtype.Text) (f1 FindModuleByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"name":             name,
-				"organizationName": organizationName}, map[string]interface{}{
+				"ctx": ctx,
+				"id":  id}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2342,18 +2851,18 @@ func (_d QuerierWithTracing) FindTagByName(ctx context.Context, name pgtype.Text
 
 		_span.End()
 	}()
-	return _d.Querier.FindTagByName(ctx, name, organizationName)
+	return _d.Querier.FindModuleByID(ctx, id)
 }
 
-// FindTags implements Querier
-func (_d QuerierWithTracing) FindTags(ctx context.Context, params FindTagsParams) (fa1 []FindTagsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTags")
+// FindModuleByModuleVersionID implements Querier
+func (_d QuerierWithTracing) FindModuleByModuleVersionID(ctx context.Context, moduleVersionID pgtype.Text) (f1 FindModuleByModuleVersionIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleByModuleVersionID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":             ctx,
+				"moduleVersionID": moduleVersionID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2365,40 +2874,17 @@ func (_d QuerierWithTracing) FindTags(ctx context.Context, params FindTagsParams
 
 		_span.End()
 	}()
-	return _d.Querier.FindTags(ctx, params)
-}
-
-// FindTeamByID implements Querier
-func (_d QuerierWithTracing) FindTeamByID(ctx context.Context, teamID pgtype.Text) (f1 FindTeamByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamByID")
-	defer func() {
-		if _d._spanDecorator != nil {
-			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"teamID": teamID}, map[string]interface{}{
-				"f1":  f1,
-				"err": err})
-		} else if err != nil {
-			_span.RecordError(err)
-			_span.SetAttributes(
-				attribute.String("event", "error"),
-				attribute.String("message", err.Error()),
-			)
-		}
-
-		_span.End()
-	}()
-	return _d.Querier.FindTeamByID(ctx, teamID)
+	return _d.Querier.FindModuleByModuleVersionID(ctx, moduleVersionID)
 }
 
-// FindTeamByIDForUpdate implements Querier
-func (_d QuerierWithTracing) FindTeamByIDForUpdate(ctx context.Context, teamID pgtype.Text) (f1 FindTeamByIDForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamByIDForUpdate")
+// FindModuleByName implements Querier
+func (_d QuerierWithTracing) FindModuleByName(ctx context.Context, params FindModuleByNameParams) (f1 FindModuleByNameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleByName")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
-				"teamID": teamID}, map[string]interface{}{
+				"params": params}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2411,18 +2897,17 @@ func (_d QuerierWithTracing) FindTeamByIDForUpdate(ctx context.Context, teamID p
 
 		_span.End()
 	}()
-	return _d.Querier.FindTeamByIDForUpdate(ctx, teamID)
+	return _d.Querier.FindModuleByName(ctx, params)
 }
 
-// FindTeamByName implements Querier
-func (_d QuerierWithTracing) FindTeamByName(ctx context.Context, name pgtype.Text, organizationName pgtype.Text) (f1 FindTeamByNameRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamByName")
+// FindModuleShareByID implements Querier
+func (_d QuerierWithTracing) FindModuleShareByID(ctx context.Context, moduleShareID pgtype.Text) (f1 FindModuleShareByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleShareByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"name":             name,
-				"organizationName": organizationName}, map[string]interface{}{
+				"ctx":           ctx,
+				"moduleShareID": moduleShareID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2435,18 +2920,18 @@ func (_d QuerierWithTracing) FindTeamByName(ctx context.Context, name pgtype.Tex
 
 		_span.End()
 	}()
-	return _d.Querier.FindTeamByName(ctx, name, organizationName)
+	return _d.Querier.FindModuleShareByID(ctx, moduleShareID)
 }
 
-// FindTeamByTokenID implements Querier
-func (_d QuerierWithTracing) FindTeamByTokenID(ctx context.Context, tokenID pgtype.Text) (f1 FindTeamByTokenIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamByTokenID")
+// FindModuleSharesByModuleID implements Querier
+func (_d QuerierWithTracing) FindModuleSharesByModuleID(ctx context.Context, moduleID pgtype.Text) (fa1 []FindModuleSharesByModuleIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleSharesByModuleID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"tokenID": tokenID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":      ctx,
+				"moduleID": moduleID}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2458,17 +2943,17 @@ func (_d QuerierWithTracing) FindTeamByTokenID(ctx context.Context, tokenID pgty
 
 		_span.End()
 	}()
-	return _d.Querier.FindTeamByTokenID(ctx, tokenID)
+	return _d.Querier.FindModuleSharesByModuleID(ctx, moduleID)
 }
 
-// FindTeamTokensByID implements Querier
-func (_d QuerierWithTracing) FindTeamTokensByID(ctx context.Context, teamID pgtype.Text) (fa1 []FindTeamTokensByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamTokensByID")
+// FindModuleSharesByOrganization implements Querier
+func (_d QuerierWithTracing) FindModuleSharesByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindModuleSharesByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleSharesByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"teamID": teamID}, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -2481,18 +2966,18 @@ func (_d QuerierWithTracing) FindTeamTokensByID(ctx context.Context, teamID pgty
 
 		_span.End()
 	}()
-	return _d.Querier.FindTeamTokensByID(ctx, teamID)
+	return _d.Querier.FindModuleSharesByOrganization(ctx, organizationName)
 }
 
-// FindTeamsByOrg implements Querier
-func (_d QuerierWithTracing) FindTeamsByOrg(ctx context.Context, organizationName pgtype.Text) (fa1 []FindTeamsByOrgRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamsByOrg")
+// FindModuleTarball implements Querier
+func (_d QuerierWithTracing) FindModuleTarball(ctx context.Context, moduleVersionID pgtype.Text) (ba1 []byte, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleTarball")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"organizationName": organizationName}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":             ctx,
+				"moduleVersionID": moduleVersionID}, map[string]interface{}{
+				"ba1": ba1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2504,17 +2989,17 @@ func (_d QuerierWithTracing) FindTeamsByOrg(ctx context.Context, organizationNam
 
 		_span.End()
 	}()
-	return _d.Querier.FindTeamsByOrg(ctx, organizationName)
+	return _d.Querier.FindModuleTarball(ctx, moduleVersionID)
 }
 
-// FindTokenByID implements Querier
-func (_d QuerierWithTracing) FindTokenByID(ctx context.Context, tokenID pgtype.Text) (f1 FindTokenByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTokenByID")
+// FindModuleTemplateByID implements Querier
+func (_d QuerierWithTracing) FindModuleTemplateByID(ctx context.Context, moduleTemplateID pgtype.Text) (f1 FindModuleTemplateByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleTemplateByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"tokenID": tokenID}, map[string]interface{}{
+				"ctx":              ctx,
+				"moduleTemplateID": moduleTemplateID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2527,18 +3012,18 @@ func (_d QuerierWithTracing) FindTokenByID(ctx context.Context, tokenID pgtype.T
 
 		_span.End()
 	}()
-	return _d.Querier.FindTokenByID(ctx, tokenID)
+	return _d.Querier.FindModuleTemplateByID(ctx, moduleTemplateID)
 }
 
-// FindTokensByUsername implements Querier
-func (_d QuerierWithTracing) FindTokensByUsername(ctx context.Context, username pgtype.Text) (fa1 []FindTokensByUsernameRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTokensByUsername")
+// FindModuleTemplateByModuleID implements Querier
+func (_d QuerierWithTracing) FindModuleTemplateByModuleID(ctx context.Context, moduleID pgtype.Text) (f1 FindModuleTemplateByModuleIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleTemplateByModuleID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":      ctx,
-				"username": username}, map[string]interface{}{
-				"fa1": fa1,
+				"moduleID": moduleID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2550,17 +3035,18 @@ func (_d QuerierWithTracing) FindTokensByUsername(ctx context.Context, username
 
 		_span.End()
 	}()
-	return _d.Querier.FindTokensByUsername(ctx, username)
+	return _d.Querier.FindModuleTemplateByModuleID(ctx, moduleID)
 }
 
-// FindUnreferencedRepohooks implements Querier
-func (_d QuerierWithTracing) FindUnreferencedRepohooks(ctx context.Context) (fa1 []FindUnreferencedRepohooksRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUnreferencedRepohooks")
+// FindModuleTemplateForUpdate implements Querier
+func (_d QuerierWithTracing) FindModuleTemplateForUpdate(ctx context.Context, moduleTemplateID pgtype.Text) (f1 FindModuleTemplateForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleTemplateForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":              ctx,
+				"moduleTemplateID": moduleTemplateID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2572,17 +3058,17 @@ func (_d QuerierWithTracing) FindUnreferencedRepohooks(ctx context.Context) (fa1
 
 		_span.End()
 	}()
-	return _d.Querier.FindUnreferencedRepohooks(ctx)
+	return _d.Querier.FindModuleTemplateForUpdate(ctx, moduleTemplateID)
 }
 
-// FindUserByAuthenticationTokenID implements Querier
-func (_d QuerierWithTracing) FindUserByAuthenticationTokenID(ctx context.Context, tokenID pgtype.Text) (f1 FindUserByAuthenticationTokenIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUserByAuthenticationTokenID")
+// FindModuleTemplateWorkspaceForUpdate implements Querier
+func (_d QuerierWithTracing) FindModuleTemplateWorkspaceForUpdate(ctx context.Context, workspaceID pgtype.Text) (f1 FindModuleTemplateWorkspaceForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleTemplateWorkspaceForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"tokenID": tokenID}, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2595,18 +3081,18 @@ func (_d QuerierWithTracing) FindUserByAuthenticationTokenID(ctx context.Context
 
 		_span.End()
 	}()
-	return _d.Querier.FindUserByAuthenticationTokenID(ctx, tokenID)
+	return _d.Querier.FindModuleTemplateWorkspaceForUpdate(ctx, workspaceID)
 }
 
-// FindUserByID implements Querier
-func (_d QuerierWithTracing) FindUserByID(ctx context.Context, userID pgtype.Text) (f1 FindUserByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUserByID")
+// FindModuleTemplateWorkspacesByTemplateID implements Querier
+func (_d QuerierWithTracing) FindModuleTemplateWorkspacesByTemplateID(ctx context.Context, moduleTemplateID pgtype.Text) (fa1 []FindModuleTemplateWorkspacesByTemplateIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleTemplateWorkspacesByTemplateID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"userID": userID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":              ctx,
+				"moduleTemplateID": moduleTemplateID}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2618,18 +3104,18 @@ func (_d QuerierWithTracing) FindUserByID(ctx context.Context, userID pgtype.Tex
 
 		_span.End()
 	}()
-	return _d.Querier.FindUserByID(ctx, userID)
+	return _d.Querier.FindModuleTemplateWorkspacesByTemplateID(ctx, moduleTemplateID)
 }
 
-// FindUserByUsername implements Querier
-func (_d QuerierWithTracing) FindUserByUsername(ctx context.Context, username pgtype.Text) (f1 FindUserByUsernameRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUserByUsername")
+// FindModuleTemplatesByOrganization implements Querier
+func (_d QuerierWithTracing) FindModuleTemplatesByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindModuleTemplatesByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleTemplatesByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":      ctx,
-				"username": username}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2641,17 +3127,18 @@ func (_d QuerierWithTracing) FindUserByUsername(ctx context.Context, username pg
 
 		_span.End()
 	}()
-	return _d.Querier.FindUserByUsername(ctx, username)
+	return _d.Querier.FindModuleTemplatesByOrganization(ctx, organizationName)
 }
 
-// FindUsers implements Querier
-func (_d QuerierWithTracing) FindUsers(ctx context.Context) (fa1 []FindUsersRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUsers")
+// FindModuleVersionIDsDownloadedBefore implements Querier
+func (_d QuerierWithTracing) FindModuleVersionIDsDownloadedBefore(ctx context.Context, params FindModuleVersionIDsDownloadedBeforeParams) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleVersionIDsDownloadedBefore")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"ta1": ta1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2663,12 +3150,12 @@ func (_d QuerierWithTracing) FindUsers(ctx context.Context) (fa1 []FindUsersRow,
 
 		_span.End()
 	}()
-	return _d.Querier.FindUsers(ctx)
+	return _d.Querier.FindModuleVersionIDsDownloadedBefore(ctx, params)
 }
 
-// FindUsersByOrganization implements Querier
-func (_d QuerierWithTracing) FindUsersByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindUsersByOrganizationRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUsersByOrganization")
+// FindModuleVersionPoliciesByOrganization implements Querier
+func (_d QuerierWithTracing) FindModuleVersionPoliciesByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindModuleVersionPoliciesByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleVersionPoliciesByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -2686,18 +3173,18 @@ func (_d QuerierWithTracing) FindUsersByOrganization(ctx context.Context, organi
 
 		_span.End()
 	}()
-	return _d.Querier.FindUsersByOrganization(ctx, organizationName)
+	return _d.Querier.FindModuleVersionPoliciesByOrganization(ctx, organizationName)
 }
 
-// FindUsersByTeamID implements Querier
-func (_d QuerierWithTracing) FindUsersByTeamID(ctx context.Context, teamID pgtype.Text) (fa1 []FindUsersByTeamIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUsersByTeamID")
+// FindModuleVersionPolicyByID implements Querier
+func (_d QuerierWithTracing) FindModuleVersionPolicyByID(ctx context.Context, moduleVersionPolicyID pgtype.Text) (f1 FindModuleVersionPolicyByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleVersionPolicyByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"teamID": teamID}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":                   ctx,
+				"moduleVersionPolicyID": moduleVersionPolicyID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2709,17 +3196,17 @@ func (_d QuerierWithTracing) FindUsersByTeamID(ctx context.Context, teamID pgtyp
 
 		_span.End()
 	}()
-	return _d.Querier.FindUsersByTeamID(ctx, teamID)
+	return _d.Querier.FindModuleVersionPolicyByID(ctx, moduleVersionPolicyID)
 }
 
-// FindVCSProvider implements Querier
-func (_d QuerierWithTracing) FindVCSProvider(ctx context.Context, vcsProviderID pgtype.Text) (f1 FindVCSProviderRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProvider")
+// FindModuleVersionPolicyForUpdate implements Querier
+func (_d QuerierWithTracing) FindModuleVersionPolicyForUpdate(ctx context.Context, moduleVersionPolicyID pgtype.Text) (f1 FindModuleVersionPolicyForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindModuleVersionPolicyForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":           ctx,
-				"vcsProviderID": vcsProviderID}, map[string]interface{}{
+				"ctx":                   ctx,
+				"moduleVersionPolicyID": moduleVersionPolicyID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2732,17 +3219,17 @@ func (_d QuerierWithTracing) FindVCSProvider(ctx context.Context, vcsProviderID
 
 		_span.End()
 	}()
-	return _d.Querier.FindVCSProvider(ctx, vcsProviderID)
+	return _d.Querier.FindModuleVersionPolicyForUpdate(ctx, moduleVersionPolicyID)
 }
 
-// FindVCSProviderForUpdate implements Querier
-func (_d QuerierWithTracing) FindVCSProviderForUpdate(ctx context.Context, vcsProviderID pgtype.Text) (f1 FindVCSProviderForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProviderForUpdate")
+// FindNextUnallocatedJobForUpdate implements Querier
+func (_d QuerierWithTracing) FindNextUnallocatedJobForUpdate(ctx context.Context, params FindNextUnallocatedJobForUpdateParams) (f1 FindNextUnallocatedJobForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindNextUnallocatedJobForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":           ctx,
-				"vcsProviderID": vcsProviderID}, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2755,17 +3242,18 @@ func (_d QuerierWithTracing) FindVCSProviderForUpdate(ctx context.Context, vcsPr
 
 		_span.End()
 	}()
-	return _d.Querier.FindVCSProviderForUpdate(ctx, vcsProviderID)
+	return _d.Querier.FindNextUnallocatedJobForUpdate(ctx, params)
 }
 
-// FindVCSProviders implements Querier
-func (_d QuerierWithTracing) FindVCSProviders(ctx context.Context) (fa1 []FindVCSProvidersRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProviders")
+// FindNotificationConfiguration implements Querier
+func (_d QuerierWithTracing) FindNotificationConfiguration(ctx context.Context, notificationConfigurationID pgtype.Text) (f1 FindNotificationConfigurationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindNotificationConfiguration")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":                         ctx,
+				"notificationConfigurationID": notificationConfigurationID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2777,18 +3265,18 @@ func (_d QuerierWithTracing) FindVCSProviders(ctx context.Context) (fa1 []FindVC
 
 		_span.End()
 	}()
-	return _d.Querier.FindVCSProviders(ctx)
+	return _d.Querier.FindNotificationConfiguration(ctx, notificationConfigurationID)
 }
 
-// FindVCSProvidersByGithubAppInstallID implements Querier
-func (_d QuerierWithTracing) FindVCSProvidersByGithubAppInstallID(ctx context.Context, installID pgtype.Int8) (fa1 []FindVCSProvidersByGithubAppInstallIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProvidersByGithubAppInstallID")
+// FindNotificationConfigurationForUpdate implements Querier
+func (_d QuerierWithTracing) FindNotificationConfigurationForUpdate(ctx context.Context, notificationConfigurationID pgtype.Text) (f1 FindNotificationConfigurationForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindNotificationConfigurationForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":       ctx,
-				"installID": installID}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":                         ctx,
+				"notificationConfigurationID": notificationConfigurationID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2800,17 +3288,17 @@ func (_d QuerierWithTracing) FindVCSProvidersByGithubAppInstallID(ctx context.Co
 
 		_span.End()
 	}()
-	return _d.Querier.FindVCSProvidersByGithubAppInstallID(ctx, installID)
+	return _d.Querier.FindNotificationConfigurationForUpdate(ctx, notificationConfigurationID)
 }
 
-// FindVCSProvidersByOrganization implements Querier
-func (_d QuerierWithTracing) FindVCSProvidersByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindVCSProvidersByOrganizationRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProvidersByOrganization")
+// FindNotificationConfigurationsByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindNotificationConfigurationsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindNotificationConfigurationsByWorkspaceIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindNotificationConfigurationsByWorkspaceID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"organizationName": organizationName}, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -2823,17 +3311,17 @@ func (_d QuerierWithTracing) FindVCSProvidersByOrganization(ctx context.Context,
 
 		_span.End()
 	}()
-	return _d.Querier.FindVCSProvidersByOrganization(ctx, organizationName)
+	return _d.Querier.FindNotificationConfigurationsByWorkspaceID(ctx, workspaceID)
 }
 
-// FindVariable implements Querier
-func (_d QuerierWithTracing) FindVariable(ctx context.Context, variableID pgtype.Text) (f1 FindVariableRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariable")
+// FindOrganizationByID implements Querier
+func (_d QuerierWithTracing) FindOrganizationByID(ctx context.Context, organizationID pgtype.Text) (f1 FindOrganizationByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":        ctx,
-				"variableID": variableID}, map[string]interface{}{
+				"ctx":            ctx,
+				"organizationID": organizationID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2846,17 +3334,17 @@ func (_d QuerierWithTracing) FindVariable(ctx context.Context, variableID pgtype
 
 		_span.End()
 	}()
-	return _d.Querier.FindVariable(ctx, variableID)
+	return _d.Querier.FindOrganizationByID(ctx, organizationID)
 }
 
-// FindVariableSetBySetID implements Querier
-func (_d QuerierWithTracing) FindVariableSetBySetID(ctx context.Context, variableSetID pgtype.Text) (f1 FindVariableSetBySetIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetBySetID")
+// FindOrganizationByName implements Querier
+func (_d QuerierWithTracing) FindOrganizationByName(ctx context.Context, name pgtype.Text) (f1 FindOrganizationByNameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationByName")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":           ctx,
-				"variableSetID": variableSetID}, map[string]interface{}{
+				"ctx":  ctx,
+				"name": name}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2869,17 +3357,17 @@ func (_d QuerierWithTracing) FindVariableSetBySetID(ctx context.Context, variabl
 
 		_span.End()
 	}()
-	return _d.Querier.FindVariableSetBySetID(ctx, variableSetID)
+	return _d.Querier.FindOrganizationByName(ctx, name)
 }
 
-// FindVariableSetByVariableID implements Querier
-func (_d QuerierWithTracing) FindVariableSetByVariableID(ctx context.Context, variableID pgtype.Text) (f1 FindVariableSetByVariableIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetByVariableID")
+// FindOrganizationByNameForUpdate implements Querier
+func (_d QuerierWithTracing) FindOrganizationByNameForUpdate(ctx context.Context, name pgtype.Text) (f1 FindOrganizationByNameForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationByNameForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":        ctx,
-				"variableID": variableID}, map[string]interface{}{
+				"ctx":  ctx,
+				"name": name}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2892,18 +3380,18 @@ func (_d QuerierWithTracing) FindVariableSetByVariableID(ctx context.Context, va
 
 		_span.End()
 	}()
-	return _d.Querier.FindVariableSetByVariableID(ctx, variableID)
+	return _d.Querier.FindOrganizationByNameForUpdate(ctx, name)
 }
 
-// FindVariableSetForUpdate implements Querier
-func (_d QuerierWithTracing) FindVariableSetForUpdate(ctx context.Context, variableSetID pgtype.Text) (f1 FindVariableSetForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetForUpdate")
+// FindOrganizationNameByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindOrganizationNameByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationNameByWorkspaceID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":           ctx,
-				"variableSetID": variableSetID}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2915,12 +3403,12 @@ func (_d QuerierWithTracing) FindVariableSetForUpdate(ctx context.Context, varia
 
 		_span.End()
 	}()
-	return _d.Querier.FindVariableSetForUpdate(ctx, variableSetID)
+	return _d.Querier.FindOrganizationNameByWorkspaceID(ctx, workspaceID)
 }
 
-// FindVariableSetsByOrganization implements Querier
-func (_d QuerierWithTracing) FindVariableSetsByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindVariableSetsByOrganizationRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetsByOrganization")
+// FindOrganizationTokens implements Querier
+func (_d QuerierWithTracing) FindOrganizationTokens(ctx context.Context, organizationName pgtype.Text) (fa1 []FindOrganizationTokensRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationTokens")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -2938,18 +3426,18 @@ func (_d QuerierWithTracing) FindVariableSetsByOrganization(ctx context.Context,
 
 		_span.End()
 	}()
-	return _d.Querier.FindVariableSetsByOrganization(ctx, organizationName)
+	return _d.Querier.FindOrganizationTokens(ctx, organizationName)
 }
 
-// FindVariableSetsByWorkspace implements Querier
-func (_d QuerierWithTracing) FindVariableSetsByWorkspace(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindVariableSetsByWorkspaceRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetsByWorkspace")
+// FindOrganizationTokensByID implements Querier
+func (_d QuerierWithTracing) FindOrganizationTokensByID(ctx context.Context, organizationTokenID pgtype.Text) (f1 FindOrganizationTokensByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationTokensByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"workspaceID": workspaceID}, map[string]interface{}{
-				"fa1": fa1,
+				"ctx":                 ctx,
+				"organizationTokenID": organizationTokenID}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -2961,17 +3449,17 @@ func (_d QuerierWithTracing) FindVariableSetsByWorkspace(ctx context.Context, wo
 
 		_span.End()
 	}()
-	return _d.Querier.FindVariableSetsByWorkspace(ctx, workspaceID)
+	return _d.Querier.FindOrganizationTokensByID(ctx, organizationTokenID)
 }
 
-// FindWorkspaceByID implements Querier
-func (_d QuerierWithTracing) FindWorkspaceByID(ctx context.Context, id pgtype.Text) (f1 FindWorkspaceByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceByID")
+// FindOrganizationTokensByName implements Querier
+func (_d QuerierWithTracing) FindOrganizationTokensByName(ctx context.Context, organizationName pgtype.Text) (f1 FindOrganizationTokensByNameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationTokensByName")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx,
-				"id":  id}, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -2984,18 +3472,18 @@ func (_d QuerierWithTracing) FindWorkspaceByID(ctx context.Context, id pgtype.Te
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspaceByID(ctx, id)
+	return _d.Querier.FindOrganizationTokensByName(ctx, organizationName)
 }
 
-// FindWorkspaceByIDForUpdate implements Querier
-func (_d QuerierWithTracing) FindWorkspaceByIDForUpdate(ctx context.Context, id pgtype.Text) (f1 FindWorkspaceByIDForUpdateRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceByIDForUpdate")
+// FindOrganizations implements Querier
+func (_d QuerierWithTracing) FindOrganizations(ctx context.Context, params FindOrganizationsParams) (fa1 []FindOrganizationsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizations")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx,
-				"id":  id}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3007,19 +3495,18 @@ func (_d QuerierWithTracing) FindWorkspaceByIDForUpdate(ctx context.Context, id
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspaceByIDForUpdate(ctx, id)
+	return _d.Querier.FindOrganizations(ctx, params)
 }
 
-// FindWorkspaceByName implements Querier
-func (_d QuerierWithTracing) FindWorkspaceByName(ctx context.Context, name pgtype.Text, organizationName pgtype.Text) (f1 FindWorkspaceByNameRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceByName")
+// FindOrganizationsByUsername implements Querier
+func (_d QuerierWithTracing) FindOrganizationsByUsername(ctx context.Context, params FindOrganizationsByUsernameParams) (fa1 []FindOrganizationsByUsernameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrganizationsByUsername")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"name":             name,
-				"organizationName": organizationName}, map[string]interface{}{
-				"f1":  f1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3031,17 +3518,16 @@ func (_d QuerierWithTracing) FindWorkspaceByName(ctx context.Context, name pgtyp
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspaceByName(ctx, name, organizationName)
+	return _d.Querier.FindOrganizationsByUsername(ctx, params)
 }
 
-// FindWorkspacePermissionsByWorkspaceID implements Querier
-func (_d QuerierWithTracing) FindWorkspacePermissionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindWorkspacePermissionsByWorkspaceIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspacePermissionsByWorkspaceID")
+// FindOrphanedJobs implements Querier
+func (_d QuerierWithTracing) FindOrphanedJobs(ctx context.Context) (fa1 []FindOrphanedJobsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindOrphanedJobs")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"workspaceID": workspaceID}, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -3054,17 +3540,40 @@ func (_d QuerierWithTracing) FindWorkspacePermissionsByWorkspaceID(ctx context.C
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspacePermissionsByWorkspaceID(ctx, workspaceID)
+	return _d.Querier.FindOrphanedJobs(ctx)
 }
 
-// FindWorkspaceTags implements Querier
-func (_d QuerierWithTracing) FindWorkspaceTags(ctx context.Context, params FindWorkspaceTagsParams) (fa1 []FindWorkspaceTagsRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceTags")
+// FindProviderPlatformArchive implements Querier
+func (_d QuerierWithTracing) FindProviderPlatformArchive(ctx context.Context, params FindProviderPlatformArchiveParams) (ba1 []byte, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindProviderPlatformArchive")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
+				"ba1": ba1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindProviderPlatformArchive(ctx, params)
+}
+
+// FindProviderPlatforms implements Querier
+func (_d QuerierWithTracing) FindProviderPlatforms(ctx context.Context, providerVersionIds []string) (fa1 []FindProviderPlatformsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindProviderPlatforms")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":                ctx,
+				"providerVersionIds": providerVersionIds}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -3077,17 +3586,17 @@ func (_d QuerierWithTracing) FindWorkspaceTags(ctx context.Context, params FindW
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspaceTags(ctx, params)
+	return _d.Querier.FindProviderPlatforms(ctx, providerVersionIds)
 }
 
-// FindWorkspaceVariableByVariableID implements Querier
-func (_d QuerierWithTracing) FindWorkspaceVariableByVariableID(ctx context.Context, variableID pgtype.Text) (f1 FindWorkspaceVariableByVariableIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceVariableByVariableID")
+// FindProviderVersionByID implements Querier
+func (_d QuerierWithTracing) FindProviderVersionByID(ctx context.Context, providerVersionID pgtype.Text) (f1 FindProviderVersionByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindProviderVersionByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":        ctx,
-				"variableID": variableID}, map[string]interface{}{
+				"ctx":               ctx,
+				"providerVersionID": providerVersionID}, map[string]interface{}{
 				"f1":  f1,
 				"err": err})
 		} else if err != nil {
@@ -3100,12 +3609,82 @@ func (_d QuerierWithTracing) FindWorkspaceVariableByVariableID(ctx context.Conte
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspaceVariableByVariableID(ctx, variableID)
+	return _d.Querier.FindProviderVersionByID(ctx, providerVersionID)
 }
 
-// FindWorkspaceVariablesByWorkspaceID implements Querier
-func (_d QuerierWithTracing) FindWorkspaceVariablesByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindWorkspaceVariablesByWorkspaceIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceVariablesByWorkspaceID")
+// FindProviderVersions implements Querier
+func (_d QuerierWithTracing) FindProviderVersions(ctx context.Context, organizationName pgtype.Text, name pgtype.Text) (fa1 []FindProviderVersionsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindProviderVersions")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName,
+				"name":             name}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindProviderVersions(ctx, organizationName, name)
+}
+
+// FindQueueSLABreachedJobsByOrganization implements Querier
+func (_d QuerierWithTracing) FindQueueSLABreachedJobsByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindQueueSLABreachedJobsByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindQueueSLABreachedJobsByOrganization")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindQueueSLABreachedJobsByOrganization(ctx, organizationName)
+}
+
+// FindQueuedJobsByAgentPoolID implements Querier
+func (_d QuerierWithTracing) FindQueuedJobsByAgentPoolID(ctx context.Context, agentPoolID pgtype.Text) (fa1 []FindQueuedJobsByAgentPoolIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindQueuedJobsByAgentPoolID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"agentPoolID": agentPoolID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindQueuedJobsByAgentPoolID(ctx, agentPoolID)
+}
+
+// FindRepoMovesByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindRepoMovesByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindRepoMovesByWorkspaceIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRepoMovesByWorkspaceID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -3123,17 +3702,41 @@ func (_d QuerierWithTracing) FindWorkspaceVariablesByWorkspaceID(ctx context.Con
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspaceVariablesByWorkspaceID(ctx, workspaceID)
+	return _d.Querier.FindRepoMovesByWorkspaceID(ctx, workspaceID)
 }
 
-// FindWorkspaces implements Querier
-func (_d QuerierWithTracing) FindWorkspaces(ctx context.Context, params FindWorkspacesParams) (fa1 []FindWorkspacesRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaces")
+// FindRepohookByID implements Querier
+func (_d QuerierWithTracing) FindRepohookByID(ctx context.Context, repohookID pgtype.UUID) (f1 FindRepohookByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRepohookByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx":        ctx,
+				"repohookID": repohookID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindRepohookByID(ctx, repohookID)
+}
+
+// FindRepohookByRepoAndProvider implements Querier
+func (_d QuerierWithTracing) FindRepohookByRepoAndProvider(ctx context.Context, repoPath pgtype.Text, vcsProviderID pgtype.Text) (fa1 []FindRepohookByRepoAndProviderRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRepohookByRepoAndProvider")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"repoPath":      repoPath,
+				"vcsProviderID": vcsProviderID}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -3146,18 +3749,17 @@ func (_d QuerierWithTracing) FindWorkspaces(ctx context.Context, params FindWork
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspaces(ctx, params)
+	return _d.Querier.FindRepohookByRepoAndProvider(ctx, repoPath, vcsProviderID)
 }
 
-// FindWorkspacesByConnection implements Querier
-func (_d QuerierWithTracing) FindWorkspacesByConnection(ctx context.Context, vcsProviderID pgtype.Text, repoPath pgtype.Text) (fa1 []FindWorkspacesByConnectionRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspacesByConnection")
+// FindRepohookDeliveriesByVCSProviderID implements Querier
+func (_d QuerierWithTracing) FindRepohookDeliveriesByVCSProviderID(ctx context.Context, vcsProviderID pgtype.Text) (fa1 []FindRepohookDeliveriesByVCSProviderIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRepohookDeliveriesByVCSProviderID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":           ctx,
-				"vcsProviderID": vcsProviderID,
-				"repoPath":      repoPath}, map[string]interface{}{
+				"vcsProviderID": vcsProviderID}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -3170,17 +3772,39 @@ func (_d QuerierWithTracing) FindWorkspacesByConnection(ctx context.Context, vcs
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspacesByConnection(ctx, vcsProviderID, repoPath)
+	return _d.Querier.FindRepohookDeliveriesByVCSProviderID(ctx, vcsProviderID)
 }
 
-// FindWorkspacesByUsername implements Querier
-func (_d QuerierWithTracing) FindWorkspacesByUsername(ctx context.Context, params FindWorkspacesByUsernameParams) (fa1 []FindWorkspacesByUsernameRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspacesByUsername")
+// FindRepohookDeliveryByID implements Querier
+func (_d QuerierWithTracing) FindRepohookDeliveryByID(ctx context.Context, repohookDeliveryID pgtype.Text) (f1 FindRepohookDeliveryByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRepohookDeliveryByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx":                ctx,
+				"repohookDeliveryID": repohookDeliveryID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindRepohookDeliveryByID(ctx, repohookDeliveryID)
+}
+
+// FindRepohooks implements Querier
+func (_d QuerierWithTracing) FindRepohooks(ctx context.Context) (fa1 []FindRepohooksRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRepohooks")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
 				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
@@ -3193,19 +3817,19 @@ func (_d QuerierWithTracing) FindWorkspacesByUsername(ctx context.Context, param
 
 		_span.End()
 	}()
-	return _d.Querier.FindWorkspacesByUsername(ctx, params)
+	return _d.Querier.FindRepohooks(ctx)
 }
 
-// GetGPGKey implements Querier
-func (_d QuerierWithTracing) GetGPGKey(ctx context.Context, keyID pgtype.Text, organizationName pgtype.Text) (g1 GetGPGKeyRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetGPGKey")
+// FindRunArtifactByName implements Querier
+func (_d QuerierWithTracing) FindRunArtifactByName(ctx context.Context, runID pgtype.Text, name pgtype.Text) (f1 FindRunArtifactByNameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunArtifactByName")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"keyID":            keyID,
-				"organizationName": organizationName}, map[string]interface{}{
-				"g1":  g1,
+				"ctx":   ctx,
+				"runID": runID,
+				"name":  name}, map[string]interface{}{
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3217,18 +3841,18 @@ func (_d QuerierWithTracing) GetGPGKey(ctx context.Context, keyID pgtype.Text, o
 
 		_span.End()
 	}()
-	return _d.Querier.GetGPGKey(ctx, keyID, organizationName)
+	return _d.Querier.FindRunArtifactByName(ctx, runID, name)
 }
 
-// GetLockFileByID implements Querier
-func (_d QuerierWithTracing) GetLockFileByID(ctx context.Context, runID pgtype.Text) (ba1 []byte, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetLockFileByID")
+// FindRunArtifacts implements Querier
+func (_d QuerierWithTracing) FindRunArtifacts(ctx context.Context, runID pgtype.Text) (fa1 []FindRunArtifactsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunArtifacts")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":   ctx,
 				"runID": runID}, map[string]interface{}{
-				"ba1": ba1,
+				"fa1": fa1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3240,18 +3864,18 @@ func (_d QuerierWithTracing) GetLockFileByID(ctx context.Context, runID pgtype.T
 
 		_span.End()
 	}()
-	return _d.Querier.GetLockFileByID(ctx, runID)
+	return _d.Querier.FindRunArtifacts(ctx, runID)
 }
 
-// GetPlanBinByID implements Querier
-func (_d QuerierWithTracing) GetPlanBinByID(ctx context.Context, runID pgtype.Text) (ba1 []byte, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetPlanBinByID")
+// FindRunByID implements Querier
+func (_d QuerierWithTracing) FindRunByID(ctx context.Context, runID pgtype.Text) (f1 FindRunByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":   ctx,
 				"runID": runID}, map[string]interface{}{
-				"ba1": ba1,
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3263,18 +3887,18 @@ func (_d QuerierWithTracing) GetPlanBinByID(ctx context.Context, runID pgtype.Te
 
 		_span.End()
 	}()
-	return _d.Querier.GetPlanBinByID(ctx, runID)
+	return _d.Querier.FindRunByID(ctx, runID)
 }
 
-// GetPlanJSONByID implements Querier
-func (_d QuerierWithTracing) GetPlanJSONByID(ctx context.Context, runID pgtype.Text) (ba1 []byte, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetPlanJSONByID")
+// FindRunByIDForUpdate implements Querier
+func (_d QuerierWithTracing) FindRunByIDForUpdate(ctx context.Context, runID pgtype.Text) (f1 FindRunByIDForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunByIDForUpdate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":   ctx,
 				"runID": runID}, map[string]interface{}{
-				"ba1": ba1,
+				"f1":  f1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3286,18 +3910,3179 @@ func (_d QuerierWithTracing) GetPlanJSONByID(ctx context.Context, runID pgtype.T
 
 		_span.End()
 	}()
-	return _d.Querier.GetPlanJSONByID(ctx, runID)
+	return _d.Querier.FindRunByIDForUpdate(ctx, runID)
 }
 
-// InsertAgent implements Querier
+// FindRunCommentByID implements Querier
+func (_d QuerierWithTracing) FindRunCommentByID(ctx context.Context, commentID pgtype.Text) (f1 FindRunCommentByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunCommentByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":       ctx,
+				"commentID": commentID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindRunCommentByID(ctx, commentID)
+}
+
+// FindRunComments implements Querier
+func (_d QuerierWithTracing) FindRunComments(ctx context.Context, runID pgtype.Text) (fa1 []FindRunCommentsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunComments")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindRunComments(ctx, runID)
+}
+
+// FindRunDebugLogging implements Querier
+func (_d QuerierWithTracing) FindRunDebugLogging(ctx context.Context, runID pgtype.Text) (b1 pgtype.Bool, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunDebugLogging")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"b1":  b1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindRunDebugLogging(ctx, runID)
+}
+
+// FindRunLogSize implements Querier
+func (_d QuerierWithTracing) FindRunLogSize(ctx context.Context, runID pgtype.Text) (i1 pgtype.Int4, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunLogSize")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"i1":  i1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindRunLogSize(ctx, runID)
+}
+
+// FindRunPolicyChecks implements Querier
+func (_d QuerierWithTracing) FindRunPolicyChecks(ctx context.Context, runID pgtype.Text) (fa1 []FindRunPolicyChecksRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRunPolicyChecks")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindRunPolicyChecks(ctx, runID)
+}
+
+// FindRuns implements Querier
+func (_d QuerierWithTracing) FindRuns(ctx context.Context, params FindRunsParams) (fa1 []FindRunsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindRuns")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindRuns(ctx, params)
+}
+
+// FindSCIMTokenByID implements Querier
+func (_d QuerierWithTracing) FindSCIMTokenByID(ctx context.Context, scimTokenID pgtype.Text) (f1 FindSCIMTokenByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindSCIMTokenByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"scimTokenID": scimTokenID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindSCIMTokenByID(ctx, scimTokenID)
+}
+
+// FindSCIMTokenByOrganization implements Querier
+func (_d QuerierWithTracing) FindSCIMTokenByOrganization(ctx context.Context, organizationName pgtype.Text) (f1 FindSCIMTokenByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindSCIMTokenByOrganization")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindSCIMTokenByOrganization(ctx, organizationName)
+}
+
+// FindServerAgents implements Querier
+func (_d QuerierWithTracing) FindServerAgents(ctx context.Context) (fa1 []FindServerAgentsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindServerAgents")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindServerAgents(ctx)
+}
+
+// FindSharedModules implements Querier
+func (_d QuerierWithTracing) FindSharedModules(ctx context.Context, params FindSharedModulesParams) (fa1 []FindSharedModulesRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindSharedModules")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindSharedModules(ctx, params)
+}
+
+// FindStateVersionByID implements Querier
+func (_d QuerierWithTracing) FindStateVersionByID(ctx context.Context, id pgtype.Text) (f1 FindStateVersionByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx,
+				"id":  id}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindStateVersionByID(ctx, id)
+}
+
+// FindStateVersionByIDForUpdate implements Querier
+func (_d QuerierWithTracing) FindStateVersionByIDForUpdate(ctx context.Context, id pgtype.Text) (f1 FindStateVersionByIDForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionByIDForUpdate")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx,
+				"id":  id}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindStateVersionByIDForUpdate(ctx, id)
+}
+
+// FindStateVersionOutputByID implements Querier
+func (_d QuerierWithTracing) FindStateVersionOutputByID(ctx context.Context, id pgtype.Text) (f1 FindStateVersionOutputByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionOutputByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx,
+				"id":  id}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindStateVersionOutputByID(ctx, id)
+}
+
+// FindStateVersionStateByID implements Querier
+func (_d QuerierWithTracing) FindStateVersionStateByID(ctx context.Context, id pgtype.Text) (ba1 []byte, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionStateByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx,
+				"id":  id}, map[string]interface{}{
+				"ba1": ba1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindStateVersionStateByID(ctx, id)
+}
+
+// FindStateVersionsByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindStateVersionsByWorkspaceID(ctx context.Context, params FindStateVersionsByWorkspaceIDParams) (fa1 []FindStateVersionsByWorkspaceIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindStateVersionsByWorkspaceID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindStateVersionsByWorkspaceID(ctx, params)
+}
+
+// FindTagByID implements Querier
+func (_d QuerierWithTracing) FindTagByID(ctx context.Context, tagID pgtype.Text, organizationName pgtype.Text) (f1 FindTagByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTagByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"tagID":            tagID,
+				"organizationName": organizationName}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTagByID(ctx, tagID, organizationName)
+}
+
+// FindTagByName implements Querier
+func (_d QuerierWithTracing) FindTagByName(ctx context.Context, name pgtype.Text, organizationName pgtype.Text) (f1 FindTagByNameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTagByName")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"name":             name,
+				"organizationName": organizationName}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTagByName(ctx, name, organizationName)
+}
+
+// FindTags implements Querier
+func (_d QuerierWithTracing) FindTags(ctx context.Context, params FindTagsParams) (fa1 []FindTagsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTags")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTags(ctx, params)
+}
+
+// FindTeamByID implements Querier
+func (_d QuerierWithTracing) FindTeamByID(ctx context.Context, teamID pgtype.Text) (f1 FindTeamByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"teamID": teamID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTeamByID(ctx, teamID)
+}
+
+// FindTeamByIDForUpdate implements Querier
+func (_d QuerierWithTracing) FindTeamByIDForUpdate(ctx context.Context, teamID pgtype.Text) (f1 FindTeamByIDForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamByIDForUpdate")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"teamID": teamID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTeamByIDForUpdate(ctx, teamID)
+}
+
+// FindTeamByName implements Querier
+func (_d QuerierWithTracing) FindTeamByName(ctx context.Context, name pgtype.Text, organizationName pgtype.Text) (f1 FindTeamByNameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamByName")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"name":             name,
+				"organizationName": organizationName}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTeamByName(ctx, name, organizationName)
+}
+
+// FindTeamByTokenID implements Querier
+func (_d QuerierWithTracing) FindTeamByTokenID(ctx context.Context, tokenID pgtype.Text) (f1 FindTeamByTokenIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamByTokenID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":     ctx,
+				"tokenID": tokenID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTeamByTokenID(ctx, tokenID)
+}
+
+// FindTeamTokensByID implements Querier
+func (_d QuerierWithTracing) FindTeamTokensByID(ctx context.Context, teamID pgtype.Text) (fa1 []FindTeamTokensByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamTokensByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"teamID": teamID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTeamTokensByID(ctx, teamID)
+}
+
+// FindTeamsByOrg implements Querier
+func (_d QuerierWithTracing) FindTeamsByOrg(ctx context.Context, organizationName pgtype.Text) (fa1 []FindTeamsByOrgRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTeamsByOrg")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTeamsByOrg(ctx, organizationName)
+}
+
+// FindTerraformVersionPolicy implements Querier
+func (_d QuerierWithTracing) FindTerraformVersionPolicy(ctx context.Context) (f1 FindTerraformVersionPolicyRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTerraformVersionPolicy")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTerraformVersionPolicy(ctx)
+}
+
+// FindTokenByID implements Querier
+func (_d QuerierWithTracing) FindTokenByID(ctx context.Context, tokenID pgtype.Text) (f1 FindTokenByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTokenByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":     ctx,
+				"tokenID": tokenID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTokenByID(ctx, tokenID)
+}
+
+// FindTokensByUsername implements Querier
+func (_d QuerierWithTracing) FindTokensByUsername(ctx context.Context, username pgtype.Text) (fa1 []FindTokensByUsernameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTokensByUsername")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"username": username}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTokensByUsername(ctx, username)
+}
+
+// FindTruncatedLogPhases implements Querier
+func (_d QuerierWithTracing) FindTruncatedLogPhases(ctx context.Context, runID pgtype.Text) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindTruncatedLogPhases")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"ta1": ta1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindTruncatedLogPhases(ctx, runID)
+}
+
+// FindUnreferencedRepohooks implements Querier
+func (_d QuerierWithTracing) FindUnreferencedRepohooks(ctx context.Context) (fa1 []FindUnreferencedRepohooksRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUnreferencedRepohooks")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUnreferencedRepohooks(ctx)
+}
+
+// FindUpgradeCheck implements Querier
+func (_d QuerierWithTracing) FindUpgradeCheck(ctx context.Context) (f1 FindUpgradeCheckRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUpgradeCheck")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUpgradeCheck(ctx)
+}
+
+// FindUserByAuthenticationTokenID implements Querier
+func (_d QuerierWithTracing) FindUserByAuthenticationTokenID(ctx context.Context, tokenID pgtype.Text) (f1 FindUserByAuthenticationTokenIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUserByAuthenticationTokenID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":     ctx,
+				"tokenID": tokenID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUserByAuthenticationTokenID(ctx, tokenID)
+}
+
+// FindUserByEmail implements Querier
+func (_d QuerierWithTracing) FindUserByEmail(ctx context.Context, email pgtype.Text) (f1 FindUserByEmailRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUserByEmail")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"email": email}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUserByEmail(ctx, email)
+}
+
+// FindUserByExternalID implements Querier
+func (_d QuerierWithTracing) FindUserByExternalID(ctx context.Context, externalID pgtype.Text) (f1 FindUserByExternalIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUserByExternalID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"externalID": externalID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUserByExternalID(ctx, externalID)
+}
+
+// FindUserByID implements Querier
+func (_d QuerierWithTracing) FindUserByID(ctx context.Context, userID pgtype.Text) (f1 FindUserByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUserByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"userID": userID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUserByID(ctx, userID)
+}
+
+// FindUserByUsername implements Querier
+func (_d QuerierWithTracing) FindUserByUsername(ctx context.Context, username pgtype.Text) (f1 FindUserByUsernameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUserByUsername")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":      ctx,
+				"username": username}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUserByUsername(ctx, username)
+}
+
+// FindUsers implements Querier
+func (_d QuerierWithTracing) FindUsers(ctx context.Context) (fa1 []FindUsersRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUsers")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUsers(ctx)
+}
+
+// FindUsersByOrganization implements Querier
+func (_d QuerierWithTracing) FindUsersByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindUsersByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUsersByOrganization")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUsersByOrganization(ctx, organizationName)
+}
+
+// FindUsersByTeamID implements Querier
+func (_d QuerierWithTracing) FindUsersByTeamID(ctx context.Context, teamID pgtype.Text) (fa1 []FindUsersByTeamIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindUsersByTeamID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"teamID": teamID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindUsersByTeamID(ctx, teamID)
+}
+
+// FindVCSDefaults implements Querier
+func (_d QuerierWithTracing) FindVCSDefaults(ctx context.Context, organizationName pgtype.Text) (f1 FindVCSDefaultsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSDefaults")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVCSDefaults(ctx, organizationName)
+}
+
+// FindVCSProvider implements Querier
+func (_d QuerierWithTracing) FindVCSProvider(ctx context.Context, vcsProviderID pgtype.Text) (f1 FindVCSProviderRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProvider")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"vcsProviderID": vcsProviderID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVCSProvider(ctx, vcsProviderID)
+}
+
+// FindVCSProviderForUpdate implements Querier
+func (_d QuerierWithTracing) FindVCSProviderForUpdate(ctx context.Context, vcsProviderID pgtype.Text) (f1 FindVCSProviderForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProviderForUpdate")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"vcsProviderID": vcsProviderID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVCSProviderForUpdate(ctx, vcsProviderID)
+}
+
+// FindVCSProviders implements Querier
+func (_d QuerierWithTracing) FindVCSProviders(ctx context.Context) (fa1 []FindVCSProvidersRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProviders")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVCSProviders(ctx)
+}
+
+// FindVCSProvidersByGithubAppInstallID implements Querier
+func (_d QuerierWithTracing) FindVCSProvidersByGithubAppInstallID(ctx context.Context, installID pgtype.Int8) (fa1 []FindVCSProvidersByGithubAppInstallIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProvidersByGithubAppInstallID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":       ctx,
+				"installID": installID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVCSProvidersByGithubAppInstallID(ctx, installID)
+}
+
+// FindVCSProvidersByOrganization implements Querier
+func (_d QuerierWithTracing) FindVCSProvidersByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindVCSProvidersByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVCSProvidersByOrganization")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVCSProvidersByOrganization(ctx, organizationName)
+}
+
+// FindVariable implements Querier
+func (_d QuerierWithTracing) FindVariable(ctx context.Context, variableID pgtype.Text) (f1 FindVariableRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariable")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"variableID": variableID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVariable(ctx, variableID)
+}
+
+// FindVariableSetBySetID implements Querier
+func (_d QuerierWithTracing) FindVariableSetBySetID(ctx context.Context, variableSetID pgtype.Text) (f1 FindVariableSetBySetIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetBySetID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"variableSetID": variableSetID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVariableSetBySetID(ctx, variableSetID)
+}
+
+// FindVariableSetByVariableID implements Querier
+func (_d QuerierWithTracing) FindVariableSetByVariableID(ctx context.Context, variableID pgtype.Text) (f1 FindVariableSetByVariableIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetByVariableID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"variableID": variableID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVariableSetByVariableID(ctx, variableID)
+}
+
+// FindVariableSetForUpdate implements Querier
+func (_d QuerierWithTracing) FindVariableSetForUpdate(ctx context.Context, variableSetID pgtype.Text) (f1 FindVariableSetForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetForUpdate")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"variableSetID": variableSetID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVariableSetForUpdate(ctx, variableSetID)
+}
+
+// FindVariableSetsByOrganization implements Querier
+func (_d QuerierWithTracing) FindVariableSetsByOrganization(ctx context.Context, organizationName pgtype.Text) (fa1 []FindVariableSetsByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetsByOrganization")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVariableSetsByOrganization(ctx, organizationName)
+}
+
+// FindVariableSetsByWorkspace implements Querier
+func (_d QuerierWithTracing) FindVariableSetsByWorkspace(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindVariableSetsByWorkspaceRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindVariableSetsByWorkspace")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindVariableSetsByWorkspace(ctx, workspaceID)
+}
+
+// FindWorkspaceAssessment implements Querier
+func (_d QuerierWithTracing) FindWorkspaceAssessment(ctx context.Context, workspaceID pgtype.Text) (f1 FindWorkspaceAssessmentRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceAssessment")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceAssessment(ctx, workspaceID)
+}
+
+// FindWorkspaceByID implements Querier
+func (_d QuerierWithTracing) FindWorkspaceByID(ctx context.Context, id pgtype.Text) (f1 FindWorkspaceByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx,
+				"id":  id}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceByID(ctx, id)
+}
+
+// FindWorkspaceByIDForUpdate implements Querier
+func (_d QuerierWithTracing) FindWorkspaceByIDForUpdate(ctx context.Context, id pgtype.Text) (f1 FindWorkspaceByIDForUpdateRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceByIDForUpdate")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx": ctx,
+				"id":  id}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceByIDForUpdate(ctx, id)
+}
+
+// FindWorkspaceByName implements Querier
+func (_d QuerierWithTracing) FindWorkspaceByName(ctx context.Context, name pgtype.Text, organizationName pgtype.Text) (f1 FindWorkspaceByNameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceByName")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"name":             name,
+				"organizationName": organizationName}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceByName(ctx, name, organizationName)
+}
+
+// FindWorkspaceEventsByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindWorkspaceEventsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindWorkspaceEventsByWorkspaceIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceEventsByWorkspaceID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceEventsByWorkspaceID(ctx, workspaceID)
+}
+
+// FindWorkspaceNamesCaseInsensitive implements Querier
+func (_d QuerierWithTracing) FindWorkspaceNamesCaseInsensitive(ctx context.Context, params FindWorkspaceNamesCaseInsensitiveParams) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceNamesCaseInsensitive")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"ta1": ta1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceNamesCaseInsensitive(ctx, params)
+}
+
+// FindWorkspaceOutputMapping implements Querier
+func (_d QuerierWithTracing) FindWorkspaceOutputMapping(ctx context.Context, outputMappingID pgtype.Text) (f1 FindWorkspaceOutputMappingRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceOutputMapping")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":             ctx,
+				"outputMappingID": outputMappingID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceOutputMapping(ctx, outputMappingID)
+}
+
+// FindWorkspaceOutputMappings implements Querier
+func (_d QuerierWithTracing) FindWorkspaceOutputMappings(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindWorkspaceOutputMappingsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceOutputMappings")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceOutputMappings(ctx, workspaceID)
+}
+
+// FindWorkspacePermissionsByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindWorkspacePermissionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindWorkspacePermissionsByWorkspaceIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspacePermissionsByWorkspaceID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspacePermissionsByWorkspaceID(ctx, workspaceID)
+}
+
+// FindWorkspaceTags implements Querier
+func (_d QuerierWithTracing) FindWorkspaceTags(ctx context.Context, params FindWorkspaceTagsParams) (fa1 []FindWorkspaceTagsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceTags")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceTags(ctx, params)
+}
+
+// FindWorkspaceVariableByVariableID implements Querier
+func (_d QuerierWithTracing) FindWorkspaceVariableByVariableID(ctx context.Context, variableID pgtype.Text) (f1 FindWorkspaceVariableByVariableIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceVariableByVariableID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":        ctx,
+				"variableID": variableID}, map[string]interface{}{
+				"f1":  f1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceVariableByVariableID(ctx, variableID)
+}
+
+// FindWorkspaceVariablesByWorkspaceID implements Querier
+func (_d QuerierWithTracing) FindWorkspaceVariablesByWorkspaceID(ctx context.Context, workspaceID pgtype.Text) (fa1 []FindWorkspaceVariablesByWorkspaceIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaceVariablesByWorkspaceID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaceVariablesByWorkspaceID(ctx, workspaceID)
+}
+
+// FindWorkspaces implements Querier
+func (_d QuerierWithTracing) FindWorkspaces(ctx context.Context, params FindWorkspacesParams) (fa1 []FindWorkspacesRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspaces")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspaces(ctx, params)
+}
+
+// FindWorkspacesByConnection implements Querier
+func (_d QuerierWithTracing) FindWorkspacesByConnection(ctx context.Context, vcsProviderID pgtype.Text, repoPath pgtype.Text) (fa1 []FindWorkspacesByConnectionRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspacesByConnection")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"vcsProviderID": vcsProviderID,
+				"repoPath":      repoPath}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspacesByConnection(ctx, vcsProviderID, repoPath)
+}
+
+// FindWorkspacesByUsername implements Querier
+func (_d QuerierWithTracing) FindWorkspacesByUsername(ctx context.Context, params FindWorkspacesByUsernameParams) (fa1 []FindWorkspacesByUsernameRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.FindWorkspacesByUsername")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"fa1": fa1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.FindWorkspacesByUsername(ctx, params)
+}
+
+// GetGPGKey implements Querier
+func (_d QuerierWithTracing) GetGPGKey(ctx context.Context, keyID pgtype.Text, organizationName pgtype.Text) (g1 GetGPGKeyRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetGPGKey")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":              ctx,
+				"keyID":            keyID,
+				"organizationName": organizationName}, map[string]interface{}{
+				"g1":  g1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.GetGPGKey(ctx, keyID, organizationName)
+}
+
+// GetLockFileByID implements Querier
+func (_d QuerierWithTracing) GetLockFileByID(ctx context.Context, runID pgtype.Text) (ba1 []byte, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetLockFileByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"ba1": ba1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.GetLockFileByID(ctx, runID)
+}
+
+// GetModuleManifestByID implements Querier
+func (_d QuerierWithTracing) GetModuleManifestByID(ctx context.Context, runID pgtype.Text) (ba1 []byte, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetModuleManifestByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"ba1": ba1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.GetModuleManifestByID(ctx, runID)
+}
+
+// GetPlanBinByID implements Querier
+func (_d QuerierWithTracing) GetPlanBinByID(ctx context.Context, runID pgtype.Text) (ba1 []byte, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetPlanBinByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"ba1": ba1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.GetPlanBinByID(ctx, runID)
+}
+
+// GetPlanJSONByID implements Querier
+func (_d QuerierWithTracing) GetPlanJSONByID(ctx context.Context, runID pgtype.Text) (ba1 []byte, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetPlanJSONByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"ba1": ba1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.GetPlanJSONByID(ctx, runID)
+}
+
+// GetPlanRedactedJSONByID implements Querier
+func (_d QuerierWithTracing) GetPlanRedactedJSONByID(ctx context.Context, runID pgtype.Text) (ba1 []byte, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.GetPlanRedactedJSONByID")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":   ctx,
+				"runID": runID}, map[string]interface{}{
+				"ba1": ba1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.GetPlanRedactedJSONByID(ctx, runID)
+}
+
+// InsertAgent implements Querier
 func (_d QuerierWithTracing) InsertAgent(ctx context.Context, params InsertAgentParams) (c2 pgconn.CommandTag, err error) {
 	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgent")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertAgent(ctx, params)
+}
+
+// InsertAgentBootstrapToken implements Querier
+func (_d QuerierWithTracing) InsertAgentBootstrapToken(ctx context.Context, params InsertAgentBootstrapTokenParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentBootstrapToken")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertAgentBootstrapToken(ctx, params)
+}
+
+// InsertAgentCredential implements Querier
+func (_d QuerierWithTracing) InsertAgentCredential(ctx context.Context, params InsertAgentCredentialParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentCredential")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertAgentCredential(ctx, params)
+}
+
+// InsertAgentDiagnosticsBundle implements Querier
+func (_d QuerierWithTracing) InsertAgentDiagnosticsBundle(ctx context.Context, params InsertAgentDiagnosticsBundleParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentDiagnosticsBundle")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertAgentDiagnosticsBundle(ctx, params)
+}
+
+// InsertAgentPool implements Querier
+func (_d QuerierWithTracing) InsertAgentPool(ctx context.Context, params InsertAgentPoolParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentPool")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertAgentPool(ctx, params)
+}
+
+// InsertAgentPoolAllowedWorkspaces implements Querier
+func (_d QuerierWithTracing) InsertAgentPoolAllowedWorkspaces(ctx context.Context, poolID pgtype.Text, workspaceIds []string) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentPoolAllowedWorkspaces")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":          ctx,
+				"poolID":       poolID,
+				"workspaceIds": workspaceIds}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertAgentPoolAllowedWorkspaces(ctx, poolID, workspaceIds)
+}
+
+// InsertAgentPoolScalingDecision implements Querier
+func (_d QuerierWithTracing) InsertAgentPoolScalingDecision(ctx context.Context, params InsertAgentPoolScalingDecisionParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentPoolScalingDecision")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertAgentPoolScalingDecision(ctx, params)
+}
+
+// InsertAgentStatusHistory implements Querier
+func (_d QuerierWithTracing) InsertAgentStatusHistory(ctx context.Context, params InsertAgentStatusHistoryParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentStatusHistory")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertAgentStatusHistory(ctx, params)
+}
+
+// InsertAgentToken implements Querier
+func (_d QuerierWithTracing) InsertAgentToken(ctx context.Context, params InsertAgentTokenParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentToken")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertAgentToken(ctx, params)
+}
+
+// InsertApply implements Querier
+func (_d QuerierWithTracing) InsertApply(ctx context.Context, runID pgtype.Text, status pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertApply")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"runID":  runID,
+				"status": status}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertApply(ctx, runID, status)
+}
+
+// InsertConfigurationVersion implements Querier
+func (_d QuerierWithTracing) InsertConfigurationVersion(ctx context.Context, params InsertConfigurationVersionParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertConfigurationVersion")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertConfigurationVersion(ctx, params)
+}
+
+// InsertConfigurationVersionStatusTimestamp implements Querier
+func (_d QuerierWithTracing) InsertConfigurationVersionStatusTimestamp(ctx context.Context, params InsertConfigurationVersionStatusTimestampParams) (i1 InsertConfigurationVersionStatusTimestampRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertConfigurationVersionStatusTimestamp")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"i1":  i1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertConfigurationVersionStatusTimestamp(ctx, params)
+}
+
+// InsertEventSink implements Querier
+func (_d QuerierWithTracing) InsertEventSink(ctx context.Context, name pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertEventSink")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":  ctx,
+				"name": name}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertEventSink(ctx, name)
+}
+
+// InsertEventSinkOutbox implements Querier
+func (_d QuerierWithTracing) InsertEventSinkOutbox(ctx context.Context, params InsertEventSinkOutboxParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertEventSinkOutbox")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertEventSinkOutbox(ctx, params)
+}
+
+// InsertGPGKey implements Querier
+func (_d QuerierWithTracing) InsertGPGKey(ctx context.Context, params InsertGPGKeyParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertGPGKey")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertGPGKey(ctx, params)
+}
+
+// InsertGithubApp implements Querier
+func (_d QuerierWithTracing) InsertGithubApp(ctx context.Context, params InsertGithubAppParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertGithubApp")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertGithubApp(ctx, params)
+}
+
+// InsertGithubAppInstall implements Querier
+func (_d QuerierWithTracing) InsertGithubAppInstall(ctx context.Context, params InsertGithubAppInstallParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertGithubAppInstall")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertGithubAppInstall(ctx, params)
+}
+
+// InsertIngressAttributes implements Querier
+func (_d QuerierWithTracing) InsertIngressAttributes(ctx context.Context, params InsertIngressAttributesParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertIngressAttributes")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertIngressAttributes(ctx, params)
+}
+
+// InsertJob implements Querier
+func (_d QuerierWithTracing) InsertJob(ctx context.Context, params InsertJobParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertJob")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertJob(ctx, params)
+}
+
+// InsertLatestTerraformVersion implements Querier
+func (_d QuerierWithTracing) InsertLatestTerraformVersion(ctx context.Context, version pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertLatestTerraformVersion")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":     ctx,
+				"version": version}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertLatestTerraformVersion(ctx, version)
+}
+
+// InsertLogBuffer implements Querier
+func (_d QuerierWithTracing) InsertLogBuffer(ctx context.Context, params InsertLogBufferParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertLogBuffer")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertLogBuffer(ctx, params)
+}
+
+// InsertLogChunk implements Querier
+func (_d QuerierWithTracing) InsertLogChunk(ctx context.Context, params InsertLogChunkParams) (i1 pgtype.Int4, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertLogChunk")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"i1":  i1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertLogChunk(ctx, params)
+}
+
+// InsertMaintenanceModeChange implements Querier
+func (_d QuerierWithTracing) InsertMaintenanceModeChange(ctx context.Context, params InsertMaintenanceModeChangeParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertMaintenanceModeChange")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertMaintenanceModeChange(ctx, params)
+}
+
+// InsertModule implements Querier
+func (_d QuerierWithTracing) InsertModule(ctx context.Context, params InsertModuleParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModule")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertModule(ctx, params)
+}
+
+// InsertModuleDownload implements Querier
+func (_d QuerierWithTracing) InsertModuleDownload(ctx context.Context, params InsertModuleDownloadParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModuleDownload")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertModuleDownload(ctx, params)
+}
+
+// InsertModuleShare implements Querier
+func (_d QuerierWithTracing) InsertModuleShare(ctx context.Context, params InsertModuleShareParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModuleShare")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertModuleShare(ctx, params)
+}
+
+// InsertModuleTarball implements Querier
+func (_d QuerierWithTracing) InsertModuleTarball(ctx context.Context, tarball []byte, moduleVersionID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModuleTarball")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":             ctx,
+				"tarball":         tarball,
+				"moduleVersionID": moduleVersionID}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertModuleTarball(ctx, tarball, moduleVersionID)
+}
+
+// InsertModuleTemplate implements Querier
+func (_d QuerierWithTracing) InsertModuleTemplate(ctx context.Context, params InsertModuleTemplateParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModuleTemplate")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertModuleTemplate(ctx, params)
+}
+
+// InsertModuleTemplateWorkspace implements Querier
+func (_d QuerierWithTracing) InsertModuleTemplateWorkspace(ctx context.Context, params InsertModuleTemplateWorkspaceParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModuleTemplateWorkspace")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertModuleTemplateWorkspace(ctx, params)
+}
+
+// InsertModuleVersion implements Querier
+func (_d QuerierWithTracing) InsertModuleVersion(ctx context.Context, params InsertModuleVersionParams) (i1 InsertModuleVersionRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModuleVersion")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"i1":  i1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertModuleVersion(ctx, params)
+}
+
+// InsertModuleVersionPolicy implements Querier
+func (_d QuerierWithTracing) InsertModuleVersionPolicy(ctx context.Context, params InsertModuleVersionPolicyParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModuleVersionPolicy")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertModuleVersionPolicy(ctx, params)
+}
+
+// InsertNotificationConfiguration implements Querier
+func (_d QuerierWithTracing) InsertNotificationConfiguration(ctx context.Context, params InsertNotificationConfigurationParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertNotificationConfiguration")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertNotificationConfiguration(ctx, params)
+}
+
+// InsertOrganization implements Querier
+func (_d QuerierWithTracing) InsertOrganization(ctx context.Context, params InsertOrganizationParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertOrganization")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertOrganization(ctx, params)
+}
+
+// InsertPhaseStatusTimestamp implements Querier
+func (_d QuerierWithTracing) InsertPhaseStatusTimestamp(ctx context.Context, params InsertPhaseStatusTimestampParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertPhaseStatusTimestamp")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertPhaseStatusTimestamp(ctx, params)
+}
+
+// InsertPlan implements Querier
+func (_d QuerierWithTracing) InsertPlan(ctx context.Context, runID pgtype.Text, status pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertPlan")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"runID":  runID,
+				"status": status}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertPlan(ctx, runID, status)
+}
+
+// InsertProviderPlatform implements Querier
+func (_d QuerierWithTracing) InsertProviderPlatform(ctx context.Context, params InsertProviderPlatformParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertProviderPlatform")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertProviderPlatform(ctx, params)
+}
+
+// InsertProviderVersion implements Querier
+func (_d QuerierWithTracing) InsertProviderVersion(ctx context.Context, params InsertProviderVersionParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertProviderVersion")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertProviderVersion(ctx, params)
+}
+
+// InsertRepoConnection implements Querier
+func (_d QuerierWithTracing) InsertRepoConnection(ctx context.Context, params InsertRepoConnectionParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRepoConnection")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertRepoConnection(ctx, params)
+}
+
+// InsertRepoMove implements Querier
+func (_d QuerierWithTracing) InsertRepoMove(ctx context.Context, params InsertRepoMoveParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRepoMove")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertRepoMove(ctx, params)
+}
+
+// InsertRepohook implements Querier
+func (_d QuerierWithTracing) InsertRepohook(ctx context.Context, params InsertRepohookParams) (i1 InsertRepohookRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRepohook")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"i1":  i1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertRepohook(ctx, params)
+}
+
+// InsertRepohookDelivery implements Querier
+func (_d QuerierWithTracing) InsertRepohookDelivery(ctx context.Context, params InsertRepohookDeliveryParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRepohookDelivery")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertRepohookDelivery(ctx, params)
+}
+
+// InsertRun implements Querier
+func (_d QuerierWithTracing) InsertRun(ctx context.Context, params InsertRunParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRun")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertRun(ctx, params)
+}
+
+// InsertRunComment implements Querier
+func (_d QuerierWithTracing) InsertRunComment(ctx context.Context, params InsertRunCommentParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRunComment")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertRunComment(ctx, params)
+}
+
+// InsertRunLabel implements Querier
+func (_d QuerierWithTracing) InsertRunLabel(ctx context.Context, params InsertRunLabelParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRunLabel")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertRunLabel(ctx, params)
+}
+
+// InsertRunStatusTimestamp implements Querier
+func (_d QuerierWithTracing) InsertRunStatusTimestamp(ctx context.Context, params InsertRunStatusTimestampParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRunStatusTimestamp")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertRunStatusTimestamp(ctx, params)
+}
+
+// InsertRunVariable implements Querier
+func (_d QuerierWithTracing) InsertRunVariable(ctx context.Context, params InsertRunVariableParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRunVariable")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertRunVariable(ctx, params)
+}
+
+// InsertStateVersion implements Querier
+func (_d QuerierWithTracing) InsertStateVersion(ctx context.Context, params InsertStateVersionParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertStateVersion")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertStateVersion(ctx, params)
+}
+
+// InsertStateVersionOutput implements Querier
+func (_d QuerierWithTracing) InsertStateVersionOutput(ctx context.Context, params InsertStateVersionOutputParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertStateVersionOutput")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertStateVersionOutput(ctx, params)
+}
+
+// InsertTag implements Querier
+func (_d QuerierWithTracing) InsertTag(ctx context.Context, params InsertTagParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertTag")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertTag(ctx, params)
+}
+
+// InsertTeam implements Querier
+func (_d QuerierWithTracing) InsertTeam(ctx context.Context, params InsertTeamParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertTeam")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertTeam(ctx, params)
+}
+
+// InsertTeamMembership implements Querier
+func (_d QuerierWithTracing) InsertTeamMembership(ctx context.Context, usernames []string, teamID pgtype.Text) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertTeamMembership")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":       ctx,
+				"usernames": usernames,
+				"teamID":    teamID}, map[string]interface{}{
+				"ta1": ta1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertTeamMembership(ctx, usernames, teamID)
+}
+
+// InsertTeamToken implements Querier
+func (_d QuerierWithTracing) InsertTeamToken(ctx context.Context, params InsertTeamTokenParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertTeamToken")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertTeamToken(ctx, params)
+}
+
+// InsertToken implements Querier
+func (_d QuerierWithTracing) InsertToken(ctx context.Context, params InsertTokenParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertToken")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertToken(ctx, params)
+}
+
+// InsertUser implements Querier
+func (_d QuerierWithTracing) InsertUser(ctx context.Context, params InsertUserParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertUser")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertUser(ctx, params)
+}
+
+// InsertVCSProvider implements Querier
+func (_d QuerierWithTracing) InsertVCSProvider(ctx context.Context, params InsertVCSProviderParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVCSProvider")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertVCSProvider(ctx, params)
+}
+
+// InsertVariable implements Querier
+func (_d QuerierWithTracing) InsertVariable(ctx context.Context, params InsertVariableParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVariable")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertVariable(ctx, params)
+}
+
+// InsertVariableSet implements Querier
+func (_d QuerierWithTracing) InsertVariableSet(ctx context.Context, params InsertVariableSetParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVariableSet")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertVariableSet(ctx, params)
+}
+
+// InsertVariableSetVariable implements Querier
+func (_d QuerierWithTracing) InsertVariableSetVariable(ctx context.Context, variableSetID pgtype.Text, variableID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVariableSetVariable")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"variableSetID": variableSetID,
+				"variableID":    variableID}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertVariableSetVariable(ctx, variableSetID, variableID)
+}
+
+// InsertVariableSetWorkspace implements Querier
+func (_d QuerierWithTracing) InsertVariableSetWorkspace(ctx context.Context, variableSetID pgtype.Text, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVariableSetWorkspace")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":           ctx,
+				"variableSetID": variableSetID,
+				"workspaceID":   workspaceID}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertVariableSetWorkspace(ctx, variableSetID, workspaceID)
+}
+
+// InsertWorkspace implements Querier
+func (_d QuerierWithTracing) InsertWorkspace(ctx context.Context, params InsertWorkspaceParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspace")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertWorkspace(ctx, params)
+}
+
+// InsertWorkspaceEvent implements Querier
+func (_d QuerierWithTracing) InsertWorkspaceEvent(ctx context.Context, params InsertWorkspaceEventParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspaceEvent")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertWorkspaceEvent(ctx, params)
+}
+
+// InsertWorkspaceOutputMapping implements Querier
+func (_d QuerierWithTracing) InsertWorkspaceOutputMapping(ctx context.Context, params InsertWorkspaceOutputMappingParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspaceOutputMapping")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertWorkspaceOutputMapping(ctx, params)
+}
+
+// InsertWorkspaceRunStats implements Querier
+func (_d QuerierWithTracing) InsertWorkspaceRunStats(ctx context.Context, params InsertWorkspaceRunStatsParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspaceRunStats")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertWorkspaceRunStats(ctx, params)
+}
+
+// InsertWorkspaceTag implements Querier
+func (_d QuerierWithTracing) InsertWorkspaceTag(ctx context.Context, tagID pgtype.Text, workspaceID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspaceTag")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"tagID":       tagID,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertWorkspaceTag(ctx, tagID, workspaceID)
+}
+
+// InsertWorkspaceTagByName implements Querier
+func (_d QuerierWithTracing) InsertWorkspaceTagByName(ctx context.Context, workspaceID pgtype.Text, tagName pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspaceTagByName")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"workspaceID": workspaceID,
+				"tagName":     tagName}, map[string]interface{}{
+				"t1":  t1,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertWorkspaceTagByName(ctx, workspaceID, tagName)
+}
+
+// InsertWorkspaceVariable implements Querier
+func (_d QuerierWithTracing) InsertWorkspaceVariable(ctx context.Context, variableID pgtype.Text, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspaceVariable")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":         ctx,
+				"variableID":  variableID,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"c2":  c2,
+				"err": err})
+		} else if err != nil {
+			_span.RecordError(err)
+			_span.SetAttributes(
+				attribute.String("event", "error"),
+				attribute.String("message", err.Error()),
+			)
+		}
+
+		_span.End()
+	}()
+	return _d.Querier.InsertWorkspaceVariable(ctx, variableID, workspaceID)
+}
+
+// ListGPGKeys implements Querier
+func (_d QuerierWithTracing) ListGPGKeys(ctx context.Context, organizationNames []string) (la1 []ListGPGKeysRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.ListGPGKeys")
+	defer func() {
+		if _d._spanDecorator != nil {
+			_d._spanDecorator(_span, map[string]interface{}{
+				"ctx":               ctx,
+				"organizationNames": organizationNames}, map[string]interface{}{
+				"la1": la1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3309,18 +7094,18 @@ func (_d QuerierWithTracing) InsertAgent(ctx context.Context, params InsertAgent
 
 		_span.End()
 	}()
-	return _d.Querier.InsertAgent(ctx, params)
+	return _d.Querier.ListGPGKeys(ctx, organizationNames)
 }
 
-// InsertAgentPool implements Querier
-func (_d QuerierWithTracing) InsertAgentPool(ctx context.Context, params InsertAgentPoolParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentPool")
+// ListModulesByOrganization implements Querier
+func (_d QuerierWithTracing) ListModulesByOrganization(ctx context.Context, organizationName pgtype.Text) (la1 []ListModulesByOrganizationRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.ListModulesByOrganization")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":              ctx,
+				"organizationName": organizationName}, map[string]interface{}{
+				"la1": la1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3332,19 +7117,19 @@ func (_d QuerierWithTracing) InsertAgentPool(ctx context.Context, params InsertA
 
 		_span.End()
 	}()
-	return _d.Querier.InsertAgentPool(ctx, params)
+	return _d.Querier.ListModulesByOrganization(ctx, organizationName)
 }
 
-// InsertAgentPoolAllowedWorkspace implements Querier
-func (_d QuerierWithTracing) InsertAgentPoolAllowedWorkspace(ctx context.Context, poolID pgtype.Text, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentPoolAllowedWorkspace")
+// PutLockFile implements Querier
+func (_d QuerierWithTracing) PutLockFile(ctx context.Context, lockFile []byte, runID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.PutLockFile")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"poolID":      poolID,
-				"workspaceID": workspaceID}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":      ctx,
+				"lockFile": lockFile,
+				"runID":    runID}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3356,18 +7141,19 @@ func (_d QuerierWithTracing) InsertAgentPoolAllowedWorkspace(ctx context.Context
 
 		_span.End()
 	}()
-	return _d.Querier.InsertAgentPoolAllowedWorkspace(ctx, poolID, workspaceID)
+	return _d.Querier.PutLockFile(ctx, lockFile, runID)
 }
 
-// InsertAgentToken implements Querier
-func (_d QuerierWithTracing) InsertAgentToken(ctx context.Context, params InsertAgentTokenParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertAgentToken")
+// PutModuleManifest implements Querier
+func (_d QuerierWithTracing) PutModuleManifest(ctx context.Context, moduleManifest []byte, runID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.PutModuleManifest")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":            ctx,
+				"moduleManifest": moduleManifest,
+				"runID":          runID}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3379,18 +7165,18 @@ func (_d QuerierWithTracing) InsertAgentToken(ctx context.Context, params Insert
 
 		_span.End()
 	}()
-	return _d.Querier.InsertAgentToken(ctx, params)
+	return _d.Querier.PutModuleManifest(ctx, moduleManifest, runID)
 }
 
-// InsertApply implements Querier
-func (_d QuerierWithTracing) InsertApply(ctx context.Context, runID pgtype.Text, status pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertApply")
+// ReleaseIdempotencyKey implements Querier
+func (_d QuerierWithTracing) ReleaseIdempotencyKey(ctx context.Context, subject pgtype.Text, idempotencyKey pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.ReleaseIdempotencyKey")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"runID":  runID,
-				"status": status}, map[string]interface{}{
+				"ctx":            ctx,
+				"subject":        subject,
+				"idempotencyKey": idempotencyKey}, map[string]interface{}{
 				"c2":  c2,
 				"err": err})
 		} else if err != nil {
@@ -3403,18 +7189,17 @@ func (_d QuerierWithTracing) InsertApply(ctx context.Context, runID pgtype.Text,
 
 		_span.End()
 	}()
-	return _d.Querier.InsertApply(ctx, runID, status)
+	return _d.Querier.ReleaseIdempotencyKey(ctx, subject, idempotencyKey)
 }
 
-// InsertConfigurationVersion implements Querier
-func (_d QuerierWithTracing) InsertConfigurationVersion(ctx context.Context, params InsertConfigurationVersionParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertConfigurationVersion")
+// ResetUserSiteAdmins implements Querier
+func (_d QuerierWithTracing) ResetUserSiteAdmins(ctx context.Context) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.ResetUserSiteAdmins")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx": ctx}, map[string]interface{}{
+				"ta1": ta1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3426,18 +7211,18 @@ func (_d QuerierWithTracing) InsertConfigurationVersion(ctx context.Context, par
 
 		_span.End()
 	}()
-	return _d.Querier.InsertConfigurationVersion(ctx, params)
+	return _d.Querier.ResetUserSiteAdmins(ctx)
 }
 
-// InsertConfigurationVersionStatusTimestamp implements Querier
-func (_d QuerierWithTracing) InsertConfigurationVersionStatusTimestamp(ctx context.Context, params InsertConfigurationVersionStatusTimestampParams) (i1 InsertConfigurationVersionStatusTimestampRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertConfigurationVersionStatusTimestamp")
+// RevokeGPGKey implements Querier
+func (_d QuerierWithTracing) RevokeGPGKey(ctx context.Context, params RevokeGPGKeyParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.RevokeGPGKey")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"i1":  i1,
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3449,18 +7234,19 @@ func (_d QuerierWithTracing) InsertConfigurationVersionStatusTimestamp(ctx conte
 
 		_span.End()
 	}()
-	return _d.Querier.InsertConfigurationVersionStatusTimestamp(ctx, params)
+	return _d.Querier.RevokeGPGKey(ctx, params)
 }
 
-// InsertGPGKey implements Querier
-func (_d QuerierWithTracing) InsertGPGKey(ctx context.Context, params InsertGPGKeyParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertGPGKey")
+// RevokeModuleShareByID implements Querier
+func (_d QuerierWithTracing) RevokeModuleShareByID(ctx context.Context, revokedAt pgtype.Timestamptz, moduleShareID pgtype.Text) (r1 RevokeModuleShareByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.RevokeModuleShareByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":           ctx,
+				"revokedAt":     revokedAt,
+				"moduleShareID": moduleShareID}, map[string]interface{}{
+				"r1":  r1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3472,18 +7258,19 @@ func (_d QuerierWithTracing) InsertGPGKey(ctx context.Context, params InsertGPGK
 
 		_span.End()
 	}()
-	return _d.Querier.InsertGPGKey(ctx, params)
+	return _d.Querier.RevokeModuleShareByID(ctx, revokedAt, moduleShareID)
 }
 
-// InsertGithubApp implements Querier
-func (_d QuerierWithTracing) InsertGithubApp(ctx context.Context, params InsertGithubAppParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertGithubApp")
+// SumOrganizationRunStats implements Querier
+func (_d QuerierWithTracing) SumOrganizationRunStats(ctx context.Context, organizationName pgtype.Text, since pgtype.Timestamptz) (s1 SumOrganizationRunStatsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.SumOrganizationRunStats")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":              ctx,
+				"organizationName": organizationName,
+				"since":            since}, map[string]interface{}{
+				"s1":  s1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3495,18 +7282,19 @@ func (_d QuerierWithTracing) InsertGithubApp(ctx context.Context, params InsertG
 
 		_span.End()
 	}()
-	return _d.Querier.InsertGithubApp(ctx, params)
+	return _d.Querier.SumOrganizationRunStats(ctx, organizationName, since)
 }
 
-// InsertGithubAppInstall implements Querier
-func (_d QuerierWithTracing) InsertGithubAppInstall(ctx context.Context, params InsertGithubAppInstallParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertGithubAppInstall")
+// SumWorkspaceRunStats implements Querier
+func (_d QuerierWithTracing) SumWorkspaceRunStats(ctx context.Context, workspaceID pgtype.Text, since pgtype.Timestamptz) (s1 SumWorkspaceRunStatsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.SumWorkspaceRunStats")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":         ctx,
+				"workspaceID": workspaceID,
+				"since":       since}, map[string]interface{}{
+				"s1":  s1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3518,17 +7306,18 @@ func (_d QuerierWithTracing) InsertGithubAppInstall(ctx context.Context, params
 
 		_span.End()
 	}()
-	return _d.Querier.InsertGithubAppInstall(ctx, params)
+	return _d.Querier.SumWorkspaceRunStats(ctx, workspaceID, since)
 }
 
-// InsertIngressAttributes implements Querier
-func (_d QuerierWithTracing) InsertIngressAttributes(ctx context.Context, params InsertIngressAttributesParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertIngressAttributes")
+// TrimAgentDiagnosticsBundles implements Querier
+func (_d QuerierWithTracing) TrimAgentDiagnosticsBundles(ctx context.Context, agentID pgtype.Text, limit pgtype.Int8) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.TrimAgentDiagnosticsBundles")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx":     ctx,
+				"agentID": agentID,
+				"limit":   limit}, map[string]interface{}{
 				"c2":  c2,
 				"err": err})
 		} else if err != nil {
@@ -3541,17 +7330,18 @@ func (_d QuerierWithTracing) InsertIngressAttributes(ctx context.Context, params
 
 		_span.End()
 	}()
-	return _d.Querier.InsertIngressAttributes(ctx, params)
+	return _d.Querier.TrimAgentDiagnosticsBundles(ctx, agentID, limit)
 }
 
-// InsertJob implements Querier
-func (_d QuerierWithTracing) InsertJob(ctx context.Context, params InsertJobParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertJob")
+// TrimAgentPoolScalingDecisions implements Querier
+func (_d QuerierWithTracing) TrimAgentPoolScalingDecisions(ctx context.Context, agentPoolID pgtype.Text, limit pgtype.Int8) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.TrimAgentPoolScalingDecisions")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx":         ctx,
+				"agentPoolID": agentPoolID,
+				"limit":       limit}, map[string]interface{}{
 				"c2":  c2,
 				"err": err})
 		} else if err != nil {
@@ -3564,17 +7354,18 @@ func (_d QuerierWithTracing) InsertJob(ctx context.Context, params InsertJobPara
 
 		_span.End()
 	}()
-	return _d.Querier.InsertJob(ctx, params)
+	return _d.Querier.TrimAgentPoolScalingDecisions(ctx, agentPoolID, limit)
 }
 
-// InsertLatestTerraformVersion implements Querier
-func (_d QuerierWithTracing) InsertLatestTerraformVersion(ctx context.Context, version pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertLatestTerraformVersion")
+// TrimAgentStatusHistory implements Querier
+func (_d QuerierWithTracing) TrimAgentStatusHistory(ctx context.Context, agentID pgtype.Text, limit pgtype.Int8) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.TrimAgentStatusHistory")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":     ctx,
-				"version": version}, map[string]interface{}{
+				"agentID": agentID,
+				"limit":   limit}, map[string]interface{}{
 				"c2":  c2,
 				"err": err})
 		} else if err != nil {
@@ -3587,18 +7378,19 @@ func (_d QuerierWithTracing) InsertLatestTerraformVersion(ctx context.Context, v
 
 		_span.End()
 	}()
-	return _d.Querier.InsertLatestTerraformVersion(ctx, version)
+	return _d.Querier.TrimAgentStatusHistory(ctx, agentID, limit)
 }
 
-// InsertLogChunk implements Querier
-func (_d QuerierWithTracing) InsertLogChunk(ctx context.Context, params InsertLogChunkParams) (i1 pgtype.Int4, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertLogChunk")
+// TrimRepohookDeliveries implements Querier
+func (_d QuerierWithTracing) TrimRepohookDeliveries(ctx context.Context, repohookID pgtype.UUID, limit pgtype.Int8) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.TrimRepohookDeliveries")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"i1":  i1,
+				"ctx":        ctx,
+				"repohookID": repohookID,
+				"limit":      limit}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3610,18 +7402,18 @@ func (_d QuerierWithTracing) InsertLogChunk(ctx context.Context, params InsertLo
 
 		_span.End()
 	}()
-	return _d.Querier.InsertLogChunk(ctx, params)
+	return _d.Querier.TrimRepohookDeliveries(ctx, repohookID, limit)
 }
 
-// InsertModule implements Querier
-func (_d QuerierWithTracing) InsertModule(ctx context.Context, params InsertModuleParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModule")
+// UpdateAgent implements Querier
+func (_d QuerierWithTracing) UpdateAgent(ctx context.Context, params UpdateAgentParams) (u1 UpdateAgentRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateAgent")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"u1":  u1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3633,19 +7425,18 @@ func (_d QuerierWithTracing) InsertModule(ctx context.Context, params InsertModu
 
 		_span.End()
 	}()
-	return _d.Querier.InsertModule(ctx, params)
+	return _d.Querier.UpdateAgent(ctx, params)
 }
 
-// InsertModuleTarball implements Querier
-func (_d QuerierWithTracing) InsertModuleTarball(ctx context.Context, tarball []byte, moduleVersionID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModuleTarball")
+// UpdateAgentPool implements Querier
+func (_d QuerierWithTracing) UpdateAgentPool(ctx context.Context, params UpdateAgentPoolParams) (u1 UpdateAgentPoolRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateAgentPool")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":             ctx,
-				"tarball":         tarball,
-				"moduleVersionID": moduleVersionID}, map[string]interface{}{
-				"t1":  t1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"u1":  u1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3657,18 +7448,19 @@ func (_d QuerierWithTracing) InsertModuleTarball(ctx context.Context, tarball []
 
 		_span.End()
 	}()
-	return _d.Querier.InsertModuleTarball(ctx, tarball, moduleVersionID)
+	return _d.Querier.UpdateAgentPool(ctx, params)
 }
 
-// InsertModuleVersion implements Querier
-func (_d QuerierWithTracing) InsertModuleVersion(ctx context.Context, params InsertModuleVersionParams) (i1 InsertModuleVersionRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertModuleVersion")
+// UpdateAgentPoolDeletedAt implements Querier
+func (_d QuerierWithTracing) UpdateAgentPoolDeletedAt(ctx context.Context, deletedAt pgtype.Timestamptz, poolID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateAgentPoolDeletedAt")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"i1":  i1,
+				"ctx":       ctx,
+				"deletedAt": deletedAt,
+				"poolID":    poolID}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3680,17 +7472,18 @@ func (_d QuerierWithTracing) InsertModuleVersion(ctx context.Context, params Ins
 
 		_span.End()
 	}()
-	return _d.Querier.InsertModuleVersion(ctx, params)
+	return _d.Querier.UpdateAgentPoolDeletedAt(ctx, deletedAt, poolID)
 }
 
-// InsertNotificationConfiguration implements Querier
-func (_d QuerierWithTracing) InsertNotificationConfiguration(ctx context.Context, params InsertNotificationConfigurationParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertNotificationConfiguration")
+// UpdateAgentTokenLastUsedAt implements Querier
+func (_d QuerierWithTracing) UpdateAgentTokenLastUsedAt(ctx context.Context, lastUsedAt pgtype.Timestamptz, agentTokenID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateAgentTokenLastUsedAt")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx":          ctx,
+				"lastUsedAt":   lastUsedAt,
+				"agentTokenID": agentTokenID}, map[string]interface{}{
 				"c2":  c2,
 				"err": err})
 		} else if err != nil {
@@ -3703,18 +7496,18 @@ func (_d QuerierWithTracing) InsertNotificationConfiguration(ctx context.Context
 
 		_span.End()
 	}()
-	return _d.Querier.InsertNotificationConfiguration(ctx, params)
+	return _d.Querier.UpdateAgentTokenLastUsedAt(ctx, lastUsedAt, agentTokenID)
 }
 
-// InsertOrganization implements Querier
-func (_d QuerierWithTracing) InsertOrganization(ctx context.Context, params InsertOrganizationParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertOrganization")
+// UpdateAppliedChangesByID implements Querier
+func (_d QuerierWithTracing) UpdateAppliedChangesByID(ctx context.Context, params UpdateAppliedChangesByIDParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateAppliedChangesByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3726,18 +7519,19 @@ func (_d QuerierWithTracing) InsertOrganization(ctx context.Context, params Inse
 
 		_span.End()
 	}()
-	return _d.Querier.InsertOrganization(ctx, params)
+	return _d.Querier.UpdateAppliedChangesByID(ctx, params)
 }
 
-// InsertPhaseStatusTimestamp implements Querier
-func (_d QuerierWithTracing) InsertPhaseStatusTimestamp(ctx context.Context, params InsertPhaseStatusTimestampParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertPhaseStatusTimestamp")
+// UpdateApplyStatusByID implements Querier
+func (_d QuerierWithTracing) UpdateApplyStatusByID(ctx context.Context, status pgtype.Text, runID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateApplyStatusByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"status": status,
+				"runID":  runID}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3749,19 +7543,19 @@ func (_d QuerierWithTracing) InsertPhaseStatusTimestamp(ctx context.Context, par
 
 		_span.End()
 	}()
-	return _d.Querier.InsertPhaseStatusTimestamp(ctx, params)
+	return _d.Querier.UpdateApplyStatusByID(ctx, status, runID)
 }
 
-// InsertPlan implements Querier
-func (_d QuerierWithTracing) InsertPlan(ctx context.Context, runID pgtype.Text, status pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertPlan")
+// UpdateCancelSignaledAt implements Querier
+func (_d QuerierWithTracing) UpdateCancelSignaledAt(ctx context.Context, cancelSignaledAt pgtype.Timestamptz, id pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateCancelSignaledAt")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"runID":  runID,
-				"status": status}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":              ctx,
+				"cancelSignaledAt": cancelSignaledAt,
+				"id":               id}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3773,18 +7567,19 @@ func (_d QuerierWithTracing) InsertPlan(ctx context.Context, runID pgtype.Text,
 
 		_span.End()
 	}()
-	return _d.Querier.InsertPlan(ctx, runID, status)
+	return _d.Querier.UpdateCancelSignaledAt(ctx, cancelSignaledAt, id)
 }
 
-// InsertRepoConnection implements Querier
-func (_d QuerierWithTracing) InsertRepoConnection(ctx context.Context, params InsertRepoConnectionParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRepoConnection")
+// UpdateConfigurationVersionConfigByID implements Querier
+func (_d QuerierWithTracing) UpdateConfigurationVersionConfigByID(ctx context.Context, config []byte, id pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateConfigurationVersionConfigByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"config": config,
+				"id":     id}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3796,18 +7591,18 @@ func (_d QuerierWithTracing) InsertRepoConnection(ctx context.Context, params In
 
 		_span.End()
 	}()
-	return _d.Querier.InsertRepoConnection(ctx, params)
+	return _d.Querier.UpdateConfigurationVersionConfigByID(ctx, config, id)
 }
 
-// InsertRepohook implements Querier
-func (_d QuerierWithTracing) InsertRepohook(ctx context.Context, params InsertRepohookParams) (i1 InsertRepohookRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRepohook")
+// UpdateConfigurationVersionErroredByID implements Querier
+func (_d QuerierWithTracing) UpdateConfigurationVersionErroredByID(ctx context.Context, id pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateConfigurationVersionErroredByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"i1":  i1,
+				"ctx": ctx,
+				"id":  id}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3819,12 +7614,12 @@ func (_d QuerierWithTracing) InsertRepohook(ctx context.Context, params InsertRe
 
 		_span.End()
 	}()
-	return _d.Querier.InsertRepohook(ctx, params)
+	return _d.Querier.UpdateConfigurationVersionErroredByID(ctx, id)
 }
 
-// InsertRun implements Querier
-func (_d QuerierWithTracing) InsertRun(ctx context.Context, params InsertRunParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRun")
+// UpdateEventSinkDelivered implements Querier
+func (_d QuerierWithTracing) UpdateEventSinkDelivered(ctx context.Context, params UpdateEventSinkDeliveredParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateEventSinkDelivered")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -3842,12 +7637,12 @@ func (_d QuerierWithTracing) InsertRun(ctx context.Context, params InsertRunPara
 
 		_span.End()
 	}()
-	return _d.Querier.InsertRun(ctx, params)
+	return _d.Querier.UpdateEventSinkDelivered(ctx, params)
 }
 
-// InsertRunStatusTimestamp implements Querier
-func (_d QuerierWithTracing) InsertRunStatusTimestamp(ctx context.Context, params InsertRunStatusTimestampParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRunStatusTimestamp")
+// UpdateEventSinkFailed implements Querier
+func (_d QuerierWithTracing) UpdateEventSinkFailed(ctx context.Context, params UpdateEventSinkFailedParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateEventSinkFailed")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -3865,18 +7660,19 @@ func (_d QuerierWithTracing) InsertRunStatusTimestamp(ctx context.Context, param
 
 		_span.End()
 	}()
-	return _d.Querier.InsertRunStatusTimestamp(ctx, params)
+	return _d.Querier.UpdateEventSinkFailed(ctx, params)
 }
 
-// InsertRunVariable implements Querier
-func (_d QuerierWithTracing) InsertRunVariable(ctx context.Context, params InsertRunVariableParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertRunVariable")
+// UpdateForceCancelAvailableAt implements Querier
+func (_d QuerierWithTracing) UpdateForceCancelAvailableAt(ctx context.Context, forceCancelAvailableAt pgtype.Timestamptz, id pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateForceCancelAvailableAt")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":                    ctx,
+				"forceCancelAvailableAt": forceCancelAvailableAt,
+				"id":                     id}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3888,12 +7684,12 @@ func (_d QuerierWithTracing) InsertRunVariable(ctx context.Context, params Inser
 
 		_span.End()
 	}()
-	return _d.Querier.InsertRunVariable(ctx, params)
+	return _d.Querier.UpdateForceCancelAvailableAt(ctx, forceCancelAvailableAt, id)
 }
 
-// InsertStateVersion implements Querier
-func (_d QuerierWithTracing) InsertStateVersion(ctx context.Context, params InsertStateVersionParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertStateVersion")
+// UpdateGPGKey implements Querier
+func (_d QuerierWithTracing) UpdateGPGKey(ctx context.Context, params UpdateGPGKeyParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateGPGKey")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -3911,12 +7707,12 @@ func (_d QuerierWithTracing) InsertStateVersion(ctx context.Context, params Inse
 
 		_span.End()
 	}()
-	return _d.Querier.InsertStateVersion(ctx, params)
+	return _d.Querier.UpdateGPGKey(ctx, params)
 }
 
-// InsertStateVersionOutput implements Querier
-func (_d QuerierWithTracing) InsertStateVersionOutput(ctx context.Context, params InsertStateVersionOutputParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertStateVersionOutput")
+// UpdateIdempotencyKeyResource implements Querier
+func (_d QuerierWithTracing) UpdateIdempotencyKeyResource(ctx context.Context, params UpdateIdempotencyKeyResourceParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateIdempotencyKeyResource")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -3934,18 +7730,18 @@ func (_d QuerierWithTracing) InsertStateVersionOutput(ctx context.Context, param
 
 		_span.End()
 	}()
-	return _d.Querier.InsertStateVersionOutput(ctx, params)
+	return _d.Querier.UpdateIdempotencyKeyResource(ctx, params)
 }
 
-// InsertTag implements Querier
-func (_d QuerierWithTracing) InsertTag(ctx context.Context, params InsertTagParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertTag")
+// UpdateJob implements Querier
+func (_d QuerierWithTracing) UpdateJob(ctx context.Context, params UpdateJobParams) (u1 UpdateJobRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateJob")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"u1":  u1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -3957,17 +7753,17 @@ func (_d QuerierWithTracing) InsertTag(ctx context.Context, params InsertTagPara
 
 		_span.End()
 	}()
-	return _d.Querier.InsertTag(ctx, params)
+	return _d.Querier.UpdateJob(ctx, params)
 }
 
-// InsertTeam implements Querier
-func (_d QuerierWithTracing) InsertTeam(ctx context.Context, params InsertTeamParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertTeam")
+// UpdateLatestTerraformVersion implements Querier
+func (_d QuerierWithTracing) UpdateLatestTerraformVersion(ctx context.Context, version pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateLatestTerraformVersion")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx":     ctx,
+				"version": version}, map[string]interface{}{
 				"c2":  c2,
 				"err": err})
 		} else if err != nil {
@@ -3980,19 +7776,18 @@ func (_d QuerierWithTracing) InsertTeam(ctx context.Context, params InsertTeamPa
 
 		_span.End()
 	}()
-	return _d.Querier.InsertTeam(ctx, params)
+	return _d.Querier.UpdateLatestTerraformVersion(ctx, version)
 }
 
-// InsertTeamMembership implements Querier
-func (_d QuerierWithTracing) InsertTeamMembership(ctx context.Context, usernames []string, teamID pgtype.Text) (ta1 []pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertTeamMembership")
+// UpdateLogBuffer implements Querier
+func (_d QuerierWithTracing) UpdateLogBuffer(ctx context.Context, params UpdateLogBufferParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateLogBuffer")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":       ctx,
-				"usernames": usernames,
-				"teamID":    teamID}, map[string]interface{}{
-				"ta1": ta1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4004,18 +7799,19 @@ func (_d QuerierWithTracing) InsertTeamMembership(ctx context.Context, usernames
 
 		_span.End()
 	}()
-	return _d.Querier.InsertTeamMembership(ctx, usernames, teamID)
+	return _d.Querier.UpdateLogBuffer(ctx, params)
 }
 
-// InsertTeamToken implements Querier
-func (_d QuerierWithTracing) InsertTeamToken(ctx context.Context, params InsertTeamTokenParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertTeamToken")
+// UpdateModuleStatusByID implements Querier
+func (_d QuerierWithTracing) UpdateModuleStatusByID(ctx context.Context, status pgtype.Text, moduleID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateModuleStatusByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":      ctx,
+				"status":   status,
+				"moduleID": moduleID}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4027,12 +7823,12 @@ func (_d QuerierWithTracing) InsertTeamToken(ctx context.Context, params InsertT
 
 		_span.End()
 	}()
-	return _d.Querier.InsertTeamToken(ctx, params)
+	return _d.Querier.UpdateModuleStatusByID(ctx, status, moduleID)
 }
 
-// InsertToken implements Querier
-func (_d QuerierWithTracing) InsertToken(ctx context.Context, params InsertTokenParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertToken")
+// UpdateModuleTemplate implements Querier
+func (_d QuerierWithTracing) UpdateModuleTemplate(ctx context.Context, params UpdateModuleTemplateParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateModuleTemplate")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -4050,12 +7846,12 @@ func (_d QuerierWithTracing) InsertToken(ctx context.Context, params InsertToken
 
 		_span.End()
 	}()
-	return _d.Querier.InsertToken(ctx, params)
+	return _d.Querier.UpdateModuleTemplate(ctx, params)
 }
 
-// InsertUser implements Querier
-func (_d QuerierWithTracing) InsertUser(ctx context.Context, params InsertUserParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertUser")
+// UpdateModuleTemplateWorkspace implements Querier
+func (_d QuerierWithTracing) UpdateModuleTemplateWorkspace(ctx context.Context, params UpdateModuleTemplateWorkspaceParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateModuleTemplateWorkspace")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -4073,12 +7869,12 @@ func (_d QuerierWithTracing) InsertUser(ctx context.Context, params InsertUserPa
 
 		_span.End()
 	}()
-	return _d.Querier.InsertUser(ctx, params)
+	return _d.Querier.UpdateModuleTemplateWorkspace(ctx, params)
 }
 
-// InsertVCSProvider implements Querier
-func (_d QuerierWithTracing) InsertVCSProvider(ctx context.Context, params InsertVCSProviderParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVCSProvider")
+// UpdateModuleVersionPolicy implements Querier
+func (_d QuerierWithTracing) UpdateModuleVersionPolicy(ctx context.Context, params UpdateModuleVersionPolicyParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateModuleVersionPolicy")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -4096,18 +7892,18 @@ func (_d QuerierWithTracing) InsertVCSProvider(ctx context.Context, params Inser
 
 		_span.End()
 	}()
-	return _d.Querier.InsertVCSProvider(ctx, params)
+	return _d.Querier.UpdateModuleVersionPolicy(ctx, params)
 }
 
-// InsertVariable implements Querier
-func (_d QuerierWithTracing) InsertVariable(ctx context.Context, params InsertVariableParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVariable")
+// UpdateModuleVersionStatusByID implements Querier
+func (_d QuerierWithTracing) UpdateModuleVersionStatusByID(ctx context.Context, params UpdateModuleVersionStatusByIDParams) (u1 UpdateModuleVersionStatusByIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateModuleVersionStatusByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"u1":  u1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4119,18 +7915,18 @@ func (_d QuerierWithTracing) InsertVariable(ctx context.Context, params InsertVa
 
 		_span.End()
 	}()
-	return _d.Querier.InsertVariable(ctx, params)
+	return _d.Querier.UpdateModuleVersionStatusByID(ctx, params)
 }
 
-// InsertVariableSet implements Querier
-func (_d QuerierWithTracing) InsertVariableSet(ctx context.Context, params InsertVariableSetParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVariableSet")
+// UpdateNotificationConfigurationByID implements Querier
+func (_d QuerierWithTracing) UpdateNotificationConfigurationByID(ctx context.Context, params UpdateNotificationConfigurationByIDParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateNotificationConfigurationByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4142,19 +7938,18 @@ func (_d QuerierWithTracing) InsertVariableSet(ctx context.Context, params Inser
 
 		_span.End()
 	}()
-	return _d.Querier.InsertVariableSet(ctx, params)
+	return _d.Querier.UpdateNotificationConfigurationByID(ctx, params)
 }
 
-// InsertVariableSetVariable implements Querier
-func (_d QuerierWithTracing) InsertVariableSetVariable(ctx context.Context, variableSetID pgtype.Text, variableID pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVariableSetVariable")
+// UpdateOrganizationByName implements Querier
+func (_d QuerierWithTracing) UpdateOrganizationByName(ctx context.Context, params UpdateOrganizationByNameParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateOrganizationByName")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":           ctx,
-				"variableSetID": variableSetID,
-				"variableID":    variableID}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4166,19 +7961,19 @@ func (_d QuerierWithTracing) InsertVariableSetVariable(ctx context.Context, vari
 
 		_span.End()
 	}()
-	return _d.Querier.InsertVariableSetVariable(ctx, variableSetID, variableID)
+	return _d.Querier.UpdateOrganizationByName(ctx, params)
 }
 
-// InsertVariableSetWorkspace implements Querier
-func (_d QuerierWithTracing) InsertVariableSetWorkspace(ctx context.Context, variableSetID pgtype.Text, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertVariableSetWorkspace")
+// UpdatePlanBinByID implements Querier
+func (_d QuerierWithTracing) UpdatePlanBinByID(ctx context.Context, planBin []byte, runID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdatePlanBinByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":           ctx,
-				"variableSetID": variableSetID,
-				"workspaceID":   workspaceID}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":     ctx,
+				"planBin": planBin,
+				"runID":   runID}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4190,18 +7985,19 @@ func (_d QuerierWithTracing) InsertVariableSetWorkspace(ctx context.Context, var
 
 		_span.End()
 	}()
-	return _d.Querier.InsertVariableSetWorkspace(ctx, variableSetID, workspaceID)
+	return _d.Querier.UpdatePlanBinByID(ctx, planBin, runID)
 }
 
-// InsertWorkspace implements Querier
-func (_d QuerierWithTracing) InsertWorkspace(ctx context.Context, params InsertWorkspaceParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspace")
+// UpdatePlanJSONByID implements Querier
+func (_d QuerierWithTracing) UpdatePlanJSONByID(ctx context.Context, planJSON []byte, runID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdatePlanJSONByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":      ctx,
+				"planJSON": planJSON,
+				"runID":    runID}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4213,18 +8009,18 @@ func (_d QuerierWithTracing) InsertWorkspace(ctx context.Context, params InsertW
 
 		_span.End()
 	}()
-	return _d.Querier.InsertWorkspace(ctx, params)
+	return _d.Querier.UpdatePlanJSONByID(ctx, planJSON, runID)
 }
 
-// InsertWorkspaceTag implements Querier
-func (_d QuerierWithTracing) InsertWorkspaceTag(ctx context.Context, tagID pgtype.Text, workspaceID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspaceTag")
+// UpdatePlanRedactedJSONByID implements Querier
+func (_d QuerierWithTracing) UpdatePlanRedactedJSONByID(ctx context.Context, planRedactedJSON []byte, runID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdatePlanRedactedJSONByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"tagID":       tagID,
-				"workspaceID": workspaceID}, map[string]interface{}{
+				"ctx":              ctx,
+				"planRedactedJSON": planRedactedJSON,
+				"runID":            runID}, map[string]interface{}{
 				"t1":  t1,
 				"err": err})
 		} else if err != nil {
@@ -4237,18 +8033,18 @@ func (_d QuerierWithTracing) InsertWorkspaceTag(ctx context.Context, tagID pgtyp
 
 		_span.End()
 	}()
-	return _d.Querier.InsertWorkspaceTag(ctx, tagID, workspaceID)
+	return _d.Querier.UpdatePlanRedactedJSONByID(ctx, planRedactedJSON, runID)
 }
 
-// InsertWorkspaceTagByName implements Querier
-func (_d QuerierWithTracing) InsertWorkspaceTagByName(ctx context.Context, workspaceID pgtype.Text, tagName pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspaceTagByName")
+// UpdatePlanStatusByID implements Querier
+func (_d QuerierWithTracing) UpdatePlanStatusByID(ctx context.Context, status pgtype.Text, runID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdatePlanStatusByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"workspaceID": workspaceID,
-				"tagName":     tagName}, map[string]interface{}{
+				"ctx":    ctx,
+				"status": status,
+				"runID":  runID}, map[string]interface{}{
 				"t1":  t1,
 				"err": err})
 		} else if err != nil {
@@ -4261,19 +8057,18 @@ func (_d QuerierWithTracing) InsertWorkspaceTagByName(ctx context.Context, works
 
 		_span.End()
 	}()
-	return _d.Querier.InsertWorkspaceTagByName(ctx, workspaceID, tagName)
+	return _d.Querier.UpdatePlanStatusByID(ctx, status, runID)
 }
 
-// InsertWorkspaceVariable implements Querier
-func (_d QuerierWithTracing) InsertWorkspaceVariable(ctx context.Context, variableID pgtype.Text, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.InsertWorkspaceVariable")
+// UpdatePlannedChangesByID implements Querier
+func (_d QuerierWithTracing) UpdatePlannedChangesByID(ctx context.Context, params UpdatePlannedChangesByIDParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdatePlannedChangesByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"variableID":  variableID,
-				"workspaceID": workspaceID}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4285,18 +8080,18 @@ func (_d QuerierWithTracing) InsertWorkspaceVariable(ctx context.Context, variab
 
 		_span.End()
 	}()
-	return _d.Querier.InsertWorkspaceVariable(ctx, variableID, workspaceID)
+	return _d.Querier.UpdatePlannedChangesByID(ctx, params)
 }
 
-// ListGPGKeys implements Querier
-func (_d QuerierWithTracing) ListGPGKeys(ctx context.Context, organizationNames []string) (la1 []ListGPGKeysRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.ListGPGKeys")
+// UpdateQueueSLABreachedJobs implements Querier
+func (_d QuerierWithTracing) UpdateQueueSLABreachedJobs(ctx context.Context, now pgtype.Timestamptz) (ua1 []UpdateQueueSLABreachedJobsRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateQueueSLABreachedJobs")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":               ctx,
-				"organizationNames": organizationNames}, map[string]interface{}{
-				"la1": la1,
+				"ctx": ctx,
+				"now": now}, map[string]interface{}{
+				"ua1": ua1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4308,18 +8103,18 @@ func (_d QuerierWithTracing) ListGPGKeys(ctx context.Context, organizationNames
 
 		_span.End()
 	}()
-	return _d.Querier.ListGPGKeys(ctx, organizationNames)
+	return _d.Querier.UpdateQueueSLABreachedJobs(ctx, now)
 }
 
-// ListModulesByOrganization implements Querier
-func (_d QuerierWithTracing) ListModulesByOrganization(ctx context.Context, organizationName pgtype.Text) (la1 []ListModulesByOrganizationRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.ListModulesByOrganization")
+// UpdateRepoConnectionsRepoPath implements Querier
+func (_d QuerierWithTracing) UpdateRepoConnectionsRepoPath(ctx context.Context, params UpdateRepoConnectionsRepoPathParams) (ua1 []UpdateRepoConnectionsRepoPathRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateRepoConnectionsRepoPath")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"organizationName": organizationName}, map[string]interface{}{
-				"la1": la1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"ua1": ua1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4331,19 +8126,19 @@ func (_d QuerierWithTracing) ListModulesByOrganization(ctx context.Context, orga
 
 		_span.End()
 	}()
-	return _d.Querier.ListModulesByOrganization(ctx, organizationName)
+	return _d.Querier.UpdateRepoConnectionsRepoPath(ctx, params)
 }
 
-// PutLockFile implements Querier
-func (_d QuerierWithTracing) PutLockFile(ctx context.Context, lockFile []byte, runID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.PutLockFile")
+// UpdateRepohookDeliveryTriggerDecisions implements Querier
+func (_d QuerierWithTracing) UpdateRepohookDeliveryTriggerDecisions(ctx context.Context, triggerDecisions []byte, repohookDeliveryID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateRepohookDeliveryTriggerDecisions")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":      ctx,
-				"lockFile": lockFile,
-				"runID":    runID}, map[string]interface{}{
-				"t1":  t1,
+				"ctx":                ctx,
+				"triggerDecisions":   triggerDecisions,
+				"repohookDeliveryID": repohookDeliveryID}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4355,17 +8150,19 @@ func (_d QuerierWithTracing) PutLockFile(ctx context.Context, lockFile []byte, r
 
 		_span.End()
 	}()
-	return _d.Querier.PutLockFile(ctx, lockFile, runID)
+	return _d.Querier.UpdateRepohookDeliveryTriggerDecisions(ctx, triggerDecisions, repohookDeliveryID)
 }
 
-// ResetUserSiteAdmins implements Querier
-func (_d QuerierWithTracing) ResetUserSiteAdmins(ctx context.Context) (ta1 []pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.ResetUserSiteAdmins")
+// UpdateRepohookVCSID implements Querier
+func (_d QuerierWithTracing) UpdateRepohookVCSID(ctx context.Context, vcsID pgtype.Text, repohookID pgtype.UUID) (u1 UpdateRepohookVCSIDRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateRepohookVCSID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx}, map[string]interface{}{
-				"ta1": ta1,
+				"ctx":        ctx,
+				"vcsID":      vcsID,
+				"repohookID": repohookID}, map[string]interface{}{
+				"u1":  u1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4377,18 +8174,18 @@ func (_d QuerierWithTracing) ResetUserSiteAdmins(ctx context.Context) (ta1 []pgt
 
 		_span.End()
 	}()
-	return _d.Querier.ResetUserSiteAdmins(ctx)
+	return _d.Querier.UpdateRepohookVCSID(ctx, vcsID, repohookID)
 }
 
-// UpdateAgent implements Querier
-func (_d QuerierWithTracing) UpdateAgent(ctx context.Context, params UpdateAgentParams) (u1 UpdateAgentRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateAgent")
+// UpdateRepohooksRepoPath implements Querier
+func (_d QuerierWithTracing) UpdateRepohooksRepoPath(ctx context.Context, params UpdateRepohooksRepoPathParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateRepohooksRepoPath")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"u1":  u1,
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4400,18 +8197,19 @@ func (_d QuerierWithTracing) UpdateAgent(ctx context.Context, params UpdateAgent
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateAgent(ctx, params)
+	return _d.Querier.UpdateRepohooksRepoPath(ctx, params)
 }
 
-// UpdateAgentPool implements Querier
-func (_d QuerierWithTracing) UpdateAgentPool(ctx context.Context, params UpdateAgentPoolParams) (u1 UpdateAgentPoolRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateAgentPool")
+// UpdateRunErrorCategory implements Querier
+func (_d QuerierWithTracing) UpdateRunErrorCategory(ctx context.Context, errorCategory pgtype.Text, id pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateRunErrorCategory")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"u1":  u1,
+				"ctx":           ctx,
+				"errorCategory": errorCategory,
+				"id":            id}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4423,12 +8221,12 @@ func (_d QuerierWithTracing) UpdateAgentPool(ctx context.Context, params UpdateA
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateAgentPool(ctx, params)
+	return _d.Querier.UpdateRunErrorCategory(ctx, errorCategory, id)
 }
 
-// UpdateAppliedChangesByID implements Querier
-func (_d QuerierWithTracing) UpdateAppliedChangesByID(ctx context.Context, params UpdateAppliedChangesByIDParams) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateAppliedChangesByID")
+// UpdateRunForceCanceledBy implements Querier
+func (_d QuerierWithTracing) UpdateRunForceCanceledBy(ctx context.Context, params UpdateRunForceCanceledByParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateRunForceCanceledBy")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -4446,18 +8244,18 @@ func (_d QuerierWithTracing) UpdateAppliedChangesByID(ctx context.Context, param
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateAppliedChangesByID(ctx, params)
+	return _d.Querier.UpdateRunForceCanceledBy(ctx, params)
 }
 
-// UpdateApplyStatusByID implements Querier
-func (_d QuerierWithTracing) UpdateApplyStatusByID(ctx context.Context, status pgtype.Text, runID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateApplyStatusByID")
+// UpdateRunStatus implements Querier
+func (_d QuerierWithTracing) UpdateRunStatus(ctx context.Context, status pgtype.Text, id pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateRunStatus")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"status": status,
-				"runID":  runID}, map[string]interface{}{
+				"id":     id}, map[string]interface{}{
 				"t1":  t1,
 				"err": err})
 		} else if err != nil {
@@ -4470,19 +8268,19 @@ func (_d QuerierWithTracing) UpdateApplyStatusByID(ctx context.Context, status p
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateApplyStatusByID(ctx, status, runID)
+	return _d.Querier.UpdateRunStatus(ctx, status, id)
 }
 
-// UpdateCancelSignaledAt implements Querier
-func (_d QuerierWithTracing) UpdateCancelSignaledAt(ctx context.Context, cancelSignaledAt pgtype.Timestamptz, id pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateCancelSignaledAt")
+// UpdateState implements Querier
+func (_d QuerierWithTracing) UpdateState(ctx context.Context, state []byte, stateVersionID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateState")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":              ctx,
-				"cancelSignaledAt": cancelSignaledAt,
-				"id":               id}, map[string]interface{}{
-				"t1":  t1,
+				"ctx":            ctx,
+				"state":          state,
+				"stateVersionID": stateVersionID}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4494,18 +8292,17 @@ func (_d QuerierWithTracing) UpdateCancelSignaledAt(ctx context.Context, cancelS
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateCancelSignaledAt(ctx, cancelSignaledAt, id)
+	return _d.Querier.UpdateState(ctx, state, stateVersionID)
 }
 
-// UpdateConfigurationVersionConfigByID implements Querier
-func (_d QuerierWithTracing) UpdateConfigurationVersionConfigByID(ctx context.Context, config []byte, id pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateConfigurationVersionConfigByID")
+// UpdateTeamByID implements Querier
+func (_d QuerierWithTracing) UpdateTeamByID(ctx context.Context, params UpdateTeamByIDParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateTeamByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
-				"config": config,
-				"id":     id}, map[string]interface{}{
+				"params": params}, map[string]interface{}{
 				"t1":  t1,
 				"err": err})
 		} else if err != nil {
@@ -4518,18 +8315,18 @@ func (_d QuerierWithTracing) UpdateConfigurationVersionConfigByID(ctx context.Co
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateConfigurationVersionConfigByID(ctx, config, id)
+	return _d.Querier.UpdateTeamByID(ctx, params)
 }
 
-// UpdateConfigurationVersionErroredByID implements Querier
-func (_d QuerierWithTracing) UpdateConfigurationVersionErroredByID(ctx context.Context, id pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateConfigurationVersionErroredByID")
+// UpdateUser implements Querier
+func (_d QuerierWithTracing) UpdateUser(ctx context.Context, params UpdateUserParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateUser")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx": ctx,
-				"id":  id}, map[string]interface{}{
-				"t1":  t1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4541,18 +8338,18 @@ func (_d QuerierWithTracing) UpdateConfigurationVersionErroredByID(ctx context.C
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateConfigurationVersionErroredByID(ctx, id)
+	return _d.Querier.UpdateUser(ctx, params)
 }
 
-// UpdateGPGKey implements Querier
-func (_d QuerierWithTracing) UpdateGPGKey(ctx context.Context, params UpdateGPGKeyParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateGPGKey")
+// UpdateUserSiteAdmins implements Querier
+func (_d QuerierWithTracing) UpdateUserSiteAdmins(ctx context.Context, usernames []string) (ta1 []pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateUserSiteAdmins")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":       ctx,
+				"usernames": usernames}, map[string]interface{}{
+				"ta1": ta1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4564,12 +8361,12 @@ func (_d QuerierWithTracing) UpdateGPGKey(ctx context.Context, params UpdateGPGK
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateGPGKey(ctx, params)
+	return _d.Querier.UpdateUserSiteAdmins(ctx, usernames)
 }
 
-// UpdateJob implements Querier
-func (_d QuerierWithTracing) UpdateJob(ctx context.Context, params UpdateJobParams) (u1 UpdateJobRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateJob")
+// UpdateVCSProvider implements Querier
+func (_d QuerierWithTracing) UpdateVCSProvider(ctx context.Context, params UpdateVCSProviderParams) (u1 UpdateVCSProviderRow, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateVCSProvider")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -4587,18 +8384,18 @@ func (_d QuerierWithTracing) UpdateJob(ctx context.Context, params UpdateJobPara
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateJob(ctx, params)
+	return _d.Querier.UpdateVCSProvider(ctx, params)
 }
 
-// UpdateLatestTerraformVersion implements Querier
-func (_d QuerierWithTracing) UpdateLatestTerraformVersion(ctx context.Context, version pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateLatestTerraformVersion")
+// UpdateVariableByID implements Querier
+func (_d QuerierWithTracing) UpdateVariableByID(ctx context.Context, params UpdateVariableByIDParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateVariableByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"version": version}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4610,18 +8407,17 @@ func (_d QuerierWithTracing) UpdateLatestTerraformVersion(ctx context.Context, v
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateLatestTerraformVersion(ctx, version)
+	return _d.Querier.UpdateVariableByID(ctx, params)
 }
 
-// UpdateModuleStatusByID implements Querier
-func (_d QuerierWithTracing) UpdateModuleStatusByID(ctx context.Context, status pgtype.Text, moduleID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateModuleStatusByID")
+// UpdateVariableSetByID implements Querier
+func (_d QuerierWithTracing) UpdateVariableSetByID(ctx context.Context, params UpdateVariableSetByIDParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateVariableSetByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":      ctx,
-				"status":   status,
-				"moduleID": moduleID}, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
 				"t1":  t1,
 				"err": err})
 		} else if err != nil {
@@ -4634,18 +8430,18 @@ func (_d QuerierWithTracing) UpdateModuleStatusByID(ctx context.Context, status
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateModuleStatusByID(ctx, status, moduleID)
+	return _d.Querier.UpdateVariableSetByID(ctx, params)
 }
 
-// UpdateModuleVersionStatusByID implements Querier
-func (_d QuerierWithTracing) UpdateModuleVersionStatusByID(ctx context.Context, params UpdateModuleVersionStatusByIDParams) (u1 UpdateModuleVersionStatusByIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateModuleVersionStatusByID")
+// UpdateWorkspaceByID implements Querier
+func (_d QuerierWithTracing) UpdateWorkspaceByID(ctx context.Context, params UpdateWorkspaceByIDParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspaceByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"u1":  u1,
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4657,17 +8453,18 @@ func (_d QuerierWithTracing) UpdateModuleVersionStatusByID(ctx context.Context,
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateModuleVersionStatusByID(ctx, params)
+	return _d.Querier.UpdateWorkspaceByID(ctx, params)
 }
 
-// UpdateNotificationConfigurationByID implements Querier
-func (_d QuerierWithTracing) UpdateNotificationConfigurationByID(ctx context.Context, params UpdateNotificationConfigurationByIDParams) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateNotificationConfigurationByID")
+// UpdateWorkspaceCurrentStateVersionID implements Querier
+func (_d QuerierWithTracing) UpdateWorkspaceCurrentStateVersionID(ctx context.Context, stateVersionID pgtype.Text, workspaceID pgtype.Text) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspaceCurrentStateVersionID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
+				"ctx":            ctx,
+				"stateVersionID": stateVersionID,
+				"workspaceID":    workspaceID}, map[string]interface{}{
 				"t1":  t1,
 				"err": err})
 		} else if err != nil {
@@ -4680,18 +8477,18 @@ func (_d QuerierWithTracing) UpdateNotificationConfigurationByID(ctx context.Con
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateNotificationConfigurationByID(ctx, params)
+	return _d.Querier.UpdateWorkspaceCurrentStateVersionID(ctx, stateVersionID, workspaceID)
 }
 
-// UpdateOrganizationByName implements Querier
-func (_d QuerierWithTracing) UpdateOrganizationByName(ctx context.Context, params UpdateOrganizationByNameParams) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateOrganizationByName")
+// UpdateWorkspaceDebugLogging implements Querier
+func (_d QuerierWithTracing) UpdateWorkspaceDebugLogging(ctx context.Context, params UpdateWorkspaceDebugLoggingParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspaceDebugLogging")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"t1":  t1,
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4703,19 +8500,19 @@ func (_d QuerierWithTracing) UpdateOrganizationByName(ctx context.Context, param
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateOrganizationByName(ctx, params)
+	return _d.Querier.UpdateWorkspaceDebugLogging(ctx, params)
 }
 
-// UpdatePlanBinByID implements Querier
-func (_d QuerierWithTracing) UpdatePlanBinByID(ctx context.Context, planBin []byte, runID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdatePlanBinByID")
+// UpdateWorkspaceLatestRun implements Querier
+func (_d QuerierWithTracing) UpdateWorkspaceLatestRun(ctx context.Context, runID pgtype.Text, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspaceLatestRun")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":     ctx,
-				"planBin": planBin,
-				"runID":   runID}, map[string]interface{}{
-				"t1":  t1,
+				"ctx":         ctx,
+				"runID":       runID,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4727,19 +8524,18 @@ func (_d QuerierWithTracing) UpdatePlanBinByID(ctx context.Context, planBin []by
 
 		_span.End()
 	}()
-	return _d.Querier.UpdatePlanBinByID(ctx, planBin, runID)
+	return _d.Querier.UpdateWorkspaceLatestRun(ctx, runID, workspaceID)
 }
 
-// UpdatePlanJSONByID implements Querier
-func (_d QuerierWithTracing) UpdatePlanJSONByID(ctx context.Context, planJSON []byte, runID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdatePlanJSONByID")
+// UpdateWorkspaceLockByID implements Querier
+func (_d QuerierWithTracing) UpdateWorkspaceLockByID(ctx context.Context, params UpdateWorkspaceLockByIDParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspaceLockByID")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":      ctx,
-				"planJSON": planJSON,
-				"runID":    runID}, map[string]interface{}{
-				"t1":  t1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4751,19 +8547,19 @@ func (_d QuerierWithTracing) UpdatePlanJSONByID(ctx context.Context, planJSON []
 
 		_span.End()
 	}()
-	return _d.Querier.UpdatePlanJSONByID(ctx, planJSON, runID)
+	return _d.Querier.UpdateWorkspaceLockByID(ctx, params)
 }
 
-// UpdatePlanStatusByID implements Querier
-func (_d QuerierWithTracing) UpdatePlanStatusByID(ctx context.Context, status pgtype.Text, runID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdatePlanStatusByID")
+// UpdateWorkspacePausedAt implements Querier
+func (_d QuerierWithTracing) UpdateWorkspacePausedAt(ctx context.Context, pausedAt pgtype.Timestamptz, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspacePausedAt")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"status": status,
-				"runID":  runID}, map[string]interface{}{
-				"t1":  t1,
+				"ctx":         ctx,
+				"pausedAt":    pausedAt,
+				"workspaceID": workspaceID}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4775,12 +8571,12 @@ func (_d QuerierWithTracing) UpdatePlanStatusByID(ctx context.Context, status pg
 
 		_span.End()
 	}()
-	return _d.Querier.UpdatePlanStatusByID(ctx, status, runID)
+	return _d.Querier.UpdateWorkspacePausedAt(ctx, pausedAt, workspaceID)
 }
 
-// UpdatePlannedChangesByID implements Querier
-func (_d QuerierWithTracing) UpdatePlannedChangesByID(ctx context.Context, params UpdatePlannedChangesByIDParams) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdatePlannedChangesByID")
+// UploadAgentDiagnosticsBundle implements Querier
+func (_d QuerierWithTracing) UploadAgentDiagnosticsBundle(ctx context.Context, params UploadAgentDiagnosticsBundleParams) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UploadAgentDiagnosticsBundle")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -4798,19 +8594,18 @@ func (_d QuerierWithTracing) UpdatePlannedChangesByID(ctx context.Context, param
 
 		_span.End()
 	}()
-	return _d.Querier.UpdatePlannedChangesByID(ctx, params)
+	return _d.Querier.UploadAgentDiagnosticsBundle(ctx, params)
 }
 
-// UpdateRepohookVCSID implements Querier
-func (_d QuerierWithTracing) UpdateRepohookVCSID(ctx context.Context, vcsID pgtype.Text, repohookID pgtype.UUID) (u1 UpdateRepohookVCSIDRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateRepohookVCSID")
+// UpsertAgentPoolAutoscaler implements Querier
+func (_d QuerierWithTracing) UpsertAgentPoolAutoscaler(ctx context.Context, params UpsertAgentPoolAutoscalerParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertAgentPoolAutoscaler")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":        ctx,
-				"vcsID":      vcsID,
-				"repohookID": repohookID}, map[string]interface{}{
-				"u1":  u1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4822,19 +8617,19 @@ func (_d QuerierWithTracing) UpdateRepohookVCSID(ctx context.Context, vcsID pgty
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateRepohookVCSID(ctx, vcsID, repohookID)
+	return _d.Querier.UpsertAgentPoolAutoscaler(ctx, params)
 }
 
-// UpdateRunStatus implements Querier
-func (_d QuerierWithTracing) UpdateRunStatus(ctx context.Context, status pgtype.Text, id pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateRunStatus")
+// UpsertAllocatorStatus implements Querier
+func (_d QuerierWithTracing) UpsertAllocatorStatus(ctx context.Context, lastAllocatedAt pgtype.Timestamptz, status []byte) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertAllocatorStatus")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"status": status,
-				"id":     id}, map[string]interface{}{
-				"t1":  t1,
+				"ctx":             ctx,
+				"lastAllocatedAt": lastAllocatedAt,
+				"status":          status}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4846,18 +8641,17 @@ func (_d QuerierWithTracing) UpdateRunStatus(ctx context.Context, status pgtype.
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateRunStatus(ctx, status, id)
+	return _d.Querier.UpsertAllocatorStatus(ctx, lastAllocatedAt, status)
 }
 
-// UpdateState implements Querier
-func (_d QuerierWithTracing) UpdateState(ctx context.Context, state []byte, stateVersionID pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateState")
+// UpsertAuthLockout implements Querier
+func (_d QuerierWithTracing) UpsertAuthLockout(ctx context.Context, params UpsertAuthLockoutParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertAuthLockout")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":            ctx,
-				"state":          state,
-				"stateVersionID": stateVersionID}, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
 				"c2":  c2,
 				"err": err})
 		} else if err != nil {
@@ -4870,18 +8664,18 @@ func (_d QuerierWithTracing) UpdateState(ctx context.Context, state []byte, stat
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateState(ctx, state, stateVersionID)
+	return _d.Querier.UpsertAuthLockout(ctx, params)
 }
 
-// UpdateTeamByID implements Querier
-func (_d QuerierWithTracing) UpdateTeamByID(ctx context.Context, params UpdateTeamByIDParams) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateTeamByID")
+// UpsertMaintenanceMode implements Querier
+func (_d QuerierWithTracing) UpsertMaintenanceMode(ctx context.Context, params UpsertMaintenanceModeParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertMaintenanceMode")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"t1":  t1,
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4893,18 +8687,18 @@ func (_d QuerierWithTracing) UpdateTeamByID(ctx context.Context, params UpdateTe
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateTeamByID(ctx, params)
+	return _d.Querier.UpsertMaintenanceMode(ctx, params)
 }
 
-// UpdateUserSiteAdmins implements Querier
-func (_d QuerierWithTracing) UpdateUserSiteAdmins(ctx context.Context, usernames []string) (ta1 []pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateUserSiteAdmins")
+// UpsertOrganizationToken implements Querier
+func (_d QuerierWithTracing) UpsertOrganizationToken(ctx context.Context, params UpsertOrganizationTokenParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertOrganizationToken")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":       ctx,
-				"usernames": usernames}, map[string]interface{}{
-				"ta1": ta1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4916,18 +8710,18 @@ func (_d QuerierWithTracing) UpdateUserSiteAdmins(ctx context.Context, usernames
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateUserSiteAdmins(ctx, usernames)
+	return _d.Querier.UpsertOrganizationToken(ctx, params)
 }
 
-// UpdateVCSProvider implements Querier
-func (_d QuerierWithTracing) UpdateVCSProvider(ctx context.Context, params UpdateVCSProviderParams) (u1 UpdateVCSProviderRow, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateVCSProvider")
+// UpsertRunArtifact implements Querier
+func (_d QuerierWithTracing) UpsertRunArtifact(ctx context.Context, params UpsertRunArtifactParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertRunArtifact")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"u1":  u1,
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4939,18 +8733,18 @@ func (_d QuerierWithTracing) UpdateVCSProvider(ctx context.Context, params Updat
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateVCSProvider(ctx, params)
+	return _d.Querier.UpsertRunArtifact(ctx, params)
 }
 
-// UpdateVariableByID implements Querier
-func (_d QuerierWithTracing) UpdateVariableByID(ctx context.Context, params UpdateVariableByIDParams) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateVariableByID")
+// UpsertRunPolicyCheck implements Querier
+func (_d QuerierWithTracing) UpsertRunPolicyCheck(ctx context.Context, params UpsertRunPolicyCheckParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertRunPolicyCheck")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"t1":  t1,
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4962,18 +8756,18 @@ func (_d QuerierWithTracing) UpdateVariableByID(ctx context.Context, params Upda
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateVariableByID(ctx, params)
+	return _d.Querier.UpsertRunPolicyCheck(ctx, params)
 }
 
-// UpdateVariableSetByID implements Querier
-func (_d QuerierWithTracing) UpdateVariableSetByID(ctx context.Context, params UpdateVariableSetByIDParams) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateVariableSetByID")
+// UpsertSCIMToken implements Querier
+func (_d QuerierWithTracing) UpsertSCIMToken(ctx context.Context, params UpsertSCIMTokenParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertSCIMToken")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"t1":  t1,
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -4985,18 +8779,18 @@ func (_d QuerierWithTracing) UpdateVariableSetByID(ctx context.Context, params U
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateVariableSetByID(ctx, params)
+	return _d.Querier.UpsertSCIMToken(ctx, params)
 }
 
-// UpdateWorkspaceByID implements Querier
-func (_d QuerierWithTracing) UpdateWorkspaceByID(ctx context.Context, params UpdateWorkspaceByIDParams) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspaceByID")
+// UpsertTerraformVersionPolicy implements Querier
+func (_d QuerierWithTracing) UpsertTerraformVersionPolicy(ctx context.Context, params UpsertTerraformVersionPolicyParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertTerraformVersionPolicy")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
 				"ctx":    ctx,
 				"params": params}, map[string]interface{}{
-				"t1":  t1,
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -5008,19 +8802,18 @@ func (_d QuerierWithTracing) UpdateWorkspaceByID(ctx context.Context, params Upd
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateWorkspaceByID(ctx, params)
+	return _d.Querier.UpsertTerraformVersionPolicy(ctx, params)
 }
 
-// UpdateWorkspaceCurrentStateVersionID implements Querier
-func (_d QuerierWithTracing) UpdateWorkspaceCurrentStateVersionID(ctx context.Context, stateVersionID pgtype.Text, workspaceID pgtype.Text) (t1 pgtype.Text, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspaceCurrentStateVersionID")
+// UpsertUpgradeCheck implements Querier
+func (_d QuerierWithTracing) UpsertUpgradeCheck(ctx context.Context, params UpsertUpgradeCheckParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertUpgradeCheck")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":            ctx,
-				"stateVersionID": stateVersionID,
-				"workspaceID":    workspaceID}, map[string]interface{}{
-				"t1":  t1,
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
+				"c2":  c2,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -5032,18 +8825,17 @@ func (_d QuerierWithTracing) UpdateWorkspaceCurrentStateVersionID(ctx context.Co
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateWorkspaceCurrentStateVersionID(ctx, stateVersionID, workspaceID)
+	return _d.Querier.UpsertUpgradeCheck(ctx, params)
 }
 
-// UpdateWorkspaceLatestRun implements Querier
-func (_d QuerierWithTracing) UpdateWorkspaceLatestRun(ctx context.Context, runID pgtype.Text, workspaceID pgtype.Text) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspaceLatestRun")
+// UpsertVCSDefaults implements Querier
+func (_d QuerierWithTracing) UpsertVCSDefaults(ctx context.Context, params UpsertVCSDefaultsParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertVCSDefaults")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":         ctx,
-				"runID":       runID,
-				"workspaceID": workspaceID}, map[string]interface{}{
+				"ctx":    ctx,
+				"params": params}, map[string]interface{}{
 				"c2":  c2,
 				"err": err})
 		} else if err != nil {
@@ -5056,12 +8848,12 @@ func (_d QuerierWithTracing) UpdateWorkspaceLatestRun(ctx context.Context, runID
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateWorkspaceLatestRun(ctx, runID, workspaceID)
+	return _d.Querier.UpsertVCSDefaults(ctx, params)
 }
 
-// UpdateWorkspaceLockByID implements Querier
-func (_d QuerierWithTracing) UpdateWorkspaceLockByID(ctx context.Context, params UpdateWorkspaceLockByIDParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpdateWorkspaceLockByID")
+// UpsertWorkspaceAssessment implements Querier
+func (_d QuerierWithTracing) UpsertWorkspaceAssessment(ctx context.Context, params UpsertWorkspaceAssessmentParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertWorkspaceAssessment")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -5079,12 +8871,12 @@ func (_d QuerierWithTracing) UpdateWorkspaceLockByID(ctx context.Context, params
 
 		_span.End()
 	}()
-	return _d.Querier.UpdateWorkspaceLockByID(ctx, params)
+	return _d.Querier.UpsertWorkspaceAssessment(ctx, params)
 }
 
-// UpsertOrganizationToken implements Querier
-func (_d QuerierWithTracing) UpsertOrganizationToken(ctx context.Context, params UpsertOrganizationTokenParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertOrganizationToken")
+// UpsertWorkspacePermission implements Querier
+func (_d QuerierWithTracing) UpsertWorkspacePermission(ctx context.Context, params UpsertWorkspacePermissionParams) (c2 pgconn.CommandTag, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertWorkspacePermission")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
@@ -5102,18 +8894,19 @@ func (_d QuerierWithTracing) UpsertOrganizationToken(ctx context.Context, params
 
 		_span.End()
 	}()
-	return _d.Querier.UpsertOrganizationToken(ctx, params)
+	return _d.Querier.UpsertWorkspacePermission(ctx, params)
 }
 
-// UpsertWorkspacePermission implements Querier
-func (_d QuerierWithTracing) UpsertWorkspacePermission(ctx context.Context, params UpsertWorkspacePermissionParams) (c2 pgconn.CommandTag, err error) {
-	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UpsertWorkspacePermission")
+// UseAgentBootstrapToken implements Querier
+func (_d QuerierWithTracing) UseAgentBootstrapToken(ctx context.Context, agentBootstrapTokenID pgtype.Text, now pgtype.Timestamptz) (t1 pgtype.Text, err error) {
+	ctx, _span := otel.Tracer(_d._instance).Start(ctx, "Querier.UseAgentBootstrapToken")
 	defer func() {
 		if _d._spanDecorator != nil {
 			_d._spanDecorator(_span, map[string]interface{}{
-				"ctx":    ctx,
-				"params": params}, map[string]interface{}{
-				"c2":  c2,
+				"ctx":                   ctx,
+				"agentBootstrapTokenID": agentBootstrapTokenID,
+				"now":                   now}, map[string]interface{}{
+				"t1":  t1,
 				"err": err})
 		} else if err != nil {
 			_span.RecordError(err)
@@ -5125,5 +8918,5 @@ func (_d QuerierWithTracing) UpsertWorkspacePermission(ctx context.Context, para
 
 		_span.End()
 	}()
-	return _d.Querier.UpsertWorkspacePermission(ctx, params)
+	return _d.Querier.UseAgentBootstrapToken(ctx, agentBootstrapTokenID, now)
 }
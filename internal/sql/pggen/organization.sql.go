@@ -23,7 +23,13 @@ const insertOrganizationSQL = `INSERT INTO organizations (
     cost_estimation_enabled,
     session_remember,
     session_timeout,
-    allow_force_delete_workspaces
+    allow_force_delete_workspaces,
+    default_deletion_protection,
+    log_redaction_patterns,
+    queue_time_sla,
+    workspace_name_case,
+    max_agent_pools,
+    max_high_priority_runs
 ) VALUES (
     $1,
     $2,
@@ -34,7 +40,13 @@ const insertOrganizationSQL = `INSERT INTO organizations (
     $7,
     $8,
     $9,
-    $10
+    $10,
+    $11,
+    $12,
+    $13,
+    $14,
+    $15,
+    $16
 );`
 
 type InsertOrganizationParams struct {
@@ -48,12 +60,18 @@ type InsertOrganizationParams struct {
 	SessionRemember            pgtype.Int4        `json:"session_remember"`
 	SessionTimeout             pgtype.Int4        `json:"session_timeout"`
 	AllowForceDeleteWorkspaces pgtype.Bool        `json:"allow_force_delete_workspaces"`
+	DefaultDeletionProtection  pgtype.Bool        `json:"default_deletion_protection"`
+	LogRedactionPatterns       []string           `json:"log_redaction_patterns"`
+	QueueTimeSla               pgtype.Int4        `json:"queue_time_sla"`
+	WorkspaceNameCase          pgtype.Text        `json:"workspace_name_case"`
+	MaxAgentPools              pgtype.Int4        `json:"max_agent_pools"`
+	MaxHighPriorityRuns        pgtype.Int4        `json:"max_high_priority_runs"`
 }
 
 // InsertOrganization implements Querier.InsertOrganization.
 func (q *DBQuerier) InsertOrganization(ctx context.Context, params InsertOrganizationParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertOrganization")
-	cmdTag, err := q.conn.Exec(ctx, insertOrganizationSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces)
+	cmdTag, err := q.conn.Exec(ctx, insertOrganizationSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Name, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces, params.DefaultDeletionProtection, params.LogRedactionPatterns, params.QueueTimeSla, params.WorkspaceNameCase, params.MaxAgentPools, params.MaxHighPriorityRuns)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertOrganization: %w", err)
 	}
@@ -95,6 +113,12 @@ type FindOrganizationByNameRow struct {
 	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
 	AllowForceDeleteWorkspaces pgtype.Bool        `json:"allow_force_delete_workspaces"`
 	CostEstimationEnabled      pgtype.Bool        `json:"cost_estimation_enabled"`
+	DefaultDeletionProtection  pgtype.Bool        `json:"default_deletion_protection"`
+	LogRedactionPatterns       []string           `json:"log_redaction_patterns"`
+	QueueTimeSla               pgtype.Int4        `json:"queue_time_sla"`
+	WorkspaceNameCase          pgtype.Text        `json:"workspace_name_case"`
+	MaxAgentPools              pgtype.Int4        `json:"max_agent_pools"`
+	MaxHighPriorityRuns        pgtype.Int4        `json:"max_high_priority_runs"`
 }
 
 // FindOrganizationByName implements Querier.FindOrganizationByName.
@@ -117,6 +141,12 @@ func (q *DBQuerier) FindOrganizationByName(ctx context.Context, name pgtype.Text
 			&item.CollaboratorAuthPolicy,     // 'collaborator_auth_policy', 'CollaboratorAuthPolicy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowForceDeleteWorkspaces, // 'allow_force_delete_workspaces', 'AllowForceDeleteWorkspaces', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.CostEstimationEnabled,      // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DefaultDeletionProtection,  // 'default_deletion_protection', 'DefaultDeletionProtection', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogRedactionPatterns,       // 'log_redaction_patterns', 'LogRedactionPatterns', '[]string', '', '[]string'
+			&item.QueueTimeSla,               // 'queue_time_sla', 'QueueTimeSla', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceNameCase,          // 'workspace_name_case', 'WorkspaceNameCase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MaxAgentPools,              // 'max_agent_pools', 'MaxAgentPools', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.MaxHighPriorityRuns,        // 'max_high_priority_runs', 'MaxHighPriorityRuns', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -137,6 +167,12 @@ type FindOrganizationByIDRow struct {
 	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
 	AllowForceDeleteWorkspaces pgtype.Bool        `json:"allow_force_delete_workspaces"`
 	CostEstimationEnabled      pgtype.Bool        `json:"cost_estimation_enabled"`
+	DefaultDeletionProtection  pgtype.Bool        `json:"default_deletion_protection"`
+	LogRedactionPatterns       []string           `json:"log_redaction_patterns"`
+	QueueTimeSla               pgtype.Int4        `json:"queue_time_sla"`
+	WorkspaceNameCase          pgtype.Text        `json:"workspace_name_case"`
+	MaxAgentPools              pgtype.Int4        `json:"max_agent_pools"`
+	MaxHighPriorityRuns        pgtype.Int4        `json:"max_high_priority_runs"`
 }
 
 // FindOrganizationByID implements Querier.FindOrganizationByID.
@@ -159,6 +195,12 @@ func (q *DBQuerier) FindOrganizationByID(ctx context.Context, organizationID pgt
 			&item.CollaboratorAuthPolicy,     // 'collaborator_auth_policy', 'CollaboratorAuthPolicy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowForceDeleteWorkspaces, // 'allow_force_delete_workspaces', 'AllowForceDeleteWorkspaces', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.CostEstimationEnabled,      // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DefaultDeletionProtection,  // 'default_deletion_protection', 'DefaultDeletionProtection', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogRedactionPatterns,       // 'log_redaction_patterns', 'LogRedactionPatterns', '[]string', '', '[]string'
+			&item.QueueTimeSla,               // 'queue_time_sla', 'QueueTimeSla', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceNameCase,          // 'workspace_name_case', 'WorkspaceNameCase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MaxAgentPools,              // 'max_agent_pools', 'MaxAgentPools', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.MaxHighPriorityRuns,        // 'max_high_priority_runs', 'MaxHighPriorityRuns', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -183,6 +225,12 @@ type FindOrganizationByNameForUpdateRow struct {
 	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
 	AllowForceDeleteWorkspaces pgtype.Bool        `json:"allow_force_delete_workspaces"`
 	CostEstimationEnabled      pgtype.Bool        `json:"cost_estimation_enabled"`
+	DefaultDeletionProtection  pgtype.Bool        `json:"default_deletion_protection"`
+	LogRedactionPatterns       []string           `json:"log_redaction_patterns"`
+	QueueTimeSla               pgtype.Int4        `json:"queue_time_sla"`
+	WorkspaceNameCase          pgtype.Text        `json:"workspace_name_case"`
+	MaxAgentPools              pgtype.Int4        `json:"max_agent_pools"`
+	MaxHighPriorityRuns        pgtype.Int4        `json:"max_high_priority_runs"`
 }
 
 // FindOrganizationByNameForUpdate implements Querier.FindOrganizationByNameForUpdate.
@@ -205,6 +253,12 @@ func (q *DBQuerier) FindOrganizationByNameForUpdate(ctx context.Context, name pg
 			&item.CollaboratorAuthPolicy,     // 'collaborator_auth_policy', 'CollaboratorAuthPolicy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowForceDeleteWorkspaces, // 'allow_force_delete_workspaces', 'AllowForceDeleteWorkspaces', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.CostEstimationEnabled,      // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DefaultDeletionProtection,  // 'default_deletion_protection', 'DefaultDeletionProtection', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogRedactionPatterns,       // 'log_redaction_patterns', 'LogRedactionPatterns', '[]string', '', '[]string'
+			&item.QueueTimeSla,               // 'queue_time_sla', 'QueueTimeSla', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceNameCase,          // 'workspace_name_case', 'WorkspaceNameCase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MaxAgentPools,              // 'max_agent_pools', 'MaxAgentPools', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.MaxHighPriorityRuns,        // 'max_high_priority_runs', 'MaxHighPriorityRuns', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -215,12 +269,20 @@ func (q *DBQuerier) FindOrganizationByNameForUpdate(ctx context.Context, name pg
 const findOrganizationsSQL = `SELECT *
 FROM organizations
 WHERE name LIKE ANY($1)
-ORDER BY updated_at DESC
-LIMIT $2 OFFSET $3
+AND   name ILIKE '%' || $2 || '%'
+ORDER BY
+    CASE WHEN $3 = 'name' THEN name END ASC,
+    CASE WHEN $3 = '-name' THEN name END DESC,
+    CASE WHEN $3 = 'created-at' THEN created_at END ASC,
+    CASE WHEN $3 = '-created-at' THEN created_at END DESC,
+    updated_at DESC
+LIMIT $4 OFFSET $5
 ;`
 
 type FindOrganizationsParams struct {
 	Names  []string    `json:"names"`
+	Search pgtype.Text `json:"search"`
+	Sort   pgtype.Text `json:"sort"`
 	Limit  pgtype.Int8 `json:"limit"`
 	Offset pgtype.Int8 `json:"offset"`
 }
@@ -236,12 +298,18 @@ type FindOrganizationsRow struct {
 	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
 	AllowForceDeleteWorkspaces pgtype.Bool        `json:"allow_force_delete_workspaces"`
 	CostEstimationEnabled      pgtype.Bool        `json:"cost_estimation_enabled"`
+	DefaultDeletionProtection  pgtype.Bool        `json:"default_deletion_protection"`
+	LogRedactionPatterns       []string           `json:"log_redaction_patterns"`
+	QueueTimeSla               pgtype.Int4        `json:"queue_time_sla"`
+	WorkspaceNameCase          pgtype.Text        `json:"workspace_name_case"`
+	MaxAgentPools              pgtype.Int4        `json:"max_agent_pools"`
+	MaxHighPriorityRuns        pgtype.Int4        `json:"max_high_priority_runs"`
 }
 
 // FindOrganizations implements Querier.FindOrganizations.
 func (q *DBQuerier) FindOrganizations(ctx context.Context, params FindOrganizationsParams) ([]FindOrganizationsRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizations")
-	rows, err := q.conn.Query(ctx, findOrganizationsSQL, params.Names, params.Limit, params.Offset)
+	rows, err := q.conn.Query(ctx, findOrganizationsSQL, params.Names, params.Search, params.Sort, params.Limit, params.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("query FindOrganizations: %w", err)
 	}
@@ -258,6 +326,12 @@ func (q *DBQuerier) FindOrganizations(ctx context.Context, params FindOrganizati
 			&item.CollaboratorAuthPolicy,     // 'collaborator_auth_policy', 'CollaboratorAuthPolicy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowForceDeleteWorkspaces, // 'allow_force_delete_workspaces', 'AllowForceDeleteWorkspaces', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.CostEstimationEnabled,      // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DefaultDeletionProtection,  // 'default_deletion_protection', 'DefaultDeletionProtection', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogRedactionPatterns,       // 'log_redaction_patterns', 'LogRedactionPatterns', '[]string', '', '[]string'
+			&item.QueueTimeSla,               // 'queue_time_sla', 'QueueTimeSla', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceNameCase,          // 'workspace_name_case', 'WorkspaceNameCase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MaxAgentPools,              // 'max_agent_pools', 'MaxAgentPools', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.MaxHighPriorityRuns,        // 'max_high_priority_runs', 'MaxHighPriorityRuns', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -268,12 +342,13 @@ func (q *DBQuerier) FindOrganizations(ctx context.Context, params FindOrganizati
 const countOrganizationsSQL = `SELECT count(*)
 FROM organizations
 WHERE name LIKE ANY($1)
+AND   name ILIKE '%' || $2 || '%'
 ;`
 
 // CountOrganizations implements Querier.CountOrganizations.
-func (q *DBQuerier) CountOrganizations(ctx context.Context, names []string) (pgtype.Int8, error) {
+func (q *DBQuerier) CountOrganizations(ctx context.Context, names []string, search pgtype.Text) (pgtype.Int8, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "CountOrganizations")
-	rows, err := q.conn.Query(ctx, countOrganizationsSQL, names)
+	rows, err := q.conn.Query(ctx, countOrganizationsSQL, names, search)
 	if err != nil {
 		return pgtype.Int8{}, fmt.Errorf("query CountOrganizations: %w", err)
 	}
@@ -287,6 +362,77 @@ func (q *DBQuerier) CountOrganizations(ctx context.Context, names []string) (pgt
 	})
 }
 
+const findOrganizationsByUsernameSQL = `SELECT o.*
+FROM organizations o
+WHERE o.name ILIKE '%' || $1 || '%'
+AND EXISTS (
+    SELECT FROM teams t
+    JOIN team_memberships tm USING (team_id)
+    WHERE t.organization_name = o.name
+    AND   tm.username = $2
+)
+ORDER BY o.name ASC
+LIMIT $3
+;`
+
+type FindOrganizationsByUsernameParams struct {
+	Search   pgtype.Text `json:"search"`
+	Username pgtype.Text `json:"username"`
+	Limit    pgtype.Int8 `json:"limit"`
+}
+
+type FindOrganizationsByUsernameRow struct {
+	OrganizationID             pgtype.Text        `json:"organization_id"`
+	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
+	Name                       pgtype.Text        `json:"name"`
+	SessionRemember            pgtype.Int4        `json:"session_remember"`
+	SessionTimeout             pgtype.Int4        `json:"session_timeout"`
+	Email                      pgtype.Text        `json:"email"`
+	CollaboratorAuthPolicy     pgtype.Text        `json:"collaborator_auth_policy"`
+	AllowForceDeleteWorkspaces pgtype.Bool        `json:"allow_force_delete_workspaces"`
+	CostEstimationEnabled      pgtype.Bool        `json:"cost_estimation_enabled"`
+	DefaultDeletionProtection  pgtype.Bool        `json:"default_deletion_protection"`
+	LogRedactionPatterns       []string           `json:"log_redaction_patterns"`
+	QueueTimeSla               pgtype.Int4        `json:"queue_time_sla"`
+	WorkspaceNameCase          pgtype.Text        `json:"workspace_name_case"`
+	MaxAgentPools              pgtype.Int4        `json:"max_agent_pools"`
+	MaxHighPriorityRuns        pgtype.Int4        `json:"max_high_priority_runs"`
+}
+
+// FindOrganizationsByUsername implements Querier.FindOrganizationsByUsername.
+func (q *DBQuerier) FindOrganizationsByUsername(ctx context.Context, params FindOrganizationsByUsernameParams) ([]FindOrganizationsByUsernameRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindOrganizationsByUsername")
+	rows, err := q.conn.Query(ctx, findOrganizationsByUsernameSQL, params.Search, params.Username, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("query FindOrganizationsByUsername: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindOrganizationsByUsernameRow, error) {
+		var item FindOrganizationsByUsernameRow
+		if err := row.Scan(&item.OrganizationID, // 'organization_id', 'OrganizationID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,                  // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.UpdatedAt,                  // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.Name,                       // 'name', 'Name', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.SessionRemember,            // 'session_remember', 'SessionRemember', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.SessionTimeout,             // 'session_timeout', 'SessionTimeout', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.Email,                      // 'email', 'Email', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CollaboratorAuthPolicy,     // 'collaborator_auth_policy', 'CollaboratorAuthPolicy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AllowForceDeleteWorkspaces, // 'allow_force_delete_workspaces', 'AllowForceDeleteWorkspaces', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.CostEstimationEnabled,      // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DefaultDeletionProtection,  // 'default_deletion_protection', 'DefaultDeletionProtection', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogRedactionPatterns,       // 'log_redaction_patterns', 'LogRedactionPatterns', '[]string', '', '[]string'
+			&item.QueueTimeSla,               // 'queue_time_sla', 'QueueTimeSla', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.WorkspaceNameCase,          // 'workspace_name_case', 'WorkspaceNameCase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.MaxAgentPools,              // 'max_agent_pools', 'MaxAgentPools', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.MaxHighPriorityRuns,        // 'max_high_priority_runs', 'MaxHighPriorityRuns', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
 const updateOrganizationByNameSQL = `UPDATE organizations
 SET
     name = $1,
@@ -296,8 +442,14 @@ SET
     session_remember = $5,
     session_timeout = $6,
     allow_force_delete_workspaces = $7,
-    updated_at = $8
-WHERE name = $9
+    default_deletion_protection = $8,
+    log_redaction_patterns = $9,
+    queue_time_sla = $10,
+    workspace_name_case = $11,
+    max_agent_pools = $12,
+    max_high_priority_runs = $13,
+    updated_at = $14
+WHERE name = $15
 RETURNING organization_id;`
 
 type UpdateOrganizationByNameParams struct {
@@ -308,6 +460,12 @@ type UpdateOrganizationByNameParams struct {
 	SessionRemember            pgtype.Int4        `json:"session_remember"`
 	SessionTimeout             pgtype.Int4        `json:"session_timeout"`
 	AllowForceDeleteWorkspaces pgtype.Bool        `json:"allow_force_delete_workspaces"`
+	DefaultDeletionProtection  pgtype.Bool        `json:"default_deletion_protection"`
+	LogRedactionPatterns       []string           `json:"log_redaction_patterns"`
+	QueueTimeSla               pgtype.Int4        `json:"queue_time_sla"`
+	WorkspaceNameCase          pgtype.Text        `json:"workspace_name_case"`
+	MaxAgentPools              pgtype.Int4        `json:"max_agent_pools"`
+	MaxHighPriorityRuns        pgtype.Int4        `json:"max_high_priority_runs"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
 	Name                       pgtype.Text        `json:"name"`
 }
@@ -315,7 +473,7 @@ type UpdateOrganizationByNameParams struct {
 // UpdateOrganizationByName implements Querier.UpdateOrganizationByName.
 func (q *DBQuerier) UpdateOrganizationByName(ctx context.Context, params UpdateOrganizationByNameParams) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateOrganizationByName")
-	rows, err := q.conn.Query(ctx, updateOrganizationByNameSQL, params.NewName, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces, params.UpdatedAt, params.Name)
+	rows, err := q.conn.Query(ctx, updateOrganizationByNameSQL, params.NewName, params.Email, params.CollaboratorAuthPolicy, params.CostEstimationEnabled, params.SessionRemember, params.SessionTimeout, params.AllowForceDeleteWorkspaces, params.DefaultDeletionProtection, params.LogRedactionPatterns, params.QueueTimeSla, params.WorkspaceNameCase, params.MaxAgentPools, params.MaxHighPriorityRuns, params.UpdatedAt, params.Name)
 	if err != nil {
 		return pgtype.Text{}, fmt.Errorf("query UpdateOrganizationByName: %w", err)
 	}
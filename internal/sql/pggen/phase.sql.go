@@ -45,13 +45,15 @@ func (q *DBQuerier) InsertPhaseStatusTimestamp(ctx context.Context, params Inser
 const insertLogChunkSQL = `INSERT INTO logs (
     run_id,
     phase,
+    stream,
     chunk,
     _offset
 ) VALUES (
     $1,
     $2,
     $3,
-    $4
+    $4,
+    $5
 )
 RETURNING chunk_id
 ;`
@@ -59,6 +61,7 @@ RETURNING chunk_id
 type InsertLogChunkParams struct {
 	RunID  pgtype.Text `json:"run_id"`
 	Phase  pgtype.Text `json:"phase"`
+	Stream pgtype.Text `json:"stream"`
 	Chunk  []byte      `json:"chunk"`
 	Offset pgtype.Int4 `json:"offset"`
 }
@@ -66,7 +69,7 @@ type InsertLogChunkParams struct {
 // InsertLogChunk implements Querier.InsertLogChunk.
 func (q *DBQuerier) InsertLogChunk(ctx context.Context, params InsertLogChunkParams) (pgtype.Int4, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertLogChunk")
-	rows, err := q.conn.Query(ctx, insertLogChunkSQL, params.RunID, params.Phase, params.Chunk, params.Offset)
+	rows, err := q.conn.Query(ctx, insertLogChunkSQL, params.RunID, params.Phase, params.Stream, params.Chunk, params.Offset)
 	if err != nil {
 		return pgtype.Int4{}, fmt.Errorf("query InsertLogChunk: %w", err)
 	}
@@ -87,15 +90,22 @@ FROM (
     FROM logs
     WHERE run_id = $1
     AND   phase  = $2
+    AND   stream = $3
     ORDER BY chunk_id
 ) c
 GROUP BY run_id, phase
 ;`
 
+type FindLogsParams struct {
+	RunID  pgtype.Text `json:"run_id"`
+	Phase  pgtype.Text `json:"phase"`
+	Stream pgtype.Text `json:"stream"`
+}
+
 // FindLogs implements Querier.FindLogs.
-func (q *DBQuerier) FindLogs(ctx context.Context, runID pgtype.Text, phase pgtype.Text) ([]byte, error) {
+func (q *DBQuerier) FindLogs(ctx context.Context, params FindLogsParams) ([]byte, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindLogs")
-	rows, err := q.conn.Query(ctx, findLogsSQL, runID, phase)
+	rows, err := q.conn.Query(ctx, findLogsSQL, params.RunID, params.Phase, params.Stream)
 	if err != nil {
 		return nil, fmt.Errorf("query FindLogs: %w", err)
 	}
@@ -113,6 +123,7 @@ const findLogChunkByIDSQL = `SELECT
     chunk_id,
     run_id,
     phase,
+    stream,
     chunk,
     _offset AS offset
 FROM logs
@@ -123,6 +134,7 @@ type FindLogChunkByIDRow struct {
 	ChunkID pgtype.Int4 `json:"chunk_id"`
 	RunID   pgtype.Text `json:"run_id"`
 	Phase   pgtype.Text `json:"phase"`
+	Stream  pgtype.Text `json:"stream"`
 	Chunk   []byte      `json:"chunk"`
 	Offset  pgtype.Int4 `json:"offset"`
 }
@@ -140,6 +152,7 @@ func (q *DBQuerier) FindLogChunkByID(ctx context.Context, chunkID pgtype.Int4) (
 		if err := row.Scan(&item.ChunkID, // 'chunk_id', 'ChunkID', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
 			&item.RunID,  // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Phase,  // 'phase', 'Phase', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Stream, // 'stream', 'Stream', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Chunk,  // 'chunk', 'Chunk', '[]byte', '', '[]byte'
 			&item.Offset, // 'offset', 'Offset', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
 		); err != nil {
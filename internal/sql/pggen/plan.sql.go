@@ -140,6 +140,28 @@ func (q *DBQuerier) GetPlanJSONByID(ctx context.Context, runID pgtype.Text) ([]b
 	})
 }
 
+const getPlanRedactedJSONByIDSQL = `SELECT plan_redacted_json
+FROM plans
+WHERE run_id = $1
+;`
+
+// GetPlanRedactedJSONByID implements Querier.GetPlanRedactedJSONByID.
+func (q *DBQuerier) GetPlanRedactedJSONByID(ctx context.Context, runID pgtype.Text) ([]byte, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetPlanRedactedJSONByID")
+	rows, err := q.conn.Query(ctx, getPlanRedactedJSONByIDSQL, runID)
+	if err != nil {
+		return nil, fmt.Errorf("query GetPlanRedactedJSONByID: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) ([]byte, error) {
+		var item []byte
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
 const updatePlanBinByIDSQL = `UPDATE plans
 SET plan_bin = $1
 WHERE run_id = $2
@@ -185,3 +207,26 @@ func (q *DBQuerier) UpdatePlanJSONByID(ctx context.Context, planJSON []byte, run
 		return item, nil
 	})
 }
+
+const updatePlanRedactedJSONByIDSQL = `UPDATE plans
+SET plan_redacted_json = $1
+WHERE run_id = $2
+RETURNING run_id
+;`
+
+// UpdatePlanRedactedJSONByID implements Querier.UpdatePlanRedactedJSONByID.
+func (q *DBQuerier) UpdatePlanRedactedJSONByID(ctx context.Context, planRedactedJSON []byte, runID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdatePlanRedactedJSONByID")
+	rows, err := q.conn.Query(ctx, updatePlanRedactedJSONByIDSQL, planRedactedJSON, runID)
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("query UpdatePlanRedactedJSONByID: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
@@ -107,3 +107,70 @@ func (q *DBQuerier) DeleteModuleConnectionByID(ctx context.Context, moduleID pgt
 		return item, nil
 	})
 }
+
+const updateRepoConnectionsRepoPathSQL = `UPDATE repo_connections
+SET repo_path = $1
+WHERE vcs_provider_id = $2
+AND   repo_path = $3
+RETURNING *;`
+
+type UpdateRepoConnectionsRepoPathParams struct {
+	ToRepoPath    pgtype.Text `json:"to_repo_path"`
+	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
+	FromRepoPath  pgtype.Text `json:"from_repo_path"`
+}
+
+type UpdateRepoConnectionsRepoPathRow struct {
+	ModuleID      pgtype.Text `json:"module_id"`
+	WorkspaceID   pgtype.Text `json:"workspace_id"`
+	RepoPath      pgtype.Text `json:"repo_path"`
+	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
+}
+
+// UpdateRepoConnectionsRepoPath implements Querier.UpdateRepoConnectionsRepoPath.
+func (q *DBQuerier) UpdateRepoConnectionsRepoPath(ctx context.Context, params UpdateRepoConnectionsRepoPathParams) ([]UpdateRepoConnectionsRepoPathRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateRepoConnectionsRepoPath")
+	rows, err := q.conn.Query(ctx, updateRepoConnectionsRepoPathSQL, params.ToRepoPath, params.VCSProviderID, params.FromRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("query UpdateRepoConnectionsRepoPath: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (UpdateRepoConnectionsRepoPathRow, error) {
+		var item UpdateRepoConnectionsRepoPathRow
+		if err := row.Scan(&item.ModuleID, // 'module_id', 'ModuleID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.WorkspaceID,   // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.RepoPath,      // 'repo_path', 'RepoPath', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.VCSProviderID, // 'vcs_provider_id', 'VCSProviderID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const findConnectedReposSQL = `SELECT DISTINCT vcs_provider_id, repo_path
+FROM repo_connections;`
+
+type FindConnectedReposRow struct {
+	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
+	RepoPath      pgtype.Text `json:"repo_path"`
+}
+
+// FindConnectedRepos implements Querier.FindConnectedRepos.
+func (q *DBQuerier) FindConnectedRepos(ctx context.Context) ([]FindConnectedReposRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindConnectedRepos")
+	rows, err := q.conn.Query(ctx, findConnectedReposSQL)
+	if err != nil {
+		return nil, fmt.Errorf("query FindConnectedRepos: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindConnectedReposRow, error) {
+		var item FindConnectedReposRow
+		if err := row.Scan(&item.VCSProviderID, // 'vcs_provider_id', 'VCSProviderID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.RepoPath, // 'repo_path', 'RepoPath', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
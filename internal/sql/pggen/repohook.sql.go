@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -323,3 +324,24 @@ func (q *DBQuerier) DeleteRepohookByID(ctx context.Context, repohookID pgtype.UU
 		return item, nil
 	})
 }
+
+const updateRepohooksRepoPathSQL = `UPDATE repohooks
+SET repo_path = $1
+WHERE vcs_provider_id = $2
+AND   repo_path = $3;`
+
+type UpdateRepohooksRepoPathParams struct {
+	ToRepoPath    pgtype.Text `json:"to_repo_path"`
+	VCSProviderID pgtype.Text `json:"vcs_provider_id"`
+	FromRepoPath  pgtype.Text `json:"from_repo_path"`
+}
+
+// UpdateRepohooksRepoPath implements Querier.UpdateRepohooksRepoPath.
+func (q *DBQuerier) UpdateRepohooksRepoPath(ctx context.Context, params UpdateRepohooksRepoPathParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateRepohooksRepoPath")
+	cmdTag, err := q.conn.Exec(ctx, updateRepohooksRepoPathSQL, params.ToRepoPath, params.VCSProviderID, params.FromRepoPath)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query UpdateRepohooksRepoPath: %w", err)
+	}
+	return cmdTag, err
+}
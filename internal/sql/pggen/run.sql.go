@@ -30,7 +30,11 @@ const insertRunSQL = `INSERT INTO runs (
     workspace_id,
     created_by,
     terraform_version,
-    allow_empty_apply
+    allow_empty_apply,
+    skip_preflight,
+    debug_logging,
+    parent_run_id,
+    priority
 ) VALUES (
     $1,
     $2,
@@ -48,7 +52,11 @@ const insertRunSQL = `INSERT INTO runs (
     $14,
     $15,
     $16,
-    $17
+    $17,
+    $18,
+    $19,
+    $20,
+    $21
 );`
 
 type InsertRunParams struct {
@@ -69,12 +77,16 @@ type InsertRunParams struct {
 	CreatedBy              pgtype.Text        `json:"created_by"`
 	TerraformVersion       pgtype.Text        `json:"terraform_version"`
 	AllowEmptyApply        pgtype.Bool        `json:"allow_empty_apply"`
+	SkipPreflight          pgtype.Bool        `json:"skip_preflight"`
+	DebugLogging           pgtype.Bool        `json:"debug_logging"`
+	ParentRunID            pgtype.Text        `json:"parent_run_id"`
+	Priority               pgtype.Text        `json:"priority"`
 }
 
 // InsertRun implements Querier.InsertRun.
 func (q *DBQuerier) InsertRun(ctx context.Context, params InsertRunParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertRun")
-	cmdTag, err := q.conn.Exec(ctx, insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply)
+	cmdTag, err := q.conn.Exec(ctx, insertRunSQL, params.ID, params.CreatedAt, params.IsDestroy, params.PositionInQueue, params.Refresh, params.RefreshOnly, params.Source, params.Status, params.ReplaceAddrs, params.TargetAddrs, params.AutoApply, params.PlanOnly, params.ConfigurationVersionID, params.WorkspaceID, params.CreatedBy, params.TerraformVersion, params.AllowEmptyApply, params.SkipPreflight, params.DebugLogging, params.ParentRunID, params.Priority)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertRun: %w", err)
 	}
@@ -133,10 +145,53 @@ func (q *DBQuerier) InsertRunVariable(ctx context.Context, params InsertRunVaria
 	return cmdTag, err
 }
 
+const insertRunLabelSQL = `INSERT INTO run_labels (
+    run_id,
+    key,
+    value
+) VALUES (
+    $1,
+    $2,
+    $3
+);`
+
+type InsertRunLabelParams struct {
+	RunID pgtype.Text `json:"run_id"`
+	Key   pgtype.Text `json:"key"`
+	Value pgtype.Text `json:"value"`
+}
+
+// InsertRunLabel implements Querier.InsertRunLabel.
+func (q *DBQuerier) InsertRunLabel(ctx context.Context, params InsertRunLabelParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "InsertRunLabel")
+	cmdTag, err := q.conn.Exec(ctx, insertRunLabelSQL, params.RunID, params.Key, params.Value)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertRunLabel: %w", err)
+	}
+	return cmdTag, err
+}
+
+const deleteRunLabelsSQL = `DELETE
+FROM run_labels
+WHERE run_id = $1;`
+
+// DeleteRunLabels implements Querier.DeleteRunLabels.
+func (q *DBQuerier) DeleteRunLabels(ctx context.Context, runID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteRunLabels")
+	cmdTag, err := q.conn.Exec(ctx, deleteRunLabelsSQL, runID)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query DeleteRunLabels: %w", err)
+	}
+	return cmdTag, err
+}
+
 const findRunsSQL = `SELECT
     runs.run_id,
     runs.created_at,
     runs.cancel_signaled_at,
+    runs.force_cancel_available_at,
+    runs.force_canceled_by,
+    runs.force_cancel_reason,
     runs.is_destroy,
     runs.position_in_queue,
     runs.refresh,
@@ -157,7 +212,13 @@ const findRunsSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.skip_preflight,
+    runs.debug_logging,
+    runs.error_category,
+    runs.parent_run_id,
+    runs.priority,
     workspaces.execution_mode AS execution_mode,
+    workspaces.structured_run_output_enabled,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
     END AS latest,
@@ -189,7 +250,13 @@ const findRunsSQL = `SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(l.*) AS run_labels
+        FROM run_labels l
+        WHERE l.run_id = runs.run_id
+        GROUP BY run_id
+    ) AS run_labels
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -203,10 +270,17 @@ AND workspaces.name              LIKE ANY($3)
 AND runs.source                  LIKE ANY($4)
 AND runs.status                  LIKE ANY($5)
 AND runs.plan_only::text         LIKE ANY($6)
-AND (($7::text IS NULL) OR ia.commit_sha = $7)
-AND (($8::text IS NULL) OR ia.sender_username = $8)
+AND COALESCE(runs.error_category, '') LIKE ANY($7)
+AND (($8::text IS NULL) OR ia.commit_sha = $8)
+AND (($9::text IS NULL) OR ia.sender_username = $9)
+AND (($10::text IS NULL) OR EXISTS (
+    SELECT FROM run_labels l
+    WHERE l.run_id = runs.run_id
+    AND   l.key = $10
+    AND   l.value = $11
+))
 ORDER BY runs.created_at DESC
-LIMIT $9 OFFSET $10
+LIMIT $12 OFFSET $13
 ;`
 
 type FindRunsParams struct {
@@ -216,51 +290,64 @@ type FindRunsParams struct {
 	Sources           []string    `json:"sources"`
 	Statuses          []string    `json:"statuses"`
 	PlanOnly          []string    `json:"plan_only"`
+	ErrorCategories   []string    `json:"error_categories"`
 	CommitSHA         pgtype.Text `json:"commit_sha"`
 	VCSUsername       pgtype.Text `json:"vcs_username"`
+	LabelKey          pgtype.Text `json:"label_key"`
+	LabelValue        pgtype.Text `json:"label_value"`
 	Limit             pgtype.Int8 `json:"limit"`
 	Offset            pgtype.Int8 `json:"offset"`
 }
 
 type FindRunsRow struct {
-	RunID                  pgtype.Text             `json:"run_id"`
-	CreatedAt              pgtype.Timestamptz      `json:"created_at"`
-	CancelSignaledAt       pgtype.Timestamptz      `json:"cancel_signaled_at"`
-	IsDestroy              pgtype.Bool             `json:"is_destroy"`
-	PositionInQueue        pgtype.Int4             `json:"position_in_queue"`
-	Refresh                pgtype.Bool             `json:"refresh"`
-	RefreshOnly            pgtype.Bool             `json:"refresh_only"`
-	Source                 pgtype.Text             `json:"source"`
-	Status                 pgtype.Text             `json:"status"`
-	PlanStatus             pgtype.Text             `json:"plan_status"`
-	ApplyStatus            pgtype.Text             `json:"apply_status"`
-	ReplaceAddrs           []string                `json:"replace_addrs"`
-	TargetAddrs            []string                `json:"target_addrs"`
-	AutoApply              pgtype.Bool             `json:"auto_apply"`
-	PlanResourceReport     Report                  `json:"plan_resource_report"`
-	PlanOutputReport       Report                  `json:"plan_output_report"`
-	ApplyResourceReport    Report                  `json:"apply_resource_report"`
-	ConfigurationVersionID pgtype.Text             `json:"configuration_version_id"`
-	WorkspaceID            pgtype.Text             `json:"workspace_id"`
-	PlanOnly               pgtype.Bool             `json:"plan_only"`
-	CreatedBy              pgtype.Text             `json:"created_by"`
-	TerraformVersion       pgtype.Text             `json:"terraform_version"`
-	AllowEmptyApply        pgtype.Bool             `json:"allow_empty_apply"`
-	ExecutionMode          pgtype.Text             `json:"execution_mode"`
-	Latest                 pgtype.Bool             `json:"latest"`
-	OrganizationName       pgtype.Text             `json:"organization_name"`
-	CostEstimationEnabled  pgtype.Bool             `json:"cost_estimation_enabled"`
-	IngressAttributes      IngressAttributes       `json:"ingress_attributes"`
-	RunStatusTimestamps    []RunStatusTimestamps   `json:"run_status_timestamps"`
-	PlanStatusTimestamps   []PhaseStatusTimestamps `json:"plan_status_timestamps"`
-	ApplyStatusTimestamps  []PhaseStatusTimestamps `json:"apply_status_timestamps"`
-	RunVariables           []RunVariables          `json:"run_variables"`
+	RunID                      pgtype.Text             `json:"run_id"`
+	CreatedAt                  pgtype.Timestamptz      `json:"created_at"`
+	CancelSignaledAt           pgtype.Timestamptz      `json:"cancel_signaled_at"`
+	ForceCancelAvailableAt     pgtype.Timestamptz      `json:"force_cancel_available_at"`
+	ForceCanceledBy            pgtype.Text             `json:"force_canceled_by"`
+	ForceCancelReason          pgtype.Text             `json:"force_cancel_reason"`
+	IsDestroy                  pgtype.Bool             `json:"is_destroy"`
+	PositionInQueue            pgtype.Int4             `json:"position_in_queue"`
+	Refresh                    pgtype.Bool             `json:"refresh"`
+	RefreshOnly                pgtype.Bool             `json:"refresh_only"`
+	Source                     pgtype.Text             `json:"source"`
+	Status                     pgtype.Text             `json:"status"`
+	PlanStatus                 pgtype.Text             `json:"plan_status"`
+	ApplyStatus                pgtype.Text             `json:"apply_status"`
+	ReplaceAddrs               []string                `json:"replace_addrs"`
+	TargetAddrs                []string                `json:"target_addrs"`
+	AutoApply                  pgtype.Bool             `json:"auto_apply"`
+	PlanResourceReport         Report                  `json:"plan_resource_report"`
+	PlanOutputReport           Report                  `json:"plan_output_report"`
+	ApplyResourceReport        Report                  `json:"apply_resource_report"`
+	ConfigurationVersionID     pgtype.Text             `json:"configuration_version_id"`
+	WorkspaceID                pgtype.Text             `json:"workspace_id"`
+	PlanOnly                   pgtype.Bool             `json:"plan_only"`
+	CreatedBy                  pgtype.Text             `json:"created_by"`
+	TerraformVersion           pgtype.Text             `json:"terraform_version"`
+	AllowEmptyApply            pgtype.Bool             `json:"allow_empty_apply"`
+	SkipPreflight              pgtype.Bool             `json:"skip_preflight"`
+	DebugLogging               pgtype.Bool             `json:"debug_logging"`
+	ErrorCategory              pgtype.Text             `json:"error_category"`
+	ParentRunID                pgtype.Text             `json:"parent_run_id"`
+	Priority                   pgtype.Text             `json:"priority"`
+	ExecutionMode              pgtype.Text             `json:"execution_mode"`
+	StructuredRunOutputEnabled pgtype.Bool             `json:"structured_run_output_enabled"`
+	Latest                     pgtype.Bool             `json:"latest"`
+	OrganizationName           pgtype.Text             `json:"organization_name"`
+	CostEstimationEnabled      pgtype.Bool             `json:"cost_estimation_enabled"`
+	IngressAttributes          IngressAttributes       `json:"ingress_attributes"`
+	RunStatusTimestamps        []RunStatusTimestamps   `json:"run_status_timestamps"`
+	PlanStatusTimestamps       []PhaseStatusTimestamps `json:"plan_status_timestamps"`
+	ApplyStatusTimestamps      []PhaseStatusTimestamps `json:"apply_status_timestamps"`
+	RunVariables               []RunVariables          `json:"run_variables"`
+	RunLabels                  []RunLabels             `json:"run_labels"`
 }
 
 // FindRuns implements Querier.FindRuns.
 func (q *DBQuerier) FindRuns(ctx context.Context, params FindRunsParams) ([]FindRunsRow, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "FindRuns")
-	rows, err := q.conn.Query(ctx, findRunsSQL, params.OrganizationNames, params.WorkspaceIds, params.WorkspaceNames, params.Sources, params.Statuses, params.PlanOnly, params.CommitSHA, params.VCSUsername, params.Limit, params.Offset)
+	rows, err := q.conn.Query(ctx, findRunsSQL, params.OrganizationNames, params.WorkspaceIds, params.WorkspaceNames, params.Sources, params.Statuses, params.PlanOnly, params.ErrorCategories, params.CommitSHA, params.VCSUsername, params.LabelKey, params.LabelValue, params.Limit, params.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("query FindRuns: %w", err)
 	}
@@ -268,37 +355,47 @@ func (q *DBQuerier) FindRuns(ctx context.Context, params FindRunsParams) ([]Find
 	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindRunsRow, error) {
 		var item FindRunsRow
 		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt,              // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.CancelSignaledAt,       // 'cancel_signaled_at', 'CancelSignaledAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.IsDestroy,              // 'is_destroy', 'IsDestroy', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.PositionInQueue,        // 'position_in_queue', 'PositionInQueue', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
-			&item.Refresh,                // 'refresh', 'Refresh', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.RefreshOnly,            // 'refresh_only', 'RefreshOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.Source,                 // 'source', 'Source', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Status,                 // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.PlanStatus,             // 'plan_status', 'PlanStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.ApplyStatus,            // 'apply_status', 'ApplyStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.ReplaceAddrs,           // 'replace_addrs', 'ReplaceAddrs', '[]string', '', '[]string'
-			&item.TargetAddrs,            // 'target_addrs', 'TargetAddrs', '[]string', '', '[]string'
-			&item.AutoApply,              // 'auto_apply', 'AutoApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.PlanResourceReport,     // 'plan_resource_report', 'PlanResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
-			&item.PlanOutputReport,       // 'plan_output_report', 'PlanOutputReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
-			&item.ApplyResourceReport,    // 'apply_resource_report', 'ApplyResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
-			&item.ConfigurationVersionID, // 'configuration_version_id', 'ConfigurationVersionID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.WorkspaceID,            // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.PlanOnly,               // 'plan_only', 'PlanOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.CreatedBy,              // 'created_by', 'CreatedBy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.TerraformVersion,       // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.AllowEmptyApply,        // 'allow_empty_apply', 'AllowEmptyApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.ExecutionMode,          // 'execution_mode', 'ExecutionMode', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Latest,                 // 'latest', 'Latest', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.OrganizationName,       // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CostEstimationEnabled,  // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.IngressAttributes,      // 'ingress_attributes', 'IngressAttributes', 'IngressAttributes', 'github.com/tofutf/tofutf/internal/sql/queries', 'IngressAttributes'
-			&item.RunStatusTimestamps,    // 'run_status_timestamps', 'RunStatusTimestamps', '[]RunStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunStatusTimestamps'
-			&item.PlanStatusTimestamps,   // 'plan_status_timestamps', 'PlanStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
-			&item.ApplyStatusTimestamps,  // 'apply_status_timestamps', 'ApplyStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
-			&item.RunVariables,           // 'run_variables', 'RunVariables', '[]RunVariables', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunVariables'
+			&item.CreatedAt,                  // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.CancelSignaledAt,           // 'cancel_signaled_at', 'CancelSignaledAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.ForceCancelAvailableAt,     // 'force_cancel_available_at', 'ForceCancelAvailableAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.ForceCanceledBy,            // 'force_canceled_by', 'ForceCanceledBy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ForceCancelReason,          // 'force_cancel_reason', 'ForceCancelReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.IsDestroy,                  // 'is_destroy', 'IsDestroy', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.PositionInQueue,            // 'position_in_queue', 'PositionInQueue', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.Refresh,                    // 'refresh', 'Refresh', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.RefreshOnly,                // 'refresh_only', 'RefreshOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Source,                     // 'source', 'Source', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,                     // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.PlanStatus,                 // 'plan_status', 'PlanStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ApplyStatus,                // 'apply_status', 'ApplyStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ReplaceAddrs,               // 'replace_addrs', 'ReplaceAddrs', '[]string', '', '[]string'
+			&item.TargetAddrs,                // 'target_addrs', 'TargetAddrs', '[]string', '', '[]string'
+			&item.AutoApply,                  // 'auto_apply', 'AutoApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.PlanResourceReport,         // 'plan_resource_report', 'PlanResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
+			&item.PlanOutputReport,           // 'plan_output_report', 'PlanOutputReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
+			&item.ApplyResourceReport,        // 'apply_resource_report', 'ApplyResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
+			&item.ConfigurationVersionID,     // 'configuration_version_id', 'ConfigurationVersionID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.WorkspaceID,                // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.PlanOnly,                   // 'plan_only', 'PlanOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.CreatedBy,                  // 'created_by', 'CreatedBy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion,           // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AllowEmptyApply,            // 'allow_empty_apply', 'AllowEmptyApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.SkipPreflight,              // 'skip_preflight', 'SkipPreflight', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DebugLogging,               // 'debug_logging', 'DebugLogging', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.ErrorCategory,              // 'error_category', 'ErrorCategory', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ParentRunID,                // 'parent_run_id', 'ParentRunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Priority,                   // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExecutionMode,              // 'execution_mode', 'ExecutionMode', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.StructuredRunOutputEnabled, // 'structured_run_output_enabled', 'StructuredRunOutputEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Latest,                     // 'latest', 'Latest', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.OrganizationName,           // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CostEstimationEnabled,      // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.IngressAttributes,          // 'ingress_attributes', 'IngressAttributes', 'IngressAttributes', 'github.com/tofutf/tofutf/internal/sql/queries', 'IngressAttributes'
+			&item.RunStatusTimestamps,        // 'run_status_timestamps', 'RunStatusTimestamps', '[]RunStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunStatusTimestamps'
+			&item.PlanStatusTimestamps,       // 'plan_status_timestamps', 'PlanStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
+			&item.ApplyStatusTimestamps,      // 'apply_status_timestamps', 'ApplyStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
+			&item.RunVariables,               // 'run_variables', 'RunVariables', '[]RunVariables', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunVariables'
+			&item.RunLabels,                  // 'run_labels', 'RunLabels', '[]RunLabels', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunLabels'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -317,8 +414,15 @@ AND workspaces.name              LIKE ANY($3)
 AND runs.source                  LIKE ANY($4)
 AND runs.status                  LIKE ANY($5)
 AND runs.plan_only::text         LIKE ANY($6)
-AND (($7::text IS NULL) OR ia.commit_sha = $7)
-AND (($8::text IS NULL) OR ia.sender_username = $8)
+AND COALESCE(runs.error_category, '') LIKE ANY($7)
+AND (($8::text IS NULL) OR ia.commit_sha = $8)
+AND (($9::text IS NULL) OR ia.sender_username = $9)
+AND (($10::text IS NULL) OR EXISTS (
+    SELECT FROM run_labels l
+    WHERE l.run_id = runs.run_id
+    AND   l.key = $10
+    AND   l.value = $11
+))
 ;`
 
 type CountRunsParams struct {
@@ -328,14 +432,17 @@ type CountRunsParams struct {
 	Sources           []string    `json:"sources"`
 	Statuses          []string    `json:"statuses"`
 	PlanOnly          []string    `json:"plan_only"`
+	ErrorCategories   []string    `json:"error_categories"`
 	CommitSHA         pgtype.Text `json:"commit_sha"`
 	VCSUsername       pgtype.Text `json:"vcs_username"`
+	LabelKey          pgtype.Text `json:"label_key"`
+	LabelValue        pgtype.Text `json:"label_value"`
 }
 
 // CountRuns implements Querier.CountRuns.
 func (q *DBQuerier) CountRuns(ctx context.Context, params CountRunsParams) (pgtype.Int8, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "CountRuns")
-	rows, err := q.conn.Query(ctx, countRunsSQL, params.OrganizationNames, params.WorkspaceIds, params.WorkspaceNames, params.Sources, params.Statuses, params.PlanOnly, params.CommitSHA, params.VCSUsername)
+	rows, err := q.conn.Query(ctx, countRunsSQL, params.OrganizationNames, params.WorkspaceIds, params.WorkspaceNames, params.Sources, params.Statuses, params.PlanOnly, params.ErrorCategories, params.CommitSHA, params.VCSUsername, params.LabelKey, params.LabelValue)
 	if err != nil {
 		return pgtype.Int8{}, fmt.Errorf("query CountRuns: %w", err)
 	}
@@ -349,10 +456,52 @@ func (q *DBQuerier) CountRuns(ctx context.Context, params CountRunsParams) (pgty
 	})
 }
 
+const findHighPriorityRunQuotaSQL = `SELECT
+    (
+        SELECT count(*)
+        FROM runs r
+        JOIN workspaces w USING (workspace_id)
+        WHERE w.organization_name = o.name
+        AND   r.priority = 'high'
+        AND   r.status NOT IN ('applied', 'planned_and_finished', 'discarded', 'canceled', 'force_canceled', 'errored')
+    ) AS runs,
+    o.max_high_priority_runs
+FROM organizations o
+WHERE o.name = $1
+FOR UPDATE OF o
+;`
+
+type FindHighPriorityRunQuotaRow struct {
+	Runs                pgtype.Int8 `json:"runs"`
+	MaxHighPriorityRuns pgtype.Int4 `json:"max_high_priority_runs"`
+}
+
+// FindHighPriorityRunQuota implements Querier.FindHighPriorityRunQuota.
+func (q *DBQuerier) FindHighPriorityRunQuota(ctx context.Context, organizationName pgtype.Text) (FindHighPriorityRunQuotaRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindHighPriorityRunQuota")
+	rows, err := q.conn.Query(ctx, findHighPriorityRunQuotaSQL, organizationName)
+	if err != nil {
+		return FindHighPriorityRunQuotaRow{}, fmt.Errorf("query FindHighPriorityRunQuota: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindHighPriorityRunQuotaRow, error) {
+		var item FindHighPriorityRunQuotaRow
+		if err := row.Scan(&item.Runs, // 'runs', 'Runs', 'pgtype.Int8', 'github.com/jackc/pgx/v5/pgtype', 'Int8'
+			&item.MaxHighPriorityRuns, // 'max_high_priority_runs', 'MaxHighPriorityRuns', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
 const findRunByIDSQL = `SELECT
     runs.run_id,
     runs.created_at,
     runs.cancel_signaled_at,
+    runs.force_cancel_available_at,
+    runs.force_canceled_by,
+    runs.force_cancel_reason,
     runs.is_destroy,
     runs.position_in_queue,
     runs.refresh,
@@ -373,7 +522,13 @@ const findRunByIDSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.skip_preflight,
+    runs.debug_logging,
+    runs.error_category,
+    runs.parent_run_id,
+    runs.priority,
     workspaces.execution_mode AS execution_mode,
+    workspaces.structured_run_output_enabled,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
     END AS latest,
@@ -405,7 +560,13 @@ const findRunByIDSQL = `SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(l.*) AS run_labels
+        FROM run_labels l
+        WHERE l.run_id = runs.run_id
+        GROUP BY run_id
+    ) AS run_labels
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -416,38 +577,48 @@ WHERE runs.run_id = $1
 ;`
 
 type FindRunByIDRow struct {
-	RunID                  pgtype.Text             `json:"run_id"`
-	CreatedAt              pgtype.Timestamptz      `json:"created_at"`
-	CancelSignaledAt       pgtype.Timestamptz      `json:"cancel_signaled_at"`
-	IsDestroy              pgtype.Bool             `json:"is_destroy"`
-	PositionInQueue        pgtype.Int4             `json:"position_in_queue"`
-	Refresh                pgtype.Bool             `json:"refresh"`
-	RefreshOnly            pgtype.Bool             `json:"refresh_only"`
-	Source                 pgtype.Text             `json:"source"`
-	Status                 pgtype.Text             `json:"status"`
-	PlanStatus             pgtype.Text             `json:"plan_status"`
-	ApplyStatus            pgtype.Text             `json:"apply_status"`
-	ReplaceAddrs           []string                `json:"replace_addrs"`
-	TargetAddrs            []string                `json:"target_addrs"`
-	AutoApply              pgtype.Bool             `json:"auto_apply"`
-	PlanResourceReport     Report                  `json:"plan_resource_report"`
-	PlanOutputReport       Report                  `json:"plan_output_report"`
-	ApplyResourceReport    Report                  `json:"apply_resource_report"`
-	ConfigurationVersionID pgtype.Text             `json:"configuration_version_id"`
-	WorkspaceID            pgtype.Text             `json:"workspace_id"`
-	PlanOnly               pgtype.Bool             `json:"plan_only"`
-	CreatedBy              pgtype.Text             `json:"created_by"`
-	TerraformVersion       pgtype.Text             `json:"terraform_version"`
-	AllowEmptyApply        pgtype.Bool             `json:"allow_empty_apply"`
-	ExecutionMode          pgtype.Text             `json:"execution_mode"`
-	Latest                 pgtype.Bool             `json:"latest"`
-	OrganizationName       pgtype.Text             `json:"organization_name"`
-	CostEstimationEnabled  pgtype.Bool             `json:"cost_estimation_enabled"`
-	IngressAttributes      IngressAttributes       `json:"ingress_attributes"`
-	RunStatusTimestamps    []RunStatusTimestamps   `json:"run_status_timestamps"`
-	PlanStatusTimestamps   []PhaseStatusTimestamps `json:"plan_status_timestamps"`
-	ApplyStatusTimestamps  []PhaseStatusTimestamps `json:"apply_status_timestamps"`
-	RunVariables           []RunVariables          `json:"run_variables"`
+	RunID                      pgtype.Text             `json:"run_id"`
+	CreatedAt                  pgtype.Timestamptz      `json:"created_at"`
+	CancelSignaledAt           pgtype.Timestamptz      `json:"cancel_signaled_at"`
+	ForceCancelAvailableAt     pgtype.Timestamptz      `json:"force_cancel_available_at"`
+	ForceCanceledBy            pgtype.Text             `json:"force_canceled_by"`
+	ForceCancelReason          pgtype.Text             `json:"force_cancel_reason"`
+	IsDestroy                  pgtype.Bool             `json:"is_destroy"`
+	PositionInQueue            pgtype.Int4             `json:"position_in_queue"`
+	Refresh                    pgtype.Bool             `json:"refresh"`
+	RefreshOnly                pgtype.Bool             `json:"refresh_only"`
+	Source                     pgtype.Text             `json:"source"`
+	Status                     pgtype.Text             `json:"status"`
+	PlanStatus                 pgtype.Text             `json:"plan_status"`
+	ApplyStatus                pgtype.Text             `json:"apply_status"`
+	ReplaceAddrs               []string                `json:"replace_addrs"`
+	TargetAddrs                []string                `json:"target_addrs"`
+	AutoApply                  pgtype.Bool             `json:"auto_apply"`
+	PlanResourceReport         Report                  `json:"plan_resource_report"`
+	PlanOutputReport           Report                  `json:"plan_output_report"`
+	ApplyResourceReport        Report                  `json:"apply_resource_report"`
+	ConfigurationVersionID     pgtype.Text             `json:"configuration_version_id"`
+	WorkspaceID                pgtype.Text             `json:"workspace_id"`
+	PlanOnly                   pgtype.Bool             `json:"plan_only"`
+	CreatedBy                  pgtype.Text             `json:"created_by"`
+	TerraformVersion           pgtype.Text             `json:"terraform_version"`
+	AllowEmptyApply            pgtype.Bool             `json:"allow_empty_apply"`
+	SkipPreflight              pgtype.Bool             `json:"skip_preflight"`
+	DebugLogging               pgtype.Bool             `json:"debug_logging"`
+	ErrorCategory              pgtype.Text             `json:"error_category"`
+	ParentRunID                pgtype.Text             `json:"parent_run_id"`
+	Priority                   pgtype.Text             `json:"priority"`
+	ExecutionMode              pgtype.Text             `json:"execution_mode"`
+	StructuredRunOutputEnabled pgtype.Bool             `json:"structured_run_output_enabled"`
+	Latest                     pgtype.Bool             `json:"latest"`
+	OrganizationName           pgtype.Text             `json:"organization_name"`
+	CostEstimationEnabled      pgtype.Bool             `json:"cost_estimation_enabled"`
+	IngressAttributes          IngressAttributes       `json:"ingress_attributes"`
+	RunStatusTimestamps        []RunStatusTimestamps   `json:"run_status_timestamps"`
+	PlanStatusTimestamps       []PhaseStatusTimestamps `json:"plan_status_timestamps"`
+	ApplyStatusTimestamps      []PhaseStatusTimestamps `json:"apply_status_timestamps"`
+	RunVariables               []RunVariables          `json:"run_variables"`
+	RunLabels                  []RunLabels             `json:"run_labels"`
 }
 
 // FindRunByID implements Querier.FindRunByID.
@@ -461,37 +632,47 @@ func (q *DBQuerier) FindRunByID(ctx context.Context, runID pgtype.Text) (FindRun
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindRunByIDRow, error) {
 		var item FindRunByIDRow
 		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt,              // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.CancelSignaledAt,       // 'cancel_signaled_at', 'CancelSignaledAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.IsDestroy,              // 'is_destroy', 'IsDestroy', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.PositionInQueue,        // 'position_in_queue', 'PositionInQueue', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
-			&item.Refresh,                // 'refresh', 'Refresh', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.RefreshOnly,            // 'refresh_only', 'RefreshOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.Source,                 // 'source', 'Source', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Status,                 // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.PlanStatus,             // 'plan_status', 'PlanStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.ApplyStatus,            // 'apply_status', 'ApplyStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.ReplaceAddrs,           // 'replace_addrs', 'ReplaceAddrs', '[]string', '', '[]string'
-			&item.TargetAddrs,            // 'target_addrs', 'TargetAddrs', '[]string', '', '[]string'
-			&item.AutoApply,              // 'auto_apply', 'AutoApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.PlanResourceReport,     // 'plan_resource_report', 'PlanResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
-			&item.PlanOutputReport,       // 'plan_output_report', 'PlanOutputReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
-			&item.ApplyResourceReport,    // 'apply_resource_report', 'ApplyResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
-			&item.ConfigurationVersionID, // 'configuration_version_id', 'ConfigurationVersionID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.WorkspaceID,            // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.PlanOnly,               // 'plan_only', 'PlanOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.CreatedBy,              // 'created_by', 'CreatedBy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.TerraformVersion,       // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.AllowEmptyApply,        // 'allow_empty_apply', 'AllowEmptyApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.ExecutionMode,          // 'execution_mode', 'ExecutionMode', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Latest,                 // 'latest', 'Latest', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.OrganizationName,       // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CostEstimationEnabled,  // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.IngressAttributes,      // 'ingress_attributes', 'IngressAttributes', 'IngressAttributes', 'github.com/tofutf/tofutf/internal/sql/queries', 'IngressAttributes'
-			&item.RunStatusTimestamps,    // 'run_status_timestamps', 'RunStatusTimestamps', '[]RunStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunStatusTimestamps'
-			&item.PlanStatusTimestamps,   // 'plan_status_timestamps', 'PlanStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
-			&item.ApplyStatusTimestamps,  // 'apply_status_timestamps', 'ApplyStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
-			&item.RunVariables,           // 'run_variables', 'RunVariables', '[]RunVariables', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunVariables'
+			&item.CreatedAt,                  // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.CancelSignaledAt,           // 'cancel_signaled_at', 'CancelSignaledAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.ForceCancelAvailableAt,     // 'force_cancel_available_at', 'ForceCancelAvailableAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.ForceCanceledBy,            // 'force_canceled_by', 'ForceCanceledBy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ForceCancelReason,          // 'force_cancel_reason', 'ForceCancelReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.IsDestroy,                  // 'is_destroy', 'IsDestroy', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.PositionInQueue,            // 'position_in_queue', 'PositionInQueue', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.Refresh,                    // 'refresh', 'Refresh', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.RefreshOnly,                // 'refresh_only', 'RefreshOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Source,                     // 'source', 'Source', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,                     // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.PlanStatus,                 // 'plan_status', 'PlanStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ApplyStatus,                // 'apply_status', 'ApplyStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ReplaceAddrs,               // 'replace_addrs', 'ReplaceAddrs', '[]string', '', '[]string'
+			&item.TargetAddrs,                // 'target_addrs', 'TargetAddrs', '[]string', '', '[]string'
+			&item.AutoApply,                  // 'auto_apply', 'AutoApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.PlanResourceReport,         // 'plan_resource_report', 'PlanResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
+			&item.PlanOutputReport,           // 'plan_output_report', 'PlanOutputReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
+			&item.ApplyResourceReport,        // 'apply_resource_report', 'ApplyResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
+			&item.ConfigurationVersionID,     // 'configuration_version_id', 'ConfigurationVersionID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.WorkspaceID,                // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.PlanOnly,                   // 'plan_only', 'PlanOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.CreatedBy,                  // 'created_by', 'CreatedBy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion,           // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AllowEmptyApply,            // 'allow_empty_apply', 'AllowEmptyApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.SkipPreflight,              // 'skip_preflight', 'SkipPreflight', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DebugLogging,               // 'debug_logging', 'DebugLogging', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.ErrorCategory,              // 'error_category', 'ErrorCategory', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ParentRunID,                // 'parent_run_id', 'ParentRunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Priority,                   // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExecutionMode,              // 'execution_mode', 'ExecutionMode', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.StructuredRunOutputEnabled, // 'structured_run_output_enabled', 'StructuredRunOutputEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Latest,                     // 'latest', 'Latest', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.OrganizationName,           // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CostEstimationEnabled,      // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.IngressAttributes,          // 'ingress_attributes', 'IngressAttributes', 'IngressAttributes', 'github.com/tofutf/tofutf/internal/sql/queries', 'IngressAttributes'
+			&item.RunStatusTimestamps,        // 'run_status_timestamps', 'RunStatusTimestamps', '[]RunStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunStatusTimestamps'
+			&item.PlanStatusTimestamps,       // 'plan_status_timestamps', 'PlanStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
+			&item.ApplyStatusTimestamps,      // 'apply_status_timestamps', 'ApplyStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
+			&item.RunVariables,               // 'run_variables', 'RunVariables', '[]RunVariables', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunVariables'
+			&item.RunLabels,                  // 'run_labels', 'RunLabels', '[]RunLabels', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunLabels'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -503,6 +684,9 @@ const findRunByIDForUpdateSQL = `SELECT
     runs.run_id,
     runs.created_at,
     runs.cancel_signaled_at,
+    runs.force_cancel_available_at,
+    runs.force_canceled_by,
+    runs.force_cancel_reason,
     runs.is_destroy,
     runs.position_in_queue,
     runs.refresh,
@@ -523,7 +707,13 @@ const findRunByIDForUpdateSQL = `SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.skip_preflight,
+    runs.debug_logging,
+    runs.error_category,
+    runs.parent_run_id,
+    runs.priority,
     workspaces.execution_mode AS execution_mode,
+    workspaces.structured_run_output_enabled,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
     END AS latest,
@@ -555,7 +745,13 @@ const findRunByIDForUpdateSQL = `SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(l.*) AS run_labels
+        FROM run_labels l
+        WHERE l.run_id = runs.run_id
+        GROUP BY run_id
+    ) AS run_labels
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -567,38 +763,48 @@ FOR UPDATE OF runs, plans, applies
 ;`
 
 type FindRunByIDForUpdateRow struct {
-	RunID                  pgtype.Text             `json:"run_id"`
-	CreatedAt              pgtype.Timestamptz      `json:"created_at"`
-	CancelSignaledAt       pgtype.Timestamptz      `json:"cancel_signaled_at"`
-	IsDestroy              pgtype.Bool             `json:"is_destroy"`
-	PositionInQueue        pgtype.Int4             `json:"position_in_queue"`
-	Refresh                pgtype.Bool             `json:"refresh"`
-	RefreshOnly            pgtype.Bool             `json:"refresh_only"`
-	Source                 pgtype.Text             `json:"source"`
-	Status                 pgtype.Text             `json:"status"`
-	PlanStatus             pgtype.Text             `json:"plan_status"`
-	ApplyStatus            pgtype.Text             `json:"apply_status"`
-	ReplaceAddrs           []string                `json:"replace_addrs"`
-	TargetAddrs            []string                `json:"target_addrs"`
-	AutoApply              pgtype.Bool             `json:"auto_apply"`
-	PlanResourceReport     Report                  `json:"plan_resource_report"`
-	PlanOutputReport       Report                  `json:"plan_output_report"`
-	ApplyResourceReport    Report                  `json:"apply_resource_report"`
-	ConfigurationVersionID pgtype.Text             `json:"configuration_version_id"`
-	WorkspaceID            pgtype.Text             `json:"workspace_id"`
-	PlanOnly               pgtype.Bool             `json:"plan_only"`
-	CreatedBy              pgtype.Text             `json:"created_by"`
-	TerraformVersion       pgtype.Text             `json:"terraform_version"`
-	AllowEmptyApply        pgtype.Bool             `json:"allow_empty_apply"`
-	ExecutionMode          pgtype.Text             `json:"execution_mode"`
-	Latest                 pgtype.Bool             `json:"latest"`
-	OrganizationName       pgtype.Text             `json:"organization_name"`
-	CostEstimationEnabled  pgtype.Bool             `json:"cost_estimation_enabled"`
-	IngressAttributes      IngressAttributes       `json:"ingress_attributes"`
-	RunStatusTimestamps    []RunStatusTimestamps   `json:"run_status_timestamps"`
-	PlanStatusTimestamps   []PhaseStatusTimestamps `json:"plan_status_timestamps"`
-	ApplyStatusTimestamps  []PhaseStatusTimestamps `json:"apply_status_timestamps"`
-	RunVariables           []RunVariables          `json:"run_variables"`
+	RunID                      pgtype.Text             `json:"run_id"`
+	CreatedAt                  pgtype.Timestamptz      `json:"created_at"`
+	CancelSignaledAt           pgtype.Timestamptz      `json:"cancel_signaled_at"`
+	ForceCancelAvailableAt     pgtype.Timestamptz      `json:"force_cancel_available_at"`
+	ForceCanceledBy            pgtype.Text             `json:"force_canceled_by"`
+	ForceCancelReason          pgtype.Text             `json:"force_cancel_reason"`
+	IsDestroy                  pgtype.Bool             `json:"is_destroy"`
+	PositionInQueue            pgtype.Int4             `json:"position_in_queue"`
+	Refresh                    pgtype.Bool             `json:"refresh"`
+	RefreshOnly                pgtype.Bool             `json:"refresh_only"`
+	Source                     pgtype.Text             `json:"source"`
+	Status                     pgtype.Text             `json:"status"`
+	PlanStatus                 pgtype.Text             `json:"plan_status"`
+	ApplyStatus                pgtype.Text             `json:"apply_status"`
+	ReplaceAddrs               []string                `json:"replace_addrs"`
+	TargetAddrs                []string                `json:"target_addrs"`
+	AutoApply                  pgtype.Bool             `json:"auto_apply"`
+	PlanResourceReport         Report                  `json:"plan_resource_report"`
+	PlanOutputReport           Report                  `json:"plan_output_report"`
+	ApplyResourceReport        Report                  `json:"apply_resource_report"`
+	ConfigurationVersionID     pgtype.Text             `json:"configuration_version_id"`
+	WorkspaceID                pgtype.Text             `json:"workspace_id"`
+	PlanOnly                   pgtype.Bool             `json:"plan_only"`
+	CreatedBy                  pgtype.Text             `json:"created_by"`
+	TerraformVersion           pgtype.Text             `json:"terraform_version"`
+	AllowEmptyApply            pgtype.Bool             `json:"allow_empty_apply"`
+	SkipPreflight              pgtype.Bool             `json:"skip_preflight"`
+	DebugLogging               pgtype.Bool             `json:"debug_logging"`
+	ErrorCategory              pgtype.Text             `json:"error_category"`
+	ParentRunID                pgtype.Text             `json:"parent_run_id"`
+	Priority                   pgtype.Text             `json:"priority"`
+	ExecutionMode              pgtype.Text             `json:"execution_mode"`
+	StructuredRunOutputEnabled pgtype.Bool             `json:"structured_run_output_enabled"`
+	Latest                     pgtype.Bool             `json:"latest"`
+	OrganizationName           pgtype.Text             `json:"organization_name"`
+	CostEstimationEnabled      pgtype.Bool             `json:"cost_estimation_enabled"`
+	IngressAttributes          IngressAttributes       `json:"ingress_attributes"`
+	RunStatusTimestamps        []RunStatusTimestamps   `json:"run_status_timestamps"`
+	PlanStatusTimestamps       []PhaseStatusTimestamps `json:"plan_status_timestamps"`
+	ApplyStatusTimestamps      []PhaseStatusTimestamps `json:"apply_status_timestamps"`
+	RunVariables               []RunVariables          `json:"run_variables"`
+	RunLabels                  []RunLabels             `json:"run_labels"`
 }
 
 // FindRunByIDForUpdate implements Querier.FindRunByIDForUpdate.
@@ -612,37 +818,47 @@ func (q *DBQuerier) FindRunByIDForUpdate(ctx context.Context, runID pgtype.Text)
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindRunByIDForUpdateRow, error) {
 		var item FindRunByIDForUpdateRow
 		if err := row.Scan(&item.RunID, // 'run_id', 'RunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt,              // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.CancelSignaledAt,       // 'cancel_signaled_at', 'CancelSignaledAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.IsDestroy,              // 'is_destroy', 'IsDestroy', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.PositionInQueue,        // 'position_in_queue', 'PositionInQueue', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
-			&item.Refresh,                // 'refresh', 'Refresh', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.RefreshOnly,            // 'refresh_only', 'RefreshOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.Source,                 // 'source', 'Source', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Status,                 // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.PlanStatus,             // 'plan_status', 'PlanStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.ApplyStatus,            // 'apply_status', 'ApplyStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.ReplaceAddrs,           // 'replace_addrs', 'ReplaceAddrs', '[]string', '', '[]string'
-			&item.TargetAddrs,            // 'target_addrs', 'TargetAddrs', '[]string', '', '[]string'
-			&item.AutoApply,              // 'auto_apply', 'AutoApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.PlanResourceReport,     // 'plan_resource_report', 'PlanResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
-			&item.PlanOutputReport,       // 'plan_output_report', 'PlanOutputReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
-			&item.ApplyResourceReport,    // 'apply_resource_report', 'ApplyResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
-			&item.ConfigurationVersionID, // 'configuration_version_id', 'ConfigurationVersionID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.WorkspaceID,            // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.PlanOnly,               // 'plan_only', 'PlanOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.CreatedBy,              // 'created_by', 'CreatedBy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.TerraformVersion,       // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.AllowEmptyApply,        // 'allow_empty_apply', 'AllowEmptyApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.ExecutionMode,          // 'execution_mode', 'ExecutionMode', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Latest,                 // 'latest', 'Latest', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.OrganizationName,       // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CostEstimationEnabled,  // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.IngressAttributes,      // 'ingress_attributes', 'IngressAttributes', 'IngressAttributes', 'github.com/tofutf/tofutf/internal/sql/queries', 'IngressAttributes'
-			&item.RunStatusTimestamps,    // 'run_status_timestamps', 'RunStatusTimestamps', '[]RunStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunStatusTimestamps'
-			&item.PlanStatusTimestamps,   // 'plan_status_timestamps', 'PlanStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
-			&item.ApplyStatusTimestamps,  // 'apply_status_timestamps', 'ApplyStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
-			&item.RunVariables,           // 'run_variables', 'RunVariables', '[]RunVariables', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunVariables'
+			&item.CreatedAt,                  // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.CancelSignaledAt,           // 'cancel_signaled_at', 'CancelSignaledAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.ForceCancelAvailableAt,     // 'force_cancel_available_at', 'ForceCancelAvailableAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.ForceCanceledBy,            // 'force_canceled_by', 'ForceCanceledBy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ForceCancelReason,          // 'force_cancel_reason', 'ForceCancelReason', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.IsDestroy,                  // 'is_destroy', 'IsDestroy', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.PositionInQueue,            // 'position_in_queue', 'PositionInQueue', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.Refresh,                    // 'refresh', 'Refresh', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.RefreshOnly,                // 'refresh_only', 'RefreshOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Source,                     // 'source', 'Source', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Status,                     // 'status', 'Status', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.PlanStatus,                 // 'plan_status', 'PlanStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ApplyStatus,                // 'apply_status', 'ApplyStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ReplaceAddrs,               // 'replace_addrs', 'ReplaceAddrs', '[]string', '', '[]string'
+			&item.TargetAddrs,                // 'target_addrs', 'TargetAddrs', '[]string', '', '[]string'
+			&item.AutoApply,                  // 'auto_apply', 'AutoApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.PlanResourceReport,         // 'plan_resource_report', 'PlanResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
+			&item.PlanOutputReport,           // 'plan_output_report', 'PlanOutputReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
+			&item.ApplyResourceReport,        // 'apply_resource_report', 'ApplyResourceReport', 'Report', 'github.com/tofutf/tofutf/internal/sql/queries', 'Report'
+			&item.ConfigurationVersionID,     // 'configuration_version_id', 'ConfigurationVersionID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.WorkspaceID,                // 'workspace_id', 'WorkspaceID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.PlanOnly,                   // 'plan_only', 'PlanOnly', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.CreatedBy,                  // 'created_by', 'CreatedBy', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.TerraformVersion,           // 'terraform_version', 'TerraformVersion', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.AllowEmptyApply,            // 'allow_empty_apply', 'AllowEmptyApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.SkipPreflight,              // 'skip_preflight', 'SkipPreflight', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.DebugLogging,               // 'debug_logging', 'DebugLogging', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.ErrorCategory,              // 'error_category', 'ErrorCategory', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ParentRunID,                // 'parent_run_id', 'ParentRunID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Priority,                   // 'priority', 'Priority', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExecutionMode,              // 'execution_mode', 'ExecutionMode', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.StructuredRunOutputEnabled, // 'structured_run_output_enabled', 'StructuredRunOutputEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Latest,                     // 'latest', 'Latest', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.OrganizationName,           // 'organization_name', 'OrganizationName', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CostEstimationEnabled,      // 'cost_estimation_enabled', 'CostEstimationEnabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.IngressAttributes,          // 'ingress_attributes', 'IngressAttributes', 'IngressAttributes', 'github.com/tofutf/tofutf/internal/sql/queries', 'IngressAttributes'
+			&item.RunStatusTimestamps,        // 'run_status_timestamps', 'RunStatusTimestamps', '[]RunStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunStatusTimestamps'
+			&item.PlanStatusTimestamps,       // 'plan_status_timestamps', 'PlanStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
+			&item.ApplyStatusTimestamps,      // 'apply_status_timestamps', 'ApplyStatusTimestamps', '[]PhaseStatusTimestamps', 'github.com/tofutf/tofutf/internal/sql/queries', '[]PhaseStatusTimestamps'
+			&item.RunVariables,               // 'run_variables', 'RunVariables', '[]RunVariables', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunVariables'
+			&item.RunLabels,                  // 'run_labels', 'RunLabels', '[]RunLabels', 'github.com/tofutf/tofutf/internal/sql/queries', '[]RunLabels'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -695,6 +911,51 @@ func (q *DBQuerier) GetLockFileByID(ctx context.Context, runID pgtype.Text) ([]b
 	})
 }
 
+const putModuleManifestSQL = `UPDATE runs
+SET module_manifest = $1
+WHERE run_id = $2
+RETURNING run_id
+;`
+
+// PutModuleManifest implements Querier.PutModuleManifest.
+func (q *DBQuerier) PutModuleManifest(ctx context.Context, moduleManifest []byte, runID pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "PutModuleManifest")
+	rows, err := q.conn.Query(ctx, putModuleManifestSQL, moduleManifest, runID)
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("query PutModuleManifest: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const getModuleManifestByIDSQL = `SELECT module_manifest
+FROM runs
+WHERE run_id = $1
+;`
+
+// GetModuleManifestByID implements Querier.GetModuleManifestByID.
+func (q *DBQuerier) GetModuleManifestByID(ctx context.Context, runID pgtype.Text) ([]byte, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "GetModuleManifestByID")
+	rows, err := q.conn.Query(ctx, getModuleManifestByIDSQL, runID)
+	if err != nil {
+		return nil, fmt.Errorf("query GetModuleManifestByID: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) ([]byte, error) {
+		var item []byte
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
 const updateRunStatusSQL = `UPDATE runs
 SET
     status = $1
@@ -719,6 +980,52 @@ func (q *DBQuerier) UpdateRunStatus(ctx context.Context, status pgtype.Text, id
 	})
 }
 
+const findChildRunIDsSQL = `SELECT run_id
+FROM runs
+WHERE parent_run_id = $1
+ORDER BY created_at ASC;`
+
+// FindChildRunIDs implements Querier.FindChildRunIDs.
+func (q *DBQuerier) FindChildRunIDs(ctx context.Context, parentRunID pgtype.Text) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindChildRunIDs")
+	rows, err := q.conn.Query(ctx, findChildRunIDsSQL, parentRunID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindChildRunIDs: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const updateRunErrorCategorySQL = `UPDATE runs
+SET
+    error_category = $1
+WHERE run_id = $2
+RETURNING run_id
+;`
+
+// UpdateRunErrorCategory implements Querier.UpdateRunErrorCategory.
+func (q *DBQuerier) UpdateRunErrorCategory(ctx context.Context, errorCategory pgtype.Text, id pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateRunErrorCategory")
+	rows, err := q.conn.Query(ctx, updateRunErrorCategorySQL, errorCategory, id)
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("query UpdateRunErrorCategory: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
 const updateCancelSignaledAtSQL = `UPDATE runs
 SET
     cancel_signaled_at = $1
@@ -743,6 +1050,61 @@ func (q *DBQuerier) UpdateCancelSignaledAt(ctx context.Context, cancelSignaledAt
 	})
 }
 
+const updateForceCancelAvailableAtSQL = `UPDATE runs
+SET
+    force_cancel_available_at = $1
+WHERE run_id = $2
+RETURNING run_id
+;`
+
+// UpdateForceCancelAvailableAt implements Querier.UpdateForceCancelAvailableAt.
+func (q *DBQuerier) UpdateForceCancelAvailableAt(ctx context.Context, forceCancelAvailableAt pgtype.Timestamptz, id pgtype.Text) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateForceCancelAvailableAt")
+	rows, err := q.conn.Query(ctx, updateForceCancelAvailableAtSQL, forceCancelAvailableAt, id)
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("query UpdateForceCancelAvailableAt: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const updateRunForceCanceledBySQL = `UPDATE runs
+SET
+    force_canceled_by = $1,
+    force_cancel_reason = $2
+WHERE run_id = $3
+RETURNING run_id
+;`
+
+type UpdateRunForceCanceledByParams struct {
+	ForceCanceledBy   pgtype.Text `json:"force_canceled_by"`
+	ForceCancelReason pgtype.Text `json:"force_cancel_reason"`
+	ID                pgtype.Text `json:"id"`
+}
+
+// UpdateRunForceCanceledBy implements Querier.UpdateRunForceCanceledBy.
+func (q *DBQuerier) UpdateRunForceCanceledBy(ctx context.Context, params UpdateRunForceCanceledByParams) (pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateRunForceCanceledBy")
+	rows, err := q.conn.Query(ctx, updateRunForceCanceledBySQL, params.ForceCanceledBy, params.ForceCancelReason, params.ID)
+	if err != nil {
+		return pgtype.Text{}, fmt.Errorf("query UpdateRunForceCanceledBy: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
 const deleteRunByIDSQL = `DELETE
 FROM runs
 WHERE run_id = $1
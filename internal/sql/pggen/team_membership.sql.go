@@ -20,6 +20,7 @@ const insertTeamMembershipSQL = `WITH
 INSERT INTO team_memberships (username, team_id)
 SELECT username, $2
 FROM users
+ON CONFLICT (team_id, username) DO NOTHING
 RETURNING username
 ;`
 
@@ -70,3 +71,26 @@ func (q *DBQuerier) DeleteTeamMembership(ctx context.Context, usernames []string
 		return item, nil
 	})
 }
+
+const deleteTeamMembershipsByUsernameSQL = `DELETE
+FROM team_memberships
+WHERE username = $1
+RETURNING team_id
+;`
+
+// DeleteTeamMembershipsByUsername implements Querier.DeleteTeamMembershipsByUsername.
+func (q *DBQuerier) DeleteTeamMembershipsByUsername(ctx context.Context, username pgtype.Text) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteTeamMembershipsByUsername")
+	rows, err := q.conn.Query(ctx, deleteTeamMembershipsByUsernameSQL, username)
+	if err != nil {
+		return nil, fmt.Errorf("query DeleteTeamMembershipsByUsername: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
@@ -17,12 +17,14 @@ const insertTokenSQL = `INSERT INTO tokens (
     token_id,
     created_at,
     description,
-    username
+    username,
+    scopes
 ) VALUES (
     $1,
     $2,
     $3,
-    $4
+    $4,
+    $5
 );`
 
 type InsertTokenParams struct {
@@ -30,12 +32,13 @@ type InsertTokenParams struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	Description pgtype.Text        `json:"description"`
 	Username    pgtype.Text        `json:"username"`
+	Scopes      []string           `json:"scopes"`
 }
 
 // InsertToken implements Querier.InsertToken.
 func (q *DBQuerier) InsertToken(ctx context.Context, params InsertTokenParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertToken")
-	cmdTag, err := q.conn.Exec(ctx, insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username)
+	cmdTag, err := q.conn.Exec(ctx, insertTokenSQL, params.TokenID, params.CreatedAt, params.Description, params.Username, params.Scopes)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertToken: %w", err)
 	}
@@ -52,6 +55,7 @@ type FindTokensByUsernameRow struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	Description pgtype.Text        `json:"description"`
 	Username    pgtype.Text        `json:"username"`
+	Scopes      []string           `json:"scopes"`
 }
 
 // FindTokensByUsername implements Querier.FindTokensByUsername.
@@ -68,6 +72,7 @@ func (q *DBQuerier) FindTokensByUsername(ctx context.Context, username pgtype.Te
 			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Description, // 'description', 'Description', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Scopes,      // 'scopes', 'Scopes', '[]string', '', '[]string'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -85,6 +90,7 @@ type FindTokenByIDRow struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	Description pgtype.Text        `json:"description"`
 	Username    pgtype.Text        `json:"username"`
+	Scopes      []string           `json:"scopes"`
 }
 
 // FindTokenByID implements Querier.FindTokenByID.
@@ -101,6 +107,7 @@ func (q *DBQuerier) FindTokenByID(ctx context.Context, tokenID pgtype.Text) (Fin
 			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
 			&item.Description, // 'description', 'Description', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Scopes,      // 'scopes', 'Scopes', '[]string', '', '[]string'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -130,3 +137,26 @@ func (q *DBQuerier) DeleteTokenByID(ctx context.Context, tokenID pgtype.Text) (p
 		return item, nil
 	})
 }
+
+const deleteTokensByUsernameSQL = `DELETE
+FROM tokens
+WHERE username = $1
+RETURNING token_id
+;`
+
+// DeleteTokensByUsername implements Querier.DeleteTokensByUsername.
+func (q *DBQuerier) DeleteTokensByUsername(ctx context.Context, username pgtype.Text) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "DeleteTokensByUsername")
+	rows, err := q.conn.Query(ctx, deleteTokensByUsernameSQL, username)
+	if err != nil {
+		return nil, fmt.Errorf("query DeleteTokensByUsername: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
@@ -17,25 +17,31 @@ const insertUserSQL = `INSERT INTO users (
     user_id,
     created_at,
     updated_at,
-    username
+    username,
+    email,
+    external_id
 ) VALUES (
     $1,
     $2,
     $3,
-    $4
+    $4,
+    $5,
+    $6
 );`
 
 type InsertUserParams struct {
-	ID        pgtype.Text        `json:"id"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	Username  pgtype.Text        `json:"username"`
+	ID         pgtype.Text        `json:"id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	Username   pgtype.Text        `json:"username"`
+	Email      pgtype.Text        `json:"email"`
+	ExternalID pgtype.Text        `json:"external_id"`
 }
 
 // InsertUser implements Querier.InsertUser.
 func (q *DBQuerier) InsertUser(ctx context.Context, params InsertUserParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertUser")
-	cmdTag, err := q.conn.Exec(ctx, insertUserSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Username)
+	cmdTag, err := q.conn.Exec(ctx, insertUserSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.Username, params.Email, params.ExternalID)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertUser: %w", err)
 	}
@@ -53,12 +59,15 @@ FROM users u
 ;`
 
 type FindUsersRow struct {
-	UserID    pgtype.Text        `json:"user_id"`
-	Username  pgtype.Text        `json:"username"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	SiteAdmin pgtype.Bool        `json:"site_admin"`
-	Teams     []Teams            `json:"teams"`
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	Teams       []Teams            `json:"teams"`
 }
 
 // FindUsers implements Querier.FindUsers.
@@ -72,11 +81,14 @@ func (q *DBQuerier) FindUsers(ctx context.Context) ([]FindUsersRow, error) {
 	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindUsersRow, error) {
 		var item FindUsersRow
 		if err := row.Scan(&item.UserID, // 'user_id', 'UserID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Username,  // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt, // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.UpdatedAt, // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.SiteAdmin, // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.Teams,     // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
+			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.UpdatedAt,   // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SiteAdmin,   // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Email,       // 'email', 'Email', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExternalID,  // 'external_id', 'ExternalID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Deactivated, // 'deactivated', 'Deactivated', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Teams,       // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -99,12 +111,15 @@ GROUP BY u.user_id
 ;`
 
 type FindUsersByOrganizationRow struct {
-	UserID    pgtype.Text        `json:"user_id"`
-	Username  pgtype.Text        `json:"username"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	SiteAdmin pgtype.Bool        `json:"site_admin"`
-	Teams     []Teams            `json:"teams"`
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	Teams       []Teams            `json:"teams"`
 }
 
 // FindUsersByOrganization implements Querier.FindUsersByOrganization.
@@ -118,11 +133,14 @@ func (q *DBQuerier) FindUsersByOrganization(ctx context.Context, organizationNam
 	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindUsersByOrganizationRow, error) {
 		var item FindUsersByOrganizationRow
 		if err := row.Scan(&item.UserID, // 'user_id', 'UserID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Username,  // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt, // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.UpdatedAt, // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.SiteAdmin, // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.Teams,     // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
+			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.UpdatedAt,   // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SiteAdmin,   // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Email,       // 'email', 'Email', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExternalID,  // 'external_id', 'ExternalID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Deactivated, // 'deactivated', 'Deactivated', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Teams,       // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -145,12 +163,15 @@ WHERE t.team_id = $1
 ;`
 
 type FindUsersByTeamIDRow struct {
-	UserID    pgtype.Text        `json:"user_id"`
-	Username  pgtype.Text        `json:"username"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	SiteAdmin pgtype.Bool        `json:"site_admin"`
-	Teams     []Teams            `json:"teams"`
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	Teams       []Teams            `json:"teams"`
 }
 
 // FindUsersByTeamID implements Querier.FindUsersByTeamID.
@@ -164,11 +185,14 @@ func (q *DBQuerier) FindUsersByTeamID(ctx context.Context, teamID pgtype.Text) (
 	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FindUsersByTeamIDRow, error) {
 		var item FindUsersByTeamIDRow
 		if err := row.Scan(&item.UserID, // 'user_id', 'UserID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Username,  // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt, // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.UpdatedAt, // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.SiteAdmin, // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.Teams,     // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
+			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.UpdatedAt,   // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SiteAdmin,   // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Email,       // 'email', 'Email', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExternalID,  // 'external_id', 'ExternalID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Deactivated, // 'deactivated', 'Deactivated', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Teams,       // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -188,12 +212,15 @@ WHERE u.user_id = $1
 ;`
 
 type FindUserByIDRow struct {
-	UserID    pgtype.Text        `json:"user_id"`
-	Username  pgtype.Text        `json:"username"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	SiteAdmin pgtype.Bool        `json:"site_admin"`
-	Teams     []Teams            `json:"teams"`
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	Teams       []Teams            `json:"teams"`
 }
 
 // FindUserByID implements Querier.FindUserByID.
@@ -207,11 +234,14 @@ func (q *DBQuerier) FindUserByID(ctx context.Context, userID pgtype.Text) (FindU
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindUserByIDRow, error) {
 		var item FindUserByIDRow
 		if err := row.Scan(&item.UserID, // 'user_id', 'UserID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Username,  // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt, // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.UpdatedAt, // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.SiteAdmin, // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.Teams,     // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
+			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.UpdatedAt,   // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SiteAdmin,   // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Email,       // 'email', 'Email', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExternalID,  // 'external_id', 'ExternalID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Deactivated, // 'deactivated', 'Deactivated', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Teams,       // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -231,12 +261,15 @@ WHERE u.username = $1
 ;`
 
 type FindUserByUsernameRow struct {
-	UserID    pgtype.Text        `json:"user_id"`
-	Username  pgtype.Text        `json:"username"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	SiteAdmin pgtype.Bool        `json:"site_admin"`
-	Teams     []Teams            `json:"teams"`
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	Teams       []Teams            `json:"teams"`
 }
 
 // FindUserByUsername implements Querier.FindUserByUsername.
@@ -250,11 +283,14 @@ func (q *DBQuerier) FindUserByUsername(ctx context.Context, username pgtype.Text
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindUserByUsernameRow, error) {
 		var item FindUserByUsernameRow
 		if err := row.Scan(&item.UserID, // 'user_id', 'UserID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Username,  // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt, // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.UpdatedAt, // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.SiteAdmin, // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.Teams,     // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
+			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.UpdatedAt,   // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SiteAdmin,   // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Email,       // 'email', 'Email', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExternalID,  // 'external_id', 'ExternalID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Deactivated, // 'deactivated', 'Deactivated', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Teams,       // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -275,12 +311,15 @@ WHERE t.token_id = $1
 ;`
 
 type FindUserByAuthenticationTokenIDRow struct {
-	UserID    pgtype.Text        `json:"user_id"`
-	Username  pgtype.Text        `json:"username"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	SiteAdmin pgtype.Bool        `json:"site_admin"`
-	Teams     []Teams            `json:"teams"`
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	Teams       []Teams            `json:"teams"`
 }
 
 // FindUserByAuthenticationTokenID implements Querier.FindUserByAuthenticationTokenID.
@@ -294,11 +333,14 @@ func (q *DBQuerier) FindUserByAuthenticationTokenID(ctx context.Context, tokenID
 	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindUserByAuthenticationTokenIDRow, error) {
 		var item FindUserByAuthenticationTokenIDRow
 		if err := row.Scan(&item.UserID, // 'user_id', 'UserID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.Username,  // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
-			&item.CreatedAt, // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.UpdatedAt, // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
-			&item.SiteAdmin, // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
-			&item.Teams,     // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
+			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.UpdatedAt,   // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SiteAdmin,   // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Email,       // 'email', 'Email', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExternalID,  // 'external_id', 'ExternalID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Deactivated, // 'deactivated', 'Deactivated', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Teams,       // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
 		); err != nil {
 			return item, fmt.Errorf("failed to scan: %w", err)
 		}
@@ -306,6 +348,133 @@ func (q *DBQuerier) FindUserByAuthenticationTokenID(ctx context.Context, tokenID
 	})
 }
 
+const findUserByExternalIDSQL = `SELECT u.*,
+    (
+        SELECT array_agg(t)
+        FROM teams t
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = u.username
+    ) AS teams
+FROM users u
+WHERE u.external_id = $1
+;`
+
+type FindUserByExternalIDRow struct {
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	Teams       []Teams            `json:"teams"`
+}
+
+// FindUserByExternalID implements Querier.FindUserByExternalID.
+func (q *DBQuerier) FindUserByExternalID(ctx context.Context, externalID pgtype.Text) (FindUserByExternalIDRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindUserByExternalID")
+	rows, err := q.conn.Query(ctx, findUserByExternalIDSQL, externalID)
+	if err != nil {
+		return FindUserByExternalIDRow{}, fmt.Errorf("query FindUserByExternalID: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindUserByExternalIDRow, error) {
+		var item FindUserByExternalIDRow
+		if err := row.Scan(&item.UserID, // 'user_id', 'UserID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.UpdatedAt,   // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SiteAdmin,   // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Email,       // 'email', 'Email', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExternalID,  // 'external_id', 'ExternalID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Deactivated, // 'deactivated', 'Deactivated', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Teams,       // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const findUserByEmailSQL = `SELECT u.*,
+    (
+        SELECT array_agg(t)
+        FROM teams t
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = u.username
+    ) AS teams
+FROM users u
+WHERE u.email = $1
+;`
+
+type FindUserByEmailRow struct {
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	Teams       []Teams            `json:"teams"`
+}
+
+// FindUserByEmail implements Querier.FindUserByEmail.
+func (q *DBQuerier) FindUserByEmail(ctx context.Context, email pgtype.Text) (FindUserByEmailRow, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindUserByEmail")
+	rows, err := q.conn.Query(ctx, findUserByEmailSQL, email)
+	if err != nil {
+		return FindUserByEmailRow{}, fmt.Errorf("query FindUserByEmail: %w", err)
+	}
+
+	return pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (FindUserByEmailRow, error) {
+		var item FindUserByEmailRow
+		if err := row.Scan(&item.UserID, // 'user_id', 'UserID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Username,    // 'username', 'Username', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.CreatedAt,   // 'created_at', 'CreatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.UpdatedAt,   // 'updated_at', 'UpdatedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.SiteAdmin,   // 'site_admin', 'SiteAdmin', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Email,       // 'email', 'Email', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ExternalID,  // 'external_id', 'ExternalID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.Deactivated, // 'deactivated', 'Deactivated', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.Teams,       // 'teams', 'Teams', '[]Teams', 'github.com/tofutf/tofutf/internal/sql/queries', '[]Teams'
+		); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
+
+const updateUserSQL = `UPDATE users
+SET
+    username = $1,
+    email = $2,
+    external_id = $3,
+    deactivated = $4,
+    updated_at = $5
+WHERE user_id = $6
+;`
+
+type UpdateUserParams struct {
+	Username    pgtype.Text        `json:"username"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	UserID      pgtype.Text        `json:"user_id"`
+}
+
+// UpdateUser implements Querier.UpdateUser.
+func (q *DBQuerier) UpdateUser(ctx context.Context, params UpdateUserParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateUser")
+	cmdTag, err := q.conn.Exec(ctx, updateUserSQL, params.Username, params.Email, params.ExternalID, params.Deactivated, params.UpdatedAt, params.UserID)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query UpdateUser: %w", err)
+	}
+	return cmdTag, err
+}
+
 const updateUserSiteAdminsSQL = `UPDATE users
 SET site_admin = true
 WHERE username = ANY($1::text[])
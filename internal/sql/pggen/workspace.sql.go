@@ -18,17 +18,23 @@ const insertWorkspaceSQL = `INSERT INTO workspaces (
     created_at,
     updated_at,
     agent_pool_id,
+    plan_agent_pool_id,
+    apply_agent_pool_id,
     allow_cli_apply,
     allow_destroy_plan,
+    apply_windows,
     auto_apply,
     branch,
     can_queue_destroy_plan,
+    deletion_protected,
+    log_scrubbing_disabled,
     description,
     environment,
     execution_mode,
     global_remote_state,
     migration_environment,
     name,
+    preflight_checks,
     queue_all_runs,
     speculative_enabled,
     source_name,
@@ -38,6 +44,7 @@ const insertWorkspaceSQL = `INSERT INTO workspaces (
     trigger_prefixes,
     trigger_patterns,
     vcs_tags_regex,
+    vcs_skip_drafts,
     working_directory,
     organization_name
 ) VALUES (
@@ -66,7 +73,14 @@ const insertWorkspaceSQL = `INSERT INTO workspaces (
     $23,
     $24,
     $25,
-    $26
+    $26,
+    $27,
+    $28,
+    $29,
+    $30,
+    $31,
+    $32,
+    $33
 );`
 
 type InsertWorkspaceParams struct {
@@ -74,17 +88,23 @@ type InsertWorkspaceParams struct {
 	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
 	AgentPoolID                pgtype.Text        `json:"agent_pool_id"`
+	PlanAgentPoolID            pgtype.Text        `json:"plan_agent_pool_id"`
+	ApplyAgentPoolID           pgtype.Text        `json:"apply_agent_pool_id"`
 	AllowCLIApply              pgtype.Bool        `json:"allow_cli_apply"`
 	AllowDestroyPlan           pgtype.Bool        `json:"allow_destroy_plan"`
+	ApplyWindows               []byte             `json:"apply_windows"`
 	AutoApply                  pgtype.Bool        `json:"auto_apply"`
 	Branch                     pgtype.Text        `json:"branch"`
 	CanQueueDestroyPlan        pgtype.Bool        `json:"can_queue_destroy_plan"`
+	DeletionProtected          pgtype.Bool        `json:"deletion_protected"`
+	LogScrubbingDisabled       pgtype.Bool        `json:"log_scrubbing_disabled"`
 	Description                pgtype.Text        `json:"description"`
 	Environment                pgtype.Text        `json:"environment"`
 	ExecutionMode              pgtype.Text        `json:"execution_mode"`
 	GlobalRemoteState          pgtype.Bool        `json:"global_remote_state"`
 	MigrationEnvironment       pgtype.Text        `json:"migration_environment"`
 	Name                       pgtype.Text        `json:"name"`
+	PreflightChecks            []string           `json:"preflight_checks"`
 	QueueAllRuns               pgtype.Bool        `json:"queue_all_runs"`
 	SpeculativeEnabled         pgtype.Bool        `json:"speculative_enabled"`
 	SourceName                 pgtype.Text        `json:"source_name"`
@@ -94,6 +114,7 @@ type InsertWorkspaceParams struct {
 	TriggerPrefixes            []string           `json:"trigger_prefixes"`
 	TriggerPatterns            []string           `json:"trigger_patterns"`
 	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
+	VCSSkipDrafts              pgtype.Bool        `json:"vcs_skip_drafts"`
 	WorkingDirectory           pgtype.Text        `json:"working_directory"`
 	OrganizationName           pgtype.Text        `json:"organization_name"`
 }
@@ -101,7 +122,7 @@ type InsertWorkspaceParams struct {
 // InsertWorkspace implements Querier.InsertWorkspace.
 func (q *DBQuerier) InsertWorkspace(ctx context.Context, params InsertWorkspaceParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "InsertWorkspace")
-	cmdTag, err := q.conn.Exec(ctx, insertWorkspaceSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.AgentPoolID, params.AllowCLIApply, params.AllowDestroyPlan, params.AutoApply, params.Branch, params.CanQueueDestroyPlan, params.Description, params.Environment, params.ExecutionMode, params.GlobalRemoteState, params.MigrationEnvironment, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.SourceName, params.SourceURL, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.OrganizationName)
+	cmdTag, err := q.conn.Exec(ctx, insertWorkspaceSQL, params.ID, params.CreatedAt, params.UpdatedAt, params.AgentPoolID, params.PlanAgentPoolID, params.ApplyAgentPoolID, params.AllowCLIApply, params.AllowDestroyPlan, params.ApplyWindows, params.AutoApply, params.Branch, params.CanQueueDestroyPlan, params.DeletionProtected, params.LogScrubbingDisabled, params.Description, params.Environment, params.ExecutionMode, params.GlobalRemoteState, params.MigrationEnvironment, params.Name, params.PreflightChecks, params.QueueAllRuns, params.SpeculativeEnabled, params.SourceName, params.SourceURL, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.VCSSkipDrafts, params.WorkingDirectory, params.OrganizationName)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query InsertWorkspace: %w", err)
 	}
@@ -183,6 +204,18 @@ type FindWorkspacesRow struct {
 	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
 	AllowCLIApply              pgtype.Bool        `json:"allow_cli_apply"`
 	AgentPoolID                pgtype.Text        `json:"agent_pool_id"`
+	DeletionProtected          pgtype.Bool        `json:"deletion_protected"`
+	LogScrubbingDisabled       pgtype.Bool        `json:"log_scrubbing_disabled"`
+	VCSSkipDrafts              pgtype.Bool        `json:"vcs_skip_drafts"`
+	ApplyWindows               []byte             `json:"apply_windows"`
+	LockAcquiredAt             pgtype.Timestamptz `json:"lock_acquired_at"`
+	PausedAt                   pgtype.Timestamptz `json:"paused_at"`
+	PreflightChecks            []string           `json:"preflight_checks"`
+	LockInfo                   []byte             `json:"lock_info"`
+	DebugLoggingExpiresAt      pgtype.Timestamptz `json:"debug_logging_expires_at"`
+	DebugLoggingRunsRemaining  pgtype.Int4        `json:"debug_logging_runs_remaining"`
+	PlanAgentPoolID            pgtype.Text        `json:"plan_agent_pool_id"`
+	ApplyAgentPoolID           pgtype.Text        `json:"apply_agent_pool_id"`
 	Tags                       []string           `json:"tags"`
 	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
 	UserLock                   Users              `json:"user_lock"`
@@ -230,6 +263,18 @@ func (q *DBQuerier) FindWorkspaces(ctx context.Context, params FindWorkspacesPar
 			&item.VCSTagsRegex,               // 'vcs_tags_regex', 'VCSTagsRegex', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowCLIApply,              // 'allow_cli_apply', 'AllowCLIApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.AgentPoolID,                // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DeletionProtected,          // 'deletion_protected', 'DeletionProtected', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogScrubbingDisabled,       // 'log_scrubbing_disabled', 'LogScrubbingDisabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.VCSSkipDrafts,              // 'vcs_skip_drafts', 'VCSSkipDrafts', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.ApplyWindows,               // 'apply_windows', 'ApplyWindows', '[]byte', '', '[]byte'
+			&item.LockAcquiredAt,             // 'lock_acquired_at', 'LockAcquiredAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PausedAt,                   // 'paused_at', 'PausedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PreflightChecks,            // 'preflight_checks', 'PreflightChecks', '[]string', '', '[]string'
+			&item.LockInfo,                   // 'lock_info', 'LockInfo', '[]byte', '', '[]byte'
+			&item.DebugLoggingExpiresAt,      // 'debug_logging_expires_at', 'DebugLoggingExpiresAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.DebugLoggingRunsRemaining,  // 'debug_logging_runs_remaining', 'DebugLoggingRunsRemaining', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.PlanAgentPoolID,            // 'plan_agent_pool_id', 'PlanAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ApplyAgentPoolID,           // 'apply_agent_pool_id', 'ApplyAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Tags,                       // 'tags', 'Tags', '[]string', '', '[]string'
 			&item.LatestRunStatus,            // 'latest_run_status', 'LatestRunStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.UserLock,                   // 'user_lock', 'UserLock', 'Users', 'github.com/tofutf/tofutf/internal/sql/queries', 'Users'
@@ -331,6 +376,18 @@ type FindWorkspacesByConnectionRow struct {
 	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
 	AllowCLIApply              pgtype.Bool        `json:"allow_cli_apply"`
 	AgentPoolID                pgtype.Text        `json:"agent_pool_id"`
+	DeletionProtected          pgtype.Bool        `json:"deletion_protected"`
+	LogScrubbingDisabled       pgtype.Bool        `json:"log_scrubbing_disabled"`
+	VCSSkipDrafts              pgtype.Bool        `json:"vcs_skip_drafts"`
+	ApplyWindows               []byte             `json:"apply_windows"`
+	LockAcquiredAt             pgtype.Timestamptz `json:"lock_acquired_at"`
+	PausedAt                   pgtype.Timestamptz `json:"paused_at"`
+	PreflightChecks            []string           `json:"preflight_checks"`
+	LockInfo                   []byte             `json:"lock_info"`
+	DebugLoggingExpiresAt      pgtype.Timestamptz `json:"debug_logging_expires_at"`
+	DebugLoggingRunsRemaining  pgtype.Int4        `json:"debug_logging_runs_remaining"`
+	PlanAgentPoolID            pgtype.Text        `json:"plan_agent_pool_id"`
+	ApplyAgentPoolID           pgtype.Text        `json:"apply_agent_pool_id"`
 	Tags                       []string           `json:"tags"`
 	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
 	UserLock                   Users              `json:"user_lock"`
@@ -378,6 +435,18 @@ func (q *DBQuerier) FindWorkspacesByConnection(ctx context.Context, vcsProviderI
 			&item.VCSTagsRegex,               // 'vcs_tags_regex', 'VCSTagsRegex', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowCLIApply,              // 'allow_cli_apply', 'AllowCLIApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.AgentPoolID,                // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DeletionProtected,          // 'deletion_protected', 'DeletionProtected', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogScrubbingDisabled,       // 'log_scrubbing_disabled', 'LogScrubbingDisabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.VCSSkipDrafts,              // 'vcs_skip_drafts', 'VCSSkipDrafts', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.ApplyWindows,               // 'apply_windows', 'ApplyWindows', '[]byte', '', '[]byte'
+			&item.LockAcquiredAt,             // 'lock_acquired_at', 'LockAcquiredAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PausedAt,                   // 'paused_at', 'PausedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PreflightChecks,            // 'preflight_checks', 'PreflightChecks', '[]string', '', '[]string'
+			&item.LockInfo,                   // 'lock_info', 'LockInfo', '[]byte', '', '[]byte'
+			&item.DebugLoggingExpiresAt,      // 'debug_logging_expires_at', 'DebugLoggingExpiresAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.DebugLoggingRunsRemaining,  // 'debug_logging_runs_remaining', 'DebugLoggingRunsRemaining', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.PlanAgentPoolID,            // 'plan_agent_pool_id', 'PlanAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ApplyAgentPoolID,           // 'apply_agent_pool_id', 'ApplyAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Tags,                       // 'tags', 'Tags', '[]string', '', '[]string'
 			&item.LatestRunStatus,            // 'latest_run_status', 'LatestRunStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.UserLock,                   // 'user_lock', 'UserLock', 'Users', 'github.com/tofutf/tofutf/internal/sql/queries', 'Users'
@@ -456,6 +525,18 @@ type FindWorkspacesByUsernameRow struct {
 	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
 	AllowCLIApply              pgtype.Bool        `json:"allow_cli_apply"`
 	AgentPoolID                pgtype.Text        `json:"agent_pool_id"`
+	DeletionProtected          pgtype.Bool        `json:"deletion_protected"`
+	LogScrubbingDisabled       pgtype.Bool        `json:"log_scrubbing_disabled"`
+	VCSSkipDrafts              pgtype.Bool        `json:"vcs_skip_drafts"`
+	ApplyWindows               []byte             `json:"apply_windows"`
+	LockAcquiredAt             pgtype.Timestamptz `json:"lock_acquired_at"`
+	PausedAt                   pgtype.Timestamptz `json:"paused_at"`
+	PreflightChecks            []string           `json:"preflight_checks"`
+	LockInfo                   []byte             `json:"lock_info"`
+	DebugLoggingExpiresAt      pgtype.Timestamptz `json:"debug_logging_expires_at"`
+	DebugLoggingRunsRemaining  pgtype.Int4        `json:"debug_logging_runs_remaining"`
+	PlanAgentPoolID            pgtype.Text        `json:"plan_agent_pool_id"`
+	ApplyAgentPoolID           pgtype.Text        `json:"apply_agent_pool_id"`
 	Tags                       []string           `json:"tags"`
 	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
 	UserLock                   Users              `json:"user_lock"`
@@ -503,6 +584,18 @@ func (q *DBQuerier) FindWorkspacesByUsername(ctx context.Context, params FindWor
 			&item.VCSTagsRegex,               // 'vcs_tags_regex', 'VCSTagsRegex', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowCLIApply,              // 'allow_cli_apply', 'AllowCLIApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.AgentPoolID,                // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DeletionProtected,          // 'deletion_protected', 'DeletionProtected', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogScrubbingDisabled,       // 'log_scrubbing_disabled', 'LogScrubbingDisabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.VCSSkipDrafts,              // 'vcs_skip_drafts', 'VCSSkipDrafts', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.ApplyWindows,               // 'apply_windows', 'ApplyWindows', '[]byte', '', '[]byte'
+			&item.LockAcquiredAt,             // 'lock_acquired_at', 'LockAcquiredAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PausedAt,                   // 'paused_at', 'PausedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PreflightChecks,            // 'preflight_checks', 'PreflightChecks', '[]string', '', '[]string'
+			&item.LockInfo,                   // 'lock_info', 'LockInfo', '[]byte', '', '[]byte'
+			&item.DebugLoggingExpiresAt,      // 'debug_logging_expires_at', 'DebugLoggingExpiresAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.DebugLoggingRunsRemaining,  // 'debug_logging_runs_remaining', 'DebugLoggingRunsRemaining', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.PlanAgentPoolID,            // 'plan_agent_pool_id', 'PlanAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ApplyAgentPoolID,           // 'apply_agent_pool_id', 'ApplyAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Tags,                       // 'tags', 'Tags', '[]string', '', '[]string'
 			&item.LatestRunStatus,            // 'latest_run_status', 'LatestRunStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.UserLock,                   // 'user_lock', 'UserLock', 'Users', 'github.com/tofutf/tofutf/internal/sql/queries', 'Users'
@@ -593,6 +686,18 @@ type FindWorkspaceByNameRow struct {
 	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
 	AllowCLIApply              pgtype.Bool        `json:"allow_cli_apply"`
 	AgentPoolID                pgtype.Text        `json:"agent_pool_id"`
+	DeletionProtected          pgtype.Bool        `json:"deletion_protected"`
+	LogScrubbingDisabled       pgtype.Bool        `json:"log_scrubbing_disabled"`
+	VCSSkipDrafts              pgtype.Bool        `json:"vcs_skip_drafts"`
+	ApplyWindows               []byte             `json:"apply_windows"`
+	LockAcquiredAt             pgtype.Timestamptz `json:"lock_acquired_at"`
+	PausedAt                   pgtype.Timestamptz `json:"paused_at"`
+	PreflightChecks            []string           `json:"preflight_checks"`
+	LockInfo                   []byte             `json:"lock_info"`
+	DebugLoggingExpiresAt      pgtype.Timestamptz `json:"debug_logging_expires_at"`
+	DebugLoggingRunsRemaining  pgtype.Int4        `json:"debug_logging_runs_remaining"`
+	PlanAgentPoolID            pgtype.Text        `json:"plan_agent_pool_id"`
+	ApplyAgentPoolID           pgtype.Text        `json:"apply_agent_pool_id"`
 	Tags                       []string           `json:"tags"`
 	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
 	UserLock                   Users              `json:"user_lock"`
@@ -640,6 +745,18 @@ func (q *DBQuerier) FindWorkspaceByName(ctx context.Context, name pgtype.Text, o
 			&item.VCSTagsRegex,               // 'vcs_tags_regex', 'VCSTagsRegex', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowCLIApply,              // 'allow_cli_apply', 'AllowCLIApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.AgentPoolID,                // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DeletionProtected,          // 'deletion_protected', 'DeletionProtected', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogScrubbingDisabled,       // 'log_scrubbing_disabled', 'LogScrubbingDisabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.VCSSkipDrafts,              // 'vcs_skip_drafts', 'VCSSkipDrafts', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.ApplyWindows,               // 'apply_windows', 'ApplyWindows', '[]byte', '', '[]byte'
+			&item.LockAcquiredAt,             // 'lock_acquired_at', 'LockAcquiredAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PausedAt,                   // 'paused_at', 'PausedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PreflightChecks,            // 'preflight_checks', 'PreflightChecks', '[]string', '', '[]string'
+			&item.LockInfo,                   // 'lock_info', 'LockInfo', '[]byte', '', '[]byte'
+			&item.DebugLoggingExpiresAt,      // 'debug_logging_expires_at', 'DebugLoggingExpiresAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.DebugLoggingRunsRemaining,  // 'debug_logging_runs_remaining', 'DebugLoggingRunsRemaining', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.PlanAgentPoolID,            // 'plan_agent_pool_id', 'PlanAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ApplyAgentPoolID,           // 'apply_agent_pool_id', 'ApplyAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Tags,                       // 'tags', 'Tags', '[]string', '', '[]string'
 			&item.LatestRunStatus,            // 'latest_run_status', 'LatestRunStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.UserLock,                   // 'user_lock', 'UserLock', 'Users', 'github.com/tofutf/tofutf/internal/sql/queries', 'Users'
@@ -702,6 +819,18 @@ type FindWorkspaceByIDRow struct {
 	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
 	AllowCLIApply              pgtype.Bool        `json:"allow_cli_apply"`
 	AgentPoolID                pgtype.Text        `json:"agent_pool_id"`
+	DeletionProtected          pgtype.Bool        `json:"deletion_protected"`
+	LogScrubbingDisabled       pgtype.Bool        `json:"log_scrubbing_disabled"`
+	VCSSkipDrafts              pgtype.Bool        `json:"vcs_skip_drafts"`
+	ApplyWindows               []byte             `json:"apply_windows"`
+	LockAcquiredAt             pgtype.Timestamptz `json:"lock_acquired_at"`
+	PausedAt                   pgtype.Timestamptz `json:"paused_at"`
+	PreflightChecks            []string           `json:"preflight_checks"`
+	LockInfo                   []byte             `json:"lock_info"`
+	DebugLoggingExpiresAt      pgtype.Timestamptz `json:"debug_logging_expires_at"`
+	DebugLoggingRunsRemaining  pgtype.Int4        `json:"debug_logging_runs_remaining"`
+	PlanAgentPoolID            pgtype.Text        `json:"plan_agent_pool_id"`
+	ApplyAgentPoolID           pgtype.Text        `json:"apply_agent_pool_id"`
 	Tags                       []string           `json:"tags"`
 	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
 	UserLock                   Users              `json:"user_lock"`
@@ -749,6 +878,18 @@ func (q *DBQuerier) FindWorkspaceByID(ctx context.Context, id pgtype.Text) (Find
 			&item.VCSTagsRegex,               // 'vcs_tags_regex', 'VCSTagsRegex', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowCLIApply,              // 'allow_cli_apply', 'AllowCLIApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.AgentPoolID,                // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DeletionProtected,          // 'deletion_protected', 'DeletionProtected', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogScrubbingDisabled,       // 'log_scrubbing_disabled', 'LogScrubbingDisabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.VCSSkipDrafts,              // 'vcs_skip_drafts', 'VCSSkipDrafts', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.ApplyWindows,               // 'apply_windows', 'ApplyWindows', '[]byte', '', '[]byte'
+			&item.LockAcquiredAt,             // 'lock_acquired_at', 'LockAcquiredAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PausedAt,                   // 'paused_at', 'PausedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PreflightChecks,            // 'preflight_checks', 'PreflightChecks', '[]string', '', '[]string'
+			&item.LockInfo,                   // 'lock_info', 'LockInfo', '[]byte', '', '[]byte'
+			&item.DebugLoggingExpiresAt,      // 'debug_logging_expires_at', 'DebugLoggingExpiresAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.DebugLoggingRunsRemaining,  // 'debug_logging_runs_remaining', 'DebugLoggingRunsRemaining', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.PlanAgentPoolID,            // 'plan_agent_pool_id', 'PlanAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ApplyAgentPoolID,           // 'apply_agent_pool_id', 'ApplyAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Tags,                       // 'tags', 'Tags', '[]string', '', '[]string'
 			&item.LatestRunStatus,            // 'latest_run_status', 'LatestRunStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.UserLock,                   // 'user_lock', 'UserLock', 'Users', 'github.com/tofutf/tofutf/internal/sql/queries', 'Users'
@@ -811,6 +952,18 @@ type FindWorkspaceByIDForUpdateRow struct {
 	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
 	AllowCLIApply              pgtype.Bool        `json:"allow_cli_apply"`
 	AgentPoolID                pgtype.Text        `json:"agent_pool_id"`
+	DeletionProtected          pgtype.Bool        `json:"deletion_protected"`
+	LogScrubbingDisabled       pgtype.Bool        `json:"log_scrubbing_disabled"`
+	VCSSkipDrafts              pgtype.Bool        `json:"vcs_skip_drafts"`
+	ApplyWindows               []byte             `json:"apply_windows"`
+	LockAcquiredAt             pgtype.Timestamptz `json:"lock_acquired_at"`
+	PausedAt                   pgtype.Timestamptz `json:"paused_at"`
+	PreflightChecks            []string           `json:"preflight_checks"`
+	LockInfo                   []byte             `json:"lock_info"`
+	DebugLoggingExpiresAt      pgtype.Timestamptz `json:"debug_logging_expires_at"`
+	DebugLoggingRunsRemaining  pgtype.Int4        `json:"debug_logging_runs_remaining"`
+	PlanAgentPoolID            pgtype.Text        `json:"plan_agent_pool_id"`
+	ApplyAgentPoolID           pgtype.Text        `json:"apply_agent_pool_id"`
 	Tags                       []string           `json:"tags"`
 	LatestRunStatus            pgtype.Text        `json:"latest_run_status"`
 	UserLock                   Users              `json:"user_lock"`
@@ -858,6 +1011,18 @@ func (q *DBQuerier) FindWorkspaceByIDForUpdate(ctx context.Context, id pgtype.Te
 			&item.VCSTagsRegex,               // 'vcs_tags_regex', 'VCSTagsRegex', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.AllowCLIApply,              // 'allow_cli_apply', 'AllowCLIApply', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
 			&item.AgentPoolID,                // 'agent_pool_id', 'AgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.DeletionProtected,          // 'deletion_protected', 'DeletionProtected', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.LogScrubbingDisabled,       // 'log_scrubbing_disabled', 'LogScrubbingDisabled', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.VCSSkipDrafts,              // 'vcs_skip_drafts', 'VCSSkipDrafts', 'pgtype.Bool', 'github.com/jackc/pgx/v5/pgtype', 'Bool'
+			&item.ApplyWindows,               // 'apply_windows', 'ApplyWindows', '[]byte', '', '[]byte'
+			&item.LockAcquiredAt,             // 'lock_acquired_at', 'LockAcquiredAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PausedAt,                   // 'paused_at', 'PausedAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.PreflightChecks,            // 'preflight_checks', 'PreflightChecks', '[]string', '', '[]string'
+			&item.LockInfo,                   // 'lock_info', 'LockInfo', '[]byte', '', '[]byte'
+			&item.DebugLoggingExpiresAt,      // 'debug_logging_expires_at', 'DebugLoggingExpiresAt', 'pgtype.Timestamptz', 'github.com/jackc/pgx/v5/pgtype', 'Timestamptz'
+			&item.DebugLoggingRunsRemaining,  // 'debug_logging_runs_remaining', 'DebugLoggingRunsRemaining', 'pgtype.Int4', 'github.com/jackc/pgx/v5/pgtype', 'Int4'
+			&item.PlanAgentPoolID,            // 'plan_agent_pool_id', 'PlanAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
+			&item.ApplyAgentPoolID,           // 'apply_agent_pool_id', 'ApplyAgentPoolID', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.Tags,                       // 'tags', 'Tags', '[]string', '', '[]string'
 			&item.LatestRunStatus,            // 'latest_run_status', 'LatestRunStatus', 'pgtype.Text', 'github.com/jackc/pgx/v5/pgtype', 'Text'
 			&item.UserLock,                   // 'user_lock', 'UserLock', 'Users', 'github.com/tofutf/tofutf/internal/sql/queries', 'Users'
@@ -873,36 +1038,49 @@ func (q *DBQuerier) FindWorkspaceByIDForUpdate(ctx context.Context, id pgtype.Te
 const updateWorkspaceByIDSQL = `UPDATE workspaces
 SET
     agent_pool_id                 = $1,
-    allow_destroy_plan            = $2,
-    allow_cli_apply               = $3,
-    auto_apply                    = $4,
-    branch                        = $5,
-    description                   = $6,
-    execution_mode                = $7,
-    global_remote_state           = $8,
-    name                          = $9,
-    queue_all_runs                = $10,
-    speculative_enabled           = $11,
-    structured_run_output_enabled = $12,
-    terraform_version             = $13,
-    trigger_prefixes              = $14,
-    trigger_patterns              = $15,
-    vcs_tags_regex                = $16,
-    working_directory             = $17,
-    updated_at                    = $18
-WHERE workspace_id = $19
+    plan_agent_pool_id            = $2,
+    apply_agent_pool_id           = $3,
+    allow_destroy_plan            = $4,
+    allow_cli_apply               = $5,
+    apply_windows                 = $6,
+    auto_apply                    = $7,
+    branch                        = $8,
+    deletion_protected            = $9,
+    log_scrubbing_disabled        = $10,
+    description                   = $11,
+    execution_mode                = $12,
+    global_remote_state           = $13,
+    name                          = $14,
+    preflight_checks              = $15,
+    queue_all_runs                = $16,
+    speculative_enabled           = $17,
+    structured_run_output_enabled = $18,
+    terraform_version             = $19,
+    trigger_prefixes              = $20,
+    trigger_patterns              = $21,
+    vcs_tags_regex                = $22,
+    vcs_skip_drafts               = $23,
+    working_directory             = $24,
+    updated_at                    = $25
+WHERE workspace_id = $26
 RETURNING workspace_id;`
 
 type UpdateWorkspaceByIDParams struct {
 	AgentPoolID                pgtype.Text        `json:"agent_pool_id"`
+	PlanAgentPoolID            pgtype.Text        `json:"plan_agent_pool_id"`
+	ApplyAgentPoolID           pgtype.Text        `json:"apply_agent_pool_id"`
 	AllowDestroyPlan           pgtype.Bool        `json:"allow_destroy_plan"`
 	AllowCLIApply              pgtype.Bool        `json:"allow_cli_apply"`
+	ApplyWindows               []byte             `json:"apply_windows"`
 	AutoApply                  pgtype.Bool        `json:"auto_apply"`
 	Branch                     pgtype.Text        `json:"branch"`
+	DeletionProtected          pgtype.Bool        `json:"deletion_protected"`
+	LogScrubbingDisabled       pgtype.Bool        `json:"log_scrubbing_disabled"`
 	Description                pgtype.Text        `json:"description"`
 	ExecutionMode              pgtype.Text        `json:"execution_mode"`
 	GlobalRemoteState          pgtype.Bool        `json:"global_remote_state"`
 	Name                       pgtype.Text        `json:"name"`
+	PreflightChecks            []string           `json:"preflight_checks"`
 	QueueAllRuns               pgtype.Bool        `json:"queue_all_runs"`
 	SpeculativeEnabled         pgtype.Bool        `json:"speculative_enabled"`
 	StructuredRunOutputEnabled pgtype.Bool        `json:"structured_run_output_enabled"`
@@ -910,6 +1088,7 @@ type UpdateWorkspaceByIDParams struct {
 	TriggerPrefixes            []string           `json:"trigger_prefixes"`
 	TriggerPatterns            []string           `json:"trigger_patterns"`
 	VCSTagsRegex               pgtype.Text        `json:"vcs_tags_regex"`
+	VCSSkipDrafts              pgtype.Bool        `json:"vcs_skip_drafts"`
 	WorkingDirectory           pgtype.Text        `json:"working_directory"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
 	ID                         pgtype.Text        `json:"id"`
@@ -918,7 +1097,7 @@ type UpdateWorkspaceByIDParams struct {
 // UpdateWorkspaceByID implements Querier.UpdateWorkspaceByID.
 func (q *DBQuerier) UpdateWorkspaceByID(ctx context.Context, params UpdateWorkspaceByIDParams) (pgtype.Text, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateWorkspaceByID")
-	rows, err := q.conn.Query(ctx, updateWorkspaceByIDSQL, params.AgentPoolID, params.AllowDestroyPlan, params.AllowCLIApply, params.AutoApply, params.Branch, params.Description, params.ExecutionMode, params.GlobalRemoteState, params.Name, params.QueueAllRuns, params.SpeculativeEnabled, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.WorkingDirectory, params.UpdatedAt, params.ID)
+	rows, err := q.conn.Query(ctx, updateWorkspaceByIDSQL, params.AgentPoolID, params.PlanAgentPoolID, params.ApplyAgentPoolID, params.AllowDestroyPlan, params.AllowCLIApply, params.ApplyWindows, params.AutoApply, params.Branch, params.DeletionProtected, params.LogScrubbingDisabled, params.Description, params.ExecutionMode, params.GlobalRemoteState, params.Name, params.PreflightChecks, params.QueueAllRuns, params.SpeculativeEnabled, params.StructuredRunOutputEnabled, params.TerraformVersion, params.TriggerPrefixes, params.TriggerPatterns, params.VCSTagsRegex, params.VCSSkipDrafts, params.WorkingDirectory, params.UpdatedAt, params.ID)
 	if err != nil {
 		return pgtype.Text{}, fmt.Errorf("query UpdateWorkspaceByID: %w", err)
 	}
@@ -935,25 +1114,64 @@ func (q *DBQuerier) UpdateWorkspaceByID(ctx context.Context, params UpdateWorksp
 const updateWorkspaceLockByIDSQL = `UPDATE workspaces
 SET
     lock_username = $1,
-    lock_run_id = $2
-WHERE workspace_id = $3;`
+    lock_run_id = $2,
+    lock_acquired_at = $3,
+    lock_info = $4
+WHERE workspace_id = $5;`
 
 type UpdateWorkspaceLockByIDParams struct {
-	Username    pgtype.Text `json:"username"`
-	RunID       pgtype.Text `json:"run_id"`
-	WorkspaceID pgtype.Text `json:"workspace_id"`
+	Username    pgtype.Text        `json:"username"`
+	RunID       pgtype.Text        `json:"run_id"`
+	AcquiredAt  pgtype.Timestamptz `json:"acquired_at"`
+	LockInfo    []byte             `json:"lock_info"`
+	WorkspaceID pgtype.Text        `json:"workspace_id"`
 }
 
 // UpdateWorkspaceLockByID implements Querier.UpdateWorkspaceLockByID.
 func (q *DBQuerier) UpdateWorkspaceLockByID(ctx context.Context, params UpdateWorkspaceLockByIDParams) (pgconn.CommandTag, error) {
 	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateWorkspaceLockByID")
-	cmdTag, err := q.conn.Exec(ctx, updateWorkspaceLockByIDSQL, params.Username, params.RunID, params.WorkspaceID)
+	cmdTag, err := q.conn.Exec(ctx, updateWorkspaceLockByIDSQL, params.Username, params.RunID, params.AcquiredAt, params.LockInfo, params.WorkspaceID)
 	if err != nil {
 		return pgconn.CommandTag{}, fmt.Errorf("exec query UpdateWorkspaceLockByID: %w", err)
 	}
 	return cmdTag, err
 }
 
+const updateWorkspacePausedAtSQL = `UPDATE workspaces
+SET paused_at = $1
+WHERE workspace_id = $2;`
+
+// UpdateWorkspacePausedAt implements Querier.UpdateWorkspacePausedAt.
+func (q *DBQuerier) UpdateWorkspacePausedAt(ctx context.Context, pausedAt pgtype.Timestamptz, workspaceID pgtype.Text) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateWorkspacePausedAt")
+	cmdTag, err := q.conn.Exec(ctx, updateWorkspacePausedAtSQL, pausedAt, workspaceID)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query UpdateWorkspacePausedAt: %w", err)
+	}
+	return cmdTag, err
+}
+
+const updateWorkspaceDebugLoggingSQL = `UPDATE workspaces
+SET debug_logging_expires_at = $1,
+    debug_logging_runs_remaining = $2
+WHERE workspace_id = $3;`
+
+type UpdateWorkspaceDebugLoggingParams struct {
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	RunsRemaining pgtype.Int4        `json:"runs_remaining"`
+	WorkspaceID   pgtype.Text        `json:"workspace_id"`
+}
+
+// UpdateWorkspaceDebugLogging implements Querier.UpdateWorkspaceDebugLogging.
+func (q *DBQuerier) UpdateWorkspaceDebugLogging(ctx context.Context, params UpdateWorkspaceDebugLoggingParams) (pgconn.CommandTag, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "UpdateWorkspaceDebugLogging")
+	cmdTag, err := q.conn.Exec(ctx, updateWorkspaceDebugLoggingSQL, params.ExpiresAt, params.RunsRemaining, params.WorkspaceID)
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("exec query UpdateWorkspaceDebugLogging: %w", err)
+	}
+	return cmdTag, err
+}
+
 const updateWorkspaceLatestRunSQL = `UPDATE workspaces
 SET latest_run_id = $1
 WHERE workspace_id = $2;`
@@ -1003,3 +1221,32 @@ func (q *DBQuerier) DeleteWorkspaceByID(ctx context.Context, workspaceID pgtype.
 	}
 	return cmdTag, err
 }
+
+const findWorkspaceNamesCaseInsensitiveSQL = `SELECT name
+FROM workspaces
+WHERE organization_name = $1
+AND   lower(name) = lower($2)
+AND   workspace_id <> $3;`
+
+type FindWorkspaceNamesCaseInsensitiveParams struct {
+	OrganizationName pgtype.Text `json:"organization_name"`
+	Name             pgtype.Text `json:"name"`
+	WorkspaceID      pgtype.Text `json:"workspace_id"`
+}
+
+// FindWorkspaceNamesCaseInsensitive implements Querier.FindWorkspaceNamesCaseInsensitive.
+func (q *DBQuerier) FindWorkspaceNamesCaseInsensitive(ctx context.Context, params FindWorkspaceNamesCaseInsensitiveParams) ([]pgtype.Text, error) {
+	ctx = context.WithValue(ctx, "pggen_query_name", "FindWorkspaceNamesCaseInsensitive")
+	rows, err := q.conn.Query(ctx, findWorkspaceNamesCaseInsensitiveSQL, params.OrganizationName, params.Name, params.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("query FindWorkspaceNamesCaseInsensitive: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (pgtype.Text, error) {
+		var item pgtype.Text
+		if err := row.Scan(&item); err != nil {
+			return item, fmt.Errorf("failed to scan: %w", err)
+		}
+		return item, nil
+	})
+}
@@ -8,7 +8,10 @@ INSERT INTO agents (
     last_ping_at,
     last_status_at,
     status,
-    agent_pool_id
+    agent_pool_id,
+    disk_capacity,
+    status_reason,
+    labels
 ) VALUES (
     pggen.arg('agent_id'),
     pggen.arg('name'),
@@ -18,14 +21,18 @@ INSERT INTO agents (
     pggen.arg('last_ping_at'),
     pggen.arg('last_status_at'),
     pggen.arg('status'),
-    pggen.arg('agent_pool_id')
+    pggen.arg('agent_pool_id'),
+    pggen.arg('disk_capacity'),
+    pggen.arg('status_reason'),
+    pggen.arg('labels')
 );
 
 -- name: UpdateAgent :one
 UPDATE agents
 SET status = pggen.arg('status'),
     last_ping_at = pggen.arg('last_ping_at'),
-    last_status_at = pggen.arg('last_status_at')
+    last_status_at = pggen.arg('last_status_at'),
+    status_reason = pggen.arg('status_reason')
 WHERE agent_id = pggen.arg('agent_id')
 RETURNING *;
 
@@ -52,6 +59,7 @@ SELECT
 FROM agents a
 JOIN agent_pools ap USING (agent_pool_id)
 WHERE ap.organization_name = pggen.arg('organization_name')
+AND   ap.deleted_at IS NULL
 GROUP BY a.agent_id
 ORDER BY last_ping_at DESC;
 
@@ -0,0 +1,85 @@
+-- name: InsertAgentBootstrapToken :exec
+INSERT INTO agent_bootstrap_tokens (
+    agent_bootstrap_token_id,
+    created_at,
+    description,
+    agent_pool_id,
+    expires_at,
+    max_uses
+) VALUES (
+    pggen.arg('agent_bootstrap_token_id'),
+    pggen.arg('created_at'),
+    pggen.arg('description'),
+    pggen.arg('agent_pool_id'),
+    pggen.arg('expires_at'),
+    pggen.arg('max_uses')
+);
+
+-- name: FindAgentBootstrapTokenByID :one
+SELECT *
+FROM agent_bootstrap_tokens
+WHERE agent_bootstrap_token_id = pggen.arg('agent_bootstrap_token_id')
+;
+
+-- name: FindAgentBootstrapTokensByAgentPoolID :many
+SELECT *
+FROM agent_bootstrap_tokens
+WHERE agent_pool_id = pggen.arg('agent_pool_id')
+ORDER BY created_at DESC
+;
+
+-- UseAgentBootstrapToken records a use of the token, returning no rows if the
+-- token has expired or has been used the maximum number of times.
+--
+-- name: UseAgentBootstrapToken :one
+UPDATE agent_bootstrap_tokens
+SET uses = uses + 1
+WHERE agent_bootstrap_token_id = pggen.arg('agent_bootstrap_token_id')
+AND   expires_at > pggen.arg('now')
+AND   (max_uses IS NULL OR uses < max_uses)
+RETURNING agent_bootstrap_token_id
+;
+
+-- name: DeleteAgentBootstrapTokenByID :one
+DELETE
+FROM agent_bootstrap_tokens
+WHERE agent_bootstrap_token_id = pggen.arg('agent_bootstrap_token_id')
+RETURNING agent_bootstrap_token_id
+;
+
+-- name: InsertAgentCredential :exec
+INSERT INTO agent_credentials (
+    agent_id,
+    agent_bootstrap_token_id,
+    token_hash,
+    created_at
+) VALUES (
+    pggen.arg('agent_id'),
+    pggen.arg('agent_bootstrap_token_id'),
+    pggen.arg('token_hash'),
+    pggen.arg('created_at')
+);
+
+-- name: FindAgentCredentialByAgentID :one
+SELECT *
+FROM agent_credentials
+WHERE agent_id = pggen.arg('agent_id')
+;
+
+-- FindAgentCredentialAgentIDsByOrganization lists the IDs of the agents in an
+-- organization that have been issued their own token.
+--
+-- name: FindAgentCredentialAgentIDsByOrganization :many
+SELECT ac.agent_id
+FROM agent_credentials ac
+JOIN agents a USING (agent_id)
+JOIN agent_pools ap USING (agent_pool_id)
+WHERE ap.organization_name = pggen.arg('organization_name')
+;
+
+-- name: DeleteAgentCredentialByAgentID :one
+DELETE
+FROM agent_credentials
+WHERE agent_id = pggen.arg('agent_id')
+RETURNING agent_id
+;
@@ -0,0 +1,89 @@
+-- name: InsertAgentDiagnosticsBundle :exec
+INSERT INTO agent_diagnostics_bundles (
+    agent_diagnostics_bundle_id,
+    agent_id,
+    requested_by,
+    requested_at
+) VALUES (
+    pggen.arg('agent_diagnostics_bundle_id'),
+    pggen.arg('agent_id'),
+    pggen.arg('requested_by'),
+    pggen.arg('requested_at')
+);
+
+-- ClaimAgentDiagnosticsBundle marks the agent's undelivered request for a
+-- diagnostics bundle as delivered, returning its ID.
+--
+-- name: ClaimAgentDiagnosticsBundle :many
+UPDATE agent_diagnostics_bundles
+SET delivered_at = pggen.arg('delivered_at')
+WHERE agent_id = pggen.arg('agent_id')
+AND delivered_at IS NULL
+RETURNING agent_diagnostics_bundle_id;
+
+-- UploadAgentDiagnosticsBundle stores the bundle collected by an agent, or the
+-- reason it could not be collected, for a request delivered to the agent.
+--
+-- name: UploadAgentDiagnosticsBundle :one
+UPDATE agent_diagnostics_bundles
+SET collected_at = pggen.arg('collected_at'),
+    error = pggen.arg('error'),
+    bundle = pggen.arg('bundle')
+WHERE agent_diagnostics_bundle_id = pggen.arg('agent_diagnostics_bundle_id')
+AND agent_id = pggen.arg('agent_id')
+AND delivered_at IS NOT NULL
+AND collected_at IS NULL
+RETURNING agent_diagnostics_bundle_id;
+
+-- TrimAgentDiagnosticsBundles deletes all but the most recent limit
+-- diagnostics bundles for an agent.
+--
+-- name: TrimAgentDiagnosticsBundles :exec
+DELETE
+FROM agent_diagnostics_bundles
+WHERE agent_id = pggen.arg('agent_id')
+AND agent_diagnostics_bundle_id NOT IN (
+    SELECT agent_diagnostics_bundle_id
+    FROM agent_diagnostics_bundles
+    WHERE agent_id = pggen.arg('agent_id')
+    ORDER BY requested_at DESC
+    LIMIT pggen.arg('limit')
+);
+
+-- FindAgentDiagnosticsBundlesByOrganization lists the diagnostics bundles of
+-- the agents in an organization, without their contents.
+--
+-- name: FindAgentDiagnosticsBundlesByOrganization :many
+SELECT
+    d.agent_diagnostics_bundle_id,
+    d.agent_id,
+    d.requested_by,
+    d.requested_at,
+    d.delivered_at,
+    d.collected_at,
+    d.error,
+    COALESCE(octet_length(d.bundle), 0)::int AS size
+FROM agent_diagnostics_bundles d
+JOIN agents a USING (agent_id)
+JOIN agent_pools ap USING (agent_pool_id)
+WHERE ap.organization_name = pggen.arg('organization_name')
+ORDER BY d.requested_at DESC
+;
+
+-- name: FindAgentDiagnosticsBundleByID :one
+SELECT
+    d.agent_diagnostics_bundle_id,
+    d.agent_id,
+    d.requested_by,
+    d.requested_at,
+    d.delivered_at,
+    d.collected_at,
+    d.error,
+    COALESCE(octet_length(d.bundle), 0)::int AS size,
+    d.bundle,
+    ap.organization_name
+FROM agent_diagnostics_bundles d
+JOIN agents a USING (agent_id)
+JOIN agent_pools ap USING (agent_pool_id)
+WHERE d.agent_diagnostics_bundle_id = pggen.arg('agent_diagnostics_bundle_id')
+;
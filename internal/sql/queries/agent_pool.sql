@@ -4,21 +4,42 @@ INSERT INTO agent_pools (
     name,
     created_at,
     organization_name,
-    organization_scoped
+    organization_scoped,
+    allowed_terraform_versions,
+    min_agents
 ) VALUES (
     pggen.arg('agent_pool_id'),
     pggen.arg('name'),
     pggen.arg('created_at'),
     pggen.arg('organization_name'),
-    pggen.arg('organization_scoped')
+    pggen.arg('organization_scoped'),
+    pggen.arg('allowed_terraform_versions'),
+    pggen.arg('min_agents')
 );
 
+-- Find the number of undeleted pools in an organization along with the
+-- maximum number of pools permitted in the organization, which is null if the
+-- organization uses the default maximum.
+--
+-- name: FindAgentPoolQuota :one
+SELECT
+    (
+        SELECT count(*)
+        FROM agent_pools ap
+        WHERE ap.organization_name = o.name
+        AND   ap.deleted_at IS NULL
+    ) AS pools,
+    o.max_agent_pools
+FROM organizations o
+WHERE o.name = pggen.arg('organization_name')
+;
+
 -- name: FindAgentPools :many
 SELECT ap.*,
     (
         SELECT array_agg(w.workspace_id)
         FROM workspaces w
-        WHERE w.agent_pool_id = ap.agent_pool_id
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
     ) AS workspace_ids,
     (
         SELECT array_agg(aw.workspace_id)
@@ -26,6 +47,7 @@ SELECT ap.*,
         WHERE aw.agent_pool_id = ap.agent_pool_id
     ) AS allowed_workspace_ids
 FROM agent_pools ap
+WHERE ap.deleted_at IS NULL
 ORDER BY ap.created_at DESC
 ;
 
@@ -39,7 +61,7 @@ SELECT ap.*,
     (
         SELECT array_agg(w.workspace_id)
         FROM workspaces w
-        WHERE w.agent_pool_id = ap.agent_pool_id
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
     ) AS workspace_ids,
     (
         SELECT array_agg(aw.workspace_id)
@@ -49,6 +71,7 @@ SELECT ap.*,
 FROM agent_pools ap
 LEFT JOIN (agent_pool_allowed_workspaces aw JOIN workspaces w USING (workspace_id)) ON ap.agent_pool_id = aw.agent_pool_id
 WHERE ap.organization_name = pggen.arg('organization_name')
+AND   ap.deleted_at IS NULL
 AND   ((pggen.arg('name_substring')::text IS NULL) OR ap.name LIKE '%' || pggen.arg('name_substring') || '%')
 AND   ((pggen.arg('allowed_workspace_name')::text IS NULL) OR
        ap.organization_scoped OR
@@ -67,7 +90,7 @@ SELECT ap.*,
     (
         SELECT array_agg(w.workspace_id)
         FROM workspaces w
-        WHERE w.agent_pool_id = ap.agent_pool_id
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
     ) AS workspace_ids,
     (
         SELECT array_agg(aw.workspace_id)
@@ -79,12 +102,30 @@ WHERE ap.agent_pool_id = pggen.arg('pool_id')
 GROUP BY ap.agent_pool_id
 ;
 
+-- name: FindAgentPoolsByIDs :many
+SELECT ap.*,
+    (
+        SELECT array_agg(w.workspace_id)
+        FROM workspaces w
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(aw.workspace_id)
+        FROM agent_pool_allowed_workspaces aw
+        WHERE aw.agent_pool_id = ap.agent_pool_id
+    ) AS allowed_workspace_ids
+FROM agent_pools ap
+WHERE ap.agent_pool_id = ANY(pggen.arg('pool_ids'))
+AND   ap.deleted_at IS NULL
+GROUP BY ap.agent_pool_id
+;
+
 -- name: FindAgentPoolByAgentTokenID :one
 SELECT ap.*,
     (
         SELECT array_agg(w.workspace_id)
         FROM workspaces w
-        WHERE w.agent_pool_id = ap.agent_pool_id
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
     ) AS workspace_ids,
     (
         SELECT array_agg(aw.workspace_id)
@@ -94,16 +135,52 @@ SELECT ap.*,
 FROM agent_pools ap
 JOIN agent_tokens at USING (agent_pool_id)
 WHERE at.agent_token_id = pggen.arg('agent_token_id')
+AND   ap.deleted_at IS NULL
 GROUP BY ap.agent_pool_id
 ;
 
 -- name: UpdateAgentPool :one
 UPDATE agent_pools
 SET name = pggen.arg('name'),
-    organization_scoped = pggen.arg('organization_scoped')
+    organization_scoped = pggen.arg('organization_scoped'),
+    allowed_terraform_versions = pggen.arg('allowed_terraform_versions'),
+    min_agents = pggen.arg('min_agents')
 WHERE agent_pool_id = pggen.arg('pool_id')
 RETURNING *;
 
+-- name: FindDeletedAgentPoolsByOrganization :many
+SELECT ap.*,
+    (
+        SELECT array_agg(w.workspace_id)
+        FROM workspaces w
+        WHERE ap.agent_pool_id IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
+    ) AS workspace_ids,
+    (
+        SELECT array_agg(aw.workspace_id)
+        FROM agent_pool_allowed_workspaces aw
+        WHERE aw.agent_pool_id = ap.agent_pool_id
+    ) AS allowed_workspace_ids
+FROM agent_pools ap
+WHERE ap.organization_name = pggen.arg('organization_name')
+AND   ap.deleted_at IS NOT NULL
+ORDER BY ap.deleted_at DESC
+;
+
+-- Find the IDs of pools deleted before the given time, i.e. those whose
+-- recovery window has elapsed.
+--
+-- name: FindAgentPoolIDsDeletedBefore :many
+SELECT agent_pool_id
+FROM agent_pools
+WHERE deleted_at < pggen.arg('deleted_before')
+;
+
+-- name: UpdateAgentPoolDeletedAt :exec
+UPDATE agent_pools
+SET deleted_at = pggen.arg('deleted_at')
+WHERE agent_pool_id = pggen.arg('pool_id')
+;
+
 -- name: DeleteAgentPool :one
 DELETE
 FROM agent_pools
@@ -111,18 +188,30 @@ WHERE agent_pool_id = pggen.arg('pool_id')
 RETURNING *
 ;
 
--- name: InsertAgentPoolAllowedWorkspace :exec
+-- name: InsertAgentPoolAllowedWorkspaces :exec
 INSERT INTO agent_pool_allowed_workspaces (
     agent_pool_id,
     workspace_id
-) VALUES (
-    pggen.arg('pool_id'),
-    pggen.arg('workspace_id')
-);
+)
+SELECT pggen.arg('pool_id'), workspace_id
+FROM unnest(pggen.arg('workspace_ids')::text[]) t(workspace_id)
+ON CONFLICT (agent_pool_id, workspace_id) DO NOTHING
+;
 
--- name: DeleteAgentPoolAllowedWorkspace :exec
+-- name: DeleteAgentPoolAllowedWorkspaces :exec
 DELETE
 FROM agent_pool_allowed_workspaces
 WHERE agent_pool_id = pggen.arg('pool_id')
-AND workspace_id = pggen.arg('workspace_id')
+AND workspace_id = ANY(pggen.arg('workspace_ids')::text[])
+;
+
+-- Find workspaces assigned to a pool that are not among the given workspaces
+-- allowed to use the pool, i.e. those that would lose access to the pool.
+--
+-- name: FindAgentPoolAssignedWorkspacesNotAllowed :many
+SELECT w.workspace_id, w.name
+FROM workspaces w
+WHERE pggen.arg('pool_id') IN (w.agent_pool_id, w.plan_agent_pool_id, w.apply_agent_pool_id)
+AND   w.workspace_id <> ALL(COALESCE(pggen.arg('allowed_workspace_ids')::text[], '{}'))
+ORDER BY w.name
 ;
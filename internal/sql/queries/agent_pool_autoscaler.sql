@@ -0,0 +1,98 @@
+-- name: UpsertAgentPoolAutoscaler :exec
+INSERT INTO agent_pool_autoscalers (
+    agent_pool_id,
+    webhook_url,
+    hmac_secret,
+    scale_up_threshold,
+    scale_down_idle,
+    cooldown,
+    dry_run,
+    created_at,
+    updated_at
+) VALUES (
+    pggen.arg('agent_pool_id'),
+    pggen.arg('webhook_url'),
+    pggen.arg('hmac_secret'),
+    pggen.arg('scale_up_threshold'),
+    pggen.arg('scale_down_idle'),
+    pggen.arg('cooldown'),
+    pggen.arg('dry_run'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at')
+) ON CONFLICT (agent_pool_id) DO UPDATE
+SET webhook_url        = EXCLUDED.webhook_url,
+    hmac_secret        = EXCLUDED.hmac_secret,
+    scale_up_threshold = EXCLUDED.scale_up_threshold,
+    scale_down_idle    = EXCLUDED.scale_down_idle,
+    cooldown           = EXCLUDED.cooldown,
+    dry_run            = EXCLUDED.dry_run,
+    updated_at         = EXCLUDED.updated_at;
+
+-- FindAgentPoolAutoscalers finds all autoscalers, along with the time of the
+-- most recent successful scaling decision for each pool.
+--
+-- name: FindAgentPoolAutoscalers :many
+SELECT a.*, ap.name AS agent_pool_name, ap.organization_name,
+    (
+        SELECT max(d.decided_at)
+        FROM agent_pool_scaling_decisions d
+        WHERE d.agent_pool_id = a.agent_pool_id
+        AND d.error IS NULL
+    )::timestamptz AS last_decided_at
+FROM agent_pool_autoscalers a
+JOIN agent_pools ap USING (agent_pool_id)
+WHERE ap.deleted_at IS NULL
+ORDER BY a.agent_pool_id;
+
+-- name: FindAgentPoolAutoscaler :one
+SELECT *
+FROM agent_pool_autoscalers
+WHERE agent_pool_id = pggen.arg('agent_pool_id');
+
+-- name: DeleteAgentPoolAutoscaler :one
+DELETE
+FROM agent_pool_autoscalers
+WHERE agent_pool_id = pggen.arg('agent_pool_id')
+RETURNING agent_pool_id;
+
+-- name: InsertAgentPoolScalingDecision :exec
+INSERT INTO agent_pool_scaling_decisions (
+    agent_pool_id,
+    current_agents,
+    unallocated_jobs,
+    idle_agents,
+    desired_delta,
+    dry_run,
+    error,
+    decided_at
+) VALUES (
+    pggen.arg('agent_pool_id'),
+    pggen.arg('current_agents'),
+    pggen.arg('unallocated_jobs'),
+    pggen.arg('idle_agents'),
+    pggen.arg('desired_delta'),
+    pggen.arg('dry_run'),
+    pggen.arg('error'),
+    pggen.arg('decided_at')
+);
+
+-- TrimAgentPoolScalingDecisions deletes all but the most recent limit scaling
+-- decisions for a pool.
+--
+-- name: TrimAgentPoolScalingDecisions :exec
+DELETE
+FROM agent_pool_scaling_decisions
+WHERE agent_pool_id = pggen.arg('agent_pool_id')
+AND scaling_decision_id NOT IN (
+    SELECT scaling_decision_id
+    FROM agent_pool_scaling_decisions
+    WHERE agent_pool_id = pggen.arg('agent_pool_id')
+    ORDER BY scaling_decision_id DESC
+    LIMIT pggen.arg('limit')
+);
+
+-- name: FindAgentPoolScalingDecisions :many
+SELECT *
+FROM agent_pool_scaling_decisions
+WHERE agent_pool_id = pggen.arg('agent_pool_id')
+ORDER BY scaling_decision_id DESC;
@@ -0,0 +1,53 @@
+-- name: InsertAgentStatusHistory :exec
+INSERT INTO agent_status_history (
+    agent_id,
+    status,
+    changed_at,
+    reason
+) VALUES (
+    pggen.arg('agent_id'),
+    pggen.arg('status'),
+    pggen.arg('changed_at'),
+    pggen.arg('reason')
+);
+
+-- TrimAgentStatusHistory deletes all but the most recent limit status changes
+-- for an agent.
+--
+-- name: TrimAgentStatusHistory :exec
+DELETE
+FROM agent_status_history
+WHERE agent_id = pggen.arg('agent_id')
+AND agent_status_history_id NOT IN (
+    SELECT agent_status_history_id
+    FROM agent_status_history
+    WHERE agent_id = pggen.arg('agent_id')
+    ORDER BY agent_status_history_id DESC
+    LIMIT pggen.arg('limit')
+);
+
+-- DeleteAgentStatusHistoryBefore deletes up to limit status changes made
+-- before the given time, sparing the most recent change of each agent, which
+-- records its current status.
+--
+-- name: DeleteAgentStatusHistoryBefore :exec
+DELETE
+FROM agent_status_history
+WHERE agent_status_history_id IN (
+    SELECT h.agent_status_history_id
+    FROM agent_status_history h
+    WHERE h.changed_at < pggen.arg('changed_before')
+    AND EXISTS (
+        SELECT FROM agent_status_history later
+        WHERE later.agent_id = h.agent_id
+        AND later.agent_status_history_id > h.agent_status_history_id
+    )
+    ORDER BY h.agent_status_history_id
+    LIMIT pggen.arg('limit')
+);
+
+-- name: FindAgentStatusHistory :many
+SELECT agent_status_history_id, status, changed_at, reason
+FROM agent_status_history
+WHERE agent_id = pggen.arg('agent_id')
+ORDER BY agent_status_history_id ASC;
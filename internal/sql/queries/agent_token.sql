@@ -3,12 +3,14 @@ INSERT INTO agent_tokens (
     agent_token_id,
     created_at,
     description,
-    agent_pool_id
+    agent_pool_id,
+    expires_at
 ) VALUES (
     pggen.arg('agent_token_id'),
     pggen.arg('created_at'),
     pggen.arg('description'),
-    pggen.arg('agent_pool_id')
+    pggen.arg('agent_pool_id'),
+    pggen.arg('expires_at')
 );
 
 -- name: FindAgentTokenByID :one
@@ -24,6 +26,26 @@ WHERE agent_pool_id = pggen.arg('agent_pool_id')
 ORDER BY created_at DESC
 ;
 
+-- name: FindExpiringAgentTokensByOrganization :many
+SELECT
+    at.*,
+    ap.name AS agent_pool_name
+FROM agent_tokens at
+JOIN agent_pools ap USING (agent_pool_id)
+WHERE ap.organization_name = pggen.arg('organization_name')
+AND   ap.deleted_at IS NULL
+AND   at.expires_at IS NOT NULL
+AND   at.expires_at <= pggen.arg('expires_before')
+ORDER BY at.expires_at ASC
+;
+
+-- name: UpdateAgentTokenLastUsedAt :exec
+UPDATE agent_tokens
+SET last_used_at = pggen.arg('last_used_at')
+WHERE agent_token_id = pggen.arg('agent_token_id')
+AND   (last_used_at IS NULL OR last_used_at < pggen.arg('last_used_at')::timestamptz - interval '1 minute')
+;
+
 -- name: DeleteAgentTokenByID :one
 DELETE
 FROM agent_tokens
@@ -0,0 +1,17 @@
+-- name: UpsertAllocatorStatus :exec
+INSERT INTO allocator_status (
+    allocator_status_id,
+    last_allocated_at,
+    status
+) VALUES (
+    'allocator',
+    pggen.arg('last_allocated_at'),
+    pggen.arg('status')
+)
+ON CONFLICT (allocator_status_id) DO UPDATE
+SET last_allocated_at = EXCLUDED.last_allocated_at,
+    status            = EXCLUDED.status;
+
+-- name: FindAllocatorStatus :one
+SELECT *
+FROM allocator_status;
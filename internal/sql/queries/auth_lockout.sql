@@ -0,0 +1,37 @@
+-- name: UpsertAuthLockout :exec
+INSERT INTO auth_lockouts (
+    lockout_key,
+    kind,
+    value,
+    failures,
+    created_at,
+    locked_until
+) VALUES (
+    pggen.arg('lockout_key'),
+    pggen.arg('kind'),
+    pggen.arg('value'),
+    pggen.arg('failures'),
+    pggen.arg('created_at'),
+    pggen.arg('locked_until')
+)
+ON CONFLICT (lockout_key) DO UPDATE
+SET failures     = EXCLUDED.failures,
+    created_at   = EXCLUDED.created_at,
+    locked_until = EXCLUDED.locked_until;
+
+-- name: FindActiveAuthLockouts :many
+SELECT *
+FROM auth_lockouts
+WHERE locked_until > pggen.arg('now')
+ORDER BY created_at;
+
+-- name: FindAuthLockout :one
+SELECT *
+FROM auth_lockouts
+WHERE lockout_key = pggen.arg('lockout_key');
+
+-- name: DeleteAuthLockout :one
+DELETE
+FROM auth_lockouts
+WHERE lockout_key = pggen.arg('lockout_key')
+RETURNING lockout_key;
@@ -0,0 +1,66 @@
+-- name: InsertEventSinkOutbox :exec
+INSERT INTO event_sink_outbox (
+    sink,
+    payload,
+    created_at
+) VALUES (
+    pggen.arg('sink'),
+    pggen.arg('payload'),
+    pggen.arg('created_at')
+);
+
+-- FindEventSinkOutbox finds the oldest events awaiting delivery to a sink, up
+-- to a limit.
+--
+-- name: FindEventSinkOutbox :many
+SELECT event_sink_outbox_id, payload, created_at
+FROM event_sink_outbox
+WHERE sink = pggen.arg('sink')
+ORDER BY event_sink_outbox_id
+LIMIT pggen.arg('limit');
+
+-- DeleteEventSinkOutbox deletes the events awaiting delivery to a sink up to
+-- and including the event with the given ID.
+--
+-- name: DeleteEventSinkOutbox :exec
+DELETE
+FROM event_sink_outbox
+WHERE sink = pggen.arg('sink')
+AND event_sink_outbox_id <= pggen.arg('event_sink_outbox_id');
+
+-- name: InsertEventSink :exec
+INSERT INTO event_sinks (name)
+VALUES (pggen.arg('name'))
+ON CONFLICT DO NOTHING;
+
+-- name: UpdateEventSinkDelivered :exec
+UPDATE event_sinks
+SET delivered = delivered + pggen.arg('delivered'),
+    last_delivered_at = pggen.arg('last_delivered_at')
+WHERE name = pggen.arg('name');
+
+-- name: UpdateEventSinkFailed :exec
+UPDATE event_sinks
+SET failures = failures + 1,
+    last_failed_at = pggen.arg('last_failed_at'),
+    last_error = pggen.arg('last_error')
+WHERE name = pggen.arg('name');
+
+-- FindEventSinks finds the status of each event sink, including the number of
+-- events awaiting delivery and the time at which the oldest was created.
+--
+-- name: FindEventSinks :many
+SELECT
+    s.*,
+    (
+        SELECT count(*)
+        FROM event_sink_outbox o
+        WHERE o.sink = s.name
+    ) AS pending,
+    (
+        SELECT min(o.created_at)
+        FROM event_sink_outbox o
+        WHERE o.sink = s.name
+    ) AS oldest_pending_at
+FROM event_sinks s
+ORDER BY s.name;
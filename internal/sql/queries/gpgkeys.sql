@@ -36,4 +36,10 @@ WHERE organization_name = ANY(pggen.arg('organization_names')::TEXT[]);
 SELECT *
 FROM registry_gpg_keys
 WHERE key_id = pggen.arg('key_id') AND 
-    organization_name = pggen.arg('organization_name');
\ No newline at end of file
+    organization_name = pggen.arg('organization_name');
+-- name: RevokeGPGKey :exec
+UPDATE registry_gpg_keys
+SET revoked_at = pggen.arg('revoked_at'),
+    updated_at = pggen.arg('revoked_at')
+WHERE key_id = pggen.arg('key_id') AND
+    organization_name = pggen.arg('organization_name');
@@ -0,0 +1,48 @@
+-- ClaimIdempotencyKey inserts a key, or replaces a key that has expired,
+-- returning no rows if an unexpired key already exists.
+--
+-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    subject,
+    idempotency_key,
+    request_hash,
+    created_at
+) VALUES (
+    pggen.arg('subject'),
+    pggen.arg('idempotency_key'),
+    pggen.arg('request_hash'),
+    pggen.arg('created_at')
+)
+ON CONFLICT (subject, idempotency_key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    resource_id  = NULL,
+    created_at   = EXCLUDED.created_at
+WHERE idempotency_keys.created_at < pggen.arg('expired_before')
+RETURNING *;
+
+-- name: FindIdempotencyKey :one
+SELECT *
+FROM idempotency_keys
+WHERE subject = pggen.arg('subject')
+AND   idempotency_key = pggen.arg('idempotency_key');
+
+-- name: UpdateIdempotencyKeyResource :exec
+UPDATE idempotency_keys
+SET resource_id = pggen.arg('resource_id')
+WHERE subject = pggen.arg('subject')
+AND   idempotency_key = pggen.arg('idempotency_key');
+
+-- ReleaseIdempotencyKey deletes a key that was claimed but with which no
+-- resource was created.
+--
+-- name: ReleaseIdempotencyKey :exec
+DELETE
+FROM idempotency_keys
+WHERE subject = pggen.arg('subject')
+AND   idempotency_key = pggen.arg('idempotency_key')
+AND   resource_id IS NULL;
+
+-- name: DeleteIdempotencyKeysCreatedBefore :exec
+DELETE
+FROM idempotency_keys
+WHERE created_at < pggen.arg('created_before');
@@ -1,12 +1,23 @@
+-- Insert job, assigning it to the agent pool its workspace is currently
+-- configured to use for the job's phase.
+--
 -- name: InsertJob :exec
 INSERT INTO jobs (
     run_id,
     phase,
-    status
+    status,
+    agent_pool_id,
+    created_at,
+    trace_context,
+    labels
 ) VALUES (
     pggen.arg('run_id'),
     pggen.arg('phase'),
-    pggen.arg('status')
+    pggen.arg('status'),
+    pggen.arg('agent_pool_id'),
+    pggen.arg('created_at'),
+    pggen.arg('trace_context'),
+    pggen.arg('labels')
 );
 
 -- name: FindJobs :many
@@ -16,9 +27,16 @@ SELECT
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
 FROM jobs j
 JOIN runs r USING (run_id)
 JOIN workspaces w USING (workspace_id)
@@ -31,9 +49,16 @@ SELECT
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
 FROM jobs j
 JOIN runs r USING (run_id)
 JOIN workspaces w USING (workspace_id)
@@ -48,9 +73,16 @@ SELECT
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
 FROM jobs j
 JOIN runs r USING (run_id)
 JOIN workspaces w USING (workspace_id)
@@ -66,16 +98,105 @@ SELECT
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
 FROM jobs j
 JOIN runs r USING (run_id)
 JOIN workspaces w USING (workspace_id)
 WHERE j.agent_id = pggen.arg('agent_id')
 AND   j.status = 'allocated';
 
--- Find signaled jobs and then immediately update signal with null.
+-- name: FindActiveJobsByAgentID :many
+SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE j.agent_id = pggen.arg('agent_id')
+AND   j.status IN ('allocated', 'running');
+
+-- name: FindQueuedJobsByAgentPoolID :many
+SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE j.agent_pool_id = pggen.arg('agent_pool_id')
+AND   j.status IN ('unallocated', 'allocated');
+
+-- Find the next unallocated job for an agent pool, or for the server agents
+-- if the pool ID is null, optionally with all of the given labels, and lock it
+-- for update, skipping jobs already locked by another transaction. Jobs of high
+-- priority runs, and jobs created before promote_before, are found first, and
+-- then the oldest job.
+--
+-- name: FindNextUnallocatedJobForUpdate :one
+SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE j.status = 'unallocated'
+AND   j.agent_pool_id IS NOT DISTINCT FROM pggen.arg('agent_pool_id')
+AND   (pggen.arg('labels')::jsonb IS NULL OR j.labels @> pggen.arg('labels'))
+ORDER BY (r.priority = 'high' OR j.created_at < pggen.arg('promote_before')) DESC, j.created_at
+LIMIT 1
+FOR UPDATE OF j SKIP LOCKED
+;
+
+-- Find signaled jobs and then immediately update signal with null. A job that
+-- has been force canceled is already canceled but its signal is yet to be
+-- delivered.
 --
 -- name: FindAndUpdateSignaledJobs :many
 UPDATE jobs AS j
@@ -84,7 +205,7 @@ FROM runs r, workspaces w
 WHERE j.run_id = r.run_id
 AND   r.workspace_id = w.workspace_id
 AND   j.agent_id = pggen.arg('agent_id')
-AND   j.status = 'running'
+AND   j.status IN ('running', 'canceled')
 AND   j.signaled IS NOT NULL
 RETURNING
     j.run_id,
@@ -92,16 +213,120 @@ RETURNING
     j.status,
     j.signaled,
     j.agent_id,
-    w.agent_pool_id,
+    j.agent_pool_id,
     r.workspace_id,
-    w.organization_name
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+;
+
+-- Mark unallocated jobs that have waited longer than their organization's
+-- queue time SLA as having breached the SLA, returning the newly breached
+-- jobs.
+--
+-- name: UpdateQueueSLABreachedJobs :many
+UPDATE jobs AS j
+SET sla_breached_at = pggen.arg('now')
+FROM runs r, workspaces w, organizations o
+WHERE j.run_id = r.run_id
+AND   r.workspace_id = w.workspace_id
+AND   w.organization_name = o.name
+AND   j.status = 'unallocated'
+AND   j.sla_breached_at IS NULL
+AND   o.queue_time_sla > 0
+AND   j.created_at + make_interval(mins => o.queue_time_sla) < pggen.arg('now')
+RETURNING
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+;
+
+-- name: FindQueueSLABreachedJobsByOrganization :many
+SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.signaled,
+    j.agent_id,
+    j.agent_pool_id,
+    r.workspace_id,
+    w.organization_name,
+    r.terraform_version,
+    j.created_at,
+    j.allocated_at,
+    j.sla_breached_at,
+    j.trace_context,
+    j.labels,
+    r.priority
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   j.sla_breached_at IS NOT NULL
+ORDER BY j.sla_breached_at DESC
 ;
 
 -- name: UpdateJob :one
 UPDATE jobs
 SET status   = pggen.arg('status'),
     signaled = pggen.arg('signaled'),
-    agent_id = pggen.arg('agent_id')
+    agent_id = pggen.arg('agent_id'),
+    agent_pool_id = pggen.arg('agent_pool_id'),
+    allocated_at = pggen.arg('allocated_at')
 WHERE run_id = pggen.arg('run_id')
 AND   phase = pggen.arg('phase')
 RETURNING *;
+
+-- Find jobs whose run no longer exists.
+--
+-- name: FindOrphanedJobs :many
+SELECT
+    j.run_id,
+    j.phase,
+    j.status,
+    j.agent_id
+FROM jobs j
+WHERE NOT EXISTS (SELECT FROM runs r WHERE r.run_id = j.run_id)
+;
+
+-- Delete a job, but only if its run no longer exists.
+--
+-- name: DeleteOrphanedJob :exec
+DELETE FROM jobs j
+WHERE j.run_id = pggen.arg('run_id')
+AND   j.phase = pggen.arg('phase')
+AND   NOT EXISTS (SELECT FROM runs r WHERE r.run_id = j.run_id)
+;
+
+-- Count jobs in an organization by status and agent pool, optionally only
+-- those jobs with all of the given labels.
+--
+-- name: CountJobsByOrganization :many
+SELECT
+    j.status,
+    j.agent_pool_id,
+    count(*)::int AS count
+FROM jobs j
+JOIN runs r USING (run_id)
+JOIN workspaces w USING (workspace_id)
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   (pggen.arg('labels')::jsonb IS NULL OR j.labels @> pggen.arg('labels'))
+GROUP BY j.status, j.agent_pool_id
+;
@@ -0,0 +1,60 @@
+-- InsertLogBuffer creates the buffer for a stream of logs for a run phase if
+-- it does not already exist. Logs for a phase always begin at offset zero.
+--
+-- name: InsertLogBuffer :exec
+INSERT INTO log_buffers (
+    run_id,
+    phase,
+    stream,
+    data,
+    input_offset,
+    output_offset
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('phase'),
+    pggen.arg('stream'),
+    ''::bytea,
+    0,
+    0
+)
+ON CONFLICT DO NOTHING;
+
+-- name: FindLogBufferForUpdate :one
+SELECT data, input_offset, output_offset, finished, truncated
+FROM log_buffers
+WHERE run_id = pggen.arg('run_id')
+AND   phase  = pggen.arg('phase')
+AND   stream = pggen.arg('stream')
+FOR UPDATE;
+
+-- name: UpdateLogBuffer :exec
+UPDATE log_buffers
+SET
+    data          = pggen.arg('data'),
+    input_offset  = pggen.arg('input_offset'),
+    output_offset = pggen.arg('output_offset'),
+    finished      = pggen.arg('finished'),
+    truncated     = pggen.arg('truncated')
+WHERE run_id = pggen.arg('run_id')
+AND   phase  = pggen.arg('phase')
+AND   stream = pggen.arg('stream');
+
+-- FindRunLogSize returns the total size of the persisted logs for all phases
+-- and streams of a run.
+--
+-- name: FindRunLogSize :one
+SELECT COALESCE(SUM(output_offset), 0)::INTEGER
+FROM log_buffers
+WHERE run_id = pggen.arg('run_id');
+
+-- name: FindRunDebugLogging :one
+SELECT debug_logging
+FROM runs
+WHERE run_id = pggen.arg('run_id');
+
+-- name: FindTruncatedLogPhases :many
+SELECT phase
+FROM log_buffers
+WHERE run_id = pggen.arg('run_id')
+AND   stream = ''
+AND   truncated;
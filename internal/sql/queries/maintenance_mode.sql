@@ -0,0 +1,52 @@
+-- name: UpsertMaintenanceMode :exec
+INSERT INTO maintenance_mode (
+    maintenance_mode_id,
+    enabled,
+    message,
+    starts_at,
+    ends_at,
+    updated_at,
+    updated_by
+) VALUES (
+    'site',
+    pggen.arg('enabled'),
+    pggen.arg('message'),
+    pggen.arg('starts_at'),
+    pggen.arg('ends_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('updated_by')
+)
+ON CONFLICT (maintenance_mode_id) DO UPDATE
+SET enabled    = EXCLUDED.enabled,
+    message    = EXCLUDED.message,
+    starts_at  = EXCLUDED.starts_at,
+    ends_at    = EXCLUDED.ends_at,
+    updated_at = EXCLUDED.updated_at,
+    updated_by = EXCLUDED.updated_by;
+
+-- name: FindMaintenanceMode :one
+SELECT *
+FROM maintenance_mode;
+
+-- name: InsertMaintenanceModeChange :exec
+INSERT INTO maintenance_mode_changes (
+    maintenance_mode_change_id,
+    changed_at,
+    changed_by,
+    old_mode,
+    new_mode
+) VALUES (
+    pggen.arg('maintenance_mode_change_id'),
+    pggen.arg('changed_at'),
+    pggen.arg('changed_by'),
+    pggen.arg('old_mode'),
+    pggen.arg('new_mode')
+);
+
+-- FindMaintenanceModeChanges finds the changes made to the maintenance mode,
+-- most recent first.
+--
+-- name: FindMaintenanceModeChanges :many
+SELECT *
+FROM maintenance_mode_changes
+ORDER BY changed_at DESC;
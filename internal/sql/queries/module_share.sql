@@ -0,0 +1,97 @@
+-- name: InsertModuleShare :exec
+INSERT INTO module_shares (
+    module_share_id,
+    created_at,
+    organization_name,
+    module_id,
+    shared_with
+) VALUES (
+    pggen.arg('module_share_id'),
+    pggen.arg('created_at'),
+    pggen.arg('organization_name'),
+    pggen.arg('module_id'),
+    pggen.arg('shared_with')
+);
+
+-- name: FindModuleSharesByOrganization :many
+SELECT *
+FROM module_shares
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY created_at ASC;
+
+-- FindModuleSharesByModuleID finds the shares of a module, including those
+-- sharing the whole of its organization's registry.
+--
+-- name: FindModuleSharesByModuleID :many
+SELECT s.*
+FROM module_shares s
+JOIN modules m USING (organization_name)
+WHERE m.module_id = pggen.arg('module_id')
+AND   (s.module_id IS NULL OR s.module_id = m.module_id)
+ORDER BY s.created_at ASC;
+
+-- name: FindModuleShareByID :one
+SELECT *
+FROM module_shares
+WHERE module_share_id = pggen.arg('module_share_id');
+
+-- FindSharedModules finds modules in other organizations with the given name
+-- and provider that are shared with an organization, along with the shares
+-- granting access, including revoked shares.
+--
+-- name: FindSharedModules :many
+SELECT
+    s.module_share_id,
+    s.created_at,
+    s.organization_name,
+    s.module_id,
+    s.shared_with,
+    s.revoked_at,
+    m.module_id AS shared_module_id
+FROM module_shares s
+JOIN modules m USING (organization_name)
+WHERE m.name = pggen.arg('name')
+AND   m.provider = pggen.arg('provider')
+AND   m.organization_name <> pggen.arg('shared_with')
+AND   (s.module_id IS NULL OR s.module_id = m.module_id)
+AND   (s.shared_with IS NULL OR s.shared_with = pggen.arg('shared_with'))
+ORDER BY s.created_at ASC;
+
+-- name: RevokeModuleShareByID :one
+UPDATE module_shares
+SET revoked_at = pggen.arg('revoked_at')
+WHERE module_share_id = pggen.arg('module_share_id')
+AND   revoked_at IS NULL
+RETURNING *;
+
+-- name: InsertModuleDownload :exec
+INSERT INTO module_downloads (
+    module_version_id,
+    organization_name,
+    downloaded_at
+) VALUES (
+    pggen.arg('module_version_id'),
+    pggen.arg('organization_name'),
+    pggen.arg('downloaded_at')
+);
+
+-- name: CountModuleDownloadsByModuleID :many
+SELECT
+    d.organization_name,
+    count(*) AS downloads
+FROM module_downloads d
+JOIN module_versions v USING (module_version_id)
+WHERE v.module_id = pggen.arg('module_id')
+GROUP BY d.organization_name
+ORDER BY d.organization_name ASC;
+
+-- FindModuleVersionIDsDownloadedBefore finds the versions of a module that an
+-- organization downloaded before the given time.
+--
+-- name: FindModuleVersionIDsDownloadedBefore :many
+SELECT DISTINCT d.module_version_id
+FROM module_downloads d
+JOIN module_versions v USING (module_version_id)
+WHERE v.module_id = pggen.arg('module_id')
+AND   d.organization_name = pggen.arg('organization_name')
+AND   d.downloaded_at < pggen.arg('downloaded_before');
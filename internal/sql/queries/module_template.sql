@@ -0,0 +1,158 @@
+-- name: InsertModuleTemplate :exec
+INSERT INTO module_templates (
+    module_template_id,
+    created_at,
+    updated_at,
+    module_id,
+    module_version_id,
+    variables,
+    outputs
+) VALUES (
+    pggen.arg('module_template_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('module_id'),
+    pggen.arg('module_version_id'),
+    pggen.arg('variables'),
+    pggen.arg('outputs')
+);
+
+-- name: UpdateModuleTemplate :exec
+UPDATE module_templates
+SET
+    updated_at        = pggen.arg('updated_at'),
+    module_version_id = pggen.arg('module_version_id'),
+    variables         = pggen.arg('variables'),
+    outputs           = pggen.arg('outputs')
+WHERE module_template_id = pggen.arg('module_template_id');
+
+-- name: FindModuleTemplatesByOrganization :many
+SELECT
+    t.module_template_id,
+    t.created_at,
+    t.updated_at,
+    t.module_id,
+    t.module_version_id,
+    t.variables,
+    t.outputs,
+    m.name AS module_name,
+    m.provider AS module_provider,
+    m.organization_name,
+    v.version
+FROM module_templates t
+JOIN modules m USING (module_id)
+JOIN module_versions v ON v.module_version_id = t.module_version_id
+WHERE m.organization_name = pggen.arg('organization_name')
+ORDER BY m.name, m.provider
+;
+
+-- name: FindModuleTemplateByID :one
+SELECT
+    t.module_template_id,
+    t.created_at,
+    t.updated_at,
+    t.module_id,
+    t.module_version_id,
+    t.variables,
+    t.outputs,
+    m.name AS module_name,
+    m.provider AS module_provider,
+    m.organization_name,
+    v.version
+FROM module_templates t
+JOIN modules m USING (module_id)
+JOIN module_versions v ON v.module_version_id = t.module_version_id
+WHERE t.module_template_id = pggen.arg('module_template_id')
+;
+
+-- name: FindModuleTemplateByModuleID :one
+SELECT
+    t.module_template_id,
+    t.created_at,
+    t.updated_at,
+    t.module_id,
+    t.module_version_id,
+    t.variables,
+    t.outputs,
+    m.name AS module_name,
+    m.provider AS module_provider,
+    m.organization_name,
+    v.version
+FROM module_templates t
+JOIN modules m USING (module_id)
+JOIN module_versions v ON v.module_version_id = t.module_version_id
+WHERE t.module_id = pggen.arg('module_id')
+;
+
+-- name: FindModuleTemplateForUpdate :one
+SELECT
+    t.module_template_id,
+    t.created_at,
+    t.updated_at,
+    t.module_id,
+    t.module_version_id,
+    t.variables,
+    t.outputs,
+    m.name AS module_name,
+    m.provider AS module_provider,
+    m.organization_name,
+    v.version
+FROM module_templates t
+JOIN modules m USING (module_id)
+JOIN module_versions v ON v.module_version_id = t.module_version_id
+WHERE t.module_template_id = pggen.arg('module_template_id')
+FOR UPDATE OF t
+;
+
+-- name: DeleteModuleTemplateByID :one
+DELETE
+FROM module_templates
+WHERE module_template_id = pggen.arg('module_template_id')
+RETURNING module_template_id
+;
+
+-- name: InsertModuleTemplateWorkspace :exec
+INSERT INTO module_template_workspaces (
+    workspace_id,
+    module_template_id,
+    version,
+    variables
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('module_template_id'),
+    pggen.arg('version'),
+    pggen.arg('variables')
+);
+
+-- name: UpdateModuleTemplateWorkspace :exec
+UPDATE module_template_workspaces
+SET
+    version   = pggen.arg('version'),
+    variables = pggen.arg('variables')
+WHERE workspace_id = pggen.arg('workspace_id');
+
+-- name: FindModuleTemplateWorkspacesByTemplateID :many
+SELECT
+    d.workspace_id,
+    d.module_template_id,
+    d.version,
+    d.variables,
+    w.name AS workspace_name
+FROM module_template_workspaces d
+JOIN workspaces w USING (workspace_id)
+WHERE d.module_template_id = pggen.arg('module_template_id')
+ORDER BY w.name
+;
+
+-- name: FindModuleTemplateWorkspaceForUpdate :one
+SELECT
+    d.workspace_id,
+    d.module_template_id,
+    d.version,
+    d.variables,
+    w.name AS workspace_name
+FROM module_template_workspaces d
+JOIN workspaces w USING (workspace_id)
+WHERE d.workspace_id = pggen.arg('workspace_id')
+FOR UPDATE OF d
+;
@@ -0,0 +1,49 @@
+-- name: InsertModuleVersionPolicy :exec
+INSERT INTO module_version_policies (
+    module_version_policy_id,
+    created_at,
+    updated_at,
+    organization_name,
+    module,
+    constraints,
+    enforcement
+) VALUES (
+    pggen.arg('module_version_policy_id'),
+    pggen.arg('created_at'),
+    pggen.arg('updated_at'),
+    pggen.arg('organization_name'),
+    pggen.arg('module'),
+    pggen.arg('constraints'),
+    pggen.arg('enforcement')
+);
+
+-- name: UpdateModuleVersionPolicy :exec
+UPDATE module_version_policies
+SET
+    updated_at  = pggen.arg('updated_at'),
+    constraints = pggen.arg('constraints'),
+    enforcement = pggen.arg('enforcement')
+WHERE module_version_policy_id = pggen.arg('module_version_policy_id');
+
+-- name: FindModuleVersionPoliciesByOrganization :many
+SELECT *
+FROM module_version_policies
+WHERE organization_name = pggen.arg('organization_name')
+ORDER BY module ASC;
+
+-- name: FindModuleVersionPolicyByID :one
+SELECT *
+FROM module_version_policies
+WHERE module_version_policy_id = pggen.arg('module_version_policy_id');
+
+-- name: FindModuleVersionPolicyForUpdate :one
+SELECT *
+FROM module_version_policies
+WHERE module_version_policy_id = pggen.arg('module_version_policy_id')
+FOR UPDATE;
+
+-- name: DeleteModuleVersionPolicyByID :one
+DELETE
+FROM module_version_policies
+WHERE module_version_policy_id = pggen.arg('module_version_policy_id')
+RETURNING module_version_policy_id;
@@ -9,7 +9,13 @@ INSERT INTO organizations (
     cost_estimation_enabled,
     session_remember,
     session_timeout,
-    allow_force_delete_workspaces
+    allow_force_delete_workspaces,
+    default_deletion_protection,
+    log_redaction_patterns,
+    queue_time_sla,
+    workspace_name_case,
+    max_agent_pools,
+    max_high_priority_runs
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
@@ -20,7 +26,13 @@ INSERT INTO organizations (
     pggen.arg('cost_estimation_enabled'),
     pggen.arg('session_remember'),
     pggen.arg('session_timeout'),
-    pggen.arg('allow_force_delete_workspaces')
+    pggen.arg('allow_force_delete_workspaces'),
+    pggen.arg('default_deletion_protection'),
+    pggen.arg('log_redaction_patterns'),
+    pggen.arg('queue_time_sla'),
+    pggen.arg('workspace_name_case'),
+    pggen.arg('max_agent_pools'),
+    pggen.arg('max_high_priority_runs')
 );
 
 -- name: FindOrganizationNameByWorkspaceID :one
@@ -46,7 +58,13 @@ FOR UPDATE
 SELECT *
 FROM organizations
 WHERE name LIKE ANY(pggen.arg('names'))
-ORDER BY updated_at DESC
+AND   name ILIKE '%' || pggen.arg('search') || '%'
+ORDER BY
+    CASE WHEN pggen.arg('sort') = 'name' THEN name END ASC,
+    CASE WHEN pggen.arg('sort') = '-name' THEN name END DESC,
+    CASE WHEN pggen.arg('sort') = 'created-at' THEN created_at END ASC,
+    CASE WHEN pggen.arg('sort') = '-created-at' THEN created_at END DESC,
+    updated_at DESC
 LIMIT pggen.arg('limit') OFFSET pggen.arg('offset')
 ;
 
@@ -54,6 +72,21 @@ LIMIT pggen.arg('limit') OFFSET pggen.arg('offset')
 SELECT count(*)
 FROM organizations
 WHERE name LIKE ANY(pggen.arg('names'))
+AND   name ILIKE '%' || pggen.arg('search') || '%'
+;
+
+-- name: FindOrganizationsByUsername :many
+SELECT o.*
+FROM organizations o
+WHERE o.name ILIKE '%' || pggen.arg('search') || '%'
+AND EXISTS (
+    SELECT FROM teams t
+    JOIN team_memberships tm USING (team_id)
+    WHERE t.organization_name = o.name
+    AND   tm.username = pggen.arg('username')
+)
+ORDER BY o.name ASC
+LIMIT pggen.arg('limit')
 ;
 
 -- name: UpdateOrganizationByName :one
@@ -66,6 +99,12 @@ SET
     session_remember = pggen.arg('session_remember'),
     session_timeout = pggen.arg('session_timeout'),
     allow_force_delete_workspaces = pggen.arg('allow_force_delete_workspaces'),
+    default_deletion_protection = pggen.arg('default_deletion_protection'),
+    log_redaction_patterns = pggen.arg('log_redaction_patterns'),
+    queue_time_sla = pggen.arg('queue_time_sla'),
+    workspace_name_case = pggen.arg('workspace_name_case'),
+    max_agent_pools = pggen.arg('max_agent_pools'),
+    max_high_priority_runs = pggen.arg('max_high_priority_runs'),
     updated_at = pggen.arg('updated_at')
 WHERE name = pggen.arg('name')
 RETURNING organization_id;
@@ -15,18 +15,20 @@ INSERT INTO phase_status_timestamps (
 INSERT INTO logs (
     run_id,
     phase,
+    stream,
     chunk,
     _offset
 ) VALUES (
     pggen.arg('run_id'),
     pggen.arg('phase'),
+    pggen.arg('stream'),
     pggen.arg('chunk'),
     pggen.arg('offset')
 )
 RETURNING chunk_id
 ;
 
--- FindLogs retrieves all the logs for the given run and phase.
+-- FindLogs retrieves all the logs for the given run, phase and stream.
 --
 -- name: FindLogs :one
 SELECT
@@ -36,6 +38,7 @@ FROM (
     FROM logs
     WHERE run_id = pggen.arg('run_id')
     AND   phase  = pggen.arg('phase')
+    AND   stream = pggen.arg('stream')
     ORDER BY chunk_id
 ) c
 GROUP BY run_id, phase
@@ -46,6 +49,7 @@ SELECT
     chunk_id,
     run_id,
     phase,
+    stream,
     chunk,
     _offset AS offset
 FROM logs
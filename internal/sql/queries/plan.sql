@@ -42,6 +42,12 @@ FROM plans
 WHERE run_id = pggen.arg('run_id')
 ;
 
+-- name: GetPlanRedactedJSONByID :one
+SELECT plan_redacted_json
+FROM plans
+WHERE run_id = pggen.arg('run_id')
+;
+
 -- name: UpdatePlanBinByID :one
 UPDATE plans
 SET plan_bin = pggen.arg('plan_bin')
@@ -55,3 +61,10 @@ SET plan_json = pggen.arg('plan_json')
 WHERE run_id = pggen.arg('run_id')
 RETURNING run_id
 ;
+
+-- name: UpdatePlanRedactedJSONByID :one
+UPDATE plans
+SET plan_redacted_json = pggen.arg('plan_redacted_json')
+WHERE run_id = pggen.arg('run_id')
+RETURNING run_id
+;
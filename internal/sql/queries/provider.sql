@@ -0,0 +1,70 @@
+-- name: InsertProviderVersion :exec
+INSERT INTO registry_provider_versions (
+    provider_version_id,
+    organization_name,
+    name,
+    version,
+    protocols,
+    key_id,
+    ascii_armor,
+    shasums,
+    shasums_signature,
+    created_at
+) VALUES (
+    pggen.arg('provider_version_id'),
+    pggen.arg('organization_name'),
+    pggen.arg('name'),
+    pggen.arg('version'),
+    pggen.arg('protocols'),
+    pggen.arg('key_id'),
+    pggen.arg('ascii_armor'),
+    pggen.arg('shasums'),
+    pggen.arg('shasums_signature'),
+    pggen.arg('created_at')
+);
+
+-- name: FindProviderVersions :many
+SELECT *
+FROM registry_provider_versions
+WHERE organization_name = pggen.arg('organization_name')
+AND   name = pggen.arg('name')
+ORDER BY created_at
+;
+
+-- name: FindProviderVersionByID :one
+SELECT *
+FROM registry_provider_versions
+WHERE provider_version_id = pggen.arg('provider_version_id')
+;
+
+-- name: InsertProviderPlatform :exec
+INSERT INTO registry_provider_platforms (
+    provider_version_id,
+    os,
+    arch,
+    filename,
+    shasum,
+    archive
+) VALUES (
+    pggen.arg('provider_version_id'),
+    pggen.arg('os'),
+    pggen.arg('arch'),
+    pggen.arg('filename'),
+    pggen.arg('shasum'),
+    pggen.arg('archive')
+);
+
+-- name: FindProviderPlatforms :many
+SELECT provider_version_id, os, arch, filename, shasum
+FROM registry_provider_platforms
+WHERE provider_version_id = ANY(pggen.arg('provider_version_ids')::text[])
+ORDER BY os, arch
+;
+
+-- name: FindProviderPlatformArchive :one
+SELECT archive
+FROM registry_provider_platforms
+WHERE provider_version_id = pggen.arg('provider_version_id')
+AND   os = pggen.arg('os')
+AND   arch = pggen.arg('arch')
+;
@@ -22,3 +22,17 @@ DELETE
 FROM repo_connections
 WHERE module_id = pggen.arg('module_id')
 RETURNING *;
+
+-- UpdateRepoConnectionsRepoPath updates the path of a repo connected to
+-- workspaces and modules, returning the updated connections.
+--
+-- name: UpdateRepoConnectionsRepoPath :many
+UPDATE repo_connections
+SET repo_path = pggen.arg('to_repo_path')
+WHERE vcs_provider_id = pggen.arg('vcs_provider_id')
+AND   repo_path = pggen.arg('from_repo_path')
+RETURNING *;
+
+-- name: FindConnectedRepos :many
+SELECT DISTINCT vcs_provider_id, repo_path
+FROM repo_connections;
@@ -0,0 +1,29 @@
+-- name: InsertRepoMove :exec
+INSERT INTO repo_moves (
+    repo_move_id,
+    vcs_provider_id,
+    from_repo_path,
+    to_repo_path,
+    detected_by,
+    workspace_ids,
+    module_ids,
+    moved_at
+) VALUES (
+    pggen.arg('repo_move_id'),
+    pggen.arg('vcs_provider_id'),
+    pggen.arg('from_repo_path'),
+    pggen.arg('to_repo_path'),
+    pggen.arg('detected_by'),
+    pggen.arg('workspace_ids'),
+    pggen.arg('module_ids'),
+    pggen.arg('moved_at')
+);
+
+-- FindRepoMovesByWorkspaceID finds the moves of repos connected to a
+-- workspace, most recent first.
+--
+-- name: FindRepoMovesByWorkspaceID :many
+SELECT *
+FROM repo_moves
+WHERE pggen.arg('workspace_id') = ANY(workspace_ids)
+ORDER BY moved_at DESC;
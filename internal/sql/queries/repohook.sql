@@ -88,3 +88,9 @@ DELETE
 FROM repohooks
 WHERE repohook_id = pggen.arg('repohook_id')
 RETURNING *;
+
+-- name: UpdateRepohooksRepoPath :exec
+UPDATE repohooks
+SET repo_path = pggen.arg('to_repo_path')
+WHERE vcs_provider_id = pggen.arg('vcs_provider_id')
+AND   repo_path = pggen.arg('from_repo_path');
@@ -0,0 +1,73 @@
+-- name: InsertRepohookDelivery :exec
+INSERT INTO repohook_deliveries (
+    repohook_delivery_id,
+    repohook_id,
+    received_at,
+    headers,
+    body,
+    status,
+    error,
+    replay_of
+) VALUES (
+    pggen.arg('repohook_delivery_id'),
+    pggen.arg('repohook_id'),
+    pggen.arg('received_at'),
+    pggen.arg('headers'),
+    pggen.arg('body'),
+    pggen.arg('status'),
+    pggen.arg('error'),
+    pggen.arg('replay_of')
+);
+
+-- TrimRepohookDeliveries deletes all but the most recent limit deliveries for
+-- a repohook.
+--
+-- name: TrimRepohookDeliveries :exec
+DELETE
+FROM repohook_deliveries
+WHERE repohook_id = pggen.arg('repohook_id')
+AND repohook_delivery_id NOT IN (
+    SELECT repohook_delivery_id
+    FROM repohook_deliveries
+    WHERE repohook_id = pggen.arg('repohook_id')
+    ORDER BY received_at DESC
+    LIMIT pggen.arg('limit')
+);
+
+-- name: FindRepohookDeliveryByID :one
+SELECT
+    d.*,
+    v.organization_name
+FROM repohook_deliveries d
+JOIN repohooks w USING (repohook_id)
+JOIN vcs_providers v USING (vcs_provider_id)
+WHERE d.repohook_delivery_id = pggen.arg('repohook_delivery_id');
+
+-- FindRepohookDeliveriesByVCSProviderID finds the deliveries received by the
+-- repohooks of a VCS provider, most recent first. Headers and bodies are
+-- omitted.
+--
+-- name: FindRepohookDeliveriesByVCSProviderID :many
+SELECT
+    d.repohook_delivery_id,
+    d.repohook_id,
+    d.received_at,
+    d.status,
+    d.error,
+    d.replay_of,
+    w.repo_path,
+    d.body IS NOT NULL AS has_body,
+    d.trigger_decisions
+FROM repohook_deliveries d
+JOIN repohooks w USING (repohook_id)
+WHERE w.vcs_provider_id = pggen.arg('vcs_provider_id')
+ORDER BY d.received_at DESC;
+
+-- UpdateRepohookDeliveryTriggerDecisions records the decisions taken on
+-- whether the event in a delivery triggers a run in each of the workspaces
+-- connected to the repo.
+--
+-- name: UpdateRepohookDeliveryTriggerDecisions :exec
+UPDATE repohook_deliveries
+SET trigger_decisions = pggen.arg('trigger_decisions')
+WHERE repohook_delivery_id = pggen.arg('repohook_delivery_id');
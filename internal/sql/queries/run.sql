@@ -16,7 +16,11 @@ INSERT INTO runs (
     workspace_id,
     created_by,
     terraform_version,
-    allow_empty_apply
+    allow_empty_apply,
+    skip_preflight,
+    debug_logging,
+    parent_run_id,
+    priority
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
@@ -34,7 +38,11 @@ INSERT INTO runs (
     pggen.arg('workspace_id'),
     pggen.arg('created_by'),
     pggen.arg('terraform_version'),
-    pggen.arg('allow_empty_apply')
+    pggen.arg('allow_empty_apply'),
+    pggen.arg('skip_preflight'),
+    pggen.arg('debug_logging'),
+    pggen.arg('parent_run_id'),
+    pggen.arg('priority')
 );
 
 -- name: InsertRunStatusTimestamp :exec
@@ -59,11 +67,30 @@ INSERT INTO run_variables (
     pggen.arg('value')
 );
 
+-- name: InsertRunLabel :exec
+INSERT INTO run_labels (
+    run_id,
+    key,
+    value
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('key'),
+    pggen.arg('value')
+);
+
+-- name: DeleteRunLabels :exec
+DELETE
+FROM run_labels
+WHERE run_id = pggen.arg('run_id');
+
 -- name: FindRuns :many
 SELECT
     runs.run_id,
     runs.created_at,
     runs.cancel_signaled_at,
+    runs.force_cancel_available_at,
+    runs.force_canceled_by,
+    runs.force_cancel_reason,
     runs.is_destroy,
     runs.position_in_queue,
     runs.refresh,
@@ -84,7 +111,13 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.skip_preflight,
+    runs.debug_logging,
+    runs.error_category,
+    runs.parent_run_id,
+    runs.priority,
     workspaces.execution_mode AS execution_mode,
+    workspaces.structured_run_output_enabled,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
     END AS latest,
@@ -116,7 +149,13 @@ SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(l.*) AS run_labels
+        FROM run_labels l
+        WHERE l.run_id = runs.run_id
+        GROUP BY run_id
+    ) AS run_labels
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -130,8 +169,15 @@ AND workspaces.name              LIKE ANY(pggen.arg('workspace_names'))
 AND runs.source                  LIKE ANY(pggen.arg('sources'))
 AND runs.status                  LIKE ANY(pggen.arg('statuses'))
 AND runs.plan_only::text         LIKE ANY(pggen.arg('plan_only'))
+AND COALESCE(runs.error_category, '') LIKE ANY(pggen.arg('error_categories'))
 AND ((pggen.arg('commit_sha')::text IS NULL) OR ia.commit_sha = pggen.arg('commit_sha'))
 AND ((pggen.arg('vcs_username')::text IS NULL) OR ia.sender_username = pggen.arg('vcs_username'))
+AND ((pggen.arg('label_key')::text IS NULL) OR EXISTS (
+    SELECT FROM run_labels l
+    WHERE l.run_id = runs.run_id
+    AND   l.key = pggen.arg('label_key')
+    AND   l.value = pggen.arg('label_value')
+))
 ORDER BY runs.created_at DESC
 LIMIT pggen.arg('limit') OFFSET pggen.arg('offset')
 ;
@@ -148,8 +194,35 @@ AND workspaces.name              LIKE ANY(pggen.arg('workspace_names'))
 AND runs.source                  LIKE ANY(pggen.arg('sources'))
 AND runs.status                  LIKE ANY(pggen.arg('statuses'))
 AND runs.plan_only::text         LIKE ANY(pggen.arg('plan_only'))
+AND COALESCE(runs.error_category, '') LIKE ANY(pggen.arg('error_categories'))
 AND ((pggen.arg('commit_sha')::text IS NULL) OR ia.commit_sha = pggen.arg('commit_sha'))
 AND ((pggen.arg('vcs_username')::text IS NULL) OR ia.sender_username = pggen.arg('vcs_username'))
+AND ((pggen.arg('label_key')::text IS NULL) OR EXISTS (
+    SELECT FROM run_labels l
+    WHERE l.run_id = runs.run_id
+    AND   l.key = pggen.arg('label_key')
+    AND   l.value = pggen.arg('label_value')
+))
+;
+
+-- Find the number of incomplete high priority runs in an organization along
+-- with the maximum number permitted, locking the organization to serialize
+-- the creation of high priority runs.
+--
+-- name: FindHighPriorityRunQuota :one
+SELECT
+    (
+        SELECT count(*)
+        FROM runs r
+        JOIN workspaces w USING (workspace_id)
+        WHERE w.organization_name = o.name
+        AND   r.priority = 'high'
+        AND   r.status NOT IN ('applied', 'planned_and_finished', 'discarded', 'canceled', 'force_canceled', 'errored')
+    ) AS runs,
+    o.max_high_priority_runs
+FROM organizations o
+WHERE o.name = pggen.arg('organization_name')
+FOR UPDATE OF o
 ;
 
 -- name: FindRunByID :one
@@ -157,6 +230,9 @@ SELECT
     runs.run_id,
     runs.created_at,
     runs.cancel_signaled_at,
+    runs.force_cancel_available_at,
+    runs.force_canceled_by,
+    runs.force_cancel_reason,
     runs.is_destroy,
     runs.position_in_queue,
     runs.refresh,
@@ -177,7 +253,13 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.skip_preflight,
+    runs.debug_logging,
+    runs.error_category,
+    runs.parent_run_id,
+    runs.priority,
     workspaces.execution_mode AS execution_mode,
+    workspaces.structured_run_output_enabled,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
     END AS latest,
@@ -209,7 +291,13 @@ SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(l.*) AS run_labels
+        FROM run_labels l
+        WHERE l.run_id = runs.run_id
+        GROUP BY run_id
+    ) AS run_labels
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -224,6 +312,9 @@ SELECT
     runs.run_id,
     runs.created_at,
     runs.cancel_signaled_at,
+    runs.force_cancel_available_at,
+    runs.force_canceled_by,
+    runs.force_cancel_reason,
     runs.is_destroy,
     runs.position_in_queue,
     runs.refresh,
@@ -244,7 +335,13 @@ SELECT
     runs.created_by,
     runs.terraform_version,
     runs.allow_empty_apply,
+    runs.skip_preflight,
+    runs.debug_logging,
+    runs.error_category,
+    runs.parent_run_id,
+    runs.priority,
     workspaces.execution_mode AS execution_mode,
+    workspaces.structured_run_output_enabled,
     CASE WHEN workspaces.latest_run_id = runs.run_id THEN true
          ELSE false
     END AS latest,
@@ -276,7 +373,13 @@ SELECT
         FROM run_variables v
         WHERE v.run_id = runs.run_id
         GROUP BY run_id
-    ) AS run_variables
+    ) AS run_variables,
+    (
+        SELECT array_agg(l.*) AS run_labels
+        FROM run_labels l
+        WHERE l.run_id = runs.run_id
+        GROUP BY run_id
+    ) AS run_labels
 FROM runs
 JOIN plans USING (run_id)
 JOIN applies USING (run_id)
@@ -300,6 +403,19 @@ FROM runs
 WHERE run_id = pggen.arg('run_id')
 ;
 
+-- name: PutModuleManifest :one
+UPDATE runs
+SET module_manifest = pggen.arg('module_manifest')
+WHERE run_id = pggen.arg('run_id')
+RETURNING run_id
+;
+
+-- name: GetModuleManifestByID :one
+SELECT module_manifest
+FROM runs
+WHERE run_id = pggen.arg('run_id')
+;
+
 -- name: UpdateRunStatus :one
 UPDATE runs
 SET
@@ -308,6 +424,20 @@ WHERE run_id = pggen.arg('id')
 RETURNING run_id
 ;
 
+-- name: FindChildRunIDs :many
+SELECT run_id
+FROM runs
+WHERE parent_run_id = pggen.arg('parent_run_id')
+ORDER BY created_at ASC;
+
+-- name: UpdateRunErrorCategory :one
+UPDATE runs
+SET
+    error_category = pggen.arg('error_category')
+WHERE run_id = pggen.arg('id')
+RETURNING run_id
+;
+
 -- name: UpdateCancelSignaledAt :one
 UPDATE runs
 SET
@@ -316,6 +446,23 @@ WHERE run_id = pggen.arg('id')
 RETURNING run_id
 ;
 
+-- name: UpdateForceCancelAvailableAt :one
+UPDATE runs
+SET
+    force_cancel_available_at = pggen.arg('force_cancel_available_at')
+WHERE run_id = pggen.arg('id')
+RETURNING run_id
+;
+
+-- name: UpdateRunForceCanceledBy :one
+UPDATE runs
+SET
+    force_canceled_by = pggen.arg('force_canceled_by'),
+    force_cancel_reason = pggen.arg('force_cancel_reason')
+WHERE run_id = pggen.arg('id')
+RETURNING run_id
+;
+
 -- name: DeleteRunByID :one
 DELETE
 FROM runs
@@ -0,0 +1,37 @@
+-- name: UpsertRunArtifact :exec
+INSERT INTO run_artifacts (
+    run_artifact_id,
+    run_id,
+    name,
+    content_type,
+    size,
+    data,
+    created_at
+) VALUES (
+    pggen.arg('run_artifact_id'),
+    pggen.arg('run_id'),
+    pggen.arg('name'),
+    pggen.arg('content_type'),
+    pggen.arg('size'),
+    pggen.arg('data'),
+    pggen.arg('created_at')
+) ON CONFLICT (run_id, name) DO UPDATE
+SET run_artifact_id = EXCLUDED.run_artifact_id,
+    content_type    = EXCLUDED.content_type,
+    size            = EXCLUDED.size,
+    data            = EXCLUDED.data,
+    created_at      = EXCLUDED.created_at;
+
+-- FindRunArtifacts finds a run's artifacts, excluding their data.
+--
+-- name: FindRunArtifacts :many
+SELECT run_artifact_id, run_id, name, content_type, size, created_at
+FROM run_artifacts
+WHERE run_id = pggen.arg('run_id')
+ORDER BY name ASC;
+
+-- name: FindRunArtifactByName :one
+SELECT *
+FROM run_artifacts
+WHERE run_id = pggen.arg('run_id')
+AND name = pggen.arg('name');
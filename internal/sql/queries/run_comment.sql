@@ -0,0 +1,33 @@
+-- name: InsertRunComment :exec
+INSERT INTO run_comments (
+    comment_id,
+    run_id,
+    body,
+    author,
+    mentions,
+    created_at
+) VALUES (
+    pggen.arg('comment_id'),
+    pggen.arg('run_id'),
+    pggen.arg('body'),
+    pggen.arg('author'),
+    pggen.arg('mentions'),
+    pggen.arg('created_at')
+);
+
+-- name: FindRunComments :many
+SELECT *
+FROM run_comments
+WHERE run_id = pggen.arg('run_id')
+ORDER BY created_at ASC;
+
+-- name: FindRunCommentByID :one
+SELECT *
+FROM run_comments
+WHERE comment_id = pggen.arg('comment_id');
+
+-- name: DeleteRunCommentByID :one
+DELETE
+FROM run_comments
+WHERE comment_id = pggen.arg('comment_id')
+RETURNING comment_id;
@@ -0,0 +1,21 @@
+-- name: UpsertRunPolicyCheck :exec
+INSERT INTO run_policy_checks (
+    run_id,
+    name,
+    violations,
+    created_at
+) VALUES (
+    pggen.arg('run_id'),
+    pggen.arg('name'),
+    pggen.arg('violations'),
+    pggen.arg('created_at')
+)
+ON CONFLICT (run_id, name) DO UPDATE
+SET violations = EXCLUDED.violations,
+    created_at = EXCLUDED.created_at;
+
+-- name: FindRunPolicyChecks :many
+SELECT *
+FROM run_policy_checks
+WHERE run_id = pggen.arg('run_id')
+ORDER BY name ASC;
@@ -0,0 +1,28 @@
+-- name: UpsertSCIMToken :exec
+INSERT INTO scim_tokens (
+    scim_token_id,
+    created_at,
+    organization_name
+) VALUES (
+    pggen.arg('scim_token_id'),
+    pggen.arg('created_at'),
+    pggen.arg('organization_name')
+) ON CONFLICT (organization_name) DO UPDATE
+  SET created_at    = pggen.arg('created_at'),
+      scim_token_id = pggen.arg('scim_token_id');
+
+-- name: FindSCIMTokenByOrganization :one
+SELECT *
+FROM scim_tokens
+WHERE organization_name = pggen.arg('organization_name');
+
+-- name: FindSCIMTokenByID :one
+SELECT *
+FROM scim_tokens
+WHERE scim_token_id = pggen.arg('scim_token_id');
+
+-- name: DeleteSCIMTokenByOrganization :one
+DELETE
+FROM scim_tokens
+WHERE organization_name = pggen.arg('organization_name')
+RETURNING scim_token_id;
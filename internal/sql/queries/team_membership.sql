@@ -7,6 +7,7 @@ WITH
 INSERT INTO team_memberships (username, team_id)
 SELECT username, pggen.arg('team_id')
 FROM users
+ON CONFLICT (team_id, username) DO NOTHING
 RETURNING username
 ;
 
@@ -24,3 +25,10 @@ WHERE
     tm.team_id  = pggen.arg('team_id')
 RETURNING tm.username
 ;
+
+-- name: DeleteTeamMembershipsByUsername :many
+DELETE
+FROM team_memberships
+WHERE username = pggen.arg('username')
+RETURNING team_id
+;
@@ -0,0 +1,26 @@
+-- name: UpsertTerraformVersionPolicy :exec
+INSERT INTO terraform_version_policy (
+    terraform_version_policy_id,
+    min_version,
+    max_version,
+    default_version,
+    updated_at,
+    updated_by
+) VALUES (
+    'site',
+    pggen.arg('min_version'),
+    pggen.arg('max_version'),
+    pggen.arg('default_version'),
+    pggen.arg('updated_at'),
+    pggen.arg('updated_by')
+)
+ON CONFLICT (terraform_version_policy_id) DO UPDATE
+SET min_version     = EXCLUDED.min_version,
+    max_version     = EXCLUDED.max_version,
+    default_version = EXCLUDED.default_version,
+    updated_at      = EXCLUDED.updated_at,
+    updated_by      = EXCLUDED.updated_by;
+
+-- name: FindTerraformVersionPolicy :one
+SELECT *
+FROM terraform_version_policy;
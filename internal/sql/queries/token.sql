@@ -3,12 +3,14 @@ INSERT INTO tokens (
     token_id,
     created_at,
     description,
-    username
+    username,
+    scopes
 ) VALUES (
     pggen.arg('token_id'),
     pggen.arg('created_at'),
     pggen.arg('description'),
-    pggen.arg('username')
+    pggen.arg('username'),
+    pggen.arg('scopes')
 );
 
 -- name: FindTokensByUsername :many
@@ -29,3 +31,10 @@ FROM tokens
 WHERE token_id = pggen.arg('token_id')
 RETURNING token_id
 ;
+
+-- name: DeleteTokensByUsername :many
+DELETE
+FROM tokens
+WHERE username = pggen.arg('username')
+RETURNING token_id
+;
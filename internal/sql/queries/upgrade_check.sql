@@ -0,0 +1,20 @@
+-- name: UpsertUpgradeCheck :exec
+INSERT INTO upgrade_check (
+    upgrade_check_id,
+    latest_version,
+    changelog_url,
+    checked_at
+) VALUES (
+    'site',
+    pggen.arg('latest_version'),
+    pggen.arg('changelog_url'),
+    pggen.arg('checked_at')
+)
+ON CONFLICT (upgrade_check_id) DO UPDATE
+SET latest_version = EXCLUDED.latest_version,
+    changelog_url  = EXCLUDED.changelog_url,
+    checked_at     = EXCLUDED.checked_at;
+
+-- name: FindUpgradeCheck :one
+SELECT *
+FROM upgrade_check;
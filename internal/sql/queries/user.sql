@@ -3,12 +3,16 @@ INSERT INTO users (
     user_id,
     created_at,
     updated_at,
-    username
+    username,
+    email,
+    external_id
 ) VALUES (
     pggen.arg('id'),
     pggen.arg('created_at'),
     pggen.arg('updated_at'),
-    pggen.arg('username')
+    pggen.arg('username'),
+    pggen.arg('email'),
+    pggen.arg('external_id')
 );
 
 -- name: FindUsers :many
@@ -89,6 +93,41 @@ JOIN tokens t ON u.username = t.username
 WHERE t.token_id = pggen.arg('token_id')
 ;
 
+-- name: FindUserByExternalID :one
+SELECT u.*,
+    (
+        SELECT array_agg(t)
+        FROM teams t
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = u.username
+    ) AS teams
+FROM users u
+WHERE u.external_id = pggen.arg('external_id')
+;
+
+-- name: FindUserByEmail :one
+SELECT u.*,
+    (
+        SELECT array_agg(t)
+        FROM teams t
+        JOIN team_memberships tm USING (team_id)
+        WHERE tm.username = u.username
+    ) AS teams
+FROM users u
+WHERE u.email = pggen.arg('email')
+;
+
+-- name: UpdateUser :exec
+UPDATE users
+SET
+    username = pggen.arg('username'),
+    email = pggen.arg('email'),
+    external_id = pggen.arg('external_id'),
+    deactivated = pggen.arg('deactivated'),
+    updated_at = pggen.arg('updated_at')
+WHERE user_id = pggen.arg('user_id')
+;
+
 -- name: UpdateUserSiteAdmins :many
 UPDATE users
 SET site_admin = true
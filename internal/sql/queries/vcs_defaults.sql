@@ -0,0 +1,27 @@
+-- name: UpsertVCSDefaults :exec
+INSERT INTO organization_vcs_defaults (
+    organization_name,
+    trigger_prefixes,
+    trigger_patterns,
+    vcs_tags_regex,
+    speculative_enabled,
+    vcs_skip_drafts
+) VALUES (
+    pggen.arg('organization_name'),
+    pggen.arg('trigger_prefixes'),
+    pggen.arg('trigger_patterns'),
+    pggen.arg('vcs_tags_regex'),
+    pggen.arg('speculative_enabled'),
+    pggen.arg('vcs_skip_drafts')
+)
+ON CONFLICT (organization_name) DO UPDATE
+SET trigger_prefixes    = EXCLUDED.trigger_prefixes,
+    trigger_patterns    = EXCLUDED.trigger_patterns,
+    vcs_tags_regex      = EXCLUDED.vcs_tags_regex,
+    speculative_enabled = EXCLUDED.speculative_enabled,
+    vcs_skip_drafts     = EXCLUDED.vcs_skip_drafts;
+
+-- name: FindVCSDefaults :one
+SELECT *
+FROM organization_vcs_defaults
+WHERE organization_name = pggen.arg('organization_name');
@@ -4,17 +4,23 @@ INSERT INTO workspaces (
     created_at,
     updated_at,
     agent_pool_id,
+    plan_agent_pool_id,
+    apply_agent_pool_id,
     allow_cli_apply,
     allow_destroy_plan,
+    apply_windows,
     auto_apply,
     branch,
     can_queue_destroy_plan,
+    deletion_protected,
+    log_scrubbing_disabled,
     description,
     environment,
     execution_mode,
     global_remote_state,
     migration_environment,
     name,
+    preflight_checks,
     queue_all_runs,
     speculative_enabled,
     source_name,
@@ -24,6 +30,7 @@ INSERT INTO workspaces (
     trigger_prefixes,
     trigger_patterns,
     vcs_tags_regex,
+    vcs_skip_drafts,
     working_directory,
     organization_name
 ) VALUES (
@@ -31,17 +38,23 @@ INSERT INTO workspaces (
     pggen.arg('created_at'),
     pggen.arg('updated_at'),
     pggen.arg('agent_pool_id'),
+    pggen.arg('plan_agent_pool_id'),
+    pggen.arg('apply_agent_pool_id'),
     pggen.arg('allow_cli_apply'),
     pggen.arg('allow_destroy_plan'),
+    pggen.arg('apply_windows'),
     pggen.arg('auto_apply'),
     pggen.arg('branch'),
     pggen.arg('can_queue_destroy_plan'),
+    pggen.arg('deletion_protected'),
+    pggen.arg('log_scrubbing_disabled'),
     pggen.arg('description'),
     pggen.arg('environment'),
     pggen.arg('execution_mode'),
     pggen.arg('global_remote_state'),
     pggen.arg('migration_environment'),
     pggen.arg('name'),
+    pggen.arg('preflight_checks'),
     pggen.arg('queue_all_runs'),
     pggen.arg('speculative_enabled'),
     pggen.arg('source_name'),
@@ -51,6 +64,7 @@ INSERT INTO workspaces (
     pggen.arg('trigger_prefixes'),
     pggen.arg('trigger_patterns'),
     pggen.arg('vcs_tags_regex'),
+    pggen.arg('vcs_skip_drafts'),
     pggen.arg('working_directory'),
     pggen.arg('organization_name')
 );
@@ -234,14 +248,20 @@ FOR UPDATE OF w;
 UPDATE workspaces
 SET
     agent_pool_id                 = pggen.arg('agent_pool_id'),
+    plan_agent_pool_id            = pggen.arg('plan_agent_pool_id'),
+    apply_agent_pool_id           = pggen.arg('apply_agent_pool_id'),
     allow_destroy_plan            = pggen.arg('allow_destroy_plan'),
     allow_cli_apply               = pggen.arg('allow_cli_apply'),
+    apply_windows                 = pggen.arg('apply_windows'),
     auto_apply                    = pggen.arg('auto_apply'),
     branch                        = pggen.arg('branch'),
+    deletion_protected            = pggen.arg('deletion_protected'),
+    log_scrubbing_disabled        = pggen.arg('log_scrubbing_disabled'),
     description                   = pggen.arg('description'),
     execution_mode                = pggen.arg('execution_mode'),
     global_remote_state           = pggen.arg('global_remote_state'),
     name                          = pggen.arg('name'),
+    preflight_checks              = pggen.arg('preflight_checks'),
     queue_all_runs                = pggen.arg('queue_all_runs'),
     speculative_enabled           = pggen.arg('speculative_enabled'),
     structured_run_output_enabled = pggen.arg('structured_run_output_enabled'),
@@ -249,6 +269,7 @@ SET
     trigger_prefixes              = pggen.arg('trigger_prefixes'),
     trigger_patterns              = pggen.arg('trigger_patterns'),
     vcs_tags_regex                = pggen.arg('vcs_tags_regex'),
+    vcs_skip_drafts               = pggen.arg('vcs_skip_drafts'),
     working_directory             = pggen.arg('working_directory'),
     updated_at                    = pggen.arg('updated_at')
 WHERE workspace_id = pggen.arg('id')
@@ -258,7 +279,20 @@ RETURNING workspace_id;
 UPDATE workspaces
 SET
     lock_username = pggen.arg('username'),
-    lock_run_id = pggen.arg('run_id')
+    lock_run_id = pggen.arg('run_id'),
+    lock_acquired_at = pggen.arg('acquired_at'),
+    lock_info = pggen.arg('lock_info')
+WHERE workspace_id = pggen.arg('workspace_id');
+
+-- name: UpdateWorkspacePausedAt :exec
+UPDATE workspaces
+SET paused_at = pggen.arg('paused_at')
+WHERE workspace_id = pggen.arg('workspace_id');
+
+-- name: UpdateWorkspaceDebugLogging :exec
+UPDATE workspaces
+SET debug_logging_expires_at = pggen.arg('expires_at'),
+    debug_logging_runs_remaining = pggen.arg('runs_remaining')
 WHERE workspace_id = pggen.arg('workspace_id');
 
 -- name: UpdateWorkspaceLatestRun :exec
@@ -276,3 +310,10 @@ RETURNING workspace_id;
 DELETE
 FROM workspaces
 WHERE workspace_id = pggen.arg('workspace_id');
+
+-- name: FindWorkspaceNamesCaseInsensitive :many
+SELECT name
+FROM workspaces
+WHERE organization_name = pggen.arg('organization_name')
+AND   lower(name) = lower(pggen.arg('name'))
+AND   workspace_id <> pggen.arg('workspace_id');
@@ -0,0 +1,34 @@
+-- UpsertWorkspaceAssessment replaces a workspace's assessment, unless it was
+-- detected more recently than the replacement.
+--
+-- name: UpsertWorkspaceAssessment :exec
+INSERT INTO workspace_assessments (
+    workspace_id,
+    run_id,
+    detected_at,
+    resources
+) VALUES (
+    pggen.arg('workspace_id'),
+    pggen.arg('run_id'),
+    pggen.arg('detected_at'),
+    pggen.arg('resources')
+)
+ON CONFLICT (workspace_id) DO UPDATE
+SET run_id      = EXCLUDED.run_id,
+    detected_at = EXCLUDED.detected_at,
+    resources   = EXCLUDED.resources
+WHERE workspace_assessments.detected_at <= EXCLUDED.detected_at;
+
+-- name: FindWorkspaceAssessment :one
+SELECT *
+FROM workspace_assessments
+WHERE workspace_id = pggen.arg('workspace_id');
+
+-- DeleteWorkspaceAssessmentBefore deletes a workspace's assessment if it was
+-- detected before the given time.
+--
+-- name: DeleteWorkspaceAssessmentBefore :exec
+DELETE
+FROM workspace_assessments
+WHERE workspace_id = pggen.arg('workspace_id')
+AND   detected_at < pggen.arg('before');
@@ -0,0 +1,23 @@
+-- name: InsertWorkspaceEvent :exec
+INSERT INTO workspace_events (
+    workspace_event_id,
+    workspace_id,
+    kind,
+    subject,
+    created_at
+) VALUES (
+    pggen.arg('workspace_event_id'),
+    pggen.arg('workspace_id'),
+    pggen.arg('kind'),
+    pggen.arg('subject'),
+    pggen.arg('created_at')
+);
+
+-- FindWorkspaceEventsByWorkspaceID finds the events recorded for a workspace,
+-- most recent first.
+--
+-- name: FindWorkspaceEventsByWorkspaceID :many
+SELECT *
+FROM workspace_events
+WHERE workspace_id = pggen.arg('workspace_id')
+ORDER BY created_at DESC;
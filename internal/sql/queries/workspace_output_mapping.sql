@@ -0,0 +1,38 @@
+-- name: InsertWorkspaceOutputMapping :exec
+INSERT INTO workspace_output_mappings (
+    output_mapping_id,
+    workspace_id,
+    key,
+    source_workspace_id,
+    source_output,
+    created_at
+) VALUES (
+    pggen.arg('output_mapping_id'),
+    pggen.arg('workspace_id'),
+    pggen.arg('key'),
+    pggen.arg('source_workspace_id'),
+    pggen.arg('source_output'),
+    pggen.arg('created_at')
+);
+
+-- name: FindWorkspaceOutputMappings :many
+SELECT m.*, w.name AS source_workspace_name
+FROM workspace_output_mappings m
+LEFT JOIN workspaces w ON m.source_workspace_id = w.workspace_id
+WHERE m.workspace_id = pggen.arg('workspace_id')
+ORDER BY m.key
+;
+
+-- name: FindWorkspaceOutputMapping :one
+SELECT m.*, w.name AS source_workspace_name
+FROM workspace_output_mappings m
+LEFT JOIN workspaces w ON m.source_workspace_id = w.workspace_id
+WHERE m.output_mapping_id = pggen.arg('output_mapping_id')
+;
+
+-- name: DeleteWorkspaceOutputMapping :one
+DELETE
+FROM workspace_output_mappings
+WHERE output_mapping_id = pggen.arg('output_mapping_id')
+RETURNING output_mapping_id
+;
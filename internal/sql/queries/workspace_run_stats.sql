@@ -0,0 +1,72 @@
+-- InsertWorkspaceRunStats adds a run's contribution to its workspace's run
+-- statistics for the day, unless a contribution for the run in the same status
+-- has already been made.
+-- name: InsertWorkspaceRunStats :exec
+WITH entry AS (
+    INSERT INTO workspace_run_stats_entries (run_id, status)
+    VALUES (pggen.arg('run_id'), pggen.arg('status'))
+    ON CONFLICT DO NOTHING
+    RETURNING run_id
+)
+INSERT INTO workspace_run_stats (
+    workspace_id,
+    day,
+    runs_created,
+    runs_applied,
+    runs_errored,
+    runs_canceled,
+    plan_count,
+    plan_duration_ms,
+    apply_count,
+    apply_duration_ms
+)
+SELECT
+    pggen.arg('workspace_id')::text,
+    (pggen.arg('recorded_at')::timestamptz AT TIME ZONE 'UTC')::date,
+    pggen.arg('runs_created')::int,
+    pggen.arg('runs_applied')::int,
+    pggen.arg('runs_errored')::int,
+    pggen.arg('runs_canceled')::int,
+    pggen.arg('plan_count')::int,
+    pggen.arg('plan_duration_ms')::bigint,
+    pggen.arg('apply_count')::int,
+    pggen.arg('apply_duration_ms')::bigint
+FROM entry
+ON CONFLICT (workspace_id, day) DO UPDATE
+SET runs_created      = workspace_run_stats.runs_created + EXCLUDED.runs_created,
+    runs_applied      = workspace_run_stats.runs_applied + EXCLUDED.runs_applied,
+    runs_errored      = workspace_run_stats.runs_errored + EXCLUDED.runs_errored,
+    runs_canceled     = workspace_run_stats.runs_canceled + EXCLUDED.runs_canceled,
+    plan_count        = workspace_run_stats.plan_count + EXCLUDED.plan_count,
+    plan_duration_ms  = workspace_run_stats.plan_duration_ms + EXCLUDED.plan_duration_ms,
+    apply_count       = workspace_run_stats.apply_count + EXCLUDED.apply_count,
+    apply_duration_ms = workspace_run_stats.apply_duration_ms + EXCLUDED.apply_duration_ms;
+
+-- name: SumWorkspaceRunStats :one
+SELECT
+    COALESCE(SUM(runs_created), 0)::int AS runs_created,
+    COALESCE(SUM(runs_applied), 0)::int AS runs_applied,
+    COALESCE(SUM(runs_errored), 0)::int AS runs_errored,
+    COALESCE(SUM(runs_canceled), 0)::int AS runs_canceled,
+    COALESCE(SUM(plan_count), 0)::int AS plan_count,
+    COALESCE(SUM(plan_duration_ms), 0)::bigint AS plan_duration_ms,
+    COALESCE(SUM(apply_count), 0)::int AS apply_count,
+    COALESCE(SUM(apply_duration_ms), 0)::bigint AS apply_duration_ms
+FROM workspace_run_stats
+WHERE workspace_id = pggen.arg('workspace_id')
+AND   day >= (pggen.arg('since')::timestamptz AT TIME ZONE 'UTC')::date;
+
+-- name: SumOrganizationRunStats :one
+SELECT
+    COALESCE(SUM(s.runs_created), 0)::int AS runs_created,
+    COALESCE(SUM(s.runs_applied), 0)::int AS runs_applied,
+    COALESCE(SUM(s.runs_errored), 0)::int AS runs_errored,
+    COALESCE(SUM(s.runs_canceled), 0)::int AS runs_canceled,
+    COALESCE(SUM(s.plan_count), 0)::int AS plan_count,
+    COALESCE(SUM(s.plan_duration_ms), 0)::bigint AS plan_duration_ms,
+    COALESCE(SUM(s.apply_count), 0)::int AS apply_count,
+    COALESCE(SUM(s.apply_duration_ms), 0)::bigint AS apply_duration_ms
+FROM workspace_run_stats s
+JOIN workspaces w USING (workspace_id)
+WHERE w.organization_name = pggen.arg('organization_name')
+AND   s.day >= (pggen.arg('since')::timestamptz AT TIME ZONE 'UTC')::date;
@@ -0,0 +1,31 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/tofutf/tofutf/internal"
+)
+
+// Reconcile brings a set of members stored in the database into line with a
+// desired set. Members of the desired set missing from the current set are
+// passed to insert, and members of the current set missing from the desired
+// set are passed to remove, each in a single batch, so that an entire set is
+// reconciled with at most two statements. Neither func is invoked if there are
+// no members to pass to it.
+//
+// insert should tolerate members that already exist, e.g. with ON CONFLICT DO
+// NOTHING, and both funcs should use the caller's transaction, so that the set
+// is left unchanged should either fail.
+func Reconcile(ctx context.Context, current, desired []string, insert, remove func(ctx context.Context, members []string) error) error {
+	if add := internal.DiffStrings(desired, current); len(add) > 0 {
+		if err := insert(ctx, add); err != nil {
+			return err
+		}
+	}
+	if del := internal.DiffStrings(current, desired); len(del) > 0 {
+		if err := remove(ctx, del); err != nil {
+			return err
+		}
+	}
+	return nil
+}
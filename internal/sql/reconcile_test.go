@@ -0,0 +1,86 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	// members are drawn from a small range of names so that the current and
+	// desired sets overlap.
+	names := func(ns []uint8) []string {
+		members := make([]string, len(ns))
+		for i, n := range ns {
+			members[i] = fmt.Sprintf("member-%d", n%16)
+		}
+		return members
+	}
+
+	// the end state matches the desired set regardless of the initial state
+	f := func(current, desired []uint8) bool {
+		set := make(map[string]struct{})
+		for _, m := range names(current) {
+			set[m] = struct{}{}
+		}
+		var inserts, removes int
+		err := Reconcile(context.Background(), keys(set), names(desired),
+			func(_ context.Context, members []string) error {
+				inserts++
+				for _, m := range members {
+					set[m] = struct{}{}
+				}
+				return nil
+			},
+			func(_ context.Context, members []string) error {
+				removes++
+				for _, m := range members {
+					delete(set, m)
+				}
+				return nil
+			},
+		)
+		if err != nil || inserts > 1 || removes > 1 {
+			return false
+		}
+		want := make(map[string]struct{})
+		for _, m := range names(desired) {
+			want[m] = struct{}{}
+		}
+		return assert.ObjectsAreEqual(keys(want), keys(set))
+	}
+	require.NoError(t, quick.Check(f, nil))
+
+	t.Run("no changes", func(t *testing.T) {
+		fail := func(context.Context, []string) error {
+			t.Fatal("unexpected call")
+			return nil
+		}
+		err := Reconcile(context.Background(), []string{"a", "b"}, []string{"b", "a"}, fail, fail)
+		require.NoError(t, err)
+	})
+
+	t.Run("insert error", func(t *testing.T) {
+		want := errors.New("insert failed")
+		err := Reconcile(context.Background(), nil, []string{"a"},
+			func(context.Context, []string) error { return want },
+			func(context.Context, []string) error { return nil },
+		)
+		assert.ErrorIs(t, err, want)
+	})
+}
+
+func keys(m map[string]struct{}) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
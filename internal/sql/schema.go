@@ -0,0 +1,56 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+)
+
+// MigrationVersion is the version of the most recent migration embedded in
+// this binary, i.e. the version of the schema the binary was built for.
+var MigrationVersion = mustLatestMigration()
+
+func mustLatestMigration() int64 {
+	entries, err := fs.ReadDir(migrations, "migrations")
+	if err != nil {
+		panic("reading embedded migrations: " + err.Error())
+	}
+	var latest int64
+	for _, entry := range entries {
+		version, err := goose.NumericComponent(entry.Name())
+		if err != nil {
+			panic(fmt.Sprintf("parsing version of embedded migration %s: %s", entry.Name(), err.Error()))
+		}
+		latest = max(latest, version)
+	}
+	return latest
+}
+
+// UnknownMigrations returns the versions of migrations that have been applied
+// to the database but are not embedded in this binary, in ascending order. If
+// any are returned then the binary is older than the schema, typically because
+// a newer version has been deployed alongside it.
+func (p *Pool) UnknownMigrations(ctx context.Context) ([]int64, error) {
+	rows, err := p.e.Query(ctx, `
+SELECT DISTINCT version_id
+FROM goose_db_version
+WHERE is_applied
+AND version_id > $1
+ORDER BY version_id`, MigrationVersion)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []int64
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("scanning applied migration: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
@@ -5,6 +5,7 @@ package sql
 
 import (
 	"net"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -67,6 +68,14 @@ func Int8Ptr(s *int) pgtype.Int8 {
 	return pgtype.Int8{}
 }
 
+// Int64Ptr converts a go-int64 pointer into a postgres nullable int8
+func Int64Ptr(s *int64) pgtype.Int8 {
+	if s != nil {
+		return pgtype.Int8{Int64: *s, Valid: true}
+	}
+	return pgtype.Int8{}
+}
+
 // NullString returns a postgres null string
 func NullString() pgtype.Text {
 	return pgtype.Text{}
@@ -124,6 +133,28 @@ func NoRowsInResultError(err error) bool {
 	}
 }
 
+// IsTransient determines whether err is a transient database error, i.e. one
+// that may not recur if the query or transaction is retried, such as a
+// serialization failure, a deadlock, or a lost connection.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case strings.HasPrefix(pgErr.Code, "40"): // transaction rollback
+			return true
+		case strings.HasPrefix(pgErr.Code, "08"): // connection exception
+			return true
+		case pgErr.Code == "57P01": // admin shutdown
+			return true
+		}
+		return false
+	}
+	return pgconn.SafeToRetry(err)
+}
+
 // Inet is a shim that converts a net.IP into a new.IPNet.
 func Inet(address net.IP) net.IPNet {
 	return net.IPNet{IP: address, Mask: net.CIDRMask(32, 0)}
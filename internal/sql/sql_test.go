@@ -3,10 +3,14 @@ package sql_test
 import (
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/sql"
 )
@@ -44,3 +48,31 @@ func TestSqlErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", fmt.Errorf("finishing phase: %w", &pgconn.PgError{Code: "40P01"}), true},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, true},
+		{"unique violation", samplePgErr2, false},
+		{"domain error", internal.ErrResourceNotFound, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sql.IsTransient(tt.err))
+		})
+	}
+}
+
+func TestMigrationVersion(t *testing.T) {
+	entries, err := os.ReadDir("migrations")
+	require.NoError(t, err)
+
+	latest := entries[len(entries)-1].Name()
+	assert.Equal(t, strings.SplitN(latest, "_", 2)[0], strconv.FormatInt(sql.MigrationVersion, 10))
+}
@@ -0,0 +1,212 @@
+package state
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+type (
+	// httpBackend implements the protocol of terraform's HTTP backend, allowing
+	// the terraform CLI to store a workspace's state, and to lock the
+	// workspace whilst doing so.
+	//
+	// https://developer.hashicorp.com/terraform/language/settings/backends/http
+	httpBackend struct {
+		state      httpBackendStateClient
+		workspaces httpBackendWorkspaceClient
+	}
+
+	httpBackendStateClient interface {
+		Create(ctx context.Context, opts CreateStateVersionOptions) (*Version, error)
+		DownloadCurrent(ctx context.Context, workspaceID string) ([]byte, error)
+	}
+
+	httpBackendWorkspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+		LockState(ctx context.Context, workspaceID string, info workspace.StateLockInfo) (*workspace.Workspace, error)
+		UnlockState(ctx context.Context, workspaceID string, lockID *string) (*workspace.Workspace, error)
+	}
+)
+
+func (h *httpBackend) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
+
+	// HTTP backend (OTF extension). The lock and unlock methods are those
+	// the terraform CLI uses by default.
+	r.HandleFunc("/workspaces/{workspace_id}/state", h.getState).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/state", h.postState).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/state", h.lock).Methods("LOCK")
+	r.HandleFunc("/workspaces/{workspace_id}/state", h.unlock).Methods("UNLOCK")
+}
+
+func (h *httpBackend) getState(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	state, err := h.state.DownloadCurrent(r.Context(), workspaceID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		// the CLI treats no content as the workspace having no state yet
+		w.WriteHeader(http.StatusNoContent)
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(state) //nolint:errcheck
+}
+
+// postState creates a state version. If the CLI holds the workspace lock then
+// it sends the lock ID as a query parameter. Otherwise, i.e. when locking is
+// disabled with -lock=false, state is only accepted if the workspace is
+// unlocked.
+func (h *httpBackend) postState(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	state, err := io.ReadAll(r.Body)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if checksum := r.Header.Get("Content-MD5"); checksum != "" {
+		sum := md5.Sum(state)
+		if checksum != base64.StdEncoding.EncodeToString(sum[:]) {
+			tfeapi.Error(w, &internal.HTTPError{Code: http.StatusBadRequest, Message: "state does not match Content-MD5 header"})
+			return
+		}
+	}
+	var file File
+	if err := json.Unmarshal(state, &file); err != nil {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	ws, err := h.workspaces.Get(r.Context(), workspaceID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if lockID := r.URL.Query().Get("ID"); lockID != "" {
+		if err := ws.CheckStateLock(lockID); err != nil {
+			h.lockError(w, ws, err)
+			return
+		}
+	} else if ws.Locked() {
+		h.lockError(w, ws, workspace.ErrWorkspaceAlreadyLocked)
+		return
+	}
+
+	_, err = h.state.Create(r.Context(), CreateStateVersionOptions{
+		WorkspaceID: internal.String(workspaceID),
+		State:       state,
+		Serial:      &file.Serial,
+	})
+	if errors.Is(err, ErrSerialNotGreaterThanCurrent) || errors.Is(err, ErrSerialMD5Mismatch) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusConflict, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *httpBackend) lock(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var info workspace.StateLockInfo
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	if _, err := h.workspaces.LockState(r.Context(), workspaceID, info); err != nil {
+		h.lockError(w, nil, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// unlock unlocks the workspace. The CLI normally sends the lock info it sent
+// when locking the workspace, but when forcibly unlocking it sends nothing,
+// because it no longer has the lock info.
+func (h *httpBackend) unlock(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var lockID *string
+	if len(body) > 0 {
+		var info workspace.StateLockInfo
+		if err := json.Unmarshal(body, &info); err != nil {
+			tfeapi.Error(w, &internal.HTTPError{Code: http.StatusBadRequest, Message: err.Error()})
+			return
+		}
+		lockID = &info.ID
+	}
+
+	_, err = h.workspaces.UnlockState(r.Context(), workspaceID, lockID)
+	if errors.Is(err, workspace.ErrWorkspaceAlreadyUnlocked) {
+		// the lock has already been released, e.g. forcibly from the UI, so
+		// there is nothing for the CLI to do.
+		w.WriteHeader(http.StatusOK)
+		return
+	} else if err != nil {
+		h.lockError(w, nil, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// lockError responds to the CLI with the lock info of the entity holding the
+// workspace lock, using the status code the CLI expects when state is locked.
+// The lock is taken from the error if it is a workspace.LockedError, or
+// otherwise from the workspace, if provided.
+func (h *httpBackend) lockError(w http.ResponseWriter, ws *workspace.Workspace, err error) {
+	var (
+		locked *workspace.LockedError
+		lock   *workspace.Lock
+	)
+	if errors.As(err, &locked) {
+		lock = locked.Lock
+	} else if ws != nil && ws.Locked() {
+		lock = ws.Lock
+	} else if errors.Is(err, workspace.ErrWorkspaceAlreadyUnlocked) {
+		// the lock held by the CLI has since been released, e.g. forcibly
+		// from the UI.
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusConflict, Message: "state lock no longer held: " + err.Error()})
+		return
+	} else {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusLocked)
+	json.NewEncoder(w).Encode(lock.StateLockInfo()) //nolint:errcheck
+}
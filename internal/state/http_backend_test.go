@@ -0,0 +1,192 @@
+package state
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+func TestHTTPBackend(t *testing.T) {
+	const address = "/api/v2/workspaces/ws-123/state"
+
+	setup := func() (*mux.Router, *fakeHTTPBackendWorkspaces, *fakeHTTPBackendState) {
+		workspaces := &fakeHTTPBackendWorkspaces{ws: &workspace.Workspace{ID: "ws-123"}}
+		state := &fakeHTTPBackendState{}
+		r := mux.NewRouter()
+		(&httpBackend{state: state, workspaces: workspaces}).addHandlers(r)
+		return r, workspaces, state
+	}
+
+	// do sends a request in the same manner as the terraform CLI's HTTP backend
+	do := func(t *testing.T, r *mux.Router, method, target string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, bytes.NewReader(body))
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+			sum := md5.Sum(body)
+			req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	lockInfo := func(t *testing.T, id string) []byte {
+		b, err := json.Marshal(workspace.StateLockInfo{
+			ID:        id,
+			Operation: "OperationTypeApply",
+			Who:       "bob@laptop",
+			Version:   "1.6.0",
+		})
+		require.NoError(t, err)
+		return b
+	}
+
+	stateFile := []byte(`{"version":4,"terraform_version":"1.6.0","serial":1,"lineage":"abc","outputs":{},"resources":[]}`)
+
+	t.Run("apply", func(t *testing.T) {
+		r, workspaces, state := setup()
+
+		w := do(t, r, "LOCK", address, lockInfo(t, "lock-123"))
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.True(t, workspaces.ws.Locked())
+
+		// no state yet
+		w = do(t, r, "GET", address, nil)
+		require.Equal(t, 204, w.Code, w.Body.String())
+
+		w = do(t, r, "POST", address+"?ID=lock-123", stateFile)
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.Equal(t, stateFile, state.current)
+
+		w = do(t, r, "UNLOCK", address, lockInfo(t, "lock-123"))
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.False(t, workspaces.ws.Locked())
+
+		w = do(t, r, "GET", address, nil)
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.Equal(t, stateFile, w.Body.Bytes())
+	})
+
+	t.Run("already locked", func(t *testing.T) {
+		r, _, _ := setup()
+
+		w := do(t, r, "LOCK", address, lockInfo(t, "lock-123"))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		w = do(t, r, "LOCK", address, lockInfo(t, "lock-456"))
+		require.Equal(t, http.StatusLocked, w.Code, w.Body.String())
+
+		// CLI expects lock info of existing lock
+		var got workspace.StateLockInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, "lock-123", got.ID)
+		assert.Equal(t, "bob@laptop", got.Who)
+	})
+
+	t.Run("force unlock", func(t *testing.T) {
+		r, workspaces, _ := setup()
+
+		w := do(t, r, "LOCK", address, lockInfo(t, "lock-123"))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		// terraform force-unlock sends no lock info
+		w = do(t, r, "UNLOCK", address, nil)
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.False(t, workspaces.ws.Locked())
+	})
+
+	t.Run("unlock with different lock ID", func(t *testing.T) {
+		r, workspaces, _ := setup()
+
+		w := do(t, r, "LOCK", address, lockInfo(t, "lock-123"))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		w = do(t, r, "UNLOCK", address, lockInfo(t, "lock-456"))
+		require.Equal(t, http.StatusLocked, w.Code, w.Body.String())
+		assert.True(t, workspaces.ws.Locked())
+	})
+
+	t.Run("force unlocked from UI whilst CLI holds lock", func(t *testing.T) {
+		r, workspaces, state := setup()
+
+		w := do(t, r, "LOCK", address, lockInfo(t, "lock-123"))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		err := workspaces.ws.Unlock("admin", workspace.UserLock, true)
+		require.NoError(t, err)
+
+		// CLI can no longer write state using its lock ID
+		w = do(t, r, "POST", address+"?ID=lock-123", stateFile)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Nil(t, state.current)
+
+		// CLI unlocking its lock is a no-op
+		w = do(t, r, "UNLOCK", address, lockInfo(t, "lock-123"))
+		require.Equal(t, 200, w.Code, w.Body.String())
+	})
+
+	t.Run("locked by someone else after force unlock", func(t *testing.T) {
+		r, workspaces, state := setup()
+
+		w := do(t, r, "LOCK", address, lockInfo(t, "lock-123"))
+		require.Equal(t, 200, w.Code, w.Body.String())
+
+		err := workspaces.ws.Unlock("admin", workspace.UserLock, true)
+		require.NoError(t, err)
+		err = workspaces.ws.Enlock("run-123", workspace.RunLock)
+		require.NoError(t, err)
+
+		w = do(t, r, "POST", address+"?ID=lock-123", stateFile)
+		require.Equal(t, http.StatusLocked, w.Code, w.Body.String())
+		assert.Nil(t, state.current)
+
+		var got workspace.StateLockInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, "run-123", got.ID)
+
+		// stale unlock leaves the run's lock in place
+		w = do(t, r, "UNLOCK", address, lockInfo(t, "lock-123"))
+		require.Equal(t, http.StatusLocked, w.Code, w.Body.String())
+		assert.True(t, workspaces.ws.Locked())
+	})
+
+	t.Run("write state without lock when unlocked", func(t *testing.T) {
+		r, _, state := setup()
+
+		// terraform apply -lock=false
+		w := do(t, r, "POST", address, stateFile)
+		require.Equal(t, 200, w.Code, w.Body.String())
+		assert.Equal(t, stateFile, state.current)
+	})
+
+	t.Run("write state without lock when locked", func(t *testing.T) {
+		r, workspaces, state := setup()
+
+		err := workspaces.ws.Enlock("run-123", workspace.RunLock)
+		require.NoError(t, err)
+
+		w := do(t, r, "POST", address, stateFile)
+		require.Equal(t, http.StatusLocked, w.Code, w.Body.String())
+		assert.Nil(t, state.current)
+	})
+
+	t.Run("content md5 mismatch", func(t *testing.T) {
+		r, _, state := setup()
+
+		req := httptest.NewRequest("POST", address, bytes.NewReader(stateFile))
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString([]byte("wrong")))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Nil(t, state.current)
+	})
+}
@@ -33,6 +33,7 @@ type (
 		web       *webHandlers
 		tfeapi    *tfe
 		api       *api
+		backend   *httpBackend
 
 		*factory // for creating state versions
 	}
@@ -84,6 +85,11 @@ func NewService(opts Options) *Service {
 		tfeapi:    svc.tfeapi,
 	}
 
+	svc.backend = &httpBackend{
+		state:      &svc,
+		workspaces: opts.WorkspaceService,
+	}
+
 	// include state version outputs in api responses when requested.
 	opts.Responder.Register(tfeapi.IncludeOutputs, svc.tfeapi.includeOutputs)
 	opts.Responder.Register(tfeapi.IncludeOutputs, svc.tfeapi.includeWorkspaceCurrentOutputs)
@@ -94,6 +100,7 @@ func (a *Service) AddHandlers(r *mux.Router) {
 	a.web.addHandlers(r)
 	a.tfeapi.addHandlers(r)
 	a.api.addHandlers(r)
+	a.backend.addHandlers(r)
 }
 
 func (a *Service) Create(ctx context.Context, opts CreateStateVersionOptions) (*Version, error) {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
+	"github.com/tofutf/tofutf/internal/workspace"
 )
 
 type fakeDB struct {
@@ -49,3 +50,49 @@ func (f *fakeDB) updateCurrentVersion(ctx context.Context, workspaceID, svID str
 func (f *fakeDB) uploadStateAndFinalize(ctx context.Context, svID string, state []byte) error {
 	return nil
 }
+
+type fakeHTTPBackendState struct {
+	current []byte // current state
+}
+
+func (f *fakeHTTPBackendState) Create(ctx context.Context, opts CreateStateVersionOptions) (*Version, error) {
+	f.current = opts.State
+	return &Version{Serial: *opts.Serial, State: opts.State}, nil
+}
+
+func (f *fakeHTTPBackendState) DownloadCurrent(ctx context.Context, workspaceID string) ([]byte, error) {
+	if f.current == nil {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.current, nil
+}
+
+// fakeHTTPBackendWorkspaces locks and unlocks a workspace on behalf of user
+// bob.
+type fakeHTTPBackendWorkspaces struct {
+	ws *workspace.Workspace
+}
+
+func (f *fakeHTTPBackendWorkspaces) Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	return f.ws, nil
+}
+
+func (f *fakeHTTPBackendWorkspaces) LockState(ctx context.Context, workspaceID string, info workspace.StateLockInfo) (*workspace.Workspace, error) {
+	if err := f.ws.EnlockState("bob", info); err != nil {
+		return nil, err
+	}
+	return f.ws, nil
+}
+
+func (f *fakeHTTPBackendWorkspaces) UnlockState(ctx context.Context, workspaceID string, lockID *string) (*workspace.Workspace, error) {
+	var err error
+	if lockID == nil {
+		err = f.ws.Unlock("bob", workspace.UserLock, true)
+	} else {
+		err = f.ws.UnlockState(*lockID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f.ws, nil
+}
@@ -51,7 +51,10 @@ func (h *Handlers) AddHandlers(r *mux.Router) {
 				// Version 2.5 is the minimum version terraform requires for the
 				// newer 'cloud' configuration block:
 				// https://developer.hashicorp.com/terraform/cli/cloud/settings#the-cloud-block
-				w.Header().Set("TFP-API-Version", "2.5")
+				//
+				// Version 2.6 is the minimum version terraform requires to
+				// render structured run output.
+				w.Header().Set("TFP-API-Version", "2.6")
 			}
 
 			// Remove trailing slash from all requests
@@ -29,10 +29,12 @@ func Error(w http.ResponseWriter, err error) {
 		httpError *internal.HTTPError
 		missing   *internal.MissingParameterError
 		code      int
+		meta      any
 	)
 	// If error is type internal.HTTPError then extract its status code
 	if errors.As(err, &httpError) {
 		code = httpError.Code
+		meta = httpError.Meta
 	} else if errors.As(err, &missing) {
 		// report missing parameter errors as a 422
 		code = http.StatusUnprocessableEntity
@@ -43,6 +45,7 @@ func Error(w http.ResponseWriter, err error) {
 		Status: &code,
 		Title:  http.StatusText(code),
 		Detail: err.Error(),
+		Meta:   meta,
 	})
 	if err != nil {
 		panic(err)
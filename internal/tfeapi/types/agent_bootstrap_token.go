@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// AgentBootstrapToken is a short-lived token that agents exchange for their own
+// token upon registration.
+type AgentBootstrapToken struct {
+	ID          string    `jsonapi:"primary,agent-bootstrap-tokens"`
+	CreatedAt   time.Time `jsonapi:"attribute" json:"created-at"`
+	Description string    `jsonapi:"attribute" json:"description"`
+	ExpiresAt   time.Time `jsonapi:"attribute" json:"expires-at"`
+	MaxUses     *int      `jsonapi:"attribute" json:"max-uses"`
+	Uses        int       `jsonapi:"attribute" json:"uses"`
+	Token       string    `jsonapi:"attribute" json:"token,omitempty"`
+}
+
+// AgentBootstrapTokenCreateOptions represents the options for creating a
+// bootstrap token.
+type AgentBootstrapTokenCreateOptions struct {
+	// Type is a public field utilized by JSON:API to set the resource type via
+	// the field tag.  It is not a user-defined value and does not need to be
+	// set.  https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,agent-bootstrap-tokens"`
+
+	// Description is a meaningful description of the purpose of the token.
+	Description string `jsonapi:"attribute" json:"description"`
+
+	// ExpiresAt optionally sets the time at which the token expires. Defaults
+	// to an hour from now, and cannot be more than a day from now.
+	ExpiresAt *time.Time `jsonapi:"attribute" json:"expires-at,omitempty"`
+
+	// MaxUses optionally sets the maximum number of agents that can register
+	// using the token.
+	MaxUses *int `jsonapi:"attribute" json:"max-uses,omitempty"`
+}
@@ -10,6 +10,14 @@ type AgentPool struct {
 	AgentCount         int    `jsonapi:"attribute" json:"agent-count"`
 	OrganizationScoped bool   `jsonapi:"attribute" json:"organization-scoped"`
 
+	// Version constraint restricting the terraform versions of runs the
+	// pool's agents execute. Nil if any version is allowed. OTF extension.
+	AllowedTerraformVersions *string `jsonapi:"attribute" json:"allowed-terraform-versions"`
+
+	// Minimum number of agents that must be ready before jobs are allocated
+	// to the pool's agents. Zero if disabled. OTF extension.
+	MinAgents int `jsonapi:"attribute" json:"min-agents"`
+
 	// Relations
 	Organization      *Organization `jsonapi:"relationship" json:"organization"`
 	Workspaces        []*Workspace  `jsonapi:"relationship" json:"workspaces"`
@@ -30,6 +38,15 @@ type AgentPoolCreateOptions struct {
 	// True if the agent pool is organization scoped, false otherwise.
 	OrganizationScoped *bool `jsonapi:"attribute" json:"organization-scoped,omitempty"`
 
+	// Version constraint restricting the terraform versions of runs the
+	// pool's agents execute, e.g. ">= 1.5, < 1.8". OTF extension.
+	AllowedTerraformVersions *string `jsonapi:"attribute" json:"allowed-terraform-versions,omitempty"`
+
+	// Minimum number of agents that must be ready before jobs are allocated
+	// to the pool's agents. Defaults to 0, which disables the minimum. OTF
+	// extension.
+	MinAgents *int `jsonapi:"attribute" json:"min-agents,omitempty"`
+
 	// List of workspaces that are associated with an agent pool.
 	AllowedWorkspaces []*Workspace `jsonapi:"relationship" json:"allowed-workspaces,omitempty"`
 }
@@ -48,6 +65,16 @@ type AgentPoolUpdateOptions struct {
 	// True if the agent pool is organization scoped, false otherwise.
 	OrganizationScoped *bool `jsonapi:"attribute" json:"organization-scoped,omitempty"`
 
+	// A new version constraint restricting the terraform versions of runs
+	// the pool's agents execute. An empty string removes the constraint. OTF
+	// extension.
+	AllowedTerraformVersions *string `jsonapi:"attribute" json:"allowed-terraform-versions,omitempty"`
+
+	// A new minimum number of agents that must be ready before jobs are
+	// allocated to the pool's agents. Zero disables the minimum. OTF
+	// extension.
+	MinAgents *int `jsonapi:"attribute" json:"min-agents,omitempty"`
+
 	// A new list of workspaces that are associated with an agent pool.
 	AllowedWorkspaces []*Workspace `jsonapi:"relationship" json:"allowed-workspaces,omitempty"`
 }
@@ -0,0 +1,56 @@
+package types
+
+import "time"
+
+// AgentPoolAutoscaler represents the autoscaling configuration of an agent
+// pool. The HMAC secret is write-only and is never returned.
+type AgentPoolAutoscaler struct {
+	ID                   string    `jsonapi:"primary,agent-pool-autoscalers"`
+	WebhookURL           string    `jsonapi:"attribute" json:"webhook-url"`
+	ScaleUpThreshold     int       `jsonapi:"attribute" json:"scale-up-threshold"`
+	ScaleDownIdleSeconds int       `jsonapi:"attribute" json:"scale-down-idle-seconds"`
+	CooldownSeconds      int       `jsonapi:"attribute" json:"cooldown-seconds"`
+	DryRun               bool      `jsonapi:"attribute" json:"dry-run"`
+	CreatedAt            time.Time `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt            time.Time `jsonapi:"attribute" json:"updated-at"`
+}
+
+// AgentPoolAutoscalerOptions represents the options for configuring the
+// autoscaling of an agent pool.
+type AgentPoolAutoscalerOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,agent-pool-autoscalers"`
+
+	// Required: URL to which scaling decisions are POSTed.
+	WebhookURL *string `jsonapi:"attribute" json:"webhook-url"`
+	// Secret with which requests to the webhook are signed. Required when
+	// first configuring autoscaling; if omitted thereafter the existing
+	// secret is retained.
+	HMACSecret *string `jsonapi:"attribute" json:"hmac-secret,omitempty"`
+	// Number of unallocated jobs at or above which more agents are desired.
+	ScaleUpThreshold *int `jsonapi:"attribute" json:"scale-up-threshold,omitempty"`
+	// Number of seconds an agent must be idle before fewer agents are
+	// desired.
+	ScaleDownIdleSeconds *int `jsonapi:"attribute" json:"scale-down-idle-seconds,omitempty"`
+	// Minimum number of seconds between scaling decisions.
+	CooldownSeconds *int `jsonapi:"attribute" json:"cooldown-seconds,omitempty"`
+	// Only record and log scaling decisions, without calling the webhook.
+	DryRun *bool `jsonapi:"attribute" json:"dry-run,omitempty"`
+}
+
+// AgentPoolScalingDecision is a decision by the autoscaler that an agent pool
+// needs more or fewer agents.
+type AgentPoolScalingDecision struct {
+	ID              string    `jsonapi:"primary,agent-pool-scaling-decisions"`
+	AgentPoolID     string    `jsonapi:"attribute" json:"agent-pool-id"`
+	CurrentAgents   int       `jsonapi:"attribute" json:"current-agents"`
+	UnallocatedJobs int       `jsonapi:"attribute" json:"unallocated-jobs"`
+	IdleAgents      int       `jsonapi:"attribute" json:"idle-agents"`
+	DesiredDelta    int       `jsonapi:"attribute" json:"desired-delta"`
+	DryRun          bool      `jsonapi:"attribute" json:"dry-run"`
+	Error           *string   `jsonapi:"attribute" json:"error"`
+	DecidedAt       time.Time `jsonapi:"attribute" json:"decided-at"`
+}
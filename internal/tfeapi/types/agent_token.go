@@ -12,6 +12,9 @@ type AgentToken struct {
 	Description string    `jsonapi:"attribute" json:"description"`
 	LastUsedAt  time.Time `jsonapi:"attribute" json:"last-used-at"`
 	Token       string    `jsonapi:"attribute" json:"token"`
+
+	// OTF
+	ExpiresAt *time.Time `jsonapi:"attribute" json:"expires-at"`
 }
 
 // AgentTokenCreateOptions represents the options for creating a new otf agent token.
@@ -24,4 +27,7 @@ type AgentTokenCreateOptions struct {
 	// Description is a meaningful description of the purpose of the agent
 	// token.
 	Description string `jsonapi:"attribute" json:"description"`
+
+	// OTF: ExpiresAt optionally sets the time at which the token expires.
+	ExpiresAt *time.Time `jsonapi:"attribute" json:"expires-at,omitempty"`
 }
@@ -0,0 +1,39 @@
+package types
+
+import "time"
+
+// AllocatorStatus represents the status recorded by the job allocator at the
+// end of its most recent allocation pass.
+type AllocatorStatus struct {
+	ID              string                `jsonapi:"primary,allocator-statuses"`
+	LastAllocatedAt time.Time             `jsonapi:"attribute" json:"last-allocated-at"`
+	Paused          bool                  `jsonapi:"attribute" json:"paused"`
+	PendingJobs     []AllocatorPendingJob `jsonapi:"attribute" json:"pending-jobs"`
+	Pools           []AllocatorPool       `jsonapi:"attribute" json:"pools"`
+}
+
+// AllocatorPendingJob is a job that the allocator was unable to allocate to an
+// agent.
+type AllocatorPendingJob struct {
+	RunID        string  `json:"run-id"`
+	Phase        string  `json:"phase"`
+	Status       string  `json:"status"`
+	AgentPoolID  *string `json:"agent-pool-id"`
+	Organization string  `json:"organization"`
+	WorkspaceID  string  `json:"workspace-id"`
+	// Reason the job was not allocated: one of maintenance_mode, no_agents,
+	// agents_at_capacity, or insufficient_disk.
+	Reason      string `json:"reason"`
+	Explanation string `json:"explanation"`
+}
+
+// AllocatorPool is the number of agents available to an agent pool. The
+// server agents are represented by a pool with a nil ID.
+type AllocatorPool struct {
+	AgentPoolID *string `json:"agent-pool-id"`
+	Name        string  `json:"name"`
+	// Number of agents ready to accept jobs.
+	Agents int `json:"agents"`
+	// Number of agents with spare capacity for another job.
+	CandidateAgents int `json:"candidate-agents"`
+}
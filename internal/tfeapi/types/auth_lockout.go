@@ -0,0 +1,16 @@
+package types
+
+import "time"
+
+// AuthLockout represents a client locked out after too many failed
+// authentication attempts.
+type AuthLockout struct {
+	// ID is of the form <kind>:<value>, e.g. ip:192.0.2.1 or
+	// username:site-admin.
+	ID          string    `jsonapi:"primary,auth-lockouts"`
+	Kind        string    `jsonapi:"attribute" json:"kind"`
+	Value       string    `jsonapi:"attribute" json:"value"`
+	Failures    int       `jsonapi:"attribute" json:"failures"`
+	CreatedAt   time.Time `jsonapi:"attribute" json:"created-at"`
+	LockedUntil time.Time `jsonapi:"attribute" json:"locked-until"`
+}
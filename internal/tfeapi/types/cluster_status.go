@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+// ClusterStatus reports the health of processes shared between the replicas of
+// a cluster.
+type ClusterStatus struct {
+	ID                  string     `jsonapi:"primary,cluster-statuses"`
+	UpgradeCheckEnabled bool       `jsonapi:"attribute" json:"upgrade-check-enabled"`
+	UpgradeCheckHealthy bool       `jsonapi:"attribute" json:"upgrade-check-healthy"`
+	UpgradeCheckedAt    *time.Time `jsonapi:"attribute" json:"upgrade-checked-at"`
+	// UpgradeCheckInterval is the expected interval between checks, in
+	// seconds.
+	UpgradeCheckInterval int `jsonapi:"attribute" json:"upgrade-check-interval"`
+}
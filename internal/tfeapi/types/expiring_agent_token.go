@@ -0,0 +1,16 @@
+package types
+
+import "time"
+
+// ExpiringAgentToken is an agent token that is nearing expiry, or has already
+// expired.
+type ExpiringAgentToken struct {
+	ID            string     `jsonapi:"primary,expiring-authentication-tokens"`
+	Description   string     `jsonapi:"attribute" json:"description"`
+	AgentPoolID   string     `jsonapi:"attribute" json:"agent-pool-id"`
+	AgentPoolName string     `jsonapi:"attribute" json:"agent-pool-name"`
+	CreatedAt     time.Time  `jsonapi:"attribute" json:"created-at"`
+	ExpiresAt     time.Time  `jsonapi:"attribute" json:"expires-at"`
+	LastUsedAt    *time.Time `jsonapi:"attribute" json:"last-used-at"`
+	Expired       bool       `jsonapi:"attribute" json:"expired"`
+}
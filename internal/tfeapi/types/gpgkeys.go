@@ -13,6 +13,9 @@ type GPGKey struct {
 	SourceURL      *string `jsonapi:"attribute" json:"source-url"`
 	TrustSignature string  `jsonapi:"attribute" json:"trust-signature"`
 	UpdatedAt      string  `jsonapi:"attribute" json:"updated-at,omitempty"`
+	// RevokedAt is a tofutf extension: a revoked key can no longer be used to
+	// publish artifacts.
+	RevokedAt *string `jsonapi:"attribute" json:"revoked-at,omitempty"`
 }
 
 // GPGKeyCreateOptions represents all the available options used to create a GPG key.
@@ -0,0 +1,18 @@
+package types
+
+// JobCounts represents the numbers of jobs in each status in an organization.
+type JobCounts struct {
+	// ID is the name of the organization.
+	ID     string         `jsonapi:"primary,job-counts"`
+	Counts map[string]int `jsonapi:"attribute" json:"counts"`
+	// Pools is only included if a breakdown by agent pool is requested.
+	Pools []JobCountsPool `jsonapi:"attribute" json:"pools,omitempty"`
+}
+
+// JobCountsPool represents the numbers of jobs in each status assigned to an
+// agent pool. Jobs for the server agents are represented by a pool with a nil
+// ID.
+type JobCountsPool struct {
+	AgentPoolID *string        `json:"agent-pool-id"`
+	Counts      map[string]int `json:"counts"`
+}
@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+// MaintenanceMode represents the site-wide maintenance mode.
+type MaintenanceMode struct {
+	ID        string     `jsonapi:"primary,maintenance-modes"`
+	Enabled   bool       `jsonapi:"attribute" json:"enabled"`
+	Active    bool       `jsonapi:"attribute" json:"active"`
+	Message   string     `jsonapi:"attribute" json:"message"`
+	StartsAt  *time.Time `jsonapi:"attribute" json:"starts-at"`
+	EndsAt    *time.Time `jsonapi:"attribute" json:"ends-at"`
+	UpdatedAt time.Time  `jsonapi:"attribute" json:"updated-at"`
+	UpdatedBy string     `jsonapi:"attribute" json:"updated-by"`
+}
+
+// MaintenanceModeUpdateOptions represents the options for updating the
+// maintenance mode. The existing settings are replaced.
+type MaintenanceModeUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,maintenance-modes"`
+
+	// Whether maintenance mode is enabled.
+	Enabled bool `jsonapi:"attribute" json:"enabled"`
+
+	// Optional: A message explaining the pause to users.
+	Message string `jsonapi:"attribute" json:"message,omitempty"`
+
+	// Optional: The time at which maintenance mode automatically takes effect.
+	StartsAt *time.Time `jsonapi:"attribute" json:"starts-at,omitempty"`
+
+	// Optional: The time at which maintenance mode automatically ends.
+	EndsAt *time.Time `jsonapi:"attribute" json:"ends-at,omitempty"`
+}
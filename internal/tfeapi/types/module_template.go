@@ -0,0 +1,115 @@
+package types
+
+import "time"
+
+// ModuleTemplate represents a version of a registry module from which
+// workspaces can be provisioned.
+type ModuleTemplate struct {
+	ID             string                   `jsonapi:"primary,module-templates"`
+	ModuleID       string                   `jsonapi:"attribute" json:"module-id"`
+	ModuleName     string                   `jsonapi:"attribute" json:"module-name"`
+	ModuleProvider string                   `jsonapi:"attribute" json:"module-provider"`
+	Version        string                   `jsonapi:"attribute" json:"version"`
+	Source         string                   `jsonapi:"attribute" json:"source"`
+	Variables      []ModuleTemplateVariable `jsonapi:"attribute" json:"variables"`
+	CreatedAt      time.Time                `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt      time.Time                `jsonapi:"attribute" json:"updated-at"`
+
+	// Relations
+	Organization *Organization `jsonapi:"relationship" json:"organization"`
+}
+
+// ModuleTemplateVariable is a variable for which a value is collected when
+// provisioning a workspace from a module template.
+type ModuleTemplateVariable struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Type        string  `json:"type,omitempty"`
+	Default     *string `json:"default,omitempty"`
+	Required    bool    `json:"required"`
+}
+
+// ModuleTemplateCreateOptions represents the options for marking a module
+// version as a template.
+type ModuleTemplateCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,module-templates"`
+
+	// Required: The ID of the registry module.
+	ModuleID string `jsonapi:"attribute" json:"module-id"`
+
+	// Required: The version of the registry module.
+	Version string `jsonapi:"attribute" json:"version"`
+
+	// Optional: The variables for which values are collected. Defaults to the
+	// module's input variables.
+	Variables []ModuleTemplateVariable `jsonapi:"attribute" json:"variables,omitempty"`
+}
+
+// ModuleTemplateUpdateOptions represents the options for updating a module
+// template.
+type ModuleTemplateUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,module-templates"`
+
+	// Optional: The version of the registry module.
+	Version *string `jsonapi:"attribute" json:"version,omitempty"`
+
+	// Optional: The variables for which values are collected.
+	Variables []ModuleTemplateVariable `jsonapi:"attribute" json:"variables,omitempty"`
+}
+
+// ModuleTemplateWorkspace represents a workspace provisioned from a module
+// template.
+type ModuleTemplateWorkspace struct {
+	ID               string                        `jsonapi:"primary,module-template-workspaces"`
+	Name             string                        `jsonapi:"attribute" json:"name"`
+	Version          string                        `jsonapi:"attribute" json:"version"`
+	UpgradeAvailable bool                          `jsonapi:"attribute" json:"upgrade-available"`
+	Variables        []ModuleTemplateVariableValue `jsonapi:"attribute" json:"variables"`
+
+	// Relations
+	ModuleTemplate *ModuleTemplate `jsonapi:"relationship" json:"module-template"`
+}
+
+// ModuleTemplateVariableValue is a value for a module template variable.
+type ModuleTemplateVariableValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ModuleTemplateProvisionOptions represents the options for provisioning a
+// workspace from a module template.
+type ModuleTemplateProvisionOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,module-template-workspaces"`
+
+	// Required: The name of the workspace.
+	Name string `jsonapi:"attribute" json:"name"`
+
+	// Optional: The values for the template's variables.
+	Variables []ModuleTemplateVariableValue `jsonapi:"attribute" json:"variables,omitempty"`
+}
+
+// ModuleTemplateUpgradeOptions represents the options for upgrading a
+// workspace to the module template's current version.
+type ModuleTemplateUpgradeOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,module-template-workspaces"`
+
+	// Optional: Values for variables introduced by the newer module version,
+	// or overrides for existing values.
+	Variables []ModuleTemplateVariableValue `jsonapi:"attribute" json:"variables,omitempty"`
+}
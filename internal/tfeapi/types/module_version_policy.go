@@ -0,0 +1,53 @@
+package types
+
+import "time"
+
+// ModuleVersionPolicy represents a policy restricting the versions of a
+// registry module that runs in an organization are permitted to use.
+type ModuleVersionPolicy struct {
+	ID          string    `jsonapi:"primary,module-version-policies"`
+	Module      string    `jsonapi:"attribute" json:"module"`
+	Constraints string    `jsonapi:"attribute" json:"constraints"`
+	Enforcement string    `jsonapi:"attribute" json:"enforcement"`
+	CreatedAt   time.Time `jsonapi:"attribute" json:"created-at"`
+	UpdatedAt   time.Time `jsonapi:"attribute" json:"updated-at"`
+
+	// Relations
+	Organization *Organization `jsonapi:"relationship" json:"organization"`
+}
+
+// ModuleVersionPolicyCreateOptions represents the options for creating a
+// module version policy.
+type ModuleVersionPolicyCreateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,module-version-policies"`
+
+	// Required: The address of the registry module, e.g.
+	// hashicorp/consul/aws.
+	Module string `jsonapi:"attribute" json:"module"`
+
+	// Required: The permitted versions of the module, e.g. "~> 1.2".
+	Constraints string `jsonapi:"attribute" json:"constraints"`
+
+	// Optional: Either warn or fail. Defaults to fail.
+	Enforcement *string `jsonapi:"attribute" json:"enforcement,omitempty"`
+}
+
+// ModuleVersionPolicyUpdateOptions represents the options for updating a
+// module version policy.
+type ModuleVersionPolicyUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,module-version-policies"`
+
+	// Optional: The permitted versions of the module.
+	Constraints *string `jsonapi:"attribute" json:"constraints,omitempty"`
+
+	// Optional: Either warn or fail.
+	Enforcement *string `jsonapi:"attribute" json:"enforcement,omitempty"`
+}
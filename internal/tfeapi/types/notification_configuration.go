@@ -16,6 +16,10 @@ const (
 	NotificationTriggerAssessmentDrifted     NotificationTriggerType = "assessment:drifted"
 	NotificationTriggerAssessmentFailed      NotificationTriggerType = "assessment:failed"
 	NotificationTriggerAssessmentCheckFailed NotificationTriggerType = "assessment:check_failure"
+
+	// NotificationTriggerCommented is an OTF extension, triggered when a run
+	// is commented on.
+	NotificationTriggerCommented NotificationTriggerType = "run:commented"
 )
 
 // NotificationDestinationType represents the destination type of the
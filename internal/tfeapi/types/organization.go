@@ -34,6 +34,29 @@ type Organization struct {
 	// On those TFE versions, safe delete does not exist, so ALL deletes will be force deletes.
 	AllowForceDeleteWorkspaces bool `jsonapi:"attribute" json:"allow-force-delete-workspaces"`
 
+	// OTF-specific: deletion protection applied to new workspaces by default.
+	DefaultDeletionProtection bool `jsonapi:"attribute" json:"default-deletion-protection"`
+
+	// OTF-specific: regular expressions matching values to be masked in run logs.
+	LogRedactionPatterns []string `jsonapi:"attribute" json:"log-redaction-patterns"`
+
+	// OTF-specific: minutes a job may wait to be allocated to an agent before
+	// breaching the SLA; zero disables the SLA.
+	QueueTimeSLA int `jsonapi:"attribute" json:"queue-time-sla"`
+
+	// OTF-specific: how workspace names are compared: sensitive, lowercase or
+	// insensitive.
+	WorkspaceNameCase string `jsonapi:"attribute" json:"workspace-name-case"`
+
+	// OTF-specific: maximum number of agent pools permitted in the
+	// organization; zero means no limit. Null if the site-wide default
+	// applies.
+	MaxAgentPools *int `jsonapi:"attribute" json:"max-agent-pools"`
+
+	// OTF-specific: maximum number of incomplete high priority runs
+	// permitted in the organization; zero means no limit.
+	MaxHighPriorityRuns int `jsonapi:"attribute" json:"max-high-priority-runs"`
+
 	// Relations
 	// DefaultProject *Project `jsonapi:"relation,default-project"`
 }
@@ -88,6 +111,24 @@ type OrganizationCreateOptions struct {
 
 	// Optional: AllowForceDeleteWorkspaces toggles behavior of allowing workspace admins to delete workspaces with resources under management.
 	AllowForceDeleteWorkspaces *bool `jsonapi:"attribute" json:"allow-force-delete-workspaces,omitempty"`
+
+	// Optional: DefaultDeletionProtection sets whether new workspaces are created with deletion protection enabled.
+	DefaultDeletionProtection *bool `jsonapi:"attribute" json:"default-deletion-protection,omitempty"`
+
+	// Optional: LogRedactionPatterns sets regular expressions matching values to be masked in run logs.
+	LogRedactionPatterns []string `jsonapi:"attribute" json:"log-redaction-patterns,omitempty"`
+
+	// Optional: QueueTimeSLA sets the minutes a job may wait to be allocated to an agent before breaching the SLA; zero disables the SLA.
+	QueueTimeSLA *int `jsonapi:"attribute" json:"queue-time-sla,omitempty"`
+
+	// Optional: WorkspaceNameCase sets how workspace names are compared: sensitive, lowercase or insensitive.
+	WorkspaceNameCase *string `jsonapi:"attribute" json:"workspace-name-case,omitempty"`
+
+	// Optional: MaxAgentPools sets the maximum number of agent pools permitted in the organization, overriding the site-wide default; zero means no limit.
+	MaxAgentPools *int `jsonapi:"attribute" json:"max-agent-pools,omitempty"`
+
+	// Optional: MaxHighPriorityRuns sets the maximum number of incomplete high priority runs permitted in the organization; zero means no limit.
+	MaxHighPriorityRuns *int `jsonapi:"attribute" json:"max-high-priority-runs,omitempty"`
 }
 
 // OrganizationUpdateOptions represents the options for updating an organization.
@@ -127,6 +168,24 @@ type OrganizationUpdateOptions struct {
 
 	// Optional: AllowForceDeleteWorkspaces toggles behavior of allowing workspace admins to delete workspaces with resources under management.
 	AllowForceDeleteWorkspaces *bool `jsonapi:"attribute" json:"allow-force-delete-workspaces,omitempty"`
+
+	// Optional: DefaultDeletionProtection sets whether new workspaces are created with deletion protection enabled.
+	DefaultDeletionProtection *bool `jsonapi:"attribute" json:"default-deletion-protection,omitempty"`
+
+	// Optional: LogRedactionPatterns sets regular expressions matching values to be masked in run logs.
+	LogRedactionPatterns []string `jsonapi:"attribute" json:"log-redaction-patterns,omitempty"`
+
+	// Optional: QueueTimeSLA sets the minutes a job may wait to be allocated to an agent before breaching the SLA; zero disables the SLA.
+	QueueTimeSLA *int `jsonapi:"attribute" json:"queue-time-sla,omitempty"`
+
+	// Optional: WorkspaceNameCase sets how workspace names are compared: sensitive, lowercase or insensitive.
+	WorkspaceNameCase *string `jsonapi:"attribute" json:"workspace-name-case,omitempty"`
+
+	// Optional: MaxAgentPools sets the maximum number of agent pools permitted in the organization, overriding the site-wide default; zero means no limit.
+	MaxAgentPools *int `jsonapi:"attribute" json:"max-agent-pools,omitempty"`
+
+	// Optional: MaxHighPriorityRuns sets the maximum number of incomplete high priority runs permitted in the organization; zero means no limit.
+	MaxHighPriorityRuns *int `jsonapi:"attribute" json:"max-high-priority-runs,omitempty"`
 }
 
 // Entitlements represents the entitlements of an organization. Unlike TFE/TFC,
@@ -0,0 +1,11 @@
+package types
+
+// OrphanedJob is a job whose run no longer exists, and which has been deleted
+// by the orphaned job reconciler.
+type OrphanedJob struct {
+	ID      string  `jsonapi:"primary,orphaned-jobs"`
+	RunID   string  `jsonapi:"attribute" json:"run-id"`
+	Phase   string  `jsonapi:"attribute" json:"phase"`
+	Status  string  `jsonapi:"attribute" json:"status"`
+	AgentID *string `jsonapi:"attribute" json:"agent-id"`
+}
@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+// QueueSLABreach is a job that waited longer than its organization's queue
+// time SLA to be allocated to an agent.
+type QueueSLABreach struct {
+	ID          string     `jsonapi:"primary,queue-sla-breaches"`
+	RunID       string     `jsonapi:"attribute" json:"run-id"`
+	Phase       string     `jsonapi:"attribute" json:"phase"`
+	Status      string     `jsonapi:"attribute" json:"status"`
+	WorkspaceID string     `jsonapi:"attribute" json:"workspace-id"`
+	AgentPoolID *string    `jsonapi:"attribute" json:"agent-pool-id"`
+	CreatedAt   time.Time  `jsonapi:"attribute" json:"created-at"`
+	AllocatedAt *time.Time `jsonapi:"attribute" json:"allocated-at"`
+	BreachedAt  time.Time  `jsonapi:"attribute" json:"breached-at"`
+	// Time spent waiting to be allocated, or, if the job is yet to be
+	// allocated, the time spent waiting so far.
+	QueueTimeSeconds int `jsonapi:"attribute" json:"queue-time-seconds"`
+}
@@ -29,6 +29,18 @@ type Run struct {
 	TargetAddrs            []string             `jsonapi:"attribute" json:"target-addrs,omitempty"`
 	TerraformVersion       string               `jsonapi:"attribute" json:"terraform-version"`
 	Variables              []RunVariable        `jsonapi:"attribute" json:"variables"`
+	Labels                 map[string]string    `jsonapi:"attribute" json:"labels,omitempty"`
+	SkipPreflight          bool                 `jsonapi:"attribute" json:"skip-preflight"`
+	DebugLogging           bool                 `jsonapi:"attribute" json:"debug-logging"`
+	// ErrorCategory classifies why the run errored. OTF extension.
+	ErrorCategory string `jsonapi:"attribute" json:"error-category,omitempty"`
+	// ParentRunID is the ID of the run from which the run was created, i.e.
+	// the run of which it is a canary, or the run proceeding to a full apply
+	// after its canary. OTF extension.
+	ParentRunID *string `jsonapi:"attribute" json:"parent-run-id,omitempty"`
+	// Priority is the priority of the run, either normal or high. OTF
+	// extension.
+	Priority string `jsonapi:"attribute" json:"priority"`
 
 	// Relations
 	Apply                *Apply                `jsonapi:"relationship" json:"apply"`
@@ -149,6 +161,55 @@ type RunCreateOptions struct {
 	// Variables allows you to specify terraform input variables for
 	// a particular run, prioritized over variables defined on the workspace.
 	Variables []*RunVariable `jsonapi:"attribute" json:"variables,omitempty"`
+
+	// Labels are arbitrary key-value pairs attached to the run, for
+	// correlating the run with other systems. OTF extension.
+	Labels map[string]string `jsonapi:"attribute" json:"labels,omitempty"`
+
+	// SkipPreflight bypasses the workspace's pre-flight checks of its cloud
+	// credentials. OTF extension.
+	SkipPreflight *bool `jsonapi:"attribute" json:"skip-preflight,omitempty"`
+
+	// DebugLogging enables or disables terraform's debug logging for the run,
+	// overriding the workspace's debug logging setting. OTF extension.
+	DebugLogging *bool `jsonapi:"attribute" json:"debug-logging,omitempty"`
+
+	// Priority sets the priority of the run, either normal or high, the
+	// latter requiring permission to create high priority runs. Defaults to
+	// normal. OTF extension.
+	Priority *string `jsonapi:"attribute" json:"priority,omitempty"`
+}
+
+// RunLabelsUpdateOptions represents the options for replacing the labels of a
+// run. OTF extension.
+type RunLabelsUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to set the resource type via
+	// the field tag.  It is not a user-defined value and does not need to be
+	// set.  https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,runs"`
+
+	// Labels replace the existing labels of the run.
+	Labels map[string]string `jsonapi:"attribute" json:"labels"`
+}
+
+// RunCanaryOptions represents the options for creating a canary run from a
+// planned run. OTF extension.
+type RunCanaryOptions struct {
+	// Type is a public field utilized by JSON:API to set the resource type via
+	// the field tag.  It is not a user-defined value and does not need to be
+	// set.  https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,runs"`
+
+	// TargetAddrs are the addresses of the planned resource changes to apply
+	// in the canary run.
+	TargetAddrs []string `jsonapi:"attribute" json:"target-addrs"`
+}
+
+// RunForceCancelOptions represents the options for forceably canceling a run.
+type RunForceCancelOptions struct {
+	// Comment is the reason for forceably canceling the run. Required by OTF,
+	// whereas it is optional in TFC.
+	Comment *string `json:"comment,omitempty"`
 }
 
 // RunListOptions represents the options for listing runs.
@@ -184,6 +245,14 @@ type RunListOptions struct {
 	// or as constants with the RunOperation string type.
 	Operation string `schema:"filter[operation],omitempty"`
 
+	// Optional: Filter runs by a label, in the format key:value. OTF
+	// extension.
+	Label string `schema:"filter[label],omitempty"`
+
+	// Optional: Comma-separated list of acceptable error categories of
+	// errored runs, e.g. init,provider_auth. OTF extension.
+	ErrorCategory string `schema:"filter[error_category],omitempty"`
+
 	// Optional: A list of relations to include. See available resources:
 	// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run#available-related-resources
 	Include []string `schema:"include,omitempty"`
@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+// SubscriptionStats reports the current subscriptions to one of the server's
+// event brokers. The ID is the database table whose events the broker
+// forwards.
+type SubscriptionStats struct {
+	ID                 string     `jsonapi:"primary,subscription-stats"`
+	Subscribers        int        `jsonapi:"attribute" json:"subscribers"`
+	OldestSubscribedAt *time.Time `jsonapi:"attribute" json:"oldest-subscribed-at"`
+	// Age of the oldest subscription in seconds; zero if there are no
+	// subscriptions.
+	OldestSubscriptionAgeSeconds int `jsonapi:"attribute" json:"oldest-subscription-age-seconds"`
+}
@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+// TerraformVersionPolicy represents the site-wide policy governing which
+// terraform versions workspaces may use.
+type TerraformVersionPolicy struct {
+	ID             string    `jsonapi:"primary,terraform-version-policies"`
+	MinVersion     string    `jsonapi:"attribute" json:"min-version"`
+	MaxVersion     string    `jsonapi:"attribute" json:"max-version"`
+	DefaultVersion string    `jsonapi:"attribute" json:"default-version"`
+	UpdatedAt      time.Time `jsonapi:"attribute" json:"updated-at"`
+	UpdatedBy      string    `jsonapi:"attribute" json:"updated-by"`
+}
+
+// TerraformVersionPolicyUpdateOptions represents the options for updating the
+// terraform version policy. The existing settings are replaced.
+type TerraformVersionPolicyUpdateOptions struct {
+	// Type is a public field utilized by JSON:API to
+	// set the resource type via the field tag.
+	// It is not a user-defined value and does not need to be set.
+	// https://jsonapi.org/format/#crud-creating
+	Type string `jsonapi:"primary,terraform-version-policies"`
+
+	// Optional: The oldest terraform version workspaces may use.
+	MinVersion string `jsonapi:"attribute" json:"min-version,omitempty"`
+
+	// Optional: The newest terraform version workspaces may use.
+	MaxVersion string `jsonapi:"attribute" json:"max-version,omitempty"`
+
+	// Optional: The terraform version assigned to new workspaces that don't
+	// specify a version.
+	DefaultVersion string `jsonapi:"attribute" json:"default-version,omitempty"`
+}
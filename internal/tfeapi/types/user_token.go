@@ -0,0 +1,32 @@
+package types
+
+import "time"
+
+// UserToken represents a user's API token.
+type UserToken struct {
+	ID          string    `jsonapi:"primary,authentication-tokens"`
+	CreatedAt   time.Time `jsonapi:"attribute" json:"created-at"`
+	Description string    `jsonapi:"attribute" json:"description"`
+	// Token is only populated upon creation.
+	Token string `jsonapi:"attribute" json:"token,omitempty"`
+	// Scopes restrict the token to a subset of the user's permissions. Nil
+	// permits all of the user's permissions.
+	Scopes []string `jsonapi:"attribute" json:"scopes"`
+}
+
+// UserTokenCreateOptions contains the options for creating a user token.
+type UserTokenCreateOptions struct {
+	Description string `jsonapi:"attribute" json:"description"`
+	// Optional: restrict the token to these scopes, each either a coarse
+	// scope, e.g. runs:write, or the name of an individual action, e.g.
+	// GetRunAction.
+	Scopes []string `jsonapi:"attribute" json:"scopes,omitempty"`
+}
+
+// TokenScope represents a coarse scope to which a token can be restricted.
+type TokenScope struct {
+	// ID is the name of the scope, e.g. runs:write.
+	ID string `jsonapi:"primary,token-scopes"`
+	// Actions are the names of the actions the scope permits.
+	Actions []string `jsonapi:"attribute" json:"actions"`
+}
@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+// VersionStatus reports the version of tofutf that is running and whether a
+// newer version is available.
+type VersionStatus struct {
+	ID               string     `jsonapi:"primary,versions"`
+	CurrentVersion   string     `jsonapi:"attribute" json:"current-version"`
+	LatestVersion    string     `jsonapi:"attribute" json:"latest-version"`
+	ChangelogURL     string     `jsonapi:"attribute" json:"changelog-url"`
+	UpgradeAvailable bool       `jsonapi:"attribute" json:"upgrade-available"`
+	CheckEnabled     bool       `jsonapi:"attribute" json:"check-enabled"`
+	CheckedAt        *time.Time `jsonapi:"attribute" json:"checked-at"`
+}
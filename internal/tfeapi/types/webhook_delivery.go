@@ -0,0 +1,27 @@
+package types
+
+import "time"
+
+// WebhookDelivery represents a request received from a VCS provider on a
+// webhook.
+type WebhookDelivery struct {
+	ID         string    `jsonapi:"primary,webhook-deliveries"`
+	RepoPath   string    `jsonapi:"attribute" json:"repo-path,omitempty"`
+	ReceivedAt time.Time `jsonapi:"attribute" json:"received-at"`
+	Status     string    `jsonapi:"attribute" json:"status"`
+	Error      *string   `jsonapi:"attribute" json:"error"`
+	ReplayOf   *string   `jsonapi:"attribute" json:"replay-of"`
+	Replayable bool      `jsonapi:"attribute" json:"replayable"`
+	// TriggerDecisions are the decisions whether to trigger runs in each of
+	// the workspaces connected to the repo.
+	TriggerDecisions []WebhookDeliveryTriggerDecision `jsonapi:"attribute" json:"trigger-decisions"`
+}
+
+// WebhookDeliveryTriggerDecision is the decision whether to trigger a run in a
+// workspace in response to a webhook delivery.
+type WebhookDeliveryTriggerDecision struct {
+	WorkspaceID   string `json:"workspace-id"`
+	WorkspaceName string `json:"workspace-name"`
+	Triggered     bool   `json:"triggered"`
+	Reason        string `json:"reason"`
+}
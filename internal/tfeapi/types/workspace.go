@@ -17,19 +17,25 @@ type Workspace struct {
 	Actions                    *WorkspaceActions     `jsonapi:"attribute" json:"actions"`
 	AgentPoolID                string                `jsonapi:"attribute" json:"agent-pool-id"`
 	AllowDestroyPlan           bool                  `jsonapi:"attribute" json:"allow-destroy-plan"`
+	ApplyAgentPoolID           string                `jsonapi:"attribute" json:"apply-agent-pool-id"`
+	ApplyWindows               []ApplyWindow         `jsonapi:"attribute" json:"apply-windows"`
 	AutoApply                  bool                  `jsonapi:"attribute" json:"auto-apply"`
 	CanQueueDestroyPlan        bool                  `jsonapi:"attribute" json:"can-queue-destroy-plan"`
 	CreatedAt                  time.Time             `jsonapi:"attribute" json:"created-at"`
+	DeletionProtected          bool                  `jsonapi:"attribute" json:"deletion-protected"`
 	Description                string                `jsonapi:"attribute" json:"description"`
 	Environment                string                `jsonapi:"attribute" json:"environment"`
 	ExecutionMode              string                `jsonapi:"attribute" json:"execution-mode"`
 	FileTriggersEnabled        bool                  `jsonapi:"attribute" json:"file-triggers-enabled"`
 	GlobalRemoteState          bool                  `jsonapi:"attribute" json:"global-remote-state"`
+	LogScrubbingDisabled       bool                  `jsonapi:"attribute" json:"log-scrubbing-disabled"`
 	Locked                     bool                  `jsonapi:"attribute" json:"locked"`
 	MigrationEnvironment       string                `jsonapi:"attribute" json:"migration-environment"`
 	Name                       string                `jsonapi:"attribute" json:"name"`
 	Operations                 bool                  `jsonapi:"attribute" json:"operations"`
 	Permissions                *WorkspacePermissions `jsonapi:"attribute" json:"permissions"`
+	PlanAgentPoolID            string                `jsonapi:"attribute" json:"plan-agent-pool-id"`
+	PreflightChecks            []string              `jsonapi:"attribute" json:"preflight-checks"`
 	QueueAllRuns               bool                  `jsonapi:"attribute" json:"queue-all-runs"`
 	SpeculativeEnabled         bool                  `jsonapi:"attribute" json:"speculative-enabled"`
 	SourceName                 string                `jsonapi:"attribute" json:"source-name"`
@@ -70,6 +76,19 @@ type WorkspaceList struct {
 }
 
 // VCSRepo contains the configuration of a VCS integration.
+// ApplyWindow is a weekly recurring period during which runs on a workspace
+// may be applied.
+type ApplyWindow struct {
+	// Days of the week on which the window opens, e.g. monday.
+	Days []string `json:"days"`
+	// Time of day, HH:MM, at which the window opens.
+	Start string `json:"start"`
+	// Time of day, HH:MM, at which the window closes.
+	End string `json:"end"`
+	// IANA timezone in which start and end are expressed. Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
 type VCSRepo struct {
 	Branch            string `json:"branch"`
 	DisplayIdentifier string `json:"display-identifier"`
@@ -136,12 +155,26 @@ type WorkspaceCreateOptions struct {
 	// set to true.
 	AgentPoolID *string `jsonapi:"attribute" json:"agent-pool-id,omitempty"`
 
+	// Optional: The IDs of agent pools overriding agent-pool-id for the plan
+	// and apply phases respectively. Only permitted when execution-mode is set
+	// to agent.
+	PlanAgentPoolID  *string `jsonapi:"attribute" json:"plan-agent-pool-id,omitempty"`
+	ApplyAgentPoolID *string `jsonapi:"attribute" json:"apply-agent-pool-id,omitempty"`
+
 	// Whether destroy plans can be queued on the workspace.
 	AllowDestroyPlan *bool `jsonapi:"attribute" json:"allow-destroy-plan,omitempty"`
 
+	// Optional: Windows during which runs may be applied. Runs confirmed
+	// outside of a window wait until the next window opens.
+	ApplyWindows []ApplyWindow `jsonapi:"attribute" json:"apply-windows,omitempty"`
+
 	// Whether to automatically apply changes when a Terraform plan is successful.
 	AutoApply *bool `jsonapi:"attribute" json:"auto-apply,omitempty"`
 
+	// Whether the workspace is protected from deletion. Protection must be
+	// cleared before the workspace can be deleted.
+	DeletionProtected *bool `jsonapi:"attribute" json:"deletion-protected,omitempty"`
+
 	// A description for the workspace.
 	Description *string `jsonapi:"attribute" json:"description,omitempty"`
 
@@ -159,6 +192,10 @@ type WorkspaceCreateOptions struct {
 
 	GlobalRemoteState *bool `jsonapi:"attribute" json:"global-remote-state,omitempty"`
 
+	// Whether to disable the masking of secrets in run logs. Only intended for
+	// debugging.
+	LogScrubbingDisabled *bool `jsonapi:"attribute" json:"log-scrubbing-disabled,omitempty"`
+
 	// The legacy TFE environment to use as the source of the migration, in the
 	// form organization/environment. Omit this unless you are migrating a legacy
 	// environment.
@@ -173,6 +210,11 @@ type WorkspaceCreateOptions struct {
 	// Use ExecutionMode instead.
 	Operations *bool `jsonapi:"attribute" json:"operations,omitempty"`
 
+	// Optional: Cloud provider credentials to validate before a plan is
+	// enqueued, one or more of aws, gcp, and azure. Runs with invalid
+	// credentials fail before they are allocated to an agent.
+	PreflightChecks []string `jsonapi:"attribute" json:"preflight-checks,omitempty"`
+
 	// Organization the workspace belongs to. Required.
 	Organization *string `schema:"organization_name"`
 
@@ -242,12 +284,26 @@ type WorkspaceUpdateOptions struct {
 	// set to true.
 	AgentPoolID *string `jsonapi:"attribute" json:"agent-pool-id,omitempty"`
 
+	// Optional: The IDs of agent pools overriding agent-pool-id for the plan
+	// and apply phases respectively. Only permitted when execution-mode is set
+	// to agent. An empty string removes the override.
+	PlanAgentPoolID  *string `jsonapi:"attribute" json:"plan-agent-pool-id,omitempty"`
+	ApplyAgentPoolID *string `jsonapi:"attribute" json:"apply-agent-pool-id,omitempty"`
+
 	// Whether destroy plans can be queued on the workspace.
 	AllowDestroyPlan *bool `jsonapi:"attribute" json:"allow-destroy-plan,omitempty"`
 
+	// Optional: Windows during which runs may be applied. Runs confirmed
+	// outside of a window wait until the next window opens.
+	ApplyWindows []ApplyWindow `jsonapi:"attribute" json:"apply-windows,omitempty"`
+
 	// Whether to automatically apply changes when a Terraform plan is successful.
 	AutoApply *bool `jsonapi:"attribute" json:"auto-apply,omitempty"`
 
+	// Whether the workspace is protected from deletion. Protection must be
+	// cleared before the workspace can be deleted.
+	DeletionProtected *bool `jsonapi:"attribute" json:"deletion-protected,omitempty"`
+
 	// A new name for the workspace, which can only include letters, numbers, -,
 	// and _. This will be used as an identifier and must be unique in the
 	// organization. Warning: Changing a workspace's name changes its URL in the
@@ -271,10 +327,19 @@ type WorkspaceUpdateOptions struct {
 
 	GlobalRemoteState *bool `jsonapi:"attribute" json:"global-remote-state,omitempty"`
 
+	// Whether to disable the masking of secrets in run logs. Only intended for
+	// debugging.
+	LogScrubbingDisabled *bool `jsonapi:"attribute" json:"log-scrubbing-disabled,omitempty"`
+
 	// DEPRECATED. Whether the workspace will use remote or local execution mode.
 	// Use ExecutionMode instead.
 	Operations *bool `jsonapi:"attribute" json:"operations,omitempty"`
 
+	// Optional: Cloud provider credentials to validate before a plan is
+	// enqueued, one or more of aws, gcp, and azure. Runs with invalid
+	// credentials fail before they are allocated to an agent.
+	PreflightChecks []string `jsonapi:"attribute" json:"preflight-checks,omitempty"`
+
 	// Whether to queue all runs. Unless this is set to true, runs triggered by
 	// a webhook will not be queued until at least one run is manually queued.
 	QueueAllRuns *bool `jsonapi:"attribute" json:"queue-all-runs,omitempty"`
@@ -16,6 +16,7 @@ import (
 	otfapi "github.com/tofutf/tofutf/internal/api"
 	"github.com/tofutf/tofutf/internal/http/html"
 	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/lockout"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 	"google.golang.org/api/idtoken"
 )
@@ -30,8 +31,14 @@ var AuthenticatedPrefixes = []string{
 	tfeapi.APIPrefixV2,
 	tfeapi.APIPrefixV1,
 	tfeapi.ModuleV1Prefix,
+	// provider registry endpoints serve providers from organizations' private
+	// registries
+	tfeapi.ProviderV1Prefix,
 	otfapi.DefaultBasePath,
 	paths.UIPrefix,
+	// SCIM endpoints (the scim package cannot be imported without an import
+	// cycle)
+	"/scim/",
 }
 
 type (
@@ -40,10 +47,19 @@ type (
 
 		logger *slog.Logger
 		key    jwk.Key
+		// limiter is optional
+		limiter limiter
 
 		*registry
 	}
 
+	// limiter limits failed attempts to authenticate.
+	limiter interface {
+		NewAttempt(r *http.Request, username string) lockout.Attempt
+		Check(ctx context.Context, attempt lockout.Attempt) error
+		Fail(ctx context.Context, attempt lockout.Attempt)
+	}
+
 	GoogleIAPConfig struct {
 		Audience string
 	}
@@ -60,6 +76,9 @@ type (
 // 2. If Google IAP header is present then authenticate its token and allow or deny
 // accordingly.
 // 3. If Bearer token is present then authenticate it and allow or deny accordingly.
+// The token may instead be provided as the password of basic auth credentials,
+// which is the only means of authentication supported by terraform's HTTP
+// backend.
 // 4. If requested path is for a UI endpoint then check for session cookie. If
 // present then authenticate its token. If cookie is missing or authentication fails
 // then redirect user to login page.
@@ -68,6 +87,14 @@ type (
 // Where authentication succeeds, the authenticated subject is attached to the request
 // context and the upstream handler is called. If the authenticated subject is a
 // user and the user does not exist the user is first created.
+//
+// Clients that repeatedly fail to authenticate a bearer token or basic auth
+// credentials are delayed and then locked out, responding with 429. Because a
+// token identifies its subject only once it has been verified, attempts are
+// tracked by IP address alone, and so agents and jobs are never locked out on
+// account of a username. Nor does a successful attempt forget previous failed
+// attempts, lest a client holding a valid token use it to keep guessing the
+// site token.
 func newMiddleware(opts middlewareOptions) mux.MiddlewareFunc {
 	mw := middleware{middlewareOptions: opts}
 
@@ -94,9 +121,24 @@ func newMiddleware(opts middlewareOptions) mux.MiddlewareFunc {
 					http.Error(w, err.Error(), http.StatusUnauthorized)
 					return
 				}
+			} else if _, password, ok := r.BasicAuth(); ok {
+				if !mw.permitted(w, r) {
+					return
+				}
+				subject, err = mw.validateToken(ctx, password)
+				if err != nil {
+					mw.failed(r)
+					mw.logger.Error("validating basic auth token", "err", err)
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+					return
+				}
 			} else if bearer := r.Header.Get("Authorization"); bearer != "" {
+				if !mw.permitted(w, r) {
+					return
+				}
 				subject, err = mw.validateBearer(ctx, bearer)
 				if err != nil {
+					mw.failed(r)
 					mw.logger.Error("validating bearer token", "err", err)
 					http.Error(w, err.Error(), http.StatusUnauthorized)
 					return
@@ -118,6 +160,29 @@ func newMiddleware(opts middlewareOptions) mux.MiddlewareFunc {
 	}
 }
 
+// permitted determines whether the client is permitted to attempt to
+// authenticate, responding with an error if not.
+func (m *middleware) permitted(w http.ResponseWriter, r *http.Request) bool {
+	if m.limiter == nil {
+		return true
+	}
+	attempt := m.limiter.NewAttempt(r, "")
+	if err := m.limiter.Check(r.Context(), attempt); err != nil {
+		m.logger.Warn("rejected authentication attempt", "ip", attempt.IP, "err", err)
+		lockout.Reject(w, err)
+		return false
+	}
+	return true
+}
+
+// failed records a failed attempt to authenticate.
+func (m *middleware) failed(r *http.Request) {
+	if m.limiter == nil {
+		return
+	}
+	m.limiter.Fail(r.Context(), m.limiter.NewAttempt(r, ""))
+}
+
 func (m *middleware) validateIAPToken(ctx context.Context, token string) (internal.Subject, error) {
 	payload, err := idtoken.Validate(ctx, token, m.Audience)
 	if err != nil {
@@ -135,12 +200,13 @@ func (m *middleware) validateBearer(ctx context.Context, bearer string) (interna
 	if len(splitToken) != 2 {
 		return nil, fmt.Errorf("malformed bearer token")
 	}
-	token := splitToken[1]
+	return m.validateToken(ctx, splitToken[1])
+}
 
+func (m *middleware) validateToken(ctx context.Context, token string) (internal.Subject, error) {
 	if m.SiteToken != "" && m.SiteToken == token {
 		return m.SiteAdmin, nil
 	}
-	//
 	// parse jwt and verify signature
 	parsed, err := jwt.Parse([]byte(token), jwt.WithKey(jwa.HS256, m.key))
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/lockout"
 	"github.com/tofutf/tofutf/internal/testutils"
 )
 
@@ -53,6 +54,23 @@ func TestMiddleware(t *testing.T) {
 		assert.Equal(t, 200, w.Code, w.Body.String())
 	})
 
+	t.Run("valid API token as basic auth password", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		token := newTestJWT(t, secret, Kind("test-kind"), time.Hour)
+		r.SetBasicAuth("terraform", token)
+		w := httptest.NewRecorder()
+		fakeTokenMiddleware(t, secret)(wantSubjectHandler(t, &internal.Superuser{})).ServeHTTP(w, r)
+		assert.Equal(t, 200, w.Code, w.Body.String())
+	})
+
+	t.Run("invalid basic auth password", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		r.SetBasicAuth("terraform", "incorrect")
+		w := httptest.NewRecorder()
+		fakeTokenMiddleware(t, secret)(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 401, w.Code)
+	})
+
 	t.Run("invalid jwt", func(t *testing.T) {
 		differentSecret := testutils.NewSecret(t)
 		token := newTestJWT(t, differentSecret, Kind("test-kind"), time.Hour)
@@ -63,6 +81,39 @@ func TestMiddleware(t *testing.T) {
 		assert.Equal(t, 401, w.Code)
 	})
 
+	t.Run("failed attempts are limited", func(t *testing.T) {
+		limiter := &fakeLimiter{}
+		mw := fakeLimitedSiteTokenMiddleware(t, "site-token", limiter)
+
+		r := httptest.NewRequest("GET", "/api/v2/protected", nil)
+		r.Header.Add("Authorization", "Bearer incorrect")
+		w := httptest.NewRecorder()
+		mw(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 401, w.Code)
+		assert.Equal(t, 1, limiter.failures)
+
+		// token kind is unknown before verification so attempt is only
+		// identified by IP address
+		assert.Equal(t, lockout.Attempt{IP: "192.0.2.1"}, limiter.last)
+
+		// whilst delayed even the correct token is rejected
+		limiter.delayed = true
+		r = httptest.NewRequest("GET", "/api/v2/protected", nil)
+		r.Header.Add("Authorization", "Bearer site-token")
+		w = httptest.NewRecorder()
+		mw(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 429, w.Code)
+		assert.Equal(t, "5", w.Header().Get("Retry-After"))
+
+		limiter.delayed = false
+		r = httptest.NewRequest("GET", "/api/v2/protected", nil)
+		r.SetBasicAuth("terraform", "site-token")
+		w = httptest.NewRecorder()
+		mw(emptyHandler).ServeHTTP(w, r)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, 1, limiter.failures)
+	})
+
 	t.Run("valid user session", func(t *testing.T) {
 		r := httptest.NewRequest("GET", "/app/protected", nil)
 		token := newTestJWT(t, secret, Kind("test-kind"), time.Hour)
@@ -7,12 +7,15 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/lockout"
 	"github.com/tofutf/tofutf/internal/testutils"
 	"github.com/tofutf/tofutf/internal/xslog"
 	"google.golang.org/api/idtoken"
@@ -77,6 +80,43 @@ func fakeSiteTokenMiddleware(t *testing.T, token string) mux.MiddlewareFunc {
 	})
 }
 
+func fakeLimitedSiteTokenMiddleware(t *testing.T, token string, limiter limiter) mux.MiddlewareFunc {
+	t.Helper()
+
+	key := newTestJWK(t, testutils.NewSecret(t)) // not used but constructor requires it
+	return newMiddleware(middlewareOptions{
+		logger:   slog.New(&xslog.NoopHandler{}),
+		registry: &registry{SiteToken: token, SiteAdmin: &internal.Superuser{}},
+		key:      key,
+		limiter:  limiter,
+	})
+}
+
+// fakeLimiter records failed attempts, and delays all attempts whilst delayed
+// is true.
+type fakeLimiter struct {
+	delayed  bool
+	failures int
+	last     lockout.Attempt
+}
+
+func (f *fakeLimiter) NewAttempt(r *http.Request, username string) lockout.Attempt {
+	host, _, _ := strings.Cut(r.RemoteAddr, ":")
+	return lockout.Attempt{IP: host, Username: username}
+}
+
+func (f *fakeLimiter) Check(context.Context, lockout.Attempt) error {
+	if f.delayed {
+		return &lockout.LockedError{RetryAfter: 5 * time.Second}
+	}
+	return nil
+}
+
+func (f *fakeLimiter) Fail(_ context.Context, attempt lockout.Attempt) {
+	f.failures++
+	f.last = attempt
+}
+
 func fakeIAPMiddleware(t *testing.T, aud string) mux.MiddlewareFunc {
 	t.Helper()
 
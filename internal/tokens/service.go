@@ -6,6 +6,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/lockout"
 )
 
 type (
@@ -24,6 +25,9 @@ type (
 
 		Logger *slog.Logger
 		Secret []byte
+		// LockoutService optionally delays and locks out clients that
+		// repeatedly fail to authenticate.
+		LockoutService *lockout.Service
 	}
 )
 
@@ -41,12 +45,16 @@ func NewService(opts Options) (*Service, error) {
 	svc.registry = &registry{
 		kinds: make(map[Kind]SubjectGetter),
 	}
-	svc.middleware = newMiddleware(middlewareOptions{
+	mwOpts := middlewareOptions{
 		logger:          opts.Logger,
 		GoogleIAPConfig: opts.GoogleIAPConfig,
 		key:             key,
 		registry:        svc.registry,
-	})
+	}
+	if opts.LockoutService != nil {
+		mwOpts.limiter = opts.LockoutService
+	}
+	svc.middleware = newMiddleware(mwOpts)
 	return &svc, nil
 }
 
@@ -0,0 +1,120 @@
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+)
+
+// requestTimeout is the maximum length of time a query of the release feed is
+// permitted to take.
+const requestTimeout = 30 * time.Second
+
+type (
+	// Checker periodically queries the release feed for the latest release of
+	// tofutf, recording the result in the database.
+	Checker struct {
+		Logger *slog.Logger
+
+		endpoint string
+		interval time.Duration
+		client   *http.Client
+		db       checkerDB
+	}
+
+	checkerDB interface {
+		get(ctx context.Context) (*release, *time.Time, error)
+		upsert(ctx context.Context, r release, checkedAt time.Time) error
+	}
+)
+
+func newChecker(logger *slog.Logger, db checkerDB, endpoint string) *Checker {
+	// clone the default transport in order to respect the standard proxy
+	// environment variables.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	return &Checker{
+		Logger:   logger,
+		endpoint: endpoint,
+		interval: DefaultInterval,
+		client:   &http.Client{Transport: transport, Timeout: requestTimeout},
+		db:       db,
+	}
+}
+
+// Start starts the checker. Should be invoked in a go routine.
+func (c *Checker) Start(ctx context.Context) error {
+	// check at startup and then every interval. Failures are logged rather
+	// than returned, because an unreachable release feed is no reason to
+	// restart the checker.
+	c.checkIfDue(ctx)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.checkIfDue(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// checkIfDue queries the release feed unless it has been queried within the
+// last interval, e.g. by another instance before restarting.
+func (c *Checker) checkIfDue(ctx context.Context) {
+	now := internal.CurrentTimestamp(nil)
+	_, checkedAt, err := c.db.get(ctx)
+	if err != nil {
+		c.Logger.Error("retrieving last upgrade check", "err", err)
+		return
+	}
+	if checkedAt != nil && checkedAt.After(now.Add(-c.interval)) {
+		return
+	}
+	latest, err := c.check(ctx)
+	if err != nil {
+		c.Logger.Error("checking for upgrade", "endpoint", c.endpoint, "err", err)
+		return
+	}
+	if err := c.db.upsert(ctx, latest, now); err != nil {
+		c.Logger.Error("recording upgrade check", "err", err)
+		return
+	}
+	c.Logger.Info("checked for upgrade", "current_version", internal.Version, "latest_version", latest.Version)
+}
+
+// check queries the release feed for the latest release.
+func (c *Checker) check(ctx context.Context) (release, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint, nil)
+	if err != nil {
+		return release{}, err
+	}
+	req.Header.Set("User-Agent", "tofutf-upgrade-check/"+internal.Version)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return release{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return release{}, fmt.Errorf("%s returned non-200 status code: %s", c.endpoint, resp.Status)
+	}
+	var latest struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&latest); err != nil {
+		return release{}, fmt.Errorf("decoding release feed: %w", err)
+	}
+	if latest.TagName == "" {
+		return release{}, fmt.Errorf("release feed is missing a tag name")
+	}
+	return release{Version: latest.TagName, ChangelogURL: latest.HTMLURL}, nil
+}
@@ -0,0 +1,73 @@
+package upgrade
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestChecker(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.True(t, strings.HasPrefix(r.Header.Get("User-Agent"), "tofutf-upgrade-check/"))
+		w.Write([]byte(`{"tag_name":"v0.2.0","html_url":"https://github.com/tofutf/tofutf/releases/tag/v0.2.0"}`)) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	db := &fakeCheckerDB{}
+	checker := newChecker(slog.New(&xslog.NoopHandler{}), db, srv.URL)
+
+	checker.checkIfDue(context.Background())
+	require.NotNil(t, db.release)
+	assert.Equal(t, release{Version: "v0.2.0", ChangelogURL: "https://github.com/tofutf/tofutf/releases/tag/v0.2.0"}, *db.release)
+	assert.Equal(t, 1, requests)
+
+	// the feed is not queried again until the interval has elapsed
+	checker.checkIfDue(context.Background())
+	assert.Equal(t, 1, requests)
+
+	db.checkedAt = time.Now().Add(-DefaultInterval - time.Minute)
+	checker.checkIfDue(context.Background())
+	assert.Equal(t, 2, requests)
+}
+
+func TestChecker_Unavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	db := &fakeCheckerDB{}
+	checker := newChecker(slog.New(&xslog.NoopHandler{}), db, srv.URL)
+
+	// failure is not recorded as a check
+	checker.checkIfDue(context.Background())
+	assert.Nil(t, db.release)
+}
+
+type fakeCheckerDB struct {
+	release   *release
+	checkedAt time.Time
+}
+
+func (f *fakeCheckerDB) get(context.Context) (*release, *time.Time, error) {
+	if f.release == nil {
+		return nil, nil, nil
+	}
+	return f.release, &f.checkedAt, nil
+}
+
+func (f *fakeCheckerDB) upsert(_ context.Context, r release, checkedAt time.Time) error {
+	f.release = &r
+	f.checkedAt = checkedAt
+	return nil
+}
@@ -0,0 +1,58 @@
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+// pgdb is the upgrade check database on postgres
+type pgdb struct {
+	*sql.Pool // provides access to generated SQL queries
+}
+
+func (db *pgdb) upsert(ctx context.Context, r release, checkedAt time.Time) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertUpgradeCheck(ctx, pggen.UpsertUpgradeCheckParams{
+			LatestVersion: sql.String(r.Version),
+			ChangelogURL:  sql.String(r.ChangelogURL),
+			CheckedAt:     sql.Timestamptz(checkedAt),
+		})
+		return err
+	})
+}
+
+// get retrieves the result of the most recent upgrade check. If a check has
+// never been made then nil is returned for both the release and the time of
+// the check.
+func (db *pgdb) get(ctx context.Context) (*release, *time.Time, error) {
+	type result struct {
+		release   *release
+		checkedAt *time.Time
+	}
+	res, err := sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (result, error) {
+		row, err := q.FindUpgradeCheck(ctx)
+		if err != nil {
+			err = sql.Error(err)
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				return result{}, nil
+			}
+			return result{}, err
+		}
+		return result{
+			release: &release{
+				Version:      row.LatestVersion.String,
+				ChangelogURL: row.ChangelogURL.String,
+			},
+			checkedAt: internal.Time(row.CheckedAt.Time.UTC()),
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.release, res.checkedAt, nil
+}
@@ -0,0 +1,102 @@
+package upgrade
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+type (
+	Service struct {
+		logger *slog.Logger
+
+		site    internal.Authorizer
+		db      *pgdb
+		tfeapi  *tfe
+		enabled bool
+	}
+
+	Options struct {
+		Logger *slog.Logger
+		// Enabled enables periodic checks for upgrades. Unless enabled, no
+		// requests are made to the release feed.
+		Enabled bool
+
+		*sql.Pool
+		*tfeapi.Responder
+	}
+)
+
+func NewService(opts Options) *Service {
+	svc := Service{
+		logger:  opts.Logger,
+		site:    &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:      &pgdb{opts.Pool},
+		enabled: opts.Enabled,
+	}
+	svc.tfeapi = &tfe{
+		Service:   &svc,
+		Responder: opts.Responder,
+	}
+	return &svc
+}
+
+func (s *Service) AddHandlers(r *mux.Router) {
+	s.tfeapi.addHandlers(r)
+}
+
+// Enabled determines whether upgrade checks are enabled.
+func (s *Service) Enabled() bool { return s.enabled }
+
+// NewChecker constructs a checker that periodically checks for upgrades.
+func (s *Service) NewChecker(logger *slog.Logger) *Checker {
+	return newChecker(logger.With("component", "upgrade-checker"), s.db, DefaultEndpoint)
+}
+
+// Get retrieves the upgrade status. Only a site admin may retrieve the
+// status.
+func (s *Service) Get(ctx context.Context) (*Status, error) {
+	if _, err := s.site.CanAccess(ctx, rbac.GetUpgradeStatusAction, ""); err != nil {
+		return nil, err
+	}
+	status := &Status{
+		CurrentVersion: internal.Version,
+		CheckEnabled:   s.enabled,
+	}
+	if !s.enabled {
+		return status, nil
+	}
+	latest, checkedAt, err := s.db.get(ctx)
+	if err != nil {
+		s.logger.Error("retrieving upgrade status", "err", err)
+		return nil, err
+	}
+	if latest != nil {
+		status.LatestVersion = latest.Version
+		status.ChangelogURL = latest.ChangelogURL
+		status.CheckedAt = checkedAt
+	}
+	return status, nil
+}
+
+// GetCheckHealth reports whether upgrade checks are being made across the
+// cluster. Only a site admin may retrieve the health of checks.
+func (s *Service) GetCheckHealth(ctx context.Context) (*CheckHealth, error) {
+	if _, err := s.site.CanAccess(ctx, rbac.GetUpgradeStatusAction, ""); err != nil {
+		return nil, err
+	}
+	if !s.enabled {
+		return newCheckHealth(false, nil, DefaultInterval, internal.CurrentTimestamp(nil)), nil
+	}
+	_, checkedAt, err := s.db.get(ctx)
+	if err != nil {
+		s.logger.Error("retrieving upgrade check health", "err", err)
+		return nil, err
+	}
+	return newCheckHealth(true, checkedAt, DefaultInterval, internal.CurrentTimestamp(nil)), nil
+}
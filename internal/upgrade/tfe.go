@@ -0,0 +1,61 @@
+package upgrade
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/tfeapi/types"
+)
+
+type tfe struct {
+	*Service
+	*tfeapi.Responder
+}
+
+func (a *tfe) addHandlers(r *mux.Router) {
+	r = r.PathPrefix(tfeapi.APIPrefixV1).Subrouter()
+
+	r.HandleFunc("/admin/version", a.getVersion).Methods("GET")
+	r.HandleFunc("/admin/cluster/status", a.getClusterStatus).Methods("GET")
+}
+
+func (a *tfe) getVersion(w http.ResponseWriter, r *http.Request) {
+	status, err := a.Service.Get(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toVersionStatus(status), http.StatusOK)
+}
+
+func (a *tfe) toVersionStatus(from *Status) *types.VersionStatus {
+	return &types.VersionStatus{
+		ID:               "site",
+		CurrentVersion:   from.CurrentVersion,
+		LatestVersion:    from.LatestVersion,
+		ChangelogURL:     from.ChangelogURL,
+		UpgradeAvailable: from.UpgradeAvailable(),
+		CheckEnabled:     from.CheckEnabled,
+		CheckedAt:        from.CheckedAt,
+	}
+}
+
+func (a *tfe) getClusterStatus(w http.ResponseWriter, r *http.Request) {
+	health, err := a.Service.GetCheckHealth(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, a.toClusterStatus(health), http.StatusOK)
+}
+
+func (a *tfe) toClusterStatus(from *CheckHealth) *types.ClusterStatus {
+	return &types.ClusterStatus{
+		ID:                   "cluster",
+		UpgradeCheckEnabled:  from.CheckEnabled,
+		UpgradeCheckHealthy:  from.Healthy,
+		UpgradeCheckedAt:     from.CheckedAt,
+		UpgradeCheckInterval: int(from.Interval.Seconds()),
+	}
+}
@@ -0,0 +1,85 @@
+// Package upgrade checks for newer releases of tofutf.
+package upgrade
+
+import (
+	"time"
+
+	"github.com/tofutf/tofutf/internal/semver"
+)
+
+const (
+	// LockID guarantees only one upgrade checker on a cluster is running at
+	// any time.
+	LockID int64 = 5577006791947779418
+
+	// DefaultEndpoint is the release feed queried for the latest release of
+	// tofutf.
+	DefaultEndpoint = "https://api.github.com/repos/tofutf/tofutf/releases/latest"
+
+	// DefaultInterval is the default frequency with which the release feed
+	// is queried.
+	DefaultInterval = 24 * time.Hour
+)
+
+type (
+	// Status reports the version of tofutf that is running and the latest
+	// release found by the most recent upgrade check.
+	Status struct {
+		CurrentVersion string
+		// LatestVersion is empty if the release feed has yet to be checked.
+		LatestVersion string
+		ChangelogURL  string
+		CheckedAt     *time.Time
+		// CheckEnabled is false if upgrade checks are disabled.
+		CheckEnabled bool
+	}
+
+	// CheckHealth reports whether upgrade checks are being made across the
+	// cluster. Only one replica checks at any one time, recording the result
+	// in the database, so the health of the cluster is determined from the
+	// most recent check recorded by any replica.
+	CheckHealth struct {
+		// CheckEnabled is false if upgrade checks are disabled.
+		CheckEnabled bool
+		// CheckedAt is when the most recent successful check was made; nil if
+		// a check has yet to succeed.
+		CheckedAt *time.Time
+		// Interval is the expected frequency of checks.
+		Interval time.Duration
+		// Healthy is true if a successful check was made within the expected
+		// interval.
+		Healthy bool
+	}
+
+	// release is a release of tofutf found in the release feed.
+	release struct {
+		Version      string
+		ChangelogURL string
+	}
+)
+
+// UpgradeAvailable determines whether the latest release is newer than the
+// running version. A build without a valid version, such as a development
+// build, is never deemed upgradeable.
+func (s *Status) UpgradeAvailable() bool {
+	if !semver.IsValid(s.CurrentVersion) || !semver.IsValid(s.LatestVersion) {
+		return false
+	}
+	return semver.Compare(s.LatestVersion, s.CurrentVersion) > 0
+}
+
+// newCheckHealth determines the health of upgrade checks from the time of the
+// most recent successful check. A checker skips a check if one was made within
+// the last interval, so up to two intervals may elapse between checks before
+// they are deemed to be stale.
+func newCheckHealth(enabled bool, checkedAt *time.Time, interval time.Duration, now time.Time) *CheckHealth {
+	health := &CheckHealth{
+		CheckEnabled: enabled,
+		CheckedAt:    checkedAt,
+		Interval:     interval,
+	}
+	if enabled && checkedAt != nil {
+		health.Healthy = checkedAt.After(now.Add(-2 * interval))
+	}
+	return health
+}
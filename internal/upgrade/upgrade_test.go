@@ -0,0 +1,54 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestStatus_UpgradeAvailable(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		latest  string
+		want    bool
+	}{
+		{"newer release", "v0.1.0", "v0.2.0", true},
+		{"newer release without v prefix", "0.1.0", "v0.1.1", true},
+		{"same release", "v0.2.0", "v0.2.0", false},
+		{"older release", "v0.3.0", "v0.2.0", false},
+		{"not yet checked", "v0.1.0", "", false},
+		{"development build", "unknown", "v0.2.0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &Status{CurrentVersion: tt.current, LatestVersion: tt.latest}
+			assert.Equal(t, tt.want, status.UpgradeAvailable())
+		})
+	}
+}
+
+func TestNewCheckHealth(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		enabled   bool
+		checkedAt *time.Time
+		want      bool
+	}{
+		{"checked within interval", true, internal.Time(now.Add(-time.Hour)), true},
+		{"check skipped by another replica", true, internal.Time(now.Add(-DefaultInterval - time.Hour)), true},
+		{"stale", true, internal.Time(now.Add(-2*DefaultInterval - time.Minute)), false},
+		{"not yet checked", true, nil, false},
+		{"disabled", false, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := newCheckHealth(tt.enabled, tt.checkedAt, DefaultInterval, now)
+			assert.Equal(t, tt.want, health.Healthy)
+			assert.Equal(t, tt.checkedAt, health.CheckedAt)
+		})
+	}
+}
@@ -30,6 +30,7 @@ func (a *api) addHandlers(r *mux.Router) {
 
 	r.HandleFunc("/teams/{team_id}/relationships/users", a.addTeamMembers).Methods("POST")
 	r.HandleFunc("/teams/{team_id}/relationships/users", a.removeTeamMembers).Methods("DELETE")
+	r.HandleFunc("/teams/{team_id}/relationships/users", a.setTeamMembers).Methods("PUT")
 }
 
 func (a *api) createUser(w http.ResponseWriter, r *http.Request) {
@@ -77,6 +78,14 @@ func (a *api) removeTeamMembers(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (a *api) setTeamMembers(w http.ResponseWriter, r *http.Request) {
+	if err := a.modifyTeamMembers(r, setTeamMembersAction); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (a *api) modifyTeamMembers(r *http.Request, action teamMembersAction) error {
 	teamID, err := decode.Param("team_id", r)
 	if err != nil {
@@ -93,6 +102,8 @@ func (a *api) modifyTeamMembers(r *http.Request, action teamMembersAction) error
 		return a.AddTeamMembership(r.Context(), teamID, opts.Usernames)
 	case removeTeamMembersAction:
 		return a.RemoveTeamMembership(r.Context(), teamID, opts.Usernames)
+	case setTeamMembersAction:
+		return a.SetTeamMembership(r.Context(), teamID, opts.Usernames)
 	default:
 		return fmt.Errorf("unknown team membership action: %v", action)
 	}
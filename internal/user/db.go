@@ -13,21 +13,31 @@ import (
 
 // dbresult represents the result of a database query for a user.
 type dbresult struct {
-	UserID    pgtype.Text        `json:"user_id"`
-	Username  pgtype.Text        `json:"username"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	SiteAdmin pgtype.Bool        `json:"site_admin"`
-	Teams     []pggen.Teams      `json:"teams"`
+	UserID      pgtype.Text        `json:"user_id"`
+	Username    pgtype.Text        `json:"username"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	SiteAdmin   pgtype.Bool        `json:"site_admin"`
+	Email       pgtype.Text        `json:"email"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	Deactivated pgtype.Bool        `json:"deactivated"`
+	Teams       []pggen.Teams      `json:"teams"`
 }
 
 func (result dbresult) toUser() *User {
 	user := User{
-		ID:        result.UserID.String,
-		CreatedAt: result.CreatedAt.Time.UTC(),
-		UpdatedAt: result.UpdatedAt.Time.UTC(),
-		Username:  result.Username.String,
-		SiteAdmin: result.SiteAdmin.Bool,
+		ID:          result.UserID.String,
+		CreatedAt:   result.CreatedAt.Time.UTC(),
+		UpdatedAt:   result.UpdatedAt.Time.UTC(),
+		Username:    result.Username.String,
+		SiteAdmin:   result.SiteAdmin.Bool,
+		Deactivated: result.Deactivated.Bool,
+	}
+	if result.Email.Valid {
+		user.Email = &result.Email.String
+	}
+	if result.ExternalID.Valid {
+		user.ExternalID = &result.ExternalID.String
 	}
 	for _, tr := range result.Teams {
 		user.Teams = append(user.Teams, team.TeamRow(tr).ToTeam())
@@ -45,10 +55,12 @@ type pgdb struct {
 func (db *pgdb) CreateUser(ctx context.Context, user *User) error {
 	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.InsertUser(ctx, pggen.InsertUserParams{
-			ID:        sql.String(user.ID),
-			Username:  sql.String(user.Username),
-			CreatedAt: sql.Timestamptz(user.CreatedAt),
-			UpdatedAt: sql.Timestamptz(user.UpdatedAt),
+			ID:         sql.String(user.ID),
+			Username:   sql.String(user.Username),
+			CreatedAt:  sql.Timestamptz(user.CreatedAt),
+			UpdatedAt:  sql.Timestamptz(user.UpdatedAt),
+			Email:      sql.StringPtr(user.Email),
+			ExternalID: sql.StringPtr(user.ExternalID),
 		})
 		if err != nil {
 			return sql.Error(err)
@@ -132,12 +144,66 @@ func (db *pgdb) getUser(ctx context.Context, spec UserSpec) (*User, error) {
 				return nil, sql.Error(err)
 			}
 			return dbresult(result).toUser(), nil
+		} else if spec.ExternalID != nil {
+			result, err := q.FindUserByExternalID(ctx, sql.String(*spec.ExternalID))
+			if err != nil {
+				return nil, sql.Error(err)
+			}
+			return dbresult(result).toUser(), nil
+		} else if spec.Email != nil {
+			result, err := q.FindUserByEmail(ctx, sql.String(normalizeEmail(*spec.Email)))
+			if err != nil {
+				return nil, sql.Error(err)
+			}
+			return dbresult(result).toUser(), nil
 		} else {
 			return nil, fmt.Errorf("unsupported user spec for retrieving user")
 		}
 	})
 }
 
+// updateUser retrieves a user and passes it to fn for updating, persisting
+// the updated user.
+func (db *pgdb) updateUser(ctx context.Context, spec UserSpec, fn func(context.Context, *User) error) (*User, error) {
+	var user *User
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) (err error) {
+		user, err = db.getUser(ctx, spec)
+		if err != nil {
+			return err
+		}
+		if err := fn(ctx, user); err != nil {
+			return err
+		}
+		_, err = q.UpdateUser(ctx, pggen.UpdateUserParams{
+			UserID:      sql.String(user.ID),
+			Username:    sql.String(user.Username),
+			Email:       sql.StringPtr(user.Email),
+			ExternalID:  sql.StringPtr(user.ExternalID),
+			Deactivated: sql.Bool(user.Deactivated),
+			UpdatedAt:   sql.Timestamptz(user.UpdatedAt),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+	return user, err
+}
+
+// revokeAccess deletes a user's API tokens and removes the user from all
+// teams.
+func (db *pgdb) revokeAccess(ctx context.Context, username string) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		if _, err := q.DeleteTokensByUsername(ctx, sql.String(username)); err != nil {
+			return sql.Error(err)
+		}
+		if _, err := q.DeleteTeamMembershipsByUsername(ctx, sql.String(username)); err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
 func (db *pgdb) addTeamMembership(ctx context.Context, teamID string, usernames ...string) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
 		_, err := q.InsertTeamMembership(ctx, usernames, sql.String(teamID))
@@ -160,6 +226,31 @@ func (db *pgdb) removeTeamMembership(ctx context.Context, teamID string, usernam
 	})
 }
 
+// setTeamMembership authoritatively sets the members of a team, adding and
+// removing members as necessary.
+func (db *pgdb) setTeamMembership(ctx context.Context, teamID string, usernames ...string) error {
+	return db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		rows, err := q.FindUsersByTeamID(ctx, sql.String(teamID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		current := make([]string, len(rows))
+		for i, r := range rows {
+			current[i] = r.Username.String
+		}
+		return sql.Reconcile(ctx, current, usernames,
+			func(ctx context.Context, usernames []string) error {
+				_, err := q.InsertTeamMembership(ctx, usernames, sql.String(teamID))
+				return sql.Error(err)
+			},
+			func(ctx context.Context, usernames []string) error {
+				_, err := q.DeleteTeamMembership(ctx, usernames, sql.String(teamID))
+				return sql.Error(err)
+			},
+		)
+	})
+}
+
 // DeleteUser deletes a user from the DB.
 func (db *pgdb) DeleteUser(ctx context.Context, spec UserSpec) error {
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
@@ -233,6 +324,7 @@ func (db *pgdb) createUserToken(ctx context.Context, token *UserToken) error {
 			Description: sql.String(token.Description),
 			Username:    sql.String(token.Username),
 			CreatedAt:   sql.Timestamptz(token.CreatedAt),
+			Scopes:      token.Scopes,
 		})
 		return err
 	})
@@ -252,6 +344,7 @@ func (db *pgdb) listUserTokens(ctx context.Context, username string) ([]*UserTok
 				CreatedAt:   row.CreatedAt.Time.UTC(),
 				Description: row.Description.String,
 				Username:    row.Username.String,
+				Scopes:      row.Scopes,
 			}
 		}
 		return tokens, nil
@@ -269,6 +362,7 @@ func (db *pgdb) getUserToken(ctx context.Context, id string) (*UserToken, error)
 			CreatedAt:   row.CreatedAt.Time.UTC(),
 			Description: row.Description.String,
 			Username:    row.Username.String,
+			Scopes:      row.Scopes,
 		}, nil
 	})
 }
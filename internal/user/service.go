@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/lockout"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/sql"
@@ -16,9 +17,16 @@ import (
 	"github.com/tofutf/tofutf/internal/team"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/tokens"
+	"github.com/tofutf/tofutf/internal/upgrade"
 )
 
-var ErrCannotDeleteOnlyOwner = errors.New("cannot remove the last owner")
+var (
+	ErrCannotDeleteOnlyOwner = errors.New("cannot remove the last owner")
+
+	// ErrUserDeactivated is returned when a deactivated user attempts to
+	// authenticate.
+	ErrUserDeactivated = errors.New("user account is deactivated")
+)
 
 type (
 	Service struct {
@@ -40,6 +48,12 @@ type (
 		TokensService *tokens.Service
 		TeamService   *team.Service
 		Logger        *slog.Logger
+		// UpgradeService reports whether a newer version of tofutf is
+		// available on the site admin page.
+		UpgradeService *upgrade.Service
+		// LockoutService optionally delays and locks out clients that
+		// repeatedly fail to login as the site admin.
+		LockoutService *lockout.Service
 
 		*sql.Pool
 		*tfeapi.Responder
@@ -65,6 +79,12 @@ func NewService(opts Options) *Service {
 		siteToken: opts.SiteToken,
 		users:     &svc,
 	}
+	if opts.UpgradeService != nil {
+		svc.web.upgrades = opts.UpgradeService
+	}
+	if opts.LockoutService != nil {
+		svc.web.lockouts = opts.LockoutService
+	}
 	svc.tfeapi = &tfe{
 		Service:   &svc,
 		Responder: opts.Responder,
@@ -100,8 +120,23 @@ func NewService(opts Options) *Service {
 	// Register with auth middleware the user token kind and a means of
 	// retrieving user corresponding to token.
 	opts.TokensService.RegisterKind(UserTokenKind, func(ctx context.Context, tokenID string) (internal.Subject, error) {
-		return svc.GetUser(ctx, UserSpec{AuthenticationTokenID: internal.String(tokenID)})
-
+		user, err := svc.GetUser(ctx, UserSpec{AuthenticationTokenID: internal.String(tokenID)})
+		if err != nil {
+			return nil, err
+		}
+		if user.Deactivated {
+			return nil, ErrUserDeactivated
+		}
+		token, err := svc.db.getUserToken(ctx, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		// restrict user to the token's scopes, if any
+		user.scopes, err = rbac.NewScopes(token.Scopes)
+		if err != nil {
+			return nil, err
+		}
+		return user, nil
 	})
 	// Register with auth middleware the ability to get or create a user given a
 	// username.
@@ -110,8 +145,15 @@ func NewService(opts Options) *Service {
 		if err == internal.ErrResourceNotFound {
 			user, err = svc.Create(ctx, username)
 		}
-		return user, err
-
+		if err != nil {
+			return nil, err
+		}
+		// A deactivated user's sessions are revoked by refusing to
+		// authenticate the user.
+		if user.Deactivated {
+			return nil, ErrUserDeactivated
+		}
+		return user, nil
 	})
 
 	return &svc
@@ -220,6 +262,116 @@ func (a *Service) Delete(ctx context.Context, username string) error {
 	return nil
 }
 
+// Update updates a user. Renaming a user also renames the user in their team
+// memberships and tokens.
+func (a *Service) Update(ctx context.Context, username string, opts UpdateUserOptions) (*User, error) {
+	subject, err := a.site.CanAccess(ctx, rbac.UpdateUserAction, "")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.db.updateUser(ctx, UserSpec{Username: &username}, func(ctx context.Context, user *User) error {
+		return user.update(opts)
+	})
+	if err != nil {
+		a.logger.Error("updating user", "username", username, "subject", subject, "err", err)
+		return nil, err
+	}
+
+	a.logger.Info("updated user", "username", username, "user", user, "subject", subject)
+
+	return user, nil
+}
+
+// Deactivate deactivates a user, revoking their access: their API tokens are
+// deleted, they are removed from all teams, and they can no longer
+// authenticate, which invalidates their existing sessions.
+func (a *Service) Deactivate(ctx context.Context, username string) (*User, error) {
+	subject, err := a.site.CanAccess(ctx, rbac.UpdateUserAction, "")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.db.updateUser(ctx, UserSpec{Username: &username}, func(ctx context.Context, user *User) error {
+		user.Deactivated = true
+		user.UpdatedAt = internal.CurrentTimestamp(nil)
+		user.Teams = nil
+		return a.db.revokeAccess(ctx, user.Username)
+	})
+	if err != nil {
+		a.logger.Error("deactivating user", "username", username, "subject", subject, "err", err)
+		return nil, err
+	}
+
+	a.logger.Info("deactivated user", "username", username, "subject", subject)
+
+	return user, nil
+}
+
+// Reactivate reactivates a deactivated user, permitting them to authenticate
+// once again. Their former team memberships and tokens are not restored.
+func (a *Service) Reactivate(ctx context.Context, username string) (*User, error) {
+	subject, err := a.site.CanAccess(ctx, rbac.UpdateUserAction, "")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.db.updateUser(ctx, UserSpec{Username: &username}, func(ctx context.Context, user *User) error {
+		user.Deactivated = false
+		user.UpdatedAt = internal.CurrentTimestamp(nil)
+		return nil
+	})
+	if err != nil {
+		a.logger.Error("reactivating user", "username", username, "subject", subject, "err", err)
+		return nil, err
+	}
+
+	a.logger.Info("reactivated user", "username", username, "subject", subject)
+
+	return user, nil
+}
+
+// LinkVerifiedEmail links a verified email address, provided by an identity
+// provider upon login, to a user account, returning the user that should be
+// logged in:
+//
+// (a) if a user with the email address exists then that user is returned,
+// regardless of the given username, which ensures a user provisioned by one
+// means, e.g. SCIM, and logging in by another, e.g. OIDC, has the one account.
+// (b) if a user with the given username exists then the email address is
+// recorded against the user, unless the user already has an email address.
+// (c) otherwise a user is created with the username and email address.
+func (a *Service) LinkVerifiedEmail(ctx context.Context, username, email string) (*User, error) {
+	subject, err := a.site.CanAccess(ctx, rbac.UpdateUserAction, "")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.db.getUser(ctx, UserSpec{Email: &email})
+	if err == nil {
+		if user.Username != username {
+			a.logger.Info("linked login to user by verified email", "username", username, "user", user, "subject", subject)
+		}
+		return user, nil
+	} else if !errors.Is(err, internal.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	user, err = a.db.updateUser(ctx, UserSpec{Username: &username}, func(ctx context.Context, user *User) error {
+		if user.Email != nil {
+			return nil
+		}
+		return user.update(UpdateUserOptions{Email: &email})
+	})
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		return a.Create(ctx, username, WithEmail(email))
+	} else if err != nil {
+		a.logger.Error("linking verified email to user", "username", username, "subject", subject, "err", err)
+		return nil, err
+	}
+	return user, nil
+}
+
 // AddTeamMembership adds users to a team. If a user does not exist then the
 // user is created first.
 func (a *Service) AddTeamMembership(ctx context.Context, teamID string, usernames []string) error {
@@ -292,6 +444,52 @@ func (a *Service) RemoveTeamMembership(ctx context.Context, teamID string, usern
 	return nil
 }
 
+// SetTeamMembership authoritatively sets the members of a team to the given
+// users. If a user does not exist then the user is created first. Any
+// unspecified users that are currently members are removed from the team.
+func (a *Service) SetTeamMembership(ctx context.Context, teamID string, usernames []string) error {
+	team, err := a.teams.GetByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	subject, err := a.organization.CanAccess(ctx, rbac.AddTeamMembershipAction, team.Organization)
+	if err != nil {
+		return err
+	}
+	if _, err := a.organization.CanAccess(ctx, rbac.RemoveTeamMembershipAction, team.Organization); err != nil {
+		return err
+	}
+
+	// the owners team must retain at least one member
+	if team.Name == "owners" && len(usernames) == 0 {
+		return ErrCannotDeleteOnlyOwner
+	}
+
+	err = a.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
+		// Check each username: if user does not exist then create user.
+		for _, username := range usernames {
+			_, err := a.db.getUser(ctx, UserSpec{Username: &username})
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				if _, err := a.Create(ctx, username); err != nil {
+					return err
+				}
+			} else if err != nil {
+				return err
+			}
+		}
+		return a.db.setTeamMembership(ctx, teamID, usernames...)
+	})
+	if err != nil {
+		a.logger.Error("setting team membership", "users", usernames, "team", teamID, "subject", subject, "err", err)
+		return err
+	}
+
+	a.logger.Info("set team membership", "users", usernames, "team", teamID, "subject", subject)
+
+	return nil
+}
+
 // SetSiteAdmins authoritatively promotes users with the given usernames to site
 // admins. If no such users exist then they are created. Any unspecified users
 // that are currently site admins are demoted.
@@ -318,9 +516,10 @@ func (a *Service) SetSiteAdmins(ctx context.Context, usernames ...string) error
 // User API token endpoints
 
 // CreateToken creates a user token. Only users can create a user token, and
-// they can only create a token for themselves.
+// they can only create a token for themselves. A user authenticated with a
+// scoped token cannot create tokens, lest they escape its scopes.
 func (a *Service) CreateToken(ctx context.Context, opts CreateUserTokenOptions) (*UserToken, []byte, error) {
-	user, err := UserFromContext(ctx)
+	user, err := a.tokenOwnerFromContext(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -336,7 +535,7 @@ func (a *Service) CreateToken(ctx context.Context, opts CreateUserTokenOptions)
 		return nil, nil, err
 	}
 
-	a.logger.Info("created user token", "user", user)
+	a.logger.Info("created user token", "user", user, "scopes", ut.Scopes)
 
 	return ut, token, nil
 }
@@ -350,8 +549,10 @@ func (a *Service) ListTokens(ctx context.Context) ([]*UserToken, error) {
 	return a.db.listUserTokens(ctx, user.Username)
 }
 
+// DeleteToken deletes a user token. A user authenticated with a scoped token
+// cannot delete tokens.
 func (a *Service) DeleteToken(ctx context.Context, tokenID string) error {
-	user, err := UserFromContext(ctx)
+	user, err := a.tokenOwnerFromContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -375,3 +576,17 @@ func (a *Service) DeleteToken(ctx context.Context, tokenID string) error {
 
 	return nil
 }
+
+// tokenOwnerFromContext retrieves the user from the context for the purposes
+// of managing their tokens, which is not permitted with a scoped token.
+func (a *Service) tokenOwnerFromContext(ctx context.Context) (*User, error) {
+	user, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.Scoped() {
+		a.logger.Error("unauthorized action", "action", "ManageUserTokens", "subject", user)
+		return nil, internal.ErrAccessNotPermitted
+	}
+	return user, nil
+}
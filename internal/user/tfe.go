@@ -2,12 +2,14 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/tfeapi/types"
 )
@@ -15,6 +17,7 @@ import (
 const (
 	addTeamMembersAction teamMembersAction = iota
 	removeTeamMembersAction
+	setTeamMembersAction
 )
 
 type (
@@ -30,6 +33,9 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r = r.PathPrefix(tfeapi.APIPrefixV2).Subrouter()
 
 	r.HandleFunc("/account/details", a.getCurrentUser).Methods("GET")
+	r.HandleFunc("/account/authentication-tokens", a.createUserToken).Methods("POST")
+	r.HandleFunc("/account/authentication-tokens", a.listUserTokens).Methods("GET")
+	r.HandleFunc("/token-scopes", a.listTokenScopes).Methods("GET")
 	r.HandleFunc("/teams/{team_id}/memberships/{username}", a.addTeamMembership).Methods("POST")
 	r.HandleFunc("/teams/{team_id}/memberships/{username}", a.removeTeamMembership).Methods("DELETE")
 
@@ -168,6 +174,66 @@ func (a *tfe) deleteMembership(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (a *tfe) createUserToken(w http.ResponseWriter, r *http.Request) {
+	var params types.UserTokenCreateOptions
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	ut, token, err := a.CreateToken(r.Context(), CreateUserTokenOptions{
+		Description: params.Description,
+		Scopes:      params.Scopes,
+	})
+	if errors.Is(err, rbac.ErrUnknownScope) {
+		tfeapi.Error(w, &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()})
+		return
+	} else if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	to := a.convertUserToken(ut)
+	to.Token = string(token)
+	a.Respond(w, r, to, http.StatusCreated)
+}
+
+func (a *tfe) listUserTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := a.ListTokens(r.Context())
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	to := make([]*types.UserToken, len(tokens))
+	for i, ut := range tokens {
+		to[i] = a.convertUserToken(ut)
+	}
+	a.Respond(w, r, to, http.StatusOK)
+}
+
+// listTokenScopes lists the coarse scopes to which a token can be restricted.
+func (a *tfe) listTokenScopes(w http.ResponseWriter, r *http.Request) {
+	to := make([]*types.TokenScope, len(rbac.CoarseScopes))
+	for i, scope := range rbac.CoarseScopes {
+		actions := scope.Actions()
+		to[i] = &types.TokenScope{
+			ID:      scope.String(),
+			Actions: make([]string, len(actions)),
+		}
+		for j, action := range actions {
+			to[i].Actions[j] = action.String()
+		}
+	}
+	a.Respond(w, r, to, http.StatusOK)
+}
+
+func (a *tfe) convertUserToken(from *UserToken) *types.UserToken {
+	return &types.UserToken{
+		ID:          from.ID,
+		CreatedAt:   from.CreatedAt,
+		Description: from.Description,
+		Scopes:      from.Scopes,
+	}
+}
+
 func (a *tfe) convertUser(from *User) *types.User {
 	return &types.User{
 		ID:       from.ID,
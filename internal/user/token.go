@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/tokens"
 )
 
@@ -16,12 +17,20 @@ type (
 		CreatedAt   time.Time
 		Description string
 		Username    string // Token belongs to a user
+		// Scopes restrict the token to a subset of the user's permissions.
+		// Nil permits all of the user's permissions.
+		Scopes []string
 	}
 
 	// CreateUserTokenOptions are options for creating a user token via the service
 	// endpoint
 	CreateUserTokenOptions struct {
 		Description string
+		// Scopes restrict the token to a subset of the user's permissions,
+		// each either a coarse scope, e.g. runs:write, or the name of an
+		// individual action, e.g. GetRunAction. No scopes permits all of the
+		// user's permissions.
+		Scopes []string
 	}
 
 	userTokenFactory struct {
@@ -29,12 +38,21 @@ type (
 	}
 )
 
+// Scoped determines whether the token is restricted to a subset of the user's
+// permissions.
+func (t *UserToken) Scoped() bool { return t.Scopes != nil }
+
 func (f *userTokenFactory) NewUserToken(username string, opts CreateUserTokenOptions) (*UserToken, []byte, error) {
+	scopes, err := rbac.NewScopes(opts.Scopes)
+	if err != nil {
+		return nil, nil, err
+	}
 	ut := UserToken{
 		ID:          internal.NewID("ut"),
 		CreatedAt:   internal.CurrentTimestamp(nil),
 		Description: opts.Description,
 		Username:    username,
+		Scopes:      scopes.Strings(),
 	}
 	token, err := f.tokens.NewToken(tokens.NewTokenOptions{
 		Subject: ut.ID,
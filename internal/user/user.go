@@ -4,6 +4,7 @@ package user
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"log/slog"
@@ -34,8 +35,23 @@ type (
 		// username is globally unique
 		Username string `jsonapi:"attribute" json:"username"`
 
+		// Email is the user's verified email address, if known. It is
+		// globally unique and stored in lowercase.
+		Email *string `json:"-"`
+
+		// ExternalID is the identifier assigned to the user by an identity
+		// provider that provisioned the user via SCIM.
+		ExternalID *string `json:"-"`
+
+		// Deactivated users can no longer authenticate.
+		Deactivated bool `jsonapi:"attribute" json:"deactivated"`
+
 		// user belongs to many teams
 		Teams []*team.Team
+
+		// scopes restrict the user to a subset of their permissions when
+		// they authenticate with a scoped API token.
+		scopes rbac.Scopes
 	}
 
 	// UserListOptions are options for the ListUsers endpoint.
@@ -50,10 +66,20 @@ type (
 		Username string `json:"username"`
 	}
 
+	// UpdateUserOptions are options for updating a user. Unset options leave
+	// the user's existing settings unchanged.
+	UpdateUserOptions struct {
+		Username   *string
+		Email      *string
+		ExternalID *string
+	}
+
 	UserSpec struct {
 		UserID                *string
 		Username              *string
 		AuthenticationTokenID *string
+		ExternalID            *string
+		Email                 *string
 	}
 )
 
@@ -76,8 +102,46 @@ func WithTeams(memberships ...*team.Team) NewUserOption {
 	}
 }
 
+// WithEmail sets the user's verified email address.
+func WithEmail(email string) NewUserOption {
+	return func(user *User) {
+		user.Email = internal.String(normalizeEmail(email))
+	}
+}
+
+// WithExternalID sets the identifier assigned to the user by an identity
+// provider.
+func WithExternalID(externalID string) NewUserOption {
+	return func(user *User) {
+		user.ExternalID = &externalID
+	}
+}
+
 func (u *User) String() string { return u.Username }
 
+func (u *User) update(opts UpdateUserOptions) error {
+	if opts.Username != nil {
+		if *opts.Username == "" {
+			return fmt.Errorf("%w: username cannot be empty", internal.ErrEmptyValue)
+		}
+		u.Username = *opts.Username
+	}
+	if opts.Email != nil {
+		u.Email = internal.String(normalizeEmail(*opts.Email))
+	}
+	if opts.ExternalID != nil {
+		u.ExternalID = opts.ExternalID
+	}
+	u.UpdatedAt = internal.CurrentTimestamp(nil)
+	return nil
+}
+
+// normalizeEmail normalizes an email address so that addresses differing only
+// in case are treated as the same address.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // IsTeamMember determines whether user is a member of the given team.
 func (u *User) IsTeamMember(teamID string) bool {
 	for _, t := range u.Teams {
@@ -120,7 +184,16 @@ func (u *User) IsSiteAdmin() bool {
 	return u.SiteAdmin || u.ID == SiteAdminID
 }
 
+// Scoped determines whether the user is restricted to a subset of their
+// permissions, having authenticated with a scoped API token.
+func (u *User) Scoped() bool { return u.scopes != nil }
+
 func (u *User) CanAccessSite(action rbac.Action) bool {
+	// a scoped token only permits those of the user's permissions that its
+	// scopes also permit
+	if !u.scopes.IsAllowed(action) {
+		return false
+	}
 	switch action {
 	case rbac.GetGithubAppAction:
 		return true
@@ -139,6 +212,9 @@ func (u *User) CanAccessSite(action rbac.Action) bool {
 }
 
 func (u *User) CanAccessTeam(action rbac.Action, teamID string) bool {
+	if !u.scopes.IsAllowed(action) {
+		return false
+	}
 	// coarser-grained site-level perms take precedence
 	if u.CanAccessSite(action) {
 		return true
@@ -152,6 +228,9 @@ func (u *User) CanAccessTeam(action rbac.Action, teamID string) bool {
 }
 
 func (u *User) CanAccessOrganization(action rbac.Action, org string) bool {
+	if !u.scopes.IsAllowed(action) {
+		return false
+	}
 	// coarser-grained site-level perms take precedence
 	if u.CanAccessSite(action) {
 		return true
@@ -166,6 +245,9 @@ func (u *User) CanAccessOrganization(action rbac.Action, org string) bool {
 }
 
 func (u *User) CanAccessWorkspace(action rbac.Action, policy internal.WorkspacePolicy) bool {
+	if !u.scopes.IsAllowed(action) {
+		return false
+	}
 	// coarser-grained organization perms take precedence.
 	if u.CanAccessOrganization(action, policy.Organization) {
 		return true
@@ -199,6 +281,12 @@ func (s UserSpec) LogValue() slog.Value {
 	if s.AuthenticationTokenID != nil {
 		return slog.String("token_id", "*****").Value
 	}
+	if s.ExternalID != nil {
+		return slog.String("external_id", *s.ExternalID).Value
+	}
+	if s.Email != nil {
+		return slog.String("email", *s.Email).Value
+	}
 	return slog.String("unknown key", "unknown value").Value
 }
 
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/team"
 )
@@ -54,3 +56,57 @@ func TestUser_Organizations(t *testing.T) {
 	assert.Contains(t, want, "big-tobacco")
 	assert.Contains(t, want, "big-pharma")
 }
+
+func TestScopedUser(t *testing.T) {
+	owners := &team.Team{Name: "owners", Organization: "acme-corp"}
+	policy := internal.WorkspacePolicy{Organization: "acme-corp", WorkspaceID: "ws-123"}
+
+	t.Run("runs:write", func(t *testing.T) {
+		scopes, err := rbac.NewScopes([]string{"runs:write"})
+		require.NoError(t, err)
+		u := User{Username: "bobby", Teams: []*team.Team{owners}, scopes: scopes}
+		assert.True(t, u.Scoped())
+
+		// can trigger runs
+		assert.True(t, u.CanAccessWorkspace(rbac.GetWorkspaceAction, policy))
+		assert.True(t, u.CanAccessWorkspace(rbac.CreateConfigurationVersionAction, policy))
+		assert.True(t, u.CanAccessWorkspace(rbac.CreateRunAction, policy))
+		assert.True(t, u.CanAccessWorkspace(rbac.ApplyRunAction, policy))
+
+		// cannot read state
+		assert.False(t, u.CanAccessWorkspace(rbac.GetStateVersionAction, policy))
+		assert.False(t, u.CanAccessWorkspace(rbac.DownloadStateAction, policy))
+		assert.False(t, u.CanAccessWorkspace(rbac.GetStateVersionOutputAction, policy))
+
+		// cannot manage workspaces
+		assert.False(t, u.CanAccessOrganization(rbac.CreateWorkspaceAction, "acme-corp"))
+		assert.False(t, u.CanAccessWorkspace(rbac.UpdateWorkspaceAction, policy))
+		assert.False(t, u.CanAccessWorkspace(rbac.DeleteWorkspaceAction, policy))
+		assert.False(t, u.CanAccessWorkspace(rbac.SetWorkspacePermissionAction, policy))
+	})
+
+	t.Run("scopes do not exceed user's permissions", func(t *testing.T) {
+		scopes, err := rbac.NewScopes([]string{"runs:write"})
+		require.NoError(t, err)
+		u := User{Username: "bobby", scopes: scopes}
+
+		assert.False(t, u.CanAccessWorkspace(rbac.CreateRunAction, policy))
+	})
+
+	t.Run("individual action", func(t *testing.T) {
+		scopes, err := rbac.NewScopes([]string{"GetStateVersionAction"})
+		require.NoError(t, err)
+		u := User{Username: "bobby", Teams: []*team.Team{owners}, scopes: scopes}
+
+		assert.True(t, u.CanAccessWorkspace(rbac.GetStateVersionAction, policy))
+		assert.False(t, u.CanAccessWorkspace(rbac.DownloadStateAction, policy))
+	})
+
+	t.Run("unscoped", func(t *testing.T) {
+		u := User{Username: "bobby", Teams: []*team.Team{owners}}
+		assert.False(t, u.Scoped())
+
+		assert.True(t, u.CanAccessWorkspace(rbac.DownloadStateAction, policy))
+		assert.True(t, u.CanAccessOrganization(rbac.CreateWorkspaceAction, "acme-corp"))
+	})
+}
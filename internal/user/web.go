@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"sort"
 	"time"
@@ -11,11 +12,13 @@ import (
 	"github.com/tofutf/tofutf/internal/http/decode"
 	"github.com/tofutf/tofutf/internal/http/html"
 	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/lockout"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/resource"
 	otfteam "github.com/tofutf/tofutf/internal/team"
 	"github.com/tofutf/tofutf/internal/tokens"
+	"github.com/tofutf/tofutf/internal/upgrade"
 )
 
 // webHandlers provides handlers for the web UI
@@ -25,9 +28,23 @@ type webHandlers struct {
 	users     usersClient
 	teams     teamsClient
 	tokens    tokensClient
+	upgrades  upgradesClient
+	lockouts  lockoutsClient
 	siteToken string
 }
 
+type upgradesClient interface {
+	Get(ctx context.Context) (*upgrade.Status, error)
+}
+
+// lockoutsClient limits failed attempts to login.
+type lockoutsClient interface {
+	NewAttempt(r *http.Request, username string) lockout.Attempt
+	Check(ctx context.Context, attempt lockout.Attempt) error
+	Fail(ctx context.Context, attempt lockout.Attempt)
+	Succeed(ctx context.Context, attempt lockout.Attempt)
+}
+
 type usersClient interface {
 	Create(ctx context.Context, username string, opts ...NewUserOption) (*User, error)
 	List(ctx context.Context) ([]*User, error)
@@ -136,11 +153,30 @@ func (h *webHandlers) adminLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// failed attempts are tracked by IP address and by the site admin
+	// username, so that guessing the site token from many addresses also
+	// results in a lockout.
+	var attempt lockout.Attempt
+	if h.lockouts != nil {
+		attempt = h.lockouts.NewAttempt(r, SiteAdminUsername)
+		if err := h.lockouts.Check(r.Context(), attempt); err != nil {
+			html.FlashError(w, err.Error())
+			http.Redirect(w, r, paths.AdminLogin(), http.StatusFound)
+			return
+		}
+	}
+
 	if token != h.siteToken {
+		if h.lockouts != nil {
+			h.lockouts.Fail(r.Context(), attempt)
+		}
 		html.FlashError(w, "incorrect token")
 		http.Redirect(w, r, paths.AdminLogin(), http.StatusFound)
 		return
 	}
+	if h.lockouts != nil {
+		h.lockouts.Succeed(r.Context(), attempt)
+	}
 
 	err = h.tokens.StartSession(w, r, tokens.StartSessionOptions{
 		Username: internal.String(SiteAdminUsername),
@@ -157,12 +193,23 @@ func (h *webHandlers) site(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// only the site admin may see whether an upgrade is available
+	var upgradeStatus *upgrade.Status
+	if h.upgrades != nil {
+		upgradeStatus, err = h.upgrades.Get(r.Context())
+		if err != nil && !errors.Is(err, internal.ErrAccessNotPermitted) {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 	h.Render("site.tmpl", w, struct {
 		html.SitePage
-		User internal.Subject
+		User          internal.Subject
+		UpgradeStatus *upgrade.Status
 	}{
-		SitePage: html.NewSitePage(r, "site"),
-		User:     user,
+		SitePage:      html.NewSitePage(r, "site"),
+		User:          user,
+		UpgradeStatus: upgradeStatus,
 	})
 }
 
@@ -293,7 +340,13 @@ func (h *webHandlers) getTeam(w http.ResponseWriter, r *http.Request) {
 //
 
 func (h *webHandlers) newUserToken(w http.ResponseWriter, r *http.Request) {
-	h.Render("token_new.tmpl", w, html.NewSitePage(r, "new user token"))
+	h.Render("token_new.tmpl", w, struct {
+		html.SitePage
+		Scopes []rbac.Role
+	}{
+		SitePage: html.NewSitePage(r, "new user token"),
+		Scopes:   rbac.CoarseScopes,
+	})
 }
 
 func (h *webHandlers) createUserToken(w http.ResponseWriter, r *http.Request) {
@@ -303,7 +356,10 @@ func (h *webHandlers) createUserToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	_, token, err := h.users.CreateToken(r.Context(), opts)
-	if err != nil {
+	if errors.Is(err, rbac.ErrUnknownScope) {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -7,12 +7,14 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/lockout"
 	"github.com/tofutf/tofutf/internal/team"
 	"github.com/tofutf/tofutf/internal/testutils"
 	"github.com/tofutf/tofutf/internal/tokens"
@@ -34,6 +36,7 @@ func TestWeb_UserTokens(t *testing.T) {
 		if !assert.Equal(t, 200, w.Code) {
 			t.Log(t, w.Body.String())
 		}
+		assert.Contains(t, w.Body.String(), `value="runs:write"`)
 	})
 
 	t.Run("create", func(t *testing.T) {
@@ -58,7 +61,7 @@ func TestWeb_UserTokens(t *testing.T) {
 		h := &webHandlers{
 			Renderer: testutils.NewRenderer(t),
 			users: &fakeService{
-				ut: &UserToken{},
+				ut: &UserToken{Scopes: []string{"runs:write"}},
 			},
 		}
 		q := "/?"
@@ -69,6 +72,7 @@ func TestWeb_UserTokens(t *testing.T) {
 		h.userTokens(w, r)
 
 		assert.Equal(t, 200, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "runs:write")
 	})
 
 	t.Run("delete", func(t *testing.T) {
@@ -155,12 +159,69 @@ func TestAdminLoginHandler(t *testing.T) {
 	}
 }
 
+func TestAdminLoginHandler_lockout(t *testing.T) {
+	lockouts := &fakeLockoutsClient{}
+	h := &webHandlers{
+		Renderer:  testutils.NewRenderer(t),
+		siteToken: "secrettoken",
+		tokens:    &fakeTokensService{},
+		lockouts:  lockouts,
+	}
+	login := func(token string) *httptest.ResponseRecorder {
+		form := strings.NewReader(url.Values{"token": {token}}.Encode())
+		r := httptest.NewRequest("POST", "/admin/login", form)
+		r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.adminLogin(w, r)
+		return w
+	}
+
+	login("badtoken")
+	assert.Equal(t, []lockout.Attempt{{IP: "192.0.2.1", Username: SiteAdminUsername}}, lockouts.failed)
+
+	// successful login forgets failed attempts
+	login("secrettoken")
+	assert.Equal(t, 1, lockouts.succeeded)
+
+	// a locked out client cannot login even with the correct token
+	lockouts.locked = true
+	w := login("secrettoken")
+	redirect, err := w.Result().Location()
+	require.NoError(t, err)
+	assert.Equal(t, "/admin/login", redirect.Path)
+	assert.Equal(t, 1, lockouts.succeeded)
+}
+
 func TestUserDiff(t *testing.T) {
 	a := []*User{{Username: "bob"}}
 	b := []*User{{Username: "bob"}, {Username: "alice"}}
 	assert.Equal(t, []*User{{Username: "alice"}}, diffUsers(a, b))
 }
 
+type fakeLockoutsClient struct {
+	locked    bool
+	failed    []lockout.Attempt
+	succeeded int
+}
+
+func (f *fakeLockoutsClient) NewAttempt(r *http.Request, username string) lockout.Attempt {
+	host, _, _ := strings.Cut(r.RemoteAddr, ":")
+	return lockout.Attempt{IP: host, Username: username}
+}
+
+func (f *fakeLockoutsClient) Check(context.Context, lockout.Attempt) error {
+	if f.locked {
+		return &lockout.LockedError{RetryAfter: time.Minute, Lockout: &lockout.Lockout{}}
+	}
+	return nil
+}
+
+func (f *fakeLockoutsClient) Fail(_ context.Context, attempt lockout.Attempt) {
+	f.failed = append(f.failed, attempt)
+}
+
+func (f *fakeLockoutsClient) Succeed(context.Context, lockout.Attempt) { f.succeeded++ }
+
 type fakeTokensService struct{}
 
 func (f *fakeTokensService) StartSession(w http.ResponseWriter, r *http.Request, opts tokens.StartSessionOptions) error {
@@ -1,8 +1,10 @@
 package variable
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/tofutf/tofutf/internal"
 	otfapi "github.com/tofutf/tofutf/internal/api"
 
 	"github.com/tofutf/tofutf/internal/tfeapi"
@@ -28,7 +30,13 @@ func (a *api) listEffectiveVariables(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	variables, err := a.ListEffectiveVariables(r.Context(), runID)
-	if err != nil {
+	if errors.Is(err, ErrOutputSourceUnavailable) || errors.Is(err, ErrSecretUnavailable) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
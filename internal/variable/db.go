@@ -25,6 +25,16 @@ type (
 		VersionID   pgtype.Text `json:"version_id"`
 	}
 
+	outputMappingRow struct {
+		OutputMappingID     pgtype.Text        `json:"output_mapping_id"`
+		WorkspaceID         pgtype.Text        `json:"workspace_id"`
+		Key                 pgtype.Text        `json:"key"`
+		SourceWorkspaceID   pgtype.Text        `json:"source_workspace_id"`
+		SourceOutput        pgtype.Text        `json:"source_output"`
+		CreatedAt           pgtype.Timestamptz `json:"created_at"`
+		SourceWorkspaceName pgtype.Text        `json:"source_workspace_name"`
+	}
+
 	variableSetRow struct {
 		VariableSetID    pgtype.Text       `json:"variable_set_id"`
 		Global           pgtype.Bool       `json:"global"`
@@ -49,6 +59,18 @@ func (row variableRow) convert() *Variable {
 	}
 }
 
+func (row outputMappingRow) convert() *OutputMapping {
+	return &OutputMapping{
+		ID:                  row.OutputMappingID.String,
+		CreatedAt:           row.CreatedAt.Time.UTC(),
+		WorkspaceID:         row.WorkspaceID.String,
+		Key:                 row.Key.String,
+		SourceWorkspaceID:   row.SourceWorkspaceID.String,
+		SourceWorkspaceName: row.SourceWorkspaceName.String,
+		SourceOutput:        row.SourceOutput.String,
+	}
+}
+
 func (row variableSetRow) convert() *VariableSet {
 	set := &VariableSet{
 		ID:           row.VariableSetID.String,
@@ -307,3 +329,57 @@ func (pdb *pgdb) deleteVariable(ctx context.Context, variableID string) error {
 		return sql.Error(err)
 	})
 }
+
+func (pdb *pgdb) createOutputMapping(ctx context.Context, m *OutputMapping) error {
+	return pdb.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertWorkspaceOutputMapping(ctx, pggen.InsertWorkspaceOutputMappingParams{
+			OutputMappingID:   sql.String(m.ID),
+			WorkspaceID:       sql.String(m.WorkspaceID),
+			Key:               sql.String(m.Key),
+			SourceWorkspaceID: sql.String(m.SourceWorkspaceID),
+			SourceOutput:      sql.String(m.SourceOutput),
+			CreatedAt:         sql.Timestamptz(m.CreatedAt),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+func (pdb *pgdb) listOutputMappings(ctx context.Context, workspaceID string) ([]*OutputMapping, error) {
+	return sql.Query(ctx, pdb.Pool, func(ctx context.Context, q pggen.Querier) ([]*OutputMapping, error) {
+		rows, err := q.FindWorkspaceOutputMappings(ctx, sql.String(workspaceID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		mappings := make([]*OutputMapping, len(rows))
+		for i, row := range rows {
+			mappings[i] = outputMappingRow(row).convert()
+		}
+
+		return mappings, nil
+	})
+}
+
+func (pdb *pgdb) getOutputMapping(ctx context.Context, mappingID string) (*OutputMapping, error) {
+	return sql.Query(ctx, pdb.Pool, func(ctx context.Context, q pggen.Querier) (*OutputMapping, error) {
+		row, err := q.FindWorkspaceOutputMapping(ctx, sql.String(mappingID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+
+		return outputMappingRow(row).convert(), nil
+	})
+}
+
+func (pdb *pgdb) deleteOutputMapping(ctx context.Context, mappingID string) error {
+	return pdb.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.DeleteWorkspaceOutputMapping(ctx, sql.String(mappingID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,145 @@
+package variable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/state"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+var (
+	ErrOutputMappingSameWorkspace = errors.New("a workspace cannot source a variable from its own outputs")
+	ErrOutputMappingOrganization  = errors.New("source workspace must belong to the same organization")
+	ErrOutputMappingStateShared   = errors.New("source workspace must share its state with the organization")
+	ErrOutputMappingNoState       = errors.New("source workspace has no state yet")
+	ErrOutputMappingNoSuchOutput  = errors.New("source workspace has no such output")
+	// ErrOutputSourceUnavailable is returned when the effective variables of
+	// a run cannot be determined because an output from which a variable is
+	// sourced is unavailable.
+	ErrOutputSourceUnavailable = errors.New("source of variable is unavailable")
+)
+
+type (
+	// OutputMapping sources the value of a terraform variable of a workspace
+	// from an output of the current state of another workspace.
+	OutputMapping struct {
+		ID          string    `jsonapi:"primary,output-mappings"`
+		CreatedAt   time.Time `jsonapi:"attribute" json:"created-at"`
+		WorkspaceID string    `jsonapi:"attribute" json:"workspace-id"`
+		// Key of the terraform variable.
+		Key string `jsonapi:"attribute" json:"key"`
+		// ID of the workspace from whose state the output is sourced.
+		SourceWorkspaceID string `jsonapi:"attribute" json:"source-workspace-id"`
+		// Name of the source workspace. Empty if the workspace no longer
+		// exists.
+		SourceWorkspaceName string `jsonapi:"attribute" json:"source-workspace-name"`
+		// Name of the output.
+		SourceOutput string `jsonapi:"attribute" json:"source-output"`
+	}
+
+	CreateOutputMappingOptions struct {
+		Key               string `schema:"key,required"`
+		SourceWorkspaceID string `schema:"source_workspace_id,required"`
+		SourceOutput      string `schema:"source_output,required"`
+	}
+)
+
+// newOutputMapping constructs a mapping for the workspace from the output of
+// the source workspace, validating it against the current state of the
+// source workspace. The current state is nil if the source workspace has no
+// state.
+func newOutputMapping(ws, source *workspace.Workspace, current *state.Version, opts CreateOutputMappingOptions) (*OutputMapping, error) {
+	m := &OutputMapping{
+		ID:                  internal.NewID("om"),
+		CreatedAt:           internal.CurrentTimestamp(nil),
+		WorkspaceID:         ws.ID,
+		SourceWorkspaceID:   source.ID,
+		SourceWorkspaceName: source.Name,
+		SourceOutput:        opts.SourceOutput,
+	}
+	var v Variable
+	if err := v.setKey(opts.Key); err != nil {
+		return nil, err
+	}
+	if v.Key == "" {
+		return nil, errors.New("missing key")
+	}
+	m.Key = v.Key
+	if source.ID == ws.ID {
+		return nil, ErrOutputMappingSameWorkspace
+	}
+	if source.Organization != ws.Organization {
+		return nil, ErrOutputMappingOrganization
+	}
+	if !source.GlobalRemoteState {
+		// runs read the source state with the permissions of the run, which
+		// is only permitted if the state is shared.
+		return nil, ErrOutputMappingStateShared
+	}
+	if current == nil {
+		return nil, ErrOutputMappingNoState
+	}
+	if _, ok := current.Outputs[opts.SourceOutput]; !ok {
+		return nil, ErrOutputMappingNoSuchOutput
+	}
+	return m, nil
+}
+
+// Source describes the source of the variable.
+func (m *OutputMapping) Source() string {
+	return fmt.Sprintf("workspace %s output %s", m.SourceWorkspaceName, m.SourceOutput)
+}
+
+// resolve returns a terraform variable with the value of the output in the
+// current state of the source workspace. The current state is nil if the
+// source workspace has no state.
+func (m *OutputMapping) resolve(current *state.Version) (*Variable, error) {
+	if current == nil {
+		return nil, m.unavailable("has no state yet")
+	}
+	output, ok := current.Outputs[m.SourceOutput]
+	if !ok {
+		return nil, m.unavailable("no longer has the output")
+	}
+	v := &Variable{
+		ID:        m.ID,
+		Key:       m.Key,
+		Category:  CategoryTerraform,
+		Sensitive: output.Sensitive,
+		Source:    m.Source(),
+	}
+	// a string output is used as is, whereas any other type of output is
+	// used in its JSON form, which is also valid HCL.
+	var s string
+	if err := json.Unmarshal(output.Value, &s); err == nil {
+		v.Value = s
+	} else {
+		v.Value = string(output.Value)
+		v.HCL = true
+	}
+	return v, nil
+}
+
+func (m *OutputMapping) unavailable(reason string) error {
+	name := m.SourceWorkspaceName
+	if name == "" {
+		// source workspace has been deleted
+		name = m.SourceWorkspaceID
+	}
+	return fmt.Errorf("%w: variable %s is sourced from output %s of workspace %s, which %s", ErrOutputSourceUnavailable, m.Key, m.SourceOutput, name, reason)
+}
+
+func (m *OutputMapping) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", m.ID),
+		slog.String("key", m.Key),
+		slog.String("workspace_id", m.WorkspaceID),
+		slog.String("source_workspace_id", m.SourceWorkspaceID),
+		slog.String("source_output", m.SourceOutput),
+	)
+}
@@ -0,0 +1,107 @@
+package variable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/state"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+func TestNewOutputMapping(t *testing.T) {
+	ws := &workspace.Workspace{ID: "ws-consumer", Name: "consumer", Organization: "acme"}
+	source := &workspace.Workspace{ID: "ws-network", Name: "network", Organization: "acme", GlobalRemoteState: true}
+	current := &state.Version{Outputs: map[string]*state.Output{
+		"vpc_id": {Name: "vpc_id", Value: json.RawMessage(`"vpc-123"`)},
+	}}
+	opts := CreateOutputMappingOptions{Key: "vpc_id", SourceWorkspaceID: source.ID, SourceOutput: "vpc_id"}
+
+	t.Run("valid", func(t *testing.T) {
+		m, err := newOutputMapping(ws, source, current, opts)
+		require.NoError(t, err)
+		assert.Equal(t, "vpc_id", m.Key)
+		assert.Equal(t, "ws-consumer", m.WorkspaceID)
+		assert.Equal(t, "ws-network", m.SourceWorkspaceID)
+		assert.Equal(t, "workspace network output vpc_id", m.Source())
+	})
+
+	t.Run("same workspace", func(t *testing.T) {
+		_, err := newOutputMapping(ws, ws, current, opts)
+		assert.ErrorIs(t, err, ErrOutputMappingSameWorkspace)
+	})
+
+	t.Run("different organization", func(t *testing.T) {
+		other := *source
+		other.Organization = "other"
+		_, err := newOutputMapping(ws, &other, current, opts)
+		assert.ErrorIs(t, err, ErrOutputMappingOrganization)
+	})
+
+	t.Run("state not shared", func(t *testing.T) {
+		unshared := *source
+		unshared.GlobalRemoteState = false
+		_, err := newOutputMapping(ws, &unshared, current, opts)
+		assert.ErrorIs(t, err, ErrOutputMappingStateShared)
+	})
+
+	t.Run("no state", func(t *testing.T) {
+		_, err := newOutputMapping(ws, source, nil, opts)
+		assert.ErrorIs(t, err, ErrOutputMappingNoState)
+	})
+
+	t.Run("no such output", func(t *testing.T) {
+		missing := opts
+		missing.SourceOutput = "subnet_id"
+		_, err := newOutputMapping(ws, source, current, missing)
+		assert.ErrorIs(t, err, ErrOutputMappingNoSuchOutput)
+	})
+}
+
+func TestOutputMapping_resolve(t *testing.T) {
+	m := &OutputMapping{
+		ID:                  "om-123",
+		Key:                 "vpc",
+		SourceWorkspaceID:   "ws-network",
+		SourceWorkspaceName: "network",
+		SourceOutput:        "vpc",
+	}
+	withOutput := func(value string, sensitive bool) *state.Version {
+		return &state.Version{Outputs: map[string]*state.Output{
+			"vpc": {Name: "vpc", Value: json.RawMessage(value), Sensitive: sensitive},
+		}}
+	}
+
+	t.Run("string", func(t *testing.T) {
+		got, err := m.resolve(withOutput(`"vpc-123"`, false))
+		require.NoError(t, err)
+		assert.Equal(t, &Variable{
+			ID:       "om-123",
+			Key:      "vpc",
+			Value:    "vpc-123",
+			Category: CategoryTerraform,
+			Source:   "workspace network output vpc",
+		}, got)
+	})
+
+	t.Run("object", func(t *testing.T) {
+		got, err := m.resolve(withOutput(`{"id":"vpc-123","cidr":"10.0.0.0/16"}`, true))
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":"vpc-123","cidr":"10.0.0.0/16"}`, got.Value)
+		assert.True(t, got.HCL)
+		assert.True(t, got.Sensitive)
+	})
+
+	t.Run("no state", func(t *testing.T) {
+		_, err := m.resolve(nil)
+		assert.ErrorIs(t, err, ErrOutputSourceUnavailable)
+		assert.ErrorContains(t, err, "workspace network, which has no state yet")
+	})
+
+	t.Run("output removed", func(t *testing.T) {
+		_, err := m.resolve(&state.Version{Outputs: map[string]*state.Output{}})
+		assert.ErrorIs(t, err, ErrOutputSourceUnavailable)
+		assert.ErrorContains(t, err, "no longer has the output")
+	})
+}
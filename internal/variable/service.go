@@ -2,6 +2,8 @@ package variable
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/gorilla/mux"
@@ -10,8 +12,10 @@ import (
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/secret"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
+	"github.com/tofutf/tofutf/internal/state"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/workspace"
 )
@@ -26,13 +30,20 @@ type (
 		workspace    internal.Authorizer
 		organization internal.Authorizer
 		runs         runClient
+		workspaces   workspaceClient
+		state        stateClient
+		secrets      *secret.Registry
 	}
 
 	Options struct {
 		WorkspaceAuthorizer internal.Authorizer
 		WorkspaceService    *workspace.Service
+		StateService        *state.Service
 		RunClient           runClient
 		Logger              *slog.Logger
+		// Secrets resolves variables referencing secrets in secret
+		// backends. If nil then no variables are treated as references.
+		Secrets *secret.Registry
 
 		*sql.Pool
 		*tfeapi.Responder
@@ -42,6 +53,14 @@ type (
 	runClient interface {
 		Get(ctx context.Context, runID string) (*run.Run, error)
 	}
+
+	workspaceClient interface {
+		Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error)
+	}
+
+	stateClient interface {
+		GetCurrent(ctx context.Context, workspaceID string) (*state.Version, error)
+	}
 )
 
 func NewService(opts Options) *Service {
@@ -51,6 +70,9 @@ func NewService(opts Options) *Service {
 		workspace:    opts.WorkspaceAuthorizer,
 		organization: &organization.Authorizer{Logger: opts.Logger},
 		runs:         opts.RunClient,
+		workspaces:   opts.WorkspaceService,
+		state:        opts.StateService,
+		secrets:      opts.Secrets,
 	}
 
 	svc.web = &web{
@@ -89,7 +111,157 @@ func (s *Service) ListEffectiveVariables(ctx context.Context, runID string) ([]*
 	if err != nil {
 		return nil, err
 	}
-	return mergeVariables(sets, vars, run), nil
+	mapped, err := s.resolveOutputMappings(ctx, run.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	// variables sourced from outputs take precedence over workspace variables
+	// with the same key.
+	effective := mergeVariables(sets, append(vars, mapped...), run)
+	if err := s.resolveSecrets(ctx, run.WorkspaceID, effective); err != nil {
+		return nil, err
+	}
+	return effective, nil
+}
+
+// resolveSecrets replaces the values of variables that reference secrets in a
+// secret backend with the values of the secrets. The secrets are only
+// retrieved for a subject permitted to resolve them, i.e. the run's job; for
+// any other subject their values are withheld. Either way the variables are
+// marked sensitive, so their values are neither displayed nor written to logs.
+// The values are never persisted.
+func (s *Service) resolveSecrets(ctx context.Context, workspaceID string, variables []*Variable) error {
+	var permitted *bool
+	for i, v := range variables {
+		ref, ok := s.secrets.Parse(v.Value)
+		if !ok {
+			continue
+		}
+		if permitted == nil {
+			_, err := s.workspace.CanAccess(ctx, rbac.ResolveVariableSecretsAction, workspaceID)
+			if errors.Is(err, internal.ErrAccessNotPermitted) {
+				permitted = internal.Bool(false)
+			} else if err != nil {
+				return err
+			} else {
+				permitted = internal.Bool(true)
+			}
+		}
+		resolved := *v
+		resolved.Value = ""
+		resolved.Sensitive = true
+		resolved.Source = fmt.Sprintf("%s secret %s#%s", ref.Backend, ref.Path, ref.Key)
+		if *permitted {
+			value, err := s.secrets.Resolve(ctx, ref)
+			if err != nil {
+				// the error from the backend is logged and returned as
+				// is: it must not include the value of the secret.
+				s.logger.Error("resolving secret", "variable", v.Key, "workspace_id", workspaceID, "reference", ref, "err", err)
+				return fmt.Errorf("%w: variable %s references %s secret %s: %w", ErrSecretUnavailable, v.Key, ref.Backend, ref.Path, err)
+			}
+			resolved.Value = value
+		}
+		variables[i] = &resolved
+	}
+	return nil
+}
+
+// resolveOutputMappings resolves the workspace's output mappings into
+// variables, using the current state of each source workspace.
+func (s *Service) resolveOutputMappings(ctx context.Context, workspaceID string) ([]*Variable, error) {
+	mappings, err := s.db.listOutputMappings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	variables := make([]*Variable, len(mappings))
+	for i, m := range mappings {
+		if m.SourceWorkspaceName == "" {
+			return nil, m.unavailable("no longer exists")
+		}
+		current, err := s.state.GetCurrent(ctx, m.SourceWorkspaceID)
+		if errors.Is(err, internal.ErrResourceNotFound) {
+			current = nil
+		} else if errors.Is(err, internal.ErrAccessNotPermitted) {
+			return nil, m.unavailable("does not share its state")
+		} else if err != nil {
+			return nil, err
+		}
+		variables[i], err = m.resolve(current)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return variables, nil
+}
+
+func (s *Service) CreateOutputMapping(ctx context.Context, workspaceID string, opts CreateOutputMappingOptions) (*OutputMapping, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.CreateWorkspaceVariableAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := s.workspaces.Get(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := s.workspaces.Get(ctx, opts.SourceWorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving source workspace: %w", err)
+	}
+	current, err := s.state.GetCurrent(ctx, source.ID)
+	if errors.Is(err, internal.ErrResourceNotFound) {
+		current = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("retrieving current state of source workspace: %w", err)
+	}
+	m, err := newOutputMapping(ws, source, current, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.createOutputMapping(ctx, m); err != nil {
+		s.logger.Error("creating output mapping", "subject", subject, "mapping", m, "err", err)
+		return nil, err
+	}
+	s.logger.Info("created output mapping", "subject", subject, "mapping", m)
+
+	return m, nil
+}
+
+func (s *Service) ListOutputMappings(ctx context.Context, workspaceID string) ([]*OutputMapping, error) {
+	subject, err := s.workspace.CanAccess(ctx, rbac.ListWorkspaceVariablesAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := s.db.listOutputMappings(ctx, workspaceID)
+	if err != nil {
+		s.logger.Error("listing output mappings", "subject", subject, "workspace_id", workspaceID, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("listed output mappings", "subject", subject, "workspace_id", workspaceID, "count", len(mappings))
+
+	return mappings, nil
+}
+
+func (s *Service) DeleteOutputMapping(ctx context.Context, mappingID string) (*OutputMapping, error) {
+	m, err := s.db.getOutputMapping(ctx, mappingID)
+	if err != nil {
+		s.logger.Error("retrieving output mapping", "output_mapping_id", mappingID, "err", err)
+		return nil, err
+	}
+
+	subject, err := s.workspace.CanAccess(ctx, rbac.DeleteWorkspaceVariableAction, m.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.deleteOutputMapping(ctx, mappingID); err != nil {
+		s.logger.Error("deleting output mapping", "subject", subject, "mapping", m, "err", err)
+		return nil, err
+	}
+	s.logger.Info("deleted output mapping", "subject", subject, "mapping", m)
+
+	return m, nil
 }
 
 func (s *Service) CreateWorkspaceVariable(ctx context.Context, workspaceID string, opts CreateVariableOptions) (*Variable, error) {
@@ -0,0 +1,91 @@
+package variable
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/secret"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+func TestService_resolveSecrets(t *testing.T) {
+	ctx := context.Background()
+	registry := secret.NewRegistry()
+	registry.Register("vault", &fakeResolver{secrets: map[string]string{"secret/data/foo#password": "hunter2"}})
+	svc := &Service{
+		logger:  slog.New(&xslog.NoopHandler{}),
+		secrets: registry,
+	}
+	variables := func() []*Variable {
+		return []*Variable{
+			{Key: "region", Value: "eu-west-1", Category: CategoryTerraform},
+			{Key: "password", Value: "vault:secret/data/foo#password", Category: CategoryTerraform},
+		}
+	}
+
+	t.Run("permitted", func(t *testing.T) {
+		svc.workspace = internal.NewAllowAllAuthorizer()
+		vars := variables()
+
+		err := svc.resolveSecrets(ctx, "ws-123", vars)
+		require.NoError(t, err)
+
+		assert.Equal(t, "eu-west-1", vars[0].Value)
+		assert.Equal(t, &Variable{
+			Key:       "password",
+			Value:     "hunter2",
+			Category:  CategoryTerraform,
+			Sensitive: true,
+			Source:    "vault secret secret/data/foo#password",
+		}, vars[1])
+	})
+
+	t.Run("not permitted", func(t *testing.T) {
+		svc.workspace = &denyAuthorizer{}
+		vars := variables()
+
+		err := svc.resolveSecrets(ctx, "ws-123", vars)
+		require.NoError(t, err)
+
+		// the value of the secret is withheld
+		assert.Equal(t, "", vars[1].Value)
+		assert.True(t, vars[1].Sensitive)
+		assert.Equal(t, "vault secret secret/data/foo#password", vars[1].Source)
+	})
+
+	t.Run("unresolvable", func(t *testing.T) {
+		svc.workspace = internal.NewAllowAllAuthorizer()
+		vars := []*Variable{
+			{Key: "token", Value: "vault:secret/data/bar#token", Category: CategoryEnv},
+		}
+
+		err := svc.resolveSecrets(ctx, "ws-123", vars)
+		assert.ErrorIs(t, err, ErrSecretUnavailable)
+		assert.EqualError(t, err, "secret referenced by variable is unavailable: variable token references vault secret secret/data/bar: permission denied")
+	})
+}
+
+type (
+	fakeResolver struct {
+		secrets map[string]string
+	}
+	denyAuthorizer struct{}
+)
+
+func (f *fakeResolver) Resolve(_ context.Context, path, key string) (string, error) {
+	value, ok := f.secrets[path+"#"+key]
+	if !ok {
+		return "", errors.New("permission denied")
+	}
+	return value, nil
+}
+
+func (*denyAuthorizer) CanAccess(context.Context, rbac.Action, string) (internal.Subject, error) {
+	return nil, internal.ErrAccessNotPermitted
+}
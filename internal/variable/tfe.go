@@ -44,6 +44,11 @@ func (a *tfe) addHandlers(r *mux.Router) {
 	r.HandleFunc("/varsets/{varset_id}/relationships/vars/{variable_id}", a.deleteVariableFromSet).Methods("DELETE")
 	r.HandleFunc("/varsets/{varset_id}/relationships/workspaces", a.applySetToWorkspaces).Methods("POST")
 	r.HandleFunc("/varsets/{varset_id}/relationships/workspaces", a.deleteSetFromWorkspaces).Methods("DELETE")
+
+	// tofutf extension: variables sourced from the outputs of other workspaces
+	r.HandleFunc("/workspaces/{workspace_id}/output-mappings", a.createOutputMapping).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/output-mappings", a.listOutputMappings).Methods("GET")
+	r.HandleFunc("/output-mappings/{output_mapping_id}", a.deleteOutputMapping).Methods("DELETE")
 }
 
 func (a *tfe) createWorkspaceVariable(w http.ResponseWriter, r *http.Request) {
@@ -506,3 +511,67 @@ func variableError(w http.ResponseWriter, err error) {
 		tfeapi.Error(w, err)
 	}
 }
+
+func (a *tfe) createOutputMapping(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	var params struct {
+		Type              string `jsonapi:"primary,output-mappings"`
+		Key               string `jsonapi:"attribute" json:"key"`
+		SourceWorkspaceID string `jsonapi:"attribute" json:"source-workspace-id"`
+		SourceOutput      string `jsonapi:"attribute" json:"source-output"`
+	}
+	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	m, err := a.CreateOutputMapping(r.Context(), workspaceID, CreateOutputMappingOptions{
+		Key:               params.Key,
+		SourceWorkspaceID: params.SourceWorkspaceID,
+		SourceOutput:      params.SourceOutput,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrOutputMappingSameWorkspace),
+			errors.Is(err, ErrOutputMappingOrganization),
+			errors.Is(err, ErrOutputMappingStateShared),
+			errors.Is(err, ErrOutputMappingNoState),
+			errors.Is(err, ErrOutputMappingNoSuchOutput):
+			err = &internal.HTTPError{Code: http.StatusUnprocessableEntity, Message: err.Error()}
+		}
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, m, http.StatusCreated)
+}
+
+func (a *tfe) listOutputMappings(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	mappings, err := a.ListOutputMappings(r.Context(), workspaceID)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	a.Respond(w, r, mappings, http.StatusOK)
+}
+
+func (a *tfe) deleteOutputMapping(w http.ResponseWriter, r *http.Request) {
+	mappingID, err := decode.Param("output_mapping_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if _, err := a.DeleteOutputMapping(r.Context(), mappingID); err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
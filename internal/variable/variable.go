@@ -29,6 +29,10 @@ var (
 	ErrVariableKeyMaxExceeded         = fmt.Errorf("maximum variable key size (%d chars) exceeded", VariableKeyMaxChars)
 	ErrVariableValueMaxExceeded       = fmt.Errorf("maximum variable value size of %d KB exceeded", VariableValueMaxKB)
 	ErrVariableConflict               = errors.New("variable conflicts with another variable with the same name and type")
+	// ErrSecretUnavailable is returned when the effective variables of a run
+	// cannot be determined because a secret referenced by a variable cannot
+	// be resolved.
+	ErrSecretUnavailable = errors.New("secret referenced by variable is unavailable")
 )
 
 type (
@@ -40,6 +44,10 @@ type (
 		Category    VariableCategory `jsonapi:"attribute" json:"category"`
 		Sensitive   bool             `jsonapi:"attribute" json:"sensitive"`
 		HCL         bool             `jsonapi:"attribute" json:"hcl"`
+		// Source describes where the value of the variable came from if it
+		// didn't come from the variable itself, e.g. from an output of
+		// another workspace. Only set on the effective variables of a run.
+		Source string `jsonapi:"attribute" json:"source,omitempty"`
 
 		// OTF doesn't use this internally but the go-tfe integration tests
 		// expect it to be a random value that changes on every update.
@@ -33,6 +33,10 @@ type (
 		UpdateWorkspaceVariable(ctx context.Context, variableID string, opts UpdateVariableOptions) (*WorkspaceVariable, error)
 		DeleteWorkspaceVariable(ctx context.Context, variableID string) (*WorkspaceVariable, error)
 
+		CreateOutputMapping(ctx context.Context, workspaceID string, opts CreateOutputMappingOptions) (*OutputMapping, error)
+		ListOutputMappings(ctx context.Context, workspaceID string) ([]*OutputMapping, error)
+		DeleteOutputMapping(ctx context.Context, mappingID string) (*OutputMapping, error)
+
 		createVariableSet(ctx context.Context, organization string, opts CreateVariableSetOptions) (*VariableSet, error)
 		updateVariableSet(ctx context.Context, setID string, opts UpdateVariableSetOptions) (*VariableSet, error)
 		getVariableSet(ctx context.Context, setID string) (*VariableSet, error)
@@ -97,6 +101,9 @@ func (h *web) addHandlers(r *mux.Router) {
 	r.HandleFunc("/variables/{variable_id}/update", h.updateWorkspaceVariable).Methods("POST")
 	r.HandleFunc("/variables/{variable_id}/delete", h.deleteWorkspaceVariable).Methods("POST")
 
+	r.HandleFunc("/workspaces/{workspace_id}/output-mappings/create", h.createOutputMapping).Methods("POST")
+	r.HandleFunc("/output-mappings/{output_mapping_id}/delete", h.deleteOutputMapping).Methods("POST")
+
 	r.HandleFunc("/organizations/{organization_name}/variable-sets", h.listVariableSets).Methods("GET")
 	r.HandleFunc("/organizations/{organization_name}/variable-sets/new", h.newVariableSet).Methods("GET")
 	r.HandleFunc("/organizations/{organization_name}/variable-sets/create", h.createVariableSet).Methods("POST")
@@ -197,6 +204,29 @@ func (h *web) listWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	mappings, err := h.variables.ListOutputMappings(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// workspaces from whose outputs variables can be sourced, i.e. those in
+	// the same organization sharing their state.
+	orgWorkspaces, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*workspace.Workspace], error) {
+		return h.workspaces.List(r.Context(), workspace.ListOptions{
+			Organization: &ws.Organization,
+			PageOptions:  opts,
+		})
+	})
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var sourceWorkspaces []workspaceInfo
+	for _, sw := range orgWorkspaces {
+		if sw.ID != ws.ID && sw.GlobalRemoteState {
+			sourceWorkspaces = append(sourceWorkspaces, workspaceInfo{ID: sw.ID, Name: sw.Name})
+		}
+	}
 	merged := mergeVariables(sets, variables, nil)
 	setVariableTables := make([]setVariableTable, len(sets))
 	for i := range sets {
@@ -211,6 +241,8 @@ func (h *web) listWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
 		workspace.WorkspacePage
 		WorkspaceVariableTable workspaceVariableTable
 		VariableSetTables      []setVariableTable
+		OutputMappings         []*OutputMapping
+		SourceWorkspaces       []workspaceInfo
 		Policy                 internal.WorkspacePolicy
 		CanCreateVariable      bool
 		CanDeleteVariable      bool
@@ -222,6 +254,8 @@ func (h *web) listWorkspaceVariables(w http.ResponseWriter, r *http.Request) {
 			CanDeleteVariable: user.CanAccessWorkspace(rbac.DeleteWorkspaceVariableAction, policy),
 		},
 		VariableSetTables:  setVariableTables,
+		OutputMappings:     mappings,
+		SourceWorkspaces:   sourceWorkspaces,
 		Policy:             policy,
 		CanCreateVariable:  user.CanAccessWorkspace(rbac.CreateWorkspaceVariableAction, policy),
 		CanDeleteVariable:  user.CanAccessWorkspace(rbac.DeleteWorkspaceVariableAction, policy),
@@ -302,6 +336,44 @@ func (h *web) deleteWorkspaceVariable(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, paths.Variables(wv.WorkspaceID), http.StatusFound)
 }
 
+func (h *web) createOutputMapping(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		WorkspaceID string `schema:"workspace_id,required"`
+		CreateOutputMappingOptions
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	m, err := h.variables.CreateOutputMapping(r.Context(), params.WorkspaceID, params.CreateOutputMappingOptions)
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Variables(params.WorkspaceID), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "added variable from workspace output: "+m.Key)
+	http.Redirect(w, r, paths.Variables(params.WorkspaceID), http.StatusFound)
+}
+
+func (h *web) deleteOutputMapping(w http.ResponseWriter, r *http.Request) {
+	mappingID, err := decode.Param("output_mapping_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	m, err := h.variables.DeleteOutputMapping(r.Context(), mappingID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "deleted variable from workspace output: "+m.Key)
+	http.Redirect(w, r, paths.Variables(m.WorkspaceID), http.StatusFound)
+}
+
 func (h *web) listVariableSets(w http.ResponseWriter, r *http.Request) {
 	org, err := decode.Param("organization_name", r)
 	if err != nil {
@@ -11,7 +11,8 @@ const (
 	EventTypePull         EventType = "pull"
 	EventTypePush         EventType = "push"
 	EventTypeTag          EventType = "tag"
-	EventTypeInstallation EventType = "install" // github-app installation
+	EventTypeInstallation EventType = "install"    // github-app installation
+	EventTypeRepository   EventType = "repository" // repo renamed or transferred
 )
 
 type Action string
@@ -21,18 +22,28 @@ const (
 	ActionDeleted Action = "deleted"
 	ActionMerged  Action = "merged"
 	ActionUpdated Action = "updated"
+	ActionMoved   Action = "moved"
 )
 
 // ErrIgnoreEvent informs an upstream vcs provider why an event it sent is
 // ignored.
 type ErrIgnoreEvent struct {
 	Reason string
+	// UnknownType is true if the event is ignored because its type is unknown
+	// or unsupported.
+	UnknownType bool
 }
 
 func NewErrIgnoreEvent(msg string, args ...any) ErrIgnoreEvent {
 	return ErrIgnoreEvent{Reason: fmt.Sprintf(msg, args...)}
 }
 
+// NewErrUnknownEvent constructs an error for an event with a type that is
+// unknown or unsupported.
+func NewErrUnknownEvent(eventType string) ErrIgnoreEvent {
+	return ErrIgnoreEvent{Reason: "unsupported event type: " + eventType, UnknownType: true}
+}
+
 func (e ErrIgnoreEvent) Error() string {
 	return e.Reason
 }
@@ -47,6 +58,9 @@ type (
 
 	EventHeader struct {
 		VCSProviderID string
+		// DeliveryID is the ID of the webhook delivery containing the
+		// event.
+		DeliveryID string
 	}
 
 	EventPayload struct {
@@ -65,6 +79,7 @@ type (
 		PullRequestNumber int
 		PullRequestURL    string
 		PullRequestTitle  string
+		PullRequestDraft  bool
 
 		SenderUsername  string
 		SenderAvatarURL string
@@ -74,6 +89,10 @@ type (
 		// to Push and Tag events types.
 		Paths []string
 
+		// Path of the repo before it was renamed or transferred. Only
+		// applicable to Repository event types.
+		PreviousRepoPath string
+
 		// Only set if event is from a github app
 		GithubAppInstallID *int64
 	}
@@ -94,6 +113,10 @@ func (e EventPayload) Validate() error {
 		if e.RepoPath == "" {
 			return errors.New("event missing repo path")
 		}
+	case EventTypeRepository:
+		if e.RepoPath == "" || e.PreviousRepoPath == "" {
+			return errors.New("event missing repo path or previous repo path")
+		}
 	}
 	return nil
 }
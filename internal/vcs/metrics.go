@@ -0,0 +1,30 @@
+package vcs
+
+import "github.com/prometheus/client_golang/prometheus"
+
+func init() {
+	prometheus.MustRegister(cacheRequestsMetric)
+	prometheus.MustRegister(rateLimitRemainingMetric)
+	prometheus.MustRegister(delayedCallsMetric)
+}
+
+var (
+	cacheRequestsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "otf",
+		Subsystem: "vcs",
+		Name:      "cache_requests_total",
+		Help:      "Total number of cacheable requests to a VCS provider's API, by whether the response was served from the cache.",
+	}, []string{"kind", "host", "result"})
+	rateLimitRemainingMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "otf",
+		Subsystem: "vcs",
+		Name:      "rate_limit_remaining",
+		Help:      "Number of requests remaining in a VCS provider's rate limit quota, as reported by its most recent response.",
+	}, []string{"kind", "host"})
+	delayedCallsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "otf",
+		Subsystem: "vcs",
+		Name:      "delayed_calls_total",
+		Help:      "Total number of background calls to a VCS provider's API delayed to conserve its rate limit quota.",
+	}, []string{"kind", "host"})
+)
@@ -0,0 +1,123 @@
+package vcs
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitThreshold is the fraction of a rate limit quota below which
+	// background calls are delayed.
+	rateLimitThreshold = 0.1
+	// maxBackgroundDelay is the maximum length of time a background call is
+	// delayed.
+	maxBackgroundDelay = time.Minute
+)
+
+type (
+	// rateLimitTracker tracks the rate limits of VCS provider credentials,
+	// as reported in the headers of the provider's responses.
+	rateLimitTracker struct {
+		mu     sync.Mutex
+		limits map[string]rateLimit
+	}
+
+	rateLimit struct {
+		limit     int
+		remaining int
+		reset     time.Time
+	}
+
+	backgroundCtxKey struct{}
+)
+
+// WithBackgroundPriority returns a context for making calls to a VCS
+// provider that are not urgent, such as refreshing a module. When the
+// provider's remaining rate limit quota runs low, background calls are
+// delayed to preserve the quota for urgent calls, such as setting commit
+// statuses.
+func WithBackgroundPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, backgroundCtxKey{}, true)
+}
+
+func isBackground(ctx context.Context) bool {
+	background, _ := ctx.Value(backgroundCtxKey{}).(bool)
+	return background
+}
+
+func newRateLimitTracker() *rateLimitTracker {
+	return &rateLimitTracker{limits: make(map[string]rateLimit)}
+}
+
+// update records the rate limit reported in the headers of a response for
+// the credential identified by key, returning false if the headers report no
+// rate limit. Github reports its rate limit in X-RateLimit-* headers, and
+// Gitlab in RateLimit-* headers.
+func (t *rateLimitTracker) update(key string, header http.Header, now time.Time) (rateLimit, bool) {
+	limit, ok := rateLimitHeader(header, "Limit")
+	if !ok {
+		return rateLimit{}, false
+	}
+	remaining, ok := rateLimitHeader(header, "Remaining")
+	if !ok {
+		return rateLimit{}, false
+	}
+	reset, ok := rateLimitHeader(header, "Reset")
+	if !ok {
+		return rateLimit{}, false
+	}
+	rl := rateLimit{limit: limit, remaining: remaining, reset: time.Unix(int64(reset), 0)}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.limits[key] = rl
+	// forget rate limits that have since reset, to prevent credentials that
+	// are no longer used, such as expired app tokens, from accumulating.
+	for k, v := range t.limits {
+		if v.reset.Before(now) {
+			delete(t.limits, k)
+		}
+	}
+	return rl, true
+}
+
+// delay returns the length of time a background call using the credential
+// identified by key should be delayed. Once the remaining quota drops below
+// the threshold, background calls are spaced out so that the remaining quota
+// lasts until it is reset.
+func (t *rateLimitTracker) delay(key string, now time.Time) time.Duration {
+	t.mu.Lock()
+	rl, ok := t.limits[key]
+	t.mu.Unlock()
+
+	if !ok || !rl.reset.After(now) {
+		return 0
+	}
+	if float64(rl.remaining) >= rateLimitThreshold*float64(rl.limit) {
+		return 0
+	}
+	untilReset := rl.reset.Sub(now)
+	if rl.remaining > 0 {
+		untilReset /= time.Duration(rl.remaining)
+	}
+	return min(untilReset, maxBackgroundDelay)
+}
+
+func rateLimitHeader(header http.Header, name string) (int, bool) {
+	v := header.Get("X-RateLimit-" + name)
+	if v == "" {
+		v = header.Get("RateLimit-" + name)
+	}
+	if v == "" {
+		return 0, false
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
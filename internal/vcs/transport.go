@@ -0,0 +1,226 @@
+package vcs
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// maxCachedResponses is the maximum number of responses cached across all
+	// VCS providers.
+	maxCachedResponses = 1000
+	// maxCachedResponseSize is the maximum size in bytes of the body of a
+	// cached response.
+	maxCachedResponseSize = 1 << 20
+)
+
+var (
+	// responses and rateLimits are shared by all transports, because clients
+	// are constructed afresh for each use of a VCS provider.
+	responses  = newResponseCache(maxCachedResponses)
+	rateLimits = newRateLimitTracker()
+)
+
+type (
+	// Transport is a http.RoundTripper for calls to a VCS provider's API. It
+	// caches responses bearing an ETag, revalidating them with conditional
+	// requests, which providers don't count against the rate limit. It also
+	// tracks the provider's rate limit, delaying background calls when the
+	// remaining quota runs low (see WithBackgroundPriority).
+	//
+	// Transport should sit beneath any transport that authenticates requests,
+	// so that responses and rate limits are tracked per credential.
+	Transport struct {
+		kind   Kind
+		host   string
+		base   http.RoundTripper
+		cache  *responseCache
+		limits *rateLimitTracker
+		now    func() time.Time
+	}
+
+	// responseCache is a least-recently-used cache of responses.
+	responseCache struct {
+		mu       sync.Mutex
+		capacity int
+		entries  map[string]*list.Element
+		order    *list.List
+	}
+
+	cachedResponse struct {
+		key    string
+		etag   string
+		header http.Header
+		body   []byte
+	}
+)
+
+// NewTransport constructs a transport for calls to the API of a VCS provider
+// of the given kind and hostname, sending requests using base.
+func NewTransport(kind Kind, host string, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		kind:   kind,
+		host:   host,
+		base:   base,
+		cache:  responses,
+		limits: rateLimits,
+		now:    time.Now,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := credentialKey(req)
+
+	if isBackground(req.Context()) {
+		if err := t.wait(req.Context(), t.limits.delay(key, t.now())); err != nil {
+			return nil, err
+		}
+	}
+
+	cacheable := req.Method == http.MethodGet && req.Header.Get("Range") == ""
+	cacheKey := key + " " + req.URL.String()
+	var cached *cachedResponse
+	if cacheable {
+		cached = t.cache.get(cacheKey)
+		if cached != nil {
+			req = req.Clone(req.Context())
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if rl, ok := t.limits.update(key, resp.Header, t.now()); ok {
+		rateLimitRemainingMetric.WithLabelValues(string(t.kind), t.host).Set(float64(rl.remaining))
+	}
+	if !cacheable {
+		return resp, nil
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		cacheRequestsMetric.WithLabelValues(string(t.kind), t.host, "hit").Inc()
+		io.Copy(io.Discard, resp.Body) //nolint:errcheck
+		resp.Body.Close()
+		return cached.response(req, resp), nil
+	}
+	cacheRequestsMetric.WithLabelValues(string(t.kind), t.host, "miss").Inc()
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+	// read up to one byte beyond the maximum size to determine whether the
+	// body is small enough to cache.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedResponseSize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedResponseSize {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.cache.add(&cachedResponse{
+		key:    cacheKey,
+		etag:   etag,
+		header: resp.Header.Clone(),
+		body:   body,
+	})
+	return resp, nil
+}
+
+func (t *Transport) wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	delayedCallsMetric.WithLabelValues(string(t.kind), t.host).Inc()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// credentialKey identifies the host and credential of a request, without
+// retaining the credential itself.
+func credentialKey(req *http.Request) string {
+	h := sha256.Sum256([]byte(req.Header.Get("Authorization") + req.Header.Get("Private-Token")))
+	return req.URL.Host + " " + hex.EncodeToString(h[:8])
+}
+
+// response constructs a response from the cached response, updated with the
+// headers of the not modified response revalidating it.
+func (c *cachedResponse) response(req *http.Request, notModified *http.Response) *http.Response {
+	header := c.header.Clone()
+	for k, v := range notModified.Header {
+		header[k] = v
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
+
+func newResponseCache(capacity int) *responseCache {
+	return &responseCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *responseCache) get(key string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cachedResponse)
+}
+
+func (c *responseCache) add(resp *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[resp.key]; ok {
+		elem.Value = resp
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[resp.key] = c.order.PushFront(resp)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
@@ -0,0 +1,162 @@
+package vcs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTransport() *Transport {
+	tr := NewTransport(GithubKind, "github.com", nil)
+	tr.cache = newResponseCache(maxCachedResponses)
+	tr.limits = newRateLimitTracker()
+	return tr
+}
+
+func TestTransport_Cache(t *testing.T) {
+	var requests, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`["acme/terraform"]`)) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: newTestTransport()}
+	get := func(token string) string {
+		req, err := http.NewRequest("GET", srv.URL+"/user/repos", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	assert.Equal(t, `["acme/terraform"]`, get("token-1"))
+	assert.Equal(t, int32(0), notModified.Load())
+
+	// revalidated response is served from the cache
+	assert.Equal(t, `["acme/terraform"]`, get("token-1"))
+	assert.Equal(t, int32(1), notModified.Load())
+
+	// responses are not shared between credentials
+	assert.Equal(t, `["acme/terraform"]`, get("token-2"))
+	assert.Equal(t, int32(1), notModified.Load())
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestTransport_BackgroundPriority(t *testing.T) {
+	tr := newTestTransport()
+	now := time.Now()
+	tr.now = func() time.Time { return now }
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "10")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(time.Hour).Unix(), 10))
+	}))
+	t.Cleanup(srv.Close)
+	client := &http.Client{Transport: tr}
+
+	send := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "POST", srv.URL+"/repos/acme/terraform/statuses/abc123", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	// first call records the rate limit
+	require.NoError(t, send(context.Background()))
+
+	t.Run("urgent calls are not delayed", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, send(ctx))
+	})
+
+	t.Run("background calls are delayed", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(WithBackgroundPriority(context.Background()), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, send(ctx), context.DeadlineExceeded)
+	})
+}
+
+func TestRateLimitTracker(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	header := func(limit, remaining int, reset time.Time) http.Header {
+		h := http.Header{}
+		h.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		return h
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"no rate limit", http.Header{}, 0},
+		{"quota above threshold", header(5000, 4000, now.Add(time.Hour)), 0},
+		{"quota below threshold", header(5000, 400, now.Add(400*time.Second)), time.Second},
+		{"delay is capped", header(5000, 10, now.Add(time.Hour)), maxBackgroundDelay},
+		{"quota exhausted", header(5000, 0, now.Add(30*time.Second)), 30 * time.Second},
+		{"quota reset", header(5000, 0, now.Add(-time.Second)), 0},
+		{
+			name: "gitlab headers",
+			header: http.Header{
+				"Ratelimit-Limit":     []string{"2000"},
+				"Ratelimit-Remaining": []string{"100"},
+				"Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(200*time.Second).Unix(), 10)},
+			},
+			want: 2 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newRateLimitTracker()
+			tracker.update("key", tt.header, now)
+			assert.Equal(t, tt.want, tracker.delay("key", now))
+		})
+	}
+
+	t.Run("forget reset rate limits", func(t *testing.T) {
+		tracker := newRateLimitTracker()
+		tracker.update("expired", header(5000, 0, now.Add(time.Second)), now)
+		tracker.update("current", header(5000, 0, now.Add(time.Hour)), now.Add(2*time.Second))
+		assert.Len(t, tracker.limits, 1)
+	})
+}
+
+func TestResponseCache(t *testing.T) {
+	cache := newResponseCache(2)
+	cache.add(&cachedResponse{key: "a"})
+	cache.add(&cachedResponse{key: "b"})
+	// a is now the most recently used
+	require.NotNil(t, cache.get("a"))
+	cache.add(&cachedResponse{key: "c"})
+
+	assert.NotNil(t, cache.get("a"))
+	assert.Nil(t, cache.get("b"))
+	assert.NotNil(t, cache.get("c"))
+}
@@ -0,0 +1,49 @@
+package vcs
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// IgnoreUnknownEvents ignores events with an unknown or unsupported type,
+	// logging that they were ignored. This is the default.
+	IgnoreUnknownEvents UnknownEventPolicy = "ignore"
+	// RejectUnknownEvents rejects events with an unknown or unsupported type,
+	// responding with a 400.
+	RejectUnknownEvents UnknownEventPolicy = "reject"
+)
+
+var (
+	ErrInvalidUnknownEventPolicy = errors.New("unknown event policy must be either ignore or reject")
+	// ErrRejectedEvent is returned for an event rejected because its type is
+	// unknown or unsupported.
+	ErrRejectedEvent = errors.New("rejected event")
+)
+
+// UnknownEventPolicy determines how an event sent by a cloud is handled if
+// its type is unknown or unsupported. The zero value is equivalent to
+// IgnoreUnknownEvents.
+type UnknownEventPolicy string
+
+// Validate the policy.
+func (p UnknownEventPolicy) Validate() error {
+	switch p {
+	case "", IgnoreUnknownEvents, RejectUnknownEvents:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidUnknownEventPolicy, p)
+	}
+}
+
+// Apply the policy to the error returned from unmarshaling an event. If the
+// policy is to reject unknown events and the event is being ignored because
+// its type is unknown, then ErrRejectedEvent is returned instead. Otherwise
+// the error is returned unchanged.
+func (p UnknownEventPolicy) Apply(err error) error {
+	var ignore ErrIgnoreEvent
+	if p == RejectUnknownEvents && errors.As(err, &ignore) && ignore.UnknownType {
+		return fmt.Errorf("%w: %s", ErrRejectedEvent, ignore.Reason)
+	}
+	return err
+}
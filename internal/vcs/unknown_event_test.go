@@ -0,0 +1,49 @@
+package vcs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnknownEventPolicy_Apply(t *testing.T) {
+	unknown := NewErrUnknownEvent("ping")
+	unsupportedAction := NewErrIgnoreEvent("unsupported action: edited")
+	other := errors.New("parsing payload")
+
+	tests := []struct {
+		name    string
+		policy  UnknownEventPolicy
+		err     error
+		want    error
+		ignored bool
+	}{
+		{"default ignores unknown event", "", unknown, unknown, true},
+		{"ignore unknown event", IgnoreUnknownEvents, unknown, unknown, true},
+		{"reject unknown event", RejectUnknownEvents, unknown, ErrRejectedEvent, false},
+		{"reject policy ignores unsupported action", RejectUnknownEvents, unsupportedAction, unsupportedAction, true},
+		{"reject policy leaves other errors", RejectUnknownEvents, other, other, false},
+		{"no error", RejectUnknownEvents, nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Apply(tt.err)
+			if tt.want == nil {
+				assert.NoError(t, got)
+				return
+			}
+			assert.ErrorIs(t, got, tt.want)
+
+			var ignore ErrIgnoreEvent
+			assert.Equal(t, tt.ignored, errors.As(got, &ignore))
+		})
+	}
+}
+
+func TestUnknownEventPolicy_Validate(t *testing.T) {
+	assert.NoError(t, UnknownEventPolicy("").Validate())
+	assert.NoError(t, IgnoreUnknownEvents.Validate())
+	assert.NoError(t, RejectUnknownEvents.Validate())
+	assert.ErrorIs(t, UnknownEventPolicy("strict").Validate(), ErrInvalidUnknownEventPolicy)
+}
@@ -0,0 +1,49 @@
+package vcs
+
+import (
+	"fmt"
+	"mime"
+	"net/url"
+)
+
+const (
+	jsonContentType = "application/json"
+	formContentType = "application/x-www-form-urlencoded"
+	// payloadFormField is the form field containing the JSON payload of a
+	// form-encoded webhook request.
+	payloadFormField = "payload"
+)
+
+// WebhookPayload returns the JSON payload contained in the body of a webhook
+// request with the given content type. Some providers, such as Github, can be
+// configured to send form-encoded payloads, in which case the JSON payload is
+// decoded from the payload form field. A request without a content type is
+// assumed to be JSON.
+//
+// Signatures are calculated over the body as sent, so they should be verified
+// against the body rather than the returned payload.
+func WebhookPayload(contentType string, body []byte) ([]byte, error) {
+	if contentType == "" {
+		return body, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("parsing content type: %w", err)
+	}
+	switch mediaType {
+	case jsonContentType:
+		return body, nil
+	case formContentType:
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("parsing form-encoded payload: %w", err)
+		}
+		payload := form.Get(payloadFormField)
+		if payload == "" {
+			return nil, fmt.Errorf("form-encoded payload missing %s field", payloadFormField)
+		}
+		return []byte(payload), nil
+	default:
+		return nil, fmt.Errorf("unsupported content type: %s", mediaType)
+	}
+}
@@ -0,0 +1,39 @@
+package vcs
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookPayload(t *testing.T) {
+	form := url.Values{"payload": []string{`{"ref":"refs/heads/main"}`}}.Encode()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+		wantErr     bool
+	}{
+		{"json", "application/json", `{"ref":"refs/heads/main"}`, `{"ref":"refs/heads/main"}`, false},
+		{"json with charset", "application/json; charset=utf-8", `{"ref":"refs/heads/main"}`, `{"ref":"refs/heads/main"}`, false},
+		{"no content type", "", `{"ref":"refs/heads/main"}`, `{"ref":"refs/heads/main"}`, false},
+		{"form-encoded", "application/x-www-form-urlencoded", form, `{"ref":"refs/heads/main"}`, false},
+		{"form-encoded without payload", "application/x-www-form-urlencoded", "foo=bar", "", true},
+		{"unsupported content type", "text/plain", "hello", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WebhookPayload(tt.contentType, []byte(tt.body))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
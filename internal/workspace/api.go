@@ -12,7 +12,7 @@ import (
 
 type (
 	api struct {
-		*Service
+		HandlerClient
 		*tfeapi.Responder
 	}
 )
@@ -25,9 +25,14 @@ func (a *api) addHandlers(r *mux.Router) {
 
 	r.HandleFunc("/workspaces/{workspace_id}", a.updateWorkspace).Methods("PATCH")
 	r.HandleFunc("/workspaces/{workspace_id}", a.getWorkspace).Methods("GET")
+	r.HandleFunc("/workspaces/{workspace_id}/terraform-version", a.getTerraformVersion).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}/actions/lock", a.lockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/actions/unlock", a.unlockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/actions/force-unlock", a.forceUnlockWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/actions/pause", a.pauseWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/actions/resume", a.resumeWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/actions/enable-debug-logging", a.enableDebugLogging).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/actions/disable-debug-logging", a.disableDebugLogging).Methods("POST")
 }
 
 func (a *api) getWorkspace(w http.ResponseWriter, r *http.Request) {
@@ -46,6 +51,22 @@ func (a *api) getWorkspace(w http.ResponseWriter, r *http.Request) {
 	a.Respond(w, r, ws, http.StatusOK)
 }
 
+func (a *api) getTerraformVersion(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	version, err := a.GetTerraformVersion(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	a.Respond(w, r, version, http.StatusOK)
+}
+
 func (a *api) getWorkspaceByName(w http.ResponseWriter, r *http.Request) {
 	var params byWorkspaceName
 	if err := decode.All(&params, r); err != nil {
@@ -108,7 +129,7 @@ func (a *api) lockWorkspace(w http.ResponseWriter, r *http.Request) {
 
 	ws, err := a.Lock(r.Context(), id, nil)
 	if err != nil {
-		tfeapi.Error(w, err)
+		tfeapi.Error(w, lockConflictError(err))
 		return
 	}
 
@@ -131,6 +152,78 @@ func (a *api) unlock(w http.ResponseWriter, r *http.Request, force bool) {
 	}
 
 	ws, err := a.Unlock(r.Context(), id, nil, force)
+	if err != nil {
+		tfeapi.Error(w, lockConflictError(err))
+		return
+	}
+
+	a.Respond(w, r, ws, http.StatusOK)
+}
+
+func (a *api) pauseWorkspace(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	ws, err := a.Pause(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, pauseConflictError(err))
+		return
+	}
+
+	a.Respond(w, r, ws, http.StatusOK)
+}
+
+func (a *api) resumeWorkspace(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	ws, err := a.Resume(r.Context(), id)
+	if err != nil {
+		tfeapi.Error(w, pauseConflictError(err))
+		return
+	}
+
+	a.Respond(w, r, ws, http.StatusOK)
+}
+
+func (a *api) enableDebugLogging(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	var params EnableDebugLoggingOptions
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+	}
+
+	ws, err := a.EnableDebugLogging(r.Context(), id, params)
+	if err != nil {
+		tfeapi.Error(w, debugLoggingError(err))
+		return
+	}
+
+	a.Respond(w, r, ws, http.StatusOK)
+}
+
+func (a *api) disableDebugLogging(w http.ResponseWriter, r *http.Request) {
+	id, err := decode.Param("workspace_id", r)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+
+	ws, err := a.DisableDebugLogging(r.Context(), id)
 	if err != nil {
 		tfeapi.Error(w, err)
 		return
@@ -0,0 +1,226 @@
+package workspace
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxApplyWindowDuration is the longest an apply window can stay open: a
+// window with an end time the same as its start time stays open for a whole
+// day.
+const maxApplyWindowDuration = 24 * time.Hour
+
+type (
+	// ApplyWindow is a weekly recurring period during which runs on the
+	// workspace may be applied. Plans are unaffected.
+	ApplyWindow struct {
+		// Days of the week on which the window opens.
+		Days []time.Weekday `json:"days"`
+		// Start is the time of day at which the window opens, in the form
+		// HH:MM.
+		Start string `json:"start"`
+		// End is the time of day at which the window closes, in the form
+		// HH:MM. If End is not after Start then the window closes on the
+		// following day.
+		End string `json:"end"`
+		// Timezone is the IANA name of the timezone in which Start and End are
+		// expressed, e.g. Europe/London. Defaults to UTC.
+		Timezone string `json:"timezone,omitempty"`
+	}
+
+	// ApplyWindows are the windows during which runs may be applied. No
+	// windows means runs may be applied at any time.
+	ApplyWindows []ApplyWindow
+)
+
+// ParseWeekday parses the name of a day of the week, e.g. monday or mon.
+func ParseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(s)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown day: %s", ErrInvalidApplyWindow, s)
+}
+
+// ParseApplyWindows parses apply windows from their string form, one window
+// per line, e.g. "mon,tue 09:00-17:00 Europe/London". The timezone is
+// optional. Blank lines are ignored.
+func ParseApplyWindows(s string) (ApplyWindows, error) {
+	windows := ApplyWindows{}
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 3 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidApplyWindow, line)
+		}
+		var w ApplyWindow
+		for _, name := range strings.Split(fields[0], ",") {
+			d, err := ParseWeekday(name)
+			if err != nil {
+				return nil, err
+			}
+			w.Days = append(w.Days, d)
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%w: missing times: %s", ErrInvalidApplyWindow, line)
+		}
+		var found bool
+		w.Start, w.End, found = strings.Cut(fields[1], "-")
+		if !found {
+			return nil, fmt.Errorf("%w: times must be in the form HH:MM-HH:MM: %s", ErrInvalidApplyWindow, line)
+		}
+		if len(fields) == 3 {
+			w.Timezone = fields[2]
+		}
+		if err := w.Validate(); err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// String renders the window in the form accepted by ParseApplyWindows.
+func (w ApplyWindow) String() string {
+	days := make([]string, len(w.Days))
+	for i, d := range w.Days {
+		days[i] = strings.ToLower(d.String()[:3])
+	}
+	s := fmt.Sprintf("%s %s-%s", strings.Join(days, ","), w.Start, w.End)
+	if w.Timezone != "" {
+		s += " " + w.Timezone
+	}
+	return s
+}
+
+// String renders the windows in the form accepted by ParseApplyWindows.
+func (windows ApplyWindows) String() string {
+	lines := make([]string, len(windows))
+	for i, w := range windows {
+		lines[i] = w.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks the window is well-formed.
+func (w ApplyWindow) Validate() error {
+	if len(w.Days) == 0 {
+		return fmt.Errorf("%w: at least one day must be specified", ErrInvalidApplyWindow)
+	}
+	for _, d := range w.Days {
+		if d < time.Sunday || d > time.Saturday {
+			return fmt.Errorf("%w: unknown day: %d", ErrInvalidApplyWindow, d)
+		}
+	}
+	if _, err := parseTimeOfDay(w.Start); err != nil {
+		return err
+	}
+	if _, err := parseTimeOfDay(w.End); err != nil {
+		return err
+	}
+	if _, err := w.location(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w ApplyWindow) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidApplyWindow, err)
+	}
+	return loc, nil
+}
+
+// parseTimeOfDay parses a time of day in the form HH:MM, returning the
+// duration since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid time of day: %s", ErrInvalidApplyWindow, s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// openings returns the times at which the window opens on each day from the
+// given number of days before now up until the given number of days after
+// now, along with how long the window stays open. The window is expected to
+// have been validated.
+func (w ApplyWindow) openings(now time.Time, before, after int) ([]time.Time, time.Duration) {
+	loc, _ := w.location()
+	start, _ := parseTimeOfDay(w.Start)
+	end, _ := parseTimeOfDay(w.End)
+	duration := end - start
+	if duration <= 0 {
+		duration += maxApplyWindowDuration
+	}
+	local := now.In(loc)
+	var openings []time.Time
+	for i := -before; i <= after; i++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+i, 0, 0, 0, 0, loc)
+		if !slices.Contains(w.Days, day.Weekday()) {
+			continue
+		}
+		opening := time.Date(day.Year(), day.Month(), day.Day(), int(start/time.Hour), int((start%time.Hour)/time.Minute), 0, 0, loc)
+		openings = append(openings, opening)
+	}
+	return openings, duration
+}
+
+// Validate checks each window is well-formed.
+func (windows ApplyWindows) Validate() error {
+	for _, w := range windows {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open determines whether runs may be applied at the given time, which is
+// always the case if there are no windows.
+func (windows ApplyWindows) Open(now time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, w := range windows {
+		// a window that opened the day before may still be open
+		openings, duration := w.openings(now, 1, 0)
+		for _, opening := range openings {
+			if !now.Before(opening) && now.Before(opening.Add(duration)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Next returns the time at which the next window opens after the given time.
+// Nil is returned if there are no windows.
+func (windows ApplyWindows) Next(now time.Time) *time.Time {
+	var next *time.Time
+	for _, w := range windows {
+		openings, _ := w.openings(now, 0, 7)
+		for _, opening := range openings {
+			if !opening.After(now) {
+				continue
+			}
+			if next == nil || opening.Before(*next) {
+				opening := opening
+				next = &opening
+			}
+			break
+		}
+	}
+	return next
+}
@@ -0,0 +1,134 @@
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyWindows_Open(t *testing.T) {
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+	tests := []struct {
+		name    string
+		windows ApplyWindows
+		now     time.Time
+		want    bool
+	}{
+		{
+			name: "no windows",
+			now:  time.Date(2026, 7, 11, 3, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name:    "inside window",
+			windows: ApplyWindows{{Days: weekdays, Start: "09:00", End: "17:00"}},
+			now:     time.Date(2026, 7, 8, 10, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name:    "window closes at end time",
+			windows: ApplyWindows{{Days: weekdays, Start: "09:00", End: "17:00"}},
+			now:     time.Date(2026, 7, 8, 17, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+		{
+			name:    "day without window",
+			windows: ApplyWindows{{Days: weekdays, Start: "09:00", End: "17:00"}},
+			now:     time.Date(2026, 7, 11, 10, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+		{
+			name:    "window spanning midnight still open the following day",
+			windows: ApplyWindows{{Days: []time.Weekday{time.Friday}, Start: "22:00", End: "02:00"}},
+			now:     time.Date(2026, 7, 11, 1, 0, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name:    "window spanning midnight closed the following day",
+			windows: ApplyWindows{{Days: []time.Weekday{time.Friday}, Start: "22:00", End: "02:00"}},
+			now:     time.Date(2026, 7, 11, 3, 0, 0, 0, time.UTC),
+			want:    false,
+		},
+		{
+			name:    "window in another timezone",
+			windows: ApplyWindows{{Days: []time.Weekday{time.Monday}, Start: "09:00", End: "10:00", Timezone: "America/New_York"}},
+			now:     time.Date(2026, 7, 6, 13, 30, 0, 0, time.UTC),
+			want:    true,
+		},
+		{
+			name: "any of several windows",
+			windows: ApplyWindows{
+				{Days: weekdays, Start: "09:00", End: "17:00"},
+				{Days: []time.Weekday{time.Saturday}, Start: "08:00", End: "12:00"},
+			},
+			now:  time.Date(2026, 7, 11, 10, 0, 0, 0, time.UTC),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.windows.Open(tt.now))
+		})
+	}
+}
+
+func TestApplyWindows_Next(t *testing.T) {
+	windows := ApplyWindows{{
+		Days:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start: "09:00",
+		End:   "17:00",
+	}}
+
+	t.Run("later the same day", func(t *testing.T) {
+		got := windows.Next(time.Date(2026, 7, 8, 7, 0, 0, 0, time.UTC))
+		require.NotNil(t, got)
+		assert.True(t, time.Date(2026, 7, 8, 9, 0, 0, 0, time.UTC).Equal(*got))
+	})
+
+	t.Run("after the weekend", func(t *testing.T) {
+		got := windows.Next(time.Date(2026, 7, 10, 18, 0, 0, 0, time.UTC))
+		require.NotNil(t, got)
+		assert.True(t, time.Date(2026, 7, 13, 9, 0, 0, 0, time.UTC).Equal(*got))
+	})
+
+	t.Run("no windows", func(t *testing.T) {
+		assert.Nil(t, ApplyWindows{}.Next(time.Now()))
+	})
+}
+
+func TestParseApplyWindows(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		s := "mon,tue 09:00-17:00 Europe/London\nsat 22:00-02:00"
+		got, err := ParseApplyWindows(s)
+		require.NoError(t, err)
+
+		want := ApplyWindows{
+			{Days: []time.Weekday{time.Monday, time.Tuesday}, Start: "09:00", End: "17:00", Timezone: "Europe/London"},
+			{Days: []time.Weekday{time.Saturday}, Start: "22:00", End: "02:00"},
+		}
+		assert.Equal(t, want, got)
+		assert.Equal(t, s, got.String())
+	})
+
+	t.Run("blank", func(t *testing.T) {
+		got, err := ParseApplyWindows("\n  \n")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	for _, s := range []string{
+		"someday 09:00-17:00",
+		"mon",
+		"mon 09:00",
+		"mon 9am-5pm",
+		"mon 09:00-17:00 Mars/Olympus_Mons",
+	} {
+		t.Run("invalid: "+s, func(t *testing.T) {
+			_, err := ParseApplyWindows(s)
+			assert.ErrorIs(t, err, ErrInvalidApplyWindow)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"context"
+)
+
+// BulkUpdateResult is the result of updating one of several workspaces.
+type BulkUpdateResult struct {
+	WorkspaceID string
+	// Workspace is the updated workspace; nil if the update failed.
+	Workspace *Workspace
+	// Err is the reason the update failed; nil if the update succeeded.
+	Err error
+}
+
+// BulkUpdate applies the same update to each of the given workspaces. Each
+// workspace is updated separately; failing to update one does not prevent the
+// others from being updated. A result is returned for each workspace, in the
+// order given.
+func (s *Service) BulkUpdate(ctx context.Context, workspaceIDs []string, opts UpdateOptions) []BulkUpdateResult {
+	return bulkUpdate(ctx, s, workspaceIDs, opts)
+}
+
+type bulkUpdateClient interface {
+	Update(ctx context.Context, workspaceID string, opts UpdateOptions) (*Workspace, error)
+}
+
+func bulkUpdate(ctx context.Context, client bulkUpdateClient, workspaceIDs []string, opts UpdateOptions) []BulkUpdateResult {
+	results := make([]BulkUpdateResult, len(workspaceIDs))
+	for i, id := range workspaceIDs {
+		results[i].WorkspaceID = id
+		results[i].Workspace, results[i].Err = client.Update(ctx, id, opts)
+	}
+	return results
+}
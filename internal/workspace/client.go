@@ -39,6 +39,19 @@ func (c *Client) Get(ctx context.Context, workspaceID string) (*Workspace, error
 	return &ws, nil
 }
 
+func (c *Client) GetTerraformVersion(ctx context.Context, workspaceID string) (*ResolvedTerraformVersion, error) {
+	path := fmt.Sprintf("workspaces/%s/terraform-version", workspaceID)
+	req, err := c.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var version ResolvedTerraformVersion
+	if err := c.Do(ctx, req, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
 func (c *Client) List(ctx context.Context, opts ListOptions) (*resource.Page[*Workspace], error) {
 	u := fmt.Sprintf("organizations/%s/workspaces", url.QueryEscape(*opts.Organization))
 	req, err := c.NewRequest("GET", u, &opts)
@@ -106,3 +119,51 @@ func (c *Client) Unlock(ctx context.Context, workspaceID string, runID *string,
 
 	return &ws, nil
 }
+
+func (c *Client) Pause(ctx context.Context, workspaceID string) (*Workspace, error) {
+	return c.togglePause(ctx, fmt.Sprintf("workspaces/%s/actions/pause", workspaceID))
+}
+
+func (c *Client) Resume(ctx context.Context, workspaceID string) (*Workspace, error) {
+	return c.togglePause(ctx, fmt.Sprintf("workspaces/%s/actions/resume", workspaceID))
+}
+
+func (c *Client) togglePause(ctx context.Context, path string) (*Workspace, error) {
+	req, err := c.NewRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ws Workspace
+	if err := c.Do(ctx, req, &ws); err != nil {
+		return nil, err
+	}
+
+	return &ws, nil
+}
+
+func (c *Client) EnableDebugLogging(ctx context.Context, workspaceID string, opts EnableDebugLoggingOptions) (*Workspace, error) {
+	return c.debugLoggingAction(ctx, fmt.Sprintf("workspaces/%s/actions/enable-debug-logging", workspaceID), &opts)
+}
+
+func (c *Client) DisableDebugLogging(ctx context.Context, workspaceID string) (*Workspace, error) {
+	return c.debugLoggingAction(ctx, fmt.Sprintf("workspaces/%s/actions/disable-debug-logging", workspaceID), nil)
+}
+
+func (c *Client) debugLoggingAction(ctx context.Context, path string, opts *EnableDebugLoggingOptions) (*Workspace, error) {
+	var body any
+	if opts != nil {
+		body = opts
+	}
+	req, err := c.NewRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var ws Workspace
+	if err := c.Do(ctx, req, &ws); err != nil {
+		return nil, err
+	}
+
+	return &ws, nil
+}
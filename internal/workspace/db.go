@@ -2,6 +2,8 @@ package workspace
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/tofutf/tofutf/internal"
@@ -48,6 +50,18 @@ type (
 		VCSTagsRegex               pgtype.Text           `json:"vcs_tags_regex"`
 		AllowCLIApply              pgtype.Bool           `json:"allow_cli_apply"`
 		AgentPoolID                pgtype.Text           `json:"agent_pool_id"`
+		DeletionProtected          pgtype.Bool           `json:"deletion_protected"`
+		LogScrubbingDisabled       pgtype.Bool           `json:"log_scrubbing_disabled"`
+		VCSSkipDrafts              pgtype.Bool           `json:"vcs_skip_drafts"`
+		ApplyWindows               []byte                `json:"apply_windows"`
+		LockAcquiredAt             pgtype.Timestamptz    `json:"lock_acquired_at"`
+		PausedAt                   pgtype.Timestamptz    `json:"paused_at"`
+		PreflightChecks            []string              `json:"preflight_checks"`
+		LockInfo                   []byte                `json:"lock_info"`
+		DebugLoggingExpiresAt      pgtype.Timestamptz    `json:"debug_logging_expires_at"`
+		DebugLoggingRunsRemaining  pgtype.Int4           `json:"debug_logging_runs_remaining"`
+		PlanAgentPoolID            pgtype.Text           `json:"plan_agent_pool_id"`
+		ApplyAgentPoolID           pgtype.Text           `json:"apply_agent_pool_id"`
 		Tags                       []string              `json:"tags"`
 		LatestRunStatus            pgtype.Text           `json:"latest_run_status"`
 		UserLock                   pggen.Users           `json:"user_lock"`
@@ -70,6 +84,7 @@ func (r pgresult) toWorkspace() (*Workspace, error) {
 		GlobalRemoteState:          r.GlobalRemoteState.Bool,
 		MigrationEnvironment:       r.MigrationEnvironment.String,
 		Name:                       r.Name.String,
+		PreflightChecks:            NewPreflightChecks(r.PreflightChecks),
 		QueueAllRuns:               r.QueueAllRuns.Bool,
 		SpeculativeEnabled:         r.SpeculativeEnabled.Bool,
 		StructuredRunOutputEnabled: r.StructuredRunOutputEnabled.Bool,
@@ -81,10 +96,36 @@ func (r pgresult) toWorkspace() (*Workspace, error) {
 		WorkingDirectory:           r.WorkingDirectory.String,
 		Organization:               r.OrganizationName.String,
 		Tags:                       r.Tags,
+		DeletionProtected:          r.DeletionProtected.Bool,
+		LogScrubbingDisabled:       r.LogScrubbingDisabled.Bool,
 	}
 	if r.AgentPoolID.Valid {
 		ws.AgentPoolID = &r.AgentPoolID.String
 	}
+	if r.PlanAgentPoolID.Valid {
+		ws.PlanAgentPoolID = &r.PlanAgentPoolID.String
+	}
+	if r.ApplyAgentPoolID.Valid {
+		ws.ApplyAgentPoolID = &r.ApplyAgentPoolID.String
+	}
+	if r.PausedAt.Valid {
+		ws.Paused = true
+		ws.PausedAt = internal.Time(r.PausedAt.Time.UTC())
+	}
+	if r.DebugLoggingExpiresAt.Valid || r.DebugLoggingRunsRemaining.Valid {
+		ws.DebugLogging = &DebugLogging{}
+		if r.DebugLoggingExpiresAt.Valid {
+			ws.DebugLogging.ExpiresAt = internal.Time(r.DebugLoggingExpiresAt.Time.UTC())
+		}
+		if r.DebugLoggingRunsRemaining.Valid {
+			ws.DebugLogging.RunsRemaining = internal.Int(int(r.DebugLoggingRunsRemaining.Int32))
+		}
+	}
+	if r.ApplyWindows != nil {
+		if err := json.Unmarshal(r.ApplyWindows, &ws.ApplyWindows); err != nil {
+			return nil, err
+		}
+	}
 
 	if r.WorkspaceConnection != (pggen.RepoConnections{}) {
 		ws.Connection = &Connection{
@@ -92,6 +133,7 @@ func (r pgresult) toWorkspace() (*Workspace, error) {
 			VCSProviderID: r.WorkspaceConnection.VCSProviderID.String,
 			Repo:          r.WorkspaceConnection.RepoPath.String,
 			Branch:        r.Branch.String,
+			SkipDrafts:    r.VCSSkipDrafts.Bool,
 		}
 		if r.VCSTagsRegex.Valid {
 			ws.Connection.TagsRegex = r.VCSTagsRegex.String
@@ -107,13 +149,21 @@ func (r pgresult) toWorkspace() (*Workspace, error) {
 
 	if r.UserLock != (pggen.Users{}) {
 		ws.Lock = &Lock{
-			id:       r.UserLock.Username.String,
-			LockKind: UserLock,
+			id:         r.UserLock.Username.String,
+			LockKind:   UserLock,
+			AcquiredAt: r.LockAcquiredAt.Time.UTC(),
+		}
+		if r.LockInfo != nil {
+			if err := json.Unmarshal(r.LockInfo, &ws.Lock.state); err != nil {
+				return nil, err
+			}
 		}
 	} else if r.RunLock.RunID.Valid {
 		ws.Lock = &Lock{
-			id:       r.RunLock.RunID.String,
-			LockKind: RunLock,
+			id:         r.RunLock.RunID.String,
+			LockKind:   RunLock,
+			AcquiredAt: r.LockAcquiredAt.Time.UTC(),
+			runStatus:  r.RunLock.Status.String,
 		}
 	}
 
@@ -121,23 +171,33 @@ func (r pgresult) toWorkspace() (*Workspace, error) {
 }
 
 func (db *pgdb) create(ctx context.Context, ws *Workspace) error {
+	applyWindows, err := marshalApplyWindows(ws.ApplyWindows)
+	if err != nil {
+		return err
+	}
 	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
 		params := pggen.InsertWorkspaceParams{
 			ID:                         sql.String(ws.ID),
 			CreatedAt:                  sql.Timestamptz(ws.CreatedAt),
 			UpdatedAt:                  sql.Timestamptz(ws.UpdatedAt),
 			AgentPoolID:                sql.StringPtr(ws.AgentPoolID),
+			PlanAgentPoolID:            sql.StringPtr(ws.PlanAgentPoolID),
+			ApplyAgentPoolID:           sql.StringPtr(ws.ApplyAgentPoolID),
 			AllowCLIApply:              sql.Bool(false),
 			AllowDestroyPlan:           sql.Bool(ws.AllowDestroyPlan),
+			ApplyWindows:               applyWindows,
 			AutoApply:                  sql.Bool(ws.AutoApply),
 			Branch:                     sql.String(""),
 			CanQueueDestroyPlan:        sql.Bool(ws.CanQueueDestroyPlan),
+			DeletionProtected:          sql.Bool(ws.DeletionProtected),
+			LogScrubbingDisabled:       sql.Bool(ws.LogScrubbingDisabled),
 			Description:                sql.String(ws.Description),
 			Environment:                sql.String(ws.Environment),
 			ExecutionMode:              sql.String(string(ws.ExecutionMode)),
 			GlobalRemoteState:          sql.Bool(ws.GlobalRemoteState),
 			MigrationEnvironment:       sql.String(ws.MigrationEnvironment),
 			Name:                       sql.String(ws.Name),
+			PreflightChecks:            ws.PreflightChecks.Strings(),
 			QueueAllRuns:               sql.Bool(ws.QueueAllRuns),
 			SpeculativeEnabled:         sql.Bool(ws.SpeculativeEnabled),
 			SourceName:                 sql.String(ws.SourceName),
@@ -147,6 +207,7 @@ func (db *pgdb) create(ctx context.Context, ws *Workspace) error {
 			TriggerPrefixes:            ws.TriggerPrefixes,
 			TriggerPatterns:            ws.TriggerPatterns,
 			VCSTagsRegex:               sql.StringPtr(nil),
+			VCSSkipDrafts:              sql.Bool(false),
 			WorkingDirectory:           sql.String(ws.WorkingDirectory),
 			OrganizationName:           sql.String(ws.Organization),
 		}
@@ -154,12 +215,33 @@ func (db *pgdb) create(ctx context.Context, ws *Workspace) error {
 			params.AllowCLIApply = sql.Bool(ws.Connection.AllowCLIApply)
 			params.Branch = sql.String(ws.Connection.Branch)
 			params.VCSTagsRegex = sql.String(ws.Connection.TagsRegex)
+			params.VCSSkipDrafts = sql.Bool(ws.Connection.SkipDrafts)
 		}
 		_, err := q.InsertWorkspace(ctx, params)
 		return sql.Error(err)
 	})
 }
 
+// checkNameCollision returns an error if another workspace in the
+// organization has a name that differs only in case from the workspace's
+// name.
+func (db *pgdb) checkNameCollision(ctx context.Context, ws *Workspace) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		names, err := q.FindWorkspaceNamesCaseInsensitive(ctx, pggen.FindWorkspaceNamesCaseInsensitiveParams{
+			OrganizationName: sql.String(ws.Organization),
+			Name:             sql.String(ws.Name),
+			WorkspaceID:      sql.String(ws.ID),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		if len(names) > 0 {
+			return fmt.Errorf("%w: name collides with workspace %s", internal.ErrResourceAlreadyExists, names[0].String)
+		}
+		return nil
+	})
+}
+
 func (db *pgdb) update(ctx context.Context, workspaceID string, fn func(*Workspace) error) (*Workspace, error) {
 	return sql.Tx(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Workspace, error) {
 		var err error
@@ -178,16 +260,26 @@ func (db *pgdb) update(ctx context.Context, workspaceID string, fn func(*Workspa
 			return nil, err
 		}
 		// persist update
+		applyWindows, err := marshalApplyWindows(ws.ApplyWindows)
+		if err != nil {
+			return nil, err
+		}
 		params := pggen.UpdateWorkspaceByIDParams{
 			AgentPoolID:                sql.StringPtr(ws.AgentPoolID),
+			PlanAgentPoolID:            sql.StringPtr(ws.PlanAgentPoolID),
+			ApplyAgentPoolID:           sql.StringPtr(ws.ApplyAgentPoolID),
 			AllowDestroyPlan:           sql.Bool(ws.AllowDestroyPlan),
 			AllowCLIApply:              sql.Bool(false),
+			ApplyWindows:               applyWindows,
 			AutoApply:                  sql.Bool(ws.AutoApply),
 			Branch:                     sql.String(""),
+			DeletionProtected:          sql.Bool(ws.DeletionProtected),
+			LogScrubbingDisabled:       sql.Bool(ws.LogScrubbingDisabled),
 			Description:                sql.String(ws.Description),
 			ExecutionMode:              sql.String(string(ws.ExecutionMode)),
 			GlobalRemoteState:          sql.Bool(ws.GlobalRemoteState),
 			Name:                       sql.String(ws.Name),
+			PreflightChecks:            ws.PreflightChecks.Strings(),
 			QueueAllRuns:               sql.Bool(ws.QueueAllRuns),
 			SpeculativeEnabled:         sql.Bool(ws.SpeculativeEnabled),
 			StructuredRunOutputEnabled: sql.Bool(ws.StructuredRunOutputEnabled),
@@ -195,6 +287,7 @@ func (db *pgdb) update(ctx context.Context, workspaceID string, fn func(*Workspa
 			TriggerPrefixes:            ws.TriggerPrefixes,
 			TriggerPatterns:            ws.TriggerPatterns,
 			VCSTagsRegex:               sql.StringPtr(nil),
+			VCSSkipDrafts:              sql.Bool(false),
 			WorkingDirectory:           sql.String(ws.WorkingDirectory),
 			UpdatedAt:                  sql.Timestamptz(ws.UpdatedAt),
 			ID:                         sql.String(ws.ID),
@@ -203,12 +296,22 @@ func (db *pgdb) update(ctx context.Context, workspaceID string, fn func(*Workspa
 			params.AllowCLIApply = sql.Bool(ws.Connection.AllowCLIApply)
 			params.Branch = sql.String(ws.Connection.Branch)
 			params.VCSTagsRegex = sql.String(ws.Connection.TagsRegex)
+			params.VCSSkipDrafts = sql.Bool(ws.Connection.SkipDrafts)
 		}
 		_, err = q.UpdateWorkspaceByID(ctx, params)
 		return ws, err
 	})
 }
 
+// marshalApplyWindows encodes apply windows for storage, returning nil if there
+// are no windows.
+func marshalApplyWindows(windows ApplyWindows) ([]byte, error) {
+	if len(windows) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(windows)
+}
+
 // setCurrentRun sets the ID of the current run for the specified workspace.
 func (db *pgdb) setCurrentRun(ctx context.Context, workspaceID, runID string) (*Workspace, error) {
 	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*Workspace, error) {
@@ -352,3 +455,37 @@ func (db *pgdb) delete(ctx context.Context, workspaceID string) error {
 		return nil
 	})
 }
+
+// updateDebugLogging updates the debug logging setting of a workspace using
+// the given function, within a transaction that holds a lock on the workspace
+// for its duration.
+func (db *pgdb) updateDebugLogging(ctx context.Context, workspaceID string, fn func(*Workspace) error) (*Workspace, error) {
+	var ws *Workspace
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		result, err := q.FindWorkspaceByIDForUpdate(ctx, sql.String(workspaceID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		ws, err = pgresult(result).toWorkspace()
+		if err != nil {
+			return err
+		}
+		if err := fn(ws); err != nil {
+			return err
+		}
+		params := pggen.UpdateWorkspaceDebugLoggingParams{
+			WorkspaceID: sql.String(ws.ID),
+		}
+		if ws.DebugLogging != nil {
+			if ws.DebugLogging.ExpiresAt != nil {
+				params.ExpiresAt = sql.Timestamptz(*ws.DebugLogging.ExpiresAt)
+			}
+			if ws.DebugLogging.RunsRemaining != nil {
+				params.RunsRemaining = sql.Int4(*ws.DebugLogging.RunsRemaining)
+			}
+		}
+		_, err = q.UpdateWorkspaceDebugLogging(ctx, params)
+		return sql.Error(err)
+	})
+	return ws, err
+}
@@ -0,0 +1,127 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+)
+
+const (
+	// DefaultDebugLoggingHours is the number of hours for which debug logging
+	// is enabled if neither a number of hours nor runs is specified.
+	DefaultDebugLoggingHours = 24
+	// MaxDebugLoggingHours is the maximum number of hours for which debug
+	// logging can be enabled.
+	MaxDebugLoggingHours = 7 * 24
+	// MaxDebugLoggingRuns is the maximum number of runs for which debug
+	// logging can be enabled.
+	MaxDebugLoggingRuns = 100
+)
+
+type (
+	// DebugLogging enables debug logging for the runs of a workspace, setting
+	// TF_LOG and TF_LOG_PROVIDER for their jobs. It expires once either the
+	// expiry time passes or the number of remaining runs reaches zero,
+	// whichever comes first.
+	DebugLogging struct {
+		// ExpiresAt is when debug logging expires. Nil means it does not
+		// expire after a period of time.
+		ExpiresAt *time.Time `json:"expires_at"`
+		// RunsRemaining is the number of further runs for which debug logging
+		// is enabled. Nil means it does not expire after a number of runs.
+		RunsRemaining *int `json:"runs_remaining"`
+	}
+
+	// EnableDebugLoggingOptions are options for enabling debug logging on a
+	// workspace. If neither is specified then debug logging expires after
+	// DefaultDebugLoggingHours.
+	EnableDebugLoggingOptions struct {
+		// Hours after which debug logging expires.
+		Hours *int `json:"hours,omitempty" schema:"hours"`
+		// Runs after which debug logging expires.
+		Runs *int `json:"runs,omitempty" schema:"runs"`
+	}
+)
+
+func newDebugLogging(now time.Time, opts EnableDebugLoggingOptions) (*DebugLogging, error) {
+	if opts.Hours == nil && opts.Runs == nil {
+		opts.Hours = internal.Int(DefaultDebugLoggingHours)
+	}
+	var dl DebugLogging
+	if opts.Hours != nil {
+		if *opts.Hours < 1 || *opts.Hours > MaxDebugLoggingHours {
+			return nil, fmt.Errorf("%w: hours must be between 1 and %d", ErrInvalidDebugLogging, MaxDebugLoggingHours)
+		}
+		dl.ExpiresAt = internal.Time(now.Add(time.Duration(*opts.Hours) * time.Hour))
+	}
+	if opts.Runs != nil {
+		if *opts.Runs < 1 || *opts.Runs > MaxDebugLoggingRuns {
+			return nil, fmt.Errorf("%w: runs must be between 1 and %d", ErrInvalidDebugLogging, MaxDebugLoggingRuns)
+		}
+		dl.RunsRemaining = internal.Int(*opts.Runs)
+	}
+	return &dl, nil
+}
+
+// EnableDebugLogging enables debug logging on the workspace, replacing any
+// existing expiry.
+func (ws *Workspace) EnableDebugLogging(now time.Time, opts EnableDebugLoggingOptions) error {
+	dl, err := newDebugLogging(now, opts)
+	if err != nil {
+		return err
+	}
+	ws.DebugLogging = dl
+	return nil
+}
+
+// Active determines whether debug logging has yet to expire.
+func (dl *DebugLogging) Active(now time.Time) bool {
+	if dl == nil {
+		return false
+	}
+	if dl.ExpiresAt != nil && !now.Before(*dl.ExpiresAt) {
+		return false
+	}
+	if dl.RunsRemaining != nil && *dl.RunsRemaining < 1 {
+		return false
+	}
+	return true
+}
+
+// DebugLoggingEnabled determines whether debug logging is enabled on the
+// workspace and has yet to expire.
+func (ws *Workspace) DebugLoggingEnabled() bool {
+	return ws.DebugLogging.Active(internal.CurrentTimestamp(nil))
+}
+
+// useDebugLogging determines whether debug logging is to be enabled for a new
+// run in the workspace, counting the run towards its expiry. Debug logging is
+// removed from the workspace once it expires.
+func (ws *Workspace) useDebugLogging(now time.Time) bool {
+	if !ws.DebugLogging.Active(now) {
+		ws.DebugLogging = nil
+		return false
+	}
+	if remaining := ws.DebugLogging.RunsRemaining; remaining != nil {
+		*remaining--
+		if *remaining == 0 {
+			ws.DebugLogging = nil
+		}
+	}
+	return true
+}
+
+// debugLoggingError converts an error from enabling debug logging into an
+// unprocessable entity error if the options are invalid.
+func debugLoggingError(err error) error {
+	if errors.Is(err, ErrInvalidDebugLogging) {
+		return &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		}
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+// EnableDebugLogging enables debug logging for the workspace's runs until it
+// expires, replacing any existing expiry.
+func (s *Service) EnableDebugLogging(ctx context.Context, workspaceID string, opts EnableDebugLoggingOptions) (*Workspace, error) {
+	subject, err := s.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := s.db.updateDebugLogging(ctx, workspaceID, func(ws *Workspace) error {
+		return ws.EnableDebugLogging(internal.CurrentTimestamp(nil), opts)
+	})
+	if err != nil {
+		s.logger.Error("enabling debug logging", "subject", subject, "workspace", workspaceID, "err", err)
+		return nil, err
+	}
+	s.logger.Info("enabled debug logging", "subject", subject, "workspace", workspaceID, "expires_at", ws.DebugLogging.ExpiresAt, "runs", ws.DebugLogging.RunsRemaining)
+
+	return ws, nil
+}
+
+// DisableDebugLogging disables debug logging for the workspace's runs.
+func (s *Service) DisableDebugLogging(ctx context.Context, workspaceID string) (*Workspace, error) {
+	subject, err := s.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := s.db.updateDebugLogging(ctx, workspaceID, func(ws *Workspace) error {
+		ws.DebugLogging = nil
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("disabling debug logging", "subject", subject, "workspace", workspaceID, "err", err)
+		return nil, err
+	}
+	s.logger.Info("disabled debug logging", "subject", subject, "workspace", workspaceID)
+
+	return ws, nil
+}
+
+// UseDebugLogging determines whether debug logging is to be enabled for a new
+// run in the workspace, counting the run towards the expiry of debug logging.
+// It is for use by the run service, which is responsible for authorizing the
+// creation of the run.
+func (s *Service) UseDebugLogging(ctx context.Context, workspaceID string) (bool, error) {
+	// avoid locking the workspace for the vast majority of runs, for which
+	// debug logging is not enabled.
+	ws, err := s.db.get(ctx, workspaceID)
+	if err != nil {
+		return false, err
+	}
+	if ws.DebugLogging == nil {
+		return false, nil
+	}
+	var use bool
+	_, err = s.db.updateDebugLogging(ctx, workspaceID, func(ws *Workspace) error {
+		use = ws.useDebugLogging(internal.CurrentTimestamp(nil))
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return use, nil
+}
@@ -0,0 +1,118 @@
+package workspace
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestWorkspace_EnableDebugLogging(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		opts    EnableDebugLoggingOptions
+		want    *DebugLogging
+		wantErr bool
+	}{
+		{
+			name: "default expiry",
+			want: &DebugLogging{ExpiresAt: internal.Time(now.Add(24 * time.Hour))},
+		},
+		{
+			name: "hours",
+			opts: EnableDebugLoggingOptions{Hours: internal.Int(2)},
+			want: &DebugLogging{ExpiresAt: internal.Time(now.Add(2 * time.Hour))},
+		},
+		{
+			name: "runs",
+			opts: EnableDebugLoggingOptions{Runs: internal.Int(3)},
+			want: &DebugLogging{RunsRemaining: internal.Int(3)},
+		},
+		{
+			name: "hours and runs",
+			opts: EnableDebugLoggingOptions{Hours: internal.Int(1), Runs: internal.Int(3)},
+			want: &DebugLogging{ExpiresAt: internal.Time(now.Add(time.Hour)), RunsRemaining: internal.Int(3)},
+		},
+		{
+			name:    "zero hours",
+			opts:    EnableDebugLoggingOptions{Hours: internal.Int(0)},
+			wantErr: true,
+		},
+		{
+			name:    "too many hours",
+			opts:    EnableDebugLoggingOptions{Hours: internal.Int(MaxDebugLoggingHours + 1)},
+			wantErr: true,
+		},
+		{
+			name:    "too many runs",
+			opts:    EnableDebugLoggingOptions{Runs: internal.Int(MaxDebugLoggingRuns + 1)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &Workspace{}
+			err := ws.EnableDebugLogging(now, tt.opts)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidDebugLogging)
+				assert.Nil(t, ws.DebugLogging)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ws.DebugLogging)
+		})
+	}
+}
+
+func TestWorkspace_useDebugLogging(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	t.Run("disabled", func(t *testing.T) {
+		ws := &Workspace{}
+		assert.False(t, ws.useDebugLogging(now))
+	})
+	t.Run("counts down runs", func(t *testing.T) {
+		ws := &Workspace{DebugLogging: &DebugLogging{RunsRemaining: internal.Int(2)}}
+
+		assert.True(t, ws.useDebugLogging(now))
+		assert.Equal(t, internal.Int(1), ws.DebugLogging.RunsRemaining)
+
+		// the last run removes debug logging from the workspace
+		assert.True(t, ws.useDebugLogging(now))
+		assert.Nil(t, ws.DebugLogging)
+
+		assert.False(t, ws.useDebugLogging(now))
+	})
+	t.Run("expires", func(t *testing.T) {
+		ws := &Workspace{DebugLogging: &DebugLogging{ExpiresAt: internal.Time(now.Add(time.Hour))}}
+
+		assert.True(t, ws.useDebugLogging(now))
+		assert.NotNil(t, ws.DebugLogging)
+
+		assert.False(t, ws.useDebugLogging(now.Add(time.Hour)))
+		assert.Nil(t, ws.DebugLogging)
+	})
+	t.Run("expires before runs run out", func(t *testing.T) {
+		ws := &Workspace{DebugLogging: &DebugLogging{
+			ExpiresAt:     internal.Time(now.Add(time.Hour)),
+			RunsRemaining: internal.Int(5),
+		}}
+
+		assert.False(t, ws.useDebugLogging(now.Add(2*time.Hour)))
+		assert.Nil(t, ws.DebugLogging)
+	})
+}
+
+func TestDebugLoggingError(t *testing.T) {
+	ws := &Workspace{}
+	err := debugLoggingError(ws.EnableDebugLogging(time.Now(), EnableDebugLoggingOptions{Runs: internal.Int(0)}))
+
+	var httpErr *internal.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+}
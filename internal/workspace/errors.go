@@ -9,13 +9,21 @@ var (
 	ErrWorkspaceAlreadyUnlocked       = errors.New("workspace already unlocked")
 	ErrWorkspaceUnlockDenied          = errors.New("unauthorized to unlock workspace")
 	ErrWorkspaceInvalidLock           = errors.New("invalid workspace lock")
+	ErrStateLockIDMismatch            = errors.New("lock ID does not match existing lock")
 	ErrUnsupportedTerraformVersion    = errors.New("unsupported terraform version")
+	ErrWorkspaceDeletionProtected     = errors.New("cannot delete: protection enabled")
+	ErrWorkspaceAlreadyPaused         = errors.New("workspace already paused")
+	ErrWorkspaceNotPaused             = errors.New("workspace is not paused")
+	ErrWorkspacePaused                = errors.New("workspace is paused")
 
 	ErrTagsRegexAndTriggerPatterns     = errors.New("cannot specify both tags-regex and trigger-patterns")
 	ErrTagsRegexAndAlwaysTrigger       = errors.New("cannot specify both tags-regex and always-trigger")
 	ErrTriggerPatternsAndAlwaysTrigger = errors.New("cannot specify both trigger-patterns and always-trigger")
 	ErrInvalidTriggerPattern           = errors.New("invalid trigger glob pattern")
 	ErrInvalidTagsRegex                = errors.New("invalid vcs tags regular expression")
+	ErrInvalidApplyWindow              = errors.New("invalid apply window")
+	ErrInvalidPreflightCheck           = errors.New("invalid pre-flight check")
+	ErrInvalidDebugLogging             = errors.New("invalid debug logging expiry")
 	ErrAgentExecutionModeWithoutPool   = errors.New("agent execution mode requires agent pool ID")
 	ErrNonAgentExecutionModeWithPool   = errors.New("agent pool ID can only be specified with agent execution mode")
 )
@@ -0,0 +1,44 @@
+package workspace
+
+import (
+	"context"
+	"time"
+
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+const (
+	// DeletionProtectionClearedEvent records deletion protection being
+	// turned off for a workspace.
+	DeletionProtectionClearedEvent EventKind = "deletion_protection_cleared"
+)
+
+type (
+	// EventKind identifies the change recorded by an event.
+	EventKind string
+
+	// Event records a notable change made to a workspace, and who made it,
+	// for display in the workspace's activity.
+	Event struct {
+		ID          string
+		WorkspaceID string
+		Kind        EventKind
+		Subject     string // who made the change
+		CreatedAt   time.Time
+	}
+)
+
+// ListEvents lists the events recorded for a workspace, most recent first.
+func (s *Service) ListEvents(ctx context.Context, workspaceID string) ([]*Event, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.db.listEvents(ctx, workspaceID)
+	if err != nil {
+		s.logger.Error("listing workspace events", "subject", subject, "workspace", workspaceID, "err", err)
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,44 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+func (db *pgdb) createEvent(ctx context.Context, event *Event) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.InsertWorkspaceEvent(ctx, pggen.InsertWorkspaceEventParams{
+			WorkspaceEventID: sql.String(event.ID),
+			WorkspaceID:      sql.String(event.WorkspaceID),
+			Kind:             sql.String(string(event.Kind)),
+			Subject:          sql.String(event.Subject),
+			CreatedAt:        sql.Timestamptz(event.CreatedAt),
+		})
+		if err != nil {
+			return sql.Error(err)
+		}
+		return nil
+	})
+}
+
+func (db *pgdb) listEvents(ctx context.Context, workspaceID string) ([]*Event, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) ([]*Event, error) {
+		rows, err := q.FindWorkspaceEventsByWorkspaceID(ctx, sql.String(workspaceID))
+		if err != nil {
+			return nil, sql.Error(err)
+		}
+		events := make([]*Event, len(rows))
+		for i, row := range rows {
+			events[i] = &Event{
+				ID:          row.WorkspaceEventID.String,
+				WorkspaceID: row.WorkspaceID.String,
+				Kind:        EventKind(row.Kind.String),
+				Subject:     row.Subject.String,
+				CreatedAt:   row.CreatedAt.Time.UTC(),
+			}
+		}
+		return events, nil
+	})
+}
@@ -0,0 +1,65 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/resource"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+)
+
+type (
+	// HandlerClient provides the API handlers with access to workspaces. It
+	// is implemented by Service, and can be implemented by a fake in order to
+	// serve the API handlers without a database.
+	HandlerClient interface {
+		Create(ctx context.Context, opts CreateOptions) (*Workspace, error)
+		Get(ctx context.Context, workspaceID string) (*Workspace, error)
+		GetByName(ctx context.Context, organization, workspace string) (*Workspace, error)
+		GetPolicy(ctx context.Context, workspaceID string) (internal.WorkspacePolicy, error)
+		GetTerraformVersion(ctx context.Context, workspaceID string) (*ResolvedTerraformVersion, error)
+		List(ctx context.Context, opts ListOptions) (*resource.Page[*Workspace], error)
+		Update(ctx context.Context, workspaceID string, opts UpdateOptions) (*Workspace, error)
+		UpdateWithWarnings(ctx context.Context, workspaceID string, opts UpdateOptions) (*Workspace, []Warning, error)
+		Delete(ctx context.Context, workspaceID string) (*Workspace, error)
+		Lock(ctx context.Context, workspaceID string, runID *string) (*Workspace, error)
+		Unlock(ctx context.Context, workspaceID string, runID *string, force bool) (*Workspace, error)
+		Pause(ctx context.Context, workspaceID string) (*Workspace, error)
+		Resume(ctx context.Context, workspaceID string) (*Workspace, error)
+		EnableDebugLogging(ctx context.Context, workspaceID string, opts EnableDebugLoggingOptions) (*Workspace, error)
+		DisableDebugLogging(ctx context.Context, workspaceID string) (*Workspace, error)
+
+		ListTags(ctx context.Context, organization string, opts ListTagsOptions) (*resource.Page[*Tag], error)
+		DeleteTags(ctx context.Context, organization string, tagIDs []string) error
+		TagWorkspaces(ctx context.Context, tagID string, workspaceIDs []string) error
+		AddTags(ctx context.Context, workspaceID string, tags []TagSpec) error
+		RemoveTags(ctx context.Context, workspaceID string, tags []TagSpec) error
+		ListWorkspaceTags(ctx context.Context, workspaceID string, opts ListWorkspaceTagsOptions) (*resource.Page[*Tag], error)
+	}
+
+	handlers struct {
+		tfeapi *tfe
+		api    *api
+	}
+)
+
+// NewHandlers constructs the handlers for the TFE and OTF workspace APIs,
+// using client to access workspaces, and registers with the responder the
+// inclusion of workspaces in API responses. The web UI handlers are not
+// included.
+func NewHandlers(client HandlerClient, responder *tfeapi.Responder) internal.Handlers {
+	h := &handlers{
+		tfeapi: &tfe{HandlerClient: client, Responder: responder},
+		api:    &api{HandlerClient: client, Responder: responder},
+	}
+	responder.Register(tfeapi.IncludeWorkspace, h.tfeapi.include)
+	responder.Register(tfeapi.IncludeWorkspaces, h.tfeapi.includeMany)
+	return h
+}
+
+func (h *handlers) AddHandlers(r *mux.Router) {
+	h.tfeapi.addHandlers(r)
+	h.tfeapi.addTagHandlers(r)
+	h.api.addHandlers(r)
+}
@@ -1,6 +1,11 @@
 package workspace
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/http/html/paths"
 	"github.com/tofutf/tofutf/internal/rbac"
@@ -17,13 +22,36 @@ type (
 	//
 	// https://developer.hashicorp.com/terraform/cloud-docs/workspaces/settings#locking
 	Lock struct {
-		id       string // ID of entity holding lock
-		LockKind        // kind of entity holding lock
+		id         string    // ID of entity holding lock
+		LockKind             // kind of entity holding lock
+		AcquiredAt time.Time // time at which lock was acquired
+		// status of the run holding the lock; empty if held by a user or not
+		// known.
+		runStatus string
+		// lock info sent by the terraform CLI when a user locks state via
+		// the HTTP backend; nil if the lock was not acquired that way.
+		state *StateLockInfo
 	}
 
 	// kind of entity holding a lock
 	LockKind int
 
+	// LockHolder describes the entity holding a lock.
+	LockHolder struct {
+		Kind       string     `json:"kind"`
+		ID         string     `json:"id"`
+		AcquiredAt *time.Time `json:"acquired-at,omitempty"`
+		RunStatus  string     `json:"run-status,omitempty"`
+	}
+
+	// LockedError is returned when a workspace cannot be locked or unlocked
+	// because of the entity holding its lock.
+	LockedError struct {
+		Lock *Lock
+		// err is the underlying lock error, e.g. ErrWorkspaceAlreadyLocked.
+		err error
+	}
+
 	LockButton struct {
 		State    string // locked or unlocked
 		Text     string // button text
@@ -34,6 +62,95 @@ type (
 	}
 )
 
+func (k LockKind) String() string {
+	switch k {
+	case UserLock:
+		return "user"
+	case RunLock:
+		return "run"
+	default:
+		return "unknown"
+	}
+}
+
+// Holder describes the entity holding the lock.
+func (l *Lock) Holder() LockHolder {
+	holder := LockHolder{
+		Kind:      l.LockKind.String(),
+		ID:        l.id,
+		RunStatus: l.runStatus,
+	}
+	if !l.AcquiredAt.IsZero() {
+		holder.AcquiredAt = &l.AcquiredAt
+	}
+	return holder
+}
+
+// Describe describes the entity holding the lock and how long it has held it,
+// e.g. "run run-123 (applying, started 4m ago)".
+func (l *Lock) Describe(now time.Time) string {
+	s := fmt.Sprintf("%s %s", l.LockKind, l.id)
+	var details []string
+	if l.runStatus != "" {
+		details = append(details, l.runStatus)
+	}
+	if !l.AcquiredAt.IsZero() {
+		verb := "locked"
+		if l.LockKind == RunLock {
+			verb = "started"
+		}
+		details = append(details, fmt.Sprintf("%s %s ago", verb, roundDuration(now.Sub(l.AcquiredAt))))
+	}
+	switch len(details) {
+	case 0:
+		return s
+	case 1:
+		return fmt.Sprintf("%s (%s)", s, details[0])
+	default:
+		return fmt.Sprintf("%s (%s, %s)", s, details[0], details[1])
+	}
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s: locked by %s", e.err, e.Lock.Describe(internal.CurrentTimestamp(nil)))
+}
+
+func (e *LockedError) Unwrap() error { return e.err }
+
+// lockConflictError converts an error locking or unlocking a workspace into a
+// 409 conflict error, naming the entity holding the lock in its metadata.
+func lockConflictError(err error) error {
+	var locked *LockedError
+	if errors.As(err, &locked) {
+		return &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+			Meta:    map[string]any{"lock": locked.Lock.Holder()},
+		}
+	}
+	if errors.Is(err, ErrWorkspaceAlreadyUnlocked) {
+		return &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+		}
+	}
+	return err
+}
+
+// roundDuration rounds a duration to its largest unit, e.g. 4m.
+func roundDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
 // Locked determines whether workspace is locked.
 func (ws *Workspace) Locked() bool {
 	// a nil receiver means the lock is unlocked
@@ -44,20 +161,26 @@ func (ws *Workspace) Locked() bool {
 func (ws *Workspace) Enlock(id string, kind LockKind) error {
 	if ws.Lock == nil {
 		ws.Lock = &Lock{
-			id:       id,
-			LockKind: kind,
+			id:         id,
+			LockKind:   kind,
+			AcquiredAt: internal.CurrentTimestamp(nil),
 		}
 		return nil
 	}
 	// a run can replace another run holding a lock
-	if kind == RunLock {
-		ws.Lock.id = id
+	if kind == RunLock && ws.Lock.LockKind == RunLock {
+		ws.Lock = &Lock{
+			id:         id,
+			LockKind:   RunLock,
+			AcquiredAt: internal.CurrentTimestamp(nil),
+		}
 		return nil
 	}
-	return ErrWorkspaceAlreadyLocked
+	return &LockedError{Lock: ws.Lock, err: ErrWorkspaceAlreadyLocked}
 }
 
-// Unlock the workspace.
+// Unlock the workspace. Unlocking a lock acquired via the HTTP backend
+// discards its lock info, so the terraform CLI can no longer use the lock.
 func (ws *Workspace) Unlock(id string, kind LockKind, force bool) error {
 	if ws.Lock == nil {
 		return ErrWorkspaceAlreadyUnlocked
@@ -79,9 +202,9 @@ func (ws *Workspace) Unlock(id string, kind LockKind, force bool) error {
 
 	// determine error message to return
 	if ws.Lock.LockKind == RunLock {
-		return ErrWorkspaceLockedByRun
+		return &LockedError{Lock: ws.Lock, err: ErrWorkspaceLockedByRun}
 	}
-	return ErrWorkspaceLockedByDifferentUser
+	return &LockedError{Lock: ws.Lock, err: ErrWorkspaceLockedByDifferentUser}
 }
 
 // lockButtonHelper helps the UI determine the button to display for
@@ -100,12 +223,7 @@ func lockButtonHelper(ws *Workspace, policy internal.WorkspacePolicy, user inter
 			return btn
 		}
 		// Determine message to show
-		switch ws.Lock.LockKind {
-		case UserLock, RunLock:
-			btn.Message = "locked by: " + ws.Lock.id
-		default:
-			btn.Message = "locked by unknown entity: " + ws.Lock.id
-		}
+		btn.Message = "locked by " + ws.Lock.Describe(internal.CurrentTimestamp(nil))
 		// also show message as button tooltip
 		btn.Tooltip = btn.Message
 		// A user can unlock their own lock
@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/tofutf/tofutf/internal/sql"
@@ -40,6 +41,15 @@ func (db *pgdb) toggleLock(ctx context.Context, workspaceID string, togglefn fun
 		} else {
 			return ErrWorkspaceInvalidLock
 		}
+		if ws.Lock != nil && !ws.Lock.AcquiredAt.IsZero() {
+			params.AcquiredAt = sql.Timestamptz(ws.Lock.AcquiredAt)
+		}
+		if ws.Lock != nil && ws.Lock.state != nil {
+			params.LockInfo, err = json.Marshal(ws.Lock.state)
+			if err != nil {
+				return err
+			}
+		}
 		_, err = q.UpdateWorkspaceLockByID(ctx, params)
 		if err != nil {
 			return sql.Error(err)
@@ -48,3 +58,28 @@ func (db *pgdb) toggleLock(ctx context.Context, workspaceID string, togglefn fun
 	})
 	return ws, err
 }
+
+// togglePause toggles the workspace pause state in the DB.
+func (db *pgdb) togglePause(ctx context.Context, workspaceID string, togglefn func(context.Context, *Workspace) error) (*Workspace, error) {
+	var ws *Workspace
+	err := db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
+		result, err := q.FindWorkspaceByIDForUpdate(ctx, sql.String(workspaceID))
+		if err != nil {
+			return sql.Error(err)
+		}
+		ws, err = pgresult(result).toWorkspace()
+		if err != nil {
+			return err
+		}
+		if err := togglefn(ctx, ws); err != nil {
+			return err
+		}
+		var pausedAt pgtype.Timestamptz
+		if ws.PausedAt != nil {
+			pausedAt = sql.Timestamptz(*ws.PausedAt)
+		}
+		_, err = q.UpdateWorkspacePausedAt(ctx, pausedAt, sql.String(ws.ID))
+		return sql.Error(err)
+	})
+	return ws, err
+}
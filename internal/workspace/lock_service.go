@@ -86,3 +86,52 @@ func (s *Service) Unlock(ctx context.Context, workspaceID string, runID *string,
 
 	return ws, nil
 }
+
+// LockState locks the workspace on behalf of a user running the terraform CLI
+// with the HTTP backend, recording the lock info sent by the CLI.
+func (s *Service) LockState(ctx context.Context, workspaceID string, info StateLockInfo) (*Workspace, error) {
+	subject, err := s.CanAccess(ctx, rbac.LockWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	user, ok := subject.(*user.User)
+	if !ok {
+		return nil, fmt.Errorf("only a user can lock state")
+	}
+
+	ws, err := s.db.toggleLock(ctx, workspaceID, func(ws *Workspace) error {
+		return ws.EnlockState(user.Username, info)
+	})
+	if err != nil {
+		s.logger.Error("locking state", "subject", user, "workspace", workspaceID, "lock_id", info.ID, "err", err)
+		return nil, err
+	}
+	s.logger.Info("locked state", "subject", user, "workspace", workspaceID, "lock_id", info.ID)
+
+	return ws, nil
+}
+
+// UnlockState unlocks a workspace locked via the HTTP backend, provided the
+// lock ID matches that of the lock. If lockID is nil then the workspace is
+// forcibly unlocked, which is how the terraform CLI performs a force-unlock.
+func (s *Service) UnlockState(ctx context.Context, workspaceID string, lockID *string) (*Workspace, error) {
+	if lockID == nil {
+		return s.Unlock(ctx, workspaceID, nil, true)
+	}
+
+	subject, err := s.CanAccess(ctx, rbac.UnlockWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := s.db.toggleLock(ctx, workspaceID, func(ws *Workspace) error {
+		return ws.UnlockState(*lockID)
+	})
+	if err != nil {
+		s.logger.Error("unlocking state", "subject", subject, "workspace", workspaceID, "lock_id", *lockID, "err", err)
+		return nil, err
+	}
+	s.logger.Info("unlocked state", "subject", subject, "workspace", workspaceID, "lock_id", *lockID)
+
+	return ws, nil
+}
@@ -1,7 +1,9 @@
 package workspace
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,8 +27,59 @@ func TestWorkspace_Lock(t *testing.T) {
 	t.Run("user cannot lock a locked workspace", func(t *testing.T) {
 		ws := &Workspace{Lock: &Lock{id: "run-123", LockKind: RunLock}}
 		err := ws.Enlock("janitor", UserLock)
-		require.Equal(t, ErrWorkspaceAlreadyLocked, err)
+		require.ErrorIs(t, err, ErrWorkspaceAlreadyLocked)
 	})
+	t.Run("run cannot replace user lock", func(t *testing.T) {
+		ws := &Workspace{Lock: &Lock{id: "janitor", LockKind: UserLock}}
+		err := ws.Enlock("run-123", RunLock)
+		require.ErrorIs(t, err, ErrWorkspaceAlreadyLocked)
+	})
+	t.Run("record time lock acquired", func(t *testing.T) {
+		ws := &Workspace{}
+		err := ws.Enlock("janitor", UserLock)
+		require.NoError(t, err)
+		assert.False(t, ws.Lock.AcquiredAt.IsZero())
+	})
+	t.Run("conflict error names lock holder", func(t *testing.T) {
+		ws := &Workspace{Lock: &Lock{id: "run-123", LockKind: RunLock}}
+		err := ws.Enlock("janitor", UserLock)
+
+		var locked *LockedError
+		require.True(t, errors.As(err, &locked))
+		assert.Equal(t, LockHolder{Kind: "run", ID: "run-123"}, locked.Lock.Holder())
+		assert.Equal(t, "workspace already locked: locked by run run-123", err.Error())
+	})
+}
+
+func TestLock_Describe(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		lock *Lock
+		want string
+	}{
+		{
+			"user lock without acquisition time",
+			&Lock{id: "janitor", LockKind: UserLock},
+			"user janitor",
+		},
+		{
+			"user lock",
+			&Lock{id: "janitor", LockKind: UserLock, AcquiredAt: now.Add(-90 * time.Minute)},
+			"user janitor (locked 1h ago)",
+		},
+		{
+			"run lock",
+			&Lock{id: "run-123", LockKind: RunLock, AcquiredAt: now.Add(-4 * time.Minute), runStatus: "applying"},
+			"run run-123 (applying, started 4m ago)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.lock.Describe(now))
+		})
+	}
 }
 
 func TestWorkspace_Unlock(t *testing.T) {
@@ -43,7 +96,7 @@ func TestWorkspace_Unlock(t *testing.T) {
 	t.Run("user cannot unlock another user's lock", func(t *testing.T) {
 		ws := &Workspace{Lock: &Lock{id: "janitor", LockKind: UserLock}}
 		err := ws.Unlock("burglar", UserLock, false)
-		require.Equal(t, ErrWorkspaceLockedByDifferentUser, err)
+		require.ErrorIs(t, err, ErrWorkspaceLockedByDifferentUser)
 	})
 	t.Run("user can unlock a lock by force", func(t *testing.T) {
 		ws := &Workspace{Lock: &Lock{id: "janitor", LockKind: UserLock}}
@@ -107,8 +160,8 @@ func TestWorkspace_LockButtonHelper(t *testing.T) {
 			LockButton{
 				State:   "locked",
 				Text:    "Unlock",
-				Message: "locked by: janitor",
-				Tooltip: "locked by: janitor",
+				Message: "locked by user janitor",
+				Tooltip: "locked by user janitor",
 				Action:  "/app/workspaces//unlock",
 			},
 		},
@@ -120,8 +173,8 @@ func TestWorkspace_LockButtonHelper(t *testing.T) {
 				State:    "locked",
 				Text:     "Unlock",
 				Action:   "/app/workspaces//unlock",
-				Message:  "locked by: janitor",
-				Tooltip:  "locked by: janitor",
+				Message:  "locked by user janitor",
+				Tooltip:  "locked by user janitor",
 				Disabled: true,
 			},
 		},
@@ -133,8 +186,8 @@ func TestWorkspace_LockButtonHelper(t *testing.T) {
 				State:   "locked",
 				Text:    "Force unlock",
 				Action:  "/app/workspaces//force-unlock",
-				Message: "locked by: janitor",
-				Tooltip: "locked by: janitor",
+				Message: "locked by user janitor",
+				Tooltip: "locked by user janitor",
 			},
 		},
 	}
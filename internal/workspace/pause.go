@@ -0,0 +1,42 @@
+package workspace
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+)
+
+// Pause pauses the workspace. Whilst a workspace is paused no jobs are created
+// for its runs.
+func (ws *Workspace) Pause(now time.Time) error {
+	if ws.Paused {
+		return ErrWorkspaceAlreadyPaused
+	}
+	ws.Paused = true
+	ws.PausedAt = &now
+	return nil
+}
+
+// Resume resumes a paused workspace.
+func (ws *Workspace) Resume() error {
+	if !ws.Paused {
+		return ErrWorkspaceNotPaused
+	}
+	ws.Paused = false
+	ws.PausedAt = nil
+	return nil
+}
+
+// pauseConflictError converts an error from pausing or resuming a workspace
+// into a conflict error if the workspace is already in the requested state.
+func pauseConflictError(err error) error {
+	if errors.Is(err, ErrWorkspaceAlreadyPaused) || errors.Is(err, ErrWorkspaceNotPaused) {
+		return &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+		}
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/rbac"
+)
+
+// Pause pauses the workspace, preventing jobs from being created for its runs
+// until it is resumed. Unlike maintenance mode, other workspaces are
+// unaffected.
+func (s *Service) Pause(ctx context.Context, workspaceID string) (*Workspace, error) {
+	subject, err := s.CanAccess(ctx, rbac.PauseWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := s.db.togglePause(ctx, workspaceID, func(ctx context.Context, ws *Workspace) error {
+		return ws.Pause(internal.CurrentTimestamp(nil))
+	})
+	if err != nil {
+		s.logger.Error("pausing workspace", "subject", subject, "workspace", workspaceID, "err", err)
+		return nil, err
+	}
+	s.logger.Info("paused workspace", "subject", subject, "workspace", workspaceID)
+
+	return ws, nil
+}
+
+// Resume resumes a paused workspace, invoking any AfterResumeWorkspace hooks.
+func (s *Service) Resume(ctx context.Context, workspaceID string) (*Workspace, error) {
+	subject, err := s.CanAccess(ctx, rbac.PauseWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := s.db.togglePause(ctx, workspaceID, func(ctx context.Context, ws *Workspace) error {
+		if err := ws.Resume(); err != nil {
+			return err
+		}
+		for _, hook := range s.afterResumeHooks {
+			if err := hook(ctx, ws); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("resuming workspace", "subject", subject, "workspace", workspaceID, "err", err)
+		return nil, err
+	}
+	s.logger.Info("resumed workspace", "subject", subject, "workspace", workspaceID)
+
+	return ws, nil
+}
+
+// AfterResumeWorkspace adds a hook to be invoked after a workspace is resumed.
+// The hook is invoked within the same transaction as that resuming the
+// workspace, and should it return an error then the workspace remains paused.
+func (s *Service) AfterResumeWorkspace(hook func(context.Context, *Workspace) error) {
+	s.afterResumeHooks = append(s.afterResumeHooks, hook)
+}
@@ -0,0 +1,46 @@
+package workspace
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestWorkspace_Pause(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	t.Run("pause an active workspace", func(t *testing.T) {
+		ws := &Workspace{}
+		require.NoError(t, ws.Pause(now))
+		assert.True(t, ws.Paused)
+		assert.Equal(t, &now, ws.PausedAt)
+	})
+	t.Run("cannot pause a paused workspace", func(t *testing.T) {
+		ws := &Workspace{Paused: true, PausedAt: &now}
+		err := ws.Pause(now.Add(time.Minute))
+		require.ErrorIs(t, err, ErrWorkspaceAlreadyPaused)
+		assert.Equal(t, &now, ws.PausedAt)
+	})
+	t.Run("resume a paused workspace", func(t *testing.T) {
+		ws := &Workspace{Paused: true, PausedAt: &now}
+		require.NoError(t, ws.Resume())
+		assert.False(t, ws.Paused)
+		assert.Nil(t, ws.PausedAt)
+	})
+	t.Run("cannot resume an active workspace", func(t *testing.T) {
+		ws := &Workspace{}
+		require.ErrorIs(t, ws.Resume(), ErrWorkspaceNotPaused)
+	})
+}
+
+func TestPauseConflictError(t *testing.T) {
+	var httpErr *internal.HTTPError
+	require.ErrorAs(t, pauseConflictError(ErrWorkspaceAlreadyPaused), &httpErr)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+
+	assert.Equal(t, internal.ErrAccessNotPermitted, pauseConflictError(internal.ErrAccessNotPermitted))
+}
@@ -0,0 +1,84 @@
+package workspace
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	// AWSPreflightCheck validates AWS credentials by calling STS
+	// GetCallerIdentity.
+	AWSPreflightCheck PreflightCheck = "aws"
+	// GCPPreflightCheck validates GCP credentials by looking up their access
+	// token's info.
+	GCPPreflightCheck PreflightCheck = "gcp"
+	// AzurePreflightCheck validates Azure credentials by authenticating a
+	// service principal and retrieving its subscription from Azure Resource
+	// Manager.
+	AzurePreflightCheck PreflightCheck = "azure"
+)
+
+type (
+	// PreflightCheck is a check of a workspace's cloud provider credentials,
+	// carried out before a plan is enqueued, in order to fail runs with
+	// invalid credentials before they consume an agent.
+	PreflightCheck string
+
+	PreflightChecks []PreflightCheck
+)
+
+// Validate validates the checks, returning an error for an unknown or
+// duplicate check.
+func (checks PreflightChecks) Validate() error {
+	for i, c := range checks {
+		switch c {
+		case AWSPreflightCheck, GCPPreflightCheck, AzurePreflightCheck:
+		default:
+			return fmt.Errorf("%w: %q: must be one of aws, gcp, or azure", ErrInvalidPreflightCheck, c)
+		}
+		if slices.Contains(checks[:i], c) {
+			return fmt.Errorf("%w: %q specified more than once", ErrInvalidPreflightCheck, c)
+		}
+	}
+	return nil
+}
+
+// Has determines whether the named check is included.
+func (checks PreflightChecks) Has(name string) bool {
+	return slices.Contains(checks, PreflightCheck(name))
+}
+
+// Strings returns the checks as a slice of strings.
+func (checks PreflightChecks) Strings() []string {
+	if checks == nil {
+		return nil
+	}
+	to := make([]string, len(checks))
+	for i, c := range checks {
+		to[i] = string(c)
+	}
+	return to
+}
+
+// NewPreflightChecks constructs checks from a slice of strings.
+func NewPreflightChecks(from []string) PreflightChecks {
+	if from == nil {
+		return nil
+	}
+	to := make(PreflightChecks, len(from))
+	for i, c := range from {
+		to[i] = PreflightCheck(c)
+	}
+	return to
+}
+
+func (ws *Workspace) setPreflightChecks(checks PreflightChecks) error {
+	if err := checks.Validate(); err != nil {
+		return err
+	}
+	if len(checks) == 0 {
+		checks = nil
+	}
+	ws.PreflightChecks = checks
+	return nil
+}
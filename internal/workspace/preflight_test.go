@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspace_setPreflightChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		checks  PreflightChecks
+		want    PreflightChecks
+		wantErr error
+	}{
+		{"single check", PreflightChecks{AWSPreflightCheck}, PreflightChecks{AWSPreflightCheck}, nil},
+		{"all checks", PreflightChecks{AWSPreflightCheck, GCPPreflightCheck, AzurePreflightCheck}, PreflightChecks{AWSPreflightCheck, GCPPreflightCheck, AzurePreflightCheck}, nil},
+		{"remove all checks", PreflightChecks{}, nil, nil},
+		{"unknown check", PreflightChecks{"oci"}, nil, ErrInvalidPreflightCheck},
+		{"duplicate check", PreflightChecks{GCPPreflightCheck, GCPPreflightCheck}, nil, ErrInvalidPreflightCheck},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &Workspace{}
+			err := ws.setPreflightChecks(tt.checks)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ws.PreflightChecks)
+		})
+	}
+}
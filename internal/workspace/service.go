@@ -8,9 +8,11 @@ import (
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/connections"
 	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/idempotency"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/pubsub"
 	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/releases"
 	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/sql"
 	"github.com/tofutf/tofutf/internal/sql/pggen"
@@ -26,6 +28,9 @@ type (
 		organization        internal.Authorizer
 		internal.Authorizer // workspace authorizer
 
+		organizations organizationClient
+		releases      releasesClient
+
 		logger      *slog.Logger
 		db          *pgdb
 		web         *webHandlers
@@ -36,7 +41,8 @@ type (
 
 		beforeCreateHooks []func(context.Context, *Workspace) error
 		afterCreateHooks  []func(context.Context, *Workspace) error
-		beforeUpdateHooks []func(context.Context, *Workspace) error
+		beforeUpdateHooks []func(context.Context, *Workspace) ([]Warning, error)
+		afterResumeHooks  []func(context.Context, *Workspace) error
 	}
 
 	Options struct {
@@ -51,6 +57,18 @@ type (
 		VCSProviderService  *vcsprovider.Service
 		TeamService         *team.Service
 		ConnectionService   *connections.Service
+		ReleasesService     *releases.Service
+		IdempotencyService  *idempotency.Service
+	}
+
+	organizationClient interface {
+		Get(ctx context.Context, name string) (*organization.Organization, error)
+	}
+
+	releasesClient interface {
+		versionResolver
+
+		CheckVersionPolicy(ctx context.Context, version string) error
 	}
 )
 
@@ -62,10 +80,12 @@ func NewService(opts Options) *Service {
 			logger: opts.Logger,
 			db:     db,
 		},
-		db:           db,
-		connections:  opts.ConnectionService,
-		organization: &organization.Authorizer{Logger: opts.Logger},
-		site:         &internal.SiteAuthorizer{Logger: opts.Logger},
+		db:            db,
+		connections:   opts.ConnectionService,
+		organizations: opts.OrganizationService,
+		releases:      opts.ReleasesService,
+		organization:  &organization.Authorizer{Logger: opts.Logger},
+		site:          &internal.SiteAuthorizer{Logger: opts.Logger},
 	}
 	svc.web = &webHandlers{
 		Renderer:     opts.Renderer,
@@ -74,12 +94,13 @@ func NewService(opts Options) *Service {
 		client:       &svc,
 	}
 	svc.tfeapi = &tfe{
-		Service:   &svc,
-		Responder: opts.Responder,
+		HandlerClient: &svc,
+		Responder:     opts.Responder,
+		idempotency:   opts.IdempotencyService,
 	}
 	svc.api = &api{
-		Service:   &svc,
-		Responder: opts.Responder,
+		HandlerClient: &svc,
+		Responder:     opts.Responder,
 	}
 	svc.broker = pubsub.NewBroker(
 		opts.Logger,
@@ -103,6 +124,7 @@ func (s *Service) AddHandlers(r *mux.Router) {
 	s.web.addHandlers(r)
 	s.tfeapi.addHandlers(r)
 	s.web.addTagHandlers(r)
+	s.web.addVCSDefaultsHandlers(r)
 	s.tfeapi.addTagHandlers(r)
 	s.api.addHandlers(r)
 }
@@ -112,6 +134,26 @@ func (s *Service) Watch(ctx context.Context) (<-chan pubsub.Event[*Workspace], f
 }
 
 func (s *Service) Create(ctx context.Context, opts CreateOptions) (*Workspace, error) {
+	// Inherit the organization's VCS defaults for those settings not
+	// explicitly set when connecting the workspace to a repo.
+	if opts.ConnectOptions != nil && opts.Organization != nil {
+		defaults, err := s.db.getVCSDefaults(ctx, *opts.Organization)
+		if err != nil {
+			return nil, err
+		}
+		defaults.fillCreateOptions(&opts)
+	}
+
+	// Assign the site's default terraform version unless the caller has
+	// explicitly set a version.
+	if opts.TerraformVersion == nil {
+		version, err := s.releases.DefaultVersion(ctx)
+		if err != nil {
+			return nil, err
+		}
+		opts.TerraformVersion = &version
+	}
+
 	ws, err := NewWorkspace(opts)
 	if err != nil {
 		s.logger.Error("constructing workspace", "err", err)
@@ -123,12 +165,30 @@ func (s *Service) Create(ctx context.Context, opts CreateOptions) (*Workspace, e
 		return nil, err
 	}
 
+	if err := s.releases.CheckVersionPolicy(ctx, ws.TerraformVersion); err != nil {
+		s.logger.Error("creating workspace", "name", ws.Name, "organization", ws.Organization, "subject", subject, "err", err)
+		return nil, err
+	}
+
+	org, err := s.organizations.Get(ctx, ws.Organization)
+	if err != nil {
+		return nil, err
+	}
+	// Inherit the organization's default deletion protection unless the caller
+	// has explicitly set it.
+	if opts.DeletionProtected == nil {
+		ws.DeletionProtected = org.DefaultDeletionProtection
+	}
+
 	err = s.db.Tx(ctx, func(ctx context.Context, q pggen.Querier) error {
 		for _, hook := range s.beforeCreateHooks {
 			if err := hook(ctx, ws); err != nil {
 				return err
 			}
 		}
+		if err := s.checkName(ctx, org, ws); err != nil {
+			return err
+		}
 		if err := s.db.create(ctx, ws); err != nil {
 			return err
 		}
@@ -163,6 +223,19 @@ func (s *Service) Create(ctx context.Context, opts CreateOptions) (*Workspace, e
 	return ws, nil
 }
 
+// checkName checks the workspace's name against the organization's rules for
+// the case of workspace names.
+func (s *Service) checkName(ctx context.Context, org *organization.Organization, ws *Workspace) error {
+	switch org.WorkspaceNameCase {
+	case organization.LowercaseWorkspaceNames:
+		return resource.ValidateName(&ws.Name, resource.LowercaseName)
+	case organization.CaseInsensitiveWorkspaceNames:
+		return s.db.checkNameCollision(ctx, ws)
+	default:
+		return nil
+	}
+}
+
 func (s *Service) BeforeCreateWorkspace(hook func(context.Context, *Workspace) error) {
 	s.beforeCreateHooks = append(s.beforeCreateHooks, hook)
 }
@@ -237,32 +310,118 @@ func (s *Service) ListConnectedWorkspaces(ctx context.Context, vcsProviderID, re
 	return s.db.listByConnection(ctx, vcsProviderID, repoPath)
 }
 
-func (s *Service) BeforeUpdateWorkspace(hook func(context.Context, *Workspace) error) {
+// ListRepoMoves lists the moves of repos connected to the workspace, i.e.
+// repos that have been renamed or transferred, most recent first.
+func (s *Service) ListRepoMoves(ctx context.Context, workspaceID string) ([]*connections.RepoMove, error) {
+	subject, err := s.CanAccess(ctx, rbac.GetWorkspaceAction, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	moves, err := s.connections.ListWorkspaceRepoMoves(ctx, workspaceID)
+	if err != nil {
+		s.logger.Error("listing repo moves", "subject", subject, "workspace", workspaceID, "err", err)
+		return nil, err
+	}
+	return moves, nil
+}
+
+// BeforeUpdateWorkspace registers a hook that is called with the updated
+// workspace before it is persisted. The hook can reject the update by
+// returning an error, or contribute warnings to be returned alongside the
+// updated workspace.
+func (s *Service) BeforeUpdateWorkspace(hook func(context.Context, *Workspace) ([]Warning, error)) {
 	s.beforeUpdateHooks = append(s.beforeUpdateHooks, hook)
 }
 
+// Update updates a workspace, discarding any warnings about its updated
+// settings.
 func (s *Service) Update(ctx context.Context, workspaceID string, opts UpdateOptions) (*Workspace, error) {
+	ws, _, err := s.UpdateWithWarnings(ctx, workspaceID, opts)
+	return ws, err
+}
+
+// UpdateWithWarnings updates a workspace, returning the updated workspace
+// along with warnings about settings that are permitted but interact badly.
+// Settings that contradict one another fail the update.
+func (s *Service) UpdateWithWarnings(ctx context.Context, workspaceID string, opts UpdateOptions) (*Workspace, []Warning, error) {
 	subject, err := s.CanAccess(ctx, rbac.UpdateWorkspaceAction, workspaceID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// update the workspace and optionally connect/disconnect to/from vcs repo.
-	var updated *Workspace
+	var (
+		updated      *Workspace
+		warnings     []Warning
+		wasProtected bool
+	)
 	err = s.db.Tx(ctx, func(ctx context.Context, _ pggen.Querier) error {
 		var connect *bool
 		updated, err = s.db.update(ctx, workspaceID, func(ws *Workspace) (err error) {
-			for _, hook := range s.beforeUpdateHooks {
-				if err := hook(ctx, ws); err != nil {
+			wasProtected = ws.DeletionProtected
+			// Inherit the organization's VCS defaults for those settings not
+			// explicitly set when connecting the workspace to a repo.
+			if ws.Connection == nil && opts.ConnectOptions != nil {
+				defaults, err := s.db.getVCSDefaults(ctx, ws.Organization)
+				if err != nil {
 					return err
 				}
+				defaults.fillUpdateOptions(&opts)
 			}
+			before, beforeName := ws.TerraformVersion, ws.Name
 			connect, err = ws.Update(opts)
-			return err
+			if err != nil {
+				return err
+			}
+			// Only check a changed name against the organization's naming
+			// rules, which apply only to new and renamed workspaces.
+			if ws.Name != beforeName {
+				org, err := s.organizations.Get(ctx, ws.Organization)
+				if err != nil {
+					return err
+				}
+				if err := s.checkName(ctx, org, ws); err != nil {
+					return err
+				}
+			}
+			// Only check a changed version against the policy, permitting
+			// other settings to be updated on a workspace that pre-dates the
+			// policy.
+			if ws.TerraformVersion != before {
+				if err := s.releases.CheckVersionPolicy(ctx, ws.TerraformVersion); err != nil {
+					return err
+				}
+			}
+			for _, hook := range s.beforeUpdateHooks {
+				hookWarnings, err := hook(ctx, ws)
+				if err != nil {
+					return err
+				}
+				warnings = append(warnings, hookWarnings...)
+			}
+			ruleWarnings, err := ws.validate()
+			if err != nil {
+				return err
+			}
+			warnings = append(warnings, ruleWarnings...)
+			return nil
 		})
 		if err != nil {
 			return err
 		}
+		if wasProtected && !updated.DeletionProtected {
+			err := s.db.createEvent(ctx, &Event{
+				ID:          internal.NewID("wse"),
+				WorkspaceID: workspaceID,
+				Kind:        DeletionProtectionClearedEvent,
+				Subject:     subject.String(),
+				CreatedAt:   internal.CurrentTimestamp(nil),
+			})
+			if err != nil {
+				return err
+			}
+		}
 		if connect != nil {
 			if *connect {
 				if err := s.connect(ctx, workspaceID, updated.Connection); err != nil {
@@ -278,12 +437,18 @@ func (s *Service) Update(ctx context.Context, workspaceID string, opts UpdateOpt
 	})
 	if err != nil {
 		s.logger.Error("updating workspace", "workspace", workspaceID, "subject", subject, "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	s.logger.Info("updated workspace", "workspace", workspaceID, "subject", subject)
+	if wasProtected && !updated.DeletionProtected {
+		s.logger.Info("cleared workspace deletion protection", "workspace", workspaceID, "subject", subject)
+	}
+	for _, warning := range warnings {
+		s.logger.Warn("updated workspace settings", "workspace", workspaceID, "warning", warning.Code)
+	}
 
-	return updated, nil
+	return updated, warnings, nil
 }
 
 func (s *Service) Delete(ctx context.Context, workspaceID string) (*Workspace, error) {
@@ -297,6 +462,12 @@ func (s *Service) Delete(ctx context.Context, workspaceID string) (*Workspace, e
 		return nil, err
 	}
 
+	// deletion protection must be explicitly cleared before deleting
+	if ws.DeletionProtected {
+		s.logger.Error("deleting workspace", "id", ws.ID, "name", ws.Name, "subject", subject, "err", ErrWorkspaceDeletionProtected)
+		return nil, ErrWorkspaceDeletionProtected
+	}
+
 	// disconnect repo before deleting
 	if ws.Connection != nil {
 		if err := s.disconnect(ctx, ws.ID); err != nil {
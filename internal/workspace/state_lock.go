@@ -0,0 +1,82 @@
+package workspace
+
+import (
+	"time"
+
+	"github.com/tofutf/tofutf/internal"
+)
+
+// StateLockInfo is the lock info the terraform CLI sends when locking state via
+// the HTTP backend, and which the CLI expects in response when state is
+// already locked. The field names are those the CLI uses.
+type StateLockInfo struct {
+	// ID is the unique ID the CLI generates for the lock.
+	ID string `json:"ID"`
+	// Operation is the terraform operation acquiring the lock, e.g.
+	// OperationTypeApply.
+	Operation string `json:"Operation"`
+	// Info is extra information about the lock.
+	Info string `json:"Info"`
+	// Who is the user@hostname of the user acquiring the lock.
+	Who string `json:"Who"`
+	// Version of terraform acquiring the lock.
+	Version string `json:"Version"`
+	// Created is when the lock was acquired.
+	Created time.Time `json:"Created"`
+	// Path to the state.
+	Path string `json:"Path"`
+}
+
+// StateLockInfo describes the lock in the form the terraform CLI expects. A lock
+// that was not acquired via the HTTP backend is described using its holder's
+// ID in place of a lock ID.
+func (l *Lock) StateLockInfo() StateLockInfo {
+	if l.state != nil {
+		return *l.state
+	}
+	return StateLockInfo{
+		ID:      l.id,
+		Who:     l.id,
+		Info:    "locked by " + l.Describe(internal.CurrentTimestamp(nil)),
+		Created: l.AcquiredAt,
+	}
+}
+
+// EnlockState locks the workspace on behalf of a user running the terraform
+// CLI with the HTTP backend, recording the lock info sent by the CLI. Unlike a
+// lock acquired via the UI or API, the lock cannot be re-acquired by its holder
+// without first being released.
+func (ws *Workspace) EnlockState(username string, info StateLockInfo) error {
+	if ws.Lock != nil {
+		return &LockedError{Lock: ws.Lock, err: ErrWorkspaceAlreadyLocked}
+	}
+	ws.Lock = &Lock{
+		id:         username,
+		LockKind:   UserLock,
+		AcquiredAt: internal.CurrentTimestamp(nil),
+		state:      &info,
+	}
+	return nil
+}
+
+// UnlockState unlocks a workspace locked via the HTTP backend. The lock ID
+// must match that of the lock info recorded when the lock was acquired.
+func (ws *Workspace) UnlockState(lockID string) error {
+	if err := ws.CheckStateLock(lockID); err != nil {
+		return err
+	}
+	ws.Lock = nil
+	return nil
+}
+
+// CheckStateLock checks the workspace is locked with the given lock ID, which
+// the terraform CLI sends along with the state it writes via the HTTP backend.
+func (ws *Workspace) CheckStateLock(lockID string) error {
+	if ws.Lock == nil {
+		return ErrWorkspaceAlreadyUnlocked
+	}
+	if ws.Lock.state == nil || ws.Lock.state.ID != lockID {
+		return &LockedError{Lock: ws.Lock, err: ErrStateLockIDMismatch}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package workspace
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspace_EnlockState(t *testing.T) {
+	t.Run("lock an unlocked workspace", func(t *testing.T) {
+		ws := &Workspace{}
+		err := ws.EnlockState("janitor", StateLockInfo{ID: "lock-123"})
+		require.NoError(t, err)
+		assert.True(t, ws.Locked())
+		assert.Equal(t, UserLock, ws.Lock.LockKind)
+		assert.Equal(t, "lock-123", ws.Lock.StateLockInfo().ID)
+	})
+	t.Run("holder cannot re-acquire lock", func(t *testing.T) {
+		ws := &Workspace{Lock: &Lock{id: "janitor", LockKind: UserLock, state: &StateLockInfo{ID: "lock-123"}}}
+		err := ws.EnlockState("janitor", StateLockInfo{ID: "lock-456"})
+		assert.ErrorIs(t, err, ErrWorkspaceAlreadyLocked)
+	})
+	t.Run("cannot lock workspace locked by run", func(t *testing.T) {
+		ws := &Workspace{Lock: &Lock{id: "run-123", LockKind: RunLock}}
+		err := ws.EnlockState("janitor", StateLockInfo{ID: "lock-123"})
+
+		var locked *LockedError
+		require.True(t, errors.As(err, &locked))
+		info := locked.Lock.StateLockInfo()
+		assert.Equal(t, "run-123", info.ID)
+		assert.Equal(t, "locked by run run-123", info.Info)
+	})
+}
+
+func TestWorkspace_UnlockState(t *testing.T) {
+	t.Run("unlock with matching lock ID", func(t *testing.T) {
+		ws := &Workspace{Lock: &Lock{id: "janitor", LockKind: UserLock, state: &StateLockInfo{ID: "lock-123"}}}
+		err := ws.UnlockState("lock-123")
+		require.NoError(t, err)
+		assert.False(t, ws.Locked())
+	})
+	t.Run("cannot unlock with different lock ID", func(t *testing.T) {
+		ws := &Workspace{Lock: &Lock{id: "janitor", LockKind: UserLock, state: &StateLockInfo{ID: "lock-123"}}}
+		err := ws.UnlockState("lock-456")
+		assert.ErrorIs(t, err, ErrStateLockIDMismatch)
+	})
+	t.Run("cannot unlock lock not acquired via the HTTP backend", func(t *testing.T) {
+		ws := &Workspace{Lock: &Lock{id: "janitor", LockKind: UserLock}}
+		err := ws.UnlockState("janitor")
+		assert.ErrorIs(t, err, ErrStateLockIDMismatch)
+	})
+	t.Run("unlock unlocked workspace", func(t *testing.T) {
+		ws := &Workspace{}
+		err := ws.UnlockState("lock-123")
+		assert.ErrorIs(t, err, ErrWorkspaceAlreadyUnlocked)
+	})
+	t.Run("force unlock invalidates lock ID", func(t *testing.T) {
+		ws := &Workspace{Lock: &Lock{id: "janitor", LockKind: UserLock, state: &StateLockInfo{ID: "lock-123"}}}
+		err := ws.Unlock("admin", UserLock, true)
+		require.NoError(t, err)
+
+		err = ws.CheckStateLock("lock-123")
+		assert.ErrorIs(t, err, ErrWorkspaceAlreadyUnlocked)
+	})
+}
@@ -0,0 +1,92 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tofutf/tofutf/internal/releases"
+	"github.com/tofutf/tofutf/internal/semver"
+)
+
+const (
+	// TerraformVersionSourceWorkspace means the workspace specifies a concrete
+	// terraform version.
+	TerraformVersionSourceWorkspace TerraformVersionSource = "workspace"
+	// TerraformVersionSourceLatest means the workspace specifies the latest
+	// terraform version.
+	TerraformVersionSourceLatest TerraformVersionSource = "latest"
+	// TerraformVersionSourceConstraint means the workspace specifies a
+	// version constraint, which is resolved to the newest matching version.
+	TerraformVersionSourceConstraint TerraformVersionSource = "constraint"
+	// TerraformVersionSourceDefault means the workspace doesn't specify a
+	// version, and the site's default version is used.
+	TerraformVersionSourceDefault TerraformVersionSource = "default"
+)
+
+type (
+	// TerraformVersionSource describes how a workspace's terraform version is
+	// determined.
+	TerraformVersionSource string
+
+	// ResolvedTerraformVersion is the terraform version a workspace would use
+	// for a run created now.
+	ResolvedTerraformVersion struct {
+		// ID of the workspace
+		ID string `jsonapi:"primary,workspace-terraform-versions"`
+		// Requested is the version specified by the workspace, which may be a
+		// concrete version, "latest", or a constraint.
+		Requested string                 `jsonapi:"attribute" json:"requested"`
+		Version   string                 `jsonapi:"attribute" json:"version"`
+		Source    TerraformVersionSource `jsonapi:"attribute" json:"source"`
+	}
+
+	versionResolver interface {
+		DefaultVersion(ctx context.Context) (string, error)
+		GetLatest(ctx context.Context) (string, time.Time, error)
+		ResolveVersion(ctx context.Context, constraint string) (string, error)
+	}
+)
+
+// GetTerraformVersion retrieves the terraform version the workspace would use
+// for a run created now, along with how it was determined.
+func (s *Service) GetTerraformVersion(ctx context.Context, workspaceID string) (*ResolvedTerraformVersion, error) {
+	ws, err := s.Get(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := resolveTerraformVersion(ctx, s.releases, ws)
+	if err != nil {
+		s.logger.Error("resolving terraform version", "workspace", ws.ID, "version", ws.TerraformVersion, "err", err)
+		return nil, err
+	}
+	return resolved, nil
+}
+
+// resolveTerraformVersion resolves the workspace's terraform version in the
+// same manner as when a run is created.
+func resolveTerraformVersion(ctx context.Context, resolver versionResolver, ws *Workspace) (*ResolvedTerraformVersion, error) {
+	resolved := &ResolvedTerraformVersion{
+		ID:        ws.ID,
+		Requested: ws.TerraformVersion,
+	}
+	var err error
+	switch {
+	case ws.TerraformVersion == "":
+		resolved.Source = TerraformVersionSourceDefault
+		resolved.Version, err = resolver.DefaultVersion(ctx)
+	case ws.TerraformVersion == releases.LatestVersionString:
+		resolved.Source = TerraformVersionSourceLatest
+		resolved.Version, _, err = resolver.GetLatest(ctx)
+	case !semver.IsValid(ws.TerraformVersion) && semver.IsConstraint(ws.TerraformVersion):
+		resolved.Source = TerraformVersionSourceConstraint
+		resolved.Version, err = resolver.ResolveVersion(ctx, ws.TerraformVersion)
+	default:
+		resolved.Source = TerraformVersionSourceWorkspace
+		resolved.Version = ws.TerraformVersion
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s terraform version: %w", resolved.Source, err)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,82 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal/releases"
+)
+
+func TestResolveTerraformVersion(t *testing.T) {
+	resolver := &fakeVersionResolver{
+		defaultVersion: "1.6.0",
+		latest:         "1.7.2",
+		constraints:    map[string]string{"~> 1.5.0": "1.5.7"},
+	}
+
+	tests := []struct {
+		name      string
+		requested string
+		want      ResolvedTerraformVersion
+	}{
+		{
+			"concrete version",
+			"1.4.6",
+			ResolvedTerraformVersion{ID: "ws-123", Requested: "1.4.6", Version: "1.4.6", Source: TerraformVersionSourceWorkspace},
+		},
+		{
+			"latest",
+			releases.LatestVersionString,
+			ResolvedTerraformVersion{ID: "ws-123", Requested: "latest", Version: "1.7.2", Source: TerraformVersionSourceLatest},
+		},
+		{
+			"constraint",
+			"~> 1.5.0",
+			ResolvedTerraformVersion{ID: "ws-123", Requested: "~> 1.5.0", Version: "1.5.7", Source: TerraformVersionSourceConstraint},
+		},
+		{
+			"site default",
+			"",
+			ResolvedTerraformVersion{ID: "ws-123", Requested: "", Version: "1.6.0", Source: TerraformVersionSourceDefault},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &Workspace{ID: "ws-123", TerraformVersion: tt.requested}
+			got, err := resolveTerraformVersion(context.Background(), resolver, ws)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+
+	t.Run("unsatisfiable constraint", func(t *testing.T) {
+		ws := &Workspace{ID: "ws-123", TerraformVersion: "~> 2.0"}
+		_, err := resolveTerraformVersion(context.Background(), resolver, ws)
+		assert.ErrorIs(t, err, releases.ErrNoMatchingVersion)
+	})
+}
+
+type fakeVersionResolver struct {
+	defaultVersion string
+	latest         string
+	constraints    map[string]string
+}
+
+func (f *fakeVersionResolver) DefaultVersion(context.Context) (string, error) {
+	return f.defaultVersion, nil
+}
+
+func (f *fakeVersionResolver) GetLatest(context.Context) (string, time.Time, error) {
+	return f.latest, time.Time{}, nil
+}
+
+func (f *fakeVersionResolver) ResolveVersion(_ context.Context, constraint string) (string, error) {
+	v, ok := f.constraints[constraint]
+	if !ok {
+		return "", releases.ErrNoMatchingVersion
+	}
+	return v, nil
+}
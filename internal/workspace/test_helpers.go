@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/connections"
 	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/team"
@@ -14,6 +15,8 @@ import (
 type FakeService struct {
 	Workspaces []*Workspace
 	Policy     internal.WorkspacePolicy
+	RepoMoves  []*connections.RepoMove
+	Events     []*Event
 }
 
 func (f *FakeService) ListConnectedWorkspaces(ctx context.Context, vcsProviderID, repoPath string) ([]*Workspace, error) {
@@ -29,6 +32,12 @@ func (f *FakeService) Update(_ context.Context, _ string, opts UpdateOptions) (*
 	return f.Workspaces[0], nil
 }
 
+func (f *FakeService) UpdateWithWarnings(_ context.Context, _ string, opts UpdateOptions) (*Workspace, []Warning, error) {
+	f.Workspaces[0].Update(opts) //nolint:errcheck
+	warnings, _ := f.Workspaces[0].validate()
+	return f.Workspaces[0], warnings, nil
+}
+
 func (f *FakeService) List(ctx context.Context, opts ListOptions) (*resource.Page[*Workspace], error) {
 	return resource.NewPage(f.Workspaces, opts.PageOptions, nil), nil
 }
@@ -37,6 +46,23 @@ func (f *FakeService) Get(context.Context, string) (*Workspace, error) {
 	return f.Workspaces[0], nil
 }
 
+func (f *FakeService) ListRepoMoves(context.Context, string) ([]*connections.RepoMove, error) {
+	return f.RepoMoves, nil
+}
+
+func (f *FakeService) ListEvents(context.Context, string) ([]*Event, error) {
+	return f.Events, nil
+}
+
+func (f *FakeService) GetTerraformVersion(context.Context, string) (*ResolvedTerraformVersion, error) {
+	return &ResolvedTerraformVersion{
+		ID:        f.Workspaces[0].ID,
+		Requested: f.Workspaces[0].TerraformVersion,
+		Version:   f.Workspaces[0].TerraformVersion,
+		Source:    TerraformVersionSourceWorkspace,
+	}, nil
+}
+
 func (f *FakeService) GetByName(context.Context, string, string) (*Workspace, error) {
 	return f.Workspaces[0], nil
 }
@@ -53,10 +79,38 @@ func (f *FakeService) Unlock(context.Context, string, *string, bool) (*Workspace
 	return f.Workspaces[0], nil
 }
 
+func (f *FakeService) Pause(context.Context, string) (*Workspace, error) {
+	return f.Workspaces[0], nil
+}
+
+func (f *FakeService) Resume(context.Context, string) (*Workspace, error) {
+	return f.Workspaces[0], nil
+}
+
+func (f *FakeService) EnableDebugLogging(context.Context, string, EnableDebugLoggingOptions) (*Workspace, error) {
+	return f.Workspaces[0], nil
+}
+
+func (f *FakeService) DisableDebugLogging(context.Context, string) (*Workspace, error) {
+	return f.Workspaces[0], nil
+}
+
 func (f *FakeService) ListTags(context.Context, string, ListTagsOptions) (*resource.Page[*Tag], error) {
 	return nil, nil
 }
 
+func (f *FakeService) GetVCSDefaults(_ context.Context, organization string) (*VCSDefaults, error) {
+	return &VCSDefaults{Organization: organization}, nil
+}
+
+func (f *FakeService) UpdateVCSDefaults(_ context.Context, organization string, opts UpdateVCSDefaultsOptions) (*VCSDefaults, error) {
+	return newVCSDefaults(organization, opts)
+}
+
+func (f *FakeService) ApplyVCSDefaults(context.Context, string) ([]BulkUpdateResult, error) {
+	return nil, nil
+}
+
 func (f *FakeService) GetPolicy(context.Context, string) (internal.WorkspacePolicy, error) {
 	return f.Policy, nil
 }
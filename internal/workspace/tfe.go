@@ -1,16 +1,23 @@
 package workspace
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
+	"strings"
+	"time"
 
+	"github.com/DataDog/jsonapi"
 	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
 	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/idempotency"
 	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/releases"
 	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/tfeapi"
 	"github.com/tofutf/tofutf/internal/tfeapi/types"
@@ -28,8 +35,10 @@ type (
 	//
 	// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/workspaces
 	tfe struct {
-		*Service
+		HandlerClient
 		*tfeapi.Responder
+
+		idempotency *idempotency.Service
 	}
 )
 
@@ -56,18 +65,27 @@ func (a *tfe) createWorkspace(w http.ResponseWriter, r *http.Request) {
 		tfeapi.Error(w, err)
 		return
 	}
-	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		tfeapi.Error(w, err)
+		return
+	}
+	if err := tfeapi.Unmarshal(bytes.NewReader(body), &params); err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
 
 	opts := CreateOptions{
 		AgentPoolID:                params.AgentPoolID,
+		PlanAgentPoolID:            params.PlanAgentPoolID,
+		ApplyAgentPoolID:           params.ApplyAgentPoolID,
 		AllowDestroyPlan:           params.AllowDestroyPlan,
 		AutoApply:                  params.AutoApply,
+		DeletionProtected:          params.DeletionProtected,
 		Description:                params.Description,
 		ExecutionMode:              (*ExecutionMode)(params.ExecutionMode),
 		GlobalRemoteState:          params.GlobalRemoteState,
+		LogScrubbingDisabled:       params.LogScrubbingDisabled,
 		MigrationEnvironment:       params.MigrationEnvironment,
 		Name:                       params.Name,
 		Organization:               params.Organization,
@@ -83,8 +101,10 @@ func (a *tfe) createWorkspace(w http.ResponseWriter, r *http.Request) {
 		// convert from json:api structs to tag specs
 		Tags: toTagSpecs(params.Tags),
 	}
-	// Always trigger runs if neither trigger patterns nor tags regex are set
-	if len(params.TriggerPatterns) == 0 && (params.VCSRepo == nil || params.VCSRepo.TagsRegex == nil) {
+	// Always trigger runs if file triggers are explicitly disabled and tags
+	// regex is not set; otherwise leave the trigger strategy unspecified, in
+	// order that the organization defaults may apply.
+	if (params.FileTriggersEnabled != nil && !*params.FileTriggersEnabled) && (params.VCSRepo == nil || params.VCSRepo.TagsRegex == nil) {
 		opts.AlwaysTrigger = internal.Bool(true)
 	}
 	if params.Operations != nil {
@@ -99,6 +119,17 @@ func (a *tfe) createWorkspace(w http.ResponseWriter, r *http.Request) {
 			opts.ExecutionMode = ExecutionModePtr(LocalExecutionMode)
 		}
 	}
+	if params.ApplyWindows != nil {
+		windows, err := toApplyWindows(params.ApplyWindows)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		opts.ApplyWindows = windows
+	}
+	if params.PreflightChecks != nil {
+		opts.PreflightChecks = NewPreflightChecks(params.PreflightChecks)
+	}
 	if params.VCSRepo != nil {
 		if params.VCSRepo.Identifier == nil || params.VCSRepo.OAuthTokenID == nil {
 			tfeapi.Error(w, errors.New("must specify both oauth-token-id and identifier attributes for vcs-repo"))
@@ -112,8 +143,26 @@ func (a *tfe) createWorkspace(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	ws, err := a.Create(r.Context(), opts)
-	if err != nil {
+	var ws *Workspace
+	id, replayed, err := a.idempotency.Do(r, body, func() (string, error) {
+		created, err := a.Create(r.Context(), opts)
+		if err != nil {
+			return "", err
+		}
+		ws = created
+		return created.ID, nil
+	})
+	if replayed {
+		w.Header().Set(idempotency.ReplayedHeader, "true")
+		ws, err = a.Get(r.Context(), id)
+	}
+	if errors.Is(err, releases.ErrForbiddenTerraformVersion) || errors.Is(err, ErrInvalidPreflightCheck) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
@@ -248,11 +297,7 @@ func (a *tfe) lockWorkspace(w http.ResponseWriter, r *http.Request) {
 
 	ws, err := a.Lock(r.Context(), id, nil)
 	if err != nil {
-		if errors.Is(err, ErrWorkspaceAlreadyLocked) {
-			http.Error(w, "", http.StatusConflict)
-		} else {
-			tfeapi.Error(w, err)
-		}
+		tfeapi.Error(w, lockConflictError(err))
 		return
 	}
 
@@ -282,14 +327,7 @@ func (a *tfe) unlock(w http.ResponseWriter, r *http.Request, force bool) {
 
 	ws, err := a.Unlock(r.Context(), id, nil, force)
 	if err != nil {
-		if errors.Is(err, ErrWorkspaceAlreadyUnlocked) || errors.Is(err, ErrWorkspaceLockedByRun) {
-			tfeapi.Error(w, &internal.HTTPError{
-				Code:    http.StatusConflict,
-				Message: err.Error(),
-			})
-		} else {
-			tfeapi.Error(w, err)
-		}
+		tfeapi.Error(w, lockConflictError(err))
 		return
 	}
 
@@ -311,7 +349,7 @@ func (a *tfe) deleteWorkspace(w http.ResponseWriter, r *http.Request) {
 
 	_, err = a.Delete(r.Context(), workspaceID)
 	if err != nil {
-		tfeapi.Error(w, err)
+		tfeapi.Error(w, deleteConflictError(err))
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -331,12 +369,24 @@ func (a *tfe) deleteWorkspaceByName(w http.ResponseWriter, r *http.Request) {
 	}
 	_, err = a.Delete(r.Context(), ws.ID)
 	if err != nil {
-		tfeapi.Error(w, err)
+		tfeapi.Error(w, deleteConflictError(err))
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// deleteConflictError converts an error from deleting a workspace into a
+// conflict error if the workspace is protected from deletion.
+func deleteConflictError(err error) error {
+	if errors.Is(err, ErrWorkspaceDeletionProtected) {
+		return &internal.HTTPError{
+			Code:    http.StatusConflict,
+			Message: err.Error(),
+		}
+	}
+	return err
+}
+
 func (a *tfe) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceID string) {
 	params := types.WorkspaceUpdateOptions{}
 	if err := tfeapi.Unmarshal(r.Body, &params); err != nil {
@@ -350,11 +400,15 @@ func (a *tfe) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceI
 
 	opts := UpdateOptions{
 		AgentPoolID:                params.AgentPoolID,
+		PlanAgentPoolID:            params.PlanAgentPoolID,
+		ApplyAgentPoolID:           params.ApplyAgentPoolID,
 		AllowDestroyPlan:           params.AllowDestroyPlan,
 		AutoApply:                  params.AutoApply,
+		DeletionProtected:          params.DeletionProtected,
 		Description:                params.Description,
 		ExecutionMode:              (*ExecutionMode)(params.ExecutionMode),
 		GlobalRemoteState:          params.GlobalRemoteState,
+		LogScrubbingDisabled:       params.LogScrubbingDisabled,
 		Name:                       params.Name,
 		QueueAllRuns:               params.QueueAllRuns,
 		SpeculativeEnabled:         params.SpeculativeEnabled,
@@ -377,6 +431,18 @@ func (a *tfe) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceI
 		opts.AlwaysTrigger = internal.Bool(true)
 	}
 
+	if params.ApplyWindows != nil {
+		windows, err := toApplyWindows(params.ApplyWindows)
+		if err != nil {
+			tfeapi.Error(w, err)
+			return
+		}
+		opts.ApplyWindows = windows
+	}
+	if params.PreflightChecks != nil {
+		opts.PreflightChecks = NewPreflightChecks(params.PreflightChecks)
+	}
+
 	if params.VCSRepo.Set {
 		if params.VCSRepo.Valid {
 			// client has provided non-null vcs options, which means they either
@@ -394,8 +460,14 @@ func (a *tfe) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceI
 		}
 	}
 
-	ws, err := a.Update(r.Context(), workspaceID, opts)
-	if err != nil {
+	ws, warnings, err := a.UpdateWithWarnings(r.Context(), workspaceID, opts)
+	if errors.Is(err, releases.ErrForbiddenTerraformVersion) || errors.Is(err, ErrInvalidPreflightCheck) {
+		tfeapi.Error(w, &internal.HTTPError{
+			Code:    http.StatusUnprocessableEntity,
+			Message: err.Error(),
+		})
+		return
+	} else if err != nil {
 		tfeapi.Error(w, err)
 		return
 	}
@@ -406,7 +478,11 @@ func (a *tfe) updateWorkspace(w http.ResponseWriter, r *http.Request, workspaceI
 		return
 	}
 
-	a.Respond(w, r, converted, http.StatusOK)
+	var marshalOpts []jsonapi.MarshalOption
+	if len(warnings) > 0 {
+		marshalOpts = append(marshalOpts, jsonapi.MarshalMeta(map[string]any{"warnings": warnings}))
+	}
+	a.Respond(w, r, converted, http.StatusOK, marshalOpts...)
 }
 
 func (a *tfe) convert(from *Workspace, r *http.Request) (*types.Workspace, error) {
@@ -434,16 +510,18 @@ func (a *tfe) convert(from *Workspace, r *http.Request) (*types.Workspace, error
 	to := &types.Workspace{
 		ID: from.ID,
 		Actions: &types.WorkspaceActions{
-			IsDestroyable: true,
+			IsDestroyable: !from.DeletionProtected,
 		},
 		AllowDestroyPlan:     from.AllowDestroyPlan,
 		AutoApply:            from.AutoApply,
 		CanQueueDestroyPlan:  from.CanQueueDestroyPlan,
 		CreatedAt:            from.CreatedAt,
+		DeletionProtected:    from.DeletionProtected,
 		Description:          from.Description,
 		Environment:          from.Environment,
 		ExecutionMode:        string(from.ExecutionMode),
 		GlobalRemoteState:    from.GlobalRemoteState,
+		LogScrubbingDisabled: from.LogScrubbingDisabled,
 		Locked:               from.Locked(),
 		MigrationEnvironment: from.MigrationEnvironment,
 		Name:                 from.Name,
@@ -466,9 +544,28 @@ func (a *tfe) convert(from *Workspace, r *http.Request) (*types.Workspace, error
 	if from.AgentPoolID != nil {
 		to.AgentPoolID = *from.AgentPoolID
 	}
+	if from.PlanAgentPoolID != nil {
+		to.PlanAgentPoolID = *from.PlanAgentPoolID
+	}
+	if from.ApplyAgentPoolID != nil {
+		to.ApplyAgentPoolID = *from.ApplyAgentPoolID
+	}
 	if len(from.TriggerPrefixes) > 0 || len(from.TriggerPatterns) > 0 {
 		to.FileTriggersEnabled = true
 	}
+	to.PreflightChecks = from.PreflightChecks.Strings()
+	for _, window := range from.ApplyWindows {
+		days := make([]string, len(window.Days))
+		for i, d := range window.Days {
+			days[i] = strings.ToLower(d.String())
+		}
+		to.ApplyWindows = append(to.ApplyWindows, types.ApplyWindow{
+			Days:     days,
+			Start:    window.Start,
+			End:      window.End,
+			Timezone: window.Timezone,
+		})
+	}
 	if from.LatestRun != nil {
 		to.CurrentRun = &types.Run{ID: from.LatestRun.ID}
 	}
@@ -557,3 +654,26 @@ func (a *tfe) includeMany(ctx context.Context, v any) ([]any, error) {
 	}
 	return include, nil
 }
+
+// toApplyWindows converts apply windows from their json:api representation,
+// parsing the names of days.
+func toApplyWindows(from []types.ApplyWindow) (ApplyWindows, error) {
+	to := make(ApplyWindows, len(from))
+	for i, window := range from {
+		days := make([]time.Weekday, len(window.Days))
+		for j, name := range window.Days {
+			d, err := ParseWeekday(name)
+			if err != nil {
+				return nil, err
+			}
+			days[j] = d
+		}
+		to[i] = ApplyWindow{
+			Days:     days,
+			Start:    window.Start,
+			End:      window.End,
+			Timezone: window.Timezone,
+		}
+	}
+	return to, nil
+}
@@ -0,0 +1,92 @@
+package workspace
+
+type (
+	// Warning is a non-fatal problem with a workspace's settings: the
+	// combination of settings is permitted but is unlikely to behave as
+	// intended.
+	Warning struct {
+		// Code uniquely identifies the kind of warning.
+		Code string `json:"code"`
+		// Message describes the problem to the user.
+		Message string `json:"message"`
+	}
+
+	// validationRule checks the settings of a workspace, returning a warning
+	// if the settings interact badly, or an error if they contradict one
+	// another. A rule returns nil for both if the settings are fine.
+	validationRule func(ws *Workspace) (*Warning, error)
+)
+
+func (w Warning) String() string { return w.Message }
+
+// validationRules are checked against a workspace's settings after they are
+// updated.
+var validationRules = []validationRule{
+	checkAgentExecutionModeWithoutPool,
+	checkNonAgentExecutionModeWithPool,
+	checkTagsRegexAndTriggerPatterns,
+	checkTriggerPatternsWithoutConnection,
+	checkAutoApplyWithApplyWindows,
+}
+
+// validate checks the workspace's settings against each of the validation
+// rules, returning the warnings from those rules that pass, or the error from
+// the first rule that fails.
+func (ws *Workspace) validate() ([]Warning, error) {
+	var warnings []Warning
+	for _, rule := range validationRules {
+		warning, err := rule(ws)
+		if err != nil {
+			return nil, err
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+	return warnings, nil
+}
+
+func checkAgentExecutionModeWithoutPool(ws *Workspace) (*Warning, error) {
+	if ws.ExecutionMode == AgentExecutionMode && ws.AgentPoolID == nil {
+		return nil, ErrAgentExecutionModeWithoutPool
+	}
+	return nil, nil
+}
+
+func checkNonAgentExecutionModeWithPool(ws *Workspace) (*Warning, error) {
+	if ws.ExecutionMode != AgentExecutionMode && len(ws.AgentPoolIDs()) > 0 {
+		return nil, ErrNonAgentExecutionModeWithPool
+	}
+	return nil, nil
+}
+
+func checkTagsRegexAndTriggerPatterns(ws *Workspace) (*Warning, error) {
+	if ws.Connection != nil && ws.Connection.TagsRegex != "" && len(ws.TriggerPatterns) > 0 {
+		return nil, ErrTagsRegexAndTriggerPatterns
+	}
+	return nil, nil
+}
+
+// checkTriggerPatternsWithoutConnection warns that trigger patterns are
+// ignored unless the workspace is connected to a VCS repo.
+func checkTriggerPatternsWithoutConnection(ws *Workspace) (*Warning, error) {
+	if ws.Connection == nil && len(ws.TriggerPatterns) > 0 {
+		return &Warning{
+			Code:    "trigger-patterns-without-vcs",
+			Message: "trigger patterns have no effect until the workspace is connected to a VCS repository",
+		}, nil
+	}
+	return nil, nil
+}
+
+// checkAutoApplyWithApplyWindows warns that auto-applies are held until an
+// apply window opens rather than being applied immediately.
+func checkAutoApplyWithApplyWindows(ws *Workspace) (*Warning, error) {
+	if ws.AutoApply && len(ws.ApplyWindows) > 0 {
+		return &Warning{
+			Code:    "auto-apply-with-apply-windows",
+			Message: "auto-apply runs wait for one of the workspace's apply windows to open before applying",
+		}, nil
+	}
+	return nil, nil
+}
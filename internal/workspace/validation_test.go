@@ -0,0 +1,118 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestValidationRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        validationRule
+		ws          *Workspace
+		wantWarning string
+		wantErr     error
+	}{
+		{
+			name: "agent execution mode with pool",
+			rule: checkAgentExecutionModeWithoutPool,
+			ws:   &Workspace{ExecutionMode: AgentExecutionMode, AgentPoolID: internal.String("pool-123")},
+		},
+		{
+			name:    "agent execution mode without pool",
+			rule:    checkAgentExecutionModeWithoutPool,
+			ws:      &Workspace{ExecutionMode: AgentExecutionMode},
+			wantErr: ErrAgentExecutionModeWithoutPool,
+		},
+		{
+			name: "remote execution mode without pool",
+			rule: checkNonAgentExecutionModeWithPool,
+			ws:   &Workspace{ExecutionMode: RemoteExecutionMode},
+		},
+		{
+			name:    "remote execution mode with pool",
+			rule:    checkNonAgentExecutionModeWithPool,
+			ws:      &Workspace{ExecutionMode: RemoteExecutionMode, AgentPoolID: internal.String("pool-123")},
+			wantErr: ErrNonAgentExecutionModeWithPool,
+		},
+		{
+			name: "tags regex without trigger patterns",
+			rule: checkTagsRegexAndTriggerPatterns,
+			ws:   &Workspace{Connection: &Connection{TagsRegex: `^v\d+`}},
+		},
+		{
+			name:    "tags regex with trigger patterns",
+			rule:    checkTagsRegexAndTriggerPatterns,
+			ws:      &Workspace{Connection: &Connection{TagsRegex: `^v\d+`}, TriggerPatterns: []string{"/modules/**"}},
+			wantErr: ErrTagsRegexAndTriggerPatterns,
+		},
+		{
+			name: "trigger patterns with connection",
+			rule: checkTriggerPatternsWithoutConnection,
+			ws:   &Workspace{Connection: &Connection{}, TriggerPatterns: []string{"/modules/**"}},
+		},
+		{
+			name:        "trigger patterns without connection",
+			rule:        checkTriggerPatternsWithoutConnection,
+			ws:          &Workspace{TriggerPatterns: []string{"/modules/**"}},
+			wantWarning: "trigger-patterns-without-vcs",
+		},
+		{
+			name: "auto-apply without apply windows",
+			rule: checkAutoApplyWithApplyWindows,
+			ws:   &Workspace{AutoApply: true},
+		},
+		{
+			name:        "auto-apply with apply windows",
+			rule:        checkAutoApplyWithApplyWindows,
+			ws:          &Workspace{AutoApply: true, ApplyWindows: ApplyWindows{{}}},
+			wantWarning: "auto-apply-with-apply-windows",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning, err := tt.rule(tt.ws)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantWarning != "" {
+				require.NotNil(t, warning)
+				assert.Equal(t, tt.wantWarning, warning.Code)
+			} else {
+				assert.Nil(t, warning)
+			}
+		})
+	}
+}
+
+func TestWorkspace_validate(t *testing.T) {
+	t.Run("collect warnings", func(t *testing.T) {
+		ws := &Workspace{
+			ExecutionMode:   RemoteExecutionMode,
+			AutoApply:       true,
+			ApplyWindows:    ApplyWindows{{}},
+			TriggerPatterns: []string{"/modules/**"},
+		}
+
+		got, err := ws.validate()
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "trigger-patterns-without-vcs", got[0].Code)
+		assert.Equal(t, "auto-apply-with-apply-windows", got[1].Code)
+	})
+
+	t.Run("contradiction fails validation", func(t *testing.T) {
+		ws := &Workspace{
+			ExecutionMode:   AgentExecutionMode,
+			TriggerPatterns: []string{"/modules/**"},
+		}
+
+		_, err := ws.validate()
+		assert.ErrorIs(t, err, ErrAgentExecutionModeWithoutPool)
+	})
+}
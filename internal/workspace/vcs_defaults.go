@@ -0,0 +1,135 @@
+package workspace
+
+import (
+	"regexp"
+
+	"github.com/gobwas/glob"
+)
+
+type (
+	// VCSDefaults are an organization's default VCS settings. They are applied
+	// to a workspace when it is connected to a repo, unless the settings are
+	// explicitly specified. A nil field means there is no default for that
+	// setting.
+	VCSDefaults struct {
+		Organization       string
+		TriggerPrefixes    []string
+		TriggerPatterns    []string
+		TagsRegex          *string
+		SpeculativeEnabled *bool
+		SkipDrafts         *bool
+	}
+
+	// UpdateVCSDefaultsOptions are options for updating an organization's VCS
+	// defaults. The existing defaults are replaced in their entirety.
+	UpdateVCSDefaultsOptions struct {
+		TriggerPrefixes    []string
+		TriggerPatterns    []string
+		TagsRegex          *string
+		SpeculativeEnabled *bool
+		SkipDrafts         *bool
+	}
+)
+
+func newVCSDefaults(organization string, opts UpdateVCSDefaultsOptions) (*VCSDefaults, error) {
+	if opts.TagsRegex != nil && *opts.TagsRegex == "" {
+		opts.TagsRegex = nil
+	}
+	if len(opts.TriggerPatterns) == 0 {
+		opts.TriggerPatterns = nil
+	}
+	if len(opts.TriggerPrefixes) == 0 {
+		opts.TriggerPrefixes = nil
+	}
+	if opts.TagsRegex != nil && opts.TriggerPatterns != nil {
+		return nil, ErrTagsRegexAndTriggerPatterns
+	}
+	if opts.TagsRegex != nil {
+		if _, err := regexp.Compile(*opts.TagsRegex); err != nil {
+			return nil, ErrInvalidTagsRegex
+		}
+	}
+	for _, patt := range opts.TriggerPatterns {
+		if _, err := glob.Compile(patt); err != nil {
+			return nil, ErrInvalidTriggerPattern
+		}
+	}
+	return &VCSDefaults{
+		Organization:       organization,
+		TriggerPrefixes:    opts.TriggerPrefixes,
+		TriggerPatterns:    opts.TriggerPatterns,
+		TagsRegex:          opts.TagsRegex,
+		SpeculativeEnabled: opts.SpeculativeEnabled,
+		SkipDrafts:         opts.SkipDrafts,
+	}, nil
+}
+
+// IsZero determines whether the organization has no defaults.
+func (d *VCSDefaults) IsZero() bool {
+	return d.TriggerPrefixes == nil &&
+		d.TriggerPatterns == nil &&
+		d.TagsRegex == nil &&
+		d.SpeculativeEnabled == nil &&
+		d.SkipDrafts == nil
+}
+
+// fillCreateOptions sets those VCS settings that are not specified in the
+// options for creating a workspace to their defaults. The options are only
+// modified if they connect the workspace to a repo.
+func (d *VCSDefaults) fillCreateOptions(opts *CreateOptions) {
+	if opts.ConnectOptions == nil {
+		return
+	}
+	// copy connect options to avoid modifying the caller's options
+	conn := *opts.ConnectOptions
+	opts.ConnectOptions = &conn
+	d.fill(&conn, &opts.TriggerPatterns, &opts.TriggerPrefixes, &opts.SpeculativeEnabled, opts.AlwaysTrigger)
+}
+
+// fillUpdateOptions sets those VCS settings that are not specified in the
+// options for updating a workspace to their defaults. The options are only
+// modified if they connect the workspace to a repo.
+func (d *VCSDefaults) fillUpdateOptions(opts *UpdateOptions) {
+	if opts.ConnectOptions == nil {
+		return
+	}
+	// copy connect options to avoid modifying the caller's options
+	conn := *opts.ConnectOptions
+	opts.ConnectOptions = &conn
+	d.fill(&conn, &opts.TriggerPatterns, &opts.TriggerPrefixes, &opts.SpeculativeEnabled, opts.AlwaysTrigger)
+}
+
+func (d *VCSDefaults) fill(conn *ConnectOptions, patterns, prefixes *[]string, speculative **bool, alwaysTrigger *bool) {
+	// the trigger strategy is determined by one of three mutually exclusive
+	// settings, so only apply a default if none of them is specified.
+	if *patterns == nil && conn.TagsRegex == nil && alwaysTrigger == nil {
+		if d.TagsRegex != nil {
+			conn.TagsRegex = d.TagsRegex
+		} else if d.TriggerPatterns != nil {
+			*patterns = d.TriggerPatterns
+		}
+	}
+	if *prefixes == nil {
+		*prefixes = d.TriggerPrefixes
+	}
+	if *speculative == nil {
+		*speculative = d.SpeculativeEnabled
+	}
+	if conn.SkipDrafts == nil {
+		conn.SkipDrafts = d.SkipDrafts
+	}
+}
+
+// updateOptions returns options for updating a connected workspace with the
+// defaults.
+func (d *VCSDefaults) updateOptions() UpdateOptions {
+	return UpdateOptions{
+		TriggerPrefixes:    d.TriggerPrefixes,
+		TriggerPatterns:    d.TriggerPatterns,
+		SpeculativeEnabled: d.SpeculativeEnabled,
+		ConnectOptions: &ConnectOptions{
+			TagsRegex:  d.TagsRegex,
+			SkipDrafts: d.SkipDrafts,
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/sql/pggen"
+)
+
+func (db *pgdb) upsertVCSDefaults(ctx context.Context, defaults *VCSDefaults) error {
+	return db.Query(ctx, func(ctx context.Context, q pggen.Querier) error {
+		_, err := q.UpsertVCSDefaults(ctx, pggen.UpsertVCSDefaultsParams{
+			OrganizationName:   sql.String(defaults.Organization),
+			TriggerPrefixes:    defaults.TriggerPrefixes,
+			TriggerPatterns:    defaults.TriggerPatterns,
+			VCSTagsRegex:       sql.StringPtr(defaults.TagsRegex),
+			SpeculativeEnabled: sql.BoolPtr(defaults.SpeculativeEnabled),
+			VCSSkipDrafts:      sql.BoolPtr(defaults.SkipDrafts),
+		})
+		return sql.Error(err)
+	})
+}
+
+// getVCSDefaults retrieves an organization's VCS defaults. If the defaults
+// have never been set then empty defaults are returned.
+func (db *pgdb) getVCSDefaults(ctx context.Context, organization string) (*VCSDefaults, error) {
+	return sql.Query(ctx, db.Pool, func(ctx context.Context, q pggen.Querier) (*VCSDefaults, error) {
+		result, err := q.FindVCSDefaults(ctx, sql.String(organization))
+		if err != nil {
+			err = sql.Error(err)
+			if errors.Is(err, internal.ErrResourceNotFound) {
+				return &VCSDefaults{Organization: organization}, nil
+			}
+			return nil, err
+		}
+		defaults := &VCSDefaults{
+			Organization:    result.OrganizationName.String,
+			TriggerPrefixes: result.TriggerPrefixes,
+			TriggerPatterns: result.TriggerPatterns,
+		}
+		if result.VCSTagsRegex.Valid {
+			defaults.TagsRegex = &result.VCSTagsRegex.String
+		}
+		if result.SpeculativeEnabled.Valid {
+			defaults.SpeculativeEnabled = &result.SpeculativeEnabled.Bool
+		}
+		if result.VCSSkipDrafts.Valid {
+			defaults.SkipDrafts = &result.VCSSkipDrafts.Bool
+		}
+		return defaults, nil
+	})
+}
@@ -0,0 +1,84 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/resource"
+)
+
+func (s *Service) GetVCSDefaults(ctx context.Context, organization string) (*VCSDefaults, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.GetOrganizationAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := s.db.getVCSDefaults(ctx, organization)
+	if err != nil {
+		s.logger.Error("retrieving vcs defaults", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Debug("retrieved vcs defaults", "organization", organization, "subject", subject)
+	return defaults, nil
+}
+
+func (s *Service) UpdateVCSDefaults(ctx context.Context, organization string, opts UpdateVCSDefaultsOptions) (*VCSDefaults, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateOrganizationAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := newVCSDefaults(organization, opts)
+	if err != nil {
+		s.logger.Error("constructing vcs defaults", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	if err := s.db.upsertVCSDefaults(ctx, defaults); err != nil {
+		s.logger.Error("updating vcs defaults", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	s.logger.Info("updated vcs defaults", "organization", organization, "subject", subject)
+	return defaults, nil
+}
+
+// ApplyVCSDefaults applies an organization's VCS defaults to all of its
+// workspaces that are connected to a repo, overriding their existing
+// settings. A result is returned for each workspace.
+func (s *Service) ApplyVCSDefaults(ctx context.Context, organization string) ([]BulkUpdateResult, error) {
+	subject, err := s.organization.CanAccess(ctx, rbac.UpdateOrganizationAction, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := s.db.getVCSDefaults(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	if defaults.IsZero() {
+		// nothing to apply
+		return nil, nil
+	}
+	workspaces, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Workspace], error) {
+		return s.db.list(ctx, ListOptions{Organization: &organization, PageOptions: opts})
+	})
+	if err != nil {
+		s.logger.Error("applying vcs defaults", "organization", organization, "subject", subject, "err", err)
+		return nil, err
+	}
+	var connected []string
+	for _, ws := range workspaces {
+		if ws.Connection != nil {
+			connected = append(connected, ws.ID)
+		}
+	}
+	results := s.BulkUpdate(ctx, connected, defaults.updateOptions())
+
+	var failed int
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	s.logger.Info("applied vcs defaults", "organization", organization, "workspaces", len(results), "failed", failed, "subject", subject)
+	return results, nil
+}
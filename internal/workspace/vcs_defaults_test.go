@@ -0,0 +1,154 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+)
+
+func TestNewVCSDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		opts UpdateVCSDefaultsOptions
+		want error
+	}{
+		{
+			name: "no defaults",
+		},
+		{
+			name: "trigger patterns",
+			opts: UpdateVCSDefaultsOptions{TriggerPatterns: []string{"/modules/**"}},
+		},
+		{
+			name: "tags regex",
+			opts: UpdateVCSDefaultsOptions{TagsRegex: internal.String(`^v\d+`)},
+		},
+		{
+			name: "both trigger patterns and tags regex",
+			opts: UpdateVCSDefaultsOptions{
+				TriggerPatterns: []string{"/modules/**"},
+				TagsRegex:       internal.String(`^v\d+`),
+			},
+			want: ErrTagsRegexAndTriggerPatterns,
+		},
+		{
+			name: "invalid tags regex",
+			opts: UpdateVCSDefaultsOptions{TagsRegex: internal.String(`(`)},
+			want: ErrInvalidTagsRegex,
+		},
+		{
+			name: "invalid trigger pattern",
+			opts: UpdateVCSDefaultsOptions{TriggerPatterns: []string{"[!"}},
+			want: ErrInvalidTriggerPattern,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newVCSDefaults("acme", tt.opts)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+
+	t.Run("empty values mean no default", func(t *testing.T) {
+		got, err := newVCSDefaults("acme", UpdateVCSDefaultsOptions{
+			TriggerPatterns: []string{},
+			TagsRegex:       internal.String(""),
+		})
+		require.NoError(t, err)
+		assert.True(t, got.IsZero())
+	})
+}
+
+func TestVCSDefaults_Fill(t *testing.T) {
+	defaults := &VCSDefaults{
+		TriggerPrefixes:    []string{"/modules"},
+		TriggerPatterns:    []string{"/modules/**"},
+		SpeculativeEnabled: internal.Bool(false),
+		SkipDrafts:         internal.Bool(true),
+	}
+	connect := &ConnectOptions{
+		RepoPath:      internal.String("acme/monorepo"),
+		VCSProviderID: internal.String("vcs-123"),
+	}
+
+	t.Run("inherit defaults", func(t *testing.T) {
+		opts := CreateOptions{ConnectOptions: connect}
+		defaults.fillCreateOptions(&opts)
+
+		assert.Equal(t, []string{"/modules"}, opts.TriggerPrefixes)
+		assert.Equal(t, []string{"/modules/**"}, opts.TriggerPatterns)
+		assert.Equal(t, internal.Bool(false), opts.SpeculativeEnabled)
+		assert.Equal(t, internal.Bool(true), opts.SkipDrafts)
+		// caller's connect options should be untouched
+		assert.Nil(t, connect.SkipDrafts)
+	})
+
+	t.Run("override defaults", func(t *testing.T) {
+		opts := UpdateOptions{
+			SpeculativeEnabled: internal.Bool(true),
+			ConnectOptions: &ConnectOptions{
+				RepoPath:      internal.String("acme/monorepo"),
+				VCSProviderID: internal.String("vcs-123"),
+				TagsRegex:     internal.String(`^v\d+`),
+				SkipDrafts:    internal.Bool(false),
+			},
+		}
+		defaults.fillUpdateOptions(&opts)
+
+		assert.Equal(t, []string{"/modules"}, opts.TriggerPrefixes)
+		// tags regex is mutually exclusive with trigger patterns, so trigger
+		// patterns should not be inherited
+		assert.Nil(t, opts.TriggerPatterns)
+		assert.Equal(t, internal.Bool(true), opts.SpeculativeEnabled)
+		assert.Equal(t, internal.Bool(false), opts.SkipDrafts)
+	})
+
+	t.Run("always trigger excludes default trigger patterns", func(t *testing.T) {
+		opts := UpdateOptions{AlwaysTrigger: internal.Bool(true), ConnectOptions: connect}
+		defaults.fillUpdateOptions(&opts)
+
+		assert.Nil(t, opts.TriggerPatterns)
+	})
+
+	t.Run("not connecting", func(t *testing.T) {
+		opts := UpdateOptions{}
+		defaults.fillUpdateOptions(&opts)
+
+		assert.Equal(t, UpdateOptions{}, opts)
+	})
+}
+
+func TestBulkUpdate(t *testing.T) {
+	client := &fakeBulkUpdateClient{fail: "ws-2"}
+
+	got := bulkUpdate(context.Background(), client, []string{"ws-1", "ws-2", "ws-3"}, UpdateOptions{})
+
+	require.Equal(t, 3, len(got))
+	assert.Equal(t, "ws-1", got[0].WorkspaceID)
+	assert.NoError(t, got[0].Err)
+	assert.NotNil(t, got[0].Workspace)
+
+	// failure to update one workspace should not prevent updating others
+	assert.Equal(t, "ws-2", got[1].WorkspaceID)
+	assert.Error(t, got[1].Err)
+	assert.Nil(t, got[1].Workspace)
+
+	assert.Equal(t, "ws-3", got[2].WorkspaceID)
+	assert.NoError(t, got[2].Err)
+}
+
+type fakeBulkUpdateClient struct {
+	// fail updating the workspace with this ID
+	fail string
+}
+
+func (f *fakeBulkUpdateClient) Update(ctx context.Context, workspaceID string, opts UpdateOptions) (*Workspace, error) {
+	if workspaceID == f.fail {
+		return nil, errors.New("something went wrong")
+	}
+	return &Workspace{ID: workspaceID}, nil
+}
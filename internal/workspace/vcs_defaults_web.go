@@ -0,0 +1,179 @@
+package workspace
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal/http/decode"
+	"github.com/tofutf/tofutf/internal/http/html"
+	"github.com/tofutf/tofutf/internal/http/html/paths"
+	"github.com/tofutf/tofutf/internal/organization"
+)
+
+func (h *webHandlers) addVCSDefaultsHandlers(r *mux.Router) {
+	r = html.UIRouter(r)
+
+	r.HandleFunc("/organizations/{organization_name}/vcs-defaults/show", h.getVCSDefaults).Methods("GET")
+	r.HandleFunc("/organizations/{organization_name}/vcs-defaults/update", h.updateVCSDefaults).Methods("POST")
+	r.HandleFunc("/organizations/{organization_name}/vcs-defaults/apply", h.applyVCSDefaults).Methods("POST")
+}
+
+func (h *webHandlers) getVCSDefaults(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	defaults, err := h.client.GetVCSDefaults(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderVCSDefaults(w, r, defaults, nil)
+}
+
+func (h *webHandlers) updateVCSDefaults(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Organization       string `schema:"organization_name,required"`
+		TriggerPrefixes    string `schema:"trigger_prefixes"`
+		TriggerPatterns    string `schema:"trigger_patterns"`
+		TagsRegex          string `schema:"tags_regex"`
+		SpeculativeEnabled string `schema:"speculative_enabled"`
+		SkipDrafts         string `schema:"skip_drafts"`
+	}
+	if err := decode.All(&params, r); err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.client.UpdateVCSDefaults(r.Context(), params.Organization, UpdateVCSDefaultsOptions{
+		TriggerPrefixes:    splitLines(params.TriggerPrefixes),
+		TriggerPatterns:    splitLines(params.TriggerPatterns),
+		TagsRegex:          &params.TagsRegex,
+		SpeculativeEnabled: parseOptionalBool(params.SpeculativeEnabled),
+		SkipDrafts:         parseOptionalBool(params.SkipDrafts),
+	})
+	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.VCSDefaults(params.Organization), http.StatusFound)
+		return
+	}
+
+	html.FlashSuccess(w, "updated vcs defaults")
+	http.Redirect(w, r, paths.VCSDefaults(params.Organization), http.StatusFound)
+}
+
+func (h *webHandlers) applyVCSDefaults(w http.ResponseWriter, r *http.Request) {
+	org, err := decode.Param("organization_name", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	results, err := h.client.ApplyVCSDefaults(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defaults, err := h.client.GetVCSDefaults(r.Context(), org)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// render results rather than redirect in order to report the outcome for
+	// each workspace.
+	h.renderVCSDefaults(w, r, defaults, results)
+}
+
+func (h *webHandlers) renderVCSDefaults(w http.ResponseWriter, r *http.Request, defaults *VCSDefaults, results []BulkUpdateResult) {
+	var tagsRegex string
+	if defaults.TagsRegex != nil {
+		tagsRegex = *defaults.TagsRegex
+	}
+	h.Render("vcs_defaults_get.tmpl", w, struct {
+		organization.OrganizationPage
+		TriggerPrefixes    string
+		TriggerPatterns    string
+		TagsRegex          string
+		SpeculativeEnabled string
+		SkipDrafts         string
+		// Results of applying the defaults to workspaces.
+		Results []BulkUpdateResult
+		// Applied is true if the defaults have just been applied.
+		Applied bool
+	}{
+		OrganizationPage:   organization.NewPage(r, "vcs defaults", defaults.Organization),
+		TriggerPrefixes:    strings.Join(defaults.TriggerPrefixes, "\n"),
+		TriggerPatterns:    strings.Join(defaults.TriggerPatterns, "\n"),
+		TagsRegex:          tagsRegex,
+		SpeculativeEnabled: formatOptionalBool(defaults.SpeculativeEnabled),
+		SkipDrafts:         formatOptionalBool(defaults.SkipDrafts),
+		Results:            results,
+		Applied:            r.Method == http.MethodPost,
+	})
+}
+
+// splitLines splits a string into its non-empty lines, trimming whitespace.
+func splitLines(s string) (lines []string) {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return
+}
+
+// parseOptionalBool parses a form value that is either a boolean or empty,
+// the latter denoting that no value has been chosen.
+func parseOptionalBool(s string) *bool {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil
+	}
+	return &b
+}
+
+// formatOptionalBool is the inverse of parseOptionalBool.
+func formatOptionalBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+// inheritedVCSSettings describes an organization's VCS defaults for display
+// alongside a workspace's own settings. An empty string means there is no
+// default for the setting.
+type inheritedVCSSettings struct {
+	Triggers           string
+	SpeculativeEnabled string
+	SkipDrafts         string
+}
+
+func newInheritedVCSSettings(defaults *VCSDefaults) (inherited inheritedVCSSettings) {
+	if defaults.TagsRegex != nil {
+		inherited.Triggers = "tags matching " + *defaults.TagsRegex
+	} else if defaults.TriggerPatterns != nil {
+		inherited.Triggers = "files matching " + strings.Join(defaults.TriggerPatterns, ", ")
+	}
+	if defaults.SpeculativeEnabled != nil {
+		if *defaults.SpeculativeEnabled {
+			inherited.SpeculativeEnabled = "enabled"
+		} else {
+			inherited.SpeculativeEnabled = "disabled"
+		}
+	}
+	if defaults.SkipDrafts != nil {
+		if *defaults.SkipDrafts {
+			inherited.SkipDrafts = "skip"
+		} else {
+			inherited.SkipDrafts = "don't skip"
+		}
+	}
+	return
+}
@@ -3,15 +3,20 @@ package workspace
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/connections"
 	"github.com/tofutf/tofutf/internal/http/decode"
 	"github.com/tofutf/tofutf/internal/http/html"
 	"github.com/tofutf/tofutf/internal/http/html/paths"
 	"github.com/tofutf/tofutf/internal/organization"
 	"github.com/tofutf/tofutf/internal/rbac"
+	"github.com/tofutf/tofutf/internal/releases"
 	"github.com/tofutf/tofutf/internal/resource"
 	"github.com/tofutf/tofutf/internal/team"
 	"github.com/tofutf/tofutf/internal/vcs"
@@ -64,17 +69,29 @@ type (
 		Create(ctx context.Context, opts CreateOptions) (*Workspace, error)
 		Get(ctx context.Context, workspaceID string) (*Workspace, error)
 		GetByName(ctx context.Context, organization, workspace string) (*Workspace, error)
+		GetTerraformVersion(ctx context.Context, workspaceID string) (*ResolvedTerraformVersion, error)
 		List(ctx context.Context, opts ListOptions) (*resource.Page[*Workspace], error)
 		Update(ctx context.Context, workspaceID string, opts UpdateOptions) (*Workspace, error)
+		UpdateWithWarnings(ctx context.Context, workspaceID string, opts UpdateOptions) (*Workspace, []Warning, error)
 		Delete(ctx context.Context, workspaceID string) (*Workspace, error)
 		Lock(ctx context.Context, workspaceID string, runID *string) (*Workspace, error)
 		Unlock(ctx context.Context, workspaceID string, runID *string, force bool) (*Workspace, error)
+		Pause(ctx context.Context, workspaceID string) (*Workspace, error)
+		Resume(ctx context.Context, workspaceID string) (*Workspace, error)
+		EnableDebugLogging(ctx context.Context, workspaceID string, opts EnableDebugLoggingOptions) (*Workspace, error)
+		DisableDebugLogging(ctx context.Context, workspaceID string) (*Workspace, error)
 
 		AddTags(ctx context.Context, workspaceID string, tags []TagSpec) error
 		RemoveTags(ctx context.Context, workspaceID string, tags []TagSpec) error
 		ListTags(ctx context.Context, organization string, opts ListTagsOptions) (*resource.Page[*Tag], error)
 
+		GetVCSDefaults(ctx context.Context, organization string) (*VCSDefaults, error)
+		UpdateVCSDefaults(ctx context.Context, organization string, opts UpdateVCSDefaultsOptions) (*VCSDefaults, error)
+		ApplyVCSDefaults(ctx context.Context, organization string) ([]BulkUpdateResult, error)
+
 		GetPolicy(ctx context.Context, workspaceID string) (internal.WorkspacePolicy, error)
+		ListRepoMoves(ctx context.Context, workspaceID string) ([]*connections.RepoMove, error)
+		ListEvents(ctx context.Context, workspaceID string) ([]*Event, error)
 		SetPermission(ctx context.Context, workspaceID, teamID string, role rbac.Role) error
 		UnsetPermission(ctx context.Context, workspaceID, teamID string) error
 	}
@@ -108,6 +125,10 @@ func (h *webHandlers) addHandlers(r *mux.Router) {
 	r.HandleFunc("/workspaces/{workspace_id}/lock", h.lockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/unlock", h.unlockWorkspace).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/force-unlock", h.forceUnlockWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/pause", h.pauseWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/resume", h.resumeWorkspace).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/enable-debug-logging", h.enableDebugLogging).Methods("POST")
+	r.HandleFunc("/workspaces/{workspace_id}/disable-debug-logging", h.disableDebugLogging).Methods("POST")
 	r.HandleFunc("/workspaces/{workspace_id}/setup-connection-provider", h.listWorkspaceVCSProviders).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}/setup-connection-repo", h.listWorkspaceVCSRepos).Methods("GET")
 	r.HandleFunc("/workspaces/{workspace_id}/connect", h.connect).Methods("POST")
@@ -261,6 +282,10 @@ func (h *webHandlers) getWorkspace(w http.ResponseWriter, r *http.Request) {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// the version is only displayed, so a failure to resolve it, e.g. because
+	// available versions cannot be listed, falls back to displaying the
+	// version the workspace specifies.
+	version, _ := h.client.GetTerraformVersion(r.Context(), id)
 
 	var provider *vcsprovider.VCSProvider
 	if ws.Connection != nil {
@@ -270,6 +295,18 @@ func (h *webHandlers) getWorkspace(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	// repos that have since been renamed or transferred are listed in the
+	// workspace's activity.
+	moves, err := h.client.ListRepoMoves(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	events, err := h.client.ListEvents(r.Context(), id)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	tags, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Tag], error) {
 		return h.client.ListTags(r.Context(), ws.Organization, ListTagsOptions{
@@ -290,27 +327,37 @@ func (h *webHandlers) getWorkspace(w http.ResponseWriter, r *http.Request) {
 	h.Render("workspace_get.tmpl", w, struct {
 		WorkspacePage
 		LockButton
-		VCSProvider        *vcsprovider.VCSProvider
-		CanApply           bool
-		CanAddTags         bool
-		CanRemoveTags      bool
-		CanCreateRun       bool
-		CanLockWorkspace   bool
-		CanUnlockWorkspace bool
-		CanUpdateWorkspace bool
-		UnassignedTags     []string
-		TagsDropdown       html.DropdownUI
+		VCSProvider              *vcsprovider.VCSProvider
+		RepoMoves                []*connections.RepoMove
+		Events                   []*Event
+		TerraformVersion         *ResolvedTerraformVersion
+		CanApply                 bool
+		CanAddTags               bool
+		CanRemoveTags            bool
+		CanCreateRun             bool
+		CanCreateHighPriorityRun bool
+		CanLockWorkspace         bool
+		CanUnlockWorkspace       bool
+		CanPauseWorkspace        bool
+		CanUpdateWorkspace       bool
+		UnassignedTags           []string
+		TagsDropdown             html.DropdownUI
 	}{
-		WorkspacePage:      NewPage(r, ws.Name, ws),
-		LockButton:         lockButtonHelper(ws, policy, user),
-		VCSProvider:        provider,
-		CanApply:           user.CanAccessWorkspace(rbac.ApplyRunAction, policy),
-		CanAddTags:         user.CanAccessWorkspace(rbac.AddTagsAction, policy),
-		CanRemoveTags:      user.CanAccessWorkspace(rbac.RemoveTagsAction, policy),
-		CanCreateRun:       user.CanAccessWorkspace(rbac.CreateRunAction, policy),
-		CanLockWorkspace:   user.CanAccessWorkspace(rbac.LockWorkspaceAction, policy),
-		CanUnlockWorkspace: user.CanAccessWorkspace(rbac.UnlockWorkspaceAction, policy),
-		CanUpdateWorkspace: user.CanAccessWorkspace(rbac.UpdateWorkspaceAction, policy),
+		WorkspacePage:            NewPage(r, ws.Name, ws),
+		LockButton:               lockButtonHelper(ws, policy, user),
+		VCSProvider:              provider,
+		RepoMoves:                moves,
+		Events:                   events,
+		TerraformVersion:         version,
+		CanApply:                 user.CanAccessWorkspace(rbac.ApplyRunAction, policy),
+		CanAddTags:               user.CanAccessWorkspace(rbac.AddTagsAction, policy),
+		CanRemoveTags:            user.CanAccessWorkspace(rbac.RemoveTagsAction, policy),
+		CanCreateRun:             user.CanAccessWorkspace(rbac.CreateRunAction, policy),
+		CanCreateHighPriorityRun: user.CanAccessWorkspace(rbac.CreateHighPriorityRunAction, policy),
+		CanLockWorkspace:         user.CanAccessWorkspace(rbac.LockWorkspaceAction, policy),
+		CanUnlockWorkspace:       user.CanAccessWorkspace(rbac.UnlockWorkspaceAction, policy),
+		CanPauseWorkspace:        user.CanAccessWorkspace(rbac.PauseWorkspaceAction, policy),
+		CanUpdateWorkspace:       user.CanAccessWorkspace(rbac.UpdateWorkspaceAction, policy),
 		TagsDropdown: html.DropdownUI{
 			Name:        "tag_name",
 			Available:   internal.DiffStrings(getTagNames(), ws.Tags),
@@ -392,13 +439,22 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var provider *vcsprovider.VCSProvider
+	var (
+		provider  *vcsprovider.VCSProvider
+		inherited inheritedVCSSettings
+	)
 	if workspace.Connection != nil {
 		provider, err = h.vcsproviders.Get(r.Context(), workspace.Connection.VCSProviderID)
 		if err != nil {
 			h.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		defaults, err := h.client.GetVCSDefaults(r.Context(), workspace.Organization)
+		if err != nil {
+			h.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		inherited = newInheritedVCSSettings(defaults)
 	}
 
 	tags, err := resource.ListAll(func(opts resource.PageOptions) (*resource.Page[*Tag], error) {
@@ -423,6 +479,7 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 		Unassigned         []*team.Team
 		Roles              []rbac.Role
 		VCSProvider        *vcsprovider.VCSProvider
+		InheritedVCS       inheritedVCSSettings
 		UnassignedTags     []string
 		CanUpdateWorkspace bool
 		CanDeleteWorkspace bool
@@ -444,6 +501,7 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 			rbac.WorkspaceAdminRole,
 		},
 		VCSProvider:        provider,
+		InheritedVCS:       inherited,
 		UnassignedTags:     internal.DiffStrings(getTagNames(), workspace.Tags),
 		VCSTagRegexDefault: vcsTagRegexDefault,
 		VCSTagRegexPrefix:  vcsTagRegexPrefix,
@@ -459,15 +517,21 @@ func (h *webHandlers) editWorkspace(w http.ResponseWriter, r *http.Request) {
 
 func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 	var params struct {
-		AgentPoolID       string `schema:"agent_pool_id"`
-		AutoApply         bool   `schema:"auto_apply"`
-		Name              string
-		Description       string
-		ExecutionMode     ExecutionMode `schema:"execution_mode"`
-		TerraformVersion  string        `schema:"terraform_version"`
-		WorkingDirectory  string        `schema:"working_directory"`
-		WorkspaceID       string        `schema:"workspace_id,required"`
-		GlobalRemoteState bool          `schema:"global_remote_state"`
+		AgentPoolID          string `schema:"agent_pool_id"`
+		PlanAgentPoolID      string `schema:"plan_agent_pool_id"`
+		ApplyAgentPoolID     string `schema:"apply_agent_pool_id"`
+		AutoApply            bool   `schema:"auto_apply"`
+		DeletionProtected    bool   `schema:"deletion_protected"`
+		Name                 string
+		Description          string
+		ExecutionMode        ExecutionMode `schema:"execution_mode"`
+		TerraformVersion     string        `schema:"terraform_version"`
+		WorkingDirectory     string        `schema:"working_directory"`
+		WorkspaceID          string        `schema:"workspace_id,required"`
+		GlobalRemoteState    bool          `schema:"global_remote_state"`
+		LogScrubbingDisabled bool          `schema:"log_scrubbing_disabled"`
+		ApplyWindows         string        `schema:"apply_windows"`
+		PreflightChecks      []string      `schema:"preflight_checks"`
 
 		// VCS connection
 		VCSTriggerStrategy  string `schema:"vcs_trigger"`
@@ -476,6 +540,8 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 		PredefinedTagsRegex string `schema:"tags_regex"`
 		CustomTagsRegex     string `schema:"custom_tags_regex"`
 		AllowCLIApply       bool   `schema:"allow_cli_apply"`
+		SpeculativeEnabled  bool   `schema:"speculative_enabled"`
+		SkipDrafts          bool   `schema:"skip_drafts"`
 	}
 	if err := decode.All(&params, r); err != nil {
 		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
@@ -490,20 +556,24 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 	}
 
 	opts := UpdateOptions{
-		AutoApply:         &params.AutoApply,
-		Name:              &params.Name,
-		Description:       &params.Description,
-		ExecutionMode:     &params.ExecutionMode,
-		TerraformVersion:  &params.TerraformVersion,
-		WorkingDirectory:  &params.WorkingDirectory,
-		GlobalRemoteState: &params.GlobalRemoteState,
+		AutoApply:            &params.AutoApply,
+		DeletionProtected:    &params.DeletionProtected,
+		Name:                 &params.Name,
+		Description:          &params.Description,
+		ExecutionMode:        &params.ExecutionMode,
+		TerraformVersion:     &params.TerraformVersion,
+		WorkingDirectory:     &params.WorkingDirectory,
+		GlobalRemoteState:    &params.GlobalRemoteState,
+		LogScrubbingDisabled: &params.LogScrubbingDisabled,
 	}
 	if ws.Connection != nil {
 		// workspace is connected, so set connection fields
 		opts.ConnectOptions = &ConnectOptions{
 			AllowCLIApply: &params.AllowCLIApply,
 			Branch:        &params.VCSBranch,
+			SkipDrafts:    &params.SkipDrafts,
 		}
+		opts.SpeculativeEnabled = &params.SpeculativeEnabled
 		switch params.VCSTriggerStrategy {
 		case VCSTriggerAlways:
 			opts.AlwaysTrigger = internal.Bool(true)
@@ -524,15 +594,32 @@ func (h *webHandlers) updateWorkspace(w http.ResponseWriter, r *http.Request) {
 	// only set agent pool ID if execution mode is set to agent
 	if params.ExecutionMode == AgentExecutionMode {
 		opts.AgentPoolID = &params.AgentPoolID
+		opts.PlanAgentPoolID = &params.PlanAgentPoolID
+		opts.ApplyAgentPoolID = &params.ApplyAgentPoolID
 	}
-
-	ws, err = h.client.Update(r.Context(), params.WorkspaceID, opts)
+	// unchecking every check removes them all
+	opts.PreflightChecks = append(PreflightChecks{}, NewPreflightChecks(params.PreflightChecks)...)
+	opts.ApplyWindows, err = ParseApplyWindows(params.ApplyWindows)
 	if err != nil {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
+		return
+	}
+
+	ws, warnings, err := h.client.UpdateWithWarnings(r.Context(), params.WorkspaceID, opts)
+	if errors.Is(err, releases.ErrForbiddenTerraformVersion) || errors.Is(err, ErrInvalidPreflightCheck) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.EditWorkspace(params.WorkspaceID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	html.FlashSuccess(w, "updated workspace")
+	for _, warning := range warnings {
+		html.FlashWarning(w, warning.Message)
+	}
 	// User may have updated workspace name so path references updated workspace
 	http.Redirect(w, r, paths.EditWorkspace(ws.ID), http.StatusFound)
 }
@@ -545,7 +632,11 @@ func (h *webHandlers) deleteWorkspace(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ws, err := h.client.Delete(r.Context(), workspaceID)
-	if err != nil {
+	if errors.Is(err, ErrWorkspaceDeletionProtected) {
+		html.FlashError(w, "cannot delete workspace: deletion protection is enabled")
+		http.Redirect(w, r, paths.EditWorkspace(workspaceID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -561,7 +652,12 @@ func (h *webHandlers) lockWorkspace(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ws, err := h.client.Lock(r.Context(), id, nil)
-	if err != nil {
+	var locked *LockedError
+	if errors.As(err, &locked) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Workspace(id), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -576,7 +672,12 @@ func (h *webHandlers) unlockWorkspace(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ws, err := h.client.Unlock(r.Context(), workspaceID, nil, false)
-	if err != nil {
+	var locked *LockedError
+	if errors.As(err, &locked) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Workspace(workspaceID), http.StatusFound)
+		return
+	} else if err != nil {
 		h.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -600,6 +701,98 @@ func (h *webHandlers) forceUnlockWorkspace(w http.ResponseWriter, r *http.Reques
 	http.Redirect(w, r, paths.Workspace(ws.ID), http.StatusFound)
 }
 
+func (h *webHandlers) pauseWorkspace(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err = h.client.Pause(r.Context(), workspaceID)
+	if errors.Is(err, ErrWorkspaceAlreadyPaused) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Workspace(workspaceID), http.StatusFound)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "paused workspace")
+	http.Redirect(w, r, paths.Workspace(workspaceID), http.StatusFound)
+}
+
+func (h *webHandlers) resumeWorkspace(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err = h.client.Resume(r.Context(), workspaceID)
+	if errors.Is(err, ErrWorkspaceNotPaused) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Workspace(workspaceID), http.StatusFound)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "resumed workspace")
+	http.Redirect(w, r, paths.Workspace(workspaceID), http.StatusFound)
+}
+
+func (h *webHandlers) enableDebugLogging(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	// either field may be left blank
+	var opts EnableDebugLoggingOptions
+	for field, dst := range map[string]**int{"hours": &opts.Hours, "runs": &opts.Runs} {
+		if s := r.PostFormValue(field); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				h.Error(w, fmt.Sprintf("invalid %s: %s", field, s), http.StatusUnprocessableEntity)
+				return
+			}
+			*dst = &n
+		}
+	}
+
+	_, err = h.client.EnableDebugLogging(r.Context(), workspaceID, opts)
+	if errors.Is(err, ErrInvalidDebugLogging) {
+		html.FlashError(w, err.Error())
+		http.Redirect(w, r, paths.Workspace(workspaceID), http.StatusFound)
+		return
+	} else if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "enabled debug logging")
+	http.Redirect(w, r, paths.Workspace(workspaceID), http.StatusFound)
+}
+
+func (h *webHandlers) disableDebugLogging(w http.ResponseWriter, r *http.Request) {
+	workspaceID, err := decode.Param("workspace_id", r)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err = h.client.DisableDebugLogging(r.Context(), workspaceID)
+	if err != nil {
+		h.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	html.FlashSuccess(w, "disabled debug logging")
+	http.Redirect(w, r, paths.Workspace(workspaceID), http.StatusFound)
+}
+
 func (h *webHandlers) listWorkspaceVCSProviders(w http.ResponseWriter, r *http.Request) {
 	workspaceID, err := decode.Param("workspace_id", r)
 	if err != nil {
@@ -7,11 +7,13 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/antchfx/htmlquery"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/connections"
 	"github.com/tofutf/tofutf/internal/http/html/paths"
 	"github.com/tofutf/tofutf/internal/rbac"
 	"github.com/tofutf/tofutf/internal/team"
@@ -82,6 +84,68 @@ func TestGetWorkspaceHandler(t *testing.T) {
 	}
 }
 
+func TestGetWorkspaceHandler_RepoMoves(t *testing.T) {
+	app := &webHandlers{
+		Renderer: testutils.NewRenderer(t),
+		client: &FakeService{
+			Workspaces: []*Workspace{{ID: "ws-123"}},
+			RepoMoves: []*connections.RepoMove{
+				{
+					From:       "leg100/otf-workspaces",
+					To:         "tofutf/otf-workspaces",
+					DetectedBy: connections.MoveDetectedByWebhook,
+					MovedAt:    time.Now(),
+				},
+			},
+		},
+	}
+
+	r := httptest.NewRequest("GET", "/?workspace_id=ws-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &user.User{ID: "janitor"}))
+	w := httptest.NewRecorder()
+	app.getWorkspace(w, r)
+	require.Equal(t, 200, w.Code, w.Body.String())
+
+	doc, err := htmlquery.Parse(w.Body)
+	require.NoError(t, err)
+	moves := htmlquery.Find(doc, "//ul[@id='repo-moves']/li")
+	require.Len(t, moves, 1)
+	got := htmlquery.InnerText(moves[0])
+	assert.Contains(t, got, "leg100/otf-workspaces")
+	assert.Contains(t, got, "tofutf/otf-workspaces")
+}
+
+func TestGetWorkspaceHandler_Events(t *testing.T) {
+	app := &webHandlers{
+		Renderer: testutils.NewRenderer(t),
+		client: &FakeService{
+			Workspaces: []*Workspace{{ID: "ws-123"}},
+			Events: []*Event{
+				{
+					WorkspaceID: "ws-123",
+					Kind:        DeletionProtectionClearedEvent,
+					Subject:     "bobby",
+					CreatedAt:   time.Now(),
+				},
+			},
+		},
+	}
+
+	r := httptest.NewRequest("GET", "/?workspace_id=ws-123", nil)
+	r = r.WithContext(internal.AddSubjectToContext(r.Context(), &user.User{ID: "janitor"}))
+	w := httptest.NewRecorder()
+	app.getWorkspace(w, r)
+	require.Equal(t, 200, w.Code, w.Body.String())
+
+	doc, err := htmlquery.Parse(w.Body)
+	require.NoError(t, err)
+	events := htmlquery.Find(doc, "//ul[@id='workspace-events']/li")
+	require.Len(t, events, 1)
+	got := htmlquery.InnerText(events[0])
+	assert.Contains(t, got, "Deletion protection cleared")
+	assert.Contains(t, got, "bobby")
+}
+
 func TestWorkspace_GetByName(t *testing.T) {
 	ws := &Workspace{ID: "ws-123"}
 	app := &webHandlers{
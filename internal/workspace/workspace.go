@@ -34,30 +34,42 @@ var (
 type (
 	// Workspace is a terraform workspace.
 	Workspace struct {
-		ID                         string        `jsonapi:"primary,workspaces"`
-		CreatedAt                  time.Time     `jsonapi:"attribute" json:"created_at"`
-		UpdatedAt                  time.Time     `jsonapi:"attribute" json:"updated_at"`
-		AgentPoolID                *string       `jsonapi:"attribute" json:"agent-pool-id"`
-		AllowDestroyPlan           bool          `jsonapi:"attribute" json:"allow_destroy_plan"`
-		AutoApply                  bool          `jsonapi:"attribute" json:"auto_apply"`
-		CanQueueDestroyPlan        bool          `jsonapi:"attribute" json:"can_queue_destroy_plan"`
-		Description                string        `jsonapi:"attribute" json:"description"`
-		Environment                string        `jsonapi:"attribute" json:"environment"`
-		ExecutionMode              ExecutionMode `jsonapi:"attribute" json:"execution_mode"`
-		GlobalRemoteState          bool          `jsonapi:"attribute" json:"global_remote_state"`
-		MigrationEnvironment       string        `jsonapi:"attribute" json:"migration_environment"`
-		Name                       string        `jsonapi:"attribute" json:"name"`
-		QueueAllRuns               bool          `jsonapi:"attribute" json:"queue_all_runs"`
-		SpeculativeEnabled         bool          `jsonapi:"attribute" json:"speculative_enabled"`
-		StructuredRunOutputEnabled bool          `jsonapi:"attribute" json:"structured_run_output_enabled"`
-		SourceName                 string        `jsonapi:"attribute" json:"source_name"`
-		SourceURL                  string        `jsonapi:"attribute" json:"source_url"`
-		TerraformVersion           string        `jsonapi:"attribute" json:"terraform_version"`
-		WorkingDirectory           string        `jsonapi:"attribute" json:"working_directory"`
-		Organization               string        `jsonapi:"attribute" json:"organization"`
-		LatestRun                  *LatestRun    `jsonapi:"attribute" json:"latest_run"`
-		Tags                       []string      `jsonapi:"attribute" json:"tags"`
-		Lock                       *Lock         `jsonapi:"attribute" json:"lock"`
+		ID          string    `jsonapi:"primary,workspaces"`
+		CreatedAt   time.Time `jsonapi:"attribute" json:"created_at"`
+		UpdatedAt   time.Time `jsonapi:"attribute" json:"updated_at"`
+		AgentPoolID *string   `jsonapi:"attribute" json:"agent-pool-id"`
+		// PlanAgentPoolID and ApplyAgentPoolID override AgentPoolID for the
+		// jobs of the plan and apply phases respectively. Nil uses
+		// AgentPoolID.
+		PlanAgentPoolID            *string         `jsonapi:"attribute" json:"plan-agent-pool-id"`
+		ApplyAgentPoolID           *string         `jsonapi:"attribute" json:"apply-agent-pool-id"`
+		AllowDestroyPlan           bool            `jsonapi:"attribute" json:"allow_destroy_plan"`
+		ApplyWindows               ApplyWindows    `jsonapi:"attribute" json:"apply_windows"`
+		AutoApply                  bool            `jsonapi:"attribute" json:"auto_apply"`
+		CanQueueDestroyPlan        bool            `jsonapi:"attribute" json:"can_queue_destroy_plan"`
+		DeletionProtected          bool            `jsonapi:"attribute" json:"deletion_protected"`
+		Description                string          `jsonapi:"attribute" json:"description"`
+		Environment                string          `jsonapi:"attribute" json:"environment"`
+		ExecutionMode              ExecutionMode   `jsonapi:"attribute" json:"execution_mode"`
+		GlobalRemoteState          bool            `jsonapi:"attribute" json:"global_remote_state"`
+		LogScrubbingDisabled       bool            `jsonapi:"attribute" json:"log_scrubbing_disabled"`
+		MigrationEnvironment       string          `jsonapi:"attribute" json:"migration_environment"`
+		Name                       string          `jsonapi:"attribute" json:"name"`
+		PreflightChecks            PreflightChecks `jsonapi:"attribute" json:"preflight_checks"`
+		QueueAllRuns               bool            `jsonapi:"attribute" json:"queue_all_runs"`
+		SpeculativeEnabled         bool            `jsonapi:"attribute" json:"speculative_enabled"`
+		StructuredRunOutputEnabled bool            `jsonapi:"attribute" json:"structured_run_output_enabled"`
+		SourceName                 string          `jsonapi:"attribute" json:"source_name"`
+		SourceURL                  string          `jsonapi:"attribute" json:"source_url"`
+		TerraformVersion           string          `jsonapi:"attribute" json:"terraform_version"`
+		WorkingDirectory           string          `jsonapi:"attribute" json:"working_directory"`
+		Organization               string          `jsonapi:"attribute" json:"organization"`
+		LatestRun                  *LatestRun      `jsonapi:"attribute" json:"latest_run"`
+		Tags                       []string        `jsonapi:"attribute" json:"tags"`
+		Lock                       *Lock           `jsonapi:"attribute" json:"lock"`
+		Paused                     bool            `jsonapi:"attribute" json:"paused"`
+		PausedAt                   *time.Time      `jsonapi:"attribute" json:"paused_at"`
+		DebugLogging               *DebugLogging   `jsonapi:"attribute" json:"debug_logging"`
 
 		// VCS Connection; nil means the workspace is not connected.
 		Connection *Connection
@@ -90,6 +102,9 @@ type (
 		// possible to run a terraform apply via the CLI. Setting this to true
 		// overrides this behaviour.
 		AllowCLIApply bool
+
+		// Skip runs for draft pull requests.
+		SkipDrafts bool
 	}
 
 	ConnectOptions struct {
@@ -99,6 +114,7 @@ type (
 		Branch        *string
 		TagsRegex     *string
 		AllowCLIApply *bool
+		SkipDrafts    *bool
 	}
 
 	ExecutionMode string
@@ -106,13 +122,19 @@ type (
 	// CreateOptions represents the options for creating a new workspace.
 	CreateOptions struct {
 		AgentPoolID                *string
+		PlanAgentPoolID            *string
+		ApplyAgentPoolID           *string
 		AllowDestroyPlan           *bool
+		ApplyWindows               ApplyWindows
 		AutoApply                  *bool
+		DeletionProtected          *bool
 		Description                *string
 		ExecutionMode              *ExecutionMode
 		GlobalRemoteState          *bool
+		LogScrubbingDisabled       *bool
 		MigrationEnvironment       *string
 		Name                       *string
+		PreflightChecks            PreflightChecks
 		QueueAllRuns               *bool
 		SpeculativeEnabled         *bool
 		SourceName                 *string
@@ -133,14 +155,23 @@ type (
 	}
 
 	UpdateOptions struct {
-		AgentPoolID                *string `json:"agent-pool-id,omitempty"`
+		AgentPoolID *string `json:"agent-pool-id,omitempty"`
+		// PlanAgentPoolID and ApplyAgentPoolID override the agent pool for
+		// the plan and apply phases respectively. An empty string removes
+		// the override.
+		PlanAgentPoolID            *string `json:"plan-agent-pool-id,omitempty"`
+		ApplyAgentPoolID           *string `json:"apply-agent-pool-id,omitempty"`
 		AllowDestroyPlan           *bool
+		ApplyWindows               ApplyWindows
 		AutoApply                  *bool
+		DeletionProtected          *bool
 		Name                       *string
 		Description                *string
 		ExecutionMode              *ExecutionMode `json:"execution-mode,omitempty"`
 		GlobalRemoteState          *bool
+		LogScrubbingDisabled       *bool
 		Operations                 *bool
+		PreflightChecks            PreflightChecks
 		QueueAllRuns               *bool
 		SpeculativeEnabled         *bool
 		StructuredRunOutputEnabled *bool
@@ -202,18 +233,37 @@ func NewWorkspace(opts CreateOptions) (*Workspace, error) {
 	if _, err := ws.setExecutionModeAndAgentPoolID(opts.ExecutionMode, opts.AgentPoolID); err != nil {
 		return nil, err
 	}
+	if _, err := ws.setPhaseAgentPoolIDs(opts.PlanAgentPoolID, opts.ApplyAgentPoolID); err != nil {
+		return nil, err
+	}
 	if opts.AllowDestroyPlan != nil {
 		ws.AllowDestroyPlan = *opts.AllowDestroyPlan
 	}
+	if opts.ApplyWindows != nil {
+		if err := ws.setApplyWindows(opts.ApplyWindows); err != nil {
+			return nil, err
+		}
+	}
+	if opts.PreflightChecks != nil {
+		if err := ws.setPreflightChecks(opts.PreflightChecks); err != nil {
+			return nil, err
+		}
+	}
 	if opts.AutoApply != nil {
 		ws.AutoApply = *opts.AutoApply
 	}
+	if opts.DeletionProtected != nil {
+		ws.DeletionProtected = *opts.DeletionProtected
+	}
 	if opts.Description != nil {
 		ws.Description = *opts.Description
 	}
 	if opts.GlobalRemoteState != nil {
 		ws.GlobalRemoteState = *opts.GlobalRemoteState
 	}
+	if opts.LogScrubbingDisabled != nil {
+		ws.LogScrubbingDisabled = *opts.LogScrubbingDisabled
+	}
 	if opts.QueueAllRuns != nil {
 		ws.QueueAllRuns = *opts.QueueAllRuns
 	}
@@ -305,10 +355,26 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 		ws.AllowDestroyPlan = *opts.AllowDestroyPlan
 		updated = true
 	}
+	if opts.ApplyWindows != nil {
+		if err := ws.setApplyWindows(opts.ApplyWindows); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+	if opts.PreflightChecks != nil {
+		if err := ws.setPreflightChecks(opts.PreflightChecks); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
 	if opts.AutoApply != nil {
 		ws.AutoApply = *opts.AutoApply
 		updated = true
 	}
+	if opts.DeletionProtected != nil {
+		ws.DeletionProtected = *opts.DeletionProtected
+		updated = true
+	}
 	if opts.Description != nil {
 		ws.Description = *opts.Description
 		updated = true
@@ -318,6 +384,11 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 	} else if changed {
 		updated = true
 	}
+	if changed, err := ws.setPhaseAgentPoolIDs(opts.PlanAgentPoolID, opts.ApplyAgentPoolID); err != nil {
+		return nil, err
+	} else if changed {
+		updated = true
+	}
 	if opts.Operations != nil {
 		if *opts.Operations {
 			ws.ExecutionMode = "remote"
@@ -330,6 +401,10 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 		ws.GlobalRemoteState = *opts.GlobalRemoteState
 		updated = true
 	}
+	if opts.LogScrubbingDisabled != nil {
+		ws.LogScrubbingDisabled = *opts.LogScrubbingDisabled
+		updated = true
+	}
 	if opts.QueueAllRuns != nil {
 		ws.QueueAllRuns = *opts.QueueAllRuns
 		updated = true
@@ -425,6 +500,10 @@ func (ws *Workspace) Update(opts UpdateOptions) (*bool, error) {
 				ws.Connection.AllowCLIApply = *opts.AllowCLIApply
 				updated = true
 			}
+			if opts.SkipDrafts != nil {
+				ws.Connection.SkipDrafts = *opts.SkipDrafts
+				updated = true
+			}
 		}
 	}
 	if updated {
@@ -448,6 +527,9 @@ func (ws *Workspace) addConnection(opts *ConnectOptions) error {
 	if opts.AllowCLIApply != nil {
 		ws.Connection.AllowCLIApply = *opts.AllowCLIApply
 	}
+	if opts.SkipDrafts != nil {
+		ws.Connection.SkipDrafts = *opts.SkipDrafts
+	}
 	if opts.TagsRegex != nil && *opts.TagsRegex != "" {
 		if err := ws.setTagsRegex(*opts.TagsRegex); err != nil {
 			return fmt.Errorf("invalid tags-regex: %w", err)
@@ -499,15 +581,101 @@ func (ws *Workspace) setExecutionModeAndAgentPoolID(m *ExecutionMode, agentPoolI
 	if m != nil {
 		ws.ExecutionMode = *m
 	}
+	if ws.ExecutionMode != AgentExecutionMode {
+		// phase pools only apply to agent execution mode
+		ws.PlanAgentPoolID = nil
+		ws.ApplyAgentPoolID = nil
+	}
 	return true, nil
 }
 
+// setPhaseAgentPoolIDs sets the agent pools overriding the workspace's agent
+// pool for the plan and apply phases. A nil ID leaves the override unchanged
+// whereas an empty ID removes it.
+func (ws *Workspace) setPhaseAgentPoolIDs(plan, apply *string) (bool, error) {
+	if plan == nil && apply == nil {
+		return false, nil
+	}
+	set := func(dst **string, id *string) error {
+		if id == nil {
+			return nil
+		}
+		if *id == "" {
+			*dst = nil
+			return nil
+		}
+		if ws.ExecutionMode != AgentExecutionMode {
+			return ErrNonAgentExecutionModeWithPool
+		}
+		*dst = internal.String(*id)
+		return nil
+	}
+	if err := set(&ws.PlanAgentPoolID, plan); err != nil {
+		return false, err
+	}
+	if err := set(&ws.ApplyAgentPoolID, apply); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AgentPoolIDForPhase returns the ID of the agent pool to which the
+// workspace's jobs for the given phase are assigned: the phase's pool if one
+// is set, otherwise the workspace's agent pool. Nil means the jobs are
+// assigned to server agents.
+func (ws *Workspace) AgentPoolIDForPhase(phase internal.PhaseType) *string {
+	switch phase {
+	case internal.PlanPhase:
+		if ws.PlanAgentPoolID != nil {
+			return ws.PlanAgentPoolID
+		}
+	case internal.ApplyPhase:
+		if ws.ApplyAgentPoolID != nil {
+			return ws.ApplyAgentPoolID
+		}
+	}
+	return ws.AgentPoolID
+}
+
+// AgentPoolIDs returns the IDs of the distinct agent pools the workspace's
+// jobs may be assigned to.
+func (ws *Workspace) AgentPoolIDs() []string {
+	var ids []string
+	for _, id := range []*string{ws.AgentPoolID, ws.PlanAgentPoolID, ws.ApplyAgentPoolID} {
+		if id != nil && !slices.Contains(ids, *id) {
+			ids = append(ids, *id)
+		}
+	}
+	return ids
+}
+
+func (ws *Workspace) setApplyWindows(windows ApplyWindows) error {
+	if err := windows.Validate(); err != nil {
+		return err
+	}
+	if len(windows) == 0 {
+		windows = nil
+	}
+	ws.ApplyWindows = windows
+	return nil
+}
+
 func (ws *Workspace) setTerraformVersion(v string) error {
 	if v == releases.LatestVersionString {
 		ws.TerraformVersion = v
 		return nil
 	}
 	if !semver.IsValid(v) {
+		// accept a constraint, e.g. "~> 1.6", which is resolved to a concrete
+		// version when a run is created, providing it cannot resolve to a
+		// version below the minimum requirement.
+		if semver.IsConstraint(v) {
+			if semver.AllowsBelow(v, MinTerraformVersion) {
+				return ErrUnsupportedTerraformVersion
+			}
+			ws.TerraformVersion = v
+			return nil
+		}
 		return internal.ErrInvalidTerraformVersion
 	}
 	// only accept terraform versions above the minimum requirement.
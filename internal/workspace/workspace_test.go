@@ -51,6 +51,14 @@ func TestNewWorkspace(t *testing.T) {
 				TerraformVersion: internal.String("latest"),
 			},
 		},
+		{
+			name: "terraform version constraint",
+			opts: CreateOptions{
+				Name:             internal.String("my-workspace"),
+				Organization:     internal.String("my-org"),
+				TerraformVersion: internal.String("~> 1.6"),
+			},
+		},
 		{
 			name: "bad terraform version",
 			opts: CreateOptions{
@@ -69,6 +77,15 @@ func TestNewWorkspace(t *testing.T) {
 			},
 			want: ErrUnsupportedTerraformVersion,
 		},
+		{
+			name: "terraform version constraint allowing unsupported versions",
+			opts: CreateOptions{
+				Name:             internal.String("my-workspace"),
+				Organization:     internal.String("my-org"),
+				TerraformVersion: internal.String("~> 0.12"),
+			},
+			want: ErrUnsupportedTerraformVersion,
+		},
 		{
 			name: "specifying both tags regex and trigger patterns",
 			opts: CreateOptions{
@@ -163,6 +180,27 @@ func TestNewWorkspace(t *testing.T) {
 			},
 			want: ErrNonAgentExecutionModeWithPool,
 		},
+		{
+			name: "agent execution mode with plan and apply pools",
+			opts: CreateOptions{
+				Name:             internal.String("my-workspace"),
+				Organization:     internal.String("my-org"),
+				ExecutionMode:    ExecutionModePtr(AgentExecutionMode),
+				AgentPoolID:      internal.String("apool-123"),
+				PlanAgentPoolID:  internal.String("apool-spot"),
+				ApplyAgentPoolID: internal.String("apool-stable"),
+			},
+			want: nil,
+		},
+		{
+			name: "remote execution mode with plan pool",
+			opts: CreateOptions{
+				Name:            internal.String("my-workspace"),
+				Organization:    internal.String("my-org"),
+				PlanAgentPoolID: internal.String("apool-spot"),
+			},
+			want: ErrNonAgentExecutionModeWithPool,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -303,6 +341,14 @@ func TestWorkspace_UpdateError(t *testing.T) {
 			},
 			want: ErrNonAgentExecutionModeWithPool,
 		},
+		{
+			name: "existing remote execution mode with apply pool",
+			ws:   &Workspace{Name: "dev", Organization: "acme", ExecutionMode: RemoteExecutionMode},
+			opts: UpdateOptions{
+				ApplyAgentPoolID: internal.String("apool-stable"),
+			},
+			want: ErrNonAgentExecutionModeWithPool,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -357,6 +403,51 @@ func TestWorkspace_Update(t *testing.T) {
 				assert.Equal(t, "\\d+", got.Connection.TagsRegex)
 			},
 		},
+		{
+			name: "clear deletion protection",
+			ws:   &Workspace{Name: "dev", Organization: "acme", DeletionProtected: true},
+			opts: UpdateOptions{
+				DeletionProtected: internal.Bool(false),
+			},
+			want: func(t *testing.T, got *Workspace) {
+				assert.False(t, got.DeletionProtected)
+			},
+		},
+		{
+			name: "set plan pool and remove apply pool",
+			ws: &Workspace{
+				Name:             "dev",
+				Organization:     "acme",
+				ExecutionMode:    AgentExecutionMode,
+				AgentPoolID:      internal.String("apool-123"),
+				ApplyAgentPoolID: internal.String("apool-stable"),
+			},
+			opts: UpdateOptions{
+				PlanAgentPoolID:  internal.String("apool-spot"),
+				ApplyAgentPoolID: internal.String(""),
+			},
+			want: func(t *testing.T, got *Workspace) {
+				assert.Equal(t, internal.String("apool-spot"), got.PlanAgentPoolID)
+				assert.Nil(t, got.ApplyAgentPoolID)
+			},
+		},
+		{
+			name: "switching to remote execution mode removes phase pools",
+			ws: &Workspace{
+				Name:            "dev",
+				Organization:    "acme",
+				ExecutionMode:   AgentExecutionMode,
+				AgentPoolID:     internal.String("apool-123"),
+				PlanAgentPoolID: internal.String("apool-spot"),
+			},
+			opts: UpdateOptions{
+				ExecutionMode: ExecutionModePtr(RemoteExecutionMode),
+			},
+			want: func(t *testing.T, got *Workspace) {
+				assert.Nil(t, got.AgentPoolID)
+				assert.Nil(t, got.PlanAgentPoolID)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -367,6 +458,23 @@ func TestWorkspace_Update(t *testing.T) {
 	}
 }
 
+func TestWorkspace_AgentPoolIDForPhase(t *testing.T) {
+	ws := &Workspace{
+		AgentPoolID:      internal.String("apool-123"),
+		PlanAgentPoolID:  internal.String("apool-spot"),
+		ApplyAgentPoolID: internal.String("apool-stable"),
+	}
+	assert.Equal(t, "apool-spot", *ws.AgentPoolIDForPhase(internal.PlanPhase))
+	assert.Equal(t, "apool-stable", *ws.AgentPoolIDForPhase(internal.ApplyPhase))
+	assert.Equal(t, []string{"apool-123", "apool-spot", "apool-stable"}, ws.AgentPoolIDs())
+
+	// without overrides both phases use the workspace's pool
+	ws.PlanAgentPoolID, ws.ApplyAgentPoolID = nil, nil
+	assert.Equal(t, "apool-123", *ws.AgentPoolIDForPhase(internal.PlanPhase))
+	assert.Equal(t, "apool-123", *ws.AgentPoolIDForPhase(internal.ApplyPhase))
+	assert.Equal(t, []string{"apool-123"}, ws.AgentPoolIDs())
+}
+
 func TestWorkspace_UpdateConnection(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,10 @@
+// Package tofutftest provides in-memory fakes of tofutf services, along with
+// an HTTP server exposing the real API handlers backed by the fakes, for use
+// in the tests of programs that integrate with tofutf.
+//
+// The fakes validate their inputs and transition resources between states
+// using the same code as the real services, so a fake returns the same errors
+// as tofutf would. They do not, however, enforce permissions: any subject may
+// carry out any action. IDs are allocated deterministically, in sequence, to
+// permit tests to make assertions about them.
+package tofutftest
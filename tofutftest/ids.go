@@ -0,0 +1,25 @@
+package tofutftest
+
+import (
+	"fmt"
+	"sync"
+)
+
+// IDs allocates deterministic resource IDs. The zero value is ready for use.
+type IDs struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// New returns the next ID for the kind of resource, e.g. the first call with
+// "ws" returns ws-0000000000000001, the second ws-0000000000000002, and so on.
+func (ids *IDs) New(kind string) string {
+	ids.mu.Lock()
+	defer ids.mu.Unlock()
+
+	if ids.counts == nil {
+		ids.counts = make(map[string]int)
+	}
+	ids.counts[kind]++
+	return fmt.Sprintf("%s-%016d", kind, ids.counts[kind])
+}
@@ -0,0 +1,47 @@
+package tofutftest
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/xslog"
+)
+
+var logger = slog.New(&xslog.NoopHandler{})
+
+// listener stands in for the database listener, forwarding changes made to
+// the fakes to the brokers registered for them, which in turn deliver events
+// to subscribers.
+type listener struct {
+	mu    sync.Mutex
+	funcs map[string]sql.ForwardFunc
+}
+
+func newListener() *listener {
+	return &listener{funcs: make(map[string]sql.ForwardFunc)}
+}
+
+func (l *listener) RegisterFunc(table string, ff sql.ForwardFunc) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.funcs[table] = ff
+}
+
+// notify notifies the broker for the table that a row has changed. The caller
+// must not hold any locks that the broker's getter acquires.
+func (l *listener) notify(ctx context.Context, table, id string, action sql.Action) {
+	l.mu.Lock()
+	ff, ok := l.funcs[table]
+	l.mu.Unlock()
+
+	if ok {
+		ff(ctx, id, action)
+	}
+}
+
+// RegisterReconnectFunc is a no-op because the fakes never lose their
+// connection.
+func (l *listener) RegisterReconnectFunc(fn sql.ReconnectFunc) {}
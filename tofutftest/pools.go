@@ -0,0 +1,129 @@
+package tofutftest
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/agent"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/sql"
+)
+
+// AgentPools is an in-memory fake of the agent pool management provided by
+// the agent service. The rest of the agent service, i.e. agents and jobs, is
+// not faked: it is only accessible to tofutf itself.
+//
+// The workspaces assigned to a pool are those in the accompanying Workspaces
+// fake that are configured to use the pool.
+type AgentPools struct {
+	ids        *IDs
+	broker     *pubsub.Broker[*agent.Pool]
+	listener   *listener
+	workspaces *Workspaces
+
+	mu    sync.Mutex
+	pools map[string]*agent.Pool
+}
+
+func newAgentPools(ids *IDs, listener *listener, workspaces *Workspaces) *AgentPools {
+	f := &AgentPools{
+		ids:        ids,
+		listener:   listener,
+		workspaces: workspaces,
+		pools:      make(map[string]*agent.Pool),
+	}
+	f.broker = pubsub.NewBroker(logger, listener, "agent_pools", func(ctx context.Context, id string, action sql.Action) (*agent.Pool, error) {
+		if action == sql.DeleteAction {
+			return &agent.Pool{ID: id}, nil
+		}
+		return f.GetAgentPool(ctx, id)
+	})
+	return f
+}
+
+// WatchAgentPools subscribes to events for agent pools that are created or
+// deleted.
+func (f *AgentPools) WatchAgentPools(ctx context.Context) (<-chan pubsub.Event[*agent.Pool], func()) {
+	return f.broker.Subscribe(ctx)
+}
+
+func (f *AgentPools) CreateAgentPool(ctx context.Context, opts agent.CreateAgentPoolOptions) (*agent.Pool, error) {
+	pool, err := agent.NewPool(opts)
+	if err != nil {
+		return nil, err
+	}
+	pool.ID = f.ids.New("apool")
+	pool.AllowedWorkspaces = slices.Clone(pool.AllowedWorkspaces)
+
+	f.mu.Lock()
+	f.pools[pool.ID] = pool
+	f.mu.Unlock()
+
+	f.listener.notify(ctx, "agent_pools", pool.ID, sql.InsertAction)
+	return f.GetAgentPool(ctx, pool.ID)
+}
+
+func (f *AgentPools) GetAgentPool(ctx context.Context, poolID string) (*agent.Pool, error) {
+	f.mu.Lock()
+	pool, ok := f.pools[poolID]
+	f.mu.Unlock()
+
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.withAssigned(pool), nil
+}
+
+// ListAgentPools lists an organization's agent pools, newest first.
+func (f *AgentPools) ListAgentPools(ctx context.Context, organization string) ([]*agent.Pool, error) {
+	f.mu.Lock()
+	var pools []*agent.Pool
+	for _, pool := range f.pools {
+		if pool.Organization == organization {
+			pools = append(pools, pool)
+		}
+	}
+	f.mu.Unlock()
+
+	for i, pool := range pools {
+		pools[i] = f.withAssigned(pool)
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		if !pools[i].CreatedAt.Equal(pools[j].CreatedAt) {
+			return pools[i].CreatedAt.After(pools[j].CreatedAt)
+		}
+		return pools[i].ID > pools[j].ID
+	})
+	return pools, nil
+}
+
+// DeleteAgentPool deletes an agent pool. As with the real service, a pool
+// cannot be deleted while workspaces are assigned to it.
+func (f *AgentPools) DeleteAgentPool(ctx context.Context, poolID string) (*agent.Pool, error) {
+	pool, err := f.GetAgentPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pool.AssignedWorkspaces) > 0 {
+		return nil, agent.ErrCannotDeletePoolReferencedByWorkspaces
+	}
+
+	f.mu.Lock()
+	delete(f.pools, poolID)
+	f.mu.Unlock()
+
+	f.listener.notify(ctx, "agent_pools", poolID, sql.DeleteAction)
+	return pool, nil
+}
+
+// withAssigned returns a copy of the pool populated with the IDs of the
+// workspaces assigned to it. The caller must not hold the mutex.
+func (f *AgentPools) withAssigned(pool *agent.Pool) *agent.Pool {
+	cp := *pool
+	cp.AllowedWorkspaces = slices.Clone(pool.AllowedWorkspaces)
+	cp.AssignedWorkspaces = f.workspaces.idsByPool(pool.ID)
+	return &cp
+}
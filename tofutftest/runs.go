@@ -0,0 +1,399 @@
+package tofutftest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/configversion"
+	"github.com/tofutf/tofutf/internal/organization"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/resource"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/user"
+)
+
+// Runs is an in-memory fake of the run service. Runs are created for
+// workspaces in the accompanying Workspaces fake.
+//
+// Unlike the real service, the fake doesn't schedule runs nor create jobs for
+// them: it is up to the test to move a run through its phases, using
+// EnqueuePlan, StartPhase, FinishPhase, and so on.
+type Runs struct {
+	ids        *IDs
+	broker     *pubsub.Broker[*run.Run]
+	listener   *listener
+	workspaces *Workspaces
+
+	mu        sync.Mutex
+	runs      map[string]*run.Run
+	planFiles map[string][]byte // keyed by run ID and plan format
+	lockFiles map[string][]byte
+	manifests map[string][]byte
+	artifacts map[string]map[string]*run.Artifact // keyed by run ID and name
+}
+
+var _ run.HandlerClient = (*Runs)(nil)
+
+func newRuns(ids *IDs, listener *listener, workspaces *Workspaces) *Runs {
+	f := &Runs{
+		ids:        ids,
+		listener:   listener,
+		workspaces: workspaces,
+		runs:       make(map[string]*run.Run),
+		planFiles:  make(map[string][]byte),
+		lockFiles:  make(map[string][]byte),
+		manifests:  make(map[string][]byte),
+		artifacts:  make(map[string]map[string]*run.Artifact),
+	}
+	f.broker = pubsub.NewBroker(logger, listener, "runs", func(ctx context.Context, id string, action sql.Action) (*run.Run, error) {
+		if action == sql.DeleteAction {
+			return &run.Run{ID: id}, nil
+		}
+		return f.Get(ctx, id)
+	})
+	return f
+}
+
+// Watch subscribes to events for runs that are created or updated.
+func (f *Runs) Watch(ctx context.Context) (<-chan pubsub.Event[*run.Run], func()) {
+	return f.broker.Subscribe(ctx)
+}
+
+// Create creates a run for the workspace. If no configuration version is
+// specified then one is allocated that is speculative only if the run is
+// plan-only.
+func (f *Runs) Create(ctx context.Context, workspaceID string, opts run.CreateOptions) (*run.Run, error) {
+	ws, err := f.workspaces.Get(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	cv := &configversion.ConfigurationVersion{
+		WorkspaceID: ws.ID,
+		Speculative: opts.PlanOnly != nil && *opts.PlanOnly,
+	}
+	if opts.ConfigurationVersionID != nil {
+		cv.ID = *opts.ConfigurationVersionID
+	} else {
+		cv.ID = f.ids.New("cv")
+	}
+	r, err := run.New(ctx, &organization.Organization{Name: ws.Organization}, cv, ws, opts)
+	if err != nil {
+		return nil, err
+	}
+	r.ID = f.ids.New("run")
+	r.Plan.RunID = r.ID
+	r.Apply.RunID = r.ID
+
+	f.mu.Lock()
+	f.runs[r.ID] = r
+	f.mu.Unlock()
+
+	f.listener.notify(ctx, "runs", r.ID, sql.InsertAction)
+	return copyRun(r), nil
+}
+
+func (f *Runs) Get(ctx context.Context, runID string) (*run.Run, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := f.runs[runID]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return copyRun(r), nil
+}
+
+func (f *Runs) List(ctx context.Context, opts run.ListOptions) (*resource.Page[*run.Run], error) {
+	// look up the workspaces before acquiring the mutex, to filter by
+	// workspace name.
+	var workspaceIDs []string
+	if opts.WorkspaceName != nil {
+		workspaceIDs = f.workspaces.idsByName(*opts.WorkspaceName)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matches []*run.Run
+	for _, r := range f.runs {
+		if opts.WorkspaceID != nil && r.WorkspaceID != *opts.WorkspaceID {
+			continue
+		}
+		if opts.Organization != nil && r.Organization != *opts.Organization {
+			continue
+		}
+		if opts.WorkspaceName != nil && !slices.Contains(workspaceIDs, r.WorkspaceID) {
+			continue
+		}
+		if len(opts.Statuses) > 0 && !slices.Contains(opts.Statuses, r.Status) {
+			continue
+		}
+		if opts.PlanOnly != nil && r.PlanOnly != *opts.PlanOnly {
+			continue
+		}
+		if len(opts.Sources) > 0 && !slices.Contains(opts.Sources, r.Source) {
+			continue
+		}
+		if opts.CommitSHA != nil && (r.IngressAttributes == nil || r.IngressAttributes.CommitSHA != *opts.CommitSHA) {
+			continue
+		}
+		if opts.VCSUsername != nil && (r.IngressAttributes == nil || r.IngressAttributes.SenderUsername != *opts.VCSUsername) {
+			continue
+		}
+		if opts.Label != nil {
+			if v, ok := r.Labels[opts.Label.Key]; !ok || v != opts.Label.Value {
+				continue
+			}
+		}
+		matches = append(matches, copyRun(r))
+	}
+	// newest first, breaking ties using the ID, which is allocated in
+	// sequence.
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].CreatedAt.Equal(matches[j].CreatedAt) {
+			return matches[i].CreatedAt.After(matches[j].CreatedAt)
+		}
+		return matches[i].ID > matches[j].ID
+	})
+	return resource.NewPage(matches, opts.PageOptions, nil), nil
+}
+
+func (f *Runs) EnqueuePlan(ctx context.Context, runID string) (*run.Run, error) {
+	return f.update(ctx, runID, func(r *run.Run) error {
+		return r.EnqueuePlan()
+	})
+}
+
+func (f *Runs) StartPhase(ctx context.Context, runID string, phase internal.PhaseType, _ run.PhaseStartOptions) (*run.Run, error) {
+	return f.update(ctx, runID, func(r *run.Run) error {
+		return r.Start()
+	})
+}
+
+// FinishPhase finishes a phase. If the run is to be applied automatically then
+// an apply is enqueued.
+func (f *Runs) FinishPhase(ctx context.Context, runID string, phase internal.PhaseType, opts run.PhaseFinishOptions) (*run.Run, error) {
+	var autoapply bool
+	r, err := f.update(ctx, runID, func(r *run.Run) (err error) {
+		autoapply, err = r.Finish(phase, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if autoapply {
+		if err := f.Apply(ctx, runID); err != nil {
+			return nil, err
+		}
+		return f.Get(ctx, runID)
+	}
+	return r, nil
+}
+
+// Apply enqueues an apply for the run, or, if its workspace's apply windows
+// are closed, holds the run until the next window opens.
+func (f *Runs) Apply(ctx context.Context, runID string) error {
+	r, err := f.Get(ctx, runID)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workspaces.Get(ctx, r.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	_, err = f.update(ctx, runID, func(r *run.Run) error {
+		if !ws.ApplyWindows.Open(internal.CurrentTimestamp(nil)) {
+			return r.AwaitApplyWindow()
+		}
+		return r.EnqueueApply()
+	})
+	return err
+}
+
+func (f *Runs) Discard(ctx context.Context, runID string) error {
+	_, err := f.update(ctx, runID, func(r *run.Run) error {
+		return r.Discard()
+	})
+	return err
+}
+
+// Cancel cancels the run in the same manner as the real service: the run is
+// canceled immediately if it is yet to start, otherwise it is signaled to
+// cancel.
+func (f *Runs) Cancel(ctx context.Context, runID string) error {
+	subject, _ := internal.SubjectFromContext(ctx)
+	_, isUser := subject.(*user.User)
+
+	_, err := f.update(ctx, runID, func(r *run.Run) error {
+		return r.Cancel(isUser, false)
+	})
+	return err
+}
+
+func (f *Runs) GetPlanFile(ctx context.Context, runID string, format run.PlanFormat) ([]byte, error) {
+	if err := validPlanFormat(format); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.runs[runID]; !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.planFiles[planFileKey(runID, format)], nil
+}
+
+func (f *Runs) UploadPlanFile(ctx context.Context, runID string, plan []byte, format run.PlanFormat) error {
+	if err := validPlanFormat(format); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.runs[runID]; !ok {
+		return internal.ErrResourceNotFound
+	}
+	f.planFiles[planFileKey(runID, format)] = slices.Clone(plan)
+	return nil
+}
+
+func (f *Runs) GetLockFile(ctx context.Context, runID string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.runs[runID]; !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return f.lockFiles[runID], nil
+}
+
+func (f *Runs) UploadLockFile(ctx context.Context, runID string, file []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.runs[runID]; !ok {
+		return internal.ErrResourceNotFound
+	}
+	f.lockFiles[runID] = slices.Clone(file)
+	return nil
+}
+
+func (f *Runs) UploadModuleManifest(ctx context.Context, runID string, manifest []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.runs[runID]; !ok {
+		return internal.ErrResourceNotFound
+	}
+	f.manifests[runID] = slices.Clone(manifest)
+	return nil
+}
+
+// UploadArtifact attaches an artifact to a run, replacing any existing artifact
+// with the same name. The default artifact limits apply.
+func (f *Runs) UploadArtifact(ctx context.Context, runID, name, contentType string, data []byte) (*run.Artifact, error) {
+	artifact, err := run.NewArtifact(runID, name, contentType, slices.Clone(data))
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.runs[runID]; !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	existing := f.artifacts[runID]
+	if existing == nil {
+		existing = make(map[string]*run.Artifact)
+		f.artifacts[runID] = existing
+	}
+	if _, replacing := existing[name]; !replacing && len(existing) >= run.DefaultMaxArtifacts {
+		return nil, fmt.Errorf("%w: %d", run.ErrTooManyArtifacts, run.DefaultMaxArtifacts)
+	}
+	artifact.ID = f.ids.New("rart")
+	existing[name] = artifact
+	return artifact, nil
+}
+
+// ListArtifacts lists a run's artifacts, ordered by name. The data of the
+// artifacts is omitted.
+func (f *Runs) ListArtifacts(ctx context.Context, runID string) ([]*run.Artifact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.runs[runID]; !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	artifacts := make([]*run.Artifact, 0, len(f.artifacts[runID]))
+	for _, artifact := range f.artifacts[runID] {
+		cp := *artifact
+		cp.Data = nil
+		artifacts = append(artifacts, &cp)
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts, nil
+}
+
+func (f *Runs) GetArtifact(ctx context.Context, runID, name string) (*run.Artifact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	artifact, ok := f.artifacts[runID][name]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	cp := *artifact
+	return &cp, nil
+}
+
+// update applies fn to a copy of the run, and, if it succeeds, replaces the
+// run with the copy.
+func (f *Runs) update(ctx context.Context, runID string, fn func(*run.Run) error) (*run.Run, error) {
+	f.mu.Lock()
+	r, ok := f.runs[runID]
+	if !ok {
+		f.mu.Unlock()
+		return nil, internal.ErrResourceNotFound
+	}
+	updated := copyRun(r)
+	if err := fn(updated); err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.runs[runID] = updated
+	f.mu.Unlock()
+
+	f.listener.notify(ctx, "runs", runID, sql.UpdateAction)
+	return copyRun(updated), nil
+}
+
+func validPlanFormat(format run.PlanFormat) error {
+	switch format {
+	case run.PlanFormatBinary, run.PlanFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown plan format: %s", string(format))
+	}
+}
+
+func planFileKey(runID string, format run.PlanFormat) string {
+	return fmt.Sprintf("%s.%s", runID, format)
+}
+
+func copyRun(r *run.Run) *run.Run {
+	cp := *r
+	cp.StatusTimestamps = slices.Clone(r.StatusTimestamps)
+	cp.Plan.StatusTimestamps = slices.Clone(r.Plan.StatusTimestamps)
+	cp.Apply.StatusTimestamps = slices.Clone(r.Apply.StatusTimestamps)
+	cp.Labels = maps.Clone(r.Labels)
+	return &cp
+}
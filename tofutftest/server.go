@@ -0,0 +1,63 @@
+package tofutftest
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/tfeapi"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// Fakes is a set of fakes sharing the same IDs and events.
+type Fakes struct {
+	IDs        *IDs
+	Workspaces *Workspaces
+	Runs       *Runs
+	AgentPools *AgentPools
+	Tokens     *Tokens
+}
+
+// New constructs a set of fakes.
+func New() (*Fakes, error) {
+	var (
+		ids      = &IDs{}
+		listener = newListener()
+	)
+	tokens, err := newTokens(ids)
+	if err != nil {
+		return nil, err
+	}
+	workspaces := newWorkspaces(ids, listener)
+	return &Fakes{
+		IDs:        ids,
+		Workspaces: workspaces,
+		Runs:       newRuns(ids, listener, workspaces),
+		AgentPools: newAgentPools(ids, listener, workspaces),
+		Tokens:     tokens,
+	}, nil
+}
+
+// NewServer constructs a set of fakes and starts an HTTP server serving the
+// real workspace and run API handlers, backed by the fakes. Requests must be
+// authenticated using a token issued by the Tokens fake. The server is closed
+// when the test completes.
+func NewServer(t testing.TB) (*httptest.Server, *Fakes) {
+	t.Helper()
+
+	fakes, err := New()
+	if err != nil {
+		t.Fatalf("constructing fakes: %s", err)
+	}
+	responder := tfeapi.NewResponder()
+
+	r := mux.NewRouter()
+	r.Use(fakes.Tokens.Middleware())
+	workspace.NewHandlers(fakes.Workspaces, responder).AddHandlers(r)
+	run.NewHandlers(fakes.Runs, responder, logger).AddHandlers(r)
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, fakes
+}
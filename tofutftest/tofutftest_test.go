@@ -0,0 +1,159 @@
+package tofutftest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/run"
+	"github.com/tofutf/tofutf/internal/user"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+func TestIDs(t *testing.T) {
+	var ids IDs
+
+	assert.Equal(t, "ws-0000000000000001", ids.New("ws"))
+	assert.Equal(t, "ws-0000000000000002", ids.New("ws"))
+	assert.Equal(t, "run-0000000000000001", ids.New("run"))
+}
+
+func TestWorkspaces(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create", func(t *testing.T) {
+		fakes, err := New()
+		require.NoError(t, err)
+		sub, _ := fakes.Workspaces.Watch(ctx)
+
+		ws, err := fakes.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:         internal.String("dev"),
+			Organization: internal.String("acme"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ws-0000000000000001", ws.ID)
+
+		event := <-sub
+		assert.Equal(t, pubsub.CreatedEvent, event.Type)
+		assert.Equal(t, ws.ID, event.Payload.ID)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		fakes, err := New()
+		require.NoError(t, err)
+
+		_, err = fakes.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:         internal.String("$dev"),
+			Organization: internal.String("acme"),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		fakes, err := New()
+		require.NoError(t, err)
+		opts := workspace.CreateOptions{
+			Name:         internal.String("dev"),
+			Organization: internal.String("acme"),
+		}
+
+		_, err = fakes.Workspaces.Create(ctx, opts)
+		require.NoError(t, err)
+		_, err = fakes.Workspaces.Create(ctx, opts)
+		assert.ErrorIs(t, err, internal.ErrResourceAlreadyExists)
+	})
+
+	t.Run("lock", func(t *testing.T) {
+		fakes, err := New()
+		require.NoError(t, err)
+		ws, err := fakes.Workspaces.Create(ctx, workspace.CreateOptions{
+			Name:         internal.String("dev"),
+			Organization: internal.String("acme"),
+		})
+		require.NoError(t, err)
+		userCtx := internal.AddSubjectToContext(ctx, user.NewUser("bob"))
+
+		ws, err = fakes.Workspaces.Lock(userCtx, ws.ID, nil)
+		require.NoError(t, err)
+		assert.True(t, ws.Locked())
+
+		_, err = fakes.Workspaces.Lock(userCtx, ws.ID, nil)
+		assert.ErrorIs(t, err, workspace.ErrWorkspaceAlreadyLocked)
+	})
+}
+
+func TestRuns(t *testing.T) {
+	ctx := context.Background()
+	fakes, err := New()
+	require.NoError(t, err)
+	ws, err := fakes.Workspaces.Create(ctx, workspace.CreateOptions{
+		Name:         internal.String("dev"),
+		Organization: internal.String("acme"),
+	})
+	require.NoError(t, err)
+
+	r, err := fakes.Runs.Create(ctx, ws.ID, run.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "run-0000000000000001", r.ID)
+	assert.Equal(t, r.ID, r.Plan.RunID)
+
+	// a run cannot be started before it has been enqueued
+	_, err = fakes.Runs.StartPhase(ctx, r.ID, internal.PlanPhase, run.PhaseStartOptions{})
+	assert.ErrorIs(t, err, run.ErrInvalidRunStateTransition)
+
+	_, err = fakes.Runs.EnqueuePlan(ctx, r.ID)
+	require.NoError(t, err)
+	_, err = fakes.Runs.StartPhase(ctx, r.ID, internal.PlanPhase, run.PhaseStartOptions{})
+	require.NoError(t, err)
+	r, err = fakes.Runs.FinishPhase(ctx, r.ID, internal.PlanPhase, run.PhaseFinishOptions{})
+	require.NoError(t, err)
+	// a plan without changes finishes the run
+	assert.Equal(t, run.RunPlannedAndFinished, r.Status)
+
+	t.Run("invalid artifact", func(t *testing.T) {
+		_, err := fakes.Runs.UploadArtifact(ctx, r.ID, ".sbom", "", []byte("data"))
+		assert.ErrorIs(t, err, run.ErrInvalidArtifactName)
+	})
+}
+
+func TestServer(t *testing.T) {
+	srv, fakes := NewServer(t)
+	ws, err := fakes.Workspaces.Create(context.Background(), workspace.CreateOptions{
+		Name:         internal.String("dev"),
+		Organization: internal.String("acme"),
+	})
+	require.NoError(t, err)
+
+	t.Run("authenticated", func(t *testing.T) {
+		token, err := fakes.Tokens.NewToken(user.NewUser("bob"))
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("GET", srv.URL+"/api/v2/workspaces/"+ws.ID, nil)
+		require.NoError(t, err)
+		req.Header.Add("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var got struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		assert.Equal(t, ws.ID, got.Data.ID)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/api/v2/workspaces/" + ws.ID)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
@@ -0,0 +1,71 @@
+package tofutftest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/tokens"
+)
+
+// tokenKind is the kind of token issued by the Tokens fake.
+const tokenKind tokens.Kind = "tofutftest"
+
+// secret is the fixed secret with which the Tokens fake signs tokens.
+var secret = []byte("tofutftest-16-by")
+
+// Tokens issues authentication tokens for subjects, using the real token
+// service, and provides the real authentication middleware to verify them.
+type Tokens struct {
+	ids *IDs
+	svc *tokens.Service
+
+	mu       sync.Mutex
+	subjects map[string]internal.Subject // keyed by token ID
+}
+
+func newTokens(ids *IDs) (*Tokens, error) {
+	svc, err := tokens.NewService(tokens.Options{Logger: logger, Secret: secret})
+	if err != nil {
+		return nil, err
+	}
+	f := &Tokens{
+		ids:      ids,
+		svc:      svc,
+		subjects: make(map[string]internal.Subject),
+	}
+	svc.RegisterKind(tokenKind, func(ctx context.Context, tokenID string) (internal.Subject, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		subject, ok := f.subjects[tokenID]
+		if !ok {
+			return nil, internal.ErrResourceNotFound
+		}
+		return subject, nil
+	})
+	return f, nil
+}
+
+// NewToken issues a token that authenticates requests as the subject.
+func (f *Tokens) NewToken(subject internal.Subject) (string, error) {
+	id := f.ids.New("at")
+	token, err := f.svc.NewToken(tokens.NewTokenOptions{
+		Kind:    tokenKind,
+		Subject: id,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	f.subjects[id] = subject
+	f.mu.Unlock()
+
+	return string(token), nil
+}
+
+// Middleware returns the authentication middleware, which adds the subject
+// of a request's bearer token to the request context.
+func (f *Tokens) Middleware() mux.MiddlewareFunc { return f.svc.Middleware() }
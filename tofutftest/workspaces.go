@@ -0,0 +1,500 @@
+package tofutftest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tofutf/tofutf/internal"
+	"github.com/tofutf/tofutf/internal/pubsub"
+	"github.com/tofutf/tofutf/internal/releases"
+	"github.com/tofutf/tofutf/internal/resource"
+	"github.com/tofutf/tofutf/internal/semver"
+	"github.com/tofutf/tofutf/internal/sql"
+	"github.com/tofutf/tofutf/internal/user"
+	"github.com/tofutf/tofutf/internal/workspace"
+)
+
+// Workspaces is an in-memory fake of the workspace service.
+type Workspaces struct {
+	ids      *IDs
+	broker   *pubsub.Broker[*workspace.Workspace]
+	listener *listener
+
+	mu         sync.Mutex
+	workspaces map[string]*workspace.Workspace
+	tags       map[string]*workspace.Tag // keyed by tag ID
+	// seq records the order in which workspaces were last updated, in lieu
+	// of the database ordering workspaces by their update time.
+	seq     map[string]int
+	lastSeq int
+}
+
+var _ workspace.HandlerClient = (*Workspaces)(nil)
+
+func newWorkspaces(ids *IDs, listener *listener) *Workspaces {
+	f := &Workspaces{
+		ids:        ids,
+		listener:   listener,
+		workspaces: make(map[string]*workspace.Workspace),
+		tags:       make(map[string]*workspace.Tag),
+		seq:        make(map[string]int),
+	}
+	f.broker = pubsub.NewBroker(logger, listener, "workspaces", func(ctx context.Context, id string, action sql.Action) (*workspace.Workspace, error) {
+		if action == sql.DeleteAction {
+			return &workspace.Workspace{ID: id}, nil
+		}
+		return f.Get(ctx, id)
+	})
+	return f
+}
+
+// Watch subscribes to events for workspaces that are created, updated, or
+// deleted.
+func (f *Workspaces) Watch(ctx context.Context) (<-chan pubsub.Event[*workspace.Workspace], func()) {
+	return f.broker.Subscribe(ctx)
+}
+
+func (f *Workspaces) Create(ctx context.Context, opts workspace.CreateOptions) (*workspace.Workspace, error) {
+	if opts.TerraformVersion == nil {
+		opts.TerraformVersion = internal.String(releases.DefaultTerraformVersion)
+	}
+	ws, err := workspace.NewWorkspace(opts)
+	if err != nil {
+		return nil, err
+	}
+	ws.ID = f.ids.New("ws")
+
+	f.mu.Lock()
+	for _, existing := range f.workspaces {
+		if existing.Organization == ws.Organization && existing.Name == ws.Name {
+			f.mu.Unlock()
+			return nil, internal.ErrResourceAlreadyExists
+		}
+	}
+	f.put(ws)
+	f.mu.Unlock()
+
+	f.listener.notify(ctx, "workspaces", ws.ID, sql.InsertAction)
+	return copyWorkspace(ws), nil
+}
+
+func (f *Workspaces) Get(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ws, ok := f.workspaces[workspaceID]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	return copyWorkspace(ws), nil
+}
+
+func (f *Workspaces) GetByName(ctx context.Context, organization, name string) (*workspace.Workspace, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, ws := range f.workspaces {
+		if ws.Organization == organization && ws.Name == name {
+			return copyWorkspace(ws), nil
+		}
+	}
+	return nil, internal.ErrResourceNotFound
+}
+
+// GetPolicy retrieves the workspace's policy. The fake does not record
+// permissions, so the policy only ever grants access via organization-wide
+// roles.
+func (f *Workspaces) GetPolicy(ctx context.Context, workspaceID string) (internal.WorkspacePolicy, error) {
+	ws, err := f.Get(ctx, workspaceID)
+	if err != nil {
+		return internal.WorkspacePolicy{}, err
+	}
+	return internal.WorkspacePolicy{
+		Organization:      ws.Organization,
+		WorkspaceID:       ws.ID,
+		GlobalRemoteState: ws.GlobalRemoteState,
+	}, nil
+}
+
+// GetTerraformVersion retrieves the terraform version the workspace would use.
+// The fake cannot consult the releases feed, so "latest" and version
+// constraints resolve to the default terraform version.
+func (f *Workspaces) GetTerraformVersion(ctx context.Context, workspaceID string) (*workspace.ResolvedTerraformVersion, error) {
+	ws, err := f.Get(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	resolved := &workspace.ResolvedTerraformVersion{
+		ID:        ws.ID,
+		Requested: ws.TerraformVersion,
+		Version:   releases.DefaultTerraformVersion,
+	}
+	switch {
+	case ws.TerraformVersion == "":
+		resolved.Source = workspace.TerraformVersionSourceDefault
+	case ws.TerraformVersion == releases.LatestVersionString:
+		resolved.Source = workspace.TerraformVersionSourceLatest
+	case !semver.IsValid(ws.TerraformVersion) && semver.IsConstraint(ws.TerraformVersion):
+		resolved.Source = workspace.TerraformVersionSourceConstraint
+	default:
+		resolved.Source = workspace.TerraformVersionSourceWorkspace
+		resolved.Version = ws.TerraformVersion
+	}
+	return resolved, nil
+}
+
+func (f *Workspaces) List(ctx context.Context, opts workspace.ListOptions) (*resource.Page[*workspace.Workspace], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var matches []*workspace.Workspace
+	for _, ws := range f.workspaces {
+		if opts.Organization != nil && ws.Organization != *opts.Organization {
+			continue
+		}
+		if !strings.Contains(ws.Name, opts.Search) {
+			continue
+		}
+		if !containsAll(ws.Tags, opts.Tags) {
+			continue
+		}
+		matches = append(matches, copyWorkspace(ws))
+	}
+	// most recently updated first
+	sort.Slice(matches, func(i, j int) bool {
+		return f.seq[matches[i].ID] > f.seq[matches[j].ID]
+	})
+	return resource.NewPage(matches, opts.PageOptions, nil), nil
+}
+
+func (f *Workspaces) Update(ctx context.Context, workspaceID string, opts workspace.UpdateOptions) (*workspace.Workspace, error) {
+	ws, _, err := f.UpdateWithWarnings(ctx, workspaceID, opts)
+	return ws, err
+}
+
+func (f *Workspaces) UpdateWithWarnings(ctx context.Context, workspaceID string, opts workspace.UpdateOptions) (*workspace.Workspace, []workspace.Warning, error) {
+	ws, err := f.update(ctx, workspaceID, func(ws *workspace.Workspace) error {
+		if _, err := ws.Update(opts); err != nil {
+			return err
+		}
+		for id, existing := range f.workspaces {
+			if id != ws.ID && existing.Organization == ws.Organization && existing.Name == ws.Name {
+				return internal.ErrResourceAlreadyExists
+			}
+		}
+		return nil
+	})
+	return ws, nil, err
+}
+
+func (f *Workspaces) Delete(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	f.mu.Lock()
+	ws, ok := f.workspaces[workspaceID]
+	if !ok {
+		f.mu.Unlock()
+		return nil, internal.ErrResourceNotFound
+	}
+	// deletion protection must be explicitly cleared before deleting
+	if ws.DeletionProtected {
+		f.mu.Unlock()
+		return nil, workspace.ErrWorkspaceDeletionProtected
+	}
+	delete(f.workspaces, workspaceID)
+	delete(f.seq, workspaceID)
+	f.mu.Unlock()
+
+	f.listener.notify(ctx, "workspaces", workspaceID, sql.DeleteAction)
+	return ws, nil
+}
+
+// Lock locks the workspace on behalf of a run, or, if runID is nil, on behalf
+// of the user in the context.
+func (f *Workspaces) Lock(ctx context.Context, workspaceID string, runID *string) (*workspace.Workspace, error) {
+	id, kind, err := lockHolder(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return f.update(ctx, workspaceID, func(ws *workspace.Workspace) error {
+		return ws.Enlock(id, kind)
+	})
+}
+
+// Unlock unlocks the workspace on behalf of a run, or, if runID is nil, on
+// behalf of the user in the context.
+func (f *Workspaces) Unlock(ctx context.Context, workspaceID string, runID *string, force bool) (*workspace.Workspace, error) {
+	id, kind, err := lockHolder(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return f.update(ctx, workspaceID, func(ws *workspace.Workspace) error {
+		return ws.Unlock(id, kind, force)
+	})
+}
+
+func (f *Workspaces) Pause(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	return f.update(ctx, workspaceID, func(ws *workspace.Workspace) error {
+		return ws.Pause(internal.CurrentTimestamp(nil))
+	})
+}
+
+func (f *Workspaces) Resume(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	return f.update(ctx, workspaceID, func(ws *workspace.Workspace) error {
+		return ws.Resume()
+	})
+}
+
+func (f *Workspaces) EnableDebugLogging(ctx context.Context, workspaceID string, opts workspace.EnableDebugLoggingOptions) (*workspace.Workspace, error) {
+	return f.update(ctx, workspaceID, func(ws *workspace.Workspace) error {
+		return ws.EnableDebugLogging(internal.CurrentTimestamp(nil), opts)
+	})
+}
+
+func (f *Workspaces) DisableDebugLogging(ctx context.Context, workspaceID string) (*workspace.Workspace, error) {
+	return f.update(ctx, workspaceID, func(ws *workspace.Workspace) error {
+		ws.DebugLogging = nil
+		return nil
+	})
+}
+
+func (f *Workspaces) ListTags(ctx context.Context, organization string, opts workspace.ListTagsOptions) (*resource.Page[*workspace.Tag], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var tags []*workspace.Tag
+	for _, tag := range f.tags {
+		if tag.Organization == organization {
+			tags = append(tags, f.countTag(tag))
+		}
+	}
+	sortTags(tags)
+	return resource.NewPage(tags, opts.PageOptions, nil), nil
+}
+
+func (f *Workspaces) DeleteTags(ctx context.Context, organization string, tagIDs []string) error {
+	var updated []string
+	f.mu.Lock()
+	for _, id := range tagIDs {
+		tag, ok := f.tags[id]
+		if !ok || tag.Organization != organization {
+			continue
+		}
+		delete(f.tags, id)
+		for _, ws := range f.workspaces {
+			if i := slices.Index(ws.Tags, tag.Name); i >= 0 && ws.Organization == organization {
+				ws.Tags = slices.Delete(ws.Tags, i, i+1)
+				updated = append(updated, ws.ID)
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	for _, id := range updated {
+		f.listener.notify(ctx, "workspaces", id, sql.UpdateAction)
+	}
+	return nil
+}
+
+func (f *Workspaces) TagWorkspaces(ctx context.Context, tagID string, workspaceIDs []string) error {
+	for _, id := range workspaceIDs {
+		if err := f.AddTags(ctx, id, []workspace.TagSpec{{ID: tagID}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Workspaces) AddTags(ctx context.Context, workspaceID string, tags []workspace.TagSpec) error {
+	_, err := f.update(ctx, workspaceID, func(ws *workspace.Workspace) error {
+		for _, spec := range tags {
+			if err := spec.Valid(); err != nil {
+				return fmt.Errorf("invalid tag: %w", err)
+			}
+			var tag *workspace.Tag
+			if spec.Name != "" {
+				tag = f.findTagByName(ws.Organization, spec.Name)
+				if tag == nil {
+					tag = &workspace.Tag{
+						ID:           f.ids.New("tag"),
+						Name:         spec.Name,
+						Organization: ws.Organization,
+					}
+					f.tags[tag.ID] = tag
+				}
+			} else {
+				tag = f.tags[spec.ID]
+				if tag == nil || tag.Organization != ws.Organization {
+					return internal.ErrResourceNotFound
+				}
+			}
+			if !slices.Contains(ws.Tags, tag.Name) {
+				ws.Tags = append(ws.Tags, tag.Name)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (f *Workspaces) RemoveTags(ctx context.Context, workspaceID string, tags []workspace.TagSpec) error {
+	_, err := f.update(ctx, workspaceID, func(ws *workspace.Workspace) error {
+		for _, spec := range tags {
+			if err := spec.Valid(); err != nil {
+				return err
+			}
+			var tag *workspace.Tag
+			if spec.Name != "" {
+				// ignore tags that cannot be found when specified by name
+				if tag = f.findTagByName(ws.Organization, spec.Name); tag == nil {
+					continue
+				}
+			} else if tag = f.tags[spec.ID]; tag == nil || tag.Organization != ws.Organization {
+				return internal.ErrResourceNotFound
+			}
+			if i := slices.Index(ws.Tags, tag.Name); i >= 0 {
+				ws.Tags = slices.Delete(ws.Tags, i, i+1)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (f *Workspaces) ListWorkspaceTags(ctx context.Context, workspaceID string, opts workspace.ListWorkspaceTagsOptions) (*resource.Page[*workspace.Tag], error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ws, ok := f.workspaces[workspaceID]
+	if !ok {
+		return nil, internal.ErrResourceNotFound
+	}
+	var tags []*workspace.Tag
+	for _, name := range ws.Tags {
+		if tag := f.findTagByName(ws.Organization, name); tag != nil {
+			tags = append(tags, f.countTag(tag))
+		}
+	}
+	sortTags(tags)
+	return resource.NewPage(tags, opts.PageOptions, nil), nil
+}
+
+// update applies fn to a copy of the workspace, and, if it succeeds, replaces
+// the workspace with the copy.
+func (f *Workspaces) update(ctx context.Context, workspaceID string, fn func(*workspace.Workspace) error) (*workspace.Workspace, error) {
+	f.mu.Lock()
+	ws, ok := f.workspaces[workspaceID]
+	if !ok {
+		f.mu.Unlock()
+		return nil, internal.ErrResourceNotFound
+	}
+	updated := copyWorkspace(ws)
+	if err := fn(updated); err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	updated.UpdatedAt = internal.CurrentTimestamp(nil)
+	f.put(updated)
+	f.mu.Unlock()
+
+	f.listener.notify(ctx, "workspaces", workspaceID, sql.UpdateAction)
+	return copyWorkspace(updated), nil
+}
+
+// idsByName returns the IDs of workspaces with the name, across all
+// organizations.
+func (f *Workspaces) idsByName(name string) (ids []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, ws := range f.workspaces {
+		if ws.Name == name {
+			ids = append(ids, ws.ID)
+		}
+	}
+	return ids
+}
+
+// idsByPool returns the IDs of workspaces assigned to the agent pool, in
+// order.
+func (f *Workspaces) idsByPool(poolID string) (ids []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, ws := range f.workspaces {
+		if ws.AgentPoolID != nil && *ws.AgentPoolID == poolID {
+			ids = append(ids, ws.ID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// put stores the workspace. The caller must hold the mutex.
+func (f *Workspaces) put(ws *workspace.Workspace) {
+	f.lastSeq++
+	f.seq[ws.ID] = f.lastSeq
+	f.workspaces[ws.ID] = ws
+}
+
+// findTagByName finds an organization's tag by name, returning nil if it does
+// not exist. The caller must hold the mutex.
+func (f *Workspaces) findTagByName(organization, name string) *workspace.Tag {
+	for _, tag := range f.tags {
+		if tag.Organization == organization && tag.Name == name {
+			return tag
+		}
+	}
+	return nil
+}
+
+// countTag returns a copy of the tag with the number of workspaces it is
+// attached to. The caller must hold the mutex.
+func (f *Workspaces) countTag(tag *workspace.Tag) *workspace.Tag {
+	counted := *tag
+	for _, ws := range f.workspaces {
+		if ws.Organization == tag.Organization && slices.Contains(ws.Tags, tag.Name) {
+			counted.InstanceCount++
+		}
+	}
+	return &counted
+}
+
+// lockHolder determines on whose behalf a workspace is locked or unlocked: a
+// run if runID is non-nil, otherwise the user in the context.
+func lockHolder(ctx context.Context, runID *string) (string, workspace.LockKind, error) {
+	if runID != nil {
+		return *runID, workspace.RunLock, nil
+	}
+	subject, err := internal.SubjectFromContext(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	u, ok := subject.(*user.User)
+	if !ok {
+		return "", 0, errors.New("only a run or a user can lock or unlock a workspace")
+	}
+	return u.Username, workspace.UserLock, nil
+}
+
+func copyWorkspace(ws *workspace.Workspace) *workspace.Workspace {
+	cp := *ws
+	cp.Tags = slices.Clone(ws.Tags)
+	return &cp
+}
+
+func containsAll(s, subset []string) bool {
+	for _, v := range subset {
+		if !slices.Contains(s, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortTags(tags []*workspace.Tag) {
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+}